@@ -0,0 +1,103 @@
+// Package acceptance runs a ticket's executable acceptance assertions (see
+// ticket.Assertion) after coding, so completion is decided by the project's own
+// commands rather than by the agent's report alone.
+package acceptance
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// DefaultTimeout bounds an assertion without its own TimeoutSec.
+const DefaultTimeout = 5 * time.Minute
+
+// maxOutputChars caps the output kept on a result; the tail is kept.
+const maxOutputChars = 2000
+
+// Run executes the assertions in order in dir and returns one result per assertion.
+// Every assertion runs even when an earlier one fails, so all failures are reported.
+func Run(ctx context.Context, dir string, assertions []ticket.Assertion) []ticket.AssertionResult {
+	results := make([]ticket.AssertionResult, 0, len(assertions))
+	for _, a := range assertions {
+		results = append(results, runOne(ctx, dir, a))
+	}
+	return results
+}
+
+func runOne(ctx context.Context, dir string, a ticket.Assertion) ticket.AssertionResult {
+	res := ticket.AssertionResult{Command: a.Command, RanAt: time.Now()}
+
+	timeout := DefaultTimeout
+	if a.TimeoutSec > 0 {
+		timeout = time.Duration(a.TimeoutSec) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := shellCommand(ctx, a.Command)
+	cmd.Dir = dir
+	// Children of the shell may keep the output pipes open after a timeout kill.
+	cmd.WaitDelay = time.Second
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	res.Output = tail(out.String(), maxOutputChars)
+
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		res.ExitCode = -1
+		res.Error = fmt.Sprintf("timed out after %s", timeout)
+		return res
+	case errors.As(err, &exitErr):
+		res.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		res.ExitCode = -1
+		res.Error = err.Error()
+		return res
+	}
+
+	if res.ExitCode != a.ExitCode {
+		res.Error = fmt.Sprintf("exit code %d, want %d", res.ExitCode, a.ExitCode)
+		return res
+	}
+	if a.OutputPattern != "" {
+		re, err := regexp.Compile(a.OutputPattern)
+		if err != nil {
+			res.Error = fmt.Sprintf("invalid output_pattern: %v", err)
+			return res
+		}
+		if !re.MatchString(out.String()) {
+			res.Error = fmt.Sprintf("output does not match /%s/", a.OutputPattern)
+			return res
+		}
+	}
+	res.Passed = true
+	return res
+}
+
+// shellCommand runs command through the platform shell.
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+// tail returns the last n runes of s, prefixed with "..." when cut.
+func tail(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return "..." + string(r[len(r)-n:])
+}
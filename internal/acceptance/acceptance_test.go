@@ -0,0 +1,74 @@
+package acceptance
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("assertions use sh syntax")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "marker.txt"), []byte("ok"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		assertion ticket.Assertion
+		passed    bool
+		exitCode  int
+		errSubstr string
+	}{
+		{"exit 0", ticket.Assertion{Command: "test -f marker.txt"}, true, 0, ""},
+		{"unexpected exit", ticket.Assertion{Command: "exit 3"}, false, 3, "exit code 3, want 0"},
+		{"expected non-zero exit", ticket.Assertion{Command: "exit 2", ExitCode: 2}, true, 2, ""},
+		{"output matches", ticket.Assertion{Command: "echo 'PASS: 12 tests'", OutputPattern: `PASS: \d+`}, true, 0, ""},
+		{"output mismatch", ticket.Assertion{Command: "echo FAIL", OutputPattern: "PASS"}, false, 0, "does not match"},
+		{"stderr counts as output", ticket.Assertion{Command: "echo oops >&2", OutputPattern: "oops"}, true, 0, ""},
+		{"timeout", ticket.Assertion{Command: "sleep 5", TimeoutSec: 1}, false, -1, "timed out"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Run(context.Background(), dir, []ticket.Assertion{tt.assertion})[0]
+			if res.Passed != tt.passed || res.ExitCode != tt.exitCode {
+				t.Errorf("result = %+v, want passed=%v exit=%d", res, tt.passed, tt.exitCode)
+			}
+			if tt.errSubstr != "" && !strings.Contains(res.Error, tt.errSubstr) {
+				t.Errorf("Error = %q, want it to contain %q", res.Error, tt.errSubstr)
+			}
+			if res.Command != tt.assertion.Command || res.RanAt.IsZero() {
+				t.Errorf("result should record command and time, got %+v", res)
+			}
+		})
+	}
+}
+
+func TestRun_AllAssertionsRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("assertions use sh syntax")
+	}
+	results := Run(context.Background(), t.TempDir(), []ticket.Assertion{{Command: "false"}, {Command: "true"}})
+	if len(results) != 2 || results[0].Passed || !results[1].Passed {
+		t.Errorf("Run() = %+v, want [failed, passed]", results)
+	}
+	if failed := ticket.FailedAssertions(results); len(failed) != 1 {
+		t.Errorf("FailedAssertions() = %+v, want one", failed)
+	}
+}
+
+func TestTail(t *testing.T) {
+	if got := tail("abcdef", 3); got != "...def" {
+		t.Errorf("tail() = %q, want ...def", got)
+	}
+	if got := tail("abc", 3); got != "abc" {
+		t.Errorf("tail() = %q, want abc", got)
+	}
+}
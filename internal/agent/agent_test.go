@@ -1,9 +1,13 @@
 package agent
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
 	"github.com/anthropic/agent-orchestrator/internal/jsonutil"
 	"github.com/anthropic/agent-orchestrator/internal/ticket"
 )
@@ -59,6 +63,18 @@ func TestCodingAgent_buildPrompt(t *testing.T) {
 				"需要建立的檔案",
 			},
 		},
+		{
+			name: "ticket with operator notes",
+			ticket: &ticket.Ticket{
+				ID:    "TEST-004",
+				Title: "Steered",
+				Notes: []ticket.Note{{Author: "alice", Text: "keep the public API", CreatedAt: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}},
+			},
+			wantContains: []string{
+				"操作者備註",
+				"- [2024-06-01 12:00 alice] keep the public API",
+			},
+		},
 		{
 			name: "ticket without acceptance criteria",
 			ticket: &ticket.Ticket{
@@ -72,6 +88,7 @@ func TestCodingAgent_buildPrompt(t *testing.T) {
 			},
 			wantNotContain: []string{
 				"驗收標準",
+				"操作者備註",
 			},
 		},
 	}
@@ -123,6 +140,17 @@ func TestReviewAgent_buildReviewPrompt(t *testing.T) {
 	}
 }
 
+func TestReviewAgent_buildReviewPrompt_Notes(t *testing.T) {
+	ra := NewReviewAgent(nil, "/test/project")
+	if strings.Contains(ra.buildReviewPrompt([]string{"a.go"}), "操作者備註") {
+		t.Error("buildReviewPrompt() should omit the notes section without notes")
+	}
+	ra.SetNotes([]string{"T-1: keep the public API"})
+	if prompt := ra.buildReviewPrompt([]string{"a.go"}); !strings.Contains(prompt, "- T-1: keep the public API") {
+		t.Errorf("buildReviewPrompt() should list the notes, got:\n%s", prompt)
+	}
+}
+
 func TestReviewAgent_parseReviewResult(t *testing.T) {
 	ra := NewReviewAgent(nil, "/test/project")
 
@@ -329,6 +357,23 @@ func TestAnalyzeAgent_buildAnalyzePrompt(t *testing.T) {
 	}
 }
 
+func TestAnalyzeAgent_buildAnalyzePrompt_files(t *testing.T) {
+	aa := NewAnalyzeAgent(nil, "/test/project")
+	header := strings.TrimSuffix(i18n.AgentAnalyzeFilesOnly, "\n")
+
+	if strings.Contains(aa.buildAnalyzePrompt(AllScopes()), header) {
+		t.Error("buildAnalyzePrompt() should not restrict files by default")
+	}
+
+	aa.SetFiles([]string{"cmd/main.go", "internal/x.go"})
+	prompt := aa.buildAnalyzePrompt(AllScopes())
+	for _, want := range []string{header, "- cmd/main.go", "- internal/x.go"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("buildAnalyzePrompt() should contain %q", want)
+		}
+	}
+}
+
 func TestAllScopes(t *testing.T) {
 	scope := AllScopes()
 
@@ -486,6 +531,24 @@ func TestTestAgent_buildTestPrompt(t *testing.T) {
 	}
 }
 
+func TestTestAgent_buildTestPrompt_detected(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "yarn.lock"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	prompt := NewTestAgent(nil, dir).buildTestPrompt()
+	if !strings.Contains(prompt, "Node.js: yarn test") {
+		t.Errorf("buildTestPrompt() should contain the detected test command, got:\n%s", prompt)
+	}
+	if strings.Contains(prompt, "go test") {
+		t.Error("buildTestPrompt() should only list detected project types")
+	}
+}
+
 func TestTestAgent_parseTestResult(t *testing.T) {
 	ta := NewTestAgent(nil, "/test/project")
 
@@ -558,6 +621,42 @@ FAIL	github.com/foo/qux	0.200s`,
 			wantSkipped: 0,
 			wantSummary: "2 passed, 2 failed",
 		},
+		{
+			name: "jest summary",
+			output: `Test Suites: 1 failed, 2 passed, 3 total
+Tests:       1 failed, 1 skipped, 1 todo, 10 passed, 13 total`,
+			wantPassed:  10,
+			wantFailed:  1,
+			wantSkipped: 2,
+			wantSummary: "10 passed, 1 failed, 2 skipped",
+		},
+		{
+			name: "vitest summary",
+			output: ` Test Files  1 failed | 2 passed (3)
+      Tests  2 failed | 8 passed (10)`,
+			wantPassed:  8,
+			wantFailed:  2,
+			wantSkipped: 0,
+			wantSummary: "8 passed, 2 failed",
+		},
+		{
+			name: "cargo test results are summed",
+			output: `test result: FAILED. 3 passed; 1 failed; 2 ignored; 0 measured; 0 filtered out; finished in 0.01s
+test result: ok. 2 passed; 0 failed; 0 ignored; 0 measured; 0 filtered out; finished in 0.00s`,
+			wantPassed:  5,
+			wantFailed:  1,
+			wantSkipped: 2,
+			wantSummary: "5 passed, 1 failed, 2 skipped",
+		},
+		{
+			name: "dotnet test assemblies are summed",
+			output: `Failed!  - Failed:     1, Passed:    12, Skipped:     1, Total:    14, Duration: 1 s - Api.Tests.dll (net8.0)
+Passed!  - Failed:     0, Passed:     4, Skipped:     0, Total:     4, Duration: 80 ms - Core.Tests.dll (net8.0)`,
+			wantPassed:  16,
+			wantFailed:  1,
+			wantSkipped: 1,
+			wantSummary: "16 passed, 1 failed, 1 skipped",
+		},
 	}
 
 	for _, tt := range tests {
@@ -579,10 +678,55 @@ FAIL	github.com/foo/qux	0.200s`,
 	}
 }
 
+func TestParseCoverage(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   float64 // -1 for no coverage
+	}{
+		{name: "no coverage", output: "ok  \tgithub.com/foo/bar\t0.123s", want: -1},
+		{
+			name:   "go test -cover packages are averaged",
+			output: "ok  \tgithub.com/foo/bar\t0.1s\tcoverage: 80.0% of statements\nok  \tgithub.com/foo/baz\t0.1s\tcoverage: 60.0% of statements",
+			want:   70,
+		},
+		{
+			name:   "go tool cover -func total",
+			output: "coverage: 90.0% of statements\ngithub.com/foo/bar/a.go:3:\tA\t100.0%\ntotal:\t\t\t(statements)\t72.4%",
+			want:   72.4,
+		},
+		{
+			name:   "pytest-cov TOTAL row",
+			output: "Name    Stmts   Miss  Cover\n---\napp.py     40      4    90%\nTOTAL      120     18    85%\n==== 5 passed in 0.42s ====",
+			want:   85,
+		},
+		{
+			name:   "total line from the prompt wins",
+			output: "coverage: 50.0% of statements\n- 總 coverage: 81.5%",
+			want:   81.5,
+		},
+		{name: "english total line", output: "Total coverage: 64%", want: 64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseCoverage(tt.output)
+			if tt.want < 0 {
+				if got != nil {
+					t.Errorf("parseCoverage() = %v, want nil", *got)
+				}
+				return
+			}
+			if got == nil || *got != tt.want {
+				t.Errorf("parseCoverage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestPlanningAgent_buildPlanningPrompt(t *testing.T) {
 	pa := NewPlanningAgent(nil, "/test/project", "/test/tickets")
 
-	prompt := pa.buildPlanningPrompt("# Milestone content", "/test/milestone.md", "/test/output.json")
+	prompt := pa.buildPlanningPrompt([]string{"/test/milestone.md"}, "/test/output.json")
 
 	expectedContents := []string{
 		"/test/milestone.md",
@@ -683,6 +827,25 @@ func TestPlanningAgent_mapToTicket(t *testing.T) {
 	}
 }
 
+func TestPlanningAgent_mapToTicket_SoftDependencies(t *testing.T) {
+	pa := NewPlanningAgent(nil, "/test/project", "/test/tickets")
+	result := pa.mapToTicket(map[string]interface{}{
+		"id":                "T3",
+		"title":             "Docs",
+		"dependencies":      []interface{}{"T1"},
+		"soft_dependencies": []interface{}{"T2"},
+	})
+	if result == nil {
+		t.Fatal("mapToTicket() = nil, want non-nil")
+	}
+	if len(result.Dependencies) != 1 || result.Dependencies[0] != "T1" {
+		t.Errorf("mapToTicket().Dependencies = %v, want [T1]", result.Dependencies)
+	}
+	if len(result.SoftDependencies) != 1 || result.SoftDependencies[0] != "T2" {
+		t.Errorf("mapToTicket().SoftDependencies = %v, want [T2]", result.SoftDependencies)
+	}
+}
+
 func TestInitAgent_parseQuestions(t *testing.T) {
 	ia := NewInitAgent(nil, "/test/project", "/test/docs")
 
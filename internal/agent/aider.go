@@ -0,0 +1,127 @@
+package agent
+
+import (
+	"strings"
+)
+
+// AiderBackend drives aider ("aider --message <prompt>"). aider has no JSON output, so
+// ParseStream reports applied edits as write tool calls and ParseOutput strips the
+// banner and renders its SEARCH/REPLACE edit blocks as unified-diff hunks.
+//
+// Commits are left to the orchestrator (--no-auto-commits). Force maps to --yes-always;
+// without it aider runs with --dry-run and only proposes edits.
+type AiderBackend struct{}
+
+func (AiderBackend) Name() string           { return "aider" }
+func (AiderBackend) DefaultCommand() string { return "aider" }
+
+func (AiderBackend) Detect(command string) bool {
+	return commandName(command) == "aider"
+}
+
+// BuildArgs passes the context files as files to add to the chat.
+func (AiderBackend) BuildArgs(inv Invocation) []string {
+	args := []string{"--message", inv.Prompt, "--no-auto-commits", "--no-pretty", "--no-check-update"}
+	if inv.Force {
+		args = append(args, "--yes-always")
+	} else {
+		args = append(args, "--dry-run")
+	}
+	if inv.Model != "" {
+		args = append(args, "--model", inv.Model)
+	}
+	return append(args, inv.ContextFiles...)
+}
+
+// aiderAppliedPrefix starts the line aider prints after writing an edit.
+const aiderAppliedPrefix = "Applied edit to "
+
+func (AiderBackend) ParseStream(line string) []StreamEvent {
+	path, ok := strings.CutPrefix(strings.TrimSpace(line), aiderAppliedPrefix)
+	if !ok {
+		return nil
+	}
+	data := map[string]interface{}{
+		"type":      "tool_call",
+		"subtype":   "started",
+		"tool_call": map[string]interface{}{"writeToolCall": map[string]interface{}{"args": map[string]interface{}{"path": path}}},
+	}
+	return []StreamEvent{{Type: "tool_call", Subtype: "started", Data: data, Raw: line}}
+}
+
+// aiderBannerPrefixes start the status lines aider prints around the model's answer.
+var aiderBannerPrefixes = []string{
+	"Aider v", "Model:", "Main model:", "Weak model:", "Editor model:", "Git repo:", "Repo-map:",
+	"Use /help", "Tokens:", "Cost:", "https://aider.chat/",
+}
+
+// ParseOutput drops aider's banner and status lines and rewrites each SEARCH/REPLACE
+// block as a diff of its file:
+//
+//	--- a/main.go
+//	+++ b/main.go
+//	@@
+//	-old line
+//	+new line
+func (AiderBackend) ParseOutput(output string) string {
+	lines := strings.Split(strings.ReplaceAll(output, "\r\n", "\n"), "\n")
+	var out []string
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if isAiderBanner(trimmed) {
+			continue
+		}
+		if !strings.HasPrefix(trimmed, "<<<<<<< SEARCH") {
+			out = append(out, line)
+			continue
+		}
+
+		// The file name precedes the block, possibly followed by an opening fence.
+		path := ""
+		if n := len(out); n > 0 && strings.HasPrefix(strings.TrimSpace(out[n-1]), "```") {
+			out = out[:n-1]
+		}
+		if n := len(out); n > 0 && strings.TrimSpace(out[n-1]) != "" {
+			path = strings.TrimSpace(out[n-1])
+			out = out[:n-1]
+		}
+		var search, replace []string
+		inReplace := false
+		for i++; i < len(lines); i++ {
+			t := strings.TrimSpace(lines[i])
+			if !inReplace && t == "=======" {
+				inReplace = true
+				continue
+			}
+			if strings.HasPrefix(t, ">>>>>>> REPLACE") {
+				break
+			}
+			if inReplace {
+				replace = append(replace, lines[i])
+			} else {
+				search = append(search, lines[i])
+			}
+		}
+		if i+1 < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i+1]), "```") {
+			i++
+		}
+		out = append(out, "--- a/"+path, "+++ b/"+path, "@@")
+		for _, l := range search {
+			out = append(out, "-"+l)
+		}
+		for _, l := range replace {
+			out = append(out, "+"+l)
+		}
+	}
+	return strings.TrimSpace(strings.Join(out, "\n")) + "\n"
+}
+
+func isAiderBanner(line string) bool {
+	for _, p := range aiderBannerPrefixes {
+		if strings.HasPrefix(line, p) {
+			return true
+		}
+	}
+	return strings.HasPrefix(line, "Added ") && strings.HasSuffix(line, " to the chat.")
+}
@@ -0,0 +1,95 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+const aiderSampleOutput = "Aider v0.50.1\n" +
+	"Model: claude-3-5-sonnet-20240620 with diff edit format\n" +
+	"Git repo: .git with 25 files\n" +
+	"Repo-map: using 1024 tokens\n" +
+	"Added main.go to the chat.\n" +
+	"Use /help <question> for help, run \"aider --help\" to see cmd line args\n" +
+	"\n" +
+	"I'll add the greeting.\n" +
+	"\n" +
+	"main.go\n" +
+	"```go\n" +
+	"<<<<<<< SEARCH\n" +
+	"func main() {}\n" +
+	"=======\n" +
+	"func main() {\n" +
+	"\tfmt.Println(\"hi\")\n" +
+	"}\n" +
+	">>>>>>> REPLACE\n" +
+	"```\n" +
+	"\n" +
+	"Tokens: 1.2k sent, 100 received. Cost: $0.01 message, $0.01 session.\n" +
+	"Applied edit to main.go\n"
+
+func TestAiderBackend_ParseOutput(t *testing.T) {
+	got := AiderBackend{}.ParseOutput(aiderSampleOutput)
+	want := "I'll add the greeting.\n" +
+		"\n" +
+		"--- a/main.go\n" +
+		"+++ b/main.go\n" +
+		"@@\n" +
+		"-func main() {}\n" +
+		"+func main() {\n" +
+		"+\tfmt.Println(\"hi\")\n" +
+		"+}\n" +
+		"\n" +
+		"Applied edit to main.go\n"
+	if got != want {
+		t.Errorf("ParseOutput() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestAiderBackend_ParseStream(t *testing.T) {
+	b := AiderBackend{}
+	if events := b.ParseStream("I'll add the greeting."); events != nil {
+		t.Errorf("ParseStream(text) = %v, want nil", events)
+	}
+	events := b.ParseStream("Applied edit to pkg/main.go")
+	if len(events) != 1 || events[0].Type != "tool_call" || events[0].Subtype != "started" {
+		t.Fatalf("ParseStream() = %+v, want a started tool call", events)
+	}
+	call := events[0].Data["tool_call"].(map[string]interface{})["writeToolCall"].(map[string]interface{})
+	if path := call["args"].(map[string]interface{})["path"]; path != "pkg/main.go" {
+		t.Errorf("path = %v, want pkg/main.go", path)
+	}
+}
+
+func TestCaller_Call_AiderBackend(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as a fake aider")
+	}
+	dir := t.TempDir()
+	script := filepath.Join(dir, "aider")
+	// The fake aider echoes its working directory and the sample output.
+	body := "#!/bin/sh\npwd\ncat <<'EOF'\n" + aiderSampleOutput + "EOF\n"
+	if err := os.WriteFile(script, []byte(body), 0755); err != nil {
+		t.Fatal(err)
+	}
+	work := t.TempDir()
+	caller := NewCaller(script, true, "text", "")
+	if caller.backend().Name() != "aider" {
+		t.Fatalf("backend = %s, want aider detected from the command", caller.backend().Name())
+	}
+	result, err := caller.Call(context.Background(), "add a greeting", WithWorkingDir(work), WithTimeout(time.Minute))
+	if err != nil || !result.Success {
+		t.Fatalf("Call() = %+v, %v", result, err)
+	}
+	if !strings.Contains(result.Output, "+++ b/main.go") || strings.Contains(result.Output, "Aider v") {
+		t.Errorf("Output should hold the diff without the banner, got:\n%s", result.Output)
+	}
+	if real, _ := filepath.EvalSymlinks(work); !strings.Contains(result.Output, real) && !strings.Contains(result.Output, work) {
+		t.Errorf("aider should run in the working dir %s, got:\n%s", work, result.Output)
+	}
+}
@@ -0,0 +1,503 @@
+package agent
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+)
+
+// Executor is implemented by backends that run a call in-process instead of through
+// an agent CLI. The Caller hands it the invocation and an emit function for stream
+// events; the returned Result follows the same contract as a CLI call.
+type Executor interface {
+	// Available reports whether calls can be made (e.g. credentials are configured).
+	Available() bool
+	// Execute performs the call in workingDir, passing each event to emit as it occurs.
+	Execute(ctx context.Context, inv Invocation, workingDir string, emit func(StreamEvent)) (*Result, error)
+}
+
+// BackendAPI names the backend that calls the Anthropic Messages API directly.
+const BackendAPI = "api"
+
+// Defaults of the API backend.
+const (
+	DefaultAPIURL       = "https://api.anthropic.com"
+	DefaultAPIModel     = "claude-sonnet-4-20250514"
+	DefaultAPIMaxTokens = 8192
+	DefaultAPIMaxTurns  = 50
+)
+
+// apiVersion is the anthropic-version header sent with every request.
+const apiVersion = "2023-06-01"
+
+// maxToolOutput caps what a tool returns to the model, keeping the conversation within
+// the context window.
+const maxToolOutput = 100 << 10
+
+// APIBackend calls the Anthropic Messages API with tools for reading and writing files
+// and running shell commands in the working directory, so no agent CLI needs to be
+// installed (e.g. in CI containers). Writing and shell access are only offered when
+// Invocation.Force is set, mirroring the CLIs' --force.
+type APIBackend struct {
+	APIKey    string
+	BaseURL   string
+	Model     string
+	MaxTokens int
+	// MaxTurns bounds the model/tool round trips of one call.
+	MaxTurns   int
+	HTTPClient *http.Client
+}
+
+// NewAPIBackend returns an API backend; empty baseURL and model select the defaults.
+func NewAPIBackend(apiKey, baseURL, model string) APIBackend {
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	}
+	if model == "" {
+		model = DefaultAPIModel
+	}
+	return APIBackend{
+		APIKey:     apiKey,
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		Model:      model,
+		MaxTokens:  DefaultAPIMaxTokens,
+		MaxTurns:   DefaultAPIMaxTurns,
+		HTTPClient: &http.Client{},
+	}
+}
+
+func (APIBackend) Name() string                  { return BackendAPI }
+func (APIBackend) DefaultCommand() string        { return BackendAPI }
+func (APIBackend) Detect(string) bool            { return false }
+func (APIBackend) BuildArgs(Invocation) []string { return nil }
+
+func (APIBackend) ParseStream(line string) []StreamEvent {
+	if event := parseStreamEvent(line); event != nil {
+		return []StreamEvent{*event}
+	}
+	return nil
+}
+
+// Available reports whether an API key is configured.
+func (b APIBackend) Available() bool {
+	return b.APIKey != ""
+}
+
+// apiContent is a content block of a Messages API message.
+type apiContent struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Input     json.RawMessage `json:"input,omitempty"`
+	ToolUseID string          `json:"tool_use_id,omitempty"`
+	Content   string          `json:"content,omitempty"`
+	IsError   bool            `json:"is_error,omitempty"`
+}
+
+type apiMessage struct {
+	Role    string       `json:"role"`
+	Content []apiContent `json:"content"`
+}
+
+type apiTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"input_schema"`
+}
+
+type apiUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// Tool names offered to the model.
+const (
+	apiToolRead  = "read_file"
+	apiToolWrite = "write_file"
+	apiToolShell = "run_command"
+)
+
+// apiTools returns the tools offered for an invocation; force adds write and shell.
+func apiTools(force bool) []apiTool {
+	str := map[string]any{"type": "string"}
+	tools := []apiTool{{
+		Name:        apiToolRead,
+		Description: "Read a file. The path is relative to the working directory.",
+		InputSchema: map[string]any{"type": "object", "properties": map[string]any{"path": str}, "required": []string{"path"}},
+	}}
+	if !force {
+		return tools
+	}
+	return append(tools,
+		apiTool{
+			Name:        apiToolWrite,
+			Description: "Create or overwrite a file with the given content. The path is relative to the working directory; parent directories are created.",
+			InputSchema: map[string]any{"type": "object", "properties": map[string]any{"path": str, "content": str}, "required": []string{"path", "content"}},
+		},
+		apiTool{
+			Name:        apiToolShell,
+			Description: "Run a shell command in the working directory and return its combined output and exit code.",
+			InputSchema: map[string]any{"type": "object", "properties": map[string]any{"command": str}, "required": []string{"command"}},
+		},
+	)
+}
+
+// Execute runs the conversation: the prompt is sent, tool uses are executed and their
+// results returned until the model ends its turn or MaxTurns is reached. Events use
+// the Cursor Agent schema (system init, assistant, tool_call started/completed, result).
+// API and transport failures yield an unsuccessful Result rather than an error; those
+// worth retrying exit with ExitCodeTransient.
+func (b APIBackend) Execute(ctx context.Context, inv Invocation, workingDir string, emit func(StreamEvent)) (*Result, error) {
+	start := time.Now()
+	if inv.Model != "" {
+		b.Model = inv.Model
+	}
+	if workingDir == "" {
+		workingDir, _ = os.Getwd()
+	}
+	result := &Result{StreamEvents: make([]StreamEvent, 0)}
+	var lines strings.Builder
+	send := func(data map[string]any) {
+		// Round-trip through JSON so Data holds plain maps, as for parsed CLI output.
+		raw, _ := json.Marshal(data)
+		_ = json.Unmarshal(raw, &data)
+		event := StreamEvent{Data: data, Raw: string(raw)}
+		event.Type, _ = data["type"].(string)
+		event.Subtype, _ = data["subtype"].(string)
+		lines.WriteString(event.Raw + "\n")
+		result.StreamEvents = append(result.StreamEvents, event)
+		if emit != nil {
+			emit(event)
+		}
+	}
+	send(map[string]any{"type": "system", "subtype": "init", "model": b.Model, "cwd": workingDir})
+
+	messages := []apiMessage{{Role: "user", Content: []apiContent{{Type: "text", Text: inv.Prompt}}}}
+	tools := apiTools(inv.Force)
+	var usage apiUsage
+	var final string
+	var failure error
+	maxTurns := b.MaxTurns
+	if maxTurns <= 0 {
+		maxTurns = DefaultAPIMaxTurns
+	}
+
+	for turn := 0; ; turn++ {
+		if turn == maxTurns {
+			failure = fmt.Errorf("reached the maximum of %d turns", maxTurns)
+			break
+		}
+		msg, stop, u, err := b.stream(ctx, workingDir, messages, tools)
+		usage.InputTokens += u.InputTokens
+		usage.OutputTokens += u.OutputTokens
+		if err != nil {
+			failure = err
+			break
+		}
+		messages = append(messages, msg)
+		send(map[string]any{"type": "assistant", "message": msg})
+		if text := messageText(msg); text != "" {
+			final = text
+		}
+		if stop != "tool_use" {
+			break
+		}
+
+		var results []apiContent
+		for _, block := range msg.Content {
+			if block.Type != "tool_use" {
+				continue
+			}
+			call := toolCallData(block)
+			send(map[string]any{"type": "tool_call", "subtype": "started", "call_id": block.ID, "tool_call": call})
+			out, isErr := runAPITool(ctx, workingDir, inv.Force, block)
+			send(map[string]any{"type": "tool_call", "subtype": "completed", "call_id": block.ID, "tool_call": call, "is_error": isErr})
+			results = append(results, apiContent{Type: "tool_result", ToolUseID: block.ID, Content: out, IsError: isErr})
+		}
+		messages = append(messages, apiMessage{Role: "user", Content: results})
+	}
+
+	subtype := "success"
+	if failure != nil {
+		subtype = "error"
+		result.Error = failure.Error()
+		result.ExitCode = 1
+		if errors.As(failure, new(transientError)) {
+			result.ExitCode = ExitCodeTransient
+		}
+	} else {
+		result.Success = true
+	}
+	send(map[string]any{
+		"type": "result", "subtype": subtype, "is_error": failure != nil, "result": final,
+		"duration_ms": float64(time.Since(start).Milliseconds()),
+		"usage":       map[string]any{"input_tokens": usage.InputTokens, "output_tokens": usage.OutputTokens},
+	})
+
+	switch inv.OutputFormat {
+	case "stream-json":
+		result.Output = lines.String()
+	case "json":
+		result.Output = result.StreamEvents[len(result.StreamEvents)-1].Raw
+	default:
+		result.Output = final
+	}
+	return result, nil
+}
+
+// transientError marks an API failure expected to pass on retry: transport errors,
+// rate limits and server errors. Execute reports it with ExitCodeTransient.
+type transientError struct{ error }
+
+func (e transientError) Unwrap() error { return e.error }
+
+// transientAPIErrors are the stream error types reported as transient.
+var transientAPIErrors = map[string]bool{"overloaded_error": true, "rate_limit_error": true, "api_error": true}
+
+// messageText returns the text blocks of msg.
+func messageText(msg apiMessage) string {
+	var parts []string
+	for _, c := range msg.Content {
+		if c.Type == "text" && strings.TrimSpace(c.Text) != "" {
+			parts = append(parts, c.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// toolCallData describes a tool use in the Cursor schema (writeToolCall, readToolCall,
+// shellToolCall) so handleStreamEvent reports it like a CLI tool call.
+func toolCallData(block apiContent) map[string]any {
+	var input map[string]any
+	_ = json.Unmarshal(block.Input, &input)
+	switch block.Name {
+	case apiToolRead:
+		return map[string]any{"readToolCall": map[string]any{"args": map[string]any{"path": input["path"]}}}
+	case apiToolWrite:
+		return map[string]any{"writeToolCall": map[string]any{"args": map[string]any{"path": input["path"]}}}
+	case apiToolShell:
+		return map[string]any{"shellToolCall": map[string]any{"args": map[string]any{"command": input["command"]}}}
+	}
+	return map[string]any{block.Name: map[string]any{"args": input}}
+}
+
+// runAPITool executes a tool use and returns its output and whether it failed.
+func runAPITool(ctx context.Context, dir string, force bool, block apiContent) (string, bool) {
+	var input struct {
+		Path    string `json:"path"`
+		Content string `json:"content"`
+		Command string `json:"command"`
+	}
+	if err := json.Unmarshal(block.Input, &input); err != nil {
+		return "invalid tool input: " + err.Error(), true
+	}
+	if block.Name != apiToolRead && !force {
+		return "tool not permitted without agent_force", true
+	}
+	switch block.Name {
+	case apiToolRead:
+		path, err := resolveToolPath(dir, input.Path)
+		if err != nil {
+			return err.Error(), true
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err.Error(), true
+		}
+		return truncateToolOutput(string(data)), false
+	case apiToolWrite:
+		path, err := resolveToolPath(dir, input.Path)
+		if err != nil {
+			return err.Error(), true
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err.Error(), true
+		}
+		if err := os.WriteFile(path, []byte(input.Content), 0644); err != nil {
+			return err.Error(), true
+		}
+		return fmt.Sprintf("wrote %d bytes to %s", len(input.Content), input.Path), false
+	case apiToolShell:
+		shell, flag := "sh", "-c"
+		if runtime.GOOS == "windows" {
+			shell, flag = "cmd", "/C"
+		}
+		cmd := exec.CommandContext(ctx, shell, flag, input.Command)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		text := truncateToolOutput(string(out))
+		if err != nil {
+			return fmt.Sprintf("%s\n[%v]", text, err), true
+		}
+		return text, false
+	}
+	return "unknown tool " + block.Name, true
+}
+
+// resolveToolPath resolves path against dir and rejects paths outside dir.
+func resolveToolPath(dir, path string) (string, error) {
+	if path == "" {
+		return "", fmt.Errorf("path is required")
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	path = filepath.Clean(path)
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s is outside the working directory", path)
+	}
+	return path, nil
+}
+
+// truncateToolOutput keeps the end of long output, where errors usually are.
+func truncateToolOutput(s string) string {
+	if len(s) <= maxToolOutput {
+		return s
+	}
+	return "[truncated]\n" + s[len(s)-maxToolOutput:]
+}
+
+// stream sends one Messages API request with streaming enabled and assembles the
+// assistant message from its server-sent events. It returns the message, its stop
+// reason and the token usage.
+func (b APIBackend) stream(ctx context.Context, dir string, messages []apiMessage, tools []apiTool) (apiMessage, string, apiUsage, error) {
+	msg := apiMessage{Role: "assistant"}
+	var usage apiUsage
+	payload, err := json.Marshal(map[string]any{
+		"model":      b.Model,
+		"max_tokens": b.MaxTokens,
+		"system":     fmt.Sprintf(i18n.AgentAPISystemPrompt, dir),
+		"messages":   messages,
+		"tools":      tools,
+		"stream":     true,
+	})
+	if err != nil {
+		return msg, "", usage, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.BaseURL+"/v1/messages", bytes.NewReader(payload))
+	if err != nil {
+		return msg, "", usage, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", b.APIKey)
+	req.Header.Set("anthropic-version", apiVersion)
+	client := b.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return msg, "", usage, transientError{fmt.Errorf("messages request: %w", err)}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		err := fmt.Errorf("messages request: unexpected status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			err = transientError{err}
+		}
+		return msg, "", usage, err
+	}
+
+	var stop string
+	var partialJSON []string
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		var ev struct {
+			Type    string `json:"type"`
+			Index   int    `json:"index"`
+			Message struct {
+				Usage apiUsage `json:"usage"`
+			} `json:"message"`
+			ContentBlock apiContent `json:"content_block"`
+			Delta        struct {
+				Type        string `json:"type"`
+				Text        string `json:"text"`
+				PartialJSON string `json:"partial_json"`
+				StopReason  string `json:"stop_reason"`
+			} `json:"delta"`
+			Usage apiUsage `json:"usage"`
+			Error struct {
+				Type    string `json:"type"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal([]byte(strings.TrimSpace(data)), &ev); err != nil {
+			continue
+		}
+		switch ev.Type {
+		case "message_start":
+			usage.InputTokens = ev.Message.Usage.InputTokens
+			usage.OutputTokens = ev.Message.Usage.OutputTokens
+		case "content_block_start":
+			for len(msg.Content) <= ev.Index {
+				msg.Content = append(msg.Content, apiContent{})
+				partialJSON = append(partialJSON, "")
+			}
+			block := ev.ContentBlock
+			block.Input = nil
+			msg.Content[ev.Index] = block
+		case "content_block_delta":
+			if ev.Index >= len(msg.Content) {
+				continue
+			}
+			switch ev.Delta.Type {
+			case "text_delta":
+				msg.Content[ev.Index].Text += ev.Delta.Text
+			case "input_json_delta":
+				partialJSON[ev.Index] += ev.Delta.PartialJSON
+			}
+		case "message_delta":
+			stop = ev.Delta.StopReason
+			usage.OutputTokens = ev.Usage.OutputTokens
+		case "error":
+			err := fmt.Errorf("messages stream: %s: %s", ev.Error.Type, ev.Error.Message)
+			if transientAPIErrors[ev.Error.Type] {
+				err = transientError{err}
+			}
+			return msg, "", usage, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return msg, "", usage, transientError{fmt.Errorf("messages stream: %w", err)}
+	}
+	// Empty text blocks are rejected when the message is sent back.
+	content := msg.Content[:0]
+	for i, block := range msg.Content {
+		switch block.Type {
+		case "text":
+			if block.Text == "" {
+				continue
+			}
+		case "tool_use":
+			input := strings.TrimSpace(partialJSON[i])
+			if input == "" {
+				input = "{}"
+			}
+			block.Input = json.RawMessage(input)
+		}
+		content = append(content, block)
+	}
+	msg.Content = content
+	return msg, stop, usage, nil
+}
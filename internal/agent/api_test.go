@@ -0,0 +1,251 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// sse renders Messages API stream events.
+func sse(events ...string) string {
+	var b strings.Builder
+	for _, e := range events {
+		var typ struct{ Type string }
+		_ = json.Unmarshal([]byte(e), &typ)
+		fmt.Fprintf(&b, "event: %s\ndata: %s\n\n", typ.Type, e)
+	}
+	return b.String()
+}
+
+// fakeMessagesAPI answers each request with the next of responses and records the
+// request bodies.
+func fakeMessagesAPI(t *testing.T, responses ...string) (*httptest.Server, *[]map[string]any) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/messages" || r.Header.Get("x-api-key") != "test-key" || r.Header.Get("anthropic-version") == "" {
+			http.Error(w, `{"error":{"type":"authentication_error"}}`, http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var req map[string]any
+		_ = json.Unmarshal(body, &req)
+		mu.Lock()
+		i := len(requests)
+		requests = append(requests, req)
+		mu.Unlock()
+		if i >= len(responses) {
+			http.Error(w, "no more responses", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, responses[i])
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func toolUseTurn(id, name, input string) string {
+	half := len(input) / 2
+	return sse(
+		`{"type":"message_start","message":{"usage":{"input_tokens":100,"output_tokens":1}}}`,
+		`{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Working on it."}}`,
+		`{"type":"content_block_stop","index":0}`,
+		fmt.Sprintf(`{"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":%q,"name":%q,"input":{}}}`, id, name),
+		fmt.Sprintf(`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":%q}}`, input[:half]),
+		fmt.Sprintf(`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":%q}}`, input[half:]),
+		`{"type":"content_block_stop","index":1}`,
+		`{"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":20}}`,
+		`{"type":"message_stop"}`,
+	)
+}
+
+func endTurn(text string) string {
+	return sse(
+		`{"type":"message_start","message":{"usage":{"input_tokens":150,"output_tokens":1}}}`,
+		`{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+		fmt.Sprintf(`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":%q}}`, text),
+		`{"type":"content_block_stop","index":0}`,
+		`{"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":10}}`,
+		`{"type":"message_stop"}`,
+	)
+}
+
+func TestAPIBackend_Execute_ToolLoop(t *testing.T) {
+	dir := t.TempDir()
+	srv, requests := fakeMessagesAPI(t,
+		toolUseTurn("tu_1", apiToolWrite, `{"path":"pkg/hello.txt","content":"hello"}`),
+		toolUseTurn("tu_2", apiToolShell, `{"command":"cat pkg/hello.txt"}`),
+		endTurn("Created pkg/hello.txt."),
+	)
+	b := NewAPIBackend("test-key", srv.URL, "test-model")
+
+	var events []StreamEvent
+	result, err := b.Execute(context.Background(), Invocation{Prompt: "create a file", Force: true, OutputFormat: "text"}, dir, func(e StreamEvent) {
+		events = append(events, e)
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !result.Success || result.ExitCode != 0 {
+		t.Fatalf("Execute() = %+v, want success", result)
+	}
+	if result.Text() != "Created pkg/hello.txt." || result.Output != "Created pkg/hello.txt." {
+		t.Errorf("Text() = %q, Output = %q", result.Text(), result.Output)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "pkg", "hello.txt")); err != nil || string(data) != "hello" {
+		t.Errorf("written file = %q, %v", data, err)
+	}
+	if len(events) != len(result.StreamEvents) {
+		t.Errorf("emitted %d events, result holds %d", len(events), len(result.StreamEvents))
+	}
+	if events[0].Type != "system" || events[0].Data["model"] != "test-model" {
+		t.Errorf("first event = %+v, want system init with model", events[0])
+	}
+	var started []string
+	for _, e := range events {
+		if e.Type == "tool_call" && e.Subtype == "started" {
+			call := e.Data["tool_call"].(map[string]interface{})
+			for k := range call {
+				started = append(started, k)
+			}
+		}
+	}
+	if strings.Join(started, ",") != "writeToolCall,shellToolCall" {
+		t.Errorf("tool calls = %v", started)
+	}
+	last := events[len(events)-1]
+	usage, _ := last.Data["usage"].(map[string]interface{})
+	if last.Type != "result" || usage["input_tokens"] != float64(350) || usage["output_tokens"] != float64(50) {
+		t.Errorf("result event = %+v", last.Data)
+	}
+
+	if len(*requests) != 3 {
+		t.Fatalf("requests = %d, want 3", len(*requests))
+	}
+	// The shell tool's output is returned to the model in the third request.
+	msgs := (*requests)[2]["messages"].([]interface{})
+	toolResult := msgs[len(msgs)-1].(map[string]interface{})["content"].([]interface{})[0].(map[string]interface{})
+	if toolResult["type"] != "tool_result" || toolResult["tool_use_id"] != "tu_2" || toolResult["content"] != "hello" {
+		t.Errorf("tool result = %+v", toolResult)
+	}
+	if tools := (*requests)[0]["tools"].([]interface{}); len(tools) != 3 {
+		t.Errorf("tools with force = %d, want 3", len(tools))
+	}
+}
+
+func TestAPIBackend_Execute_NoForceOffersReadOnly(t *testing.T) {
+	dir := t.TempDir()
+	srv, requests := fakeMessagesAPI(t,
+		toolUseTurn("tu_1", apiToolWrite, `{"path":"x.txt","content":"x"}`),
+		endTurn("done"),
+	)
+	b := NewAPIBackend("test-key", srv.URL, "")
+	result, err := b.Execute(context.Background(), Invocation{Prompt: "p", OutputFormat: "stream-json"}, dir, nil)
+	if err != nil || !result.Success {
+		t.Fatalf("Execute() = %+v, %v", result, err)
+	}
+	if tools := (*requests)[0]["tools"].([]interface{}); len(tools) != 1 {
+		t.Errorf("tools without force = %d, want 1 (read_file)", len(tools))
+	}
+	if _, err := os.Stat(filepath.Join(dir, "x.txt")); err == nil {
+		t.Error("write_file must not run without force")
+	}
+	if (*requests)[0]["model"] != DefaultAPIModel {
+		t.Errorf("model = %v, want default", (*requests)[0]["model"])
+	}
+	if !strings.Contains(result.Output, `"type":"result"`) {
+		t.Errorf("stream-json Output should hold the event lines, got %q", result.Output)
+	}
+}
+
+func TestAPIBackend_Execute_Failures(t *testing.T) {
+	t.Run("api error", func(t *testing.T) {
+		srv, _ := fakeMessagesAPI(t)
+		b := NewAPIBackend("wrong-key", srv.URL, "m")
+		result, err := b.Execute(context.Background(), Invocation{Prompt: "p"}, t.TempDir(), nil)
+		if err != nil {
+			t.Fatalf("Execute() error = %v, want failure in Result", err)
+		}
+		if result.Success || result.ExitCode != 1 || !strings.Contains(result.Error, "401") {
+			t.Errorf("Execute() = %+v, want unsuccessful result naming the status", result)
+		}
+	})
+	t.Run("rate limited is transient", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"type":"error","error":{"type":"rate_limit_error"}}`, http.StatusTooManyRequests)
+		}))
+		defer srv.Close()
+		result, _ := NewAPIBackend("test-key", srv.URL, "m").Execute(context.Background(), Invocation{Prompt: "p"}, t.TempDir(), nil)
+		if result.Success || result.ExitCode != ExitCodeTransient {
+			t.Errorf("Execute() = %+v, want exit code %d", result, ExitCodeTransient)
+		}
+	})
+	t.Run("max turns", func(t *testing.T) {
+		srv, _ := fakeMessagesAPI(t, toolUseTurn("tu_1", apiToolRead, `{"path":"missing.txt"}`))
+		b := NewAPIBackend("test-key", srv.URL, "m")
+		b.MaxTurns = 1
+		result, _ := b.Execute(context.Background(), Invocation{Prompt: "p"}, t.TempDir(), nil)
+		if result.Success || !strings.Contains(result.Error, "maximum") {
+			t.Errorf("Execute() = %+v, want max turns failure", result)
+		}
+	})
+}
+
+func TestResolveToolPath(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{"a/b.go", filepath.Join(dir, "a", "b.go"), false},
+		{filepath.Join(dir, "c.go"), filepath.Join(dir, "c.go"), false},
+		{"../outside.txt", "", true},
+		{"a/../../outside.txt", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		got, err := resolveToolPath(dir, tt.path)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("resolveToolPath(%q) = %q, %v; want %q, err %v", tt.path, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestCaller_Call_APIBackend(t *testing.T) {
+	srv, _ := fakeMessagesAPI(t, endTurn("all good"))
+	logDir := t.TempDir()
+	caller := NewCaller("api", true, "text", logDir)
+	caller.SetBackend(NewAPIBackend("test-key", srv.URL, "m"))
+	caller.SetWriter(io.Discard)
+	if !caller.IsAvailable() {
+		t.Fatal("IsAvailable() = false with an API key")
+	}
+
+	var streamed int
+	result, err := caller.Call(context.Background(), "hi", WithWorkingDir(t.TempDir()), WithTimeout(time.Minute),
+		WithStreamHandler(func(StreamEvent) { streamed++ }))
+	if err != nil || !result.Success {
+		t.Fatalf("Call() = %+v, %v", result, err)
+	}
+	if result.Text() != "all good" || streamed == 0 || result.LogPath == "" {
+		t.Errorf("Call() text %q, streamed %d, log %q", result.Text(), streamed, result.LogPath)
+	}
+
+	caller.SetBackend(NewAPIBackend("", srv.URL, "m"))
+	if caller.IsAvailable() {
+		t.Error("IsAvailable() = true without an API key")
+	}
+}
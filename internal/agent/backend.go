@@ -0,0 +1,237 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Backend adapts the Caller to one agent CLI: how its command line is built and how its
+// streaming output is read. Events are normalized to the Cursor Agent schema ("system"
+// init with "model", "tool_call" started with writeToolCall/readToolCall, "result" with
+// "result" and "duration_ms"), which the rest of the package consumes.
+type Backend interface {
+	// Name identifies the backend in the agent_backend setting, e.g. "cursor".
+	Name() string
+	// DefaultCommand is the CLI's usual executable name.
+	DefaultCommand() string
+	// Detect reports whether command (a name or path) invokes this backend's CLI.
+	Detect(command string) bool
+	// BuildArgs returns the arguments for a non-interactive call with inv.
+	BuildArgs(inv Invocation) []string
+	// ParseStream parses one line of stream-json output into zero or more events.
+	ParseStream(line string) []StreamEvent
+}
+
+// Invocation holds what a Backend needs to build a command line.
+type Invocation struct {
+	// Prompt is the full prompt, including the context file list.
+	Prompt string
+	// ContextFiles are the files the prompt refers to, for CLIs that take them as
+	// arguments.
+	ContextFiles []string
+	// Force allows the agent to write files and run commands without asking.
+	Force bool
+	// OutputFormat is text, json or stream-json.
+	OutputFormat string
+	// Model is the model to use, passed as given (aliases such as "opus" work where the
+	// CLI accepts them); empty uses the CLI's default.
+	Model string
+}
+
+// OutputParser is implemented by backends whose CLI output needs reshaping before it
+// becomes Result.Output (e.g. to drop banners or render edits as diffs).
+type OutputParser interface {
+	ParseOutput(output string) string
+}
+
+// BackendAuto selects the backend whose Detect matches the agent command.
+const BackendAuto = "auto"
+
+// backends lists the built-in backends; the first is the fallback for auto detection.
+// The API backend is never detected; LookupBackend returns it unconfigured (see
+// NewAPIBackend).
+var backends = []Backend{CursorBackend{}, ClaudeBackend{}, AiderBackend{}, APIBackend{}}
+
+// Backends returns the built-in backends.
+func Backends() []Backend {
+	return append([]Backend(nil), backends...)
+}
+
+// BackendNames returns the accepted agent_backend values, BackendAuto first.
+func BackendNames() []string {
+	names := []string{BackendAuto}
+	for _, b := range backends {
+		names = append(names, b.Name())
+	}
+	return names
+}
+
+// LookupBackend returns the backend named name. BackendAuto (or an empty name) detects
+// it from command, falling back to the Cursor backend.
+func LookupBackend(name, command string) (Backend, error) {
+	if name == "" || name == BackendAuto {
+		return DetectBackend(command), nil
+	}
+	for _, b := range backends {
+		if b.Name() == name {
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown agent backend %q (expected one of %s)", name, strings.Join(BackendNames(), ", "))
+}
+
+// DetectBackend returns the backend whose CLI command invokes, or the Cursor backend.
+func DetectBackend(command string) Backend {
+	for _, b := range backends {
+		if b.Detect(command) {
+			return b
+		}
+	}
+	return backends[0]
+}
+
+// commandName returns the executable name of command without directory or extension.
+func commandName(command string) string {
+	name := filepath.Base(strings.TrimSpace(command))
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+// CursorBackend drives the Cursor Agent CLI ("agent -p --force --output-format ...").
+type CursorBackend struct{}
+
+func (CursorBackend) Name() string           { return "cursor" }
+func (CursorBackend) DefaultCommand() string { return "agent" }
+
+func (CursorBackend) Detect(command string) bool {
+	switch commandName(command) {
+	case "agent", "cursor-agent", "cursor":
+		return true
+	}
+	return false
+}
+
+func (CursorBackend) BuildArgs(inv Invocation) []string {
+	args := []string{"-p"}
+	if inv.Force {
+		args = append(args, "--force")
+	}
+	if inv.Model != "" {
+		args = append(args, "--model", inv.Model)
+	}
+	return append(args, "--output-format", inv.OutputFormat, inv.Prompt)
+}
+
+func (CursorBackend) ParseStream(line string) []StreamEvent {
+	if event := parseStreamEvent(line); event != nil {
+		return []StreamEvent{*event}
+	}
+	return nil
+}
+
+// ClaudeBackend drives the Claude Code CLI ("claude -p <prompt> --output-format ...").
+// Force maps to --dangerously-skip-permissions, as print mode cannot ask for approval.
+type ClaudeBackend struct{}
+
+func (ClaudeBackend) Name() string           { return "claude" }
+func (ClaudeBackend) DefaultCommand() string { return "claude" }
+
+func (ClaudeBackend) Detect(command string) bool {
+	return commandName(command) == "claude"
+}
+
+func (ClaudeBackend) BuildArgs(inv Invocation) []string {
+	args := []string{"-p", inv.Prompt, "--output-format", inv.OutputFormat}
+	if inv.OutputFormat == "stream-json" {
+		// Print mode only streams events with --verbose.
+		args = append(args, "--verbose")
+	}
+	if inv.Force {
+		args = append(args, "--dangerously-skip-permissions")
+	}
+	if inv.Model != "" {
+		args = append(args, "--model", inv.Model)
+	}
+	return args
+}
+
+// claudeWriteTools and claudeReadTools are the Claude Code tools reported as file writes
+// and reads.
+var (
+	claudeWriteTools = map[string]bool{"Write": true, "Edit": true, "MultiEdit": true, "NotebookEdit": true}
+	claudeReadTools  = map[string]bool{"Read": true}
+)
+
+// ParseStream passes every event through and, for assistant messages, adds a
+// "tool_call" started event per file write or read in the Cursor schema.
+func (ClaudeBackend) ParseStream(line string) []StreamEvent {
+	event := parseStreamEvent(line)
+	if event == nil {
+		return nil
+	}
+	events := []StreamEvent{*event}
+	if event.Type != "assistant" {
+		return events
+	}
+	message, _ := event.Data["message"].(map[string]interface{})
+	content, _ := message["content"].([]interface{})
+	for _, item := range content {
+		block, _ := item.(map[string]interface{})
+		if block["type"] != "tool_use" {
+			continue
+		}
+		name, _ := block["name"].(string)
+		input, _ := block["input"].(map[string]interface{})
+		path, _ := input["file_path"].(string)
+		if path == "" {
+			path, _ = input["notebook_path"].(string)
+		}
+		key := ""
+		switch {
+		case claudeWriteTools[name]:
+			key = "writeToolCall"
+		case claudeReadTools[name]:
+			key = "readToolCall"
+		default:
+			continue
+		}
+		events = append(events, StreamEvent{
+			Type:    "tool_call",
+			Subtype: "started",
+			Data: map[string]interface{}{
+				"type":      "tool_call",
+				"subtype":   "started",
+				"tool_call": map[string]interface{}{key: map[string]interface{}{"args": map[string]interface{}{"path": path}}},
+			},
+			Raw: line,
+		})
+	}
+	return events
+}
+
+// parseStreamEvent parses a JSON stream event; nil when line is not a JSON object.
+func parseStreamEvent(line string) *StreamEvent {
+	if !strings.HasPrefix(line, "{") {
+		return nil
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &data); err != nil {
+		return nil
+	}
+
+	event := &StreamEvent{
+		Data: data,
+		Raw:  line,
+	}
+
+	if t, ok := data["type"].(string); ok {
+		event.Type = t
+	}
+	if st, ok := data["subtype"].(string); ok {
+		event.Subtype = st
+	}
+
+	return event
+}
@@ -0,0 +1,98 @@
+package agent
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLookupBackend(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    string
+		wantErr bool
+	}{
+		{"auto", "agent", "cursor", false},
+		{"auto", "/usr/local/bin/claude", "claude", false},
+		{"", "claude.exe", "claude", false},
+		{"auto", "my-wrapper", "cursor", false},
+		{"claude", "my-wrapper", "claude", false},
+		{"cursor", "claude", "cursor", false},
+		{"auto", "/opt/bin/aider", "aider", false},
+		{"aider", "my-wrapper", "aider", false},
+		{"api", "agent", "api", false},
+		{"copilot", "agent", "", true},
+	}
+	for _, tt := range tests {
+		b, err := LookupBackend(tt.name, tt.command)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("LookupBackend(%q, %q) err = %v, wantErr %v", tt.name, tt.command, err, tt.wantErr)
+			continue
+		}
+		if err == nil && b.Name() != tt.want {
+			t.Errorf("LookupBackend(%q, %q) = %s, want %s", tt.name, tt.command, b.Name(), tt.want)
+		}
+	}
+}
+
+func TestBackend_BuildArgs(t *testing.T) {
+	tests := []struct {
+		backend Backend
+		inv     Invocation
+		want    []string
+	}{
+		{CursorBackend{}, Invocation{Prompt: "do it", Force: true, OutputFormat: "text"},
+			[]string{"-p", "--force", "--output-format", "text", "do it"}},
+		{CursorBackend{}, Invocation{Prompt: "do it", OutputFormat: "json"},
+			[]string{"-p", "--output-format", "json", "do it"}},
+		{ClaudeBackend{}, Invocation{Prompt: "do it", Force: true, OutputFormat: "stream-json"},
+			[]string{"-p", "do it", "--output-format", "stream-json", "--verbose", "--dangerously-skip-permissions"}},
+		{ClaudeBackend{}, Invocation{Prompt: "do it", OutputFormat: "text"},
+			[]string{"-p", "do it", "--output-format", "text"}},
+		{AiderBackend{}, Invocation{Prompt: "do it", Force: true, ContextFiles: []string{"a.go", "b.go"}},
+			[]string{"--message", "do it", "--no-auto-commits", "--no-pretty", "--no-check-update", "--yes-always", "a.go", "b.go"}},
+		{AiderBackend{}, Invocation{Prompt: "do it"},
+			[]string{"--message", "do it", "--no-auto-commits", "--no-pretty", "--no-check-update", "--dry-run"}},
+		{CursorBackend{}, Invocation{Prompt: "do it", OutputFormat: "text", Model: "gpt-5"},
+			[]string{"-p", "--model", "gpt-5", "--output-format", "text", "do it"}},
+		{ClaudeBackend{}, Invocation{Prompt: "do it", OutputFormat: "text", Model: "opus"},
+			[]string{"-p", "do it", "--output-format", "text", "--model", "opus"}},
+		{AiderBackend{}, Invocation{Prompt: "do it", Model: "sonnet", ContextFiles: []string{"a.go"}},
+			[]string{"--message", "do it", "--no-auto-commits", "--no-pretty", "--no-check-update", "--dry-run", "--model", "sonnet", "a.go"}},
+	}
+	for _, tt := range tests {
+		if got := tt.backend.BuildArgs(tt.inv); !slices.Equal(got, tt.want) {
+			t.Errorf("%s.BuildArgs(%+v) = %q, want %q", tt.backend.Name(), tt.inv, got, tt.want)
+		}
+	}
+}
+
+func TestClaudeBackend_ParseStream(t *testing.T) {
+	b := ClaudeBackend{}
+	if events := b.ParseStream("not json"); events != nil {
+		t.Errorf("ParseStream(non-JSON) = %v, want nil", events)
+	}
+
+	line := `{"type":"assistant","message":{"content":[` +
+		`{"type":"text","text":"Editing"},` +
+		`{"type":"tool_use","name":"Edit","input":{"file_path":"/p/main.go"}},` +
+		`{"type":"tool_use","name":"Read","input":{"file_path":"/p/go.mod"}},` +
+		`{"type":"tool_use","name":"Bash","input":{"command":"go test"}}]}}`
+	events := b.ParseStream(line)
+	if len(events) != 3 || events[0].Type != "assistant" {
+		t.Fatalf("ParseStream() = %d events, want the assistant event plus 2 tool calls", len(events))
+	}
+	for i, key := range []string{"writeToolCall", "readToolCall"} {
+		ev := events[i+1]
+		call, _ := ev.Data["tool_call"].(map[string]interface{})
+		if ev.Type != "tool_call" || ev.Subtype != "started" || call[key] == nil {
+			t.Errorf("event %d = %+v, want a started %s", i+1, ev, key)
+		}
+	}
+
+	result := b.ParseStream(`{"type":"result","subtype":"success","result":"done","duration_ms":1200}`)
+	r := &Result{StreamEvents: result}
+	if r.Text() != "done" {
+		t.Errorf("Text() = %q, want the result of the result event", r.Text())
+	}
+}
@@ -1,22 +1,27 @@
-// Package agent provides Cursor Agent integration for invoking the Cursor CLI
-// agent, executing tickets, planning, code review, and related workflows.
+// Package agent invokes an agent (the Cursor Agent, Claude Code or aider CLI, or the Anthropic
+// Messages API directly; see Backend) for executing tickets, planning, code review, and
+// related workflows.
 package agent
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/logging"
+	"github.com/anthropic/agent-orchestrator/internal/prompts"
+	"github.com/anthropic/agent-orchestrator/internal/redact"
 	"github.com/anthropic/agent-orchestrator/internal/ui"
 )
 
@@ -31,6 +36,65 @@ type Result struct {
 	ExitCode     int
 	StreamEvents []StreamEvent
 	LogPath      string // Path to log file when detailed logging is enabled
+	TimedOut     bool   // The call hit its timeout; Output holds whatever was produced until then
+	Attempts     int    // Number of times the agent was run, including retries (see WithRetry)
+	Usage        Usage  // Tokens and cost reported by the agent over all attempts
+}
+
+// Text returns the agent's final answer. For stream-json output this is the "result"
+// field of the last result event, or, when the call ended before one (a timeout), the
+// text of the assistant messages so far; otherwise it is the raw Output.
+func (r *Result) Text() string {
+	for i := len(r.StreamEvents) - 1; i >= 0; i-- {
+		ev := r.StreamEvents[i]
+		if ev.Type != "result" {
+			continue
+		}
+		if text, ok := ev.Data["result"].(string); ok {
+			return text
+		}
+	}
+	var parts []string
+	seen := false
+	for _, ev := range r.StreamEvents {
+		if ev.Type != "assistant" {
+			continue
+		}
+		seen = true
+		message, _ := ev.Data["message"].(map[string]interface{})
+		content, _ := message["content"].([]interface{})
+		for _, item := range content {
+			block, _ := item.(map[string]interface{})
+			if text, _ := block["text"].(string); block["type"] == "text" && strings.TrimSpace(text) != "" {
+				parts = append(parts, text)
+			}
+		}
+	}
+	if seen {
+		return strings.Join(parts, "\n")
+	}
+	return r.Output
+}
+
+// WrittenFiles returns the paths of the files the agent reported writing (tool_call
+// started events with a writeToolCall), in order and without duplicates. Empty unless
+// the output format is stream-json or the backend runs in-process.
+func (r *Result) WrittenFiles() []string {
+	seen := make(map[string]bool)
+	var files []string
+	for _, ev := range r.StreamEvents {
+		if ev.Type != "tool_call" || ev.Subtype != "started" {
+			continue
+		}
+		call, _ := ev.Data["tool_call"].(map[string]interface{})
+		write, _ := call["writeToolCall"].(map[string]interface{})
+		args, _ := write["args"].(map[string]interface{})
+		if path, _ := args["path"].(string); path != "" && !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+	return files
 }
 
 // StreamEvent represents a single streaming event from the agent (e.g. system init, tool_call).
@@ -51,6 +115,9 @@ type callOptions struct {
 	workingDir   string
 	timeout      time.Duration
 	onStream     func(StreamEvent)
+	maxRetries   int
+	backoff      time.Duration
+	model        string
 }
 
 // WithContextFiles adds context file paths to the agent call so the agent can read them.
@@ -85,25 +152,72 @@ func WithStreamHandler(fn func(StreamEvent)) CallOption {
 	}
 }
 
-// Caller handles Cursor Agent CLI invocations. It builds and runs the agent command
-// with configurable working dir, context files, timeout, and logging. Use Call
-// for normal prompts and CallForJSON when the agent should write JSON to a file.
+// WithRetry retries a failed call up to max times when the agent exits with a retryable
+// exit code (see IsRetryableExitCode) or reports a transient failure (see
+// IsRetryableFailure), waiting backoff before the first retry and doubling the wait for
+// each further one. Each attempt gets the full timeout. Defaults to the Caller's SetRetry
+// settings.
+func WithRetry(max int, backoff time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.maxRetries = max
+		o.backoff = backoff
+	}
+}
+
+// Caller handles agent CLI invocations. It builds and runs the agent command through
+// its Backend with configurable working dir, context files, timeout, and logging. Use
+// Call for normal prompts and CallForJSON when the agent should write JSON to a file.
 type Caller struct {
 	Command            string
+	Backend            Backend // nil means detected from Command
 	Force              bool
 	OutputFormat       string
 	DryRun             bool
 	LogDir             string
-	Verbose            bool
-	DisableDetailedLog bool // When true, disables logging of prompts and outputs
+	DisableDetailedLog bool          // When true, disables logging of prompts and outputs
+	MaxRetries         int           // Default retries of a call; see WithRetry
+	Backoff            time.Duration // Default wait before the first retry
 	writer             io.Writer
+	logger             *slog.Logger // diagnostics; see SetLogger
+	onCall             func(CallInfo)
+	onProcess          func(pid int) (exited func())
+	usageMu            sync.Mutex
+	usage              Usage             // accumulated over all calls; see Usage
+	models             map[string]string // model per key; see SetModels
+	prompts            *prompts.Set      // prompt templates; see SetPrompts
+	policy             Policy            // prepended to every prompt; see SetPolicy
+}
+
+// CallInfo describes a finished agent call. It is passed to the hook set with SetCallHook,
+// e.g. to write an audit record.
+type CallInfo struct {
+	Start       time.Time
+	Duration    time.Duration
+	Command     string
+	WorkingDir  string
+	PromptChars int
+	DryRun      bool
+	Result      *Result // nil when the call failed before producing a result
+	Err         error
+	// PolicyVersion and PolicyHash identify the policy prepended to the prompt; both
+	// are empty when no policy is set.
+	PolicyVersion string
+	PolicyHash    string
+}
+
+// EstimateTokens approximates how many model tokens text of the given length in bytes
+// uses (about four bytes per token). Used for budgeting when the agent reports no usage.
+func EstimateTokens(chars int) int {
+	return (chars + 3) / 4
 }
 
 // NewCaller creates a new Caller with the given command name, force flag, output format, and log directory.
-// Command is typically the Cursor agent CLI binary name (e.g. "cursor" or full path).
+// Command is the agent CLI binary name or path (e.g. "agent" or "claude"); the Backend
+// is detected from it unless set with SetBackend.
 func NewCaller(command string, force bool, outputFormat string, logDir string) *Caller {
 	return &Caller{
 		Command:      command,
+		Backend:      DetectBackend(command),
 		Force:        force,
 		OutputFormat: outputFormat,
 		LogDir:       logDir,
@@ -111,38 +225,105 @@ func NewCaller(command string, force bool, outputFormat string, logDir string) *
 	}
 }
 
-// SetWriter sets the writer used for verbose and dry-run output. Default is os.Stdout.
+// SetWriter sets the writer used for dry-run output. Default is os.Stdout.
 func (c *Caller) SetWriter(w io.Writer) {
 	c.writer = w
 }
 
+// SetBackend selects the agent CLI driver.
+func (c *Caller) SetBackend(b Backend) {
+	c.Backend = b
+}
+
+// backend returns the configured Backend, detecting it from Command when unset.
+func (c *Caller) backend() Backend {
+	if c.Backend == nil {
+		return DetectBackend(c.Command)
+	}
+	return c.Backend
+}
+
+// Usage returns the usage reported by all calls made through c so far, e.g. to
+// attribute the calls of one ticket (coding plus prompt compression) to it.
+func (c *Caller) Usage() Usage {
+	c.usageMu.Lock()
+	defer c.usageMu.Unlock()
+	return c.usage
+}
+
+// SetRetry sets the retries and backoff used by calls without WithRetry.
+func (c *Caller) SetRetry(maxRetries int, backoff time.Duration) {
+	c.MaxRetries = maxRetries
+	c.Backoff = backoff
+}
+
 // SetDryRun enables or disables dry run mode. When true, Call does not execute the agent;
 // it returns a success result with "[DRY RUN] Agent call skipped".
 func (c *Caller) SetDryRun(dryRun bool) {
 	c.DryRun = dryRun
 }
 
-// SetVerbose enables or disables verbose output (e.g. model name, tool calls, duration).
-func (c *Caller) SetVerbose(verbose bool) {
-	c.Verbose = verbose
+// SetLogger sets the logger for diagnostics: the model in use, tool calls, duration
+// and retries are Info records (shown with --verbose), dry-run prompts Debug records.
+// Without a logger slog.Default() is used.
+func (c *Caller) SetLogger(l *slog.Logger) {
+	c.logger = l
 }
 
-// IsAvailable reports whether the agent command is found on PATH.
+func (c *Caller) log() *slog.Logger {
+	if c.logger == nil {
+		return slog.Default()
+	}
+	return c.logger
+}
+
+// SetCallHook sets a function invoked after every Call (including dry runs) with a
+// description of the call. The hook runs synchronously and should be cheap.
+func (c *Caller) SetCallHook(fn func(CallInfo)) {
+	c.onCall = fn
+}
+
+// SetProcessHook sets a function invoked with the PID of every agent subprocess right
+// after it starts, e.g. to record it in the run state; the function it returns is
+// called once the subprocess has exited. In-process backends start no subprocess.
+func (c *Caller) SetProcessHook(fn func(pid int) (exited func())) {
+	c.onProcess = fn
+}
+
+// trackProcess reports a started agent subprocess to the hook set with SetProcessHook
+// and returns the function to call once it has exited.
+func (c *Caller) trackProcess(pid int) (exited func()) {
+	if c.onProcess == nil {
+		return func() {}
+	}
+	return c.onProcess(pid)
+}
+
+// IsAvailable reports whether the agent command is found on PATH, or for an in-process
+// backend (see Executor) whether it is configured.
 func (c *Caller) IsAvailable() bool {
+	if ex, ok := c.backend().(Executor); ok {
+		return ex.Available()
+	}
 	_, err := exec.LookPath(c.Command)
 	return err == nil
 }
 
-// Call invokes the Cursor Agent with the given prompt and options.
+// Call invokes the agent with the given prompt and options.
 // It returns the result (output, success, duration, stream events) and any execution error.
-// Use WithContextFiles, WithWorkingDir, WithTimeout, WithStreamHandler to configure the call.
+// Use WithContextFiles, WithWorkingDir, WithTimeout, WithStreamHandler, WithRetry to configure the call.
+// Calls first wait for the process-wide rate limit (see SetRateLimit); a cancelled
+// wait returns ctx's error.
 func (c *Caller) Call(ctx context.Context, prompt string, opts ...CallOption) (*Result, error) {
 	options := &callOptions{
-		timeout: 10 * time.Minute,
+		timeout:    10 * time.Minute,
+		maxRetries: c.MaxRetries,
+		backoff:    c.Backoff,
 	}
 	for _, opt := range opts {
 		opt(options)
 	}
+	prompt = c.policy.apply(prompt)
 
 	startTime := time.Now()
 
@@ -151,37 +332,38 @@ func (c *Caller) Call(ctx context.Context, prompt string, opts ...CallOption) (*
 
 	if c.DryRun {
 		c.logDryRun(prompt, options)
-		return &Result{
+		result := &Result{
 			Success:  true,
 			Output:   "[DRY RUN] Agent call skipped",
 			Duration: time.Since(startTime),
-		}, nil
-	}
-
-	// Build command arguments
-	args := c.buildArgs(prompt, options)
-
-	// Create context with timeout
-	ctx, cancel := context.WithTimeout(ctx, options.timeout)
-	defer cancel()
-
-	// Create command
-	cmd := exec.CommandContext(ctx, c.Command, args...)
-	if options.workingDir != "" {
-		cmd.Dir = options.workingDir
+			Attempts: 1,
+		}
+		c.notifyCall(startTime, prompt, options, result, nil)
+		return result, nil
 	}
 
-	// Log the command
-	c.logCommand(logFile, prompt, args, options)
-
-	// Execute based on output format
 	var result *Result
 	var err error
-
-	if c.OutputFormat == "stream-json" {
-		result, err = c.executeStream(ctx, cmd, logFile, options.onStream)
-	} else {
-		result, err = c.executeNormal(ctx, cmd, logFile)
+	var usage Usage
+	for attempt := 1; ; attempt++ {
+		// Retries count against the shared rate limit like any other call.
+		if err = waitForCallSlot(ctx); err != nil {
+			break
+		}
+		result, err = c.execute(ctx, prompt, logFile, options)
+		if result != nil {
+			result.Attempts = attempt
+			usage = usage.Add(usageOf(result))
+			result.Usage = usage
+		}
+		if attempt > options.maxRetries || err != nil || !retryable(result) || ctx.Err() != nil {
+			break
+		}
+		delay := retryDelay(options.backoff, attempt)
+		c.logRetry(logFile, attempt, options.maxRetries, result.ExitCode, delay)
+		if sleepContext(ctx, delay) != nil {
+			break
+		}
 	}
 
 	if result != nil {
@@ -190,40 +372,199 @@ func (c *Caller) Call(ctx context.Context, prompt string, opts ...CallOption) (*
 			result.LogPath = logFile.Name()
 		}
 	}
+	c.usageMu.Lock()
+	c.usage = c.usage.Add(usage)
+	c.usageMu.Unlock()
 
 	// Log result
 	c.logResult(logFile, result, err)
+	c.notifyCall(startTime, prompt, options, result, err)
 
 	return result, err
 }
 
-// buildArgs constructs the command line arguments
-func (c *Caller) buildArgs(prompt string, opts *callOptions) []string {
-	args := []string{"-p"}
+// execute makes one attempt of a call, bounded by the call's timeout.
+func (c *Caller) execute(ctx context.Context, prompt string, logFile *os.File, options *callOptions) (*Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, options.timeout)
+	defer cancel()
+
+	var result *Result
+	var err error
+
+	if ex, ok := c.backend().(Executor); ok {
+		c.logCommand(logFile, prompt, nil, options)
+		result, err = c.executeInProcess(ctx, ex, prompt, logFile, options)
+	} else {
+		// Build command arguments
+		args := c.buildArgs(prompt, options)
+
+		// Create command
+		cmd := exec.CommandContext(ctx, c.Command, args...)
+		if options.workingDir != "" {
+			cmd.Dir = options.workingDir
+		}
 
-	if c.Force {
-		args = append(args, "--force")
+		// Log the command
+		c.logCommand(logFile, prompt, args, options)
+
+		// Execute based on output format
+		if c.OutputFormat == "stream-json" {
+			result, err = c.executeStream(ctx, cmd, logFile, options.onStream)
+		} else {
+			result, err = c.executeNormal(ctx, cmd, logFile)
+		}
 	}
 
-	args = append(args, "--output-format", c.OutputFormat)
+	if result != nil {
+		if p, ok := c.backend().(OutputParser); ok {
+			result.Output = p.ParseOutput(result.Output)
+		}
+		if ctx.Err() == context.DeadlineExceeded {
+			result.TimedOut = true
+			result.Success = false
+		}
+	}
+	return result, err
+}
+
+// retryable reports whether a failed attempt is worth repeating: it did not time out
+// and exited with a retryable exit code, or its stderr or last line of output reports
+// a transient failure (see IsRetryableFailure) whatever the code, as CLIs exit 1 on a
+// rate limit too.
+func retryable(result *Result) bool {
+	if result == nil || result.Success || result.TimedOut {
+		return false
+	}
+	if IsRetryableExitCode(result.ExitCode) {
+		return true
+	}
+	lines := strings.Split(strings.TrimSpace(result.Output), "\n")
+	return IsRetryableFailure(result.Error + "\n" + lines[len(lines)-1])
+}
+
+// IsRetryableFailure reports whether an agent error message is of a failure class
+// expected to pass on its own: FailureRateLimit or FailureNetwork.
+func IsRetryableFailure(message string) bool {
+	switch ClassifyFailure(message) {
+	case FailureRateLimit, FailureNetwork:
+		return true
+	}
+	return false
+}
+
+// ExitCodeTransient (EX_TEMPFAIL) is the exit code of a call that failed for a reason
+// expected to pass, e.g. the API backend's rate limits and connection errors.
+const ExitCodeTransient = 75
+
+// IsRetryableExitCode reports whether an agent exiting with code failed transiently:
+// ExitCodeTransient, or a crash (killed by a signal, -1; SIGKILL, 137; SIGSEGV, 139).
+// Other non-zero codes are the agent's own verdict and are not retried.
+func IsRetryableExitCode(code int) bool {
+	switch code {
+	case -1, ExitCodeTransient, 137, 139:
+		return true
+	}
+	return false
+}
+
+// retryDelay returns the wait before retry n (1-based): backoff doubled per retry.
+func retryDelay(backoff time.Duration, n int) time.Duration {
+	return backoff << (n - 1)
+}
+
+// sleepContext waits for d or until ctx is done, returning ctx's error in that case.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// logRetry records a retry in the call's log file and the logger.
+func (c *Caller) logRetry(file *os.File, attempt, maxRetries, exitCode int, delay time.Duration) {
+	msg := fmt.Sprintf(i18n.AgentRetrying, exitCode, attempt, maxRetries, delay)
+	if file != nil {
+		file.WriteString("\n=== " + msg + " ===\n")
+	}
+	c.log().Info(msg, "exit_code", exitCode, "attempt", attempt, "max_retries", maxRetries, logging.KeyDuration, delay)
+}
+
+// notifyCall reports a finished call to the hook set with SetCallHook, if any.
+func (c *Caller) notifyCall(start time.Time, prompt string, opts *callOptions, result *Result, err error) {
+	if c.onCall == nil {
+		return
+	}
+	c.onCall(CallInfo{
+		Start:       start,
+		Duration:    time.Since(start),
+		Command:     c.Command,
+		WorkingDir:  opts.workingDir,
+		PromptChars: len(prompt),
+		DryRun:      c.DryRun,
+		Result:      result,
+		Err:         err,
+
+		PolicyVersion: c.policy.Version,
+		PolicyHash:    c.policy.Hash(),
+	})
+}
 
-	// Build full prompt with context files
+// invocation builds the backend invocation: the prompt with the context file list,
+// the caller's force flag and output format, and the call's model.
+func (c *Caller) invocation(prompt string, opts *callOptions) Invocation {
 	fullPrompt := prompt
 	if len(opts.contextFiles) > 0 {
 		fullPrompt = fmt.Sprintf("%s\n\n"+i18n.AgentContextFilesLabel, prompt, strings.Join(opts.contextFiles, " "))
 	}
+	return Invocation{
+		Prompt:       fullPrompt,
+		ContextFiles: opts.contextFiles,
+		Force:        c.Force,
+		OutputFormat: c.OutputFormat,
+		Model:        opts.model,
+	}
+}
 
-	args = append(args, fullPrompt)
+// buildArgs constructs the command line arguments through the backend
+func (c *Caller) buildArgs(prompt string, opts *callOptions) []string {
+	return c.backend().BuildArgs(c.invocation(prompt, opts))
+}
 
-	return args
+// executeInProcess runs the call through an in-process backend. Events are logged and
+// handled as they arrive, like the lines of a streaming CLI.
+func (c *Caller) executeInProcess(ctx context.Context, ex Executor, prompt string, logFile *os.File, opts *callOptions) (*Result, error) {
+	return ex.Execute(ctx, c.invocation(prompt, opts), opts.workingDir, func(event StreamEvent) {
+		if logFile != nil {
+			logFile.WriteString(sanitizeSensitiveData(event.Raw) + "\n")
+		}
+		if opts.onStream != nil {
+			opts.onStream(event)
+		}
+		c.handleStreamEvent(event)
+	})
 }
 
 // executeNormal executes the command and captures output
 func (c *Caller) executeNormal(ctx context.Context, cmd *exec.Cmd, logFile *os.File) (*Result, error) {
-	output, err := cmd.CombinedOutput()
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	err := cmd.Start()
+	if err == nil {
+		exited := c.trackProcess(cmd.Process.Pid)
+		err = cmd.Wait()
+		exited()
+	}
 
 	result := &Result{
-		Output:   string(output),
+		Output:   output.String(),
 		ExitCode: 0,
 	}
 
@@ -239,7 +580,7 @@ func (c *Caller) executeNormal(ctx context.Context, cmd *exec.Cmd, logFile *os.F
 
 	if logFile != nil {
 		// Sanitize output before writing to log
-		logFile.WriteString(sanitizeSensitiveData(string(output)))
+		logFile.WriteString(sanitizeSensitiveData(output.String()))
 	}
 
 	return result, nil
@@ -260,12 +601,14 @@ func (c *Caller) executeStream(ctx context.Context, cmd *exec.Cmd, logFile *os.F
 	if err := cmd.Start(); err != nil {
 		return nil, fmt.Errorf("failed to start command: %w", err)
 	}
+	defer c.trackProcess(cmd.Process.Pid)()
 
 	result := &Result{
 		StreamEvents: make([]StreamEvent, 0),
 	}
 
 	var outputBuilder strings.Builder
+	backend := c.backend()
 
 	// Process stdout with larger buffer to support long lines (default max token ~64KB)
 	scanner := bufio.NewScanner(stdout)
@@ -282,14 +625,13 @@ func (c *Caller) executeStream(ctx context.Context, cmd *exec.Cmd, logFile *os.F
 			logFile.WriteString(sanitizeSensitiveData(line) + "\n")
 		}
 
-		// Try to parse as JSON event
-		event := c.parseStreamEvent(line)
-		if event != nil {
-			result.StreamEvents = append(result.StreamEvents, *event)
+		// Try to parse as JSON events
+		for _, event := range backend.ParseStream(line) {
+			result.StreamEvents = append(result.StreamEvents, event)
 			if onStream != nil {
-				onStream(*event)
+				onStream(event)
 			}
-			c.handleStreamEvent(*event)
+			c.handleStreamEvent(event)
 		}
 	}
 
@@ -328,39 +670,13 @@ func (c *Caller) executeStream(ctx context.Context, cmd *exec.Cmd, logFile *os.F
 	return result, nil
 }
 
-// parseStreamEvent parses a JSON stream event
-func (c *Caller) parseStreamEvent(line string) *StreamEvent {
-	if !strings.HasPrefix(line, "{") {
-		return nil
-	}
-
-	var data map[string]interface{}
-	if err := json.Unmarshal([]byte(line), &data); err != nil {
-		return nil
-	}
-
-	event := &StreamEvent{
-		Data: data,
-		Raw:  line,
-	}
-
-	if t, ok := data["type"].(string); ok {
-		event.Type = t
-	}
-	if st, ok := data["subtype"].(string); ok {
-		event.Subtype = st
-	}
-
-	return event
-}
-
 // handleStreamEvent processes a stream event and outputs to terminal
 func (c *Caller) handleStreamEvent(event StreamEvent) {
 	switch event.Type {
 	case "system":
 		if event.Subtype == "init" {
-			if model, ok := event.Data["model"].(string); ok && c.Verbose {
-				ui.PrintInfo(c.writer, fmt.Sprintf(i18n.AgentModelInUse, model))
+			if model, ok := event.Data["model"].(string); ok {
+				c.log().Info(fmt.Sprintf(i18n.AgentModelInUse, model), "model", model)
 			}
 		}
 	case "tool_call":
@@ -370,28 +686,35 @@ func (c *Caller) handleStreamEvent(event StreamEvent) {
 			}
 		}
 	case "result":
-		if duration, ok := event.Data["duration_ms"].(float64); ok && c.Verbose {
-			ui.PrintSuccess(c.writer, fmt.Sprintf(i18n.AgentDurationMs, duration))
+		if duration, ok := event.Data["duration_ms"].(float64); ok {
+			c.log().Info(fmt.Sprintf(i18n.AgentDurationMs, duration), logging.KeyDuration, time.Duration(duration*float64(time.Millisecond)))
 		}
 	}
 }
 
 // logToolCall logs a tool call event
 func (c *Caller) logToolCall(toolCall map[string]interface{}) {
-	if !c.Verbose {
+	l := c.log()
+	if !l.Enabled(context.Background(), slog.LevelInfo) {
 		return
 	}
 
 	if writeCall, ok := toolCall["writeToolCall"].(map[string]interface{}); ok {
 		if args, ok := writeCall["args"].(map[string]interface{}); ok {
 			if path, ok := args["path"].(string); ok {
-				ui.PrintInfo(c.writer, fmt.Sprintf(i18n.AgentWriteFile, path))
+				l.Info(fmt.Sprintf(i18n.AgentWriteFile, path), "tool", "write", "path", path)
 			}
 		}
 	} else if readCall, ok := toolCall["readToolCall"].(map[string]interface{}); ok {
 		if args, ok := readCall["args"].(map[string]interface{}); ok {
 			if path, ok := args["path"].(string); ok {
-				ui.PrintInfo(c.writer, fmt.Sprintf(i18n.AgentReadFile, path))
+				l.Info(fmt.Sprintf(i18n.AgentReadFile, path), "tool", "read", "path", path)
+			}
+		}
+	} else if shellCall, ok := toolCall["shellToolCall"].(map[string]interface{}); ok {
+		if args, ok := shellCall["args"].(map[string]interface{}); ok {
+			if command, ok := args["command"].(string); ok {
+				l.Info(fmt.Sprintf(i18n.AgentRunCommand, ui.Truncate(command, 80)), "tool", "shell")
 			}
 		}
 	}
@@ -427,48 +750,9 @@ func (c *Caller) createLogFile() *os.File {
 	return file
 }
 
-// sensitivePatterns contains regex patterns for sensitive information
-var sensitivePatterns = []string{
-	// API keys and tokens
-	`(?i)(api[_-]?key|apikey|api_secret|secret[_-]?key)\s*[:=]\s*['"]?[a-zA-Z0-9_\-]{16,}['"]?`,
-	`(?i)(access[_-]?token|auth[_-]?token|bearer)\s*[:=]\s*['"]?[a-zA-Z0-9_\-\.]{20,}['"]?`,
-	// AWS credentials
-	`(?i)(aws[_-]?access[_-]?key[_-]?id|aws[_-]?secret)\s*[:=]\s*['"]?[A-Z0-9]{16,}['"]?`,
-	`AKIA[0-9A-Z]{16}`,
-	// Password patterns
-	`(?i)(password|passwd|pwd)\s*[:=]\s*['"]?[^\s'"]{4,}['"]?`,
-	// Private keys
-	`-----BEGIN\s+(RSA\s+)?PRIVATE\s+KEY-----`,
-	// GitHub tokens
-	`gh[pousr]_[A-Za-z0-9_]{36,}`,
-	// Generic secrets
-	`(?i)(client[_-]?secret|secret)\s*[:=]\s*['"]?[a-zA-Z0-9_\-]{16,}['"]?`,
-}
-
-// compiledPatterns holds compiled regex patterns (initialized lazily)
-var compiledPatterns []*regexp.Regexp
-var patternsOnce sync.Once
-
-// getCompiledPatterns returns compiled regex patterns, initializing them once
-func getCompiledPatterns() []*regexp.Regexp {
-	patternsOnce.Do(func() {
-		compiledPatterns = make([]*regexp.Regexp, 0, len(sensitivePatterns))
-		for _, pattern := range sensitivePatterns {
-			if re, err := regexp.Compile(pattern); err == nil {
-				compiledPatterns = append(compiledPatterns, re)
-			}
-		}
-	})
-	return compiledPatterns
-}
-
-// sanitizeSensitiveData removes or masks sensitive information from text
+// sanitizeSensitiveData masks sensitive information in text (see log_redaction_patterns)
 func sanitizeSensitiveData(text string) string {
-	result := text
-	for _, re := range getCompiledPatterns() {
-		result = re.ReplaceAllString(result, "[REDACTED]")
-	}
-	return result
+	return redact.String(text)
 }
 
 // logCommand logs the command being executed
@@ -483,6 +767,9 @@ func (c *Caller) logCommand(file *os.File, prompt string, args []string, opts *c
 	file.WriteString(fmt.Sprintf("Prompt length: %d\n", len(prompt)))
 	file.WriteString(fmt.Sprintf("Context files: %v\n", opts.contextFiles))
 	file.WriteString(fmt.Sprintf("Working dir: %s\n", opts.workingDir))
+	if !c.policy.IsZero() {
+		file.WriteString(fmt.Sprintf("Policy: %s\n", c.policy))
+	}
 	file.WriteString("=== Output ===\n")
 }
 
@@ -507,12 +794,7 @@ func (c *Caller) logResult(file *os.File, result *Result, err error) {
 // logDryRun logs a dry run
 func (c *Caller) logDryRun(prompt string, opts *callOptions) {
 	ui.PrintWarning(c.writer, i18n.AgentDryRunSkipCall)
-	if c.Verbose {
-		ui.PrintInfo(c.writer, fmt.Sprintf("Prompt: %s", ui.Truncate(prompt, 200)))
-		if len(opts.contextFiles) > 0 {
-			ui.PrintInfo(c.writer, fmt.Sprintf("Context: %v", opts.contextFiles))
-		}
-	}
+	c.log().Debug("Prompt: "+ui.Truncate(prompt, 200), "context_files", opts.contextFiles)
 }
 
 // CallForJSON invokes the agent with a prompt that asks for JSON output to be written to outputFile,
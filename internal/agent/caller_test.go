@@ -4,10 +4,16 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/logging"
 )
 
 func TestNewCaller(t *testing.T) {
@@ -45,16 +51,27 @@ func TestCaller_SetDryRun(t *testing.T) {
 	}
 }
 
-func TestCaller_SetVerbose(t *testing.T) {
+func TestCaller_SetLogger(t *testing.T) {
 	caller := NewCaller("cursor", false, "text", "")
+	var buf bytes.Buffer
+	caller.SetLogger(logging.New(&buf, logging.FormatJSON, slog.LevelInfo))
 
-	if caller.Verbose {
-		t.Error("default Verbose should be false")
+	caller.handleStreamEvent(StreamEvent{Type: "system", Subtype: "init", Data: map[string]interface{}{"model": "sonnet"}})
+	caller.handleStreamEvent(StreamEvent{Type: "result", Data: map[string]interface{}{"duration_ms": 1500.0}})
+	out := buf.String()
+	if !strings.Contains(out, `"model":"sonnet"`) || !strings.Contains(out, `"duration":1.5`) {
+		t.Errorf("log = %s, want the model and the duration in seconds", out)
 	}
 
-	caller.SetVerbose(true)
-	if !caller.Verbose {
-		t.Error("SetVerbose(true) should set Verbose to true")
+	// Dry-run prompts are Debug records.
+	buf.Reset()
+	caller.SetWriter(&bytes.Buffer{})
+	caller.SetDryRun(true)
+	if _, err := caller.Call(context.Background(), "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Info logger should skip the dry-run prompt, got %s", buf.String())
 	}
 }
 
@@ -68,6 +85,155 @@ func TestCaller_SetWriter(t *testing.T) {
 	// but we can at least verify the method doesn't panic
 }
 
+func TestCaller_SetCallHook(t *testing.T) {
+	var calls []CallInfo
+	hook := func(info CallInfo) { calls = append(calls, info) }
+
+	// Dry run still reports the call.
+	caller := NewCaller("cursor", false, "text", "")
+	caller.SetWriter(&bytes.Buffer{})
+	caller.SetDryRun(true)
+	caller.SetCallHook(hook)
+	if _, err := caller.Call(context.Background(), "hello", WithWorkingDir("/work")); err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+
+	// Real call.
+	caller = NewCaller(writeFakeAgent(t, "done"), false, "text", "")
+	caller.SetCallHook(hook)
+	if _, err := caller.Call(context.Background(), "prompt text"); err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("hook called %d times, want 2", len(calls))
+	}
+	if !calls[0].DryRun || calls[0].WorkingDir != "/work" || calls[0].PromptChars != len("hello") {
+		t.Errorf("dry-run CallInfo = %+v", calls[0])
+	}
+	if calls[1].DryRun || calls[1].Result == nil || !calls[1].Result.Success || calls[1].Start.IsZero() {
+		t.Errorf("CallInfo = %+v, want successful non-dry-run call", calls[1])
+	}
+}
+
+func TestCaller_SetProcessHook(t *testing.T) {
+	for _, format := range []string{"text", "stream-json"} {
+		t.Run(format, func(t *testing.T) {
+			var started, exited []int
+			caller := NewCaller(writeFakeAgent(t, "done"), false, format, "")
+			caller.SetProcessHook(func(pid int) func() {
+				started = append(started, pid)
+				return func() { exited = append(exited, pid) }
+			})
+			if _, err := caller.Call(context.Background(), "prompt"); err != nil {
+				t.Fatalf("Call() error = %v", err)
+			}
+			if len(started) != 1 || started[0] <= 0 || fmt.Sprint(exited) != fmt.Sprint(started) {
+				t.Errorf("started = %v, exited = %v; want one subprocess reported on start and exit", started, exited)
+			}
+		})
+	}
+}
+
+func TestCaller_Call_TimeoutKeepsPartialOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	path := filepath.Join(t.TempDir(), "slow-agent")
+	script := "#!/bin/sh\necho 'step 1 done'\nexec sleep 5\n"
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	caller := NewCaller(path, false, "text", "")
+	result, err := caller.Call(context.Background(), "prompt", WithTimeout(300*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if !result.TimedOut || result.Success {
+		t.Errorf("result = %+v, want TimedOut and not Success", result)
+	}
+	if !strings.Contains(result.Output, "step 1 done") {
+		t.Errorf("Output = %q, want output produced before the timeout", result.Output)
+	}
+}
+
+func TestCaller_Call_Retry(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	// The fake agent exits with the codes in order, one per run, then succeeds.
+	fakeAgent := func(t *testing.T, codes ...int) string {
+		dir := t.TempDir()
+		var b strings.Builder
+		b.WriteString("#!/bin/sh\nn=$(cat " + dir + "/runs 2>/dev/null || echo 0)\necho $((n+1)) > " + dir + "/runs\n")
+		for i, code := range codes {
+			fmt.Fprintf(&b, "[ \"$n\" = %d ] && exit %d\n", i, code)
+		}
+		b.WriteString("echo done\n")
+		path := filepath.Join(dir, "agent")
+		if err := os.WriteFile(path, []byte(b.String()), 0755); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name        string
+		codes       []int
+		opts        []CallOption
+		wantSuccess bool
+		wantAttempt int
+	}{
+		{"transient failure retried", []int{ExitCodeTransient, 137}, []CallOption{WithRetry(2, 0)}, true, 3},
+		{"retries exhausted", []int{ExitCodeTransient, ExitCodeTransient}, []CallOption{WithRetry(1, 0)}, false, 2},
+		{"agent failure not retried", []int{1}, []CallOption{WithRetry(2, 0)}, false, 1},
+		{"no retries by default", []int{ExitCodeTransient}, nil, false, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			caller := NewCaller(fakeAgent(t, tt.codes...), false, "text", "")
+			result, err := caller.Call(context.Background(), "prompt", tt.opts...)
+			if err != nil {
+				t.Fatalf("Call() error = %v", err)
+			}
+			if result.Success != tt.wantSuccess || result.Attempts != tt.wantAttempt {
+				t.Errorf("Success = %v, Attempts = %d, want %v, %d", result.Success, result.Attempts, tt.wantSuccess, tt.wantAttempt)
+			}
+		})
+	}
+
+	t.Run("rate limit on exit 1 retried", func(t *testing.T) {
+		dir := t.TempDir()
+		script := "#!/bin/sh\nif [ ! -f " + dir + "/ran ]; then touch " + dir + "/ran; echo 'API Error: 429 rate_limit_error' >&2; exit 1; fi\necho done\n"
+		path := filepath.Join(dir, "agent")
+		if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+			t.Fatal(err)
+		}
+		result, err := NewCaller(path, false, "text", "").Call(context.Background(), "prompt", WithRetry(2, 0))
+		if err != nil || !result.Success || result.Attempts != 2 {
+			t.Errorf("Call() = %+v, %v; want success on the second attempt", result, err)
+		}
+	})
+
+	t.Run("caller default", func(t *testing.T) {
+		caller := NewCaller(fakeAgent(t, ExitCodeTransient), false, "text", "")
+		caller.SetRetry(1, time.Millisecond)
+		result, _ := caller.Call(context.Background(), "prompt")
+		if !result.Success || result.Attempts != 2 {
+			t.Errorf("Success = %v, Attempts = %d, want true, 2", result.Success, result.Attempts)
+		}
+	})
+}
+
+func TestRetryDelay(t *testing.T) {
+	for n, want := range map[int]time.Duration{1: 5 * time.Second, 2: 10 * time.Second, 3: 20 * time.Second} {
+		if got := retryDelay(5*time.Second, n); got != want {
+			t.Errorf("retryDelay(5s, %d) = %s, want %s", n, got, want)
+		}
+	}
+}
+
 func TestCaller_buildArgs(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -165,8 +331,6 @@ func containsHelper(s, substr string) bool {
 }
 
 func TestCaller_parseStreamEvent(t *testing.T) {
-	caller := NewCaller("cursor", false, "stream-json", "")
-
 	tests := []struct {
 		name        string
 		line        string
@@ -206,7 +370,7 @@ func TestCaller_parseStreamEvent(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			event := caller.parseStreamEvent(tt.line)
+			event := parseStreamEvent(tt.line)
 
 			if tt.wantNil {
 				if event != nil {
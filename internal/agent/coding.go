@@ -2,6 +2,7 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,6 +11,8 @@ import (
 
 	"github.com/anthropic/agent-orchestrator/internal/i18n"
 	"github.com/anthropic/agent-orchestrator/internal/jsonutil"
+	"github.com/anthropic/agent-orchestrator/internal/project"
+	"github.com/anthropic/agent-orchestrator/internal/prompts"
 	"github.com/anthropic/agent-orchestrator/internal/ticket"
 )
 
@@ -17,8 +20,14 @@ import (
 // It builds a prompt from the ticket (ID, title, description, files to create/modify,
 // acceptance criteria) and runs the agent in the project directory with context files.
 type CodingAgent struct {
-	caller     *Caller
-	projectDir string
+	caller       *Caller
+	projectDir   string
+	promptBudget int      // max prompt chars before the description is summarized; 0 disables
+	conventions  []string // recurring review findings stated as project conventions
+	projects     []project.Detected
+	review       *ReviewResult            // review feedback to address (see Fix)
+	timeouts     map[string]time.Duration // coding timeout per estimated complexity
+	timeout      time.Duration            // coding timeout of other complexities
 }
 
 // NewCodingAgent creates a CodingAgent that uses the given Caller and project directory.
@@ -26,13 +35,57 @@ func NewCodingAgent(caller *Caller, projectDir string) *CodingAgent {
 	return &CodingAgent{
 		caller:     caller,
 		projectDir: projectDir,
+		projects:   project.Detect(projectDir),
+		timeout:    10 * time.Minute,
 	}
 }
 
+// SetTimeouts sets the timeout of a ticket's coding call by its estimated complexity
+// (ticket_timeouts); complexities not in timeouts get fallback (agent_timeout). Ignored
+// when fallback is not positive.
+func (ca *CodingAgent) SetTimeouts(timeouts map[string]time.Duration, fallback time.Duration) {
+	ca.timeouts = timeouts
+	if fallback > 0 {
+		ca.timeout = fallback
+	}
+}
+
+// Timeout returns the timeout of t's coding call (see TicketTimeout).
+func (ca *CodingAgent) Timeout(t *ticket.Ticket) time.Duration {
+	return TicketTimeout(t, ca.timeouts, ca.timeout)
+}
+
+// TicketTimeout returns the timeout of t's coding call: the one of its complexity in
+// timeouts (else fallback), raised to twice the timeout its last attempt hit
+// (t.TimedOutAfter), so a retry is not cut off at the same point again. The raise is
+// capped at twice the configured timeout, so repeated timeouts do not keep doubling it.
+func TicketTimeout(t *ticket.Ticket, timeouts map[string]time.Duration, fallback time.Duration) time.Duration {
+	timeout := fallback
+	if d, ok := timeouts[strings.ToLower(t.EstimatedComplexity)]; ok {
+		timeout = d
+	}
+	bumped := min(2*time.Duration(t.TimedOutAfter)*time.Second, 2*timeout)
+	return max(timeout, bumped)
+}
+
+// SetConventions sets recurring review findings to include in every prompt as a
+// "project conventions" section, so the agent avoids them up front.
+func (ca *CodingAgent) SetConventions(conventions []string) {
+	ca.conventions = conventions
+}
+
 // Execute runs the agent to implement the given ticket. It builds a prompt from the ticket,
 // collects context files from FilesToModify, and returns the agent Result and any error.
+// When a prompt budget is set and exceeded, sections of the prompt are summarized first
+// and t.PromptCompression records it; it is cleared when this call needs no compression.
 func (ca *CodingAgent) Execute(ctx context.Context, t *ticket.Ticket) (*Result, error) {
+	t.PromptCompression = nil
 	prompt := ca.buildPrompt(t)
+	if ca.needsCompression(prompt) {
+		if compressed, ok := ca.compressPrompt(ctx, t, prompt); ok {
+			prompt = compressed
+		}
+	}
 
 	// Collect context files
 	contextFiles := make([]string, 0)
@@ -43,58 +96,94 @@ func (ca *CodingAgent) Execute(ctx context.Context, t *ticket.Ticket) (*Result,
 		}
 	}
 
+	timeout := ca.Timeout(t)
 	opts := []CallOption{
 		WithWorkingDir(ca.projectDir),
-		WithTimeout(10 * time.Minute),
+		WithTimeout(timeout),
+		WithModel(ca.caller.modelFor(TicketModelKeys(t)...)),
 	}
 
 	if len(contextFiles) > 0 {
 		opts = append(opts, WithContextFiles(contextFiles...))
 	}
 
-	return ca.caller.Call(ctx, prompt, opts...)
+	role := roleCoding
+	if ca.review != nil {
+		role = roleFix
+	}
+	start := recordCallStarted(t, role)
+	result, err := ca.caller.Call(ctx, prompt, opts...)
+	recordCallFinished(t, start, result, err)
+	if result != nil && result.TimedOut {
+		t.TimedOutAfter = int((timeout + time.Second - 1) / time.Second)
+	}
+	return result, err
 }
 
-// buildPrompt creates the prompt for the coding agent
+// Fix runs the agent to address a review's issues and suggestions on the changes of t,
+// with the ticket's context; run uses it to fix CHANGES_REQUESTED reviews.
+func (ca *CodingAgent) Fix(ctx context.Context, t *ticket.Ticket, review *ReviewResult) (*Result, error) {
+	fixer := *ca
+	fixer.review = review
+	return fixer.Execute(ctx, t)
+}
+
+// buildPrompt renders the coding prompt (see prompts.CodingData) for t.
 func (ca *CodingAgent) buildPrompt(t *ticket.Ticket) string {
-	var sb strings.Builder
+	return ca.caller.renderPrompt(prompts.Coding, ca.promptData(t))
+}
 
-	sb.WriteString(i18n.AgentCodingIntro)
-	sb.WriteString(fmt.Sprintf(i18n.AgentCodingProjectRoot, ca.projectDir))
-	sb.WriteString(i18n.AgentCodingSectionTicket)
-	sb.WriteString(fmt.Sprintf(i18n.AgentCodingTicketId, t.ID))
-	sb.WriteString(fmt.Sprintf(i18n.AgentCodingTicketTitle, t.Title))
-	sb.WriteString(fmt.Sprintf(i18n.AgentCodingTicketDesc, t.Description))
-	sb.WriteString(fmt.Sprintf(i18n.AgentCodingTicketType, t.Type))
-	sb.WriteString(fmt.Sprintf(i18n.AgentCodingTicketComplexity, t.EstimatedComplexity))
-
-	if len(t.FilesToCreate) > 0 {
-		sb.WriteString(i18n.AgentCodingSectionFilesCreate)
-		for _, f := range t.FilesToCreate {
-			sb.WriteString(fmt.Sprintf("- %s\n", f))
-		}
-		sb.WriteString("\n")
+// promptData returns the data of the coding prompt for t.
+func (ca *CodingAgent) promptData(t *ticket.Ticket) prompts.CodingData {
+	data := prompts.CodingData{
+		Ticket:             t,
+		ProjectRoot:        ca.projectDir,
+		AcceptanceCriteria: t.AcceptanceCriteria,
+		Conventions:        ca.conventions,
+		PartialOutput:      t.PartialOutput,
 	}
-
-	if len(t.FilesToModify) > 0 {
-		sb.WriteString(i18n.AgentCodingSectionFilesModify)
-		for _, f := range t.FilesToModify {
-			sb.WriteString(fmt.Sprintf("- %s\n", f))
-		}
-		sb.WriteString("\n")
+	if ca.review != nil {
+		data.ReviewSummary = ca.review.Summary
+		data.ReviewIssues = ca.review.Issues
+		data.ReviewSuggestions = ca.review.Suggestions
 	}
-
-	if len(t.AcceptanceCriteria) > 0 {
-		sb.WriteString(i18n.AgentCodingSectionAcceptance)
-		for _, c := range t.AcceptanceCriteria {
-			sb.WriteString(fmt.Sprintf("- %s\n", c))
-		}
-		sb.WriteString("\n")
+	for _, n := range t.Notes {
+		data.Notes = append(data.Notes, noteLine(n))
 	}
+	for _, d := range ca.projects {
+		data.Projects = append(data.Projects, projectLine(d))
+		data.ProjectHints = append(data.ProjectHints, d.Plugin.PromptHints()...)
+	}
+	return data
+}
 
-	sb.WriteString(i18n.AgentCodingSteps)
+// noteLine renders an operator note for the prompt, e.g.
+// "[2024-06-01 12:00 alice] 改用既有的 retry 套件".
+func noteLine(n ticket.Note) string {
+	stamp := n.CreatedAt.Format("2006-01-02 15:04")
+	if n.Author != "" {
+		stamp += " " + n.Author
+	}
+	return fmt.Sprintf("[%s] %s", stamp, n.Text)
+}
 
-	return sb.String()
+// projectLine describes a detected project type and its commands for the prompt, e.g.
+// "Go（測試: go test ./...；建置: go build ./...）".
+func projectLine(d project.Detected) string {
+	var cmds []string
+	if d.Commands.Test != "" {
+		cmds = append(cmds, fmt.Sprintf(i18n.AgentProjectTest, d.Commands.Test))
+	}
+	if d.Commands.Build != "" {
+		cmds = append(cmds, fmt.Sprintf(i18n.AgentProjectBuild, d.Commands.Build))
+	}
+	if d.Commands.Format != "" {
+		cmds = append(cmds, fmt.Sprintf(i18n.AgentProjectFormat, d.Commands.Format))
+	}
+	if len(cmds) == 0 {
+		return d.Plugin.Name()
+	}
+	return fmt.Sprintf(i18n.AgentProjectCommands, d.Plugin.Name(), strings.Join(cmds, "；"))
 }
 
 // AnalyzeAgent analyzes existing project code and generates issues (performance, refactor, security, test, docs).
@@ -102,6 +191,11 @@ func (ca *CodingAgent) buildPrompt(t *ticket.Ticket) string {
 type AnalyzeAgent struct {
 	caller     *Caller
 	projectDir string
+	files      []string // when set, only these files are analyzed
+
+	// Follow-up (deeper) pass: directories to focus on and issues already reported.
+	focusDirs []string
+	known     []*ticket.Issue
 }
 
 // NewAnalyzeAgent creates an AnalyzeAgent that uses the given Caller and project directory.
@@ -112,6 +206,19 @@ func NewAnalyzeAgent(caller *Caller, projectDir string) *AnalyzeAgent {
 	}
 }
 
+// SetFiles restricts the analysis to the given files (paths relative to the project).
+// An empty list analyzes the whole project.
+func (aa *AnalyzeAgent) SetFiles(files []string) {
+	aa.files = files
+}
+
+// SetFollowUp turns the analysis into a deeper follow-up pass focused on dirs (the whole
+// project when empty). Known issues are listed in the prompt so they are not reported again.
+func (aa *AnalyzeAgent) SetFollowUp(dirs []string, known []*ticket.Issue) {
+	aa.focusDirs = dirs
+	aa.known = known
+}
+
 // AnalyzeScope defines which aspects of the codebase to analyze (performance, refactor, security, test, docs).
 // Enable one or more flags to narrow or broaden the analysis.
 type AnalyzeScope struct {
@@ -169,6 +276,7 @@ func (aa *AnalyzeAgent) Analyze(ctx context.Context, scope AnalyzeScope) (*ticke
 	result, jsonData, err := aa.caller.CallForJSON(ctx, prompt, outputFile,
 		WithWorkingDir(aa.projectDir),
 		WithTimeout(15*time.Minute),
+		WithModel(aa.caller.modelFor(ModelKeyAnalyze)),
 	)
 
 	if err != nil {
@@ -191,6 +299,26 @@ func (aa *AnalyzeAgent) buildAnalyzePrompt(scope AnalyzeScope) string {
 
 	sb.WriteString(i18n.AgentAnalyzeIntro)
 	sb.WriteString(fmt.Sprintf(i18n.AgentAnalyzeProjectDir, aa.projectDir))
+	if len(aa.files) > 0 {
+		sb.WriteString(i18n.AgentAnalyzeFilesOnly)
+		for _, f := range aa.files {
+			sb.WriteString(fmt.Sprintf("- %s\n", f))
+		}
+		sb.WriteString("\n")
+	}
+	if len(aa.focusDirs) > 0 || len(aa.known) > 0 {
+		sb.WriteString(i18n.AgentAnalyzeFollowUp)
+		for _, d := range aa.focusDirs {
+			sb.WriteString(fmt.Sprintf("- %s\n", d))
+		}
+		if len(aa.known) > 0 {
+			sb.WriteString(i18n.AgentAnalyzeKnownIssues)
+			for _, issue := range aa.known {
+				sb.WriteString(fmt.Sprintf("- [%s] %s (%s)\n", issue.Severity, issue.Title, issue.Location))
+			}
+		}
+		sb.WriteString("\n")
+	}
 	sb.WriteString(i18n.AgentAnalyzeAspects)
 	if scope.Performance {
 		sb.WriteString(i18n.AgentAnalyzePerf)
@@ -216,7 +344,7 @@ func (aa *AnalyzeAgent) buildAnalyzePrompt(scope AnalyzeScope) string {
 func (aa *AnalyzeAgent) parseIssues(data map[string]interface{}) (*ticket.IssueList, error) {
 	issuesData, ok := data["issues"].([]interface{})
 	if !ok {
-		return nil, fmt.Errorf(i18n.ErrAgentInvalidIssues)
+		return nil, errors.New(i18n.ErrAgentInvalidIssues)
 	}
 
 	il := ticket.NewIssueList()
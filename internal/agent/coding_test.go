@@ -2,10 +2,15 @@ package agent
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/prompts"
 	"github.com/anthropic/agent-orchestrator/internal/ticket"
 )
 
@@ -24,21 +29,21 @@ func TestCodingAgent_buildPrompt_outputFormat(t *testing.T) {
 
 	prompt := ca.buildPrompt(tkt)
 
-	// 必須包含的區塊標題與固定步驟（使用 i18n 常數以與 agent 一致）
+	// 必須包含的區塊標題與固定步驟（見 prompts/templates/coding.tmpl）
 	wantSections := []string{
-		strings.TrimSuffix(i18n.AgentCodingIntro, "\n\n"),
+		"你是一個專業的開發 Agent。請根據以下 ticket 實作程式碼。",
 		"專案根目錄: /test/project",
-		strings.TrimSuffix(i18n.AgentCodingSectionTicket, "\n"),
+		"## Ticket 資訊",
 		"- ID: T-001",
 		"- 標題: 標題",
 		"- 描述: 描述",
 		"- 類型: feature",
 		"- 複雜度: medium",
-		strings.TrimSuffix(i18n.AgentCodingSectionFilesCreate, "\n"),
+		"## 需要建立的檔案",
 		"- a.go",
-		strings.TrimSuffix(i18n.AgentCodingSectionFilesModify, "\n"),
+		"## 需要修改的檔案",
 		"- b.go",
-		strings.TrimSuffix(i18n.AgentCodingSectionAcceptance, "\n"),
+		"## 驗收標準",
 		"- 標準1",
 		"## 請執行以下步驟:",
 		"1. 閱讀相關的現有程式碼",
@@ -51,6 +56,46 @@ func TestCodingAgent_buildPrompt_outputFormat(t *testing.T) {
 	}
 }
 
+func TestCodingAgent_buildPrompt_conventions(t *testing.T) {
+	ca := NewCodingAgent(nil, "/test/project")
+	tkt := &ticket.Ticket{ID: "T-001", Title: "標題"}
+
+	if prompt := ca.buildPrompt(tkt); strings.Contains(prompt, "## 專案慣例") {
+		t.Error("buildPrompt() should omit the conventions section when there are none")
+	}
+
+	ca.SetConventions([]string{"Wrap errors with %w", "Add tests for new parsers"})
+	prompt := ca.buildPrompt(tkt)
+	section := strings.Index(prompt, "## 專案慣例")
+	if section < 0 {
+		t.Fatal("buildPrompt() should contain the conventions section")
+	}
+	for _, want := range []string{"- Wrap errors with %w", "- Add tests for new parsers"} {
+		if !strings.Contains(prompt[section:], want) {
+			t.Errorf("conventions section should contain %q", want)
+		}
+	}
+	if steps := strings.Index(prompt, "## 請執行以下步驟:"); steps < section {
+		t.Error("conventions section should come before the steps")
+	}
+}
+
+func TestCodingAgent_buildPrompt_partialOutput(t *testing.T) {
+	ca := NewCodingAgent(nil, "/test/project")
+	tkt := &ticket.Ticket{ID: "T-001", Title: "標題"}
+	header := "## 上次執行進度"
+
+	if strings.Contains(ca.buildPrompt(tkt), header) {
+		t.Error("buildPrompt() should omit the previous-progress section without partial output")
+	}
+
+	tkt.PartialOutput = "created parser.go"
+	prompt := ca.buildPrompt(tkt)
+	if !strings.Contains(prompt, header) || !strings.Contains(prompt, "created parser.go") {
+		t.Error("buildPrompt() should include the partial output from the timed-out attempt")
+	}
+}
+
 func TestAnalyzeAgent_parseIssues(t *testing.T) {
 	aa := NewAnalyzeAgent(nil, "/test/project")
 
@@ -242,3 +287,127 @@ func TestAnalyzeAgent_Analyze_dryRunReturnsMockIssues(t *testing.T) {
 		t.Errorf("Analyze(dry run) want at least 2 mock issues, got %d", il.Count())
 	}
 }
+
+func TestCodingAgent_buildPrompt_projectType(t *testing.T) {
+	tkt := &ticket.Ticket{ID: "T-001", Title: "標題"}
+	section := "## 專案類型"
+
+	if prompt := NewCodingAgent(nil, t.TempDir()).buildPrompt(tkt); strings.Contains(prompt, section) {
+		t.Error("buildPrompt() should omit the project type section when no type is detected")
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	prompt := NewCodingAgent(nil, dir).buildPrompt(tkt)
+	for _, want := range []string{section, "- Go（測試: go test ./...；建置: go build ./...；格式化: gofmt -w .）", i18n.ProjectHintGoTests} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("buildPrompt() should contain %q", want)
+		}
+	}
+}
+
+func TestCodingAgent_buildPrompt_projectTemplate(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, prompts.Dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	tmpl := "Implement {{.Ticket.ID}} in {{.ProjectRoot}}{{range .AcceptanceCriteria}}; {{.}}{{end}}"
+	if err := os.WriteFile(filepath.Join(dir, "coding.tmpl"), []byte(tmpl), 0644); err != nil {
+		t.Fatal(err)
+	}
+	set, err := prompts.Load(root)
+	if err != nil {
+		t.Fatalf("prompts.Load() error = %v", err)
+	}
+	caller := NewCaller("agent", false, "text", "")
+	caller.SetPrompts(set)
+
+	tkt := &ticket.Ticket{ID: "T-001", AcceptanceCriteria: []string{"a", "b"}}
+	if got := NewCodingAgent(caller, "/p").buildPrompt(tkt); got != "Implement T-001 in /p; a; b" {
+		t.Errorf("buildPrompt() = %q, want the project template", got)
+	}
+	// Prompts without a project template keep the embedded default.
+	if got := NewReviewAgent(caller, "/p").buildReviewPrompt([]string{"a.go"}); !strings.Contains(got, "- a.go") {
+		t.Errorf("buildReviewPrompt() = %q, want the embedded template", got)
+	}
+}
+
+func TestCodingAgent_Fix_reviewFeedback(t *testing.T) {
+	ca := NewCodingAgent(nil, "/test/project")
+	tkt := &ticket.Ticket{ID: "T-001", Title: "標題"}
+	header := "## 審查意見"
+
+	if strings.Contains(ca.buildPrompt(tkt), header) {
+		t.Error("buildPrompt() should omit the review section without review feedback")
+	}
+
+	fixer := *ca
+	fixer.review = &ReviewResult{
+		Status:      "CHANGES_REQUESTED",
+		Summary:     "缺少錯誤處理",
+		Issues:      []string{"parse() ignores the error"},
+		Suggestions: []string{"add a table-driven test"},
+	}
+	prompt := fixer.buildPrompt(tkt)
+	for _, want := range []string{header, "缺少錯誤處理", "- 問題: parse() ignores the error", "- 建議: add a table-driven test"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Fix prompt should contain %q, got:\n%s", want, prompt)
+		}
+	}
+}
+
+func TestTicketTimeout(t *testing.T) {
+	timeouts := map[string]time.Duration{"low": 5 * time.Minute, "high": 40 * time.Minute}
+	tests := []struct {
+		name          string
+		complexity    string
+		timedOutAfter int
+		want          time.Duration
+	}{
+		{name: "by complexity", complexity: "high", want: 40 * time.Minute},
+		{name: "complexity is case-insensitive", complexity: "Low", want: 5 * time.Minute},
+		{name: "unlisted complexity falls back", complexity: "medium", want: 10 * time.Minute},
+		{name: "timed out attempt doubles", complexity: "low", timedOutAfter: 300, want: 10 * time.Minute},
+		{name: "configured timeout above the bump wins", complexity: "high", timedOutAfter: 60, want: 40 * time.Minute},
+		{name: "repeated timeouts stop at twice the configured", complexity: "low", timedOutAfter: 600, want: 10 * time.Minute},
+		{name: "cap follows the fallback", complexity: "medium", timedOutAfter: 3600, want: 20 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tk := &ticket.Ticket{EstimatedComplexity: tt.complexity, TimedOutAfter: tt.timedOutAfter}
+			if got := TicketTimeout(tk, timeouts, 10*time.Minute); got != tt.want {
+				t.Errorf("TicketTimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCodingAgent_Execute_recordsTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	agentPath := filepath.Join(t.TempDir(), "fake-agent")
+	if err := os.WriteFile(agentPath, []byte("#!/bin/sh\nexec sleep 5\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	ca := NewCodingAgent(NewCaller(agentPath, true, "text", ""), t.TempDir())
+	ca.SetTimeouts(map[string]time.Duration{"low": 200 * time.Millisecond}, time.Minute)
+
+	tk := &ticket.Ticket{ID: "T-1", Title: "slow", EstimatedComplexity: "low"}
+	result, _ := ca.Execute(context.Background(), tk)
+	if result == nil || !result.TimedOut {
+		t.Fatalf("Execute() = %+v, want a timed-out result", result)
+	}
+	// Recorded in whole seconds, rounded up.
+	if tk.TimedOutAfter != 1 {
+		t.Errorf("TimedOutAfter = %d, want 1", tk.TimedOutAfter)
+	}
+	// The call is recorded in the ticket's history.
+	if len(tk.Events) != 2 || tk.Events[0].Kind != ticket.EventAgentStarted || tk.Events[0].Detail != roleCoding ||
+		tk.Events[1].Kind != ticket.EventAgentFinished || tk.Events[1].Detail != "timed out" || tk.Events[1].Duration < 200*time.Millisecond {
+		t.Errorf("Events = %+v, want the started and timed-out coding call", tk.Events)
+	}
+}
@@ -0,0 +1,142 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/prompts"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+const (
+	// compressTimeout bounds the summarization call; it should be much cheaper than the coding call.
+	compressTimeout = 2 * time.Minute
+	// minSummaryChars is the smallest summary length we ask the summarizer for, so very
+	// tight budgets still leave room for a meaningful section; shorter sections are kept.
+	minSummaryChars = 500
+)
+
+// SetPromptBudget sets the maximum coding prompt length in characters. When the prompt
+// built for a ticket exceeds the budget, Execute first summarizes its sections with short
+// agent calls, least important first (see promptSections); acceptance criteria and
+// review feedback are always kept verbatim. Zero disables compression.
+func (ca *CodingAgent) SetPromptBudget(chars int) {
+	ca.promptBudget = chars
+}
+
+// needsCompression reports whether the prompt exceeds the configured budget.
+func (ca *CodingAgent) needsCompression(prompt string) bool {
+	return ca.promptBudget > 0 && utf8.RuneCountInString(prompt) > ca.promptBudget
+}
+
+// promptSection is a part of the coding prompt that may be summarized.
+type promptSection struct {
+	name string // recorded in ticket.PromptCompression.Sections
+	get  func(*prompts.CodingData) string
+	set  func(*prompts.CodingData, string)
+}
+
+// promptSections are the summarizable sections of the coding prompt, least important
+// first: project conventions, the progress of a timed-out run, operator notes but the
+// latest (which wins over the rest), then the description, the statement of the task.
+var promptSections = []promptSection{
+	{
+		name: "conventions",
+		get:  func(d *prompts.CodingData) string { return strings.Join(d.Conventions, "\n") },
+		set:  func(d *prompts.CodingData, s string) { d.Conventions = []string{s} },
+	},
+	{
+		name: "partial_output",
+		get:  func(d *prompts.CodingData) string { return d.PartialOutput },
+		set:  func(d *prompts.CodingData, s string) { d.PartialOutput = s },
+	},
+	{
+		name: "notes",
+		get: func(d *prompts.CodingData) string {
+			if len(d.Notes) < 2 {
+				return ""
+			}
+			return strings.Join(d.Notes[:len(d.Notes)-1], "\n")
+		},
+		set: func(d *prompts.CodingData, s string) { d.Notes = []string{s, d.Notes[len(d.Notes)-1]} },
+	},
+	{
+		name: "description",
+		get:  func(d *prompts.CodingData) string { return d.Ticket.Description },
+		set: func(d *prompts.CodingData, s string) {
+			condensed := *d.Ticket
+			condensed.Description = s
+			d.Ticket = &condensed
+		},
+	},
+}
+
+// compressPrompt summarizes sections of the coding prompt of t, in the order of
+// promptSections, until it fits the budget. On success it returns the rebuilt prompt and
+// records the compression on the ticket. When no section could be summarized (dry run,
+// agent error, sections too short, summaries not shorter) it returns ok=false and the
+// caller keeps the original prompt.
+func (ca *CodingAgent) compressPrompt(ctx context.Context, t *ticket.Ticket, prompt string) (string, bool) {
+	if ca.caller == nil || ca.caller.DryRun {
+		return "", false
+	}
+
+	originalChars := utf8.RuneCountInString(prompt)
+	data := ca.promptData(t)
+	compressed, chars := prompt, originalChars
+	var sections []string
+	for _, sec := range promptSections {
+		if chars <= ca.promptBudget {
+			break
+		}
+		text := sec.get(&data)
+		textChars := utf8.RuneCountInString(text)
+		// Ask for just what the prompt is over budget by, but never a uselessly short summary.
+		target := max(textChars-(chars-ca.promptBudget), minSummaryChars)
+		if textChars <= target {
+			continue
+		}
+		summary, ok := ca.summarize(ctx, text, target)
+		if !ok {
+			continue
+		}
+		sec.set(&data, summary+i18n.AgentCompressNote)
+		compressed = ca.caller.renderPrompt(prompts.Coding, data)
+		chars = utf8.RuneCountInString(compressed)
+		sections = append(sections, sec.name)
+	}
+	if len(sections) == 0 {
+		return "", false
+	}
+
+	t.PromptCompression = &ticket.PromptCompression{
+		OriginalChars:   originalChars,
+		CompressedChars: chars,
+		Budget:          ca.promptBudget,
+		Sections:        sections,
+		CompressedAt:    time.Now(),
+	}
+	return compressed, true
+}
+
+// summarize condenses text to about target characters with a short agent call. It
+// returns ok=false when the call fails or the summary is not shorter than text.
+func (ca *CodingAgent) summarize(ctx context.Context, text string, target int) (string, bool) {
+	result, err := ca.caller.Call(ctx, fmt.Sprintf(i18n.AgentCompressPrompt, target, text),
+		WithWorkingDir(ca.projectDir),
+		WithTimeout(compressTimeout),
+		WithModel(ca.caller.modelFor(ModelKeyEnhance)),
+	)
+	if err != nil || result == nil || !result.Success {
+		return "", false
+	}
+	summary := strings.TrimSpace(result.Text())
+	if summary == "" || utf8.RuneCountInString(summary) >= utf8.RuneCountInString(text) {
+		return "", false
+	}
+	return summary, true
+}
@@ -0,0 +1,211 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// writeFakeAgent writes a shell script that ignores its arguments and prints output.
+func writeFakeAgent(t *testing.T, output string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	path := filepath.Join(t.TempDir(), "fake-agent")
+	script := "#!/bin/sh\ncat <<'EOF'\n" + output + "\nEOF\n"
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatalf("write fake agent: %v", err)
+	}
+	return path
+}
+
+func TestCodingAgent_needsCompression(t *testing.T) {
+	ca := NewCodingAgent(nil, "/p")
+	if ca.needsCompression(strings.Repeat("x", 100)) {
+		t.Error("budget 0 should disable compression")
+	}
+	ca.SetPromptBudget(50)
+	if !ca.needsCompression(strings.Repeat("x", 51)) {
+		t.Error("prompt over budget should need compression")
+	}
+	if ca.needsCompression(strings.Repeat("中", 50)) {
+		t.Error("budget is counted in characters, not bytes")
+	}
+}
+
+func TestCodingAgent_compressPrompt(t *testing.T) {
+	caller := NewCaller(writeFakeAgent(t, "精簡後的摘要"), false, "text", "")
+	ca := NewCodingAgent(caller, t.TempDir())
+	ca.SetPromptBudget(600)
+
+	tkt := ticket.NewTicket("T-001", "大型 ticket", strings.Repeat("很長的背景說明。", 400))
+	tkt.AcceptanceCriteria = []string{"必須保留的驗收標準"}
+
+	prompt := ca.buildPrompt(tkt)
+	if !ca.needsCompression(prompt) {
+		t.Fatal("test prompt should exceed the budget")
+	}
+
+	compressed, ok := ca.compressPrompt(context.Background(), tkt, prompt)
+	if !ok {
+		t.Fatal("compressPrompt() ok = false, want true")
+	}
+	if !strings.Contains(compressed, "精簡後的摘要") {
+		t.Error("compressed prompt should contain the summary")
+	}
+	if !strings.Contains(compressed, "- 必須保留的驗收標準") {
+		t.Error("acceptance criteria must be kept verbatim")
+	}
+	if strings.Contains(compressed, strings.Repeat("很長的背景說明。", 10)) {
+		t.Error("original description should be replaced by the summary")
+	}
+	if tkt.PromptCompression == nil {
+		t.Fatal("PromptCompression should be recorded on the ticket")
+	}
+	if tkt.PromptCompression.CompressedChars >= tkt.PromptCompression.OriginalChars {
+		t.Errorf("CompressedChars = %d, want < OriginalChars %d",
+			tkt.PromptCompression.CompressedChars, tkt.PromptCompression.OriginalChars)
+	}
+	if tkt.PromptCompression.Budget != 600 {
+		t.Errorf("Budget = %d, want 600", tkt.PromptCompression.Budget)
+	}
+}
+
+func TestCodingAgent_compressPrompt_Sections(t *testing.T) {
+	description := strings.Repeat("任務說明。", 120)
+	tests := []struct {
+		name         string
+		budget       int
+		wantSections []string
+	}{
+		{name: "least important sections are enough", budget: 3000, wantSections: []string{"conventions", "partial_output"}},
+		{name: "description last", budget: 900, wantSections: []string{"conventions", "partial_output", "notes", "description"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			caller := NewCaller(writeFakeAgent(t, "摘要"), false, "text", "")
+			ca := NewCodingAgent(caller, t.TempDir())
+			ca.SetConventions([]string{strings.Repeat("慣例一。", 100), strings.Repeat("慣例二。", 100)})
+			ca.SetPromptBudget(tt.budget)
+
+			tkt := ticket.NewTicket("T-1", "t", description)
+			tkt.PartialOutput = strings.Repeat("上次的輸出。", 300)
+			tkt.Notes = []ticket.Note{{Text: strings.Repeat("舊備註。", 200)}, {Text: strings.Repeat("新備註。", 100)}}
+			tkt.AcceptanceCriteria = []string{"必須保留的驗收標準"}
+
+			compressed, ok := ca.compressPrompt(context.Background(), tkt, ca.buildPrompt(tkt))
+			if !ok {
+				t.Fatal("compressPrompt() ok = false, want true")
+			}
+			if got := tkt.PromptCompression.Sections; !reflect.DeepEqual(got, tt.wantSections) {
+				t.Errorf("Sections = %v, want %v", got, tt.wantSections)
+			}
+			if keep := !slices.Contains(tt.wantSections, "description"); strings.Contains(compressed, description) != keep {
+				t.Errorf("description kept verbatim = %v, want %v", !keep, keep)
+			}
+			if !strings.Contains(compressed, tkt.Notes[1].Text) {
+				t.Error("the latest note must be kept verbatim")
+			}
+			if !strings.Contains(compressed, "- 必須保留的驗收標準") {
+				t.Error("acceptance criteria must be kept verbatim")
+			}
+		})
+	}
+}
+
+func TestCodingAgent_Execute_ClearsPromptCompression(t *testing.T) {
+	caller := NewCaller(writeFakeAgent(t, "done"), false, "text", "")
+	ca := NewCodingAgent(caller, t.TempDir())
+	ca.SetPromptBudget(24000)
+
+	// A retry of a ticket whose previous attempt was compressed, now within budget.
+	tkt := ticket.NewTicket("T-1", "t", "short")
+	tkt.PromptCompression = &ticket.PromptCompression{OriginalChars: 30000, CompressedChars: 20000, Budget: 24000}
+	if _, err := ca.Execute(context.Background(), tkt); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if tkt.PromptCompression != nil {
+		t.Errorf("PromptCompression = %+v, want nil for an attempt that was not compressed", tkt.PromptCompression)
+	}
+}
+
+func TestCodingAgent_compressPrompt_Skipped(t *testing.T) {
+	t.Run("dry run", func(t *testing.T) {
+		caller := NewCaller("agent", false, "text", "")
+		caller.SetDryRun(true)
+		ca := NewCodingAgent(caller, "/p")
+		ca.SetPromptBudget(10)
+		tkt := ticket.NewTicket("T-1", "t", strings.Repeat("x", 2000))
+		if _, ok := ca.compressPrompt(context.Background(), tkt, ca.buildPrompt(tkt)); ok {
+			t.Error("dry run should not compress")
+		}
+		if tkt.PromptCompression != nil {
+			t.Error("PromptCompression should stay nil")
+		}
+	})
+
+	t.Run("summary not shorter than original", func(t *testing.T) {
+		caller := NewCaller(writeFakeAgent(t, strings.Repeat("y", 3000)), false, "text", "")
+		ca := NewCodingAgent(caller, t.TempDir())
+		ca.SetPromptBudget(600)
+		tkt := ticket.NewTicket("T-2", "t", strings.Repeat("x", 2000))
+		if _, ok := ca.compressPrompt(context.Background(), tkt, ca.buildPrompt(tkt)); ok {
+			t.Error("a summary longer than the original should be rejected")
+		}
+	})
+
+	t.Run("description fits its share of the budget", func(t *testing.T) {
+		caller := NewCaller(writeFakeAgent(t, "summary"), false, "text", "")
+		ca := NewCodingAgent(caller, t.TempDir())
+		ca.SetPromptBudget(10)
+		tkt := ticket.NewTicket("T-3", "t", "short")
+		if _, ok := ca.compressPrompt(context.Background(), tkt, ca.buildPrompt(tkt)); ok {
+			t.Error("short description should not be summarized")
+		}
+	})
+}
+
+func TestResult_Text(t *testing.T) {
+	r := &Result{Output: "raw output"}
+	if got := r.Text(); got != "raw output" {
+		t.Errorf("Text() = %q, want raw output", got)
+	}
+
+	r = &Result{
+		Output: "{...}",
+		StreamEvents: []StreamEvent{
+			{Type: "assistant", Data: map[string]interface{}{"type": "assistant"}},
+			{Type: "result", Data: map[string]interface{}{"type": "result", "result": "final answer"}},
+		},
+	}
+	if got := r.Text(); got != "final answer" {
+		t.Errorf("Text() = %q, want final answer", got)
+	}
+
+	// Cut off before the result event: the assistant text so far, not the raw events.
+	r = &Result{Output: "{...}", TimedOut: true}
+	for _, line := range []string{
+		`{"type":"system","subtype":"init"}`,
+		`{"type":"assistant","message":{"content":[{"type":"text","text":"Added the handler."},{"type":"tool_use","name":"Write","input":{"file_path":"a.go"}}]}}`,
+		`{"type":"assistant","message":{"content":[{"type":"text","text":"Tests next."}]}}`,
+	} {
+		r.StreamEvents = append(r.StreamEvents, ClaudeBackend{}.ParseStream(line)...)
+	}
+	if got, want := r.Text(), "Added the handler.\nTests next."; got != want {
+		t.Errorf("Text() without a result event = %q, want %q", got, want)
+	}
+
+	// Events without assistant messages (aider's edit reports) keep the raw output.
+	r = &Result{Output: "raw output", StreamEvents: []StreamEvent{{Type: "tool_call", Subtype: "started"}}}
+	if got := r.Text(); got != "raw output" {
+		t.Errorf("Text() without assistant events = %q, want raw output", got)
+	}
+}
@@ -42,6 +42,7 @@ func (ea *EnhanceAgent) Enhance(ctx context.Context, t *ticket.Ticket) (*ticket.
 	result, jsonData, err := ea.caller.CallForJSON(ctx, prompt, outputFile,
 		WithWorkingDir(ea.projectDir),
 		WithTimeout(5*time.Minute),
+		WithModel(ea.caller.modelFor(ModelKeyEnhance)),
 	)
 
 	if err != nil {
@@ -129,7 +130,7 @@ func (ea *EnhanceAgent) applyEnhancements(t *ticket.Ticket, data map[string]inte
 			enhanced.Description = desc
 		} else {
 			// Append AI suggestions if original exists
-			enhanced.Description = enhanced.Description + "\n\n## AI 補充說明\n" + desc
+			enhanced.Description = enhanced.Description + i18n.AgentEnhanceAppendix + desc
 		}
 	}
 
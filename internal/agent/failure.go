@@ -0,0 +1,111 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrUnavailable reports that no agent caller could be created for a ticket. Wrap it
+// with the cause; ClassifyFailure puts its message in FailureUnavailable.
+var ErrUnavailable = errors.New("agent not available")
+
+// FailureClass groups agent failures whose cause lies outside the ticket, so that
+// several tickets failing the same way point to one systemic problem (see
+// ClassifyFailure). The empty class is a ticket-specific failure.
+type FailureClass string
+
+const (
+	// FailureUnavailable: the agent CLI is not installed or cannot be started.
+	FailureUnavailable FailureClass = "agent_unavailable"
+	// FailureAuth: credentials are missing, invalid or expired.
+	FailureAuth FailureClass = "auth"
+	// FailureQuota: the account ran out of quota or credits.
+	FailureQuota FailureClass = "quota"
+	// FailureNetwork: the model API cannot be reached.
+	FailureNetwork FailureClass = "network"
+	// FailureRateLimit: the model API is rate limited or overloaded.
+	FailureRateLimit FailureClass = "rate_limit"
+)
+
+// failurePatterns lists lower-case substrings of agent errors per class, checked in
+// order. They cover the messages of the supported CLIs and the Messages API.
+var failurePatterns = []struct {
+	class    FailureClass
+	patterns []string
+}{
+	{FailureUnavailable, []string{"executable file not found", "failed to start command", "command not found", "agent not available"}},
+	{FailureAuth, []string{
+		"authentication_error", "authentication failed", "invalid api key", "invalid x-api-key",
+		"unauthorized", "not logged in", "please run /login", "login required",
+		"token has expired", "token expired", "session expired", "permission_error",
+	}},
+	{FailureQuota, []string{"insufficient_quota", "credit balance is too low", "quota exceeded", "usage limit reached", "billing"}},
+	{FailureNetwork, []string{"no such host", "connection refused", "network is unreachable", "tls handshake timeout", "i/o timeout"}},
+	{FailureRateLimit, []string{"rate_limit_error", "rate limit exceeded", "rate limited", "too many requests", "overloaded_error", "overloaded"}},
+}
+
+// ClassifyFailure returns the systemic class of an agent error message, or "" when the
+// failure looks specific to the ticket.
+func ClassifyFailure(message string) FailureClass {
+	lower := strings.ToLower(message)
+	for _, fp := range failurePatterns {
+		for _, p := range fp.patterns {
+			if strings.Contains(lower, p) {
+				return fp.class
+			}
+		}
+	}
+	return ""
+}
+
+// FailureStreak counts consecutive failures of the same systemic class. It is not safe
+// for concurrent use.
+type FailureStreak struct {
+	// Threshold is the streak length that trips the streak; 0 disables it.
+	Threshold int
+
+	class FailureClass
+	count int
+	last  string
+}
+
+// Success ends the current streak.
+func (s *FailureStreak) Success() {
+	s.class, s.count, s.last = "", 0, ""
+}
+
+// Failure records a failure with message and reports whether the streak reached the
+// threshold. A ticket-specific failure ends the streak; a different class starts a new one.
+func (s *FailureStreak) Failure(message string) bool {
+	class := ClassifyFailure(message)
+	switch {
+	case class == "":
+		s.Success()
+		return false
+	case class == s.class:
+		s.count++
+	default:
+		s.class, s.count = class, 1
+	}
+	s.last = message
+	return s.Threshold > 0 && s.count >= s.Threshold
+}
+
+// Class returns the class of the current streak, its length and the last error message.
+func (s *FailureStreak) Class() (class FailureClass, count int, lastError string) {
+	return s.class, s.count, s.last
+}
+
+// probePrompt asks for the shortest possible answer: the probe only checks that the
+// agent reaches the model.
+const probePrompt = "Reply with the single word OK. Do not read, change or run anything."
+
+// Probe makes one cheap call in dir to check that the agent works at all (credentials,
+// quota, model availability) before a batch of calls that would otherwise all fail the
+// same way. It is never retried. Like Call, a failed probe may return a Result without
+// an error; its Error (and output) explain the failure.
+func (c *Caller) Probe(ctx context.Context, dir string, timeout time.Duration) (*Result, error) {
+	return c.Call(ctx, probePrompt, WithWorkingDir(dir), WithTimeout(timeout), WithRetry(0, 0))
+}
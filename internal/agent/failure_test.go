@@ -0,0 +1,104 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	tests := []struct {
+		message string
+		want    FailureClass
+	}{
+		{`exec: "agent": executable file not found in $PATH`, FailureUnavailable},
+		{"sh: 1: claude: command not found", FailureUnavailable},
+		{fmt.Errorf("%w: unknown backend", ErrUnavailable).Error(), FailureUnavailable},
+		{"messages request: unexpected status 401 Unauthorized: {\"type\":\"authentication_error\"}", FailureAuth},
+		{"Invalid API key · Please run /login", FailureAuth},
+		{"Your credit balance is too low to access the API", FailureQuota},
+		{"dial tcp: lookup api.anthropic.com: no such host", FailureNetwork},
+		{`API Error: 429 {"type":"error","error":{"type":"rate_limit_error"}}`, FailureRateLimit},
+		{"Error: Too Many Requests", FailureRateLimit},
+		{"ticket T-1 failed: assertion `go test ./...` failed", ""},
+		{"execution failed", ""},
+	}
+	for _, tt := range tests {
+		if got := ClassifyFailure(tt.message); got != tt.want {
+			t.Errorf("ClassifyFailure(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestFailureStreak(t *testing.T) {
+	s := &FailureStreak{Threshold: 3}
+	auth := "Error: not logged in"
+	steps := []struct {
+		fail    string // "" records a success
+		tripped bool
+		count   int
+	}{
+		{auth, false, 1},
+		{auth, false, 2},
+		{"", false, 0}, // a success ends the streak
+		{auth, false, 1},
+		{"connection refused", false, 1}, // another class starts over
+		{"connection refused", false, 2},
+		{"compile error in main.go", false, 0}, // ticket-specific failure ends it
+		{"connection refused", false, 1},
+		{"connection refused", false, 2},
+		{"dial tcp: connection refused", true, 3},
+	}
+	for i, step := range steps {
+		tripped := false
+		if step.fail == "" {
+			s.Success()
+		} else {
+			tripped = s.Failure(step.fail)
+		}
+		if _, count, _ := s.Class(); tripped != step.tripped || count != step.count {
+			t.Fatalf("step %d: tripped = %v, count = %d; want %v, %d", i, tripped, count, step.tripped, step.count)
+		}
+	}
+	if class, _, last := s.Class(); class != FailureNetwork || last != "dial tcp: connection refused" {
+		t.Errorf("Class() = %q, %q", class, last)
+	}
+
+	if (&FailureStreak{}).Failure(auth) {
+		t.Error("a zero threshold should never trip")
+	}
+}
+
+func TestCaller_Probe(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "agent")
+	script := "#!/bin/sh\necho run >> " + dir + "/runs\necho 'Error: Invalid API key'\nexit " + fmt.Sprint(ExitCodeTransient) + "\n"
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	caller := NewCaller(path, false, "text", "")
+	// The caller's retries do not apply to the probe.
+	caller.SetRetry(2, time.Millisecond)
+	result, err := caller.Probe(context.Background(), dir, time.Minute)
+	if err != nil {
+		t.Fatalf("Probe() error = %v", err)
+	}
+	if result.Success || result.Attempts != 1 {
+		t.Errorf("Success = %v, Attempts = %d, want false, 1", result.Success, result.Attempts)
+	}
+	if !strings.Contains(result.Output, "Invalid API key") {
+		t.Errorf("Output = %q, want the agent's error", result.Output)
+	}
+	if runs, _ := os.ReadFile(filepath.Join(dir, "runs")); strings.Count(string(runs), "run") != 1 {
+		t.Errorf("agent ran %d times, want 1", strings.Count(string(runs), "run"))
+	}
+}
@@ -0,0 +1,105 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/golden"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// goldenProject is a minimal Go project, so project type detection is stable.
+const goldenProject = "testdata/project"
+
+// goldenTicket returns a ticket exercising every optional section of the prompts.
+func goldenTicket() *ticket.Ticket {
+	return &ticket.Ticket{
+		ID:                  "TICKET-001-login",
+		Title:               "Implement login endpoint",
+		Description:         "POST /login validates credentials and returns a session token.",
+		Type:                ticket.TypeFeature,
+		Priority:            1,
+		Status:              ticket.StatusPending,
+		EstimatedComplexity: "medium",
+		Dependencies:        []string{"TICKET-000-setup"},
+		AcceptanceCriteria:  []string{"Valid credentials return 200 with a token", "Invalid credentials return 401"},
+		FilesToCreate:       []string{"internal/auth/login.go"},
+		FilesToModify:       []string{"cmd/server/main.go"},
+		PartialOutput:       "Created internal/auth/login.go with the handler skeleton.",
+		Notes: []ticket.Note{
+			{Author: "alice", Text: "Use bcrypt for password hashes.", CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+		},
+	}
+}
+
+// TestGolden_Prompts renders the prompt of every agent type in each language.
+func TestGolden_Prompts(t *testing.T) {
+	t.Cleanup(func() { _ = i18n.SetLanguage(i18n.LangZhTW) })
+
+	for _, lang := range []string{i18n.LangZhTW, i18n.LangEn} {
+		if err := i18n.SetLanguage(lang); err != nil {
+			t.Fatal(err)
+		}
+
+		coding := NewCodingAgent(nil, goldenProject)
+		coding.SetConventions([]string{"Wrap errors with %w", "Table-driven tests"})
+		review := NewReviewAgent(nil, goldenProject)
+		review.SetNotes([]string{"Use bcrypt for password hashes."})
+		review.SetDiff("diff --git a/cmd/server/main.go b/cmd/server/main.go\n--- a/cmd/server/main.go\n+++ b/cmd/server/main.go\n@@ -3 +3,2 @@\n mux := http.NewServeMux()\n+mux.HandleFunc(\"/login\", auth.Login)\n")
+
+		prompts := map[string]string{
+			"coding":       coding.Prompt(goldenTicket()),
+			"review":       review.Prompt([]string{"internal/auth/login.go", "cmd/server/main.go"}),
+			"test":         NewTestAgent(nil, goldenProject).Prompt(),
+			"test_failure": NewTestAgent(nil, goldenProject).FailurePrompt("$ go test ./...\n--- FAIL: TestLogin (0.00s)\n    login_test.go:12: status = 500, want 200\nFAIL\texample.com/demo/auth\t0.031s\n"),
+			"planning":     NewPlanningAgent(nil, goldenProject, ".tickets").Prompt("docs/milestone-001.md", ".tickets/generated-tickets.json"),
+			"commit":       NewCommitAgent(nil, goldenProject).Prompt("TICKET-001-login", "Implement login endpoint", "feature implemented", []string{"internal/auth/login.go"}),
+			"enhance":      NewEnhanceAgent(nil, goldenProject).Prompt(goldenTicket()),
+			"analyze":      NewAnalyzeAgent(nil, goldenProject).Prompt(AllScopes()),
+		}
+		for name, prompt := range prompts {
+			t.Run(lang+"/"+name, func(t *testing.T) {
+				golden.Assert(t, "prompts/"+lang+"/"+name+".txt", []byte(prompt))
+			})
+		}
+	}
+}
+
+// TestGolden_ParseOutputs parses every recorded agent output under testdata/outputs.
+func TestGolden_ParseOutputs(t *testing.T) {
+	parsers := map[string]func(t *testing.T, data []byte) any{
+		"review": func(t *testing.T, data []byte) any { return ParseReviewOutput(string(data)) },
+		"test":   func(t *testing.T, data []byte) any { return ParseTestOutput(string(data)) },
+		"planning": func(t *testing.T, data []byte) any {
+			tickets, err := ParsePlanningOutput(data)
+			if err != nil {
+				t.Fatalf("ParsePlanningOutput() error = %v", err)
+			}
+			for _, tk := range tickets {
+				tk.CreatedAt = time.Time{}
+			}
+			return tickets
+		},
+	}
+
+	for kind, parse := range parsers {
+		files, err := filepath.Glob(filepath.Join("testdata", "outputs", kind, "*"))
+		if err != nil || len(files) == 0 {
+			t.Fatalf("no recorded %s outputs: %v", kind, err)
+		}
+		for _, file := range files {
+			name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+			t.Run(kind+"/"+name, func(t *testing.T) {
+				data, err := os.ReadFile(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				golden.AssertJSON(t, "parse/"+kind+"/"+name+".json", parse(t, data))
+			})
+		}
+	}
+}
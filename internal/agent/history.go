@@ -0,0 +1,39 @@
+package agent
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// Agent roles recorded in ticket histories.
+const (
+	roleCoding = "coding"
+	roleFix    = "fix"
+	roleVerify = "verify"
+)
+
+// recordCallStarted records in t's history that an agent call in role starts, and
+// returns the start time for recordCallFinished.
+func recordCallStarted(t *ticket.Ticket, role string) time.Time {
+	t.AddEvent(ticket.Event{Kind: ticket.EventAgentStarted, Detail: role})
+	return time.Now()
+}
+
+// recordCallFinished records in t's history how the agent call started at start ended
+// and how long it took.
+func recordCallFinished(t *ticket.Ticket, start time.Time, result *Result, err error) {
+	e := ticket.Event{Kind: ticket.EventAgentFinished, Duration: time.Since(start)}
+	switch {
+	case result != nil && result.TimedOut:
+		e.Detail = "timed out"
+	case err != nil:
+		e.Detail = "error: " + err.Error()
+	case result != nil && !result.Success:
+		e.Detail = fmt.Sprintf("failed (exit %d)", result.ExitCode)
+	default:
+		e.Detail = "ok"
+	}
+	t.AddEvent(e)
+}
@@ -0,0 +1,94 @@
+package agent
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// junitSuite is a <testsuite> or <testsuites> element of a JUnit XML report; both nest
+// suites and test cases the same way.
+type junitSuite struct {
+	Suites []junitSuite `xml:"testsuite"`
+	Cases  []junitCase  `xml:"testcase"`
+}
+
+// junitCase is a <testcase>: failed with a <failure> or <error>, skipped with <skipped>.
+type junitCase struct {
+	Failures []struct{} `xml:"failure"`
+	Errors   []struct{} `xml:"error"`
+	Skipped  *struct{}  `xml:"skipped"`
+}
+
+// count adds the suite's test cases, including those of nested suites, to r.
+func (s junitSuite) count(r *TestResult) {
+	for _, c := range s.Cases {
+		switch {
+		case len(c.Failures) > 0 || len(c.Errors) > 0:
+			r.Failed++
+		case c.Skipped != nil:
+			r.Skipped++
+		default:
+			r.Passed++
+		}
+	}
+	for _, nested := range s.Suites {
+		nested.count(r)
+	}
+}
+
+// SetJUnitReports sets glob patterns, relative to the project directory, of the JUnit
+// XML reports the test run may write (see config test_junit_reports). Reports written
+// during RunTests take precedence over the counts parsed from the output.
+func (ta *TestAgent) SetJUnitReports(patterns []string) {
+	ta.junitReports = patterns
+}
+
+// readJUnitReports sums the test cases of the reports matching patterns in dir that
+// were written at or after since, so reports left by an earlier run are ignored. since
+// is compared at second precision, as some file systems store no finer times.
+// Returns false when no such report could be read.
+func readJUnitReports(dir string, patterns []string, since time.Time) (*TestResult, bool) {
+	result := &TestResult{}
+	found := false
+	seen := make(map[string]bool)
+	for _, pattern := range patterns {
+		paths, _ := filepath.Glob(filepath.Join(dir, pattern))
+		for _, path := range paths {
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+			info, err := os.Stat(path)
+			if err != nil || info.IsDir() || info.ModTime().Before(since.Truncate(time.Second)) {
+				continue
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			var suite junitSuite
+			if err := xml.Unmarshal(data, &suite); err != nil {
+				continue
+			}
+			suite.count(result)
+			found = true
+		}
+	}
+	return result, found
+}
+
+// applyJUnitReports replaces the counts of testResult with those of the JUnit reports
+// written since start, when there are any.
+func (ta *TestAgent) applyJUnitReports(testResult *TestResult, start time.Time) {
+	if testResult == nil || len(ta.junitReports) == 0 {
+		return
+	}
+	report, ok := readJUnitReports(ta.projectDir, ta.junitReports, start)
+	if !ok {
+		return
+	}
+	testResult.Passed, testResult.Failed, testResult.Skipped = report.Passed, report.Failed, report.Skipped
+	testResult.Summary = summarizeTestResult(report.Passed, report.Failed, report.Skipped)
+}
@@ -0,0 +1,61 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadJUnitReports(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	patterns := []string{"junit.xml", "reports/*.xml"}
+
+	if _, ok := readJUnitReports(dir, patterns, time.Now()); ok {
+		t.Error("readJUnitReports() without reports should report none")
+	}
+
+	// A report left by an earlier run is ignored.
+	stale := write("reports/old.xml", `<testsuite><testcase name="a"><failure/></testcase></testsuite>`)
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(stale, old, old); err != nil {
+		t.Fatal(err)
+	}
+	write("junit.xml", `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="auth">
+    <testcase name="login"/>
+    <testcase name="logout"><failure message="500 != 204"/></testcase>
+    <testcase name="refresh"><error message="panic"/></testcase>
+    <testsuite name="nested">
+      <testcase name="sso"><skipped/></testcase>
+    </testsuite>
+  </testsuite>
+</testsuites>`)
+	write("reports/api.xml", `<testsuite name="api"><testcase name="get"/><testcase name="put"/></testsuite>`)
+	write("reports/broken.xml", `<testsuite`)
+
+	got, ok := readJUnitReports(dir, patterns, time.Now().Add(-time.Minute))
+	if !ok || got.Passed != 3 || got.Failed != 2 || got.Skipped != 1 {
+		t.Errorf("readJUnitReports() = %+v, %v; want 3 passed, 2 failed, 1 skipped", got, ok)
+	}
+
+	ta := NewTestAgent(nil, dir)
+	ta.SetJUnitReports(patterns)
+	result := &TestResult{Passed: 1, Summary: "1 passed"}
+	ta.applyJUnitReports(result, time.Now().Add(-time.Minute))
+	if result.Passed != 3 || result.Failed != 2 || result.Summary != "3 passed, 2 failed, 1 skipped" {
+		t.Errorf("applyJUnitReports() = %+v, want the reports' counts", result)
+	}
+}
@@ -0,0 +1,189 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/jsonutil"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// MilestoneReviewAgent asks the agent whether the implemented result meets the
+// acceptance criteria section of the milestone the tickets were planned from. It runs
+// once all of the milestone's tickets are completed, as the last stage of the pipeline.
+type MilestoneReviewAgent struct {
+	caller     *Caller
+	projectDir string
+}
+
+// NewMilestoneReviewAgent creates a MilestoneReviewAgent with the given Caller and project directory.
+func NewMilestoneReviewAgent(caller *Caller, projectDir string) *MilestoneReviewAgent {
+	return &MilestoneReviewAgent{
+		caller:     caller,
+		projectDir: projectDir,
+	}
+}
+
+// CriterionResult is the verdict on one acceptance criterion of the milestone.
+type CriterionResult struct {
+	Criterion string
+	Met       bool
+	// Evidence is what the verdict is based on, e.g. files, tests or command output.
+	Evidence string
+	// Gap describes what is missing for an unmet criterion.
+	Gap string
+	// FollowUp is the ticket suggested to close the gap; nil for met criteria.
+	FollowUp *ticket.Ticket
+}
+
+// MilestoneReview is the gap report of a milestone: a verdict per acceptance criterion.
+type MilestoneReview struct {
+	Milestone string
+	Summary   string
+	Criteria  []CriterionResult
+}
+
+// Unmet returns the criteria that are not met.
+func (r *MilestoneReview) Unmet() []CriterionResult {
+	var unmet []CriterionResult
+	for _, c := range r.Criteria {
+		if !c.Met {
+			unmet = append(unmet, c)
+		}
+	}
+	return unmet
+}
+
+// acceptanceHeading matches the Markdown heading of a milestone's acceptance criteria
+// section, e.g. "## 驗收標準", "### 7. 驗收條件" or "## Acceptance Criteria".
+var acceptanceHeading = regexp.MustCompile(`(?i)^(#{1,6})\s+(?:\d+[.)]?\s*)?(?:驗收標準|驗收條件|acceptance(?:\s+criteria)?)`)
+
+// AcceptanceSection returns the body of the acceptance criteria section of milestone
+// Markdown: the lines after its heading up to the next heading of the same or a higher
+// level. Returns "" when there is no such section.
+func AcceptanceSection(markdown string) string {
+	lines := strings.Split(markdown, "\n")
+	for i, line := range lines {
+		m := acceptanceHeading.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		level := len(m[1])
+		var body []string
+		for _, next := range lines[i+1:] {
+			trimmed := strings.TrimSpace(next)
+			if h := strings.TrimLeft(trimmed, "#"); strings.HasPrefix(trimmed, "#") && len(trimmed)-len(h) <= level && strings.HasPrefix(h, " ") {
+				break
+			}
+			body = append(body, next)
+		}
+		return strings.TrimSpace(strings.Join(body, "\n"))
+	}
+	return ""
+}
+
+// Review evaluates the project against the acceptance criteria section of milestoneFile,
+// given the tickets implemented for it. The agent writes its verdicts to
+// .tickets/acceptance-review.json. Returns nil and no error when the milestone has no
+// such section, as there is nothing to review against. On dry run, returns a review
+// without verdicts.
+func (ma *MilestoneReviewAgent) Review(ctx context.Context, milestoneFile string, tickets []*ticket.Ticket) (*MilestoneReview, error) {
+	content, err := os.ReadFile(milestoneFile)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.ErrAgentReadMilestone, err)
+	}
+	section := AcceptanceSection(string(content))
+	if section == "" {
+		return nil, nil
+	}
+
+	outputFile := filepath.Join(ma.projectDir, ".tickets", "acceptance-review.json")
+	if err := os.MkdirAll(filepath.Dir(outputFile), 0700); err != nil {
+		return nil, fmt.Errorf(i18n.ErrAgentMkdirOutput, err)
+	}
+
+	review := &MilestoneReview{Milestone: milestoneFile}
+	result, data, err := ma.caller.CallForJSON(ctx, ma.buildPrompt(milestoneFile, section, tickets), outputFile,
+		WithWorkingDir(ma.projectDir),
+		WithTimeout(15*time.Minute),
+		WithModel(ma.caller.modelFor(ModelKeyReview)),
+	)
+	if err != nil {
+		if ma.caller.DryRun {
+			return review, nil
+		}
+		return nil, fmt.Errorf(i18n.ErrAgentAcceptanceFailed, err)
+	}
+	if !result.Success {
+		return nil, fmt.Errorf(i18n.ErrAgentAcceptanceOutput, result.Error)
+	}
+	ma.parseReview(review, data)
+	return review, nil
+}
+
+// buildPrompt renders the acceptance review prompt for the milestone's criteria section
+// and the tickets implemented for it.
+func (ma *MilestoneReviewAgent) buildPrompt(milestoneFile, section string, tickets []*ticket.Ticket) string {
+	var list strings.Builder
+	for _, t := range tickets {
+		list.WriteString(fmt.Sprintf("- %s: %s\n", t.ID, t.Title))
+	}
+	return fmt.Sprintf(i18n.AgentAcceptancePrompt, ma.projectDir, milestoneFile, section, list.String())
+}
+
+// parseReview fills review from the agent's JSON output. Unmet criteria without a
+// suggested follow-up get one built from the criterion and its gap.
+func (ma *MilestoneReviewAgent) parseReview(review *MilestoneReview, data map[string]interface{}) {
+	review.Summary = jsonutil.GetString(data, "summary")
+	items, _ := data["criteria"].([]interface{})
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		c := CriterionResult{
+			Criterion: jsonutil.GetString(m, "criterion"),
+			Met:       jsonutil.GetBool(m, "met"),
+			Evidence:  jsonutil.GetString(m, "evidence"),
+			Gap:       jsonutil.GetString(m, "gap"),
+		}
+		if c.Criterion == "" {
+			continue
+		}
+		if !c.Met {
+			followUp, _ := m["follow_up"].(map[string]interface{})
+			c.FollowUp = followUpTicket(c, followUp)
+		}
+		review.Criteria = append(review.Criteria, c)
+	}
+}
+
+// followUpTicket builds the pending ticket closing the gap of an unmet criterion from
+// the agent's suggestion (title, description, type, priority), falling back to the
+// criterion itself. The ticket's acceptance criterion is the milestone criterion.
+func followUpTicket(c CriterionResult, suggestion map[string]interface{}) *ticket.Ticket {
+	title := jsonutil.GetString(suggestion, "title")
+	if title == "" {
+		title = fmt.Sprintf(i18n.AgentAcceptanceFollowUpTitle, c.Criterion)
+	}
+	description := jsonutil.GetString(suggestion, "description")
+	if description == "" {
+		description = c.Gap
+	}
+	t := ticket.NewTicket("", title, description)
+	if typ := ticket.Type(jsonutil.GetString(suggestion, "type")); typ != "" && typ != ticket.TypeEpic {
+		t.Type = typ
+	}
+	t.Priority = 2
+	if p := jsonutil.GetInt(suggestion, "priority"); p > 0 {
+		t.Priority = p
+	}
+	t.AcceptanceCriteria = []string{c.Criterion}
+	return t
+}
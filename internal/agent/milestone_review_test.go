@@ -0,0 +1,113 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestAcceptanceSection(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{
+			name:     "chinese heading",
+			markdown: "# M1\n\n## 目標\n做事\n\n## 驗收標準\n- 可以登入\n- 可以登出\n\n## 備註\n無",
+			want:     "- 可以登入\n- 可以登出",
+		},
+		{
+			name:     "numbered heading keeps nested subsections",
+			markdown: "# M1\n\n## 7. 驗收條件\n\n### API\n- 回傳 200\n\n# Appendix\nx",
+			want:     "### API\n- 回傳 200",
+		},
+		{
+			name:     "english heading to end of file",
+			markdown: "# Milestone\n\n### Acceptance Criteria\n- tests pass",
+			want:     "- tests pass",
+		},
+		{
+			name:     "no section",
+			markdown: "# Milestone\n\n## Goals\n- ship it",
+			want:     "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AcceptanceSection(tt.markdown); got != tt.want {
+				t.Errorf("AcceptanceSection() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMilestoneReviewAgent_parseReview(t *testing.T) {
+	data := map[string]interface{}{
+		"summary": "mostly done",
+		"criteria": []interface{}{
+			map[string]interface{}{"criterion": "login works", "met": true, "evidence": "auth_test.go"},
+			map[string]interface{}{"criterion": "logout works", "met": false, "gap": "no endpoint",
+				"follow_up": map[string]interface{}{"title": "Add logout endpoint", "type": "feature", "priority": float64(1)}},
+			map[string]interface{}{"criterion": "docs updated", "met": false, "gap": "README missing",
+				"follow_up": map[string]interface{}{"type": "epic"}},
+			map[string]interface{}{"met": false},
+		},
+	}
+	review := &MilestoneReview{}
+	NewMilestoneReviewAgent(nil, "/p").parseReview(review, data)
+
+	if review.Summary != "mostly done" || len(review.Criteria) != 3 {
+		t.Fatalf("parseReview() = %+v, want summary and 3 criteria", review)
+	}
+	unmet := review.Unmet()
+	if len(unmet) != 2 {
+		t.Fatalf("Unmet() = %d criteria, want 2", len(unmet))
+	}
+	if f := unmet[0].FollowUp; f.Title != "Add logout endpoint" || f.Type != ticket.TypeFeature || f.Priority != 1 || f.Description != "no endpoint" {
+		t.Errorf("suggested follow-up = %+v", f)
+	}
+	if f := unmet[1].FollowUp; f.Title == "" || f.Type == ticket.TypeEpic || f.Priority != 2 || len(f.AcceptanceCriteria) != 1 || f.AcceptanceCriteria[0] != "docs updated" {
+		t.Errorf("fallback follow-up = %+v", f)
+	}
+	if review.Criteria[0].FollowUp != nil {
+		t.Error("met criterion should have no follow-up")
+	}
+}
+
+func TestMilestoneReviewAgent_Review(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	projectDir := t.TempDir()
+	script := "#!/bin/sh\nmkdir -p .tickets\ncat > .tickets/acceptance-review.json <<'EOF'\n" +
+		`{"summary": "ok", "criteria": [{"criterion": "tests pass", "met": true}]}` + "\nEOF\n"
+	agentPath := filepath.Join(t.TempDir(), "fake-agent")
+	if err := os.WriteFile(agentPath, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	milestone := filepath.Join(projectDir, "m1.md")
+	if err := os.WriteFile(milestone, []byte("# M1\n\n## Acceptance Criteria\n- tests pass\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ma := NewMilestoneReviewAgent(NewCaller(agentPath, true, "text", ""), projectDir)
+
+	review, err := ma.Review(context.Background(), milestone, nil)
+	if err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+	if review == nil || len(review.Criteria) != 1 || !review.Criteria[0].Met {
+		t.Errorf("Review() = %+v, want one met criterion", review)
+	}
+
+	if err := os.WriteFile(milestone, []byte("# M1\n\n## Goals\n- ship\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if review, err := ma.Review(context.Background(), milestone, nil); review != nil || err != nil {
+		t.Errorf("Review() without criteria = %+v, %v; want nil, nil", review, err)
+	}
+}
@@ -0,0 +1,66 @@
+package agent
+
+import (
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// Model keys for the agents other than the coding agent, as used in the models setting.
+const (
+	ModelKeyDefault  = "default"
+	ModelKeyCoding   = "coding"
+	ModelKeyPlanning = "planning" // plan and init
+	ModelKeyAnalyze  = "analyze"
+	ModelKeyReview   = "review"
+	ModelKeyTesting  = "testing"
+	ModelKeyCommit   = "commit"
+	ModelKeyEnhance  = "enhance"
+)
+
+// WithModel selects the model of the call, overriding the backend's default. An empty
+// model keeps the default.
+func WithModel(model string) CallOption {
+	return func(o *callOptions) {
+		o.model = model
+	}
+}
+
+// SetModels sets the model per key (see the ModelKey constants and TicketModelKeys)
+// that the agents pass to WithModel. Keys are case-insensitive.
+func (c *Caller) SetModels(models map[string]string) {
+	c.models = make(map[string]string, len(models))
+	for k, v := range models {
+		c.models[strings.ToLower(k)] = v
+	}
+}
+
+// modelFor returns the model of the first key that has one, else the default model,
+// else "" (the backend's default).
+func (c *Caller) modelFor(keys ...string) string {
+	for _, k := range append(keys, ModelKeyDefault) {
+		if m := c.models[k]; m != "" {
+			return m
+		}
+	}
+	return ""
+}
+
+// TicketModelKeys returns the keys checked, most specific first, for the model that
+// codes t: "<type>-<complexity>" (e.g. feature-high), "<type>", "<complexity>", then
+// "coding".
+func TicketModelKeys(t *ticket.Ticket) []string {
+	typ := strings.ToLower(string(t.Type))
+	complexity := strings.ToLower(t.EstimatedComplexity)
+	var keys []string
+	if typ != "" && complexity != "" {
+		keys = append(keys, typ+"-"+complexity)
+	}
+	if typ != "" {
+		keys = append(keys, typ)
+	}
+	if complexity != "" {
+		keys = append(keys, complexity)
+	}
+	return append(keys, ModelKeyCoding)
+}
@@ -0,0 +1,47 @@
+package agent
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestTicketModelKeys(t *testing.T) {
+	tests := []struct {
+		ticket *ticket.Ticket
+		want   []string
+	}{
+		{&ticket.Ticket{Type: ticket.TypeFeature, EstimatedComplexity: "High"}, []string{"feature-high", "feature", "high", "coding"}},
+		{&ticket.Ticket{Type: ticket.TypeDocs}, []string{"docs", "coding"}},
+		{&ticket.Ticket{}, []string{"coding"}},
+	}
+	for _, tt := range tests {
+		if got := TicketModelKeys(tt.ticket); !slices.Equal(got, tt.want) {
+			t.Errorf("TicketModelKeys(%s, %s) = %v, want %v", tt.ticket.Type, tt.ticket.EstimatedComplexity, got, tt.want)
+		}
+	}
+}
+
+func TestCaller_modelFor(t *testing.T) {
+	c := NewCaller("agent", false, "text", "")
+	if got := c.modelFor(ModelKeyReview); got != "" {
+		t.Errorf("modelFor() without models = %q, want the CLI default", got)
+	}
+
+	c.SetModels(map[string]string{"Feature-High": "opus", "docs": "haiku", "coding": "sonnet", "default": "sonnet-lite"})
+	tests := []struct {
+		keys []string
+		want string
+	}{
+		{TicketModelKeys(&ticket.Ticket{Type: ticket.TypeFeature, EstimatedComplexity: "high"}), "opus"},
+		{TicketModelKeys(&ticket.Ticket{Type: ticket.TypeFeature, EstimatedComplexity: "low"}), "sonnet"},
+		{TicketModelKeys(&ticket.Ticket{Type: ticket.TypeDocs, EstimatedComplexity: "high"}), "haiku"},
+		{[]string{ModelKeyReview}, "sonnet-lite"},
+	}
+	for _, tt := range tests {
+		if got := c.modelFor(tt.keys...); got != tt.want {
+			t.Errorf("modelFor(%v) = %q, want %q", tt.keys, got, tt.want)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package agent
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/anthropic/agent-orchestrator/internal/i18n"
 	"github.com/anthropic/agent-orchestrator/internal/jsonutil"
+	"github.com/anthropic/agent-orchestrator/internal/prompts"
 	"github.com/anthropic/agent-orchestrator/internal/ticket"
 )
 
@@ -21,6 +23,7 @@ type PlanningAgent struct {
 	caller     *Caller
 	projectDir string
 	ticketsDir string
+	epics      bool // ask for one epic per milestone phase
 }
 
 // NewPlanningAgent creates a PlanningAgent with the given Caller, project directory, and tickets directory.
@@ -32,13 +35,27 @@ func NewPlanningAgent(caller *Caller, projectDir, ticketsDir string) *PlanningAg
 	}
 }
 
+// SetEpics asks the agent to group tickets under one epic per milestone phase, with
+// child tickets pointing to their epic via parent_id.
+func (pa *PlanningAgent) SetEpics(enabled bool) {
+	pa.epics = enabled
+}
+
 // Plan reads the milestone file, invokes the agent to generate tickets, and returns the parsed list.
 // Output is written to ticketsDir/generated-tickets.json. On dry run, returns mock tickets.
 func (pa *PlanningAgent) Plan(ctx context.Context, milestoneFile string) ([]*ticket.Ticket, error) {
-	// Read milestone file
-	content, err := os.ReadFile(milestoneFile)
-	if err != nil {
-		return nil, fmt.Errorf(i18n.ErrAgentReadMilestone, err)
+	return pa.PlanAll(ctx, []string{milestoneFile})
+}
+
+// PlanAll plans several milestone files in one agent call, so tickets of one milestone
+// can depend on tickets of another. With more than one file, each ticket's Milestone
+// is the file the agent assigned it to, as listed in milestoneFiles.
+func (pa *PlanningAgent) PlanAll(ctx context.Context, milestoneFiles []string) ([]*ticket.Ticket, error) {
+	// Make sure every milestone file is readable before calling the agent
+	for _, f := range milestoneFiles {
+		if _, err := os.ReadFile(f); err != nil {
+			return nil, fmt.Errorf(i18n.ErrAgentReadMilestone, err)
+		}
 	}
 
 	// Prepare output file
@@ -47,12 +64,13 @@ func (pa *PlanningAgent) Plan(ctx context.Context, milestoneFile string) ([]*tic
 		return nil, fmt.Errorf(i18n.ErrAgentMkdirOutput, err)
 	}
 
-	prompt := pa.buildPlanningPrompt(string(content), milestoneFile, outputFile)
+	prompt := pa.buildPlanningPrompt(milestoneFiles, outputFile)
 
 	result, jsonData, err := pa.caller.CallForJSON(ctx, prompt, outputFile,
-		WithContextFiles(milestoneFile),
+		WithContextFiles(milestoneFiles...),
 		WithWorkingDir(pa.projectDir),
 		WithTimeout(10*time.Minute),
+		WithModel(pa.caller.modelFor(ModelKeyPlanning)),
 	)
 
 	if err != nil {
@@ -70,16 +88,22 @@ func (pa *PlanningAgent) Plan(ctx context.Context, milestoneFile string) ([]*tic
 	return pa.parseTickets(jsonData)
 }
 
-// buildPlanningPrompt creates the prompt for the planning agent
-func (pa *PlanningAgent) buildPlanningPrompt(content, milestoneFile, outputFile string) string {
-	return fmt.Sprintf(i18n.AgentPlanningPromptTemplate, milestoneFile, outputFile)
+// buildPlanningPrompt renders the planning prompt (see prompts.PlanningData).
+func (pa *PlanningAgent) buildPlanningPrompt(milestoneFiles []string, outputFile string) string {
+	return pa.caller.renderPrompt(prompts.Planning, prompts.PlanningData{
+		ProjectRoot:    pa.projectDir,
+		MilestoneFile:  milestoneFiles[0],
+		MilestoneFiles: milestoneFiles,
+		OutputFile:     outputFile,
+		Epics:          pa.epics,
+	})
 }
 
 // parseTickets parses the JSON output into tickets
 func (pa *PlanningAgent) parseTickets(data map[string]interface{}) ([]*ticket.Ticket, error) {
 	ticketsData, ok := data["tickets"].([]interface{})
 	if !ok {
-		return nil, fmt.Errorf(i18n.ErrAgentInvalidTickets)
+		return nil, errors.New(i18n.ErrAgentInvalidTickets)
 	}
 
 	tickets := make([]*ticket.Ticket, 0)
@@ -126,6 +150,10 @@ func (pa *PlanningAgent) mapToTicket(data map[string]interface{}) *ticket.Ticket
 		t.Dependencies = deps
 	}
 
+	if soft := jsonutil.GetStringSlice(data, "soft_dependencies"); soft != nil {
+		t.SoftDependencies = soft
+	}
+
 	if criteria := jsonutil.GetStringSlice(data, "acceptance_criteria"); criteria != nil {
 		t.AcceptanceCriteria = criteria
 	}
@@ -138,9 +166,49 @@ func (pa *PlanningAgent) mapToTicket(data map[string]interface{}) *ticket.Ticket
 		t.FilesToModify = files
 	}
 
+	if labels := jsonutil.GetStringSlice(data, "labels"); labels != nil {
+		t.Labels = ticket.NormalizeLabels(labels)
+	}
+
+	if parent, ok := data["parent_id"].(string); ok {
+		t.ParentID = parent
+	}
+
+	if milestone, ok := data["milestone"].(string); ok {
+		t.Milestone = milestone
+	}
+
+	t.Assertions = parseAssertions(data["assertions"])
+
 	return t
 }
 
+// parseAssertions reads the optional "assertions" list: objects with command,
+// exit_code, output_pattern and timeout_sec. Entries without a command are skipped.
+func parseAssertions(v interface{}) []ticket.Assertion {
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+	var out []ticket.Assertion
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		a := ticket.Assertion{
+			Command:       jsonutil.GetString(m, "command"),
+			ExitCode:      jsonutil.GetInt(m, "exit_code"),
+			OutputPattern: jsonutil.GetString(m, "output_pattern"),
+			TimeoutSec:    jsonutil.GetInt(m, "timeout_sec"),
+		}
+		if a.Command != "" {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
 // createMockTickets creates mock tickets for dry run
 func (pa *PlanningAgent) createMockTickets() []*ticket.Ticket {
 	return []*ticket.Ticket{
@@ -206,19 +274,19 @@ type ProjectSummary struct {
 func (ps *ProjectSummary) String() string {
 	var sb strings.Builder
 	if ps.Language != "" {
-		sb.WriteString(fmt.Sprintf("  - 語言: %s\n", ps.Language))
+		sb.WriteString(fmt.Sprintf(i18n.AgentSummaryLanguage, ps.Language))
 	}
 	if ps.Framework != "" {
-		sb.WriteString(fmt.Sprintf("  - 框架: %s\n", ps.Framework))
+		sb.WriteString(fmt.Sprintf(i18n.AgentSummaryFramework, ps.Framework))
 	}
 	if ps.Structure != "" {
-		sb.WriteString(fmt.Sprintf("  - 結構: %s\n", ps.Structure))
+		sb.WriteString(fmt.Sprintf(i18n.AgentSummaryStructure, ps.Structure))
 	}
 	if ps.HasTests {
-		sb.WriteString("  - 已有測試: 是\n")
+		sb.WriteString(i18n.AgentSummaryHasTests)
 	}
 	if ps.HasDocs {
-		sb.WriteString("  - 已有文件: 是\n")
+		sb.WriteString(i18n.AgentSummaryHasDocs)
 	}
 	return sb.String()
 }
@@ -249,6 +317,7 @@ func (ia *InitAgent) ScanProject(ctx context.Context) (*ProjectSummary, error) {
 	result, err := ia.caller.Call(ctx, prompt,
 		WithWorkingDir(ia.projectDir),
 		WithTimeout(3*time.Minute),
+		WithModel(ia.caller.modelFor(ModelKeyPlanning)),
 	)
 
 	if err != nil {
@@ -336,7 +405,7 @@ func (ia *InitAgent) GenerateQuestions(ctx context.Context, goal string, summary
 		prompt = fmt.Sprintf(i18n.AgentInitQuestionsNew, goal)
 	}
 
-	result, err := ia.caller.Call(ctx, prompt, WithTimeout(2*time.Minute))
+	result, err := ia.caller.Call(ctx, prompt, WithTimeout(2*time.Minute), WithModel(ia.caller.modelFor(ModelKeyPlanning)))
 	if err != nil {
 		return nil, err
 	}
@@ -378,22 +447,22 @@ func (ia *InitAgent) parseQuestions(output string) ([]string, error) {
 // defaultQuestions returns default questions for new projects
 func (ia *InitAgent) defaultQuestions() []string {
 	return []string{
-		"這個專案使用什麼程式語言？",
-		"主要的目標使用者是誰？",
-		"有什麼關鍵功能需求？",
-		"有沒有效能或規模上的需求？",
-		"需要什麼輸出格式或介面？",
+		i18n.AgentQuestionLanguage,
+		i18n.AgentQuestionUsers,
+		i18n.AgentQuestionFeatures,
+		i18n.AgentQuestionScale,
+		i18n.AgentQuestionOutput,
 	}
 }
 
 // defaultQuestionsExisting returns default questions for existing projects
 func (ia *InitAgent) defaultQuestionsExisting() []string {
 	return []string{
-		"這個新功能如何與現有架構整合？",
-		"是否需要修改現有的模組或 API？",
-		"有沒有相容性的考量？",
-		"需要新增哪些測試？",
-		"是否需要更新文件？",
+		i18n.AgentQuestionIntegrate,
+		i18n.AgentQuestionModify,
+		i18n.AgentQuestionCompat,
+		i18n.AgentQuestionTests,
+		i18n.AgentQuestionDocs,
 	}
 }
 
@@ -440,6 +509,7 @@ func (ia *InitAgent) GenerateMilestone(ctx context.Context, goal string, questio
 	result, err := ia.caller.Call(ctx, prompt,
 		WithWorkingDir(ia.projectDir),
 		WithTimeout(5*time.Minute),
+		WithModel(ia.caller.modelFor(ModelKeyPlanning)),
 	)
 
 	if err != nil {
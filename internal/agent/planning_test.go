@@ -13,11 +13,10 @@ import (
 
 func TestPlanningAgent_buildPlanningPrompt_outputFormat(t *testing.T) {
 	pa := NewPlanningAgent(nil, "/test/project", "/test/tickets")
-	content := "# Milestone"
 	milestoneFile := "/path/milestone.md"
 	outputFile := "/test/tickets/generated-tickets.json"
 
-	prompt := pa.buildPlanningPrompt(content, milestoneFile, outputFile)
+	prompt := pa.buildPlanningPrompt([]string{milestoneFile}, outputFile)
 
 	wantContains := []string{
 		"你是一個專案規劃 Agent",
@@ -149,6 +148,7 @@ func TestPlanningAgent_parseTickets_fullFields(t *testing.T) {
 				"acceptance_criteria":  []interface{}{"C1", "C2"},
 				"files_to_create":      []interface{}{"new.go"},
 				"files_to_modify":      []interface{}{"old.go"},
+				"labels":               []interface{}{"Backend", "api", "backend"},
 			},
 		},
 	}
@@ -185,6 +185,90 @@ func TestPlanningAgent_parseTickets_fullFields(t *testing.T) {
 	if len(t0.FilesToModify) != 1 || t0.FilesToModify[0] != "old.go" {
 		t.Errorf("parseTickets() FilesToModify = %v", t0.FilesToModify)
 	}
+	if len(t0.Labels) != 2 || t0.Labels[0] != "backend" || t0.Labels[1] != "api" {
+		t.Errorf("parseTickets() Labels = %v, want [backend api]", t0.Labels)
+	}
+}
+
+func TestPlanningAgent_parseTickets_assertions(t *testing.T) {
+	pa := NewPlanningAgent(nil, "/test/project", "/test/tickets")
+	tickets, err := pa.parseTickets(map[string]interface{}{
+		"tickets": []interface{}{
+			map[string]interface{}{
+				"id":    "T-1",
+				"title": "Feature",
+				"assertions": []interface{}{
+					map[string]interface{}{"command": "go test ./pkg/...", "output_pattern": "^ok"},
+					map[string]interface{}{"command": "./bin/tool --bad", "exit_code": float64(2), "timeout_sec": float64(30)},
+					map[string]interface{}{"exit_code": float64(1)},
+					"not an object",
+				},
+			},
+		},
+	})
+	if err != nil || len(tickets) != 1 {
+		t.Fatalf("parseTickets() = %v, %v", tickets, err)
+	}
+	want := []ticket.Assertion{
+		{Command: "go test ./pkg/...", OutputPattern: "^ok"},
+		{Command: "./bin/tool --bad", ExitCode: 2, TimeoutSec: 30},
+	}
+	got := tickets[0].Assertions
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("parseTickets() Assertions = %+v, want %+v", got, want)
+	}
+}
+
+func TestPlanningAgent_Epics(t *testing.T) {
+	pa := NewPlanningAgent(nil, "/test/project", "/test/tickets")
+	const epics = `type 為 "epic" 的 ticket`
+	if prompt := pa.buildPlanningPrompt([]string{"m.md"}, "out.json"); strings.Contains(prompt, epics) {
+		t.Error("buildPlanningPrompt() should not ask for epics by default")
+	}
+	pa.SetEpics(true)
+	if prompt := pa.buildPlanningPrompt([]string{"m.md"}, "out.json"); !strings.Contains(prompt, epics) {
+		t.Error("buildPlanningPrompt() should ask for epics after SetEpics(true)")
+	}
+
+	tickets, err := pa.parseTickets(map[string]interface{}{
+		"tickets": []interface{}{
+			map[string]interface{}{"id": "EPIC-1", "title": "Phase 1", "type": "epic"},
+			map[string]interface{}{"id": "T-1", "title": "Child", "type": "feature", "parent_id": "EPIC-1"},
+		},
+	})
+	if err != nil || len(tickets) != 2 {
+		t.Fatalf("parseTickets() = %v, %v", tickets, err)
+	}
+	if !tickets[0].IsEpic() || tickets[1].ParentID != "EPIC-1" {
+		t.Errorf("parseTickets() epic = %+v, child = %+v", tickets[0], tickets[1])
+	}
+}
+
+func TestPlanningAgent_MultipleMilestones(t *testing.T) {
+	pa := NewPlanningAgent(nil, "/test/project", "/test/tickets")
+	const field = "- milestone:"
+	if prompt := pa.buildPlanningPrompt([]string{"m1.md"}, "out.json"); strings.Contains(prompt, field) {
+		t.Error("buildPlanningPrompt() should not ask for the milestone of each ticket for one file")
+	}
+	prompt := pa.buildPlanningPrompt([]string{"m1.md", "m2.md"}, "out.json")
+	for _, want := range []string{"- m1.md", "- m2.md", field} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("buildPlanningPrompt() should contain %q:\n%s", want, prompt)
+		}
+	}
+
+	tickets, err := pa.parseTickets(map[string]interface{}{
+		"tickets": []interface{}{
+			map[string]interface{}{"id": "T-1", "title": "Schema", "milestone": "m1.md"},
+			map[string]interface{}{"id": "T-2", "title": "API", "milestone": "m2.md", "dependencies": []interface{}{"T-1"}},
+		},
+	})
+	if err != nil || len(tickets) != 2 {
+		t.Fatalf("parseTickets() = %v, %v", tickets, err)
+	}
+	if tickets[0].Milestone != "m1.md" || tickets[1].Milestone != "m2.md" {
+		t.Errorf("parseTickets() milestones = %q, %q; want m1.md, m2.md", tickets[0].Milestone, tickets[1].Milestone)
+	}
 }
 
 func TestPlanningAgent_createMockTickets_dryRun(t *testing.T) {
@@ -0,0 +1,56 @@
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Policy is an organization's safety and data-handling policy (e.g. "never send data to
+// external services"), prepended to every prompt a Caller sends; see SetPolicy.
+type Policy struct {
+	Text    string
+	Version string
+}
+
+// IsZero reports whether no policy is set.
+func (p Policy) IsZero() bool {
+	return strings.TrimSpace(p.Text) == ""
+}
+
+// Hash returns the first 12 hex digits of the SHA-256 of the policy text. Recorded with
+// Version for each call, it shows which text was applied even if Version was not bumped
+// after an edit. Empty for the zero Policy.
+func (p Policy) Hash() string {
+	if p.IsZero() {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(strings.TrimSpace(p.Text)))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// String describes the policy for logs: "sha256 <hash>", preceded by "version <v>, "
+// when a version is set.
+func (p Policy) String() string {
+	if p.IsZero() {
+		return ""
+	}
+	if p.Version == "" {
+		return "sha256 " + p.Hash()
+	}
+	return "version " + p.Version + ", sha256 " + p.Hash()
+}
+
+// apply returns prompt preceded by the policy text, or prompt itself when none is set.
+func (p Policy) apply(prompt string) string {
+	if p.IsZero() {
+		return prompt
+	}
+	return strings.TrimSpace(p.Text) + "\n\n---\n\n" + prompt
+}
+
+// SetPolicy sets the policy prepended to every prompt. Each call records the policy's
+// version and hash in its log file and CallInfo.
+func (c *Caller) SetPolicy(p Policy) {
+	c.policy = p
+}
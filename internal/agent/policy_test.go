@@ -0,0 +1,76 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestPolicy_Hash(t *testing.T) {
+	p := Policy{Text: "Never send data to external services.", Version: "v1"}
+	if h := p.Hash(); len(h) != 12 {
+		t.Errorf("Hash() = %q, want 12 hex digits", h)
+	}
+	if p.Hash() != (Policy{Text: "  Never send data to external services.\n"}).Hash() {
+		t.Error("Hash() should ignore surrounding whitespace")
+	}
+	if p.Hash() == (Policy{Text: "Never send data anywhere."}).Hash() {
+		t.Error("different texts should hash differently")
+	}
+	if (Policy{Version: "v1"}).Hash() != "" || (Policy{}).String() != "" {
+		t.Error("the zero policy should have no hash")
+	}
+	if got := p.String(); got != "version v1, sha256 "+p.Hash() {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestCaller_Call_Policy(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	path := filepath.Join(t.TempDir(), "echo-agent")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\necho \"$@\"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	logDir := t.TempDir()
+	policy := Policy{Text: "Do not include secrets in output.\n", Version: "2024-06"}
+
+	caller := NewCaller(path, false, "text", logDir)
+	caller.SetPolicy(policy)
+	var info CallInfo
+	caller.SetCallHook(func(i CallInfo) { info = i })
+	result, err := caller.Call(context.Background(), "Implement TICKET-001")
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+
+	policyAt := strings.Index(result.Output, "Do not include secrets in output.")
+	promptAt := strings.Index(result.Output, "Implement TICKET-001")
+	if policyAt < 0 || promptAt < policyAt {
+		t.Errorf("agent got %q, want the policy before the prompt", result.Output)
+	}
+	if info.PolicyVersion != "2024-06" || info.PolicyHash != policy.Hash() {
+		t.Errorf("CallInfo policy = %q, %q", info.PolicyVersion, info.PolicyHash)
+	}
+	log, err := os.ReadFile(result.LogPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(log), "Policy: version 2024-06, sha256 "+policy.Hash()) {
+		t.Errorf("log should record the policy, got:\n%s", log)
+	}
+
+	// Without a policy the prompt is sent unchanged.
+	caller.SetPolicy(Policy{})
+	result, err = caller.Call(context.Background(), "Implement TICKET-001")
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if strings.Contains(result.Output, "secrets") || info.PolicyHash != "" {
+		t.Errorf("output = %q, PolicyHash = %q, want no policy", result.Output, info.PolicyHash)
+	}
+}
@@ -0,0 +1,27 @@
+package agent
+
+import (
+	"github.com/anthropic/agent-orchestrator/internal/prompts"
+)
+
+// SetPrompts sets the templates the agents render their prompts from; nil (the
+// default) uses the embedded templates.
+func (c *Caller) SetPrompts(set *prompts.Set) {
+	c.prompts = set
+}
+
+// renderPrompt renders the named prompt; a nil Caller renders the embedded template.
+// prompts.Load has already run every template on sample data, so failures are rare;
+// when a project template still fails, the embedded default is used rather than
+// sending the agent a broken prompt.
+func (c *Caller) renderPrompt(name string, data any) string {
+	var set *prompts.Set
+	if c != nil {
+		set = c.prompts
+	}
+	if text, err := set.Render(name, data); err == nil {
+		return text
+	}
+	text, _ := (*prompts.Set)(nil).Render(name, data)
+	return text
+}
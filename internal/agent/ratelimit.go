@@ -0,0 +1,94 @@
+package agent
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket allowing perMinute calls per minute, with bursts of up
+// to perMinute calls. Waiters reserve tokens in arrival order, so queued calls start in
+// the order they asked.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewRateLimiter returns a limiter for perMinute calls per minute, starting full.
+func NewRateLimiter(perMinute int) *RateLimiter {
+	return &RateLimiter{
+		rate:   float64(perMinute) / 60,
+		burst:  float64(perMinute),
+		tokens: float64(perMinute),
+		now:    time.Now,
+	}
+}
+
+// Wait blocks until a call may start or ctx is done. A cancelled wait gives its
+// reservation back.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+	if err := sleepContext(ctx, delay); err != nil {
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// reserve takes a token, possibly going into debt, and returns how long the caller
+// must wait for it.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// callLimiter is shared by all Callers; nil means unlimited.
+var (
+	callLimiterMu sync.RWMutex
+	callLimiter   *RateLimiter
+)
+
+// SetRateLimit limits the agent calls of all Callers in the process to perMinute per
+// minute (e.g. to stay under a provider's rate limit when run and work start several
+// agents at once); 0 removes the limit. Setting the current limit again keeps the
+// limiter's state, so creating another Caller does not refill the bucket.
+func SetRateLimit(perMinute int) {
+	callLimiterMu.Lock()
+	defer callLimiterMu.Unlock()
+	switch {
+	case perMinute <= 0:
+		callLimiter = nil
+	case callLimiter == nil || callLimiter.burst != float64(perMinute):
+		callLimiter = NewRateLimiter(perMinute)
+	}
+}
+
+// waitForCallSlot waits for the shared limiter, if any.
+func waitForCallSlot(ctx context.Context) error {
+	callLimiterMu.RLock()
+	l := callLimiter
+	callLimiterMu.RUnlock()
+	if l == nil {
+		return nil
+	}
+	return l.Wait(ctx)
+}
@@ -0,0 +1,63 @@
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Reserve(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := NewRateLimiter(60) // one token per second, burst 60
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 60; i++ {
+		if d := l.reserve(); d != 0 {
+			t.Fatalf("call %d within burst waits %s", i, d)
+		}
+	}
+	if d := l.reserve(); d != time.Second {
+		t.Errorf("first call over burst waits %s, want 1s", d)
+	}
+	if d := l.reserve(); d != 2*time.Second {
+		t.Errorf("queued call waits %s, want 2s", d)
+	}
+
+	now = now.Add(10 * time.Second)
+	if d := l.reserve(); d != 0 {
+		t.Errorf("call after refill waits %s, want 0", d)
+	}
+}
+
+func TestRateLimiter_WaitCancelled(t *testing.T) {
+	l := NewRateLimiter(1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() within burst = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Wait() = %v, want deadline exceeded", err)
+	}
+	if l.tokens < -0.5 {
+		t.Errorf("cancelled wait kept its reservation: tokens = %v", l.tokens)
+	}
+}
+
+func TestCaller_Call_RateLimited(t *testing.T) {
+	SetRateLimit(1)
+	defer SetRateLimit(0)
+
+	caller := NewCaller("true", false, "text", "")
+	if _, err := caller.Call(context.Background(), "p"); err != nil {
+		t.Fatalf("first Call() error = %v", err)
+	}
+	// Configuring another Caller with the same limit must not refill the bucket.
+	SetRateLimit(1)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	result, err := caller.Call(ctx, "p")
+	if err != context.DeadlineExceeded || result != nil {
+		t.Errorf("Call() over the limit = %+v, %v; want deadline exceeded while queued", result, err)
+	}
+}
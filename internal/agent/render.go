@@ -0,0 +1,76 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// The functions below render prompts and parse agent output without calling the agent,
+// for prompt previews and the golden-file tests under testdata/golden. A prompt depends
+// only on the arguments, the agent's settings (e.g. SetConventions, SetNotes), the
+// Caller's templates (SetPrompts; a nil Caller uses the embedded ones) and the current
+// language. Nothing is read from the clock; the project directory is only inspected to
+// detect the project type.
+
+// Prompt returns the coding prompt Execute sends for t, before any compression.
+func (ca *CodingAgent) Prompt(t *ticket.Ticket) string {
+	return ca.buildPrompt(t)
+}
+
+// Prompt returns the review prompt Review sends for files.
+func (ra *ReviewAgent) Prompt(files []string) string {
+	return ra.buildReviewPrompt(files)
+}
+
+// Prompt returns the prompt RunTests sends.
+func (ta *TestAgent) Prompt() string {
+	return ta.buildTestPrompt()
+}
+
+// FailurePrompt returns the prompt RunTests sends in TestModeNative to analyze the
+// failures in output, the output of the test commands.
+func (ta *TestAgent) FailurePrompt(output string) string {
+	return ta.buildFailurePrompt(output)
+}
+
+// Prompt returns the planning prompt Plan sends for milestoneFile, asking for the
+// tickets to be written to outputFile.
+func (pa *PlanningAgent) Prompt(milestoneFile, outputFile string) string {
+	return pa.buildPlanningPrompt([]string{milestoneFile}, outputFile)
+}
+
+// Prompt returns the commit prompt Commit sends.
+func (ca *CommitAgent) Prompt(ticketID, ticketTitle, changes string, filesToStage []string) string {
+	return ca.buildCommitPrompt(ticketID, ticketTitle, changes, filesToStage)
+}
+
+// Prompt returns the enhancement prompt Enhance sends for t.
+func (ea *EnhanceAgent) Prompt(t *ticket.Ticket) string {
+	return ea.buildPrompt(t)
+}
+
+// Prompt returns the analysis prompt Analyze sends for scope.
+func (aa *AnalyzeAgent) Prompt(scope AnalyzeScope) string {
+	return aa.buildAnalyzePrompt(scope)
+}
+
+// ParseReviewOutput parses the output of a review agent call as Review does.
+func ParseReviewOutput(output string) *ReviewResult {
+	return (&ReviewAgent{}).parseReviewResult(output)
+}
+
+// ParseTestOutput parses the output of a test agent call as RunTests does.
+func ParseTestOutput(output string) *TestResult {
+	return (&TestAgent{}).parseTestResult(output)
+}
+
+// ParsePlanningOutput parses the JSON file written by the planning agent as Plan does.
+func ParsePlanningOutput(data []byte) ([]*ticket.Ticket, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON output: %w", err)
+	}
+	return (&PlanningAgent{}).parseTickets(doc)
+}
@@ -2,12 +2,22 @@ package agent
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode/utf8"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/jsonutil"
+	"github.com/anthropic/agent-orchestrator/internal/project"
+	"github.com/anthropic/agent-orchestrator/internal/prompts"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
 )
 
 // ReviewAgent invokes the agent to perform code review on given files.
@@ -16,8 +26,16 @@ import (
 type ReviewAgent struct {
 	caller     *Caller
 	projectDir string
+	notes      []string
+	diff       string
+	batchSize  int
+	parallel   int
 }
 
+// maxReviewDiffChars caps the diff embedded in the review prompt; the agent reads the
+// files for the rest.
+const maxReviewDiffChars = 50000
+
 // NewReviewAgent creates a ReviewAgent with the given Caller and project directory.
 func NewReviewAgent(caller *Caller, projectDir string) *ReviewAgent {
 	return &ReviewAgent{
@@ -26,67 +44,374 @@ func NewReviewAgent(caller *Caller, projectDir string) *ReviewAgent {
 	}
 }
 
+// SetNotes sets operator notes (see the note command) of the tickets under review, e.g.
+// "T-1: keep the public API unchanged", to include in the prompt.
+func (ra *ReviewAgent) SetNotes(notes []string) {
+	ra.notes = notes
+}
+
+// SetDiff sets the diff of the files under review (see gitx.Manager.Diff) to embed in
+// the prompt, so the review focuses on what changed rather than whole files.
+func (ra *ReviewAgent) SetDiff(diff string) {
+	ra.diff = diff
+}
+
+// SetBatching splits reviews of more than size files into batches of size files, of
+// which up to parallel are reviewed at once (see config review_batch_size and
+// max_parallel). The results are merged into one. Size 0 reviews all files in one call.
+func (ra *ReviewAgent) SetBatching(size, parallel int) {
+	ra.batchSize, ra.parallel = size, parallel
+}
+
 // ReviewResult holds the parsed outcome of a code review: status (APPROVED or CHANGES_REQUESTED),
-// summary, list of issues, and list of suggestions.
+// summary, list of issues, and list of suggestions. Findings are the issues with their
+// severity and location when the agent wrote a structured (JSON) review; Issues then
+// holds them rendered as lines.
 type ReviewResult struct {
 	Status      string   // APPROVED or CHANGES_REQUESTED
 	Summary     string
 	Issues      []string
 	Suggestions []string
+	Findings    []ReviewFinding `json:",omitempty"`
+}
+
+// ReviewFinding is one issue of a structured review.
+type ReviewFinding struct {
+	Severity string // HIGH, MED or LOW
+	File     string `json:",omitempty"`
+	Line     int    `json:",omitempty"`
+	Message  string
+}
+
+// String renders the finding as an issue line, e.g. "[HIGH] internal/api.go:42 nil map write".
+func (f ReviewFinding) String() string {
+	var b strings.Builder
+	if f.Severity != "" {
+		fmt.Fprintf(&b, "[%s] ", f.Severity)
+	}
+	if f.File != "" {
+		b.WriteString(f.File)
+		if f.Line > 0 {
+			fmt.Fprintf(&b, ":%d", f.Line)
+		}
+		b.WriteString(" ")
+	}
+	b.WriteString(f.Message)
+	return b.String()
+}
+
+// Blocks reports whether the review has a finding of one of severities (compared by
+// ticket.SeverityRank, so MED and MEDIUM match), e.g. config review_block_on. A review
+// without findings blocks when it requested changes; no severities never block.
+func (r *ReviewResult) Blocks(severities []string) bool {
+	if r == nil || len(severities) == 0 {
+		return false
+	}
+	if len(r.Findings) == 0 {
+		return r.Status == "CHANGES_REQUESTED"
+	}
+	for _, f := range r.Findings {
+		rank := ticket.SeverityRank(f.Severity)
+		for _, s := range severities {
+			if rank > 0 && rank == ticket.SeverityRank(s) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // Review runs the agent to review the given file paths and returns the raw Result,
-// parsed ReviewResult, and any error.
+// parsed ReviewResult, and any error. The agent is asked to write its review as JSON
+// to .tickets/review-result.json; when it writes none or an unusable one, the review
+// is parsed from its output instead. More files than the batch size (see SetBatching)
+// are reviewed in concurrent batches whose results are merged.
 func (ra *ReviewAgent) Review(ctx context.Context, files []string) (*Result, *ReviewResult, error) {
 	if len(files) == 0 {
 		return &Result{Success: true, Output: "No files to review"}, nil, nil
 	}
 
+	outputFile := filepath.Join(ra.projectDir, ".tickets", "review-result.json")
+	if err := os.MkdirAll(filepath.Dir(outputFile), 0700); err != nil {
+		return nil, nil, fmt.Errorf(i18n.ErrAgentMkdirOutput, err)
+	}
+	// A result left by an earlier review must not be taken for this one.
+	_ = os.Remove(outputFile)
+
+	if ra.batchSize > 0 && len(files) > ra.batchSize {
+		return ra.reviewBatches(ctx, files, outputFile)
+	}
+	return ra.review(ctx, files, outputFile)
+}
+
+// review runs one review call for files, reading the JSON review from outputFile.
+func (ra *ReviewAgent) review(ctx context.Context, files []string, outputFile string) (*Result, *ReviewResult, error) {
 	prompt := ra.buildReviewPrompt(files)
 
-	result, err := ra.caller.Call(ctx, prompt,
+	result, data, err := ra.caller.CallForJSON(ctx, prompt, outputFile,
 		WithWorkingDir(ra.projectDir),
 		WithContextFiles(files...),
 		WithTimeout(10*time.Minute),
+		WithModel(ra.caller.modelFor(ModelKeyReview)),
 	)
 
-	if err != nil {
+	if result == nil {
 		return nil, nil, err
 	}
+	if reviewResult := parseReviewJSON(data); err == nil && reviewResult != nil {
+		return result, reviewResult, nil
+	}
 
-	// Parse review result from output
+	// Fall back to parsing the review from the output
 	reviewResult := ra.parseReviewResult(result.Output)
 
 	return result, reviewResult, nil
 }
 
-// buildReviewPrompt creates the prompt for code review
-func (ra *ReviewAgent) buildReviewPrompt(files []string) string {
-	var sb strings.Builder
+// reviewBatches reviews files in batches of ra.batchSize, up to ra.parallel at once,
+// each with its own output file and only its files' part of the diff. The results are
+// merged (see mergeReviewResults) and the merged review is written to outputFile.
+func (ra *ReviewAgent) reviewBatches(ctx context.Context, files []string, outputFile string) (*Result, *ReviewResult, error) {
+	var batches [][]string
+	for start := 0; start < len(files); start += ra.batchSize {
+		batches = append(batches, files[start:min(start+ra.batchSize, len(files))])
+	}
+	diffs := splitDiff(ra.diff)
+
+	results := make([]*Result, len(batches))
+	reviews := make([]*ReviewResult, len(batches))
+	errs := make([]error, len(batches))
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, max(ra.parallel, 1))
+	for i, batch := range batches {
+		wg.Add(1)
+		go func(i int, batch []string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			batchAgent := *ra
+			var diff strings.Builder
+			for _, f := range batch {
+				diff.WriteString(diffs[filepath.ToSlash(f)])
+			}
+			batchAgent.diff = diff.String()
+			batchFile := strings.TrimSuffix(outputFile, ".json") + fmt.Sprintf("-%d.json", i+1)
+			_ = os.Remove(batchFile)
+			results[i], reviews[i], errs[i] = batchAgent.review(ctx, batch, batchFile)
+			_ = os.Remove(batchFile)
+		}(i, batch)
+	}
+	wg.Wait()
 
-	sb.WriteString("你是一個程式碼審查 Agent。請審查以下變更的檔案。\n\n")
-	sb.WriteString(fmt.Sprintf("專案目錄: %s\n\n", ra.projectDir))
-	
-	sb.WriteString("變更的檔案:\n")
+	for _, err := range errs {
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	merged := mergeReviewResults(batches, reviews)
+	if data, err := json.MarshalIndent(merged.jsonReview(), "", "  "); err == nil {
+		_ = os.WriteFile(outputFile, data, 0600)
+	}
+	return mergeResults(results), merged, nil
+}
+
+// reviewStatusRank orders review statuses from best to worst for merging.
+var reviewStatusRank = map[string]int{"APPROVED": 0, "UNKNOWN": 1, "CHANGES_REQUESTED": 2}
+
+// mergeReviewResults merges the reviews of batches (reviews[i] reviewed batches[i])
+// into one: the worst status wins, and the summaries, issues and suggestions are
+// concatenated. Summaries, and issues that do not name a file, are attributed to their
+// batch's files, e.g. "api.go: missing error check".
+func mergeReviewResults(batches [][]string, reviews []*ReviewResult) *ReviewResult {
+	merged := &ReviewResult{Status: "APPROVED", Issues: make([]string, 0), Suggestions: make([]string, 0)}
+	var summaries []string
+	for i, r := range reviews {
+		if r == nil {
+			continue
+		}
+		label := strings.Join(batches[i], ", ")
+		if reviewStatusRank[r.Status] > reviewStatusRank[merged.Status] {
+			merged.Status = r.Status
+		}
+		if r.Summary != "" {
+			summaries = append(summaries, label+": "+r.Summary)
+		}
+		if len(r.Findings) > 0 {
+			for _, f := range r.Findings {
+				if f.File == "" {
+					if len(batches[i]) == 1 {
+						f.File = batches[i][0]
+					} else {
+						f.Message = label + ": " + f.Message
+					}
+				}
+				merged.Findings = append(merged.Findings, f)
+				merged.Issues = append(merged.Issues, f.String())
+			}
+		} else {
+			for _, issue := range r.Issues {
+				if !mentionsAny(issue, batches[i]) {
+					issue = label + ": " + issue
+				}
+				merged.Issues = append(merged.Issues, issue)
+			}
+		}
+		merged.Suggestions = append(merged.Suggestions, r.Suggestions...)
+	}
+	merged.Summary = strings.Join(summaries, "\n")
+	return merged
+}
+
+// mentionsAny reports whether s contains one of files.
+func mentionsAny(s string, files []string) bool {
 	for _, f := range files {
-		sb.WriteString(fmt.Sprintf("- %s\n", f))
+		if strings.Contains(s, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonReview returns the review in the JSON format the agent is asked to write
+// (see parseReviewJSON). Issues are written as strings unless all of them are
+// structured findings.
+func (r *ReviewResult) jsonReview() map[string]interface{} {
+	issues := make([]interface{}, 0, len(r.Issues))
+	if len(r.Findings) > 0 && len(r.Findings) == len(r.Issues) {
+		for _, f := range r.Findings {
+			issues = append(issues, map[string]interface{}{"severity": f.Severity, "file": f.File, "line": f.Line, "message": f.Message})
+		}
+	} else {
+		for _, issue := range r.Issues {
+			issues = append(issues, issue)
+		}
+	}
+	return map[string]interface{}{
+		"status":      r.Status,
+		"summary":     r.Summary,
+		"issues":      issues,
+		"suggestions": r.Suggestions,
+	}
+}
+
+// mergeResults combines the Results of concurrent calls: successful when all were,
+// with their outputs and errors concatenated, the longest duration and the summed
+// attempts and usage.
+func mergeResults(results []*Result) *Result {
+	merged := &Result{Success: true}
+	var outputs, errs []string
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+		merged.Success = merged.Success && r.Success
+		merged.TimedOut = merged.TimedOut || r.TimedOut
+		if merged.ExitCode == 0 {
+			merged.ExitCode = r.ExitCode
+		}
+		if merged.LogPath == "" {
+			merged.LogPath = r.LogPath
+		}
+		merged.Duration = max(merged.Duration, r.Duration)
+		merged.Attempts += r.Attempts
+		merged.Usage = merged.Usage.Add(r.Usage)
+		outputs = append(outputs, r.Output)
+		if r.Error != "" {
+			errs = append(errs, r.Error)
+		}
+	}
+	merged.Output = strings.Join(outputs, "\n\n")
+	merged.Error = strings.Join(errs, "\n")
+	return merged
+}
+
+// splitDiff splits a git diff into the diffs of its files, keyed by the file's path
+// after the change (the "b/" side of "diff --git a/x b/x").
+func splitDiff(diff string) map[string]string {
+	files := make(map[string]string)
+	var path string
+	var current strings.Builder
+	flush := func() {
+		if path != "" {
+			files[path] += current.String()
+		}
+		current.Reset()
 	}
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			flush()
+			path = ""
+			if i := strings.LastIndex(line, " b/"); i >= 0 {
+				path = strings.TrimRight(line[i+len(" b/"):], "\n")
+			}
+		}
+		current.WriteString(line)
+	}
+	flush()
+	return files
+}
 
-	sb.WriteString(`
-請檢查:
-1. 程式碼品質與風格一致性
-2. 潛在的 bugs 或問題
-3. 效能考量
-4. 安全性問題
-5. 測試覆蓋率
+// parseReviewJSON reads a structured review ({status, summary, issues: [{severity,
+// file, line, message}], suggestions}). Returns nil when data has no valid status.
+func parseReviewJSON(data map[string]interface{}) *ReviewResult {
+	status := strings.ToUpper(jsonutil.GetString(data, "status"))
+	if status != "APPROVED" && status != "CHANGES_REQUESTED" {
+		return nil
+	}
+	result := &ReviewResult{
+		Status:      status,
+		Summary:     jsonutil.GetString(data, "summary"),
+		Issues:      make([]string, 0),
+		Suggestions: jsonutil.GetStringSlice(data, "suggestions"),
+	}
+	if result.Suggestions == nil {
+		result.Suggestions = make([]string, 0)
+	}
+	items, _ := data["issues"].([]interface{})
+	for _, item := range items {
+		var f ReviewFinding
+		switch v := item.(type) {
+		case string:
+			f.Message = v
+		case map[string]interface{}:
+			f = ReviewFinding{
+				Severity: strings.ToUpper(jsonutil.GetString(v, "severity")),
+				File:     jsonutil.GetString(v, "file"),
+				Line:     jsonutil.GetInt(v, "line"),
+				Message:  jsonutil.GetString(v, "message"),
+			}
+		}
+		if f.Message == "" {
+			continue
+		}
+		result.Findings = append(result.Findings, f)
+		result.Issues = append(result.Issues, f.String())
+	}
+	return result
+}
 
-請在輸出中包含:
-- 狀態: APPROVED 或 CHANGES_REQUESTED
-- 摘要: 簡短的審查摘要
-- 問題: 發現的問題列表 (如果有)
-- 建議: 改進建議`)
+// buildReviewPrompt renders the review prompt (see prompts.ReviewData) for files.
+func (ra *ReviewAgent) buildReviewPrompt(files []string) string {
+	return ra.caller.renderPrompt(prompts.Review, prompts.ReviewData{
+		ProjectRoot: ra.projectDir,
+		Files:       files,
+		Notes:       ra.notes,
+		Diff:        truncateDiff(strings.TrimRight(ra.diff, "\n"), maxReviewDiffChars),
+	})
+}
 
-	return sb.String()
+// truncateDiff cuts diff to at most max characters at a line boundary, noting how much
+// was left out.
+func truncateDiff(diff string, max int) string {
+	if len(diff) <= max {
+		return diff
+	}
+	cut := strings.LastIndex(diff[:max], "\n")
+	if cut < 0 {
+		cut = max
+	}
+	return diff[:cut] + "\n" + fmt.Sprintf(i18n.AgentReviewDiffTruncated, len(diff)-cut)
 }
 
 // statusPattern matches "狀態: APPROVED" or "Status: CHANGES_REQUESTED" (with optional colon variants)
@@ -214,8 +539,11 @@ func parseListSection(output string, startMarkers, endMarkers []string) []string
 // TestAgent invokes the agent to run tests in the project (e.g. go test, pytest).
 // It parses the agent output to extract pass/fail/skip counts and a summary.
 type TestAgent struct {
-	caller     *Caller
-	projectDir string
+	caller       *Caller
+	projectDir   string
+	mode         string
+	junitReports []string
+	retries      int
 }
 
 // NewTestAgent creates a TestAgent with the given Caller and project directory.
@@ -227,21 +555,40 @@ func NewTestAgent(caller *Caller, projectDir string) *TestAgent {
 }
 
 // TestResult holds the parsed test outcome: passed/failed/skipped counts and a summary string.
+// Coverage is the total statement coverage in percent, nil when the output reports none.
+// FailedTests names the failed tests the output lists; Flaky those that failed and then
+// passed when rerun (see TestAgent.SetRetries), which count as passed.
 type TestResult struct {
-	Passed  int
-	Failed  int
-	Skipped int
-	Summary string
+	Passed      int
+	Failed      int
+	Skipped     int
+	Summary     string
+	Coverage    *float64 `json:",omitempty"`
+	FailedTests []string `json:",omitempty"`
+	Flaky       []string `json:",omitempty"`
 }
 
 // RunTests runs the agent to execute tests in the project and returns the raw Result,
-// parsed TestResult (go test or pytest format), and any error.
+// parsed TestResult (go test or pytest format), and any error. In TestModeNative the
+// detected test commands are run directly instead (see runNative); a project whose type
+// is not detected, and a dry run, still go through the agent. The counts of JUnit XML
+// reports written by the run (see SetJUnitReports) replace those parsed from the output.
 func (ta *TestAgent) RunTests(ctx context.Context) (*Result, *TestResult, error) {
+	start := time.Now()
+	if ta.mode == TestModeNative && !ta.caller.DryRun {
+		if commands := ta.testCommands(); len(commands) > 0 {
+			result, testResult, err := ta.runNative(ctx, commands)
+			ta.applyJUnitReports(testResult, start)
+			return result, testResult, err
+		}
+	}
+
 	prompt := ta.buildTestPrompt()
 
 	result, err := ta.caller.Call(ctx, prompt,
 		WithWorkingDir(ta.projectDir),
-		WithTimeout(15*time.Minute),
+		WithTimeout(testTimeout),
+		WithModel(ta.caller.modelFor(ModelKeyTesting)),
 	)
 
 	if err != nil {
@@ -249,32 +596,33 @@ func (ta *TestAgent) RunTests(ctx context.Context) (*Result, *TestResult, error)
 	}
 
 	testResult := ta.parseTestResult(result.Output)
+	ta.applyJUnitReports(testResult, start)
 
 	return result, testResult, nil
 }
 
-// buildTestPrompt creates the prompt for test execution
+// buildTestPrompt creates the prompt for test execution. The test commands come from
+// the project types detected in the project directory; when none is detected, the
+// default commands of every registered project type are listed.
 func (ta *TestAgent) buildTestPrompt() string {
-	return fmt.Sprintf(`你是一個測試 Agent。請在專案目錄 %s 執行以下任務:
-
-1. 檢查專案類型並找到適合的測試指令
-   - Go: go test ./...
-   - Java (Maven): mvn test
-   - Java (Gradle): gradle test
-   - Node.js: npm test
-   - Python: pytest
-   
-2. 執行測試
-
-3. 分析測試結果
-
-4. 如果有測試失敗，分析失敗原因
+	var commands strings.Builder
+	if detected := project.Detect(ta.projectDir); len(detected) > 0 {
+		commands.WriteString(i18n.AgentTestProjectDetected)
+		for _, d := range detected {
+			if d.Commands.Test != "" {
+				commands.WriteString(fmt.Sprintf("   - %s: %s\n", d.Plugin.Name(), d.Commands.Test))
+			}
+		}
+	} else {
+		commands.WriteString(i18n.AgentTestProjectUnknown)
+		for _, p := range project.Plugins() {
+			if test := p.Commands(ta.projectDir).Test; test != "" {
+				commands.WriteString(fmt.Sprintf("   - %s: %s\n", p.Name(), test))
+			}
+		}
+	}
 
-請在輸出中包含:
-- 測試摘要
-- 通過/失敗的測試數量
-- 失敗測試的詳細資訊 (如果有)
-- 修復建議`, ta.projectDir)
+	return fmt.Sprintf(i18n.AgentTestPrompt, ta.projectDir, commands.String())
 }
 
 // goTestOkPattern matches "ok  \tpath/to/pkg\t0.123s" or "ok  path 0.12s"
@@ -292,10 +640,61 @@ var pytestFailedPattern = regexp.MustCompile(`(\d+)\s+failed`)
 var pytestSkippedPattern = regexp.MustCompile(`(\d+)\s+skipped`)
 var pytestErrorPattern = regexp.MustCompile(`(\d+)\s+error`)
 
+// coverageTotalPatterns match a total coverage, most explicit first: the line the
+// prompt asks for ("Total coverage: 82.5%"), go tool cover -func's "total:" line and
+// pytest-cov's "TOTAL" row.
+var coverageTotalPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?im)^\W*(?:總\s*coverage|total coverage)\s*[：:]\s*([\d.]+)\s*%`),
+	regexp.MustCompile(`(?m)^total:\s+\(statements\)\s+([\d.]+)%`),
+	regexp.MustCompile(`(?m)^TOTAL(?:\s+\d+)+\s+([\d.]+)%`),
+}
+
+// goCoverPattern matches go test -cover's per-package "coverage: 82.5% of statements".
+var goCoverPattern = regexp.MustCompile(`coverage: ([\d.]+)% of statements`)
+
+// parseCoverage extracts the total coverage in percent from test output: a total line
+// (see coverageTotalPatterns), else the mean of go test -cover's package coverages.
+// Returns nil when the output reports no coverage.
+func parseCoverage(output string) *float64 {
+	for _, p := range coverageTotalPatterns {
+		if m := p.FindStringSubmatch(output); len(m) >= 2 {
+			if v, err := strconv.ParseFloat(m[1], 64); err == nil {
+				return &v
+			}
+		}
+	}
+	var sum float64
+	var n int
+	for _, m := range goCoverPattern.FindAllStringSubmatch(output, -1) {
+		if v, err := strconv.ParseFloat(m[1], 64); err == nil {
+			sum += v
+			n++
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	mean := sum / float64(n)
+	return &mean
+}
+
+// jestTestsPattern matches the summary line of Jest ("Tests:       1 failed, 10 passed, 11 total")
+// and Vitest ("      Tests  1 failed | 10 passed (11)"); the counts are read with jestCountPattern.
+var jestTestsPattern = regexp.MustCompile(`(?m)^\s*Tests:?\s+(\d+ (?:passed|failed|skipped|todo).*)$`)
+var jestCountPattern = regexp.MustCompile(`(\d+) (passed|failed|skipped|todo)`)
+
+// cargoTestResultPattern matches "test result: FAILED. 3 passed; 1 failed; 2 ignored; ..."
+var cargoTestResultPattern = regexp.MustCompile(`(?m)^test result: \w+\. (\d+) passed; (\d+) failed; (\d+) ignored`)
+
+// dotnetTestPattern matches "Failed!  - Failed:     1, Passed:    12, Skipped:     0, Total:    13, ..."
+var dotnetTestPattern = regexp.MustCompile(`(?m)^\s*(?:Passed|Failed)!\s+-\s+Failed:\s+(\d+),\s+Passed:\s+(\d+),\s+Skipped:\s+(\d+)`)
+
 // parseTestResult extracts test result from output.
-// It supports common formats: go test (ok/FAIL lines and --- PASS/--- FAIL), pytest (X passed, Y failed).
+// It supports common formats: go test (ok/FAIL lines and --- PASS/--- FAIL), Jest and Vitest
+// ("Tests:" summary), cargo test ("test result:"), dotnet test (Passed!/Failed!), pytest
+// (X passed, Y failed), and the coverage of go test -cover, go tool cover -func and pytest-cov.
 func (ta *TestAgent) parseTestResult(output string) *TestResult {
-	result := &TestResult{}
+	result := &TestResult{Coverage: parseCoverage(output), FailedTests: parseFailedTests(output)}
 
 	// Try go test format first: --- PASS / --- FAIL lines (most precise)
 	passCount := 0
@@ -328,6 +727,53 @@ func (ta *TestAgent) parseTestResult(output string) *TestResult {
 		return result
 	}
 
+	// Jest / Vitest: the "Tests:" summary line
+	if m := jestTestsPattern.FindStringSubmatch(output); len(m) >= 2 {
+		for _, c := range jestCountPattern.FindAllStringSubmatch(m[1], -1) {
+			n, _ := strconv.Atoi(c[1])
+			switch c[2] {
+			case "passed":
+				result.Passed += n
+			case "failed":
+				result.Failed += n
+			default:
+				result.Skipped += n
+			}
+		}
+		if result.Passed > 0 || result.Failed > 0 || result.Skipped > 0 {
+			result.Summary = summarizeTestResult(result.Passed, result.Failed, result.Skipped)
+			return result
+		}
+	}
+
+	// cargo test: one "test result:" line per test binary (unit, integration, doc tests)
+	if matches := cargoTestResultPattern.FindAllStringSubmatch(output, -1); len(matches) > 0 {
+		for _, m := range matches {
+			passed, _ := strconv.Atoi(m[1])
+			failed, _ := strconv.Atoi(m[2])
+			ignored, _ := strconv.Atoi(m[3])
+			result.Passed += passed
+			result.Failed += failed
+			result.Skipped += ignored
+		}
+		result.Summary = summarizeTestResult(result.Passed, result.Failed, result.Skipped)
+		return result
+	}
+
+	// dotnet test: one "Passed!" / "Failed!" line per test assembly
+	if matches := dotnetTestPattern.FindAllStringSubmatch(output, -1); len(matches) > 0 {
+		for _, m := range matches {
+			failed, _ := strconv.Atoi(m[1])
+			passed, _ := strconv.Atoi(m[2])
+			skipped, _ := strconv.Atoi(m[3])
+			result.Passed += passed
+			result.Failed += failed
+			result.Skipped += skipped
+		}
+		result.Summary = summarizeTestResult(result.Passed, result.Failed, result.Skipped)
+		return result
+	}
+
 	// Pytest format: "X passed", "Y failed", "Z skipped"
 	if m := pytestPassedPattern.FindStringSubmatch(output); len(m) >= 2 {
 		result.Passed, _ = strconv.Atoi(m[1])
@@ -392,38 +838,18 @@ func (ca *CommitAgent) Commit(ctx context.Context, ticketID, ticketTitle, change
 	return ca.caller.Call(ctx, prompt,
 		WithWorkingDir(ca.projectDir),
 		WithTimeout(5*time.Minute),
+		WithModel(ca.caller.modelFor(ModelKeyCommit)),
 	)
 }
 
-// buildCommitPrompt creates the prompt for committing.
+// buildCommitPrompt renders the commit prompt (see prompts.CommitData).
 // When filesToStage is non-empty, the prompt instructs the agent to only add those files.
 func (ca *CommitAgent) buildCommitPrompt(ticketID, ticketTitle, changes string, filesToStage []string) string {
-	addStep := "2. 執行 git add 將相關檔案加入暫存區"
-	if len(filesToStage) > 0 {
-		addStep = "2. 只對以下檔案執行 git add 並加入暫存區，不要 add 其他檔案：\n" + strings.Join(filesToStage, "\n")
-	}
-	return fmt.Sprintf(`你是一個 Git Commit Agent。請根據以下變更產生適當的 commit 並提交。
-
-專案目錄: %s
-Ticket ID: %s
-Ticket 標題: %s
-
-目前的變更:
-%s
-
-請:
-1. 分析變更內容
-%s
-3. 產生符合 Conventional Commits 格式的 commit message
-4. 執行 git commit
-
-Commit message 格式:
-<type>(<scope>): <description>
-
-[optional body]
-
-Refs: %s
-
-Type 應該是: feat, fix, docs, style, refactor, test, chore`,
-		ca.projectDir, ticketID, ticketTitle, changes, addStep, ticketID)
+	return ca.caller.renderPrompt(prompts.Commit, prompts.CommitData{
+		ProjectRoot:  ca.projectDir,
+		TicketID:     ticketID,
+		TicketTitle:  ticketTitle,
+		Changes:      changes,
+		FilesToStage: filesToStage,
+	})
 }
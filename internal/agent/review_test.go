@@ -1,8 +1,15 @@
 package agent
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
 )
 
 func TestReviewAgent_buildReviewPrompt_outputFormat(t *testing.T) {
@@ -23,10 +30,10 @@ func TestReviewAgent_buildReviewPrompt_outputFormat(t *testing.T) {
 		"效能",
 		"安全性",
 		"測試覆蓋率",
-		"狀態: APPROVED 或 CHANGES_REQUESTED",
-		"摘要:",
-		"問題:",
-		"建議:",
+		`"status": "APPROVED 或 CHANGES_REQUESTED"`,
+		`"summary":`,
+		`"severity": "HIGH、MED 或 LOW"`,
+		`"suggestions":`,
 	}
 	for _, want := range wantContains {
 		if !strings.Contains(prompt, want) {
@@ -148,3 +155,207 @@ func TestParseListSection(t *testing.T) {
 		})
 	}
 }
+
+func TestParseReviewJSON(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         map[string]interface{}
+		wantNil      bool
+		wantStatus   string
+		wantIssues   []string
+		wantFindings int
+	}{
+		{
+			name: "structured issues",
+			data: map[string]interface{}{
+				"status":  "changes_requested",
+				"summary": "nil map write",
+				"issues": []interface{}{
+					map[string]interface{}{"severity": "high", "file": "api.go", "line": float64(42), "message": "map is nil"},
+					map[string]interface{}{"severity": "LOW", "message": "naming"},
+					map[string]interface{}{"severity": "MED"},
+					"plain issue",
+				},
+				"suggestions": []interface{}{"add a test"},
+			},
+			wantStatus:   "CHANGES_REQUESTED",
+			wantIssues:   []string{"[HIGH] api.go:42 map is nil", "[LOW] naming", "plain issue"},
+			wantFindings: 3,
+		},
+		{
+			name:       "approved without issues",
+			data:       map[string]interface{}{"status": "APPROVED"},
+			wantStatus: "APPROVED",
+		},
+		{name: "no status", data: map[string]interface{}{"summary": "ok"}, wantNil: true},
+		{name: "no data", data: nil, wantNil: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseReviewJSON(tt.data)
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("parseReviewJSON() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("parseReviewJSON() = nil")
+			}
+			if got.Status != tt.wantStatus || len(got.Findings) != tt.wantFindings ||
+				strings.Join(got.Issues, "|") != strings.Join(tt.wantIssues, "|") {
+				t.Errorf("parseReviewJSON() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestReviewResult_Blocks(t *testing.T) {
+	structured := &ReviewResult{Status: "CHANGES_REQUESTED", Findings: []ReviewFinding{
+		{Severity: "MEDIUM", Message: "a"},
+		{Severity: "LOW", Message: "b"},
+	}}
+	prose := &ReviewResult{Status: "CHANGES_REQUESTED", Issues: []string{"a"}}
+	tests := []struct {
+		name       string
+		result     *ReviewResult
+		severities []string
+		want       bool
+	}{
+		{name: "no blocking severities", result: structured, severities: nil, want: false},
+		{name: "only high blocks", result: structured, severities: []string{"HIGH"}, want: false},
+		{name: "med matches medium", result: structured, severities: []string{"HIGH", "med"}, want: true},
+		{name: "prose changes requested", result: prose, severities: []string{"HIGH"}, want: true},
+		{name: "prose approved", result: &ReviewResult{Status: "APPROVED"}, severities: []string{"HIGH"}, want: false},
+		{name: "no review", result: nil, severities: []string{"HIGH"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.result.Blocks(tt.severities); got != tt.want {
+				t.Errorf("Blocks(%v) = %v, want %v", tt.severities, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateDiff(t *testing.T) {
+	diff := "line one\nline two\nline three"
+	tests := []struct {
+		name string
+		max  int
+		want string
+	}{
+		{name: "fits", max: 100, want: diff},
+		{name: "cut at a line boundary", max: 12, want: "line one\n" + fmt.Sprintf(i18n.AgentReviewDiffTruncated, len(diff)-8)},
+		{name: "no line boundary", max: 4, want: "line\n" + fmt.Sprintf(i18n.AgentReviewDiffTruncated, len(diff)-4)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncateDiff(diff, tt.max); got != tt.want {
+				t.Errorf("truncateDiff(%d) = %q, want %q", tt.max, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitDiff(t *testing.T) {
+	a := "diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -1 +1 @@\n-old\n+new\n"
+	b := "diff --git a/old.go b/pkg/b.go\nsimilarity index 90%\nrename from old.go\nrename to pkg/b.go\n"
+	got := splitDiff(a + b)
+	if len(got) != 2 || got["a.go"] != a || got["pkg/b.go"] != b {
+		t.Errorf("splitDiff() = %q", got)
+	}
+	if got := splitDiff(""); len(got) != 0 {
+		t.Errorf("splitDiff(\"\") = %q, want empty", got)
+	}
+}
+
+func TestMergeReviewResults(t *testing.T) {
+	batches := [][]string{{"a.go"}, {"b.go", "c.go"}, {"d.go"}}
+	tests := []struct {
+		name        string
+		reviews     []*ReviewResult
+		wantStatus  string
+		wantIssues  []string
+		wantSummary string
+	}{
+		{
+			name: "all approved",
+			reviews: []*ReviewResult{
+				{Status: "APPROVED", Summary: "fine"},
+				{Status: "APPROVED"},
+				{Status: "APPROVED"},
+			},
+			wantStatus:  "APPROVED",
+			wantIssues:  []string{},
+			wantSummary: "a.go: fine",
+		},
+		{
+			name: "worst status wins",
+			reviews: []*ReviewResult{
+				{Status: "APPROVED"},
+				{Status: "CHANGES_REQUESTED", Summary: "needs work", Issues: []string{"c.go leaks a file", "no tests"}},
+				{Status: "UNKNOWN"},
+			},
+			wantStatus:  "CHANGES_REQUESTED",
+			wantIssues:  []string{"c.go leaks a file", "b.go, c.go: no tests"},
+			wantSummary: "b.go, c.go: needs work",
+		},
+		{
+			name: "findings are attributed to their batch",
+			reviews: []*ReviewResult{
+				{Status: "CHANGES_REQUESTED", Findings: []ReviewFinding{{Severity: "HIGH", Message: "nil map write"}}},
+				{Status: "CHANGES_REQUESTED", Findings: []ReviewFinding{{Severity: "LOW", File: "c.go", Line: 3, Message: "typo"}, {Severity: "MED", Message: "naming"}}},
+				nil,
+			},
+			wantStatus:  "CHANGES_REQUESTED",
+			wantIssues:  []string{"[HIGH] a.go nil map write", "[LOW] c.go:3 typo", "[MED] b.go, c.go: naming"},
+			wantSummary: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeReviewResults(batches, tt.reviews)
+			if got.Status != tt.wantStatus || got.Summary != tt.wantSummary || strings.Join(got.Issues, "|") != strings.Join(tt.wantIssues, "|") {
+				t.Errorf("mergeReviewResults() = %+v, want status %s, summary %q, issues %q", got, tt.wantStatus, tt.wantSummary, tt.wantIssues)
+			}
+		})
+	}
+}
+
+func TestReviewAgent_Review_batches(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	projectDir := t.TempDir()
+	// Each batch must only see its own files' diff; b.go's batch requests changes.
+	script := `#!/bin/sh
+case "$*" in
+*"+change a"*"+change b"*) printf '狀態: CHANGES_REQUESTED\n問題:\n- saw the whole diff\n' ;;
+*"+change b"*) printf '狀態: CHANGES_REQUESTED\n問題:\n- missing check\n' ;;
+*) printf '狀態: APPROVED\n' ;;
+esac
+`
+	agentPath := filepath.Join(t.TempDir(), "fake-agent")
+	if err := os.WriteFile(agentPath, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	ra := NewReviewAgent(NewCaller(agentPath, true, "text", ""), projectDir)
+	ra.SetDiff("diff --git a/a.go b/a.go\n+change a\ndiff --git a/b.go b/b.go\n+change b\n")
+	ra.SetBatching(1, 2)
+
+	result, review, err := ra.Review(context.Background(), []string{"a.go", "b.go", "c.go"})
+	if err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+	if !result.Success || result.Attempts != 3 {
+		t.Errorf("Review() result = %+v, want the three successful batch calls", result)
+	}
+	if review.Status != "CHANGES_REQUESTED" || strings.Join(review.Issues, "|") != "b.go: missing check" {
+		t.Errorf("Review() = %+v, want b.go's issue", review)
+	}
+	data, err := os.ReadFile(filepath.Join(projectDir, ".tickets", "review-result.json"))
+	if err != nil || !strings.Contains(string(data), "b.go: missing check") {
+		t.Errorf("the merged review should be written to review-result.json, got %s, %v", data, err)
+	}
+}
@@ -0,0 +1,127 @@
+package agent
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+const (
+	// expandMinIssues is the number of issues in one category that warrants a deeper pass.
+	expandMinIssues = 3
+	// expandMinHigh is the number of HIGH issues in one category that warrants a deeper pass.
+	expandMinHigh = 2
+	// expandMaxDirs caps how many directories one suggested pass is focused on.
+	expandMaxDirs = 3
+)
+
+// ScopeSuggestion is a follow-up analyze pass suggested by earlier findings: one
+// category, optionally narrowed to the directories where its issues cluster.
+type ScopeSuggestion struct {
+	Category string
+	// Dirs are the directories to focus on; empty means the whole project.
+	Dirs []string
+	// Issues and High count the findings that triggered the suggestion.
+	Issues int
+	High   int
+}
+
+// Key identifies the suggestion so the same pass is not suggested twice.
+func (s ScopeSuggestion) Key() string {
+	return s.Category + ":" + strings.Join(s.Dirs, ",")
+}
+
+// Scope returns the analyze scope of the suggested pass.
+func (s ScopeSuggestion) Scope() AnalyzeScope {
+	return ParseScopes([]string{s.Category})
+}
+
+// SuggestExpansions looks at analyze findings and suggests deeper single-category passes
+// where issues concentrate: a category with at least expandMinIssues issues or
+// expandMinHigh HIGH issues gets a pass focused on its busiest directories. Suggestions
+// whose Key is in done are skipped. Results are ordered by HIGH count, then issue count.
+func SuggestExpansions(issues *ticket.IssueList, done map[string]bool) []ScopeSuggestion {
+	type stats struct {
+		issues, high int
+		dirs         map[string]int
+	}
+	byCategory := make(map[string]*stats)
+	for _, issue := range issues.Issues {
+		cat := strings.ToLower(strings.TrimSpace(issue.Category))
+		if cat == "" {
+			continue
+		}
+		st := byCategory[cat]
+		if st == nil {
+			st = &stats{dirs: make(map[string]int)}
+			byCategory[cat] = st
+		}
+		st.issues++
+		if ticket.SeverityRank(issue.Severity) == 3 {
+			st.high++
+		}
+		if dir := locationDir(issue.Location); dir != "" {
+			st.dirs[dir]++
+		}
+	}
+
+	var out []ScopeSuggestion
+	for cat, st := range byCategory {
+		if st.issues < expandMinIssues && st.high < expandMinHigh {
+			continue
+		}
+		s := ScopeSuggestion{Category: cat, Dirs: topDirs(st.dirs, expandMaxDirs), Issues: st.issues, High: st.high}
+		if done[s.Key()] {
+			continue
+		}
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].High != out[j].High {
+			return out[i].High > out[j].High
+		}
+		if out[i].Issues != out[j].Issues {
+			return out[i].Issues > out[j].Issues
+		}
+		return out[i].Category < out[j].Category
+	})
+	return out
+}
+
+// locationDir returns the directory of an issue location such as "service/user.go:45";
+// empty when the location has no directory part.
+func locationDir(location string) string {
+	location = strings.TrimSpace(location)
+	if i := strings.Index(location, ":"); i >= 0 {
+		location = location[:i]
+	}
+	if location == "" {
+		return ""
+	}
+	dir := filepath.ToSlash(filepath.Dir(location))
+	if dir == "." || dir == "/" {
+		return ""
+	}
+	return dir
+}
+
+// topDirs returns up to n directories with the most issues, ties broken by name.
+func topDirs(counts map[string]int, n int) []string {
+	dirs := make([]string, 0, len(counts))
+	for d := range counts {
+		dirs = append(dirs, d)
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		if counts[dirs[i]] != counts[dirs[j]] {
+			return counts[dirs[i]] > counts[dirs[j]]
+		}
+		return dirs[i] < dirs[j]
+	})
+	if len(dirs) > n {
+		dirs = dirs[:n]
+	}
+	sort.Strings(dirs)
+	return dirs
+}
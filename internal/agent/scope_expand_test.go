@@ -0,0 +1,56 @@
+package agent
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestSuggestExpansions(t *testing.T) {
+	issues := ticket.NewIssueList()
+	for _, i := range []*ticket.Issue{
+		{ID: "S1", Category: "security", Severity: "HIGH", Location: "internal/auth/token.go:12"},
+		{ID: "S2", Category: "security", Severity: "HIGH", Location: "internal/auth/session.go:40"},
+		{ID: "P1", Category: "performance", Severity: "LOW", Location: "db/query.go:3"},
+		{ID: "P2", Category: "performance", Severity: "MED", Location: "db/query.go:30"},
+		{ID: "P3", Category: "performance", Severity: "LOW", Location: "main.go"},
+		{ID: "D1", Category: "docs", Severity: "HIGH", Location: "README.md"},
+	} {
+		issues.Add(i)
+	}
+
+	got := SuggestExpansions(issues, nil)
+	if len(got) != 2 {
+		t.Fatalf("SuggestExpansions() = %+v, want security and performance", got)
+	}
+	if got[0].Category != "security" || got[0].High != 2 || strings.Join(got[0].Dirs, ",") != "internal/auth" {
+		t.Errorf("first suggestion = %+v, want security on internal/auth", got[0])
+	}
+	if got[1].Category != "performance" || got[1].Issues != 3 || strings.Join(got[1].Dirs, ",") != "db" {
+		t.Errorf("second suggestion = %+v, want performance on db", got[1])
+	}
+	if !got[0].Scope().Security || got[0].Scope().Performance {
+		t.Errorf("Scope() = %+v, want security only", got[0].Scope())
+	}
+
+	done := map[string]bool{got[0].Key(): true}
+	if again := SuggestExpansions(issues, done); len(again) != 1 || again[0].Category != "performance" {
+		t.Errorf("SuggestExpansions() with done = %+v, want only performance", again)
+	}
+	if none := SuggestExpansions(ticket.NewIssueList(), nil); len(none) != 0 {
+		t.Errorf("SuggestExpansions() on no issues = %+v", none)
+	}
+}
+
+func TestAnalyzeAgent_buildAnalyzePrompt_followUp(t *testing.T) {
+	aa := NewAnalyzeAgent(nil, "/test/project")
+	aa.SetFollowUp([]string{"internal/auth"}, []*ticket.Issue{{Severity: "HIGH", Title: "Hardcoded secret", Location: "internal/auth/token.go:12"}})
+	prompt := aa.buildAnalyzePrompt(ParseScopes([]string{"security"}))
+	for _, want := range []string{"- internal/auth\n", "Hardcoded secret", strings.TrimSuffix(i18n.AgentAnalyzeFollowUp, "\n")} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("buildAnalyzePrompt() should contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/project"
+)
+
+// Test modes (config test_mode): how RunTests runs the project's tests.
+const (
+	// TestModeAgent asks the agent to find, run and analyze the tests.
+	TestModeAgent = "agent"
+	// TestModeNative runs the detected test commands directly and calls the agent only
+	// to analyze failures.
+	TestModeNative = "native"
+)
+
+// testTimeout bounds a test run, by the agent or by the native commands together.
+const testTimeout = 15 * time.Minute
+
+// maxTestFailureOutput caps the test output embedded in the failure analysis prompt;
+// the end, where the failures are summarized, is kept.
+const maxTestFailureOutput = 30000
+
+// SetMode selects how RunTests runs the tests (TestModeAgent or TestModeNative).
+func (ta *TestAgent) SetMode(mode string) {
+	ta.mode = mode
+}
+
+// testCommands returns the test commands of the project types detected in the project
+// directory, e.g. "go test ./..." and "npm test" for a Go backend with a Node frontend.
+func (ta *TestAgent) testCommands() []string {
+	var commands []string
+	for _, d := range project.Detect(ta.projectDir) {
+		if d.Commands.Test != "" {
+			commands = append(commands, d.Commands.Test)
+		}
+	}
+	return commands
+}
+
+// runNative runs commands in the project directory and parses their combined output.
+// Only when a command fails is the agent called, to analyze the failures; its analysis
+// is appended to the output. An analysis that fails leaves the test results as they are.
+func (ta *TestAgent) runNative(ctx context.Context, commands []string) (*Result, *TestResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, testTimeout)
+	defer cancel()
+
+	start := time.Now()
+	result := &Result{Success: true}
+	var output, retries strings.Builder
+	var flaky []string
+	for _, command := range commands {
+		out, exitCode, err := runTestCommand(ctx, ta.projectDir, command)
+		fmt.Fprintf(&output, "$ %s\n%s", command, out)
+		if err != nil {
+			fmt.Fprintf(&output, "%v\n", err)
+			exitCode = -1
+		}
+		if exitCode != 0 && ta.retries > 0 && ctx.Err() == nil {
+			commandFlaky, passed := ta.retryFailed(ctx, command, out, &retries)
+			flaky = append(flaky, commandFlaky...)
+			if passed {
+				exitCode = 0
+			}
+		}
+		if exitCode != 0 {
+			result.Success = false
+			if result.ExitCode == 0 {
+				result.ExitCode = exitCode
+			}
+		}
+	}
+	result.TimedOut = ctx.Err() == context.DeadlineExceeded
+	result.Output = output.String() + retries.String()
+	result.Duration = time.Since(start)
+	testResult := ta.parseTestResult(output.String())
+	testResult.markFlaky(flaky)
+
+	if result.Success || ctx.Err() != nil {
+		return result, testResult, nil
+	}
+	analysis, err := ta.caller.Call(ctx, ta.buildFailurePrompt(result.Output),
+		WithWorkingDir(ta.projectDir),
+		WithTimeout(10*time.Minute),
+		WithModel(ta.caller.modelFor(ModelKeyTesting)),
+	)
+	if err == nil && analysis != nil {
+		result.Output += "\n" + analysis.Output
+		result.Attempts = analysis.Attempts
+		result.Usage = analysis.Usage
+		result.LogPath = analysis.LogPath
+	}
+	return result, testResult, nil
+}
+
+// runTestCommand runs command through the platform shell in dir and returns its
+// combined output and exit code. err is set only when the command could not run to
+// completion (e.g. it was not found by the shell's exec or timed out).
+func runTestCommand(ctx context.Context, dir, command string) (string, int, error) {
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	cmd := exec.CommandContext(ctx, shell, flag, command)
+	cmd.Dir = dir
+	// Children of the shell may keep the output pipes open after a timeout kill.
+	cmd.WaitDelay = time.Second
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		return out.String(), -1, ctx.Err()
+	case errors.As(err, &exitErr):
+		return out.String(), exitErr.ExitCode(), nil
+	}
+	return out.String(), 0, err
+}
+
+// buildFailurePrompt creates the prompt asking the agent to analyze the failures in
+// output, the output of the test commands.
+func (ta *TestAgent) buildFailurePrompt(output string) string {
+	output = strings.TrimRight(output, "\n")
+	if len(output) > maxTestFailureOutput {
+		output = "...\n" + output[len(output)-maxTestFailureOutput:]
+	}
+	return fmt.Sprintf(i18n.AgentTestFailurePrompt, ta.projectDir, output)
+}
+
+// SetRetries sets how many times RunTests reruns failed tests in TestModeNative (see
+// config test_retries). Tests that pass on a rerun are reported as flaky instead of
+// failing the run.
+func (ta *TestAgent) SetRetries(n int) {
+	ta.retries = n
+}
+
+// retryFailed reruns command, which failed with output out, up to ta.retries times:
+// only the failed tests when their names are known and the command supports selecting
+// them (see rerunCommand), else the whole command. Tests that pass on a rerun are
+// flaky; a command whose failed tests are not known is flaky as a whole when a rerun
+// passes. It returns the flaky tests and whether the command passed in the end. The
+// reruns' output is written to log.
+func (ta *TestAgent) retryFailed(ctx context.Context, command, out string, log *strings.Builder) ([]string, bool) {
+	remaining := parseFailedTests(out)
+	var flaky []string
+	for attempt := 1; attempt <= ta.retries && ctx.Err() == nil; attempt++ {
+		rerun := command
+		if c := rerunCommand(command, remaining); c != "" {
+			rerun = c
+		}
+		fmt.Fprintf(log, "\n"+i18n.AgentTestRetry+"\n$ %s\n", attempt, ta.retries, rerun)
+		rerunOut, exitCode, err := runTestCommand(ctx, ta.projectDir, rerun)
+		log.WriteString(rerunOut)
+		if err != nil {
+			fmt.Fprintf(log, "%v\n", err)
+			continue
+		}
+		if exitCode == 0 {
+			if len(remaining) == 0 {
+				remaining = []string{command}
+			}
+			return append(flaky, remaining...), true
+		}
+		still := parseFailedTests(rerunOut)
+		if len(still) == 0 || len(remaining) == 0 {
+			// No per-test results (e.g. a build error): nothing can be told apart.
+			continue
+		}
+		var next []string
+		for _, name := range remaining {
+			if slices.Contains(still, name) {
+				next = append(next, name)
+			} else {
+				flaky = append(flaky, name)
+			}
+		}
+		remaining = next
+	}
+	return flaky, false
+}
+
+// failedTestPatterns match the name of a failed test: go test ("--- FAIL: TestLogin"),
+// pytest ("FAILED tests/test_auth.py::test_logout - ..."), cargo test
+// ("test auth::tests::login ... FAILED") and dotnet test ("  Failed Demo.AuthTests.Login [12 ms]").
+var failedTestPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?m)^\s*--- FAIL: (\S+)`),
+	regexp.MustCompile(`(?m)^FAILED (\S+)`),
+	regexp.MustCompile(`(?m)^test (\S+) \.\.\. FAILED`),
+	regexp.MustCompile(`(?m)^\s+Failed (\S+) \[`),
+}
+
+// parseFailedTests returns the names of the failed tests in output, in order and
+// without duplicates.
+func parseFailedTests(output string) []string {
+	var names []string
+	for _, p := range failedTestPatterns {
+		for _, m := range p.FindAllStringSubmatch(output, -1) {
+			if !slices.Contains(names, m[1]) {
+				names = append(names, m[1])
+			}
+		}
+	}
+	return names
+}
+
+// rerunCommand returns command narrowed to the tests names, for go test (-run with the
+// top-level test names, as subtests run with their parent) and pytest (the test IDs).
+// Returns "" when there are no names or command is of another kind.
+func rerunCommand(command string, names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	switch {
+	case strings.HasPrefix(command, "go test"):
+		var top []string
+		for _, name := range names {
+			name, _, _ = strings.Cut(name, "/")
+			if name = regexp.QuoteMeta(name); !slices.Contains(top, name) {
+				top = append(top, name)
+			}
+		}
+		return fmt.Sprintf(`go test -run "^(%s)$"%s`, strings.Join(top, "|"), strings.TrimPrefix(command, "go test"))
+	case command == "pytest" || strings.HasPrefix(command, "pytest "):
+		return command + " " + strings.Join(names, " ")
+	}
+	return ""
+}
+
+// markFlaky records flaky, the tests that passed on a rerun, on the result: they are
+// counted as passed instead of failed and left out of FailedTests.
+func (r *TestResult) markFlaky(flaky []string) {
+	if len(flaky) == 0 {
+		return
+	}
+	for _, name := range flaky {
+		if i := slices.Index(r.FailedTests, name); i >= 0 {
+			r.FailedTests = slices.Delete(r.FailedTests, i, i+1)
+			if r.Failed > 0 {
+				r.Failed--
+				r.Passed++
+			}
+		}
+	}
+	r.Flaky = flaky
+	r.Summary = strings.TrimPrefix(summarizeTestResult(r.Passed, r.Failed, r.Skipped)+fmt.Sprintf(", %d flaky", len(flaky)), ", ")
+}
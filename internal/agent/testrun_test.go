@@ -0,0 +1,136 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestTestAgent_RunTests_native(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not available")
+	}
+	projectDir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(projectDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("go.mod", "module example.com/demo\n\ngo 1.21\n")
+	write("demo_test.go", "package demo\n\nimport \"testing\"\n\nfunc TestGood(t *testing.T) {}\n\nfunc TestBad(t *testing.T) { t.Fatal(\"status = 500\") }\n")
+
+	// The fake agent records that it was asked to analyze the failures.
+	calls := filepath.Join(t.TempDir(), "calls")
+	agentPath := filepath.Join(t.TempDir(), "fake-agent")
+	script := "#!/bin/sh\necho called >> " + calls + "\ncase \"$*\" in\n*TestBad*) echo 'Analysis: TestBad expects 200' ;;\nesac\n"
+	if err := os.WriteFile(agentPath, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	ta := NewTestAgent(NewCaller(agentPath, true, "text", ""), projectDir)
+	ta.SetMode(TestModeNative)
+
+	result, testResult, err := ta.RunTests(context.Background())
+	if err != nil {
+		t.Fatalf("RunTests() error = %v", err)
+	}
+	if result.Success || result.ExitCode == 0 || testResult.Failed != 1 {
+		t.Errorf("RunTests() = %+v, %+v; want one failed test", result, testResult)
+	}
+	if !strings.Contains(result.Output, "$ go test ./...") || !strings.Contains(result.Output, "Analysis: TestBad expects 200") {
+		t.Errorf("output should hold the test output and the agent's analysis, got:\n%s", result.Output)
+	}
+
+	// Passing tests do not call the agent.
+	write("demo_test.go", "package demo\n\nimport \"testing\"\n\nfunc TestGood(t *testing.T) {}\n")
+	if err := os.Remove(calls); err != nil {
+		t.Fatal(err)
+	}
+	result, _, err = ta.RunTests(context.Background())
+	if err != nil || !result.Success {
+		t.Fatalf("RunTests() = %+v, %v; want success", result, err)
+	}
+	if _, err := os.Stat(calls); !os.IsNotExist(err) {
+		t.Errorf("the agent should not be called when the tests pass, stat error = %v", err)
+	}
+}
+
+func TestTestAgent_RunTests_flaky(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not available")
+	}
+	projectDir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(projectDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("go.mod", "module example.com/demo\n\ngo 1.21\n")
+	// TestFlaky fails on its first run only; TestBroken always fails.
+	flaky := "func TestFlaky(t *testing.T) {\n\tif _, err := os.Stat(\"ran\"); err != nil {\n\t\tos.WriteFile(\"ran\", nil, 0644)\n\t\tt.Fatal(\"first run\")\n\t}\n}\n"
+	write("demo_test.go", "package demo\n\nimport (\n\t\"os\"\n\t\"testing\"\n)\n\n"+flaky+"\nfunc TestBroken(t *testing.T) { t.Fatal(\"always\") }\n\nfunc TestGood(t *testing.T) {}\n")
+
+	agentPath := filepath.Join(t.TempDir(), "fake-agent")
+	if err := os.WriteFile(agentPath, []byte("#!/bin/sh\necho 'Analysis'\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	ta := NewTestAgent(NewCaller(agentPath, true, "text", ""), projectDir)
+	ta.SetMode(TestModeNative)
+	ta.SetRetries(2)
+
+	result, testResult, err := ta.RunTests(context.Background())
+	if err != nil {
+		t.Fatalf("RunTests() error = %v", err)
+	}
+	if result.Success {
+		t.Errorf("RunTests() should fail while TestBroken fails, got:\n%s", result.Output)
+	}
+	if strings.Join(testResult.Flaky, ",") != "TestFlaky" || strings.Join(testResult.FailedTests, ",") != "TestBroken" || testResult.Failed != 1 {
+		t.Errorf("RunTests() = %+v, want TestFlaky flaky and TestBroken failed", testResult)
+	}
+	if !strings.Contains(result.Output, `go test -run "^(TestFlaky|TestBroken)$" ./...`) {
+		t.Errorf("only the failed tests should be rerun, got:\n%s", result.Output)
+	}
+
+	// With only the flaky test failing, the run passes.
+	write("demo_test.go", "package demo\n\nimport (\n\t\"os\"\n\t\"testing\"\n)\n\n"+flaky)
+	if err := os.Remove(filepath.Join(projectDir, "ran")); err != nil {
+		t.Fatal(err)
+	}
+	result, testResult, err = ta.RunTests(context.Background())
+	if err != nil || !result.Success {
+		t.Fatalf("RunTests() = %+v, %v; want success with a flaky test", result, err)
+	}
+	if strings.Join(testResult.Flaky, ",") != "TestFlaky" || testResult.Failed != 0 || testResult.Summary != "1 passed, 1 flaky" {
+		t.Errorf("RunTests() = %+v, want TestFlaky flaky", testResult)
+	}
+}
+
+func TestRerunCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		names   []string
+		want    string
+	}{
+		{command: "go test ./...", names: nil, want: ""},
+		{command: "go test ./...", names: []string{"TestA", "TestA/sub", "TestB"}, want: `go test -run "^(TestA|TestB)$" ./...`},
+		{command: "pytest", names: []string{"tests/test_a.py::test_x"}, want: "pytest tests/test_a.py::test_x"},
+		{command: "npm test", names: []string{"x"}, want: ""},
+	}
+	for _, tt := range tests {
+		if got := rerunCommand(tt.command, tt.names); got != tt.want {
+			t.Errorf("rerunCommand(%q, %q) = %q, want %q", tt.command, tt.names, got, tt.want)
+		}
+	}
+}
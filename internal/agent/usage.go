@@ -0,0 +1,85 @@
+package agent
+
+import "strings"
+
+// Usage is the model usage an agent reported for a call. Zero when the agent reports
+// none (e.g. text output).
+type Usage struct {
+	// InputTokens includes prompt-cache reads and writes.
+	InputTokens  int
+	OutputTokens int
+	// CostUSD is the cost the agent reported; zero when it reports tokens only.
+	CostUSD float64
+}
+
+// Tokens returns the total of input and output tokens.
+func (u Usage) Tokens() int {
+	return u.InputTokens + u.OutputTokens
+}
+
+// IsZero reports whether no usage was reported.
+func (u Usage) IsZero() bool {
+	return u == Usage{}
+}
+
+// Add returns the sum of u and o.
+func (u Usage) Add(o Usage) Usage {
+	return Usage{
+		InputTokens:  u.InputTokens + o.InputTokens,
+		OutputTokens: u.OutputTokens + o.OutputTokens,
+		CostUSD:      u.CostUSD + o.CostUSD,
+	}
+}
+
+// Sub returns u minus o, e.g. the usage of the calls made between two Caller.Usage readings.
+func (u Usage) Sub(o Usage) Usage {
+	return Usage{
+		InputTokens:  u.InputTokens - o.InputTokens,
+		OutputTokens: u.OutputTokens - o.OutputTokens,
+		CostUSD:      u.CostUSD - o.CostUSD,
+	}
+}
+
+// usageOf returns the usage reported by the result events of r: stream-json result
+// events, or for json output the result object that makes up the whole output.
+func usageOf(r *Result) Usage {
+	var u Usage
+	found := false
+	for _, ev := range r.StreamEvents {
+		if ev.Type == "result" {
+			u = u.Add(usageFromEvent(ev.Data))
+			found = true
+		}
+	}
+	if !found {
+		if ev := parseStreamEvent(strings.TrimSpace(r.Output)); ev != nil && ev.Type == "result" {
+			u = usageFromEvent(ev.Data)
+		}
+	}
+	return u
+}
+
+// usageFromEvent reads the "usage" object (Anthropic field names) and the cost
+// ("total_cost_usd", or "cost_usd" in older Claude Code versions) of a result event.
+func usageFromEvent(data map[string]interface{}) Usage {
+	var u Usage
+	if usage, ok := data["usage"].(map[string]interface{}); ok {
+		for _, key := range []string{"input_tokens", "cache_creation_input_tokens", "cache_read_input_tokens"} {
+			u.InputTokens += intField(usage, key)
+		}
+		u.OutputTokens = intField(usage, "output_tokens")
+	}
+	for _, key := range []string{"total_cost_usd", "cost_usd"} {
+		if cost, ok := data[key].(float64); ok {
+			u.CostUSD = cost
+			break
+		}
+	}
+	return u
+}
+
+// intField returns the JSON number m[key] as an int, or 0.
+func intField(m map[string]interface{}, key string) int {
+	n, _ := m[key].(float64)
+	return int(n)
+}
@@ -0,0 +1,73 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestUsageOf(t *testing.T) {
+	claudeResult := `{"type":"result","subtype":"success","result":"done","total_cost_usd":0.25,` +
+		`"usage":{"input_tokens":100,"cache_creation_input_tokens":20,"cache_read_input_tokens":30,"output_tokens":50}}`
+
+	tests := []struct {
+		name   string
+		result *Result
+		want   Usage
+	}{
+		{
+			name:   "stream-json result event",
+			result: &Result{StreamEvents: []StreamEvent{*parseStreamEvent(`{"type":"system","subtype":"init"}`), *parseStreamEvent(claudeResult)}},
+			want:   Usage{InputTokens: 150, OutputTokens: 50, CostUSD: 0.25},
+		},
+		{
+			name:   "json output",
+			result: &Result{Output: claudeResult + "\n"},
+			want:   Usage{InputTokens: 150, OutputTokens: 50, CostUSD: 0.25},
+		},
+		{
+			name:   "legacy cost field",
+			result: &Result{Output: `{"type":"result","cost_usd":0.1}`},
+			want:   Usage{CostUSD: 0.1},
+		},
+		{
+			name:   "text output",
+			result: &Result{Output: "done"},
+			want:   Usage{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := usageOf(tt.result); got != tt.want {
+				t.Errorf("usageOf() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCaller_Usage(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	path := filepath.Join(t.TempDir(), "agent")
+	script := "#!/bin/sh\necho '{\"type\":\"result\",\"total_cost_usd\":0.5,\"usage\":{\"input_tokens\":10,\"output_tokens\":5}}'\n"
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	caller := NewCaller(path, false, "json", "")
+	for i := 0; i < 2; i++ {
+		result, err := caller.Call(context.Background(), "prompt")
+		if err != nil {
+			t.Fatalf("Call() error = %v", err)
+		}
+		if want := (Usage{InputTokens: 10, OutputTokens: 5, CostUSD: 0.5}); result.Usage != want {
+			t.Errorf("Result.Usage = %+v, want %+v", result.Usage, want)
+		}
+	}
+	if got, want := caller.Usage(), (Usage{InputTokens: 20, OutputTokens: 10, CostUSD: 1}); got != want {
+		t.Errorf("Caller.Usage() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,122 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/jsonutil"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// VerificationAgent asks the agent whether a completed ticket meets its acceptance
+// criteria, one verdict per criterion, by inspecting the repository and the ticket's
+// assertion results (and running tests when needed). Unlike MilestoneReviewAgent it
+// judges a single ticket's own criteria.
+type VerificationAgent struct {
+	caller     *Caller
+	projectDir string
+}
+
+// NewVerificationAgent creates a VerificationAgent with the given Caller and project directory.
+func NewVerificationAgent(caller *Caller, projectDir string) *VerificationAgent {
+	return &VerificationAgent{
+		caller:     caller,
+		projectDir: projectDir,
+	}
+}
+
+// Verify evaluates t against its acceptance criteria. The agent writes its verdicts to
+// .tickets/verify-<id>.json. Criteria the agent gives no verdict on fail. Returns nil
+// and no error when t has no acceptance criteria, as there is nothing to verify, and
+// on dry run.
+func (va *VerificationAgent) Verify(ctx context.Context, t *ticket.Ticket) (*ticket.Verification, error) {
+	if len(t.AcceptanceCriteria) == 0 {
+		return nil, nil
+	}
+
+	outputFile := filepath.Join(va.projectDir, ".tickets", "verify-"+t.ID+".json")
+	if err := os.MkdirAll(filepath.Dir(outputFile), 0700); err != nil {
+		return nil, fmt.Errorf(i18n.ErrAgentMkdirOutput, err)
+	}
+	// A verdict left by an earlier verification must not be taken for this one.
+	_ = os.Remove(outputFile)
+	defer os.Remove(outputFile)
+
+	start := recordCallStarted(t, roleVerify)
+	result, data, err := va.caller.CallForJSON(ctx, va.buildPrompt(t), outputFile,
+		WithWorkingDir(va.projectDir),
+		WithTimeout(15*time.Minute),
+		WithModel(va.caller.modelFor(ModelKeyReview)),
+	)
+	recordCallFinished(t, start, result, err)
+	if err != nil {
+		if va.caller.DryRun {
+			return nil, nil
+		}
+		return nil, fmt.Errorf(i18n.ErrAgentVerifyFailed, err)
+	}
+	if !result.Success {
+		return nil, fmt.Errorf(i18n.ErrAgentVerifyFailed, result.Error)
+	}
+	return parseVerification(t, data), nil
+}
+
+// buildPrompt renders the verification prompt for t: its description, acceptance
+// criteria and the results of its assertions, if they ran.
+func (va *VerificationAgent) buildPrompt(t *ticket.Ticket) string {
+	var criteria strings.Builder
+	for i, c := range t.AcceptanceCriteria {
+		fmt.Fprintf(&criteria, "%d. %s\n", i+1, c)
+	}
+	assertions := i18n.AgentVerifyNoAssertions + "\n"
+	if len(t.AssertionResults) > 0 {
+		var b strings.Builder
+		for _, r := range t.AssertionResults {
+			status := "PASS"
+			if !r.Passed {
+				status = "FAIL: " + r.Error
+			}
+			fmt.Fprintf(&b, "- %s (%s)\n", r.Command, status)
+		}
+		assertions = b.String()
+	}
+	return fmt.Sprintf(i18n.AgentVerifyPrompt, va.projectDir, t.ID, t.Title, t.Description, criteria.String(), assertions)
+}
+
+// parseVerification builds the verification of t from the agent's JSON output
+// ({summary, criteria: [{criterion, passed, evidence}]}). Verdicts are matched to t's
+// criteria by text, else by position; criteria without a verdict fail.
+func parseVerification(t *ticket.Ticket, data map[string]interface{}) *ticket.Verification {
+	v := &ticket.Verification{Summary: jsonutil.GetString(data, "summary"), VerifiedAt: time.Now()}
+	items, _ := data["criteria"].([]interface{})
+	verdicts := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			verdicts = append(verdicts, m)
+		}
+	}
+	for i, criterion := range t.AcceptanceCriteria {
+		verdict := ticket.CriterionVerdict{Criterion: criterion, Evidence: i18n.AgentVerifyNoVerdict}
+		var match map[string]interface{}
+		for _, m := range verdicts {
+			if strings.TrimSpace(jsonutil.GetString(m, "criterion")) == strings.TrimSpace(criterion) {
+				match = m
+				break
+			}
+		}
+		if match == nil && i < len(verdicts) {
+			match = verdicts[i]
+		}
+		if match != nil {
+			verdict.Passed = jsonutil.GetBool(match, "passed")
+			verdict.Evidence = jsonutil.GetString(match, "evidence")
+		}
+		v.Criteria = append(v.Criteria, verdict)
+	}
+	return v
+}
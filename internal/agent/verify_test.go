@@ -0,0 +1,95 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestParseVerification(t *testing.T) {
+	tk := &ticket.Ticket{ID: "T-1", AcceptanceCriteria: []string{"login works", "logout works"}}
+	tests := []struct {
+		name       string
+		data       map[string]interface{}
+		wantPassed []bool
+		wantNoneOn int // index of the criterion expected to have no verdict, or -1
+	}{
+		{
+			name: "matched by text in any order",
+			data: map[string]interface{}{"criteria": []interface{}{
+				map[string]interface{}{"criterion": "logout works", "passed": false, "evidence": "no endpoint"},
+				map[string]interface{}{"criterion": " login works ", "passed": true},
+			}},
+			wantPassed: []bool{true, false},
+			wantNoneOn: -1,
+		},
+		{
+			name: "matched by position when reworded",
+			data: map[string]interface{}{"criteria": []interface{}{
+				map[string]interface{}{"criterion": "Login", "passed": true},
+				map[string]interface{}{"criterion": "Logout", "passed": true},
+			}},
+			wantPassed: []bool{true, true},
+			wantNoneOn: -1,
+		},
+		{
+			name: "missing verdict fails",
+			data: map[string]interface{}{"criteria": []interface{}{
+				map[string]interface{}{"criterion": "login works", "passed": true},
+			}},
+			wantPassed: []bool{true, false},
+			wantNoneOn: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := parseVerification(tk, tt.data)
+			if len(v.Criteria) != len(tt.wantPassed) {
+				t.Fatalf("parseVerification() = %+v", v)
+			}
+			for i, want := range tt.wantPassed {
+				if v.Criteria[i].Criterion != tk.AcceptanceCriteria[i] || v.Criteria[i].Passed != want {
+					t.Errorf("criterion %d = %+v, want %q passed=%v", i, v.Criteria[i], tk.AcceptanceCriteria[i], want)
+				}
+				if (v.Criteria[i].Evidence == i18n.AgentVerifyNoVerdict) != (i == tt.wantNoneOn) {
+					t.Errorf("criterion %d evidence = %q", i, v.Criteria[i].Evidence)
+				}
+			}
+		})
+	}
+}
+
+func TestVerificationAgent_Verify(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	projectDir := t.TempDir()
+	script := "#!/bin/sh\nmkdir -p .tickets\ncat > .tickets/verify-T-1.json <<'EOF'\n" +
+		`{"summary": "ok", "criteria": [{"criterion": "tests pass", "passed": true, "evidence": "go test ./..."}]}` + "\nEOF\n"
+	agentPath := filepath.Join(t.TempDir(), "fake-agent")
+	if err := os.WriteFile(agentPath, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	va := NewVerificationAgent(NewCaller(agentPath, true, "text", ""), projectDir)
+
+	tk := &ticket.Ticket{ID: "T-1", Title: "Tests", AcceptanceCriteria: []string{"tests pass"}}
+	v, err := va.Verify(context.Background(), tk)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if v == nil || !v.Passed() || v.Summary != "ok" || v.Criteria[0].Evidence != "go test ./..." {
+		t.Errorf("Verify() = %+v, want one passed criterion", v)
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, ".tickets", "verify-T-1.json")); !os.IsNotExist(err) {
+		t.Errorf("the verdict file should be removed, stat error = %v", err)
+	}
+
+	if v, err := va.Verify(context.Background(), &ticket.Ticket{ID: "T-2"}); v != nil || err != nil {
+		t.Errorf("Verify() without criteria = %+v, %v; want nil, nil", v, err)
+	}
+}
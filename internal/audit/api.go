@@ -0,0 +1,52 @@
+package audit
+
+import "time"
+
+// APIFileName is the default file name of the serve API audit log inside the tickets
+// directory.
+const APIFileName = "api-audit.jsonl"
+
+// APIEntry is a request to the serve API that changed something (any method but GET)
+// or was refused for lack of a valid token or scope.
+type APIEntry struct {
+	Time time.Time `json:"time"`
+	// Token is the name of the token the request was made with; empty without one.
+	Token  string `json:"token,omitempty"`
+	Scope  string `json:"scope,omitempty"`
+	Remote string `json:"remote"`
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Status int    `json:"status"`
+	// Target is what the request created: a ticket ID, job ID or work queue entry.
+	Target string `json:"target,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// AppendAPI writes an entry to the API audit log at path, as Append does.
+func AppendAPI(path string, e APIEntry) error {
+	return appendLine(path, e)
+}
+
+// LoadAPI reads all entries from the API audit log in append order.
+func LoadAPI(path string) ([]APIEntry, error) {
+	return loadLines[APIEntry](path)
+}
+
+// FilterAPI returns the entries within [since, until) made with the token named token.
+// Zero times and an empty token do not filter.
+func FilterAPI(entries []APIEntry, since, until time.Time, token string) []APIEntry {
+	out := make([]APIEntry, 0, len(entries))
+	for _, e := range entries {
+		if !since.IsZero() && e.Time.Before(since) {
+			continue
+		}
+		if !until.IsZero() && !e.Time.Before(until) {
+			continue
+		}
+		if token != "" && e.Token != token {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
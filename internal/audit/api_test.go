@@ -0,0 +1,49 @@
+package audit
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppendAPIAndFilter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), APIFileName)
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, e := range []APIEntry{
+		{Time: base, Token: "ci", Scope: "work", Method: "POST", Path: "/api/work", Status: 202, Target: "work-1"},
+		{Time: base.Add(time.Hour), Method: "POST", Path: "/api/plan", Status: 401, Error: "unauthorized"},
+		{Time: base.Add(2 * time.Hour), Token: "ci", Method: "POST", Path: "/api/plan", Status: 403},
+	} {
+		if err := AppendAPI(path, e); err != nil {
+			t.Fatalf("AppendAPI() error = %v", err)
+		}
+	}
+	entries, err := LoadAPI(path)
+	if err != nil || len(entries) != 3 {
+		t.Fatalf("LoadAPI() = %v, %v", entries, err)
+	}
+	if entries[0].Target != "work-1" || entries[1].Error != "unauthorized" {
+		t.Errorf("LoadAPI() = %+v", entries)
+	}
+
+	tests := []struct {
+		name  string
+		since time.Time
+		until time.Time
+		token string
+		want  int
+	}{
+		{"all", time.Time{}, time.Time{}, "", 3},
+		{"by token", time.Time{}, time.Time{}, "ci", 2},
+		{"since", base.Add(30 * time.Minute), time.Time{}, "", 2},
+		{"until is exclusive", time.Time{}, base.Add(time.Hour), "", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FilterAPI(entries, tt.since, tt.until, tt.token); len(got) != tt.want {
+				t.Errorf("FilterAPI() = %d entries, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
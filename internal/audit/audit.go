@@ -0,0 +1,198 @@
+// Package audit records who triggered each agent call and with which configuration,
+// so agent activity can be reviewed later (e.g. for compliance).
+//
+// The log is an append-only JSON Lines file with one Entry per agent call.
+package audit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry is a single agent call in the audit log.
+type Entry struct {
+	Time time.Time `json:"time"`
+	// User is the OS user that ran the orchestrator.
+	User string `json:"user"`
+	// Identity is the optionally configured operator identity (e.g. an email).
+	Identity string `json:"identity,omitempty"`
+	Host     string `json:"host,omitempty"`
+	// CommandLine is the orchestrator command line that led to the call.
+	CommandLine string `json:"command_line"`
+	// ConfigHash identifies the configuration snapshot in effect.
+	ConfigHash   string        `json:"config_hash"`
+	AgentCommand string        `json:"agent_command"`
+	WorkingDir   string        `json:"working_dir,omitempty"`
+	PromptChars  int           `json:"prompt_chars"`
+	Duration     time.Duration `json:"duration_ns"`
+	Success      bool          `json:"success"`
+	ExitCode     int           `json:"exit_code"`
+	DryRun       bool          `json:"dry_run,omitempty"`
+	Error        string        `json:"error,omitempty"`
+	LogPath      string        `json:"log_path,omitempty"`
+	// PolicyVersion and PolicyHash identify the policy preamble sent with the prompt,
+	// proving the policy in effect for the call; empty when none was configured.
+	PolicyVersion string `json:"policy_version,omitempty"`
+	PolicyHash    string `json:"policy_hash,omitempty"`
+}
+
+// Operator returns the configured identity, or the OS user when none is set.
+func (e Entry) Operator() string {
+	if e.Identity != "" {
+		return e.Identity
+	}
+	return e.User
+}
+
+// appendMu serializes appends from concurrent workers in the same process.
+var appendMu sync.Mutex
+
+// Append writes an entry to the audit log at path, creating the file (0600) and its
+// directory (0700) if needed.
+func Append(path string, e Entry) error {
+	return appendLine(path, e)
+}
+
+// Load reads all entries from the audit log in append order.
+// A missing file yields an empty log; malformed lines are skipped.
+func Load(path string) ([]Entry, error) {
+	return loadLines[Entry](path)
+}
+
+// appendLine appends v as one JSON line to the log at path.
+func appendLine(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+
+	appendMu.Lock()
+	defer appendMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create audit directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// loadLines reads the JSON lines of the log at path, skipping malformed ones.
+func loadLines[T any](path string) ([]T, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	entries := make([]T, 0)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var e T
+		if err := json.Unmarshal(line, &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return entries, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// Filter returns the entries within [since, until) that match operator (compared
+// against both User and Identity). Zero times and an empty operator do not filter.
+func Filter(entries []Entry, since, until time.Time, operator string) []Entry {
+	out := make([]Entry, 0, len(entries))
+	for _, e := range entries {
+		if !since.IsZero() && e.Time.Before(since) {
+			continue
+		}
+		if !until.IsZero() && !e.Time.Before(until) {
+			continue
+		}
+		if operator != "" && e.User != operator && e.Identity != operator {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// CurrentUser returns the OS user name running the process, falling back to $USER
+// (or %USERNAME% on Windows) and finally "unknown".
+func CurrentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	for _, env := range []string{"USER", "USERNAME"} {
+		if name := os.Getenv(env); name != "" {
+			return name
+		}
+	}
+	return "unknown"
+}
+
+// Hash returns a SHA-256 hex digest of v's JSON encoding, used to fingerprint a
+// configuration snapshot without storing its contents.
+func Hash(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseTime parses a point in time relative to now: a duration ago ("24h", "30m",
+// or days such as "7d"), a date or date and time ("2006-01-02", "2006-01-02 15:04",
+// local time) or an RFC 3339 timestamp.
+// An empty string yields the zero time.
+func ParseTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if strings.HasSuffix(s, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(s, "d")); err == nil && days >= 0 {
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{"2006-01-02", "2006-01-02 15:04", "2006-01-02 15:04:05"} {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (use e.g. 24h, 7d, 2006-01-02, 2006-01-02 15:04 or RFC 3339)", s)
+}
@@ -0,0 +1,117 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppendAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "audit.jsonl")
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, e := range []Entry{
+		{Time: now, User: "alice", CommandLine: "agent-orchestrator work", ConfigHash: "abc", Success: true},
+		{Time: now.Add(time.Minute), User: "bob", Identity: "bob@example.com", ExitCode: 1},
+	} {
+		if err := Append(path, e); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm&0077 != 0 {
+		t.Errorf("audit log permissions = %o, want no group/other access", perm)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(got) != 2 || got[0].CommandLine != "agent-orchestrator work" || got[1].Operator() != "bob@example.com" {
+		t.Errorf("Load() = %+v", got)
+	}
+	if got[0].Operator() != "alice" {
+		t.Errorf("Operator() without identity = %q, want OS user", got[0].Operator())
+	}
+
+	if entries, err := Load(filepath.Join(t.TempDir(), "missing")); err != nil || len(entries) != 0 {
+		t.Errorf("Load() on missing file = %v, %v; want empty, nil", entries, err)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	entries := []Entry{
+		{Time: base, User: "alice"},
+		{Time: base.Add(24 * time.Hour), User: "bob", Identity: "bob@example.com"},
+		{Time: base.Add(48 * time.Hour), User: "alice"},
+	}
+
+	tests := []struct {
+		name     string
+		since    time.Time
+		until    time.Time
+		operator string
+		want     int
+	}{
+		{name: "no filter", want: 3},
+		{name: "since", since: base.Add(time.Hour), want: 2},
+		{name: "until exclusive", until: base.Add(24 * time.Hour), want: 1},
+		{name: "by user", operator: "alice", want: 2},
+		{name: "by identity", operator: "bob@example.com", want: 1},
+		{name: "combined", since: base.Add(time.Hour), operator: "alice", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Filter(entries, tt.since, tt.until, tt.operator); len(got) != tt.want {
+				t.Errorf("Filter() returned %d entries, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{in: "", want: time.Time{}},
+		{in: "24h", want: now.Add(-24 * time.Hour)},
+		{in: "7d", want: now.AddDate(0, 0, -7)},
+		{in: "2026-03-01", want: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{in: "2026-03-01 12:30", want: time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)},
+		{in: "2026-03-01T08:00:00Z", want: time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)},
+		{in: "yesterday", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTime(tt.in, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTime(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseTime(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	type snapshot struct{ A, B string }
+	a := Hash(snapshot{"x", "y"})
+	if len(a) != 64 {
+		t.Fatalf("Hash() = %q, want sha256 hex", a)
+	}
+	if a != Hash(snapshot{"x", "y"}) {
+		t.Error("Hash() should be deterministic")
+	}
+	if a == Hash(snapshot{"x", "z"}) {
+		t.Error("Hash() should change with the value")
+	}
+}
@@ -0,0 +1,248 @@
+// Package budget enforces an hourly token and cost budget on agent calls.
+//
+// Each limit is a token bucket whose capacity is the hourly limit and which refills
+// continuously at that rate. Agent calls are charged after they finish; new work is
+// dispatched only while every bucket is above zero. Bucket levels live in a small
+// JSON state file (TicketsDir/budget.json) so the foreground CLI and the detach
+// child share one budget; every read-modify-write holds an OS file lock beside the
+// state file, so parallel workers and processes never lose an update.
+package budget
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/filelock"
+)
+
+// Limits are the hourly budget. A zero limit is not enforced.
+type Limits struct {
+	TokensPerHour int
+	CostPerHour   float64
+}
+
+// Enabled reports whether any limit is set.
+func (l Limits) Enabled() bool {
+	return l.TokensPerHour > 0 || l.CostPerHour > 0
+}
+
+// bucket is the persisted level of one token bucket at a point in time.
+type bucket struct {
+	Level   float64   `json:"level"`
+	Updated time.Time `json:"updated"`
+}
+
+// level returns the bucket level at now, refilled at capacity per hour up to capacity.
+// A missing bucket starts full.
+func (b *bucket) level(capacity float64, now time.Time) float64 {
+	if b == nil {
+		return capacity
+	}
+	elapsed := now.Sub(b.Updated).Hours()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	l := b.Level + elapsed*capacity
+	if l > capacity {
+		l = capacity
+	}
+	return l
+}
+
+type state struct {
+	Tokens *bucket `json:"tokens,omitempty"`
+	Cost   *bucket `json:"cost,omitempty"`
+}
+
+// Manager charges and checks the budget stored at a state file.
+type Manager struct {
+	path   string
+	limits Limits
+
+	// now and pollInterval are replaced in tests.
+	now          func() time.Time
+	pollInterval time.Duration
+}
+
+// New returns a Manager for the state file at path.
+func New(path string, limits Limits) *Manager {
+	return &Manager{
+		path:         path,
+		limits:       limits,
+		now:          time.Now,
+		pollInterval: 30 * time.Second,
+	}
+}
+
+// Limits returns the configured limits.
+func (m *Manager) Limits() Limits {
+	return m.limits
+}
+
+// Spend charges a finished agent call against the budget. Levels may go below zero;
+// dispatch then pauses until they refill. No-op when no limit is set.
+func (m *Manager) Spend(tokens int, cost float64) error {
+	if !m.limits.Enabled() {
+		return nil
+	}
+	unlock, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	st, err := m.load()
+	if err != nil {
+		return err
+	}
+	now := m.now()
+	if m.limits.TokensPerHour > 0 {
+		capacity := float64(m.limits.TokensPerHour)
+		st.Tokens = &bucket{Level: st.Tokens.level(capacity, now) - float64(tokens), Updated: now}
+	}
+	if m.limits.CostPerHour > 0 {
+		st.Cost = &bucket{Level: st.Cost.level(m.limits.CostPerHour, now) - cost, Updated: now}
+	}
+	return m.save(st)
+}
+
+// Available returns the remaining tokens and cost in the budget now. Values for a limit
+// that is not set are zero.
+func (m *Manager) Available() (tokens, cost float64, err error) {
+	if !m.limits.Enabled() {
+		return 0, 0, nil
+	}
+	unlock, err := m.lock()
+	if err != nil {
+		return 0, 0, err
+	}
+	st, err := m.load()
+	unlock()
+	if err != nil {
+		return 0, 0, err
+	}
+	now := m.now()
+	if m.limits.TokensPerHour > 0 {
+		tokens = st.Tokens.level(float64(m.limits.TokensPerHour), now)
+	}
+	if m.limits.CostPerHour > 0 {
+		cost = st.Cost.level(m.limits.CostPerHour, now)
+	}
+	return tokens, cost, nil
+}
+
+// Delay returns how long until every bucket is above zero again; zero when work can
+// be dispatched now.
+func (m *Manager) Delay() (time.Duration, error) {
+	tokens, cost, err := m.Available()
+	if err != nil {
+		return 0, err
+	}
+	var wait time.Duration
+	if m.limits.TokensPerHour > 0 && tokens <= 0 {
+		wait = maxDuration(wait, refillTime(-tokens, float64(m.limits.TokensPerHour)))
+	}
+	if m.limits.CostPerHour > 0 && cost <= 0 {
+		wait = maxDuration(wait, refillTime(-cost, m.limits.CostPerHour))
+	}
+	return wait, nil
+}
+
+// refillTime is how long a bucket with the given hourly capacity needs to climb from
+// -deficit to just above zero.
+func refillTime(deficit, capacity float64) time.Duration {
+	d := time.Duration(deficit / capacity * float64(time.Hour))
+	return d + time.Second
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Wait blocks until the budget allows dispatching new work or ctx is done. onPause,
+// when non-nil, is called once with the expected wait when Wait has to pause. The
+// state file is re-read periodically, so spending by other processes is observed.
+func (m *Manager) Wait(ctx context.Context, onPause func(time.Duration)) error {
+	paused := false
+	for {
+		wait, err := m.Delay()
+		if err != nil {
+			return err
+		}
+		if wait <= 0 {
+			return nil
+		}
+		if !paused && onPause != nil {
+			onPause(wait)
+		}
+		paused = true
+		if wait > m.pollInterval {
+			wait = m.pollInterval
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// lock serializes read-modify-write of the state file across processes.
+func (m *Manager) lock() (unlock func(), err error) {
+	return filelock.Lock(m.path+".lock", 0)
+}
+
+func (m *Manager) load() (*state, error) {
+	data, err := os.ReadFile(m.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &state{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read budget state: %w", err)
+	}
+	var st state
+	if err := json.Unmarshal(data, &st); err != nil {
+		// A corrupt state file must not block work forever; start from a full budget.
+		return &state{}, nil
+	}
+	return &st, nil
+}
+
+// save writes the state atomically (temp file + rename) so a concurrent reader in
+// another process never sees a partial file.
+func (m *Manager) save(st *state) error {
+	data, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(m.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create budget directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".budget-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write budget state: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write budget state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write budget state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), m.path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write budget state: %w", err)
+	}
+	return nil
+}
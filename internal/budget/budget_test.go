@@ -0,0 +1,159 @@
+package budget
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestManager(t *testing.T, limits Limits, now *time.Time) *Manager {
+	t.Helper()
+	m := New(filepath.Join(t.TempDir(), "budget.json"), limits)
+	m.now = func() time.Time { return *now }
+	return m
+}
+
+func TestManager_SpendAndRefill(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := newTestManager(t, Limits{TokensPerHour: 1000, CostPerHour: 2}, &now)
+
+	if wait, err := m.Delay(); err != nil || wait != 0 {
+		t.Fatalf("fresh budget Delay() = %v, %v; want 0", wait, err)
+	}
+	if err := m.Spend(1500, 1); err != nil {
+		t.Fatal(err)
+	}
+	tokens, cost, _ := m.Available()
+	if tokens != -500 || cost != 1 {
+		t.Errorf("Available() = %v, %v; want -500, 1", tokens, cost)
+	}
+
+	// 500 tokens over budget at 1000/hour: about 30 minutes until dispatch resumes.
+	wait, err := m.Delay()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wait < 30*time.Minute || wait > 31*time.Minute {
+		t.Errorf("Delay() = %v, want ~30m", wait)
+	}
+
+	now = now.Add(31 * time.Minute)
+	if wait, _ := m.Delay(); wait != 0 {
+		t.Errorf("Delay() after refill = %v, want 0", wait)
+	}
+
+	// Refill never exceeds the hourly capacity.
+	now = now.Add(10 * time.Hour)
+	if tokens, _, _ := m.Available(); tokens != 1000 {
+		t.Errorf("Available() after long idle = %v, want capacity 1000", tokens)
+	}
+}
+
+func TestManager_CostLimitOnly(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := newTestManager(t, Limits{CostPerHour: 1}, &now)
+
+	if err := m.Spend(1_000_000, 1.5); err != nil {
+		t.Fatal(err)
+	}
+	wait, _ := m.Delay()
+	if wait < 30*time.Minute || wait > 31*time.Minute {
+		t.Errorf("Delay() = %v, want ~30m (tokens are not limited)", wait)
+	}
+}
+
+func TestManager_Disabled(t *testing.T) {
+	now := time.Now()
+	m := newTestManager(t, Limits{}, &now)
+	if err := m.Spend(1_000_000, 100); err != nil {
+		t.Fatal(err)
+	}
+	if wait, _ := m.Delay(); wait != 0 {
+		t.Errorf("disabled budget Delay() = %v, want 0", wait)
+	}
+	if _, err := os.Stat(m.path); !os.IsNotExist(err) {
+		t.Error("disabled budget should not write a state file")
+	}
+}
+
+func TestManager_SharedStateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "budget.json")
+	limits := Limits{TokensPerHour: 100_000}
+	// A fixed clock: waiting for the file lock must not refill the bucket.
+	now := time.Now()
+	manager := func() *Manager {
+		m := New(path, limits)
+		m.now = func() time.Time { return now }
+		return m
+	}
+
+	// Parallel workers in one process, each with its own Manager on the same file.
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := manager().Spend(1000, 0); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// A separate Manager (as in the detach child) sees the combined spend.
+	tokens, _, err := manager().Available()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tokens > 80_001 || tokens < 79_999 {
+		t.Errorf("Available() = %v, want ~80000 after 20 x 1000", tokens)
+	}
+}
+
+func TestManager_Wait(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	var mu sync.Mutex
+	m := New(filepath.Join(t.TempDir(), "budget.json"), Limits{TokensPerHour: 3600})
+	m.now = func() time.Time { mu.Lock(); defer mu.Unlock(); return now }
+	m.pollInterval = 5 * time.Millisecond
+
+	if err := m.Spend(7200, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// Cancelled while paused.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	var paused time.Duration
+	if err := m.Wait(ctx, func(d time.Duration) { paused = d }); err == nil {
+		t.Fatal("Wait() should return the context error when cancelled")
+	}
+	if paused < time.Hour {
+		t.Errorf("onPause got %v, want about an hour", paused)
+	}
+
+	// Resumes once the window rolls.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		now = now.Add(2 * time.Hour)
+		mu.Unlock()
+	}()
+	if err := m.Wait(context.Background(), nil); err != nil {
+		t.Errorf("Wait() error = %v", err)
+	}
+}
+
+func TestManager_CorruptStateStartsFull(t *testing.T) {
+	now := time.Now()
+	m := newTestManager(t, Limits{TokensPerHour: 10}, &now)
+	if err := os.WriteFile(m.path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if tokens, _, err := m.Available(); err != nil || tokens != 10 {
+		t.Errorf("Available() with corrupt state = %v, %v; want 10", tokens, err)
+	}
+}
@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/acceptance"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// runTicketAssertions runs t's executable acceptance assertions in dir after coding and
+// records the results on t. Returns an error naming the failed assertions; nil when all
+// pass or the ticket has none. Skipped in dry-run mode, where no code was changed.
+func runTicketAssertions(ctx context.Context, t *ticket.Ticket, dir string) error {
+	if len(t.Assertions) == 0 || cfg.DryRun {
+		return nil
+	}
+	t.AssertionResults = acceptance.Run(ctx, dir, t.Assertions)
+	failed := ticket.FailedAssertions(t.AssertionResults)
+	if len(failed) == 0 {
+		return nil
+	}
+	parts := make([]string, len(failed))
+	for i, r := range failed {
+		parts[i] = fmt.Sprintf("%s: %s", r.Command, r.Error)
+	}
+	return fmt.Errorf(i18n.ErrAssertionsFailed, len(failed), len(t.AssertionResults), strings.Join(parts, "; "))
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestRunTicketAssertions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("assertions use POSIX shell commands")
+	}
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+
+	tests := []struct {
+		name        string
+		dryRun      bool
+		assertions  []ticket.Assertion
+		wantErr     string
+		wantResults int
+	}{
+		{"no assertions", false, nil, "", 0},
+		{"all pass", false, []ticket.Assertion{{Command: "echo ok", OutputPattern: "^ok"}, {Command: "exit 3", ExitCode: 3}}, "", 2},
+		{"one fails", false, []ticket.Assertion{{Command: "true"}, {Command: "false"}}, "(1/2): false: exit code 1, want 0", 2},
+		{"dry run skips", true, []ticket.Assertion{{Command: "false"}}, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg = &config.Config{ProjectRoot: t.TempDir(), DryRun: tt.dryRun}
+			tk := ticket.NewTicket("T-1", "Test", "")
+			tk.Assertions = tt.assertions
+
+			err := runTicketAssertions(context.Background(), tk, cfg.ProjectRoot)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("runTicketAssertions() err = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("runTicketAssertions() err = %v, want containing %q", err, tt.wantErr)
+			}
+			if len(tk.AssertionResults) != tt.wantResults {
+				t.Errorf("AssertionResults = %+v, want %d results", tk.AssertionResults, tt.wantResults)
+			}
+		})
+	}
+}
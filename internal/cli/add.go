@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -20,8 +21,14 @@ var (
 	addPriority    int
 	addDescription string
 	addDeps        string
+	addSoftDeps    string
 	addCriteria    string
 	addEnhance     bool
+	addRecur       string
+	addLabels      []string
+	addParent      string
+	addAssertions  []string
+	addFromFile    string
 )
 
 var addCmd = &cobra.Command{
@@ -37,21 +44,34 @@ func init() {
 	addCmd.Flags().IntVar(&addPriority, "priority", 3, i18n.FlagPriority)
 	addCmd.Flags().StringVar(&addDescription, "description", "", i18n.FlagDescription)
 	addCmd.Flags().StringVar(&addDeps, "deps", "", i18n.FlagDeps)
+	addCmd.Flags().StringVar(&addSoftDeps, "soft-deps", "", i18n.FlagSoftDeps)
 	addCmd.Flags().StringVar(&addCriteria, "criteria", "", i18n.FlagCriteria)
 	addCmd.Flags().BoolVar(&addEnhance, "enhance", false, i18n.FlagEnhance)
+	addCmd.Flags().StringVar(&addRecur, "recur", "", i18n.FlagRecur)
+	addCmd.Flags().StringSliceVar(&addLabels, "label", nil, i18n.FlagLabel)
+	addCmd.Flags().StringVar(&addParent, "parent", "", i18n.FlagParent)
+	addCmd.Flags().StringArrayVar(&addAssertions, "assert", nil, i18n.FlagAssert)
+	addCmd.Flags().StringVar(&addFromFile, "from-file", "", i18n.FlagAddFromFile)
 }
 
 func runAdd(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 	w := os.Stdout
 
-	ui.PrintHeader(w, i18n.UIAddTicket)
+	if addFromFile != "" && (addTitle != "" || addEnhance || addRecur != "") {
+		return errors.New(i18n.ErrAddFileWithFlags)
+	}
 
 	// Initialize store
-	store := ticket.NewStore(cfg.TicketsDir)
+	store := newTicketStore()
 	if err := store.Init(); err != nil {
 		return fmt.Errorf(i18n.ErrInitStoreFailed, err)
 	}
+	if addFromFile != "" {
+		return addTicketsFromFile(w, store, addFromFile)
+	}
+
+	ui.PrintHeader(w, i18n.UIAddTicket)
 
 	var t *ticket.Ticket
 	var err error
@@ -69,17 +89,37 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	ticketSpec{Labels: addLabels, ParentID: addParent, Assertions: addAssertions}.applyOptions(t)
+	if t.ParentID != "" {
+		if _, err := store.Load(t.ParentID); err != nil {
+			ui.PrintError(w, fmt.Sprintf(i18n.ErrTicketNotFound, t.ParentID))
+			return nil
+		}
+	}
+
 	// AI enhancement if requested
 	if addEnhance {
 		t, err = enhanceTicket(ctx, w, t)
 		if err != nil {
-			ui.PrintWarning(w, fmt.Sprintf("AI 預處理失敗: %s，使用原始內容", err.Error()))
+			ui.PrintWarning(w, fmt.Sprintf(i18n.MsgEnhanceFailedUsed, err.Error()))
 		}
 	}
 
 	// Validate
 	if err := t.Validate(); err != nil {
-		ui.PrintError(w, fmt.Sprintf("Ticket 驗證失敗: %s", err.Error()))
+		ui.PrintError(w, fmt.Sprintf(i18n.ErrTicketInvalid, err.Error()))
+		return nil
+	}
+
+	// Recurring template: goes to the recurring registry, not the store
+	if addRecur != "" {
+		if err := addRecurringTemplate(t, addRecur); err != nil {
+			ui.PrintError(w, err.Error())
+			return nil
+		}
+		ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgRecurringAdded, t.ID, t.RecurrenceRule))
+		ui.PrintInfo(w, "")
+		displayTicketDetails(w, t)
 		return nil
 	}
 
@@ -106,7 +146,7 @@ func collectTicketInteractive(w *os.File) (*ticket.Ticket, error) {
 		return nil, err
 	}
 	if title == "" {
-		ui.PrintError(w, "標題不能為空")
+		ui.PrintError(w, i18n.ErrTitleEmpty)
 		return nil, fmt.Errorf("empty title")
 	}
 
@@ -119,13 +159,13 @@ func collectTicketInteractive(w *os.File) (*ticket.Ticket, error) {
 
 	// Type
 	typeOptions := []string{
-		"feature - 新功能",
-		"bugfix - 錯誤修復",
-		"refactor - 重構",
-		"test - 測試",
-		"docs - 文件",
-		"performance - 效能優化",
-		"security - 安全性",
+		i18n.TicketTypeFeature,
+		i18n.TicketTypeBugfix,
+		i18n.TicketTypeRefactor,
+		i18n.TicketTypeTest,
+		i18n.TicketTypeDocs,
+		i18n.TicketTypePerformance,
+		i18n.TicketTypeSecurity,
 	}
 	typeIdx, err := prompt.Select(i18n.PromptTicketType, typeOptions)
 	if err != nil {
@@ -189,12 +229,39 @@ func collectTicketInteractive(w *os.File) (*ticket.Ticket, error) {
 }
 
 func createTicketFromFlags() (*ticket.Ticket, error) {
-	id := generateTicketID()
+	return newTicketFromSpec(ticketSpec{
+		Title:              addTitle,
+		Description:        addDescription,
+		Type:               addType,
+		Priority:           addPriority,
+		Dependencies:       splitList(addDeps),
+		SoftDependencies:   splitList(addSoftDeps),
+		AcceptanceCriteria: splitList(addCriteria),
+	}), nil
+}
+
+// ticketSpec describes a new ticket. add builds it from its flags and serve from the
+// body of POST /api/tickets, so tickets are created the same way by both.
+type ticketSpec struct {
+	Title              string
+	Description        string
+	Type               string
+	Priority           int
+	Dependencies       []string
+	SoftDependencies   []string
+	AcceptanceCriteria []string
+	Labels             []string
+	ParentID           string
+	Assertions         []string // commands, see ticket.Assertion
+}
 
-	t := ticket.NewTicket(id, addTitle, addDescription)
+// newTicketFromSpec builds a pending ticket with a generated ID. An unknown type falls
+// back to feature and a priority outside 1-5 to the default.
+func newTicketFromSpec(spec ticketSpec) *ticket.Ticket {
+	t := ticket.NewTicket(generateTicketID(), spec.Title, spec.Description)
 
 	// Parse type
-	switch strings.ToLower(addType) {
+	switch strings.ToLower(spec.Type) {
 	case "feature":
 		t.Type = ticket.TypeFeature
 	case "bugfix":
@@ -209,36 +276,47 @@ func createTicketFromFlags() (*ticket.Ticket, error) {
 		t.Type = ticket.TypePerf
 	case "security":
 		t.Type = ticket.TypeSecurity
+	case "epic":
+		t.Type = ticket.TypeEpic
 	default:
 		t.Type = ticket.TypeFeature
 	}
 
 	// Priority
-	if addPriority >= 1 && addPriority <= 5 {
-		t.Priority = addPriority
+	if spec.Priority >= 1 && spec.Priority <= 5 {
+		t.Priority = spec.Priority
 	}
 
-	// Dependencies
-	if addDeps != "" {
-		for _, d := range strings.Split(addDeps, ",") {
-			d = strings.TrimSpace(d)
-			if d != "" {
-				t.Dependencies = append(t.Dependencies, d)
-			}
-		}
+	t.Dependencies = spec.Dependencies
+	t.SoftDependencies = spec.SoftDependencies
+	t.AcceptanceCriteria = spec.AcceptanceCriteria
+	spec.applyOptions(t)
+	return t
+}
+
+// applyOptions sets the labels, parent and assertions of spec on t. add applies them to
+// interactively collected tickets too.
+func (spec ticketSpec) applyOptions(t *ticket.Ticket) {
+	if len(spec.Labels) > 0 {
+		t.Labels = ticket.NormalizeLabels(append(t.Labels, spec.Labels...))
+	}
+	if spec.ParentID != "" {
+		t.ParentID = spec.ParentID
 	}
+	for _, command := range spec.Assertions {
+		t.Assertions = append(t.Assertions, ticket.Assertion{Command: command})
+	}
+}
 
-	// Acceptance Criteria
-	if addCriteria != "" {
-		for _, c := range strings.Split(addCriteria, ",") {
-			c = strings.TrimSpace(c)
-			if c != "" {
-				t.AcceptanceCriteria = append(t.AcceptanceCriteria, c)
-			}
+// splitList splits a comma-separated flag value (ticket IDs, criteria), dropping empty entries.
+func splitList(list string) []string {
+	var ids []string
+	for _, id := range strings.Split(list, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
 		}
 	}
-
-	return t, nil
+	return ids
 }
 
 func generateTicketID() string {
@@ -258,7 +336,7 @@ func enhanceTicket(ctx context.Context, w *os.File, t *ticket.Ticket) (*ticket.T
 
 	enhanced, err := enhancer.Enhance(ctx, t)
 	if err != nil {
-		spinner.Fail("AI 預處理失敗")
+		spinner.Fail(i18n.SpinnerFailEnhance)
 		return t, err
 	}
 
@@ -267,32 +345,99 @@ func enhanceTicket(ctx context.Context, w *os.File, t *ticket.Ticket) (*ticket.T
 }
 
 func displayTicketDetails(w *os.File, t *ticket.Ticket) {
-	ui.PrintInfo(w, fmt.Sprintf("ID: %s", t.ID))
-	ui.PrintInfo(w, fmt.Sprintf("標題: %s", t.Title))
-	ui.PrintInfo(w, fmt.Sprintf("類型: %s", t.Type))
-	ui.PrintInfo(w, fmt.Sprintf("優先級: P%d", t.Priority))
-	ui.PrintInfo(w, fmt.Sprintf("狀態: %s", t.Status))
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTicketInfo, t.ID))
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTicketTitle, t.Title))
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTicketType, t.Type))
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTicketPriority, t.Priority))
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTicketStatus, t.Status))
 
 	if t.Description != "" {
-		ui.PrintInfo(w, fmt.Sprintf("描述: %s", t.Description))
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTicketDescription, t.Description))
 	}
 
 	if len(t.Dependencies) > 0 {
-		ui.PrintInfo(w, fmt.Sprintf("依賴: %s", strings.Join(t.Dependencies, ", ")))
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTicketDeps, strings.Join(t.Dependencies, ", ")))
+	}
+
+	if len(t.SoftDependencies) > 0 {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTicketSoftDeps, strings.Join(t.SoftDependencies, ", ")))
+	}
+
+	if len(t.Labels) > 0 {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgLabels, strings.Join(t.Labels, ", ")))
+	}
+
+	if t.ParentID != "" {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgParent, t.ParentID))
 	}
 
 	if len(t.AcceptanceCriteria) > 0 {
-		ui.PrintInfo(w, "驗收條件:")
+		ui.PrintInfo(w, i18n.MsgTicketCriteria)
 		for _, c := range t.AcceptanceCriteria {
 			fmt.Fprintf(w, "  - %s\n", c)
 		}
 	}
 
+	if len(t.Assertions) > 0 {
+		ui.PrintInfo(w, i18n.UIAssertions)
+		for _, a := range t.Assertions {
+			fmt.Fprintf(w, "  - %s\n", a)
+		}
+	}
+
+	if len(t.Notes) > 0 {
+		ui.PrintInfo(w, i18n.MsgNotes)
+		for _, n := range t.Notes {
+			ui.PrintInfo(w, "  "+noteSummary(n))
+		}
+	}
+
+	for _, r := range t.AssertionResults {
+		if r.Passed {
+			ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgAssertionPassed, r.Command))
+		} else {
+			ui.PrintError(w, fmt.Sprintf(i18n.MsgAssertionFailed, r.Command, r.Error))
+		}
+	}
+
+	if v := t.Verification; v != nil {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.UIVerification, v.VerifiedAt.Format("2006-01-02 15:04")))
+		printCriterionVerdicts(w, v)
+	}
+
 	if len(t.FilesToModify) > 0 {
-		ui.PrintInfo(w, fmt.Sprintf("要修改的檔案: %s", strings.Join(t.FilesToModify, ", ")))
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTicketFilesModify, strings.Join(t.FilesToModify, ", ")))
 	}
 
 	if len(t.FilesToCreate) > 0 {
-		ui.PrintInfo(w, fmt.Sprintf("要建立的檔案: %s", strings.Join(t.FilesToCreate, ", ")))
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTicketFilesCreate, strings.Join(t.FilesToCreate, ", ")))
+	}
+
+	if pc := t.PromptCompression; pc != nil {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgPromptCompressed, pc.SectionList(), pc.OriginalChars, pc.CompressedChars, pc.Budget))
+	}
+
+	if t.RecurringFrom != "" {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgRecurringFrom, t.RecurringFrom))
+	}
+
+	if t.Milestone != "" {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTraceMilestone, t.Milestone))
+	}
+
+	if len(t.Commits) > 0 {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTraceCommits, strings.Join(shortSHAs(t.Commits), ", ")))
+	}
+
+	if t.Branch != "" {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTicketBranch, t.Branch))
+	}
+
+	if t.PullRequest != nil {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTracePR, t.PullRequest))
+	}
+
+	if t.PartialOutput != "" {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgPartialOutputSaved, len([]rune(t.PartialOutput))))
 	}
 }
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+)
+
+// addTicketsFromFile adds the tickets of a JSON or CSV file (see ticket.ReadBulkFile)
+// to store. Every row and the dependencies between the new and stored tickets are
+// checked first; if any row has an error, the errors are listed and nothing is saved.
+// --label, --parent and --assert apply to every ticket; rows without an ID get a
+// generated one.
+func addTicketsFromFile(w io.Writer, store ticket.Storer, path string) error {
+	rows, err := ticket.ReadBulkFile(path)
+	if err != nil {
+		return fmt.Errorf(i18n.ErrAddFileRead, path, err)
+	}
+	ui.PrintHeader(w, fmt.Sprintf(i18n.UIAddFromFile, path))
+	if len(rows) == 0 {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgAddFileEmpty, path))
+		return nil
+	}
+
+	base := time.Now().UnixMilli()
+	for i, row := range rows {
+		if row.Ticket.ID == "" {
+			row.Ticket.ID = fmt.Sprintf("TICKET-%d", base+int64(i))
+		}
+		ticketSpec{Labels: addLabels, ParentID: addParent, Assertions: addAssertions}.applyOptions(row.Ticket)
+	}
+	if err := ticket.NewDependencyResolver(store).ValidateBulk(rows); err != nil {
+		return err
+	}
+
+	invalid := 0
+	for _, row := range rows {
+		if len(row.Errors) > 0 {
+			invalid++
+		}
+		for _, msg := range row.Errors {
+			ui.PrintError(w, fmt.Sprintf(i18n.MsgAddFileRowError, row.Row, row.Ticket.ID, msg))
+		}
+	}
+	if invalid > 0 {
+		return errors.New(fmt.Sprintf(i18n.ErrAddFileInvalid, invalid, len(rows)))
+	}
+
+	tickets := make([]*ticket.Ticket, len(rows))
+	for i, row := range rows {
+		tickets[i] = row.Ticket
+	}
+	table := ui.NewTable("Priority", "ID", "Title", "Type", "Dependencies")
+	for _, t := range tickets {
+		priority := ui.PriorityStyle(t.Priority).Render(fmt.Sprintf("P%d", t.Priority))
+		table.AddRow(priority, t.ID, ui.Truncate(t.Title, 40), string(t.Type), fmt.Sprint(len(t.Dependencies)))
+	}
+	table.Render(w)
+	ui.PrintInfo(w, "")
+	if cfg.DryRun {
+		ui.PrintWarning(w, fmt.Sprintf(i18n.MsgAddFileDryRun, len(tickets)))
+		return nil
+	}
+
+	for _, t := range tickets {
+		if err := store.Save(t); err != nil {
+			return fmt.Errorf("%s: %w", fmt.Sprintf(i18n.ErrSaveTicketFailed, t.ID), err)
+		}
+	}
+	ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgAddFileAdded, len(tickets)))
+	ui.PrintInfo(w, i18n.HintRunWork)
+	return nil
+}
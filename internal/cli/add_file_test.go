@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestRunAdd_FromFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+	if err := store.Save(ticket.NewTicket("T-0", "stored", "")); err != nil {
+		t.Fatal(err)
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: ticketsDir}
+	defer resetAddFlags()
+
+	write := func(name, content string) string {
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	// A file with errors adds nothing and reports every bad row.
+	resetAddFlags()
+	addFromFile = write("bad.json", `{"tickets": [
+		{"id": "T-1", "title": "ok", "dependencies": ["T-0"]},
+		{"id": "T-2", "title": "cycle", "dependencies": ["T-3"]},
+		{"id": "T-3", "title": "cycle", "dependencies": ["T-2"]},
+		{"id": "T-4", "title": "", "dependencies": ["T-9"]}
+	]}`)
+	var err error
+	output := captureOutput(func() { err = runAdd(nil, nil) })
+	if err == nil || err.Error() != fmt.Sprintf(i18n.ErrAddFileInvalid, 3, 4) {
+		t.Errorf("runAdd(--from-file bad.json) error = %v, want 3/4 invalid", err)
+	}
+	for _, want := range []string{"T-2 → T-3 → T-2", "unknown dependency: T-9", "title is required"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("report should contain %q, got:\n%s", want, output)
+		}
+	}
+	if n, _ := store.Count(); n[ticket.StatusPending] != 1 {
+		t.Errorf("pending tickets = %d, want only the stored one", n[ticket.StatusPending])
+	}
+
+	// Dry run validates without saving.
+	addFromFile = write("backlog.csv", "id,title,type,priority,dependencies,acceptance_criteria\nT-1,Add cache,feature,2,T-0,hits are served;misses are counted\nT-2,Document cache,docs,,T-1,\n")
+	cfg.DryRun = true
+	output = captureOutput(func() { err = runAdd(nil, nil) })
+	if err != nil || !strings.Contains(output, fmt.Sprintf(i18n.MsgAddFileDryRun, 2)) {
+		t.Errorf("dry run = %v, output:\n%s", err, output)
+	}
+	if _, err := store.Load("T-1"); err == nil {
+		t.Error("dry run should not save T-1")
+	}
+
+	cfg.DryRun = false
+	addLabels = []string{"cache"}
+	output = captureOutput(func() { err = runAdd(nil, nil) })
+	if err != nil || !strings.Contains(output, fmt.Sprintf(i18n.MsgAddFileAdded, 2)) {
+		t.Errorf("runAdd(--from-file backlog.csv) = %v, output:\n%s", err, output)
+	}
+	got, err := store.Load("T-1")
+	if err != nil || got.Priority != 2 || len(got.AcceptanceCriteria) != 2 || !got.HasLabels([]string{"cache"}) {
+		t.Errorf("T-1 = %+v, %v", got, err)
+	}
+	if got, err := store.Load("T-2"); err != nil || got.Type != ticket.TypeDocs || got.Dependencies[0] != "T-1" {
+		t.Errorf("T-2 = %+v, %v", got, err)
+	}
+
+	addTitle = "both"
+	if err := runAdd(nil, nil); err == nil {
+		t.Error("runAdd() with --from-file and --title should fail")
+	}
+}
@@ -20,6 +20,11 @@ func resetAddFlags() {
 	addDeps = ""
 	addCriteria = ""
 	addEnhance = false
+	addRecur = ""
+	addLabels = nil
+	addParent = ""
+	addAssertions = nil
+	addFromFile = ""
 }
 
 func TestCreateTicketFromFlags_Feature(t *testing.T) {
@@ -3,20 +3,30 @@ package cli
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/anthropic/agent-orchestrator/internal/agent"
 	orcherrors "github.com/anthropic/agent-orchestrator/internal/errors"
 	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/metrics"
 	"github.com/anthropic/agent-orchestrator/internal/ticket"
 	"github.com/anthropic/agent-orchestrator/internal/ui"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var (
-	analyzeScope   []string
-	analyzeAutoGen bool
+	analyzeScope       []string
+	analyzeAutoGen     bool
+	analyzePreviewFull bool
+	analyzeChanged     bool
+	analyzeFailOn      string
+
+	analyzeAutoExpand  bool
+	analyzeExpandDepth int
 )
 
 var analyzeCmd = &cobra.Command{
@@ -29,16 +39,38 @@ var analyzeCmd = &cobra.Command{
 func init() {
 	analyzeCmd.Flags().StringSliceVar(&analyzeScope, "scope", []string{"all"}, i18n.FlagScope)
 	analyzeCmd.Flags().BoolVar(&analyzeAutoGen, "auto", false, i18n.FlagAuto)
+	analyzeCmd.Flags().BoolVar(&analyzePreviewFull, "preview-full", false, i18n.FlagPreviewFull)
+	analyzeCmd.Flags().BoolVar(&analyzeChanged, "changed", false, i18n.FlagAnalyzeChanged)
+	analyzeCmd.Flags().StringVar(&analyzeFailOn, "fail-on", "", i18n.FlagAnalyzeFailOn)
+	analyzeCmd.Flags().BoolVar(&analyzeAutoExpand, "auto-expand", false, i18n.FlagAnalyzeAutoExpand)
+	analyzeCmd.Flags().IntVar(&analyzeExpandDepth, "expand-depth", 1, i18n.FlagAnalyzeExpandDepth)
 }
 
 func runAnalyze(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 	w := os.Stdout
 
+	if analyzeFailOn != "" && ticket.SeverityRank(analyzeFailOn) == 0 {
+		return fmt.Errorf(i18n.ErrInvalidSeverity, analyzeFailOn)
+	}
+	if analyzeExpandDepth < 0 {
+		return fmt.Errorf(i18n.ErrAnalyzeExpandDepth, analyzeExpandDepth)
+	}
+
 	ui.PrintHeader(w, i18n.UIProjectAnalyze)
 	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgAnalyzeProject, cfg.ProjectRoot))
 	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgAnalyzeScope, strings.Join(analyzeScope, ", ")))
 
+	var changedFiles []string
+	if analyzeChanged {
+		changedFiles = getGitChangedFilesSinceUpstream(ctx)
+		if len(changedFiles) == 0 {
+			ui.PrintSuccess(w, i18n.MsgAnalyzeNoChangedFiles)
+			return nil
+		}
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgAnalyzeChangedFiles, len(changedFiles)))
+	}
+
 	// Create agent caller
 	caller, err := CreateAgentCaller()
 	if err != nil {
@@ -46,6 +78,7 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	}
 
 	analyzeAgent := agent.NewAnalyzeAgent(caller, cfg.ProjectRoot)
+	analyzeAgent.SetFiles(changedFiles)
 	scope := agent.ParseScopes(analyzeScope)
 
 	// Run analysis
@@ -61,12 +94,86 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 
 	if issues.Count() == 0 {
 		ui.PrintSuccess(w, i18n.MsgNoIssuesFound)
+		recordQuality(w, issues)
 		return nil
 	}
 
 	// Display issues by category
 	ui.PrintHeader(w, i18n.UIAnalysisReport)
+	printIssueReport(w, issues.Issues)
+
+	// Suggest (and optionally run) deeper passes where findings concentrate
+	if err := expandAnalysis(ctx, w, caller, issues); err != nil {
+		return err
+	}
+
+	ui.PrintInfo(w, "")
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgFoundIssues, issues.Count()))
+	recordQuality(w, issues)
+
+	// Ask to generate tickets, showing what would be created
+	tickets := issues.ToTickets().Tickets
+	if analyzePreviewFull {
+		if err := showTicketsJSON(w, tickets); err != nil {
+			return err
+		}
+	}
+	generateTickets := analyzeAutoGen
+	if !generateTickets && !cfg.Quiet {
+		if err := previewTickets(w, os.Stdin, tickets, term.IsTerminal(int(os.Stdin.Fd()))); err != nil {
+			return err
+		}
+		prompt := ui.NewPrompt(os.Stdin, w)
+		var err error
+		generateTickets, err = prompt.Confirm(i18n.PromptGenerateTickets, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	if generateTickets {
+		if err := saveGeneratedTickets(tickets); err != nil {
+			return err
+		}
+	}
+
+	return checkFailOn(cmd, issues)
+}
 
+// recordQuality appends the severity-weighted score of a full analysis to the quality
+// history and prints it with the change since the previous run of the same scope.
+// Analyses of changed files only and dry runs are not comparable and are skipped;
+// recording is best-effort.
+func recordQuality(w io.Writer, issues *ticket.IssueList) {
+	if analyzeChanged || cfg.DryRun {
+		return
+	}
+	path := cfg.QualityHistoryPath()
+	history, _ := metrics.LoadQuality(path)
+	r := metrics.NewQualityRecord(time.Now(), analyzeScope, issues.Issues)
+	if err := metrics.AppendQuality(path, r); err != nil {
+		ui.PrintWarning(w, err.Error())
+		return
+	}
+
+	msg := fmt.Sprintf(i18n.MsgQualityScore, r.Score, r.High, r.Med, r.Low)
+	if prev := metrics.QualityTrend(append(history, r), 2); len(prev) == 2 {
+		msg += fmt.Sprintf(i18n.MsgQualityScoreDelta, prev[0].Score, formatScoreDelta(r.Score-prev[0].Score))
+	}
+	ui.PrintInfo(w, msg)
+}
+
+// formatScoreDelta renders a score change with its sign, e.g. "+5", "-3" or "±0".
+func formatScoreDelta(d int) string {
+	if d == 0 {
+		return "±0"
+	}
+	return fmt.Sprintf("%+d", d)
+}
+
+// printIssueReport renders issues as one table per category.
+func printIssueReport(w io.Writer, issues []*ticket.Issue) {
+	list := &ticket.IssueList{Issues: issues}
 	categories := []struct {
 		name     string
 		category string
@@ -79,7 +186,7 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	}
 
 	for _, cat := range categories {
-		filtered := issues.FilterByCategory(cat.category)
+		filtered := list.FilterByCategory(cat.category)
 		if len(filtered) > 0 {
 			table := ui.NewIssueTable(cat.name)
 			for _, issue := range filtered {
@@ -88,42 +195,100 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 			table.Render(w)
 		}
 	}
+}
 
-	ui.PrintInfo(w, "")
-	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgFoundIssues, issues.Count()))
+// expandAnalysis suggests follow-up passes based on the findings (see
+// agent.SuggestExpansions) and runs the ones accepted: all of them with --auto-expand,
+// otherwise those confirmed interactively. New issues are merged into issues and may
+// trigger further suggestions, up to --expand-depth rounds. In quiet mode without
+// --auto-expand the suggestions are only listed.
+func expandAnalysis(ctx context.Context, w io.Writer, caller *agent.Caller, issues *ticket.IssueList) error {
+	done := make(map[string]bool)
+	frontier := issues
+	for depth := 1; depth <= analyzeExpandDepth; depth++ {
+		suggestions := agent.SuggestExpansions(frontier, done)
+		if len(suggestions) == 0 {
+			return nil
+		}
 
-	// Ask to generate tickets
-	generateTickets := analyzeAutoGen
-	if !generateTickets && !cfg.Quiet {
-		prompt := ui.NewPrompt(os.Stdin, w)
-		var err error
-		generateTickets, err = prompt.Confirm(i18n.PromptGenerateTickets, true)
-		if err != nil {
-			return err
+		ui.PrintSubheader(w, i18n.UIAnalyzeExpand)
+		for _, s := range suggestions {
+			ui.PrintInfo(w, formatScopeSuggestion(s))
+		}
+		if !analyzeAutoExpand && cfg.Quiet {
+			ui.PrintInfo(w, i18n.HintAnalyzeAutoExpand)
+			return nil
+		}
+
+		next := ticket.NewIssueList()
+		for _, s := range suggestions {
+			done[s.Key()] = true
+			if !analyzeAutoExpand {
+				prompt := ui.NewPrompt(os.Stdin, w)
+				ok, err := prompt.Confirm(fmt.Sprintf(i18n.PromptAnalyzeExpand, formatScopeSuggestion(s)), false)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					continue
+				}
+			}
+
+			aa := agent.NewAnalyzeAgent(caller, cfg.ProjectRoot)
+			aa.SetFollowUp(s.Dirs, issues.FilterByCategory(s.Category))
+			spinner := ui.NewSpinner(fmt.Sprintf(i18n.SpinnerAnalyzeExpand, s.Category), w)
+			spinner.Start()
+			found, err := aa.Analyze(ctx, s.Scope())
+			if err != nil {
+				// A failed follow-up pass does not invalidate the main analysis.
+				spinner.Fail(i18n.SpinnerFailAnalysis)
+				ui.PrintWarning(w, err.Error())
+				continue
+			}
+			added := issues.Merge(found, fmt.Sprintf("X%d", depth))
+			spinner.Success(fmt.Sprintf(i18n.MsgAnalyzeExpandFound, len(added)))
+			printIssueReport(w, added)
+			next.Issues = append(next.Issues, added...)
 		}
+		frontier = next
 	}
+	return nil
+}
 
-	if generateTickets {
-		return generateTicketsFromIssues(issues)
+// formatScopeSuggestion describes a suggested pass, e.g. "security (5 個問題，2 個 HIGH): internal/auth".
+func formatScopeSuggestion(s agent.ScopeSuggestion) string {
+	where := i18n.MsgAnalyzeExpandWholeProject
+	if len(s.Dirs) > 0 {
+		where = strings.Join(s.Dirs, ", ")
 	}
+	return fmt.Sprintf(i18n.MsgAnalyzeExpandSuggestion, s.Category, s.Issues, s.High, where)
+}
 
+// checkFailOn returns an error when --fail-on is set and the analysis found issues at or
+// above that severity, so that scripts and git hooks can gate on the exit code.
+func checkFailOn(cmd *cobra.Command, issues *ticket.IssueList) error {
+	if analyzeFailOn == "" {
+		return nil
+	}
+	if n := len(issues.AtLeast(analyzeFailOn)); n > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf(i18n.ErrAnalyzeFailOn, n, strings.ToUpper(analyzeFailOn))
+	}
 	return nil
 }
 
-func generateTicketsFromIssues(issues *ticket.IssueList) error {
+// saveGeneratedTickets saves the tickets converted from the analysis findings.
+func saveGeneratedTickets(tickets []*ticket.Ticket) error {
 	w := os.Stdout
 
-	// Convert issues to tickets
-	ticketList := issues.ToTickets()
-
 	// Save tickets
-	store := ticket.NewStore(cfg.TicketsDir)
+	store := newTicketStore()
 	if err := store.Init(); err != nil {
 		// Store initialization is fatal
 		return orcherrors.ErrStoreInit(err)
 	}
 
-	for _, t := range ticketList.Tickets {
+	for _, t := range tickets {
 		if err := store.Save(t); err != nil {
 			// Ticket save failure is recoverable - log and continue
 			recErr := orcherrors.ErrSaveTicket(t.ID, err)
@@ -134,7 +299,7 @@ func generateTicketsFromIssues(issues *ticket.IssueList) error {
 	}
 
 	ui.PrintInfo(w, "")
-	ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgToDirectory, ticketList.Count(), cfg.TicketsDir))
+	ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgToDirectory, len(tickets), cfg.TicketsDir))
 	ui.PrintInfo(w, i18n.HintRunWork)
 
 	return nil
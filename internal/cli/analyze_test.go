@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/spf13/cobra"
+)
+
+func TestCheckFailOn(t *testing.T) {
+	original := analyzeFailOn
+	defer func() { analyzeFailOn = original }()
+
+	issues := ticket.NewIssueList()
+	issues.Add(&ticket.Issue{ID: "PERF-001", Severity: "MED"})
+	issues.Add(&ticket.Issue{ID: "DOC-001", Severity: "LOW"})
+
+	tests := []struct {
+		failOn  string
+		wantErr bool
+	}{
+		{"", false},
+		{"HIGH", false},
+		{"med", true},
+		{"LOW", true},
+	}
+	for _, tt := range tests {
+		analyzeFailOn = tt.failOn
+		err := checkFailOn(&cobra.Command{}, issues)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("checkFailOn(fail-on=%q) error = %v, wantErr %v", tt.failOn, err, tt.wantErr)
+		}
+	}
+}
+
+func TestExpandAnalysis_AutoExpand_MergesFollowUpIssues(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalCfg := cfg
+	defer func() {
+		cfg = originalCfg
+		analyzeAutoExpand, analyzeExpandDepth = false, 1
+	}()
+	cfg = &config.Config{ProjectRoot: tmpDir, TicketsDir: filepath.Join(tmpDir, ".tickets"), DryRun: true}
+	analyzeAutoExpand, analyzeExpandDepth = true, 2
+
+	issues := ticket.NewIssueList()
+	issues.Add(&ticket.Issue{ID: "S-1", Category: "security", Severity: "HIGH", Title: "a", Location: "internal/auth/a.go:1"})
+	issues.Add(&ticket.Issue{ID: "S-2", Category: "security", Severity: "HIGH", Title: "b", Location: "internal/auth/b.go:1"})
+
+	caller := agent.NewCaller("agent", true, "text", filepath.Join(tmpDir, "logs"))
+	caller.SetDryRun(true)
+	caller.SetWriter(io.Discard)
+
+	var buf bytes.Buffer
+	if err := expandAnalysis(context.Background(), &buf, caller, issues); err != nil {
+		t.Fatalf("expandAnalysis() error = %v", err)
+	}
+	// The dry-run security pass reports one new issue; it alone does not trigger another round.
+	if issues.Count() != 3 {
+		t.Errorf("issues after expansion = %d, want 3", issues.Count())
+	}
+	if !strings.Contains(buf.String(), "internal/auth") {
+		t.Errorf("output should list the suggested directory, got:\n%s", buf.String())
+	}
+
+	// Depth 0 disables suggestions entirely.
+	analyzeExpandDepth = 0
+	buf.Reset()
+	if err := expandAnalysis(context.Background(), &buf, caller, issues); err != nil || buf.Len() != 0 {
+		t.Errorf("expandAnalysis() with depth 0 = %v, output %q", err, buf.String())
+	}
+}
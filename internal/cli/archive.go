@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/audit"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var archiveOlderThan string
+
+var archiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: i18n.CmdArchiveShort,
+	Long:  i18n.CmdArchiveLong,
+	Args:  cobra.NoArgs,
+	RunE:  runArchive,
+}
+
+func init() {
+	archiveCmd.Flags().StringVar(&archiveOlderThan, "older-than", "30d", i18n.FlagArchiveOlderThan)
+}
+
+func runArchive(cmd *cobra.Command, args []string) error {
+	w := os.Stdout
+	cutoff, err := audit.ParseTime(archiveOlderThan, time.Now())
+	if err != nil {
+		return err
+	}
+	if !cfg.DryRun {
+		if err := ErrIfBackgroundWorkRunning(); err != nil {
+			return err
+		}
+	}
+
+	store := newTicketStore()
+	ui.PrintHeader(w, i18n.UIArchive)
+	if cfg.DryRun {
+		all, err := store.LoadAll()
+		if err != nil {
+			return err
+		}
+		tickets := ticket.ArchivableTickets(all.Tickets, cutoff)
+		if len(tickets) == 0 {
+			ui.PrintInfo(w, i18n.MsgArchiveNone)
+			return nil
+		}
+		for _, t := range tickets {
+			ui.PrintInfo(w, archivedTicketLine(t))
+		}
+		ui.PrintWarning(w, fmt.Sprintf(i18n.MsgArchiveDryRun, len(tickets)))
+		return nil
+	}
+
+	archived, err := ticket.ArchiveTickets(store, ticket.NewArchive(cfg.ArchiveDir()), cutoff)
+	for _, t := range archived {
+		ui.PrintInfo(w, archivedTicketLine(t))
+	}
+	if err != nil {
+		return err
+	}
+	if len(archived) == 0 {
+		ui.PrintInfo(w, i18n.MsgArchiveNone)
+		return nil
+	}
+	ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgArchived, len(archived), cfg.ArchiveDir()))
+	return nil
+}
+
+// archivedTicketLine renders t as in status, with its final status and when it ended.
+func archivedTicketLine(t *ticket.Ticket) string {
+	ended := t.CreatedAt
+	if t.CompletedAt != nil {
+		ended = *t.CompletedAt
+	}
+	return statusTicketLine(t) + " " + ui.StyleMuted.Render(fmt.Sprintf(i18n.MsgArchivedTicketState, t.Status, ended.Format("2006-01-02")))
+}
+
+// printArchivedTickets lists the archived tickets that filterStatusTickets keeps, most
+// recently finished first, as text or as a JSON array.
+func printArchivedTickets(w io.Writer) error {
+	all, err := ticket.NewArchive(cfg.ArchiveDir()).LoadAll()
+	if err != nil {
+		return err
+	}
+	tickets := filterStatusTickets(all)
+	if statusOutput == statusOutputJSON {
+		if tickets == nil {
+			tickets = []*ticket.Ticket{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(tickets)
+	}
+	if len(tickets) == 0 {
+		ui.PrintInfo(w, i18n.MsgNoArchivedTickets)
+		return nil
+	}
+	ui.PrintHeader(w, i18n.UIArchivedTickets)
+	for _, t := range tickets {
+		ui.PrintInfo(w, archivedTicketLine(t))
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestRunArchive(t *testing.T) {
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+	longAgo := time.Now().AddDate(0, -2, 0)
+	yesterday := time.Now().AddDate(0, 0, -1)
+	for _, tk := range []*ticket.Ticket{
+		{ID: "T-1", Title: "old done", Status: ticket.StatusCompleted, CompletedAt: &longAgo},
+		{ID: "T-2", Title: "old failed", Status: ticket.StatusFailed, CompletedAt: &longAgo},
+		{ID: "T-3", Title: "recent", Status: ticket.StatusCompleted, CompletedAt: &yesterday},
+		{ID: "T-4", Title: "open", Status: ticket.StatusPending},
+	} {
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("Failed to save ticket: %v", err)
+		}
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: ticketsDir, WorkPIDFile: filepath.Join(tmpDir, ".work.pid")}
+	defer func() { archiveOlderThan, statusArchived = "30d", false }()
+
+	// Dry run lists the tickets and moves nothing.
+	cfg.DryRun = true
+	output := captureOutput(func() {
+		if err := runArchive(nil, nil); err != nil {
+			t.Errorf("runArchive() dry run error = %v", err)
+		}
+	})
+	if !strings.Contains(output, fmt.Sprintf(i18n.MsgArchiveDryRun, 2)) {
+		t.Errorf("dry run should report 2 tickets, got:\n%s", output)
+	}
+	if _, err := store.Load("T-1"); err != nil {
+		t.Errorf("dry run should not move T-1: %v", err)
+	}
+
+	cfg.DryRun = false
+	output = captureOutput(func() {
+		if err := runArchive(nil, nil); err != nil {
+			t.Errorf("runArchive() error = %v", err)
+		}
+	})
+	if !strings.Contains(output, fmt.Sprintf(i18n.MsgArchived, 2, cfg.ArchiveDir())) {
+		t.Errorf("output should report 2 archived tickets, got:\n%s", output)
+	}
+	counts, err := ticket.NewStore(ticketsDir).Count()
+	if err != nil || counts[ticket.StatusCompleted] != 1 || counts[ticket.StatusFailed] != 0 || counts[ticket.StatusPending] != 1 {
+		t.Errorf("store counts after archive = %v, %v; want T-3 and T-4 left", counts, err)
+	}
+
+	// status hides archived tickets but says how many there are.
+	output = captureOutput(func() {
+		if err := runStatus(nil, nil); err != nil {
+			t.Errorf("runStatus() error = %v", err)
+		}
+	})
+	if strings.Contains(output, "old done") || !strings.Contains(output, fmt.Sprintf(i18n.MsgArchivedCount, 2)) {
+		t.Errorf("status should hide archived tickets and count them, got:\n%s", output)
+	}
+
+	statusArchived = true
+	output = captureOutput(func() {
+		if err := runStatus(nil, nil); err != nil {
+			t.Errorf("runStatus(--archived) error = %v", err)
+		}
+	})
+	for _, want := range []string{"T-1", "T-2", i18n.UIArchivedTickets} {
+		if !strings.Contains(output, want) {
+			t.Errorf("status --archived should contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "T-3") || strings.Contains(output, "T-4") {
+		t.Errorf("status --archived should only list archived tickets, got:\n%s", output)
+	}
+
+	// --older-than 0 archives every finished ticket.
+	archiveOlderThan = "0"
+	captureOutput(func() {
+		if err := runArchive(nil, nil); err != nil {
+			t.Errorf("runArchive(--older-than 0) error = %v", err)
+		}
+	})
+	if n := ticket.NewArchive(cfg.ArchiveDir()).Count(); n != 3 {
+		t.Errorf("archived tickets = %d, want 3", n)
+	}
+}
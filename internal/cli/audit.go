@@ -0,0 +1,202 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+	"github.com/anthropic/agent-orchestrator/internal/audit"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditSince string
+	auditUntil string
+	auditUser  string
+	auditAPI   bool
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: i18n.CmdAuditShort,
+	Long:  i18n.CmdAuditLong,
+	Args:  cobra.NoArgs,
+	RunE:  runAudit,
+}
+
+func init() {
+	auditCmd.Flags().StringVar(&auditSince, "since", "7d", i18n.FlagAuditSince)
+	auditCmd.Flags().StringVar(&auditUntil, "until", "", i18n.FlagAuditUntil)
+	auditCmd.Flags().StringVar(&auditUser, "user", "", i18n.FlagAuditUser)
+	auditCmd.Flags().BoolVar(&auditAPI, "api", false, i18n.FlagAuditAPI)
+}
+
+// auditOperator returns who is running the orchestrator: the configured audit identity,
+// or the OS user when none is set.
+func auditOperator() string {
+	if cfg != nil && cfg.AuditIdentity != "" {
+		return cfg.AuditIdentity
+	}
+	return audit.CurrentUser()
+}
+
+// auditCommandLine returns the orchestrator command line with the binary path reduced
+// to its base name.
+func auditCommandLine() string {
+	if len(os.Args) == 0 {
+		return ""
+	}
+	args := append([]string{filepath.Base(os.Args[0])}, os.Args[1:]...)
+	return strings.Join(args, " ")
+}
+
+// recordAgentCall appends an audit entry for a finished agent call. It is installed as
+// the Caller hook by CreateAgentCaller. Auditing is best-effort: a write failure must
+// not fail the call.
+func recordAgentCall(info agent.CallInfo) {
+	if cfg == nil {
+		return
+	}
+	host, _ := os.Hostname()
+	e := audit.Entry{
+		Time:         info.Start,
+		User:         audit.CurrentUser(),
+		Identity:     cfg.AuditIdentity,
+		Host:         host,
+		CommandLine:  auditCommandLine(),
+		ConfigHash:   audit.Hash(cfg),
+		AgentCommand: info.Command,
+		WorkingDir:   info.WorkingDir,
+		PromptChars:  info.PromptChars,
+		Duration:     info.Duration,
+		DryRun:       info.DryRun,
+
+		PolicyVersion: info.PolicyVersion,
+		PolicyHash:    info.PolicyHash,
+	}
+	if info.Result != nil {
+		e.Success = info.Result.Success
+		e.ExitCode = info.Result.ExitCode
+		e.LogPath = info.Result.LogPath
+	}
+	if info.Err != nil {
+		e.Error = info.Err.Error()
+	}
+	_ = audit.Append(cfg.AuditLogPath(), e)
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	now := time.Now()
+	since, err := audit.ParseTime(auditSince, now)
+	if err != nil {
+		return err
+	}
+	until, err := audit.ParseTime(auditUntil, now)
+	if err != nil {
+		return err
+	}
+
+	if auditAPI {
+		entries, err := audit.LoadAPI(cfg.APIAuditLogPath())
+		if err != nil {
+			return err
+		}
+		printAPIAudit(os.Stdout, audit.FilterAPI(entries, since, until, auditUser))
+		return nil
+	}
+
+	entries, err := audit.Load(cfg.AuditLogPath())
+	if err != nil {
+		return err
+	}
+	printAudit(os.Stdout, audit.Filter(entries, since, until, auditUser))
+	return nil
+}
+
+// printAudit lists agent calls and summarizes them by operator and outcome.
+func printAudit(w io.Writer, entries []audit.Entry) {
+	ui.PrintHeader(w, i18n.UIAudit)
+	if len(entries) == 0 {
+		ui.PrintInfo(w, i18n.MsgAuditNoEntries)
+		return
+	}
+
+	table := ui.NewTable(i18n.TableAuditTime, i18n.TableAuditOperator, i18n.TableAuditResult,
+		i18n.TableAuditDuration, i18n.TableAuditConfig, i18n.TableAuditCommand)
+	operators := make(map[string]bool)
+	failed := 0
+	for _, e := range entries {
+		operators[e.Operator()] = true
+		result := "ok"
+		switch {
+		case e.DryRun:
+			result = "dry-run"
+		case !e.Success || e.Error != "":
+			result = fmt.Sprintf("fail(%d)", e.ExitCode)
+			failed++
+		}
+		configHash := e.ConfigHash
+		if len(configHash) > 12 {
+			configHash = configHash[:12]
+		}
+		table.AddRow(
+			e.Time.Local().Format("2006-01-02 15:04:05"),
+			e.Operator(),
+			result,
+			e.Duration.Round(time.Second).String(),
+			configHash,
+			ui.Truncate(e.CommandLine, 60),
+		)
+	}
+	table.Render(w)
+
+	fmt.Fprintln(w)
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgAuditSummary, len(entries), len(operators), failed))
+}
+
+// printAPIAudit lists requests to the serve API and summarizes them by token.
+func printAPIAudit(w io.Writer, entries []audit.APIEntry) {
+	ui.PrintHeader(w, i18n.UIAuditAPI)
+	if len(entries) == 0 {
+		ui.PrintInfo(w, i18n.MsgAuditNoEntries)
+		return
+	}
+
+	table := ui.NewTable(i18n.TableAuditTime, i18n.TableAuditToken, i18n.TableAuditRequest,
+		i18n.TableAuditStatus, i18n.TableAuditTarget)
+	tokens := make(map[string]bool)
+	refused := 0
+	for _, e := range entries {
+		token := e.Token
+		if token == "" {
+			token = "-"
+		}
+		tokens[token] = true
+		if e.Status == http.StatusUnauthorized || e.Status == http.StatusForbidden {
+			refused++
+		}
+		target := e.Target
+		if e.Error != "" {
+			target = e.Error
+		}
+		table.AddRow(
+			e.Time.Local().Format("2006-01-02 15:04:05"),
+			token,
+			e.Method+" "+e.Path,
+			strconv.Itoa(e.Status),
+			ui.Truncate(target, 60),
+		)
+	}
+	table.Render(w)
+
+	fmt.Fprintln(w)
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgAuditAPISummary, len(entries), len(tokens), refused))
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+	"github.com/anthropic/agent-orchestrator/internal/audit"
+	"github.com/anthropic/agent-orchestrator/internal/config"
+)
+
+func TestRecordAgentCall_AndAuditCommand(t *testing.T) {
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: t.TempDir(), AuditIdentity: "alice@example.com", AgentCommand: "agent"}
+
+	recordAgentCall(agent.CallInfo{
+		Start:       time.Now().Add(-time.Minute),
+		Duration:    90 * time.Second,
+		Command:     "agent",
+		PromptChars: 42,
+		Result:      &agent.Result{Success: true},
+	})
+	recordAgentCall(agent.CallInfo{
+		Start:   time.Now().Add(-30 * 24 * time.Hour),
+		Command: "agent",
+		Result:  &agent.Result{Success: false, ExitCode: 2},
+	})
+
+	entries, err := audit.Load(cfg.AuditLogPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("audit log has %d entries, want 2", len(entries))
+	}
+	e := entries[0]
+	if e.Identity != "alice@example.com" || e.User == "" || e.CommandLine == "" || len(e.ConfigHash) != 64 {
+		t.Errorf("entry = %+v, want identity, user, command line and config hash", e)
+	}
+	if e.ConfigHash != audit.Hash(cfg) {
+		t.Error("config hash should fingerprint the current config")
+	}
+
+	defer func() { auditSince, auditUntil, auditUser = "7d", "", "" }()
+	auditSince, auditUntil, auditUser = "7d", "", ""
+	output := captureOutput(func() {
+		if err := runAudit(nil, nil); err != nil {
+			t.Errorf("runAudit() error = %v", err)
+		}
+	})
+	if !strings.Contains(output, "alice@example.com") {
+		t.Errorf("output should list the operator, got:\n%s", output)
+	}
+	if strings.Contains(output, "fail(2)") {
+		t.Errorf("entry older than --since should be filtered out, got:\n%s", output)
+	}
+
+	auditUser = "someone-else"
+	output = captureOutput(func() {
+		if err := runAudit(nil, nil); err != nil {
+			t.Errorf("runAudit() error = %v", err)
+		}
+	})
+	if strings.Contains(output, "alice@example.com") {
+		t.Errorf("--user should filter by operator, got:\n%s", output)
+	}
+
+	auditSince = "not-a-time"
+	if err := runAudit(nil, nil); err == nil {
+		t.Error("runAudit() should reject an invalid --since")
+	}
+}
+
+func TestAuditCommand_API(t *testing.T) {
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: t.TempDir()}
+
+	for _, e := range []audit.APIEntry{
+		{Time: time.Now(), Token: "ci", Scope: "work", Method: "POST", Path: "/api/work", Status: 202, Target: "job-1"},
+		{Time: time.Now(), Token: "dashboard", Scope: "read", Method: "POST", Path: "/api/plan", Status: 403, Error: "forbidden"},
+	} {
+		if err := audit.AppendAPI(cfg.APIAuditLogPath(), e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defer func() { auditSince, auditUntil, auditUser, auditAPI = "7d", "", "", false }()
+	auditSince, auditUntil, auditUser, auditAPI = "7d", "", "ci", true
+	output := captureOutput(func() {
+		if err := runAudit(nil, nil); err != nil {
+			t.Errorf("runAudit() error = %v", err)
+		}
+	})
+	if !strings.Contains(output, "POST /api/work") || !strings.Contains(output, "job-1") {
+		t.Errorf("output should list the ci token's request, got:\n%s", output)
+	}
+	if strings.Contains(output, "/api/plan") {
+		t.Errorf("--user should filter by token name, got:\n%s", output)
+	}
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+	"github.com/anthropic/agent-orchestrator/internal/budget"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// agentBudget returns the hourly token/cost budget shared by all agent calls, or nil
+// when no limit is configured. The state lives in a file, so every Manager returned
+// here (and the one in a detach child) sees the same budget.
+func agentBudget() *budget.Manager {
+	if cfg == nil {
+		return nil
+	}
+	limits := budget.Limits{TokensPerHour: cfg.BudgetTokensPerHour, CostPerHour: cfg.BudgetCostPerHour}
+	if !limits.Enabled() {
+		return nil
+	}
+	return budget.New(cfg.BudgetStatePath(), limits)
+}
+
+// chargeAgentBudget charges a finished agent call against the budget, using the usage
+// the agent reported or else estimating tokens from prompt and output size. Installed as
+// part of the Caller hook by CreateAgentCaller. Budgeting is best-effort: a state write failure must not fail the call.
+func chargeAgentBudget(info agent.CallInfo) {
+	b := agentBudget()
+	if b == nil || info.DryRun {
+		return
+	}
+	if info.Result != nil && !info.Result.Usage.IsZero() {
+		_ = b.Spend(info.Result.Usage.Tokens(), usageCost(info.Result.Usage))
+		return
+	}
+	chars := info.PromptChars
+	if info.Result != nil {
+		chars += len(info.Result.Output)
+	}
+	tokens := agent.EstimateTokens(chars)
+	cost := float64(tokens) * cfg.TokenPricePerMillion / 1_000_000
+	_ = b.Spend(tokens, cost)
+}
+
+// usageCost returns the cost of u: the cost the agent reported, or its tokens priced
+// at token_price_per_million.
+func usageCost(u agent.Usage) float64 {
+	if u.CostUSD > 0 || cfg == nil {
+		return u.CostUSD
+	}
+	return float64(u.Tokens()) * cfg.TokenPricePerMillion / 1_000_000
+}
+
+// recordTicketUsage adds u, the usage of the agent calls made for t, to t.
+func recordTicketUsage(t *ticket.Ticket, u agent.Usage) {
+	if !u.IsZero() {
+		t.AddUsage(u.Tokens(), usageCost(u))
+	}
+}
+
+// formatUsage renders tokens and cost for display, e.g. "12.3k tokens · $0.42".
+func formatUsage(tokens int, costUSD float64) string {
+	var count string
+	switch {
+	case tokens >= 1_000_000:
+		count = fmt.Sprintf("%.1fM", float64(tokens)/1_000_000)
+	case tokens >= 1_000:
+		count = fmt.Sprintf("%.1fk", float64(tokens)/1_000)
+	default:
+		count = fmt.Sprint(tokens)
+	}
+	return fmt.Sprintf(i18n.MsgUsage, count, costUSD)
+}
+
+// waitForBudget blocks before dispatching a ticket while the hourly budget is exhausted.
+// onPause is called once with the expected wait. Returns an error only when ctx is done;
+// an unreadable budget state does not hold up work.
+func waitForBudget(ctx context.Context, onPause func(time.Duration)) error {
+	b := agentBudget()
+	if b == nil {
+		return nil
+	}
+	if err := b.Wait(ctx, onPause); err != nil && ctx.Err() != nil {
+		return err
+	}
+	return nil
+}
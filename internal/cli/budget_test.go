@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestChargeAgentBudget(t *testing.T) {
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = config.DefaultConfig()
+	cfg.TicketsDir = t.TempDir()
+
+	// No limits: nothing is tracked and dispatch never waits.
+	chargeAgentBudget(agent.CallInfo{PromptChars: 4000})
+	if agentBudget() != nil {
+		t.Fatal("agentBudget() should be nil without limits")
+	}
+
+	cfg.BudgetTokensPerHour = 1000
+	cfg.BudgetCostPerHour = 1
+	cfg.TokenPricePerMillion = 1000 // $0.001 per token
+
+	// Dry runs are free.
+	chargeAgentBudget(agent.CallInfo{PromptChars: 40000, DryRun: true})
+	if err := waitForBudget(context.Background(), nil); err != nil {
+		t.Fatalf("waitForBudget() after dry run = %v", err)
+	}
+
+	// 3000 prompt bytes + 1000 output bytes ≈ 1000 tokens ≈ $1.
+	chargeAgentBudget(agent.CallInfo{PromptChars: 3000, Result: &agent.Result{Output: string(make([]byte, 1000))}})
+	tokens, cost, err := agentBudget().Available()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tokens > 1 || cost > 0.01 {
+		t.Errorf("Available() = %v tokens, $%v; want budget used up", tokens, cost)
+	}
+
+	// Exhausted: dispatch pauses and gives up when the context ends.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	paused := false
+	chargeAgentBudget(agent.CallInfo{PromptChars: 4000})
+	if err := waitForBudget(ctx, func(time.Duration) { paused = true }); err == nil {
+		t.Error("waitForBudget() should return the context error while the budget is exhausted")
+	}
+	if !paused {
+		t.Error("onPause should be called when dispatch pauses")
+	}
+}
+
+func TestRecordTicketUsage(t *testing.T) {
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = config.DefaultConfig()
+	cfg.TokenPricePerMillion = 10
+
+	tk := &ticket.Ticket{ID: "T-1"}
+	recordTicketUsage(tk, agent.Usage{InputTokens: 800, OutputTokens: 200, CostUSD: 0.5})
+	recordTicketUsage(tk, agent.Usage{InputTokens: 1000}) // priced: 1000 × $10/M
+	recordTicketUsage(tk, agent.Usage{})
+	if tk.TokensUsed != 2000 || math.Abs(tk.CostUSD-0.51) > 1e-9 {
+		t.Errorf("TokensUsed = %d, CostUSD = %v; want 2000, 0.51", tk.TokensUsed, tk.CostUSD)
+	}
+	if got, want := formatUsage(tk.TokensUsed, tk.CostUSD), "2.0k tokens · $0.51"; got != want {
+		t.Errorf("formatUsage() = %q, want %q", got, want)
+	}
+}
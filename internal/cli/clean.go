@@ -28,7 +28,7 @@ func init() {
 func runClean(cmd *cobra.Command, args []string) error {
 	w := os.Stdout
 
-	store := ticket.NewStore(cfg.TicketsDir)
+	store := newTicketStore()
 
 	// Get current counts
 	counts, err := store.Count()
@@ -32,14 +32,14 @@ func runCommit(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 	w := os.Stdout
 
-	store := ticket.NewStore(cfg.TicketsDir)
+	store := newTicketStore()
 
 	if commitAll {
 		return commitAllTickets(ctx, store)
 	}
 
 	if len(args) == 0 {
-		ui.PrintError(w, "請提供 ticket ID 或使用 --all")
+		ui.PrintError(w, i18n.ErrCommitTicketOrAll)
 		return nil
 	}
 
@@ -69,7 +69,7 @@ func filesForTicket(t *ticket.Ticket, changedFiles []string) []string {
 	return out
 }
 
-func commitSingleTicket(ctx context.Context, store *ticket.Store, ticketID string) error {
+func commitSingleTicket(ctx context.Context, store ticket.Storer, ticketID string) error {
 	w := os.Stdout
 
 	t, err := store.Load(ticketID)
@@ -121,10 +121,19 @@ func commitSingleTicket(ctx context.Context, store *ticket.Store, ticketID strin
 
 	commitAgent := agent.NewCommitAgent(caller, cfg.ProjectRoot)
 
+	if err := checkoutTicketBranch(ctx, t, ticketBranchBase(ctx)); err != nil {
+		ui.PrintError(w, fmt.Sprintf(i18n.ErrTicketBranch, t.ID, err))
+		return nil
+	}
+	if t.Branch != "" {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgCommitOnBranch, t.Branch))
+	}
+
 	// Run commit
 	spinner := ui.NewSpinner(i18n.SpinnerCommitting, w)
 	spinner.Start()
 
+	before := getGitHead(ctx)
 	result, err := commitAgent.Commit(ctx, t.ID, t.Title, changes, filesToStage)
 	if err != nil {
 		spinner.Fail(i18n.SpinnerFailCommit)
@@ -133,6 +142,7 @@ func commitSingleTicket(ctx context.Context, store *ticket.Store, ticketID strin
 
 	if result.Success {
 		spinner.Success(i18n.MsgCommitSuccess)
+		recordTicketCommit(ctx, w, store, t, before)
 	} else {
 		spinner.Fail(i18n.SpinnerFailCommit + ": " + result.Error)
 	}
@@ -140,7 +150,7 @@ func commitSingleTicket(ctx context.Context, store *ticket.Store, ticketID strin
 	return nil
 }
 
-func commitAllTickets(ctx context.Context, store *ticket.Store) error {
+func commitAllTickets(ctx context.Context, store ticket.Storer) error {
 	w := os.Stdout
 
 	completed, err := store.LoadByStatus(ticket.StatusCompleted)
@@ -155,6 +165,8 @@ func commitAllTickets(ctx context.Context, store *ticket.Store) error {
 
 	ui.PrintHeader(w, i18n.UIBatchCommit)
 	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgPrepareCommit, len(completed)))
+	// Branches missing for some tickets all fork from where the commit started.
+	branchBase := ticketBranchBase(ctx)
 
 	// Create agent caller
 	caller, err := CreateAgentCaller()
@@ -170,7 +182,7 @@ func commitAllTickets(ctx context.Context, store *ticket.Store) error {
 	skipped := 0
 
 	for i, t := range completed {
-		ui.PrintStep(w, i+1, len(completed), fmt.Sprintf("提交 %s: %s", t.ID, t.Title))
+		ui.PrintStep(w, i+1, len(completed), fmt.Sprintf(i18n.MsgCommitTicketStep, t.ID, t.Title))
 
 		changedFiles := getGitChangedFiles(ctx)
 		if len(changedFiles) == 0 {
@@ -196,6 +208,16 @@ func commitAllTickets(ctx context.Context, store *ticket.Store) error {
 			continue
 		}
 
+		if err := checkoutTicketBranch(ctx, t, branchBase); err != nil {
+			ui.PrintError(w, "  "+fmt.Sprintf(i18n.ErrTicketBranch, t.ID, err))
+			failed++
+			continue
+		}
+		if t.Branch != "" {
+			ui.PrintInfo(w, "  "+fmt.Sprintf(i18n.MsgCommitOnBranch, t.Branch))
+		}
+
+		before := getGitHead(ctx)
 		result, err := commitAgent.Commit(ctx, t.ID, t.Title, changes, filesToStage)
 		if err != nil || !result.Success {
 			ui.PrintError(w, "  "+i18n.SpinnerFailCommit)
@@ -204,6 +226,7 @@ func commitAllTickets(ctx context.Context, store *ticket.Store) error {
 		}
 
 		ui.PrintSuccess(w, "  "+i18n.MsgCommitSuccess)
+		recordTicketCommit(ctx, w, store, t, before)
 		committed++
 	}
 
@@ -3,7 +3,10 @@ package cli
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
+	"github.com/anthropic/agent-orchestrator/internal/agent"
 	"github.com/anthropic/agent-orchestrator/internal/config"
 	"github.com/anthropic/agent-orchestrator/internal/i18n"
 	"github.com/anthropic/agent-orchestrator/internal/ui"
@@ -25,31 +28,58 @@ var configShowCmd = &cobra.Command{
 		ui.PrintHeader(w, i18n.UICurrentConfig)
 
 		// Load config
-		cfg, err := config.Load()
+		cfg, err := config.LoadProfile(profileName)
 		if err != nil {
 			ui.PrintError(w, fmt.Sprintf(i18n.ErrLoadConfigFailed, err.Error()))
 			return nil
 		}
 
-		table := ui.NewTable("設定項", "值")
+		table := ui.NewTable(i18n.TableConfigKey, i18n.TableConfigValue)
 		table.AddRow("Agent Command", cfg.AgentCommand)
 		table.AddRow("Output Format", cfg.AgentOutputFormat)
 		table.AddRow("Force Mode", fmt.Sprintf("%v", cfg.AgentForce))
-		table.AddRow("Timeout", fmt.Sprintf("%d 秒", cfg.AgentTimeout))
+		table.AddRow("Timeout", fmt.Sprintf(i18n.MsgSeconds, cfg.AgentTimeout))
+		table.AddRow("Retries", fmt.Sprintf(i18n.MsgRetriesBackoff, cfg.AgentMaxRetries, cfg.AgentBackoff))
+		if len(cfg.Models) > 0 {
+			keys := make([]string, 0, len(cfg.Models))
+			for k := range cfg.Models {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			pairs := make([]string, 0, len(keys))
+			for _, k := range keys {
+				pairs = append(pairs, k+"="+cfg.Models[k])
+			}
+			table.AddRow("Models", strings.Join(pairs, ", "))
+		}
 		table.AddRow("Project Root", cfg.ProjectRoot)
 		table.AddRow("Tickets Dir", cfg.TicketsDir)
 		table.AddRow("Logs Dir", cfg.LogsDir)
 		table.AddRow("Docs Dir", cfg.DocsDir)
 		table.AddRow("Max Parallel", fmt.Sprintf("%d", cfg.MaxParallel))
+		table.AddRow("Theme", ui.ActiveTheme().Name)
+		table.AddRow("Language", i18n.Language())
+		if cfg.Profile != "" {
+			table.AddRow("Profile", cfg.Profile)
+		}
+		if policy := (agent.Policy{Text: cfg.Policy.Preamble, Version: cfg.Policy.Version}); !policy.IsZero() {
+			table.AddRow("Policy", policy.String())
+		}
 		table.Render(w)
 
 		ui.PrintInfo(w, "")
-		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgConfigFilePath, config.GetConfigFilePath()))
+		if files := config.ConfigFiles(); len(files) > 0 {
+			ui.PrintInfo(w, fmt.Sprintf(i18n.MsgConfigFilesInUse, strings.Join(files, ", ")))
+		} else {
+			ui.PrintInfo(w, i18n.MsgConfigNoFiles)
+		}
 
 		return nil
 	},
 }
 
+var configInitGlobal bool
+
 var configInitCmd = &cobra.Command{
 	Use:   "init",
 	Short: i18n.CmdConfigInitShort,
@@ -57,6 +87,9 @@ var configInitCmd = &cobra.Command{
 		w := os.Stdout
 
 		path := ".agent-orchestrator.yaml"
+		if configInitGlobal {
+			path = config.GlobalConfigFilePath()
+		}
 
 		// Check if already exists
 		if _, err := os.Stat(path); err == nil {
@@ -88,10 +121,92 @@ var configPathCmd = &cobra.Command{
 	},
 }
 
+var configGetCmd = &cobra.Command{
+	Use:               "get <key>",
+	Short:             i18n.CmdConfigGetShort,
+	Long:              i18n.CmdConfigGetLong,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeConfigKey,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		loaded, err := config.LoadProfile(profileName)
+		if err != nil {
+			return fmt.Errorf(i18n.ErrLoadConfigFailed, err.Error())
+		}
+		value, err := loaded.Get(args[0])
+		if err != nil {
+			return fmt.Errorf(i18n.ErrConfigGetFailed, err.Error())
+		}
+		fmt.Fprintln(os.Stdout, value)
+		return nil
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:               "set <key> <value>",
+	Short:             i18n.CmdConfigSetShort,
+	Long:              i18n.CmdConfigSetLong,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeConfigKey,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := config.GetConfigFilePath()
+		if err := config.SetFileValue(path, args[0], args[1]); err != nil {
+			return fmt.Errorf(i18n.ErrConfigSetFailed, err.Error())
+		}
+		ui.PrintSuccess(os.Stdout, fmt.Sprintf(i18n.MsgConfigSet, args[0], args[1], path))
+		return nil
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate [file]",
+	Short: i18n.CmdConfigValidateShort,
+	Long:  i18n.CmdConfigValidateLong,
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := os.Stdout
+		path := config.GetConfigFilePath()
+		if len(args) > 0 {
+			path = args[0]
+		} else if cfgFile != "" {
+			path = cfgFile
+		}
+		problems, err := config.CheckFile(path)
+		if os.IsNotExist(err) && path == config.GetConfigFilePath() {
+			ui.PrintWarning(w, fmt.Sprintf(i18n.MsgConfigValidateNoFile, path))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(problems) == 0 {
+			ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgConfigValid, path))
+			return nil
+		}
+		for _, p := range problems {
+			ui.PrintError(w, fmt.Sprintf("%s: %s", path, p))
+		}
+		cmd.SilenceUsage = true
+		return fmt.Errorf(i18n.ErrConfigInvalid, path, len(problems))
+	},
+}
+
+// completeConfigKey completes the key argument of config get and set.
+func completeConfigKey(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return config.Keys(), cobra.ShellCompDirectiveNoFileComp
+}
+
 func init() {
+	configInitCmd.Flags().BoolVar(&configInitGlobal, "global", false, i18n.FlagConfigInitGlobal)
+
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configInitCmd)
 	configCmd.AddCommand(configPathCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configValidateCmd)
 
 	// Default subcommand is show
 	configCmd.RunE = configShowCmd.RunE
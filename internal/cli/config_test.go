@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestConfigSetGet(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	content := "agent_command: agent           # Agent CLI\nmax_parallel: 3                # workers\n"
+	if err := os.WriteFile(".agent-orchestrator.yaml", []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var err error
+	captureOutput(func() { err = configSetCmd.RunE(configSetCmd, []string{"max_parallel", "6"}) })
+	if err != nil {
+		t.Fatalf("config set error = %v", err)
+	}
+	data, _ := os.ReadFile(".agent-orchestrator.yaml")
+	if want := strings.Replace(content, "max_parallel: 3", "max_parallel: 6", 1); string(data) != want {
+		t.Errorf("config file =\n%s\nwant\n%s", data, want)
+	}
+
+	out := captureOutput(func() { err = configGetCmd.RunE(configGetCmd, []string{"max_parallel"}) })
+	if err != nil {
+		t.Fatalf("config get error = %v", err)
+	}
+	if strings.TrimSpace(out) != "6" {
+		t.Errorf("config get max_parallel = %q, want 6", out)
+	}
+
+	captureOutput(func() { err = configSetCmd.RunE(configSetCmd, []string{"max_parallel", "-1"}) })
+	if err == nil {
+		t.Error("config set should reject an invalid value")
+	}
+	if after, _ := os.ReadFile(".agent-orchestrator.yaml"); string(after) != string(data) {
+		t.Errorf("rejected value changed the file:\n%s", after)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	var err error
+	out := captureOutput(func() { err = configValidateCmd.RunE(configValidateCmd, nil) })
+	if err != nil {
+		t.Errorf("config validate without a file error = %v, want nil", err)
+	}
+	if !strings.Contains(out, ".agent-orchestrator.yaml") {
+		t.Errorf("output should name the missing file:\n%s", out)
+	}
+
+	if err := os.WriteFile(".agent-orchestrator.yaml", []byte("max_parallel: 3\nagent_forse: true\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	out = captureOutput(func() { err = configValidateCmd.RunE(configValidateCmd, nil) })
+	if err == nil {
+		t.Fatal("config validate should fail on an unknown key")
+	}
+	if want := "line 2: agent_forse: unknown key (did you mean agent_force?)"; !strings.Contains(out, want) {
+		t.Errorf("output should contain %q:\n%s", want, out)
+	}
+
+	if err := os.WriteFile("other.yaml", []byte("max_parallel: 3\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	captureOutput(func() { err = configValidateCmd.RunE(configValidateCmd, []string{"other.yaml"}) })
+	if err != nil {
+		t.Errorf("config validate other.yaml error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/deps"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var depsEcosystems []string
+
+// depsRunner runs the scanner commands; replaced in tests.
+var depsRunner deps.Runner = deps.ExecRunner
+
+var depsCmd = &cobra.Command{
+	Use:   "deps",
+	Short: i18n.CmdDepsShort,
+	Long:  i18n.CmdDepsLong,
+}
+
+var depsScanCmd = &cobra.Command{
+	Use:   "scan",
+	Short: i18n.CmdDepsScanShort,
+	Long:  i18n.CmdDepsScanLong,
+	Args:  cobra.NoArgs,
+	RunE:  runDepsScan,
+}
+
+func init() {
+	depsScanCmd.Flags().StringSliceVar(&depsEcosystems, "ecosystem", nil, i18n.FlagDepsEcosystem)
+	depsCmd.AddCommand(depsScanCmd)
+}
+
+func runDepsScan(cmd *cobra.Command, args []string) error {
+	w := os.Stdout
+	scanners, err := selectDepsScanners(depsEcosystems)
+	if err != nil {
+		return err
+	}
+	if !cfg.DryRun {
+		if err := ErrIfBackgroundWorkRunning(); err != nil {
+			return err
+		}
+	}
+
+	ui.PrintHeader(w, i18n.UIDepsScan)
+	var updates []deps.Update
+	ran, failed := 0, 0
+	for _, s := range deps.Detect(cfg.ProjectRoot, scanners) {
+		ran++
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgDepsScanning, s.Ecosystem))
+		found, err := s.Scan(context.Background(), depsRunner, cfg.ProjectRoot)
+		if err != nil {
+			failed++
+			ui.PrintWarning(w, fmt.Sprintf(i18n.MsgDepsScanFailed, s.Ecosystem, err))
+			continue
+		}
+		updates = append(updates, found...)
+	}
+	if ran == 0 {
+		ui.PrintInfo(w, i18n.MsgDepsNoEcosystem)
+		return nil
+	}
+	if failed == ran {
+		return errors.New(i18n.ErrDepsAllFailed)
+	}
+	return createDepsTickets(w, updates)
+}
+
+// selectDepsScanners returns the scanners for ecosystems, or all of them when none
+// are given.
+func selectDepsScanners(ecosystems []string) ([]deps.Scanner, error) {
+	all := deps.Scanners()
+	if len(ecosystems) == 0 {
+		return all, nil
+	}
+	byName := make(map[string]deps.Scanner, len(all))
+	names := make([]string, 0, len(all))
+	for _, s := range all {
+		byName[s.Ecosystem] = s
+		names = append(names, s.Ecosystem)
+	}
+	var out []deps.Scanner
+	for _, e := range ecosystems {
+		s, ok := byName[strings.ToLower(strings.TrimSpace(e))]
+		if !ok {
+			return nil, fmt.Errorf(i18n.ErrDepsEcosystem, e, strings.Join(names, ", "))
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// createDepsTickets saves a ticket per update unless the dependency is already tracked:
+// by a ticket with the same ID (any status), or by an unfinished ticket for another
+// version of it. In dry-run mode nothing is written.
+func createDepsTickets(w io.Writer, updates []deps.Update) error {
+	if len(updates) == 0 {
+		ui.PrintSuccess(w, i18n.MsgDepsNone)
+		return nil
+	}
+	store := newTicketStore()
+	if err := store.Init(); err != nil {
+		return fmt.Errorf(i18n.ErrInitStoreFailed, err)
+	}
+	all, err := store.LoadAll()
+	if err != nil {
+		return err
+	}
+
+	created, skipped := 0, 0
+	for _, u := range updates {
+		if existing := trackingDepsTicket(all.Tickets, u); existing != nil {
+			ui.PrintInfo(w, ui.StyleMuted.Render(fmt.Sprintf(i18n.MsgDepsSkipped, u.Name, existing.ID)))
+			skipped++
+			continue
+		}
+		t := deps.ToTicket(u)
+		if cfg.DryRun {
+			ui.PrintInfo(w, fmt.Sprintf(i18n.MsgDepsWouldAdd, t.ID, t.Title))
+			created++
+			continue
+		}
+		if err := store.Save(t); err != nil {
+			return fmt.Errorf(i18n.ErrSaveTicketFailed, t.ID)
+		}
+		all.Add(t)
+		ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgDepsCreated, t.ID, t.Title))
+		created++
+	}
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgDepsSummary, created, skipped))
+	return nil
+}
+
+// trackingDepsTicket returns the ticket already covering u, or nil.
+func trackingDepsTicket(tickets []*ticket.Ticket, u deps.Update) *ticket.Ticket {
+	id := deps.TicketID(u)
+	for _, t := range tickets {
+		if t.ID == id || (deps.SameDependency(t.ID, u) && t.Status != ticket.StatusCompleted) {
+			return t
+		}
+	}
+	return nil
+}
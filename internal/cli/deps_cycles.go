@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Ways to break a dependency cycle.
+const (
+	cycleFixSoft = "soft" // turn the edge into a soft dependency
+	cycleFixDrop = "drop" // remove the edge
+)
+
+var fixCyclesStrategy string
+
+var depsFixCyclesCmd = &cobra.Command{
+	Use:   "fix-cycles",
+	Short: i18n.CmdDepsFixCyclesShort,
+	Long:  i18n.CmdDepsFixCyclesLong,
+	Args:  cobra.NoArgs,
+	RunE:  runDepsFixCycles,
+}
+
+func init() {
+	depsFixCyclesCmd.Flags().StringVar(&fixCyclesStrategy, "strategy", "", i18n.FlagCycleStrategy)
+	depsCmd.AddCommand(depsFixCyclesCmd)
+}
+
+// cycleChooser decides how to break cycle at its weakest edge: cycleFixSoft,
+// cycleFixDrop, or "" to leave it.
+type cycleChooser func(cycle []string, edge ticket.Edge) (string, error)
+
+func runDepsFixCycles(cmd *cobra.Command, args []string) error {
+	w := os.Stdout
+	switch fixCyclesStrategy {
+	case "", cycleFixSoft, cycleFixDrop:
+	default:
+		return fmt.Errorf(i18n.ErrCycleStrategy, fixCyclesStrategy)
+	}
+	if !cfg.DryRun {
+		if err := ErrIfBackgroundWorkRunning(); err != nil {
+			return err
+		}
+	}
+
+	store := newTicketStore()
+	if err := store.Init(); err != nil {
+		return fmt.Errorf(i18n.ErrInitStoreFailed, err)
+	}
+	all, err := store.LoadAll()
+	if err != nil {
+		return err
+	}
+	open := openTickets(all.Tickets)
+
+	ui.PrintHeader(w, i18n.UIFixCycles)
+	choose := func(cycle []string, edge ticket.Edge) (string, error) {
+		if fixCyclesStrategy != "" {
+			return fixCyclesStrategy, nil
+		}
+		return askCycleFix(w, os.Stdin, edge)
+	}
+	fixed, changed, err := repairCycles(w, ticket.NewDependencyResolver(store), open, choose)
+	if err != nil {
+		return err
+	}
+	if fixed == 0 {
+		return nil
+	}
+	if cfg.DryRun {
+		ui.PrintInfo(w, i18n.MsgCyclesDryRun)
+		return nil
+	}
+	for _, t := range changed {
+		if err := store.Save(t); err != nil {
+			return fmt.Errorf(i18n.ErrSaveTicketFailed, t.ID)
+		}
+	}
+	ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgCyclesFixed, fixed, len(changed)))
+	return nil
+}
+
+// repairCycles reports each dependency cycle among tickets with its weakest edge and
+// breaks the edge as choose decides, until only cycles choose left remain. It returns
+// the number of edges changed and the tickets they belong to, modified in place.
+func repairCycles(w io.Writer, resolver *ticket.DependencyResolver, tickets []*ticket.Ticket, choose cycleChooser) (fixed int, changed []*ticket.Ticket, err error) {
+	byID := make(map[string]*ticket.Ticket, len(tickets))
+	for _, t := range tickets {
+		byID[t.ID] = t
+	}
+	skipped := make(map[string]bool)
+	seen := false
+	for {
+		var cycle []string
+		for _, c := range resolver.FindCycles(tickets) {
+			if !skipped[strings.Join(c, " ")] {
+				cycle = c
+				break
+			}
+		}
+		if cycle == nil {
+			break
+		}
+		seen = true
+		edge := printCycle(w, resolver, tickets, cycle)
+
+		action, err := choose(cycle, edge)
+		if err != nil {
+			return fixed, changed, err
+		}
+		t := byID[edge.From]
+		switch action {
+		case cycleFixSoft:
+			t.SoftenDependency(edge.To)
+			ui.PrintSuccess(w, "  "+fmt.Sprintf(i18n.MsgCycleSoftened, edge.From, edge.To))
+		case cycleFixDrop:
+			t.RemoveDependency(edge.To)
+			ui.PrintSuccess(w, "  "+fmt.Sprintf(i18n.MsgCycleDropped, edge.From, edge.To))
+		default:
+			skipped[strings.Join(cycle, " ")] = true
+			continue
+		}
+		fixed++
+		if !slices.Contains(changed, t) {
+			changed = append(changed, t)
+		}
+	}
+	if !seen {
+		ui.PrintSuccess(w, i18n.MsgNoCycles)
+	}
+	return fixed, changed, nil
+}
+
+// askCycleFix asks how to break edge.
+func askCycleFix(w io.Writer, in io.Reader, edge ticket.Edge) (string, error) {
+	prompt := ui.NewPrompt(in, w)
+	choice, err := prompt.Select(i18n.PromptCycleRepair, []string{
+		fmt.Sprintf(i18n.CycleOptionSoft, edge.From, edge.To),
+		fmt.Sprintf(i18n.CycleOptionDrop, edge.From, edge.To),
+		i18n.CycleOptionSkip,
+	})
+	if err != nil {
+		return "", err
+	}
+	return []string{cycleFixSoft, cycleFixDrop, ""}[choice], nil
+}
+
+// openTickets returns the tickets that are not completed. Dependencies on completed
+// tickets are satisfied, so only cycles among these block anything.
+func openTickets(tickets []*ticket.Ticket) []*ticket.Ticket {
+	var open []*ticket.Ticket
+	for _, t := range tickets {
+		if t.Status != ticket.StatusCompleted {
+			open = append(open, t)
+		}
+	}
+	return open
+}
+
+// printCycle prints cycle with the edge suggested to break it and returns the edge.
+func printCycle(w io.Writer, resolver *ticket.DependencyResolver, tickets []*ticket.Ticket, cycle []string) ticket.Edge {
+	edge := resolver.WeakestEdge(tickets, cycle)
+	ui.PrintWarning(w, fmt.Sprintf(i18n.MsgCyclePath, formatCycle(cycle)))
+	ui.PrintInfo(w, "  "+fmt.Sprintf(i18n.MsgCycleSuggest, edge.From, edge.To))
+	return edge
+}
+
+// blockingCycles returns the dependency cycles among the open tickets of store, with
+// those tickets; none when the tickets cannot be loaded.
+func blockingCycles(store ticket.Storer) (cycles [][]string, open []*ticket.Ticket) {
+	all, err := store.LoadAll()
+	if err != nil {
+		return nil, nil
+	}
+	open = openTickets(all.Tickets)
+	return ticket.NewDependencyResolver(store).FindCycles(open), open
+}
+
+// printCycles prints cycles among tickets, each with the edge to break, and the deps
+// fix-cycles hint.
+func printCycles(w io.Writer, resolver *ticket.DependencyResolver, tickets []*ticket.Ticket, cycles [][]string) {
+	for _, cycle := range cycles {
+		printCycle(w, resolver, tickets, cycle)
+	}
+	ui.PrintInfo(w, i18n.HintFixCycles)
+}
+
+// formatCycle renders cycle as "A → B → C → A".
+func formatCycle(cycle []string) string {
+	return strings.Join(append(append([]string{}, cycle...), cycle[0]), " → ")
+}
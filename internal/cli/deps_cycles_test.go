@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestRunDepsFixCycles(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy string
+		dryRun   bool
+		wantDeps []string // B's dependencies afterwards
+		wantSoft []string
+	}{
+		{"soft", cycleFixSoft, false, nil, []string{"A"}},
+		{"drop", cycleFixDrop, false, nil, nil},
+		{"dry run", cycleFixSoft, true, []string{"A"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticketsDir := filepath.Join(t.TempDir(), ".tickets")
+			store := ticket.NewStore(ticketsDir)
+			if err := store.Init(); err != nil {
+				t.Fatal(err)
+			}
+			// A ⇄ B is a cycle; C depends on a completed ticket that depends on C, which
+			// blocks nothing.
+			a := ticket.NewTicket("A", "a", "")
+			a.Dependencies = []string{"B"}
+			b := ticket.NewTicket("B", "b", "")
+			b.Dependencies = []string{"A"}
+			c := ticket.NewTicket("C", "c", "")
+			c.Dependencies = []string{"D"}
+			d := ticket.NewTicket("D", "d", "")
+			d.Dependencies = []string{"C"}
+			d.Status = ticket.StatusCompleted
+			for _, tk := range []*ticket.Ticket{a, b, c, d} {
+				if err := store.Save(tk); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			originalCfg := cfg
+			defer func() { cfg = originalCfg }()
+			cfg = &config.Config{TicketsDir: ticketsDir, DryRun: tt.dryRun}
+			defer func() { fixCyclesStrategy = "" }()
+			fixCyclesStrategy = tt.strategy
+
+			output := captureOutput(func() {
+				if err := runDepsFixCycles(nil, nil); err != nil {
+					t.Errorf("runDepsFixCycles() error = %v", err)
+				}
+			})
+			if !strings.Contains(output, fmt.Sprintf(i18n.MsgCyclePath, "A → B → A")) {
+				t.Errorf("output should name the cycle, got:\n%s", output)
+			}
+			if strings.Contains(output, "C → D") {
+				t.Errorf("a cycle through a completed ticket should be ignored, got:\n%s", output)
+			}
+			got, _ := store.Load("B")
+			// Compare printed forms: a nil and an empty list both mean no dependencies.
+			if fmt.Sprint(got.Dependencies) != fmt.Sprint(tt.wantDeps) {
+				t.Errorf("B.Dependencies = %v, want %v", got.Dependencies, tt.wantDeps)
+			}
+			if fmt.Sprint(got.SoftDependencies) != fmt.Sprint(tt.wantSoft) {
+				t.Errorf("B.SoftDependencies = %v, want %v", got.SoftDependencies, tt.wantSoft)
+			}
+		})
+	}
+}
+
+func TestRunDepsFixCycles_UnknownStrategy(t *testing.T) {
+	defer func() { fixCyclesStrategy = "" }()
+	fixCyclesStrategy = "reverse"
+	if err := runDepsFixCycles(nil, nil); err == nil {
+		t.Error("runDepsFixCycles() should reject an unknown strategy")
+	}
+}
+
+func TestRepairCycles_Interactive(t *testing.T) {
+	var tickets []*ticket.Ticket
+	for _, edge := range [][2]string{{"A", "B"}, {"B", "A"}, {"C", "D"}, {"D", "C"}} {
+		tk := ticket.NewTicket(edge[0], edge[0], "")
+		tk.Dependencies = []string{edge[1]}
+		tickets = append(tickets, tk)
+	}
+	resolver := ticket.NewDependencyResolver(nil)
+
+	// Skip the first cycle, drop the edge of the second.
+	var w bytes.Buffer
+	fixed, changed, err := repairCycles(&w, resolver, tickets, func(cycle []string, edge ticket.Edge) (string, error) {
+		return askCycleFix(&w, strings.NewReader(map[string]string{"A": "3\n", "C": "2\n"}[cycle[0]]), edge)
+	})
+	if err != nil {
+		t.Fatalf("repairCycles() error = %v", err)
+	}
+	// The edge closing each cycle is suggested, as all edges are equally strong.
+	if fixed != 1 || len(changed) != 1 || changed[0].ID != "D" {
+		t.Fatalf("fixed = %d, changed = %v", fixed, changed)
+	}
+	if len(tickets[3].Dependencies) != 0 {
+		t.Errorf("D.Dependencies = %v, want none", tickets[3].Dependencies)
+	}
+	if cycles := resolver.FindCycles(tickets); len(cycles) != 1 {
+		t.Errorf("the skipped cycle should remain, got %v", cycles)
+	}
+}
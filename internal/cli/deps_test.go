@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/deps"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestRunDepsScan_CreatesAndDeduplicates(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/app\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{ProjectRoot: tmpDir, TicketsDir: ticketsDir}
+
+	latest := "v0.20.0"
+	originalRunner := depsRunner
+	defer func() { depsRunner = originalRunner }()
+	depsRunner = func(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+		if name != "go" {
+			return nil, fmt.Errorf("unexpected scanner %s", name)
+		}
+		return []byte(`{"Path": "golang.org/x/net", "Version": "v0.17.0", "Update": {"Version": "` + latest + `"}}`), nil
+	}
+
+	captureOutput(func() {
+		if err := runDepsScan(nil, nil); err != nil {
+			t.Fatalf("runDepsScan() err = %v", err)
+		}
+	})
+	store := ticket.NewStore(ticketsDir)
+	id := "DEPS-GO-GOLANG.ORG-X-NET@v0.20.0"
+	got, err := store.Load(id)
+	if err != nil {
+		t.Fatalf("%s not created: %v", id, err)
+	}
+	if got.Type != ticket.TypeRefactor || !strings.Contains(got.Description, "go get golang.org/x/net@v0.20.0") {
+		t.Errorf("ticket = %s: %s", got.Type, got.Description)
+	}
+
+	// A newer release while the upgrade is still pending does not add a second ticket.
+	latest = "v0.21.0"
+	output := captureOutput(func() {
+		if err := runDepsScan(nil, nil); err != nil {
+			t.Fatalf("runDepsScan() second run err = %v", err)
+		}
+	})
+	if !strings.Contains(output, fmt.Sprintf(i18n.MsgDepsSummary, 0, 1)) {
+		t.Errorf("second run should skip the tracked dependency, got:\n%s", output)
+	}
+
+	// Once the upgrade is completed, a newer release gets its own ticket.
+	if err := store.MoveToStatus(id, ticket.StatusCompleted); err != nil {
+		t.Fatal(err)
+	}
+	captureOutput(func() {
+		if err := runDepsScan(nil, nil); err != nil {
+			t.Fatalf("runDepsScan() third run err = %v", err)
+		}
+	})
+	if _, err := store.Load("DEPS-GO-GOLANG.ORG-X-NET@v0.21.0"); err != nil {
+		t.Errorf("newer release after completion should create a ticket: %v", err)
+	}
+}
+
+func TestRunDepsScan_NoEcosystem(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{ProjectRoot: tmpDir, TicketsDir: filepath.Join(tmpDir, ".tickets")}
+
+	output := captureOutput(func() {
+		if err := runDepsScan(nil, nil); err != nil {
+			t.Fatalf("runDepsScan() err = %v", err)
+		}
+	})
+	if !strings.Contains(output, i18n.MsgDepsNoEcosystem) {
+		t.Errorf("output should report no supported ecosystem, got:\n%s", output)
+	}
+}
+
+func TestSelectDepsScanners(t *testing.T) {
+	got, err := selectDepsScanners([]string{"NPM"})
+	if err != nil || len(got) != 1 || got[0].Ecosystem != deps.EcosystemNpm {
+		t.Errorf("selectDepsScanners(NPM) = %v, %v", got, err)
+	}
+	if got, _ := selectDepsScanners(nil); len(got) != len(deps.Scanners()) {
+		t.Errorf("selectDepsScanners(nil) = %d scanners, want all", len(got))
+	}
+	if _, err := selectDepsScanners([]string{"cargo"}); err == nil {
+		t.Error("selectDepsScanners(cargo) expected error")
+	}
+}
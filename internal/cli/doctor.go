@@ -0,0 +1,325 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/runstate"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var doctorFix bool
+
+// doctorConfigErr is why the config could not be loaded or validated. doctor runs
+// anyway (on the defaults when loading failed) so it can report it with the rest.
+var doctorConfigErr error
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: i18n.CmdDoctorShort,
+	Long:  i18n.CmdDoctorLong,
+	Args:  cobra.NoArgs,
+	RunE:  runDoctor,
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, i18n.FlagDoctorFix)
+}
+
+// doctorStatus is the outcome of one check.
+type doctorStatus int
+
+const (
+	doctorOK doctorStatus = iota
+	doctorWarn
+	doctorFail
+)
+
+// doctorCheck is one diagnosis: what was checked, what was found and how to fix it.
+type doctorCheck struct {
+	name   string
+	status doctorStatus
+	detail string
+	// hint tells the user how to fix a problem.
+	hint string
+	// fix repairs the problem automatically with doctor --fix; nil when it needs the user.
+	fix func() error
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	w := os.Stdout
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	ui.PrintHeader(w, i18n.UIDoctor)
+	checks := doctorChecks(ctx)
+	problems := printDoctorChecks(w, checks)
+
+	fixable := 0
+	for _, c := range checks {
+		if c.status == doctorOK || c.fix == nil {
+			continue
+		}
+		if !doctorFix {
+			fixable++
+			continue
+		}
+		if err := c.fix(); err != nil {
+			ui.PrintError(w, fmt.Sprintf(i18n.ErrDoctorFix, c.name, err))
+			continue
+		}
+		ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgDoctorFixed, c.name))
+		if c.status == doctorFail {
+			problems--
+		}
+	}
+	if fixable > 0 {
+		ui.PrintInfo(w, "")
+		ui.PrintInfo(w, fmt.Sprintf(i18n.HintDoctorFix, fixable))
+	}
+
+	ui.PrintInfo(w, "")
+	if problems == 0 {
+		ui.PrintSuccess(w, i18n.MsgDoctorHealthy)
+		return nil
+	}
+	cmd.SilenceUsage = true
+	return fmt.Errorf(i18n.ErrDoctorProblems, problems)
+}
+
+// printDoctorChecks lists checks with the fix of each problem and returns how many
+// checks failed. Warnings are shown but not counted.
+func printDoctorChecks(w io.Writer, checks []doctorCheck) (failed int) {
+	for _, c := range checks {
+		line := c.name
+		if c.detail != "" {
+			line += ": " + c.detail
+		}
+		switch c.status {
+		case doctorOK:
+			ui.PrintSuccess(w, line)
+			continue
+		case doctorWarn:
+			ui.PrintWarning(w, line)
+		case doctorFail:
+			ui.PrintError(w, line)
+			failed++
+		}
+		if c.hint != "" {
+			ui.PrintInfo(w, ui.StyleMuted.Render("  → "+c.hint))
+		}
+	}
+	return failed
+}
+
+// doctorChecks runs every check in the order a new user runs into the problems.
+func doctorChecks(ctx context.Context) []doctorCheck {
+	checks := []doctorCheck{checkDoctorConfig(), checkDoctorAgent(ctx), checkDoctorGit(ctx)}
+	checks = append(checks, checkDoctorDir(i18n.DoctorTicketsDir, cfg.TicketsDir))
+	checks = append(checks, checkDoctorDir(i18n.DoctorLogsDir, cfg.LogsDir))
+	if cfg.WorkDetachLogDir != "" {
+		checks = append(checks, checkDoctorDir(i18n.DoctorDetachLogDir, cfg.WorkDetachLogDir))
+	}
+	checks = append(checks, checkDoctorPIDFile(), checkDoctorInProgress(), checkDoctorOrphanAgents(), checkDoctorCorruptTickets())
+	return checks
+}
+
+func checkDoctorConfig() doctorCheck {
+	c := doctorCheck{name: i18n.DoctorConfig}
+	path := config.GetConfigFilePath()
+	if _, err := os.Stat(path); err != nil {
+		c.status, c.detail, c.hint = doctorWarn, i18n.MsgDoctorNoConfig, i18n.HintDoctorInitConfig
+	} else {
+		c.detail = path
+	}
+	if doctorConfigErr != nil {
+		c.status, c.detail, c.hint = doctorFail, doctorConfigErr.Error(), fmt.Sprintf(i18n.HintDoctorFixConfig, path)
+	}
+	return c
+}
+
+func checkDoctorAgent(ctx context.Context) doctorCheck {
+	c := doctorCheck{name: i18n.DoctorAgent}
+	backend, err := agent.LookupBackend(cfg.AgentBackend, cfg.AgentCommand)
+	if err != nil {
+		c.status, c.detail, c.hint = doctorFail, err.Error(), i18n.HintDoctorAgentBackend
+		return c
+	}
+	if backend.Name() == agent.BackendAPI {
+		if anthropicAPIKey() == "" {
+			c.status, c.detail, c.hint = doctorFail, i18n.MsgDoctorNoAPIKey, i18n.HintDoctorAPIKey
+			return c
+		}
+		c.detail = fmt.Sprintf(i18n.MsgDoctorAPIBackend, cfg.AnthropicModel)
+		return c
+	}
+
+	command := agentCommand(backend)
+	path, err := exec.LookPath(command)
+	if err != nil {
+		c.status, c.detail, c.hint = doctorFail, fmt.Sprintf(i18n.MsgDoctorAgentMissing, command), fmt.Sprintf(i18n.HintDoctorAgentInstall, backend.Name())
+		return c
+	}
+	c.detail = path
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, "--version").CombinedOutput()
+	if version := firstLine(string(out)); err == nil && version != "" {
+		c.detail += " (" + version + ")"
+	} else {
+		c.status, c.hint = doctorWarn, fmt.Sprintf(i18n.HintDoctorAgentVersion, command)
+	}
+	return c
+}
+
+func checkDoctorGit(ctx context.Context) doctorCheck {
+	c := doctorCheck{name: i18n.DoctorGit}
+	if _, err := exec.LookPath("git"); err != nil {
+		c.status, c.detail, c.hint = doctorFail, i18n.MsgDoctorGitMissing, i18n.HintDoctorGitInstall
+		return c
+	}
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--show-toplevel")
+	cmd.Dir = cfg.ProjectRoot
+	out, err := cmd.Output()
+	if err != nil {
+		c.status, c.detail, c.hint = doctorWarn, fmt.Sprintf(i18n.MsgDoctorNotARepo, cfg.ProjectRoot), i18n.HintDoctorGitInit
+		return c
+	}
+	c.detail = strings.TrimSpace(string(out))
+	return c
+}
+
+// checkDoctorDir checks that dir exists and is writable; a missing dir is created by
+// --fix (commands create it on demand too).
+func checkDoctorDir(name, dir string) doctorCheck {
+	c := doctorCheck{name: name, detail: dir}
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		c.status, c.hint = doctorWarn, i18n.HintDoctorCreateDir
+		c.fix = func() error { return os.MkdirAll(dir, 0755) }
+		return c
+	}
+	if err == nil && !info.IsDir() {
+		err = fmt.Errorf(i18n.MsgDoctorNotADir, dir)
+	}
+	if err == nil {
+		var f *os.File
+		if f, err = os.CreateTemp(dir, ".doctor-*"); err == nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}
+	if err != nil {
+		c.status, c.detail, c.hint = doctorFail, err.Error(), fmt.Sprintf(i18n.HintDoctorDirPermissions, dir)
+	}
+	return c
+}
+
+func checkDoctorPIDFile() doctorCheck {
+	c := doctorCheck{name: i18n.DoctorPIDFile}
+	path := cfg.WorkPIDFilePath()
+	pid, err := ReadWorkPIDFile(path)
+	switch {
+	case os.IsNotExist(err):
+		c.detail = i18n.MsgDoctorNoBackgroundWork
+	case err != nil:
+		c.status, c.detail, c.hint = doctorWarn, fmt.Sprintf("%s: %v", path, err), i18n.HintDoctorStalePID
+		c.fix = func() error { return os.Remove(path) }
+	case IsProcessAlive(pid):
+		c.detail = fmt.Sprintf(i18n.MsgDoctorBackgroundWork, pid)
+	default:
+		c.status, c.detail, c.hint = doctorWarn, fmt.Sprintf(i18n.MsgDoctorStalePID, path, pid), i18n.HintDoctorStalePID
+		c.fix = func() error { return os.Remove(path) }
+	}
+	return c
+}
+
+// checkDoctorInProgress reports in_progress tickets that no running orchestrator is
+// working on (see orphanedTickets).
+func checkDoctorInProgress() doctorCheck {
+	c := doctorCheck{name: i18n.DoctorInProgress}
+	store := newTicketStore()
+	tickets, err := store.LoadByStatus(ticket.StatusInProgress)
+	if err != nil || len(tickets) == 0 {
+		c.detail = i18n.MsgDoctorNoInProgress
+		return c
+	}
+	orphaned := orphanedTickets(tickets)
+	if len(orphaned) == 0 {
+		c.detail = fmt.Sprintf(i18n.MsgDoctorInProgressActive, len(tickets))
+		return c
+	}
+	ids := make([]string, len(orphaned))
+	for i, t := range orphaned {
+		ids[i] = t.ID
+	}
+	c.status, c.detail, c.hint = doctorWarn, fmt.Sprintf(i18n.MsgDoctorOrphanedInProgress, strings.Join(ids, ", ")), i18n.HintDoctorRequeue
+	c.fix = func() error {
+		_, err := reclaimOrphanedTickets(io.Discard, store)
+		return err
+	}
+	return c
+}
+
+// checkDoctorCorruptTickets reports ticket files that cannot be parsed, which the store
+// otherwise skips when listing tickets.
+func checkDoctorCorruptTickets() doctorCheck {
+	c := doctorCheck{name: i18n.DoctorCorruptTickets}
+	files := corruptTicketFiles(newTicketStore())
+	if len(files) == 0 {
+		c.detail = i18n.MsgDoctorNoCorruptTickets
+		return c
+	}
+	c.status, c.detail, c.hint = doctorWarn, formatCorruptFiles(files), i18n.HintCorruptTickets
+	return c
+}
+
+// orchestratorRunning reports whether another orchestrator process is running an agent.
+func orchestratorRunning() bool {
+	entries, _ := runstate.New(cfg.AgentRunStatePath()).List()
+	for _, e := range entries {
+		if e.OwnerPID != os.Getpid() && IsProcessAlive(e.OwnerPID) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkDoctorOrphanAgents() doctorCheck {
+	c := doctorCheck{name: i18n.DoctorOrphanAgents}
+	orphans, err := runstate.New(cfg.AgentRunStatePath()).Orphans(IsProcessAlive, processStartTime)
+	if err != nil || len(orphans) == 0 {
+		c.detail = i18n.MsgDoctorNoOrphanAgents
+		return c
+	}
+	pids := make([]string, len(orphans))
+	for i, e := range orphans {
+		pids[i] = fmt.Sprint(e.PID)
+	}
+	c.status, c.detail, c.hint = doctorWarn, fmt.Sprintf(i18n.MsgOrphanAgentsFound, len(orphans))+" (PID "+strings.Join(pids, ", ")+")", i18n.HintWorkReap
+	c.fix = func() error {
+		_, err := reapOrphanAgents(io.Discard, orphans, func(runstate.Entry) (bool, error) { return true, nil })
+		return err
+	}
+	return c
+}
+
+// firstLine returns the first non-empty line of s, trimmed.
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
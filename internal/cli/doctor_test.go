@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestDoctorCommand(t *testing.T) {
+	originalCfg, originalFix, originalErr := cfg, doctorFix, doctorConfigErr
+	defer func() { cfg, doctorFix, doctorConfigErr = originalCfg, originalFix, originalErr }()
+
+	setup := func(t *testing.T) (*config.Config, ticket.Storer) {
+		t.Helper()
+		dir := t.TempDir()
+		t.Chdir(dir)
+		cfg = &config.Config{
+			ProjectRoot:  dir,
+			TicketsDir:   filepath.Join(dir, ".tickets"),
+			LogsDir:      filepath.Join(dir, ".agent-logs"),
+			StoreBackend: "file",
+			AgentBackend: "cursor",
+			AgentCommand: "sh",
+		}
+		store := newTicketStore()
+		if err := store.Init(); err != nil {
+			t.Fatal(err)
+		}
+		return cfg, store
+	}
+
+	t.Run("reports and fixes a stale PID file and orphaned tickets", func(t *testing.T) {
+		c, store := setup(t)
+		doctorConfigErr = nil
+		// A PID that cannot be alive.
+		if err := os.WriteFile(c.WorkPIDFilePath(), []byte("999999999\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		tk := ticket.NewTicket("TICKET-001-stuck", "Stuck", "")
+		tk.Status = ticket.StatusInProgress
+		if err := store.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+
+		doctorFix = false
+		out := captureOutput(func() { _ = runDoctor(doctorCmd, nil) })
+		for _, want := range []string{"999999999", "TICKET-001-stuck", "doctor --fix"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("output missing %q:\n%s", want, out)
+			}
+		}
+		if _, err := os.Stat(c.WorkPIDFilePath()); err != nil {
+			t.Errorf("without --fix the PID file should be kept: %v", err)
+		}
+
+		doctorFix = true
+		out = captureOutput(func() { _ = runDoctor(doctorCmd, nil) })
+		if _, err := os.Stat(c.WorkPIDFilePath()); !os.IsNotExist(err) {
+			t.Errorf("--fix should remove the stale PID file:\n%s", out)
+		}
+		if _, err := os.Stat(c.LogsDir); err != nil {
+			t.Errorf("--fix should create the logs dir: %v", err)
+		}
+		got, err := store.Load(tk.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != ticket.StatusPending {
+			t.Errorf("status = %s, want %s", got.Status, ticket.StatusPending)
+		}
+	})
+
+	t.Run("fails on an invalid config and a missing agent", func(t *testing.T) {
+		c, _ := setup(t)
+		c.AgentCommand = "agent-orchestrator-no-such-agent"
+		doctorConfigErr = errors.New("invalid max_parallel")
+		doctorFix = false
+
+		var err error
+		out := captureOutput(func() { err = runDoctor(doctorCmd, nil) })
+		if err == nil || !strings.Contains(err.Error(), "2") {
+			t.Errorf("runDoctor() error = %v, want 2 problems", err)
+		}
+		for _, want := range []string{"invalid max_parallel", "agent-orchestrator-no-such-agent"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("output missing %q:\n%s", want, out)
+			}
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+	"github.com/anthropic/agent-orchestrator/internal/dod"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+)
+
+// workLenient downgrades unmet definition_of_done requirements to warnings.
+var workLenient bool
+
+// dodRequirements returns the definition_of_done requirements for t's type.
+func dodRequirements(t *ticket.Ticket) []string {
+	if cfg == nil || cfg.DryRun {
+		return nil
+	}
+	return cfg.DefinitionOfDone[string(t.Type)]
+}
+
+// dodBaseline returns the files already changed in dir before t's agent runs, so
+// they are not credited to the ticket; nil when t's type has no requirements.
+func dodBaseline(ctx context.Context, t *ticket.Ticket, dir string) map[string]bool {
+	if len(dodRequirements(t)) == 0 {
+		return nil
+	}
+	baseline := make(map[string]bool)
+	for _, f := range gitChangedFilesIn(ctx, dir) {
+		baseline[f] = true
+	}
+	return baseline
+}
+
+// dodChangedFiles returns the files t changed in dir: working-tree changes not in
+// baseline, plus the files the agent reported writing (which also catches files that
+// were already dirty).
+func dodChangedFiles(ctx context.Context, dir string, baseline map[string]bool, result *agent.Result) []string {
+	seen := make(map[string]bool)
+	var files []string
+	add := func(f string) {
+		if !seen[f] {
+			seen[f] = true
+			files = append(files, f)
+		}
+	}
+	for _, f := range gitChangedFilesIn(ctx, dir) {
+		if !baseline[f] {
+			add(f)
+		}
+	}
+	if result != nil {
+		for _, f := range result.WrittenFiles() {
+			if filepath.IsAbs(f) {
+				if rel, err := filepath.Rel(dir, f); err == nil {
+					f = rel
+				}
+			}
+			add(filepath.ToSlash(f))
+		}
+	}
+	return files
+}
+
+// checkDefinitionOfDone checks t against the definition_of_done of its type before it
+// is marked completed. Unmet requirements fail the ticket, or when lenient (--lenient)
+// are printed to w as a warning.
+func checkDefinitionOfDone(ctx context.Context, w io.Writer, t *ticket.Ticket, dir string, baseline map[string]bool, result *agent.Result, lenient bool) error {
+	required := dodRequirements(t)
+	if len(required) == 0 {
+		return nil
+	}
+	unmet := dod.Check(required, dod.Evidence{
+		ChangedFiles:     dodChangedFiles(ctx, dir, baseline, result),
+		AssertionResults: t.AssertionResults,
+	})
+	if len(unmet) == 0 {
+		return nil
+	}
+	msg := fmt.Sprintf(i18n.ErrDoDUnmet, t.ID, t.Type, strings.Join(unmet, ", "))
+	if lenient {
+		ui.PrintWarning(w, msg)
+		return nil
+	}
+	return fmt.Errorf("%s", msg)
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestCheckDefinitionOfDone(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	if err := exec.Command("git", "-C", dir, "init", "-q").Run(); err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+	write := func(name string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{ProjectRoot: dir, DefinitionOfDone: map[string][]string{
+		"feature": {"tests", "docs"},
+		"bugfix":  {"tests"},
+	}}
+
+	// A test file that was already dirty before the agent ran does not count.
+	write("old_test.go")
+	feature := ticket.NewTicket("T-1", "Feature", "")
+	feature.Type = ticket.TypeFeature
+	baseline := dodBaseline(ctx, feature, dir)
+	write("pkg/feature.go")
+	write("docs/feature.md")
+
+	err := checkDefinitionOfDone(ctx, &strings.Builder{}, feature, dir, baseline, &agent.Result{}, false)
+	if err == nil || !strings.Contains(err.Error(), "tests") || strings.Contains(err.Error(), "docs") {
+		t.Fatalf("checkDefinitionOfDone() = %v, want only tests unmet", err)
+	}
+
+	// Files the agent reports writing count even when they were already dirty.
+	written := &agent.Result{StreamEvents: []agent.StreamEvent{{Type: "tool_call", Subtype: "started", Data: map[string]interface{}{
+		"tool_call": map[string]interface{}{"writeToolCall": map[string]interface{}{"args": map[string]interface{}{"path": filepath.Join(dir, "old_test.go")}}},
+	}}}}
+	if err := checkDefinitionOfDone(ctx, &strings.Builder{}, feature, dir, baseline, written, false); err != nil {
+		t.Errorf("checkDefinitionOfDone() with written test file = %v, want nil", err)
+	}
+
+	var out strings.Builder
+	if err := checkDefinitionOfDone(ctx, &out, feature, dir, baseline, &agent.Result{}, true); err != nil {
+		t.Errorf("checkDefinitionOfDone() lenient = %v, want nil", err)
+	}
+	if !strings.Contains(out.String(), "T-1") {
+		t.Errorf("lenient check should warn, got %q", out.String())
+	}
+
+	docs := ticket.NewTicket("T-2", "Docs", "")
+	docs.Type = ticket.TypeDocs
+	if dodBaseline(ctx, docs, dir) != nil {
+		t.Error("dodBaseline() for a type without requirements should be nil")
+	}
+	if err := checkDefinitionOfDone(ctx, &strings.Builder{}, docs, dir, nil, &agent.Result{}, false); err != nil {
+		t.Errorf("checkDefinitionOfDone() without requirements = %v", err)
+	}
+}
@@ -1,8 +1,10 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/anthropic/agent-orchestrator/internal/i18n"
 	"github.com/anthropic/agent-orchestrator/internal/ticket"
@@ -11,33 +13,56 @@ import (
 )
 
 var (
-	dropForce bool
+	dropForce  bool
+	dropLabels []string
+	dropFilter string
 )
 
 var dropCmd = &cobra.Command{
 	Use:   "drop <ticket-id>",
 	Short: i18n.CmdDropShort,
 	Long:  i18n.CmdDropLong,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runDrop,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(dropLabels) > 0 || dropFilter != "" {
+			if len(args) > 0 || (len(dropLabels) > 0 && dropFilter != "") {
+				return errors.New(i18n.ErrDropIDAndLabel)
+			}
+			return nil
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	RunE: runDrop,
 }
 
 func init() {
 	dropCmd.Flags().BoolVar(&dropForce, "force", false, i18n.FlagForce)
+	dropCmd.Flags().StringSliceVar(&dropLabels, "label", nil, i18n.FlagDropLabel)
+	dropCmd.Flags().StringVar(&dropFilter, "filter", "", i18n.FlagDropFilter)
 }
 
 func runDrop(cmd *cobra.Command, args []string) error {
 	w := os.Stdout
-	ticketID := args[0]
 
+	filter, err := ticket.ParseFilter(dropFilter)
+	if err != nil {
+		return err
+	}
 	ui.PrintHeader(w, i18n.UIDropTicket)
 
 	// Initialize store
-	store := ticket.NewStore(cfg.TicketsDir)
+	store := newTicketStore()
 	if err := store.Init(); err != nil {
 		return fmt.Errorf(i18n.ErrInitStoreFailed, err)
 	}
 
+	if len(dropLabels) > 0 {
+		return dropByLabels(w, store, dropLabels)
+	}
+	if filter != nil {
+		return dropByFilter(w, store, filter)
+	}
+	ticketID := args[0]
+
 	// Load existing ticket to show info
 	t, err := store.Load(ticketID)
 	if err != nil {
@@ -45,11 +70,11 @@ func runDrop(cmd *cobra.Command, args []string) error {
 	}
 
 	// Show ticket info
-	ui.PrintInfo(w, "即將刪除的 Ticket:")
-	ui.PrintInfo(w, fmt.Sprintf("  ID: %s", t.ID))
-	ui.PrintInfo(w, fmt.Sprintf("  標題: %s", t.Title))
-	ui.PrintInfo(w, fmt.Sprintf("  類型: %s", t.Type))
-	ui.PrintInfo(w, fmt.Sprintf("  狀態: %s", t.Status))
+	ui.PrintInfo(w, i18n.MsgDropTicketPreview)
+	ui.PrintInfo(w, "  "+fmt.Sprintf(i18n.MsgTicketInfo, t.ID))
+	ui.PrintInfo(w, "  "+fmt.Sprintf(i18n.MsgTicketTitle, t.Title))
+	ui.PrintInfo(w, "  "+fmt.Sprintf(i18n.MsgTicketType, t.Type))
+	ui.PrintInfo(w, "  "+fmt.Sprintf(i18n.MsgTicketStatus, t.Status))
 	ui.PrintInfo(w, "")
 
 	// Confirm deletion unless force flag is set
@@ -74,3 +99,62 @@ func runDrop(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// dropByLabels deletes every ticket carrying all of labels, after one confirmation
+// listing them (skipped with --force).
+func dropByLabels(w *os.File, store ticket.Storer, labels []string) error {
+	all, err := store.LoadAll()
+	if err != nil {
+		return err
+	}
+	matched := ticket.FilterByLabels(all.Tickets, labels)
+	if len(matched) == 0 {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgNoTicketsWithLabels, strings.Join(labels, ", ")))
+		return nil
+	}
+	return dropTickets(w, store, matched, fmt.Sprintf(i18n.PromptConfirmDropLabel, len(matched), strings.Join(labels, ", ")))
+}
+
+// dropByFilter deletes every ticket matching filter, confirmed as dropByLabels.
+func dropByFilter(w *os.File, store ticket.Storer, filter *ticket.Filter) error {
+	all, err := store.LoadAll()
+	if err != nil {
+		return err
+	}
+	matched := ticket.FilterByExpr(all.Tickets, filter)
+	if len(matched) == 0 {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgNoTicketsMatchFilter, filter))
+		return nil
+	}
+	return dropTickets(w, store, matched, fmt.Sprintf(i18n.PromptConfirmDropFilter, len(matched), filter))
+}
+
+// dropTickets lists matched and deletes them after confirming prompt (skipped with
+// --force).
+func dropTickets(w *os.File, store ticket.Storer, matched []*ticket.Ticket, prompt string) error {
+
+	ui.PrintInfo(w, i18n.MsgDropTicketsPreview)
+	for _, t := range matched {
+		ui.PrintInfo(w, fmt.Sprintf("  %s: %s (%s)", t.ID, t.Title, t.Status))
+	}
+	ui.PrintInfo(w, "")
+
+	if !dropForce {
+		confirmed, err := ui.NewPrompt(os.Stdin, w).Confirm(prompt, false)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			ui.PrintInfo(w, i18n.MsgCancelled)
+			return nil
+		}
+	}
+
+	for _, t := range matched {
+		if err := store.Delete(t.ID); err != nil {
+			return fmt.Errorf("%s: %w", i18n.ErrDeleteTicketFailed, err)
+		}
+	}
+	ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgTicketsDroppedByLabel, len(matched)))
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/anthropic/agent-orchestrator/internal/config"
 	"github.com/anthropic/agent-orchestrator/internal/ticket"
@@ -35,3 +36,88 @@ func TestRunDrop_TicketNotFound_ReturnsError(t *testing.T) {
 		t.Error("runDrop with nonexistent ticket ID should return non-nil error for non-zero exit code")
 	}
 }
+
+func TestRunDrop_ByLabel(t *testing.T) {
+	ticketsDir := filepath.Join(t.TempDir(), ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+	for _, tk := range []*ticket.Ticket{
+		{ID: "T-1", Title: "a", Status: ticket.StatusPending, Labels: []string{"stale"}},
+		{ID: "T-2", Title: "b", Status: ticket.StatusFailed, Labels: []string{"stale", "backend"}},
+		{ID: "T-3", Title: "c", Status: ticket.StatusPending, Labels: []string{"backend"}},
+	} {
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("Failed to save ticket: %v", err)
+		}
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: ticketsDir}
+	defer func() { dropLabels, dropForce = nil, false }()
+	dropLabels, dropForce = []string{"Stale"}, true
+
+	if err := dropCmd.Args(dropCmd, []string{"T-1"}); err == nil {
+		t.Error("drop should reject a ticket ID together with --label")
+	}
+	captureOutput(func() {
+		if err := runDrop(&cobra.Command{}, nil); err != nil {
+			t.Fatalf("runDrop(--label) error = %v", err)
+		}
+	})
+
+	all, err := store.LoadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all.Tickets) != 1 || all.Tickets[0].ID != "T-3" {
+		t.Errorf("remaining tickets = %v, want only T-3", all.Tickets)
+	}
+}
+
+func TestRunDrop_ByFilter(t *testing.T) {
+	ticketsDir := filepath.Join(t.TempDir(), ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+	longAgo := time.Now().AddDate(0, -1, 0)
+	for _, tk := range []*ticket.Ticket{
+		{ID: "T-1", Title: "a", Status: ticket.StatusFailed, CreatedAt: time.Now()},
+		{ID: "T-2", Title: "b", Status: ticket.StatusFailed, CreatedAt: longAgo},
+		{ID: "T-3", Title: "c", Status: ticket.StatusPending, CreatedAt: time.Now()},
+	} {
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("Failed to save ticket: %v", err)
+		}
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: ticketsDir}
+	defer func() { dropFilter, dropLabels, dropForce = "", nil, false }()
+	dropFilter, dropForce = "status=failed and created<7d", true
+
+	if err := dropCmd.Args(dropCmd, []string{"T-1"}); err == nil {
+		t.Error("drop should reject a ticket ID together with --filter")
+	}
+	captureOutput(func() {
+		if err := runDrop(&cobra.Command{}, nil); err != nil {
+			t.Fatalf("runDrop(--filter) error = %v", err)
+		}
+	})
+	all, err := store.LoadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all.Tickets) != 2 || all.Tickets[0].ID == "T-1" || all.Tickets[1].ID == "T-1" {
+		t.Errorf("remaining tickets = %v, want T-2 and T-3", all.Tickets)
+	}
+
+	dropFilter = "status=done"
+	if err := runDrop(&cobra.Command{}, nil); err == nil {
+		t.Error("runDrop() with an invalid filter should fail")
+	}
+}
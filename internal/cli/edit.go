@@ -19,8 +19,12 @@ var (
 	editPriority    int
 	editDescription string
 	editDeps        string
+	editSoftDeps    string
 	editCriteria    string
 	editEnhance     bool
+	editLabels      []string
+	editParent      string
+	editPR          string
 )
 
 var editCmd = &cobra.Command{
@@ -37,8 +41,12 @@ func init() {
 	editCmd.Flags().IntVar(&editPriority, "priority", 0, i18n.FlagPriority)
 	editCmd.Flags().StringVar(&editDescription, "description", "", i18n.FlagDescription)
 	editCmd.Flags().StringVar(&editDeps, "deps", "", i18n.FlagDeps)
+	editCmd.Flags().StringVar(&editSoftDeps, "soft-deps", "", i18n.FlagEditSoftDeps)
 	editCmd.Flags().StringVar(&editCriteria, "criteria", "", i18n.FlagCriteria)
 	editCmd.Flags().BoolVar(&editEnhance, "enhance", false, i18n.FlagEnhance)
+	editCmd.Flags().StringSliceVar(&editLabels, "label", nil, i18n.FlagEditLabel)
+	editCmd.Flags().StringVar(&editParent, "parent", "", i18n.FlagEditParent)
+	editCmd.Flags().StringVar(&editPR, "pr", "", i18n.FlagEditPR)
 }
 
 func runEdit(cmd *cobra.Command, args []string) error {
@@ -49,7 +57,7 @@ func runEdit(cmd *cobra.Command, args []string) error {
 	ui.PrintHeader(w, i18n.UIEditTicket)
 
 	// Initialize store
-	store := ticket.NewStore(cfg.TicketsDir)
+	store := newTicketStore()
 	if err := store.Init(); err != nil {
 		return fmt.Errorf(i18n.ErrInitStoreFailed, err)
 	}
@@ -63,7 +71,22 @@ func runEdit(cmd *cobra.Command, args []string) error {
 
 	// Check if any flags provided for direct edit
 	hasFlags := editTitle != "" || editType != "" || editPriority != 0 ||
-		editDescription != "" || editDeps != "" || editCriteria != ""
+		editDescription != "" || editDeps != "" || editCriteria != "" || len(editLabels) > 0 ||
+		editParent != "" || editPR != "" || editSoftDeps != ""
+
+	if editParent != "" && editParent != "none" {
+		if _, err := store.Load(editParent); err != nil {
+			ui.PrintError(w, fmt.Sprintf(i18n.ErrTicketNotFound, editParent))
+			return nil
+		}
+	}
+
+	if editPR != "" && editPR != "none" {
+		if _, err := ticket.ParsePullRequestRef(editPR); err != nil {
+			ui.PrintError(w, err.Error())
+			return nil
+		}
+	}
 
 	if hasFlags {
 		// Direct edit mode
@@ -88,20 +111,20 @@ func runEdit(cmd *cobra.Command, args []string) error {
 
 			enhanced, err := enhancer.Enhance(ctx, t)
 			if err != nil {
-				spinner.Fail("AI 預處理失敗")
-				ui.PrintWarning(w, fmt.Sprintf("AI 預處理失敗: %s，保留原始內容", err.Error()))
+				spinner.Fail(i18n.SpinnerFailEnhance)
+				ui.PrintWarning(w, fmt.Sprintf(i18n.MsgEnhanceFailedKept, err.Error()))
 			} else {
 				spinner.Success(i18n.MsgEnhanceComplete)
 				t = enhanced
 			}
 		} else {
-			ui.PrintWarning(w, "無法使用 AI 預處理: agent 不可用")
+			ui.PrintWarning(w, i18n.MsgEnhanceUnavailable)
 		}
 	}
 
 	// Validate
 	if err := t.Validate(); err != nil {
-		ui.PrintError(w, fmt.Sprintf("Ticket 驗證失敗: %s", err.Error()))
+		ui.PrintError(w, fmt.Sprintf(i18n.ErrTicketInvalid, err.Error()))
 		return nil
 	}
 
@@ -140,6 +163,8 @@ func applyEditFlags(t *ticket.Ticket) {
 			t.Type = ticket.TypePerf
 		case "security":
 			t.Type = ticket.TypeSecurity
+		case "epic":
+			t.Type = ticket.TypeEpic
 		}
 	}
 
@@ -161,6 +186,36 @@ func applyEditFlags(t *ticket.Ticket) {
 		}
 	}
 
+	switch editSoftDeps {
+	case "":
+	case "none":
+		t.SoftDependencies = nil
+	default:
+		t.SoftDependencies = splitList(editSoftDeps)
+	}
+
+	if len(editLabels) > 0 {
+		t.Labels = ticket.NormalizeLabels(editLabels)
+	}
+
+	switch editParent {
+	case "":
+	case "none":
+		t.ParentID = ""
+	default:
+		t.ParentID = editParent
+	}
+
+	switch editPR {
+	case "":
+	case "none":
+		t.PullRequest = nil
+	default:
+		if ref, err := ticket.ParsePullRequestRef(editPR); err == nil {
+			t.PullRequest = ref
+		}
+	}
+
 	if editCriteria != "" {
 		t.AcceptanceCriteria = []string{}
 		for _, c := range strings.Split(editCriteria, ",") {
@@ -176,19 +231,20 @@ func editTicketInteractive(w *os.File, t *ticket.Ticket) (*ticket.Ticket, error)
 	prompt := ui.NewPrompt(os.Stdin, w)
 
 	// Show current ticket info
-	ui.PrintInfo(w, "目前 Ticket 資訊:")
+	ui.PrintInfo(w, i18n.MsgCurrentTicketInfo)
 	displayTicketDetails(w, t)
 	ui.PrintInfo(w, "")
 
 	// Select field to edit
 	editOptions := []string{
-		"標題",
-		"描述",
-		"類型",
-		"優先級",
-		"依賴",
-		"驗收條件",
-		"完成編輯",
+		i18n.EditFieldTitle,
+		i18n.EditFieldDescription,
+		i18n.EditFieldType,
+		i18n.EditFieldPriority,
+		i18n.EditFieldDeps,
+		i18n.EditFieldCriteria,
+		i18n.EditFieldLabels,
+		i18n.EditFieldDone,
 	}
 
 	for {
@@ -199,7 +255,7 @@ func editTicketInteractive(w *os.File, t *ticket.Ticket) (*ticket.Ticket, error)
 
 		switch idx {
 		case 0: // Title
-			newTitle, err := prompt.Ask(fmt.Sprintf("新標題 (目前: %s)", t.Title))
+			newTitle, err := prompt.Ask(fmt.Sprintf(i18n.PromptNewTitle, t.Title))
 			if err != nil {
 				return nil, err
 			}
@@ -208,8 +264,8 @@ func editTicketInteractive(w *os.File, t *ticket.Ticket) (*ticket.Ticket, error)
 			}
 
 		case 1: // Description
-			ui.PrintInfo(w, fmt.Sprintf("目前描述: %s", t.Description))
-			descLines, err := prompt.AskMultiline("新描述")
+			ui.PrintInfo(w, fmt.Sprintf(i18n.MsgCurrentDescription, t.Description))
+			descLines, err := prompt.AskMultiline(i18n.PromptNewDescription)
 			if err != nil {
 				return nil, err
 			}
@@ -219,15 +275,15 @@ func editTicketInteractive(w *os.File, t *ticket.Ticket) (*ticket.Ticket, error)
 
 		case 2: // Type
 			typeOptions := []string{
-				"feature - 新功能",
-				"bugfix - 錯誤修復",
-				"refactor - 重構",
-				"test - 測試",
-				"docs - 文件",
-				"performance - 效能優化",
-				"security - 安全性",
+				i18n.TicketTypeFeature,
+				i18n.TicketTypeBugfix,
+				i18n.TicketTypeRefactor,
+				i18n.TicketTypeTest,
+				i18n.TicketTypeDocs,
+				i18n.TicketTypePerformance,
+				i18n.TicketTypeSecurity,
 			}
-			typeIdx, err := prompt.Select(fmt.Sprintf("選擇類型 (目前: %s)", t.Type), typeOptions)
+			typeIdx, err := prompt.Select(fmt.Sprintf(i18n.PromptSelectType, t.Type), typeOptions)
 			if err != nil {
 				return nil, err
 			}
@@ -243,7 +299,7 @@ func editTicketInteractive(w *os.File, t *ticket.Ticket) (*ticket.Ticket, error)
 			t.Type = ticketTypes[typeIdx]
 
 		case 3: // Priority
-			priorityStr, err := prompt.Ask(fmt.Sprintf("新優先級 1-5 (目前: %d)", t.Priority))
+			priorityStr, err := prompt.Ask(fmt.Sprintf(i18n.PromptNewPriority, t.Priority))
 			if err != nil {
 				return nil, err
 			}
@@ -256,8 +312,8 @@ func editTicketInteractive(w *os.File, t *ticket.Ticket) (*ticket.Ticket, error)
 			}
 
 		case 4: // Dependencies
-			ui.PrintInfo(w, fmt.Sprintf("目前依賴: %s", strings.Join(t.Dependencies, ", ")))
-			depsStr, err := prompt.Ask("新依賴 (逗號分隔，留空清除)")
+			ui.PrintInfo(w, fmt.Sprintf(i18n.MsgCurrentDeps, strings.Join(t.Dependencies, ", ")))
+			depsStr, err := prompt.Ask(i18n.PromptNewDeps)
 			if err != nil {
 				return nil, err
 			}
@@ -273,12 +329,12 @@ func editTicketInteractive(w *os.File, t *ticket.Ticket) (*ticket.Ticket, error)
 
 		case 5: // Acceptance Criteria
 			if len(t.AcceptanceCriteria) > 0 {
-				ui.PrintInfo(w, "目前驗收條件:")
+				ui.PrintInfo(w, i18n.MsgCurrentCriteria)
 				for _, c := range t.AcceptanceCriteria {
 					fmt.Fprintf(w, "  - %s\n", c)
 				}
 			}
-			criteriaLines, err := prompt.AskMultiline("新驗收條件 (每行一條)")
+			criteriaLines, err := prompt.AskMultiline(i18n.PromptNewCriteria)
 			if err != nil {
 				return nil, err
 			}
@@ -286,7 +342,15 @@ func editTicketInteractive(w *os.File, t *ticket.Ticket) (*ticket.Ticket, error)
 				t.AcceptanceCriteria = criteriaLines
 			}
 
-		case 6: // Done
+		case 6: // Labels
+			ui.PrintInfo(w, fmt.Sprintf(i18n.MsgLabels, strings.Join(t.Labels, ", ")))
+			labelsStr, err := prompt.Ask(i18n.PromptNewLabels)
+			if err != nil {
+				return nil, err
+			}
+			t.Labels = ticket.NormalizeLabels(strings.Split(labelsStr, ","))
+
+		case 7: // Done
 			return t, nil
 		}
 	}
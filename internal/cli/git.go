@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
 )
 
 // parsePorcelainLinePath extracts the file path from a "git status --porcelain"
@@ -40,12 +42,26 @@ func getGitChangedFiles(ctx context.Context) []string {
 	cmd.Dir = cfg.ProjectRoot
 	output, err := cmd.Output()
 	if err != nil {
-		if ctx.Err() != nil {
-			return nil
-		}
 		return nil
 	}
+	return porcelainPaths(output)
+}
+
+// gitChangedFilesIn returns the files modified in the working tree at dir (e.g. a
+// ticket's worktree), listing the files inside untracked directories rather than the
+// directories. Returns nil if the git command fails.
+func gitChangedFilesIn(ctx context.Context, dir string) []string {
+	cmd := exec.CommandContext(ctx, "git", "status", "--porcelain", "--untracked-files=all")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	return porcelainPaths(output)
+}
 
+// porcelainPaths returns the distinct paths of "git status --porcelain" output.
+func porcelainPaths(output []byte) []string {
 	seen := make(map[string]struct{})
 	var files []string
 	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
@@ -62,6 +78,41 @@ func getGitChangedFiles(ctx context.Context) []string {
 	return files
 }
 
+// getGitChangedFilesSinceUpstream returns the working-tree changes from
+// getGitChangedFiles plus files changed by commits not yet on the upstream
+// branch (the commits a push would send). When the branch has no upstream,
+// only working-tree changes are returned. Used by analyze --changed.
+func getGitChangedFilesSinceUpstream(ctx context.Context) []string {
+	files := getGitChangedFiles(ctx)
+	if err := validateProjectRoot(cfg.ProjectRoot); err != nil {
+		return files
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "diff", "--name-only", "@{upstream}...HEAD")
+	cmd.Dir = cfg.ProjectRoot
+	output, err := cmd.Output()
+	if err != nil {
+		return files
+	}
+
+	seen := make(map[string]struct{}, len(files))
+	for _, f := range files {
+		seen[f] = struct{}{}
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		path := strings.TrimSpace(line)
+		if path == "" {
+			continue
+		}
+		if _, ok := seen[path]; ok {
+			continue
+		}
+		seen[path] = struct{}{}
+		files = append(files, path)
+	}
+	return files
+}
+
 // getGitStatusForFiles returns only the "git status --porcelain" lines whose
 // path is in files. Used by commit to restrict which changes are shown/staged
 // per ticket. Returns empty string if files is empty or no matching lines.
@@ -107,6 +158,21 @@ func getGitStatus(ctx context.Context) string {
 	return strings.TrimSpace(string(output))
 }
 
+// getGitHead returns the SHA of HEAD, or empty string if the project root is not a
+// valid git repository or has no commits. Used by commit to record ticket commits.
+func getGitHead(ctx context.Context) string {
+	if err := validateProjectRoot(cfg.ProjectRoot); err != nil {
+		return ""
+	}
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "HEAD")
+	cmd.Dir = cfg.ProjectRoot
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
 // validateProjectRoot checks that the project root is a safe and valid git
 // repository (no path traversal, no dangerous characters, absolute path, .git
 // present). Used by git helpers before running any git command.
@@ -143,3 +209,100 @@ func validateProjectRoot(projectRoot string) error {
 	}
 	return nil
 }
+
+// unsafeBranchChars matches characters replaced when deriving a branch name from a ticket ID.
+var unsafeBranchChars = regexp.MustCompile(`[^A-Za-z0-9._/-]+`)
+
+// maxBranchSlugLen caps the title part of a ticket branch name.
+const maxBranchSlugLen = 32
+
+// branchSlugChars matches the characters dropped from a ticket title in its branch name.
+var branchSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ticketBranchName returns the branch for a ticket when git_branch_per_ticket is on:
+// its ID followed by a short slug of its title, e.g. "ticket/TICKET-001-setup".
+func ticketBranchName(t *ticket.Ticket) string {
+	name := unsafeBranchChars.ReplaceAllString(t.ID, "-")
+	name = strings.Trim(strings.ReplaceAll(name, "..", "-"), "./-")
+	if slug := branchSlug(t.Title); slug != "" && !strings.HasSuffix(strings.ToLower(name), slug) {
+		name += "-" + slug
+	}
+	return "ticket/" + name
+}
+
+// branchSlug returns the lower-case ASCII words of title joined by "-", cut at a word
+// boundary to maxBranchSlugLen. Titles without ASCII letters or digits give "".
+func branchSlug(title string) string {
+	slug := strings.Trim(branchSlugChars.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if len(slug) <= maxBranchSlugLen {
+		return slug
+	}
+	slug = slug[:maxBranchSlugLen]
+	if i := strings.LastIndex(slug, "-"); i > 0 {
+		slug = slug[:i]
+	}
+	return strings.Trim(slug, "-")
+}
+
+// ticketBranchBase returns the commit ticket branches are created from: HEAD when a
+// run starts, so each ticket branch forks from the same commit rather than from the
+// previous ticket's branch. Empty (create from HEAD) when no ticket branches are created
+// or HEAD has no commit yet.
+func ticketBranchBase(ctx context.Context) string {
+	if cfg.DryRun || !cfg.GitBranchPerTicket || validateProjectRoot(cfg.ProjectRoot) != nil {
+		return ""
+	}
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", "HEAD")
+	cmd.Dir = cfg.ProjectRoot
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// checkoutBranch checks out branch in the project root, creating it from base (HEAD
+// when empty) when it does not exist. Uncommitted changes carry over; git refuses when
+// they would be lost.
+func checkoutBranch(ctx context.Context, branch, base string) error {
+	if err := validateProjectRoot(cfg.ProjectRoot); err != nil {
+		return err
+	}
+	exists := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	exists.Dir = cfg.ProjectRoot
+	args := []string{"checkout", branch}
+	if exists.Run() != nil {
+		args = []string{"checkout", "-b", branch}
+		if base != "" {
+			args = append(args, base)
+		}
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = cfg.ProjectRoot
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git %s: %s", strings.Join(args, " "), strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// checkoutTicketBranch switches to the branch t is worked and committed on: t.Branch
+// when recorded, otherwise ticket/<id>-<title> when git_branch_per_ticket is on, which
+// is then recorded on t. A missing branch is created from base, the run's
+// ticketBranchBase. No-op in dry-run mode or when t has no branch and the option is off.
+func checkoutTicketBranch(ctx context.Context, t *ticket.Ticket, base string) error {
+	if cfg.DryRun {
+		return nil
+	}
+	branch := t.Branch
+	if branch == "" {
+		if !cfg.GitBranchPerTicket {
+			return nil
+		}
+		branch = ticketBranchName(t)
+	}
+	if err := checkoutBranch(ctx, branch, base); err != nil {
+		return err
+	}
+	t.Branch = branch
+	return nil
+}
@@ -5,9 +5,11 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
 )
 
 func TestParsePorcelainLinePath(t *testing.T) {
@@ -91,3 +93,138 @@ func TestGetGitStatusForFiles_MatchesRenamedPath(t *testing.T) {
 		t.Errorf("rename path: got %q, want new.go", got)
 	}
 }
+
+func TestTicketBranchName(t *testing.T) {
+	tests := []struct {
+		id    string
+		title string
+		want  string
+	}{
+		{"TICKET-001", "Setup", "ticket/TICKET-001-setup"},
+		{"TICKET-001-setup", "Setup", "ticket/TICKET-001-setup"},
+		{"GH-12", "", "ticket/GH-12"},
+		{"GH-12", "修正登入流程", "ticket/GH-12"},
+		{"T 1: fix..bug", "Fix the bug!", "ticket/T-1-fix-bug-fix-the-bug"},
+		{".hidden/", "", "ticket/hidden"},
+		{"T-2", "Add OAuth login with refresh tokens and session expiry", "ticket/T-2-add-oauth-login-with-refresh"},
+	}
+	for _, tt := range tests {
+		if got := ticketBranchName(&ticket.Ticket{ID: tt.id, Title: tt.title}); got != tt.want {
+			t.Errorf("ticketBranchName(%q, %q) = %q, want %q", tt.id, tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestCheckoutTicketBranch(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.CommandContext(ctx, "git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	git("init", "-q")
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", ".")
+	git("commit", "-qm", "init")
+	base := git("rev-parse", "--abbrev-ref", "HEAD")
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{ProjectRoot: dir}
+
+	tk := ticket.NewTicket("TICKET-001-setup", "Setup", "")
+	if err := checkoutTicketBranch(ctx, tk, ""); err != nil || tk.Branch != "" {
+		t.Fatalf("checkoutTicketBranch() with option off = %v, branch %q", err, tk.Branch)
+	}
+
+	cfg.GitBranchPerTicket = true
+	// Uncommitted work carries over to the new branch.
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("changed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkoutTicketBranch(ctx, tk, ""); err != nil {
+		t.Fatalf("checkoutTicketBranch() err = %v", err)
+	}
+	if tk.Branch != "ticket/TICKET-001-setup" || git("rev-parse", "--abbrev-ref", "HEAD") != tk.Branch {
+		t.Errorf("branch = %q, HEAD on %q", tk.Branch, git("rev-parse", "--abbrev-ref", "HEAD"))
+	}
+	if status := git("status", "--porcelain"); status != "M a.txt" {
+		t.Errorf("status after checkout = %q, want the change carried over", status)
+	}
+
+	// A recorded branch is checked out again even when the option is turned off.
+	git("checkout", "-q", base)
+	cfg.GitBranchPerTicket = false
+	if err := checkoutTicketBranch(ctx, tk, ""); err != nil || git("rev-parse", "--abbrev-ref", "HEAD") != tk.Branch {
+		t.Errorf("checkoutTicketBranch() recorded branch err = %v", err)
+	}
+
+	cfg.DryRun = true
+	git("checkout", "-q", base)
+	if err := checkoutTicketBranch(ctx, tk, ""); err != nil || git("rev-parse", "--abbrev-ref", "HEAD") != base {
+		t.Errorf("checkoutTicketBranch() should not switch branches in dry-run mode (err %v)", err)
+	}
+}
+
+func TestCheckoutTicketBranch_FromRunBase(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.CommandContext(ctx, "git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	git("init", "-q")
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", ".")
+	git("commit", "-qm", "init")
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{ProjectRoot: dir}
+	if base := ticketBranchBase(ctx); base != "" {
+		t.Errorf("ticketBranchBase() with option off = %q, want empty", base)
+	}
+	cfg.GitBranchPerTicket = true
+	base := ticketBranchBase(ctx)
+	if base != git("rev-parse", "HEAD") {
+		t.Fatalf("ticketBranchBase() = %q, want HEAD", base)
+	}
+
+	// The first ticket commits on its branch; the next one still forks from the run's base.
+	first := ticket.NewTicket("T-1", "First", "")
+	if err := checkoutTicketBranch(ctx, first, base); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "first.txt"), []byte("1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", ".")
+	git("commit", "-qm", "first")
+
+	second := ticket.NewTicket("T-2", "Second", "")
+	if err := checkoutTicketBranch(ctx, second, base); err != nil {
+		t.Fatal(err)
+	}
+	if second.Branch != "ticket/T-2-second" || git("rev-parse", "HEAD") != base {
+		t.Errorf("branch %q at %s, want ticket/T-2-second at the run base %s", second.Branch, git("rev-parse", "HEAD"), base)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "first.txt")); !os.IsNotExist(err) {
+		t.Errorf("second ticket branch should not contain the first ticket's commit (stat err %v)", err)
+	}
+}
@@ -0,0 +1,231 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Output formats of the graph command.
+const (
+	graphFormatASCII   = "ascii"
+	graphFormatDOT     = "dot"
+	graphFormatMermaid = "mermaid"
+)
+
+var graphFormat string
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: i18n.CmdGraphShort,
+	Long:  i18n.CmdGraphLong,
+	Args:  cobra.NoArgs,
+	RunE:  runGraph,
+}
+
+func init() {
+	graphCmd.Flags().StringVar(&graphFormat, "format", graphFormatASCII, i18n.FlagGraphFormat)
+}
+
+func runGraph(cmd *cobra.Command, args []string) error {
+	w := os.Stdout
+	var render func(io.Writer, *ticket.Graph)
+	switch graphFormat {
+	case graphFormatASCII:
+		render = renderGraphASCII
+	case graphFormatDOT:
+		render = renderGraphDOT
+	case graphFormatMermaid:
+		render = renderGraphMermaid
+	default:
+		return fmt.Errorf(i18n.ErrGraphFormat, graphFormat)
+	}
+
+	store := newTicketStore()
+	if err := store.Init(); err != nil {
+		return fmt.Errorf(i18n.ErrInitStoreFailed, err)
+	}
+	all, err := store.LoadAll()
+	if err != nil {
+		return err
+	}
+	if len(all.Tickets) == 0 && graphFormat == graphFormatASCII {
+		ui.PrintInfo(w, i18n.MsgNoTickets)
+		return nil
+	}
+	render(w, ticket.NewDependencyResolver(store).Graph(all.Tickets))
+	return nil
+}
+
+// graphColors are the fill colors of ticket nodes in DOT and Mermaid output by status;
+// a missing dependency is drawn unfilled with a dashed border.
+var graphColors = map[ticket.Status]string{
+	ticket.StatusPending:    "#fde68a",
+	ticket.StatusInProgress: "#93c5fd",
+	ticket.StatusCompleted:  "#86efac",
+	ticket.StatusFailed:     "#fca5a5",
+}
+
+// graphStatusStyle colors a status in the ASCII tree, as status lists tickets.
+func graphStatusStyle(status ticket.Status) func(...string) string {
+	switch status {
+	case ticket.StatusInProgress:
+		return ui.StyleInfo.Render
+	case ticket.StatusCompleted:
+		return ui.StyleSuccess.Render
+	case ticket.StatusFailed:
+		return ui.StyleError.Render
+	default:
+		return ui.StyleWarning.Render
+	}
+}
+
+// renderGraphDOT writes g as a Graphviz digraph. Arrows point from a dependency to the
+// tickets waiting on it, i.e. in the order work runs them; soft dependencies are dashed.
+func renderGraphDOT(w io.Writer, g *ticket.Graph) {
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ")
+	quote := func(lines ...string) string {
+		for i, l := range lines {
+			lines[i] = escape.Replace(l)
+		}
+		return `"` + strings.Join(lines, `\n`) + `"`
+	}
+	fmt.Fprintln(w, "digraph tickets {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, `  node [shape=box, style="rounded,filled", fontname="Helvetica"];`)
+	for _, t := range g.Nodes {
+		label := quote(t.ID, ui.Truncate(t.Title, 40), "["+string(t.Status)+"]")
+		fmt.Fprintf(w, "  %s [label=%s, fillcolor=%s];\n", quote(t.ID), label, quote(graphColors[t.Status]))
+	}
+	for _, id := range g.Missing {
+		fmt.Fprintf(w, "  %s [label=%s, style=\"rounded,dashed\"];\n", quote(id), quote(fmt.Sprintf(i18n.GraphMissing, id)))
+	}
+	for _, e := range g.Edges {
+		attrs := ""
+		if e.Soft {
+			attrs = " [style=dashed]"
+		}
+		fmt.Fprintf(w, "  %s -> %s%s;\n", quote(e.To), quote(e.From), attrs)
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// renderGraphMermaid writes g as a Mermaid flowchart, with the same arrows as
+// renderGraphDOT. Ticket IDs are not valid Mermaid node IDs in general, so nodes are
+// numbered and labeled with the ID.
+func renderGraphMermaid(w io.Writer, g *ticket.Graph) {
+	label := func(s string) string {
+		return `"` + strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(s) + `"`
+	}
+	node := make(map[string]string, len(g.Nodes)+len(g.Missing))
+	byClass := make(map[string][]string)
+	fmt.Fprintln(w, "flowchart LR")
+	for i, t := range g.Nodes {
+		node[t.ID] = fmt.Sprintf("t%d", i)
+		fmt.Fprintf(w, "  %s[%s]\n", node[t.ID], label(fmt.Sprintf("%s: %s", t.ID, ui.Truncate(t.Title, 40))))
+		class := strings.ReplaceAll(string(t.Status), "_", "")
+		byClass[class] = append(byClass[class], node[t.ID])
+	}
+	for i, id := range g.Missing {
+		node[id] = fmt.Sprintf("m%d", i)
+		fmt.Fprintf(w, "  %s[%s]\n", node[id], label(fmt.Sprintf(i18n.GraphMissing, id)))
+		byClass["missing"] = append(byClass["missing"], node[id])
+	}
+	for _, e := range g.Edges {
+		arrow := "-->"
+		if e.Soft {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(w, "  %s %s %s\n", node[e.To], arrow, node[e.From])
+	}
+	for _, s := range []ticket.Status{ticket.StatusPending, ticket.StatusInProgress, ticket.StatusCompleted, ticket.StatusFailed} {
+		class := strings.ReplaceAll(string(s), "_", "")
+		fmt.Fprintf(w, "  classDef %s fill:%s\n", class, graphColors[s])
+	}
+	fmt.Fprintln(w, "  classDef missing fill:#fff,stroke-dasharray:4 2")
+	for _, class := range []string{"pending", "inprogress", "completed", "failed", "missing"} {
+		if nodes := byClass[class]; len(nodes) > 0 {
+			fmt.Fprintf(w, "  class %s %s\n", strings.Join(nodes, ","), class)
+		}
+	}
+}
+
+// renderGraphASCII writes g as trees for the terminal: each ticket no other ticket
+// depends on, with its dependencies below it. A ticket reached again is not expanded
+// again; tickets only reachable through a cycle start trees of their own.
+func renderGraphASCII(w io.Writer, g *ticket.Graph) {
+	byID := make(map[string]*ticket.Ticket, len(g.Nodes))
+	for _, t := range g.Nodes {
+		byID[t.ID] = t
+	}
+	deps := make(map[string][]ticket.GraphEdge)
+	for _, e := range g.Edges {
+		deps[e.From] = append(deps[e.From], e)
+	}
+	dependents := g.Dependents()
+
+	shown, expanded := make(map[string]bool), make(map[string]bool)
+	line := func(id string) string {
+		t := byID[id]
+		if t == nil {
+			return ui.StyleError.Render(fmt.Sprintf(i18n.GraphMissing, id))
+		}
+		return fmt.Sprintf("%s: %s %s", t.ID, ui.Truncate(t.Title, 50), graphStatusStyle(t.Status)("["+string(t.Status)+"]"))
+	}
+	var walk func(id, prefix string, path map[string]bool)
+	walk = func(id, prefix string, path map[string]bool) {
+		expanded[id] = true
+		edges := deps[id]
+		for i, e := range edges {
+			branch, next := "├── ", prefix+"│   "
+			if i == len(edges)-1 {
+				branch, next = "└── ", prefix+"    "
+			}
+			var notes []string
+			if e.Soft {
+				notes = append(notes, i18n.GraphSoft)
+			}
+			expand := false
+			switch {
+			case path[e.To]:
+				notes = append(notes, i18n.GraphCycle)
+			case expanded[e.To] && len(deps[e.To]) > 0:
+				notes = append(notes, i18n.GraphSeeAbove)
+			default:
+				expand = true
+			}
+			text := line(e.To)
+			if len(notes) > 0 {
+				text += " " + ui.StyleMuted.Render("("+strings.Join(notes, ", ")+")")
+			}
+			fmt.Fprintln(w, prefix+branch+text)
+			shown[e.To] = true
+			if expand {
+				path[e.To] = true
+				walk(e.To, next, path)
+				delete(path, e.To)
+			}
+		}
+	}
+	tree := func(id string) {
+		fmt.Fprintln(w, line(id))
+		shown[id] = true
+		walk(id, "", map[string]bool{id: true})
+	}
+	for _, t := range g.Nodes {
+		if len(dependents[t.ID]) == 0 {
+			tree(t.ID)
+		}
+	}
+	for _, t := range g.Nodes {
+		if !shown[t.ID] {
+			tree(t.ID)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// testGraph: B waits on A and C (and softly on the missing X), C on A; D and E depend
+// on each other.
+func testGraph() *ticket.Graph {
+	a := ticket.NewTicket("A", "Set up", "")
+	a.Status = ticket.StatusCompleted
+	b := ticket.NewTicket("B", `Ship "it"`, "")
+	b.Dependencies = []string{"A", "C"}
+	b.SoftDependencies = []string{"X"}
+	c := ticket.NewTicket("C", "Build", "")
+	c.Dependencies = []string{"A"}
+	d := ticket.NewTicket("D", "Loop one", "")
+	d.Dependencies = []string{"E"}
+	e := ticket.NewTicket("E", "Loop two", "")
+	e.Dependencies = []string{"D"}
+	return ticket.NewDependencyResolver(nil).Graph([]*ticket.Ticket{a, b, c, d, e})
+}
+
+func TestRenderGraphASCII(t *testing.T) {
+	var buf bytes.Buffer
+	renderGraphASCII(&buf, testGraph())
+	want := `B: Ship "it" [pending]
+├── A: Set up [completed]
+├── C: Build [pending]
+│   └── A: Set up [completed]
+└── X（不存在） (軟相依)
+D: Loop one [pending]
+└── E: Loop two [pending]
+    └── D: Loop one [pending] (循環)
+`
+	if got := buf.String(); got != want {
+		t.Errorf("renderGraphASCII() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderGraphDOT(t *testing.T) {
+	var buf bytes.Buffer
+	renderGraphDOT(&buf, testGraph())
+	out := buf.String()
+	for _, want := range []string{
+		"digraph tickets {",
+		`"B" [label="B\nShip \"it\"\n[pending]", fillcolor="#fde68a"];`,
+		`"A" [label="A\nSet up\n[completed]", fillcolor="#86efac"];`,
+		`"X" [label="X（不存在）", style="rounded,dashed"];`,
+		`"A" -> "B";`,
+		`"X" -> "B" [style=dashed];`,
+		`"E" -> "D";`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("DOT output should contain %s:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderGraphMermaid(t *testing.T) {
+	var buf bytes.Buffer
+	renderGraphMermaid(&buf, testGraph())
+	out := buf.String()
+	for _, want := range []string{
+		"flowchart LR",
+		`t1["B: Ship #quot;it#quot;"]`,
+		`m0["X（不存在）"]`,
+		"t0 --> t1",
+		"m0 -.-> t1",
+		"class t0 completed",
+		"class t1,t2,t3,t4 pending",
+		"class m0 missing",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Mermaid output should contain %s:\n%s", want, out)
+		}
+	}
+}
@@ -107,9 +107,6 @@ func TestCreateAgentCaller(t *testing.T) {
 				if caller.DryRun != cfg.DryRun {
 					t.Errorf("caller.DryRun = %v, want %v", caller.DryRun, cfg.DryRun)
 				}
-				if caller.Verbose != cfg.Verbose {
-					t.Errorf("caller.Verbose = %v, want %v", caller.Verbose, cfg.Verbose)
-				}
 			}
 		})
 	}
@@ -154,7 +151,47 @@ func TestCreateAgentCaller_ConfigProperties(t *testing.T) {
 	if caller.DryRun != true {
 		t.Errorf("DryRun = %v, want %v", caller.DryRun, true)
 	}
-	if caller.Verbose != true {
-		t.Errorf("Verbose = %v, want %v", caller.Verbose, true)
+}
+
+func TestCreateAgentCaller_Backend(t *testing.T) {
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+
+	tests := []struct {
+		name        string
+		backend     string
+		command     string
+		wantBackend string
+		wantCommand string
+		wantErr     bool
+	}{
+		{"auto detects cursor", "auto", "agent", "cursor", "agent", false},
+		{"auto detects claude", "auto", "/usr/local/bin/claude", "claude", "/usr/local/bin/claude", false},
+		{"explicit claude uses its default command", "claude", "agent", "claude", "claude", false},
+		{"explicit backend keeps custom command", "claude", "my-claude", "claude", "my-claude", false},
+		{"explicit aider uses its default command", "aider", "agent", "aider", "aider", false},
+		{"api backend needs no CLI", "api", "agent", "api", "api", false},
+		{"unknown backend", "copilot", "agent", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg = &config.Config{AgentBackend: tt.backend, AgentCommand: tt.command, AgentOutputFormat: "text", DryRun: true}
+			caller, err := CreateAgentCaller()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("CreateAgentCaller() expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateAgentCaller() unexpected error: %v", err)
+			}
+			if caller.Backend.Name() != tt.wantBackend {
+				t.Errorf("Backend = %q, want %q", caller.Backend.Name(), tt.wantBackend)
+			}
+			if caller.Command != tt.wantCommand {
+				t.Errorf("Command = %q, want %q", caller.Command, tt.wantCommand)
+			}
+		})
 	}
 }
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history <ticket-id>",
+	Short: i18n.CmdHistoryShort,
+	Long:  i18n.CmdHistoryLong,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runHistory,
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	t, err := loadTicketOrArchived(newTicketStore(), args[0])
+	if err != nil {
+		return err
+	}
+	printHistory(os.Stdout, t)
+	return nil
+}
+
+// loadTicketOrArchived loads ticket id from store, or from the archive when it has
+// been archived.
+func loadTicketOrArchived(store ticket.Storer, id string) (*ticket.Ticket, error) {
+	t, err := store.Load(id)
+	if err == nil {
+		return t, nil
+	}
+	if archived, aerr := ticket.NewArchive(cfg.ArchiveDir()).Load(id); aerr == nil {
+		return archived, nil
+	}
+	return nil, fmt.Errorf(i18n.ErrTicketNotFound, id)
+}
+
+// printHistory lists the events of t, oldest first, followed by a summary of its agent
+// calls.
+func printHistory(w io.Writer, t *ticket.Ticket) {
+	ui.PrintHeader(w, fmt.Sprintf(i18n.UIHistory, t.ID, t.Title))
+	if len(t.Events) == 0 {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgHistoryEmpty, t.ID))
+		return
+	}
+	calls, failed := 0, 0
+	var total time.Duration
+	for _, e := range t.Events {
+		line := ui.StyleMuted.Render(e.At.Local().Format("2006-01-02 15:04:05")) + "  " + historyEventText(e)
+		if e.Kind == ticket.EventStatus && e.To == ticket.StatusFailed {
+			line = ui.StyleError.Render(line)
+			failed++
+		}
+		if e.Kind == ticket.EventAgentFinished {
+			calls++
+			total += e.Duration
+		}
+		ui.PrintInfo(w, line)
+	}
+	ui.PrintInfo(w, "")
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgHistorySummary, calls, total.Round(time.Second), failed))
+}
+
+// historyEventText describes e, e.g. "status in_progress → failed (exit status 1)".
+func historyEventText(e ticket.Event) string {
+	var text string
+	switch e.Kind {
+	case ticket.EventCreated:
+		text = i18n.HistoryCreated
+	case ticket.EventStatus:
+		text = fmt.Sprintf(i18n.HistoryStatus, e.From, e.To)
+	case ticket.EventAgentStarted:
+		return fmt.Sprintf(i18n.HistoryAgentStarted, e.Detail)
+	case ticket.EventAgentFinished:
+		return fmt.Sprintf(i18n.HistoryAgentFinished, e.Detail, e.Duration.Round(time.Second))
+	case ticket.EventCommit:
+		return fmt.Sprintf(i18n.HistoryCommit, e.Detail)
+	default:
+		text = string(e.Kind)
+	}
+	if e.Detail != "" {
+		text += " (" + e.Detail + ")"
+	}
+	return text
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestRunHistory(t *testing.T) {
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+
+	// Failed once, retried, then completed and committed.
+	tk := ticket.NewTicket("T-1", "flaky", "")
+	for _, fail := range []bool{true, false} {
+		tk.MarkInProgress()
+		tk.AddEvent(ticket.Event{Kind: ticket.EventAgentStarted, Detail: "coding"})
+		tk.AddEvent(ticket.Event{Kind: ticket.EventAgentFinished, Detail: "ok", Duration: 90 * time.Second})
+		if fail {
+			tk.MarkFailed(errors.New("tests failed"))
+			tk.SetStatus(ticket.StatusPending, ticket.ReasonRetry)
+		}
+	}
+	tk.MarkCompleted("done")
+	tk.AddCommit("abc1234def")
+	if err := store.Save(tk); err != nil {
+		t.Fatal(err)
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: ticketsDir, WorkPIDFile: filepath.Join(tmpDir, ".work.pid")}
+
+	output := captureOutput(func() {
+		if err := runHistory(nil, []string{"T-1"}); err != nil {
+			t.Errorf("runHistory() error = %v", err)
+		}
+	})
+	for _, want := range []string{
+		i18n.HistoryCreated,
+		fmt.Sprintf(i18n.HistoryStatus, ticket.StatusInProgress, ticket.StatusFailed) + " (tests failed)",
+		fmt.Sprintf(i18n.HistoryStatus, ticket.StatusFailed, ticket.StatusPending) + " (" + ticket.ReasonRetry + ")",
+		fmt.Sprintf(i18n.HistoryAgentFinished, "ok", "1m30s"),
+		fmt.Sprintf(i18n.HistoryCommit, "abc1234def"),
+		fmt.Sprintf(i18n.MsgHistorySummary, 2, "3m0s", 1),
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output should contain %q, got:\n%s", want, output)
+		}
+	}
+
+	// Archived tickets keep their history.
+	if _, err := ticket.ArchiveTickets(store, ticket.NewArchive(cfg.ArchiveDir()), time.Now().Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	output = captureOutput(func() {
+		if err := runHistory(nil, []string{"T-1"}); err != nil {
+			t.Errorf("runHistory() of an archived ticket error = %v", err)
+		}
+	})
+	if !strings.Contains(output, fmt.Sprintf(i18n.HistoryCommit, "abc1234def")) {
+		t.Errorf("history of an archived ticket should list its events, got:\n%s", output)
+	}
+
+	if err := runHistory(nil, []string{"T-404"}); err == nil {
+		t.Error("runHistory() of an unknown ticket should fail")
+	}
+}
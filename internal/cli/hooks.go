@@ -0,0 +1,304 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// hookMarker identifies hook scripts written by hooks install; uninstall only
+	// removes files containing it.
+	hookMarker = "# agent-orchestrator hook"
+	// hookBackupSuffix is appended to an existing hook replaced by hooks install --force.
+	hookBackupSuffix = ".pre-agent-orchestrator"
+)
+
+// hookNames lists the git hooks hooks install knows how to write, in install order.
+var hookNames = []string{"pre-push", "commit-msg", "post-merge"}
+
+var (
+	hooksSelected []string
+	hooksForce    bool
+	hooksFailOn   string
+)
+
+// ticketRefPattern matches ticket IDs such as TICKET-001 or PERF-12 in commit messages.
+var ticketRefPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9_]*-\d+\b`)
+
+// commitMsgExemptPrefixes are commit messages generated by git itself that need no ticket reference.
+var commitMsgExemptPrefixes = []string{"Merge ", "Revert ", "fixup! ", "squash! ", "amend! "}
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: i18n.CmdHooksShort,
+	Long:  i18n.CmdHooksLong,
+}
+
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: i18n.CmdHooksInstallShort,
+	Args:  cobra.NoArgs,
+	RunE:  runHooksInstall,
+}
+
+var hooksUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: i18n.CmdHooksUninstallShort,
+	Args:  cobra.NoArgs,
+	RunE:  runHooksUninstall,
+}
+
+// hooksCommitMsgCmd is invoked by the installed commit-msg hook.
+var hooksCommitMsgCmd = &cobra.Command{
+	Use:    "commit-msg <message-file>",
+	Hidden: true,
+	Args:   cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		cmd.SilenceUsage = true
+		return checkCommitMessage(string(data), newTicketStore())
+	},
+}
+
+// hooksPostMergeCmd is invoked by the installed post-merge hook.
+var hooksPostMergeCmd = &cobra.Command{
+	Use:    "post-merge",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		printPendingReminder(os.Stdout, newTicketStore())
+		return nil
+	},
+}
+
+func init() {
+	hooksInstallCmd.Flags().StringSliceVar(&hooksSelected, "hooks", hookNames, i18n.FlagHooksSelected)
+	hooksInstallCmd.Flags().BoolVar(&hooksForce, "force", false, i18n.FlagHooksForce)
+	hooksInstallCmd.Flags().StringVar(&hooksFailOn, "fail-on", "HIGH", i18n.FlagHooksFailOn)
+	hooksUninstallCmd.Flags().StringSliceVar(&hooksSelected, "hooks", hookNames, i18n.FlagHooksSelected)
+
+	hooksCmd.AddCommand(hooksInstallCmd)
+	hooksCmd.AddCommand(hooksUninstallCmd)
+	hooksCmd.AddCommand(hooksCommitMsgCmd)
+	hooksCmd.AddCommand(hooksPostMergeCmd)
+}
+
+func runHooksInstall(cmd *cobra.Command, args []string) error {
+	w := os.Stdout
+	if err := validateHookNames(hooksSelected); err != nil {
+		return err
+	}
+	if ticket.SeverityRank(hooksFailOn) == 0 {
+		return fmt.Errorf(i18n.ErrInvalidSeverity, hooksFailOn)
+	}
+	dir, err := gitHooksDir(context.Background(), cfg.ProjectRoot)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf(i18n.ErrHooksWrite, dir, err)
+	}
+
+	bin := hookBinary()
+	for _, name := range hooksSelected {
+		path := filepath.Join(dir, name)
+		if existing, err := os.ReadFile(path); err == nil && !isOrchestratorHook(existing) {
+			if !hooksForce {
+				ui.PrintWarning(w, fmt.Sprintf(i18n.MsgHookExists, name))
+				continue
+			}
+			if err := os.Rename(path, path+hookBackupSuffix); err != nil {
+				return fmt.Errorf(i18n.ErrHooksWrite, path, err)
+			}
+			ui.PrintInfo(w, fmt.Sprintf(i18n.MsgHookBackedUp, name, name+hookBackupSuffix))
+		}
+		if err := os.WriteFile(path, []byte(hookScript(name, bin, hooksFailOn)), 0755); err != nil {
+			return fmt.Errorf(i18n.ErrHooksWrite, path, err)
+		}
+		ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgHookInstalled, name))
+	}
+	return nil
+}
+
+func runHooksUninstall(cmd *cobra.Command, args []string) error {
+	w := os.Stdout
+	if err := validateHookNames(hooksSelected); err != nil {
+		return err
+	}
+	dir, err := gitHooksDir(context.Background(), cfg.ProjectRoot)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range hooksSelected {
+		path := filepath.Join(dir, name)
+		existing, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if !isOrchestratorHook(existing) {
+			ui.PrintWarning(w, fmt.Sprintf(i18n.MsgHookNotOurs, name))
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf(i18n.ErrHooksWrite, path, err)
+		}
+		ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgHookRemoved, name))
+		if _, err := os.Stat(path + hookBackupSuffix); err == nil {
+			if err := os.Rename(path+hookBackupSuffix, path); err != nil {
+				return fmt.Errorf(i18n.ErrHooksWrite, path, err)
+			}
+			ui.PrintInfo(w, fmt.Sprintf(i18n.MsgHookRestored, name))
+		}
+	}
+	return nil
+}
+
+// validateHookNames rejects hook names that hooks install does not provide.
+func validateHookNames(names []string) error {
+	for _, n := range names {
+		known := false
+		for _, h := range hookNames {
+			if n == h {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf(i18n.ErrHooksUnknown, n, strings.Join(hookNames, ", "))
+		}
+	}
+	return nil
+}
+
+// gitHooksDir returns the hooks directory of the repository at projectRoot, honoring
+// core.hooksPath and worktrees via "git rev-parse --git-path hooks".
+func gitHooksDir(ctx context.Context, projectRoot string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--git-path", "hooks")
+	cmd.Dir = projectRoot
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf(i18n.ErrHooksNotGitRepo, projectRoot)
+	}
+	dir := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(projectRoot, dir)
+	}
+	return dir, nil
+}
+
+// hookBinary returns how hook scripts invoke the orchestrator: the plain command name
+// when it is on PATH, otherwise the absolute path of the running executable.
+func hookBinary() string {
+	if _, err := exec.LookPath("agent-orchestrator"); err == nil {
+		return "agent-orchestrator"
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return "agent-orchestrator"
+	}
+	return shellQuote(exe)
+}
+
+// shellQuote single-quotes s for /bin/sh.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// hookScript returns the shell script for the named hook.
+func hookScript(name, bin, failOn string) string {
+	var body string
+	switch name {
+	case "pre-push":
+		body = fmt.Sprintf("exec %s analyze --changed --fail-on %s --quiet\n", bin, strings.ToUpper(failOn))
+	case "commit-msg":
+		body = fmt.Sprintf("exec %s hooks commit-msg \"$1\"\n", bin)
+	case "post-merge":
+		body = fmt.Sprintf("%s hooks post-merge || true\n", bin)
+	}
+	return "#!/bin/sh\n" + hookMarker + " (remove with: agent-orchestrator hooks uninstall)\n" + body
+}
+
+// isOrchestratorHook reports whether a hook script was written by hooks install.
+func isOrchestratorHook(content []byte) bool {
+	return strings.Contains(string(content), hookMarker)
+}
+
+// checkCommitMessage requires a commit message to reference an existing ticket ID.
+// Messages generated by git (merge, revert, fixup) and repositories without any
+// tickets are exempt, so the hook never gets in the way of projects not using the store.
+func checkCommitMessage(msg string, store ticket.Storer) error {
+	msg = stripCommitComments(msg)
+	if strings.TrimSpace(msg) == "" {
+		return nil
+	}
+	for _, p := range commitMsgExemptPrefixes {
+		if strings.HasPrefix(msg, p) {
+			return nil
+		}
+	}
+
+	all, err := store.LoadAll()
+	if err != nil || all.Count() == 0 {
+		return nil
+	}
+	known := make(map[string]bool, all.Count())
+	for _, t := range all.Tickets {
+		known[t.ID] = true
+	}
+
+	refs := ticketRefPattern.FindAllString(msg, -1)
+	if len(refs) == 0 {
+		return errors.New(i18n.ErrCommitMsgNoTicket)
+	}
+	for _, ref := range refs {
+		if known[ref] {
+			return nil
+		}
+	}
+	return fmt.Errorf(i18n.ErrCommitMsgUnknownTicket, strings.Join(refs, ", "))
+}
+
+// stripCommitComments drops the "#" comment lines git adds to the message template.
+func stripCommitComments(msg string) string {
+	var lines []string
+	for _, line := range strings.Split(msg, "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// printPendingReminder prints how many tickets still need work after a merge.
+// It stays silent when there is nothing to do.
+func printPendingReminder(w io.Writer, store ticket.Storer) {
+	counts, err := store.Count()
+	if err != nil {
+		return
+	}
+	pending := counts[ticket.StatusPending]
+	inProgress := counts[ticket.StatusInProgress]
+	failed := counts[ticket.StatusFailed]
+	if pending+inProgress+failed == 0 {
+		return
+	}
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgHookPendingReminder, pending, inProgress, failed))
+	ui.PrintInfo(w, i18n.HintRunWork)
+}
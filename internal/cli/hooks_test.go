@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func setupHooksRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	cmd := exec.Command("git", "init")
+	cmd.Dir = dir
+	if err := cmd.Run(); err != nil {
+		t.Skipf("git init: %v", err)
+	}
+
+	originalCfg := cfg
+	t.Cleanup(func() { cfg = originalCfg })
+	cfg = config.DefaultConfig()
+	cfg.ProjectRoot = dir
+	cfg.TicketsDir = filepath.Join(dir, ".tickets")
+
+	hooksSelected = hookNames
+	hooksForce = false
+	hooksFailOn = "HIGH"
+	return dir
+}
+
+func TestHooksInstallUninstall(t *testing.T) {
+	dir := setupHooksRepo(t)
+	hooksDir := filepath.Join(dir, ".git", "hooks")
+
+	captureOutput(func() {
+		if err := runHooksInstall(hooksInstallCmd, nil); err != nil {
+			t.Fatalf("install: %v", err)
+		}
+	})
+
+	for _, name := range hookNames {
+		path := filepath.Join(hooksDir, name)
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("%s not installed: %v", name, err)
+		}
+		if info.Mode().Perm()&0100 == 0 {
+			t.Errorf("%s is not executable: %v", name, info.Mode())
+		}
+		data, _ := os.ReadFile(path)
+		if !isOrchestratorHook(data) {
+			t.Errorf("%s missing marker:\n%s", name, data)
+		}
+	}
+	prePush, _ := os.ReadFile(filepath.Join(hooksDir, "pre-push"))
+	if !strings.Contains(string(prePush), "analyze --changed --fail-on HIGH") {
+		t.Errorf("pre-push script = %q", prePush)
+	}
+
+	captureOutput(func() {
+		if err := runHooksUninstall(hooksUninstallCmd, nil); err != nil {
+			t.Fatalf("uninstall: %v", err)
+		}
+	})
+	for _, name := range hookNames {
+		if _, err := os.Stat(filepath.Join(hooksDir, name)); !os.IsNotExist(err) {
+			t.Errorf("%s still present after uninstall", name)
+		}
+	}
+}
+
+func TestHooksInstall_ExistingHook(t *testing.T) {
+	dir := setupHooksRepo(t)
+	hooksDir := filepath.Join(dir, ".git", "hooks")
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	custom := "#!/bin/sh\necho custom\n"
+	path := filepath.Join(hooksDir, "pre-push")
+	if err := os.WriteFile(path, []byte(custom), 0755); err != nil {
+		t.Fatal(err)
+	}
+	hooksSelected = []string{"pre-push"}
+
+	// Without --force the user's hook is left alone.
+	captureOutput(func() {
+		if err := runHooksInstall(hooksInstallCmd, nil); err != nil {
+			t.Fatalf("install: %v", err)
+		}
+	})
+	if data, _ := os.ReadFile(path); string(data) != custom {
+		t.Fatalf("existing hook overwritten without --force: %q", data)
+	}
+
+	// With --force it is backed up, and uninstall restores it.
+	hooksForce = true
+	captureOutput(func() {
+		if err := runHooksInstall(hooksInstallCmd, nil); err != nil {
+			t.Fatalf("install --force: %v", err)
+		}
+	})
+	if data, _ := os.ReadFile(path + hookBackupSuffix); string(data) != custom {
+		t.Fatalf("backup = %q, want original hook", data)
+	}
+	captureOutput(func() {
+		if err := runHooksUninstall(hooksUninstallCmd, nil); err != nil {
+			t.Fatalf("uninstall: %v", err)
+		}
+	})
+	if data, _ := os.ReadFile(path); string(data) != custom {
+		t.Errorf("hook after uninstall = %q, want original restored", data)
+	}
+	if _, err := os.Stat(path + hookBackupSuffix); !os.IsNotExist(err) {
+		t.Error("backup should be gone after restore")
+	}
+}
+
+func TestHooksInstall_UnknownHook(t *testing.T) {
+	setupHooksRepo(t)
+	hooksSelected = []string{"pre-rebase"}
+	if err := runHooksInstall(hooksInstallCmd, nil); err == nil {
+		t.Error("expected error for unknown hook")
+	}
+}
+
+func TestCheckCommitMessage(t *testing.T) {
+	store := ticket.NewStore(t.TempDir())
+	if err := store.Init(); err != nil {
+		t.Fatal(err)
+	}
+	empty := ticket.NewStore(t.TempDir())
+	if err := store.Save(ticket.NewTicket("TICKET-001", "Login", "")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		store   *ticket.Store
+		msg     string
+		wantErr bool
+	}{
+		{"references existing ticket", store, "TICKET-001: add login form", false},
+		{"reference in body", store, "Add login form\n\nRefs TICKET-001", false},
+		{"no reference", store, "add login form", true},
+		{"unknown ticket", store, "TICKET-999: add login form", true},
+		{"merge commit exempt", store, "Merge branch 'main'", false},
+		{"fixup exempt", store, "fixup! TICKET-001: add login form", false},
+		{"comments ignored", store, "add login form\n# TICKET-001 in a comment", true},
+		{"empty message", store, "# only comments\n", false},
+		{"no tickets in store", empty, "add login form", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkCommitMessage(tt.msg, tt.store)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkCommitMessage(%q) error = %v, wantErr %v", tt.msg, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPrintPendingReminder(t *testing.T) {
+	store := ticket.NewStore(t.TempDir())
+	if err := store.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	printPendingReminder(&buf, store)
+	if buf.Len() != 0 {
+		t.Errorf("expected no output for empty store, got %q", buf.String())
+	}
+
+	if err := store.Save(ticket.NewTicket("TICKET-001", "Login", "")); err != nil {
+		t.Fatal(err)
+	}
+	printPendingReminder(&buf, store)
+	if !strings.Contains(buf.String(), "1") {
+		t.Errorf("reminder = %q, want pending count", buf.String())
+	}
+}
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/anthropic/agent-orchestrator/internal/github"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/jira"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importLabels []string
+	importJQL    string
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: i18n.CmdImportShort,
+	Long:  i18n.CmdImportLong,
+}
+
+var importGithubCmd = &cobra.Command{
+	Use:   "github <owner/repo>",
+	Short: i18n.CmdImportGithubShort,
+	Long:  i18n.CmdImportGithubLong,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runImportGithub,
+}
+
+var importJiraCmd = &cobra.Command{
+	Use:   "jira",
+	Short: i18n.CmdImportJiraShort,
+	Long:  i18n.CmdImportJiraLong,
+	Args:  cobra.NoArgs,
+	RunE:  runImportJira,
+}
+
+func init() {
+	importGithubCmd.Flags().StringSliceVar(&importLabels, "label", nil, i18n.FlagImportLabel)
+	importJiraCmd.Flags().StringVar(&importJQL, "jql", "", i18n.FlagImportJQL)
+	_ = importJiraCmd.MarkFlagRequired("jql")
+	importCmd.AddCommand(importGithubCmd)
+	importCmd.AddCommand(importJiraCmd)
+}
+
+// githubToken returns the GitHub API token from config, falling back to GITHUB_TOKEN.
+func githubToken() string {
+	if cfg.GitHubToken != "" {
+		return cfg.GitHubToken
+	}
+	return os.Getenv("GITHUB_TOKEN")
+}
+
+func runImportGithub(cmd *cobra.Command, args []string) error {
+	w := os.Stdout
+	repo, err := github.ParseRepo(args[0])
+	if err != nil {
+		return err
+	}
+	if !cfg.DryRun {
+		if err := ErrIfBackgroundWorkRunning(); err != nil {
+			return err
+		}
+	}
+
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgImportFetching, repo))
+	client := github.NewClient(cfg.GitHubAPIURL, githubToken())
+	issues, err := client.ListOpenIssues(context.Background(), repo, importLabels)
+	if err != nil {
+		return fmt.Errorf(i18n.ErrImportFailed, err)
+	}
+	return importTickets(w, github.ToTickets(repo, issues))
+}
+
+// jiraToken returns the Jira API token from config, falling back to JIRA_API_TOKEN.
+func jiraToken() string {
+	if cfg.Jira.Token != "" {
+		return cfg.Jira.Token
+	}
+	return os.Getenv("JIRA_API_TOKEN")
+}
+
+func runImportJira(cmd *cobra.Command, args []string) error {
+	w := os.Stdout
+	if cfg.Jira.URL == "" {
+		return errors.New(i18n.ErrJiraURLRequired)
+	}
+	mapping, err := jira.DefaultMapping().WithOverrides(cfg.Jira.PriorityMap, cfg.Jira.TypeMap, cfg.Jira.DependencyLinks)
+	if err != nil {
+		return fmt.Errorf(i18n.ErrImportFailed, err)
+	}
+	if !cfg.DryRun {
+		if err := ErrIfBackgroundWorkRunning(); err != nil {
+			return err
+		}
+	}
+
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgImportJiraFetching, importJQL))
+	client := jira.NewClient(cfg.Jira.URL, cfg.Jira.Email, jiraToken())
+	issues, err := client.Search(context.Background(), importJQL)
+	if err != nil {
+		return fmt.Errorf(i18n.ErrImportFailed, err)
+	}
+	return importTickets(w, jira.ToTickets(cfg.Jira.URL, issues, mapping))
+}
+
+// importTickets saves imported tickets as pending, skipping IDs that already exist so
+// an import can be re-run to pick up new issues. In dry-run mode nothing is written.
+func importTickets(w io.Writer, tickets []*ticket.Ticket) error {
+	if len(tickets) == 0 {
+		ui.PrintInfo(w, i18n.MsgImportNone)
+		return nil
+	}
+	store := newTicketStore()
+	if err := store.Init(); err != nil {
+		return fmt.Errorf(i18n.ErrInitStoreFailed, err)
+	}
+
+	created, skipped := 0, 0
+	for _, t := range tickets {
+		if _, err := store.Load(t.ID); err == nil {
+			skipped++
+			continue
+		}
+		if cfg.DryRun {
+			ui.PrintInfo(w, fmt.Sprintf(i18n.MsgImportWouldAdd, t.ID, t.Title))
+			created++
+			continue
+		}
+		if err := store.Save(t); err != nil {
+			return fmt.Errorf(i18n.ErrImportFailed, err)
+		}
+		ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgImportCreated, t.ID, t.Title))
+		created++
+	}
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgImportSummary, created, skipped))
+	return nil
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestRunImportGithub_CreatesAndSkipsExisting(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/octo/widgets/issues" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"number": 1, "title": "Fix crash", "labels": [{"name": "bug"}]},
+			{"number": 2, "title": "Open PR", "pull_request": {"url": "x"}}
+		]`))
+	}))
+	defer srv.Close()
+
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{ProjectRoot: tmpDir, TicketsDir: ticketsDir, GitHubAPIURL: srv.URL}
+
+	captureOutput(func() {
+		if err := runImportGithub(nil, []string{"octo/widgets"}); err != nil {
+			t.Fatalf("runImportGithub() err = %v", err)
+		}
+	})
+	store := ticket.NewStore(ticketsDir)
+	got, err := store.Load("GH-1")
+	if err != nil {
+		t.Fatalf("GH-1 not imported: %v", err)
+	}
+	if got.Type != ticket.TypeBugfix || got.Status != ticket.StatusPending {
+		t.Errorf("GH-1 = %s (%s), want pending bugfix", got.Type, got.Status)
+	}
+	if _, err := store.Load("GH-2"); err == nil {
+		t.Error("pull requests should not be imported")
+	}
+
+	// Re-running keeps the existing ticket untouched.
+	if err := store.MoveToStatus("GH-1", ticket.StatusCompleted); err != nil {
+		t.Fatal(err)
+	}
+	output := captureOutput(func() {
+		if err := runImportGithub(nil, []string{"octo/widgets"}); err != nil {
+			t.Fatalf("runImportGithub() second run err = %v", err)
+		}
+	})
+	if got, _ := store.Load("GH-1"); got == nil || got.Status != ticket.StatusCompleted {
+		t.Errorf("re-import should not reset GH-1, got %+v", got)
+	}
+	if !strings.Contains(output, fmt.Sprintf(i18n.MsgImportSummary, 0, 1)) {
+		t.Errorf("summary missing, got:\n%s", output)
+	}
+
+	if err := runImportGithub(nil, []string{"not-a-repo"}); err == nil {
+		t.Error("runImportGithub() should reject an invalid repository")
+	}
+}
+
+func TestRunImportJira(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"startAt": 0, "total": 2, "issues": [
+			{"key": "PROJ-1", "fields": {"summary": "Schema", "issuetype": {"name": "Story"}}},
+			{"key": "PROJ-2", "fields": {"summary": "API", "priority": {"name": "P0"},
+				"issuelinks": [{"type": {"name": "Blocks"}, "inwardIssue": {"key": "PROJ-1"}}]}}
+		]}`))
+	}))
+	defer srv.Close()
+
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	originalCfg := cfg
+	defer func() { cfg = originalCfg; importJQL = "" }()
+	t.Setenv("JIRA_API_TOKEN", "pat")
+
+	cfg = &config.Config{ProjectRoot: tmpDir, TicketsDir: ticketsDir}
+	if err := runImportJira(nil, nil); err == nil {
+		t.Error("runImportJira() should require jira.url")
+	}
+
+	cfg.Jira = config.JiraConfig{URL: srv.URL, PriorityMap: map[string]int{"p0": 1}}
+	importJQL = "project = PROJ"
+	captureOutput(func() {
+		if err := runImportJira(nil, nil); err != nil {
+			t.Fatalf("runImportJira() err = %v", err)
+		}
+	})
+	if gotAuth != "Bearer pat" {
+		t.Errorf("Authorization = %q, want bearer token from JIRA_API_TOKEN", gotAuth)
+	}
+	got, err := ticket.NewStore(ticketsDir).Load("PROJ-2")
+	if err != nil {
+		t.Fatalf("PROJ-2 not imported: %v", err)
+	}
+	if got.Priority != 1 || len(got.Dependencies) != 1 || got.Dependencies[0] != "PROJ-1" {
+		t.Errorf("PROJ-2 priority/deps = %d %v, want 1 [PROJ-1]", got.Priority, got.Dependencies)
+	}
+}
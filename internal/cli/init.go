@@ -138,7 +138,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 		summary, err = initAgent.ScanProject(ctx)
 		if err != nil {
-			spinner.Fail("掃描專案失敗")
+			spinner.Fail(i18n.SpinnerFailScan)
 			// Continue without summary on error
 			summary = nil
 		} else {
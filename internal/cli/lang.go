@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const langFlagName = "--lang"
+
+// parseLangFlag returns the value of --lang in args before Cobra runs, so help output,
+// which skips PersistentPreRunE, is in the requested language too.
+func parseLangFlag(args []string) string {
+	for i, a := range args {
+		if a == "--" {
+			break
+		}
+		if v, ok := strings.CutPrefix(a, langFlagName+"="); ok {
+			return v
+		}
+		if a == langFlagName && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// applyLanguage switches all messages to lang and re-localizes the help and flag usage
+// of root's command tree, which Cobra copied from the messages at init.
+func applyLanguage(root *cobra.Command, lang string) error {
+	if err := i18n.SetLanguage(lang); err != nil {
+		return err
+	}
+	localizeCommands(root)
+	return nil
+}
+
+// localizeCommands translates the help texts and flag usage of cmd and its subcommands
+// to the current language.
+func localizeCommands(cmd *cobra.Command) {
+	cmd.Short = i18n.Translate(cmd.Short)
+	cmd.Long = i18n.Translate(cmd.Long)
+	cmd.Example = i18n.Translate(cmd.Example)
+	localizeFlag := func(f *pflag.Flag) { f.Usage = i18n.Translate(f.Usage) }
+	cmd.Flags().VisitAll(localizeFlag)
+	cmd.PersistentFlags().VisitAll(localizeFlag)
+	for _, c := range cmd.Commands() {
+		localizeCommands(c)
+	}
+}
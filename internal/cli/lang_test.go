@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+)
+
+func TestParseLangFlag(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"none", []string{"status"}, ""},
+		{"separate value", []string{"--lang", "en", "status"}, "en"},
+		{"equals", []string{"status", "--lang=en"}, "en"},
+		{"missing value", []string{"status", "--lang"}, ""},
+		{"after --", []string{"add", "--", "--lang", "en"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseLangFlag(tt.args); got != tt.want {
+				t.Errorf("parseLangFlag(%v) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyLanguage(t *testing.T) {
+	defer applyLanguage(rootCmd, i18n.LangZhTW)
+	zhShort := statusCmd.Short
+
+	if err := applyLanguage(rootCmd, i18n.LangEn); err != nil {
+		t.Fatalf("applyLanguage(en) error = %v", err)
+	}
+	if statusCmd.Short != i18n.CmdStatusShort || statusCmd.Short == zhShort {
+		t.Errorf("status Short = %q, want the English %q", statusCmd.Short, i18n.CmdStatusShort)
+	}
+	if usage := rootCmd.PersistentFlags().Lookup("lang").Usage; usage != i18n.FlagLang {
+		t.Errorf("--lang usage = %q, want %q", usage, i18n.FlagLang)
+	}
+	if err := applyLanguage(rootCmd, "fr"); err == nil {
+		t.Error("applyLanguage(fr) should fail")
+	}
+
+	if err := applyLanguage(rootCmd, i18n.LangZhTW); err != nil {
+		t.Fatalf("applyLanguage(zh-TW) error = %v", err)
+	}
+	if statusCmd.Short != zhShort {
+		t.Errorf("status Short = %q after switching back, want %q", statusCmd.Short, zhShort)
+	}
+}
@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"io"
+	"log/slog"
+
+	"github.com/anthropic/agent-orchestrator/internal/logging"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// newLogger returns a logger writing to w in the configured log format at the level of
+// --verbose, --debug and --quiet. A detach child always logs JSON, and at least Info
+// records since its log file is its only output.
+func newLogger(w io.Writer) *slog.Logger {
+	format := cfg.LogFormat
+	if IsDetachChild() {
+		format = logging.FormatJSON
+	}
+	return logging.New(w, format, logging.Level(cfg.Verbose || IsDetachChild(), cfg.Debug, cfg.Quiet))
+}
+
+// setupLogging makes newLogger(w) the default logger.
+func setupLogging(w io.Writer) *slog.Logger {
+	l := newLogger(w)
+	slog.SetDefault(l)
+	return l
+}
+
+// ticketLogger returns the default logger with the ticket_id field of t.
+func ticketLogger(t *ticket.Ticket) *slog.Logger {
+	return logging.Ticket(slog.Default(), t.ID)
+}
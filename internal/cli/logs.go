@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logsFollow bool
+	logsTicket string
+)
+
+// logsPollInterval is how often logs --follow checks the file for new output.
+var logsPollInterval = 500 * time.Millisecond
+
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: i18n.CmdLogsShort,
+	Long:  i18n.CmdLogsLong,
+	Args:  cobra.NoArgs,
+	RunE:  runLogs,
+}
+
+func init() {
+	logsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, i18n.FlagLogsFollow)
+	logsCmd.Flags().StringVar(&logsTicket, "ticket", "", i18n.FlagLogsTicket)
+}
+
+func runLogs(cmd *cobra.Command, args []string) error {
+	path, filter, err := resolveLogPath(logsTicket)
+	if err != nil {
+		return err
+	}
+	// Keep stdout to the log itself so it can be piped.
+	ui.PrintInfo(os.Stderr, fmt.Sprintf(i18n.MsgLogsFile, path))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Following ends when the background work exits, so logs -f does not hang after work finishes.
+	var running func() bool
+	if logsFollow {
+		running = func() bool { return ErrIfBackgroundWorkRunning() != nil }
+	}
+	return streamLog(ctx, os.Stdout, path, filter, running)
+}
+
+// resolveLogPath picks the log to show. Without a ticket it is the latest detach log.
+// With a ticket it is the agent log recorded on the ticket when there is one; otherwise
+// the latest detach log filtered to lines mentioning the ticket ID (returned as filter).
+func resolveLogPath(ticketID string) (path, filter string, err error) {
+	if ticketID != "" {
+		store := newTicketStore()
+		t, err := store.Load(ticketID)
+		if err != nil {
+			return "", "", fmt.Errorf(i18n.ErrTicketNotFound, ticketID)
+		}
+		if t.ErrorLog != "" {
+			if _, err := os.Stat(t.ErrorLog); err == nil {
+				return t.ErrorLog, "", nil
+			}
+		}
+		filter = ticketID
+	}
+	path, err = latestDetachLog()
+	if err != nil {
+		return "", "", err
+	}
+	return path, filter, nil
+}
+
+// latestDetachLog returns the most recently modified work-*.log in the detach log directory.
+func latestDetachLog() (string, error) {
+	dir := cfg.LogsDir
+	if cfg.WorkDetachLogDir != "" {
+		dir = cfg.WorkDetachLogDir
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "work-*.log"))
+	var latest string
+	var latestMod time.Time
+	for _, m := range matches {
+		info, err := os.Stat(m)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		if latest == "" || info.ModTime().After(latestMod) {
+			latest, latestMod = m, info.ModTime()
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf(i18n.ErrLogsNotFound, dir)
+	}
+	return latest, nil
+}
+
+// streamLog copies the log at path to w, keeping only lines containing filter when it is
+// non-empty. When running is nil it stops at end of file; otherwise it keeps polling for
+// new output until ctx is cancelled or running reports false and the file is drained.
+func streamLog(ctx context.Context, w io.Writer, path, filter string, running func() bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf(i18n.ErrLogsOpen, path, err)
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	var partial string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		partial += line
+		if err == nil {
+			if filter == "" || strings.Contains(partial, filter) {
+				fmt.Fprint(w, partial)
+			}
+			partial = ""
+			continue
+		}
+
+		// At end of file: stop, or wait for the writer to append more.
+		if running != nil && !running() {
+			// The writer is gone; read once more to pick up its final output.
+			running = nil
+			continue
+		}
+		if running == nil {
+			if partial != "" && (filter == "" || strings.Contains(partial, filter)) {
+				fmt.Fprintln(w, partial)
+			}
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(logsPollInterval):
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func setupLogsConfig(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	originalCfg := cfg
+	t.Cleanup(func() { cfg = originalCfg })
+	cfg = config.DefaultConfig()
+	cfg.ProjectRoot = dir
+	cfg.TicketsDir = filepath.Join(dir, ".tickets")
+	cfg.LogsDir = filepath.Join(dir, ".agent-logs")
+	if err := os.MkdirAll(cfg.LogsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLatestDetachLog(t *testing.T) {
+	setupLogsConfig(t)
+
+	if _, err := latestDetachLog(); err == nil {
+		t.Fatal("expected error when no detach logs exist")
+	}
+
+	older := filepath.Join(cfg.LogsDir, "work-20250101-000000.log")
+	newer := filepath.Join(cfg.LogsDir, "work-20250102-000000.log")
+	other := filepath.Join(cfg.LogsDir, "agent-20250103-000000.log")
+	for _, p := range []string{older, newer, other} {
+		if err := os.WriteFile(p, []byte("x\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	past := time.Now().Add(-time.Hour)
+	_ = os.Chtimes(older, past, past)
+
+	got, err := latestDetachLog()
+	if err != nil {
+		t.Fatalf("latestDetachLog: %v", err)
+	}
+	if got != newer {
+		t.Errorf("latestDetachLog() = %s, want %s", got, newer)
+	}
+}
+
+func TestResolveLogPath_Ticket(t *testing.T) {
+	setupLogsConfig(t)
+	detachLog := filepath.Join(cfg.LogsDir, "work-20250101-000000.log")
+	agentLog := filepath.Join(cfg.LogsDir, "agent-20250101-000000.log")
+	for _, p := range []string{detachLog, agentLog} {
+		if err := os.WriteFile(p, []byte("x\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store := ticket.NewStore(cfg.TicketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatal(err)
+	}
+	failed := ticket.NewTicket("TICKET-001", "Failed", "")
+	failed.ErrorLog = agentLog
+	failed.MarkFailed(nil)
+	pending := ticket.NewTicket("TICKET-002", "Pending", "")
+	for _, tk := range []*ticket.Ticket{failed, pending} {
+		if err := store.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		ticketID   string
+		wantPath   string
+		wantFilter string
+		wantErr    bool
+	}{
+		{"", detachLog, "", false},
+		{"TICKET-001", agentLog, "", false},
+		{"TICKET-002", detachLog, "TICKET-002", false},
+		{"TICKET-404", "", "", true},
+	}
+	for _, tt := range tests {
+		path, filter, err := resolveLogPath(tt.ticketID)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("resolveLogPath(%q) error = %v, wantErr %v", tt.ticketID, err, tt.wantErr)
+			continue
+		}
+		if path != tt.wantPath || filter != tt.wantFilter {
+			t.Errorf("resolveLogPath(%q) = (%s, %q), want (%s, %q)", tt.ticketID, path, filter, tt.wantPath, tt.wantFilter)
+		}
+	}
+}
+
+func TestStreamLog_Filter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "work.log")
+	content := "start\nTICKET-001 begin\nTICKET-002 begin\nTICKET-001 done"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var all, filtered bytes.Buffer
+	if err := streamLog(context.Background(), &all, path, "", nil); err != nil {
+		t.Fatal(err)
+	}
+	if all.String() != content+"\n" {
+		t.Errorf("unfiltered output = %q", all.String())
+	}
+	if err := streamLog(context.Background(), &filtered, path, "TICKET-001", nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := "TICKET-001 begin\nTICKET-001 done\n"; filtered.String() != want {
+		t.Errorf("filtered output = %q, want %q", filtered.String(), want)
+	}
+}
+
+func TestStreamLog_FollowUntilWorkExits(t *testing.T) {
+	origInterval := logsPollInterval
+	logsPollInterval = 10 * time.Millisecond
+	defer func() { logsPollInterval = origInterval }()
+
+	path := filepath.Join(t.TempDir(), "work.log")
+	if err := os.WriteFile(path, []byte("first\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var alive atomic.Bool
+	alive.Store(true)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+		if err == nil {
+			f.WriteString("second\n")
+			f.Close()
+		}
+		alive.Store(false)
+	}()
+
+	var buf bytes.Buffer
+	done := make(chan error, 1)
+	go func() { done <- streamLog(context.Background(), &buf, path, "", alive.Load) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("streamLog did not return after work exited")
+	}
+	if !strings.Contains(buf.String(), "first\n") || !strings.Contains(buf.String(), "second\n") {
+		t.Errorf("followed output = %q, want both lines", buf.String())
+	}
+}
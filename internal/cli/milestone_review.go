@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+)
+
+// acceptanceGapLabel marks the follow-up tickets created for unmet milestone criteria.
+const acceptanceGapLabel = "acceptance-gap"
+
+// runAcceptanceReview is the last pipeline stage (run --acceptance-review). Once every
+// ticket planned from milestoneFile is completed, the agent checks the result against
+// the milestone's acceptance criteria section; unmet criteria become pending follow-up
+// tickets and the verdicts are written to a gap report next to the generated docs.
+// Skipped when tickets are still open or the milestone has no such section.
+func runAcceptanceReview(ctx context.Context, w io.Writer, caller *agent.Caller, store ticket.Storer, milestoneFile string, planned []*ticket.Ticket) error {
+	var done []*ticket.Ticket
+	for _, p := range planned {
+		t, err := store.Load(p.ID)
+		if err != nil {
+			t = p
+		}
+		if t.Status == ticket.StatusCompleted {
+			done = append(done, t)
+		}
+	}
+	if open := len(planned) - len(done); open > 0 {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgAcceptanceSkippedIncomplete, open))
+		return nil
+	}
+
+	review, err := agent.NewMilestoneReviewAgent(caller, cfg.ProjectRoot).Review(ctx, milestoneFile, withoutEpics(done))
+	if err != nil {
+		return err
+	}
+	if review == nil {
+		ui.PrintInfo(w, i18n.MsgAcceptanceNoSection)
+		return nil
+	}
+	if cfg.DryRun {
+		return nil
+	}
+
+	unmet := review.Unmet()
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgAcceptanceResult, len(review.Criteria)-len(unmet), len(review.Criteria)))
+	base := generateTicketID()
+	for i, c := range unmet {
+		t := c.FollowUp
+		t.ID = fmt.Sprintf("%s-%d", base, i+1)
+		t.Milestone = milestoneRef(milestoneFile)
+		t.Labels = append(t.Labels, acceptanceGapLabel)
+		if err := store.Save(t); err != nil {
+			return err
+		}
+		ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgAcceptanceFollowUp, t.ID, t.Title))
+	}
+
+	path := acceptanceReportPath(milestoneFile)
+	if err := writeAcceptanceReport(path, review); err != nil {
+		return err
+	}
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgAcceptanceReport, path))
+	if len(unmet) == 0 {
+		ui.PrintSuccess(w, i18n.MsgAcceptanceAllMet)
+	} else {
+		ui.PrintInfo(w, i18n.HintAcceptanceFollowUps)
+	}
+	return nil
+}
+
+// acceptanceReportPath returns where the gap report of milestoneFile is written:
+// <docs_dir>/<milestone name>-acceptance.md.
+func acceptanceReportPath(milestoneFile string) string {
+	name := strings.TrimSuffix(filepath.Base(milestoneFile), filepath.Ext(milestoneFile))
+	return filepath.Join(cfg.DocsDir, name+"-acceptance.md")
+}
+
+// writeAcceptanceReport writes the gap report of review as Markdown: the summary, then
+// the unmet criteria with their gaps and follow-up tickets, then the met criteria.
+func writeAcceptanceReport(path string, review *agent.MilestoneReview) error {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(i18n.UIAcceptanceReport, review.Milestone) + "\n\n")
+	if review.Summary != "" {
+		sb.WriteString(i18n.UIAcceptanceSummary + "\n\n" + review.Summary + "\n\n")
+	}
+	unmet := review.Unmet()
+	sb.WriteString(fmt.Sprintf(i18n.UIAcceptanceUnmet, len(unmet)) + "\n\n")
+	for _, c := range unmet {
+		sb.WriteString("- [ ] " + c.Criterion + "\n")
+		if c.Gap != "" {
+			sb.WriteString("  - " + fmt.Sprintf(i18n.MsgAcceptanceGap, c.Gap) + "\n")
+		}
+		if c.Evidence != "" {
+			sb.WriteString("  - " + fmt.Sprintf(i18n.MsgAcceptanceEvidence, c.Evidence) + "\n")
+		}
+		if c.FollowUp != nil && c.FollowUp.ID != "" {
+			sb.WriteString("  - " + fmt.Sprintf(i18n.MsgAcceptanceTicket, c.FollowUp.ID+" "+c.FollowUp.Title) + "\n")
+		}
+	}
+	sb.WriteString("\n" + fmt.Sprintf(i18n.UIAcceptanceMet, len(review.Criteria)-len(unmet)) + "\n\n")
+	for _, c := range review.Criteria {
+		if !c.Met {
+			continue
+		}
+		sb.WriteString("- [x] " + c.Criterion + "\n")
+		if c.Evidence != "" {
+			sb.WriteString("  - " + fmt.Sprintf(i18n.MsgAcceptanceEvidence, c.Evidence) + "\n")
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf(i18n.ErrAgentMkdirDocs, err)
+	}
+	return os.WriteFile(path, []byte(sb.String()), 0644)
+}
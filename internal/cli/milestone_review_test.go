@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// setupAcceptanceTest writes a milestone with an acceptance criteria section and an
+// agent that marks "logout works" unmet, and returns the milestone, caller and store.
+func setupAcceptanceTest(t *testing.T) (string, *agent.Caller, *ticket.Store) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	tmpDir := t.TempDir()
+	originalCfg := cfg
+	t.Cleanup(func() { cfg = originalCfg })
+	cfg = &config.Config{ProjectRoot: tmpDir, TicketsDir: filepath.Join(tmpDir, ".tickets"), DocsDir: filepath.Join(tmpDir, "docs")}
+
+	store := ticket.NewStore(cfg.TicketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+	milestone := filepath.Join(tmpDir, "milestone-001.md")
+	if err := os.WriteFile(milestone, []byte("# M1\n\n## 驗收標準\n- login works\n- logout works\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	script := "#!/bin/sh\nmkdir -p .tickets\ncat > .tickets/acceptance-review.json <<'EOF'\n" +
+		`{"summary": "logout missing", "criteria": [{"criterion": "login works", "met": true, "evidence": "auth_test.go"},` +
+		` {"criterion": "logout works", "met": false, "gap": "no endpoint", "follow_up": {"title": "Add logout endpoint"}}]}` + "\nEOF\n"
+	agentPath := filepath.Join(t.TempDir(), "fake-agent")
+	if err := os.WriteFile(agentPath, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return milestone, agent.NewCaller(agentPath, true, "text", ""), store
+}
+
+func TestRunAcceptanceReview_CreatesFollowUps(t *testing.T) {
+	milestone, caller, store := setupAcceptanceTest(t)
+	planned := []*ticket.Ticket{{ID: "T-1", Title: "Login", Status: ticket.StatusCompleted}}
+	if err := store.Save(planned[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	var err error
+	output := captureOutput(func() {
+		err = runAcceptanceReview(context.Background(), os.Stdout, caller, store, milestone, planned)
+	})
+	if err != nil {
+		t.Fatalf("runAcceptanceReview() error = %v", err)
+	}
+	if !strings.Contains(output, fmt.Sprintf(i18n.MsgAcceptanceResult, 1, 2)) {
+		t.Errorf("output should report 1 of 2 criteria met, got:\n%s", output)
+	}
+
+	pending, err := store.LoadByStatus(ticket.StatusPending)
+	if err != nil || len(pending) != 1 {
+		t.Fatalf("pending tickets = %v, %v; want one follow-up", pending, err)
+	}
+	followUp := pending[0]
+	if followUp.Title != "Add logout endpoint" || !followUp.HasLabels([]string{acceptanceGapLabel}) || followUp.Milestone != "milestone-001.md" {
+		t.Errorf("follow-up ticket = %+v", followUp)
+	}
+
+	report, err := os.ReadFile(acceptanceReportPath(milestone))
+	if err != nil {
+		t.Fatalf("gap report not written: %v", err)
+	}
+	for _, want := range []string{"- [ ] logout works", "- [x] login works", followUp.ID} {
+		if !strings.Contains(string(report), want) {
+			t.Errorf("gap report should contain %q, got:\n%s", want, report)
+		}
+	}
+}
+
+func TestRunAcceptanceReview_SkipsIncomplete(t *testing.T) {
+	milestone, caller, store := setupAcceptanceTest(t)
+	planned := []*ticket.Ticket{
+		{ID: "T-1", Title: "Login", Status: ticket.StatusCompleted},
+		{ID: "T-2", Title: "Logout", Status: ticket.StatusFailed},
+	}
+	for _, p := range planned {
+		if err := store.Save(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	output := captureOutput(func() {
+		if err := runAcceptanceReview(context.Background(), os.Stdout, caller, store, milestone, planned); err != nil {
+			t.Errorf("runAcceptanceReview() error = %v", err)
+		}
+	})
+	if !strings.Contains(output, fmt.Sprintf(i18n.MsgAcceptanceSkippedIncomplete, 1)) {
+		t.Errorf("output should report the skip, got:\n%s", output)
+	}
+	if _, err := os.Stat(acceptanceReportPath(milestone)); !os.IsNotExist(err) {
+		t.Errorf("no gap report should be written while tickets are open, stat err = %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var noteCmd = &cobra.Command{
+	Use:     "note <ticket-id> [message]",
+	Aliases: []string{"comment"},
+	Short:   i18n.CmdNoteShort,
+	Long:    i18n.CmdNoteLong,
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    runNote,
+}
+
+func runNote(cmd *cobra.Command, args []string) error {
+	w := os.Stdout
+	store := newTicketStore()
+	if err := store.Init(); err != nil {
+		return fmt.Errorf(i18n.ErrInitStoreFailed, err)
+	}
+	t, err := store.Load(args[0])
+	if err != nil {
+		return fmt.Errorf(i18n.ErrTicketNotFound, args[0])
+	}
+	if len(args) == 1 {
+		printNotes(w, t)
+		return nil
+	}
+	return addNote(w, store, t, strings.Join(args[1:], " "))
+}
+
+// addNote appends message to t. Unlike other writes it is allowed while background
+// work runs: the worker merges notes added during an agent call before saving the
+// ticket (see adoptStoredNotes).
+func addNote(w io.Writer, store ticket.Storer, t *ticket.Ticket, message string) error {
+	switch t.Status {
+	case ticket.StatusPending, ticket.StatusInProgress, ticket.StatusFailed:
+	default:
+		return fmt.Errorf(i18n.ErrNoteClosedTicket, t.ID, t.Status)
+	}
+	t.AddNote(auditOperator(), message)
+	if err := store.Save(t); err != nil {
+		return fmt.Errorf(i18n.ErrSaveTicketFailed, t.ID)
+	}
+	ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgNoteAdded, t.ID))
+	return nil
+}
+
+func printNotes(w io.Writer, t *ticket.Ticket) {
+	if len(t.Notes) == 0 {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgNoNotes, t.ID))
+		return
+	}
+	ui.PrintInfo(w, i18n.MsgNotes)
+	for _, n := range t.Notes {
+		ui.PrintInfo(w, "  "+noteSummary(n))
+	}
+}
+
+// noteSummary renders a note for display, e.g. "2024-06-01 12:00 alice: use the retry package".
+func noteSummary(n ticket.Note) string {
+	stamp := n.CreatedAt.Format("2006-01-02 15:04")
+	if n.Author != "" {
+		stamp += " " + n.Author
+	}
+	return ui.StyleMuted.Render(stamp+":") + " " + n.Text
+}
+
+// adoptStoredNotes merges into t the notes added to its stored copy (by note) while t
+// was being processed, so saving t does not drop them.
+func adoptStoredNotes(store ticket.Storer, t *ticket.Ticket) {
+	if stored, err := store.Load(t.ID); err == nil {
+		t.MergeNotes(stored.Notes)
+	}
+}
+
+// ticketNotes returns the notes of tickets as "ID: text" lines for the review prompt.
+func ticketNotes(tickets []*ticket.Ticket) []string {
+	var notes []string
+	for _, t := range tickets {
+		for _, n := range t.Notes {
+			notes = append(notes, t.ID+": "+n.Text)
+		}
+	}
+	return notes
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestRunNote(t *testing.T) {
+	ticketsDir := filepath.Join(t.TempDir(), ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatal(err)
+	}
+	running := ticket.NewTicket("T-1", "running", "")
+	running.Status = ticket.StatusInProgress
+	done := ticket.NewTicket("T-2", "done", "")
+	done.Status = ticket.StatusCompleted
+	for _, tk := range []*ticket.Ticket{running, done} {
+		if err := store.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: ticketsDir, AuditIdentity: "alice"}
+
+	output := captureOutput(func() {
+		if err := runNote(nil, []string{"T-1", "keep", "the public API"}); err != nil {
+			t.Errorf("runNote() error = %v", err)
+		}
+	})
+	if !strings.Contains(output, fmt.Sprintf(i18n.MsgNoteAdded, "T-1")) {
+		t.Errorf("output = %q, want the added message", output)
+	}
+	got, _ := store.Load("T-1")
+	if len(got.Notes) != 1 || got.Notes[0].Text != "keep the public API" || got.Notes[0].Author != "alice" {
+		t.Errorf("Notes = %+v, want one note by alice", got.Notes)
+	}
+
+	output = captureOutput(func() {
+		if err := runNote(nil, []string{"T-1"}); err != nil {
+			t.Errorf("runNote() error = %v", err)
+		}
+	})
+	if !strings.Contains(output, "keep the public API") {
+		t.Errorf("listing notes should show the note, got %q", output)
+	}
+
+	// status shows the latest note under the ticket.
+	if err := runNote(nil, []string{"T-1", "then run the linter"}); err != nil {
+		t.Fatal(err)
+	}
+	output = captureOutput(func() {
+		if err := runStatus(nil, nil); err != nil {
+			t.Errorf("runStatus() error = %v", err)
+		}
+	})
+	if !strings.Contains(output, fmt.Sprintf(i18n.MsgLatestNote, 2)) || !strings.Contains(output, "then run the linter") {
+		t.Errorf("status should show the latest of 2 notes, got:\n%s", output)
+	}
+
+	if err := runNote(nil, []string{"T-2", "too late"}); err == nil {
+		t.Error("runNote() on a completed ticket should fail")
+	}
+	if err := runNote(nil, []string{"T-9", "missing"}); err == nil {
+		t.Error("runNote() on an unknown ticket should fail")
+	}
+}
+
+func TestAdoptStoredNotes(t *testing.T) {
+	store := ticket.NewStore(t.TempDir())
+	if err := store.Init(); err != nil {
+		t.Fatal(err)
+	}
+	tk := ticket.NewTicket("T-1", "running", "")
+	if err := store.Save(tk); err != nil {
+		t.Fatal(err)
+	}
+
+	// A note is added while the worker holds its own copy of the ticket.
+	if err := addNote(&bytes.Buffer{}, store, mustLoad(t, store, "T-1"), "mid-run note"); err != nil {
+		t.Fatal(err)
+	}
+	adoptStoredNotes(store, tk)
+	if err := store.Save(tk); err != nil {
+		t.Fatal(err)
+	}
+	if got := mustLoad(t, store, "T-1"); len(got.Notes) != 1 || got.Notes[0].Text != "mid-run note" {
+		t.Errorf("Notes after the worker saved = %+v, want the mid-run note kept", got.Notes)
+	}
+}
+
+func mustLoad(t *testing.T, store ticket.Storer, id string) *ticket.Ticket {
+	t.Helper()
+	tk, err := store.Load(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tk
+}
+
+func TestNoteCmd_CommentAlias(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"comment"})
+	if err != nil || cmd != noteCmd {
+		t.Errorf("Find(comment) = %v, %v; want the note command", cmd, err)
+	}
+}
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/metrics"
+	"github.com/anthropic/agent-orchestrator/internal/notify"
+	"github.com/anthropic/agent-orchestrator/internal/server"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+)
+
+// maxNotifiedTicketIDs caps the failed ticket IDs listed in a summary notification.
+const maxNotifiedTicketIDs = 20
+
+// newNotifier returns the notifier for the sinks in the notifications: section.
+func newNotifier() *notify.Notifier {
+	n := &notify.Notifier{}
+	if cfg == nil {
+		return n
+	}
+	if cfg.Notifications.Command != "" {
+		n.Sinks = append(n.Sinks, notify.CommandSink{Command: cfg.Notifications.Command})
+	}
+	webhook := cfg.Notifications.SlackWebhook
+	if webhook == "" {
+		webhook = os.Getenv("SLACK_WEBHOOK_URL")
+	}
+	if webhook != "" {
+		n.Sinks = append(n.Sinks, notify.SlackSink{WebhookURL: webhook})
+	}
+	return n
+}
+
+// sendNotification delivers e to the configured sinks; failures only warn on w.
+func sendNotification(ctx context.Context, w io.Writer, e notify.Event) {
+	if err := newNotifier().Send(ctx, e); err != nil {
+		ui.PrintWarning(w, fmt.Sprintf(i18n.MsgNotifyFailed, err))
+	}
+}
+
+// summaryEvent builds the notification summarizing the ticket runs recorded in the
+// metrics history since startedAt: completed and failed counts, the failed ticket IDs
+// with the commands (and, with notifications.serve_url, the links) retrying them, the
+// tickets still pending and the log path.
+func summaryEvent(kind, title string, store ticket.Storer, startedAt time.Time, logPath string) notify.Event {
+	records, _ := metrics.Load(cfg.MetricsHistoryPath())
+	completed, failed := metrics.OutcomesSince(records, startedAt)
+	counts, _ := store.Count()
+
+	e := notify.Event{
+		Kind:    kind,
+		Title:   title,
+		Message: fmt.Sprintf(i18n.NotifyRunSummary, len(completed), len(failed), counts[ticket.StatusPending]),
+		Fields: map[string]string{
+			"completed": fmt.Sprint(len(completed)),
+			"failed":    fmt.Sprint(len(failed)),
+			"pending":   fmt.Sprint(counts[ticket.StatusPending]),
+		},
+	}
+	if len(failed) > 0 {
+		ids := failed
+		if len(ids) > maxNotifiedTicketIDs {
+			ids = append(ids[:maxNotifiedTicketIDs:maxNotifiedTicketIDs], "…")
+		}
+		e.Fields["failed_tickets"] = strings.Join(ids, ", ")
+		e.Fields["retry"] = retryCommands(failed)
+		if links := retryLinks(failed); links != "" {
+			e.Fields["retry_links"] = links
+		}
+	}
+	if logPath != "" {
+		e.Fields["log"] = logPath
+	}
+	return e
+}
+
+// retryCommands returns the retry command of each of ids, one per line, for at most
+// maxNotifiedTicketIDs tickets.
+func retryCommands(ids []string) string {
+	cmds := make([]string, 0, min(len(ids), maxNotifiedTicketIDs))
+	for _, id := range ids[:min(len(ids), maxNotifiedTicketIDs)] {
+		cmds = append(cmds, retryCommand(id))
+	}
+	return strings.Join(cmds, "\n")
+}
+
+// retryLinks returns the retry link of each of ids (see retryLink), one per line, for
+// at most maxNotifiedTicketIDs tickets; empty when retry links are not set up.
+func retryLinks(ids []string) string {
+	links := make([]string, 0, min(len(ids), maxNotifiedTicketIDs))
+	for _, id := range ids[:min(len(ids), maxNotifiedTicketIDs)] {
+		link := retryLink(id)
+		if link == "" {
+			return ""
+		}
+		links = append(links, link)
+	}
+	return strings.Join(links, "\n")
+}
+
+// retryLink returns the signed link retrying ticket id through serve at
+// notifications.serve_url (see server.RetryLink), or "" when serve_url is not set or
+// the signing key cannot be read.
+func retryLink(id string) string {
+	if cfg.Notifications.ServeURL == "" {
+		return ""
+	}
+	key, err := server.LoadRetryKey(cfg.RetryKeyPath())
+	if err != nil {
+		return ""
+	}
+	return server.RetryLink(cfg.Notifications.ServeURL, key, id, time.Now().Add(server.RetryLinkTTL))
+}
+
+// notifyRunFinished sends the summary of a run of milestoneFile started at startedAt.
+func notifyRunFinished(ctx context.Context, w io.Writer, store ticket.Storer, milestoneFile string, startedAt time.Time) {
+	if !newNotifier().Enabled() {
+		return
+	}
+	title := fmt.Sprintf(i18n.NotifyRunFinishedTitle, filepath.Base(milestoneFile))
+	sendNotification(ctx, w, summaryEvent(notify.KindRunFinished, title, store, startedAt, cfg.LogsDir))
+}
+
+// notifyWorkFinished sends the summary of a background work process (work --detach)
+// started at startedAt and logging to logPath.
+func notifyWorkFinished(ctx context.Context, w io.Writer, store ticket.Storer, startedAt time.Time, logPath string) {
+	if !newNotifier().Enabled() {
+		return
+	}
+	sendNotification(ctx, w, summaryEvent(notify.KindWorkFinished, i18n.NotifyWorkFinishedTitle, store, startedAt, logPath))
+}
+
+// notifyRepeatedFailure notifies when failed ticket t has now failed
+// notifications.repeated_failures runs in a row (and again at each multiple), going by
+// the metrics history. Call after recording the run.
+func notifyRepeatedFailure(t *ticket.Ticket) {
+	threshold := cfg.Notifications.RepeatedFailures
+	if t.Status != ticket.StatusFailed || threshold <= 0 || !newNotifier().Enabled() {
+		return
+	}
+	records, err := metrics.Load(cfg.MetricsHistoryPath())
+	if err != nil {
+		return
+	}
+	n := metrics.ConsecutiveFailures(records, t.ID)
+	if n < threshold || n%threshold != 0 {
+		return
+	}
+	e := notify.Event{
+		Kind:    notify.KindRepeatedFailure,
+		Title:   fmt.Sprintf(i18n.NotifyRepeatedFailureTitle, t.ID, n),
+		Message: t.Title,
+		Fields:  map[string]string{"ticket": t.ID, "failures": fmt.Sprint(n)},
+	}
+	if t.Error != "" {
+		e.Fields["error"] = ui.Truncate(t.Error, 200)
+	}
+	if t.ErrorLog != "" {
+		e.Fields["log"] = t.ErrorLog
+	}
+	e.Fields["retry"] = retryCommand(t.ID)
+	if link := retryLink(t.ID); link != "" {
+		e.Fields["retry_link"] = link
+	}
+	sendNotification(context.Background(), os.Stdout, e)
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/metrics"
+	"github.com/anthropic/agent-orchestrator/internal/notify"
+	"github.com/anthropic/agent-orchestrator/internal/server"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// slackRecorder is a fake Slack webhook keeping the text of every posted message.
+func slackRecorder(t *testing.T) (url string, texts func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg struct {
+			Text string `json:"text"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Errorf("webhook body: %v", err)
+		}
+		mu.Lock()
+		got = append(got, msg.Text)
+		mu.Unlock()
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), got...)
+	}
+}
+
+func TestSummaryEvent(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: filepath.Join(tmpDir, ".tickets")}
+	store := ticket.NewStore(cfg.TicketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("store.Init(): %v", err)
+	}
+	if err := store.Save(ticket.NewTicket("T-9", "later", "")); err != nil {
+		t.Fatalf("store.Save(): %v", err)
+	}
+
+	startedAt := time.Now()
+	for _, r := range []metrics.Record{
+		{TicketID: "T-0", Outcome: metrics.OutcomeFailed, StartedAt: startedAt.Add(-time.Hour)},
+		{TicketID: "T-1", Outcome: metrics.OutcomeCompleted, StartedAt: startedAt.Add(time.Second)},
+		{TicketID: "T-2", Outcome: metrics.OutcomeFailed, StartedAt: startedAt.Add(2 * time.Second)},
+	} {
+		if err := metrics.Append(cfg.MetricsHistoryPath(), r); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	e := summaryEvent(notify.KindWorkFinished, "work finished", store, startedAt, "/logs/work.log")
+	want := map[string]string{"completed": "1", "failed": "1", "pending": "1", "failed_tickets": "T-2", "log": "/logs/work.log", "retry": retryCommand("T-2")}
+	for k, v := range want {
+		if e.Fields[k] != v {
+			t.Errorf("Fields[%s] = %q, want %q (all: %v)", k, e.Fields[k], v, e.Fields)
+		}
+	}
+	if link, ok := e.Fields["retry_links"]; ok {
+		t.Errorf("Fields[retry_links] = %q without notifications.serve_url", link)
+	}
+
+	// With serve_url the failed ticket gets a link serve accepts.
+	cfg.Notifications.ServeURL = "https://ao.example.com"
+	e = summaryEvent(notify.KindWorkFinished, "work finished", store, startedAt, "/logs/work.log")
+	link, err := url.Parse(e.Fields["retry_links"])
+	if err != nil || link.Host != "ao.example.com" || link.Path != "/retry/T-2" {
+		t.Fatalf("Fields[retry_links] = %q, want a link retrying T-2 on serve_url", e.Fields["retry_links"])
+	}
+	key, err := server.LoadRetryKey(cfg.RetryKeyPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := server.New(&serveBackend{store: store})
+	api.SetRetryKey(key)
+	req := httptest.NewRequest(http.MethodGet, link.RequestURI(), nil)
+	req.Host = link.Host
+	rec := httptest.NewRecorder()
+	api.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("GET %s = %d, want the confirmation page; body %s", link, rec.Code, rec.Body)
+	}
+}
+
+func TestNotifyRepeatedFailure(t *testing.T) {
+	webhook, texts := slackRecorder(t)
+	tmpDir := t.TempDir()
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{
+		TicketsDir:    filepath.Join(tmpDir, ".tickets"),
+		Notifications: config.NotificationsConfig{SlackWebhook: webhook, RepeatedFailures: 2},
+	}
+
+	tk := ticket.NewTicket("T-1", "flaky", "")
+	tk.Status = ticket.StatusFailed
+	for i := 0; i < 4; i++ {
+		captureOutput(func() { recordTicketRun(tk, time.Now()) })
+	}
+	// Notified at the 2nd and 4th failure in a row.
+	if got := texts(); len(got) != 2 {
+		t.Errorf("notifications = %q, want 2", got)
+	}
+
+	tk.Status = ticket.StatusCompleted
+	recordTicketRun(tk, time.Now())
+	tk.Status = ticket.StatusFailed
+	captureOutput(func() { recordTicketRun(tk, time.Now()) })
+	if got := texts(); len(got) != 2 {
+		t.Errorf("a success should reset the streak, got notifications %q", got)
+	}
+}
@@ -0,0 +1,382 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"go.yaml.in/yaml/v3"
+)
+
+// pipelineFile is the pipeline definition run picks up without --pipeline, relative to
+// the project root.
+const pipelineFile = ".agent-orchestrator/pipeline.yaml"
+
+// Step types of a pipeline definition.
+const (
+	pipelineAnalyze    = "analyze"
+	pipelinePlan       = "plan"
+	pipelineWork       = "work"
+	pipelineShell      = "shell"
+	pipelineTest       = "test"
+	pipelineReview     = "review"
+	pipelineCommit     = "commit"
+	pipelineAcceptance = "acceptance"
+	pipelineVerify     = "verify"
+)
+
+// Conditions of a pipeline step (if:).
+const (
+	condAlways           = "always"
+	condSuccess          = "success"
+	condFailure          = "failure"
+	condTicketsCompleted = "tickets_completed"
+	condReviewPassed     = "review_passed"
+)
+
+// pipelineStepNames maps each step type to its default name, which for the built-in
+// steps is the stable step_changed event name run always used.
+var pipelineStepNames = map[string]string{
+	pipelineAnalyze:    stepAnalyze,
+	pipelinePlan:       stepPlanning,
+	pipelineWork:       stepCoding,
+	pipelineShell:      "shell",
+	pipelineTest:       stepTesting,
+	pipelineReview:     stepReview,
+	pipelineCommit:     stepCommitting,
+	pipelineAcceptance: stepAcceptance,
+	pipelineVerify:     stepVerify,
+}
+
+var pipelineConditions = []string{condAlways, condSuccess, condFailure, condTicketsCompleted, condReviewPassed}
+
+// pipelineDef is the ordered steps of a run: the built-in order (see defaultPipeline)
+// or a user-defined one read from a pipeline file.
+type pipelineDef struct {
+	Steps []pipelineStep `yaml:"steps"`
+}
+
+// pipelineStep is one step of a pipeline.
+type pipelineStep struct {
+	// Name identifies the step in output, step_changed events and --resume; it defaults
+	// to the step's default name (see pipelineStepNames) and must be unique.
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+	// If is the condition for running the step; empty is always.
+	If string `yaml:"if"`
+	// ContinueOnError keeps the pipeline going when the step fails. Unset, built-in
+	// steps continue (as run always did) and shell steps stop the pipeline.
+	ContinueOnError *bool `yaml:"continue_on_error"`
+	// Run is the command of a shell step, run through the platform shell.
+	Run string `yaml:"run"`
+	// Dir is the working directory of a shell step, relative to the project root.
+	Dir string `yaml:"dir"`
+	// Timeout bounds a shell step in seconds (default acceptance.DefaultTimeout).
+	Timeout int `yaml:"timeout"`
+	// Labels restrict a work step to the tickets carrying all of them.
+	Labels []string `yaml:"labels"`
+}
+
+// continues reports whether the pipeline goes on after the step failed.
+func (s pipelineStep) continues() bool {
+	if s.ContinueOnError != nil {
+		return *s.ContinueOnError
+	}
+	return s.Type != pipelineShell
+}
+
+// title is the step's heading in the human output.
+func (s pipelineStep) title() string {
+	titles := map[string]string{
+		pipelineAnalyze:    i18n.StepAnalyze,
+		pipelinePlan:       i18n.StepPlanning,
+		pipelineWork:       i18n.StepCoding,
+		pipelineShell:      i18n.StepShell,
+		pipelineTest:       i18n.StepTesting,
+		pipelineReview:     i18n.StepReview,
+		pipelineCommit:     i18n.StepCommitting,
+		pipelineAcceptance: i18n.StepAcceptance,
+		pipelineVerify:     i18n.StepVerify,
+	}
+	title := titles[s.Type]
+	if s.Name != pipelineStepNames[s.Type] {
+		title += " (" + s.Name + ")"
+	}
+	return title
+}
+
+// defaultPipeline is run's built-in step order, shaped by --analyze-first, --verify
+// and --acceptance-review.
+func defaultPipeline() *pipelineDef {
+	var types []string
+	if runAnalyzeFirst {
+		types = append(types, pipelineAnalyze)
+	}
+	types = append(types, pipelinePlan, pipelineWork)
+	if runVerifyCriteria {
+		types = append(types, pipelineVerify)
+	}
+	types = append(types, pipelineTest, pipelineReview, pipelineCommit)
+	if runAcceptance {
+		types = append(types, pipelineAcceptance)
+	}
+	def := &pipelineDef{}
+	for _, t := range types {
+		def.Steps = append(def.Steps, pipelineStep{Name: pipelineStepNames[t], Type: t})
+	}
+	return def
+}
+
+// loadPipeline reads and validates the pipeline definition at path.
+func loadPipeline(path string) (*pipelineDef, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.ErrPipelineInvalid, path, err)
+	}
+	def, err := parsePipeline(data)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.ErrPipelineInvalid, path, err)
+	}
+	return def, nil
+}
+
+// parsePipeline decodes a pipeline definition, rejecting unknown fields, and fills in
+// the default step names.
+func parsePipeline(data []byte) (*pipelineDef, error) {
+	var def pipelineDef
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&def); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if len(def.Steps) == 0 {
+		return nil, errors.New(i18n.ErrPipelineNoSteps)
+	}
+	seen := make(map[string]bool)
+	for i := range def.Steps {
+		s := &def.Steps[i]
+		name, ok := pipelineStepNames[s.Type]
+		if !ok {
+			return nil, fmt.Errorf(i18n.ErrPipelineStepType, i+1, s.Type)
+		}
+		if s.Name == "" {
+			s.Name = name
+		}
+		if seen[s.Name] {
+			return nil, fmt.Errorf(i18n.ErrPipelineStepDuplicate, i+1, s.Name)
+		}
+		seen[s.Name] = true
+		if s.If != "" && !slices.Contains(pipelineConditions, s.If) {
+			return nil, fmt.Errorf(i18n.ErrPipelineStepCondition, s.Name, s.If)
+		}
+		switch {
+		case s.Type == pipelineShell && s.Run == "":
+			return nil, fmt.Errorf(i18n.ErrPipelineStepRun, s.Name)
+		case s.Type != pipelineShell && (s.Run != "" || s.Dir != "" || s.Timeout != 0):
+			return nil, fmt.Errorf(i18n.ErrPipelineStepOption, s.Name, "run/dir/timeout", pipelineShell)
+		case s.Type != pipelineWork && len(s.Labels) > 0:
+			return nil, fmt.Errorf(i18n.ErrPipelineStepOption, s.Name, "labels", pipelineWork)
+		case s.Timeout < 0:
+			return nil, fmt.Errorf(i18n.ErrPipelineStepTimeout, s.Name)
+		}
+	}
+	return &def, nil
+}
+
+// between returns the steps from step from through step until, each given by name or
+// type (the first step of that type); empty leaves that end open.
+func (d *pipelineDef) between(from, until string) (*pipelineDef, error) {
+	start, end := 0, len(d.Steps)-1
+	if from != "" {
+		if start = d.index(from); start < 0 {
+			return nil, fmt.Errorf(i18n.ErrRunStepUnknown, from, strings.Join(d.names(), ", "))
+		}
+	}
+	if until != "" {
+		if end = d.index(until); end < 0 {
+			return nil, fmt.Errorf(i18n.ErrRunStepUnknown, until, strings.Join(d.names(), ", "))
+		}
+	}
+	if start > end {
+		return nil, fmt.Errorf(i18n.ErrRunStepRange, from, until)
+	}
+	return &pipelineDef{Steps: d.Steps[start : end+1]}, nil
+}
+
+// index returns the position of the step named ref, else of the first step of type
+// ref, else -1.
+func (d *pipelineDef) index(ref string) int {
+	if i := slices.IndexFunc(d.Steps, func(s pipelineStep) bool { return s.Name == ref }); i >= 0 {
+		return i
+	}
+	return slices.IndexFunc(d.Steps, func(s pipelineStep) bool { return s.Type == ref })
+}
+
+func (d *pipelineDef) names() []string {
+	names := make([]string, len(d.Steps))
+	for i, s := range d.Steps {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// withoutSkipped drops the steps --skip-test, --skip-review and --skip-commit skip.
+func (d *pipelineDef) withoutSkipped() *pipelineDef {
+	skipped := map[string]bool{
+		pipelineTest:   runSkipTest,
+		pipelineReview: runSkipReview,
+		pipelineCommit: runSkipCommit,
+	}
+	out := &pipelineDef{}
+	for _, s := range d.Steps {
+		if !skipped[s.Type] {
+			out.Steps = append(out.Steps, s)
+		}
+	}
+	return out
+}
+
+// resolvePipeline returns the pipeline of a run: path when given, else the project's
+// pipeline file when there is one, else the built-in order. The returned path is empty
+// for the built-in order.
+func resolvePipeline(path string) (*pipelineDef, string, error) {
+	if path == "" {
+		candidate := filepath.Join(cfg.ProjectRoot, pipelineFile)
+		if _, err := os.Stat(candidate); err == nil {
+			path = candidate
+		}
+	}
+	if path == "" {
+		return defaultPipeline(), "", nil
+	}
+	def, err := loadPipeline(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return def, path, nil
+}
+
+// pipelineRun is the state the steps of one run share.
+type pipelineRun struct {
+	ctx       context.Context
+	w         io.Writer
+	caller    *agent.Caller
+	store     ticket.Storer
+	milestone string
+	state     *runState
+	snapshot  *runSnapshot
+	startedAt time.Time
+	// tickets are those the plan step created.
+	tickets []*ticket.Ticket
+	// completed and failed count the tickets the work steps finished in this process;
+	// completedIDs also has those completed before --resume, for the review step.
+	completed, failed int
+	completedIDs      []string
+	// detached is set once the remaining work was handed to a background work.
+	detached bool
+}
+
+// pipelineStepFunc runs one step and reports whether it succeeded. An error ends the
+// run right away; a failed step ends it only when the step does not continue on error.
+type pipelineStepFunc func(r *pipelineRun, s pipelineStep) (bool, error)
+
+// pipelineStepFuncs are the implementations of the step types; see pipeline_steps.go.
+var pipelineStepFuncs map[string]pipelineStepFunc
+
+func init() {
+	pipelineStepFuncs = map[string]pipelineStepFunc{
+		pipelineAnalyze:    (*pipelineRun).analyze,
+		pipelinePlan:       (*pipelineRun).plan,
+		pipelineWork:       (*pipelineRun).work,
+		pipelineShell:      (*pipelineRun).shell,
+		pipelineTest:       (*pipelineRun).test,
+		pipelineReview:     (*pipelineRun).review,
+		pipelineCommit:     (*pipelineRun).commit,
+		pipelineAcceptance: (*pipelineRun).acceptance,
+		pipelineVerify:     (*pipelineRun).verify,
+	}
+}
+
+// conditionMet reports whether a step with condition cond runs, given the steps so far.
+func (r *pipelineRun) conditionMet(cond string) bool {
+	switch cond {
+	case condSuccess:
+		return len(r.state.Failed) == 0
+	case condFailure:
+		return len(r.state.Failed) > 0
+	case condTicketsCompleted:
+		return len(r.completedIDs) > 0
+	case condReviewPassed:
+		return r.state.ReviewPassed != nil && *r.state.ReviewPassed
+	default:
+		return true
+	}
+}
+
+// execute runs the steps in order, checkpointing each into the run state. It returns
+// early, keeping the checkpoint, when the run is interrupted or a stopping step fails.
+func (r *pipelineRun) execute(def *pipelineDef) error {
+	total := len(def.Steps)
+	for i, s := range def.Steps {
+		ui.PrintStep(r.w, i+1, total, s.title())
+		emitStepChanged(s.Name, i+1, total)
+
+		if r.state.done(s.Name) {
+			ui.PrintInfo(r.w, "  "+i18n.MsgRunStepAlreadyDone)
+			if s.Type == pipelinePlan {
+				r.tickets = r.state.tickets(r.store)
+			}
+			continue
+		}
+		if !r.conditionMet(s.If) {
+			ui.PrintInfo(r.w, "  "+fmt.Sprintf(i18n.MsgPipelineStepSkipped, s.If))
+			continue
+		}
+
+		// A step retried by --resume after it stopped the run starts over clean.
+		r.state.Failed = slices.DeleteFunc(r.state.Failed, func(name string) bool { return name == s.Name })
+		ok, err := pipelineStepFuncs[s.Type](r, s)
+		if err != nil {
+			return err
+		}
+		if r.ctx.Err() != nil {
+			ui.PrintWarning(r.w, i18n.MsgPipelineInterrupted)
+			ui.PrintInfo(r.w, i18n.HintRunResume)
+			return nil
+		}
+		if r.detached {
+			return nil
+		}
+		if !ok {
+			r.state.Failed = append(r.state.Failed, s.Name)
+			if !s.continues() {
+				r.checkpointFailed()
+				return fmt.Errorf(i18n.ErrPipelineStepFailed, s.Name)
+			}
+		}
+		if err := r.state.complete(s.Name); err != nil {
+			ui.PrintWarning(r.w, fmt.Sprintf(i18n.ErrRunStateSave, err))
+		}
+	}
+	return nil
+}
+
+// checkpointFailed saves the run state after a stopping step failed, leaving the step
+// itself to be retried by --resume.
+func (r *pipelineRun) checkpointFailed() {
+	if err := r.state.save(); err != nil {
+		ui.PrintWarning(r.w, fmt.Sprintf(i18n.ErrRunStateSave, err))
+	}
+	ui.PrintInfo(r.w, i18n.HintRunResume)
+}
@@ -0,0 +1,388 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/acceptance"
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+	orcherrors "github.com/anthropic/agent-orchestrator/internal/errors"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/redact"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+)
+
+// analyze turns the issues an analysis of the project finds into tickets. Analysis
+// failure is recoverable.
+func (r *pipelineRun) analyze(s pipelineStep) (bool, error) {
+	analyzeAgent := agent.NewAnalyzeAgent(r.caller, cfg.ProjectRoot)
+	issues, err := analyzeAgent.Analyze(r.ctx, agent.AllScopes())
+	if err != nil {
+		ui.PrintWarning(r.w, orcherrors.ErrAnalysis(err).Error())
+		return false, nil
+	}
+	if issues.Count() == 0 {
+		return true, nil
+	}
+	ui.PrintInfo(r.w, fmt.Sprintf(i18n.MsgFoundIssues, issues.Count()))
+	ticketList := issues.ToTickets()
+	for _, t := range ticketList.Tickets {
+		if err := r.store.Save(t); err != nil {
+			// Ticket save failure is recoverable - log and continue
+			ui.PrintWarning(r.w, orcherrors.ErrSaveTicket(t.ID, err).Error())
+		}
+	}
+	if err := r.snapshot.addTickets(ticketList.Tickets); err != nil {
+		ui.PrintWarning(r.w, err.Error())
+	}
+	return true, nil
+}
+
+// plan breaks the milestone into tickets. Planning failure is fatal. With
+// --detach-after-plan the remaining work is handed to a background work.
+func (r *pipelineRun) plan(s pipelineStep) (bool, error) {
+	planningAgent := agent.NewPlanningAgent(r.caller, cfg.ProjectRoot, cfg.TicketsDir)
+	tickets, err := planningAgent.Plan(r.ctx, r.milestone)
+	if err != nil {
+		return false, orcherrors.ErrPlanning(err)
+	}
+	for _, t := range tickets {
+		if err := r.store.Save(t); err != nil {
+			// Ticket save failure is recoverable - log and continue
+			ui.PrintWarning(r.w, orcherrors.ErrSaveTicket(t.ID, err).Error())
+		}
+		r.state.Tickets = append(r.state.Tickets, t.ID)
+	}
+	if err := r.snapshot.addTickets(tickets); err != nil {
+		ui.PrintWarning(r.w, err.Error())
+	}
+	r.tickets = tickets
+	ui.PrintSuccess(r.w, fmt.Sprintf(i18n.MsgGeneratedTickets, len(tickets)))
+
+	if runDetachAfterPlan && r.ctx.Err() == nil {
+		return true, r.detachWork()
+	}
+	return true, nil
+}
+
+// detachWork starts the remaining work in a background work and drops the checkpoint,
+// which the background work takes over.
+func (r *pipelineRun) detachWork() error {
+	params, err := buildWorkDetachParams(nil)
+	if err != nil {
+		return err
+	}
+	pid, err := execWorkDetach(params)
+	if err != nil {
+		return err
+	}
+	if params.LogPath != "" {
+		ui.PrintSuccess(r.w, fmt.Sprintf(i18n.MsgRunDetachCodingDetached, pid, params.LogPath))
+	} else {
+		ui.PrintSuccess(r.w, fmt.Sprintf(i18n.MsgRunDetachCodingDetachedNoLog, pid))
+	}
+	ui.PrintInfo(r.w, i18n.MsgRunDetachHintNextSteps)
+	if err := r.state.remove(); err != nil {
+		ui.PrintWarning(r.w, fmt.Sprintf(i18n.ErrRunStateSave, err))
+	}
+	r.detached = true
+	return nil
+}
+
+// work codes the processable tickets, restricted to the step's labels, until none is
+// left. It fails when any ticket failed; a systemic failure streak aborts the run.
+func (r *pipelineRun) work(s pipelineStep) (bool, error) {
+	codingAgent := newCodingAgent(r.caller, cfg.ProjectRoot)
+	resolver := ticket.NewDependencyResolver(r.store)
+	streak := newFailureStreak()
+	completed, failed := 0, 0
+
+	maxIterations := 20
+	for iteration := 0; iteration < maxIterations && r.ctx.Err() == nil; iteration++ {
+		epics, _ := resolver.CompleteEpics()
+		for _, e := range epics {
+			ui.PrintSuccess(r.w, fmt.Sprintf(i18n.MsgEpicCompleted, e.ID))
+		}
+
+		processable, _ := resolver.GetProcessable()
+		processable = ticket.FilterByLabels(processable, s.Labels)
+		if len(processable) == 0 {
+			break
+		}
+		processable, _ = resolver.DeferSoftDependents(processable)
+		escalated, _ := resolver.Prioritize(processable, escalationRule())
+		printEscalations(r.w, escalated)
+
+		for _, t := range processable {
+			if err := waitForBudget(r.ctx, func(d time.Duration) {
+				ui.PrintWarning(r.w, fmt.Sprintf(i18n.MsgBudgetPaused, t.ID, d.Round(time.Second)))
+			}); err != nil {
+				break
+			}
+			claimTicket(t)
+			if err := r.store.Save(t); err != nil {
+				ui.PrintWarning(r.w, orcherrors.ErrSaveTicket(t.ID, err).Error())
+			}
+
+			if err := checkoutTicketBranch(r.ctx, t, r.state.BranchBase); err != nil {
+				ui.PrintError(r.w, fmt.Sprintf(i18n.ErrTicketBranch, t.ID, err))
+				t.MarkFailed(fmt.Errorf(i18n.ErrTicketBranch, t.ID, err))
+				failed++
+				if err := r.store.Save(t); err != nil {
+					ui.PrintWarning(r.w, orcherrors.ErrSaveTicket(t.ID, err).Error())
+				}
+				continue
+			}
+
+			baseline := dodBaseline(r.ctx, t, cfg.ProjectRoot)
+			aborted := false
+			ticketStartedAt := time.Now()
+			emitTicketStarted("run", t)
+			usageBefore := r.caller.Usage()
+			result, err := codingAgent.Execute(r.ctx, t)
+			recordTicketUsage(t, r.caller.Usage().Sub(usageBefore))
+			adoptStoredNotes(r.store, t)
+			if err != nil || !result.Success {
+				t.MarkFailed(fmt.Errorf("execution failed"))
+				salvagePartialOutput(t, result)
+				failed++
+				aborted = streak.Failure(agentFailureMessage(err, result))
+			} else if err := checkDefinitionOfDone(r.ctx, r.w, t, cfg.ProjectRoot, baseline, result, workLenient); err != nil {
+				ui.PrintError(r.w, err.Error())
+				t.MarkFailed(err)
+				failed++
+			} else {
+				t.MarkCompleted(redact.String(result.Output))
+				completed++
+				r.completedIDs = append(r.completedIDs, t.ID)
+				if err := r.state.addCompleted(t.ID); err != nil {
+					ui.PrintWarning(r.w, fmt.Sprintf(i18n.ErrRunStateSave, err))
+				}
+				streak.Success()
+			}
+			recordTicketRun(t, ticketStartedAt)
+			if err := r.store.Save(t); err != nil {
+				ui.PrintWarning(r.w, orcherrors.ErrSaveTicket(t.ID, err).Error())
+			}
+			emitTicketCompleted("run", t, ticketStartedAt)
+			if aborted {
+				r.completed += completed
+				r.failed += failed
+				ui.PrintSuccess(r.w, fmt.Sprintf("  "+i18n.MsgCountCompleted+", "+i18n.MsgCountFailed, completed, failed))
+				counts, _ := r.store.Count()
+				emitRunSummary("run", r.completed, r.failed, counts[ticket.StatusPending], r.caller.Usage().Tokens(), usageCost(r.caller.Usage()), r.startedAt)
+				return false, reportSystemicAbort(r.ctx, r.w, "run", streak)
+			}
+		}
+	}
+
+	r.completed += completed
+	r.failed += failed
+	ui.PrintSuccess(r.w, fmt.Sprintf("  "+i18n.MsgCountCompleted+", "+i18n.MsgCountFailed, completed, failed))
+	return failed == 0, nil
+}
+
+// shell runs the step's command through the platform shell in its dir, showing the
+// tail of its output; a non-zero exit fails the step.
+func (r *pipelineRun) shell(s pipelineStep) (bool, error) {
+	dir := cfg.ProjectRoot
+	if s.Dir != "" {
+		dir = filepath.Join(cfg.ProjectRoot, s.Dir)
+	}
+	ui.PrintInfo(r.w, "  $ "+s.Run)
+	res := acceptance.Run(r.ctx, dir, []ticket.Assertion{{Command: s.Run, TimeoutSec: s.Timeout}})[0]
+	if out := strings.TrimRight(res.Output, "\n"); out != "" {
+		ui.PrintInfo(r.w, ui.StyleMuted.Render(out))
+	}
+	if !res.Passed {
+		ui.PrintError(r.w, "  "+fmt.Sprintf(i18n.MsgPipelineShellFailed, s.Name, res.Error))
+		return false, nil
+	}
+	ui.PrintSuccess(r.w, "  "+fmt.Sprintf(i18n.MsgPipelineShellPassed, s.Name))
+	return true, nil
+}
+
+// test runs the project's tests through the test agent; coverage below min_coverage
+// fails the step. Test failure is recoverable.
+func (r *pipelineRun) test(s pipelineStep) (bool, error) {
+	testAgent := agent.NewTestAgent(r.caller, cfg.ProjectRoot)
+	testAgent.SetMode(cfg.TestMode)
+	testAgent.SetJUnitReports(cfg.TestJUnitReports)
+	testAgent.SetRetries(cfg.TestRetries)
+	result, testResult, err := testAgent.RunTests(r.ctx)
+	if err != nil {
+		ui.PrintWarning(r.w, orcherrors.ErrTest(err).Error())
+		return false, nil
+	}
+	ui.PrintSuccess(r.w, "  "+i18n.MsgTestComplete)
+	if testResult != nil && testResult.Coverage != nil {
+		ui.PrintInfo(r.w, fmt.Sprintf(i18n.MsgTestCoverage, *testResult.Coverage))
+	}
+	if testResult != nil && len(testResult.Flaky) > 0 {
+		ui.PrintWarning(r.w, fmt.Sprintf(i18n.MsgTestFlaky, strings.Join(testResult.Flaky, ", ")))
+	}
+	if err := checkCoverage(testResult); err != nil {
+		ui.PrintError(r.w, "  "+err.Error())
+		return false, nil
+	}
+	return result.Success, nil
+}
+
+// review reviews the changed files with the notes of the tickets the run completed,
+// recording the outcome for the review_passed condition. With --review-fix-iterations,
+// changes requested by the review are handed back to the coding agent with each
+// ticket's context and the result reviewed again, until it is approved or the
+// iterations run out. Review failure is recoverable.
+func (r *pipelineRun) review(s pipelineStep) (bool, error) {
+	reviewAgent := agent.NewReviewAgent(r.caller, cfg.ProjectRoot)
+	var reviewed []*ticket.Ticket
+	for _, id := range r.completedIDs {
+		if t, err := r.store.Load(id); err == nil {
+			reviewed = append(reviewed, t)
+		}
+	}
+	reviewAgent.SetNotes(ticketNotes(reviewed))
+	reviewAgent.SetBatching(cfg.ReviewBatchSize, cfg.MaxParallel)
+
+	passed, blocked := true, false
+	for iteration := 0; ; iteration++ {
+		files := getGitChangedFiles(r.ctx)
+		if len(files) == 0 {
+			ui.PrintInfo(r.w, "  "+i18n.MsgNoFilesToReview)
+			break
+		}
+		diff, _ := reviewDiff(r.ctx, "", files)
+		reviewAgent.SetDiff(diff)
+		result, reviewResult, err := reviewAgent.Review(r.ctx, files)
+		if err != nil {
+			ui.PrintWarning(r.w, orcherrors.ErrReview(err).Error())
+			passed = false
+			break
+		}
+		ui.PrintSuccess(r.w, "  "+i18n.MsgReviewComplete)
+		recordReviewFindings(strings.Join(r.completedIDs, ","), reviewResult)
+		changesRequested := reviewResult != nil && reviewResult.Status == "CHANGES_REQUESTED"
+		passed = result.Success && !changesRequested
+		blocked = reviewResult.Blocks(cfg.ReviewBlockOn)
+		if !changesRequested || r.ctx.Err() != nil {
+			break
+		}
+		if iteration >= runReviewFixIterations {
+			if runReviewFixIterations > 0 {
+				ui.PrintWarning(r.w, "  "+fmt.Sprintf(i18n.MsgReviewFixExhausted, runReviewFixIterations))
+			}
+			break
+		}
+		if len(reviewed) == 0 {
+			ui.PrintWarning(r.w, "  "+i18n.MsgReviewFixNoTickets)
+			break
+		}
+		ui.PrintInfo(r.w, fmt.Sprintf(i18n.MsgReviewFixIteration, iteration+1, runReviewFixIterations, len(reviewResult.Issues)))
+		r.fixReview(reviewed, reviewResult)
+	}
+	if blocked {
+		ui.PrintWarning(r.w, "  "+fmt.Sprintf(i18n.MsgReviewBlocksCommit, strings.Join(cfg.ReviewBlockOn, ", ")))
+	}
+	r.state.ReviewPassed = &passed
+	r.state.ReviewBlocked = blocked
+	return passed, nil
+}
+
+// fixReview asks the coding agent to address the review's feedback on each ticket.
+func (r *pipelineRun) fixReview(tickets []*ticket.Ticket, reviewResult *agent.ReviewResult) {
+	codingAgent := newCodingAgent(r.caller, cfg.ProjectRoot)
+	for _, t := range tickets {
+		if r.ctx.Err() != nil {
+			return
+		}
+		usageBefore := r.caller.Usage()
+		result, err := codingAgent.Fix(r.ctx, t, reviewResult)
+		recordTicketUsage(t, r.caller.Usage().Sub(usageBefore))
+		if err != nil || !result.Success {
+			ui.PrintWarning(r.w, "  "+fmt.Sprintf(i18n.MsgReviewFixFailed, t.ID, agentFailureMessage(err, result)))
+		}
+		if err := r.store.Save(t); err != nil {
+			ui.PrintWarning(r.w, orcherrors.ErrSaveTicket(t.ID, err).Error())
+		}
+	}
+}
+
+// commit commits the changes of each completed ticket on its branch, unless the review
+// found issues of a review_block_on severity.
+func (r *pipelineRun) commit(s pipelineStep) (bool, error) {
+	if r.state.ReviewBlocked {
+		ui.PrintWarning(r.w, "  "+i18n.MsgCommitBlockedByReview)
+		return false, nil
+	}
+	completedTickets, _ := r.store.LoadByStatus(ticket.StatusCompleted)
+	commitAgent := agent.NewCommitAgent(r.caller, cfg.ProjectRoot)
+
+	commitCount := 0
+	for _, t := range completedTickets {
+		changedFiles := getGitChangedFiles(r.ctx)
+		if len(changedFiles) == 0 {
+			break
+		}
+		filesToStage := filesForTicket(t, changedFiles)
+		if filesToStage == nil {
+			filesToStage = changedFiles
+		}
+		if len(filesToStage) == 0 {
+			continue
+		}
+		changes := getGitStatusForFiles(r.ctx, filesToStage)
+		if changes == "" {
+			continue
+		}
+		if err := checkoutTicketBranch(r.ctx, t, r.state.BranchBase); err != nil {
+			ui.PrintWarning(r.w, fmt.Sprintf(i18n.ErrTicketBranch, t.ID, err))
+			continue
+		}
+		result, err := commitAgent.Commit(r.ctx, t.ID, t.Title, changes, filesToStage)
+		if err == nil && result.Success {
+			commitCount++
+		}
+	}
+
+	ui.PrintSuccess(r.w, fmt.Sprintf("  "+i18n.MsgCommitCount, commitCount))
+	return true, nil
+}
+
+// acceptance reviews the milestone against the planned tickets. Acceptance review
+// failure is recoverable.
+func (r *pipelineRun) acceptance(s pipelineStep) (bool, error) {
+	if err := runAcceptanceReview(r.ctx, r.w, r.caller, r.store, r.milestone, r.tickets); err != nil {
+		ui.PrintWarning(r.w, err.Error())
+		return false, nil
+	}
+	return true, nil
+}
+
+// verify checks the acceptance criteria of the tickets the run completed, recording
+// the verdicts on each ticket. A failed criterion fails the step; verification errors
+// are recoverable.
+func (r *pipelineRun) verify(s pipelineStep) (bool, error) {
+	var tickets []*ticket.Ticket
+	for _, id := range r.completedIDs {
+		if t, err := r.store.Load(id); err == nil {
+			tickets = append(tickets, t)
+		}
+	}
+	if len(tickets) == 0 {
+		ui.PrintInfo(r.w, "  "+i18n.MsgVerifyNothing)
+		return true, nil
+	}
+	failed, err := verifyTickets(r.ctx, r.w, r.caller, r.store, tickets)
+	if err != nil {
+		ui.PrintWarning(r.w, err.Error())
+		return false, nil
+	}
+	if len(failed) > 0 {
+		ui.PrintWarning(r.w, fmt.Sprintf(i18n.ErrVerifyFailed, len(failed), strings.Join(failed, ", ")))
+		return false, nil
+	}
+	return true, nil
+}
@@ -0,0 +1,388 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestParsePipeline(t *testing.T) {
+	tests := []struct {
+		name      string
+		yaml      string
+		wantNames []string
+		wantErr   string
+	}{
+		{
+			name: "default names",
+			yaml: `steps:
+  - type: plan
+  - type: work
+    labels: [backend]
+  - type: shell
+    run: go vet ./...
+  - type: commit
+    if: success`,
+			wantNames: []string{stepPlanning, stepCoding, "shell", stepCommitting},
+		},
+		{
+			name: "named shell steps",
+			yaml: `steps:
+  - {type: shell, name: lint, run: make lint, dir: web, timeout: 60}
+  - {type: shell, name: notify, run: ./notify.sh, if: failure}`,
+			wantNames: []string{"lint", "notify"},
+		},
+		{name: "empty", yaml: ``, wantErr: i18n.ErrPipelineNoSteps},
+		{name: "unknown field", yaml: "steps:\n  - type: plan\n    when: always", wantErr: "field when not found"},
+		{name: "unknown type", yaml: "steps:\n  - type: deploy", wantErr: fmt.Sprintf(i18n.ErrPipelineStepType, 1, "deploy")},
+		{
+			name:    "duplicate name",
+			yaml:    "steps:\n  - {type: shell, run: a}\n  - {type: shell, run: b}",
+			wantErr: fmt.Sprintf(i18n.ErrPipelineStepDuplicate, 2, "shell"),
+		},
+		{
+			name:    "unknown condition",
+			yaml:    "steps:\n  - {type: review, if: sometimes}",
+			wantErr: fmt.Sprintf(i18n.ErrPipelineStepCondition, stepReview, "sometimes"),
+		},
+		{name: "shell without run", yaml: "steps:\n  - type: shell", wantErr: fmt.Sprintf(i18n.ErrPipelineStepRun, "shell")},
+		{
+			name:    "run on a built-in step",
+			yaml:    "steps:\n  - {type: test, run: go test ./...}",
+			wantErr: fmt.Sprintf(i18n.ErrPipelineStepOption, stepTesting, "run/dir/timeout", "shell"),
+		},
+		{
+			name:    "labels outside work",
+			yaml:    "steps:\n  - {type: commit, labels: [x]}",
+			wantErr: fmt.Sprintf(i18n.ErrPipelineStepOption, stepCommitting, "labels", "work"),
+		},
+		{
+			name:    "negative timeout",
+			yaml:    "steps:\n  - {type: shell, run: a, timeout: -1}",
+			wantErr: fmt.Sprintf(i18n.ErrPipelineStepTimeout, "shell"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def, err := parsePipeline([]byte(tt.yaml))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parsePipeline() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsePipeline() error = %v", err)
+			}
+			var names []string
+			for _, s := range def.Steps {
+				names = append(names, s.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantNames, ",") {
+				t.Errorf("step names = %v, want %v", names, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestDefaultPipeline_WithoutSkipped(t *testing.T) {
+	defer func() {
+		runAnalyzeFirst, runVerifyCriteria, runAcceptance, runSkipTest, runSkipCommit = false, false, false, false, false
+	}()
+	runAnalyzeFirst, runVerifyCriteria, runAcceptance, runSkipTest, runSkipCommit = true, true, true, true, true
+
+	var names []string
+	for _, s := range defaultPipeline().withoutSkipped().Steps {
+		names = append(names, s.Name)
+	}
+	want := []string{stepAnalyze, stepPlanning, stepCoding, stepVerify, stepReview, stepAcceptance}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("default pipeline = %v, want %v", names, want)
+	}
+}
+
+func TestRunPipeline_PipelineFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell steps use sh syntax")
+	}
+	tmpDir, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	milestone := createMilestoneFile(t, tmpDir, "# Milestone")
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = createTestConfig(tmpDir)
+	cfg.ProjectRoot = tmpDir
+
+	pipeline := `steps:
+  - {type: shell, name: build, run: echo built > build.txt}
+  - {type: shell, name: lint, run: exit 3, continue_on_error: true}
+  - {type: shell, name: on-failure, run: touch recovered, if: failure}
+  - {type: shell, name: on-success, run: touch never, if: success}
+  - {type: shell, name: gate, run: exit 1}
+  - {type: shell, name: after-gate, run: touch unreachable}
+`
+	dir := filepath.Join(tmpDir, filepath.Dir(pipelineFile))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, pipelineFile), []byte(pipeline), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var err error
+	output := captureOutput(func() {
+		err = runPipeline(runCmd, []string{milestone})
+	})
+	if err == nil || err.Error() != fmt.Sprintf(i18n.ErrPipelineStepFailed, "gate") {
+		t.Fatalf("runPipeline() error = %v, want the gate step to stop the run\n%s", err, output)
+	}
+	for file, want := range map[string]bool{"build.txt": true, "recovered": true, "never": false, "unreachable": false} {
+		if _, err := os.Stat(filepath.Join(tmpDir, file)); (err == nil) != want {
+			t.Errorf("%s exists = %v, want %v\n%s", file, err == nil, want, output)
+		}
+	}
+	if !strings.Contains(output, fmt.Sprintf(i18n.MsgPipelineStepSkipped, condSuccess)) {
+		t.Errorf("output should say the success step was skipped, got:\n%s", output)
+	}
+
+	// The stopped run keeps its checkpoint; the failed gate is retried on resume.
+	state, err := loadRunState()
+	if err != nil || state == nil {
+		t.Fatalf("loadRunState() = %v, %v", state, err)
+	}
+	if !state.done("on-failure") || state.done("gate") || state.Pipeline == "" {
+		t.Errorf("run state = %+v", state)
+	}
+}
+
+func TestPipelineDef_Between(t *testing.T) {
+	def := &pipelineDef{Steps: []pipelineStep{
+		{Name: stepPlanning, Type: pipelinePlan},
+		{Name: stepCoding, Type: pipelineWork},
+		{Name: "lint", Type: pipelineShell},
+		{Name: stepTesting, Type: pipelineTest},
+		{Name: stepCommitting, Type: pipelineCommit},
+	}}
+	tests := []struct {
+		name        string
+		from, until string
+		want        []string
+		wantErr     string
+	}{
+		{name: "whole pipeline", want: []string{stepPlanning, stepCoding, "lint", stepTesting, stepCommitting}},
+		{name: "until type", until: "work", want: []string{stepPlanning, stepCoding}},
+		{name: "from type", from: "test", want: []string{stepTesting, stepCommitting}},
+		{name: "by name", from: "coding", until: "lint", want: []string{stepCoding, "lint"}},
+		{name: "by shell type", from: "shell", until: "shell", want: []string{"lint"}},
+		{name: "unknown step", until: "deploy", wantErr: fmt.Sprintf(i18n.ErrRunStepUnknown, "deploy", "planning, coding, lint, testing, committing")},
+		{name: "reversed", from: "commit", until: "plan", wantErr: fmt.Sprintf(i18n.ErrRunStepRange, "commit", "plan")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := def.between(tt.from, tt.until)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("between() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("between() error = %v", err)
+			}
+			if strings.Join(got.names(), ",") != strings.Join(tt.want, ",") {
+				t.Errorf("between(%q, %q) = %v, want %v", tt.from, tt.until, got.names(), tt.want)
+			}
+		})
+	}
+}
+
+func TestPipelineRun_ReviewFixIterations(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	tests := []struct {
+		name       string
+		iterations int
+		fixesNeed  int // fixes before the review approves
+		wantPassed bool
+		wantFixes  int
+		wantOutput string
+	}{
+		{name: "report only", iterations: 0, fixesNeed: 1, wantPassed: false, wantFixes: 0},
+		{name: "approved after a fix", iterations: 3, fixesNeed: 1, wantPassed: true, wantFixes: 1},
+		{name: "budget exhausted", iterations: 2, fixesNeed: 5, wantPassed: false, wantFixes: 2, wantOutput: fmt.Sprintf(i18n.MsgReviewFixExhausted, 2)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := exec.Command("git", "-C", tmpDir, "init").Run(); err != nil {
+				t.Fatalf("git init: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tmpDir, "api.go"), []byte("package api\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			// Fix prompts append to fixes; reviews request changes until enough fixes were made.
+			fixes := filepath.Join(tmpDir, "fixes")
+			script := fmt.Sprintf(`#!/bin/sh
+case "$*" in
+*"## 審查意見"*) echo fix >> %[1]s; echo fixed ;;
+*) if [ "$(cat %[1]s 2>/dev/null | wc -l)" -ge %[2]d ]; then echo "狀態: APPROVED"; else printf '狀態: CHANGES_REQUESTED\n問題:\n- parse ignores the error\n'; fi ;;
+esac
+`, fixes, tt.fixesNeed)
+			agentPath := filepath.Join(t.TempDir(), "fake-agent")
+			if err := os.WriteFile(agentPath, []byte(script), 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			originalCfg := cfg
+			defer func() { cfg = originalCfg; runReviewFixIterations = 0 }()
+			cfg = &config.Config{ProjectRoot: tmpDir, TicketsDir: t.TempDir(), AgentCommand: agentPath}
+			runReviewFixIterations = tt.iterations
+			store := ticket.NewStore(cfg.TicketsDir)
+			if err := store.Init(); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.Save(ticket.NewTicket("T-1", "api", "")); err != nil {
+				t.Fatal(err)
+			}
+			caller, err := CreateAgentCaller()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			r := &pipelineRun{ctx: context.Background(), w: &buf, caller: caller, store: store,
+				state: newRunState("docs/m.md", ""), completedIDs: []string{"T-1"}}
+			passed, err := r.review(pipelineStep{Name: stepReview, Type: pipelineReview})
+			if err != nil {
+				t.Fatalf("review() error = %v", err)
+			}
+			if passed != tt.wantPassed || r.state.ReviewPassed == nil || *r.state.ReviewPassed != tt.wantPassed {
+				t.Errorf("review() passed = %v, state %v; want %v\n%s", passed, r.state.ReviewPassed, tt.wantPassed, buf.String())
+			}
+			data, _ := os.ReadFile(fixes)
+			if got := strings.Count(string(data), "fix"); got != tt.wantFixes {
+				t.Errorf("fixes = %d, want %d\n%s", got, tt.wantFixes, buf.String())
+			}
+			if tt.wantOutput != "" && !strings.Contains(buf.String(), tt.wantOutput) {
+				t.Errorf("output should contain %q, got:\n%s", tt.wantOutput, buf.String())
+			}
+		})
+	}
+}
+
+func TestPipelineRun_ReviewBlockOn(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	tests := []struct {
+		name        string
+		blockOn     []string
+		wantBlocked bool
+	}{
+		{name: "not configured", blockOn: nil, wantBlocked: false},
+		{name: "severity not found", blockOn: []string{"HIGH"}, wantBlocked: false},
+		{name: "blocking severity", blockOn: []string{"HIGH", "MED"}, wantBlocked: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := exec.Command("git", "-C", tmpDir, "init").Run(); err != nil {
+				t.Fatalf("git init: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tmpDir, "api.go"), []byte("package api\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			// The agent writes a structured review with a MED issue.
+			script := "#!/bin/sh\ncat > " + filepath.Join(tmpDir, ".tickets", "review-result.json") + " <<'EOF'\n" +
+				`{"status": "CHANGES_REQUESTED", "issues": [{"severity": "MED", "file": "api.go", "line": 1, "message": "missing doc"}]}` + "\nEOF\n"
+			agentPath := filepath.Join(t.TempDir(), "fake-agent")
+			if err := os.WriteFile(agentPath, []byte(script), 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			originalCfg := cfg
+			defer func() { cfg = originalCfg }()
+			cfg = &config.Config{ProjectRoot: tmpDir, TicketsDir: t.TempDir(), AgentCommand: agentPath, ReviewBlockOn: tt.blockOn}
+			caller, err := CreateAgentCaller()
+			if err != nil {
+				t.Fatal(err)
+			}
+			store := ticket.NewStore(cfg.TicketsDir)
+			if err := store.Init(); err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			r := &pipelineRun{ctx: context.Background(), w: &buf, caller: caller, store: store, state: newRunState("docs/m.md", "")}
+			if _, err := r.review(pipelineStep{Name: stepReview, Type: pipelineReview}); err != nil {
+				t.Fatalf("review() error = %v", err)
+			}
+			if r.state.ReviewBlocked != tt.wantBlocked {
+				t.Errorf("ReviewBlocked = %v, want %v\n%s", r.state.ReviewBlocked, tt.wantBlocked, buf.String())
+			}
+			ok, err := r.commit(pipelineStep{Name: stepCommitting, Type: pipelineCommit})
+			if err != nil {
+				t.Fatalf("commit() error = %v", err)
+			}
+			if blocked := strings.Contains(buf.String(), i18n.MsgCommitBlockedByReview); blocked != tt.wantBlocked || ok == tt.wantBlocked {
+				t.Errorf("commit() = %v, blocked message %v; want blocked %v\n%s", ok, blocked, tt.wantBlocked, buf.String())
+			}
+		})
+	}
+}
+
+func TestPipelineRun_WorkWaitsForBudget(t *testing.T) {
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = config.DefaultConfig()
+	cfg.ProjectRoot = t.TempDir()
+	cfg.TicketsDir = t.TempDir()
+	cfg.AgentCommand = "true"
+	cfg.BudgetTokensPerHour = 1000
+	cfg.TokenPricePerMillion = 1000
+
+	store := ticket.NewStore(cfg.TicketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(ticket.NewTicket("T-1", "api", "")); err != nil {
+		t.Fatal(err)
+	}
+	caller, err := CreateAgentCaller()
+	if err != nil {
+		t.Fatal(err)
+	}
+	chargeAgentBudget(agent.CallInfo{PromptChars: 8000})
+
+	// Exhausted: the coding step pauses before claiming a ticket and stops with the context.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var buf bytes.Buffer
+	r := &pipelineRun{ctx: ctx, w: &buf, caller: caller, store: store, state: newRunState("docs/m.md", "")}
+	if _, err := r.work(pipelineStep{Name: stepCoding, Type: pipelineWork}); err != nil {
+		t.Fatalf("work() error = %v", err)
+	}
+	if want := strings.SplitN(i18n.MsgBudgetPaused, "%s", 2)[0]; !strings.Contains(buf.String(), want) {
+		t.Errorf("output should report the budget pause, got:\n%s", buf.String())
+	}
+	got, err := store.Load("T-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != ticket.StatusPending {
+		t.Errorf("Status = %s, want %s (not claimed while the budget is exhausted)", got.Status, ticket.StatusPending)
+	}
+}
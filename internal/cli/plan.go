@@ -3,7 +3,11 @@ package cli
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/anthropic/agent-orchestrator/internal/agent"
 	orcherrors "github.com/anthropic/agent-orchestrator/internal/errors"
@@ -11,21 +15,71 @@ import (
 	"github.com/anthropic/agent-orchestrator/internal/ticket"
 	"github.com/anthropic/agent-orchestrator/internal/ui"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	planEpics       bool
+	planPreviewFull bool
+	planAuto        bool
 )
 
 var planCmd = &cobra.Command{
-	Use:   "plan <milestone-file>",
+	Use:   "plan <milestone-file>...",
 	Short: i18n.CmdPlanShort,
 	Long:  i18n.CmdPlanLong,
-	Args:  cobra.ExactArgs(1),
+	Args:  cobra.MinimumNArgs(1),
 	RunE:  runPlan,
 }
 
+func init() {
+	planCmd.Flags().BoolVar(&planEpics, "epics", false, i18n.FlagPlanEpics)
+	planCmd.Flags().BoolVar(&planPreviewFull, "preview-full", false, i18n.FlagPreviewFull)
+	planCmd.Flags().BoolVar(&planAuto, "auto", false, i18n.FlagPlanAuto)
+}
+
 func runPlan(cmd *cobra.Command, args []string) error {
-	return runPlanWithFile(context.Background(), args[0])
+	milestoneFiles, err := expandMilestoneArgs(args)
+	if err != nil {
+		return err
+	}
+	return runPlanWithFiles(context.Background(), milestoneFiles)
+}
+
+// expandMilestoneArgs returns the milestone files named by the plan arguments: an
+// argument with glob characters (quoted, so the shell left it alone) stands for the
+// files matching it, in lexical order. Each file is returned once.
+func expandMilestoneArgs(args []string) ([]string, error) {
+	var files []string
+	seen := make(map[string]bool)
+	for _, arg := range args {
+		matches := []string{arg}
+		if strings.ContainsAny(arg, "*?[") {
+			var err error
+			if matches, err = filepath.Glob(arg); err != nil {
+				return nil, fmt.Errorf("%s: %w", arg, err)
+			}
+			if len(matches) == 0 {
+				return nil, orcherrors.ErrFileNotFound(arg)
+			}
+		}
+		for _, f := range matches {
+			if key := filepath.Clean(f); !seen[key] {
+				seen[key] = true
+				files = append(files, f)
+			}
+		}
+	}
+	return files, nil
 }
 
 func runPlanWithFile(ctx context.Context, milestoneFile string) error {
+	return runPlanWithFiles(ctx, []string{milestoneFile})
+}
+
+// runPlanWithFiles plans milestoneFiles together, so tickets of one milestone can
+// depend on those of another, and records on each ticket the milestone it belongs to.
+func runPlanWithFiles(ctx context.Context, milestoneFiles []string) error {
 	w := os.Stdout
 
 	// Refuse to write if background work is running (TICKET-018).
@@ -33,13 +87,15 @@ func runPlanWithFile(ctx context.Context, milestoneFile string) error {
 		return err
 	}
 
-	// Check if milestone file exists
-	if _, err := os.Stat(milestoneFile); os.IsNotExist(err) {
-		return orcherrors.ErrFileNotFound(milestoneFile)
+	// Check if milestone files exist
+	for _, f := range milestoneFiles {
+		if _, err := os.Stat(f); os.IsNotExist(err) {
+			return orcherrors.ErrFileNotFound(f)
+		}
 	}
 
 	ui.PrintHeader(w, i18n.UIPlanning)
-	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgAnalyzeMilestone, milestoneFile))
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgAnalyzeMilestone, strings.Join(milestoneFiles, ", ")))
 
 	// Create agent caller
 	caller, err := CreateAgentCaller()
@@ -48,12 +104,13 @@ func runPlanWithFile(ctx context.Context, milestoneFile string) error {
 	}
 
 	planningAgent := agent.NewPlanningAgent(caller, cfg.ProjectRoot, cfg.TicketsDir)
+	planningAgent.SetEpics(planEpics)
 
 	// Run planning
 	spinner := ui.NewSpinner(i18n.SpinnerPlanning, w)
 	spinner.Start()
 
-	tickets, err := planningAgent.Plan(ctx, milestoneFile)
+	tickets, err := planningAgent.PlanAll(ctx, milestoneFiles)
 	if err != nil {
 		spinner.Fail(i18n.SpinnerFailPlanning)
 		return err
@@ -64,9 +121,10 @@ func runPlanWithFile(ctx context.Context, milestoneFile string) error {
 		ui.PrintWarning(w, i18n.MsgNoTicketsGenerated)
 		return nil
 	}
+	assignMilestones(w, tickets, milestoneFiles)
 
 	// Initialize store and save tickets
-	store := ticket.NewStore(cfg.TicketsDir)
+	store := newTicketStore()
 	if err := store.Init(); err != nil {
 		return fmt.Errorf(i18n.ErrInitStoreFailed, err)
 	}
@@ -77,9 +135,52 @@ func runPlanWithFile(ctx context.Context, milestoneFile string) error {
 		ui.PrintWarning(w, fmt.Sprintf(i18n.MsgDependencyWarning, err.Error()))
 	}
 
-	// Check for circular dependencies
+	// Check for circular dependencies: offer to break them before the tickets are
+	// saved, or name them and point to deps fix-cycles when nobody can answer.
 	if resolver.HasCircularDependency(tickets) {
 		ui.PrintWarning(w, i18n.MsgCircularDependency)
+		choose := func([]string, ticket.Edge) (string, error) { return "", nil }
+		if !cfg.Quiet && term.IsTerminal(int(os.Stdin.Fd())) {
+			choose = func(_ []string, edge ticket.Edge) (string, error) { return askCycleFix(w, os.Stdin, edge) }
+		}
+		if _, _, err := repairCycles(w, resolver, tickets, choose); err != nil {
+			return err
+		}
+		if resolver.HasCircularDependency(tickets) {
+			ui.PrintInfo(w, i18n.HintFixCycles)
+		}
+	}
+
+	// Let the user review the plan ticket by ticket before anything is saved. With
+	// --auto, or without a terminal to ask on, the tickets are saved as planned.
+	if planPreviewFull {
+		if err := showTicketsJSON(w, tickets); err != nil {
+			return err
+		}
+	}
+	interactive := !planAuto && !cfg.Quiet && term.IsTerminal(int(os.Stdin.Fd()))
+	if interactive {
+		if err := previewTickets(w, os.Stdin, tickets, true); err != nil {
+			return err
+		}
+		accepted, ok, err := reviewTickets(w, os.Stdin, tickets)
+		if err != nil {
+			return err
+		}
+		if !ok || len(accepted) == 0 {
+			ui.PrintInfo(w, i18n.MsgCancelled)
+			return nil
+		}
+		tickets = accepted
+		// Edited dependencies are checked again, but only warned about: the user
+		// just chose them.
+		if err := resolver.ValidateDependencies(tickets); err != nil {
+			ui.PrintWarning(w, fmt.Sprintf(i18n.MsgDependencyWarning, err.Error()))
+		}
+		if resolver.HasCircularDependency(tickets) {
+			ui.PrintWarning(w, i18n.MsgCircularDependency)
+			ui.PrintInfo(w, i18n.HintFixCycles)
+		}
 	}
 
 	// Save tickets
@@ -95,17 +196,64 @@ func runPlanWithFile(ctx context.Context, milestoneFile string) error {
 	ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgGeneratedTickets, len(tickets)))
 	ui.PrintInfo(w, "")
 
-	// Show ticket list
-	table := ui.NewTable("Priority", "ID", "Title", "Type", "Complexity")
-	for _, t := range tickets {
-		priority := ui.PriorityStyle(t.Priority).Render(fmt.Sprintf("P%d", t.Priority))
-		table.AddRow(priority, t.ID, ui.Truncate(t.Title, 40), string(t.Type), t.EstimatedComplexity)
+	// Show ticket list, unless the preview just did
+	if !interactive {
+		table := ui.NewTable("Priority", "ID", "Title", "Type", "Complexity")
+		for _, t := range tickets {
+			priority := ui.PriorityStyle(t.Priority).Render(fmt.Sprintf("P%d", t.Priority))
+			table.AddRow(priority, t.ID, ui.Truncate(t.Title, 40), string(t.Type), t.EstimatedComplexity)
+		}
+		table.Render(w)
+		ui.PrintInfo(w, "")
 	}
-	table.Render(w)
-
-	ui.PrintInfo(w, "")
 	ui.PrintInfo(w, i18n.HintRunWork)
 	ui.PrintInfo(w, i18n.HintRunStatus)
 
 	return nil
 }
+
+// assignMilestones records on each ticket the milestone file it was planned from, as
+// milestoneRef gives it. With several files the agent names the file of each ticket;
+// a ticket it names none (or an unknown one) for is put in the first file, with a warning.
+func assignMilestones(w io.Writer, tickets []*ticket.Ticket, milestoneFiles []string) {
+	refs := milestoneRefs(milestoneFiles)
+	for _, t := range tickets {
+		if len(refs) == 1 {
+			t.Milestone = refs[0]
+			continue
+		}
+		i := -1
+		if t.Milestone != "" {
+			i = slices.Index(refs, milestoneRef(t.Milestone))
+		}
+		if i < 0 {
+			ui.PrintWarning(w, fmt.Sprintf(i18n.MsgMilestoneUnassigned, t.ID, refs[0]))
+			i = 0
+		}
+		t.Milestone = refs[i]
+	}
+}
+
+// milestoneRefs returns milestoneRef of each file, e.g. to match --milestone filters
+// against the Milestone of tickets.
+func milestoneRefs(milestoneFiles []string) []string {
+	refs := make([]string, len(milestoneFiles))
+	for i, f := range milestoneFiles {
+		refs[i] = milestoneRef(f)
+	}
+	return refs
+}
+
+// milestoneRef returns how tickets refer to their milestone file: the path relative to
+// the project root when the file is inside it, the given path otherwise.
+func milestoneRef(milestoneFile string) string {
+	abs, err := filepath.Abs(milestoneFile)
+	if err != nil {
+		return milestoneFile
+	}
+	rel, err := filepath.Rel(cfg.ProjectRoot, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return milestoneFile
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+)
+
+// Decisions on a planned ticket in reviewTickets.
+const (
+	reviewAccept     = iota
+	reviewAcceptRest // accept this ticket and all after it
+	reviewReject
+	reviewQuit // cancel the plan
+)
+
+// reviewTickets shows the planned tickets one at a time and reads from in whether to
+// accept, edit (title, priority, dependencies) or reject each. It returns the accepted
+// tickets, edited in place, or ok false when the user cancels the plan (q or the end of
+// the input). Accepted tickets lose their dependencies on rejected ones, which would
+// never be created.
+//
+// Answers are read line by line from one reader, so the whole review can be scripted.
+func reviewTickets(w io.Writer, in io.Reader, tickets []*ticket.Ticket) (accepted []*ticket.Ticket, ok bool, err error) {
+	r := bufio.NewReader(in)
+	var rejected []string
+	for i, t := range tickets {
+		decision, err := reviewTicket(w, r, t, i+1, len(tickets))
+		if errors.Is(err, io.EOF) {
+			decision, err = reviewQuit, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		switch decision {
+		case reviewAccept:
+			accepted = append(accepted, t)
+		case reviewAcceptRest:
+			accepted = append(accepted, tickets[i:]...)
+		case reviewReject:
+			rejected = append(rejected, t.ID)
+			ui.PrintInfo(w, ui.StyleMuted.Render(fmt.Sprintf(i18n.MsgTicketRejected, t.ID)))
+		case reviewQuit:
+			return nil, false, nil
+		}
+		if decision == reviewAcceptRest {
+			break
+		}
+	}
+
+	for _, t := range accepted {
+		for _, id := range rejected {
+			hard := t.RemoveDependency(id)
+			soft := slices.Contains(t.SoftDependencies, id)
+			t.SoftDependencies = slices.DeleteFunc(t.SoftDependencies, func(d string) bool { return d == id })
+			if hard || soft {
+				ui.PrintWarning(w, fmt.Sprintf(i18n.MsgRejectedDependency, t.ID, id))
+			}
+		}
+	}
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgReviewSummary, len(accepted), len(rejected)))
+	return accepted, true, nil
+}
+
+// reviewTicket shows t and asks until the answer is a decision; edits are applied to t
+// and shown again.
+func reviewTicket(w io.Writer, r *bufio.Reader, t *ticket.Ticket, n, total int) (int, error) {
+	printReviewTicket(w, t, n, total)
+	for {
+		answer, err := readAnswer(w, r, i18n.PromptReviewTicket)
+		if err != nil {
+			return 0, err
+		}
+		switch answer {
+		case "", "a", "y":
+			return reviewAccept, nil
+		case "A":
+			return reviewAcceptRest, nil
+		case "r", "n":
+			return reviewReject, nil
+		case "q":
+			return reviewQuit, nil
+		case "e":
+			if err := editReviewTicket(w, r, t); err != nil {
+				return 0, err
+			}
+			printReviewTicket(w, t, n, total)
+		default:
+			ui.PrintWarning(w, fmt.Sprintf(i18n.MsgInvalidSelection, answer))
+		}
+	}
+}
+
+// printReviewTicket shows the ticket being reviewed: ID, title, type, priority,
+// dependencies and the first line of the description.
+func printReviewTicket(w io.Writer, t *ticket.Ticket, n, total int) {
+	deps := "-"
+	if len(t.Dependencies) > 0 {
+		deps = strings.Join(t.Dependencies, ", ")
+	}
+	fmt.Fprintln(w)
+	ui.PrintInfo(w, fmt.Sprintf("[%d/%d] %s: %s", n, total, t.ID, ui.StyleBold.Render(t.Title)))
+	ui.PrintInfo(w, "  "+fmt.Sprintf(i18n.MsgReviewTicketMeta, t.Type, ui.PriorityStyle(t.Priority).Render(fmt.Sprintf("P%d", t.Priority)), deps))
+	if line, _, _ := strings.Cut(strings.TrimSpace(t.Description), "\n"); line != "" {
+		ui.PrintInfo(w, "  "+ui.StyleMuted.Render(ui.Truncate(line, 100)))
+	}
+}
+
+// editReviewTicket asks for a new title, priority and dependencies; an empty answer
+// keeps the current value and "-" clears the dependencies.
+func editReviewTicket(w io.Writer, r *bufio.Reader, t *ticket.Ticket) error {
+	title, err := readAnswer(w, r, fmt.Sprintf(i18n.PromptReviewTitle, t.Title))
+	if err != nil {
+		return err
+	}
+	if title != "" {
+		t.Title = title
+	}
+
+	for {
+		answer, err := readAnswer(w, r, fmt.Sprintf(i18n.PromptReviewPriority, t.Priority))
+		if err != nil {
+			return err
+		}
+		if answer == "" {
+			break
+		}
+		if p, err := strconv.Atoi(answer); err == nil && p >= 1 && p <= 5 {
+			t.Priority = p
+			break
+		}
+		ui.PrintWarning(w, fmt.Sprintf(i18n.MsgInvalidSelection, answer))
+	}
+
+	deps := "-"
+	if len(t.Dependencies) > 0 {
+		deps = strings.Join(t.Dependencies, ", ")
+	}
+	answer, err := readAnswer(w, r, fmt.Sprintf(i18n.PromptReviewDeps, deps))
+	if err != nil {
+		return err
+	}
+	switch answer {
+	case "":
+	case "-":
+		t.Dependencies = nil
+	default:
+		t.Dependencies = nil
+		for _, id := range strings.Split(answer, ",") {
+			if id = strings.TrimSpace(id); id != "" && id != t.ID && !slices.Contains(t.Dependencies, id) {
+				t.Dependencies = append(t.Dependencies, id)
+			}
+		}
+	}
+	return nil
+}
+
+// readAnswer prints question and returns the next line of r, trimmed. The end of the
+// input is io.EOF unless a last unterminated line was read.
+func readAnswer(w io.Writer, r *bufio.Reader, question string) (string, error) {
+	fmt.Fprintf(w, "%s %s ", ui.StyleInfo.Render("?"), question)
+	line, err := r.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	if err != nil {
+		fmt.Fprintln(w)
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestReviewTickets(t *testing.T) {
+	newTickets := func() []*ticket.Ticket {
+		a := ticket.NewTicket("T-1", "Schema", "")
+		b := ticket.NewTicket("T-2", "API", "")
+		b.Dependencies = []string{"T-1"}
+		c := ticket.NewTicket("T-3", "UI", "")
+		c.Dependencies = []string{"T-2"}
+		c.SoftDependencies = []string{"T-1"}
+		return []*ticket.Ticket{a, b, c}
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		ok       bool
+		accepted []string
+		check    func(t *testing.T, accepted []*ticket.Ticket)
+	}{
+		{"enter accepts", "\n\n\n", true, []string{"T-1", "T-2", "T-3"}, nil},
+		{"A accepts the rest", "a\nA\n", true, []string{"T-1", "T-2", "T-3"}, nil},
+		{"q cancels", "a\nq\n", false, nil, nil},
+		{"end of input cancels", "a\n", false, nil, nil},
+		{"invalid answer asks again", "x\na\na\na\n", true, []string{"T-1", "T-2", "T-3"}, nil},
+		{"reject drops dependencies on the ticket", "r\na\na\n", true, []string{"T-2", "T-3"},
+			func(t *testing.T, accepted []*ticket.Ticket) {
+				if len(accepted[0].Dependencies) != 0 {
+					t.Errorf("T-2 dependencies = %v, want none", accepted[0].Dependencies)
+				}
+				if len(accepted[1].SoftDependencies) != 0 {
+					t.Errorf("T-3 soft dependencies = %v, want none", accepted[1].SoftDependencies)
+				}
+			}},
+		{"edit title, priority and dependencies", "a\ne\nREST API\n9\n1\nT-1, T-9, T-2\na\nA\n", true, []string{"T-1", "T-2", "T-3"},
+			func(t *testing.T, accepted []*ticket.Ticket) {
+				got := accepted[1]
+				if got.Title != "REST API" || got.Priority != 1 {
+					t.Errorf("T-2 = %q P%d, want %q P1", got.Title, got.Priority, "REST API")
+				}
+				if want := []string{"T-1", "T-9"}; !reflect.DeepEqual(got.Dependencies, want) {
+					t.Errorf("T-2 dependencies = %v, want %v", got.Dependencies, want)
+				}
+			}},
+		{"edit keeps values on enter and clears dependencies with -", "a\na\ne\n\n\n-\na\n", true, []string{"T-1", "T-2", "T-3"},
+			func(t *testing.T, accepted []*ticket.Ticket) {
+				got := accepted[2]
+				if got.Title != "UI" || got.Priority != 5 || len(got.Dependencies) != 0 {
+					t.Errorf("T-3 = %q P%d deps %v, want %q P5 without dependencies", got.Title, got.Priority, got.Dependencies, "UI")
+				}
+			}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			accepted, ok, err := reviewTickets(&out, strings.NewReader(tt.input), newTickets())
+			if err != nil {
+				t.Fatalf("reviewTickets() error = %v", err)
+			}
+			if ok != tt.ok {
+				t.Fatalf("reviewTickets() ok = %v, want %v:\n%s", ok, tt.ok, out.String())
+			}
+			var ids []string
+			for _, tk := range accepted {
+				ids = append(ids, tk.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.accepted) {
+				t.Fatalf("accepted = %v, want %v:\n%s", ids, tt.accepted, out.String())
+			}
+			if tt.check != nil {
+				tt.check(t, accepted)
+			}
+		})
+	}
+}
@@ -2,12 +2,16 @@ package cli
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
 )
 
 func TestRunPlan_ExactArgs(t *testing.T) {
@@ -105,3 +109,91 @@ func TestRunPlan_WithFile(t *testing.T) {
 		t.Error("runPlan with missing file should return error")
 	}
 }
+
+func TestExpandMilestoneArgs(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"m-1.md", "m-2.md", "notes.md"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte("# "+name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m1, m2, notes := filepath.Join(tmpDir, "m-1.md"), filepath.Join(tmpDir, "m-2.md"), filepath.Join(tmpDir, "notes.md")
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr bool
+	}{
+		{"files in order", []string{m2, m1}, []string{m2, m1}, false},
+		{"glob in lexical order", []string{filepath.Join(tmpDir, "m-*.md")}, []string{m1, m2}, false},
+		{"duplicates once", []string{m1, filepath.Join(tmpDir, "*.md")}, []string{m1, m2, notes}, false},
+		{"glob without match", []string{filepath.Join(tmpDir, "x-*.md")}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandMilestoneArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expandMilestoneArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expandMilestoneArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunPlan_MultipleMilestones(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	if err := os.MkdirAll("docs", 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"docs/m1.md", "docs/m2.md"} {
+		if err := os.WriteFile(name, []byte("# "+name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The agent plans both milestones at once; T-3 names no milestone.
+	script := "#!/bin/sh\nmkdir -p " + ticketsDir + "\ncat > " + filepath.Join(ticketsDir, "generated-tickets.json") + " <<'EOF'\n" +
+		`{"tickets": [{"id": "T-1", "title": "Schema", "milestone": "docs/m1.md"},` +
+		` {"id": "T-2", "title": "API", "milestone": "docs/m2.md", "dependencies": ["T-1"]},` +
+		` {"id": "T-3", "title": "Docs"}]}` + "\nEOF\n"
+	agentPath := filepath.Join(t.TempDir(), "fake-agent")
+	if err := os.WriteFile(agentPath, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{ProjectRoot: tmpDir, TicketsDir: ticketsDir, AgentCommand: agentPath, WorkPIDFile: filepath.Join(tmpDir, ".work.pid")}
+
+	var err error
+	output := captureOutput(func() {
+		err = runPlan(planCmd, []string{"docs/m*.md"})
+	})
+	if err != nil {
+		t.Fatalf("runPlan() error = %v\n%s", err, output)
+	}
+	if want := fmt.Sprintf(i18n.MsgMilestoneUnassigned, "T-3", "docs/m1.md"); !strings.Contains(output, want) {
+		t.Errorf("output should contain %q, got:\n%s", want, output)
+	}
+
+	store := ticket.NewStore(ticketsDir)
+	for id, want := range map[string]string{"T-1": "docs/m1.md", "T-2": "docs/m2.md", "T-3": "docs/m1.md"} {
+		tk, err := store.Load(id)
+		if err != nil {
+			t.Fatalf("store.Load(%s): %v", id, err)
+		}
+		if tk.Milestone != want {
+			t.Errorf("%s Milestone = %q, want %q", id, tk.Milestone, want)
+		}
+	}
+	if tk, _ := store.Load("T-2"); len(tk.Dependencies) != 1 || tk.Dependencies[0] != "T-1" {
+		t.Errorf("T-2 Dependencies = %v, want [T-1] across milestones", tk.Dependencies)
+	}
+}
@@ -0,0 +1,297 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/github"
+	"github.com/anthropic/agent-orchestrator/internal/gitlab"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	prAll  bool
+	prBase string
+)
+
+var prCmd = &cobra.Command{
+	Use:   "pr [ticket-id]",
+	Short: i18n.CmdPRShort,
+	Long:  i18n.CmdPRLong,
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runPR,
+}
+
+func init() {
+	prCmd.Flags().BoolVar(&prAll, "all", false, i18n.FlagPRAll)
+	prCmd.Flags().StringVar(&prBase, "base", "", i18n.FlagPRBase)
+}
+
+// prHost creates pull requests on the hosting service of the project's origin remote.
+type prHost interface {
+	DefaultBranch(ctx context.Context) (string, error)
+	Create(ctx context.Context, head, base, title, body string) (*ticket.PullRequestRef, error)
+}
+
+type githubPRHost struct {
+	client *github.Client
+	repo   string
+}
+
+func (h githubPRHost) DefaultBranch(ctx context.Context) (string, error) {
+	return h.client.DefaultBranch(ctx, h.repo)
+}
+
+func (h githubPRHost) Create(ctx context.Context, head, base, title, body string) (*ticket.PullRequestRef, error) {
+	pr, err := h.client.CreatePullRequest(ctx, h.repo, head, base, title, body)
+	if err != nil {
+		return nil, err
+	}
+	return &ticket.PullRequestRef{Number: pr.Number, URL: pr.HTMLURL}, nil
+}
+
+type gitlabPRHost struct {
+	client  *gitlab.Client
+	project string
+}
+
+func (h gitlabPRHost) DefaultBranch(ctx context.Context) (string, error) {
+	return h.client.DefaultBranch(ctx, h.project)
+}
+
+func (h gitlabPRHost) Create(ctx context.Context, head, base, title, body string) (*ticket.PullRequestRef, error) {
+	mr, err := h.client.CreateMergeRequest(ctx, h.project, head, base, title, body)
+	if err != nil {
+		return nil, err
+	}
+	return &ticket.PullRequestRef{Number: mr.IID, URL: mr.WebURL}, nil
+}
+
+// gitlabToken returns the GitLab API token from config, falling back to GITLAB_TOKEN.
+func gitlabToken() string {
+	if cfg.GitLabToken != "" {
+		return cfg.GitLabToken
+	}
+	return os.Getenv("GITLAB_TOKEN")
+}
+
+// parseRemoteURL splits a git remote URL (https://host/owner/repo.git,
+// ssh://git@host/owner/repo.git or git@host:owner/repo.git) into host and repository path.
+func parseRemoteURL(remote string) (host, path string, err error) {
+	remote = strings.TrimSpace(remote)
+	if u, perr := url.Parse(remote); perr == nil && u.Host != "" {
+		host, path = u.Hostname(), u.Path
+	} else if at, colon := strings.Index(remote, "@"), strings.Index(remote, ":"); colon > at && !strings.Contains(remote[:colon], "/") {
+		host, path = remote[at+1:colon], remote[colon+1:]
+	}
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || !strings.Contains(path, "/") {
+		return "", "", fmt.Errorf("unsupported remote URL %q", remote)
+	}
+	return host, path, nil
+}
+
+// newPRHost returns the host of the origin remote: GitLab when its host matches
+// gitlab_api_url or contains "gitlab", otherwise GitHub (github_api_url).
+func newPRHost(remote string) (prHost, error) {
+	host, path, err := parseRemoteURL(remote)
+	if err != nil {
+		return nil, err
+	}
+	gitlabHost := ""
+	if u, err := url.Parse(cfg.GitLabAPIURL); err == nil {
+		gitlabHost = u.Hostname()
+	}
+	if host == gitlabHost || strings.Contains(host, "gitlab") {
+		token := gitlabToken()
+		if token == "" {
+			return nil, fmt.Errorf(i18n.ErrPRNoToken, "GitLab", "gitlab_token", "GITLAB_TOKEN")
+		}
+		return gitlabPRHost{client: gitlab.NewClient(cfg.GitLabAPIURL, token), project: path}, nil
+	}
+	repo, err := github.ParseRepo(path)
+	if err != nil {
+		return nil, err
+	}
+	token := githubToken()
+	if token == "" {
+		return nil, fmt.Errorf(i18n.ErrPRNoToken, "GitHub", "github_token", "GITHUB_TOKEN")
+	}
+	return githubPRHost{client: github.NewClient(cfg.GitHubAPIURL, token), repo: repo}, nil
+}
+
+// gitOriginURL returns the URL of the project's origin remote.
+func gitOriginURL(ctx context.Context) (string, error) {
+	if err := validateProjectRoot(cfg.ProjectRoot); err != nil {
+		return "", err
+	}
+	cmd := exec.CommandContext(ctx, "git", "remote", "get-url", "origin")
+	cmd.Dir = cfg.ProjectRoot
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git remote get-url origin: %s", strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// pushBranch pushes branch to origin and sets it as the upstream.
+func pushBranch(ctx context.Context, branch string) error {
+	cmd := exec.CommandContext(ctx, "git", "push", "-u", "origin", branch)
+	cmd.Dir = cfg.ProjectRoot
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git push -u origin %s: %s", branch, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func runPR(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	w := os.Stdout
+	if len(args) == 0 && !prAll {
+		return errors.New(i18n.ErrPRTicketRequired)
+	}
+	if !cfg.DryRun {
+		if err := ErrIfBackgroundWorkRunning(); err != nil {
+			return err
+		}
+	}
+
+	store := newTicketStore()
+	var tickets []*ticket.Ticket
+	if len(args) == 1 {
+		t, err := store.Load(args[0])
+		if err != nil {
+			return fmt.Errorf(i18n.ErrTicketNotFound, args[0])
+		}
+		if t.PullRequest != nil {
+			ui.PrintInfo(w, fmt.Sprintf(i18n.MsgPRExists, t.ID, t.PullRequest))
+			return nil
+		}
+		if err := checkPRReady(t); err != nil {
+			return err
+		}
+		tickets = []*ticket.Ticket{t}
+	} else {
+		completed, err := store.LoadByStatus(ticket.StatusCompleted)
+		if err != nil {
+			return err
+		}
+		for _, t := range completed {
+			if t.PullRequest == nil && t.Branch != "" {
+				tickets = append(tickets, t)
+			}
+		}
+		if len(tickets) == 0 {
+			ui.PrintInfo(w, i18n.MsgPRNone)
+			return nil
+		}
+	}
+
+	remote, err := gitOriginURL(ctx)
+	if err != nil {
+		return err
+	}
+	host, err := newPRHost(remote)
+	if err != nil {
+		return err
+	}
+	base := prBase
+	if base == "" {
+		base = cfg.PRBaseBranch
+	}
+	if base == "" {
+		if cfg.DryRun {
+			base = i18n.MsgPRDefaultBase
+		} else if base, err = host.DefaultBranch(ctx); err != nil {
+			return fmt.Errorf(i18n.ErrPRDefaultBranch, err)
+		}
+	}
+
+	ui.PrintHeader(w, i18n.UIPullRequests)
+	failed := 0
+	for _, t := range tickets {
+		if err := openPullRequest(ctx, w, store, host, t, base); err != nil {
+			if len(tickets) == 1 {
+				return err
+			}
+			ui.PrintError(w, err.Error())
+			failed++
+		}
+	}
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf(i18n.ErrPRSomeFailed, failed, len(tickets))
+	}
+	return nil
+}
+
+// checkPRReady reports why t cannot get a pull request yet: it must be completed and
+// have its own branch to open the pull request from.
+func checkPRReady(t *ticket.Ticket) error {
+	if t.Status != ticket.StatusCompleted {
+		return fmt.Errorf(i18n.ErrPRNotCompleted, t.ID, t.Status)
+	}
+	if t.Branch == "" {
+		return fmt.Errorf(i18n.ErrPRNoBranch, t.ID)
+	}
+	return nil
+}
+
+// openPullRequest pushes t's branch and opens a pull request into base, recording the
+// link on the ticket. In dry-run mode it only prints what would be done.
+func openPullRequest(ctx context.Context, w io.Writer, store ticket.Storer, host prHost, t *ticket.Ticket, base string) error {
+	title := pullRequestTitle(t)
+	if cfg.DryRun {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgPRDryRun, t.Branch, base, title))
+		return nil
+	}
+	if err := pushBranch(ctx, t.Branch); err != nil {
+		return fmt.Errorf(i18n.ErrPRFailed, t.ID, err)
+	}
+	ref, err := host.Create(ctx, t.Branch, base, title, pullRequestBody(t))
+	if err != nil {
+		return fmt.Errorf(i18n.ErrPRFailed, t.ID, err)
+	}
+	t.PullRequest = ref
+	if err := store.Save(t); err != nil {
+		return err
+	}
+	ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgPRCreated, t.ID, ref))
+	return nil
+}
+
+// pullRequestTitle returns "<ticket ID>: <title>".
+func pullRequestTitle(t *ticket.Ticket) string {
+	return fmt.Sprintf("%s: %s", t.ID, t.Title)
+}
+
+// pullRequestBody describes t for reviewers: its description, the acceptance criteria
+// as a checklist, and a closing reference to the GitHub issue it was imported from.
+func pullRequestBody(t *ticket.Ticket) string {
+	var sb strings.Builder
+	if desc := strings.TrimSpace(t.Description); desc != "" {
+		sb.WriteString(desc)
+		sb.WriteString("\n\n")
+	}
+	if len(t.AcceptanceCriteria) > 0 {
+		sb.WriteString(i18n.PRBodyAcceptance)
+		for _, c := range t.AcceptanceCriteria {
+			sb.WriteString(fmt.Sprintf("- [ ] %s\n", c))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fmt.Sprintf(i18n.PRBodyTicket, t.ID))
+	if t.RemoteRef != nil && t.RemoteRef.System == github.System {
+		sb.WriteString(fmt.Sprintf("Closes %s\n", t.RemoteRef))
+	}
+	return sb.String()
+}
@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/spf13/cobra"
+)
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		in      string
+		host    string
+		path    string
+		wantErr bool
+	}{
+		{"https://github.com/octo/widgets.git", "github.com", "octo/widgets", false},
+		{"git@github.com:octo/widgets.git", "github.com", "octo/widgets", false},
+		{"ssh://git@gitlab.example.com:2222/group/sub/app.git", "gitlab.example.com", "group/sub/app", false},
+		{"/srv/git/widgets.git", "", "", true},
+		{"https://github.com/widgets", "", "", true},
+	}
+	for _, tt := range tests {
+		host, path, err := parseRemoteURL(tt.in)
+		if (err != nil) != tt.wantErr || host != tt.host || path != tt.path {
+			t.Errorf("parseRemoteURL(%q) = %q, %q, %v; want %q, %q (err %v)", tt.in, host, path, err, tt.host, tt.path, tt.wantErr)
+		}
+	}
+}
+
+func TestNewPRHost(t *testing.T) {
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{GitHubToken: "gh", GitLabToken: "gl", GitLabAPIURL: "https://code.example.com/api/v4"}
+
+	tests := []struct {
+		remote     string
+		wantGitLab bool
+	}{
+		{"git@github.com:octo/widgets.git", false},
+		{"https://gitlab.com/group/app.git", true},
+		{"https://code.example.com/group/sub/app.git", true},
+	}
+	for _, tt := range tests {
+		host, err := newPRHost(tt.remote)
+		if err != nil {
+			t.Fatalf("newPRHost(%q) err = %v", tt.remote, err)
+		}
+		if _, isGitLab := host.(gitlabPRHost); isGitLab != tt.wantGitLab {
+			t.Errorf("newPRHost(%q) = %T, want GitLab %v", tt.remote, host, tt.wantGitLab)
+		}
+	}
+
+	cfg.GitHubToken = ""
+	t.Setenv("GITHUB_TOKEN", "")
+	if _, err := newPRHost("git@github.com:octo/widgets.git"); err == nil {
+		t.Error("newPRHost() should require a token")
+	}
+}
+
+func TestPullRequestBody(t *testing.T) {
+	tk := ticket.NewTicket("GH-11", "Fix crash", "Crash on start")
+	tk.AcceptanceCriteria = []string{"no panic", "log error"}
+	tk.RemoteRef = &ticket.RemoteRef{System: "github", Repo: "o/r", Number: 11}
+
+	body := pullRequestBody(tk)
+	for _, want := range []string{"Crash on start\n", "- [ ] no panic\n", "- [ ] log error\n", "Ticket: GH-11\n", "Closes o/r#11\n"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("pullRequestBody() should contain %q, got:\n%s", want, body)
+		}
+	}
+	if got := pullRequestTitle(tk); got != "GH-11: Fix crash" {
+		t.Errorf("pullRequestTitle() = %q", got)
+	}
+}
+
+func TestRunPR(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	bare := t.TempDir()
+	git := func(dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.CommandContext(ctx, "git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	git(bare, "init", "-q", "--bare")
+	git(dir, "init", "-q")
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git(dir, "add", ".")
+	git(dir, "commit", "-qm", "init")
+	git(dir, "checkout", "-qb", "ticket/T-1")
+	// Pushes to the GitHub URL go to the local bare repository.
+	git(dir, "remote", "add", "origin", "https://github.com/octo/widgets.git")
+	git(dir, "config", "url."+bare+".pushInsteadOf", "https://github.com/octo/widgets.git")
+
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/octo/widgets":
+			_, _ = w.Write([]byte(`{"default_branch": "main"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/octo/widgets/pulls":
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"number": 42, "html_url": "https://github.com/octo/widgets/pull/42"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg; prAll, prBase = false, "" }()
+	cfg = &config.Config{ProjectRoot: dir, TicketsDir: filepath.Join(t.TempDir(), ".tickets"), GitHubAPIURL: srv.URL, GitHubToken: "secret"}
+
+	store := newTicketStore()
+	if err := store.Init(); err != nil {
+		t.Fatal(err)
+	}
+	done := ticket.NewTicket("T-1", "Setup", "Set things up")
+	done.Status = ticket.StatusCompleted
+	done.Branch = "ticket/T-1"
+	pending := ticket.NewTicket("T-2", "Later", "")
+	for _, tk := range []*ticket.Ticket{done, pending} {
+		if err := store.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := runPR(&cobra.Command{}, []string{"T-2"}); err == nil {
+		t.Error("runPR() should refuse a ticket that is not completed")
+	}
+
+	prAll = true
+	captureOutput(func() {
+		if err := runPR(&cobra.Command{}, nil); err != nil {
+			t.Fatalf("runPR(--all) err = %v", err)
+		}
+	})
+	if got["head"] != "ticket/T-1" || got["base"] != "main" || got["title"] != "T-1: Setup" || !strings.Contains(got["body"], "Set things up") {
+		t.Errorf("pull request payload = %v", got)
+	}
+	if branches := git(bare, "branch", "--list", "ticket/T-1"); !strings.Contains(branches, "ticket/T-1") {
+		t.Error("ticket branch should have been pushed")
+	}
+	reloaded, err := store.Load("T-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.PullRequest == nil || reloaded.PullRequest.Number != 42 || reloaded.PullRequest.URL == "" {
+		t.Errorf("PullRequest = %+v, want #42 recorded", reloaded.PullRequest)
+	}
+
+	// A ticket with a pull request is not opened again.
+	got = nil
+	captureOutput(func() {
+		if err := runPR(&cobra.Command{}, nil); err != nil {
+			t.Fatalf("second runPR(--all) err = %v", err)
+		}
+	})
+	if got != nil {
+		t.Error("runPR(--all) should skip tickets that already have a pull request")
+	}
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"golang.org/x/term"
+)
+
+// previewPageSize is how many tickets a preview shows before asking to continue.
+var previewPageSize = 15
+
+// previewTickets renders the tickets about to be created as a compact table (ID,
+// title, type, priority, dependencies). With paged, tickets are shown previewPageSize
+// at a time, reading Enter for the next page or q to skip the rest from in.
+func previewTickets(w io.Writer, in io.Reader, tickets []*ticket.Ticket, paged bool) error {
+	ui.PrintHeader(w, i18n.UITicketPreview)
+	size := len(tickets)
+	if paged && previewPageSize > 0 {
+		size = previewPageSize
+	}
+	scanner := bufio.NewScanner(in)
+	for start := 0; start < len(tickets); start += size {
+		end := min(start+size, len(tickets))
+		table := ui.NewTable(i18n.TablePreviewID, i18n.TablePreviewTitle, i18n.TablePreviewType,
+			i18n.TablePreviewPriority, i18n.TablePreviewDeps)
+		for _, t := range tickets[start:end] {
+			deps := "-"
+			if len(t.Dependencies) > 0 {
+				deps = strings.Join(t.Dependencies, ", ")
+			}
+			priority := ui.PriorityStyle(t.Priority).Render(fmt.Sprintf("P%d", t.Priority))
+			table.AddRow(t.ID, ui.Truncate(t.Title, 40), string(t.Type), priority, ui.Truncate(deps, 30))
+		}
+		table.Render(w)
+		if end == len(tickets) {
+			break
+		}
+
+		fmt.Fprint(w, ui.StyleMuted.Render(fmt.Sprintf(i18n.PromptPreviewMore, end, len(tickets)))+" ")
+		if !scanner.Scan() {
+			fmt.Fprintln(w)
+			return scanner.Err()
+		}
+		if strings.EqualFold(strings.TrimSpace(scanner.Text()), "q") {
+			break
+		}
+	}
+	return nil
+}
+
+// showTicketsJSON writes the complete tickets as indented JSON through the pager
+// ($PAGER, default "less") when w is a terminal, otherwise (or when the pager cannot
+// be started) directly to w.
+func showTicketsJSON(w *os.File, tickets []*ticket.Ticket) error {
+	data, err := json.MarshalIndent(tickets, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if !term.IsTerminal(int(w.Fd())) {
+		_, err := w.Write(data)
+		return err
+	}
+
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if len(pager) == 0 {
+		pager = []string{"less"}
+	}
+	cmd := exec.Command(pager[0], pager[1:]...)
+	cmd.Stdin = strings.NewReader(string(data))
+	cmd.Stdout = w
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			// The pager ran; quitting it early is not an error.
+			return nil
+		}
+		_, err := w.Write(data)
+		return err
+	}
+	return nil
+}
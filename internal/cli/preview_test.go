@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestPreviewTickets_Pages(t *testing.T) {
+	original := previewPageSize
+	defer func() { previewPageSize = original }()
+	previewPageSize = 2
+
+	var tickets []*ticket.Ticket
+	for i := 1; i <= 5; i++ {
+		tk := ticket.NewTicket(fmt.Sprintf("TICKET-%03d", i), fmt.Sprintf("Title %d", i), "")
+		if i > 1 {
+			tk.Dependencies = []string{tickets[i-2].ID}
+		}
+		tickets = append(tickets, tk)
+	}
+
+	tests := []struct {
+		name  string
+		paged bool
+		input string
+		shown int
+	}{
+		{"not paged shows all", false, "", 5},
+		{"enter shows the next page", true, "\n", 4},
+		{"all pages", true, "\n\n", 5},
+		{"q skips the rest", true, "q\n", 2},
+		{"end of input stops", true, "", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := previewTickets(&out, strings.NewReader(tt.input), tickets, tt.paged); err != nil {
+				t.Fatalf("previewTickets() error = %v", err)
+			}
+			for i, tk := range tickets {
+				if got := strings.Contains(out.String(), tk.ID+" "); got != (i < tt.shown) {
+					t.Errorf("%s shown = %v, want %v:\n%s", tk.ID, got, i < tt.shown, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestShowTicketsJSON_NotATerminal(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	tk := ticket.NewTicket("TICKET-001", "Title", "Full description")
+	tk.AcceptanceCriteria = []string{"works"}
+
+	if err := showTicketsJSON(f, []*ticket.Ticket{tk}); err != nil {
+		t.Fatalf("showTicketsJSON() error = %v", err)
+	}
+	data, _ := os.ReadFile(f.Name())
+	var got []*ticket.Ticket
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, data)
+	}
+	if len(got) != 1 || got[0].Description != "Full description" || len(got[0].AcceptanceCriteria) != 1 {
+		t.Errorf("tickets = %+v, want the complete ticket", got)
+	}
+}
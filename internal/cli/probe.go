@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+)
+
+// probeTimeout bounds the warm-up call; a working agent answers it in seconds.
+var probeTimeout = time.Minute
+
+// probeAgent makes one cheap agent call before work dispatches tickets in parallel, so
+// a problem every call would hit (credentials, quota, model availability) ends the
+// batch at once with the probe's error instead of failing each ticket on its own.
+func probeAgent(ctx context.Context, w io.Writer) error {
+	caller, err := CreateAgentCaller()
+	if err != nil {
+		return err
+	}
+	caller.SetWriter(io.Discard)
+	ui.PrintInfo(w, i18n.MsgAgentProbe)
+	result, err := caller.Probe(ctx, cfg.ProjectRoot, probeTimeout)
+	if err == nil && result.Success {
+		return nil
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	message := agentFailureMessage(err, result)
+	if result != nil && result.TimedOut {
+		message = fmt.Sprintf(i18n.MsgAgentProbeTimeout, probeTimeout)
+	}
+	ui.PrintError(w, fmt.Sprintf(i18n.MsgAgentProbeFailed, ui.Truncate(message, 200)))
+	if hint := systemicFailureHint(agent.ClassifyFailure(message)); hint != "" {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgSystemicHint, hint))
+	}
+	return fmt.Errorf(i18n.ErrAgentProbeFailed, message)
+}
@@ -2,10 +2,48 @@
 
 package cli
 
-import "syscall"
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+)
 
 // IsProcessAlive reports whether the process with the given PID exists and is running.
 // On Unix, uses kill(pid, 0) which sends no signal but checks process existence.
 func IsProcessAlive(pid int) bool {
 	return syscall.Kill(pid, 0) == nil
 }
+
+// terminateProcess asks the process to shut down gracefully (SIGTERM).
+func terminateProcess(pid int) error {
+	return syscall.Kill(pid, syscall.SIGTERM)
+}
+
+// killProcess stops the process immediately (SIGKILL).
+func killProcess(pid int) error {
+	return syscall.Kill(pid, syscall.SIGKILL)
+}
+
+// processStartTime returns when the process with the given PID started, as an opaque
+// string that differs for a later process reusing the PID; "" when it cannot be told.
+// Reads the start time in clock ticks from /proc where there is one, else asks ps.
+func processStartTime(pid int) string {
+	if data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid)); err == nil {
+		// The command name (field 2) may contain spaces and ")"; starttime is field 22.
+		if i := bytes.LastIndexByte(data, ')'); i >= 0 {
+			if fields := strings.Fields(string(data[i+1:])); len(fields) > 19 {
+				return fields[19]
+			}
+		}
+		return ""
+	}
+	out, err := exec.Command("ps", "-o", "lstart=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -2,7 +2,11 @@
 
 package cli
 
-import "syscall"
+import (
+	"os"
+	"strconv"
+	"syscall"
+)
 
 // PROCESS_QUERY_LIMITED_INFORMATION = 0x1000
 const processQueryLimitedInformation = 0x1000
@@ -17,3 +21,34 @@ func IsProcessAlive(pid int) bool {
 	defer syscall.CloseHandle(h)
 	return true
 }
+
+// terminateProcess stops the process. Windows has no SIGTERM equivalent for detached
+// console processes, so this terminates it immediately.
+func terminateProcess(pid int) error {
+	return killProcess(pid)
+}
+
+// killProcess stops the process immediately.
+func killProcess(pid int) error {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return p.Kill()
+}
+
+// processStartTime returns the creation time of the process with the given PID, as an
+// opaque string that differs for a later process reusing the PID; "" when it cannot
+// be told.
+func processStartTime(pid int) string {
+	h, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+	if err != nil || h == 0 {
+		return ""
+	}
+	defer syscall.CloseHandle(h)
+	var creation, exit, kernel, user syscall.Filetime
+	if err := syscall.GetProcessTimes(h, &creation, &exit, &kernel, &user); err != nil {
+		return ""
+	}
+	return strconv.FormatInt(creation.Nanoseconds(), 10)
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/progress"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// Stable step names of run's step_changed events; the human output shows the
+// localized step titles instead.
+const (
+	stepAnalyze    = "analyze"
+	stepPlanning   = "planning"
+	stepCoding     = "coding"
+	stepTesting    = "testing"
+	stepReview     = "review"
+	stepCommitting = "committing"
+	stepAcceptance = "acceptance"
+	stepVerify     = "verify"
+)
+
+// progressEmitter receives progress events with --progress-format jsonl; it is nil, and
+// discards them, otherwise.
+var progressEmitter *progress.Emitter
+
+// setupProgress applies --progress-format and --progress-fd. With jsonl, events are
+// written to fd (1 is the original stdout) and human output moves to stderr, so stdout
+// carries nothing but events. A detach child never emits: its output is the log file.
+func setupProgress(format string, fd int) error {
+	progressEmitter = nil
+	switch format {
+	case "", progress.FormatText:
+		return nil
+	case progress.FormatJSONL:
+	default:
+		return fmt.Errorf(i18n.ErrUnknownProgressFormat, format, progress.FormatText, progress.FormatJSONL)
+	}
+	if IsDetachChild() {
+		return nil
+	}
+	out := os.Stdout
+	if fd != 1 {
+		if fd < 0 {
+			return fmt.Errorf(i18n.ErrProgressFD, fd)
+		}
+		out = os.NewFile(uintptr(fd), fmt.Sprintf("fd%d", fd))
+		if _, err := out.Stat(); err != nil {
+			return fmt.Errorf(i18n.ErrProgressFD, fd)
+		}
+	}
+	progressEmitter = progress.NewEmitter(out)
+	os.Stdout = os.Stderr
+	return nil
+}
+
+// trackTicket runs process for t between ticket_started and ticket_completed events.
+func trackTicket(command string, t *ticket.Ticket, process func() error) error {
+	startedAt := time.Now()
+	emitTicketStarted(command, t)
+	err := process()
+	emitTicketCompleted(command, t, startedAt)
+	return err
+}
+
+func emitTicketStarted(command string, t *ticket.Ticket) {
+	progressEmitter.Emit(progress.Event{
+		Type:     progress.TypeTicketStarted,
+		Command:  command,
+		TicketID: t.ID,
+		Title:    t.Title,
+	})
+}
+
+// emitTicketCompleted reports t's status after processing; Error is set for failed tickets.
+func emitTicketCompleted(command string, t *ticket.Ticket, startedAt time.Time) {
+	ev := progress.Event{
+		Type:       progress.TypeTicketCompleted,
+		Command:    command,
+		TicketID:   t.ID,
+		Title:      t.Title,
+		Status:     string(t.Status),
+		DurationMS: time.Since(startedAt).Milliseconds(),
+	}
+	if t.Status == ticket.StatusFailed {
+		ev.Error = t.Error
+	}
+	progressEmitter.Emit(ev)
+}
+
+func emitStepChanged(step string, index, total int) {
+	progressEmitter.Emit(progress.Event{
+		Type:    progress.TypeStepChanged,
+		Command: "run",
+		Step:    step,
+		Index:   index,
+		Total:   total,
+	})
+}
+
+// emitRunSummary reports the outcome of a work or run invocation.
+func emitRunSummary(command string, completed, failed, skipped, tokens int, cost float64, startedAt time.Time) {
+	progressEmitter.Emit(progress.Event{
+		Type:       progress.TypeRunSummary,
+		Command:    command,
+		Counts:     &progress.Counts{Completed: completed, Failed: failed, Skipped: skipped},
+		Tokens:     tokens,
+		CostUSD:    cost,
+		DurationMS: time.Since(startedAt).Milliseconds(),
+	})
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/progress"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestSetupProgress(t *testing.T) {
+	origStdout := os.Stdout
+	defer func() {
+		os.Stdout = origStdout
+		progressEmitter = nil
+	}()
+
+	tests := []struct {
+		name        string
+		format      string
+		fd          int
+		wantErr     bool
+		wantEmitter bool
+	}{
+		{"default", "", 1, false, false},
+		{"text", "text", 1, false, false},
+		{"jsonl", "jsonl", 1, false, true},
+		{"unknown format", "xml", 1, true, false},
+		{"negative fd", "jsonl", -1, true, false},
+		{"closed fd", "jsonl", 987, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Stdout = origStdout
+			err := setupProgress(tt.format, tt.fd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("setupProgress(%q, %d) error = %v, wantErr %v", tt.format, tt.fd, err, tt.wantErr)
+			}
+			if (progressEmitter != nil) != tt.wantEmitter {
+				t.Errorf("progressEmitter set = %v, want %v", progressEmitter != nil, tt.wantEmitter)
+			}
+			if tt.wantEmitter && os.Stdout != os.Stderr {
+				t.Error("human output should move to stderr with jsonl")
+			}
+			if !tt.wantEmitter && os.Stdout != origStdout {
+				t.Error("stdout should be unchanged")
+			}
+		})
+	}
+}
+
+func TestTrackTicket(t *testing.T) {
+	var buf bytes.Buffer
+	progressEmitter = progress.NewEmitter(&buf)
+	defer func() { progressEmitter = nil }()
+
+	tk := ticket.NewTicket("TICKET-001", "Add login", "")
+	err := trackTicket("work", tk, func() error {
+		tk.MarkFailed(errors.New("tests failed"))
+		return errors.New("ticket TICKET-001 failed")
+	})
+	if err == nil {
+		t.Fatal("trackTicket should return the process error")
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d events, want 2: %s", len(lines), buf.String())
+	}
+	var started, completed progress.Event
+	if err := json.Unmarshal([]byte(lines[0]), &started); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &completed); err != nil {
+		t.Fatal(err)
+	}
+	if started.Type != progress.TypeTicketStarted || started.TicketID != "TICKET-001" || started.Command != "work" {
+		t.Errorf("started = %+v", started)
+	}
+	if completed.Type != progress.TypeTicketCompleted || completed.Status != "failed" || completed.Error != "tests failed" {
+		t.Errorf("completed = %+v", completed)
+	}
+}
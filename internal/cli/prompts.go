@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/prompts"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var promptsInitForce bool
+
+var promptsCmd = &cobra.Command{
+	Use:   "prompts",
+	Short: i18n.CmdPromptsShort,
+	Long:  i18n.CmdPromptsLong,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		set, err := prompts.Load(cfg.ProjectRoot)
+		if err != nil {
+			return err
+		}
+		listPrompts(os.Stdout, set)
+		return nil
+	},
+}
+
+var promptsInitCmd = &cobra.Command{
+	Use:   "init [name...]",
+	Short: i18n.CmdPromptsInitShort,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := filepath.Join(cfg.ProjectRoot, prompts.Dir, prompts.LangDir(i18n.Language()))
+		return initPrompts(os.Stdout, dir, args, promptsInitForce)
+	},
+}
+
+func init() {
+	promptsInitCmd.Flags().BoolVar(&promptsInitForce, "force", false, i18n.FlagPromptsInitForce)
+	promptsCmd.AddCommand(promptsInitCmd)
+}
+
+// listPrompts prints each prompt and whether the project overrides it.
+func listPrompts(w io.Writer, set *prompts.Set) {
+	ui.PrintHeader(w, i18n.UIPrompts)
+	for _, name := range prompts.Names() {
+		source := set.Source(name)
+		if source == "" {
+			source = i18n.MsgPromptEmbedded
+		}
+		fmt.Fprintf(w, "  %-10s %s\n", name, ui.StyleMuted.Render(source))
+	}
+}
+
+// initPrompts writes the embedded templates of names (all prompts when empty) in the
+// current language to dir as a starting point for customization. Existing files are
+// kept unless force is set.
+func initPrompts(w io.Writer, dir string, names []string, force bool) error {
+	if len(names) == 0 {
+		names = prompts.Names()
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, name := range names {
+		text, err := prompts.Default(name)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, name+".tmpl")
+		if _, err := os.Stat(path); err == nil && !force {
+			ui.PrintInfo(w, ui.StyleMuted.Render(fmt.Sprintf(i18n.MsgPromptExists, path)))
+			continue
+		}
+		if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+			return err
+		}
+		ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgPromptWritten, path))
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/prompts"
+)
+
+func TestInitPrompts(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), prompts.Dir)
+	var buf bytes.Buffer
+	if err := initPrompts(&buf, dir, []string{prompts.Coding}, false); err != nil {
+		t.Fatalf("initPrompts() error = %v", err)
+	}
+	path := filepath.Join(dir, "coding.tmpl")
+	want, _ := prompts.Default(prompts.Coding)
+	if got, err := os.ReadFile(path); err != nil || string(got) != want {
+		t.Fatalf("coding.tmpl = %q, %v; want the embedded template", got, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "review.tmpl")); !os.IsNotExist(err) {
+		t.Error("initPrompts(coding) should only write coding.tmpl")
+	}
+
+	// Customized templates are kept unless forced.
+	if err := os.WriteFile(path, []byte("custom"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := initPrompts(&buf, dir, nil, false); err != nil {
+		t.Fatalf("initPrompts() error = %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "custom" {
+		t.Errorf("initPrompts() without --force overwrote coding.tmpl: %q", got)
+	}
+	for _, name := range prompts.Names() {
+		if _, err := os.Stat(filepath.Join(dir, name+".tmpl")); err != nil {
+			t.Errorf("initPrompts() did not write %s.tmpl: %v", name, err)
+		}
+	}
+	if err := initPrompts(&buf, dir, []string{prompts.Coding}, true); err != nil {
+		t.Fatalf("initPrompts(force) error = %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != want {
+		t.Error("initPrompts() with --force should restore the embedded template")
+	}
+
+	if err := initPrompts(&buf, dir, []string{"deploy"}, false); err == nil {
+		t.Error("initPrompts(deploy) should fail")
+	}
+}
@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	recurringWatch    bool
+	recurringInterval time.Duration
+)
+
+var recurringCmd = &cobra.Command{
+	Use:   "recurring",
+	Short: i18n.CmdRecurringShort,
+	Long:  i18n.CmdRecurringLong,
+}
+
+var recurringListCmd = &cobra.Command{
+	Use:   "list",
+	Short: i18n.CmdRecurringListShort,
+	Args:  cobra.NoArgs,
+	RunE:  runRecurringList,
+}
+
+var recurringRemoveCmd = &cobra.Command{
+	Use:   "remove <recurring-id>",
+	Short: i18n.CmdRecurringRemoveShort,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runRecurringRemove,
+}
+
+var recurringRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: i18n.CmdRecurringRunShort,
+	Long:  i18n.CmdRecurringRunLong,
+	Args:  cobra.NoArgs,
+	RunE:  runRecurringRun,
+}
+
+func init() {
+	recurringRunCmd.Flags().BoolVar(&recurringWatch, "watch", false, i18n.FlagRecurringWatch)
+	recurringRunCmd.Flags().DurationVar(&recurringInterval, "interval", time.Minute, i18n.FlagRecurringInterval)
+
+	recurringCmd.AddCommand(recurringListCmd)
+	recurringCmd.AddCommand(recurringRemoveCmd)
+	recurringCmd.AddCommand(recurringRunCmd)
+}
+
+// generateRecurringID returns the ID of a new recurring template. Instances append
+// their schedule slot to it, e.g. RECUR-1700000000000-20250106-0900.
+func generateRecurringID() string {
+	return fmt.Sprintf("RECUR-%d", time.Now().UnixNano()/1000000)
+}
+
+// addRecurringTemplate registers t as a recurring template with the given schedule.
+func addRecurringTemplate(t *ticket.Ticket, rule string) error {
+	t.ID = generateRecurringID()
+	t.RecurrenceRule = strings.TrimSpace(rule)
+	path := cfg.RecurringPath()
+	r, err := ticket.LoadRecurring(path)
+	if err != nil {
+		return err
+	}
+	if err := r.Add(t); err != nil {
+		return err
+	}
+	return r.Save(path)
+}
+
+func runRecurringList(cmd *cobra.Command, args []string) error {
+	w := os.Stdout
+	r, err := ticket.LoadRecurring(cfg.RecurringPath())
+	if err != nil {
+		return err
+	}
+	if len(r.Templates) == 0 {
+		ui.PrintInfo(w, i18n.MsgRecurringNone)
+		return nil
+	}
+
+	store := newTicketStore()
+	ui.PrintHeader(w, i18n.UIRecurringTickets)
+	for _, t := range r.Templates {
+		fmt.Fprintf(w, "%s  %s  [%s]  P%d\n", t.ID, t.Title, t.RecurrenceRule, t.Priority)
+		if next, err := r.NextRun(t); err == nil && !next.IsZero() {
+			fmt.Fprintf(w, "  %s\n", fmt.Sprintf(i18n.MsgRecurringNext, next.Local().Format("2006-01-02 15:04")))
+		}
+		for _, id := range r.Instances(t.ID) {
+			status := "dropped"
+			if inst, err := store.Load(id); err == nil {
+				status = string(inst.Status)
+			}
+			fmt.Fprintf(w, "  - %s (%s)\n", id, status)
+		}
+	}
+	return nil
+}
+
+func runRecurringRemove(cmd *cobra.Command, args []string) error {
+	w := os.Stdout
+	path := cfg.RecurringPath()
+	r, err := ticket.LoadRecurring(path)
+	if err != nil {
+		return err
+	}
+	if !r.Remove(args[0]) {
+		return fmt.Errorf(i18n.ErrRecurringNotFound, args[0])
+	}
+	if err := r.Save(path); err != nil {
+		return err
+	}
+	ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgRecurringRemoved, args[0]))
+	return nil
+}
+
+func runRecurringRun(cmd *cobra.Command, args []string) error {
+	w := os.Stdout
+	if !recurringWatch {
+		if err := ErrIfBackgroundWorkRunning(); err != nil {
+			return err
+		}
+		created, err := instantiateRecurring(w, time.Now())
+		if err != nil {
+			return err
+		}
+		if created == 0 {
+			ui.PrintInfo(w, i18n.MsgRecurringNothingDue)
+		}
+		return nil
+	}
+
+	if recurringInterval <= 0 {
+		return fmt.Errorf(i18n.ErrRecurringInterval, recurringInterval)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgRecurringWatching, recurringInterval))
+	return watchRecurring(ctx, w, recurringInterval)
+}
+
+// watchRecurring instantiates due recurring tickets every interval until ctx is done.
+// Ticks are skipped while background work is running (the store must not be written
+// then); the slot is picked up on a later tick.
+func watchRecurring(ctx context.Context, w io.Writer, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := ErrIfBackgroundWorkRunning(); err == nil {
+			if _, err := instantiateRecurring(w, time.Now()); err != nil {
+				ui.PrintWarning(w, err.Error())
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// instantiateRecurring creates the pending tickets of all due recurring templates and
+// records the runs. Returns how many tickets were created.
+func instantiateRecurring(w io.Writer, now time.Time) (int, error) {
+	path := cfg.RecurringPath()
+	r, err := ticket.LoadRecurring(path)
+	if err != nil {
+		return 0, err
+	}
+	if len(r.Templates) == 0 {
+		return 0, nil
+	}
+	store := newTicketStore()
+	if err := store.Init(); err != nil {
+		return 0, fmt.Errorf(i18n.ErrInitStoreFailed, err)
+	}
+
+	created, err := r.Instantiate(store, now)
+	// Record the runs that did happen even if a later template failed.
+	if saveErr := r.Save(path); saveErr != nil && err == nil {
+		err = saveErr
+	}
+	for _, t := range created {
+		ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgRecurringCreated, t.ID, t.RecurringFrom))
+	}
+	return len(created), err
+}
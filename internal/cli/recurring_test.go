@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestRunAdd_Recur_CreatesTemplateAndRunInstantiates(t *testing.T) {
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{ProjectRoot: tmpDir, TicketsDir: ticketsDir}
+
+	resetAddFlags()
+	defer resetAddFlags()
+	addTitle = "Update dependencies"
+	addType = "refactor"
+	addPriority = 2
+	addRecur = "@daily"
+	captureOutput(func() {
+		if err := runAdd(nil, nil); err != nil {
+			t.Fatalf("runAdd() err = %v", err)
+		}
+	})
+
+	// The template is not a workable ticket.
+	store := ticket.NewStore(ticketsDir)
+	if counts, _ := store.Count(); counts[ticket.StatusPending] != 0 {
+		t.Errorf("template should not be in the store, got %d pending", counts[ticket.StatusPending])
+	}
+	r, err := ticket.LoadRecurring(cfg.RecurringPath())
+	if err != nil || len(r.Templates) != 1 {
+		t.Fatalf("LoadRecurring() = %v, %v; want one template", r, err)
+	}
+	tmpl := r.Templates[0]
+	if tmpl.RecurrenceRule != "@daily" || tmpl.Type != ticket.TypeRefactor {
+		t.Errorf("template = %+v", tmpl)
+	}
+
+	// Not due until the next midnight after creation.
+	if n, err := instantiateRecurring(io.Discard, time.Now()); err != nil || n != 0 {
+		t.Errorf("instantiateRecurring() now = %d, %v; want 0", n, err)
+	}
+	n, err := instantiateRecurring(io.Discard, time.Now().Add(25*time.Hour))
+	if err != nil || n != 1 {
+		t.Fatalf("instantiateRecurring() next day = %d, %v; want 1", n, err)
+	}
+	pending, _ := store.LoadByStatus(ticket.StatusPending)
+	if len(pending) != 1 || pending[0].RecurringFrom != tmpl.ID || pending[0].Priority != 2 {
+		t.Fatalf("pending = %+v, want one instance of %s", pending, tmpl.ID)
+	}
+
+	if err := runRecurringRemove(nil, []string{"RECUR-missing"}); err == nil {
+		t.Error("runRecurringRemove() should fail for an unknown ID")
+	}
+	captureOutput(func() {
+		if err := runRecurringRemove(nil, []string{tmpl.ID}); err != nil {
+			t.Errorf("runRecurringRemove() err = %v", err)
+		}
+	})
+	r, _ = ticket.LoadRecurring(cfg.RecurringPath())
+	if len(r.Templates) != 0 {
+		t.Errorf("template not removed: %+v", r.Templates)
+	}
+	if _, err := store.Load(pending[0].ID); err != nil {
+		t.Errorf("removing the template should keep its instances: %v", err)
+	}
+}
+
+func TestRunAdd_Recur_InvalidSchedule(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{ProjectRoot: tmpDir, TicketsDir: filepath.Join(tmpDir, ".tickets")}
+
+	resetAddFlags()
+	defer resetAddFlags()
+	addTitle = "Bad schedule"
+	addRecur = "every monday"
+	captureOutput(func() {
+		if err := runAdd(nil, nil); err != nil {
+			t.Fatalf("runAdd() err = %v", err)
+		}
+	})
+	r, _ := ticket.LoadRecurring(cfg.RecurringPath())
+	if len(r.Templates) != 0 {
+		t.Errorf("invalid schedule should not be registered: %+v", r.Templates)
+	}
+}
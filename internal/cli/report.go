@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/metrics"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var reportQualityLimit int
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: i18n.CmdReportShort,
+	Long:  i18n.CmdReportLong,
+}
+
+var reportQualityCmd = &cobra.Command{
+	Use:   "quality",
+	Short: i18n.CmdReportQualityShort,
+	Long:  i18n.CmdReportQualityLong,
+	Args:  cobra.NoArgs,
+	RunE:  runReportQuality,
+}
+
+func init() {
+	reportQualityCmd.Flags().IntVar(&reportQualityLimit, "limit", 10, i18n.FlagReportQualityLimit)
+	reportCmd.AddCommand(reportQualityCmd)
+}
+
+func runReportQuality(cmd *cobra.Command, args []string) error {
+	records, err := metrics.LoadQuality(cfg.QualityHistoryPath())
+	if err != nil {
+		return err
+	}
+	printQualityReport(os.Stdout, metrics.QualityTrend(records, reportQualityLimit))
+	return nil
+}
+
+// printQualityReport lists the score of each analyze run, oldest first, with the change
+// from the run before and a sparkline of the whole trend.
+func printQualityReport(w io.Writer, trend []metrics.QualityRecord) {
+	ui.PrintHeader(w, i18n.UIQualityReport)
+	if len(trend) == 0 {
+		ui.PrintInfo(w, i18n.MsgQualityNoHistory)
+		return
+	}
+
+	table := ui.NewTable(i18n.TableQualityTime, i18n.TableQualityScore, i18n.TableQualityChange,
+		"HIGH", "MED", "LOW", i18n.TableQualityIssues)
+	for i, r := range trend {
+		change := "-"
+		if i > 0 {
+			change = formatScoreDelta(r.Score - trend[i-1].Score)
+		}
+		table.AddRow(
+			r.At.Local().Format("2006-01-02 15:04"),
+			strconv.Itoa(r.Score),
+			change,
+			strconv.Itoa(r.High),
+			strconv.Itoa(r.Med),
+			strconv.Itoa(r.Low),
+			strconv.Itoa(r.Issues),
+		)
+	}
+	table.Render(w)
+
+	first, last := trend[0], trend[len(trend)-1]
+	ui.PrintInfo(w, "")
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgQualityScope, last.Scope))
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgQualityTrend, qualitySparkline(trend), first.Score, last.Score, qualityVerdict(last.Score-first.Score)))
+}
+
+// qualitySparkline renders the scores of trend as a sparkline.
+func qualitySparkline(trend []metrics.QualityRecord) string {
+	scores := make([]int, len(trend))
+	for i, r := range trend {
+		scores[i] = r.Score
+	}
+	return ui.Sparkline(scores)
+}
+
+// qualityVerdict describes a score change; lower scores mean less technical debt.
+func qualityVerdict(delta int) string {
+	switch {
+	case delta < 0:
+		return ui.StyleSuccess.Render(i18n.MsgQualityImproving)
+	case delta > 0:
+		return ui.StyleError.Render(i18n.MsgQualityDegrading)
+	default:
+		return ui.StyleMuted.Render(i18n.MsgQualityStable)
+	}
+}
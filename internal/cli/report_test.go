@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/metrics"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestRecordQuality_AndReportQuality(t *testing.T) {
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: t.TempDir()}
+	defer func(scope []string, changed bool) { analyzeScope, analyzeChanged = scope, changed }(analyzeScope, analyzeChanged)
+	analyzeScope, analyzeChanged = []string{"all"}, false
+
+	var buf bytes.Buffer
+	recordQuality(&buf, &ticket.IssueList{Issues: []*ticket.Issue{{Severity: "HIGH"}, {Severity: "LOW"}}})
+	recordQuality(&buf, &ticket.IssueList{Issues: []*ticket.Issue{{Severity: "MED"}}})
+	if !strings.Contains(buf.String(), "（上次 11，-8）") {
+		t.Errorf("second run should report the change, got:\n%s", buf.String())
+	}
+
+	analyzeChanged = true
+	recordQuality(&buf, &ticket.IssueList{Issues: []*ticket.Issue{{Severity: "HIGH"}}})
+	records, err := metrics.LoadQuality(cfg.QualityHistoryPath())
+	if err != nil || len(records) != 2 {
+		t.Fatalf("history = %d records, %v; --changed runs should not be recorded", len(records), err)
+	}
+
+	reportQualityLimit = 10
+	output := captureOutput(func() {
+		if err := runReportQuality(nil, nil); err != nil {
+			t.Errorf("runReportQuality() error = %v", err)
+		}
+	})
+	for _, want := range []string{"11", "-8", "▁", i18n.MsgQualityImproving} {
+		if !strings.Contains(output, want) {
+			t.Errorf("report should contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestPrintQualityReport_NoHistory(t *testing.T) {
+	var buf bytes.Buffer
+	printQualityReport(&buf, nil)
+	if !strings.Contains(buf.String(), i18n.MsgQualityNoHistory) {
+		t.Errorf("output = %q, want the no-history hint", buf.String())
+	}
+}
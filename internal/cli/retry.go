@@ -2,8 +2,11 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"time"
 
+	"github.com/anthropic/agent-orchestrator/internal/agent"
 	"github.com/anthropic/agent-orchestrator/internal/i18n"
 	"github.com/anthropic/agent-orchestrator/internal/ticket"
 	"github.com/anthropic/agent-orchestrator/internal/ui"
@@ -11,7 +14,7 @@ import (
 )
 
 var retryCmd = &cobra.Command{
-	Use:   "retry",
+	Use:   "retry [ticket-id...]",
 	Short: i18n.CmdRetryShort,
 	Long:  i18n.CmdRetryLong,
 	RunE:  runRetry,
@@ -20,7 +23,11 @@ var retryCmd = &cobra.Command{
 func runRetry(cmd *cobra.Command, args []string) error {
 	w := os.Stdout
 
-	store := ticket.NewStore(cfg.TicketsDir)
+	store := newTicketStore()
+
+	if len(args) > 0 {
+		return retryTickets(w, store, args)
+	}
 
 	// Get failed tickets
 	failed, err := store.LoadByStatus(ticket.StatusFailed)
@@ -35,6 +42,9 @@ func runRetry(cmd *cobra.Command, args []string) error {
 
 	ui.PrintHeader(w, i18n.UIRetryFailed)
 	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgFoundFailedTickets, len(failed)))
+	for _, t := range failed {
+		printTimeoutBump(w, t)
+	}
 
 	// Move failed tickets to pending
 	count, err := store.MoveFailed()
@@ -48,3 +58,53 @@ func runRetry(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// retryTickets moves the given failed tickets back to pending; tickets in another
+// status are reported and left alone.
+func retryTickets(w io.Writer, store ticket.Storer, ids []string) error {
+	count := 0
+	for _, id := range ids {
+		t, err := store.Load(id)
+		if err != nil {
+			ui.PrintError(w, fmt.Sprintf(i18n.ErrTicketNotFound, id))
+			continue
+		}
+		if t.Status != ticket.StatusFailed {
+			ui.PrintWarning(w, fmt.Sprintf(i18n.MsgTicketNotFailed, id, t.Status))
+			continue
+		}
+		resetForRetry(t)
+		if err := store.Save(t); err != nil {
+			return fmt.Errorf(i18n.ErrSaveTicketFailed, id)
+		}
+		printTimeoutBump(w, t)
+		count++
+	}
+	if count > 0 {
+		ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgMovedToPending, count))
+	}
+	return nil
+}
+
+// resetForRetry moves failed ticket t back to pending for another attempt.
+func resetForRetry(t *ticket.Ticket) {
+	t.SetStatus(ticket.StatusPending, ticket.ReasonRetry)
+	t.Error = ""
+	t.CompletedAt = nil
+}
+
+// printTimeoutBump reports the longer timeout the next attempt of t gets when its last
+// attempt timed out.
+func printTimeoutBump(w io.Writer, t *ticket.Ticket) {
+	if t.TimedOutAfter == 0 {
+		return
+	}
+	timeout := agent.TicketTimeout(t, cfg.TicketTimeoutDurations(), time.Duration(cfg.AgentTimeout)*time.Second)
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgRetryTimeoutBumped, t.ID, time.Duration(t.TimedOutAfter)*time.Second, timeout))
+}
+
+// retryCommand returns the commands that reproduce a failed ticket's run: moving it
+// back to pending and processing it again.
+func retryCommand(id string) string {
+	return fmt.Sprintf(i18n.RetryCommand, id, id)
+}
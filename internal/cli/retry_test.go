@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestRetryTickets(t *testing.T) {
+	store := ticket.NewStore(t.TempDir())
+	if err := store.Init(); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"T-1", "T-2"} {
+		tk := ticket.NewTicket(id, id, "")
+		tk.MarkFailed(errors.New("boom"))
+		if err := store.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Save(ticket.NewTicket("T-3", "pending", "")); err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	if err := retryTickets(&out, store, []string{"T-1", "T-3", "T-9"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"T-3", "T-9"} {
+		if !strings.Contains(out.String(), id) {
+			t.Errorf("output should report %s:\n%s", id, out.String())
+		}
+	}
+
+	want := map[string]ticket.Status{"T-1": ticket.StatusPending, "T-2": ticket.StatusFailed, "T-3": ticket.StatusPending}
+	for id, status := range want {
+		tk, err := store.Load(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tk.Status != status {
+			t.Errorf("%s status = %s, want %s", id, tk.Status, status)
+		}
+	}
+	if tk, _ := store.Load("T-1"); tk.Error != "" || tk.CompletedAt != nil {
+		t.Errorf("retried ticket keeps error %q / completed_at %v", tk.Error, tk.CompletedAt)
+	}
+}
+
+func TestRetryCommand(t *testing.T) {
+	want := "agent-orchestrator retry TICKET-007 && agent-orchestrator work TICKET-007"
+	if got := retryCommand("TICKET-007"); got != want {
+		t.Errorf("retryCommand = %q, want %q", got, want)
+	}
+}
+
+func TestRetryTickets_TimeoutBump(t *testing.T) {
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = createTestConfig(t.TempDir())
+	cfg.TicketTimeouts = map[string]string{"medium": "15m"}
+	cfg.AgentTimeout = 600
+
+	store := ticket.NewStore(cfg.TicketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatal(err)
+	}
+	tk := ticket.NewTicket("T-1", "slow", "")
+	tk.TimedOutAfter = 900
+	tk.MarkFailed(errors.New("timeout"))
+	if err := store.Save(tk); err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	if err := retryTickets(&out, store, []string{"T-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := fmt.Sprintf(i18n.MsgRetryTimeoutBumped, "T-1", 15*time.Minute, 30*time.Minute); !strings.Contains(out.String(), want) {
+		t.Errorf("output should contain %q, got:\n%s", want, out.String())
+	}
+}
@@ -4,13 +4,20 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/anthropic/agent-orchestrator/internal/agent"
+	"github.com/anthropic/agent-orchestrator/internal/feedback"
+	"github.com/anthropic/agent-orchestrator/internal/gitx"
 	"github.com/anthropic/agent-orchestrator/internal/i18n"
 	"github.com/anthropic/agent-orchestrator/internal/ui"
 	"github.com/spf13/cobra"
 )
 
+// reviewBase is the ref review compares with (--base); empty is HEAD.
+var reviewBase string
+
 var reviewCmd = &cobra.Command{
 	Use:   "review [files...]",
 	Short: i18n.CmdReviewShort,
@@ -18,6 +25,10 @@ var reviewCmd = &cobra.Command{
 	RunE:  runReview,
 }
 
+func init() {
+	reviewCmd.Flags().StringVar(&reviewBase, "base", "", i18n.FlagReviewBase)
+}
+
 func runReview(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 	w := os.Stdout
@@ -26,6 +37,11 @@ func runReview(cmd *cobra.Command, args []string) error {
 	var files []string
 	if len(args) > 0 {
 		files = args
+	} else if reviewBase != "" {
+		var err error
+		if files, err = gitx.NewManager(cfg.ProjectRoot, "").DiffFiles(ctx, reviewBase, snapshotExcludes()); err != nil {
+			return fmt.Errorf(i18n.ErrReviewDiff, reviewBase, err)
+		}
 	} else {
 		// Get changed files from git
 		files = getGitChangedFiles(ctx)
@@ -50,6 +66,12 @@ func runReview(cmd *cobra.Command, args []string) error {
 	}
 
 	reviewAgent := agent.NewReviewAgent(caller, cfg.ProjectRoot)
+	diff, err := reviewDiff(ctx, reviewBase, files)
+	if err != nil && reviewBase != "" {
+		return fmt.Errorf(i18n.ErrReviewDiff, reviewBase, err)
+	}
+	reviewAgent.SetDiff(diff)
+	reviewAgent.SetBatching(cfg.ReviewBatchSize, cfg.MaxParallel)
 
 	// Run review
 	spinner := ui.NewSpinner(i18n.SpinnerReviewing, w)
@@ -74,9 +96,16 @@ func runReview(cmd *cobra.Command, args []string) error {
 			ui.PrintInfo(w, "")
 			ui.PrintInfo(w, fmt.Sprintf(i18n.MsgSummary, reviewResult.Summary))
 		}
+		for _, issue := range reviewResult.Issues {
+			ui.PrintInfo(w, "  - "+issue)
+		}
+		if reviewResult.Blocks(cfg.ReviewBlockOn) {
+			ui.PrintWarning(w, fmt.Sprintf(i18n.MsgReviewBlocksCommit, strings.Join(cfg.ReviewBlockOn, ", ")))
+		}
 	} else {
 		spinner.Success(i18n.MsgReviewComplete)
 	}
+	recordReviewFindings(time.Now().Format("review-20060102-150405"), reviewResult)
 
 	// Print full output if verbose
 	if cfg.Verbose && result != nil {
@@ -87,3 +116,29 @@ func runReview(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// reviewDiff returns the diff of files since base (HEAD when empty) for the review
+// prompt, leaving out the orchestrator's own directories. Outside a git repository
+// there is no diff and the review reads the files.
+func reviewDiff(ctx context.Context, base string, files []string) (string, error) {
+	return gitx.NewManager(cfg.ProjectRoot, "").Diff(ctx, base, files, snapshotExcludes())
+}
+
+// recordReviewFindings persists the review's issues under source (ticket IDs or a review
+// label) so that recurring ones are added to future coding prompts as conventions.
+// Best-effort: a write failure must not fail the review.
+func recordReviewFindings(source string, reviewResult *agent.ReviewResult) {
+	if cfg.DryRun || reviewResult == nil || len(reviewResult.Issues) == 0 {
+		return
+	}
+	// Structured findings are recorded by message, so the same issue recurs regardless
+	// of where it was found.
+	issues := reviewResult.Issues
+	if len(reviewResult.Findings) > 0 {
+		issues = make([]string, len(reviewResult.Findings))
+		for i, f := range reviewResult.Findings {
+			issues[i] = f.Message
+		}
+	}
+	_ = feedback.Record(cfg.ReviewFindingsPath(), source, issues)
+}
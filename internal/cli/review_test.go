@@ -2,11 +2,18 @@ package cli
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
+	"github.com/anthropic/agent-orchestrator/internal/agent"
 	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/feedback"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
 )
 
 func TestGetGitChangedFiles_InvalidProjectRoot(t *testing.T) {
@@ -118,3 +125,104 @@ func TestGetGitChangedFiles_ValidContext(t *testing.T) {
 	// Just verify no panic occurred - result can be nil or a slice
 	t.Logf("getGitChangedFiles returned %d files", len(result))
 }
+
+func TestRecordReviewFindings_FeedsCodingConventions(t *testing.T) {
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: t.TempDir(), ReviewConventionsTop: 5}
+
+	rr := &agent.ReviewResult{Status: "CHANGES_REQUESTED", Issues: []string{"Missing error wrapping"}}
+	recordReviewFindings("T-1", rr)
+	recordReviewFindings("T-2", nil)
+	if got := feedback.Conventions(cfg.ReviewFindingsPath(), cfg.ReviewConventionsTop); len(got) != 0 {
+		t.Fatalf("a single occurrence should not become a convention, got %v", got)
+	}
+
+	recordReviewFindings("T-3", rr)
+	got := feedback.Conventions(cfg.ReviewFindingsPath(), cfg.ReviewConventionsTop)
+	if len(got) != 1 || got[0] != "Missing error wrapping" {
+		t.Errorf("Conventions() = %v, want [Missing error wrapping]", got)
+	}
+
+	cfg.DryRun = true
+	recordReviewFindings("T-4", &agent.ReviewResult{Issues: []string{"Dry run issue"}})
+	f, err := feedback.Load(cfg.ReviewFindingsPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Items) != 1 {
+		t.Errorf("dry run should not record findings, got %d items", len(f.Items))
+	}
+}
+
+func TestRunReview_Base(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=t", "-c", "user.email=t@t"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("init", "-q", "-b", "main")
+	write("old.go", "package old\n")
+	git("add", ".")
+	git("commit", "-qm", "base")
+	git("checkout", "-qb", "feature")
+	write("api.go", "package api\n")
+	git("add", ".")
+	git("commit", "-qm", "api")
+	write("util.go", "package util // uncommitted\n")
+
+	// The fake agent records the prompt it was given.
+	promptPath := filepath.Join(t.TempDir(), "prompt.txt")
+	agentPath := filepath.Join(t.TempDir(), "fake-agent")
+	if err := os.WriteFile(agentPath, []byte("#!/bin/sh\necho \"$*\" > "+promptPath+"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	originalCfg := cfg
+	defer func() { cfg, reviewBase = originalCfg, "" }()
+	cfg = &config.Config{ProjectRoot: dir, TicketsDir: filepath.Join(dir, ".tickets"), AgentCommand: agentPath}
+
+	reviewBase = "main"
+	var err error
+	output := captureOutput(func() {
+		err = runReview(reviewCmd, nil)
+	})
+	if err != nil {
+		t.Fatalf("runReview(--base main) error = %v\n%s", err, output)
+	}
+	for _, want := range []string{"  - api.go", "  - util.go"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output should list %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "old.go") {
+		t.Errorf("files unchanged since the base should not be reviewed, got:\n%s", output)
+	}
+	prompt, err := os.ReadFile(promptPath)
+	if err != nil {
+		t.Fatalf("the agent was not called: %v", err)
+	}
+	for _, want := range []string{"+package api", "+package util // uncommitted"} {
+		if !strings.Contains(string(prompt), want) {
+			t.Errorf("prompt should contain the diff line %q, got:\n%s", want, prompt)
+		}
+	}
+
+	reviewBase = "no-such-ref"
+	err = runReview(reviewCmd, nil)
+	if err == nil || !strings.HasPrefix(err.Error(), fmt.Sprintf(i18n.ErrReviewDiff, "no-such-ref", "")) {
+		t.Errorf("runReview(--base no-such-ref) error = %v, want %q", err, i18n.ErrReviewDiff)
+	}
+}
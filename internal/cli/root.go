@@ -2,13 +2,20 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/anthropic/agent-orchestrator/internal/agent"
 	"github.com/anthropic/agent-orchestrator/internal/config"
 	orcherrors "github.com/anthropic/agent-orchestrator/internal/errors"
 	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/prompts"
+	"github.com/anthropic/agent-orchestrator/internal/redact"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/anthropic/agent-orchestrator/internal/update"
 	"github.com/spf13/cobra"
 )
 
@@ -27,11 +34,17 @@ var (
 
 	// Global flags
 	cfgFile     string
+	profileName string
 	dryRun      bool
 	verbose     bool
 	debug       bool
 	quiet       bool
 	outputFormat string
+	logFormat    string
+	lang         string
+	progressFormat string
+	progressFD     int
+	noLock         bool
 
 	// Global config
 	cfg *config.Config
@@ -49,9 +62,14 @@ var rootCmd = &cobra.Command{
 		}
 
 		var err error
-		cfg, err = config.Load()
+		cfg, err = config.LoadProfile(profileName)
 		if err != nil {
-			return fmt.Errorf(i18n.ErrLoadConfigFailed, err)
+			if !reportsConfigErrors(cmd) {
+				return fmt.Errorf(i18n.ErrLoadConfigFailed, err)
+			}
+			// doctor and config validate report the broken config themselves.
+			doctorConfigErr = fmt.Errorf(i18n.ErrLoadConfigFailed, err)
+			cfg = config.DefaultConfig()
 		}
 
 		// Override with flags
@@ -72,8 +90,34 @@ var rootCmd = &cobra.Command{
 		if outputFormat != "" {
 			cfg.AgentOutputFormat = outputFormat
 		}
+		if logFormat != "" {
+			cfg.LogFormat = logFormat
+		}
+		if lang != "" {
+			cfg.Language = lang
+		}
 
-		return cfg.Validate()
+		if err := i18n.LoadOverrides(filepath.Join(cfg.ProjectRoot, i18n.OverridesDir)); err != nil {
+			return err
+		}
+		if err := applyLanguage(cmd.Root(), cfg.Language); err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			if !reportsConfigErrors(cmd) {
+				return err
+			}
+			if doctorConfigErr == nil {
+				doctorConfigErr = err
+			}
+		}
+		// Invalid patterns were reported by Validate; the built-in ones then stay in effect.
+		_ = redact.Configure(cfg.LogRedactionPatterns)
+		if err := setupProgress(progressFormat, progressFD); err != nil {
+			return err
+		}
+		setupLogging(os.Stderr)
+		return ui.ConfigureTheme(cfg.Theme, cfg.ThemeColors)
 	},
 }
 
@@ -98,6 +142,10 @@ func IsDetachChild() bool {
 // Execute runs the root command
 func Execute() {
 	parseDetachChild(os.Args)
+	// Locale files and --lang are applied before Cobra runs so help output, which skips
+	// PersistentPreRunE, uses them too; errors are reported by PersistentPreRunE.
+	_ = i18n.LoadOverrides(i18n.OverridesDir)
+	_ = applyLanguage(rootCmd, parseLangFlag(os.Args[1:]))
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
 	}
@@ -106,6 +154,7 @@ func Execute() {
 func init() {
 	// Persistent flags (available to all commands)
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", i18n.FlagConfig)
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", i18n.FlagProfile)
 	rootCmd.PersistentFlags().BoolVar(&isDetachChild, "detach-child", false, "internal: run as detach child (set by work --detach)")
 	_ = rootCmd.PersistentFlags().MarkHidden("detach-child")
 	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, i18n.FlagDryRun)
@@ -113,25 +162,51 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, i18n.FlagDebug)
 	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, i18n.FlagQuiet)
 	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", i18n.FlagOutput)
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "", i18n.FlagLogFormat)
+	rootCmd.PersistentFlags().StringVar(&lang, "lang", "", i18n.FlagLang)
+	rootCmd.PersistentFlags().StringVar(&progressFormat, "progress-format", "text", i18n.FlagProgressFormat)
+	rootCmd.PersistentFlags().IntVar(&progressFD, "progress-fd", 1, i18n.FlagProgressFD)
+	rootCmd.PersistentFlags().BoolVar(&noLock, "no-lock", false, i18n.FlagNoLock)
 
 	// Add subcommands
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(selfUpdateCmd)
 	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(triageCmd)
 	rootCmd.AddCommand(analyzeCmd)
 	rootCmd.AddCommand(planCmd)
 	rootCmd.AddCommand(workCmd)
 	rootCmd.AddCommand(reviewCmd)
 	rootCmd.AddCommand(testCmd)
+	rootCmd.AddCommand(verifyCmd)
 	rootCmd.AddCommand(commitCmd)
 	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(statusCmd)
 	rootCmd.AddCommand(retryCmd)
 	rootCmd.AddCommand(cleanCmd)
+	rootCmd.AddCommand(archiveCmd)
 	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(storeCmd)
+	rootCmd.AddCommand(auditCmd)
+	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(hooksCmd)
+	rootCmd.AddCommand(logsCmd)
+	rootCmd.AddCommand(recurringCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(traceCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(searchCmd)
+	rootCmd.AddCommand(prCmd)
+	rootCmd.AddCommand(depsCmd)
+	rootCmd.AddCommand(graphCmd)
+	rootCmd.AddCommand(promptsCmd)
+	rootCmd.AddCommand(serveCmd)
 
 	// Ticket management commands
 	rootCmd.AddCommand(addCmd)
 	rootCmd.AddCommand(editCmd)
+	rootCmd.AddCommand(noteCmd)
 	rootCmd.AddCommand(dropCmd)
 }
 
@@ -139,10 +214,15 @@ func init() {
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: i18n.CmdVersionShort,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Agent Orchestrator %s\n", Version)
 		fmt.Printf("  Commit: %s\n", Commit)
 		fmt.Printf("  Built:  %s\n", BuildDate)
+		if versionCheck {
+			fmt.Println()
+			return runVersionCheck(context.Background(), os.Stdout, update.NewClient(updateReleaseURL()))
+		}
+		return nil
 	},
 }
 
@@ -151,19 +231,65 @@ func GetConfig() *config.Config {
 	return cfg
 }
 
-// CreateAgentCaller creates and configures an agent caller with the current config.
+// agentCommand returns the agent CLI to run: agent_command, except that the default
+// "agent" becomes the backend's own command when another backend is chosen explicitly.
+func agentCommand(backend agent.Backend) string {
+	if cfg.AgentCommand == config.DefaultConfig().AgentCommand && cfg.AgentBackend != "" && cfg.AgentBackend != agent.BackendAuto {
+		return backend.DefaultCommand()
+	}
+	return cfg.AgentCommand
+}
+
+// anthropicAPIKey returns the Messages API key from config, falling back to ANTHROPIC_API_KEY.
+func anthropicAPIKey() string {
+	if cfg.AnthropicAPIKey != "" {
+		return cfg.AnthropicAPIKey
+	}
+	return os.Getenv("ANTHROPIC_API_KEY")
+}
+
+// reportsConfigErrors reports whether cmd runs on the defaults when the config cannot
+// be loaded or is invalid, to diagnose it instead of failing.
+func reportsConfigErrors(cmd *cobra.Command) bool {
+	return cmd == doctorCmd || cmd == configValidateCmd
+}
+
+// CreateAgentCaller creates and configures an agent caller with the current config
+// and agent backend.
 // It sets up DryRun and Verbose modes, and checks if the agent is available.
 // Returns an error if the agent is not available (unless in DryRun mode).
 func CreateAgentCaller() (*agent.Caller, error) {
+	backend, err := agent.LookupBackend(cfg.AgentBackend, cfg.AgentCommand)
+	if err != nil {
+		return nil, err
+	}
+	if backend.Name() == agent.BackendAPI {
+		backend = agent.NewAPIBackend(anthropicAPIKey(), cfg.AnthropicAPIURL, cfg.AnthropicModel)
+	}
 	caller := agent.NewCaller(
-		cfg.AgentCommand,
+		agentCommand(backend),
 		cfg.AgentForce,
 		cfg.AgentOutputFormat,
 		cfg.LogsDir,
 	)
+	caller.SetBackend(backend)
 	caller.SetDryRun(cfg.DryRun)
-	caller.SetVerbose(cfg.Verbose)
+	caller.SetRetry(cfg.AgentMaxRetries, time.Duration(cfg.AgentBackoff)*time.Second)
+	caller.SetModels(cfg.Models)
+	caller.SetPolicy(agent.Policy{Text: cfg.Policy.Preamble, Version: cfg.Policy.Version})
+	promptSet, err := prompts.Load(cfg.ProjectRoot)
+	if err != nil {
+		return nil, err
+	}
+	caller.SetPrompts(promptSet)
+	agent.SetRateLimit(cfg.MaxAgentCallsPerMinute)
 	caller.DisableDetailedLog = cfg.DisableDetailedLog
+	caller.SetCallHook(func(info agent.CallInfo) {
+		recordAgentCall(info)
+		chargeAgentBudget(info)
+		observeAgentCall(info)
+	})
+	caller.SetProcessHook(trackAgentProcess)
 
 	if !caller.IsAvailable() && !cfg.DryRun {
 		return nil, orcherrors.ErrAgentNotAvailable()
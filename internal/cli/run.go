@@ -2,12 +2,15 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
 	"syscall"
+	"time"
 
-	"github.com/anthropic/agent-orchestrator/internal/agent"
 	orcherrors "github.com/anthropic/agent-orchestrator/internal/errors"
 	"github.com/anthropic/agent-orchestrator/internal/i18n"
 	"github.com/anthropic/agent-orchestrator/internal/ticket"
@@ -15,22 +18,46 @@ import (
 	"github.com/spf13/cobra"
 )
 
-// Note: agent import is still needed for NewPlanningAgent, NewCodingAgent, etc.
-
 var (
 	runAnalyzeFirst    bool
 	runSkipTest        bool
 	runSkipReview      bool
 	runSkipCommit      bool
 	runDetachAfterPlan bool
+	runAcceptance      bool
+	runVerifyCriteria  bool
+	runSnapshotFlag    bool
+	runRestoreLast     bool
+	runForce           bool
+	runResume          bool
+	runPipelineFile    string
+	runFrom            string
+	runUntil           string
+	// runReviewFixIterations is how many times changes requested by review are fixed
+	// and reviewed again before committing; 0 only reports them.
+	runReviewFixIterations int
 )
 
 var runCmd = &cobra.Command{
 	Use:   "run <milestone-file>",
 	Short: i18n.CmdRunShort,
 	Long:  i18n.CmdRunLong,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runPipeline,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if runRestoreLast {
+			if len(args) > 0 || runSnapshotFlag || runResume {
+				return errors.New(i18n.ErrRunRestoreArgs)
+			}
+			return nil
+		}
+		if runResume {
+			if len(args) > 0 || runSnapshotFlag || runPipelineFile != "" || runFrom != "" || runUntil != "" {
+				return errors.New(i18n.ErrRunResumeArgs)
+			}
+			return nil
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	RunE: runPipeline,
 }
 
 func init() {
@@ -39,6 +66,17 @@ func init() {
 	runCmd.Flags().BoolVar(&runSkipReview, "skip-review", false, i18n.FlagSkipReview)
 	runCmd.Flags().BoolVar(&runSkipCommit, "skip-commit", false, i18n.FlagSkipCommit)
 	runCmd.Flags().BoolVar(&runDetachAfterPlan, "detach-after-plan", false, i18n.FlagDetachAfterPlan)
+	runCmd.Flags().BoolVar(&runAcceptance, "acceptance-review", false, i18n.FlagAcceptanceReview)
+	runCmd.Flags().BoolVar(&runVerifyCriteria, "verify", false, i18n.FlagVerify)
+	runCmd.Flags().BoolVar(&workLenient, "lenient", false, i18n.FlagWorkLenient)
+	runCmd.Flags().BoolVar(&runSnapshotFlag, "snapshot", false, i18n.FlagRunSnapshot)
+	runCmd.Flags().BoolVar(&runRestoreLast, "restore-last", false, i18n.FlagRunRestoreLast)
+	runCmd.Flags().BoolVarP(&runForce, "force", "f", false, i18n.FlagForce)
+	runCmd.Flags().BoolVar(&runResume, "resume", false, i18n.FlagRunResume)
+	runCmd.Flags().StringVar(&runPipelineFile, "pipeline", "", i18n.FlagRunPipeline)
+	runCmd.Flags().StringVar(&runFrom, "from", "", i18n.FlagRunFrom)
+	runCmd.Flags().StringVar(&runUntil, "until", "", i18n.FlagRunUntil)
+	runCmd.Flags().IntVar(&runReviewFixIterations, "review-fix-iterations", 0, i18n.FlagReviewFixIterations)
 }
 
 func runPipeline(cmd *cobra.Command, args []string) error {
@@ -60,7 +98,47 @@ func runPipeline(cmd *cobra.Command, args []string) error {
 	}()
 
 	w := os.Stdout
-	milestoneFile := args[0]
+	if runRestoreLast {
+		store := newTicketStore()
+		if err := store.Init(); err != nil {
+			return orcherrors.ErrStoreInit(err)
+		}
+		return restoreLastRun(ctx, w, store, runForce)
+	}
+	startedAt := time.Now()
+
+	// The run is checkpointed after every step; --resume continues the last one with
+	// the milestone, pipeline and step flags it started with.
+	var state *runState
+	var pipeline *pipelineDef
+	if runResume {
+		var err error
+		if state, err = loadRunState(); err != nil {
+			return err
+		}
+		if state == nil {
+			return errors.New(i18n.ErrNoRunState)
+		}
+		state.apply()
+		pipeline = defaultPipeline()
+		if state.Pipeline != "" {
+			if pipeline, err = loadPipeline(state.Pipeline); err != nil {
+				return err
+			}
+		}
+	} else {
+		var path string
+		var err error
+		if pipeline, path, err = resolvePipeline(runPipelineFile); err != nil {
+			return err
+		}
+		state = newRunState(args[0], path)
+	}
+	milestoneFile := state.Milestone
+	steps, err := pipeline.between(runFrom, runUntil)
+	if err != nil {
+		return err
+	}
 
 	// Check if milestone file exists
 	if _, err := os.Stat(milestoneFile); os.IsNotExist(err) {
@@ -69,233 +147,66 @@ func runPipeline(cmd *cobra.Command, args []string) error {
 
 	ui.PrintHeader(w, i18n.UIFullPipeline)
 	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgMilestone, milestoneFile))
+	if state.Pipeline != "" {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgPipelineDefinition, state.Pipeline))
+	}
+	if runResume {
+		steps := "-"
+		if len(state.Steps) > 0 {
+			steps = strings.Join(state.Steps, ", ")
+		}
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgRunResuming, state.StartedAt.Format("2006-01-02 15:04:05"), steps))
+	}
 	ui.PrintInfo(w, "")
 
-	results := make(map[string]interface{})
-	totalSteps := 5
-	currentStep := 0
-
 	// Create agent caller
 	caller, err := CreateAgentCaller()
 	if err != nil {
 		return err
 	}
 
-	store := ticket.NewStore(cfg.TicketsDir)
+	store := newTicketStore()
 	if err := store.Init(); err != nil {
 		return orcherrors.ErrStoreInit(err)
 	}
 
-	// Step 0: Analyze (optional)
-	if runAnalyzeFirst {
-		currentStep++
-		ui.PrintStep(w, currentStep, totalSteps+1, i18n.StepAnalyze)
-
-		analyzeAgent := agent.NewAnalyzeAgent(caller, cfg.ProjectRoot)
-		scope := agent.AllScopes()
-
-		issues, err := analyzeAgent.Analyze(ctx, scope)
-		if err != nil {
-			// Analysis failure is recoverable - log and continue
-			recErr := orcherrors.ErrAnalysis(err)
-			ui.PrintWarning(w, recErr.Error())
-		} else if issues.Count() > 0 {
-			ui.PrintInfo(w, fmt.Sprintf(i18n.MsgFoundIssues, issues.Count()))
-			// Convert to tickets
-			ticketList := issues.ToTickets()
-			for _, t := range ticketList.Tickets {
-				if err := store.Save(t); err != nil {
-					// Ticket save failure is recoverable - log and continue
-					recErr := orcherrors.ErrSaveTicket(t.ID, err)
-					ui.PrintWarning(w, recErr.Error())
-				}
-			}
-			results["analyze"] = map[string]int{"issues": issues.Count()}
-		}
-		totalSteps++
-	}
-
-	// Step 1: Planning
-	currentStep++
-	ui.PrintStep(w, currentStep, totalSteps, i18n.StepPlanning)
-
-	planningAgent := agent.NewPlanningAgent(caller, cfg.ProjectRoot, cfg.TicketsDir)
-	tickets, err := planningAgent.Plan(ctx, milestoneFile)
-	if err != nil {
-		// Planning failure is fatal - must return error
-		return orcherrors.ErrPlanning(err)
+	// A resumed run keeps forking ticket branches from where it first started.
+	if state.BranchBase == "" {
+		state.BranchBase = ticketBranchBase(ctx)
 	}
-
-	for _, t := range tickets {
-		if err := store.Save(t); err != nil {
-			// Ticket save failure is recoverable - log and continue
-			recErr := orcherrors.ErrSaveTicket(t.ID, err)
-			ui.PrintWarning(w, recErr.Error())
-		}
+	if runResume {
+		state.requeueInterrupted(w, store)
+	} else if err := state.save(); err != nil {
+		ui.PrintWarning(w, fmt.Sprintf(i18n.ErrRunStateSave, err))
 	}
-	ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgGeneratedTickets, len(tickets)))
-	results["planning"] = map[string]int{"tickets_created": len(tickets)}
 
-	// Check for cancellation
-	select {
-	case <-ctx.Done():
-		ui.PrintWarning(w, i18n.MsgPipelineInterrupted)
-		return nil
-	default:
-	}
-
-	// If --detach-after-plan: start work in detach mode and return (Step 2+ run in background).
-	if runDetachAfterPlan {
-		params, err := buildWorkDetachParams(nil)
-		if err != nil {
-			return err
-		}
-		pid, err := execWorkDetach(params)
-		if err != nil {
+	var snapshot *runSnapshot
+	if runSnapshotFlag {
+		if snapshot, err = takeRunSnapshot(ctx, milestoneFile); err != nil {
 			return err
 		}
-		if params.LogPath != "" {
-			ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgRunDetachCodingDetached, pid, params.LogPath))
-		} else {
-			ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgRunDetachCodingDetachedNoLog, pid))
+		branch := snapshot.Branch
+		if branch == "" {
+			branch = "HEAD"
 		}
-		ui.PrintInfo(w, i18n.MsgRunDetachHintNextSteps)
-		return nil
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgRunSnapshotTaken, shortSHAs([]string{snapshot.Commit})[0], branch))
 	}
 
-	// Step 2: Coding
-	currentStep++
-	ui.PrintStep(w, currentStep, totalSteps, i18n.StepCoding)
-
-	codingAgent := agent.NewCodingAgent(caller, cfg.ProjectRoot)
-	resolver := ticket.NewDependencyResolver(store)
-
-	completed := 0
-	failed := 0
-
-	maxIterations := 20
-	for iteration := 0; iteration < maxIterations; iteration++ {
-		select {
-		case <-ctx.Done():
-			break
-		default:
-		}
-
-		processable, _ := resolver.GetProcessable()
-		if len(processable) == 0 {
-			break
-		}
-
-		for _, t := range processable {
-			t.MarkInProgress()
-			if err := store.Save(t); err != nil {
-				recErr := orcherrors.ErrSaveTicket(t.ID, err)
-				ui.PrintWarning(w, recErr.Error())
-			}
-
-			result, err := codingAgent.Execute(ctx, t)
-			if err != nil || !result.Success {
-				t.MarkFailed(fmt.Errorf("execution failed"))
-				failed++
-			} else {
-				t.MarkCompleted(result.Output)
-				completed++
-			}
-			if err := store.Save(t); err != nil {
-				recErr := orcherrors.ErrSaveTicket(t.ID, err)
-				ui.PrintWarning(w, recErr.Error())
-			}
-		}
-	}
-
-	ui.PrintSuccess(w, fmt.Sprintf("  "+i18n.MsgCountCompleted+", "+i18n.MsgCountFailed, completed, failed))
-	results["coding"] = map[string]int{"completed": completed, "failed": failed}
-
-	// Check for cancellation
-	select {
-	case <-ctx.Done():
-		ui.PrintWarning(w, i18n.MsgPipelineInterrupted)
-		return nil
-	default:
-	}
-
-	// Step 3: Testing
-	if !runSkipTest {
-		currentStep++
-		ui.PrintStep(w, currentStep, totalSteps, i18n.StepTesting)
-
-		testAgent := agent.NewTestAgent(caller, cfg.ProjectRoot)
-		testResult, _, err := testAgent.RunTests(ctx)
-		if err != nil {
-			// Test failure is recoverable - log and continue
-			recErr := orcherrors.ErrTest(err)
-			ui.PrintWarning(w, recErr.Error())
-			results["testing"] = map[string]bool{"success": false}
-		} else {
-			ui.PrintSuccess(w, "  "+i18n.MsgTestComplete)
-			results["testing"] = map[string]bool{"success": testResult.Success}
-		}
+	r := &pipelineRun{
+		ctx:       ctx,
+		w:         w,
+		caller:    caller,
+		store:     store,
+		milestone: milestoneFile,
+		state:     state,
+		snapshot:  snapshot,
+		startedAt: startedAt,
+		// Tickets completed before --resume are reviewed with this run's.
+		completedIDs: slices.Clone(state.Completed),
 	}
-
-	// Step 4: Review
-	if !runSkipReview {
-		currentStep++
-		ui.PrintStep(w, currentStep, totalSteps, i18n.StepReview)
-
-		files := getGitChangedFiles(ctx)
-		if len(files) > 0 {
-			reviewAgent := agent.NewReviewAgent(caller, cfg.ProjectRoot)
-			result, reviewResult, err := reviewAgent.Review(ctx, files)
-			if err != nil {
-				// Review failure is recoverable - log and continue
-				recErr := orcherrors.ErrReview(err)
-				ui.PrintWarning(w, recErr.Error())
-				results["review"] = map[string]bool{"success": false}
-			} else {
-				ui.PrintSuccess(w, "  "+i18n.MsgReviewComplete)
-				results["review"] = map[string]bool{"success": result.Success}
-				// 若需區分審查通過/需修改，可依 reviewResult.Status 使用
-				_ = reviewResult
-			}
-		} else {
-			ui.PrintInfo(w, "  "+i18n.MsgNoFilesToReview)
-			results["review"] = map[string]bool{"success": true}
-		}
-	}
-
-	// Step 5: Commit
-	if !runSkipCommit {
-		currentStep++
-		ui.PrintStep(w, currentStep, totalSteps, i18n.StepCommitting)
-
-		completedTickets, _ := store.LoadByStatus(ticket.StatusCompleted)
-		commitAgent := agent.NewCommitAgent(caller, cfg.ProjectRoot)
-
-		commitCount := 0
-		for _, t := range completedTickets {
-			changedFiles := getGitChangedFiles(ctx)
-			if len(changedFiles) == 0 {
-				break
-			}
-			filesToStage := filesForTicket(t, changedFiles)
-			if filesToStage == nil {
-				filesToStage = changedFiles
-			}
-			if len(filesToStage) == 0 {
-				continue
-			}
-			changes := getGitStatusForFiles(ctx, filesToStage)
-			if changes == "" {
-				continue
-			}
-			result, err := commitAgent.Commit(ctx, t.ID, t.Title, changes, filesToStage)
-			if err == nil && result.Success {
-				commitCount++
-			}
-		}
-
-		ui.PrintSuccess(w, fmt.Sprintf("  "+i18n.MsgCommitCount, commitCount))
-		results["committing"] = map[string]int{"commits": commitCount}
+	// An interrupted or stopped run keeps its checkpoint for --resume.
+	if err := r.execute(steps.withoutSkipped()); err != nil || r.detached || ctx.Err() != nil {
+		return err
 	}
 
 	// Summary
@@ -312,6 +223,15 @@ func runPipeline(cmd *cobra.Command, args []string) error {
 		counts[ticket.StatusFailed],
 	)
 	statusTable.Render(w)
+	u := caller.Usage()
+	if !u.IsZero() {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgRunUsageTotal, formatUsage(u.Tokens(), usageCost(u))))
+	}
+	emitRunSummary("run", r.completed, r.failed, counts[ticket.StatusPending], u.Tokens(), usageCost(u), startedAt)
+	notifyRunFinished(ctx, w, store, milestoneFile, startedAt)
 
+	if err := state.remove(); err != nil {
+		ui.PrintWarning(w, fmt.Sprintf(i18n.ErrRunStateSave, err))
+	}
 	return nil
 }
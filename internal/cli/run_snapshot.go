@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/gitx"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+)
+
+// runSnapshotRef keeps the snapshot commit of the last run --snapshot.
+const runSnapshotRef = "refs/agent-orchestrator/run-snapshot"
+
+// runSnapshot is the record of the last run --snapshot, kept at cfg.RunSnapshotPath()
+// until run --restore-last uses it or the next run --snapshot replaces it.
+type runSnapshot struct {
+	gitx.Snapshot
+	Milestone string `json:"milestone"`
+	// Tickets are the tickets the run created, deleted on restore.
+	Tickets []string `json:"tickets,omitempty"`
+}
+
+// takeRunSnapshot snapshots the project's working tree before a run of milestone.
+func takeRunSnapshot(ctx context.Context, milestone string) (*runSnapshot, error) {
+	snap, err := gitx.NewManager(cfg.ProjectRoot, "").Snapshot(ctx, runSnapshotRef)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.ErrRunSnapshotFailed, err)
+	}
+	s := &runSnapshot{Snapshot: *snap, Milestone: milestone}
+	return s, s.save()
+}
+
+// addTickets records tickets as created by the snapshotted run.
+func (s *runSnapshot) addTickets(tickets []*ticket.Ticket) error {
+	if s == nil || len(tickets) == 0 {
+		return nil
+	}
+	for _, t := range tickets {
+		s.Tickets = append(s.Tickets, t.ID)
+	}
+	return s.save()
+}
+
+func (s *runSnapshot) save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.RunSnapshotPath()), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(cfg.RunSnapshotPath(), data, 0644)
+}
+
+// loadRunSnapshot returns the record of the last run --snapshot, or nil when there is none.
+func loadRunSnapshot() (*runSnapshot, error) {
+	data, err := os.ReadFile(cfg.RunSnapshotPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var s runSnapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// snapshotExcludes returns the orchestrator's own directories inside the project, which
+// a restore must not touch (the ticket store is cleaned up ticket by ticket instead)
+// and a review diff leaves out.
+func snapshotExcludes() []string {
+	var excludes []string
+	for _, dir := range []string{cfg.TicketsDir, cfg.LogsDir, cfg.WorkDetachLogDir} {
+		if dir == "" {
+			continue
+		}
+		rel, err := filepath.Rel(cfg.ProjectRoot, dir)
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		excludes = append(excludes, filepath.ToSlash(rel))
+	}
+	return excludes
+}
+
+// restoreLastRun rolls the project back to the last run --snapshot after listing the
+// commits, files and tickets that go away and asking for confirmation (skipped with
+// force). Ticket branches of the deleted tickets are deleted too.
+func restoreLastRun(ctx context.Context, w *os.File, store ticket.Storer, force bool) error {
+	s, err := loadRunSnapshot()
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		return errors.New(i18n.ErrNoRunSnapshot)
+	}
+	m := gitx.NewManager(cfg.ProjectRoot, "")
+	commits, err := m.SnapshotCommits(ctx, &s.Snapshot)
+	if err != nil {
+		return fmt.Errorf(i18n.ErrRunRestoreFailed, err)
+	}
+	files, err := m.SnapshotChanges(ctx, &s.Snapshot, snapshotExcludes())
+	if err != nil {
+		return fmt.Errorf(i18n.ErrRunRestoreFailed, err)
+	}
+	var tickets []*ticket.Ticket
+	for _, id := range s.Tickets {
+		if t, err := store.Load(id); err == nil {
+			tickets = append(tickets, t)
+		}
+	}
+
+	ui.PrintHeader(w, i18n.UIRunRestore)
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgRunRestoreFrom, s.CreatedAt.Format("2006-01-02 15:04:05"), s.Milestone, shortSHAs([]string{s.Commit})[0]))
+	if len(commits) == 0 && len(files) == 0 && len(tickets) == 0 {
+		ui.PrintInfo(w, i18n.MsgRunRestoreNothing)
+		return nil
+	}
+	if len(commits) > 0 {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgRunRestoreCommits, len(commits)))
+		for _, c := range commits {
+			ui.PrintInfo(w, "  "+c)
+		}
+	}
+	if len(files) > 0 {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgRunRestoreFiles, len(files)))
+		for _, f := range files {
+			ui.PrintInfo(w, "  "+f)
+		}
+	}
+	if len(tickets) > 0 {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgRunRestoreTickets, len(tickets)))
+		for _, t := range tickets {
+			ui.PrintInfo(w, fmt.Sprintf("  %s: %s (%s)", t.ID, t.Title, t.Status))
+		}
+	}
+	ui.PrintInfo(w, "")
+
+	if !force {
+		prompt := ui.NewPrompt(os.Stdin, w)
+		ok, err := prompt.Confirm(i18n.PromptConfirmRestore, false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			ui.PrintInfo(w, i18n.MsgCancelled)
+			return nil
+		}
+	}
+
+	if err := m.Restore(ctx, &s.Snapshot, snapshotExcludes()); err != nil {
+		return fmt.Errorf(i18n.ErrRunRestoreFailed, err)
+	}
+	for _, t := range tickets {
+		if err := store.Delete(t.ID); err != nil {
+			ui.PrintWarning(w, fmt.Sprintf("%s: %v", i18n.ErrDeleteTicketFailed, err))
+		}
+		if t.Branch != "" && t.Branch != s.Branch {
+			// Best effort: the branch may have been deleted or merged already.
+			cmd := exec.CommandContext(ctx, "git", "branch", "-D", t.Branch)
+			cmd.Dir = cfg.ProjectRoot
+			_ = cmd.Run()
+		}
+	}
+	_ = m.DeleteSnapshot(ctx, &s.Snapshot)
+	if err := os.Remove(cfg.RunSnapshotPath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgRunRestored, s.CreatedAt.Format("2006-01-02 15:04:05")))
+	return nil
+}
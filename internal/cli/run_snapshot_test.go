@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestRestoreLastRun(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.CommandContext(ctx, "git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("init", "-q")
+	write("main.go", "package main\n")
+	git("add", ".")
+	git("commit", "-qm", "init")
+	head := git("rev-parse", "HEAD")
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	// The tickets directory is inside the project and not ignored.
+	cfg = &config.Config{ProjectRoot: dir, TicketsDir: filepath.Join(dir, ".tickets"), StoreBackend: "file"}
+	store := newTicketStore()
+	if err := store.Init(); err != nil {
+		t.Fatal(err)
+	}
+	existing := ticket.NewTicket("TICKET-000-existing", "Existing", "")
+	if err := store.Save(existing); err != nil {
+		t.Fatal(err)
+	}
+
+	var err error
+	captureOutput(func() { err = restoreLastRun(ctx, os.Stdout, store, true) })
+	if err == nil {
+		t.Fatal("restoreLastRun() without a snapshot should fail")
+	}
+
+	write("notes.txt", "uncommitted before run\n")
+	snap, err := takeRunSnapshot(ctx, "docs/milestone.md")
+	if err != nil {
+		t.Fatalf("takeRunSnapshot() error = %v", err)
+	}
+	// The run: a planned ticket worked on its branch, a commit and new files.
+	planned := ticket.NewTicket("TICKET-001-feature", "Feature", "")
+	planned.Branch = "ticket/TICKET-001-feature"
+	if err := store.Save(planned); err != nil {
+		t.Fatal(err)
+	}
+	if err := snap.addTickets([]*ticket.Ticket{planned}); err != nil {
+		t.Fatal(err)
+	}
+	git("checkout", "-qb", planned.Branch)
+	write("feature.go", "package main\n")
+	git("add", "feature.go")
+	git("commit", "-qm", "feature")
+	write("notes.txt", "changed by agent\n")
+
+	out := captureOutput(func() { err = restoreLastRun(ctx, os.Stdout, store, true) })
+	if err != nil {
+		t.Fatalf("restoreLastRun() error = %v\n%s", err, out)
+	}
+	for _, want := range []string{"feature.go", "notes.txt", "TICKET-001-feature"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("preview missing %q:\n%s", want, out)
+		}
+	}
+
+	if got := git("rev-parse", "HEAD"); got != head {
+		t.Errorf("HEAD = %s, want %s", got, head)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "feature.go")); !os.IsNotExist(err) {
+		t.Error("feature.go should be removed")
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "notes.txt")); string(data) != "uncommitted before run\n" {
+		t.Errorf("notes.txt = %q, want the uncommitted pre-run content", data)
+	}
+	if git("branch", "--list", planned.Branch) != "" {
+		t.Error("the ticket branch should be deleted")
+	}
+	if _, err := store.Load(planned.ID); err == nil {
+		t.Error("the planned ticket should be deleted")
+	}
+	if _, err := store.Load(existing.ID); err != nil {
+		t.Errorf("the pre-existing ticket should be kept: %v", err)
+	}
+	if s, _ := loadRunSnapshot(); s != nil {
+		t.Error("the snapshot record should be removed after restoring")
+	}
+}
@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+)
+
+// runState is the checkpoint of an unfinished run, kept at cfg.RunStatePath() after
+// every step so run --resume can continue after the last completed one. It is removed
+// when the run finishes or hands the remaining steps to a background work.
+type runState struct {
+	Milestone string `json:"milestone"`
+	// The step flags of the run, applied again on resume.
+	AnalyzeFirst bool `json:"analyze_first,omitempty"`
+	SkipTest     bool `json:"skip_test,omitempty"`
+	SkipReview   bool `json:"skip_review,omitempty"`
+	SkipCommit   bool `json:"skip_commit,omitempty"`
+	Acceptance   bool `json:"acceptance_review,omitempty"`
+	Verify       bool `json:"verify,omitempty"`
+	ReviewFixes  int  `json:"review_fix_iterations,omitempty"`
+	// From and Until bound the steps the run runs (--from, --until).
+	From  string `json:"from,omitempty"`
+	Until string `json:"until,omitempty"`
+	// Pipeline is the pipeline file the run follows; empty is the built-in order.
+	Pipeline string `json:"pipeline,omitempty"`
+	// BranchBase is the commit the run's ticket branches are created from (see
+	// ticketBranchBase), kept so that a resumed run forks from the same commit.
+	BranchBase string `json:"branch_base,omitempty"`
+	// Steps are the completed steps, by their step_changed event names.
+	Steps []string `json:"completed_steps"`
+	// Failed are the steps that failed, for the success and failure step conditions.
+	Failed []string `json:"failed_steps,omitempty"`
+	// Tickets are the tickets planning created.
+	Tickets []string `json:"tickets,omitempty"`
+	// Completed are the tickets the coding step completed, reviewed by the review step.
+	Completed []string `json:"completed_tickets,omitempty"`
+	// ReviewPassed is the outcome of the review step, once it ran.
+	ReviewPassed *bool `json:"review_passed,omitempty"`
+	// ReviewBlocked is set when the review found issues of a review_block_on severity.
+	ReviewBlocked bool      `json:"review_blocked,omitempty"`
+	StartedAt     time.Time `json:"started_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// newRunState starts the checkpoint of a run of milestone following pipeline, with the
+// current step flags.
+func newRunState(milestone, pipeline string) *runState {
+	return &runState{
+		Milestone:    milestone,
+		Pipeline:     pipeline,
+		AnalyzeFirst: runAnalyzeFirst,
+		SkipTest:     runSkipTest,
+		SkipReview:   runSkipReview,
+		SkipCommit:   runSkipCommit,
+		Acceptance:   runAcceptance,
+		Verify:       runVerifyCriteria,
+		ReviewFixes:  runReviewFixIterations,
+		From:         runFrom,
+		Until:        runUntil,
+		Steps:        []string{},
+		StartedAt:    time.Now(),
+	}
+}
+
+// loadRunState returns the checkpoint of the unfinished run, or nil when there is none.
+func loadRunState() (*runState, error) {
+	data, err := os.ReadFile(cfg.RunStatePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var s runState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.RunStatePath(), err)
+	}
+	return &s, nil
+}
+
+// apply sets the step flags to those the run started with.
+func (s *runState) apply() {
+	runAnalyzeFirst = s.AnalyzeFirst
+	runSkipTest = s.SkipTest
+	runSkipReview = s.SkipReview
+	runSkipCommit = s.SkipCommit
+	runAcceptance = s.Acceptance
+	runVerifyCriteria = s.Verify
+	runReviewFixIterations = s.ReviewFixes
+	runFrom = s.From
+	runUntil = s.Until
+}
+
+// done reports whether step completed before the run was resumed.
+func (s *runState) done(step string) bool {
+	return slices.Contains(s.Steps, step)
+}
+
+// complete records step as completed.
+func (s *runState) complete(step string) error {
+	if !s.done(step) {
+		s.Steps = append(s.Steps, step)
+	}
+	return s.save()
+}
+
+// addCompleted records a ticket the coding step completed.
+func (s *runState) addCompleted(id string) error {
+	s.Completed = append(s.Completed, id)
+	return s.save()
+}
+
+// save writes the checkpoint through a temporary file renamed over it, so a run killed
+// mid-write leaves the previous checkpoint rather than a truncated one.
+func (s *runState) save() error {
+	s.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := cfg.RunStatePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// remove deletes the checkpoint once nothing is left to resume.
+func (s *runState) remove() error {
+	if err := os.Remove(cfg.RunStatePath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// tickets loads the tickets planning created; those deleted since are left out.
+func (s *runState) tickets(store ticket.Storer) []*ticket.Ticket {
+	var out []*ticket.Ticket
+	for _, id := range s.Tickets {
+		if t, err := store.Load(id); err == nil {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// requeueInterrupted returns the run's tickets left in progress by the interrupted
+// run to pending, so the resumed coding step picks them up again.
+func (s *runState) requeueInterrupted(w io.Writer, store ticket.Storer) {
+	for _, t := range s.tickets(store) {
+		if t.Status != ticket.StatusInProgress {
+			continue
+		}
+		t.SetStatus(ticket.StatusPending, ticket.ReasonResumed)
+		if err := store.Save(t); err != nil {
+			ui.PrintWarning(w, err.Error())
+			continue
+		}
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgRunResumeRequeued, t.ID))
+	}
+}
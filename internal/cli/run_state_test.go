@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestRunState_SaveLoad(t *testing.T) {
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = createTestConfig(t.TempDir())
+
+	if s, err := loadRunState(); s != nil || err != nil {
+		t.Fatalf("loadRunState() without a run = %v, %v; want nil, nil", s, err)
+	}
+
+	defer func() { runSkipTest = false }()
+	runSkipTest = true
+	s := newRunState("docs/m.md", "")
+	s.Tickets = []string{"T-1"}
+	if err := s.complete(stepPlanning); err != nil {
+		t.Fatalf("complete() error = %v", err)
+	}
+	if err := s.addCompleted("T-1"); err != nil {
+		t.Fatalf("addCompleted() error = %v", err)
+	}
+	// Saves go through a temporary file renamed over the checkpoint.
+	entries, _ := os.ReadDir(filepath.Dir(cfg.RunStatePath()))
+	for _, e := range entries {
+		if strings.Contains(e.Name(), ".tmp-") {
+			t.Errorf("save() left the temporary file %s behind", e.Name())
+		}
+	}
+
+	runSkipTest = false
+	loaded, err := loadRunState()
+	if err != nil || loaded == nil {
+		t.Fatalf("loadRunState() = %v, %v", loaded, err)
+	}
+	loaded.apply()
+	if !runSkipTest {
+		t.Error("apply() should restore --skip-test")
+	}
+	if loaded.Milestone != "docs/m.md" || !loaded.done(stepPlanning) || loaded.done(stepCoding) ||
+		strings.Join(loaded.Tickets, ",") != "T-1" || strings.Join(loaded.Completed, ",") != "T-1" {
+		t.Errorf("loadRunState() = %+v", loaded)
+	}
+
+	if err := loaded.remove(); err != nil {
+		t.Fatalf("remove() error = %v", err)
+	}
+	if s, err := loadRunState(); s != nil || err != nil {
+		t.Errorf("loadRunState() after remove = %v, %v; want nil, nil", s, err)
+	}
+}
+
+func TestRunPipeline_Resume(t *testing.T) {
+	tmpDir, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	milestone := createMilestoneFile(t, tmpDir, "# Milestone")
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = createTestConfig(tmpDir)
+	defer func() {
+		runResume, runSkipTest, runSkipReview, runSkipCommit = false, false, false, false
+	}()
+
+	store := ticket.NewStore(cfg.TicketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+
+	// No checkpoint: nothing to resume
+	runResume = true
+	if err := runPipeline(runCmd, nil); err == nil || err.Error() != i18n.ErrNoRunState {
+		t.Fatalf("runPipeline(--resume) without a checkpoint error = %v, want %q", err, i18n.ErrNoRunState)
+	}
+
+	// The run stopped during coding: T-1 was left in progress.
+	for _, tk := range []*ticket.Ticket{
+		{ID: "T-1", Title: "api", Status: ticket.StatusInProgress},
+		{ID: "T-2", Title: "unrelated", Status: ticket.StatusInProgress},
+	} {
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("Failed to save ticket: %v", err)
+		}
+	}
+	runSkipTest, runSkipReview, runSkipCommit = true, true, true
+	s := newRunState(milestone, "")
+	s.Tickets = []string{"T-1"}
+	if err := s.complete(stepPlanning); err != nil {
+		t.Fatal(err)
+	}
+	runSkipTest, runSkipReview, runSkipCommit = false, false, false
+
+	var err error
+	output := captureOutput(func() {
+		err = runPipeline(runCmd, nil)
+	})
+	if err != nil {
+		t.Fatalf("runPipeline(--resume) error = %v\n%s", err, output)
+	}
+	for _, want := range []string{
+		i18n.MsgRunStepAlreadyDone,
+		fmt.Sprintf(i18n.MsgRunResumeRequeued, "T-1"),
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output should contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, fmt.Sprintf(i18n.MsgRunResumeRequeued, "T-2")) {
+		t.Errorf("only the run's own tickets should be requeued, got:\n%s", output)
+	}
+	if strings.Contains(output, i18n.StepTesting) {
+		t.Errorf("the resumed run should keep --skip-test, got:\n%s", output)
+	}
+	if tk, err := store.Load("T-1"); err != nil || tk.Status != ticket.StatusCompleted {
+		t.Errorf("T-1 = %+v, %v; want completed by the resumed coding step", tk, err)
+	}
+	if _, err := os.Stat(cfg.RunStatePath()); !os.IsNotExist(err) {
+		t.Errorf("the checkpoint should be removed once the run finishes, stat error = %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchStatuses []string
+	searchTypes    []string
+	searchArchived bool
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: i18n.CmdSearchShort,
+	Long:  i18n.CmdSearchLong,
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runSearch,
+}
+
+func init() {
+	searchCmd.Flags().StringSliceVar(&searchStatuses, "status", nil, i18n.FlagSearchStatus)
+	searchCmd.Flags().StringSliceVar(&searchTypes, "type", nil, i18n.FlagSearchType)
+	searchCmd.Flags().BoolVar(&searchArchived, "archived", false, i18n.FlagSearchArchived)
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	w := os.Stdout
+	for _, s := range searchStatuses {
+		if !ticket.Status(s).IsValid() {
+			return fmt.Errorf(i18n.ErrSearchStatus, s)
+		}
+	}
+	for _, t := range searchTypes {
+		if !ticket.Type(t).IsValid() {
+			return fmt.Errorf(i18n.ErrSearchType, t)
+		}
+	}
+
+	all, err := newTicketStore().LoadAll()
+	if err != nil {
+		return err
+	}
+	tickets := all.Tickets
+	if searchArchived {
+		archived, err := ticket.NewArchive(cfg.ArchiveDir()).LoadAll()
+		if err != nil {
+			return err
+		}
+		tickets = append(tickets, archived...)
+	}
+
+	query := strings.Join(args, " ")
+	hits := ticket.Search(filterSearchTickets(tickets), query)
+	ui.PrintHeader(w, fmt.Sprintf(i18n.UISearch, query))
+	if len(hits) == 0 {
+		ui.PrintInfo(w, i18n.MsgSearchNoMatch)
+		return nil
+	}
+	for _, h := range hits {
+		ui.PrintInfo(w, statusTicketLine(h.Ticket))
+		ui.PrintInfo(w, "    "+ui.StyleMuted.Render(fmt.Sprintf(i18n.MsgSearchHit, h.Ticket.Status, h.Field, h.Snippet)))
+	}
+	ui.PrintInfo(w, "")
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgSearchFound, len(hits)))
+	return nil
+}
+
+// filterSearchTickets keeps the tickets in one of the --status statuses and of one of
+// the --type types; an empty filter keeps every ticket.
+func filterSearchTickets(tickets []*ticket.Ticket) []*ticket.Ticket {
+	out := make([]*ticket.Ticket, 0, len(tickets))
+	for _, t := range tickets {
+		if len(searchStatuses) > 0 && !slices.Contains(searchStatuses, string(t.Status)) {
+			continue
+		}
+		if len(searchTypes) > 0 && !slices.Contains(searchTypes, string(t.Type)) {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestRunSearch(t *testing.T) {
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+	longAgo := time.Now().AddDate(0, -2, 0)
+	bug := ticket.NewTicket("T-1", "Fix cache invalidation", "")
+	bug.Type = ticket.TypeBugfix
+	bug.MarkCompleted("done")
+	feature := ticket.NewTicket("T-2", "Add metrics", "Count cache misses")
+	old := ticket.NewTicket("T-3", "Old cache work", "")
+	old.Status, old.CompletedAt = ticket.StatusCompleted, &longAgo
+	for _, tk := range []*ticket.Ticket{bug, feature, old} {
+		if err := store.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: ticketsDir, WorkPIDFile: filepath.Join(tmpDir, ".work.pid")}
+	if _, err := ticket.ArchiveTickets(store, ticket.NewArchive(cfg.ArchiveDir()), time.Now().AddDate(0, -1, 0)); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { searchStatuses, searchTypes, searchArchived = nil, nil, false }()
+
+	tests := []struct {
+		name     string
+		statuses []string
+		types    []string
+		archived bool
+		want     []string
+		notWant  []string
+	}{
+		{"all statuses", nil, nil, false, []string{"T-1", "T-2", fmt.Sprintf(i18n.MsgSearchFound, 2)}, []string{"T-3"}},
+		{"status filter", []string{"pending"}, nil, false, []string{"T-2"}, []string{"T-1"}},
+		{"type filter", nil, []string{"bugfix"}, false, []string{"T-1"}, []string{"T-2"}},
+		{"archived", nil, nil, true, []string{"T-1", "T-2", "T-3"}, nil},
+		{"no match", []string{"failed"}, nil, false, []string{i18n.MsgSearchNoMatch}, []string{"T-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchStatuses, searchTypes, searchArchived = tt.statuses, tt.types, tt.archived
+			output := captureOutput(func() {
+				if err := runSearch(nil, []string{"cache"}); err != nil {
+					t.Errorf("runSearch() error = %v", err)
+				}
+			})
+			for _, want := range tt.want {
+				if !strings.Contains(output, want) {
+					t.Errorf("output should contain %q, got:\n%s", want, output)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(output, notWant) {
+					t.Errorf("output should not contain %q, got:\n%s", notWant, output)
+				}
+			}
+		})
+	}
+
+	searchStatuses, searchTypes = []string{"done"}, nil
+	if err := runSearch(nil, []string{"cache"}); err == nil {
+		t.Error("runSearch() with an invalid status should fail")
+	}
+	searchStatuses, searchTypes = nil, []string{"chore"}
+	if err := runSearch(nil, []string{"cache"}); err == nil {
+		t.Error("runSearch() with an invalid type should fail")
+	}
+}
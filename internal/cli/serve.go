@@ -0,0 +1,318 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/audit"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/server"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/anthropic/agent-orchestrator/internal/workqueue"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddr              string
+	serveRecurring         bool
+	serveRecurringInterval time.Duration
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: i18n.CmdServeShort,
+	Long:  i18n.CmdServeLong,
+	Args:  cobra.NoArgs,
+	RunE:  runServe,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:8080", i18n.FlagServeAddr)
+	serveCmd.Flags().BoolVar(&serveRecurring, "recurring", false, i18n.FlagServeRecurring)
+	serveCmd.Flags().DurationVar(&serveRecurringInterval, "recurring-interval", time.Minute, i18n.FlagServeRecurringInterval)
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	w := os.Stdout
+	if serveRecurring && serveRecurringInterval <= 0 {
+		return fmt.Errorf(i18n.ErrRecurringInterval, serveRecurringInterval)
+	}
+	ln, err := net.Listen("tcp", serveAddr)
+	if err != nil {
+		return fmt.Errorf(i18n.ErrServeListen, serveAddr, err)
+	}
+	if len(cfg.ServeTokens) == 0 && !isLoopback(ln.Addr()) {
+		ln.Close()
+		return fmt.Errorf(i18n.ErrServeExposed, serveAddr)
+	}
+	defer ln.Close()
+
+	store := enableTelemetry(newTicketStore())
+	if err := store.Init(); err != nil {
+		return fmt.Errorf(i18n.ErrInitStoreFailed, err)
+	}
+	if err := cfg.EnsureDirs(); err != nil {
+		return err
+	}
+
+	api := server.New(&serveBackend{store: store})
+	api.SetTokens(serveTokens())
+	if cfg.Notifications.ServeURL != "" {
+		key, err := server.LoadRetryKey(cfg.RetryKeyPath())
+		if err != nil {
+			return err
+		}
+		api.SetRetryKey(key)
+	}
+	api.SetAudit(func(e audit.APIEntry) {
+		if err := audit.AppendAPI(cfg.APIAuditLogPath(), e); err != nil {
+			ui.PrintWarning(w, err.Error())
+		}
+	})
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", telemetryRegistry.Handler())
+	mux.Handle("/", api)
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgServeListening, "http://"+ln.Addr().String()))
+	if len(cfg.ServeTokens) > 0 {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgServeTokens, len(cfg.ServeTokens)))
+	}
+	if serveRecurring {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgServeRecurring, serveRecurringInterval))
+		go watchRecurring(ctx, w, serveRecurringInterval)
+	}
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// serveTokens returns the API tokens configured in serve_tokens.
+func serveTokens() []server.Token {
+	tokens := make([]server.Token, 0, len(cfg.ServeTokens))
+	for _, t := range cfg.ServeTokens {
+		tokens = append(tokens, server.Token{Name: t.Name, Secret: t.Token, Scope: server.Scope(t.Scope)})
+	}
+	return tokens
+}
+
+// isLoopback reports whether addr only accepts connections from this machine.
+func isLoopback(addr net.Addr) bool {
+	tcp, ok := addr.(*net.TCPAddr)
+	return ok && tcp.IP.IsLoopback()
+}
+
+// serveBackend implements server.Backend with the code paths of the add, status, work
+// and plan commands.
+type serveBackend struct {
+	store ticket.Storer
+
+	mu   sync.Mutex
+	work *server.Job // last work process started, to queue requests while it starts up
+	plan *server.Job // last plan process started; one plan runs at a time
+}
+
+func (b *serveBackend) Status(labels []string) (any, error) {
+	return buildStatusReport(b.store, labels, nil, nil)
+}
+
+func (b *serveBackend) Tickets(status ticket.Status, labels []string) ([]*ticket.Ticket, error) {
+	var tickets []*ticket.Ticket
+	if status == "" {
+		all, err := b.store.LoadAll()
+		if err != nil {
+			return nil, err
+		}
+		tickets = all.Tickets
+	} else {
+		var err error
+		if tickets, err = b.store.LoadByStatus(status); err != nil {
+			return nil, err
+		}
+	}
+	return ticket.FilterByLabels(tickets, labels), nil
+}
+
+func (b *serveBackend) Ticket(id string) (*ticket.Ticket, error) {
+	t, err := b.store.Load(id)
+	if err != nil {
+		return nil, server.NewError(http.StatusNotFound, fmt.Errorf(i18n.ErrTicketNotFound, id))
+	}
+	return t, nil
+}
+
+// AddTicket creates a ticket as add does with the same flags.
+func (b *serveBackend) AddTicket(req server.TicketRequest) (*ticket.Ticket, error) {
+	if req.Title == "" {
+		return nil, server.NewError(http.StatusBadRequest, errors.New(i18n.ErrTitleEmpty))
+	}
+	t := newTicketFromSpec(ticketSpec{
+		Title:              req.Title,
+		Description:        req.Description,
+		Type:               req.Type,
+		Priority:           req.Priority,
+		Dependencies:       req.Dependencies,
+		SoftDependencies:   req.SoftDependencies,
+		AcceptanceCriteria: req.AcceptanceCriteria,
+		Labels:             req.Labels,
+		ParentID:           req.ParentID,
+		Assertions:         req.Assertions,
+	})
+	if t.ParentID != "" {
+		if _, err := b.store.Load(t.ParentID); err != nil {
+			return nil, server.NewError(http.StatusBadRequest, fmt.Errorf(i18n.ErrTicketNotFound, t.ParentID))
+		}
+	}
+	if err := t.Validate(); err != nil {
+		return nil, server.NewError(http.StatusBadRequest, fmt.Errorf(i18n.ErrTicketInvalid, err.Error()))
+	}
+	if err := b.store.Save(t); err != nil {
+		return nil, fmt.Errorf(i18n.ErrSaveTicketFailed, t.ID)
+	}
+	return t, nil
+}
+
+// StartWork starts background work as work --detach does. While background work is
+// running the request is queued for it instead, as work --queue does.
+func (b *serveBackend) StartWork(ticketID string) (*server.Job, error) {
+	var args []string
+	if ticketID != "" {
+		if _, err := b.Ticket(ticketID); err != nil {
+			return nil, err
+		}
+		args = []string{ticketID}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	req := workqueue.Request{TicketID: ticketID}
+	switch {
+	case b.work != nil && !b.work.Exited():
+		// Started by us but possibly before writing its PID file: it takes the queue
+		// once its batch is done.
+		req, err := workqueue.New(cfg.WorkQueuePath()).Push(req)
+		if err != nil {
+			return nil, err
+		}
+		return &server.Job{Queued: true, QueueID: req.ID}, nil
+	case ErrIfBackgroundWorkRunning() != nil:
+		req, queued, err := pushWorkRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		if queued {
+			return &server.Job{Queued: true, QueueID: req.ID}, nil
+		}
+	}
+
+	params, err := buildWorkDetachParams(args)
+	if err != nil {
+		return nil, err
+	}
+	cmd, err := startWorkDetach(params)
+	if err != nil {
+		return nil, err
+	}
+	b.work = &server.Job{PID: cmd.Process.Pid, LogPath: params.LogPath, Done: waitDone(cmd)}
+	return b.work, nil
+}
+
+// RetryTicket moves failed ticket id back to pending, as retry does, and starts work
+// on it. Tickets that have not failed are refused.
+func (b *serveBackend) RetryTicket(id string) (*server.Job, error) {
+	t, err := b.Ticket(id)
+	if err != nil {
+		return nil, err
+	}
+	if t.Status != ticket.StatusFailed {
+		return nil, server.NewError(http.StatusConflict, fmt.Errorf(i18n.MsgTicketNotFailed, id, t.Status))
+	}
+	resetForRetry(t)
+	if err := b.store.Save(t); err != nil {
+		return nil, fmt.Errorf(i18n.ErrSaveTicketFailed, id)
+	}
+	return b.StartWork(id)
+}
+
+// StartPlan runs plan on milestone in a child process writing to a log under the logs
+// directory. Plans write the store, so they are refused while work or another plan runs.
+func (b *serveBackend) StartPlan(milestone string) (*server.Job, error) {
+	path := milestone
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(cfg.ProjectRoot, path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return nil, server.NewError(http.StatusBadRequest, fmt.Errorf(i18n.ErrMilestoneNotFound, milestone))
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := ErrIfBackgroundWorkRunning(); err != nil {
+		return nil, server.NewError(http.StatusConflict, err)
+	}
+	if (b.work != nil && !b.work.Exited()) || (b.plan != nil && !b.plan.Exited()) {
+		return nil, server.NewError(http.StatusConflict, errors.New(i18n.ErrServeBusy))
+	}
+
+	binary, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	logPath := filepath.Join(cfg.LogsDir, "plan-"+time.Now().Format("20060102-150405")+".log")
+	f, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	childArgs := []string{"plan", path}
+	if cfgFile != "" {
+		childArgs = append(childArgs, "--config", cfgFile)
+	}
+	if cfg.Profile != "" {
+		childArgs = append(childArgs, "--profile", cfg.Profile)
+	}
+	cmd := exec.Command(binary, childArgs...)
+	cmd.Dir = cfg.ProjectRoot
+	cmd.Stdout = f
+	cmd.Stderr = f
+	if err := cmd.Start(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	done := waitDone(cmd)
+	go func() {
+		<-done
+		f.Close()
+	}()
+	b.plan = &server.Job{PID: cmd.Process.Pid, LogPath: logPath, Done: done}
+	return b.plan, nil
+}
+
+// waitDone waits for cmd in the background and returns a channel closed once it exits.
+func waitDone(cmd *exec.Cmd) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(done)
+	}()
+	return done
+}
@@ -0,0 +1,238 @@
+package cli
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/server"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func setupServeBackend(t *testing.T) *serveBackend {
+	t.Helper()
+	tmpDir := t.TempDir()
+	originalCfg := cfg
+	t.Cleanup(func() { cfg = originalCfg })
+	cfg = &config.Config{
+		ProjectRoot: tmpDir,
+		TicketsDir:  filepath.Join(tmpDir, ".tickets"),
+		LogsDir:     filepath.Join(tmpDir, ".logs"),
+		WorkPIDFile: filepath.Join(tmpDir, ".tickets", ".work.pid"),
+	}
+	store := ticket.NewStore(cfg.TicketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+	return &serveBackend{store: store}
+}
+
+// httpCode returns the HTTP status err is reported with.
+func httpCode(err error) int {
+	var e *server.Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return http.StatusInternalServerError
+}
+
+func TestServeBackend_AddTicket(t *testing.T) {
+	b := setupServeBackend(t)
+
+	created, err := b.AddTicket(server.TicketRequest{
+		Title:            "Add logout",
+		Type:             "bugfix",
+		Priority:         2,
+		Labels:           []string{"API"},
+		SoftDependencies: []string{"T-0"},
+		Assertions:       []string{"go test ./..."},
+	})
+	if err != nil {
+		t.Fatalf("AddTicket() error = %v", err)
+	}
+	saved, err := b.store.Load(created.ID)
+	if err != nil {
+		t.Fatalf("ticket not saved: %v", err)
+	}
+	if saved.Type != ticket.TypeBugfix || saved.Priority != 2 || saved.Status != ticket.StatusPending ||
+		!saved.HasLabels([]string{"api"}) || len(saved.SoftDependencies) != 1 || len(saved.Assertions) != 1 {
+		t.Errorf("saved ticket = %+v", saved)
+	}
+
+	tests := []struct {
+		name string
+		req  server.TicketRequest
+	}{
+		{"missing title", server.TicketRequest{}},
+		{"unknown parent", server.TicketRequest{Title: "x", ParentID: "EPIC-404"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := b.AddTicket(tt.req); httpCode(err) != http.StatusBadRequest {
+				t.Errorf("AddTicket() error = %v, want a 400 error", err)
+			}
+		})
+	}
+}
+
+func TestServeBackend_Tickets(t *testing.T) {
+	b := setupServeBackend(t)
+	for _, tk := range []*ticket.Ticket{
+		{ID: "T-1", Title: "one", Status: ticket.StatusPending, Labels: []string{"backend"}},
+		{ID: "T-2", Title: "two", Status: ticket.StatusCompleted, Labels: []string{"backend"}},
+		{ID: "T-3", Title: "three", Status: ticket.StatusPending},
+	} {
+		if err := b.store.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	all, err := b.Tickets("", []string{"backend"})
+	if err != nil || len(all) != 2 {
+		t.Errorf("Tickets(all, backend) = %d tickets, %v; want 2", len(all), err)
+	}
+	pending, err := b.Tickets(ticket.StatusPending, nil)
+	if err != nil || len(pending) != 2 {
+		t.Errorf("Tickets(pending) = %d tickets, %v; want 2", len(pending), err)
+	}
+	if _, err := b.Ticket("T-404"); httpCode(err) != http.StatusNotFound {
+		t.Errorf("Ticket(unknown) error = %v, want a 404 error", err)
+	}
+}
+
+func TestServeBackend_StartRejectsBadInput(t *testing.T) {
+	b := setupServeBackend(t)
+
+	if _, err := b.StartWork("T-404"); httpCode(err) != http.StatusNotFound {
+		t.Errorf("StartWork(unknown ticket) error = %v, want a 404 error", err)
+	}
+	if _, err := b.StartPlan("docs/missing.md"); httpCode(err) != http.StatusBadRequest {
+		t.Errorf("StartPlan(missing milestone) error = %v, want a 400 error", err)
+	}
+
+	milestone := filepath.Join(cfg.ProjectRoot, "m.md")
+	if err := os.WriteFile(milestone, []byte("# M"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteWorkPIDFile(cfg.WorkPIDFilePath()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.StartPlan("m.md"); httpCode(err) != http.StatusConflict {
+		t.Errorf("StartPlan() while background work runs error = %v, want a 409 error", err)
+	}
+}
+
+func TestRunServe_ExposedWithoutTokens(t *testing.T) {
+	setupServeBackend(t)
+	originalAddr := serveAddr
+	t.Cleanup(func() { serveAddr = originalAddr })
+	serveAddr = "0.0.0.0:0"
+
+	err := runServe(serveCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "serve_tokens") {
+		t.Errorf("runServe(--addr 0.0.0.0:0) without tokens = %v, want an error asking for serve_tokens", err)
+	}
+}
+
+func TestRunServe_RecurringScheduler(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("interrupting the server needs a Unix signal")
+	}
+	b := setupServeBackend(t)
+	originalAddr, originalRecurring := serveAddr, serveRecurring
+	t.Cleanup(func() {
+		serveAddr, serveRecurring = originalAddr, originalRecurring
+		// runServe enables telemetry for the process.
+		telemetryEnabled = false
+		telemetryStore.Store(nil)
+	})
+	serveAddr, serveRecurring = "127.0.0.1:0", true
+	cfg.DocsDir = filepath.Join(cfg.ProjectRoot, "docs")
+
+	tmpl := ticket.NewTicket("RECUR-1", "Update dependencies", "")
+	tmpl.RecurrenceRule = "@daily"
+	tmpl.CreatedAt = time.Now().Add(-48 * time.Hour)
+	r, err := ticket.LoadRecurring(cfg.RecurringPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Add(tmpl); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Save(cfg.RecurringPath()); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go captureOutput(func() { done <- runServe(serveCmd, nil) })
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if pending, _ := b.store.LoadByStatus(ticket.StatusPending); len(pending) == 1 && pending[0].RecurringFrom == tmpl.ID {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("serve --recurring did not instantiate the due recurring ticket")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	self, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := self.Signal(os.Interrupt); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("runServe() = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("runServe() did not stop on SIGINT")
+	}
+}
+
+func TestRunServe_InvalidRecurringInterval(t *testing.T) {
+	setupServeBackend(t)
+	originalRecurring, originalInterval := serveRecurring, serveRecurringInterval
+	t.Cleanup(func() { serveRecurring, serveRecurringInterval = originalRecurring, originalInterval })
+	serveRecurring, serveRecurringInterval = true, 0
+
+	if err := runServe(serveCmd, nil); err == nil {
+		t.Error("runServe(--recurring-interval 0) should fail")
+	}
+}
+
+func TestServeBackend_RetryTicket(t *testing.T) {
+	b := setupServeBackend(t)
+	failed := ticket.NewTicket("T-1", "failed", "")
+	failed.MarkFailed(errors.New("boom"))
+	pending := ticket.NewTicket("T-2", "pending", "")
+	for _, tk := range []*ticket.Ticket{failed, pending} {
+		if err := b.store.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Background work is running: the retried ticket is queued for it.
+	if err := WriteWorkPIDFile(cfg.WorkPIDFilePath()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := b.RetryTicket("T-2"); httpCode(err) != http.StatusConflict {
+		t.Errorf("RetryTicket(pending ticket) error = %v, want a 409 error", err)
+	}
+	job, err := b.RetryTicket("T-1")
+	if err != nil || !job.Queued {
+		t.Fatalf("RetryTicket() = %+v, %v; want a queued job", job, err)
+	}
+	if got, _ := b.store.Load("T-1"); got.Status != ticket.StatusPending || got.Error != "" {
+		t.Errorf("retried ticket = %s %q, want pending without error", got.Status, got.Error)
+	}
+}
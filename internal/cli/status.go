@@ -1,15 +1,36 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/anthropic/agent-orchestrator/internal/audit"
 	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/metrics"
 	"github.com/anthropic/agent-orchestrator/internal/ticket"
 	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/anthropic/agent-orchestrator/internal/workqueue"
 	"github.com/spf13/cobra"
 )
 
+var (
+	statusLabels     []string
+	statusMilestones []string
+	statusAsOf       string
+	statusOutput     = statusOutputText
+	statusWatch      bool
+	statusInterval   time.Duration
+	statusArchived   bool
+	statusFilter     string
+	// statusSelect is statusFilter parsed by runStatus.
+	statusSelect *ticket.Filter
+)
+
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: i18n.CmdStatusShort,
@@ -17,15 +38,91 @@ var statusCmd = &cobra.Command{
 	RunE:  runStatus,
 }
 
+func init() {
+	statusCmd.Flags().StringSliceVar(&statusLabels, "label", nil, i18n.FlagLabelFilter)
+	statusCmd.Flags().StringSliceVar(&statusMilestones, "milestone", nil, i18n.FlagMilestoneFilter)
+	statusCmd.Flags().StringVar(&statusAsOf, "as-of", "", i18n.FlagStatusAsOf)
+	// Shadows the global --output (agent output format), which status has no use for.
+	statusCmd.Flags().StringVarP(&statusOutput, "output", "o", statusOutputText, i18n.FlagStatusOutput)
+	statusCmd.Flags().BoolVarP(&statusWatch, "watch", "w", false, i18n.FlagStatusWatch)
+	statusCmd.Flags().DurationVar(&statusInterval, "interval", 2*time.Second, i18n.FlagStatusInterval)
+	statusCmd.Flags().BoolVar(&statusArchived, "archived", false, i18n.FlagStatusArchived)
+	statusCmd.Flags().StringVar(&statusFilter, "filter", "", i18n.FlagStatusFilter)
+}
+
+// filterStatusTickets returns the tickets status shows: those carrying every one of
+// statusLabels, planned from one of statusMilestones and matching --filter.
+func filterStatusTickets(tickets []*ticket.Ticket) []*ticket.Ticket {
+	return ticket.FilterByExpr(ticket.FilterByMilestones(ticket.FilterByLabels(tickets, statusLabels), statusMilestones), statusSelect)
+}
+
+// countFiltered counts the tickets per status among those filterStatusTickets keeps.
+func countFiltered(store ticket.Storer) (map[ticket.Status]int, error) {
+	counts := make(map[ticket.Status]int)
+	for _, status := range []ticket.Status{ticket.StatusPending, ticket.StatusInProgress, ticket.StatusCompleted, ticket.StatusFailed} {
+		tickets, err := store.LoadByStatus(status)
+		if err != nil {
+			return nil, err
+		}
+		counts[status] = len(filterStatusTickets(tickets))
+	}
+	return counts, nil
+}
+
 func runStatus(cmd *cobra.Command, args []string) error {
 	// status 為僅讀（查詢）指令，不呼叫 ErrIfBackgroundWorkRunning，可與背景 work 並存（TICKET-019）。
 	// 僅「會寫入 store」的指令（plan, work, run 等）受並行策略限制。
 	w := os.Stdout
 
-	store := ticket.NewStore(cfg.TicketsDir)
+	store := newTicketStore()
+	// --milestone takes a path as plan does; tickets record it relative to the project.
+	statusMilestones = milestoneRefs(statusMilestones)
+	var err error
+	if statusSelect, err = ticket.ParseFilter(statusFilter); err != nil {
+		return err
+	}
+
+	if statusWatch && (statusOutput != statusOutputText || statusAsOf != "") {
+		return errors.New(i18n.ErrStatusWatchMode)
+	}
+	if statusArchived && (statusOutput == statusOutputText || statusOutput == statusOutputJSON) {
+		return printArchivedTickets(w)
+	}
+	switch statusOutput {
+	case statusOutputText:
+	case statusOutputJSON:
+		if statusAsOf != "" {
+			return errors.New(i18n.ErrStatusJSONAsOf)
+		}
+		return printStatusJSON(w, store)
+	default:
+		return fmt.Errorf(i18n.ErrStatusOutput, statusOutput)
+	}
+
+	if statusAsOf != "" {
+		at, err := audit.ParseTime(statusAsOf, time.Now())
+		if err != nil {
+			return err
+		}
+		return printStatusAsOf(w, store, at)
+	}
+	if statusWatch {
+		return runStatusWatch(w, store)
+	}
+	return renderStatus(w, store)
+}
 
+// renderStatus prints the status summary table, background work, work queue, epic tree,
+// ticket lists, stats and hints for the tickets filterStatusTickets keeps.
+func renderStatus(w io.Writer, store ticket.Storer) error {
 	// Get counts
-	counts, err := store.Count()
+	var counts map[ticket.Status]int
+	var err error
+	if len(statusLabels) > 0 || len(statusMilestones) > 0 || statusSelect != nil {
+		counts, err = countFiltered(store)
+	} else {
+		counts, err = store.Count()
+	}
 	if err != nil {
 		return err
 	}
@@ -35,6 +132,19 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		total += c
 	}
 
+	if total == 0 && len(statusLabels) > 0 {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgNoTicketsWithLabels, strings.Join(statusLabels, ", ")))
+		return nil
+	}
+	if total == 0 && len(statusMilestones) > 0 {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgNoTicketsInMilestones, strings.Join(statusMilestones, ", ")))
+		return nil
+	}
+	if total == 0 && statusSelect != nil {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgNoTicketsMatchFilter, statusSelect))
+		return nil
+	}
+
 	if total == 0 {
 		ui.PrintInfo(w, i18n.MsgNoTickets)
 		ui.PrintInfo(w, "")
@@ -60,24 +170,15 @@ func runStatus(cmd *cobra.Command, args []string) error {
 
 	// Show background work if PID file exists and process is alive.
 	// If PID file exists but process is dead, treat as stale and remove the PID file (do not show as running).
-	if cfg != nil {
-		pidPath := cfg.WorkPIDFilePath()
-		pid, err := ReadWorkPIDFile(pidPath)
-		if err == nil {
-			if !IsProcessAlive(pid) {
-				RemoveWorkPIDFile(pidPath)
-			} else {
-				ui.PrintInfo(w, "")
-				ui.PrintInfo(w, fmt.Sprintf(i18n.MsgBackgroundWorkRunningPid, pid))
-				logDir := cfg.LogsDir
-				if cfg.WorkDetachLogDir != "" {
-					logDir = cfg.WorkDetachLogDir
-				}
-				ui.PrintInfo(w, ui.StyleMuted.Render(fmt.Sprintf(i18n.MsgLogPath, logDir)))
-			}
-		}
+	if pid, logDir, ok := backgroundWork(); ok {
+		ui.PrintInfo(w, "")
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgBackgroundWorkRunningPid, pid))
+		ui.PrintInfo(w, ui.StyleMuted.Render(fmt.Sprintf(i18n.MsgLogPath, logDir)))
 	}
 
+	printWorkQueue(w)
+	printEpicTree(w, store, statusLabels, statusMilestones)
+
 	// List tickets by status
 	statuses := []struct {
 		status ticket.Status
@@ -90,11 +191,14 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		{ticket.StatusFailed, "Failed", ui.StyleError.Render},
 	}
 
+	rule := escalationRule()
+	rc, rcErr := ticket.NewResolverContext(store)
 	for _, s := range statuses {
 		tickets, err := store.LoadByStatus(s.status)
 		if err != nil {
 			continue
 		}
+		tickets = withoutEpics(filterStatusTickets(tickets))
 		if len(tickets) == 0 {
 			continue
 		}
@@ -103,13 +207,23 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		ui.PrintInfo(w, s.style(fmt.Sprintf("%s (%d):", s.name, len(tickets))))
 
 		for _, t := range tickets {
-			priority := ui.PriorityStyle(t.Priority).Render(fmt.Sprintf("P%d", t.Priority))
-			ui.PrintInfo(w, fmt.Sprintf("  %s %s: %s", priority, t.ID, ui.Truncate(t.Title, 50)))
+			ui.PrintInfo(w, statusTicketLine(t))
 
 			// Show dependencies if any
 			if len(t.Dependencies) > 0 {
 				ui.PrintInfo(w, ui.StyleMuted.Render(fmt.Sprintf(i18n.MsgDependencies, t.Dependencies)))
 			}
+			if len(t.SoftDependencies) > 0 {
+				ui.PrintInfo(w, ui.StyleMuted.Render(fmt.Sprintf(i18n.MsgSoftDependencies, t.SoftDependencies)))
+			}
+			if n := len(t.Notes); n > 0 {
+				ui.PrintInfo(w, ui.StyleMuted.Render(fmt.Sprintf(i18n.MsgLatestNote, n))+" "+noteSummary(t.Notes[n-1]))
+			}
+			if rcErr == nil && s.status != ticket.StatusCompleted {
+				if line := unblocksLine(rc, rule, t); line != "" {
+					ui.PrintInfo(w, ui.StyleWarning.Render(line))
+				}
+			}
 
 			// Show full error and log path if failed
 			if s.status == ticket.StatusFailed {
@@ -124,10 +238,28 @@ func runStatus(cmd *cobra.Command, args []string) error {
 				if t.ErrorLog != "" {
 					ui.PrintInfo(w, ui.StyleMuted.Render(fmt.Sprintf(i18n.MsgErrorLog, t.ErrorLog)))
 				}
+				ui.PrintInfo(w, ui.StyleMuted.Render(fmt.Sprintf(i18n.MsgRetryHint, retryCommand(t.ID))))
 			}
 		}
 	}
 
+	if cycles, open := blockingCycles(store); len(cycles) > 0 {
+		ui.PrintInfo(w, "")
+		printCycles(w, ticket.NewDependencyResolver(store), open, cycles)
+	}
+
+	printStatusStats(w, store)
+
+	if files := corruptTicketFiles(store); len(files) > 0 {
+		ui.PrintInfo(w, "")
+		ui.PrintWarning(w, formatCorruptFiles(files))
+		ui.PrintInfo(w, ui.StyleMuted.Render(i18n.HintCorruptTickets))
+	}
+	if n := ticket.NewArchive(cfg.ArchiveDir()).Count(); n > 0 {
+		ui.PrintInfo(w, "")
+		ui.PrintInfo(w, ui.StyleMuted.Render(fmt.Sprintf(i18n.MsgArchivedCount, n)))
+	}
+
 	// Show helpful commands
 	ui.PrintInfo(w, "")
 	ui.PrintInfo(w, ui.StyleMuted.Render(i18n.UICommonCommands))
@@ -143,3 +275,302 @@ func runStatus(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// backgroundWork returns the PID and log directory of the background work process
+// (work --detach) when its PID file exists and the process is alive. A PID file left
+// by a dead process is stale and removed.
+func backgroundWork() (pid int, logDir string, ok bool) {
+	if cfg == nil {
+		return 0, "", false
+	}
+	pidPath := cfg.WorkPIDFilePath()
+	pid, err := ReadWorkPIDFile(pidPath)
+	if err != nil {
+		return 0, "", false
+	}
+	if !IsProcessAlive(pid) {
+		RemoveWorkPIDFile(pidPath)
+		return 0, "", false
+	}
+	logDir = cfg.LogsDir
+	if cfg.WorkDetachLogDir != "" {
+		logDir = cfg.WorkDetachLogDir
+	}
+	return pid, logDir, true
+}
+
+// escalationRule returns the priority escalation rule of the configuration (escalation:).
+func escalationRule() ticket.EscalationRule {
+	if cfg == nil {
+		return ticket.EscalationRule{}
+	}
+	e := cfg.Escalation
+	return ticket.EscalationRule{Threshold: e.Threshold, Step: e.Step, Transitive: e.Transitive}
+}
+
+// printEscalations reports the tickets a batch starts early because others wait on them.
+func printEscalations(w io.Writer, escalated []ticket.Escalation) {
+	for _, e := range escalated {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgEscalated, e.Ticket.ID, e.Unblocks, e.Ticket.Priority, e.Priority))
+	}
+}
+
+// unblocksLine returns the "unblocks N tickets" note of t for the status lists, with
+// its effective priority when rule escalates it; "" when no pending ticket waits on t.
+func unblocksLine(rc *ticket.ResolverContext, rule ticket.EscalationRule, t *ticket.Ticket) string {
+	n := rc.Unblocks(t.ID, rule.Transitive)
+	if n == 0 {
+		return ""
+	}
+	if p := rule.EffectivePriority(t.Priority, n); p < t.Priority {
+		return fmt.Sprintf(i18n.MsgUnblocksEscalated, n, p)
+	}
+	return fmt.Sprintf(i18n.MsgUnblocks, n)
+}
+
+// statusTicketLine formats a ticket for the status lists: priority, ID, title, labels,
+// parent epic, pull request and token usage.
+func statusTicketLine(t *ticket.Ticket) string {
+	priority := ui.PriorityStyle(t.Priority).Render(fmt.Sprintf("P%d", t.Priority))
+	line := fmt.Sprintf("  %s %s: %s", priority, t.ID, ui.Truncate(t.Title, 50))
+	if len(t.Labels) > 0 {
+		line += " " + ui.StyleMuted.Render("["+strings.Join(t.Labels, ", ")+"]")
+	}
+	if t.ParentID != "" {
+		line += " " + ui.StyleMuted.Render("↳ "+t.ParentID)
+	}
+	if t.PullRequest != nil {
+		line += " " + ui.StyleMuted.Render(fmt.Sprintf("PR #%d", t.PullRequest.Number))
+	}
+	if t.TokensUsed > 0 || t.CostUSD > 0 {
+		line += " " + ui.StyleMuted.Render(formatUsage(t.TokensUsed, t.CostUSD))
+	}
+	return line
+}
+
+// statusAt reconstructs the status t had at time at from the run state recorded in the
+// metrics history (see metrics.StatesAt) and its completion time. Tickets without a
+// finished run count as pending, unless they were completed without one (e.g. epics or
+// tickets completed by hand). ok is false when t did not exist yet.
+func statusAt(t *ticket.Ticket, state string, at time.Time) (status ticket.Status, ok bool) {
+	if !t.CreatedAt.IsZero() && t.CreatedAt.After(at) {
+		return "", false
+	}
+	switch state {
+	case metrics.StateRunning:
+		return ticket.StatusInProgress, true
+	case metrics.OutcomeCompleted:
+		return ticket.StatusCompleted, true
+	case metrics.OutcomeFailed:
+		return ticket.StatusFailed, true
+	}
+	if t.CompletedAt != nil && !t.CompletedAt.After(at) {
+		return ticket.StatusCompleted, true
+	}
+	return ticket.StatusPending, true
+}
+
+// printStatusAsOf prints the status summary and ticket lists as they were at time at,
+// reconstructed from the metrics history and ticket timestamps. Tickets dropped since
+// are not shown, as the store no longer holds them.
+func printStatusAsOf(w io.Writer, store ticket.Storer, at time.Time) error {
+	all, err := store.LoadAll()
+	if err != nil {
+		return err
+	}
+	records, err := metrics.Load(cfg.MetricsHistoryPath())
+	if err != nil {
+		return err
+	}
+	states := metrics.StatesAt(records, at)
+
+	byStatus := make(map[ticket.Status][]*ticket.Ticket)
+	for _, t := range withoutEpics(filterStatusTickets(all.Tickets)) {
+		if status, ok := statusAt(t, states[t.ID], at); ok {
+			byStatus[status] = append(byStatus[status], t)
+		}
+	}
+
+	ui.PrintHeader(w, fmt.Sprintf(i18n.UITicketStatusAsOf, at.Local().Format("2006-01-02 15:04")))
+	statusTable := ui.NewStatusTable()
+	statusTable.SetCounts(
+		len(byStatus[ticket.StatusPending]),
+		len(byStatus[ticket.StatusInProgress]),
+		len(byStatus[ticket.StatusCompleted]),
+		len(byStatus[ticket.StatusFailed]),
+	)
+	statusTable.Render(w)
+
+	for _, s := range []struct {
+		status ticket.Status
+		name   string
+		style  func(...string) string
+	}{
+		{ticket.StatusPending, "Pending", ui.StyleWarning.Render},
+		{ticket.StatusInProgress, "In Progress", ui.StyleInfo.Render},
+		{ticket.StatusCompleted, "Completed", ui.StyleSuccess.Render},
+		{ticket.StatusFailed, "Failed", ui.StyleError.Render},
+	} {
+		tickets := byStatus[s.status]
+		if len(tickets) == 0 {
+			continue
+		}
+		sort.SliceStable(tickets, func(i, j int) bool { return tickets[i].Priority < tickets[j].Priority })
+		ui.PrintInfo(w, "")
+		ui.PrintInfo(w, s.style(fmt.Sprintf("%s (%d):", s.name, len(tickets))))
+		for _, t := range tickets {
+			ui.PrintInfo(w, statusTicketLine(t))
+		}
+	}
+
+	ui.PrintInfo(w, "")
+	ui.PrintInfo(w, ui.StyleMuted.Render(i18n.MsgStatusAsOfNote))
+	return nil
+}
+
+// printWorkQueue lists work requests queued for the background worker (work --queue).
+func printWorkQueue(w io.Writer) {
+	if cfg == nil {
+		return
+	}
+	reqs, err := workqueue.New(cfg.WorkQueuePath()).List()
+	if err != nil || len(reqs) == 0 {
+		return
+	}
+	ui.PrintInfo(w, "")
+	ui.PrintInfo(w, ui.StyleInfo.Render(fmt.Sprintf(i18n.UIWorkQueue, len(reqs))))
+	for _, r := range reqs {
+		ui.PrintInfo(w, fmt.Sprintf("  %s  %s  %s", r.ID, describeWorkRequest(r),
+			ui.StyleMuted.Render(r.QueuedAt.Local().Format("2006-01-02 15:04"))))
+	}
+}
+
+// withoutEpics drops epics from a status list; they are shown in the epic tree instead.
+func withoutEpics(tickets []*ticket.Ticket) []*ticket.Ticket {
+	out := make([]*ticket.Ticket, 0, len(tickets))
+	for _, t := range tickets {
+		if !t.IsEpic() {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// printEpicTree prints each top-level epic with its progress and its children, nested
+// epics indented under their parent. Only epics carrying labels and planned from one of
+// milestones are shown when these are given. Prints nothing when there are no epics.
+func printEpicTree(w io.Writer, store ticket.Storer, labels, milestones []string) {
+	all, err := store.LoadAll()
+	if err != nil {
+		return
+	}
+	rc, err := ticket.NewResolverContext(store)
+	if err != nil {
+		return
+	}
+	byID := make(map[string]*ticket.Ticket, len(all.Tickets))
+	for _, t := range all.Tickets {
+		byID[t.ID] = t
+	}
+
+	var roots []*ticket.Ticket
+	for _, t := range all.Tickets {
+		if !t.IsEpic() || byID[t.ParentID] != nil {
+			continue
+		}
+		if t.HasLabels(labels) && t.InMilestones(milestones) {
+			roots = append(roots, t)
+		}
+	}
+	if len(roots) == 0 {
+		return
+	}
+
+	ui.PrintInfo(w, "")
+	ui.PrintInfo(w, ui.StyleInfo.Render(i18n.UIEpics+":"))
+	visited := make(map[string]bool)
+	var walk func(epic *ticket.Ticket, indent string)
+	walk = func(epic *ticket.Ticket, indent string) {
+		visited[epic.ID] = true
+		kids := rc.Children(epic.ID)
+		done := 0
+		for _, id := range kids {
+			if rc.IsCompleted(id) {
+				done++
+			}
+		}
+		ui.PrintInfo(w, indent+fmt.Sprintf(i18n.MsgEpicProgress, epic.ID, ui.Truncate(epic.Title, 50), done, len(kids)))
+		for _, id := range kids {
+			child := byID[id]
+			if child == nil || visited[id] {
+				continue
+			}
+			if child.IsEpic() {
+				walk(child, indent+"    ")
+				continue
+			}
+			mark := "·"
+			if child.Status == ticket.StatusCompleted {
+				mark = ui.StyleSuccess.Render("✓")
+			} else if child.Status == ticket.StatusFailed {
+				mark = ui.StyleError.Render("✗")
+			}
+			ui.PrintInfo(w, fmt.Sprintf("%s    %s %s: %s %s", indent, mark, child.ID, ui.Truncate(child.Title, 50),
+				ui.StyleMuted.Render("["+string(child.Status)+"]")))
+		}
+	}
+	for _, epic := range roots {
+		walk(epic, "  ")
+	}
+}
+
+// printStatusStats prints the footer with aggregate stats: remaining estimated complexity
+// of open tickets and the token usage of all tickets, plus average completion time by type and recent failure rate from the
+// metrics history (omitted when no history exists yet), and the analysis score trend
+// when analyze has recorded one.
+func printStatusStats(w io.Writer, store ticket.Storer) {
+	remaining := 0
+	for _, status := range []ticket.Status{ticket.StatusPending, ticket.StatusInProgress} {
+		tickets, err := store.LoadByStatus(status)
+		if err != nil {
+			continue
+		}
+		for _, t := range tickets {
+			remaining += metrics.ComplexityPoints(t.EstimatedComplexity)
+		}
+	}
+
+	ui.PrintInfo(w, "")
+	ui.PrintInfo(w, ui.StyleMuted.Render(i18n.UIStatusStats))
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgStatsRemainingComplexity, remaining))
+	if all, err := store.LoadAll(); err == nil {
+		tokens, cost := 0, 0.0
+		for _, t := range all.Tickets {
+			tokens += t.TokensUsed
+			cost += t.CostUSD
+		}
+		if tokens > 0 || cost > 0 {
+			ui.PrintInfo(w, fmt.Sprintf(i18n.MsgUsageTotal, formatUsage(tokens, cost)))
+		}
+	}
+
+	if quality, _ := metrics.LoadQuality(cfg.QualityHistoryPath()); len(quality) > 0 {
+		trend := metrics.QualityTrend(quality, metrics.DefaultRecentRuns)
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgStatsQuality, qualitySparkline(trend), trend[len(trend)-1].Score))
+	}
+
+	records, err := metrics.Load(cfg.MetricsHistoryPath())
+	if err != nil || len(records) == 0 {
+		return
+	}
+	stats := metrics.Summarize(records, metrics.DefaultRecentRuns)
+
+	if types := stats.Types(); len(types) > 0 {
+		parts := make([]string, 0, len(types))
+		for _, typ := range types {
+			parts = append(parts, fmt.Sprintf("%s %s", typ, stats.AvgDurationByType[typ].Round(time.Second)))
+		}
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgStatsAvgDuration, strings.Join(parts, ", ")))
+	}
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgStatsFailureRate, stats.RecentRuns, stats.FailureRate()*100, stats.RecentFailed, stats.RecentRuns))
+}
@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// status --output 可用的格式。
+const (
+	statusOutputText = "text"
+	statusOutputJSON = "json"
+)
+
+// statusReport is the document printed by status --output json for CI and dashboards.
+// Field names are part of the output contract: add fields rather than renaming them.
+type statusReport struct {
+	GeneratedAt    time.Time               `json:"generated_at"`
+	Labels         []string                `json:"labels,omitempty"`
+	Milestones     []string                `json:"milestones,omitempty"`
+	Filter         string                  `json:"filter,omitempty"`
+	Total          int                     `json:"total"`
+	Counts         statusCounts            `json:"counts"`
+	ByType         map[string]statusCounts `json:"by_type"`
+	Blocked        []blockedTicket         `json:"blocked"`
+	Bottlenecks    []bottleneckTicket      `json:"bottlenecks"`
+	Cycles         []dependencyCycle       `json:"cycles"`
+	BackgroundWork *backgroundWorkReport   `json:"background_work"`
+}
+
+// statusCounts holds the number of tickets per status.
+type statusCounts struct {
+	Pending    int `json:"pending"`
+	InProgress int `json:"in_progress"`
+	Completed  int `json:"completed"`
+	Failed     int `json:"failed"`
+}
+
+func (c *statusCounts) add(status ticket.Status) {
+	switch status {
+	case ticket.StatusPending:
+		c.Pending++
+	case ticket.StatusInProgress:
+		c.InProgress++
+	case ticket.StatusCompleted:
+		c.Completed++
+	case ticket.StatusFailed:
+		c.Failed++
+	}
+}
+
+// blockedTicket is a pending ticket waiting on dependencies that are not completed yet.
+type blockedTicket struct {
+	ID                  string   `json:"id"`
+	Title               string   `json:"title"`
+	Priority            int      `json:"priority"`
+	MissingDependencies []string `json:"missing_dependencies"`
+}
+
+// bottleneckTicket is an incomplete ticket other pending tickets wait on, with the
+// priority the scheduler gives it under the escalation rule.
+type bottleneckTicket struct {
+	ID                string `json:"id"`
+	Title             string `json:"title"`
+	Status            string `json:"status"`
+	Priority          int    `json:"priority"`
+	EffectivePriority int    `json:"effective_priority"`
+	Unblocks          int    `json:"unblocks"`
+}
+
+// dependencyCycle is a dependency cycle among the open tickets (all of them, whatever
+// the labels): Tickets[0] depends on Tickets[1] and so on, the last one on Tickets[0].
+// SuggestedBreak is the dependency deps fix-cycles suggests removing.
+type dependencyCycle struct {
+	Tickets        []string  `json:"tickets"`
+	SuggestedBreak cycleEdge `json:"suggested_break"`
+}
+
+// cycleEdge is a dependency: From depends on To.
+type cycleEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// backgroundWorkReport describes a running background work process (work --detach).
+type backgroundWorkReport struct {
+	PID    int    `json:"pid"`
+	LogDir string `json:"log_dir"`
+}
+
+// buildStatusReport collects the status of the tickets carrying every one of labels,
+// planned from one of milestones and matching filter (nil matches all).
+// Epics count towards the totals (type "epic") but are never listed as blocked: they
+// complete with their children rather than through dependencies.
+func buildStatusReport(store ticket.Storer, labels, milestones []string, filter *ticket.Filter) (*statusReport, error) {
+	all, err := store.LoadAll()
+	if err != nil {
+		return nil, err
+	}
+	rc, err := ticket.NewResolverContext(store)
+	if err != nil {
+		return nil, err
+	}
+	resolver := ticket.NewDependencyResolver(store)
+
+	report := &statusReport{
+		GeneratedAt: time.Now().UTC(),
+		Labels:      labels,
+		Milestones:  milestones,
+		Filter:      filter.String(),
+		ByType:      make(map[string]statusCounts),
+		Blocked:     []blockedTicket{},
+		Bottlenecks: []bottleneckTicket{},
+		Cycles:      []dependencyCycle{},
+	}
+	rule := escalationRule()
+	for _, t := range ticket.FilterByExpr(ticket.FilterByMilestones(ticket.FilterByLabels(all.Tickets, labels), milestones), filter) {
+		report.Total++
+		report.Counts.add(t.Status)
+		byType := report.ByType[string(t.Type)]
+		byType.add(t.Status)
+		report.ByType[string(t.Type)] = byType
+
+		if t.Status != ticket.StatusCompleted {
+			if n := rc.Unblocks(t.ID, rule.Transitive); n > 0 {
+				report.Bottlenecks = append(report.Bottlenecks, bottleneckTicket{
+					ID:                t.ID,
+					Title:             t.Title,
+					Status:            string(t.Status),
+					Priority:          t.Priority,
+					EffectivePriority: rule.EffectivePriority(t.Priority, n),
+					Unblocks:          n,
+				})
+			}
+		}
+
+		if t.Status != ticket.StatusPending || t.IsEpic() {
+			continue
+		}
+		if missing := resolver.GetMissingDependenciesWithContext(t, rc); len(missing) > 0 {
+			report.Blocked = append(report.Blocked, blockedTicket{
+				ID:                  t.ID,
+				Title:               t.Title,
+				Priority:            t.Priority,
+				MissingDependencies: missing,
+			})
+		}
+	}
+	sort.SliceStable(report.Blocked, func(i, j int) bool { return report.Blocked[i].ID < report.Blocked[j].ID })
+	sort.SliceStable(report.Bottlenecks, func(i, j int) bool {
+		a, b := report.Bottlenecks[i], report.Bottlenecks[j]
+		if a.Unblocks != b.Unblocks {
+			return a.Unblocks > b.Unblocks
+		}
+		return a.ID < b.ID
+	})
+
+	open := openTickets(all.Tickets)
+	for _, cycle := range resolver.FindCycles(open) {
+		edge := resolver.WeakestEdge(open, cycle)
+		report.Cycles = append(report.Cycles, dependencyCycle{
+			Tickets:        cycle,
+			SuggestedBreak: cycleEdge{From: edge.From, To: edge.To},
+		})
+	}
+
+	if pid, logDir, ok := backgroundWork(); ok {
+		report.BackgroundWork = &backgroundWorkReport{PID: pid, LogDir: logDir}
+	}
+	return report, nil
+}
+
+// printStatusJSON writes the status report for statusLabels, statusMilestones and
+// --filter to w as indented JSON.
+func printStatusJSON(w io.Writer, store ticket.Storer) error {
+	report, err := buildStatusReport(store, statusLabels, statusMilestones, statusSelect)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
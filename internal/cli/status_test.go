@@ -2,16 +2,19 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/anthropic/agent-orchestrator/internal/config"
 	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/metrics"
 	"github.com/anthropic/agent-orchestrator/internal/ticket"
 )
 
@@ -416,3 +419,472 @@ func TestIntegration_Status_StalePidFile_RemovedAndNotShown(t *testing.T) {
 		t.Errorf("status should not show background work as running for stale PID, got:\n%s", statusOut)
 	}
 }
+
+// TestRunStatus_FooterStats 驗證 status 底部統計：剩餘預估複雜度、依類型平均完成時間、最近失敗率（取自 metrics 歷史檔）。
+func TestRunStatus_FooterStats(t *testing.T) {
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+	for _, tk := range []*ticket.Ticket{
+		{ID: "T-1", Title: "a", Status: ticket.StatusPending, EstimatedComplexity: "high"},
+		{ID: "T-2", Title: "b", Status: ticket.StatusPending, EstimatedComplexity: "low"},
+		{ID: "T-3", Title: "c", Status: ticket.StatusCompleted, EstimatedComplexity: "high", TokensUsed: 1500, CostUSD: 0.5},
+	} {
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("Failed to save ticket: %v", err)
+		}
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: ticketsDir, WorkPIDFile: filepath.Join(tmpDir, ".work.pid")}
+
+	output := captureOutput(func() {
+		if err := runStatus(nil, nil); err != nil {
+			t.Errorf("runStatus() error = %v", err)
+		}
+	})
+	if !strings.Contains(output, fmt.Sprintf(i18n.MsgStatsRemainingComplexity, 4)) {
+		t.Errorf("output should contain remaining complexity 4, got:\n%s", output)
+	}
+	if !strings.Contains(output, fmt.Sprintf(i18n.MsgUsageTotal, "1.5k tokens · $0.50")) {
+		t.Errorf("output should contain total token usage, got:\n%s", output)
+	}
+	if strings.Contains(output, "平均完成時間") {
+		t.Errorf("average duration should be omitted without metrics history, got:\n%s", output)
+	}
+
+	for _, r := range []metrics.Record{
+		{TicketID: "T-3", Type: "feature", Outcome: metrics.OutcomeCompleted, Duration: 90 * time.Second},
+		{TicketID: "T-4", Type: "feature", Outcome: metrics.OutcomeFailed, Duration: time.Minute},
+	} {
+		if err := metrics.Append(cfg.MetricsHistoryPath(), r); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	output = captureOutput(func() {
+		if err := runStatus(nil, nil); err != nil {
+			t.Errorf("runStatus() error = %v", err)
+		}
+	})
+	if !strings.Contains(output, fmt.Sprintf(i18n.MsgStatsAvgDuration, "feature 1m30s")) {
+		t.Errorf("output should contain average duration for feature, got:\n%s", output)
+	}
+	if !strings.Contains(output, fmt.Sprintf(i18n.MsgStatsFailureRate, 2, 50.0, 1, 2)) {
+		t.Errorf("output should contain failure rate 1/2, got:\n%s", output)
+	}
+}
+
+func TestRunStatus_LabelFilter(t *testing.T) {
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+	for _, tk := range []*ticket.Ticket{
+		{ID: "T-1", Title: "api work", Status: ticket.StatusPending, Labels: []string{"backend"}},
+		{ID: "T-2", Title: "css work", Status: ticket.StatusPending, Labels: []string{"frontend"}},
+	} {
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("Failed to save ticket: %v", err)
+		}
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: ticketsDir, WorkPIDFile: filepath.Join(tmpDir, ".work.pid")}
+	defer func() { statusLabels = nil }()
+
+	statusLabels = []string{"backend"}
+	output := captureOutput(func() {
+		if err := runStatus(nil, nil); err != nil {
+			t.Errorf("runStatus() error = %v", err)
+		}
+	})
+	if !strings.Contains(output, "T-1") || !strings.Contains(output, "[backend]") {
+		t.Errorf("output should list T-1 with its label, got:\n%s", output)
+	}
+	if strings.Contains(output, "T-2") {
+		t.Errorf("output should not list T-2 (frontend), got:\n%s", output)
+	}
+
+	statusLabels = []string{"infra"}
+	output = captureOutput(func() {
+		if err := runStatus(nil, nil); err != nil {
+			t.Errorf("runStatus() error = %v", err)
+		}
+	})
+	if !strings.Contains(output, fmt.Sprintf(i18n.MsgNoTicketsWithLabels, "infra")) {
+		t.Errorf("output should report no tickets with label infra, got:\n%s", output)
+	}
+}
+
+func TestRunStatus_FilterExpr(t *testing.T) {
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+	for _, tk := range []*ticket.Ticket{
+		{ID: "T-1", Title: "urgent feature", Type: ticket.TypeFeature, Priority: 1, Status: ticket.StatusPending},
+		{ID: "T-2", Title: "later feature", Type: ticket.TypeFeature, Priority: 4, Status: ticket.StatusPending},
+		{ID: "T-3", Title: "urgent bug", Type: ticket.TypeBugfix, Priority: 1, Status: ticket.StatusPending},
+	} {
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("Failed to save ticket: %v", err)
+		}
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: ticketsDir, WorkPIDFile: filepath.Join(tmpDir, ".work.pid")}
+	defer func() { statusFilter, statusSelect = "", nil }()
+
+	statusFilter = "type=feature and priority<=2"
+	output := captureOutput(func() {
+		if err := runStatus(nil, nil); err != nil {
+			t.Errorf("runStatus() error = %v", err)
+		}
+	})
+	if !strings.Contains(output, "T-1") || strings.Contains(output, "T-2") || strings.Contains(output, "T-3") {
+		t.Errorf("output should list T-1 only, got:\n%s", output)
+	}
+
+	statusFilter = "type=docs"
+	output = captureOutput(func() {
+		if err := runStatus(nil, nil); err != nil {
+			t.Errorf("runStatus() error = %v", err)
+		}
+	})
+	if !strings.Contains(output, fmt.Sprintf(i18n.MsgNoTicketsMatchFilter, "type=docs")) {
+		t.Errorf("output should report no tickets matching the filter, got:\n%s", output)
+	}
+
+	statusFilter = "priority<=urgent"
+	if err := runStatus(nil, nil); err == nil {
+		t.Error("runStatus() with an invalid filter should fail")
+	}
+}
+
+func TestRunStatus_MilestoneFilter(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+	for _, tk := range []*ticket.Ticket{
+		{ID: "T-1", Title: "schema", Status: ticket.StatusPending, Milestone: "docs/m1.md"},
+		{ID: "T-2", Title: "api", Status: ticket.StatusPending, Milestone: "docs/m2.md"},
+	} {
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("Failed to save ticket: %v", err)
+		}
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{ProjectRoot: tmpDir, TicketsDir: ticketsDir, WorkPIDFile: filepath.Join(tmpDir, ".work.pid")}
+	defer func() { statusMilestones = nil }()
+
+	// Paths are taken as plan takes them and compared relative to the project root.
+	statusMilestones = []string{"./docs/m2.md"}
+	output := captureOutput(func() {
+		if err := runStatus(nil, nil); err != nil {
+			t.Errorf("runStatus() error = %v", err)
+		}
+	})
+	if !strings.Contains(output, "T-2") || strings.Contains(output, "T-1") {
+		t.Errorf("output should list T-2 only, got:\n%s", output)
+	}
+
+	statusMilestones = []string{filepath.Join(tmpDir, "docs", "m3.md")}
+	output = captureOutput(func() {
+		if err := runStatus(nil, nil); err != nil {
+			t.Errorf("runStatus() error = %v", err)
+		}
+	})
+	if !strings.Contains(output, fmt.Sprintf(i18n.MsgNoTicketsInMilestones, "docs/m3.md")) {
+		t.Errorf("output should report no tickets in docs/m3.md, got:\n%s", output)
+	}
+}
+
+func TestRunStatus_EpicTree(t *testing.T) {
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+	for _, tk := range []*ticket.Ticket{
+		{ID: "EPIC-1", Title: "Phase 1", Type: ticket.TypeEpic, Status: ticket.StatusPending},
+		{ID: "T-1", Title: "first child", Status: ticket.StatusCompleted, ParentID: "EPIC-1"},
+		{ID: "T-2", Title: "second child", Status: ticket.StatusPending, ParentID: "EPIC-1"},
+	} {
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("Failed to save ticket: %v", err)
+		}
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: ticketsDir, WorkPIDFile: filepath.Join(tmpDir, ".work.pid")}
+
+	output := captureOutput(func() {
+		if err := runStatus(nil, nil); err != nil {
+			t.Errorf("runStatus() error = %v", err)
+		}
+	})
+	if !strings.Contains(output, fmt.Sprintf(i18n.MsgEpicProgress, "EPIC-1", "Phase 1", 1, 2)) {
+		t.Errorf("output should show epic progress 1/2, got:\n%s", output)
+	}
+	if !strings.Contains(output, "↳ EPIC-1") {
+		t.Errorf("pending child should reference its epic, got:\n%s", output)
+	}
+	if strings.Count(output, "EPIC-1:") != 1 {
+		t.Errorf("epic should be listed once (in the epic tree), got:\n%s", output)
+	}
+}
+
+func TestRunStatus_Unblocks(t *testing.T) {
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+	for _, tk := range []*ticket.Ticket{
+		{ID: "T-1", Title: "schema", Status: ticket.StatusFailed, Priority: 4},
+		{ID: "T-2", Title: "api", Status: ticket.StatusPending, Priority: 2, Dependencies: []string{"T-1"}},
+		{ID: "T-3", Title: "api tests", Status: ticket.StatusPending, Priority: 2, Dependencies: []string{"T-2"}},
+	} {
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("Failed to save ticket: %v", err)
+		}
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: ticketsDir, WorkPIDFile: filepath.Join(tmpDir, ".work.pid"),
+		Escalation: config.EscalationConfig{Threshold: 2, Step: 2, Transitive: true}}
+
+	output := captureOutput(func() {
+		if err := runStatus(nil, nil); err != nil {
+			t.Errorf("runStatus() error = %v", err)
+		}
+	})
+	// T-1 blocks T-2 directly and T-3 through T-2: escalated from P4 to P2.
+	if !strings.Contains(output, fmt.Sprintf(i18n.MsgUnblocksEscalated, 2, 2)) {
+		t.Errorf("output should show T-1 unblocking 2 tickets at P2, got:\n%s", output)
+	}
+	if !strings.Contains(output, fmt.Sprintf(i18n.MsgUnblocks, 1)) {
+		t.Errorf("output should show T-2 unblocking 1 ticket, got:\n%s", output)
+	}
+}
+
+func TestRunStatus_Cycles(t *testing.T) {
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+	for _, tk := range []*ticket.Ticket{
+		{ID: "T-1", Title: "api", Status: ticket.StatusPending, Priority: 2, Dependencies: []string{"T-2"}},
+		{ID: "T-2", Title: "schema", Status: ticket.StatusPending, Priority: 3, Dependencies: []string{"T-3"}},
+		{ID: "T-3", Title: "models", Status: ticket.StatusFailed, Priority: 3, Dependencies: []string{"T-1"}},
+		{ID: "T-4", Title: "docs", Status: ticket.StatusPending, Dependencies: []string{"T-1"}},
+	} {
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("Failed to save ticket: %v", err)
+		}
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: ticketsDir, WorkPIDFile: filepath.Join(tmpDir, ".work.pid")}
+
+	output := captureOutput(func() {
+		if err := runStatus(nil, nil); err != nil {
+			t.Errorf("runStatus() error = %v", err)
+		}
+	})
+	// T-2 (P3) blocking T-1 (P2) is the weakest link.
+	for _, want := range []string{
+		fmt.Sprintf(i18n.MsgCyclePath, "T-1 → T-2 → T-3 → T-1"),
+		fmt.Sprintf(i18n.MsgCycleSuggest, "T-1", "T-2"),
+		i18n.HintFixCycles,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output should contain %q, got:\n%s", want, output)
+		}
+	}
+
+	defer func() { statusOutput = statusOutputText }()
+	statusOutput = statusOutputJSON
+	output = captureOutput(func() {
+		if err := runStatus(nil, nil); err != nil {
+			t.Errorf("runStatus() error = %v", err)
+		}
+	})
+	var report statusReport
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		t.Fatalf("output is not a status report: %v\n%s", err, output)
+	}
+	want := []dependencyCycle{{Tickets: []string{"T-1", "T-2", "T-3"}, SuggestedBreak: cycleEdge{From: "T-1", To: "T-2"}}}
+	if !reflect.DeepEqual(report.Cycles, want) {
+		t.Errorf("cycles = %+v, want %+v", report.Cycles, want)
+	}
+}
+
+func TestRunStatus_AsOf(t *testing.T) {
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+	base := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	done := base.Add(2 * time.Hour)
+	for _, tk := range []*ticket.Ticket{
+		{ID: "T-1", Title: "shipped before", Status: ticket.StatusCompleted, CreatedAt: base.Add(-time.Hour), CompletedAt: &done},
+		{ID: "T-2", Title: "shipped after", Status: ticket.StatusCompleted, CreatedAt: base.Add(-time.Hour)},
+		{ID: "T-3", Title: "added later", Status: ticket.StatusPending, CreatedAt: base.Add(5 * time.Hour)},
+	} {
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("Failed to save ticket: %v", err)
+		}
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: ticketsDir, WorkPIDFile: filepath.Join(tmpDir, ".work.pid")}
+	for _, r := range []metrics.Record{
+		{TicketID: "T-1", Outcome: metrics.OutcomeCompleted, StartedAt: base, Duration: time.Hour},
+		{TicketID: "T-2", Outcome: metrics.OutcomeCompleted, StartedAt: base.Add(3 * time.Hour), Duration: time.Hour},
+	} {
+		if err := metrics.Append(cfg.MetricsHistoryPath(), r); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+	defer func() { statusAsOf = "" }()
+
+	tests := []struct {
+		asOf      string
+		sections  []string
+		notListed string
+	}{
+		{"2026-06-01 12:30", []string{"Pending (1):", "In Progress (1):"}, "T-3"},
+		{"2026-06-01 14:00", []string{"Pending (1):", "Completed (1):"}, "T-3"},
+		{"2026-06-01 18:00", []string{"Pending (1):", "Completed (2):"}, ""},
+	}
+	for _, tt := range tests {
+		statusAsOf = tt.asOf
+		output := captureOutput(func() {
+			if err := runStatus(nil, nil); err != nil {
+				t.Errorf("runStatus() error = %v", err)
+			}
+		})
+		if !strings.Contains(output, fmt.Sprintf(i18n.UITicketStatusAsOf, tt.asOf)) {
+			t.Errorf("as of %s: output should name the point in time, got:\n%s", tt.asOf, output)
+		}
+		for _, section := range tt.sections {
+			if !strings.Contains(output, section) {
+				t.Errorf("as of %s: output should contain %q, got:\n%s", tt.asOf, section, output)
+			}
+		}
+		if tt.notListed != "" && strings.Contains(output, tt.notListed) {
+			t.Errorf("as of %s: output should not list %s, got:\n%s", tt.asOf, tt.notListed, output)
+		}
+	}
+
+	statusAsOf = "someday"
+	if err := runStatus(nil, nil); err == nil {
+		t.Error("runStatus() with an invalid --as-of should fail")
+	}
+}
+
+func TestRunStatus_JSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+	for _, tk := range []*ticket.Ticket{
+		{ID: "T-1", Title: "schema", Type: ticket.TypeFeature, Status: ticket.StatusCompleted},
+		{ID: "T-2", Title: "api", Type: ticket.TypeFeature, Status: ticket.StatusPending, Priority: 2, Dependencies: []string{"T-1", "T-3"}},
+		{ID: "T-3", Title: "api tests", Type: ticket.TypeTest, Status: ticket.StatusFailed},
+		{ID: "T-4", Title: "docs", Type: ticket.TypeDocs, Status: ticket.StatusPending},
+	} {
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("Failed to save ticket: %v", err)
+		}
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: ticketsDir, LogsDir: filepath.Join(tmpDir, ".logs"), WorkPIDFile: filepath.Join(tmpDir, ".work.pid")}
+	if err := WriteWorkPIDFile(cfg.WorkPIDFilePath()); err != nil {
+		t.Fatalf("WriteWorkPIDFile() error = %v", err)
+	}
+	defer func() { statusOutput = statusOutputText }()
+
+	statusOutput = statusOutputJSON
+	output := captureOutput(func() {
+		if err := runStatus(nil, nil); err != nil {
+			t.Errorf("runStatus() error = %v", err)
+		}
+	})
+	var report statusReport
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		t.Fatalf("output is not a status report: %v\n%s", err, output)
+	}
+
+	if report.Total != 4 {
+		t.Errorf("total = %d, want 4", report.Total)
+	}
+	if want := (statusCounts{Pending: 2, Completed: 1, Failed: 1}); report.Counts != want {
+		t.Errorf("counts = %+v, want %+v", report.Counts, want)
+	}
+	if want := (statusCounts{Pending: 1, Completed: 1}); report.ByType["feature"] != want {
+		t.Errorf("by_type[feature] = %+v, want %+v", report.ByType["feature"], want)
+	}
+	if len(report.Blocked) != 1 || report.Blocked[0].ID != "T-2" || fmt.Sprint(report.Blocked[0].MissingDependencies) != "[T-3]" {
+		t.Errorf("blocked = %+v, want only T-2 waiting on T-3", report.Blocked)
+	}
+	if len(report.Bottlenecks) != 1 || report.Bottlenecks[0].ID != "T-3" || report.Bottlenecks[0].Unblocks != 1 {
+		t.Errorf("bottlenecks = %+v, want only T-3 unblocking 1", report.Bottlenecks)
+	}
+	if len(report.Cycles) != 0 {
+		t.Errorf("cycles = %+v, want none", report.Cycles)
+	}
+	if report.BackgroundWork == nil || report.BackgroundWork.PID != os.Getpid() || report.BackgroundWork.LogDir != cfg.LogsDir {
+		t.Errorf("background_work = %+v, want PID %d logging to %s", report.BackgroundWork, os.Getpid(), cfg.LogsDir)
+	}
+
+	statusOutput = "yaml"
+	if err := runStatus(nil, nil); err == nil {
+		t.Error("runStatus() with an unknown --output should fail")
+	}
+}
+
+func TestStatusCmd_OutputFlagShadowsGlobal(t *testing.T) {
+	defer func() { statusOutput = statusOutputText }()
+	if err := statusCmd.ParseFlags([]string{"-o", "json"}); err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if statusOutput != statusOutputJSON {
+		t.Errorf("statusOutput = %q, want %q", statusOutput, statusOutputJSON)
+	}
+	if outputFormat != "" {
+		t.Errorf("global --output = %q, want it untouched by status -o", outputFormat)
+	}
+}
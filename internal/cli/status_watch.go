@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/metrics"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/fsnotify/fsnotify"
+	"golang.org/x/term"
+)
+
+// statusSpinnerInterval is how often status --watch advances the in-flight spinners.
+var statusSpinnerInterval = 200 * time.Millisecond
+
+// spinnerFrames are the frames of the in-flight ticket spinners (as ui.MultiSpinner).
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// statusSnapshot is what status --watch shows, loaded on every refresh. Spinner frames
+// are drawn between refreshes from the same snapshot.
+type statusSnapshot struct {
+	at         time.Time
+	inFlight   []*ticket.Ticket
+	background string // background work progress line; empty when none runs
+	body       string // the regular status output
+}
+
+// runStatusWatch re-renders the status every statusInterval and whenever the tickets
+// directory changes, until interrupted.
+func runStatusWatch(w io.Writer, store ticket.Storer) error {
+	if statusInterval <= 0 {
+		return fmt.Errorf(i18n.ErrStatusInterval, statusInterval)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	tty := term.IsTerminal(int(os.Stdout.Fd()))
+	return watchStatus(ctx, w, store, statusInterval, watchTicketsDir(ctx, cfg.TicketsDir), tty)
+}
+
+// watchStatus draws the status view on every tick of interval and every value received
+// from changes (nil when file events are unavailable) until ctx is done. On a terminal
+// the screen is cleared before each frame and in-flight spinners animate in between;
+// otherwise each refresh is appended.
+func watchStatus(ctx context.Context, w io.Writer, store ticket.Storer, interval time.Duration, changes <-chan struct{}, tty bool) error {
+	snap := loadStatusSnapshot(store)
+	frame, drawn := 0, false
+	draw := func() {
+		var buf bytes.Buffer
+		if tty {
+			buf.WriteString("\033[H\033[2J")
+		} else if drawn {
+			buf.WriteString("\n")
+		}
+		renderStatusWatch(&buf, snap, frame)
+		w.Write(buf.Bytes())
+		drawn = true
+	}
+	draw()
+
+	refresh := time.NewTicker(interval)
+	defer refresh.Stop()
+	var spin <-chan time.Time
+	if tty {
+		spinner := time.NewTicker(statusSpinnerInterval)
+		defer spinner.Stop()
+		spin = spinner.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-refresh.C:
+		case <-changes:
+		case <-spin:
+			if len(snap.inFlight) == 0 {
+				continue
+			}
+			frame++
+			draw()
+			continue
+		}
+		snap = loadStatusSnapshot(store)
+		draw()
+	}
+}
+
+// loadStatusSnapshot renders the status of the tickets filterStatusTickets keeps and
+// collects the in-flight tickets and background work progress.
+func loadStatusSnapshot(store ticket.Storer) statusSnapshot {
+	snap := statusSnapshot{at: time.Now()}
+	var body bytes.Buffer
+	if err := renderStatus(&body, store); err != nil {
+		ui.PrintError(&body, err.Error())
+	}
+	snap.body = body.String()
+	if tickets, err := store.LoadByStatus(ticket.StatusInProgress); err == nil {
+		snap.inFlight = withoutEpics(filterStatusTickets(tickets))
+	}
+	snap.background = backgroundProgress(store)
+	return snap
+}
+
+// backgroundProgress describes how far the running background work has got: tickets
+// finished since it started (from the metrics history) and tickets still pending.
+// Returns "" when no background work runs.
+func backgroundProgress(store ticket.Storer) string {
+	pid, _, ok := backgroundWork()
+	if !ok {
+		return ""
+	}
+	started := time.Now()
+	if info, err := os.Stat(cfg.WorkPIDFilePath()); err == nil {
+		started = info.ModTime()
+	}
+	completed, failed := 0, 0
+	if records, err := metrics.Load(cfg.MetricsHistoryPath()); err == nil {
+		for _, r := range records {
+			if r.StartedAt.Before(started) {
+				continue
+			}
+			switch r.Outcome {
+			case metrics.OutcomeCompleted:
+				completed++
+			case metrics.OutcomeFailed:
+				failed++
+			}
+		}
+	}
+	pending := 0
+	if counts, err := store.Count(); err == nil {
+		pending = counts[ticket.StatusPending]
+	}
+	return fmt.Sprintf(i18n.MsgWatchBackground, pid, time.Since(started).Round(time.Second), completed, failed, pending)
+}
+
+// renderStatusWatch writes one frame of the watch view: a header with the refresh
+// time, the in-flight tickets with spinners, background work progress and the status.
+func renderStatusWatch(w io.Writer, snap statusSnapshot, frame int) {
+	ui.PrintInfo(w, ui.StyleMuted.Render(fmt.Sprintf(i18n.MsgWatchHeader, statusInterval, snap.at.Format("15:04:05"))))
+	if snap.background != "" {
+		ui.PrintInfo(w, snap.background)
+	}
+	if len(snap.inFlight) > 0 {
+		ui.PrintInfo(w, "")
+		ui.PrintInfo(w, ui.StyleInfo.Render(fmt.Sprintf(i18n.UIWatchInFlight, len(snap.inFlight))))
+		for i, t := range snap.inFlight {
+			spinner := ui.StyleInfo.Render(spinnerFrames[(frame+i)%len(spinnerFrames)])
+			ui.PrintInfo(w, fmt.Sprintf("  %s %s: %s", spinner, t.ID, ui.Truncate(t.Title, 50)))
+		}
+	}
+	ui.PrintInfo(w, "")
+	fmt.Fprint(w, snap.body)
+}
+
+// watchTicketsDir reports changes under the tickets directory (the directory itself and
+// its status subdirectories) on the returned channel. Bursts of events collapse into
+// one value. Returns nil when file events are unavailable; the view then only refreshes
+// on its interval.
+func watchTicketsDir(ctx context.Context, dir string) <-chan struct{} {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil
+	}
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return nil
+	}
+	for _, status := range []ticket.Status{ticket.StatusPending, ticket.StatusInProgress, ticket.StatusCompleted, ticket.StatusFailed} {
+		_ = watcher.Add(filepath.Join(dir, string(status)))
+	}
+
+	changes := make(chan struct{}, 1)
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				// Temp files of atomic writes are followed by the rename that matters.
+				if strings.HasSuffix(ev.Name, ".tmp") {
+					continue
+				}
+				select {
+				case changes <- struct{}{}:
+				default:
+				}
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+	return changes
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// lockedBuffer is a bytes.Buffer safe to write from the watch loop while the test reads it.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// waitForOutput polls out until it contains want or a second has passed.
+func waitForOutput(t *testing.T, out *lockedBuffer, want string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !strings.Contains(out.String(), want) {
+		if time.Now().After(deadline) {
+			t.Fatalf("output should contain %q, got:\n%s", want, out.String())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWatchStatus_RefreshesOnChange(t *testing.T) {
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+	if err := store.Save(&ticket.Ticket{ID: "T-1", Title: "first", Status: ticket.StatusPending}); err != nil {
+		t.Fatal(err)
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: ticketsDir, WorkPIDFile: filepath.Join(tmpDir, ".work.pid")}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := make(chan struct{})
+	out := &lockedBuffer{}
+	done := make(chan error)
+	go func() { done <- watchStatus(ctx, out, store, time.Hour, changes, false) }()
+
+	waitForOutput(t, out, "T-1: first")
+	if err := store.Save(&ticket.Ticket{ID: "T-2", Title: "second", Status: ticket.StatusInProgress}); err != nil {
+		t.Fatal(err)
+	}
+	changes <- struct{}{}
+	waitForOutput(t, out, fmt.Sprintf(i18n.UIWatchInFlight, 1))
+	waitForOutput(t, out, "T-2: second")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("watchStatus() error = %v", err)
+	}
+}
+
+func TestWatchTicketsDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, string(ticket.StatusPending)), 0755); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := watchTicketsDir(ctx, dir)
+	if changes == nil {
+		t.Skip("file events unavailable")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, string(ticket.StatusPending), "T-1.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-changes:
+	case <-time.After(2 * time.Second):
+		t.Fatal("no change reported for a new ticket file")
+	}
+}
+
+func TestRunStatus_WatchRejectsOtherModes(t *testing.T) {
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: t.TempDir()}
+	defer func() { statusWatch, statusOutput, statusAsOf = false, statusOutputText, "" }()
+
+	statusWatch, statusOutput = true, statusOutputJSON
+	if err := runStatus(nil, nil); err == nil || err.Error() != i18n.ErrStatusWatchMode {
+		t.Errorf("runStatus() with --watch -o json error = %v, want %q", err, i18n.ErrStatusWatchMode)
+	}
+	statusOutput, statusAsOf = statusOutputText, "24h"
+	if err := runStatus(nil, nil); err == nil || err.Error() != i18n.ErrStatusWatchMode {
+		t.Errorf("runStatus() with --watch --as-of error = %v, want %q", err, i18n.ErrStatusWatchMode)
+	}
+}
@@ -0,0 +1,272 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	storeMigrateFrom     string
+	storeMigrateTo       string
+	storeMigrateFromPath string
+	storeMigrateToPath   string
+)
+
+var storeCmd = &cobra.Command{
+	Use:   "store",
+	Short: i18n.CmdStoreShort,
+	Long:  i18n.CmdStoreLong,
+}
+
+var storeMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: i18n.CmdStoreMigrateShort,
+	Long:  i18n.CmdStoreMigrateLong,
+	Args:  cobra.NoArgs,
+	RunE:  runStoreMigrate,
+}
+
+var storeReindexCmd = &cobra.Command{
+	Use:   "reindex",
+	Short: i18n.CmdStoreReindexShort,
+	Long:  i18n.CmdStoreReindexLong,
+	Args:  cobra.NoArgs,
+	RunE:  runStoreReindex,
+}
+
+func init() {
+	storeMigrateCmd.Flags().StringVar(&storeMigrateFrom, "from", "file", i18n.FlagStoreFrom)
+	storeMigrateCmd.Flags().StringVar(&storeMigrateTo, "to", "", i18n.FlagStoreTo)
+	storeMigrateCmd.Flags().StringVar(&storeMigrateFromPath, "from-path", "", i18n.FlagStoreFromPath)
+	storeMigrateCmd.Flags().StringVar(&storeMigrateToPath, "to-path", "", i18n.FlagStoreToPath)
+	_ = storeMigrateCmd.MarkFlagRequired("to")
+	storeCmd.AddCommand(storeMigrateCmd)
+	storeCmd.AddCommand(storeReindexCmd)
+}
+
+// newTicketStore returns the ticket store selected by the store_backend config key,
+// reading files with store_io_parallelism concurrent reads and locking them unless
+// --no-lock is given.
+func newTicketStore() ticket.Storer {
+	store, err := ticket.OpenStore(cfg.StoreBackend, cfg.StorePath())
+	if err != nil {
+		// Validate rejects unknown backends, so only an unvalidated config gets here.
+		return ticket.NewStore(cfg.TicketsDir)
+	}
+	if fs, ok := store.(*ticket.Store); ok {
+		if cfg.StoreIOParallelism > 0 {
+			fs.SetIOParallelism(cfg.StoreIOParallelism)
+		}
+		fs.SetLocking(!noLock, 0)
+	}
+	if telemetryEnabled {
+		return instrumentedStore{store}
+	}
+	return store
+}
+
+// resolveStorePath returns the location of a backend's data: a tickets directory for
+// the file backend, a database file for the sqlite backend. Relative paths are resolved
+// against the project root; an empty path uses the configured tickets directory.
+func resolveStorePath(backend, path string) string {
+	if path == "" {
+		if backend == ticket.BackendSQLite {
+			return filepath.Join(cfg.TicketsDir, ticket.SQLiteFileName)
+		}
+		return cfg.TicketsDir
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(cfg.ProjectRoot, path)
+	}
+	return path
+}
+
+// openStoreBackend opens the ticket store for a backend name at path (see resolveStorePath).
+func openStoreBackend(backend, path string) (ticket.Storer, error) {
+	if backend == "" {
+		return nil, fmt.Errorf(i18n.ErrStoreUnknownBackend, backend, ticket.Backends)
+	}
+	store, err := ticket.OpenStore(backend, resolveStorePath(backend, path))
+	if err != nil {
+		return nil, fmt.Errorf(i18n.ErrStoreUnknownBackend, backend, ticket.Backends)
+	}
+	return store, nil
+}
+
+// storeAuxDir returns the directory holding auxiliary data (metrics history) for a
+// store location: the tickets directory itself for the file backend, the directory
+// of the database file for the sqlite backend.
+func storeAuxDir(backend, path string) string {
+	location := resolveStorePath(backend, path)
+	if backend == ticket.BackendSQLite {
+		return filepath.Dir(location)
+	}
+	return location
+}
+
+func runStoreMigrate(cmd *cobra.Command, args []string) error {
+	if err := ErrIfBackgroundWorkRunning(); err != nil {
+		return err
+	}
+	w := os.Stdout
+
+	src, err := openStoreBackend(storeMigrateFrom, storeMigrateFromPath)
+	if err != nil {
+		return err
+	}
+	dst, err := openStoreBackend(storeMigrateTo, storeMigrateToPath)
+	if err != nil {
+		return err
+	}
+	srcAux := storeAuxDir(storeMigrateFrom, storeMigrateFromPath)
+	dstAux := storeAuxDir(storeMigrateTo, storeMigrateToPath)
+	srcPath := resolveStorePath(storeMigrateFrom, storeMigrateFromPath)
+	dstPath := resolveStorePath(storeMigrateTo, storeMigrateToPath)
+	if storeMigrateFrom == storeMigrateTo && filepath.Clean(srcPath) == filepath.Clean(dstPath) {
+		return errors.New(i18n.ErrStoreMigrateSame)
+	}
+
+	ui.PrintHeader(w, i18n.UIStoreMigrate)
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgStoreMigrateFromTo, storeMigrateFrom, srcPath, storeMigrateTo, dstPath))
+
+	if cfg.DryRun {
+		counts, err := src.Count()
+		if err != nil {
+			return err
+		}
+		total := 0
+		for _, c := range counts {
+			total += c
+		}
+		ui.PrintWarning(w, fmt.Sprintf(i18n.MsgStoreMigrateDryRun, total))
+		return nil
+	}
+
+	report, err := ticket.Migrate(src, dst)
+	if err != nil {
+		if report != nil && report.RolledBack {
+			ui.PrintWarning(w, i18n.MsgStoreMigrateRolledBack)
+		}
+		return fmt.Errorf(i18n.ErrStoreMigrateFailed, err)
+	}
+
+	// Metrics history lives next to the tickets; copy it along unless it is the same file.
+	copied, err := copyVerifiedFile(config.MetricsHistoryPath(srcAux), config.MetricsHistoryPath(dstAux))
+	if err != nil {
+		for _, t := range loadAllTickets(src) {
+			_ = dst.Delete(t.ID)
+		}
+		ui.PrintWarning(w, i18n.MsgStoreMigrateRolledBack)
+		return fmt.Errorf(i18n.ErrStoreMigrateFailed, err)
+	}
+
+	ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgStoreMigrateDone, report.Tickets, report.Checksum[:12]))
+	if copied {
+		ui.PrintSuccess(w, i18n.MsgStoreMigrateMetricsCopied)
+	}
+	ui.PrintInfo(w, i18n.MsgStoreMigrateSourceKept)
+	return nil
+}
+
+// fileStore returns store as the file backend, looking through the telemetry wrapper.
+func fileStore(store ticket.Storer) (*ticket.Store, bool) {
+	if is, ok := store.(instrumentedStore); ok {
+		store = is.Storer
+	}
+	fs, ok := store.(*ticket.Store)
+	return fs, ok
+}
+
+// runStoreReindex rebuilds the index of the file store from its ticket files.
+func runStoreReindex(cmd *cobra.Command, args []string) error {
+	if err := ErrIfBackgroundWorkRunning(); err != nil {
+		return err
+	}
+	w := os.Stdout
+	fs, ok := fileStore(newTicketStore())
+	if !ok {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgStoreReindexNotNeeded, cfg.StoreBackend))
+		return nil
+	}
+	n, err := fs.Reindex()
+	if err != nil {
+		return err
+	}
+	ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgStoreReindexed, n))
+	return nil
+}
+
+// loadAllTickets returns all tickets in s, or nil when they cannot be loaded.
+func loadAllTickets(s ticket.Storer) []*ticket.Ticket {
+	all, err := s.LoadAll()
+	if err != nil {
+		return nil
+	}
+	return all.Tickets
+}
+
+// copyVerifiedFile copies src to dst (0600) and verifies the copy byte-for-byte.
+// It refuses to overwrite an existing, different dst. Returns false when src does
+// not exist or src and dst are the same file.
+func copyVerifiedFile(src, dst string) (bool, error) {
+	if filepath.Clean(src) == filepath.Clean(dst) {
+		return false, nil
+	}
+	data, err := os.ReadFile(src)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if existing, err := os.ReadFile(dst); err == nil {
+		if bytes.Equal(existing, data) {
+			return true, nil
+		}
+		return false, fmt.Errorf(i18n.ErrStoreMigrateFileExists, dst)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0700); err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(dst, data, 0600); err != nil {
+		return false, err
+	}
+	written, err := os.ReadFile(dst)
+	if err != nil || !bytes.Equal(written, data) {
+		_ = os.Remove(dst)
+		return false, fmt.Errorf(i18n.ErrStoreMigrateVerifyFile, dst)
+	}
+	return true, nil
+}
+
+// corruptTicketFiles loads every ticket in store and returns the files that could not
+// be parsed. Only the file store keeps ticket files of its own to report.
+func corruptTicketFiles(store ticket.Storer) []*ticket.CorruptFileError {
+	fs, ok := fileStore(store)
+	if !ok {
+		return nil
+	}
+	if _, err := fs.LoadAll(); err != nil {
+		return nil
+	}
+	return fs.CorruptFiles()
+}
+
+// formatCorruptFiles renders files as MsgCorruptTicketFiles with their paths.
+func formatCorruptFiles(files []*ticket.CorruptFileError) string {
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.Path
+	}
+	return fmt.Sprintf(i18n.MsgCorruptTicketFiles, len(files), strings.Join(paths, ", "))
+}
@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/metrics"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestOpenStoreBackend_UnknownBackend(t *testing.T) {
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: t.TempDir()}
+
+	if _, err := openStoreBackend("s3", ""); err == nil {
+		t.Error("openStoreBackend() should reject unknown backends")
+	}
+	if _, err := openStoreBackend("file", ""); err != nil {
+		t.Errorf("openStoreBackend(file) error = %v", err)
+	}
+}
+
+func TestCopyVerifiedFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.jsonl")
+	if err := os.WriteFile(src, []byte("line\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		dst        string
+		existing   string
+		wantCopied bool
+		wantErr    bool
+	}{
+		{name: "new file", dst: "new/out.jsonl", wantCopied: true},
+		{name: "identical existing", dst: "same.jsonl", existing: "line\n", wantCopied: true},
+		{name: "different existing", dst: "diff.jsonl", existing: "other\n", wantErr: true},
+		{name: "same path", dst: "src.jsonl"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := filepath.Join(dir, tt.dst)
+			if tt.existing != "" {
+				if err := os.WriteFile(dst, []byte(tt.existing), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			copied, err := copyVerifiedFile(src, dst)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("copyVerifiedFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if copied != tt.wantCopied {
+				t.Errorf("copied = %v, want %v", copied, tt.wantCopied)
+			}
+		})
+	}
+
+	if copied, err := copyVerifiedFile(filepath.Join(dir, "missing"), filepath.Join(dir, "x")); err != nil || copied {
+		t.Errorf("missing source: copied = %v, err = %v; want false, nil", copied, err)
+	}
+}
+
+func TestRunStoreMigrate_CopiesTicketsAndMetrics(t *testing.T) {
+	tmpDir := t.TempDir()
+	srcDir := filepath.Join(tmpDir, ".tickets")
+	src := ticket.NewStore(srcDir)
+	if err := src.Init(); err != nil {
+		t.Fatal(err)
+	}
+	for _, tk := range []*ticket.Ticket{
+		ticket.NewTicket("T-1", "a", ""),
+		ticket.NewTicket("T-2", "b", ""),
+	} {
+		if err := src.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := metrics.Append(filepath.Join(srcDir, "metrics.jsonl"), metrics.Record{TicketID: "T-0"}); err != nil {
+		t.Fatal(err)
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{
+		ProjectRoot: tmpDir,
+		TicketsDir:  srcDir,
+		WorkPIDFile: filepath.Join(tmpDir, ".work.pid"),
+	}
+	defer func() {
+		storeMigrateFrom, storeMigrateTo, storeMigrateFromPath, storeMigrateToPath = "file", "", "", ""
+	}()
+	storeMigrateFrom, storeMigrateTo, storeMigrateToPath = "file", "file", "migrated"
+
+	output := captureOutput(func() {
+		if err := runStoreMigrate(nil, nil); err != nil {
+			t.Errorf("runStoreMigrate() error = %v", err)
+		}
+	})
+	if !strings.Contains(output, "2") {
+		t.Errorf("output should report migrated count, got:\n%s", output)
+	}
+
+	dst := ticket.NewStore(filepath.Join(tmpDir, "migrated"))
+	counts, err := dst.Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts[ticket.StatusPending] != 2 {
+		t.Errorf("destination pending count = %d, want 2", counts[ticket.StatusPending])
+	}
+	if recs, _ := metrics.Load(filepath.Join(tmpDir, "migrated", "metrics.jsonl")); len(recs) != 1 {
+		t.Errorf("metrics history should be copied, got %d records", len(recs))
+	}
+
+	// Same backend and location is refused.
+	storeMigrateToPath = ""
+	if err := runStoreMigrate(nil, nil); err == nil {
+		t.Error("runStoreMigrate() should refuse migrating a store onto itself")
+	}
+}
+
+func TestRunStoreMigrate_FileToSQLite(t *testing.T) {
+	tmpDir := t.TempDir()
+	srcDir := filepath.Join(tmpDir, ".tickets")
+	src := ticket.NewStore(srcDir)
+	if err := src.Init(); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Save(ticket.NewTicket("T-1", "a", "")); err != nil {
+		t.Fatal(err)
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{
+		ProjectRoot: tmpDir,
+		TicketsDir:  srcDir,
+		WorkPIDFile: filepath.Join(tmpDir, ".work.pid"),
+	}
+	defer func() {
+		storeMigrateFrom, storeMigrateTo, storeMigrateFromPath, storeMigrateToPath = "file", "", "", ""
+	}()
+	storeMigrateFrom, storeMigrateTo, storeMigrateToPath = "file", "sqlite", ""
+
+	captureOutput(func() {
+		if err := runStoreMigrate(nil, nil); err != nil {
+			t.Errorf("runStoreMigrate() error = %v", err)
+		}
+	})
+
+	// Switching the config to the sqlite backend now finds the migrated ticket.
+	cfg.StoreBackend = "sqlite"
+	store := newTicketStore()
+	if _, ok := store.(*ticket.SQLiteStore); !ok {
+		t.Fatalf("newTicketStore() = %T, want *ticket.SQLiteStore", store)
+	}
+	defer store.(*ticket.SQLiteStore).Close()
+	if _, err := store.Load("T-1"); err != nil {
+		t.Errorf("migrated ticket not found in sqlite store: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(srcDir, ticket.SQLiteFileName)); err != nil {
+		t.Errorf("sqlite database should be created in tickets_dir: %v", err)
+	}
+}
+
+func TestRunStoreReindex(t *testing.T) {
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatal(err)
+	}
+	for _, tk := range []*ticket.Ticket{ticket.NewTicket("T-1", "a", ""), ticket.NewTicket("T-2", "b", "")} {
+		if err := store.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{
+		ProjectRoot: tmpDir,
+		TicketsDir:  ticketsDir,
+		WorkPIDFile: filepath.Join(tmpDir, ".work.pid"),
+	}
+
+	output := captureOutput(func() {
+		if err := runStoreReindex(nil, nil); err != nil {
+			t.Errorf("runStoreReindex() error = %v", err)
+		}
+	})
+	if want := fmt.Sprintf(i18n.MsgStoreReindexed, 2); !strings.Contains(output, want) {
+		t.Errorf("output should contain %q, got:\n%s", want, output)
+	}
+	if _, err := os.Stat(filepath.Join(ticketsDir, ticket.IndexFileName)); err != nil {
+		t.Errorf("store reindex should write the index: %v", err)
+	}
+}
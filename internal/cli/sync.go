@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/github"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+)
+
+// maxSyncOutputChars caps the agent output quoted in an issue comment.
+const maxSyncOutputChars = 3000
+
+// remoteEvent is a ticket change reported back to the issue it was imported from.
+type remoteEvent int
+
+const (
+	remoteCompleted remoteEvent = iota
+	remoteFailed
+	remoteCommitted
+)
+
+// syncRemote reports a ticket change to its RemoteRef issue: a completed ticket posts
+// its agent output and closes the issue, a failed one posts the error and the commands
+// retrying it, a commit posts the commit SHA. Tickets without a RemoteRef, dry runs and
+// github_sync: false are no-ops. Failures only warn; the local ticket state is already saved.
+func syncRemote(ctx context.Context, t *ticket.Ticket, event remoteEvent) {
+	ref := t.RemoteRef
+	if ref == nil || ref.System != github.System || cfg == nil || !cfg.GitHubSync || cfg.DryRun {
+		return
+	}
+	client := github.NewClient(cfg.GitHubAPIURL, githubToken())
+	if client.Token == "" {
+		ui.PrintWarning(os.Stderr, fmt.Sprintf(i18n.MsgSyncNoToken, t.ID, ref))
+		return
+	}
+
+	var err error
+	switch event {
+	case remoteCompleted:
+		err = client.Comment(ctx, ref.Repo, ref.Number, fmt.Sprintf(i18n.GitHubCommentCompleted, t.ID, quoteOutput(t.AgentOutput)))
+		if err == nil {
+			err = client.CloseIssue(ctx, ref.Repo, ref.Number)
+		}
+	case remoteFailed:
+		err = client.Comment(ctx, ref.Repo, ref.Number, fmt.Sprintf(i18n.GitHubCommentFailed, t.ID, quoteOutput(t.Error), retryCommand(t.ID)))
+	case remoteCommitted:
+		if n := len(t.Commits); n > 0 {
+			err = client.Comment(ctx, ref.Repo, ref.Number, fmt.Sprintf(i18n.GitHubCommentCommitted, t.Commits[n-1], t.ID))
+		}
+	}
+	if err != nil {
+		ui.PrintWarning(os.Stderr, fmt.Sprintf(i18n.MsgSyncFailed, t.ID, ref, err))
+	}
+}
+
+// quoteOutput wraps text in a Markdown code block, keeping only its tail when long.
+func quoteOutput(text string) string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "_(empty)_"
+	}
+	if r := []rune(text); len(r) > maxSyncOutputChars {
+		text = "..." + string(r[len(r)-maxSyncOutputChars:])
+	}
+	return "```\n" + strings.ReplaceAll(text, "```", "'''") + "\n```"
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestSyncRemote(t *testing.T) {
+	var mu sync.Mutex
+	var requests, bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+
+	imported := ticket.NewTicket("GH-11", "Fix crash", "")
+	imported.RemoteRef = &ticket.RemoteRef{System: "github", Repo: "o/r", Number: 11}
+	imported.MarkCompleted("fixed")
+	local := ticket.NewTicket("T-1", "Local", "")
+	local.MarkCompleted("done")
+
+	tests := []struct {
+		name  string
+		cfg   config.Config
+		t     *ticket.Ticket
+		event remoteEvent
+		want  []string
+		body  string
+	}{
+		{"completed comments and closes", config.Config{GitHubSync: true, GitHubToken: "tok"}, imported, remoteCompleted,
+			[]string{"POST /repos/o/r/issues/11/comments", "PATCH /repos/o/r/issues/11"}, "fixed"},
+		{"failed only comments", config.Config{GitHubSync: true, GitHubToken: "tok"}, imported, remoteFailed,
+			[]string{"POST /repos/o/r/issues/11/comments"}, "agent-orchestrator retry GH-11"},
+		{"sync disabled", config.Config{GitHubToken: "tok"}, imported, remoteCompleted, nil, ""},
+		{"dry run", config.Config{GitHubSync: true, GitHubToken: "tok", DryRun: true}, imported, remoteCompleted, nil, ""},
+		{"no remote ref", config.Config{GitHubSync: true, GitHubToken: "tok"}, local, remoteCompleted, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests, bodies = nil, nil
+			c := tt.cfg
+			c.GitHubAPIURL = srv.URL
+			cfg = &c
+			syncRemote(context.Background(), tt.t, tt.event)
+			if strings.Join(requests, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("requests = %v, want %v", requests, tt.want)
+			}
+			if tt.body != "" && (len(bodies) == 0 || !strings.Contains(bodies[0], tt.body)) {
+				t.Errorf("comment body %v should contain %q", bodies, tt.body)
+			}
+		})
+	}
+}
+
+func TestQuoteOutput(t *testing.T) {
+	if got := quoteOutput("  "); got != "_(empty)_" {
+		t.Errorf("quoteOutput(blank) = %q", got)
+	}
+	long := strings.Repeat("a", maxSyncOutputChars) + "TAIL"
+	got := quoteOutput(long)
+	if !strings.HasPrefix(got, "```\n...") || !strings.HasSuffix(got, "TAIL\n```") {
+		t.Errorf("quoteOutput(long) should keep the tail, got prefix %q", got[:10])
+	}
+}
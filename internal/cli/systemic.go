@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/notify"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+)
+
+// newFailureStreak returns the tracker of consecutive systemic failures for work and run.
+func newFailureStreak() *agent.FailureStreak {
+	return &agent.FailureStreak{Threshold: cfg.SystemicFailureThreshold}
+}
+
+// agentFailureMessage returns why an agent call failed: err, else the result's error.
+// With text output the error is only the exit status, so the last line the agent
+// printed (usually its error message) is appended.
+func agentFailureMessage(err error, result *agent.Result) string {
+	if err != nil {
+		return err.Error()
+	}
+	if result == nil || result.Error == "" {
+		return "execution failed"
+	}
+	if strings.HasPrefix(result.Error, "exit status") {
+		if last := lastLine(result.Output); last != "" {
+			return result.Error + ": " + last
+		}
+	}
+	return result.Error
+}
+
+// lastLine returns the last non-blank line of s, trimmed.
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// systemicFailureHint suggests what to fix for a failure class.
+func systemicFailureHint(class agent.FailureClass) string {
+	switch class {
+	case agent.FailureUnavailable:
+		return i18n.HintSystemicUnavailable
+	case agent.FailureAuth:
+		return i18n.HintSystemicAuth
+	case agent.FailureQuota:
+		return i18n.HintSystemicQuota
+	case agent.FailureNetwork:
+		return i18n.HintSystemicNetwork
+	case agent.FailureRateLimit:
+		return i18n.HintSystemicRateLimit
+	}
+	return ""
+}
+
+// reportSystemicAbort prints the diagnosis of a tripped failure streak, notifies the
+// configured sinks and returns the error that command (work or run) ends with.
+func reportSystemicAbort(ctx context.Context, w io.Writer, command string, streak *agent.FailureStreak) error {
+	class, count, lastError := streak.Class()
+	ui.PrintError(w, fmt.Sprintf(i18n.MsgSystemicAbort, count, class))
+	ui.PrintInfo(w, ui.StyleMuted.Render(fmt.Sprintf(i18n.MsgSystemicLastError, ui.Truncate(lastError, 200))))
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgSystemicHint, systemicFailureHint(class)))
+
+	event := notify.Event{
+		Kind:    notify.KindAborted,
+		Title:   fmt.Sprintf(i18n.NotifySystemicAbortTitle, command),
+		Message: fmt.Sprintf(i18n.MsgSystemicAbort, count, class) + "\n" + systemicFailureHint(class),
+		Fields:  map[string]string{"class": string(class), "failures": fmt.Sprint(count), "last_error": lastError},
+	}
+	sendNotification(ctx, w, event)
+	return fmt.Errorf(i18n.ErrSystemicAbort, command, class)
+}
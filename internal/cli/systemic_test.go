@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+)
+
+func TestAgentFailureMessage(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		result *agent.Result
+		want   string
+	}{
+		{"call error", errors.New("failed to start command"), nil, "failed to start command"},
+		{"no result", nil, nil, "execution failed"},
+		{"text output", nil, &agent.Result{Error: "exit status 1", Output: "working...\nError: not logged in\n\n"}, "exit status 1: Error: not logged in"},
+		{"text output without output", nil, &agent.Result{Error: "exit status 1"}, "exit status 1"},
+		{"stderr", nil, &agent.Result{Error: "rate limited", Output: "{}"}, "rate limited"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := agentFailureMessage(tt.err, tt.result); got != tt.want {
+				t.Errorf("agentFailureMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
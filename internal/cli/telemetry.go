@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/telemetry"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+)
+
+// Prometheus metrics of this process. They are always recorded but only served by the
+// long-running modes: serve on its own address, work --detach on metrics_addr.
+var (
+	telemetryRegistry = telemetry.NewRegistry()
+
+	ticketsProcessed = telemetryRegistry.NewCounter("agent_orchestrator_tickets_processed_total",
+		"Ticket runs finished by work or run, by resulting status.", "status")
+	agentCallDuration = telemetryRegistry.NewHistogram("agent_orchestrator_agent_call_duration_seconds",
+		"Duration of agent calls including retries, by outcome (success, failure, timeout, error).",
+		[]float64{5, 15, 30, 60, 120, 300, 600, 1200, 1800, 3600}, "outcome")
+	agentRetries = telemetryRegistry.NewCounter("agent_orchestrator_agent_retries_total",
+		"Agent call attempts repeated after a transient failure.")
+	storeOperationDuration = telemetryRegistry.NewHistogram("agent_orchestrator_store_operation_duration_seconds",
+		"Latency of ticket store operations, by operation.",
+		[]float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5}, "operation")
+
+	// lastTicketFinished is the Unix time the last ticket run finished (0: none yet).
+	lastTicketFinished atomic.Int64
+	// telemetryStore is the store the ticket gauge counts; nil until enableTelemetry.
+	telemetryStore atomic.Pointer[ticket.Storer]
+)
+
+func init() {
+	telemetryRegistry.NewGaugeFunc("agent_orchestrator_tickets", "Tickets in the store by status.", "status",
+		func() map[string]float64 {
+			store := telemetryStore.Load()
+			if store == nil {
+				return nil
+			}
+			counts, err := (*store).Count()
+			if err != nil {
+				return nil
+			}
+			values := make(map[string]float64, len(counts))
+			for status, n := range counts {
+				values[string(status)] = float64(n)
+			}
+			return values
+		})
+	telemetryRegistry.NewGaugeFunc("agent_orchestrator_last_ticket_finished_timestamp_seconds",
+		"Unix time the last ticket run of this process finished; alert when it stops advancing.", "",
+		func() map[string]float64 {
+			if ts := lastTicketFinished.Load(); ts > 0 {
+				return map[string]float64{"": float64(ts)}
+			}
+			return nil
+		})
+}
+
+// telemetryEnabled is set by the long-running modes; newTicketStore then times store
+// operations.
+var telemetryEnabled bool
+
+// enableTelemetry turns on store instrumentation for this process and returns store
+// instrumented, also counting its tickets for the tickets gauge.
+func enableTelemetry(store ticket.Storer) ticket.Storer {
+	telemetryEnabled = true
+	if _, ok := store.(instrumentedStore); !ok {
+		store = instrumentedStore{store}
+	}
+	telemetryStore.Store(&store)
+	return store
+}
+
+// observeTicketRun records a finished ticket run.
+func observeTicketRun(t *ticket.Ticket) {
+	ticketsProcessed.Inc(string(t.Status))
+	lastTicketFinished.Store(time.Now().Unix())
+}
+
+// observeAgentCall records the duration and retries of a finished agent call. Dry runs
+// are not recorded.
+func observeAgentCall(info agent.CallInfo) {
+	if info.DryRun {
+		return
+	}
+	outcome := "error"
+	if info.Err == nil && info.Result != nil {
+		switch {
+		case info.Result.Success:
+			outcome = "success"
+		case info.Result.TimedOut:
+			outcome = "timeout"
+		default:
+			outcome = "failure"
+		}
+		if info.Result.Attempts > 1 {
+			agentRetries.Add(float64(info.Result.Attempts - 1))
+		}
+	}
+	agentCallDuration.Observe(info.Duration.Seconds(), outcome)
+}
+
+// serveMetrics serves /metrics on addr in the background until the process exits.
+// A failure to listen only warns: metrics must not stop the work.
+func serveMetrics(w io.Writer, addr string) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		ui.PrintWarning(w, fmt.Sprintf(i18n.MsgMetricsListenFailed, addr, err))
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", telemetryRegistry.Handler())
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgMetricsListening, "http://"+ln.Addr().String()+"/metrics"))
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			ui.PrintWarning(w, fmt.Sprintf(i18n.MsgMetricsListenFailed, addr, err))
+		}
+	}()
+}
+
+// instrumentedStore times every operation of a ticket store.
+type instrumentedStore struct {
+	ticket.Storer
+}
+
+func timeStoreOp(op string, start time.Time) {
+	storeOperationDuration.Observe(time.Since(start).Seconds(), op)
+}
+
+func (s instrumentedStore) Init() error {
+	defer timeStoreOp("init", time.Now())
+	return s.Storer.Init()
+}
+
+func (s instrumentedStore) Save(t *ticket.Ticket) error {
+	defer timeStoreOp("save", time.Now())
+	return s.Storer.Save(t)
+}
+
+func (s instrumentedStore) Load(id string) (*ticket.Ticket, error) {
+	defer timeStoreOp("load", time.Now())
+	return s.Storer.Load(id)
+}
+
+func (s instrumentedStore) LoadByStatus(status ticket.Status) ([]*ticket.Ticket, error) {
+	defer timeStoreOp("load_by_status", time.Now())
+	return s.Storer.LoadByStatus(status)
+}
+
+func (s instrumentedStore) LoadAll() (*ticket.TicketList, error) {
+	defer timeStoreOp("load_all", time.Now())
+	return s.Storer.LoadAll()
+}
+
+func (s instrumentedStore) Delete(id string) error {
+	defer timeStoreOp("delete", time.Now())
+	return s.Storer.Delete(id)
+}
+
+func (s instrumentedStore) CountByStatus(status ticket.Status) (int, error) {
+	defer timeStoreOp("count_by_status", time.Now())
+	return s.Storer.CountByStatus(status)
+}
+
+func (s instrumentedStore) Count() (map[ticket.Status]int, error) {
+	defer timeStoreOp("count", time.Now())
+	return s.Storer.Count()
+}
+
+func (s instrumentedStore) MoveToStatus(id string, newStatus ticket.Status) error {
+	defer timeStoreOp("move_to_status", time.Now())
+	return s.Storer.MoveToStatus(id, newStatus)
+}
+
+func (s instrumentedStore) MoveFailed() (int, error) {
+	defer timeStoreOp("move_failed", time.Now())
+	return s.Storer.MoveFailed()
+}
+
+func (s instrumentedStore) ResetInProgress() (int, error) {
+	defer timeStoreOp("reset_in_progress", time.Now())
+	return s.Storer.ResetInProgress()
+}
+
+func (s instrumentedStore) Clean() error {
+	defer timeStoreOp("clean", time.Now())
+	return s.Storer.Clean()
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// withTelemetry enables telemetry for the test and restores the process state after it.
+func withTelemetry(t *testing.T, store ticket.Storer) ticket.Storer {
+	t.Helper()
+	t.Cleanup(func() {
+		telemetryEnabled = false
+		telemetryStore.Store(nil)
+	})
+	return enableTelemetry(store)
+}
+
+func TestObserveAgentCall(t *testing.T) {
+	tests := []struct {
+		name        string
+		info        agent.CallInfo
+		wantOutcome string
+		wantRetries float64
+	}{
+		{
+			name:        "success after retries",
+			info:        agent.CallInfo{Duration: 2 * time.Second, Result: &agent.Result{Success: true, Attempts: 3}},
+			wantOutcome: "success",
+			wantRetries: 2,
+		},
+		{
+			name:        "timeout",
+			info:        agent.CallInfo{Duration: time.Minute, Result: &agent.Result{TimedOut: true, Attempts: 1}},
+			wantOutcome: "timeout",
+		},
+		{
+			name:        "failure",
+			info:        agent.CallInfo{Result: &agent.Result{Attempts: 1}},
+			wantOutcome: "failure",
+		},
+		{
+			name:        "error without result",
+			info:        agent.CallInfo{Err: errors.New("boom")},
+			wantOutcome: "error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := agentCallDuration.Count(tt.wantOutcome)
+			retries := agentRetries.Value()
+
+			observeAgentCall(tt.info)
+
+			if got := agentCallDuration.Count(tt.wantOutcome) - calls; got != 1 {
+				t.Errorf("calls with outcome %q = %d, want 1", tt.wantOutcome, got)
+			}
+			if got := agentRetries.Value() - retries; got != tt.wantRetries {
+				t.Errorf("retries = %v, want %v", got, tt.wantRetries)
+			}
+		})
+	}
+
+	t.Run("dry run is not recorded", func(t *testing.T) {
+		calls := agentCallDuration.Count("success")
+		observeAgentCall(agent.CallInfo{DryRun: true, Result: &agent.Result{Success: true}})
+		if got := agentCallDuration.Count("success"); got != calls {
+			t.Errorf("dry run recorded: calls = %d, want %d", got, calls)
+		}
+	})
+}
+
+func TestInstrumentedStore(t *testing.T) {
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{TicketsDir: t.TempDir()}
+
+	store := withTelemetry(t, newTicketStore())
+	if _, ok := store.(instrumentedStore); !ok {
+		t.Fatalf("enableTelemetry() = %T, want instrumentedStore", store)
+	}
+	if _, ok := newTicketStore().(instrumentedStore); !ok {
+		t.Error("newTicketStore() should be instrumented once telemetry is enabled")
+	}
+	if err := store.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	saves := storeOperationDuration.Count("save")
+	tk := ticket.NewTicket("TICKET-001", "Metrics", "")
+	if err := store.Save(tk); err != nil {
+		t.Fatal(err)
+	}
+	if got := storeOperationDuration.Count("save") - saves; got != 1 {
+		t.Errorf("save operations = %d, want 1", got)
+	}
+
+	processed := ticketsProcessed.Value(string(ticket.StatusCompleted))
+	tk.Status = ticket.StatusCompleted
+	observeTicketRun(tk)
+	if got := ticketsProcessed.Value(string(ticket.StatusCompleted)) - processed; got != 1 {
+		t.Errorf("completed tickets processed = %v, want 1", got)
+	}
+
+	rec := httptest.NewRecorder()
+	telemetryRegistry.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	for _, want := range []string{
+		`agent_orchestrator_tickets{status="pending"} 1`,
+		`agent_orchestrator_store_operation_duration_seconds_count{operation="save"}`,
+		`agent_orchestrator_last_ticket_finished_timestamp_seconds `,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("/metrics missing %q:\n%s", want, body)
+		}
+	}
+}
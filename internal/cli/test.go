@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/anthropic/agent-orchestrator/internal/agent"
 	"github.com/anthropic/agent-orchestrator/internal/i18n"
@@ -33,6 +34,9 @@ func runTest(cmd *cobra.Command, args []string) error {
 	}
 
 	testAgent := agent.NewTestAgent(caller, cfg.ProjectRoot)
+	testAgent.SetMode(cfg.TestMode)
+	testAgent.SetJUnitReports(cfg.TestJUnitReports)
+	testAgent.SetRetries(cfg.TestRetries)
 
 	// Run tests
 	spinner := ui.NewSpinner(i18n.SpinnerTesting, w)
@@ -55,14 +59,20 @@ func runTest(cmd *cobra.Command, args []string) error {
 		ui.PrintInfo(w, "")
 		if testResult.Passed > 0 || testResult.Failed > 0 {
 			ui.PrintInfo(w, i18n.MsgTestResult)
-			ui.PrintSuccess(w, "  通過: "+string(rune('0'+testResult.Passed)))
+			ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgTestPassed, testResult.Passed))
 			if testResult.Failed > 0 {
-				ui.PrintError(w, "  失敗: "+string(rune('0'+testResult.Failed)))
+				ui.PrintError(w, fmt.Sprintf(i18n.MsgTestFailed, testResult.Failed))
 			}
 			if testResult.Skipped > 0 {
-				ui.PrintWarning(w, "  跳過: "+string(rune('0'+testResult.Skipped)))
+				ui.PrintWarning(w, fmt.Sprintf(i18n.MsgTestSkipped, testResult.Skipped))
 			}
 		}
+		if testResult.Coverage != nil {
+			ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTestCoverage, *testResult.Coverage))
+		}
+		if len(testResult.Flaky) > 0 {
+			ui.PrintWarning(w, fmt.Sprintf(i18n.MsgTestFlaky, strings.Join(testResult.Flaky, ", ")))
+		}
 		if testResult.Summary != "" {
 			ui.PrintInfo(w, "")
 			ui.PrintInfo(w, fmt.Sprintf(i18n.MsgSummary, testResult.Summary))
@@ -76,5 +86,20 @@ func runTest(cmd *cobra.Command, args []string) error {
 		ui.PrintInfo(w, result.Output)
 	}
 
+	return checkCoverage(testResult)
+}
+
+// checkCoverage enforces config min_coverage on a test result: the coverage must be
+// reported and at least the minimum. Nil when min_coverage is not set.
+func checkCoverage(testResult *agent.TestResult) error {
+	if cfg.MinCoverage <= 0 {
+		return nil
+	}
+	if testResult == nil || testResult.Coverage == nil {
+		return fmt.Errorf(i18n.ErrCoverageUnknown, cfg.MinCoverage)
+	}
+	if *testResult.Coverage < cfg.MinCoverage {
+		return fmt.Errorf(i18n.ErrCoverageBelow, *testResult.Coverage, cfg.MinCoverage)
+	}
 	return nil
 }
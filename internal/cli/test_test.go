@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+)
+
+func TestCheckCoverage(t *testing.T) {
+	coverage := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name        string
+		minCoverage float64
+		result      *agent.TestResult
+		want        string // empty for no error
+	}{
+		{name: "not configured", minCoverage: 0, result: &agent.TestResult{}, want: ""},
+		{name: "met", minCoverage: 70, result: &agent.TestResult{Coverage: coverage(70)}, want: ""},
+		{name: "below", minCoverage: 70, result: &agent.TestResult{Coverage: coverage(64.5)}, want: fmt.Sprintf(i18n.ErrCoverageBelow, 64.5, 70.0)},
+		{name: "not reported", minCoverage: 70, result: &agent.TestResult{Passed: 3}, want: fmt.Sprintf(i18n.ErrCoverageUnknown, 70.0)},
+		{name: "no result", minCoverage: 70, result: nil, want: fmt.Sprintf(i18n.ErrCoverageUnknown, 70.0)},
+	}
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg = &config.Config{MinCoverage: tt.minCoverage}
+			err := checkCoverage(tt.result)
+			if got := fmt.Sprint(err); (tt.want == "" && err != nil) || (tt.want != "" && got != tt.want) {
+				t.Errorf("checkCoverage() = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
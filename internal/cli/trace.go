@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var traceCmd = &cobra.Command{
+	Use:   "trace <ticket-id | pr | commit>",
+	Short: i18n.CmdTraceShort,
+	Long:  i18n.CmdTraceLong,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTrace,
+}
+
+// shaPattern matches full or abbreviated commit SHAs.
+var shaPattern = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
+
+func runTrace(cmd *cobra.Command, args []string) error {
+	w := os.Stdout
+	store := newTicketStore()
+	tickets, err := findTickets(store, args[0])
+	if err != nil {
+		return err
+	}
+	for i, t := range tickets {
+		if i > 0 {
+			ui.PrintInfo(w, "")
+		}
+		printTrace(w, t)
+	}
+	return nil
+}
+
+// findTickets resolves a ticket ID, a pull request (number or URL) or a commit SHA to
+// the tickets it belongs to, in that order.
+func findTickets(store ticket.Storer, ref string) ([]*ticket.Ticket, error) {
+	if t, err := store.Load(ref); err == nil {
+		return []*ticket.Ticket{t}, nil
+	}
+	all, err := store.LoadAll()
+	if err != nil {
+		return nil, err
+	}
+	var found []*ticket.Ticket
+	if pr, err := ticket.ParsePullRequestRef(ref); err == nil {
+		found = ticket.FindByPullRequest(all.Tickets, pr)
+	}
+	if len(found) == 0 && shaPattern.MatchString(ref) {
+		found = ticket.FindByCommit(all.Tickets, ref)
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf(i18n.ErrTraceNotFound, ref)
+	}
+	return found, nil
+}
+
+// findTicketsByPR returns the tickets linked to a pull request reference.
+func findTicketsByPR(store ticket.Storer, ref string) ([]*ticket.Ticket, error) {
+	pr, err := ticket.ParsePullRequestRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	all, err := store.LoadAll()
+	if err != nil {
+		return nil, err
+	}
+	found := ticket.FindByPullRequest(all.Tickets, pr)
+	if len(found) == 0 {
+		return nil, fmt.Errorf(i18n.ErrTraceNotFound, ref)
+	}
+	return found, nil
+}
+
+// printTrace prints the milestone → ticket → commits → PR chain of one ticket.
+func printTrace(w io.Writer, t *ticket.Ticket) {
+	none := ui.StyleMuted.Render("-")
+	milestone, commits, pr := none, none, none
+	if t.Milestone != "" {
+		milestone = t.Milestone
+	}
+	if len(t.Commits) > 0 {
+		commits = strings.Join(shortSHAs(t.Commits), ", ")
+	}
+	if t.PullRequest != nil {
+		pr = t.PullRequest.String()
+	}
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTraceMilestone, milestone))
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTraceTicket, t.ID, t.Title, t.Status))
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTraceCommits, commits))
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTracePR, pr))
+}
+
+// shortSHAs abbreviates commit SHAs to 7 characters for display.
+func shortSHAs(shas []string) []string {
+	out := make([]string, len(shas))
+	for i, s := range shas {
+		if len(s) > 7 {
+			s = s[:7]
+		}
+		out[i] = s
+	}
+	return out
+}
+
+// recordTicketCommit stores the new HEAD on the ticket when a commit moved it away
+// from before. Failures only warn: the commit itself already succeeded.
+func recordTicketCommit(ctx context.Context, w io.Writer, store ticket.Storer, t *ticket.Ticket, before string) {
+	head := getGitHead(ctx)
+	if head == "" || head == before {
+		return
+	}
+	t.AddCommit(head)
+	if err := store.Save(t); err != nil {
+		ui.PrintWarning(w, fmt.Sprintf(i18n.ErrSaveTicketFailed, t.ID))
+	}
+	syncRemote(ctx, t, remoteCommitted)
+}
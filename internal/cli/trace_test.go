@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestRunTrace(t *testing.T) {
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatal(err)
+	}
+	tk := ticket.NewTicket("T-1", "Add login", "")
+	tk.Milestone = "docs/milestone-001.md"
+	tk.AddCommit("3f2c1ab9d0e4aa")
+	tk.PullRequest = &ticket.PullRequestRef{Number: 42, URL: "https://github.com/o/r/pull/42"}
+	if err := store.Save(tk); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(ticket.NewTicket("T-2", "Unrelated", "")); err != nil {
+		t.Fatal(err)
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{ProjectRoot: tmpDir, TicketsDir: ticketsDir}
+
+	for _, ref := range []string{"T-1", "42", "#42", "https://github.com/o/r/pull/42", "3f2c1ab"} {
+		output := captureOutput(func() {
+			if err := runTrace(nil, []string{ref}); err != nil {
+				t.Errorf("runTrace(%q) err = %v", ref, err)
+			}
+		})
+		for _, want := range []string{
+			fmt.Sprintf(i18n.MsgTraceMilestone, "docs/milestone-001.md"),
+			fmt.Sprintf(i18n.MsgTraceTicket, "T-1", "Add login", ticket.StatusPending),
+			fmt.Sprintf(i18n.MsgTraceCommits, "3f2c1ab"),
+			"#42 https://github.com/o/r/pull/42",
+		} {
+			if !strings.Contains(output, want) {
+				t.Errorf("runTrace(%q) output missing %q, got:\n%s", ref, want, output)
+			}
+		}
+		if strings.Contains(output, "T-2") {
+			t.Errorf("runTrace(%q) should not list T-2", ref)
+		}
+	}
+
+	if err := runTrace(nil, []string{"43"}); err == nil {
+		t.Error("runTrace() should fail for an unlinked PR")
+	}
+}
+
+func TestMilestoneRef(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{ProjectRoot: tmpDir}
+
+	if got := milestoneRef(filepath.Join(tmpDir, "docs", "m1.md")); got != "docs/m1.md" {
+		t.Errorf("milestoneRef() inside project = %q, want docs/m1.md", got)
+	}
+	outside := filepath.Join(filepath.Dir(tmpDir), "m2.md")
+	if got := milestoneRef(outside); got != outside {
+		t.Errorf("milestoneRef() outside project = %q, want %q", got, outside)
+	}
+}
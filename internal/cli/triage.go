@@ -0,0 +1,185 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+	orcherrors "github.com/anthropic/agent-orchestrator/internal/errors"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	triageScope []string
+	triageAll   bool
+	triageLimit int
+)
+
+var triageCmd = &cobra.Command{
+	Use:   "triage",
+	Short: i18n.CmdTriageShort,
+	Long:  i18n.CmdTriageLong,
+	Args:  cobra.NoArgs,
+	RunE:  runTriage,
+}
+
+func init() {
+	triageCmd.Flags().StringSliceVar(&triageScope, "scope", []string{"all"}, i18n.FlagScope)
+	triageCmd.Flags().BoolVar(&triageAll, "all", false, i18n.FlagTriageAll)
+	triageCmd.Flags().IntVar(&triageLimit, "limit", 10, i18n.FlagTriageLimit)
+}
+
+// runTriage is the daily one-shot: analyze what changed, create tickets for the
+// findings not tracked yet and print the queue work would take next.
+func runTriage(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	w := os.Stdout
+
+	store := newTicketStore()
+	if err := store.Init(); err != nil {
+		return orcherrors.ErrStoreInit(err)
+	}
+
+	ui.PrintHeader(w, i18n.UITriage)
+	ui.PrintStep(w, 1, 3, i18n.StepTriageAnalyze)
+	issues, err := triageAnalyze(ctx, w)
+	if err != nil {
+		return err
+	}
+
+	ui.PrintStep(w, 2, 3, i18n.StepTriageDedupe)
+	if err := triageCreate(w, store, issues); err != nil {
+		return err
+	}
+
+	ui.PrintStep(w, 3, 3, i18n.StepTriageQueue)
+	return printTriageQueue(w, store, triageLimit)
+}
+
+// triageAnalyze analyzes the files changed since the upstream branch (the whole
+// project with --all) and returns the findings; none when nothing changed.
+func triageAnalyze(ctx context.Context, w io.Writer) ([]*ticket.Issue, error) {
+	var files []string
+	if !triageAll {
+		files = getGitChangedFilesSinceUpstream(ctx)
+		if len(files) == 0 {
+			ui.PrintSuccess(w, i18n.MsgAnalyzeNoChangedFiles)
+			return nil, nil
+		}
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgAnalyzeChangedFiles, len(files)))
+	}
+
+	caller, err := CreateAgentCaller()
+	if err != nil {
+		return nil, err
+	}
+	aa := agent.NewAnalyzeAgent(caller, cfg.ProjectRoot)
+	aa.SetFiles(files)
+
+	spinner := ui.NewSpinner(i18n.SpinnerAnalyzing, w)
+	spinner.Start()
+	found, err := aa.Analyze(ctx, agent.ParseScopes(triageScope))
+	if err != nil {
+		spinner.Fail(i18n.SpinnerFailAnalysis)
+		return nil, err
+	}
+	spinner.Success(fmt.Sprintf(i18n.MsgFoundIssues, found.Count()))
+	return found.Issues, nil
+}
+
+// triageCreate saves tickets for the issues no existing ticket covers. A ticket whose
+// ID is taken by an unrelated ticket gets a numeric suffix instead of replacing it.
+func triageCreate(w io.Writer, store ticket.Storer, issues []*ticket.Issue) error {
+	all, err := store.LoadAll()
+	if err != nil {
+		return err
+	}
+	fresh, duplicates := ticket.Dedupe(issues, all.Tickets)
+	for _, d := range duplicates {
+		ui.PrintInfo(w, ui.StyleMuted.Render(fmt.Sprintf(i18n.MsgTriageDuplicate, ui.Truncate(d.Issue.Title, 50), d.Ticket.ID, d.Ticket.Status)))
+	}
+
+	taken := make(map[string]bool, len(all.Tickets))
+	for _, t := range all.Tickets {
+		taken[t.ID] = true
+	}
+	created := 0
+	for _, t := range (&ticket.IssueList{Issues: fresh}).ToTickets().Tickets {
+		t.ID = freeTicketID(taken, t.ID)
+		taken[t.ID] = true
+		if cfg.DryRun {
+			ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTriageWouldCreate, t.ID, t.Title))
+			continue
+		}
+		if err := store.Save(t); err != nil {
+			ui.PrintWarning(w, orcherrors.ErrSaveTicket(t.ID, err).Error())
+			continue
+		}
+		created++
+		ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgTicketCreated, t.ID, t.Title))
+	}
+	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgTriageCreated, created, len(duplicates)))
+	return nil
+}
+
+// freeTicketID returns id, or id with the first free suffix "-2", "-3", ... when taken.
+func freeTicketID(taken map[string]bool, id string) string {
+	if !taken[id] {
+		return id
+	}
+	for n := 2; ; n++ {
+		if candidate := fmt.Sprintf("%s-%d", id, n); !taken[candidate] {
+			return candidate
+		}
+	}
+}
+
+// printTriageQueue prints up to limit tickets work would start now, in the order it
+// starts them: by priority, raised for tickets others wait on (see escalation).
+func printTriageQueue(w io.Writer, store ticket.Storer, limit int) error {
+	resolver := ticket.NewDependencyResolver(store)
+	rc, err := ticket.NewResolverContext(store)
+	if err != nil {
+		return err
+	}
+	queue, err := resolver.GetProcessableWithContext(rc)
+	if err != nil {
+		return err
+	}
+	rule := escalationRule()
+	resolver.PrioritizeWithContext(queue, rule, rc)
+	if len(queue) == 0 {
+		ui.PrintInfo(w, i18n.MsgTriageQueueEmpty)
+		return nil
+	}
+
+	table := ui.NewTable("#", i18n.TablePreviewID, i18n.TablePreviewTitle, i18n.TablePreviewType, i18n.TablePreviewPriority, i18n.TableTriageUnblocks)
+	shown := queue
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+	}
+	for i, t := range shown {
+		n := rc.Unblocks(t.ID, rule.Transitive)
+		p := rule.EffectivePriority(t.Priority, n)
+		priority := ui.PriorityStyle(p).Render(fmt.Sprintf("P%d", p))
+		if p < t.Priority {
+			priority += ui.StyleMuted.Render(fmt.Sprintf(" (P%d)", t.Priority))
+		}
+		unblocks := "-"
+		if n > 0 {
+			unblocks = fmt.Sprint(n)
+		}
+		table.AddRow(fmt.Sprint(i+1), t.ID, ui.Truncate(t.Title, 40), string(t.Type), priority, unblocks)
+	}
+	table.Render(w)
+	if len(queue) > len(shown) {
+		ui.PrintInfo(w, ui.StyleMuted.Render(fmt.Sprintf(i18n.MsgTriageQueueMore, len(queue)-len(shown))))
+	}
+	ui.PrintInfo(w, i18n.HintRunWork)
+	return nil
+}
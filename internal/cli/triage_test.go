@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestTriageCreateAndQueue(t *testing.T) {
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	dir := t.TempDir()
+	cfg = &config.Config{ProjectRoot: dir, TicketsDir: filepath.Join(dir, ".tickets"), StoreBackend: "file"}
+	store := newTicketStore()
+	if err := store.Init(); err != nil {
+		t.Fatal(err)
+	}
+	tracked := ticket.NewTicket("SEC-001", "Validate the redirect URL", "")
+	tracked.FilesToModify = []string{"internal/auth/redirect.go:10"}
+	if err := store.Save(tracked); err != nil {
+		t.Fatal(err)
+	}
+
+	issues := []*ticket.Issue{
+		// Reworded finding about the tracked ticket's file.
+		{ID: "SEC-002", Category: "security", Severity: "HIGH", Title: "Redirect URL is not validated", Location: "internal/auth/redirect.go:14"},
+		// New finding whose ID is taken by an unrelated ticket.
+		{ID: "SEC-001", Category: "security", Severity: "MED", Title: "Session cookie lacks the Secure flag", Location: "internal/auth/cookie.go:3"},
+		{ID: "TEST-001", Category: "test", Severity: "LOW", Title: "Add tests for the cookie helpers", Location: "internal/auth/cookie.go"},
+	}
+	var err error
+	out := captureOutput(func() { err = triageCreate(os.Stdout, store, issues) })
+	if err != nil {
+		t.Fatalf("triageCreate() error = %v", err)
+	}
+	if !strings.Contains(out, "SEC-001") {
+		t.Errorf("output should name the ticket of the duplicate:\n%s", out)
+	}
+	all, _ := store.LoadAll()
+	if len(all.Tickets) != 3 {
+		t.Fatalf("tickets = %d, want the tracked one and 2 new", len(all.Tickets))
+	}
+	if got, err := store.Load("SEC-001"); err != nil || got.Title != tracked.Title {
+		t.Errorf("SEC-001 = %v (%v), want the tracked ticket kept", got, err)
+	}
+	if got, err := store.Load("SEC-001-2"); err != nil || got.Priority != 3 {
+		t.Errorf("SEC-001-2 = %v (%v), want the new P3 ticket", got, err)
+	}
+
+	out = captureOutput(func() { err = printTriageQueue(os.Stdout, store, 2) })
+	if err != nil {
+		t.Fatalf("printTriageQueue() error = %v", err)
+	}
+	first, second := strings.Index(out, "SEC-001-2"), strings.Index(out, "TEST-001")
+	if first < 0 || second >= 0 {
+		t.Errorf("queue limited to 2 should list SEC-001-2 and not TEST-001:\n%s", out)
+	}
+	if !strings.Contains(out, "--limit 0") {
+		t.Errorf("queue should mention the hidden tickets:\n%s", out)
+	}
+}
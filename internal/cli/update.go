@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/anthropic/agent-orchestrator/internal/update"
+	"github.com/spf13/cobra"
+)
+
+var (
+	versionCheck    bool
+	selfUpdateForce bool
+)
+
+var selfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: i18n.CmdSelfUpdateShort,
+	Long:  i18n.CmdSelfUpdateLong,
+	Args:  cobra.NoArgs,
+	RunE:  runSelfUpdate,
+}
+
+func init() {
+	versionCmd.Flags().BoolVar(&versionCheck, "check", false, i18n.FlagVersionCheck)
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateForce, "force", false, i18n.FlagSelfUpdateForce)
+}
+
+// updateReleaseURL returns the release endpoint from config. The version command skips
+// config loading in PersistentPreRunE, so fall back to loading it here.
+func updateReleaseURL() string {
+	if cfg != nil && cfg.UpdateReleaseURL != "" {
+		return cfg.UpdateReleaseURL
+	}
+	if loaded, err := config.Load(); err == nil && loaded.UpdateReleaseURL != "" {
+		return loaded.UpdateReleaseURL
+	}
+	return config.DefaultUpdateReleaseURL
+}
+
+// runVersionCheck reports whether a newer release than Version exists.
+func runVersionCheck(ctx context.Context, w io.Writer, client *update.Client) error {
+	rel, err := client.Latest(ctx)
+	if err != nil {
+		return fmt.Errorf(i18n.ErrUpdateCheckFailed, err)
+	}
+	switch {
+	case isDevVersion(Version):
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgUpdateDevBuild, Version, rel.TagName))
+	case update.CompareVersions(Version, rel.TagName) < 0:
+		ui.PrintWarning(w, fmt.Sprintf(i18n.MsgUpdateAvailable, rel.TagName, Version))
+		ui.PrintInfo(w, ui.StyleMuted.Render(i18n.HintSelfUpdate))
+	default:
+		ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgUpdateUpToDate, Version))
+	}
+	return nil
+}
+
+func runSelfUpdate(cmd *cobra.Command, args []string) error {
+	w := os.Stdout
+	ctx := context.Background()
+	client := update.NewClient(updateReleaseURL())
+
+	ui.PrintInfo(w, i18n.MsgUpdateChecking)
+	rel, err := client.Latest(ctx)
+	if err != nil {
+		return fmt.Errorf(i18n.ErrUpdateCheckFailed, err)
+	}
+
+	if !selfUpdateForce {
+		if isDevVersion(Version) {
+			ui.PrintInfo(w, fmt.Sprintf(i18n.MsgUpdateDevBuild, Version, rel.TagName))
+			return nil
+		}
+		if update.CompareVersions(Version, rel.TagName) >= 0 {
+			ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgUpdateUpToDate, Version))
+			return nil
+		}
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf(i18n.ErrUpdateFailed, err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+
+	spinner := ui.NewSpinner(fmt.Sprintf(i18n.MsgUpdateDownloading, update.CurrentAssetName()), w)
+	spinner.Start()
+	data, err := client.Download(ctx, rel, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		spinner.Stop()
+		return fmt.Errorf(i18n.ErrUpdateFailed, err)
+	}
+	spinner.Stop()
+
+	if err := update.ReplaceExecutable(exe, data); err != nil {
+		return fmt.Errorf(i18n.ErrUpdateFailed, err)
+	}
+	ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgUpdateInstalled, rel.TagName, exe))
+	return nil
+}
+
+// isDevVersion reports whether the binary was built without a release version (ldflags).
+func isDevVersion(v string) bool {
+	return v == "" || v == "dev"
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/update"
+)
+
+func TestRunVersionCheck(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(update.Release{TagName: "v1.5.0"})
+	}))
+	defer srv.Close()
+
+	originalVersion := Version
+	defer func() { Version = originalVersion }()
+
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"v1.4.0", "v1.5.0"},
+		{"v1.5.0", "v1.5.0"},
+		{"dev", "dev"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			Version = tt.version
+			var buf bytes.Buffer
+			if err := runVersionCheck(context.Background(), &buf, update.NewClient(srv.URL)); err != nil {
+				t.Fatalf("runVersionCheck() error = %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("output = %q, want it to mention %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRunVersionCheck_EndpointError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	if err := runVersionCheck(context.Background(), &buf, update.NewClient(srv.URL)); err == nil {
+		t.Error("runVersionCheck() should return error when the endpoint fails")
+	}
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/agent"
+	orcherrors "github.com/anthropic/agent-orchestrator/internal/errors"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify [ticket-id...]",
+	Short: i18n.CmdVerifyShort,
+	Long:  i18n.CmdVerifyLong,
+	RunE:  runVerify,
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	w := os.Stdout
+
+	if err := ErrIfBackgroundWorkRunning(); err != nil {
+		return err
+	}
+	store := newTicketStore()
+	if err := store.Init(); err != nil {
+		return fmt.Errorf(i18n.ErrInitStoreFailed, err)
+	}
+
+	var tickets []*ticket.Ticket
+	if len(args) > 0 {
+		for _, id := range args {
+			t, err := store.Load(id)
+			if err != nil {
+				return fmt.Errorf(i18n.ErrTicketNotFound, id)
+			}
+			if t.Status != ticket.StatusCompleted {
+				return fmt.Errorf(i18n.ErrVerifyNotComplete, t.ID, t.Status)
+			}
+			tickets = append(tickets, t)
+		}
+	} else {
+		completed, err := store.LoadByStatus(ticket.StatusCompleted)
+		if err != nil {
+			return err
+		}
+		for _, t := range completed {
+			if t.Verification == nil {
+				tickets = append(tickets, t)
+			}
+		}
+	}
+	if len(tickets) == 0 {
+		ui.PrintInfo(w, i18n.MsgVerifyNothing)
+		return nil
+	}
+
+	caller, err := CreateAgentCaller()
+	if err != nil {
+		ui.PrintError(w, i18n.ErrAgentNotFound)
+		return nil
+	}
+
+	failed, err := verifyTickets(ctx, w, caller, store, tickets)
+	if err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf(i18n.ErrVerifyFailed, len(failed), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// verifyTickets has the verification agent check each ticket's acceptance criteria,
+// printing the verdicts and saving them on the ticket. It returns the IDs of the
+// tickets with a failed criterion; tickets without criteria are skipped.
+func verifyTickets(ctx context.Context, w io.Writer, caller *agent.Caller, store ticket.Storer, tickets []*ticket.Ticket) ([]string, error) {
+	verifier := agent.NewVerificationAgent(caller, cfg.ProjectRoot)
+	var failed []string
+	for _, t := range tickets {
+		if len(t.AcceptanceCriteria) == 0 {
+			ui.PrintInfo(w, fmt.Sprintf(i18n.MsgVerifyNoCriteria, t.ID))
+			continue
+		}
+		usageBefore := caller.Usage()
+		v, err := verifier.Verify(ctx, t)
+		if err != nil {
+			return failed, err
+		}
+		if v == nil {
+			continue
+		}
+		t.Verification = v
+		recordTicketUsage(t, caller.Usage().Sub(usageBefore))
+		if err := store.Save(t); err != nil {
+			return failed, orcherrors.ErrSaveTicket(t.ID, err)
+		}
+		printVerification(w, t)
+		if !v.Passed() {
+			failed = append(failed, t.ID)
+		}
+	}
+	return failed, nil
+}
+
+// printVerification prints t's verification: how many criteria passed, then each
+// verdict with its evidence.
+func printVerification(w io.Writer, t *ticket.Ticket) {
+	v := t.Verification
+	line := fmt.Sprintf(i18n.MsgVerifyTicket, t.ID, t.Title, len(v.Criteria)-len(v.Failed()), len(v.Criteria))
+	if v.Passed() {
+		ui.PrintSuccess(w, line)
+	} else {
+		ui.PrintError(w, line)
+	}
+	printCriterionVerdicts(w, v)
+}
+
+// printCriterionVerdicts prints each verdict of v with its evidence.
+func printCriterionVerdicts(w io.Writer, v *ticket.Verification) {
+	for _, c := range v.Criteria {
+		if c.Passed {
+			ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgVerifyCriterion, c.Criterion))
+		} else {
+			ui.PrintError(w, fmt.Sprintf(i18n.MsgVerifyCriterion, c.Criterion))
+		}
+		if c.Evidence != "" {
+			ui.PrintInfo(w, fmt.Sprintf(i18n.MsgVerifyEvidence, c.Evidence))
+		}
+	}
+}
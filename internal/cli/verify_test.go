@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestRunVerify(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	tmpDir := t.TempDir()
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	script := "#!/bin/sh\nmkdir -p .tickets\ncat > .tickets/verify-T-1.json <<'EOF'\n" +
+		`{"summary": "logout missing", "criteria": [{"criterion": "login works", "passed": true, "evidence": "auth_test.go"},` +
+		` {"criterion": "logout works", "passed": false, "evidence": "no endpoint"}]}` + "\nEOF\n"
+	agentPath := filepath.Join(t.TempDir(), "fake-agent")
+	if err := os.WriteFile(agentPath, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg = createTestConfig(tmpDir)
+	cfg.AgentCommand = agentPath
+	cfg.DryRun = false
+
+	store := ticket.NewStore(cfg.TicketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+	for _, tk := range []*ticket.Ticket{
+		{ID: "T-1", Title: "Auth", Status: ticket.StatusCompleted, AcceptanceCriteria: []string{"login works", "logout works"}},
+		{ID: "T-2", Title: "Open", Status: ticket.StatusPending, AcceptanceCriteria: []string{"x"}},
+	} {
+		if err := store.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := runVerify(verifyCmd, []string{"T-2"}); err == nil || err.Error() != fmt.Sprintf(i18n.ErrVerifyNotComplete, "T-2", ticket.StatusPending) {
+		t.Errorf("runVerify(pending) error = %v", err)
+	}
+
+	var err error
+	output := captureOutput(func() {
+		err = runVerify(verifyCmd, nil)
+	})
+	if err == nil || err.Error() != fmt.Sprintf(i18n.ErrVerifyFailed, 1, "T-1") {
+		t.Fatalf("runVerify() error = %v, want the failed criterion reported\n%s", err, output)
+	}
+	for _, want := range []string{fmt.Sprintf(i18n.MsgVerifyTicket, "T-1", "Auth", 1, 2), "no endpoint"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output should contain %q, got:\n%s", want, output)
+		}
+	}
+	tk, err := store.Load("T-1")
+	if err != nil || tk.Verification == nil || tk.Verification.Passed() || len(tk.Verification.Failed()) != 1 {
+		t.Fatalf("T-1 verification = %+v, %v", tk.Verification, err)
+	}
+
+	// Once verified, a ticket is not verified again without naming it.
+	output = captureOutput(func() {
+		err = runVerify(verifyCmd, nil)
+	})
+	if err != nil || !strings.Contains(output, i18n.MsgVerifyNothing) {
+		t.Errorf("runVerify() after verification = %v\n%s", err, output)
+	}
+}
@@ -2,30 +2,89 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
 
 	"github.com/anthropic/agent-orchestrator/internal/agent"
+	"github.com/anthropic/agent-orchestrator/internal/feedback"
 	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/logging"
+	"github.com/anthropic/agent-orchestrator/internal/metrics"
+	"github.com/anthropic/agent-orchestrator/internal/redact"
 	"github.com/anthropic/agent-orchestrator/internal/ticket"
 	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/anthropic/agent-orchestrator/internal/workqueue"
 	"github.com/spf13/cobra"
 )
 
 var (
-	workParallel  int
-	workDetach    bool
-	workLogFile   string
-	workLogWriter io.Writer // set when running as detach-child; used for log file output
+	workParallel   int
+	workDetach     bool
+	workLogFile    string
+	workLabels     []string
+	workMilestones []string
+	workFilter     string
+	workResumePR   string
+	workQueue      bool
+	workMaxCost    float64
+	workReclaim    bool
+	workLogWriter  io.Writer // set when running as detach-child; used for log file output
 )
 
+// workOptions are the settings of one work run: which tickets it processes and how.
+// runWork takes them from the flags, drainWorkQueue from each queued request.
+type workOptions struct {
+	labels     []string
+	milestones []string
+	filter     *ticket.Filter
+	parallel   int
+	lenient    bool
+	maxCost    float64
+	// branchBase is the commit new ticket branches are created from (ticketBranchBase).
+	branchBase string
+}
+
+// newWorkOptions returns the options of a run for req, parsing its filter expression;
+// a Parallel of 0 means max_parallel.
+func newWorkOptions(req workqueue.Request) (workOptions, error) {
+	filter, err := ticket.ParseFilter(req.Filter)
+	if err != nil {
+		return workOptions{}, err
+	}
+	parallel := cfg.MaxParallel
+	if req.Parallel > 0 {
+		parallel = req.Parallel
+	}
+	return workOptions{
+		labels:     req.Labels,
+		milestones: req.Milestones,
+		filter:     filter,
+		parallel:   parallel,
+		lenient:    req.Lenient,
+		maxCost:    req.MaxCost,
+	}, nil
+}
+
+// workFlagsRequest returns the work invocation the flags describe, for args.
+func workFlagsRequest(args []string) workqueue.Request {
+	req := workqueue.Request{Labels: workLabels, Milestones: workMilestones, Filter: workFilter, ResumeFromPR: workResumePR, Parallel: workParallel, Lenient: workLenient, MaxCost: workMaxCost}
+	if len(args) > 0 {
+		req.TicketID = args[0]
+	}
+	return req
+}
+
 var workCmd = &cobra.Command{
 	Use:   "work [ticket-id]",
 	Short: i18n.CmdWorkShort,
@@ -37,6 +96,14 @@ func init() {
 	workCmd.Flags().IntVarP(&workParallel, "parallel", "p", 0, i18n.FlagParallel)
 	workCmd.Flags().BoolVar(&workDetach, "detach", false, i18n.FlagDetach)
 	workCmd.Flags().StringVar(&workLogFile, "log-file", "", i18n.FlagLogFile)
+	workCmd.Flags().StringSliceVar(&workLabels, "label", nil, i18n.FlagWorkLabel)
+	workCmd.Flags().StringSliceVar(&workMilestones, "milestone", nil, i18n.FlagWorkMilestone)
+	workCmd.Flags().StringVar(&workFilter, "filter", "", i18n.FlagWorkFilter)
+	workCmd.Flags().StringVar(&workResumePR, "resume-from-pr", "", i18n.FlagWorkResumeFromPR)
+	workCmd.Flags().BoolVar(&workQueue, "queue", false, i18n.FlagWorkQueue)
+	workCmd.Flags().BoolVar(&workLenient, "lenient", false, i18n.FlagWorkLenient)
+	workCmd.Flags().Float64Var(&workMaxCost, "max-cost", 0, i18n.FlagWorkMaxCost)
+	workCmd.Flags().BoolVar(&workReclaim, "reclaim", false, i18n.FlagWorkReclaim)
 }
 
 // WorkDetachParams holds the prepared argv for exec of work in detach (child) mode.
@@ -49,7 +116,7 @@ type WorkDetachParams struct {
 }
 
 // buildWorkDetachParams builds the binary path and args for the detach child process.
-// Pass through --config and --log-file so the child loads the same config and writes logs to the given path.
+// Pass through --config, --profile, --log-file and the log level flags so the child loads the same config and writes logs to the given path.
 func buildWorkDetachParams(args []string) (WorkDetachParams, error) {
 	binary, err := os.Executable()
 	if err != nil {
@@ -60,9 +127,43 @@ func buildWorkDetachParams(args []string) (WorkDetachParams, error) {
 		childArgs = append(childArgs, args[0])
 	}
 	childArgs = append(childArgs, detachChildFlagName)
+	if len(workLabels) > 0 {
+		childArgs = append(childArgs, "--label", strings.Join(workLabels, ","))
+	}
+	if len(workMilestones) > 0 {
+		childArgs = append(childArgs, "--milestone", strings.Join(workMilestones, ","))
+	}
+	if workFilter != "" {
+		childArgs = append(childArgs, "--filter", workFilter)
+	}
+	if workResumePR != "" {
+		childArgs = append(childArgs, "--resume-from-pr", workResumePR)
+	}
+	if workLenient {
+		childArgs = append(childArgs, "--lenient")
+	}
+	if workMaxCost > 0 {
+		childArgs = append(childArgs, "--max-cost", strconv.FormatFloat(workMaxCost, 'f', -1, 64))
+	}
+	if noLock {
+		childArgs = append(childArgs, "--no-lock")
+	}
 	if cfgFile != "" {
 		childArgs = append(childArgs, "--config", cfgFile)
 	}
+	if cfg != nil && cfg.Profile != "" {
+		childArgs = append(childArgs, "--profile", cfg.Profile)
+	}
+	// The child logs at the level of the parent's flags.
+	if verbose {
+		childArgs = append(childArgs, "--verbose")
+	}
+	if debug {
+		childArgs = append(childArgs, "--debug")
+	}
+	if quiet {
+		childArgs = append(childArgs, "--quiet")
+	}
 	var logPath string
 	if cfg != nil {
 		logPath = cfg.DetachLogPath(workLogFile, time.Now())
@@ -85,9 +186,20 @@ func WorkLogWriter() io.Writer {
 // so that closing the terminal does not kill it.
 // Returns the child PID on success; caller should print it and exit 0 without waiting.
 func execWorkDetach(params WorkDetachParams) (pid int, err error) {
+	cmd, err := startWorkDetach(params)
+	if err != nil {
+		return 0, err
+	}
+	// Do not Wait: parent returns so the user gets the prompt back; child runs in background.
+	return cmd.Process.Pid, nil
+}
+
+// startWorkDetach starts the detached child process and returns it. Long-running
+// callers (serve) Wait for it so the exited child is reaped.
+func startWorkDetach(params WorkDetachParams) (*exec.Cmd, error) {
 	if cfg != nil {
 		if err := cfg.EnsureDirs(); err != nil {
-			return 0, fmt.Errorf("work --detach: ensure dirs: %w", err)
+			return nil, fmt.Errorf("work --detach: ensure dirs: %w", err)
 		}
 	}
 	cmd := exec.Command(params.Binary, params.Args...)
@@ -96,20 +208,43 @@ func execWorkDetach(params WorkDetachParams) (pid int, err error) {
 	cmd.Stderr = nil
 	setDetachSysProcAttr(cmd)
 	if err := cmd.Start(); err != nil {
-		return 0, fmt.Errorf("work --detach: start child: %w", err)
+		return nil, fmt.Errorf("work --detach: start child: %w", err)
 	}
-	// Do not Wait: parent returns so the user gets the prompt back; child runs in background.
-	return cmd.Process.Pid, nil
+	return cmd, nil
 }
 
 func runWork(cmd *cobra.Command, args []string) error {
-	// Refuse to run (or spawn another detach) if background work is already running (TICKET-018).
+	if workResumePR != "" && len(args) > 0 {
+		return errors.New(i18n.ErrWorkResumePRWithID)
+	}
+	// --milestone takes a path as plan does; tickets record it relative to the project.
+	// The detach child receives it converted already.
+	if !IsDetachChild() {
+		workMilestones = milestoneRefs(workMilestones)
+	}
+	opts, err := newWorkOptions(workFlagsRequest(args))
+	if err != nil {
+		return err
+	}
+	// Refuse to run (or spawn another detach) if background work is already running (TICKET-018),
+	// unless --queue asks to hand the request to the running worker instead.
 	if !IsDetachChild() {
 		if err := ErrIfBackgroundWorkRunning(); err != nil {
-			return err
+			if !workQueue {
+				return err
+			}
+			queued, err := queueWorkRequest(os.Stdout, args)
+			if err != nil || queued {
+				return err
+			}
+			// The worker exited in the meantime: run the request now.
 		}
 	}
 
+	if workReclaim {
+		return runWorkReclaim(os.Stdout)
+	}
+
 	// --detach (parent): prepare child argv and exec; print PID and log path then exit 0 (TICKET-009).
 	if workDetach && !IsDetachChild() {
 		params, err := buildWorkDetachParams(args)
@@ -130,10 +265,10 @@ func runWork(cmd *cobra.Command, args []string) error {
 
 	// detach-child: create log file (path from config + --log-file override), redirect stdout/stderr to it.
 	// All errors and summary go to the log writer; close log file on process exit (defer or normal path).
-	var pidPath string
+	var pidPath, logPath string
+	startedAt := time.Now()
 	if IsDetachChild() {
 		// Resolve log path with fallback so we can open log before any check that might fail.
-		var logPath string
 		if cfg != nil {
 			logPath = cfg.DetachLogPath(workLogFile, time.Now())
 		} else if workLogFile != "" {
@@ -148,7 +283,7 @@ func runWork(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("work detach-child: open log file: %w", err)
 		}
-		workLogWriter = f
+		workLogWriter = ui.NewSyncWriter(redact.NewWriter(f))
 		os.Stdout = f
 		os.Stderr = f
 		defer f.Close() // close log file on any return path
@@ -157,6 +292,13 @@ func runWork(cmd *cobra.Command, args []string) error {
 			f.Close()
 			os.Exit(1)
 		}
+		// The log is JSON records: the Print helpers log instead of printing.
+		defaultLogger := slog.Default()
+		ui.SetLogger(setupLogging(workLogWriter))
+		defer func() {
+			ui.SetLogger(nil)
+			slog.SetDefault(defaultLogger)
+		}()
 		// Write PID file before entering work logic (TICKET-013).
 		pidPath = cfg.WorkPIDFilePath()
 		if err := WriteWorkPIDFile(pidPath); err != nil {
@@ -182,27 +324,148 @@ func runWork(cmd *cobra.Command, args []string) error {
 		cancel()
 	}()
 
-	// Determine parallel count
-	parallel := cfg.MaxParallel
-	if workParallel > 0 {
-		parallel = workParallel
-	}
-
 	// Initialize store
-	store := ticket.NewStore(cfg.TicketsDir)
+	store := newTicketStore()
 	if err := store.Init(); err != nil {
 		return fmt.Errorf(i18n.ErrInitStoreFailed, err)
 	}
+	checkOrphanAgents(os.Stdout)
+	// Tickets a killed worker left in progress would never be picked up again.
+	if _, err := reclaimOrphanedTickets(os.Stdout, store); err != nil {
+		ui.PrintWarning(os.Stdout, err.Error())
+	}
+	if IsDetachChild() {
+		store = enableTelemetry(store)
+		if cfg.MetricsAddr != "" {
+			serveMetrics(os.Stdout, cfg.MetricsAddr)
+		}
+	}
 
-	// If specific ticket ID provided
-	if len(args) > 0 {
-		return workSingleTicket(ctx, store, args[0])
+	opts.branchBase = ticketBranchBase(ctx)
+	var workErr error
+	switch {
+	case workResumePR != "":
+		workErr = workFromPR(ctx, store, workResumePR, opts)
+	case len(args) > 0:
+		// Specific ticket ID provided
+		workErr = workSingleTicket(ctx, store, args[0], opts)
+	default:
+		workErr = workAllTickets(ctx, store, opts)
 	}
 
-	return workAllTickets(ctx, store, parallel)
+	// The background worker then runs requests queued with work --queue meanwhile.
+	if IsDetachChild() && workErr == nil {
+		workErr = drainWorkQueue(ctx, store, opts.branchBase)
+	}
+	if IsDetachChild() {
+		// Not ctx: a stopped worker still reports what it got done.
+		notifyWorkFinished(context.Background(), os.Stdout, store, startedAt, logPath)
+	}
+	return workErr
 }
 
-func workSingleTicket(ctx context.Context, store *ticket.Store, ticketID string) error {
+// queueWorkRequest records this work invocation for the running background worker.
+// If the worker has exited by the time the request is recorded, the request is taken
+// back and queued is false so the caller runs it directly.
+func queueWorkRequest(w io.Writer, args []string) (queued bool, err error) {
+	req, queued, err := pushWorkRequest(workFlagsRequest(args))
+	if err != nil || !queued {
+		return queued, err
+	}
+	ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgWorkQueued, req.ID, describeWorkRequest(req)))
+	ui.PrintInfo(w, ui.StyleMuted.Render(i18n.HintWorkQueueStatus))
+	return true, nil
+}
+
+// pushWorkRequest adds req to the work queue for the running background worker and
+// returns it with its queue ID. If the worker has exited by then, the request is taken
+// back and queued is false.
+func pushWorkRequest(req workqueue.Request) (workqueue.Request, bool, error) {
+	q := workqueue.New(cfg.WorkQueuePath())
+	req, err := q.Push(req)
+	if err != nil {
+		return req, false, err
+	}
+	if ErrIfBackgroundWorkRunning() == nil {
+		if removed, err := q.Remove(req.ID); err != nil || removed {
+			return req, false, err
+		}
+		// Already taken by the worker before it exited.
+	}
+	return req, true, nil
+}
+
+// drainWorkQueue runs queued work requests in order until the queue is empty or ctx
+// is cancelled. A failing request is reported and the next one still runs. Their ticket
+// branches fork from branchBase, the worker's own.
+func drainWorkQueue(ctx context.Context, store ticket.Storer, branchBase string) error {
+	w := os.Stdout
+	q := workqueue.New(cfg.WorkQueuePath())
+	for ctx.Err() == nil {
+		req, err := q.Pop()
+		if err != nil || req == nil {
+			return err
+		}
+		ui.PrintInfo(w, "")
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgWorkQueueStarting, req.ID, describeWorkRequest(*req)))
+		opts, err := newWorkOptions(*req)
+		if err != nil {
+			ui.PrintError(w, fmt.Sprintf(i18n.ErrWorkQueuedFailed, req.ID, err))
+			continue
+		}
+		opts.branchBase = branchBase
+		switch {
+		case req.ResumeFromPR != "":
+			err = workFromPR(ctx, store, req.ResumeFromPR, opts)
+		case req.TicketID != "":
+			err = workSingleTicket(ctx, store, req.TicketID, opts)
+		default:
+			err = workAllTickets(ctx, store, opts)
+		}
+		if err != nil {
+			ui.PrintError(w, fmt.Sprintf(i18n.ErrWorkQueuedFailed, req.ID, err))
+		}
+	}
+	return nil
+}
+
+// filterWorkTickets returns the tickets a run with opts processes: those carrying every
+// one of its labels, planned from one of its milestones and matching its filter.
+func filterWorkTickets(tickets []*ticket.Ticket, opts workOptions) []*ticket.Ticket {
+	return ticket.FilterByExpr(ticket.FilterByMilestones(ticket.FilterByLabels(tickets, opts.labels), opts.milestones), opts.filter)
+}
+
+// describeWorkRequest renders a queued request as the work command it stands for.
+func describeWorkRequest(r workqueue.Request) string {
+	parts := []string{"work"}
+	if r.TicketID != "" {
+		parts = append(parts, r.TicketID)
+	}
+	if r.ResumeFromPR != "" {
+		parts = append(parts, "--resume-from-pr", r.ResumeFromPR)
+	}
+	if len(r.Labels) > 0 {
+		parts = append(parts, "--label", strings.Join(r.Labels, ","))
+	}
+	if len(r.Milestones) > 0 {
+		parts = append(parts, "--milestone", strings.Join(r.Milestones, ","))
+	}
+	if r.Filter != "" {
+		parts = append(parts, "--filter", strconv.Quote(r.Filter))
+	}
+	if r.Parallel > 0 {
+		parts = append(parts, "--parallel", fmt.Sprint(r.Parallel))
+	}
+	if r.Lenient {
+		parts = append(parts, "--lenient")
+	}
+	if r.MaxCost > 0 {
+		parts = append(parts, "--max-cost", fmt.Sprintf("%.2f", r.MaxCost))
+	}
+	return strings.Join(parts, " ")
+}
+
+func workSingleTicket(ctx context.Context, store ticket.Storer, ticketID string, opts workOptions) error {
 	t, err := store.Load(ticketID)
 	if err != nil {
 		ui.PrintError(os.Stdout, fmt.Sprintf(i18n.ErrTicketNotFound, ticketID))
@@ -214,28 +477,80 @@ func workSingleTicket(ctx context.Context, store *ticket.Store, ticketID string)
 		return nil
 	}
 
+	if t.IsEpic() {
+		ui.PrintWarning(os.Stdout, fmt.Sprintf(i18n.MsgEpicCannotProcess, ticketID))
+		return nil
+	}
+
 	ui.PrintHeader(os.Stdout, i18n.UIProcessTicket)
 	ui.PrintInfo(os.Stdout, fmt.Sprintf(i18n.MsgTicketInfo, t.ID))
 	ui.PrintInfo(os.Stdout, fmt.Sprintf(i18n.MsgTicketTitle, t.Title))
 
-	return processTicket(ctx, store, t)
+	return trackTicket("work", t, func() error { return processTicket(ctx, store, t, opts) })
 }
 
-func workAllTickets(ctx context.Context, store *ticket.Store, parallel int) error {
+// workFromPR reopens the tickets linked to a pull request (e.g. to address review
+// feedback on it) and processes them one by one.
+func workFromPR(ctx context.Context, store ticket.Storer, ref string, opts workOptions) error {
 	w := os.Stdout
+	tickets, err := findTicketsByPR(store, ref)
+	if err != nil {
+		return err
+	}
+	for _, t := range tickets {
+		if t.Status == ticket.StatusCompleted || t.Status == ticket.StatusFailed {
+			if err := store.MoveToStatus(t.ID, ticket.StatusPending); err != nil {
+				return err
+			}
+			ui.PrintInfo(w, fmt.Sprintf(i18n.MsgWorkResumeFromPR, t.ID, t.PullRequest))
+		}
+		if err := workSingleTicket(ctx, store, t.ID, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func workAllTickets(ctx context.Context, store ticket.Storer, opts workOptions) error {
+	w := os.Stdout
+	parallel := opts.parallel
+	startedAt := time.Now()
 
 	ui.PrintHeader(w, i18n.UIProcessTickets)
+	// Ticket branches share one working tree, so tickets are processed one at a time.
+	if cfg.GitBranchPerTicket && !cfg.DryRun && parallel > 1 {
+		ui.PrintWarning(w, fmt.Sprintf(i18n.MsgBranchPerTicketSequential, parallel))
+		parallel = 1
+	}
 	ui.PrintInfo(w, fmt.Sprintf(i18n.MsgMaxParallel, parallel))
 
 	resolver := ticket.NewDependencyResolver(store)
 
+	maxCost := workCostCeiling(opts.maxCost)
 	results := struct {
 		completed int
-		failed    int
+		failed    []string
 		skipped   int
-		mu        sync.Mutex
-	}{}
+		tokens    int
+		cost      float64
+		// overBudget is set once cost reaches maxCost, aborted once streak trips; no
+		// further tickets start after either.
+		overBudget bool
+		aborted    bool
+		streak     *agent.FailureStreak
+		mu         sync.Mutex
+	}{streak: newFailureStreak()}
+	// startTicket reports whether another ticket may start under the cost ceiling.
+	startTicket := func() bool {
+		results.mu.Lock()
+		defer results.mu.Unlock()
+		if maxCost > 0 && results.cost >= maxCost {
+			results.overBudget = true
+		}
+		return !results.overBudget && !results.aborted
+	}
 
+	probed := false
 	maxIterations := 20
 	for iteration := 0; iteration < maxIterations; iteration++ {
 		// Check for cancellation
@@ -245,25 +560,63 @@ func workAllTickets(ctx context.Context, store *ticket.Store, parallel int) erro
 			goto done
 		default:
 		}
+		if !startTicket() {
+			break
+		}
+
+		// Epics whose children have all completed are completed without an agent call
+		epics, err := resolver.CompleteEpics()
+		if err != nil {
+			return err
+		}
+		for _, e := range epics {
+			ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgEpicCompleted, e.ID))
+		}
 
-		// Get processable tickets
+		// Get processable tickets (only those matching --label and --milestone when given)
 		processable, err := resolver.GetProcessable()
 		if err != nil {
 			return err
 		}
+		processable = filterWorkTickets(processable, opts)
+		// Tickets whose soft dependencies run in this batch wait for the next iteration
+		processable, deferred := resolver.DeferSoftDependents(processable)
+		if len(deferred) > 0 {
+			ui.PrintInfo(w, fmt.Sprintf(i18n.MsgSoftDeferred, len(deferred)))
+		}
+		// Bottlenecks many pending tickets wait on start first
+		escalated, err := resolver.Prioritize(processable, escalationRule())
+		if err != nil {
+			return err
+		}
+		printEscalations(w, escalated)
 
 		if len(processable) == 0 {
 			// Check if there are still pending tickets (blocked by dependencies)
 			pending, _ := store.LoadByStatus(ticket.StatusPending)
+			pending = filterWorkTickets(pending, opts)
 			if len(pending) > 0 {
 				ui.PrintWarning(w, fmt.Sprintf(i18n.MsgPendingBlocked, len(pending)))
 				results.skipped = len(pending)
+				// Tickets in a dependency cycle never become processable: name the cycles.
+				if cycles, open := blockingCycles(store); len(cycles) > 0 {
+					printCycles(w, resolver, open, cycles)
+				}
 			}
 			break
 		}
 
 		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgIteration, iteration+1, len(processable)))
 
+		// Before the first parallel batch one cheap call checks the agent, so a
+		// systemic problem stops the run before every ticket fails with it.
+		if !probed && cfg.AgentProbe && !cfg.DryRun && parallel > 1 && len(processable) > 1 {
+			probed = true
+			if err := probeAgent(ctx, w); err != nil {
+				return err
+			}
+		}
+
 		var wg sync.WaitGroup
 		semaphore := make(chan struct{}, parallel)
 
@@ -282,21 +635,40 @@ func workAllTickets(ctx context.Context, store *ticket.Store, parallel int) erro
 					semaphore <- struct{}{}
 					defer func() { <-semaphore }()
 
-					err := processTicket(ctx, store, t)
+					if err := waitForBudget(ctx, func(d time.Duration) {
+						ui.PrintWarning(w, fmt.Sprintf(i18n.MsgBudgetPaused, t.ID, d.Round(time.Second)))
+					}); err != nil {
+						return
+					}
+					if !startTicket() {
+						ui.PrintWarning(w, fmt.Sprintf(i18n.MsgTicketSkipped, t.ID))
+						return
+					}
+
+					tokens, cost := t.TokensUsed, t.CostUSD
+					err := trackTicket("work", t, func() error { return processTicket(ctx, store, t, opts) })
 
 					results.mu.Lock()
+					results.tokens += t.TokensUsed - tokens
+					results.cost += t.CostUSD - cost
 					if err != nil {
-						results.failed++
+						results.failed = append(results.failed, t.ID)
+						if !results.aborted && results.streak.Failure(err.Error()) {
+							results.aborted = true
+						}
 					} else {
 						results.completed++
+						results.streak.Success()
 					}
 					results.mu.Unlock()
 				}(t)
 			}
 			wg.Wait()
 		} else {
-			// Interactive: use MultiSpinner for this batch
+			// Interactive: use MultiSpinner for this batch; other output is grouped per
+			// ticket and printed once the spinner stops
 			multiSpinner := ui.NewMultiSpinner(w)
+			sections := ui.NewSectionBuffer(w)
 			for _, t := range processable {
 				multiSpinner.AddTask(t.ID, fmt.Sprintf(i18n.SpinnerProcessing, t.ID, t.Title))
 			}
@@ -315,155 +687,403 @@ func workAllTickets(ctx context.Context, store *ticket.Store, parallel int) erro
 					semaphore <- struct{}{}
 					defer func() { <-semaphore }()
 
-					err := processTicketWithMultiSpinner(ctx, store, t, multiSpinner)
+					if err := waitForBudget(ctx, func(d time.Duration) {
+						multiSpinner.UpdateTask(t.ID, fmt.Sprintf(i18n.MsgBudgetPaused, t.ID, d.Round(time.Second)))
+					}); err != nil {
+						return
+					}
+					if !startTicket() {
+						multiSpinner.FailTask(t.ID, fmt.Sprintf(i18n.MsgTicketSkipped, t.ID))
+						return
+					}
+
+					tokens, cost := t.TokensUsed, t.CostUSD
+					err := trackTicket("work", t, func() error {
+						return processTicketWithMultiSpinner(ctx, store, t, multiSpinner, sections.Writer(t.ID), opts)
+					})
 
 					results.mu.Lock()
+					results.tokens += t.TokensUsed - tokens
+					results.cost += t.CostUSD - cost
 					if err != nil {
-						results.failed++
+						results.failed = append(results.failed, t.ID)
+						if !results.aborted && results.streak.Failure(err.Error()) {
+							results.aborted = true
+						}
 					} else {
 						results.completed++
+						results.streak.Success()
 					}
 					results.mu.Unlock()
 				}(t)
 			}
 			wg.Wait()
 			multiSpinner.Stop()
+			sections.Flush()
 		}
 	}
 
 done:
+	if results.overBudget || results.aborted {
+		// Tickets not started stay pending and count as skipped
+		pending, _ := store.LoadByStatus(ticket.StatusPending)
+		results.skipped = len(filterWorkTickets(pending, opts))
+	}
+	emitRunSummary("work", results.completed, len(results.failed), results.skipped, results.tokens, results.cost, startedAt)
+
 	// Print summary
 	ui.PrintInfo(w, "")
 	ui.PrintHeader(w, i18n.UIProcessComplete)
 	ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgCountCompleted, results.completed))
-	if results.failed > 0 {
-		ui.PrintError(w, fmt.Sprintf(i18n.MsgCountFailed, results.failed))
+	if len(results.failed) > 0 {
+		ui.PrintError(w, fmt.Sprintf(i18n.MsgCountFailed, len(results.failed)))
+		for _, id := range results.failed {
+			ui.PrintInfo(w, ui.StyleMuted.Render(fmt.Sprintf(i18n.MsgRetryHint, retryCommand(id))))
+		}
 	}
 	if results.skipped > 0 {
 		ui.PrintWarning(w, fmt.Sprintf(i18n.MsgCountSkipped, results.skipped))
 	}
+	if results.tokens > 0 || results.cost > 0 {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgRunUsageTotal, formatUsage(results.tokens, results.cost)))
+	}
+	if results.overBudget {
+		ui.PrintWarning(w, fmt.Sprintf(i18n.MsgCostCeilingReached, maxCost, results.cost, results.skipped))
+	}
+	if results.aborted {
+		return reportSystemicAbort(ctx, w, "work", results.streak)
+	}
 
 	return nil
 }
 
-func processTicket(ctx context.Context, store *ticket.Store, t *ticket.Ticket) error {
+// workCostCeiling returns the cost limit of a work run in USD: maxCost (--max-cost)
+// when set, else budget_usd; 0 means no limit.
+func workCostCeiling(maxCost float64) float64 {
+	if maxCost > 0 {
+		return maxCost
+	}
+	return cfg.BudgetUSD
+}
+
+// newCodingAgent creates a CodingAgent working in dir (the project root or a ticket's
+// worktree) configured with the current config (prompt budget, timeouts, recurring
+// review findings as conventions).
+func newCodingAgent(caller *agent.Caller, dir string) *agent.CodingAgent {
+	codingAgent := agent.NewCodingAgent(caller, dir)
+	codingAgent.SetPromptBudget(cfg.PromptBudgetChars)
+	codingAgent.SetTimeouts(cfg.TicketTimeoutDurations(), time.Duration(cfg.AgentTimeout)*time.Second)
+	codingAgent.SetConventions(feedback.Conventions(cfg.ReviewFindingsPath(), cfg.ReviewConventionsTop))
+	return codingAgent
+}
+
+// requeueIfInterrupted returns t to pending when ctx was cancelled (Ctrl+C or work stop),
+// so an interrupted run is retried later instead of counting as a failure.
+// Reports whether the ticket was requeued.
+func requeueIfInterrupted(ctx context.Context, store ticket.Storer, t *ticket.Ticket) bool {
+	if ctx.Err() == nil {
+		return false
+	}
+	t.SetStatus(ticket.StatusPending, ticket.ReasonInterrupted)
+	_ = store.Save(t)
+	return true
+}
+
+// salvagePartialOutput keeps what a timed-out agent call produced on the ticket, so the
+// next attempt's prompt can continue from it instead of starting over. With stream-json
+// that is the assistant's text (Result.Text), not the raw events.
+func salvagePartialOutput(t *ticket.Ticket, result *agent.Result) {
+	if result == nil || !result.TimedOut {
+		return
+	}
+	if text := result.Text(); strings.TrimSpace(text) != "" {
+		t.SetPartialOutput(redact.String(text))
+	}
+}
+
+// recordTicketRun appends the outcome of a finished ticket run to the metrics history.
+// History is best-effort: a write failure must not fail the ticket.
+func recordTicketRun(t *ticket.Ticket, startedAt time.Time) {
+	outcome := metrics.OutcomeCompleted
+	if t.Status == ticket.StatusFailed {
+		outcome = metrics.OutcomeFailed
+	}
+	_ = metrics.Append(cfg.MetricsHistoryPath(), metrics.Record{
+		TicketID:   t.ID,
+		Type:       string(t.Type),
+		Complexity: t.EstimatedComplexity,
+		Outcome:    outcome,
+		StartedAt:  startedAt,
+		Duration:   time.Since(startedAt),
+		Operator:   auditOperator(),
+	})
+	observeTicketRun(t)
+	notifyRepeatedFailure(t)
+}
+
+func processTicket(ctx context.Context, store ticket.Storer, t *ticket.Ticket, opts workOptions) error {
 	w := os.Stdout
-	logW := WorkLogWriter()
-	useLogOnly := IsDetachChild() && logW != nil
+	log := ticketLogger(t)
+	// A detach child logs instead of drawing a spinner; plain output (dry runs, the
+	// definition of done) becomes records of the ticket too, so parallel workers
+	// sharing the log stay apart by ticket_id.
+	useLogOnly := IsDetachChild() && WorkLogWriter() != nil
+	var logW *logging.Writer
+	if useLogOnly {
+		logW = logging.NewWriter(log, slog.LevelInfo)
+		defer logW.Flush()
+	}
+	// On a terminal the spinner shows failures; they are errors only where the log is the output.
+	failLevel := slog.LevelInfo
+	if useLogOnly {
+		failLevel = slog.LevelError
+	}
+	log.Info(fmt.Sprintf(i18n.SpinnerProcessing, t.ID, t.Title), logging.Step(logging.StepStart))
 
 	// Mark as in progress
-	t.MarkInProgress()
+	claimTicket(t)
 	if err := store.Save(t); err != nil {
 		return err
 	}
 
+	if err := checkoutTicketBranch(ctx, t, opts.branchBase); err != nil {
+		if !useLogOnly {
+			ui.PrintError(w, fmt.Sprintf(i18n.ErrTicketBranch, t.ID, err))
+		}
+		log.Log(ctx, failLevel, fmt.Sprintf(i18n.ErrTicketBranch, t.ID, err), logging.Step(logging.StepFail))
+		t.MarkFailed(fmt.Errorf(i18n.ErrTicketBranch, t.ID, err))
+		store.Save(t)
+		return fmt.Errorf("ticket %s failed: %w", t.ID, err)
+	}
+
 	// Create coding agent
 	caller, err := CreateAgentCaller()
 	if err != nil {
-		if useLogOnly {
-			ui.WriteLogProgress(logW, i18n.SpinnerFailTicket, t.ID)
-		} else {
+		if !useLogOnly {
 			ui.PrintError(w, i18n.ErrAgentCommand)
 		}
+		log.Log(ctx, failLevel, i18n.ErrAgentCommand, logging.Step(logging.StepFail), "error", err)
 		t.MarkFailed(fmt.Errorf("agent command not found"))
 		store.Save(t)
-		return fmt.Errorf("agent not available")
+		return fmt.Errorf("%w: %v", agent.ErrUnavailable, err)
+	}
+	caller.SetLogger(log)
+	if useLogOnly {
+		caller.SetWriter(logW)
 	}
 
-	codingAgent := agent.NewCodingAgent(caller, cfg.ProjectRoot)
+	ws, err := openTicketWorkspace(ctx, t)
+	if err != nil {
+		if !useLogOnly {
+			ui.PrintError(w, err.Error())
+		}
+		log.Log(ctx, failLevel, err.Error(), logging.Step(logging.StepFail))
+		t.MarkFailed(err)
+		store.Save(t)
+		return fmt.Errorf("ticket %s failed: %w", t.ID, err)
+	}
+	defer ws.Close()
+
+	codingAgent := newCodingAgent(caller, ws.Dir)
 
-	// Execute: detach-child uses plain text to log; otherwise use TUI spinner
+	// Execute: detach-child only logs; otherwise use TUI spinner
 	var spinner *ui.Spinner
 	if !useLogOnly {
 		spinner = ui.NewSpinner(fmt.Sprintf(i18n.SpinnerProcessing, t.ID, t.Title), w)
 		spinner.Start()
-	} else {
-		ui.WriteLogProgress(logW, i18n.SpinnerProcessing, t.ID, t.Title)
 	}
 
+	baseline := dodBaseline(ctx, t, ws.Dir)
+	startedAt := time.Now()
 	result, err := codingAgent.Execute(ctx, t)
+	recordTicketUsage(t, caller.Usage())
+	adoptStoredNotes(store, t)
+	if pc := t.PromptCompression; pc != nil {
+		log.Info(fmt.Sprintf(i18n.MsgTicketPromptCompressed, t.ID, pc.SectionList(), pc.OriginalChars, pc.CompressedChars), logging.Step(logging.StepCoding))
+	}
 
+	if (err != nil || !result.Success) && requeueIfInterrupted(ctx, store, t) {
+		if !useLogOnly {
+			spinner.Info(fmt.Sprintf(i18n.MsgTicketRequeued, t.ID))
+		}
+		log.Info(fmt.Sprintf(i18n.MsgTicketRequeued, t.ID), logging.Step(logging.StepRequeue), logging.Since(startedAt))
+		return fmt.Errorf("ticket %s interrupted", t.ID)
+	}
 	if err != nil || !result.Success {
-		if useLogOnly {
-			ui.WriteLogProgress(logW, i18n.SpinnerFailTicket, t.ID)
-		} else {
+		if !useLogOnly {
 			spinner.Fail(fmt.Sprintf(i18n.SpinnerFailTicket, t.ID))
 		}
-		errMsg := "execution failed"
-		if err != nil {
-			errMsg = err.Error()
-		} else if result != nil && result.Error != "" {
-			errMsg = result.Error
-		}
+		errMsg := agentFailureMessage(err, result)
+		log.Log(ctx, failLevel, fmt.Sprintf(i18n.SpinnerFailTicket, t.ID), logging.Step(logging.StepCoding), logging.Since(startedAt), "error", errMsg)
 		t.MarkFailed(fmt.Errorf("%s", errMsg))
 		if result != nil && result.LogPath != "" {
 			t.ErrorLog = result.LogPath
 		}
+		salvagePartialOutput(t, result)
+		if t.PartialOutput != "" {
+			log.Info(fmt.Sprintf(i18n.MsgTicketPartialOutput, t.ID, len([]rune(t.PartialOutput))), logging.Step(logging.StepCoding))
+		}
 		store.Save(t)
+		recordTicketRun(t, startedAt)
+		syncRemote(ctx, t, remoteFailed)
 		return fmt.Errorf("ticket %s failed: %s", t.ID, errMsg)
 	}
 
-	if useLogOnly {
-		ui.WriteLogProgress(logW, i18n.MsgProcessingComplete, t.ID)
-	} else {
-		spinner.Success(fmt.Sprintf(i18n.MsgProcessingComplete, t.ID))
-	}
-
 	// Truncate output if too long
-	output := result.Output
+	output := redact.String(result.Output)
 	if len(output) > 1000 {
 		output = output[:1000] + "...(truncated)"
 	}
 
+	// Executable acceptance assertions and the definition of done decide completion;
+	// the changes then move from the ticket's worktree (if any) to the project
+	log.Info(fmt.Sprintf(i18n.MsgTicketCodingDone, t.ID), logging.Step(logging.StepCoding), logging.Since(startedAt))
+	var dodW io.Writer = w
+	if useLogOnly {
+		dodW = logW
+	}
+	step, stepStart := logging.StepVerify, time.Now()
+	err = runTicketAssertions(ctx, t, ws.Dir)
+	if err == nil {
+		err = checkDefinitionOfDone(ctx, dodW, t, ws.Dir, baseline, result, opts.lenient)
+	}
+	if err == nil {
+		step, stepStart = logging.StepMerge, time.Now()
+		err = ws.Merge(ctx)
+	}
+	if err != nil {
+		if !useLogOnly {
+			spinner.Fail(fmt.Sprintf(i18n.SpinnerFailTicket, t.ID))
+		}
+		log.Log(ctx, failLevel, fmt.Sprintf(i18n.SpinnerFailTicket, t.ID), logging.Step(step), logging.Since(stepStart), "error", err)
+		t.AgentOutput = output
+		t.MarkFailed(err)
+		store.Save(t)
+		recordTicketRun(t, startedAt)
+		syncRemote(ctx, t, remoteFailed)
+		return fmt.Errorf("ticket %s failed: %s", t.ID, err)
+	}
+
+	if !useLogOnly {
+		spinner.Success(fmt.Sprintf(i18n.MsgProcessingComplete, t.ID))
+	}
+	log.Info(fmt.Sprintf(i18n.MsgProcessingComplete, t.ID), logging.Step(logging.StepComplete), logging.Since(startedAt))
+
 	t.MarkCompleted(output)
-	return store.Save(t)
+	recordTicketRun(t, startedAt)
+	if err := store.Save(t); err != nil {
+		return err
+	}
+	syncRemote(ctx, t, remoteCompleted)
+	return nil
 }
 
-func processTicketWithMultiSpinner(ctx context.Context, store *ticket.Store, t *ticket.Ticket, multiSpinner *ui.MultiSpinner) error {
+// processTicketWithMultiSpinner processes t while multiSpinner owns the terminal; the
+// agent's own output goes to out (the ticket's section, printed after the batch).
+func processTicketWithMultiSpinner(ctx context.Context, store ticket.Storer, t *ticket.Ticket, multiSpinner *ui.MultiSpinner, out io.Writer, opts workOptions) error {
+	// Log records go to the ticket's section too: the terminal belongs to multiSpinner.
+	log := logging.Ticket(newLogger(out), t.ID)
+	log.Info(fmt.Sprintf(i18n.SpinnerProcessing, t.ID, t.Title), logging.Step(logging.StepStart))
+
 	// Mark as in progress
-	t.MarkInProgress()
+	claimTicket(t)
 	if err := store.Save(t); err != nil {
 		return err
 	}
 
+	if err := checkoutTicketBranch(ctx, t, opts.branchBase); err != nil {
+		multiSpinner.FailTask(t.ID, fmt.Sprintf(i18n.ErrTicketBranch, t.ID, err))
+		t.MarkFailed(fmt.Errorf(i18n.ErrTicketBranch, t.ID, err))
+		store.Save(t)
+		return fmt.Errorf("ticket %s failed: %w", t.ID, err)
+	}
+
 	// Create coding agent
 	caller, err := CreateAgentCaller()
 	if err != nil {
 		multiSpinner.FailTask(t.ID, fmt.Sprintf(i18n.SpinnerFailTicket, t.ID))
 		t.MarkFailed(fmt.Errorf("agent command not found"))
 		store.Save(t)
-		return fmt.Errorf("agent not available")
+		return fmt.Errorf("%w: %v", agent.ErrUnavailable, err)
+	}
+	caller.SetWriter(out)
+	caller.SetLogger(log)
+
+	ws, err := openTicketWorkspace(ctx, t)
+	if err != nil {
+		multiSpinner.FailTask(t.ID, err.Error())
+		t.MarkFailed(err)
+		store.Save(t)
+		return fmt.Errorf("ticket %s failed: %w", t.ID, err)
 	}
+	defer ws.Close()
 
-	codingAgent := agent.NewCodingAgent(caller, cfg.ProjectRoot)
+	codingAgent := newCodingAgent(caller, ws.Dir)
 
 	// Execute
+	baseline := dodBaseline(ctx, t, ws.Dir)
+	startedAt := time.Now()
 	result, err := codingAgent.Execute(ctx, t)
+	recordTicketUsage(t, caller.Usage())
+	adoptStoredNotes(store, t)
 
+	if (err != nil || !result.Success) && requeueIfInterrupted(ctx, store, t) {
+		multiSpinner.FailTask(t.ID, fmt.Sprintf(i18n.MsgTicketRequeued, t.ID))
+		log.Info(fmt.Sprintf(i18n.MsgTicketRequeued, t.ID), logging.Step(logging.StepRequeue), logging.Since(startedAt))
+		return fmt.Errorf("ticket %s interrupted", t.ID)
+	}
 	if err != nil || !result.Success {
 		multiSpinner.FailTask(t.ID, fmt.Sprintf(i18n.SpinnerFailTicket, t.ID))
-		errMsg := "execution failed"
-		if err != nil {
-			errMsg = err.Error()
-		} else if result != nil && result.Error != "" {
-			errMsg = result.Error
-		}
+		errMsg := agentFailureMessage(err, result)
+		log.Info(fmt.Sprintf(i18n.SpinnerFailTicket, t.ID), logging.Step(logging.StepCoding), logging.Since(startedAt), "error", errMsg)
 		t.MarkFailed(fmt.Errorf("%s", errMsg))
 		if result != nil && result.LogPath != "" {
 			t.ErrorLog = result.LogPath
 		}
+		salvagePartialOutput(t, result)
 		store.Save(t)
+		recordTicketRun(t, startedAt)
+		syncRemote(ctx, t, remoteFailed)
 		return fmt.Errorf("ticket %s failed: %s", t.ID, errMsg)
 	}
 
-	multiSpinner.CompleteTask(t.ID, fmt.Sprintf(i18n.MsgProcessingComplete, t.ID))
-
 	// Truncate output if too long
-	output := result.Output
+	output := redact.String(result.Output)
 	if len(output) > 1000 {
 		output = output[:1000] + "...(truncated)"
 	}
 
+	// Executable acceptance assertions and the definition of done decide completion;
+	// the changes then move from the ticket's worktree (if any) to the project
+	log.Info(fmt.Sprintf(i18n.MsgTicketCodingDone, t.ID), logging.Step(logging.StepCoding), logging.Since(startedAt))
+	step, stepStart := logging.StepVerify, time.Now()
+	err = runTicketAssertions(ctx, t, ws.Dir)
+	if err == nil {
+		err = checkDefinitionOfDone(ctx, out, t, ws.Dir, baseline, result, opts.lenient)
+	}
+	if err == nil {
+		step, stepStart = logging.StepMerge, time.Now()
+		err = ws.Merge(ctx)
+	}
+	if err != nil {
+		multiSpinner.FailTask(t.ID, fmt.Sprintf(i18n.SpinnerFailTicket, t.ID))
+		log.Info(fmt.Sprintf(i18n.SpinnerFailTicket, t.ID), logging.Step(step), logging.Since(stepStart), "error", err)
+		t.AgentOutput = output
+		t.MarkFailed(err)
+		store.Save(t)
+		recordTicketRun(t, startedAt)
+		syncRemote(ctx, t, remoteFailed)
+		return fmt.Errorf("ticket %s failed: %s", t.ID, err)
+	}
+
+	multiSpinner.CompleteTask(t.ID, fmt.Sprintf(i18n.MsgProcessingComplete, t.ID))
+	log.Info(fmt.Sprintf(i18n.MsgProcessingComplete, t.ID), logging.Step(logging.StepComplete), logging.Since(startedAt))
+
 	t.MarkCompleted(output)
-	return store.Save(t)
+	recordTicketRun(t, startedAt)
+	if err := store.Save(t); err != nil {
+		return err
+	}
+	syncRemote(ctx, t, remoteCompleted)
+	return nil
 }
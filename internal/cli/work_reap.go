@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/runstate"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var workReapYes bool
+
+// reapGracePeriod is how long a reaped agent gets to exit after SIGTERM before it is killed.
+var reapGracePeriod = 5 * time.Second
+
+var workReapCmd = &cobra.Command{
+	Use:   "reap",
+	Short: i18n.CmdWorkReapShort,
+	Long:  i18n.CmdWorkReapLong,
+	Args:  cobra.NoArgs,
+	RunE:  runWorkReap,
+}
+
+func init() {
+	workReapCmd.Flags().BoolVarP(&workReapYes, "yes", "y", false, i18n.FlagWorkReapYes)
+	workCmd.AddCommand(workReapCmd)
+}
+
+// trackAgentProcess records an agent subprocess started by this process in the run
+// state and returns the function that forgets it once it exits (see
+// agent.Caller.SetProcessHook). Recording is best effort: a run state that cannot be
+// written only means a crash could leave the agent unnoticed.
+func trackAgentProcess(pid int) (exited func()) {
+	state := runstate.New(cfg.AgentRunStatePath())
+	_ = state.Add(runstate.Entry{PID: pid, OwnerPID: os.Getpid(), Command: cfg.AgentCommand, ProcessStart: processStartTime(pid)})
+	return func() { _ = state.Remove(pid) }
+}
+
+// runWorkReap terminates the agent processes left running by crashed orchestrator
+// runs, asking about each one unless --yes is given.
+func runWorkReap(cmd *cobra.Command, args []string) error {
+	w := os.Stdout
+	orphans, err := runstate.New(cfg.AgentRunStatePath()).Orphans(IsProcessAlive, processStartTime)
+	if err != nil {
+		return err
+	}
+	if len(orphans) == 0 {
+		ui.PrintInfo(w, i18n.MsgNoOrphanAgents)
+		return nil
+	}
+	ui.PrintWarning(w, fmt.Sprintf(i18n.MsgOrphanAgentsFound, len(orphans)))
+	confirm := func(runstate.Entry) (bool, error) { return true, nil }
+	if !workReapYes {
+		confirm = askReapAgent(w, os.Stdin)
+	}
+	reaped, err := reapOrphanAgents(w, orphans, confirm)
+	if reaped > 0 {
+		ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgAgentsReaped, reaped))
+	}
+	return err
+}
+
+// checkOrphanAgents looks for agent processes left by crashed runs when work starts.
+// In a terminal it offers to terminate each one; otherwise (background work, --quiet,
+// piped input) it only reports them and points to work reap.
+func checkOrphanAgents(w io.Writer) {
+	orphans, err := runstate.New(cfg.AgentRunStatePath()).Orphans(IsProcessAlive, processStartTime)
+	if err != nil || len(orphans) == 0 {
+		return
+	}
+	ui.PrintWarning(w, fmt.Sprintf(i18n.MsgOrphanAgentsFound, len(orphans)))
+	if IsDetachChild() || cfg.Quiet || !term.IsTerminal(int(os.Stdin.Fd())) {
+		for _, e := range orphans {
+			ui.PrintInfo(w, "  "+describeOrphanAgent(e))
+		}
+		ui.PrintInfo(w, ui.StyleMuted.Render(i18n.HintWorkReap))
+		return
+	}
+	if _, err := reapOrphanAgents(w, orphans, askReapAgent(w, os.Stdin)); err != nil {
+		ui.PrintWarning(w, err.Error())
+	}
+}
+
+// askReapAgent returns a confirm function for reapOrphanAgents that asks about each
+// process on in, defaulting to keeping it.
+func askReapAgent(w io.Writer, in io.Reader) func(runstate.Entry) (bool, error) {
+	prompt := ui.NewPrompt(in, w)
+	return func(e runstate.Entry) (bool, error) {
+		return prompt.Confirm(fmt.Sprintf(i18n.PromptReapAgent, e.PID), false)
+	}
+}
+
+// reapOrphanAgents terminates each orphan confirm agrees to: SIGTERM, then SIGKILL when it
+// is still running after reapGracePeriod. Terminated processes are dropped from the run
+// state and logged to reaper.log in the logs directory. Returns how many were terminated;
+// a process that cannot be terminated is reported and the rest are still handled.
+func reapOrphanAgents(w io.Writer, orphans []runstate.Entry, confirm func(runstate.Entry) (bool, error)) (reaped int, err error) {
+	state := runstate.New(cfg.AgentRunStatePath())
+	var firstErr error
+	for _, e := range orphans {
+		ui.PrintInfo(w, "  "+describeOrphanAgent(e))
+		ok, err := confirm(e)
+		if err != nil {
+			return reaped, err
+		}
+		if !ok {
+			continue
+		}
+		if err := terminateAgent(e); err != nil {
+			err = fmt.Errorf(i18n.ErrReapAgent, e.PID, err)
+			ui.PrintError(w, err.Error())
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		_ = state.Remove(e.PID)
+		logReapedAgent(e)
+		ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgAgentReaped, e.PID))
+		reaped++
+	}
+	return reaped, firstErr
+}
+
+// terminateAgent stops an agent process: SIGTERM first, SIGKILL after reapGracePeriod.
+// Before each signal it checks that the PID still is the recorded agent, so a process
+// that reused the PID since is never signalled.
+func terminateAgent(e runstate.Entry) error {
+	pid := e.PID
+	if !e.SameProcess(processStartTime(pid)) {
+		return errors.New(i18n.ErrReapPIDReused)
+	}
+	if err := terminateProcess(pid); err != nil {
+		return err
+	}
+	if waitForProcessExit(pid, reapGracePeriod) {
+		return nil
+	}
+	if !e.SameProcess(processStartTime(pid)) {
+		return nil
+	}
+	if err := killProcess(pid); err != nil {
+		return err
+	}
+	if !waitForProcessExit(pid, reapGracePeriod) {
+		return fmt.Errorf("still running after SIGKILL")
+	}
+	return nil
+}
+
+func describeOrphanAgent(e runstate.Entry) string {
+	return fmt.Sprintf(i18n.MsgOrphanAgent, e.PID, e.Command, e.StartedAt.Local().Format("2006-01-02 15:04"), e.OwnerPID)
+}
+
+// logReapedAgent appends a line about a terminated agent to reaper.log in the logs
+// directory, so a reap that happened at work startup can be traced afterwards.
+func logReapedAgent(e runstate.Entry) {
+	if cfg.LogsDir == "" {
+		return
+	}
+	if err := os.MkdirAll(cfg.LogsDir, 0700); err != nil {
+		return
+	}
+	f, err := os.OpenFile(filepath.Join(cfg.LogsDir, "reaper.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fmt.Fprintf(f, "%s reaped pid=%d owner_pid=%d command=%q started_at=%s\n",
+		time.Now().Format(time.RFC3339), e.PID, e.OwnerPID, e.Command, e.StartedAt.Format(time.RFC3339))
+}
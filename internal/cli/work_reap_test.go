@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/runstate"
+)
+
+// startOrphanAgent starts a long-running process and records it in the run state as
+// an agent whose orchestrator has exited. It returns the process and a channel closed
+// once the process has been waited for.
+func startOrphanAgent(t *testing.T) (*exec.Cmd, chan struct{}) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("requires POSIX processes")
+	}
+	owner := exec.Command("true")
+	if err := owner.Run(); err != nil {
+		t.Fatal(err)
+	}
+	agentProc := exec.Command("sleep", "30")
+	if err := agentProc.Start(); err != nil {
+		t.Fatal(err)
+	}
+	exited := make(chan struct{})
+	go func() {
+		agentProc.Wait()
+		close(exited)
+	}()
+	t.Cleanup(func() { agentProc.Process.Kill() })
+
+	pid := agentProc.Process.Pid
+	entry := runstate.Entry{PID: pid, OwnerPID: owner.Process.Pid, Command: "sleep", ProcessStart: processStartTime(pid)}
+	if err := runstate.New(cfg.AgentRunStatePath()).Add(entry); err != nil {
+		t.Fatal(err)
+	}
+	return agentProc, exited
+}
+
+func setupReapTest(t *testing.T) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	originalCfg := cfg
+	t.Cleanup(func() { cfg = originalCfg })
+	cfg = &config.Config{TicketsDir: filepath.Join(tmpDir, ".tickets"), LogsDir: filepath.Join(tmpDir, ".logs"), AgentCommand: "sleep"}
+}
+
+func TestRunWorkReap_TerminatesOrphans(t *testing.T) {
+	setupReapTest(t)
+	agentProc, exited := startOrphanAgent(t)
+	defer func() { workReapYes = false }()
+	workReapYes = true
+
+	output := captureOutput(func() {
+		if err := runWorkReap(nil, nil); err != nil {
+			t.Errorf("runWorkReap() error = %v", err)
+		}
+	})
+	select {
+	case <-exited:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("orphaned agent PID %d still running, output:\n%s", agentProc.Process.Pid, output)
+	}
+	if !strings.Contains(output, fmt.Sprintf(i18n.MsgAgentsReaped, 1)) {
+		t.Errorf("output should report one reaped process, got:\n%s", output)
+	}
+	if entries, _ := runstate.New(cfg.AgentRunStatePath()).List(); len(entries) != 0 {
+		t.Errorf("run state should be empty after reaping, got %+v", entries)
+	}
+	log, err := os.ReadFile(filepath.Join(cfg.LogsDir, "reaper.log"))
+	if err != nil || !strings.Contains(string(log), "pid="+strconv.Itoa(agentProc.Process.Pid)) {
+		t.Errorf("reaper.log should record the reaped PID, got %q, %v", log, err)
+	}
+
+	output = captureOutput(func() { runWorkReap(nil, nil) })
+	if !strings.Contains(output, i18n.MsgNoOrphanAgents) {
+		t.Errorf("second reap should find nothing, got:\n%s", output)
+	}
+}
+
+func TestReapOrphanAgents_Declined(t *testing.T) {
+	setupReapTest(t)
+	agentProc, _ := startOrphanAgent(t)
+	orphans, err := runstate.New(cfg.AgentRunStatePath()).Orphans(IsProcessAlive, processStartTime)
+	if err != nil || len(orphans) != 1 {
+		t.Fatalf("Orphans() = %+v, %v; want the started process", orphans, err)
+	}
+
+	var reaped int
+	captureOutput(func() {
+		reaped, err = reapOrphanAgents(os.Stdout, orphans, func(runstate.Entry) (bool, error) { return false, nil })
+	})
+	if err != nil || reaped != 0 {
+		t.Errorf("reapOrphanAgents() = %d, %v; want nothing reaped", reaped, err)
+	}
+	if !IsProcessAlive(agentProc.Process.Pid) {
+		t.Error("declined agent process should keep running")
+	}
+	if entries, _ := runstate.New(cfg.AgentRunStatePath()).List(); len(entries) != 1 {
+		t.Errorf("declined agent should stay in the run state, got %+v", entries)
+	}
+}
+
+func TestTerminateAgent_ReusedPIDNotSignalled(t *testing.T) {
+	setupReapTest(t)
+	agentProc, _ := startOrphanAgent(t)
+	pid := agentProc.Process.Pid
+	if processStartTime(pid) == "" {
+		t.Skip("process start time not available")
+	}
+
+	// The recorded agent started at another time: the PID now belongs to another process.
+	entry := runstate.Entry{PID: pid, OwnerPID: 1, ProcessStart: "not-" + processStartTime(pid)}
+	if err := terminateAgent(entry); err == nil {
+		t.Error("terminateAgent() of a reused PID should fail")
+	}
+	if !IsProcessAlive(pid) {
+		t.Error("a process that reused the agent's PID must not be signalled")
+	}
+	if orphans, _ := runstate.New(cfg.AgentRunStatePath()).Orphans(IsProcessAlive, func(int) string { return "other" }); len(orphans) != 0 {
+		t.Errorf("Orphans() should drop an entry whose PID was reused, got %+v", orphans)
+	}
+}
+
+func TestCheckOrphanAgents_NonInteractiveOnlyReports(t *testing.T) {
+	setupReapTest(t)
+	agentProc, _ := startOrphanAgent(t)
+
+	output := captureOutput(func() { checkOrphanAgents(os.Stdout) })
+	if !strings.Contains(output, i18n.HintWorkReap) || !strings.Contains(output, strconv.Itoa(agentProc.Process.Pid)) {
+		t.Errorf("output should list the orphan and point to work reap, got:\n%s", output)
+	}
+	if !IsProcessAlive(agentProc.Process.Pid) {
+		t.Error("work startup without a terminal must not terminate agents")
+	}
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/runstate"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+)
+
+// reclaimNoteAuthor is the author of the note left on a reclaimed ticket.
+const reclaimNoteAuthor = "agent-orchestrator"
+
+// claimTicket marks t in_progress on behalf of this process, recording the process as
+// its Worker so that reclaimOrphanedTickets can tell when the worker is gone.
+func claimTicket(t *ticket.Ticket) {
+	t.MarkInProgress()
+	t.Worker = &ticket.Worker{PID: os.Getpid(), ProcessStart: ownProcessStart()}
+}
+
+// ownProcessStart is processStartTime of this process, which does not change.
+var ownProcessStart = sync.OnceValue(func() string { return processStartTime(os.Getpid()) })
+
+// reclaimOrphanedTickets returns to pending the in_progress tickets no live worker is
+// processing, as when the process working on them was killed, so work picks them up
+// again; each gets a note telling the next agent run its last attempt was cut off.
+// Returns the IDs of the reclaimed tickets.
+func reclaimOrphanedTickets(w io.Writer, store ticket.Storer) ([]string, error) {
+	inProgress, err := store.LoadByStatus(ticket.StatusInProgress)
+	if err != nil || len(inProgress) == 0 {
+		return nil, err
+	}
+	var reclaimed []string
+	for _, t := range orphanedTickets(inProgress) {
+		t.SetStatus(ticket.StatusPending, ticket.ReasonReclaimed)
+		t.AddNote(reclaimNoteAuthor, i18n.NoteTicketReclaimed)
+		if err := store.Save(t); err != nil {
+			return reclaimed, fmt.Errorf(i18n.ErrSaveTicketFailed, t.ID)
+		}
+		ui.PrintWarning(w, fmt.Sprintf(i18n.MsgTicketReclaimed, t.ID))
+		reclaimed = append(reclaimed, t.ID)
+	}
+	return reclaimed, nil
+}
+
+// orphanedTickets returns the in_progress tickets whose worker is gone and left no
+// agent running that may still be changing files. Tickets that record no Worker
+// (claimed before workers were recorded) count as orphaned only while no other worker
+// is alive at all and no orphaned agent runs.
+func orphanedTickets(inProgress []*ticket.Ticket) []*ticket.Ticket {
+	orphans, _ := runstate.New(cfg.AgentRunStatePath()).Orphans(IsProcessAlive, processStartTime)
+	agentOwners := make(map[int]bool, len(orphans))
+	for _, e := range orphans {
+		agentOwners[e.OwnerPID] = true
+	}
+	var out []*ticket.Ticket
+	for _, t := range inProgress {
+		if t.Worker == nil {
+			if len(orphans) == 0 && !otherWorkerAlive() {
+				out = append(out, t)
+			}
+			continue
+		}
+		if !workerAlive(t.Worker) && !agentOwners[t.Worker.PID] {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// workerAlive reports whether the process recorded as a ticket's worker still runs.
+func workerAlive(wk *ticket.Worker) bool {
+	if wk.PID == os.Getpid() {
+		return true
+	}
+	if !IsProcessAlive(wk.PID) {
+		return false
+	}
+	return wk.ProcessStart == "" || processStartTime(wk.PID) == wk.ProcessStart
+}
+
+// otherWorkerAlive reports whether a process other than this one may be processing
+// tickets: background work whose PID file names a live process, or an orchestrator
+// running an agent.
+func otherWorkerAlive() bool {
+	if pid, err := ReadWorkPIDFile(cfg.WorkPIDFilePath()); err == nil && pid != os.Getpid() && IsProcessAlive(pid) {
+		return true
+	}
+	return orchestratorRunning()
+}
+
+// runWorkReclaim runs work --reclaim: reclaim the orphaned in_progress tickets and
+// exit without processing any.
+func runWorkReclaim(w io.Writer) error {
+	store := newTicketStore()
+	if err := store.Init(); err != nil {
+		return fmt.Errorf(i18n.ErrInitStoreFailed, err)
+	}
+	reclaimed, err := reclaimOrphanedTickets(w, store)
+	if err != nil {
+		return err
+	}
+	if len(reclaimed) == 0 {
+		ui.PrintInfo(w, i18n.MsgNoOrphanedTickets)
+		return nil
+	}
+	ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgMovedToPending, len(reclaimed)))
+	return nil
+}
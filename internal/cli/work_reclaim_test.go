@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestReclaimOrphanedTickets(t *testing.T) {
+	setupReapTest(t)
+	store := ticket.NewStore(cfg.TicketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatal(err)
+	}
+	for _, tk := range []*ticket.Ticket{
+		{ID: "T-1", Title: "killed", Status: ticket.StatusInProgress},
+		{ID: "T-2", Title: "waiting", Status: ticket.StatusPending},
+	} {
+		if err := store.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Background work is alive (the PID file names the test's parent): nothing moves.
+	if err := os.WriteFile(cfg.WorkPIDFilePath(), []byte(strconv.Itoa(os.Getppid())), 0600); err != nil {
+		t.Fatal(err)
+	}
+	var out strings.Builder
+	if ids, err := reclaimOrphanedTickets(&out, store); err != nil || len(ids) != 0 {
+		t.Errorf("reclaimOrphanedTickets() with a live worker = %v, %v; want none", ids, err)
+	}
+	if err := os.Remove(cfg.WorkPIDFilePath()); err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := reclaimOrphanedTickets(&out, store)
+	if err != nil || strings.Join(ids, ",") != "T-1" {
+		t.Fatalf("reclaimOrphanedTickets() = %v, %v; want [T-1]", ids, err)
+	}
+	if !strings.Contains(out.String(), fmt.Sprintf(i18n.MsgTicketReclaimed, "T-1")) {
+		t.Errorf("output should report the reclaimed ticket, got:\n%s", out.String())
+	}
+	tk, err := store.Load("T-1")
+	if err != nil || tk.Status != ticket.StatusPending || len(tk.Notes) != 1 || tk.Notes[0].Text != i18n.NoteTicketReclaimed {
+		t.Errorf("T-1 = %+v, %v; want pending with a note", tk, err)
+	}
+}
+
+func TestReclaimOrphanedTickets_OrphanAgentRunning(t *testing.T) {
+	setupReapTest(t)
+	startOrphanAgent(t)
+	store := ticket.NewStore(cfg.TicketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(&ticket.Ticket{ID: "T-1", Title: "killed", Status: ticket.StatusInProgress}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Its agent may still be changing files: the ticket stays in progress.
+	var out strings.Builder
+	if ids, err := reclaimOrphanedTickets(&out, store); err != nil || len(ids) != 0 {
+		t.Errorf("reclaimOrphanedTickets() with an orphan agent = %v, %v; want none", ids, err)
+	}
+}
+
+func TestReclaimOrphanedTickets_OnlyDeadWorkers(t *testing.T) {
+	setupReapTest(t)
+	store := ticket.NewStore(cfg.TicketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatal(err)
+	}
+	dead := exec.Command("true")
+	if err := dead.Run(); err != nil {
+		t.Skip("requires POSIX processes")
+	}
+	live := os.Getppid()
+	for _, tk := range []*ticket.Ticket{
+		{ID: "T-1", Title: "foreground work elsewhere", Status: ticket.StatusInProgress, Worker: &ticket.Worker{PID: live, ProcessStart: processStartTime(live)}},
+		{ID: "T-2", Title: "killed worker", Status: ticket.StatusInProgress, Worker: &ticket.Worker{PID: dead.Process.Pid}},
+		{ID: "T-3", Title: "reused PID", Status: ticket.StatusInProgress, Worker: &ticket.Worker{PID: live, ProcessStart: "earlier"}},
+	} {
+		if err := store.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Another worker being alive no longer holds back the tickets of a dead one.
+	if err := os.WriteFile(cfg.WorkPIDFilePath(), []byte(strconv.Itoa(live)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	ids, err := reclaimOrphanedTickets(&out, store)
+	want := "T-2,T-3"
+	if processStartTime(live) == "" {
+		want = "T-2"
+	}
+	if err != nil || strings.Join(ids, ",") != want {
+		t.Fatalf("reclaimOrphanedTickets() = %v, %v; want [%s]", ids, err, want)
+	}
+	if tk, _ := store.Load("T-2"); tk.Worker != nil {
+		t.Errorf("a reclaimed ticket should forget its worker, got %+v", tk.Worker)
+	}
+}
+
+func TestClaimTicket(t *testing.T) {
+	tk := ticket.NewTicket("T-1", "claimed", "")
+	claimTicket(tk)
+	if tk.Status != ticket.StatusInProgress || tk.Worker == nil || tk.Worker.PID != os.Getpid() {
+		t.Fatalf("claimTicket() = %s %+v; want in_progress with this process as worker", tk.Status, tk.Worker)
+	}
+	tk.MarkCompleted("done")
+	if tk.Worker != nil {
+		t.Errorf("completing a ticket should forget its worker, got %+v", tk.Worker)
+	}
+}
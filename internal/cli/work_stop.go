@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	workStopTimeout int
+	workStopForce   bool
+)
+
+// workStopPollInterval is how often work stop checks whether the background process exited.
+var workStopPollInterval = 200 * time.Millisecond
+
+var workStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: i18n.CmdWorkStopShort,
+	Long:  i18n.CmdWorkStopLong,
+	Args:  cobra.NoArgs,
+	RunE:  runWorkStop,
+}
+
+func init() {
+	workStopCmd.Flags().IntVar(&workStopTimeout, "timeout", 30, i18n.FlagWorkStopTimeout)
+	workStopCmd.Flags().BoolVar(&workStopForce, "force", false, i18n.FlagWorkStopForce)
+	workCmd.AddCommand(workStopCmd)
+}
+
+// runWorkStop stops the background work started by work --detach: it sends SIGTERM to
+// the PID in the work PID file, waits for the process to exit, returns in_progress
+// tickets to pending and removes the PID file.
+func runWorkStop(cmd *cobra.Command, args []string) error {
+	w := os.Stdout
+	pidPath := cfg.WorkPIDFilePath()
+
+	pid, err := ReadWorkPIDFile(pidPath)
+	if err != nil {
+		ui.PrintInfo(w, i18n.MsgWorkStopNotRunning)
+		return nil
+	}
+
+	if !IsProcessAlive(pid) {
+		ui.PrintWarning(w, fmt.Sprintf(i18n.MsgWorkStopStale, pid))
+	} else {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgWorkStopSignal, pid))
+		if err := terminateProcess(pid); err != nil {
+			return fmt.Errorf(i18n.ErrWorkStopSignal, pid, err)
+		}
+		if !waitForProcessExit(pid, time.Duration(workStopTimeout)*time.Second) {
+			if !workStopForce {
+				return fmt.Errorf(i18n.ErrWorkStopTimeout, pid, workStopTimeout)
+			}
+			ui.PrintWarning(w, fmt.Sprintf(i18n.MsgWorkStopKill, pid))
+			if err := killProcess(pid); err != nil {
+				return fmt.Errorf(i18n.ErrWorkStopSignal, pid, err)
+			}
+			if !waitForProcessExit(pid, 5*time.Second) {
+				return fmt.Errorf(i18n.ErrWorkStopTimeout, pid, workStopTimeout)
+			}
+		}
+		ui.PrintSuccess(w, fmt.Sprintf(i18n.MsgWorkStopped, pid))
+	}
+	RemoveWorkPIDFile(pidPath)
+
+	store := newTicketStore()
+	n, err := store.ResetInProgress()
+	if err != nil {
+		return fmt.Errorf(i18n.ErrWorkStopRequeue, err)
+	}
+	if n > 0 {
+		ui.PrintInfo(w, fmt.Sprintf(i18n.MsgWorkStopRequeued, n))
+	}
+	return nil
+}
+
+// waitForProcessExit polls until the process exits or timeout elapses.
+// Reports whether the process exited.
+func waitForProcessExit(pid int, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for IsProcessAlive(pid) {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(workStopPollInterval)
+	}
+	return true
+}
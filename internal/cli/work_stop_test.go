@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func setupWorkStopTest(t *testing.T) (*ticket.Store, string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("Failed to init store: %v", err)
+	}
+	for _, tk := range []*ticket.Ticket{
+		{ID: "T-1", Title: "a", Status: ticket.StatusInProgress},
+		{ID: "T-2", Title: "b", Status: ticket.StatusCompleted},
+	} {
+		if err := store.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	originalCfg := cfg
+	t.Cleanup(func() { cfg = originalCfg })
+	cfg = &config.Config{TicketsDir: ticketsDir, WorkPIDFile: filepath.Join(ticketsDir, ".work.pid")}
+	return store, cfg.WorkPIDFilePath()
+}
+
+func TestRunWorkStop_NoPidFile(t *testing.T) {
+	store, _ := setupWorkStopTest(t)
+
+	output := captureOutput(func() {
+		if err := runWorkStop(nil, nil); err != nil {
+			t.Errorf("runWorkStop() error = %v", err)
+		}
+	})
+	if !strings.Contains(output, "沒有背景執行中的 work") {
+		t.Errorf("output should say nothing is running, got:\n%s", output)
+	}
+	if got, _ := store.Load("T-1"); got.Status != ticket.StatusInProgress {
+		t.Error("tickets should be untouched when no background work was recorded")
+	}
+}
+
+func TestRunWorkStop_StopsProcessAndRequeues(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX sleep process")
+	}
+	store, pidPath := setupWorkStopTest(t)
+
+	child := exec.Command("sleep", "30")
+	if err := child.Start(); err != nil {
+		t.Fatalf("start child: %v", err)
+	}
+	// Reap the child so it does not linger as a zombie that still looks alive.
+	go child.Wait()
+	defer child.Process.Kill()
+
+	if err := os.WriteFile(pidPath, []byte(strconv.Itoa(child.Process.Pid)+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	workStopPollInterval = 10 * time.Millisecond
+	defer func() { workStopPollInterval = 200 * time.Millisecond }()
+
+	output := captureOutput(func() {
+		if err := runWorkStop(nil, nil); err != nil {
+			t.Errorf("runWorkStop() error = %v", err)
+		}
+	})
+	if !strings.Contains(output, "已停止") {
+		t.Errorf("output should confirm the stop, got:\n%s", output)
+	}
+	if _, err := os.Stat(pidPath); !os.IsNotExist(err) {
+		t.Error("PID file should be removed")
+	}
+	if got, _ := store.Load("T-1"); got.Status != ticket.StatusPending {
+		t.Errorf("in_progress ticket status = %s, want pending", got.Status)
+	}
+	if got, _ := store.Load("T-2"); got.Status != ticket.StatusCompleted {
+		t.Errorf("completed ticket status = %s, want completed", got.Status)
+	}
+}
+
+func TestRunWorkStop_StalePidFile(t *testing.T) {
+	store, pidPath := setupWorkStopTest(t)
+	// PID 2^22-1 is above the default pid_max on most systems.
+	if err := os.WriteFile(pidPath, []byte("4194303\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	captureOutput(func() {
+		if err := runWorkStop(nil, nil); err != nil {
+			t.Errorf("runWorkStop() error = %v", err)
+		}
+	})
+	if _, err := os.Stat(pidPath); !os.IsNotExist(err) {
+		t.Error("stale PID file should be removed")
+	}
+	if got, _ := store.Load("T-1"); got.Status != ticket.StatusPending {
+		t.Errorf("orphaned in_progress ticket status = %s, want pending", got.Status)
+	}
+}
+
+func TestRequeueIfInterrupted(t *testing.T) {
+	store, _ := setupWorkStopTest(t)
+	tk, _ := store.Load("T-1")
+
+	if requeueIfInterrupted(context.Background(), store, tk) {
+		t.Fatal("requeueIfInterrupted() should not requeue when the context is active")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if !requeueIfInterrupted(ctx, store, tk) {
+		t.Fatal("requeueIfInterrupted() should requeue when the context is cancelled")
+	}
+	if got, _ := store.Load("T-1"); got.Status != ticket.StatusPending {
+		t.Errorf("status = %s, want pending", got.Status)
+	}
+}
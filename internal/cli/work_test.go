@@ -3,6 +3,10 @@ package cli
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -13,8 +17,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/anthropic/agent-orchestrator/internal/agent"
 	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
 	"github.com/anthropic/agent-orchestrator/internal/ticket"
+	"github.com/anthropic/agent-orchestrator/internal/workqueue"
 )
 
 func TestRunWork_StoreInitFails(t *testing.T) {
@@ -111,7 +118,7 @@ func TestWorkSingleTicket_NotFound(t *testing.T) {
 	os.Stdout = w
 	defer func() { os.Stdout = oldStdout }()
 
-	err = workSingleTicket(context.Background(), store, "NONEXISTENT-001")
+	err = workSingleTicket(context.Background(), store, "NONEXISTENT-001", workOptions{})
 	w.Close()
 	if err != nil {
 		t.Fatalf("workSingleTicket with nonexistent ID should return nil (prints error): %v", err)
@@ -161,7 +168,7 @@ func TestWorkSingleTicket_NotPending(t *testing.T) {
 	os.Stdout = w
 	defer func() { os.Stdout = oldStdout }()
 
-	err = workSingleTicket(context.Background(), store, "DONE-001")
+	err = workSingleTicket(context.Background(), store, "DONE-001", workOptions{})
 	w.Close()
 	if err != nil {
 		t.Fatalf("workSingleTicket with non-pending ticket should return nil: %v", err)
@@ -286,6 +293,118 @@ func TestRunWork_Detach_WithTicketID_StartsChildAndReturns(t *testing.T) {
 	}
 }
 
+func TestRunWork_LabelFilter_OnlyProcessesMatchingTickets(t *testing.T) {
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("store.Init(): %v", err)
+	}
+	backend := ticket.NewTicket("T-1", "api", "")
+	backend.Labels = []string{"backend"}
+	frontend := ticket.NewTicket("T-2", "css", "")
+	frontend.Labels = []string{"frontend"}
+	for _, tk := range []*ticket.Ticket{backend, frontend} {
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("store.Save(): %v", err)
+		}
+	}
+
+	originalCfg := cfg
+	defer func() {
+		cfg = originalCfg
+		workLabels = nil
+	}()
+	workLabels = []string{"backend"}
+	cfg = &config.Config{
+		ProjectRoot:       tmpDir,
+		TicketsDir:        ticketsDir,
+		AgentCommand:      "agent",
+		AgentForce:        true,
+		AgentOutputFormat: "text",
+		DryRun:            true,
+		MaxParallel:       2,
+	}
+
+	captureOutput(func() {
+		if err := runWork(nil, nil); err != nil {
+			t.Fatalf("runWork(--label backend): %v", err)
+		}
+	})
+	pending, _ := store.LoadByStatus(ticket.StatusPending)
+	if len(pending) != 1 || pending[0].ID != "T-2" {
+		t.Errorf("pending after work --label backend = %v, want only T-2", pending)
+	}
+
+	params, err := buildWorkDetachParams(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(strings.Join(params.Args, " "), "--label backend") {
+		t.Errorf("detach child args should pass --label through, got %v", params.Args)
+	}
+}
+
+func TestRunWork_FilterExpr_OnlyProcessesMatchingTickets(t *testing.T) {
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("store.Init(): %v", err)
+	}
+	urgent := ticket.NewTicket("T-1", "api", "")
+	urgent.Priority = 1
+	later := ticket.NewTicket("T-2", "css", "")
+	later.Priority = 4
+	for _, tk := range []*ticket.Ticket{urgent, later} {
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("store.Save(): %v", err)
+		}
+	}
+
+	originalCfg := cfg
+	defer func() {
+		cfg = originalCfg
+		workFilter = ""
+	}()
+	workFilter = "priority<=2"
+	cfg = &config.Config{
+		ProjectRoot:       tmpDir,
+		TicketsDir:        ticketsDir,
+		AgentCommand:      "agent",
+		AgentForce:        true,
+		AgentOutputFormat: "text",
+		DryRun:            true,
+		MaxParallel:       2,
+	}
+
+	captureOutput(func() {
+		if err := runWork(nil, nil); err != nil {
+			t.Fatalf("runWork(--filter): %v", err)
+		}
+	})
+	pending, _ := store.LoadByStatus(ticket.StatusPending)
+	if len(pending) != 1 || pending[0].ID != "T-2" {
+		t.Errorf("pending after work --filter priority<=2 = %v, want only T-2", pending)
+	}
+
+	params, err := buildWorkDetachParams(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(strings.Join(params.Args, " "), "--filter priority<=2") {
+		t.Errorf("detach child args should pass --filter through, got %v", params.Args)
+	}
+	if got := describeWorkRequest(workqueue.Request{Filter: "label=backend"}); got != `work --filter "label=backend"` {
+		t.Errorf("describeWorkRequest() = %q", got)
+	}
+
+	workFilter = "priority<=high"
+	if err := runWork(nil, nil); err == nil {
+		t.Error("runWork() with an invalid filter should fail")
+	}
+}
+
 func TestBuildWorkDetachParams(t *testing.T) {
 	originalCfgFile := cfgFile
 	originalCfg := cfg
@@ -357,6 +476,27 @@ func TestBuildWorkDetachParams(t *testing.T) {
 	if params.LogPath == "" {
 		t.Error("LogPath should be set when cfg is set")
 	}
+
+	// The child applies the same profile
+	cfg.Profile = "fast"
+	params, err = buildWorkDetachParams(nil)
+	if err != nil {
+		t.Fatalf("buildWorkDetachParams with a profile: %v", err)
+	}
+	if !strings.Contains(strings.Join(params.Args, " "), "--profile fast") {
+		t.Errorf("Args should contain --profile fast, got %v", params.Args)
+	}
+
+	// The child processes the same milestones
+	defer func() { workMilestones = nil }()
+	workMilestones = []string{"docs/m1.md", "docs/m2.md"}
+	params, err = buildWorkDetachParams(nil)
+	if err != nil {
+		t.Fatalf("buildWorkDetachParams with milestones: %v", err)
+	}
+	if !strings.Contains(strings.Join(params.Args, " "), "--milestone docs/m1.md,docs/m2.md") {
+		t.Errorf("Args should contain --milestone docs/m1.md,docs/m2.md, got %v", params.Args)
+	}
 }
 
 func TestExecWorkDetach_StartsChildAndReturnsPid(t *testing.T) {
@@ -457,6 +597,12 @@ func TestRunWork_DetachChild_CreatesLogFile(t *testing.T) {
 	if !strings.Contains(logStr, "完成") {
 		t.Errorf("log should contain completion summary, got: %s", logStr)
 	}
+	// The detach child logs JSON records.
+	for _, line := range strings.Split(strings.TrimSpace(logStr), "\n") {
+		if !json.Valid([]byte(line)) {
+			t.Errorf("log line is not a JSON record: %q", line)
+		}
+	}
 }
 
 // TestRunWork_DetachChild_LogFileOverride ensures that --log-file overrides the log path for that run.
@@ -644,3 +790,349 @@ logs_dir: .agent-logs
 		time.Sleep(100 * time.Millisecond)
 	}
 }
+
+func TestSalvagePartialOutput(t *testing.T) {
+	tk := ticket.NewTicket("T-1", "t", "")
+
+	salvagePartialOutput(tk, nil)
+	salvagePartialOutput(tk, &agent.Result{Output: "failed fast"})
+	if tk.PartialOutput != "" {
+		t.Errorf("only timed-out calls should be salvaged, got %q", tk.PartialOutput)
+	}
+
+	salvagePartialOutput(tk, &agent.Result{Output: "halfway there", TimedOut: true})
+	if tk.PartialOutput != "halfway there" {
+		t.Errorf("PartialOutput = %q, want salvaged output", tk.PartialOutput)
+	}
+
+	// stream-json: the assistant's text, not the raw JSON events.
+	line := `{"type":"assistant","message":{"content":[{"type":"text","text":"Wrote the parser."}]}}`
+	salvagePartialOutput(tk, &agent.Result{Output: line + "\n", StreamEvents: agent.ClaudeBackend{}.ParseStream(line), TimedOut: true})
+	if tk.PartialOutput != "Wrote the parser." {
+		t.Errorf("PartialOutput = %q, want the assistant text", tk.PartialOutput)
+	}
+}
+
+func TestRunWork_QueueWhileBackgroundRunning(t *testing.T) {
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("store.Init(): %v", err)
+	}
+	if err := store.Save(ticket.NewTicket("T-1", "api", "")); err != nil {
+		t.Fatalf("store.Save(): %v", err)
+	}
+
+	pidPath := filepath.Join(tmpDir, ".work.pid")
+	originalCfg := cfg
+	defer func() {
+		cfg = originalCfg
+		workQueue = false
+		workLabels = nil
+	}()
+	cfg = &config.Config{
+		ProjectRoot:       tmpDir,
+		TicketsDir:        ticketsDir,
+		WorkPIDFile:       pidPath,
+		AgentCommand:      "agent",
+		AgentForce:        true,
+		AgentOutputFormat: "text",
+		DryRun:            true,
+		MaxParallel:       1,
+	}
+	// This test process stands in for the running background worker.
+	if err := WriteWorkPIDFile(pidPath); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runWork(nil, []string{"T-1"}); err == nil {
+		t.Fatal("runWork() without --queue should refuse while background work runs")
+	}
+
+	workQueue = true
+	captureOutput(func() {
+		if err := runWork(nil, []string{"T-1"}); err != nil {
+			t.Fatalf("runWork(--queue): %v", err)
+		}
+	})
+	if got, _ := store.Load("T-1"); got.Status != ticket.StatusPending {
+		t.Errorf("queued ticket should not be processed yet, status = %s", got.Status)
+	}
+	output := captureOutput(func() {
+		if err := runStatus(nil, nil); err != nil {
+			t.Errorf("runStatus(): %v", err)
+		}
+	})
+	if !strings.Contains(output, fmt.Sprintf(i18n.UIWorkQueue, 1)) || !strings.Contains(output, "work T-1") {
+		t.Errorf("status should list the queued request, got:\n%s", output)
+	}
+
+	// A queued request's selection applies to that request only.
+	if _, err := workqueue.New(cfg.WorkQueuePath()).Push(workqueue.Request{Labels: []string{"frontend"}, Filter: "priority<=2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The worker drains the queue once its batch is done.
+	captureOutput(func() {
+		if err := drainWorkQueue(context.Background(), store, ""); err != nil {
+			t.Fatalf("drainWorkQueue(): %v", err)
+		}
+	})
+	if workLabels != nil || workFilter != "" {
+		t.Errorf("drainWorkQueue() should leave the work flags alone, got --label %v --filter %q", workLabels, workFilter)
+	}
+	if got, _ := store.Load("T-1"); got.Status != ticket.StatusCompleted {
+		t.Errorf("queued ticket should be processed by drainWorkQueue, status = %s", got.Status)
+	}
+	if reqs, _ := workqueue.New(cfg.WorkQueuePath()).List(); len(reqs) != 0 {
+		t.Errorf("queue should be empty after draining, got %+v", reqs)
+	}
+
+	// Once the worker is gone, a queued request is taken back to run directly.
+	RemoveWorkPIDFile(pidPath)
+	queued, err := queueWorkRequest(io.Discard, nil)
+	if err != nil || queued {
+		t.Errorf("queueWorkRequest() without a worker = %v, %v; want false", queued, err)
+	}
+	if reqs, _ := workqueue.New(cfg.WorkQueuePath()).List(); len(reqs) != 0 {
+		t.Errorf("request should be taken back, got %+v", reqs)
+	}
+}
+
+func TestWorkAllTickets_MaxCost_StopsScheduling(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("store.Init(): %v", err)
+	}
+	for i := 1; i <= 3; i++ {
+		tk := ticket.NewTicket(fmt.Sprintf("T-%d", i), "task", "")
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("store.Save(): %v", err)
+		}
+	}
+	// Every call reports $1.
+	agentPath := filepath.Join(tmpDir, "agent")
+	script := "#!/bin/sh\necho '{\"type\":\"result\",\"result\":\"done\",\"total_cost_usd\":1,\"usage\":{\"input_tokens\":100,\"output_tokens\":10}}'\n"
+	if err := os.WriteFile(agentPath, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	originalCfg := cfg
+	defer func() {
+		cfg = originalCfg
+	}()
+	cfg = &config.Config{
+		ProjectRoot:       tmpDir,
+		TicketsDir:        ticketsDir,
+		AgentCommand:      agentPath,
+		AgentOutputFormat: "json",
+		BudgetUSD:         100, // overridden by --max-cost
+		MaxParallel:       1,
+	}
+
+	output := captureOutput(func() {
+		if err := workAllTickets(context.Background(), store, workOptions{parallel: 1, maxCost: 1.5}); err != nil {
+			t.Fatalf("workAllTickets(): %v", err)
+		}
+	})
+	pending, _ := store.LoadByStatus(ticket.StatusPending)
+	if len(pending) != 1 {
+		t.Errorf("pending after reaching the cost ceiling = %d tickets, want 1", len(pending))
+	}
+	done, _ := store.LoadByStatus(ticket.StatusCompleted)
+	if len(done) != 2 || done[0].CostUSD != 1 || done[0].TokensUsed != 110 {
+		t.Errorf("completed = %v, want 2 tickets costing $1 and 110 tokens each", done)
+	}
+	if want := fmt.Sprintf(i18n.MsgCostCeilingReached, 1.5, 2.0, 1); !strings.Contains(output, want) {
+		t.Errorf("output should contain %q, got:\n%s", want, output)
+	}
+}
+
+func TestWorkAllTickets_AbortsOnSystemicFailures(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("store.Init(): %v", err)
+	}
+	for i := 1; i <= 4; i++ {
+		if err := store.Save(ticket.NewTicket(fmt.Sprintf("T-%d", i), "task", "")); err != nil {
+			t.Fatalf("store.Save(): %v", err)
+		}
+	}
+	agentPath := filepath.Join(tmpDir, "agent")
+	if err := os.WriteFile(agentPath, []byte("#!/bin/sh\necho 'Error: not logged in' >&2\nexit 1\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	eventPath := filepath.Join(tmpDir, "event.json")
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{
+		ProjectRoot:              tmpDir,
+		TicketsDir:               ticketsDir,
+		AgentCommand:             agentPath,
+		AgentOutputFormat:        "text",
+		MaxParallel:              1,
+		SystemicFailureThreshold: 2,
+		Notifications:            config.NotificationsConfig{Command: "cat > " + eventPath},
+	}
+
+	var err error
+	output := captureOutput(func() {
+		err = workAllTickets(context.Background(), store, workOptions{parallel: 1})
+	})
+	if err == nil {
+		t.Fatal("workAllTickets() should fail after repeated auth failures")
+	}
+	if want := fmt.Sprintf(i18n.MsgSystemicAbort, 2, agent.FailureAuth); !strings.Contains(output, want) {
+		t.Errorf("output should contain %q, got:\n%s", want, output)
+	}
+	if failed, _ := store.LoadByStatus(ticket.StatusFailed); len(failed) != 2 {
+		t.Errorf("failed tickets = %d, want 2", len(failed))
+	}
+	if pending, _ := store.LoadByStatus(ticket.StatusPending); len(pending) != 2 {
+		t.Errorf("pending tickets = %d, want 2 left unscheduled", len(pending))
+	}
+	if data, err := os.ReadFile(eventPath); err != nil || !strings.Contains(string(data), `"class":"auth"`) {
+		t.Errorf("notification = %q (%v), want an event with class auth", data, err)
+	}
+}
+
+func TestProcessTicket_AgentUnavailable(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := ticket.NewStore(filepath.Join(tmpDir, ".tickets"))
+	if err := store.Init(); err != nil {
+		t.Fatalf("store.Init(): %v", err)
+	}
+	tk := ticket.NewTicket("T-1", "task", "")
+	if err := store.Save(tk); err != nil {
+		t.Fatalf("store.Save(): %v", err)
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{
+		ProjectRoot:  tmpDir,
+		TicketsDir:   filepath.Join(tmpDir, ".tickets"),
+		AgentBackend: "no-such-backend",
+		AgentCommand: "agent",
+	}
+
+	var err error
+	captureOutput(func() {
+		err = processTicket(context.Background(), store, tk, workOptions{})
+	})
+	if !errors.Is(err, agent.ErrUnavailable) {
+		t.Fatalf("processTicket() error = %v, want agent.ErrUnavailable", err)
+	}
+	if got := agent.ClassifyFailure(err.Error()); got != agent.FailureUnavailable {
+		t.Errorf("ClassifyFailure(%q) = %q, want %q", err, got, agent.FailureUnavailable)
+	}
+	if tk.Status != ticket.StatusFailed {
+		t.Errorf("Status = %s, want %s", tk.Status, ticket.StatusFailed)
+	}
+}
+
+func TestWorkAllTickets_ProbeFailureAbortsBatch(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("store.Init(): %v", err)
+	}
+	for i := 1; i <= 3; i++ {
+		if err := store.Save(ticket.NewTicket(fmt.Sprintf("T-%d", i), "task", "")); err != nil {
+			t.Fatalf("store.Save(): %v", err)
+		}
+	}
+	runsPath := filepath.Join(tmpDir, "runs")
+	agentPath := filepath.Join(tmpDir, "agent")
+	script := "#!/bin/sh\necho run >> " + runsPath + "\necho 'Error: Invalid API key' >&2\nexit 1\n"
+	if err := os.WriteFile(agentPath, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{
+		ProjectRoot:       tmpDir,
+		TicketsDir:        ticketsDir,
+		AgentCommand:      agentPath,
+		AgentOutputFormat: "text",
+		AgentProbe:        true,
+	}
+
+	var err error
+	output := captureOutput(func() {
+		err = workAllTickets(context.Background(), store, workOptions{parallel: 3})
+	})
+	if err == nil || !strings.Contains(err.Error(), "Invalid API key") {
+		t.Fatalf("workAllTickets() error = %v, want the probe's error", err)
+	}
+	if !strings.Contains(output, i18n.HintSystemicAuth) {
+		t.Errorf("output should contain the auth hint, got:\n%s", output)
+	}
+	if runs, _ := os.ReadFile(runsPath); strings.Count(string(runs), "run") != 1 {
+		t.Errorf("agent ran %d times, want only the probe", strings.Count(string(runs), "run"))
+	}
+	if pending, _ := store.LoadByStatus(ticket.StatusPending); len(pending) != 3 {
+		t.Errorf("pending tickets = %d, want all 3 untouched", len(pending))
+	}
+}
+
+func TestWorkAllTickets_ReportsDependencyCycle(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script requires a POSIX shell")
+	}
+	tmpDir := t.TempDir()
+	ticketsDir := filepath.Join(tmpDir, ".tickets")
+	store := ticket.NewStore(ticketsDir)
+	if err := store.Init(); err != nil {
+		t.Fatalf("store.Init(): %v", err)
+	}
+	for _, tk := range []*ticket.Ticket{
+		{ID: "T-1", Title: "api", Status: ticket.StatusPending, Priority: 2, Dependencies: []string{"T-2"}},
+		{ID: "T-2", Title: "schema", Status: ticket.StatusPending, Priority: 3, Dependencies: []string{"T-1"}},
+	} {
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("store.Save(): %v", err)
+		}
+	}
+	agentPath := filepath.Join(tmpDir, "agent")
+	if err := os.WriteFile(agentPath, []byte("#!/bin/sh\nexit 1\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	cfg = &config.Config{ProjectRoot: tmpDir, TicketsDir: ticketsDir, AgentCommand: agentPath, MaxParallel: 1}
+
+	output := captureOutput(func() {
+		if err := workAllTickets(context.Background(), store, workOptions{parallel: 1}); err != nil {
+			t.Fatalf("workAllTickets(): %v", err)
+		}
+	})
+	for _, want := range []string{
+		fmt.Sprintf(i18n.MsgPendingBlocked, 2),
+		fmt.Sprintf(i18n.MsgCyclePath, "T-1 → T-2 → T-1"),
+		fmt.Sprintf(i18n.MsgCycleSuggest, "T-1", "T-2"), // T-1 (P2) waiting on the lower-priority T-2
+		i18n.HintFixCycles,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output should contain %q, got:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/anthropic/agent-orchestrator/internal/gitx"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// ticketWorkspace is the directory a ticket is coded in: the project root, or its own
+// git worktree when git_worktrees is on.
+type ticketWorkspace struct {
+	Dir string
+	mgr *gitx.Manager
+	wt  *gitx.Worktree
+	// keep leaves the worktree in place on Close, e.g. after a failed merge.
+	keep bool
+}
+
+// openTicketWorkspace provisions the workspace for t. Without git_worktrees, or in
+// dry-run mode, it is the project root and Merge/Close are no-ops.
+func openTicketWorkspace(ctx context.Context, t *ticket.Ticket) (*ticketWorkspace, error) {
+	ws := &ticketWorkspace{Dir: cfg.ProjectRoot}
+	if !cfg.GitWorktrees || cfg.DryRun {
+		return ws, nil
+	}
+	if err := validateProjectRoot(cfg.ProjectRoot); err != nil {
+		return nil, fmt.Errorf(i18n.ErrWorktreeCreate, t.ID, err)
+	}
+	ws.mgr = gitx.NewManager(cfg.ProjectRoot, cfg.WorktreePath())
+	wt, err := ws.mgr.Create(ctx, t.ID)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.ErrWorktreeCreate, t.ID, err)
+	}
+	ws.wt = wt
+	ws.Dir = wt.Path
+	return ws, nil
+}
+
+// Merge applies the ticket's changes from its worktree to the project root. On
+// failure the worktree is kept so the changes can be recovered by hand.
+func (ws *ticketWorkspace) Merge(ctx context.Context) error {
+	if ws.wt == nil {
+		return nil
+	}
+	if err := ws.mgr.Merge(ctx, ws.wt); err != nil {
+		ws.keep = true
+		return fmt.Errorf(i18n.ErrWorktreeMerge, ws.wt.Path, err)
+	}
+	return nil
+}
+
+// Close removes the worktree unless a failed merge asked to keep it.
+func (ws *ticketWorkspace) Close() {
+	if ws.wt == nil || ws.keep {
+		return
+	}
+	// The run may have been cancelled; cleanup still has to happen.
+	ws.mgr.Remove(context.Background(), ws.wt)
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/config"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestOpenTicketWorkspace(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	for _, args := range [][]string{{"init", "-q"}, {"commit", "-q", "--allow-empty", "-m", "init"}} {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	originalCfg := cfg
+	defer func() { cfg = originalCfg }()
+	tk := ticket.NewTicket("T-1", "Test", "")
+
+	for _, c := range []config.Config{{ProjectRoot: dir}, {ProjectRoot: dir, GitWorktrees: true, DryRun: true}} {
+		cfg = &c
+		ws, err := openTicketWorkspace(ctx, tk)
+		if err != nil || ws.Dir != dir {
+			t.Errorf("openTicketWorkspace() with %+v = %v, %v; want the project root", c, ws, err)
+		}
+	}
+
+	cfg = &config.Config{ProjectRoot: dir, GitWorktrees: true, GitWorktreeDir: t.TempDir()}
+	ws, err := openTicketWorkspace(ctx, tk)
+	if err != nil {
+		t.Fatalf("openTicketWorkspace() err = %v", err)
+	}
+	if ws.Dir == dir || filepath.Dir(ws.Dir) != cfg.GitWorktreeDir {
+		t.Fatalf("workspace dir = %s, want a worktree in %s", ws.Dir, cfg.GitWorktreeDir)
+	}
+	if err := os.WriteFile(filepath.Join(ws.Dir, "feature.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ws.Merge(ctx); err != nil {
+		t.Fatalf("Merge() err = %v", err)
+	}
+	ws.Close()
+	if _, err := os.Stat(filepath.Join(dir, "feature.go")); err != nil {
+		t.Errorf("merged file missing from project: %v", err)
+	}
+	if _, err := os.Stat(ws.Dir); !os.IsNotExist(err) {
+		t.Errorf("Close() should remove the worktree, stat err = %v", err)
+	}
+}
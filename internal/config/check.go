@@ -0,0 +1,248 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
+)
+
+// Problem is one issue CheckFile found in a config file.
+type Problem struct {
+	// Line is the 1-based line of the problem, 0 when it cannot be located.
+	Line int
+	// Key is the key the problem is about ("section.key", list items as "key[i]"), if any.
+	Key     string
+	Message string
+}
+
+func (p Problem) String() string {
+	s := p.Message
+	if p.Key != "" {
+		s = p.Key + ": " + s
+	}
+	if p.Line > 0 {
+		s = fmt.Sprintf("line %d: %s", p.Line, s)
+	}
+	return s
+}
+
+// yamlErrLine finds the line number in the syntax errors of the YAML parser.
+var yamlErrLine = regexp.MustCompile(`line (\d+):`)
+
+// CheckFile checks the config file at path against the schema of Config: YAML syntax,
+// unknown keys (with the closest known key as a suggestion), values of the wrong type
+// and the rules of Validate. Problems are in the order of the file.
+func CheckFile(path string) ([]Problem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		p := Problem{Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+		if m := yamlErrLine.FindStringSubmatch(err.Error()); m != nil {
+			p.Line, _ = strconv.Atoi(m[1])
+			p.Message = strings.TrimSpace(strings.TrimPrefix(p.Message, m[0]))
+		}
+		return []Problem{p}, nil
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	c := &checker{lines: make(map[string]int)}
+	c.check(doc.Content[0], reflect.TypeOf(Config{}), "")
+	if len(c.problems) > 0 {
+		// Validate would only repeat the decoding errors.
+		return c.problems, nil
+	}
+
+	cfg, err := parseConfig(data)
+	if err != nil {
+		return []Problem{{Message: err.Error()}}, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		key, line := c.locate(err.Error())
+		return []Problem{{Line: line, Key: key, Message: err.Error()}}, nil
+	}
+	return nil, nil
+}
+
+// checker walks a config document alongside the type it decodes into.
+type checker struct {
+	problems []Problem
+	// lines maps every key found to the line it is on.
+	lines map[string]int
+}
+
+func (c *checker) add(n *yaml.Node, key, format string, args ...any) {
+	c.problems = append(c.problems, Problem{Line: n.Line, Key: key, Message: fmt.Sprintf(format, args...)})
+}
+
+// check checks node n at key against type t.
+func (c *checker) check(n *yaml.Node, t reflect.Type, key string) {
+	if isNull(n) {
+		return
+	}
+	switch {
+	case t.Kind() == reflect.Struct:
+		if n.Kind != yaml.MappingNode {
+			c.add(n, key, "expected a section of keys")
+			return
+		}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			path := joinKey(key, k.Value)
+			c.lines[path] = k.Line
+			f, ok := fieldByTag(t, k.Value)
+			if !ok {
+				msg := "unknown key"
+				if s := suggestKey(k.Value, t); s != "" {
+					msg += fmt.Sprintf(" (did you mean %s?)", s)
+				}
+				c.add(k, path, "%s", msg)
+				continue
+			}
+			if path == "profiles" && v.Kind == yaml.MappingNode {
+				// Every profile is a set of top-level settings.
+				for j := 0; j+1 < len(v.Content); j += 2 {
+					name := joinKey(path, v.Content[j].Value)
+					c.lines[name] = v.Content[j].Line
+					c.check(v.Content[j+1], t, name)
+				}
+				continue
+			}
+			c.check(v, f.Type, path)
+		}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Struct:
+		if n.Kind != yaml.SequenceNode {
+			c.add(n, key, "expected a list")
+			return
+		}
+		for i, item := range n.Content {
+			c.check(item, t.Elem(), fmt.Sprintf("%s[%d]", key, i))
+		}
+	default:
+		var raw any
+		if err := n.Decode(&raw); err == nil {
+			// Decode the way Load does, so e.g. a quoted number is accepted for an integer.
+			v := viper.New()
+			v.Set("value", raw)
+			err = v.UnmarshalKey("value", reflect.New(t).Interface())
+			if err == nil {
+				return
+			}
+		}
+		c.add(n, key, "invalid value %s: expected %s", valueText(n), describeType(t))
+	}
+}
+
+// locate returns the key a Validate error is about and its line: the longest key of
+// the file named in the message.
+func (c *checker) locate(message string) (key string, line int) {
+	keys := make([]string, 0, len(c.lines))
+	for k := range c.lines {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return len(keys[i]) > len(keys[j]) })
+	for _, k := range keys {
+		if strings.Contains(message, k) {
+			return k, c.lines[k]
+		}
+	}
+	return "", 0
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// fieldByTag returns the field of struct type t with mapstructure tag name.
+func fieldByTag(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.Tag.Get("mapstructure") == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// suggestKey returns the key of struct type t closest to the unknown name, or "" when
+// none is close enough to be a typo.
+func suggestKey(name string, t reflect.Type) string {
+	best, bestDist := "", 3
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("mapstructure")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		if d := editDistance(name, tag); d < bestDist {
+			best, bestDist = tag, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// valueText quotes the value of n for a message; collections are not repeated.
+func valueText(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "(a section)"
+	case yaml.SequenceNode:
+		return "(a list)"
+	}
+	return strconv.Quote(n.Value)
+}
+
+// describeType names t in messages.
+func describeType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Int, reflect.Int64:
+		return "an integer"
+	case reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.String {
+			return "a list of strings"
+		}
+		return "a list"
+	case reflect.Map:
+		return "a map"
+	}
+	return t.String()
+}
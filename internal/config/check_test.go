@@ -0,0 +1,98 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCheckFile(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want []Problem
+	}{
+		{"valid", "max_parallel: 5\nagent_force: false\njira:\n  url: https://jira.example.com\n", nil},
+		{"empty", "", nil},
+		{"quoted number", "max_parallel: \"5\"\n", nil},
+		{
+			"unknown keys",
+			"max_paralel: 5\nagent_force: true\njira:\n  emial: x\n  nonsense_key: y\n",
+			[]Problem{
+				{Line: 1, Key: "max_paralel", Message: "unknown key (did you mean max_parallel?)"},
+				{Line: 4, Key: "jira.emial", Message: "unknown key (did you mean email?)"},
+				{Line: 5, Key: "jira.nonsense_key", Message: "unknown key"},
+			},
+		},
+		{
+			"bad values",
+			"max_parallel: many\nagent_force: sometimes\nlabels_filter: x\njira: on\n",
+			[]Problem{
+				{Line: 1, Key: "max_parallel", Message: `invalid value "many": expected an integer`},
+				{Line: 2, Key: "agent_force", Message: `invalid value "sometimes": expected true or false`},
+				{Line: 3, Key: "labels_filter", Message: "unknown key"},
+				{Line: 4, Key: "jira", Message: "expected a section of keys"},
+			},
+		},
+		{
+			"list of sections",
+			"serve_tokens:\n  - name: ci\n    secret: x\n",
+			[]Problem{{Line: 3, Key: "serve_tokens[0].secret", Message: "unknown key"}},
+		},
+		{
+			"profiles",
+			"profiles:\n  fast:\n    max_parallel: 8\n    max_paralel: 8\n    agent_force: maybe\n",
+			[]Problem{
+				{Line: 4, Key: "profiles.fast.max_paralel", Message: "unknown key (did you mean max_parallel?)"},
+				{Line: 5, Key: "profiles.fast.agent_force", Message: `invalid value "maybe": expected true or false`},
+			},
+		},
+		{
+			"validate rule",
+			"agent_force: true\nmax_parallel: 0\n",
+			[]Problem{{Line: 2, Key: "max_parallel", Message: "max_parallel must be at least 1"}},
+		},
+		{
+			"syntax error",
+			"max_parallel: 5\n  agent_force: true\n",
+			[]Problem{{Line: 2, Message: "mapping values are not allowed in this context"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.yaml), 0644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := CheckFile(path)
+			if err != nil {
+				t.Fatalf("CheckFile() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckFile() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := CheckFile(filepath.Join(t.TempDir(), "missing.yaml")); !os.IsNotExist(err) {
+		t.Errorf("CheckFile(missing) error = %v, want not exist", err)
+	}
+}
+
+func TestProblem_String(t *testing.T) {
+	p := Problem{Line: 3, Key: "max_parallel", Message: "max_parallel must be at least 1"}
+	if got, want := p.String(), "line 3: max_parallel: max_parallel must be at least 1"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestCheckFile_DefaultConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := GenerateDefaultConfigFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := CheckFile(path); err != nil || got != nil {
+		t.Errorf("CheckFile(default config) = %v, %v, want no problems", got, err)
+	}
+}
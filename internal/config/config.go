@@ -3,23 +3,57 @@ package config
 
 import (
 	"fmt"
+	"hash/fnv"
+	"net"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/anthropic/agent-orchestrator/internal/redact"
 	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
 )
 
 // Config holds the application configuration.
-// 預設值以 DefaultConfig() 為準；設定檔與環境變數會覆寫對應欄位。
+// 預設值以 DefaultConfig() 為準；全域設定檔、專案設定檔、環境變數依序覆寫對應欄位（見 LoadProfile）。
 type Config struct {
 	// Agent settings
 
-	// AgentCommand 是呼叫 Cursor Agent 的 CLI 指令名稱或路徑。預設 "agent"。
+	// AgentCommand 是呼叫 agent（Cursor Agent、Claude Code，見 agent_backend）的 CLI 指令名稱或路徑。預設 "agent"。
 	// 何時調整：Cursor CLI 安裝在非 PATH 或使用自訂執行檔時，改為完整路徑或別名。
 	AgentCommand string `mapstructure:"agent_command"`
 
+	// AgentBackend 為 agent 的驅動：cursor（Cursor Agent CLI）、claude（Claude Code CLI）、aider（aider --message，
+	// 不自動 commit）、api（直接呼叫 Anthropic
+	// Messages API，不需安裝 CLI，見 anthropic_*）或 auto（依 agent_command 的執行檔名稱判斷，無法判斷時為 cursor）。
+	// 指定 claude、aider 且 agent_command 仍為預設的 "agent" 時改執行對應的 CLI。預設 "auto"。
+	// 何時調整：使用 Claude Code、aider 等非 Cursor 的 agent，且 agent_command 為無法辨識的包裝腳本時明確指定；
+	// 在未安裝 agent CLI 的 CI 容器中執行時設為 api。
+	AgentBackend string `mapstructure:"agent_backend"`
+
+	// AnthropicAPIKey 為 agent_backend: api 呼叫 Messages API 的 API key。未設時改用環境變數 ANTHROPIC_API_KEY。
+	// 何時調整：使用 api backend 時設定；建議以環境變數提供，避免 key 寫入設定檔。
+	AnthropicAPIKey string `mapstructure:"anthropic_api_key"`
+
+	// AnthropicAPIURL 為 Messages API 端點。預設 "https://api.anthropic.com"。
+	// 何時調整：經由公司代理或相容的閘道存取 API 時改為其網址。
+	AnthropicAPIURL string `mapstructure:"anthropic_api_url"`
+
+	// AnthropicModel 為 api backend 使用的模型。預設 "claude-sonnet-4-20250514"。
+	// 何時調整：需要更強或更便宜的模型時改為其他模型 ID。
+	AnthropicModel string `mapstructure:"anthropic_model"`
+
+	// Models 依用途指定模型，值原樣以 --model 傳給 agent CLI（api backend 則取代 anthropic_model），例如
+	// {feature-high: opus, docs: haiku, review: sonnet}。Coding 依序查找 <類型>-<複雜度>、<類型>、<複雜度>、coding；
+	// 其他 agent 使用 planning（plan、init）、analyze、review、testing、commit、enhance；皆未設定時使用 default，
+	// 再無則為 CLI 預設模型。預設為空。
+	// 何時調整：想讓複雜的 tickets 用較強的模型、文件等簡單工作用較便宜的模型時設定。
+	Models map[string]string `mapstructure:"models"`
+
 	// AgentOutputFormat 為 agent 輸出格式：text、json、stream-json。預設 "text"。
 	// 何時調整：需要程式化解析輸出時用 "json" 或 "stream-json"；一般使用 "text" 即可。
 	AgentOutputFormat string `mapstructure:"agent_output_format"`
@@ -32,6 +66,86 @@ type Config struct {
 	// 何時調整：任務較大或環境較慢時可提高；想提早中止卡住任務時可降低。
 	AgentTimeout int `mapstructure:"agent_timeout"`
 
+	// TicketTimeouts 依 ticket 的 estimated_complexity（low、medium、high）指定 work 時 coding agent 的超時，
+	// 值為 Go duration（如 5m、1h30m）；未列出的複雜度使用 agent_timeout。ticket 因逾時失敗時會記下當時的超時，
+	// 重試時提高為其兩倍（最多為設定值的兩倍）。預設 {low: 5m, medium: 15m, high: 40m}。
+	// 何時調整：tickets 經常逾時（專案大、agent 慢）時提高對應的複雜度；想讓卡住的簡單 tickets 更早中止時降低。
+	TicketTimeouts map[string]string `mapstructure:"ticket_timeouts"`
+
+	// AgentMaxRetries 為 agent 暫時性失敗（crash、被 signal 終止、api backend 的 rate limit 或連線錯誤）時的重試次數。
+	// 不論 exit code，stderr 或輸出最後一行顯示 rate limit、overloaded 或連線錯誤時也會重試；
+	// 其他非零 exit code 視為 agent 的結果，不重試；逾時也不重試。預設 2；設為 0 停用。
+	// 何時調整：網路或 API 不穩定時可提高；希望失敗立即回報時設為 0。
+	AgentMaxRetries int `mapstructure:"agent_max_retries"`
+
+	// AgentBackoff 為第一次重試前的等待秒數，之後每次加倍。預設 5。
+	// 何時調整：常遇到 rate limit 時可提高；本地 CLI 偶發 crash 時可降低。
+	AgentBackoff int `mapstructure:"agent_backoff"`
+
+	// SystemicFailureThreshold 為連續幾張 ticket 因同一類系統性錯誤（agent 無法執行、認證失效、額度用盡、網路無法連線）
+	// 失敗時，work 與 run 提早中止（不再派發新 ticket）並透過 notifications 通知。預設 3；設為 0 停用。
+	// 何時調整：大量 tickets 無人看管執行時可降低以更早停止；agent 偶有誤判為系統性錯誤的訊息時可提高。
+	SystemicFailureThreshold int `mapstructure:"systemic_failure_threshold"`
+
+	// AgentProbe 為 true 時，work 並行派發一批 tickets 前先做一次簡短的 agent 呼叫，確認認證、額度與模型可用；
+	// 預檢失敗時立即以該錯誤中止，而不是讓每張 ticket 各自失敗。預設 true。
+	// 何時調整：agent 按次計費且很少並行時可設為 false；預檢本身常因逾時誤判時也可關閉。
+	AgentProbe bool `mapstructure:"agent_probe"`
+
+	// MaxAgentCallsPerMinute 為整個程序（run、work 的所有並行 agent 共用）每分鐘最多發起的 agent 呼叫數，
+	// 超過時呼叫會排隊等待。預設 0（不限制）。
+	// 何時調整：max_parallel 較高或 run 同時啟動多個 agent 而觸發 provider rate limit 時設定。
+	MaxAgentCallsPerMinute int `mapstructure:"max_agent_calls_per_minute"`
+
+	// PromptBudgetChars 為 coding prompt 的字元上限。超過時會依序以簡短的 agent 呼叫摘要專案慣例、上次執行進度、
+	// 較早的備註，最後才是 ticket 描述（驗收標準保持原文），並在 ticket 上記錄已壓縮。預設 24000；設為 0 停用壓縮。
+	// 何時調整：agent 模型的 context 較小或 ticket 描述常貼入大量背景資料時可降低；不希望額外呼叫時設為 0。
+	PromptBudgetChars int `mapstructure:"prompt_budget_chars"`
+
+	// ReviewConventionsTop 為附加到 coding prompt「專案慣例」段落的重複審查問題數上限。
+	// review 發現的問題會記錄於 TicketsDir/review-findings.json，至少在兩次審查中出現者才會列入。預設 5；設為 0 停用。
+	// 何時調整：希望 prompt 更精簡時可降低；審查常重複指出多種問題時可提高。
+	ReviewConventionsTop int `mapstructure:"review_conventions_top"`
+
+	// ReviewBlockOn 為會阻擋 commit 的審查問題嚴重度（HIGH、MED、LOW）。run 的 review 找到這些嚴重度的問題時略過 commit 步驟；
+	// 審查輸出不是結構化 JSON（問題沒有嚴重度）時，以 CHANGES_REQUESTED 視為阻擋。預設為空（審查結果不影響 commit）。
+	// 何時調整：希望高風險問題修正前不提交時設為 [HIGH]；要求更嚴格時設為 [HIGH, MED]。
+	ReviewBlockOn []string `mapstructure:"review_block_on"`
+
+	// ReviewBatchSize 為每次審查呼叫的檔案數上限。變更的檔案較多時分批審查，最多 MaxParallel 批同時進行，
+	// 再合併結果（任一批要求修改即為要求修改，問題標註所屬檔案）。預設 10；設為 0 一次審查全部檔案。
+	// 何時調整：大量檔案的審查逾時時降低；希望 agent 看到整體變更、檔案間關聯較多時提高或設為 0。
+	ReviewBatchSize int `mapstructure:"review_batch_size"`
+
+	// MinCoverage 為測試覆蓋率的下限（百分比，0-100）。test 指令與 run 的 test 步驟會請 agent 量測 coverage
+	// （支援 go test -cover、go tool cover -func 與 pytest-cov 的輸出），低於此值或輸出沒有 coverage 時視為失敗。
+	// 預設 0（不檢查）。
+	// 何時調整：團隊有覆蓋率要求、希望 agent 新增程式碼時一併補上測試時設定，例如 70。
+	MinCoverage float64 `mapstructure:"min_coverage"`
+
+	// TestMode 決定 test 指令與 run 的 test 步驟如何執行測試：agent（由 agent 找出並執行測試指令）或 native
+	// （直接執行偵測到的專案類型的測試指令，如 go test ./...、npm test、pytest，只在測試失敗時呼叫 agent 分析原因）。
+	// 偵測不到專案類型時仍交由 agent。預設 agent。
+	// 何時調整：希望測試更快、更省 token 且結果可重現時設為 native；測試需要特殊的準備步驟、要由 agent 判斷如何執行時維持 agent。
+	TestMode string `mapstructure:"test_mode"`
+
+	// TestJUnitReports 為測試執行時可能產生的 JUnit XML 報告路徑（glob，相對 ProjectRoot）。test 執行期間寫出的報告
+	// 會取代從輸出解析的通過/失敗/略過數量；執行前就存在的舊報告會被忽略。
+	// 預設涵蓋 jest-junit（junit.xml）、test-results/、Maven surefire 與 Gradle 的預設位置。
+	// 何時調整：測試框架把報告寫到其他位置（例如 reports/junit/*.xml）時加入；不希望讀取報告時設為 []。
+	TestJUnitReports []string `mapstructure:"test_junit_reports"`
+
+	// TestRetries 為 test_mode: native 時測試失敗後重新執行的次數上限。go test 與 pytest 只重新執行失敗的測試，
+	// 其他指令整個重新執行。重新執行後通過的測試標記為不穩定（flaky）另外列出，不會使 test 失敗。預設 0（不重新執行）。
+	// 何時調整：專案有偶發失敗的測試（時序、網路、共用資源）而拖累 run 時設為 1-2；要求每次都通過時維持 0。
+	TestRetries int `mapstructure:"test_retries"`
+
+	// DefinitionOfDone 依 ticket 類型列出完成前必須滿足的條件：tests（新增或修改測試檔）、docs（新增或修改文件）、
+	// tests_pass（驗收 assertions 已執行且全數通過）。依 agent 改動的檔案與 assertion 結果檢查，未滿足時 ticket 標記為失敗；
+	// work --lenient 時僅警告。預設為空（不檢查）。
+	// 何時調整：希望功能一定附上測試與文件、修 bug 一定附回歸測試時設定，例如 feature: [tests, docs]、bugfix: [tests]。
+	DefinitionOfDone map[string][]string `mapstructure:"definition_of_done"`
+
 	// Paths（皆可為相對路徑，會依 ProjectRoot 解析為絕對路徑）
 
 	// ProjectRoot 為專案根目錄，未設時為當前工作目錄。
@@ -40,6 +154,16 @@ type Config struct {
 	// TicketsDir 為 tickets 儲存目錄。預設 ".tickets"。
 	TicketsDir string `mapstructure:"tickets_dir"`
 
+	// StoreBackend 為 tickets 儲存後端：file（每個 ticket 一個 JSON 檔，依狀態分目錄）或 sqlite（TicketsDir/tickets.db 單一資料庫）。預設 "file"。
+	// 何時調整：tickets 達數百個、或 max_parallel 較高使多個 worker 同時寫入時改用 sqlite；
+	// 切換前先執行 store migrate --from file --to sqlite 搬移既有 tickets。
+	StoreBackend string `mapstructure:"store_backend"`
+
+	// StoreIOParallelism 為 file backend 載入 tickets（status、work 挑選可處理 tickets 等）時同時讀取的檔案數。預設 1，即逐一讀取。
+	// 何時調整：tickets 達數千個、機器有多個 CPU 且位於 SSD 或網路磁碟時可提高（例如 8），
+	// 並以 go test ./internal/ticket -run '^$' -bench LoadByStatus 確認確實變快。
+	StoreIOParallelism int `mapstructure:"store_io_parallelism"`
+
 	// LogsDir 為 agent 執行日誌目錄；日誌可能含 prompt/輸出內容。預設 ".agent-logs"。
 	LogsDir string `mapstructure:"logs_dir"`
 
@@ -52,12 +176,35 @@ type Config struct {
 	// DocsDir 為文件（如 milestone）輸出目錄。預設 "docs"。
 	DocsDir string `mapstructure:"docs_dir"`
 
+	// MetricsAddr 為背景 work（work --detach）提供 Prometheus /metrics 的監聽位址（host:port），例如 127.0.0.1:9464。
+	// 預設為空（不提供）；serve 一律在自己的位址提供 /metrics。
+	// 何時調整：以 Prometheus 監控背景 work（例如長時間沒有 ticket 完成時告警）時設定。
+	MetricsAddr string `mapstructure:"metrics_addr"`
+
 	// Execution settings
 
 	// MaxParallel 為 work 指令同時執行的 agent 數量上限。預設 3。
 	// 何時調整：機器資源足夠且想加快處理時可提高；資源有限或避免過載時可降低。
 	MaxParallel int `mapstructure:"max_parallel"`
 
+	// BudgetTokensPerHour 為所有 agent 呼叫每小時可用的 token 上限（以 prompt 與輸出字元數估算，約 4 字元 1 token）。
+	// 用盡時 work 暫停派發新 ticket，額度隨時間回補後自動繼續；前景 CLI 與背景 work 共用 TicketsDir/budget.json。預設 0（不限制）。
+	// 何時調整：多個 ticket 並行、需避免短時間耗用過多額度時設定。
+	BudgetTokensPerHour int `mapstructure:"budget_tokens_per_hour"`
+
+	// BudgetCostPerHour 為每小時可用的費用上限（USD），以估算 token 數乘以 TokenPricePerMillion 計算。預設 0（不限制）。
+	// 何時調整：以金額控管用量時設定；需同時設定 token_price_per_million。
+	BudgetCostPerHour float64 `mapstructure:"budget_cost_per_hour"`
+
+	// TokenPricePerMillion 為每百萬 token 的價格（USD），用於將 token 用量換算為費用。預設 0。
+	// 何時調整：設定 budget_cost_per_hour 時，依所用模型的價格設定。
+	TokenPricePerMillion float64 `mapstructure:"token_price_per_million"`
+
+	// BudgetUSD 為單次 work 可花費的費用上限（USD），以 agent 回報（或依 TokenPricePerMillion 換算）的費用累計；
+	// 達到後不再派發新 ticket，其餘 tickets 留在 pending 並計為略過。work --max-cost 可覆寫。預設 0（不限制）。
+	// 何時調整：無人看管地處理大量 tickets（例如背景 work）時設定，避免單次執行費用失控。
+	BudgetUSD float64 `mapstructure:"budget_usd"`
+
 	// DryRun 為是否僅模擬不實際呼叫 agent。
 	DryRun bool `mapstructure:"dry_run"`
 
@@ -70,6 +217,27 @@ type Config struct {
 	// Quiet 為是否減少一般輸出。
 	Quiet bool `mapstructure:"quiet"`
 
+	// LogFormat 為診斷日誌（agent 呼叫、重試、各 ticket 步驟，輸出到 stderr）的格式：text 或 json。
+	// 日誌等級依 --verbose（info）、--debug（debug）與 --quiet（僅 error），預設只輸出 warning 以上。
+	// work --detach 的背景程序一律以 json 寫入日誌檔。--log-format 可覆寫。預設 "text"。
+	// 何時調整：要將日誌交給 jq 或日誌收集系統處理時設為 "json"。
+	LogFormat string `mapstructure:"log_format"`
+
+	// Theme 為終端機輸出的配色：default、colorblind（Okabe–Ito 色盲友善配色，成功與失敗不靠紅綠區分）
+	// 或 monochrome（不使用顏色）。預設 "default"。設定環境變數 NO_COLOR 時一律使用 monochrome。
+	// 何時調整：有紅綠色盲、或終端機背景使預設配色難以辨識時用 "colorblind"；輸出會被轉存或終端機不支援顏色時用 "monochrome"。
+	Theme string `mapstructure:"theme"`
+
+	// ThemeColors 覆寫 Theme 的個別顏色，鍵為 primary、success、warning、error、info、muted、highlight、background，
+	// 值為十六進位色碼（#rgb、#rrggbb）或 ANSI 256 色碼（0-255），例如 {success: "#00A0FF"}。預設為空。
+	// 何時調整：內建配色與終端機配色衝突、或想配合團隊慣用顏色時設定。
+	ThemeColors map[string]string `mapstructure:"theme_colors"`
+
+	// Language 為 CLI 輸出與 agent prompt 的語言：zh-TW、en，或 .agent-orchestrator/locales/<lang>.yaml
+	// 語系檔新增的語言。預設 "zh-TW"。全域旗標 --lang 會覆寫此設定。
+	// 何時調整：團隊不使用中文、或希望 agent 以英文撰寫程式碼註解與 commit 訊息時設為 "en"。
+	Language string `mapstructure:"language"`
+
 	// Security settings
 
 	// DisableDetailedLog 為是否停用「詳細日誌」：停用後不會在 LogsDir 寫入含 prompt 與 agent 輸出的日誌檔。
@@ -77,55 +245,304 @@ type Config struct {
 	// 何時調整：在含機密或專屬程式碼的環境、或需符合資安/合規要求時，建議設為 true。
 	DisableDetailedLog bool `mapstructure:"disable_detailed_log"`
 
+	// LogRedactionPatterns 調整遮蔽敏感資料（API key、token、密碼等）的規則，遮蔽套用於 agent 日誌、
+	// work --detach 的日誌與 ticket 檔案中的 agent 輸出。每個項目是額外的正規表示式；"-<名稱>" 停用一條
+	// 內建規則（api_key、access_token、aws_credentials、aws_access_key_id、password、private_key、
+	// github_token、secret），"-defaults" 停用全部內建規則。預設空（只用內建規則）。
+	// 何時調整：專案有內建規則認不出的憑證格式（例如內部 token 前綴）時新增規則；內建規則誤遮蔽正常輸出時停用該條。
+	LogRedactionPatterns []string `mapstructure:"log_redaction_patterns"`
+
+	// AuditIdentity 為記錄在稽核紀錄（TicketsDir/audit.jsonl）中的操作者身分，例如 email 或員工編號。
+	// 每次 agent 呼叫都會記錄 OS 使用者、此身分、指令列與設定快照雜湊；未設時僅記錄 OS 使用者。
+	// 何時調整：多人共用機器或帳號、或需符合稽核要求時，設為可辨識個人的身分（亦可用環境變數 AGENT_ORCHESTRATOR_AUDIT_IDENTITY）。
+	AuditIdentity string `mapstructure:"audit_identity"`
+
+	// Git settings
+
+	// GitBranchPerTicket 為是否每個 ticket 在自己的分支上處理：coding 前建立（或切換到）ticket/<ticket ID>-<標題> 分支並記錄在 ticket，
+	// commit 時切回該分支提交。分支一律由該次 work/run 開始時的 HEAD 建立，不會疊在前一個 ticket 的分支上；
+	// 因共用同一工作目錄，啟用時 work 會逐一處理 tickets。預設 false。
+	// 何時調整：希望每個 ticket 各自成為一個分支（方便逐一開 PR 或審查）時設為 true。
+	GitBranchPerTicket bool `mapstructure:"git_branch_per_ticket"`
+
+	// GitWorktrees 為是否讓每個 ticket 在獨立的 git worktree 中處理：worktree 由專案目前的工作目錄（含未提交變更）建立，
+	// 完成後將變更套用回專案工作目錄並移除 worktree；套用衝突時 ticket 標記為失敗並保留 worktree 供檢查。預設 false。
+	// 何時調整：max_parallel 大於 1 且多個 agent 會修改相同檔案、彼此干擾時設為 true。
+	GitWorktrees bool `mapstructure:"git_worktrees"`
+
+	// GitWorktreeDir 為 git_worktrees 建立 worktree 的目錄。預設為空，表示系統暫存目錄下依專案區分的子目錄。
+	// 何時調整：暫存目錄空間不足、或希望 worktree 放在固定位置時設定；須位於專案之外或已被 .gitignore 忽略。
+	GitWorktreeDir string `mapstructure:"git_worktree_dir"`
+
+	// GitHub settings
+
+	// GitHubToken 為 import github 與 pr 呼叫 GitHub API 使用的 token。未設時改用環境變數 GITHUB_TOKEN（亦可用 AGENT_ORCHESTRATOR_GITHUB_TOKEN）。
+	// 何時調整：匯入私有 repo 的 issues、或公開 repo 遇到匿名請求的速率限制時設定；建議以環境變數提供，避免 token 寫入設定檔。
+	GitHubToken string `mapstructure:"github_token"`
+
+	// GitHubAPIURL 為 GitHub REST API 端點。預設 "https://api.github.com"。
+	// 何時調整：使用 GitHub Enterprise Server 時改為 https://<host>/api/v3。
+	GitHubAPIURL string `mapstructure:"github_api_url"`
+
+	// GitHubSync 為是否將從 GitHub 匯入之 tickets 的進度同步回原 issue：完成時留言 agent 輸出並關閉 issue、
+	// 失敗時留言錯誤、commit 時留言 commit SHA。需要具寫入權限的 token。預設 true。
+	// 何時調整：只想單向匯入、不希望工具在 issue 上留言或關閉 issue 時設為 false。
+	GitHubSync bool `mapstructure:"github_sync"`
+
+	// GitLab settings
+
+	// GitLabToken 為 pr 於 GitLab 建立 merge request 使用的 token（需 api 權限）。未設時改用環境變數 GITLAB_TOKEN。
+	// 何時調整：專案的 origin 為 GitLab 且要使用 pr 指令時設定；建議以環境變數提供，避免 token 寫入設定檔。
+	GitLabToken string `mapstructure:"gitlab_token"`
+
+	// GitLabAPIURL 為 GitLab REST API 端點。預設 "https://gitlab.com/api/v4"。origin 的主機與此端點相同、
+	// 或主機名稱含 "gitlab" 時，pr 會建立 GitLab merge request，否則建立 GitHub pull request。
+	// 何時調整：使用自架 GitLab 時改為 https://<host>/api/v4。
+	GitLabAPIURL string `mapstructure:"gitlab_api_url"`
+
+	// PRBaseBranch 為 pr 建立 pull request 的目標分支。預設為空，表示 repo 的預設分支。
+	// 何時調整：tickets 應合併到 develop 等非預設分支時設定（亦可用 pr --base 指定單次）。
+	PRBaseBranch string `mapstructure:"pr_base_branch"`
+
+	// Jira settings
+
+	// Jira 為 import jira 的連線與欄位對應設定（設定檔中的 jira: 區段）。
+	// 何時調整：從 Jira 匯入 tickets 時至少設定 jira.url；自訂優先級、issue 類型或 link 類型時設定對應表。
+	Jira JiraConfig `mapstructure:"jira"`
+
+	// Notifications 為通知設定（設定檔中的 notifications: 區段）：run 與背景 work 結束時的摘要、同一 ticket 連續失敗，
+	// 以及需要人工處理的事件（例如連續系統性失敗而中止）。
+	// 何時調整：背景或無人看管執行時，設定通知以便及時處理。
+	Notifications NotificationsConfig `mapstructure:"notifications"`
+
+	// Policy 為加在每個 agent prompt 開頭的安全與資料處理政策（設定檔中的 policy: 區段）。
+	// 何時調整：組織要求 agent 遵守資料處理規範（例如不得將資料送往外部服務、輸出不得含機密）時設定。
+	Policy PolicyConfig `mapstructure:"policy"`
+
+	// Escalation 為阻塞多張 tickets 的 ticket 提升有效優先級的規則（設定檔中的 escalation: 區段）。
+	// 何時調整：瓶頸 ticket 常因原始優先級較低而延後，或希望完全依原始優先級排程時。
+	Escalation EscalationConfig `mapstructure:"escalation"`
+
+	// ServeTokens 為 serve API 接受的 tokens（設定檔中的 serve_tokens: 清單）；設定後每個 API 請求都需帶上其中之一，
+	// 並依 token 的權限範圍限制可用的端點，API 觸發的操作與被拒絕的請求記錄於 TicketsDir/api-audit.jsonl。未設定時不驗證。
+	// 何時調整：serve 監聽非本機位址，或需要區分 dashboard 唯讀、CI 觸發 work 與管理者等不同使用者時設定。
+	ServeTokens []ServeToken `mapstructure:"serve_tokens"`
+
+	// Update settings
+
+	// UpdateReleaseURL 為 self-update / version --check 查詢最新 release 的端點（GitHub Releases API 格式）。
+	// 只能由環境變數或全域設定檔設定；專案設定檔與 profiles 中的值會被忽略，以免 clone 的 repo 指定下載來源。
+	// 下載的執行檔另需通過內建公鑰的簽章驗證（見 update.PublicKey）。
+	// 何時調整：於內部鏡像或 fork 發布時，改為對應的 releases/latest URL（release 須以相同金鑰簽章）。
+	UpdateReleaseURL string `mapstructure:"update_release_url"`
+
 	// Analyze settings
 
 	// AnalyzeScopes 為 analyze 指令的預設分析範圍。預設 ["all"] 表示所有面向。
 	// 可選值：performance、refactor、security、test、docs、all。指令列 --scope 會覆寫此預設。
 	// 何時調整：若經常只分析部分面向（例如僅 performance,security），可在此設定以省去每次下 --scope。
 	AnalyzeScopes []string `mapstructure:"analyze_scopes"`
+
+	// Profiles
+
+	// Profiles 為具名的設定組合，例如 fast（便宜、快速）與 thorough（完整審查流程）。每個 profile 是一組頂層設定，
+	// 以 --profile 或 profile 選用時覆寫設定檔中的同名設定（區段如 escalation 只覆寫列出的鍵）；環境變數與指令旗標仍優先。
+	// profile 內不可再設定 profile 或 profiles。
+	// 何時調整：需要在不同情境（日常小改、發版前完整檢查、CI）切換多個設定而不想每次編輯檔案時。
+	Profiles map[string]map[string]any `mapstructure:"profiles"`
+
+	// Profile 為未指定 --profile 時套用的 profile 名稱（須存在於 profiles）。預設空字串，不套用。
+	// 亦可以 AGENT_ORCHESTRATOR_PROFILE 環境變數指定。
+	// 何時調整：多數時候都使用同一個 profile 時設為其名稱，其餘情境再以 --profile 切換。
+	Profile string `mapstructure:"profile"`
 }
 
+// JiraConfig 為 Jira 連線與欄位對應設定。對應表只需列出要新增或覆寫的項目，
+// 未列出者沿用內建對應（Highest→1 … Lowest→5；Bug→bugfix、Story/Task→feature 等；Blocks link）。
+type JiraConfig struct {
+	// URL 為 Jira 網址，例如 https://example.atlassian.net。
+	URL string `mapstructure:"url"`
+
+	// Email 為 Jira Cloud 帳號 email，與 Token 以 basic auth 驗證；未設時 Token 以 Bearer 傳送（Server/Data Center 的 personal access token）。
+	Email string `mapstructure:"email"`
+
+	// Token 為 API token。未設時改用環境變數 JIRA_API_TOKEN。
+	// 何時調整：建議以環境變數提供，避免 token 寫入設定檔。
+	Token string `mapstructure:"token"`
+
+	// PriorityMap 將 Jira 優先級名稱（不分大小寫）對應到 ticket 優先級 1-5。
+	// 何時調整：專案使用自訂優先級（例如 P0、P1）時。
+	PriorityMap map[string]int `mapstructure:"priority_map"`
+
+	// TypeMap 將 Jira issue 類型名稱（不分大小寫）對應到 ticket 類型（feature、bugfix、docs 等）。
+	// 何時調整：專案使用自訂 issue 類型（例如 Spike、Tech Debt）時。
+	TypeMap map[string]string `mapstructure:"type_map"`
+
+	// DependencyLinks 為視為依賴的 link 類型名稱：issue「is blocked by」另一 issue 時，後者成為依賴。預設 ["Blocks"]。
+	// 何時調整：專案以其他 link 類型（例如 Depends）表示先後順序時。
+	DependencyLinks []string `mapstructure:"dependency_links"`
+}
+
+// NotificationsConfig 為通知的目的地設定；未設定任何目的地時不發送通知。
+type NotificationsConfig struct {
+	// Command 為每個事件執行的 shell 指令；事件以 JSON 由 stdin 傳入，並設定環境變數 AO_EVENT、AO_TITLE、AO_MESSAGE。
+	// 何時調整：以既有工具（例如 curl 呼叫 webhook、notify-send、mail）接收通知時設定。
+	Command string `mapstructure:"command"`
+
+	// SlackWebhook 為 Slack incoming webhook URL；設定後事件也以格式化訊息（標題、摘要、數量、失敗的 ticket IDs、日誌路徑）
+	// 發到對應的頻道。未設時改用環境變數 SLACK_WEBHOOK_URL。
+	// 何時調整：團隊以 Slack 追蹤 run 結果與背景 work 時設定；建議以環境變數提供，避免 URL 寫入設定檔。
+	SlackWebhook string `mapstructure:"slack_webhook"`
+
+	// RepeatedFailures 為同一張 ticket 連續失敗幾次時發送通知（之後每再連續失敗同樣次數再通知一次）。預設 3；設為 0 停用。
+	// 何時調整：希望第一次重試失敗就收到通知時設為 2；重試頻繁、通知過多時可提高。
+	RepeatedFailures int `mapstructure:"repeated_failures"`
+
+	// ServeURL 為 serve 對外的網址（例如 https://ao.example.com）；設定後失敗通知附上每張失敗 ticket 的重試連結，
+	// 點開後在 serve 上確認即可重試，不需 API token。連結以 TicketsDir/.retry-key 的金鑰簽章，只對該 ticket 有效，24 小時後過期。
+	// 預設為空，不附連結。
+	// 何時調整：在 Slack 等處收到失敗通知、希望直接重試時設定；serve 必須能由開啟連結的裝置連到（監聽非本機位址時需設定 serve_tokens）。
+	ServeURL string `mapstructure:"serve_url"`
+}
+
+// PolicyConfig 為 prompt 政策前言。每次 agent 呼叫都會在 agent 日誌與稽核紀錄（audit）中記錄
+// 政策版本與內容雜湊，以證明該次呼叫已套用政策。
+type PolicyConfig struct {
+	// Preamble 為政策全文，原樣加在每個 prompt（coding、review、planning、commit 等）之前。預設為空，不注入。
+	// 何時調整：組織有統一的 agent 使用規範時設定；多行文字可用 YAML 的 | 區塊。
+	Preamble string `mapstructure:"preamble"`
+
+	// Version 為政策版本標記（例如 "2024-06" 或 "v3"），與內容雜湊一起記錄。預設為空。
+	// 何時調整：每次修改 Preamble 時更新，稽核時即可對應到政策文件的版本。
+	Version string `mapstructure:"version"`
+}
+
+// EscalationConfig 為優先級提升規則。有 Threshold 張以上 pending tickets 在等待某張 ticket 時，
+// work 與 run 排程時將其有效優先級提升 Step 級（每多 Threshold 張再提升一次，最高到 1）；
+// ticket 上儲存的優先級不變。status 會標示「解除 N 張 tickets 的阻塞」。
+type EscalationConfig struct {
+	// Threshold 為觸發提升所需的等待 tickets 數。預設 3；設為 0 停用。
+	// 何時調整：tickets 相依關係較密時可提高，避免大部分 tickets 都被提升；希望更積極清除瓶頸時可降低。
+	Threshold int `mapstructure:"threshold"`
+
+	// Step 為每達到一次 Threshold 提升的優先級數。預設 1。
+	// 何時調整：希望瓶頸直接排到最前面時可提高（例如 4）。
+	Step int `mapstructure:"step"`
+
+	// Transitive 為 true 時也計入間接等待的 tickets（例如 C 依賴 B、B 依賴 A，則 A 解除 2 張）。預設 true。
+	// 何時調整：只想依直接依賴者計算時設為 false。
+	Transitive bool `mapstructure:"transitive"`
+}
+
+// ServeToken 為一個 serve API token。
+type ServeToken struct {
+	// Name 用於稽核紀錄中識別 token（例如 dashboard、ci），不可重複。
+	Name string `mapstructure:"name"`
+
+	// Token 為用戶端以 Authorization: Bearer <token> 送出的密鑰，不可重複。
+	// 何時調整：建議使用 openssl rand -hex 32 等隨機產生的長字串，外洩時更換。
+	Token string `mapstructure:"token"`
+
+	// Scope 為權限範圍：read（讀取狀態、tickets、jobs 與日誌）、work（另可觸發 work）、
+	// admin（另可建立 tickets 與觸發 plan）。
+	Scope string `mapstructure:"scope"`
+}
+
+// ServeTokenScopes 為 ServeToken.Scope 可用的值，權限由小到大。
+var ServeTokenScopes = []string{"read", "work", "admin"}
+
+// DefaultUpdateReleaseURL 為預設的 release 查詢端點。
+const DefaultUpdateReleaseURL = "https://api.github.com/repos/kokjohn0824/agent_orchestrator/releases/latest"
+
+// DefaultGitHubAPIURL 為預設的 GitHub REST API 端點。
+const DefaultGitHubAPIURL = "https://api.github.com"
+
+// DefaultAnthropicAPIURL 為預設的 Anthropic Messages API 端點。
+const DefaultAnthropicAPIURL = "https://api.anthropic.com"
+
+// DefaultGitLabAPIURL 為預設的 GitLab REST API 端點。
+const DefaultGitLabAPIURL = "https://gitlab.com/api/v4"
+
 // DefaultConfig 回傳預設設定，為本套件中「預設值」的單一來源；
 // Load 會先以此為基底，再以設定檔與環境變數覆寫。
 func DefaultConfig() *Config {
 	cwd, _ := os.Getwd()
 	return &Config{
-		AgentCommand:       "agent",
-		AgentOutputFormat:  "text",
-		AgentForce:         true,
-		AgentTimeout:       600,
-		ProjectRoot:        cwd,
-		TicketsDir:         ".tickets",
-		LogsDir:            ".agent-logs",
-		WorkDetachLogDir:   "",
-		WorkPIDFile:        "",
-		DocsDir:            "docs",
-		MaxParallel:        3,
-		DryRun:             false,
-		Verbose:            false,
-		Debug:              false,
-		Quiet:              false,
-		DisableDetailedLog: false,
-		AnalyzeScopes:      []string{"all"},
+		AgentCommand:             "agent",
+		AgentBackend:             "auto",
+		AnthropicAPIURL:          DefaultAnthropicAPIURL,
+		AnthropicModel:           "claude-sonnet-4-20250514",
+		AgentOutputFormat:        "text",
+		AgentForce:               true,
+		AgentTimeout:             600,
+		TicketTimeouts:           map[string]string{"low": "5m", "medium": "15m", "high": "40m"},
+		AgentMaxRetries:          2,
+		SystemicFailureThreshold: 3,
+		AgentProbe:               true,
+		AgentBackoff:             5,
+		PromptBudgetChars:        24000,
+		ReviewConventionsTop:     5,
+		ReviewBatchSize:          10,
+		TestMode:                 "agent",
+		TestJUnitReports:         []string{"junit.xml", "test-results/*.xml", "target/surefire-reports/*.xml", "build/test-results/test/*.xml"},
+		ProjectRoot:              cwd,
+		TicketsDir:               ".tickets",
+		StoreBackend:             "file",
+		StoreIOParallelism:       1,
+		LogsDir:                  ".agent-logs",
+		WorkDetachLogDir:         "",
+		WorkPIDFile:              "",
+		DocsDir:                  "docs",
+		MaxParallel:              3,
+		DryRun:                   false,
+		Verbose:                  false,
+		Debug:                    false,
+		Quiet:                    false,
+		LogFormat:                "text",
+		Theme:                    "default",
+		Language:                 "zh-TW",
+		DisableDetailedLog:       false,
+		AnalyzeScopes:            []string{"all"},
+		GitHubAPIURL:             DefaultGitHubAPIURL,
+		GitHubSync:               true,
+		GitLabAPIURL:             DefaultGitLabAPIURL,
+		UpdateReleaseURL:         DefaultUpdateReleaseURL,
+		Escalation:               EscalationConfig{Threshold: 3, Step: 1, Transitive: true},
+		Notifications:            NotificationsConfig{RepeatedFailures: 3},
 	}
 }
 
+// EnvPrefix prefixes the environment variables that override config keys: a key
+// maps to EnvPrefix + "_" + the upper-cased key, with "." as "_"
+// (max_parallel → AGENT_ORCHESTRATOR_MAX_PARALLEL, jira.url → AGENT_ORCHESTRATOR_JIRA_URL).
+// Lists are comma-separated; maps and lists of sections are JSON or YAML.
+const EnvPrefix = "AGENT_ORCHESTRATOR"
+
 // Load loads configuration from files and environment
 func Load() (*Config, error) {
+	return LoadProfile("")
+}
+
+// LoadProfile is Load with the named profile applied over the config file (see
+// Profiles); an empty name applies the profile setting, if any.
+//
+// Precedence, highest first: command-line flags (applied by the caller),
+// environment variables (see EnvPrefix), the project file ./.agent-orchestrator.yaml,
+// the global file in the home directory, defaults. The project file only overrides
+// the keys it sets; the rest of the global file still applies.
+func LoadProfile(profile string) (*Config, error) {
 	cfg := DefaultConfig()
 
 	v := viper.New()
-	v.SetConfigName(".agent-orchestrator")
 	v.SetConfigType("yaml")
 
-	// Search paths
-	v.AddConfigPath(".")                         // Current directory
-	v.AddConfigPath("$HOME")                     // Home directory
-	v.AddConfigPath("$HOME/.config/agent-orchestrator") // XDG config
-
 	// Environment variables
-	v.SetEnvPrefix("AGENT_ORCHESTRATOR")
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	v.AutomaticEnv()
+	for _, key := range Keys() {
+		v.BindEnv(key)
+	}
 
 	// Bind specific env vars for backward compatibility
 	v.BindEnv("agent_command", "AGENT_CMD")
@@ -134,29 +551,101 @@ func Load() (*Config, error) {
 
 	// Set defaults
 	v.SetDefault("agent_command", cfg.AgentCommand)
+	v.SetDefault("agent_backend", cfg.AgentBackend)
+	v.SetDefault("anthropic_api_key", cfg.AnthropicAPIKey)
+	v.SetDefault("anthropic_api_url", cfg.AnthropicAPIURL)
+	v.SetDefault("anthropic_model", cfg.AnthropicModel)
 	v.SetDefault("agent_output_format", cfg.AgentOutputFormat)
 	v.SetDefault("agent_force", cfg.AgentForce)
 	v.SetDefault("agent_timeout", cfg.AgentTimeout)
+	v.SetDefault("ticket_timeouts", cfg.TicketTimeouts)
+	v.SetDefault("agent_max_retries", cfg.AgentMaxRetries)
+	v.SetDefault("systemic_failure_threshold", cfg.SystemicFailureThreshold)
+	v.SetDefault("agent_probe", cfg.AgentProbe)
+	v.SetDefault("agent_backoff", cfg.AgentBackoff)
+	v.SetDefault("max_agent_calls_per_minute", cfg.MaxAgentCallsPerMinute)
+	v.SetDefault("prompt_budget_chars", cfg.PromptBudgetChars)
+	v.SetDefault("review_conventions_top", cfg.ReviewConventionsTop)
+	v.SetDefault("review_block_on", cfg.ReviewBlockOn)
+	v.SetDefault("review_batch_size", cfg.ReviewBatchSize)
+	v.SetDefault("min_coverage", cfg.MinCoverage)
+	v.SetDefault("test_mode", cfg.TestMode)
+	v.SetDefault("test_junit_reports", cfg.TestJUnitReports)
+	v.SetDefault("test_retries", cfg.TestRetries)
 	v.SetDefault("tickets_dir", cfg.TicketsDir)
+	v.SetDefault("store_backend", cfg.StoreBackend)
+	v.SetDefault("store_io_parallelism", cfg.StoreIOParallelism)
 	v.SetDefault("logs_dir", cfg.LogsDir)
 	v.SetDefault("work_detach_log_dir", cfg.WorkDetachLogDir)
 	v.SetDefault("work_pid_file", cfg.WorkPIDFile)
 	v.SetDefault("docs_dir", cfg.DocsDir)
+	v.SetDefault("metrics_addr", cfg.MetricsAddr)
 	v.SetDefault("max_parallel", cfg.MaxParallel)
+	v.SetDefault("budget_tokens_per_hour", cfg.BudgetTokensPerHour)
+	v.SetDefault("budget_cost_per_hour", cfg.BudgetCostPerHour)
+	v.SetDefault("token_price_per_million", cfg.TokenPricePerMillion)
+	v.SetDefault("budget_usd", cfg.BudgetUSD)
+	v.SetDefault("log_format", cfg.LogFormat)
+	v.SetDefault("theme", cfg.Theme)
+	v.SetDefault("language", cfg.Language)
 	v.SetDefault("disable_detailed_log", cfg.DisableDetailedLog)
+	v.SetDefault("log_redaction_patterns", cfg.LogRedactionPatterns)
 	v.SetDefault("analyze_scopes", cfg.AnalyzeScopes)
-
-	// Try to read config file (don't fail if not found)
-	if err := v.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			return nil, fmt.Errorf("error reading config file: %w", err)
+	v.SetDefault("update_release_url", cfg.UpdateReleaseURL)
+	v.SetDefault("audit_identity", cfg.AuditIdentity)
+	v.SetDefault("git_branch_per_ticket", cfg.GitBranchPerTicket)
+	v.SetDefault("git_worktrees", cfg.GitWorktrees)
+	v.SetDefault("git_worktree_dir", cfg.GitWorktreeDir)
+	v.SetDefault("github_token", cfg.GitHubToken)
+	v.SetDefault("github_api_url", cfg.GitHubAPIURL)
+	v.SetDefault("github_sync", cfg.GitHubSync)
+	v.SetDefault("gitlab_token", cfg.GitLabToken)
+	v.SetDefault("gitlab_api_url", cfg.GitLabAPIURL)
+	v.SetDefault("pr_base_branch", cfg.PRBaseBranch)
+	v.SetDefault("notifications.command", cfg.Notifications.Command)
+	v.SetDefault("notifications.slack_webhook", cfg.Notifications.SlackWebhook)
+	v.SetDefault("notifications.repeated_failures", cfg.Notifications.RepeatedFailures)
+	v.SetDefault("notifications.serve_url", cfg.Notifications.ServeURL)
+	v.SetDefault("policy.preamble", cfg.Policy.Preamble)
+	v.SetDefault("policy.version", cfg.Policy.Version)
+	v.SetDefault("escalation.threshold", cfg.Escalation.Threshold)
+	v.SetDefault("escalation.step", cfg.Escalation.Step)
+	v.SetDefault("escalation.transitive", cfg.Escalation.Transitive)
+	v.SetDefault("jira.url", cfg.Jira.URL)
+	v.SetDefault("jira.email", cfg.Jira.Email)
+	v.SetDefault("jira.token", cfg.Jira.Token)
+
+	// Read the config files that exist, the project file last so it wins
+	var updateReleaseURL string
+	for _, path := range ConfigFiles() {
+		if !filepath.IsAbs(path) {
+			// The project file comes with the repository, so it must not choose where
+			// self-update downloads binaries from: keep the value set so far.
+			updateReleaseURL = v.GetString("update_release_url")
 		}
+		v.SetConfigFile(path)
+		if err := v.MergeInConfig(); err != nil {
+			return nil, fmt.Errorf("error reading config file %s: %w", path, err)
+		}
+	}
+	if updateReleaseURL == "" {
+		updateReleaseURL = v.GetString("update_release_url")
+	}
+	if err := setEncodedEnv(v); err != nil {
+		return nil, err
+	}
+	if profile == "" {
+		profile = v.GetString("profile")
+	}
+	if err := applyProfile(v, profile); err != nil {
+		return nil, err
 	}
 
 	// Unmarshal to struct
 	if err := v.Unmarshal(cfg); err != nil {
 		return nil, fmt.Errorf("error unmarshaling config: %w", err)
 	}
+	cfg.UpdateReleaseURL = updateReleaseURL
 
 	// Resolve relative paths
 	cfg.resolvePaths()
@@ -164,6 +653,114 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// ConfigFiles returns the config files Load reads, lowest precedence first: the
+// global file (see GlobalConfigFilePath; ~/.agent-orchestrator.yaml is still read
+// when it does not exist) as an absolute path and the project file in the current
+// directory as a relative one.
+func ConfigFiles() []string {
+	var files []string
+	candidates := []string{GlobalConfigFilePath()}
+	if home, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates,
+			filepath.Join(home, ".agent-orchestrator.yaml"),
+			filepath.Join(home, ".config", "agent-orchestrator", ".agent-orchestrator.yaml"),
+		)
+	}
+	for _, path := range candidates {
+		if path != "" && isFile(path) {
+			files = append(files, path)
+			break
+		}
+	}
+	for _, path := range []string{".agent-orchestrator.yaml", ".agent-orchestrator.yml"} {
+		if !isFile(path) {
+			continue
+		}
+		// Run from the home directory, the project file is the global file.
+		if abs, err := filepath.Abs(path); err != nil || len(files) == 0 || abs != files[0] {
+			files = append(files, path)
+		}
+		break
+	}
+	return files
+}
+
+// GlobalConfigFilePath returns the user's global config file,
+// $XDG_CONFIG_HOME/agent-orchestrator/config.yaml (~/.config when XDG_CONFIG_HOME is
+// unset), whether or not it exists; empty when there is no home directory.
+func GlobalConfigFilePath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "agent-orchestrator", "config.yaml")
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// setEncodedEnv sets the keys of encodedEnvKeys from their environment variables,
+// decoded as YAML (JSON is valid YAML). Values set this way win over the config
+// files and profiles, as other environment variables do.
+func setEncodedEnv(v *viper.Viper) error {
+	c := DefaultConfig()
+	for _, key := range encodedEnvKeys() {
+		name := EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		var decoded any
+		if err := yaml.Unmarshal([]byte(value), &decoded); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+		field, _ := c.field(key)
+		if want, got := field.Kind(), reflect.ValueOf(decoded).Kind(); want != reflect.Interface && got != want {
+			return fmt.Errorf("invalid %s: want a JSON or YAML %s, got %s", name, want, got)
+		}
+		v.Set(key, decoded)
+	}
+	return nil
+}
+
+// applyProfile merges the settings of the named profile into v.
+func applyProfile(v *viper.Viper, name string) error {
+	if name == "" {
+		return nil
+	}
+	profiles := v.GetStringMap("profiles")
+	settings, ok := profiles[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, 0, len(profiles))
+		for n := range profiles {
+			names = append(names, n)
+		}
+		slices.Sort(names)
+		return fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(names, ", "))
+	}
+	m, ok := settings.(map[string]any)
+	if !ok {
+		return fmt.Errorf("profile %q must be a section of settings", name)
+	}
+	if _, ok := m["profile"]; ok {
+		return fmt.Errorf("profile %q cannot set profile", name)
+	}
+	if _, ok := m["profiles"]; ok {
+		return fmt.Errorf("profile %q cannot set profiles", name)
+	}
+	if err := v.MergeConfigMap(m); err != nil {
+		return err
+	}
+	v.Set("profile", name)
+	return nil
+}
+
 // resolvePaths converts relative paths to absolute paths
 func (c *Config) resolvePaths() {
 	if c.ProjectRoot == "" {
@@ -182,6 +779,10 @@ func (c *Config) resolvePaths() {
 		c.WorkDetachLogDir = filepath.Join(c.ProjectRoot, c.WorkDetachLogDir)
 	}
 
+	if c.GitWorktreeDir != "" && !filepath.IsAbs(c.GitWorktreeDir) {
+		c.GitWorktreeDir = filepath.Join(c.ProjectRoot, c.GitWorktreeDir)
+	}
+
 	if c.WorkPIDFile != "" && !filepath.IsAbs(c.WorkPIDFile) {
 		c.WorkPIDFile = filepath.Join(c.ProjectRoot, c.WorkPIDFile)
 	}
@@ -203,17 +804,110 @@ func (c *Config) Save(path string) error {
 	v := viper.New()
 
 	v.Set("agent_command", c.AgentCommand)
+	v.Set("agent_backend", c.AgentBackend)
+	v.Set("anthropic_api_key", c.AnthropicAPIKey)
+	v.Set("anthropic_api_url", c.AnthropicAPIURL)
+	v.Set("anthropic_model", c.AnthropicModel)
 	v.Set("agent_output_format", c.AgentOutputFormat)
 	v.Set("agent_force", c.AgentForce)
 	v.Set("agent_timeout", c.AgentTimeout)
+	v.Set("ticket_timeouts", c.TicketTimeouts)
+	v.Set("agent_max_retries", c.AgentMaxRetries)
+	v.Set("systemic_failure_threshold", c.SystemicFailureThreshold)
+	v.Set("agent_probe", c.AgentProbe)
+	v.Set("agent_backoff", c.AgentBackoff)
+	v.Set("max_agent_calls_per_minute", c.MaxAgentCallsPerMinute)
+	v.Set("prompt_budget_chars", c.PromptBudgetChars)
+	v.Set("review_conventions_top", c.ReviewConventionsTop)
+	v.Set("review_block_on", c.ReviewBlockOn)
+	v.Set("review_batch_size", c.ReviewBatchSize)
+	v.Set("min_coverage", c.MinCoverage)
+	v.Set("test_mode", c.TestMode)
+	v.Set("test_junit_reports", c.TestJUnitReports)
+	v.Set("test_retries", c.TestRetries)
 	v.Set("tickets_dir", c.TicketsDir)
+	v.Set("store_backend", c.StoreBackend)
+	v.Set("store_io_parallelism", c.StoreIOParallelism)
 	v.Set("logs_dir", c.LogsDir)
 	v.Set("work_detach_log_dir", c.WorkDetachLogDir)
 	v.Set("work_pid_file", c.WorkPIDFile)
 	v.Set("docs_dir", c.DocsDir)
+	v.Set("metrics_addr", c.MetricsAddr)
 	v.Set("max_parallel", c.MaxParallel)
+	v.Set("budget_tokens_per_hour", c.BudgetTokensPerHour)
+	v.Set("budget_cost_per_hour", c.BudgetCostPerHour)
+	v.Set("token_price_per_million", c.TokenPricePerMillion)
+	v.Set("budget_usd", c.BudgetUSD)
+	v.Set("log_format", c.LogFormat)
+	v.Set("theme", c.Theme)
+	if len(c.ThemeColors) > 0 {
+		v.Set("theme_colors", c.ThemeColors)
+	}
+	v.Set("language", c.Language)
 	v.Set("disable_detailed_log", c.DisableDetailedLog)
+	if len(c.LogRedactionPatterns) > 0 {
+		v.Set("log_redaction_patterns", c.LogRedactionPatterns)
+	}
 	v.Set("analyze_scopes", c.AnalyzeScopes)
+	v.Set("update_release_url", c.UpdateReleaseURL)
+	v.Set("audit_identity", c.AuditIdentity)
+	v.Set("git_branch_per_ticket", c.GitBranchPerTicket)
+	v.Set("git_worktrees", c.GitWorktrees)
+	v.Set("git_worktree_dir", c.GitWorktreeDir)
+	v.Set("github_token", c.GitHubToken)
+	v.Set("github_api_url", c.GitHubAPIURL)
+	v.Set("github_sync", c.GitHubSync)
+	v.Set("gitlab_token", c.GitLabToken)
+	v.Set("gitlab_api_url", c.GitLabAPIURL)
+	v.Set("pr_base_branch", c.PRBaseBranch)
+	if c.Notifications.Command != "" {
+		v.Set("notifications.command", c.Notifications.Command)
+	}
+	if c.Notifications.SlackWebhook != "" {
+		v.Set("notifications.slack_webhook", c.Notifications.SlackWebhook)
+	}
+	v.Set("notifications.repeated_failures", c.Notifications.RepeatedFailures)
+	if c.Notifications.ServeURL != "" {
+		v.Set("notifications.serve_url", c.Notifications.ServeURL)
+	}
+	if c.Policy.Preamble != "" {
+		v.Set("policy.preamble", c.Policy.Preamble)
+		v.Set("policy.version", c.Policy.Version)
+	}
+	v.Set("escalation.threshold", c.Escalation.Threshold)
+	v.Set("escalation.step", c.Escalation.Step)
+	v.Set("escalation.transitive", c.Escalation.Transitive)
+	v.Set("jira.url", c.Jira.URL)
+	v.Set("jira.email", c.Jira.Email)
+	v.Set("jira.token", c.Jira.Token)
+	if len(c.Models) > 0 {
+		v.Set("models", c.Models)
+	}
+	if len(c.DefinitionOfDone) > 0 {
+		v.Set("definition_of_done", c.DefinitionOfDone)
+	}
+	if len(c.Jira.PriorityMap) > 0 {
+		v.Set("jira.priority_map", c.Jira.PriorityMap)
+	}
+	if len(c.ServeTokens) > 0 {
+		tokens := make([]map[string]string, 0, len(c.ServeTokens))
+		for _, t := range c.ServeTokens {
+			tokens = append(tokens, map[string]string{"name": t.Name, "token": t.Token, "scope": t.Scope})
+		}
+		v.Set("serve_tokens", tokens)
+	}
+	if len(c.Jira.TypeMap) > 0 {
+		v.Set("jira.type_map", c.Jira.TypeMap)
+	}
+	if len(c.Profiles) > 0 {
+		v.Set("profiles", c.Profiles)
+	}
+	if c.Profile != "" {
+		v.Set("profile", c.Profile)
+	}
+	if len(c.Jira.DependencyLinks) > 0 {
+		v.Set("jira.dependency_links", c.Jira.DependencyLinks)
+	}
 
 	return v.WriteConfigAs(path)
 }
@@ -231,6 +925,162 @@ func (c *Config) Validate() error {
 	if c.AgentTimeout < 1 {
 		return fmt.Errorf("agent_timeout must be at least 1 second")
 	}
+	for complexity, timeout := range c.TicketTimeouts {
+		switch complexity {
+		case "low", "medium", "high":
+		default:
+			return fmt.Errorf("invalid ticket_timeouts key: %s (available: low, medium, high)", complexity)
+		}
+		if d, err := time.ParseDuration(timeout); err != nil || d <= 0 {
+			return fmt.Errorf("ticket_timeouts[%s] must be a positive duration such as 15m: %s", complexity, timeout)
+		}
+	}
+
+	if c.AgentMaxRetries < 0 {
+		return fmt.Errorf("agent_max_retries must be non-negative")
+	}
+	if c.SystemicFailureThreshold < 0 {
+		return fmt.Errorf("systemic_failure_threshold must be non-negative")
+	}
+
+	if c.AgentBackoff < 0 {
+		return fmt.Errorf("agent_backoff must be non-negative")
+	}
+
+	if c.MaxAgentCallsPerMinute < 0 {
+		return fmt.Errorf("max_agent_calls_per_minute must be non-negative")
+	}
+
+	if c.PromptBudgetChars < 0 {
+		return fmt.Errorf("prompt_budget_chars must not be negative")
+	}
+
+	if c.ReviewConventionsTop < 0 {
+		return fmt.Errorf("review_conventions_top must not be negative")
+	}
+
+	for _, severity := range c.ReviewBlockOn {
+		if !slices.Contains([]string{"HIGH", "MED", "MEDIUM", "LOW"}, strings.ToUpper(severity)) {
+			return fmt.Errorf("invalid review_block_on severity: %s (must be HIGH, MED or LOW)", severity)
+		}
+	}
+
+	if c.ReviewBatchSize < 0 {
+		return fmt.Errorf("review_batch_size must not be negative")
+	}
+
+	if c.MinCoverage < 0 || c.MinCoverage > 100 {
+		return fmt.Errorf("min_coverage must be between 0 and 100")
+	}
+
+	switch c.TestMode {
+	case "", "agent", "native":
+	default:
+		return fmt.Errorf("invalid test_mode: %s (available: agent, native)", c.TestMode)
+	}
+	if c.TestRetries < 0 {
+		return fmt.Errorf("test_retries must not be negative")
+	}
+	for _, pattern := range c.TestJUnitReports {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid test_junit_reports pattern %q: %w", pattern, err)
+		}
+	}
+
+	if c.BudgetTokensPerHour < 0 || c.BudgetCostPerHour < 0 || c.TokenPricePerMillion < 0 {
+		return fmt.Errorf("budget_tokens_per_hour, budget_cost_per_hour and token_price_per_million must not be negative")
+	}
+	if c.BudgetUSD < 0 {
+		return fmt.Errorf("budget_usd must not be negative")
+	}
+	if c.BudgetCostPerHour > 0 && c.TokenPricePerMillion == 0 {
+		return fmt.Errorf("budget_cost_per_hour requires token_price_per_million")
+	}
+
+	switch c.AgentBackend {
+	case "", "auto", "cursor", "claude", "aider", "api":
+	default:
+		return fmt.Errorf("invalid agent_backend: %s (available: auto, cursor, claude, aider, api)", c.AgentBackend)
+	}
+	if c.AnthropicAPIURL != "" && !strings.HasPrefix(c.AnthropicAPIURL, "http://") && !strings.HasPrefix(c.AnthropicAPIURL, "https://") {
+		return fmt.Errorf("invalid anthropic_api_url: %s (must start with http:// or https://)", c.AnthropicAPIURL)
+	}
+
+	switch c.StoreBackend {
+	case "", "file", "sqlite":
+	default:
+		return fmt.Errorf("invalid store_backend: %s (available: file, sqlite)", c.StoreBackend)
+	}
+
+	for typ, reqs := range c.DefinitionOfDone {
+		switch typ {
+		case "feature", "test", "refactor", "docs", "bugfix", "performance", "security":
+		default:
+			return fmt.Errorf("invalid definition_of_done type: %s (available: feature, test, refactor, docs, bugfix, performance, security)", typ)
+		}
+		for _, r := range reqs {
+			switch r {
+			case "tests", "docs", "tests_pass":
+			default:
+				return fmt.Errorf("invalid definition_of_done[%s] requirement: %s (available: tests, docs, tests_pass)", typ, r)
+			}
+		}
+	}
+
+	for key, model := range c.Models {
+		if !isModelKey(strings.ToLower(key)) {
+			return fmt.Errorf("invalid models key: %s (expected <type>, <complexity>, <type>-<complexity>, or one of default, coding, planning, analyze, review, testing, commit, enhance)", key)
+		}
+		if strings.TrimSpace(model) == "" {
+			return fmt.Errorf("models[%s] must not be empty", key)
+		}
+	}
+
+	switch c.LogFormat {
+	case "", "text", "json":
+	default:
+		return fmt.Errorf("invalid log_format: %s (available: text, json)", c.LogFormat)
+	}
+
+	if c.Profile != "" {
+		if _, ok := c.Profiles[strings.ToLower(c.Profile)]; !ok {
+			return fmt.Errorf("profile %q is not defined in profiles", c.Profile)
+		}
+	}
+
+	switch strings.ToLower(c.Theme) {
+	case "", "default", "colorblind", "monochrome":
+	default:
+		return fmt.Errorf("invalid theme: %s (available: default, colorblind, monochrome)", c.Theme)
+	}
+	for name, color := range c.ThemeColors {
+		switch strings.ToLower(name) {
+		case "primary", "success", "warning", "error", "info", "muted", "highlight", "background":
+		default:
+			return fmt.Errorf("invalid theme_colors key: %s (available: primary, success, warning, error, info, muted, highlight, background)", name)
+		}
+		if !isThemeColor(color) {
+			return fmt.Errorf("invalid theme_colors[%s]: %s (expected #rgb, #rrggbb or 0-255)", name, color)
+		}
+	}
+
+	if _, err := redact.Compile(c.LogRedactionPatterns); err != nil {
+		return fmt.Errorf("log_redaction_patterns: %w", err)
+	}
+
+	if c.Policy.Version != "" && strings.TrimSpace(c.Policy.Preamble) == "" {
+		return fmt.Errorf("policy.version is set but policy.preamble is empty")
+	}
+
+	// Whether a language is available (built in or from a locale file) is checked when
+	// it is applied; only the form of the tag is checked here.
+	if c.Language != "" && !isLanguageTag(c.Language) {
+		return fmt.Errorf("invalid language: %s (expected a tag such as zh-TW or en)", c.Language)
+	}
+
+	if c.StoreIOParallelism < 0 {
+		return fmt.Errorf("store_io_parallelism must be non-negative")
+	}
 
 	validFormats := map[string]bool{
 		"text":        true,
@@ -241,6 +1091,62 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid agent_output_format: %s", c.AgentOutputFormat)
 	}
 
+	if c.GitHubAPIURL != "" && !strings.HasPrefix(c.GitHubAPIURL, "http://") && !strings.HasPrefix(c.GitHubAPIURL, "https://") {
+		return fmt.Errorf("invalid github_api_url: %s (must start with http:// or https://)", c.GitHubAPIURL)
+	}
+	if c.GitLabAPIURL != "" && !strings.HasPrefix(c.GitLabAPIURL, "http://") && !strings.HasPrefix(c.GitLabAPIURL, "https://") {
+		return fmt.Errorf("invalid gitlab_api_url: %s (must start with http:// or https://)", c.GitLabAPIURL)
+	}
+
+	if c.Notifications.SlackWebhook != "" && !strings.HasPrefix(c.Notifications.SlackWebhook, "https://") && !strings.HasPrefix(c.Notifications.SlackWebhook, "http://") {
+		return fmt.Errorf("invalid notifications.slack_webhook: %s (must start with http:// or https://)", c.Notifications.SlackWebhook)
+	}
+	if c.Notifications.ServeURL != "" && !strings.HasPrefix(c.Notifications.ServeURL, "https://") && !strings.HasPrefix(c.Notifications.ServeURL, "http://") {
+		return fmt.Errorf("invalid notifications.serve_url: %s (must start with http:// or https://)", c.Notifications.ServeURL)
+	}
+	if c.Notifications.RepeatedFailures < 0 {
+		return fmt.Errorf("notifications.repeated_failures must be non-negative")
+	}
+
+	if c.MetricsAddr != "" {
+		if _, _, err := net.SplitHostPort(c.MetricsAddr); err != nil {
+			return fmt.Errorf("invalid metrics_addr: %s (must be host:port)", c.MetricsAddr)
+		}
+	}
+
+	names, secrets := make(map[string]bool), make(map[string]bool)
+	for i, t := range c.ServeTokens {
+		if t.Name == "" || t.Token == "" {
+			return fmt.Errorf("serve_tokens[%d] needs both name and token", i)
+		}
+		if names[t.Name] {
+			return fmt.Errorf("duplicate serve_tokens name: %s", t.Name)
+		}
+		if secrets[t.Token] {
+			return fmt.Errorf("serve_tokens %s reuses the token of another entry", t.Name)
+		}
+		names[t.Name], secrets[t.Token] = true, true
+		if !slices.Contains(ServeTokenScopes, t.Scope) {
+			return fmt.Errorf("invalid serve_tokens %s scope: %q (available: %s)", t.Name, t.Scope, strings.Join(ServeTokenScopes, ", "))
+		}
+	}
+
+	if c.Escalation.Threshold < 0 {
+		return fmt.Errorf("escalation.threshold must be non-negative")
+	}
+	if c.Escalation.Threshold > 0 && c.Escalation.Step < 1 {
+		return fmt.Errorf("escalation.step must be at least 1 when escalation.threshold is set")
+	}
+
+	if c.Jira.URL != "" && !strings.HasPrefix(c.Jira.URL, "http://") && !strings.HasPrefix(c.Jira.URL, "https://") {
+		return fmt.Errorf("invalid jira.url: %s (must start with http:// or https://)", c.Jira.URL)
+	}
+	for name, p := range c.Jira.PriorityMap {
+		if p < 1 || p > 5 {
+			return fmt.Errorf("invalid jira.priority_map[%s]: %d (must be between 1 and 5)", name, p)
+		}
+	}
+
 	// 可選：當 WorkDetachLogDir 有值時檢查路徑格式（不含 null 等無效字元）
 	if c.WorkDetachLogDir != "" && strings.Contains(c.WorkDetachLogDir, "\x00") {
 		return fmt.Errorf("work_detach_log_dir contains invalid character")
@@ -249,6 +1155,72 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// isModelKey 回報 key 是否為 models 可用的鍵：用途（default、coding、planning 等）、ticket 類型、
+// 複雜度（low、medium、high），或 <類型>-<複雜度>。
+func isModelKey(key string) bool {
+	isType := func(s string) bool {
+		switch s {
+		case "feature", "test", "refactor", "docs", "bugfix", "performance", "security":
+			return true
+		}
+		return false
+	}
+	isComplexity := func(s string) bool {
+		return s == "low" || s == "medium" || s == "high"
+	}
+	switch key {
+	case "default", "coding", "planning", "analyze", "review", "testing", "commit", "enhance":
+		return true
+	}
+	if typ, complexity, ok := strings.Cut(key, "-"); ok {
+		return isType(typ) && isComplexity(complexity)
+	}
+	return isType(key) || isComplexity(key)
+}
+
+// isThemeColor 回報 s 是否為十六進位色碼（#rgb、#rrggbb）或 ANSI 256 色碼（0-255）。
+func isThemeColor(s string) bool {
+	s = strings.TrimSpace(s)
+	if hex, ok := strings.CutPrefix(s, "#"); ok {
+		if len(hex) != 3 && len(hex) != 6 {
+			return false
+		}
+		_, err := strconv.ParseUint(hex, 16, 32)
+		return err == nil
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// isLanguageTag 回報 s 是否形如語言標籤（如 en、zh-TW、pt_BR）：以 - 或 _ 分隔的英數字段，
+// 第一段為 2-3 個字母。
+func isLanguageTag(s string) bool {
+	parts := strings.FieldsFunc(s, func(r rune) bool { return r == '-' || r == '_' })
+	if len(parts) == 0 || len(parts[0]) < 2 || len(parts[0]) > 3 || strings.Join(parts, "-") != strings.ReplaceAll(s, "_", "-") {
+		return false
+	}
+	for i, p := range parts {
+		for _, r := range p {
+			isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+			if !isLetter && (i == 0 || r < '0' || r > '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// TicketTimeoutDurations 回傳 ticket_timeouts 解析後的各複雜度超時；無法解析的值（Validate 會拒絕）略過。
+func (c *Config) TicketTimeoutDurations() map[string]time.Duration {
+	timeouts := make(map[string]time.Duration, len(c.TicketTimeouts))
+	for complexity, timeout := range c.TicketTimeouts {
+		if d, err := time.ParseDuration(timeout); err == nil && d > 0 {
+			timeouts[complexity] = d
+		}
+	}
+	return timeouts
+}
+
 // WorkPIDFilePath 回傳 work 背景執行時使用的 PID 檔路徑。
 // 若 WorkPIDFile 已設定則回傳該路徑，否則約定為 TicketsDir/.work.pid。
 func (c *Config) WorkPIDFilePath() string {
@@ -258,6 +1230,98 @@ func (c *Config) WorkPIDFilePath() string {
 	return filepath.Join(c.TicketsDir, ".work.pid")
 }
 
+// MetricsHistoryPath 回傳 metrics 歷史檔路徑（每處理一張 ticket 追加一行 JSON），約定為 TicketsDir/metrics.jsonl。
+func (c *Config) MetricsHistoryPath() string {
+	return MetricsHistoryPath(c.TicketsDir)
+}
+
+// MetricsHistoryPath 回傳 ticketsDir 下的 metrics 歷史檔路徑；store migrate 於兩個 store 間複製時使用。
+func MetricsHistoryPath(ticketsDir string) string {
+	return filepath.Join(ticketsDir, "metrics.jsonl")
+}
+
+// QualityHistoryPath 回傳分析分數歷史檔路徑（每次 analyze 追加一行 JSON），約定為 TicketsDir/quality.jsonl。
+func (c *Config) QualityHistoryPath() string {
+	return filepath.Join(c.TicketsDir, "quality.jsonl")
+}
+
+// AuditLogPath 回傳 agent 呼叫稽核紀錄檔路徑（每次呼叫追加一行 JSON），約定為 TicketsDir/audit.jsonl。
+func (c *Config) AuditLogPath() string {
+	return filepath.Join(c.TicketsDir, "audit.jsonl")
+}
+
+// APIAuditLogPath 回傳 serve API 操作稽核紀錄檔路徑（觸發的操作與被拒絕的請求各一行 JSON），約定為 TicketsDir/api-audit.jsonl。
+func (c *Config) APIAuditLogPath() string {
+	return filepath.Join(c.TicketsDir, "api-audit.jsonl")
+}
+
+// RetryKeyPath 回傳簽署通知中重試連結的金鑰檔路徑（發送通知的 work/run 與驗證連結的 serve 共用），約定為 TicketsDir/.retry-key。
+func (c *Config) RetryKeyPath() string {
+	return filepath.Join(c.TicketsDir, ".retry-key")
+}
+
+// BudgetStatePath 回傳每小時 token/費用預算的狀態檔路徑（前景與背景 work 共用），約定為 TicketsDir/budget.json。
+func (c *Config) BudgetStatePath() string {
+	return filepath.Join(c.TicketsDir, "budget.json")
+}
+
+// ArchiveDir 回傳已封存 tickets 的目錄（每張 ticket 一個 .json.gz），約定為 TicketsDir/archive。
+func (c *Config) ArchiveDir() string {
+	return filepath.Join(c.TicketsDir, "archive")
+}
+
+// StorePath 回傳目前 store backend 的資料位置：file 為 TicketsDir，sqlite 為 TicketsDir/tickets.db。
+func (c *Config) StorePath() string {
+	if c.StoreBackend == "sqlite" {
+		return filepath.Join(c.TicketsDir, "tickets.db")
+	}
+	return c.TicketsDir
+}
+
+// ReviewFindingsPath 回傳重複審查問題的記錄檔路徑，約定為 TicketsDir/review-findings.json。
+func (c *Config) ReviewFindingsPath() string {
+	return filepath.Join(c.TicketsDir, "review-findings.json")
+}
+
+// RecurringPath 回傳週期性 ticket 範本與其實例歷史的記錄檔路徑，約定為 TicketsDir/recurring.json。
+func (c *Config) RecurringPath() string {
+	return filepath.Join(c.TicketsDir, "recurring.json")
+}
+
+// RunSnapshotPath 回傳最近一次 run --snapshot 的快照記錄檔路徑（含 run 建立的 tickets），約定為 TicketsDir/run-snapshot.json；
+// 供 run --restore-last 還原。
+func (c *Config) RunSnapshotPath() string {
+	return filepath.Join(c.TicketsDir, "run-snapshot.json")
+}
+
+// RunStatePath 回傳未完成 run 的進度記錄檔路徑（已完成的步驟、產生的 tickets、審查結果），約定為 TicketsDir/run-state.json；
+// 供 run --resume 從最後完成的步驟之後繼續，run 完成時刪除。
+func (c *Config) RunStatePath() string {
+	return filepath.Join(c.TicketsDir, "run-state.json")
+}
+
+// WorkQueuePath 回傳背景 work 執行中以 work --queue 排入的請求記錄檔路徑，約定為 TicketsDir/work-queue.json。
+func (c *Config) WorkQueuePath() string {
+	return filepath.Join(c.TicketsDir, "work-queue.json")
+}
+
+// AgentRunStatePath 回傳記錄執行中 agent 子行程（PID 與所屬 orchestrator PID）的檔案路徑，約定為 TicketsDir/agents.json；
+// 用於找出 orchestrator 崩潰後遺留的 agent 行程。
+func (c *Config) AgentRunStatePath() string {
+	return filepath.Join(c.TicketsDir, "agents.json")
+}
+
+// WorktreePath 回傳 git_worktrees 建立 worktree 的目錄：GitWorktreeDir，未設時為
+// 系統暫存目錄下的 agent-orchestrator-worktrees/<專案名稱>-<專案路徑雜湊>，避免不同專案互相覆蓋。
+func (c *Config) WorktreePath() string {
+	if c.GitWorktreeDir != "" {
+		return c.GitWorktreeDir
+	}
+	h := fnv.New32a()
+	h.Write([]byte(c.ProjectRoot))
+	return filepath.Join(os.TempDir(), "agent-orchestrator-worktrees", fmt.Sprintf("%s-%08x", filepath.Base(c.ProjectRoot), h.Sum32()))
+}
+
 // DetachLogPath 回傳當次 detach 執行的 log 檔路徑。
 // 依 config（WorkDetachLogDir 或 LogsDir）與可選的 --log-file 覆寫、時間戳決定：
 //   - 若 logFileOverride 非空（對應 --log-file），則以此路徑為準；相對路徑會依 ProjectRoot 解析為絕對路徑。
@@ -308,31 +1372,12 @@ func (c *Config) EnsureDirs() error {
 	return nil
 }
 
-// GetConfigFilePath returns the path to the config file
+// GetConfigFilePath returns the path to the config file: the project file when
+// there is one, else the global file in use, else the project file to create.
 func GetConfigFilePath() string {
-	// Check current directory first
-	if _, err := os.Stat(".agent-orchestrator.yaml"); err == nil {
-		return ".agent-orchestrator.yaml"
+	if files := ConfigFiles(); len(files) > 0 {
+		return files[len(files)-1]
 	}
-
-	// Then home directory
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return ".agent-orchestrator.yaml"
-	}
-
-	configPath := filepath.Join(home, ".agent-orchestrator.yaml")
-	if _, err := os.Stat(configPath); err == nil {
-		return configPath
-	}
-
-	// XDG config
-	xdgConfig := filepath.Join(home, ".config", "agent-orchestrator", "config.yaml")
-	if _, err := os.Stat(xdgConfig); err == nil {
-		return xdgConfig
-	}
-
-	// Default to current directory
 	return ".agent-orchestrator.yaml"
 }
 
@@ -342,27 +1387,142 @@ func GenerateDefaultConfigFile(path string) error {
 # 各欄位說明、預設值與建議情境請見 README「設定說明」章節
 
 # Agent 設定
-agent_command: agent           # Cursor Agent CLI 指令 (預設: agent)
+agent_command: agent           # Agent CLI 指令 (預設: agent)
+agent_backend: auto            # Agent 驅動: auto, cursor, claude, aider, api (預設: auto，依指令名稱判斷)
+# anthropic_api_key:           # agent_backend: api 的 API key，未設則使用環境變數 ANTHROPIC_API_KEY
+# anthropic_model: claude-sonnet-4-20250514  # agent_backend: api 使用的模型
 agent_output_format: text      # 輸出格式: text, json, stream-json (預設: text)
 agent_force: true              # 是否使用 --force 允許修改檔案 (預設: true)
 agent_timeout: 600             # Agent 執行超時秒數 (預設: 600)
+ticket_timeouts:               # work 時依 ticket 複雜度的 coding 超時；逾時失敗的 ticket 重試時加倍
+  low: 5m
+  medium: 15m
+  high: 40m
+agent_max_retries: 2           # Agent 暫時性失敗的重試次數；0 為停用 (預設: 2)
+agent_backoff: 5               # 第一次重試前的等待秒數，之後每次加倍 (預設: 5)
+max_agent_calls_per_minute: 0  # 每分鐘最多 agent 呼叫數，所有並行 agent 共用；0 為不限制 (預設: 0)
+systemic_failure_threshold: 3  # 連續幾張 ticket 因同類系統性錯誤失敗時中止 work/run；0 為停用 (預設: 3)
+agent_probe: true              # work 並行派發前先以一次簡短呼叫確認 agent 可用 (預設: true)
+prompt_budget_chars: 24000     # Coding prompt 字元上限，超過時摘要較不重要的段落；0 為停用 (預設: 24000)
+review_conventions_top: 5      # 附加到 coding prompt 的重複審查問題數；0 為停用 (預設: 5)
+review_block_on: []            # 阻擋 run commit 的審查問題嚴重度，例如 [HIGH, MED] (預設: [] 不阻擋)
+review_batch_size: 10          # 每次審查呼叫的檔案數，分批並行審查；0 為一次審查全部 (預設: 10)
+min_coverage: 0                # 測試覆蓋率下限（百分比），低於此值時 test 失敗；0 為不檢查 (預設: 0)
+test_mode: agent               # 測試執行方式: agent 或 native（直接執行測試指令，失敗時才呼叫 agent 分析） (預設: agent)
+test_junit_reports: [junit.xml, test-results/*.xml, target/surefire-reports/*.xml, build/test-results/test/*.xml]  # 測試產生的 JUnit XML 報告，優先於輸出解析
+test_retries: 0                # test_mode: native 時重新執行失敗測試的次數，重跑後通過者標記為 flaky (預設: 0)
+# models:                      # 依用途指定模型 (預設: CLI 預設模型)
+#   feature-high: opus         # <類型>-<複雜度>、<類型>、<複雜度>、coding 依序查找
+#   docs: haiku
+#   review: sonnet             # planning, analyze, review, testing, commit, enhance, default
+# definition_of_done:          # 依 ticket 類型的完成條件: tests, docs, tests_pass (預設: 不檢查)
+#   feature: [tests, docs]
+#   bugfix: [tests]
 
 # 路徑設定 (相對於專案根目錄)
 tickets_dir: .tickets          # Tickets 儲存目錄 (預設: .tickets)
+store_backend: file            # Tickets 儲存後端: file, sqlite (預設: file)
+store_io_parallelism: 1        # file backend 同時讀取的 ticket 檔案數 (預設: 1)
 logs_dir: .agent-logs          # Agent 執行日誌目錄 (預設: .agent-logs)
 # work_detach_log_dir:          # work detach 日誌目錄，未設則不使用 (選填)
 # work_pid_file:               # work 背景 PID 檔路徑，未設則為 tickets_dir/.work.pid (選填)
 docs_dir: docs                 # 文件目錄 (預設: docs)
+# metrics_addr: 127.0.0.1:9464  # work --detach 提供 Prometheus /metrics 的位址，未設則不提供 (選填)
 
 # 執行設定
 max_parallel: 3                # 最大並行 Agent 數量 (預設: 3)
+budget_tokens_per_hour: 0      # 每小時 token 上限，用盡時暫停派發；0 為不限制 (預設: 0)
+budget_cost_per_hour: 0        # 每小時費用上限 (USD)，需設定 token_price_per_million；0 為不限制 (預設: 0)
+token_price_per_million: 0     # 每百萬 token 價格 (USD)，用於換算費用 (預設: 0)
+budget_usd: 0                  # 單次 work 的費用上限 (USD)，達到後停止派發新 ticket；0 為不限制 (預設: 0)
+
+# 顯示設定
+theme: default                 # 配色: default, colorblind, monochrome；設定 NO_COLOR 時為 monochrome (預設: default)
+# theme_colors:                # 覆寫個別顏色，值為 #rrggbb 或 0-255 (選填)
+#   success: "#00A0FF"         # primary, success, warning, error, info, muted, highlight, background
+log_format: text               # 診斷日誌格式: text 或 json；work --detach 一律為 json (預設: text)
+language: zh-TW                # 輸出與 agent prompt 的語言: zh-TW, en 或 .agent-orchestrator/locales/ 語系檔的語言；--lang 會覆寫 (預設: zh-TW)
 
 # 安全設定
 disable_detailed_log: false    # 設為 true 停用詳細日誌，避免敏感資訊落檔 (預設: false)
+# audit_identity:              # 稽核紀錄中的操作者身分 (例如 email)，未設則僅記錄 OS 使用者 (選填)
+# log_redaction_patterns:      # 日誌與 ticket 輸出的額外遮蔽規則 (正規表示式)；"-<名稱>" 停用內建規則 (選填)
+#   - 'corp_[A-Za-z0-9]{32}'
+#   - -password
 
 # 分析範圍 (用於 analyze 指令，--scope 會覆寫)
 analyze_scopes:
   - all                        # 可選: performance, refactor, security, test, docs, all (預設: all)
+
+# Git 設定
+git_branch_per_ticket: false   # 每個 ticket 在 ticket/<ID>-<標題> 分支上處理與提交；啟用時逐一處理 (預設: false)
+git_worktrees: false           # 每個 ticket 在獨立的 git worktree 中處理，完成後套用回專案 (預設: false)
+# git_worktree_dir:            # worktree 目錄，未設則為系統暫存目錄 (選填)
+
+# GitHub 設定 (import github)
+# github_token:                # GitHub API token，未設則使用環境變數 GITHUB_TOKEN (選填)
+# github_api_url: https://api.github.com  # GitHub Enterprise 時改為 https://<host>/api/v3
+github_sync: true              # 將匯入之 tickets 的完成/失敗/commit 同步回 issue (預設: true)
+
+# Pull requests (pr)
+# gitlab_token:                # GitLab API token，未設則使用環境變數 GITLAB_TOKEN (選填)
+# gitlab_api_url: https://gitlab.com/api/v4  # 自架 GitLab 時改為 https://<host>/api/v4
+# pr_base_branch:              # PR 目標分支，未設則為 repo 預設分支 (選填)
+
+# Jira 設定 (import jira)
+# jira:
+#   url: https://example.atlassian.net
+#   email: you@example.com     # Jira Cloud 帳號；未設則 token 以 Bearer 傳送 (Server/Data Center)
+#   token:                     # API token，未設則使用環境變數 JIRA_API_TOKEN
+#   priority_map:              # 覆寫優先級對應 (預設: Highest=1, High=2, Medium=3, Low=4, Lowest=5)
+#     P0: 1
+#   type_map:                  # 覆寫 issue 類型對應 (預設: Bug=bugfix, Story/Task=feature, Improvement=refactor)
+#     Spike: docs
+#   dependency_links: [Blocks] # 視為依賴的 link 類型 (預設: Blocks)
+
+# 通知設定 (run / 背景 work 結束、ticket 連續失敗、系統性失敗而中止)
+# notifications:
+#   command: 'curl -s -X POST -d @- https://hooks.example.com/agent'  # 事件 JSON 由 stdin 傳入
+#   slack_webhook: https://hooks.slack.com/services/...  # 未設則使用環境變數 SLACK_WEBHOOK_URL
+#   repeated_failures: 3   # 同一 ticket 連續失敗幾次時通知，0 停用
+#   serve_url: https://ao.example.com  # serve 對外的網址；設定後失敗通知附上重試連結
+
+# prompt 政策前言：加在每個 agent prompt 之前，版本與雜湊記錄於 agent 日誌與 audit
+# policy:
+#   version: "2024-06"
+#   preamble: |
+#     不得將任何程式碼或資料傳送至外部服務。
+#     輸出中不得包含機密（API key、密碼、token）。
+
+# 優先級提升：有 threshold 張以上 pending tickets 在等待的 ticket，排程時優先級提升 step 級
+# escalation:
+#   threshold: 3       # 0 停用
+#   step: 1            # 每多 threshold 張再提升一次，最高到 P1
+#   transitive: true   # 也計入間接等待的 tickets
+
+# serve API tokens：設定後 API 需以 Authorization: Bearer <token> 驗證；scope 為 read、work 或 admin
+# serve_tokens:
+#   - name: dashboard
+#     token: <隨機字串>
+#     scope: read
+#   - name: ci
+#     token: <隨機字串>
+#     scope: work
+
+# Profiles：以 --profile <名稱> 選用（或以 profile 設定預設的 profile），覆寫上方的同名設定
+# profile: fast
+# profiles:
+#   fast:
+#     max_parallel: 6
+#     agent_max_retries: 0
+#     budget_usd: 2
+#   thorough:
+#     max_parallel: 2
+#     agent_timeout: 1800
+#     review_conventions_top: 10
+
+# 更新設定 (self-update / version --check)
+# update_release_url: https://api.github.com/repos/kokjohn0824/agent_orchestrator/releases/latest
 `
 
 	dir := filepath.Dir(path)
@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -298,6 +300,50 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "negative agent_max_retries",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				AgentMaxRetries:   -1,
+				MaxParallel:       3,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative store_io_parallelism",
+			cfg: &Config{
+				AgentCommand:       "agent",
+				AgentOutputFormat:  "text",
+				AgentTimeout:       600,
+				StoreIOParallelism: -1,
+				MaxParallel:        3,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid definition_of_done requirement",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				DefinitionOfDone:  map[string][]string{"feature": {"tests", "changelog"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid definition_of_done type",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				DefinitionOfDone:  map[string][]string{"story": {"tests"}},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid output format",
 			cfg: &Config{
@@ -330,6 +376,249 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid serve_tokens",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				ServeTokens:       []ServeToken{{Name: "ci", Token: "a", Scope: "work"}, {Name: "ops", Token: "b", Scope: "admin"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "serve_tokens with unknown scope",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				ServeTokens:       []ServeToken{{Name: "ci", Token: "a", Scope: "write"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "serve_tokens sharing a token",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				ServeTokens:       []ServeToken{{Name: "ci", Token: "a", Scope: "work"}, {Name: "ops", Token: "a", Scope: "admin"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "serve_tokens without a token",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				ServeTokens:       []ServeToken{{Name: "ci", Scope: "read"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid metrics_addr",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				MetricsAddr:       "127.0.0.1:9464",
+			},
+			wantErr: false,
+		},
+		{
+			name: "metrics_addr without port",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				MetricsAddr:       "localhost",
+			},
+			wantErr: true,
+		},
+		{
+			name: "json log_format",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				LogFormat:         "json",
+			},
+			wantErr: false,
+		},
+		{
+			name: "defined profile",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				Profiles:          map[string]map[string]any{"fast": {"max_parallel": 6}},
+				Profile:           "Fast",
+			},
+			wantErr: false,
+		},
+		{
+			name: "undefined profile",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				Profile:           "fast",
+			},
+			wantErr: true,
+		},
+		{
+			name: "redaction patterns",
+			cfg: &Config{
+				AgentCommand:         "agent",
+				AgentOutputFormat:    "text",
+				AgentTimeout:         600,
+				MaxParallel:          3,
+				LogRedactionPatterns: []string{`corp_[0-9a-f]{32}`, "-password"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid redaction pattern",
+			cfg: &Config{
+				AgentCommand:         "agent",
+				AgentOutputFormat:    "text",
+				AgentTimeout:         600,
+				MaxParallel:          3,
+				LogRedactionPatterns: []string{"corp_("},
+			},
+			wantErr: true,
+		},
+		{
+			name: "review_block_on severities",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				ReviewBlockOn:     []string{"HIGH", "med"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown review_block_on severity",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				ReviewBlockOn:     []string{"CRITICAL"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative review_batch_size",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				ReviewBatchSize:   -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "min_coverage over 100",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				MinCoverage:       120,
+			},
+			wantErr: true,
+		},
+		{
+			name: "native test_mode",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				TestMode:          "native",
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown test_mode",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				TestMode:          "ci",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid test_junit_reports pattern",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				TestJUnitReports:  []string{"reports/[*.xml"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative test_retries",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				TestRetries:       -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown ticket_timeouts complexity",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				TicketTimeouts:    map[string]string{"huge": "1h"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid ticket_timeouts duration",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				TicketTimeouts:    map[string]string{"low": "300"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown log_format",
+			cfg: &Config{
+				AgentCommand:      "agent",
+				AgentOutputFormat: "text",
+				AgentTimeout:      600,
+				MaxParallel:       3,
+				LogFormat:         "logfmt",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -341,3 +630,658 @@ func TestConfig_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_Validate_PromptBudgetChars(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.PromptBudgetChars != 24000 {
+		t.Errorf("default PromptBudgetChars = %d, want 24000", cfg.PromptBudgetChars)
+	}
+	cfg.PromptBudgetChars = 0
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("PromptBudgetChars 0 (disabled) should be valid: %v", err)
+	}
+	cfg.PromptBudgetChars = -1
+	if err := cfg.Validate(); err == nil {
+		t.Error("negative PromptBudgetChars should be invalid")
+	}
+}
+
+func TestConfig_Validate_ReviewConventionsTop(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.ReviewConventionsTop != 5 {
+		t.Errorf("default ReviewConventionsTop = %d, want 5", cfg.ReviewConventionsTop)
+	}
+	cfg.ReviewConventionsTop = 0
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("ReviewConventionsTop 0 (disabled) should be valid: %v", err)
+	}
+	cfg.ReviewConventionsTop = -1
+	if err := cfg.Validate(); err == nil {
+		t.Error("negative ReviewConventionsTop should be invalid")
+	}
+}
+
+func TestConfig_StoreBackend(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TicketsDir = "/p/.tickets"
+	if cfg.StoreBackend != "file" {
+		t.Errorf("default StoreBackend = %q, want file", cfg.StoreBackend)
+	}
+	if got := cfg.StorePath(); got != "/p/.tickets" {
+		t.Errorf("StorePath() for file = %s", got)
+	}
+
+	cfg.StoreBackend = "sqlite"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("sqlite backend should be valid: %v", err)
+	}
+	if got := cfg.StorePath(); got != filepath.Join("/p/.tickets", "tickets.db") {
+		t.Errorf("StorePath() for sqlite = %s", got)
+	}
+
+	cfg.StoreBackend = "postgres"
+	if err := cfg.Validate(); err == nil {
+		t.Error("unknown store_backend should be invalid")
+	}
+}
+
+func TestConfig_AgentBackend(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.AgentBackend != "auto" {
+		t.Errorf("default AgentBackend = %q, want auto", cfg.AgentBackend)
+	}
+	for _, backend := range []string{"auto", "cursor", "claude", "aider", "api", ""} {
+		cfg.AgentBackend = backend
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("agent_backend %q should be valid: %v", backend, err)
+		}
+	}
+	cfg.AgentBackend = "copilot"
+	if err := cfg.Validate(); err == nil {
+		t.Error("unknown agent_backend should be invalid")
+	}
+	cfg.AgentBackend = "api"
+	cfg.AnthropicAPIURL = "api.anthropic.com"
+	if err := cfg.Validate(); err == nil {
+		t.Error("anthropic_api_url without scheme should be invalid")
+	}
+}
+
+func TestConfig_Validate_Budget(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{"disabled by default", func(c *Config) {}, false},
+		{"token limit", func(c *Config) { c.BudgetTokensPerHour = 100000 }, false},
+		{"cost limit with price", func(c *Config) { c.BudgetCostPerHour = 5; c.TokenPricePerMillion = 3 }, false},
+		{"cost limit without price", func(c *Config) { c.BudgetCostPerHour = 5 }, true},
+		{"negative tokens", func(c *Config) { c.BudgetTokensPerHour = -1 }, true},
+		{"negative price", func(c *Config) { c.TokenPricePerMillion = -1 }, true},
+		{"negative budget_usd", func(c *Config) { c.BudgetUSD = -1 }, true},
+		{"negative systemic_failure_threshold", func(c *Config) { c.SystemicFailureThreshold = -1 }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.modify(cfg)
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad_ReadsJiraSection(t *testing.T) {
+	tempDir := t.TempDir()
+	configContent := `jira:
+  url: https://example.atlassian.net
+  email: dev@example.com
+  priority_map:
+    P0: 1
+  type_map:
+    Spike: docs
+  dependency_links: [Depends]
+`
+	if err := os.WriteFile(filepath.Join(tempDir, ".agent-orchestrator.yaml"), []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	origWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Chdir: %v", err)
+	}
+	defer os.Chdir(origWd)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	j := cfg.Jira
+	if j.URL != "https://example.atlassian.net" || j.Email != "dev@example.com" {
+		t.Errorf("Load() Jira connection = %+v", j)
+	}
+	// Viper lowercases map keys; the Jira mapping matches names case-insensitively.
+	if j.PriorityMap["p0"] != 1 || j.TypeMap["spike"] != "docs" {
+		t.Errorf("Load() Jira maps = %v, %v", j.PriorityMap, j.TypeMap)
+	}
+	if len(j.DependencyLinks) != 1 || j.DependencyLinks[0] != "Depends" {
+		t.Errorf("Load() Jira dependency_links = %v", j.DependencyLinks)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	cfg.Jira.URL = "example.atlassian.net"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should reject a jira.url without scheme")
+	}
+	cfg.Jira.URL = ""
+	cfg.Jira.PriorityMap = map[string]int{"urgent": 0}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should reject a jira.priority_map value outside 1-5")
+	}
+}
+
+func TestLoad_ReadsEscalationSection(t *testing.T) {
+	tempDir := t.TempDir()
+	configContent := `escalation:
+  threshold: 5
+  transitive: false
+`
+	if err := os.WriteFile(filepath.Join(tempDir, ".agent-orchestrator.yaml"), []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	origWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Chdir: %v", err)
+	}
+	defer os.Chdir(origWd)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	// step is not in the file and keeps its default.
+	if want := (EscalationConfig{Threshold: 5, Step: 1, Transitive: false}); cfg.Escalation != want {
+		t.Errorf("Load() Escalation = %+v, want %+v", cfg.Escalation, want)
+	}
+
+	cfg.Escalation.Step = 0
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should reject escalation.step 0 while escalation is enabled")
+	}
+	cfg.Escalation.Threshold = 0
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() with escalation disabled error = %v", err)
+	}
+}
+
+func TestLoad_ReadsServeTokens(t *testing.T) {
+	cfg, err := parseConfig([]byte(`serve_tokens:
+  - name: dashboard
+    token: read-secret
+    scope: read
+  - name: ci
+    token: work-secret
+    scope: work
+`))
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+	want := []ServeToken{{Name: "dashboard", Token: "read-secret", Scope: "read"}, {Name: "ci", Token: "work-secret", Scope: "work"}}
+	if !reflect.DeepEqual(cfg.ServeTokens, want) {
+		t.Errorf("ServeTokens = %+v, want %+v", cfg.ServeTokens, want)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestLoad_ReadsDefinitionOfDone(t *testing.T) {
+	tempDir := t.TempDir()
+	configContent := `definition_of_done:
+  feature: [tests, docs]
+  bugfix: [tests]
+`
+	if err := os.WriteFile(filepath.Join(tempDir, ".agent-orchestrator.yaml"), []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	origWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Chdir: %v", err)
+	}
+	defer os.Chdir(origWd)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	dod := cfg.DefinitionOfDone
+	if strings.Join(dod["feature"], ",") != "tests,docs" || strings.Join(dod["bugfix"], ",") != "tests" {
+		t.Errorf("Load() definition_of_done = %v", dod)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestConfig_Validate_Models(t *testing.T) {
+	tests := []struct {
+		name    string
+		models  map[string]string
+		wantErr bool
+	}{
+		{"none", nil, false},
+		{"type and complexity", map[string]string{"feature-high": "opus", "docs": "haiku", "low": "haiku"}, false},
+		{"roles", map[string]string{"default": "sonnet", "Review": "opus", "planning": "opus"}, false},
+		{"unknown key", map[string]string{"bogus": "opus"}, true},
+		{"unknown complexity", map[string]string{"feature-extreme": "opus"}, true},
+		{"empty model", map[string]string{"docs": " "}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Models = tt.models
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_Display(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{"default", func(c *Config) {}, false},
+		{"colorblind", func(c *Config) { c.Theme = "colorblind" }, false},
+		{"unknown theme", func(c *Config) { c.Theme = "neon" }, true},
+		{"custom colors", func(c *Config) { c.ThemeColors = map[string]string{"success": "#00A0FF", "muted": "240"} }, false},
+		{"short hex", func(c *Config) { c.ThemeColors = map[string]string{"error": "#f00"} }, false},
+		{"unknown color name", func(c *Config) { c.ThemeColors = map[string]string{"accent": "#fff"} }, true},
+		{"named color", func(c *Config) { c.ThemeColors = map[string]string{"success": "green"} }, true},
+		{"ansi code out of range", func(c *Config) { c.ThemeColors = map[string]string{"success": "256"} }, true},
+		{"english", func(c *Config) { c.Language = "en" }, false},
+		{"empty language", func(c *Config) { c.Language = "" }, false},
+		{"locale file language", func(c *Config) { c.Language = "pt_BR" }, false},
+		{"malformed language", func(c *Config) { c.Language = "en US" }, true},
+		{"empty subtag", func(c *Config) { c.Language = "en--US" }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.modify(cfg)
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad_ReadsModels(t *testing.T) {
+	tempDir := t.TempDir()
+	configContent := `models:
+  feature-high: opus
+  Docs: haiku
+`
+	if err := os.WriteFile(filepath.Join(tempDir, ".agent-orchestrator.yaml"), []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	origWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Chdir: %v", err)
+	}
+	defer os.Chdir(origWd)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	// Viper lowercases map keys, matching the case-insensitive lookup.
+	if cfg.Models["feature-high"] != "opus" || cfg.Models["docs"] != "haiku" {
+		t.Errorf("Load() models = %v", cfg.Models)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestLoad_TicketTimeouts(t *testing.T) {
+	tempDir := t.TempDir()
+	configContent := `ticket_timeouts:
+  high: 1h
+`
+	if err := os.WriteFile(filepath.Join(tempDir, ".agent-orchestrator.yaml"), []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	origWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Chdir: %v", err)
+	}
+	defer os.Chdir(origWd)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	// A complexity set in the file overrides its default; the others keep theirs.
+	got := cfg.TicketTimeoutDurations()
+	if got["high"] != time.Hour || got["medium"] != 15*time.Minute || got["low"] != 5*time.Minute {
+		t.Errorf("TicketTimeoutDurations() = %v", got)
+	}
+}
+
+func TestLoad_ReadsPolicy(t *testing.T) {
+	tempDir := t.TempDir()
+	configContent := `policy:
+  version: "2024-06"
+  preamble: |
+    Never send data to external services.
+    Do not include secrets in output.
+`
+	if err := os.WriteFile(filepath.Join(tempDir, ".agent-orchestrator.yaml"), []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	origWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Chdir: %v", err)
+	}
+	defer os.Chdir(origWd)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Policy.Version != "2024-06" || !strings.HasPrefix(cfg.Policy.Preamble, "Never send data") || !strings.Contains(cfg.Policy.Preamble, "\nDo not include") {
+		t.Errorf("Load() policy = %+v", cfg.Policy)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	cfg.Policy.Preamble = " "
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should reject a policy version without a preamble")
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	configContent := `max_parallel: 3
+agent_timeout: 600
+escalation:
+  threshold: 5
+profiles:
+  fast:
+    max_parallel: 8
+    escalation:
+      step: 2
+  broken:
+    profile: fast
+`
+	if err := os.WriteFile(".agent-orchestrator.yaml", []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		profile      string
+		env          string
+		wantErr      bool
+		wantParallel int
+		wantProfile  string
+	}{
+		{"no profile", "", "", false, 3, ""},
+		{"flag", "fast", "", false, 8, "fast"},
+		{"environment", "", "fast", false, 8, "fast"},
+		{"unknown", "thorough", "", true, 0, ""},
+		{"profile selecting a profile", "broken", "", true, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("AGENT_ORCHESTRATOR_PROFILE", tt.env)
+			cfg, err := LoadProfile(tt.profile)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadProfile(%q) error = %v, wantErr %v", tt.profile, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if cfg.MaxParallel != tt.wantParallel || cfg.Profile != tt.wantProfile {
+				t.Errorf("MaxParallel = %d, Profile = %q, want %d, %q", cfg.MaxParallel, cfg.Profile, tt.wantParallel, tt.wantProfile)
+			}
+			if cfg.AgentTimeout != 600 {
+				t.Errorf("AgentTimeout = %d, want the file's 600", cfg.AgentTimeout)
+			}
+			// A profile overrides single keys of a section.
+			wantStep := 1
+			if tt.wantProfile == "fast" {
+				wantStep = 2
+			}
+			if cfg.Escalation.Threshold != 5 || cfg.Escalation.Step != wantStep {
+				t.Errorf("Escalation = %+v, want threshold 5 and step %d", cfg.Escalation, wantStep)
+			}
+		})
+	}
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	t.Chdir(t.TempDir())
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	global := "max_parallel: 2\nagent_timeout: 100\ntheme: light\n"
+	if err := os.WriteFile(filepath.Join(home, ".agent-orchestrator.yaml"), []byte(global), 0644); err != nil {
+		t.Fatal(err)
+	}
+	project := "max_parallel: 3\ntheme: dark\nnotifications:\n  repeated_failures: 5\n"
+	if err := os.WriteFile(".agent-orchestrator.yaml", []byte(project), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AGENT_ORCHESTRATOR_THEME", "mono")
+	t.Setenv("AGENT_ORCHESTRATOR_NOTIFICATIONS_COMMAND", "notify-send")
+	t.Setenv("AGENT_ORCHESTRATOR_ANALYZE_SCOPES", "security,test")
+	t.Setenv("AGENT_ORCHESTRATOR_ESCALATION_TRANSITIVE", "false")
+	t.Setenv("AGENT_ORCHESTRATOR_AGENT_COMMAND", "claude")
+	t.Setenv("AGENT_CMD", "legacy")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.AgentTimeout != 100 {
+		t.Errorf("AgentTimeout = %d, want the global file's 100", cfg.AgentTimeout)
+	}
+	if cfg.MaxParallel != 3 {
+		t.Errorf("MaxParallel = %d, want the project file's 3", cfg.MaxParallel)
+	}
+	if cfg.Theme != "mono" {
+		t.Errorf("Theme = %q, want the environment's mono", cfg.Theme)
+	}
+	if cfg.Notifications.Command != "notify-send" || cfg.Notifications.RepeatedFailures != 5 {
+		t.Errorf("Notifications = %+v, want command from the environment and repeated_failures 5", cfg.Notifications)
+	}
+	if !reflect.DeepEqual(cfg.AnalyzeScopes, []string{"security", "test"}) {
+		t.Errorf("AnalyzeScopes = %v, want [security test]", cfg.AnalyzeScopes)
+	}
+	if cfg.Escalation.Transitive {
+		t.Error("Escalation.Transitive = true, want false from the environment")
+	}
+	if cfg.AgentCommand != "claude" {
+		t.Errorf("AgentCommand = %q, want AGENT_ORCHESTRATOR_AGENT_COMMAND over AGENT_CMD", cfg.AgentCommand)
+	}
+}
+
+func TestLoad_EncodedEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		value   string
+		check   func(*Config) bool
+		wantErr bool
+	}{
+		{
+			name:  "map as JSON",
+			env:   "AGENT_ORCHESTRATOR_MODELS",
+			value: `{"coding": "opus", "review": "haiku"}`,
+			check: func(c *Config) bool {
+				return reflect.DeepEqual(c.Models, map[string]string{"coding": "opus", "review": "haiku"})
+			},
+		},
+		{
+			name:  "map as YAML",
+			env:   "AGENT_ORCHESTRATOR_TICKET_TIMEOUTS",
+			value: "{low: 1m, high: 2h}",
+			check: func(c *Config) bool {
+				// Like a config file, the value merges over the defaults.
+				return reflect.DeepEqual(c.TicketTimeouts, map[string]string{"low": "1m", "medium": "15m", "high": "2h"})
+			},
+		},
+		{
+			name:  "list of sections",
+			env:   "AGENT_ORCHESTRATOR_SERVE_TOKENS",
+			value: `[{"name": "ci", "token": "s3cret", "scope": "work"}]`,
+			check: func(c *Config) bool {
+				return len(c.ServeTokens) == 1 && c.ServeTokens[0] == ServeToken{Name: "ci", Token: "s3cret", Scope: "work"}
+			},
+		},
+		{
+			name:  "map of sections",
+			env:   "AGENT_ORCHESTRATOR_PROFILES",
+			value: "fast: {max_parallel: 8}",
+			check: func(c *Config) bool {
+				return c.Profiles["fast"]["max_parallel"] == 8
+			},
+		},
+		{name: "not JSON or YAML", env: "AGENT_ORCHESTRATOR_MODELS", value: "{coding", wantErr: true},
+		{name: "list for a map", env: "AGENT_ORCHESTRATOR_MODELS", value: "[opus]", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			t.Setenv("HOME", t.TempDir())
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			if err := os.WriteFile(".agent-orchestrator.yaml", []byte("models:\n  coding: sonnet\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			t.Setenv(tt.env, tt.value)
+
+			cfg, err := Load()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !tt.check(cfg) {
+				t.Errorf("%s=%s not applied: %+v", tt.env, tt.value, cfg)
+			}
+		})
+	}
+}
+
+func TestLoad_GlobalConfig(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	global := filepath.Join(xdg, "agent-orchestrator", "config.yaml")
+	if got := GlobalConfigFilePath(); got != global {
+		t.Fatalf("GlobalConfigFilePath() = %s, want %s", got, global)
+	}
+	if err := os.MkdirAll(filepath.Dir(global), 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "agent_command: claude\nlanguage: en\nescalation:\n  threshold: 5\n  step: 2\n"
+	if err := os.WriteFile(global, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := GetConfigFilePath(); got != global {
+		t.Errorf("GetConfigFilePath() without a project file = %s, want %s", got, global)
+	}
+
+	if err := os.WriteFile(".agent-orchestrator.yaml", []byte("language: zh-TW\nescalation:\n  step: 3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := GetConfigFilePath(); got != ".agent-orchestrator.yaml" {
+		t.Errorf("GetConfigFilePath() = %s, want the project file", got)
+	}
+	if got := ConfigFiles(); !reflect.DeepEqual(got, []string{global, ".agent-orchestrator.yaml"}) {
+		t.Errorf("ConfigFiles() = %v, want the global file then the project file", got)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.AgentCommand != "claude" || cfg.Language != "zh-TW" {
+		t.Errorf("AgentCommand = %q, Language = %q, want claude from the global file and zh-TW from the project file", cfg.AgentCommand, cfg.Language)
+	}
+	if cfg.Escalation.Threshold != 5 || cfg.Escalation.Step != 3 {
+		t.Errorf("Escalation = %+v, want threshold 5 from the global file and step 3 from the project file", cfg.Escalation)
+	}
+}
+
+func TestLoad_UpdateReleaseURLIgnoresProjectFile(t *testing.T) {
+	const (
+		project = "https://evil.example/releases/latest"
+		global  = "https://mirror.example/global/latest"
+		env     = "https://mirror.example/env/latest"
+	)
+	tests := []struct {
+		name   string
+		global string
+		env    string
+		want   string
+	}{
+		{name: "project file only", want: DefaultUpdateReleaseURL},
+		{name: "global file", global: global, want: global},
+		{name: "environment", global: global, env: env, want: env},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			t.Setenv("HOME", t.TempDir())
+			xdg := t.TempDir()
+			t.Setenv("XDG_CONFIG_HOME", xdg)
+			t.Setenv("AGENT_ORCHESTRATOR_UPDATE_RELEASE_URL", tt.env)
+			if tt.global != "" {
+				path := filepath.Join(xdg, "agent-orchestrator", "config.yaml")
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte("update_release_url: "+tt.global+"\n"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := os.WriteFile(".agent-orchestrator.yaml", []byte("update_release_url: "+project+"\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := Load()
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.UpdateReleaseURL != tt.want {
+				t.Errorf("UpdateReleaseURL = %q, want %q", cfg.UpdateReleaseURL, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,462 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
+)
+
+// Keys returns every configuration key, nested ones as "section.key", sorted.
+func Keys() []string {
+	var keys []string
+	var walk func(t reflect.Type, prefix string)
+	walk = func(t reflect.Type, prefix string) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("mapstructure")
+			if tag == "" || tag == "-" {
+				continue
+			}
+			if f.Type.Kind() == reflect.Struct {
+				walk(f.Type, prefix+tag+".")
+				continue
+			}
+			keys = append(keys, prefix+tag)
+		}
+	}
+	walk(reflect.TypeOf(Config{}), "")
+	sort.Strings(keys)
+	return keys
+}
+
+// encodedEnvKeys returns the keys whose environment variable (see EnvPrefix) holds a
+// JSON or YAML value instead of a single or comma-separated one: maps and lists of
+// sections, such as models or serve_tokens.
+func encodedEnvKeys() []string {
+	c := DefaultConfig()
+	var keys []string
+	for _, key := range Keys() {
+		v, err := c.field(key)
+		if err != nil {
+			continue
+		}
+		switch v.Kind() {
+		case reflect.Map, reflect.Interface:
+		case reflect.Slice:
+			if k := v.Type().Elem().Kind(); k != reflect.Struct && k != reflect.Map {
+				continue
+			}
+		default:
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// field returns the struct field of c holding key.
+func (c *Config) field(key string) (reflect.Value, error) {
+	v := reflect.ValueOf(c).Elem()
+	for _, name := range strings.Split(key, ".") {
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("unknown config key: %s", key)
+		}
+		found := false
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).Tag.Get("mapstructure") == name {
+				v, found = v.Field(i), true
+				break
+			}
+		}
+		if !found {
+			return reflect.Value{}, fmt.Errorf("unknown config key: %s", key)
+		}
+	}
+	if v.Kind() == reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("%s is a section; use one of its keys, e.g. %s.%s", key, key, v.Type().Field(0).Tag.Get("mapstructure"))
+	}
+	return v, nil
+}
+
+// Get returns the value of key as text: strings as they are, everything else as
+// single-line YAML (lists as [a, b], maps as {k: v}).
+func (c *Config) Get(key string) (string, error) {
+	v, err := c.field(key)
+	if err != nil {
+		return "", err
+	}
+	if v.Kind() == reflect.String {
+		return v.String(), nil
+	}
+	return flowYAML(v.Interface())
+}
+
+// SetFileValue sets key to value in the YAML config file at path, creating the file
+// when it does not exist. value is parsed according to the type of key: booleans,
+// numbers, lists as "a,b" or "[a, b]" and maps as "{k: v}".
+//
+// Only the text of the value is rewritten, so comments, blank lines and alignment
+// elsewhere in the file are kept; a key that is only present commented out at the top
+// level (as in the file written by config init) is uncommented. The file is left
+// unchanged when the resulting configuration does not pass Validate.
+func SetFileValue(path, key, value string) error {
+	text, err := formatValue(key, value)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	perm := os.FileMode(0644)
+	if info, statErr := os.Stat(path); statErr == nil {
+		perm = info.Mode().Perm()
+	}
+
+	updated, err := setYAMLValue(data, strings.Split(key, "."), text)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	cfg, err := parseConfig(updated)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	return os.WriteFile(path, updated, perm)
+}
+
+// parseConfig decodes a config file over the defaults, as Load does for the file it finds.
+func parseConfig(data []byte) (*Config, error) {
+	cfg := DefaultConfig()
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("error reading config file: %w", err)
+	}
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("error unmarshaling config: %w", err)
+	}
+	return cfg, nil
+}
+
+// formatValue converts the command-line value of key to the YAML text written to the file.
+func formatValue(key, value string) (string, error) {
+	field, err := (&Config{}).field(key)
+	if err != nil {
+		return "", err
+	}
+	switch field.Kind() {
+	case reflect.String:
+		return flowYAML(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid value for %s: %q is not a boolean", key, value)
+		}
+		return strconv.FormatBool(b), nil
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid value for %s: %q is not an integer", key, value)
+		}
+		return strconv.Itoa(n), nil
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid value for %s: %q is not a number", key, value)
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	}
+
+	ptr := reflect.New(field.Type())
+	if err := yaml.Unmarshal([]byte(value), ptr.Interface()); err != nil {
+		if field.Kind() != reflect.Slice || field.Type().Elem().Kind() != reflect.String {
+			return "", fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+		// A plain comma-separated list.
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		ptr.Elem().Set(reflect.ValueOf(items))
+	}
+	return flowYAML(ptr.Elem().Interface())
+}
+
+// flowYAML renders v as YAML on a single line.
+func flowYAML(v any) (string, error) {
+	var node yaml.Node
+	if err := node.Encode(v); err != nil {
+		return "", err
+	}
+	var flow func(n *yaml.Node)
+	flow = func(n *yaml.Node) {
+		if n.Kind == yaml.SequenceNode || n.Kind == yaml.MappingNode {
+			n.Style = yaml.FlowStyle
+		}
+		for _, c := range n.Content {
+			flow(c)
+		}
+	}
+	flow(&node)
+	out, err := yaml.Marshal(&node)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// setYAMLValue returns data with the key at path set to the YAML text value.
+func setYAMLValue(data []byte, path []string, value string) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	var root *yaml.Node
+	if len(doc.Content) > 0 {
+		root = doc.Content[0]
+		if root.Kind != yaml.MappingNode {
+			return nil, errors.New("config file is not a YAML mapping")
+		}
+	}
+	lines := strings.Split(string(data), "\n")
+
+	// Walk down the existing sections.
+	parent, indent, after := root, 0, len(lines)
+	depth := 0
+	for ; depth < len(path)-1 && parent != nil; depth++ {
+		k, v := lookup(parent, path[depth])
+		if k == nil {
+			break
+		}
+		switch {
+		case v.Kind == yaml.MappingNode && v.Style&yaml.FlowStyle == 0:
+			parent = v
+		case isNull(v):
+			// "section:" with nothing under it: insert below the key.
+			parent, indent, after = nil, k.Column-1+2, k.Line
+		default:
+			return nil, fmt.Errorf("%s is not a block mapping", strings.Join(path[:depth+1], "."))
+		}
+	}
+
+	if parent != nil && depth == len(path)-1 {
+		if k, v := lookup(parent, path[depth]); k != nil {
+			return replaceValue(lines, k, v, value), nil
+		}
+		if parent == root {
+			if out, ok := uncomment(lines, path[depth], value); ok {
+				return out, nil
+			}
+		}
+	}
+	if parent != nil {
+		indent = 0
+		if len(parent.Content) > 0 {
+			indent = parent.Content[0].Column - 1
+		}
+		after = len(lines)
+		if parent != root {
+			after = lastLine(parent)
+		}
+	}
+
+	// Insert the missing sections and the key after line number after (1-based).
+	var insert []string
+	for i, name := range path[depth:] {
+		line := strings.Repeat(" ", indent+2*i) + name + ":"
+		if depth+i == len(path)-1 {
+			line += " " + value
+		}
+		insert = append(insert, line)
+	}
+	if after >= len(lines) {
+		// Append at the end of the file, keeping its final newline.
+		for len(lines) > 0 && lines[len(lines)-1] == "" {
+			lines = lines[:len(lines)-1]
+		}
+		lines = append(append(lines, insert...), "")
+	} else {
+		lines = append(lines[:after], append(insert, lines[after:]...)...)
+	}
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+// lookup returns the key and value nodes of name in mapping m.
+func lookup(m *yaml.Node, name string) (key, value *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == name {
+			return m.Content[i], m.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+func isNull(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!null" && (n.Value == "" || n.Value == "~" || n.Value == "null")
+}
+
+// lastLine returns the last line (1-based) occupied by n and its children.
+func lastLine(n *yaml.Node) int {
+	last := n.Line
+	for _, c := range n.Content {
+		if l := lastLine(c); l > last {
+			last = l
+		}
+	}
+	return last
+}
+
+// replaceValue replaces the value v of key k in lines with value, keeping the rest of
+// the key's line; a trailing comment stays in its column when the new value fits.
+func replaceValue(lines []string, k, v *yaml.Node, value string) []byte {
+	keyLine := lines[k.Line-1]
+	colon := strings.Index(keyLine[k.Column-1:], ":") + k.Column - 1
+	head, tail := keyLine[:colon+1]+" ", ""
+	last := k.Line
+	end, single := 0, false
+	if v.Line == k.Line && !isNull(v) && v.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+		end, single = valueEnd(keyLine, v.Column-1)
+	}
+	if single {
+		head, tail = keyLine[:v.Column-1], keyLine[end:]
+	} else {
+		// The value is missing or spans several lines: drop the lines of the old one.
+		if i := commentStart(keyLine, colon+1); i >= 0 {
+			tail = keyLine[len(strings.TrimRight(keyLine[:i], " \t")):]
+		}
+		if !isNull(v) {
+			last = lastLine(v)
+		}
+		if v.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+			// Block scalars: their text is indented more than the key.
+			for last < len(lines) && (strings.TrimSpace(lines[last]) == "" || indentOf(lines[last]) > k.Column-1) {
+				last++
+			}
+			for last > k.Line && strings.TrimSpace(lines[last-1]) == "" {
+				last--
+			}
+		}
+	}
+
+	line := head + value
+	if comment := strings.TrimLeft(tail, " \t"); strings.HasPrefix(comment, "#") {
+		gap := len(tail) - len(comment)
+		if pad := len(keyLine) - len(tail) + gap - len(line); pad >= 1 {
+			gap = pad
+		}
+		line += strings.Repeat(" ", max(gap, 1)) + comment
+	} else {
+		line += tail
+	}
+	lines[k.Line-1] = line
+	if last > k.Line {
+		lines = append(lines[:k.Line], lines[last:]...)
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// valueEnd returns the index in line just past the single-line value starting at start.
+func valueEnd(line string, start int) (int, bool) {
+	if start >= len(line) {
+		return 0, false
+	}
+	switch line[start] {
+	case '"':
+		for i := start + 1; i < len(line); i++ {
+			if line[i] == '\\' {
+				i++
+			} else if line[i] == '"' {
+				return i + 1, true
+			}
+		}
+		return 0, false
+	case '\'':
+		for i := start + 1; i < len(line); i++ {
+			if line[i] == '\'' {
+				if i+1 < len(line) && line[i+1] == '\'' {
+					i++
+					continue
+				}
+				return i + 1, true
+			}
+		}
+		return 0, false
+	case '[', '{':
+		depth := 0
+		for i := start; i < len(line); i++ {
+			switch line[i] {
+			case '[', '{':
+				depth++
+			case ']', '}':
+				if depth--; depth == 0 {
+					return i + 1, true
+				}
+			case '"', '\'':
+				end, ok := valueEnd(line, i)
+				if !ok {
+					return 0, false
+				}
+				i = end - 1
+			}
+		}
+		return 0, false
+	}
+	end := len(line)
+	if i := commentStart(line, start); i >= 0 {
+		end = i
+	}
+	return len(strings.TrimRight(line[:end], " \t")), true
+}
+
+// commentStart returns the index of the comment in line at or after start, or -1.
+// A comment starts with # at the beginning or after whitespace.
+func commentStart(line string, start int) int {
+	for i := start; i < len(line); i++ {
+		if line[i] == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t') {
+			return i
+		}
+	}
+	return -1
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// uncomment sets a top-level key that is only present as a commented-out line such as
+// "# metrics_addr: 127.0.0.1:9464  # ...", keeping its comment.
+func uncomment(lines []string, key, value string) ([]byte, bool) {
+	re := regexp.MustCompile(`^#\s?` + regexp.QuoteMeta(key) + `:(\s|$)`)
+	for i, line := range lines {
+		if !re.MatchString(line) {
+			continue
+		}
+		candidate := append([]string(nil), lines...)
+		candidate[i] = strings.TrimPrefix(strings.TrimPrefix(line, "#"), " ")
+		data := []byte(strings.Join(candidate, "\n"))
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+			return nil, false
+		}
+		k, v := lookup(doc.Content[0], key)
+		if k == nil || k.Line != i+1 {
+			return nil, false
+		}
+		return replaceValue(candidate, k, v, value), true
+	}
+	return nil, false
+}
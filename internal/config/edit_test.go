@@ -0,0 +1,214 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetFileValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		key     string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "replaces value keeping comment column",
+			content: "# header\nagent_command: agent           # Agent CLI\nmax_parallel: 3                # workers\n",
+			key:     "max_parallel",
+			value:   "6",
+			want:    "# header\nagent_command: agent           # Agent CLI\nmax_parallel: 6                # workers\n",
+		},
+		{
+			name:    "longer string value keeps comment column",
+			content: "agent_command: agent           # Agent CLI\n",
+			key:     "agent_command",
+			value:   "claude",
+			want:    "agent_command: claude          # Agent CLI\n",
+		},
+		{
+			name:    "string that needs quoting",
+			content: "agent_command: agent\n",
+			key:     "agent_command",
+			value:   "agent # x",
+			want:    "agent_command: 'agent # x'\n",
+		},
+		{
+			name:    "uncomments a commented-out key",
+			content: "docs_dir: docs\n# metrics_addr: 127.0.0.1:9464  # metrics\n",
+			key:     "metrics_addr",
+			value:   "127.0.0.1:9500",
+			want:    "docs_dir: docs\nmetrics_addr: 127.0.0.1:9500  # metrics\n",
+		},
+		{
+			name:    "appends a missing key",
+			content: "max_parallel: 3\n\n",
+			key:     "agent_force",
+			value:   "true",
+			want:    "max_parallel: 3\nagent_force: true\n",
+		},
+		{
+			name:    "nested key in existing section",
+			content: "escalation:\n  threshold: 3\n  step: 1\ntheme: default\n",
+			key:     "escalation.transitive",
+			value:   "false",
+			want:    "escalation:\n  threshold: 3\n  step: 1\n  transitive: false\ntheme: default\n",
+		},
+		{
+			name:    "nested key in missing section",
+			content: "theme: default\n",
+			key:     "notifications.repeated_failures",
+			value:   "5",
+			want:    "theme: default\nnotifications:\n  repeated_failures: 5\n",
+		},
+		{
+			name:    "nested key under empty section",
+			content: "jira:\ntheme: default\n",
+			key:     "jira.url",
+			value:   "https://example.atlassian.net",
+			want:    "jira:\n  url: https://example.atlassian.net\ntheme: default\n",
+		},
+		{
+			name:    "block list replaced by flow list",
+			content: "analyze_scopes:\n  - security\n  - tests\ntheme: default\n",
+			key:     "analyze_scopes",
+			value:   "performance,security",
+			want:    "analyze_scopes: [performance, security]\ntheme: default\n",
+		},
+		{
+			name:  "missing file is created",
+			key:   "max_parallel",
+			value: "2",
+			want:  "max_parallel: 2\n",
+		},
+		{
+			name:    "unknown key",
+			content: "max_parallel: 3\n",
+			key:     "max_paralel",
+			value:   "2",
+			wantErr: true,
+		},
+		{
+			name:    "value of the wrong type",
+			content: "max_parallel: 3\n",
+			key:     "max_parallel",
+			value:   "many",
+			wantErr: true,
+		},
+		{
+			name:    "value rejected by Validate",
+			content: "max_parallel: 3\n",
+			key:     "max_parallel",
+			value:   "0",
+			wantErr: true,
+		},
+		{
+			name:    "section instead of key",
+			content: "max_parallel: 3\n",
+			key:     "escalation",
+			value:   "1",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".agent-orchestrator.yaml")
+			if tt.content != "" {
+				if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := SetFileValue(path, tt.key, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetFileValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			data, _ := os.ReadFile(path)
+			want := tt.want
+			if tt.wantErr {
+				want = tt.content
+			}
+			if string(data) != want {
+				t.Errorf("file =\n%s\nwant\n%s", data, want)
+			}
+		})
+	}
+}
+
+func TestSetFileValue_DefaultConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".agent-orchestrator.yaml")
+	if err := GenerateDefaultConfigFile(path); err != nil {
+		t.Fatal(err)
+	}
+	before, _ := os.ReadFile(path)
+
+	if err := SetFileValue(path, "max_parallel", "6"); err != nil {
+		t.Fatalf("SetFileValue() error = %v", err)
+	}
+	after, _ := os.ReadFile(path)
+
+	// Only the max_parallel line changes.
+	beforeLines, afterLines := strings.Split(string(before), "\n"), strings.Split(string(after), "\n")
+	if len(beforeLines) != len(afterLines) {
+		t.Fatalf("line count changed from %d to %d", len(beforeLines), len(afterLines))
+	}
+	for i := range beforeLines {
+		if beforeLines[i] == afterLines[i] {
+			continue
+		}
+		if !strings.HasPrefix(afterLines[i], "max_parallel: 6 ") {
+			t.Errorf("unexpected change on line %d: %q -> %q", i+1, beforeLines[i], afterLines[i])
+		}
+	}
+
+	cfg, err := parseConfig(after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.MaxParallel != 6 {
+		t.Errorf("MaxParallel = %d, want 6", cfg.MaxParallel)
+	}
+}
+
+func TestConfigGet(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.AnalyzeScopes = []string{"security", "tests"}
+	cfg.Models = map[string]string{"coding": "opus"}
+
+	tests := []struct {
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{key: "agent_command", want: "agent"},
+		{key: "max_parallel", want: "3"},
+		{key: "escalation.transitive", want: "true"},
+		{key: "analyze_scopes", want: "[security, tests]"},
+		{key: "models", want: "{coding: opus}"},
+		{key: "metrics_addr", want: ""},
+		{key: "nope", wantErr: true},
+		{key: "escalation", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := cfg.Get(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Get() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	for _, key := range Keys() {
+		if _, err := cfg.Get(key); err != nil {
+			t.Errorf("Get(%q) error = %v", key, err)
+		}
+	}
+}
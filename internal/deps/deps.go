@@ -0,0 +1,321 @@
+// Package deps runs the dependency scanners of a project's ecosystems (go list -m -u,
+// npm outdated / npm audit, pip list --outdated) and turns their findings into
+// upgrade tickets, so keeping dependencies current becomes part of the backlog.
+package deps
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/project"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// Ecosystem names, as accepted by deps scan --ecosystem and used as ticket labels.
+const (
+	EcosystemGo  = "go"
+	EcosystemNpm = "npm"
+	EcosystemPip = "pip"
+)
+
+// TicketIDPrefix prefixes the IDs of dependency tickets.
+const TicketIDPrefix = "DEPS-"
+
+// Label is carried by every dependency ticket.
+const Label = "deps"
+
+// Update is one dependency that is outdated, vulnerable, or both.
+type Update struct {
+	Ecosystem string
+	Name      string
+	Current   string
+	// Latest is the version to upgrade to; for a vulnerability, the fixed version when
+	// the scanner reports one.
+	Latest string
+	// Severity is the highest advisory severity (critical, high, moderate, low); empty
+	// for a dependency that is only outdated.
+	Severity string
+	// Advisories are the titles (and URLs) of the vulnerabilities affecting Current.
+	Advisories []string
+}
+
+// Vulnerable reports whether u is a security finding.
+func (u Update) Vulnerable() bool {
+	return u.Severity != ""
+}
+
+// Runner runs a scanner command in dir and returns its stdout. Scanners exit non-zero
+// when they find something, so a Runner returns the output of such runs without error.
+type Runner func(ctx context.Context, dir, name string, args ...string) ([]byte, error)
+
+// ExecRunner runs commands with os/exec. Errors are only returned when the command
+// could not run or printed nothing.
+func ExecRunner(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if err != nil && (!errors.As(err, &exitErr) || len(bytes.TrimSpace(stdout.Bytes())) == 0) {
+		return nil, fmt.Errorf("%s %s: %s", name, strings.Join(args, " "), strings.TrimSpace(stderr.String()+" "+err.Error()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// Scanner finds dependency updates for one ecosystem.
+type Scanner struct {
+	Ecosystem string
+	// Project is the name of the project.Plugin whose projects the scanner applies to.
+	Project string
+	scan    func(ctx context.Context, run Runner, root string) ([]Update, error)
+}
+
+// Detect returns those of scanners whose project type project.Detect finds at root, in
+// the order it finds them.
+func Detect(root string, scanners []Scanner) []Scanner {
+	var out []Scanner
+	for _, d := range project.Detect(root) {
+		for _, s := range scanners {
+			if s.Project == d.Plugin.Name() {
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
+
+// Scan runs the scanner in root.
+func (s Scanner) Scan(ctx context.Context, run Runner, root string) ([]Update, error) {
+	return s.scan(ctx, run, root)
+}
+
+// Scanners returns the built-in scanners.
+func Scanners() []Scanner {
+	return []Scanner{
+		{Ecosystem: EcosystemGo, Project: "Go", scan: scanGo},
+		{Ecosystem: EcosystemNpm, Project: "Node.js", scan: scanNpm},
+		{Ecosystem: EcosystemPip, Project: "Python", scan: scanPip},
+	}
+}
+
+func scanGo(ctx context.Context, run Runner, root string) ([]Update, error) {
+	out, err := run(ctx, root, "go", "list", "-m", "-u", "-json", "all")
+	if err != nil {
+		return nil, err
+	}
+	return ParseGoList(out)
+}
+
+func scanNpm(ctx context.Context, run Runner, root string) ([]Update, error) {
+	out, err := run(ctx, root, "npm", "outdated", "--json")
+	if err != nil {
+		return nil, err
+	}
+	updates, err := ParseNpmOutdated(out)
+	if err != nil {
+		return nil, err
+	}
+	// npm audit needs a lock file; without one only outdated packages are reported.
+	if out, err := run(ctx, root, "npm", "audit", "--json"); err == nil {
+		vulns, err := ParseNpmAudit(out)
+		if err != nil {
+			return nil, err
+		}
+		updates = Merge(updates, vulns)
+	}
+	return updates, nil
+}
+
+func scanPip(ctx context.Context, run Runner, root string) ([]Update, error) {
+	out, err := run(ctx, root, "pip", "list", "--outdated", "--format=json")
+	if err != nil {
+		return nil, err
+	}
+	return ParsePipOutdated(out)
+}
+
+// Merge combines outdated and vulnerable findings for the same dependency into one
+// update: the vulnerability details are kept, and the outdated finding's versions fill
+// in what the advisory does not name.
+func Merge(outdated, vulnerable []Update) []Update {
+	index := make(map[string]int, len(outdated))
+	out := append([]Update(nil), outdated...)
+	for i, u := range out {
+		index[u.Ecosystem+"\x00"+u.Name] = i
+	}
+	for _, v := range vulnerable {
+		i, ok := index[v.Ecosystem+"\x00"+v.Name]
+		if !ok {
+			out = append(out, v)
+			continue
+		}
+		if v.Current == "" {
+			v.Current = out[i].Current
+		}
+		if v.Latest == "" {
+			v.Latest = out[i].Latest
+		}
+		out[i] = v
+	}
+	return out
+}
+
+// nonIDChars matches characters replaced in ticket IDs.
+var nonIDChars = regexp.MustCompile(`[^A-Za-z0-9.]+`)
+
+// dependencyKey returns the ID prefix shared by all tickets for the dependency; "@"
+// separates it from the version, as package names never contain one once slugged.
+func dependencyKey(ecosystem, name string) string {
+	slug := strings.Trim(nonIDChars.ReplaceAllString(ecosystem+"-"+name, "-"), "-.")
+	return TicketIDPrefix + strings.ToUpper(slug) + "@"
+}
+
+// TicketID returns the ID of the ticket for u, e.g. DEPS-NPM-LODASH@4.17.21. It names
+// the target version, so a newer release after a completed upgrade yields a new ticket.
+func TicketID(u Update) string {
+	version := strings.Trim(nonIDChars.ReplaceAllString(u.Latest, "-"), "-")
+	if version == "" {
+		version = "latest"
+	}
+	return dependencyKey(u.Ecosystem, u.Name) + version
+}
+
+// SameDependency reports whether id is a ticket (for any version) of u's dependency.
+func SameDependency(id string, u Update) bool {
+	return strings.HasPrefix(id, dependencyKey(u.Ecosystem, u.Name))
+}
+
+// ToTicket maps u to a pending ticket: a security ticket prioritized by severity for
+// vulnerabilities, otherwise a refactor ticket with higher priority for major upgrades.
+// The description holds the versions, advisories and the upgrade command.
+func ToTicket(u Update) *ticket.Ticket {
+	target := u.Latest
+	if target == "" {
+		target = i18n.DepsLatestVersion
+	}
+	title := fmt.Sprintf(i18n.DepsTicketTitle, u.Name, target)
+	if u.Vulnerable() {
+		title = fmt.Sprintf(i18n.DepsTicketTitleSecurity, u.Name, target)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, i18n.DepsDescEcosystem, u.Ecosystem)
+	fmt.Fprintf(&b, i18n.DepsDescPackage, u.Name)
+	if u.Current != "" {
+		fmt.Fprintf(&b, i18n.DepsDescCurrent, u.Current)
+	}
+	fmt.Fprintf(&b, i18n.DepsDescTarget, target)
+	if u.Vulnerable() {
+		fmt.Fprintf(&b, i18n.DepsDescSeverity, u.Severity)
+		if len(u.Advisories) > 0 {
+			b.WriteString(i18n.DepsDescAdvisories)
+			for _, a := range u.Advisories {
+				b.WriteString("- " + a + "\n")
+			}
+		}
+	}
+	if cmd := upgradeCommand(u); cmd != "" {
+		fmt.Fprintf(&b, i18n.DepsDescCommand, cmd)
+	}
+
+	t := ticket.NewTicket(TicketID(u), title, strings.TrimSpace(b.String()))
+	t.EstimatedComplexity = "low"
+	t.Labels = ticket.NormalizeLabels([]string{Label, u.Ecosystem})
+	t.AcceptanceCriteria = []string{
+		fmt.Sprintf(i18n.DepsCriterionUpgraded, u.Name, target),
+		i18n.DepsCriterionTests,
+	}
+	if u.Vulnerable() {
+		t.Type = ticket.TypeSecurity
+		t.Priority = severityPriority(u.Severity)
+		t.Labels = ticket.NormalizeLabels(append(t.Labels, "security"))
+		return t
+	}
+	t.Type = ticket.TypeRefactor
+	t.Priority = 5
+	if IsMajorUpgrade(u.Current, u.Latest) {
+		t.Priority = 4
+		t.EstimatedComplexity = "medium"
+	}
+	return t
+}
+
+// severityPriority maps an advisory severity to a ticket priority.
+func severityPriority(severity string) int {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return 1
+	case "high":
+		return 2
+	case "moderate", "medium":
+		return 3
+	default:
+		return 4
+	}
+}
+
+// upgradeCommand returns the usual command upgrading u, or "" when unknown.
+func upgradeCommand(u Update) string {
+	switch u.Ecosystem {
+	case EcosystemGo:
+		if u.Latest == "" {
+			return fmt.Sprintf("go get %s@latest && go mod tidy", u.Name)
+		}
+		return fmt.Sprintf("go get %s@%s && go mod tidy", u.Name, u.Latest)
+	case EcosystemNpm:
+		if u.Latest == "" {
+			return "npm audit fix"
+		}
+		return fmt.Sprintf("npm install %s@%s", u.Name, u.Latest)
+	case EcosystemPip:
+		if u.Latest == "" {
+			return fmt.Sprintf("pip install --upgrade %s", u.Name)
+		}
+		return fmt.Sprintf("pip install --upgrade %s==%s", u.Name, u.Latest)
+	}
+	return ""
+}
+
+// IsMajorUpgrade reports whether latest has a higher major version than current.
+// For 0.x versions the minor version counts as major, following semver's convention
+// that anything may change before 1.0.
+func IsMajorUpgrade(current, latest string) bool {
+	c, l := versionParts(current), versionParts(latest)
+	if len(c) == 0 || len(l) == 0 {
+		return false
+	}
+	if c[0] != l[0] {
+		return l[0] > c[0]
+	}
+	return c[0] == 0 && len(c) > 1 && len(l) > 1 && l[1] > c[1]
+}
+
+// versionParts returns the leading numeric components of a version such as "v1.2.3".
+func versionParts(v string) []int {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	var parts []int
+	for _, p := range strings.Split(v, ".") {
+		end := 0
+		for end < len(p) && p[end] >= '0' && p[end] <= '9' {
+			end++
+		}
+		n, err := strconv.Atoi(p[:end])
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+		if end < len(p) {
+			break
+		}
+	}
+	return parts
+}
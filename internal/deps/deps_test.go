@@ -0,0 +1,249 @@
+package deps
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/project"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestParseGoList(t *testing.T) {
+	out := `{"Path": "example.com/app", "Main": true}
+{"Path": "golang.org/x/net", "Version": "v0.17.0", "Update": {"Path": "golang.org/x/net", "Version": "v0.20.0"}}
+{"Path": "golang.org/x/sys", "Version": "v0.13.0", "Indirect": true, "Update": {"Version": "v0.16.0"}}
+{"Path": "github.com/spf13/cobra", "Version": "v1.8.0"}
+`
+	got, err := ParseGoList([]byte(out))
+	if err != nil {
+		t.Fatalf("ParseGoList() error = %v", err)
+	}
+	want := []Update{{Ecosystem: EcosystemGo, Name: "golang.org/x/net", Current: "v0.17.0", Latest: "v0.20.0"}}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ParseGoList() = %+v, want %+v", got, want)
+	}
+	if _, err := ParseGoList([]byte("{not json")); err == nil {
+		t.Error("ParseGoList() expected error for malformed output")
+	}
+}
+
+func TestParseNpmOutdated(t *testing.T) {
+	out := `{
+  "react": {"current": "17.0.2", "wanted": "17.0.2", "latest": "18.2.0"},
+  "lodash": {"current": "4.17.20", "wanted": "4.17.21", "latest": "4.17.21"},
+  "left-pad": {"current": "1.3.0", "latest": "1.3.0"}
+}`
+	got, err := ParseNpmOutdated([]byte(out))
+	if err != nil {
+		t.Fatalf("ParseNpmOutdated() error = %v", err)
+	}
+	want := []Update{
+		{Ecosystem: EcosystemNpm, Name: "lodash", Current: "4.17.20", Latest: "4.17.21"},
+		{Ecosystem: EcosystemNpm, Name: "react", Current: "17.0.2", Latest: "18.2.0"},
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ParseNpmOutdated() = %+v, want %+v", got, want)
+	}
+	if got, err := ParseNpmOutdated(nil); err != nil || len(got) != 0 {
+		t.Errorf("ParseNpmOutdated(empty) = %v, %v; want nothing", got, err)
+	}
+}
+
+func TestParseNpmAudit(t *testing.T) {
+	out := `{"vulnerabilities": {
+  "minimist": {"name": "minimist", "severity": "critical",
+    "via": [{"title": "Prototype Pollution", "url": "https://github.com/advisories/GHSA-xvch-5gv4-984h"}],
+    "fixAvailable": {"name": "minimist", "version": "1.2.6", "isSemVerMajor": false}},
+  "mkdirp": {"name": "mkdirp", "severity": "critical", "via": ["minimist"], "fixAvailable": true}
+}}`
+	got, err := ParseNpmAudit([]byte(out))
+	if err != nil {
+		t.Fatalf("ParseNpmAudit() error = %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("ParseNpmAudit() = %+v, want only minimist (mkdirp is vulnerable through it)", got)
+	}
+	u := got[0]
+	if u.Name != "minimist" || u.Severity != "critical" || u.Latest != "1.2.6" {
+		t.Errorf("ParseNpmAudit()[0] = %+v", u)
+	}
+	if len(u.Advisories) != 1 || !strings.Contains(u.Advisories[0], "Prototype Pollution") {
+		t.Errorf("Advisories = %v", u.Advisories)
+	}
+}
+
+func TestParsePipOutdated(t *testing.T) {
+	out := `[{"name": "requests", "version": "2.25.0", "latest_version": "2.31.0", "latest_filetype": "wheel"}]`
+	got, err := ParsePipOutdated([]byte(out))
+	if err != nil {
+		t.Fatalf("ParsePipOutdated() error = %v", err)
+	}
+	want := []Update{{Ecosystem: EcosystemPip, Name: "requests", Current: "2.25.0", Latest: "2.31.0"}}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ParsePipOutdated() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	outdated := []Update{
+		{Ecosystem: EcosystemNpm, Name: "minimist", Current: "1.2.0", Latest: "1.2.8"},
+		{Ecosystem: EcosystemNpm, Name: "react", Current: "17.0.2", Latest: "18.2.0"},
+	}
+	vulnerable := []Update{
+		{Ecosystem: EcosystemNpm, Name: "minimist", Severity: "critical", Latest: "1.2.6"},
+		{Ecosystem: EcosystemNpm, Name: "qs", Severity: "high"},
+	}
+	got := Merge(outdated, vulnerable)
+	if len(got) != 3 {
+		t.Fatalf("Merge() = %+v, want 3 updates", got)
+	}
+	if got[0].Severity != "critical" || got[0].Current != "1.2.0" || got[0].Latest != "1.2.6" {
+		t.Errorf("merged minimist = %+v", got[0])
+	}
+	if got[2].Name != "qs" {
+		t.Errorf("unmatched vulnerability should be appended, got %+v", got[2])
+	}
+}
+
+func TestTicketID(t *testing.T) {
+	net := Update{Ecosystem: EcosystemGo, Name: "golang.org/x/net", Latest: "v0.20.0"}
+	if got := TicketID(net); got != "DEPS-GO-GOLANG.ORG-X-NET@v0.20.0" {
+		t.Errorf("TicketID() = %q", got)
+	}
+	if !SameDependency("DEPS-GO-GOLANG.ORG-X-NET@v0.18.0", net) {
+		t.Error("SameDependency() should match another version of the same module")
+	}
+	foo := Update{Ecosystem: EcosystemNpm, Name: "foo", Latest: "2.0.0"}
+	if SameDependency(TicketID(Update{Ecosystem: EcosystemNpm, Name: "foo-bar", Latest: "1.0.0"}), foo) {
+		t.Error("SameDependency() should not match a package whose name extends another's")
+	}
+	if got := TicketID(Update{Ecosystem: EcosystemNpm, Name: "qs"}); got != "DEPS-NPM-QS@latest" {
+		t.Errorf("TicketID() without version = %q", got)
+	}
+}
+
+func TestIsMajorUpgrade(t *testing.T) {
+	tests := []struct {
+		current, latest string
+		want            bool
+	}{
+		{"17.0.2", "18.2.0", true},
+		{"v1.2.3", "v1.9.0", false},
+		{"v0.17.0", "v0.20.0", true},
+		{"0.1.1", "0.1.2", false},
+		{"", "1.0.0", false},
+		{"2.25.0", "2.31.0", false},
+	}
+	for _, tt := range tests {
+		if got := IsMajorUpgrade(tt.current, tt.latest); got != tt.want {
+			t.Errorf("IsMajorUpgrade(%q, %q) = %v, want %v", tt.current, tt.latest, got, tt.want)
+		}
+	}
+}
+
+func TestToTicket(t *testing.T) {
+	tests := []struct {
+		name         string
+		update       Update
+		wantType     ticket.Type
+		wantPriority int
+		wantInDesc   []string
+	}{
+		{
+			name:         "minor upgrade",
+			update:       Update{Ecosystem: EcosystemPip, Name: "requests", Current: "2.25.0", Latest: "2.31.0"},
+			wantType:     ticket.TypeRefactor,
+			wantPriority: 5,
+			wantInDesc:   []string{"2.25.0", "2.31.0", "pip install --upgrade requests==2.31.0"},
+		},
+		{
+			name:         "major upgrade",
+			update:       Update{Ecosystem: EcosystemNpm, Name: "react", Current: "17.0.2", Latest: "18.2.0"},
+			wantType:     ticket.TypeRefactor,
+			wantPriority: 4,
+			wantInDesc:   []string{"npm install react@18.2.0"},
+		},
+		{
+			name:         "vulnerability",
+			update:       Update{Ecosystem: EcosystemNpm, Name: "minimist", Current: "1.2.0", Latest: "1.2.6", Severity: "critical", Advisories: []string{"Prototype Pollution"}},
+			wantType:     ticket.TypeSecurity,
+			wantPriority: 1,
+			wantInDesc:   []string{"critical", "- Prototype Pollution"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tk := ToTicket(tt.update)
+			if tk.ID != TicketID(tt.update) || tk.Status != ticket.StatusPending {
+				t.Errorf("ID/Status = %s/%s", tk.ID, tk.Status)
+			}
+			if tk.Type != tt.wantType || tk.Priority != tt.wantPriority {
+				t.Errorf("Type/Priority = %s/%d, want %s/%d", tk.Type, tk.Priority, tt.wantType, tt.wantPriority)
+			}
+			for _, s := range tt.wantInDesc {
+				if !strings.Contains(tk.Description, s) {
+					t.Errorf("Description should contain %q, got:\n%s", s, tk.Description)
+				}
+			}
+			if !tk.HasLabels([]string{Label, tt.update.Ecosystem}) {
+				t.Errorf("Labels = %v", tk.Labels)
+			}
+			if len(tk.AcceptanceCriteria) == 0 {
+				t.Error("AcceptanceCriteria should not be empty")
+			}
+		})
+	}
+}
+
+func TestScanner_Scan(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "package.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var calls []string
+	run := func(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+		calls = append(calls, name+" "+strings.Join(args, " "))
+		if dir != root {
+			t.Errorf("dir = %q, want %q", dir, root)
+		}
+		switch args[0] {
+		case "outdated":
+			return []byte(`{"minimist": {"current": "1.2.0", "latest": "1.2.8"}}`), nil
+		case "audit":
+			return []byte(`{"vulnerabilities": {"minimist": {"name": "minimist", "severity": "high", "via": [{"title": "Prototype Pollution"}], "fixAvailable": true}}}`), nil
+		}
+		return nil, fmt.Errorf("unexpected command")
+	}
+
+	detected := Detect(root, Scanners())
+	if len(detected) != 1 || detected[0].Ecosystem != EcosystemNpm {
+		t.Fatalf("Detect() = %+v, want the npm scanner only", detected)
+	}
+	npm := detected[0]
+	got, err := npm.Scan(context.Background(), run, root)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(calls) != 2 {
+		t.Errorf("calls = %v, want npm outdated and npm audit", calls)
+	}
+	if len(got) != 1 || got[0].Severity != "high" || got[0].Latest != "1.2.8" {
+		t.Errorf("Scan() = %+v, want minimist merged with its advisory", got)
+	}
+}
+
+func TestScanners_RegisteredProjects(t *testing.T) {
+	names := make(map[string]bool)
+	for _, p := range project.Plugins() {
+		names[p.Name()] = true
+	}
+	for _, s := range Scanners() {
+		if !names[s.Project] {
+			t.Errorf("%s scanner: project %q is not a registered project plugin", s.Ecosystem, s.Project)
+		}
+	}
+}
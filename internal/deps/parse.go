@@ -0,0 +1,135 @@
+package deps
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ParseGoList parses `go list -m -u -json all`: a stream of module objects. Direct
+// dependencies with an available Update are returned; the main module and indirect
+// dependencies are skipped.
+func ParseGoList(data []byte) ([]Update, error) {
+	var out []Update
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var m struct {
+			Path     string
+			Version  string
+			Main     bool
+			Indirect bool
+			Update   *struct{ Version string }
+		}
+		if err := dec.Decode(&m); err == io.EOF {
+			return out, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse go list output: %w", err)
+		}
+		if m.Main || m.Indirect || m.Update == nil {
+			continue
+		}
+		out = append(out, Update{Ecosystem: EcosystemGo, Name: m.Path, Current: m.Version, Latest: m.Update.Version})
+	}
+}
+
+// ParseNpmOutdated parses `npm outdated --json`, an object keyed by package name.
+// Packages whose current version is already the latest are skipped.
+func ParseNpmOutdated(data []byte) ([]Update, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+	var pkgs map[string]struct {
+		Current string `json:"current"`
+		Latest  string `json:"latest"`
+	}
+	if err := json.Unmarshal(data, &pkgs); err != nil {
+		return nil, fmt.Errorf("failed to parse npm outdated output: %w", err)
+	}
+	var out []Update
+	for _, name := range sortedKeys(pkgs) {
+		p := pkgs[name]
+		if p.Latest == "" || p.Current == p.Latest {
+			continue
+		}
+		out = append(out, Update{Ecosystem: EcosystemNpm, Name: name, Current: p.Current, Latest: p.Latest})
+	}
+	return out, nil
+}
+
+// ParseNpmAudit parses `npm audit --json` (npm 7+). Only packages with a direct
+// advisory are returned; packages vulnerable only through a dependency are reported
+// by that dependency's own entry. The fixed version is taken from fixAvailable when
+// it names this package.
+func ParseNpmAudit(data []byte) ([]Update, error) {
+	var report struct {
+		Vulnerabilities map[string]struct {
+			Name         string            `json:"name"`
+			Severity     string            `json:"severity"`
+			Via          []json.RawMessage `json:"via"`
+			FixAvailable json.RawMessage   `json:"fixAvailable"`
+		} `json:"vulnerabilities"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse npm audit output: %w", err)
+	}
+	var out []Update
+	for _, name := range sortedKeys(report.Vulnerabilities) {
+		v := report.Vulnerabilities[name]
+		var advisories []string
+		for _, raw := range v.Via {
+			var adv struct {
+				Title string `json:"title"`
+				URL   string `json:"url"`
+			}
+			if json.Unmarshal(raw, &adv) != nil || adv.Title == "" {
+				continue
+			}
+			advisories = append(advisories, strings.TrimSpace(adv.Title+" "+adv.URL))
+		}
+		if len(advisories) == 0 {
+			continue
+		}
+		u := Update{Ecosystem: EcosystemNpm, Name: name, Severity: v.Severity, Advisories: advisories}
+		var fix struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		}
+		if json.Unmarshal(v.FixAvailable, &fix) == nil && fix.Name == name {
+			u.Latest = fix.Version
+		}
+		out = append(out, u)
+	}
+	return out, nil
+}
+
+// ParsePipOutdated parses `pip list --outdated --format=json`.
+func ParsePipOutdated(data []byte) ([]Update, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+	var pkgs []struct {
+		Name          string `json:"name"`
+		Version       string `json:"version"`
+		LatestVersion string `json:"latest_version"`
+	}
+	if err := json.Unmarshal(data, &pkgs); err != nil {
+		return nil, fmt.Errorf("failed to parse pip list output: %w", err)
+	}
+	out := make([]Update, 0, len(pkgs))
+	for _, p := range pkgs {
+		out = append(out, Update{Ecosystem: EcosystemPip, Name: p.Name, Current: p.Version, Latest: p.LatestVersion})
+	}
+	return out, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,106 @@
+// Package dod checks a finished ticket against the "definition of done" configured for
+// its type (definition_of_done), e.g. that a feature added tests and touched the docs,
+// or that a bugfix came with a regression test. Evidence comes from the files the
+// ticket changed and the results of its acceptance assertions.
+package dod
+
+import (
+	"path"
+	"strings"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// Requirement names accepted in definition_of_done.
+const (
+	// RequireTests needs an added or changed test file (a regression test for a bugfix).
+	RequireTests = "tests"
+	// RequireDocs needs an added or changed documentation file.
+	RequireDocs = "docs"
+	// RequireTestsPass needs acceptance assertions that ran and all passed.
+	RequireTestsPass = "tests_pass"
+)
+
+// Evidence is what a ticket's run produced.
+type Evidence struct {
+	// ChangedFiles are the paths (relative to the project root) the ticket added or changed.
+	ChangedFiles     []string
+	AssertionResults []ticket.AssertionResult
+}
+
+// Check returns the requirements of required that ev does not meet, in order.
+func Check(required []string, ev Evidence) []string {
+	var unmet []string
+	for _, r := range required {
+		if !met(r, ev) {
+			unmet = append(unmet, r)
+		}
+	}
+	return unmet
+}
+
+func met(requirement string, ev Evidence) bool {
+	switch requirement {
+	case RequireTests:
+		return anyFile(ev.ChangedFiles, IsTestFile)
+	case RequireDocs:
+		return anyFile(ev.ChangedFiles, IsDocFile)
+	case RequireTestsPass:
+		return len(ev.AssertionResults) > 0 && len(ticket.FailedAssertions(ev.AssertionResults)) == 0
+	}
+	return false
+}
+
+func anyFile(files []string, match func(string) bool) bool {
+	for _, f := range files {
+		if match(f) {
+			return true
+		}
+	}
+	return false
+}
+
+// testDirs are directory names whose files count as tests.
+var testDirs = map[string]bool{"test": true, "tests": true, "__tests__": true, "spec": true, "testdata": true}
+
+// IsTestFile reports whether p looks like a test: Go _test.go files, Python test_*.py
+// and *_test.py, JavaScript/TypeScript *.test.* and *.spec.*, and files under test
+// directories (test/, tests/, __tests__/, spec/).
+func IsTestFile(p string) bool {
+	p = strings.ReplaceAll(p, "\\", "/")
+	base := path.Base(p)
+	ext := path.Ext(base)
+	name := strings.TrimSuffix(base, ext)
+	switch {
+	case strings.HasSuffix(base, "_test.go"),
+		ext == ".py" && (strings.HasPrefix(name, "test_") || strings.HasSuffix(name, "_test")),
+		strings.HasSuffix(name, ".test"), strings.HasSuffix(name, ".spec"),
+		strings.HasSuffix(name, "Test") && (ext == ".java" || ext == ".kt"):
+		return true
+	}
+	for _, dir := range strings.Split(path.Dir(p), "/") {
+		if testDirs[dir] {
+			return true
+		}
+	}
+	return false
+}
+
+// docExts are the extensions of documentation files.
+var docExts = map[string]bool{".md": true, ".mdx": true, ".rst": true, ".adoc": true}
+
+// IsDocFile reports whether p is documentation: Markdown, reStructuredText or AsciiDoc
+// files anywhere, and any file under a docs/ or doc/ directory.
+func IsDocFile(p string) bool {
+	p = strings.ReplaceAll(p, "\\", "/")
+	ext := strings.ToLower(path.Ext(p))
+	if docExts[ext] {
+		return true
+	}
+	for _, dir := range strings.Split(path.Dir(p), "/") {
+		if dir == "docs" || dir == "doc" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package dod
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestIsTestFile(t *testing.T) {
+	tests := map[string]bool{
+		"pkg/store_test.go":          true,
+		"tests/test_api.py":          true,
+		"app/test_models.py":         true,
+		"app/models_test.py":         true,
+		"src/Button.test.tsx":        true,
+		"src/api.spec.js":            true,
+		"src/__tests__/util.js":      true,
+		"src/test/java/FooTest.java": true,
+		"src/main/java/FooTest.java": true,
+		"pkg/store.go":               false,
+		"app/testing.py":             false,
+		"src/contest.js":             false,
+		"README.md":                  false,
+	}
+	for path, want := range tests {
+		if got := IsTestFile(path); got != want {
+			t.Errorf("IsTestFile(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestIsDocFile(t *testing.T) {
+	tests := map[string]bool{
+		"README.md":           true,
+		"docs/guide.html":     true,
+		"pkg/doc/overview.go": true,
+		"CHANGELOG.rst":       true,
+		"notes.txt":           false,
+		"pkg/docs.go":         false,
+		"main.go":             false,
+	}
+	for path, want := range tests {
+		if got := IsDocFile(path); got != want {
+			t.Errorf("IsDocFile(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	passed := []ticket.AssertionResult{{Command: "go test ./...", Passed: true}}
+	failed := []ticket.AssertionResult{{Command: "go test ./...", Passed: true}, {Command: "go vet ./...", Passed: false}}
+
+	tests := []struct {
+		name     string
+		required []string
+		ev       Evidence
+		want     []string
+	}{
+		{"nothing required", nil, Evidence{}, nil},
+		{"feature complete", []string{RequireTests, RequireDocs}, Evidence{ChangedFiles: []string{"a.go", "a_test.go", "README.md"}}, nil},
+		{"feature without docs", []string{RequireTests, RequireDocs}, Evidence{ChangedFiles: []string{"a.go", "a_test.go"}}, []string{RequireDocs}},
+		{"bugfix without regression test", []string{RequireTests}, Evidence{ChangedFiles: []string{"a.go"}}, []string{RequireTests}},
+		{"tests pass", []string{RequireTestsPass}, Evidence{AssertionResults: passed}, nil},
+		{"tests failing", []string{RequireTestsPass}, Evidence{AssertionResults: failed}, []string{RequireTestsPass}},
+		{"no tests ran", []string{RequireTestsPass}, Evidence{}, []string{RequireTestsPass}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Check(tt.required, tt.ev); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
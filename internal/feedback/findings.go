@@ -0,0 +1,184 @@
+// Package feedback persists review issues across runs and surfaces the ones that
+// keep coming back, so future coding prompts can state them as project conventions
+// up front instead of having review flag them again.
+package feedback
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MinRecurrence is the number of separate reviews a finding must appear in before it
+// is treated as a project convention.
+const MinRecurrence = 2
+
+// maxSources caps how many review sources are remembered per finding.
+const maxSources = 10
+
+// Finding is a normalized review issue and how often it has been reported.
+type Finding struct {
+	// Key is the normalized issue text used to match repeats.
+	Key string `json:"key"`
+	// Text is the most recent original phrasing, used in prompts.
+	Text string `json:"text"`
+	// Count is the number of reviews in which the issue appeared.
+	Count int `json:"count"`
+	// Sources are the most recent reviews (ticket IDs or review labels) that reported it.
+	Sources   []string  `json:"sources,omitempty"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// Findings is the persisted set of review findings.
+type Findings struct {
+	Items []*Finding `json:"findings"`
+}
+
+// mu serializes read-modify-write cycles from concurrent callers in the same process.
+var mu sync.Mutex
+
+// Load reads the findings file at path. A missing file yields an empty set.
+func Load(path string) (*Findings, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Findings{}, nil
+		}
+		return nil, fmt.Errorf("failed to read review findings: %w", err)
+	}
+	var f Findings
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse review findings: %w", err)
+	}
+	return &f, nil
+}
+
+// Save writes the findings to path (0600), creating its directory (0700) if needed.
+func (f *Findings) Save(path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal review findings: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create findings directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write review findings: %w", err)
+	}
+	return nil
+}
+
+// Add records the issues of one review. Each distinct issue counts once per call,
+// even if the review listed it several times.
+func (f *Findings) Add(source string, issues []string, now time.Time) {
+	seen := make(map[string]bool)
+	for _, issue := range issues {
+		key := Normalize(issue)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		item := f.find(key)
+		if item == nil {
+			item = &Finding{Key: key, FirstSeen: now}
+			f.Items = append(f.Items, item)
+		}
+		item.Text = strings.TrimSpace(issue)
+		item.Count++
+		item.LastSeen = now
+		if source != "" {
+			item.Sources = append(item.Sources, source)
+			if len(item.Sources) > maxSources {
+				item.Sources = item.Sources[len(item.Sources)-maxSources:]
+			}
+		}
+	}
+}
+
+func (f *Findings) find(key string) *Finding {
+	for _, item := range f.Items {
+		if item.Key == key {
+			return item
+		}
+	}
+	return nil
+}
+
+// Top returns up to n findings seen in at least MinRecurrence reviews, most frequent
+// first (ties broken by most recently seen).
+func (f *Findings) Top(n int) []*Finding {
+	if n <= 0 {
+		return nil
+	}
+	top := make([]*Finding, 0)
+	for _, item := range f.Items {
+		if item.Count >= MinRecurrence {
+			top = append(top, item)
+		}
+	}
+	sort.SliceStable(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return top[i].LastSeen.After(top[j].LastSeen)
+	})
+	if len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// Record loads the findings at path, adds one review's issues, and saves the result.
+func Record(path, source string, issues []string) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	mu.Lock()
+	defer mu.Unlock()
+
+	f, err := Load(path)
+	if err != nil {
+		return err
+	}
+	f.Add(source, issues, time.Now())
+	return f.Save(path)
+}
+
+// Conventions returns the text of the top n recurring findings at path, for use in
+// coding prompts. Errors and a missing file yield nil.
+func Conventions(path string, n int) []string {
+	if n <= 0 {
+		return nil
+	}
+	f, err := Load(path)
+	if err != nil {
+		return nil
+	}
+	var out []string
+	for _, item := range f.Top(n) {
+		out = append(out, item.Text)
+	}
+	return out
+}
+
+// locationPrefix matches a leading file reference such as "main.go:12:" or "`pkg/x.go` -".
+var locationPrefix = regexp.MustCompile(`^\S+\.\w+(:\d+)*\s*[:：\-–]\s*`)
+
+// Normalize reduces an issue to a matching key: markdown emphasis, a leading file
+// location, letter case, extra whitespace and trailing punctuation are ignored, so
+// "**Missing error wrapping.**" and "foo.go:12: missing error wrapping" match.
+func Normalize(issue string) string {
+	s := strings.NewReplacer("**", "", "__", "", "`", "").Replace(issue)
+	s = strings.TrimSpace(s)
+	s = locationPrefix.ReplaceAllString(s, "")
+	s = strings.ToLower(strings.Join(strings.Fields(s), " "))
+	return strings.TrimRight(s, ".。;；:：!！ ")
+}
@@ -0,0 +1,87 @@
+package feedback
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Missing error wrapping", "missing error wrapping"},
+		{"**Missing  error wrapping.**", "missing error wrapping"},
+		{"internal/foo.go:12: missing error wrapping", "missing error wrapping"},
+		{"`main.go` - Missing error wrapping", "missing error wrapping"},
+		{"缺少錯誤包裝。", "缺少錯誤包裝"},
+		{"   ", ""},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.in); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFindings_AddAndTop(t *testing.T) {
+	f := &Findings{}
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	f.Add("T-1", []string{"Missing error wrapping", "missing error wrapping.", "Unused variable"}, base)
+	if got := f.Top(5); len(got) != 0 {
+		t.Fatalf("Top() after one review = %v, want none (below MinRecurrence)", got)
+	}
+
+	f.Add("T-2", []string{"a.go:3: Missing error wrapping", "No tests for parser"}, base.Add(time.Hour))
+	f.Add("T-3", []string{"No tests for parser", "Unused variable"}, base.Add(2*time.Hour))
+	f.Add("T-4", []string{"No tests for parser"}, base.Add(3*time.Hour))
+
+	top := f.Top(5)
+	var texts []string
+	for _, item := range top {
+		texts = append(texts, item.Key)
+	}
+	want := []string{"no tests for parser", "unused variable", "missing error wrapping"}
+	if !reflect.DeepEqual(texts, want) {
+		t.Errorf("Top() keys = %v, want %v", texts, want)
+	}
+	if top[2].Count != 2 {
+		t.Errorf("duplicate issue in one review should count once, got Count = %d", top[2].Count)
+	}
+	if !reflect.DeepEqual(top[2].Sources, []string{"T-1", "T-2"}) {
+		t.Errorf("Sources = %v, want [T-1 T-2]", top[2].Sources)
+	}
+	if got := f.Top(1); len(got) != 1 {
+		t.Errorf("Top(1) returned %d items", len(got))
+	}
+	if got := f.Top(0); got != nil {
+		t.Errorf("Top(0) = %v, want nil", got)
+	}
+}
+
+func TestRecordAndConventions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "review-findings.json")
+
+	if got := Conventions(path, 5); got != nil {
+		t.Errorf("Conventions() on missing file = %v, want nil", got)
+	}
+	for _, src := range []string{"T-1", "T-2"} {
+		if err := Record(path, src, []string{"Wrap errors with %w"}); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+	if err := Record(path, "T-3", nil); err != nil {
+		t.Fatalf("Record() with no issues error = %v", err)
+	}
+
+	got := Conventions(path, 5)
+	if !reflect.DeepEqual(got, []string{"Wrap errors with %w"}) {
+		t.Errorf("Conventions() = %v", got)
+	}
+	if got := Conventions(path, 0); got != nil {
+		t.Errorf("Conventions(n=0) = %v, want nil", got)
+	}
+}
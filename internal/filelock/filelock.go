@@ -0,0 +1,52 @@
+// Package filelock provides the advisory file locks (flock on Unix, LockFileEx on
+// Windows) that keep concurrent orchestrator processes from corrupting the small state
+// files they share: tickets, the run state, the work queue and the budget.
+package filelock
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultTimeout is how long Lock waits for another process to release a lock.
+const DefaultTimeout = 10 * time.Second
+
+// retryInterval is how often a held lock is tried again.
+const retryInterval = 20 * time.Millisecond
+
+// Lock takes an exclusive lock on the file at path, creating it and its directory when
+// missing, and waits up to timeout (DefaultTimeout when <= 0) for another process
+// holding it. It returns the function releasing the lock.
+func Lock(path string, timeout time.Duration) (unlock func(), err error) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		locked, err := TryLockFile(f, true)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		if locked {
+			return func() {
+				_ = UnlockFile(f)
+				f.Close()
+			}, nil
+		}
+		if time.Now().After(deadline) {
+			f.Close()
+			return nil, fmt.Errorf("%s is locked by another process (waited %s)", path, timeout)
+		}
+		time.Sleep(retryInterval)
+	}
+}
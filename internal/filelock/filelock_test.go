@@ -0,0 +1,35 @@
+package filelock
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "state.lock")
+	unlock, err := Lock(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A second open file (as in another process) waits and then gives up.
+	if _, err := Lock(path, 50*time.Millisecond); err == nil || !strings.Contains(err.Error(), "locked by another process") {
+		t.Errorf("Lock() of a held lock error = %v", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		unlock2, err := Lock(path, time.Second)
+		if err == nil {
+			unlock2()
+		}
+		done <- err
+	}()
+	time.Sleep(30 * time.Millisecond)
+	unlock()
+	if err := <-done; err != nil {
+		t.Errorf("Lock() after release error = %v", err)
+	}
+}
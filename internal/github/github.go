@@ -0,0 +1,365 @@
+// Package github reads issues from the GitHub REST API and maps them to tickets, so
+// teams can drive the orchestrator from their existing issue tracker, and reports
+// ticket progress back to the issues as comments and state changes.
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// DefaultAPIURL is the public GitHub REST API endpoint.
+const DefaultAPIURL = "https://api.github.com"
+
+// System is the RemoteRef.System of tickets imported from GitHub.
+const System = "github"
+
+// TicketIDPrefix prefixes the IDs of tickets imported from issues (GH-<number>).
+const TicketIDPrefix = "GH-"
+
+// perPage is the page size requested from the issues endpoint (the API maximum).
+const perPage = 100
+
+// maxResponseSize guards against unexpectedly large API responses.
+const maxResponseSize = 10 << 20
+
+// Issue is the subset of a GitHub issue used for import.
+type Issue struct {
+	Number    int        `json:"number"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	HTMLURL   string     `json:"html_url"`
+	State     string     `json:"state"`
+	Labels    []Label    `json:"labels"`
+	Milestone *Milestone `json:"milestone"`
+	// PullRequest is set when the issue is a pull request; those are not imported.
+	PullRequest *json.RawMessage `json:"pull_request,omitempty"`
+}
+
+// Label is an issue label.
+type Label struct {
+	Name string `json:"name"`
+}
+
+// Milestone is the milestone an issue belongs to.
+type Milestone struct {
+	Number int        `json:"number"`
+	Title  string     `json:"title"`
+	DueOn  *time.Time `json:"due_on"`
+}
+
+// Client talks to the GitHub REST API.
+type Client struct {
+	BaseURL    string
+	Token      string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Client for the given API URL (DefaultAPIURL when empty). The
+// token is optional for public repositories but raises the rate limit.
+func NewClient(baseURL, token string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	}
+	return &Client{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// ParseRepo accepts "owner/repo" or a repository URL such as
+// https://github.com/owner/repo.git and returns "owner/repo".
+func ParseRepo(s string) (string, error) {
+	repo := strings.TrimSpace(s)
+	if u, err := url.Parse(repo); err == nil && u.Host != "" {
+		repo = u.Path
+	}
+	repo = strings.TrimSuffix(strings.Trim(repo, "/"), ".git")
+	parts := strings.Split(repo, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("invalid repository %q (expected owner/repo)", s)
+	}
+	return repo, nil
+}
+
+// ListOpenIssues returns all open issues of repo ("owner/repo"), following pagination.
+// Pull requests are skipped. When labels is non-empty only issues carrying all of them
+// are returned.
+func (c *Client) ListOpenIssues(ctx context.Context, repo string, labels []string) ([]Issue, error) {
+	var out []Issue
+	for page := 1; ; page++ {
+		q := url.Values{}
+		q.Set("state", "open")
+		q.Set("per_page", fmt.Sprint(perPage))
+		q.Set("page", fmt.Sprint(page))
+		if len(labels) > 0 {
+			q.Set("labels", strings.Join(labels, ","))
+		}
+		data, err := c.get(ctx, fmt.Sprintf("%s/repos/%s/issues?%s", c.BaseURL, repo, q.Encode()))
+		if err != nil {
+			return nil, err
+		}
+		var issues []Issue
+		if err := json.Unmarshal(data, &issues); err != nil {
+			return nil, fmt.Errorf("failed to parse issues: %w", err)
+		}
+		for _, issue := range issues {
+			if issue.PullRequest == nil {
+				out = append(out, issue)
+			}
+		}
+		if len(issues) < perPage {
+			return out, nil
+		}
+	}
+}
+
+// Comment posts a comment on an issue.
+func (c *Client) Comment(ctx context.Context, repo string, number int, body string) error {
+	payload, err := json.Marshal(map[string]string{"body": body})
+	if err != nil {
+		return err
+	}
+	_, err = c.do(ctx, http.MethodPost, fmt.Sprintf("%s/repos/%s/issues/%d/comments", c.BaseURL, repo, number), payload)
+	return err
+}
+
+// CloseIssue closes an issue as completed.
+func (c *Client) CloseIssue(ctx context.Context, repo string, number int) error {
+	payload, err := json.Marshal(map[string]string{"state": "closed", "state_reason": "completed"})
+	if err != nil {
+		return err
+	}
+	_, err = c.do(ctx, http.MethodPatch, fmt.Sprintf("%s/repos/%s/issues/%d", c.BaseURL, repo, number), payload)
+	return err
+}
+
+// PullRequest is a created pull request.
+type PullRequest struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+}
+
+// DefaultBranch returns the default branch of repo.
+func (c *Client) DefaultBranch(ctx context.Context, repo string) (string, error) {
+	data, err := c.get(ctx, fmt.Sprintf("%s/repos/%s", c.BaseURL, repo))
+	if err != nil {
+		return "", err
+	}
+	var r struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return "", fmt.Errorf("failed to parse repository: %w", err)
+	}
+	return r.DefaultBranch, nil
+}
+
+// CreatePullRequest opens a pull request in repo merging head into base.
+func (c *Client) CreatePullRequest(ctx context.Context, repo, head, base, title, body string) (*PullRequest, error) {
+	payload, err := json.Marshal(map[string]string{"title": title, "head": head, "base": base, "body": body})
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.do(ctx, http.MethodPost, fmt.Sprintf("%s/repos/%s/pulls", c.BaseURL, repo), payload)
+	if err != nil {
+		return nil, err
+	}
+	var pr PullRequest
+	if err := json.Unmarshal(data, &pr); err != nil {
+		return nil, fmt.Errorf("failed to parse pull request: %w", err)
+	}
+	return &pr, nil
+}
+
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, url, nil)
+}
+
+// do sends a request with an optional JSON payload and returns the response body.
+// Any 2xx status is a success.
+func (c *Client) do(ctx context.Context, method, url string, payload []byte) ([]byte, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "agent-orchestrator")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("request %s: unexpected status %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", url, err)
+	}
+	if len(data) > maxResponseSize {
+		return nil, fmt.Errorf("response from %s exceeds %d bytes", url, maxResponseSize)
+	}
+	return data, nil
+}
+
+// TicketID returns the ticket ID of an imported issue.
+func TicketID(number int) string {
+	return fmt.Sprintf("%s%d", TicketIDPrefix, number)
+}
+
+// typeLabels maps normalized label names to ticket types.
+var typeLabels = map[string]ticket.Type{
+	"bug":           ticket.TypeBugfix,
+	"bugfix":        ticket.TypeBugfix,
+	"fix":           ticket.TypeBugfix,
+	"feature":       ticket.TypeFeature,
+	"enhancement":   ticket.TypeFeature,
+	"documentation": ticket.TypeDocs,
+	"docs":          ticket.TypeDocs,
+	"test":          ticket.TypeTest,
+	"tests":         ticket.TypeTest,
+	"testing":       ticket.TypeTest,
+	"refactor":      ticket.TypeRefactor,
+	"refactoring":   ticket.TypeRefactor,
+	"tech-debt":     ticket.TypeRefactor,
+	"performance":   ticket.TypePerf,
+	"perf":          ticket.TypePerf,
+	"security":      ticket.TypeSecurity,
+}
+
+// TypeForLabels returns the ticket type implied by issue labels such as "bug",
+// "type: docs" or "kind/security"; TypeFeature when none matches.
+func TypeForLabels(labels []Label) ticket.Type {
+	for _, l := range labels {
+		if t, ok := typeLabels[normalizeLabel(l.Name)]; ok {
+			return t
+		}
+	}
+	return ticket.TypeFeature
+}
+
+// normalizeLabel lowercases a label and strips "type:" / "kind/" style prefixes.
+func normalizeLabel(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, sep := range []string{":", "/"} {
+		if i := strings.LastIndex(name, sep); i >= 0 {
+			name = strings.TrimSpace(name[i+1:])
+		}
+	}
+	return strings.ReplaceAll(name, " ", "-")
+}
+
+// ToTickets maps issues to pending tickets:
+//   - labels that name a type set Type; the other labels are kept as ticket labels;
+//   - milestones are ordered by due date (undated last, then by number); the first gets
+//     priority 1, the next 2 and so on up to 5, and every ticket of a milestone depends
+//     on the imported tickets of the milestone before it. Issues without a milestone
+//     keep the default priority and have no dependencies;
+//   - unchecked task-list items ("- [ ] ...") in the body become acceptance criteria;
+//   - RemoteRef points back to the issue in repo ("owner/repo") so status can be synced.
+func ToTickets(repo string, issues []Issue) []*ticket.Ticket {
+	var milestones []*Milestone
+	seen := make(map[int]bool)
+	for _, issue := range issues {
+		if m := issue.Milestone; m != nil && !seen[m.Number] {
+			seen[m.Number] = true
+			milestones = append(milestones, m)
+		}
+	}
+	sort.SliceStable(milestones, func(i, j int) bool {
+		a, b := milestones[i], milestones[j]
+		if (a.DueOn == nil) != (b.DueOn == nil) {
+			return a.DueOn != nil
+		}
+		if a.DueOn != nil && !a.DueOn.Equal(*b.DueOn) {
+			return a.DueOn.Before(*b.DueOn)
+		}
+		return a.Number < b.Number
+	})
+	rank := make(map[int]int, len(milestones))
+	for i, m := range milestones {
+		rank[m.Number] = i
+	}
+
+	byRank := make(map[int][]string)
+	for _, issue := range issues {
+		if issue.Milestone != nil {
+			r := rank[issue.Milestone.Number]
+			byRank[r] = append(byRank[r], TicketID(issue.Number))
+		}
+	}
+
+	tickets := make([]*ticket.Ticket, 0, len(issues))
+	for _, issue := range issues {
+		t := ticket.NewTicket(TicketID(issue.Number), issue.Title, description(issue))
+		t.Type = TypeForLabels(issue.Labels)
+		if repo != "" {
+			t.RemoteRef = &ticket.RemoteRef{System: System, Repo: repo, Number: issue.Number, URL: issue.HTMLURL}
+		}
+		var labels []string
+		for _, l := range issue.Labels {
+			if _, isType := typeLabels[normalizeLabel(l.Name)]; !isType {
+				labels = append(labels, l.Name)
+			}
+		}
+		t.Labels = ticket.NormalizeLabels(labels)
+		t.AcceptanceCriteria = append(t.AcceptanceCriteria, taskListItems(issue.Body)...)
+		if issue.Milestone != nil {
+			r := rank[issue.Milestone.Number]
+			t.Priority = min(r+1, 5)
+			if r > 0 {
+				t.Dependencies = append(t.Dependencies, byRank[r-1]...)
+			}
+		}
+		tickets = append(tickets, t)
+	}
+	return tickets
+}
+
+// description returns the issue body followed by a link back to the issue.
+func description(issue Issue) string {
+	body := strings.TrimSpace(issue.Body)
+	if issue.HTMLURL == "" {
+		return body
+	}
+	if body == "" {
+		return issue.HTMLURL
+	}
+	return body + "\n\n" + issue.HTMLURL
+}
+
+// taskListItems returns the text of unchecked task-list items in a Markdown body.
+func taskListItems(body string) []string {
+	var items []string
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		for _, prefix := range []string{"- [ ] ", "* [ ] "} {
+			if item, ok := strings.CutPrefix(line, prefix); ok && strings.TrimSpace(item) != "" {
+				items = append(items, strings.TrimSpace(item))
+			}
+		}
+	}
+	return items
+}
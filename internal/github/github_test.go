@@ -0,0 +1,170 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestParseRepo(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"octo/widgets", "octo/widgets", false},
+		{"https://github.com/octo/widgets.git", "octo/widgets", false},
+		{"https://github.com/octo/widgets/", "octo/widgets", false},
+		{"widgets", "", true},
+		{"octo/widgets/issues", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRepo(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseRepo(%q) = %q, %v; want %q (err %v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestTypeForLabels(t *testing.T) {
+	tests := []struct {
+		labels []string
+		want   ticket.Type
+	}{
+		{nil, ticket.TypeFeature},
+		{[]string{"good first issue", "bug"}, ticket.TypeBugfix},
+		{[]string{"type: documentation"}, ticket.TypeDocs},
+		{[]string{"kind/security"}, ticket.TypeSecurity},
+		{[]string{"Tech Debt"}, ticket.TypeRefactor},
+	}
+	for _, tt := range tests {
+		var labels []Label
+		for _, name := range tt.labels {
+			labels = append(labels, Label{Name: name})
+		}
+		if got := TypeForLabels(labels); got != tt.want {
+			t.Errorf("TypeForLabels(%v) = %s, want %s", tt.labels, got, tt.want)
+		}
+	}
+}
+
+func TestToTickets(t *testing.T) {
+	jan := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC)
+	v1 := &Milestone{Number: 7, Title: "v1", DueOn: &jan}
+	v2 := &Milestone{Number: 3, Title: "v2", DueOn: &feb}
+	issues := []Issue{
+		{Number: 10, Title: "Later", Milestone: v2, Labels: []Label{{Name: "enhancement"}, {Name: "api"}}},
+		{Number: 11, Title: "First", Milestone: v1, Labels: []Label{{Name: "bug"}},
+			Body: "Crash on start\n- [ ] no panic\n- [x] repro\n* [ ] log error", HTMLURL: "https://github.com/o/r/issues/11"},
+		{Number: 12, Title: "Unplanned"},
+	}
+
+	tickets := ToTickets("o/r", issues)
+	if len(tickets) != 3 {
+		t.Fatalf("ToTickets() len = %d, want 3", len(tickets))
+	}
+	later, first, unplanned := tickets[0], tickets[1], tickets[2]
+
+	if first.ID != "GH-11" || first.Type != ticket.TypeBugfix || first.Priority != 1 || len(first.Dependencies) != 0 {
+		t.Errorf("first = %+v", first)
+	}
+	if len(first.AcceptanceCriteria) != 2 || first.AcceptanceCriteria[1] != "log error" {
+		t.Errorf("first.AcceptanceCriteria = %v", first.AcceptanceCriteria)
+	}
+	if first.Description != "Crash on start\n- [ ] no panic\n- [x] repro\n* [ ] log error\n\nhttps://github.com/o/r/issues/11" {
+		t.Errorf("first.Description = %q", first.Description)
+	}
+	if later.Priority != 2 || len(later.Dependencies) != 1 || later.Dependencies[0] != "GH-11" {
+		t.Errorf("later priority/deps = %d %v, want 2 [GH-11]", later.Priority, later.Dependencies)
+	}
+	if later.Type != ticket.TypeFeature || len(later.Labels) != 1 || later.Labels[0] != "api" {
+		t.Errorf("later type/labels = %s %v", later.Type, later.Labels)
+	}
+	if ref := first.RemoteRef; ref == nil || ref.System != System || ref.Repo != "o/r" || ref.Number != 11 || ref.URL == "" {
+		t.Errorf("first.RemoteRef = %+v", ref)
+	}
+	if unplanned.Priority != ticket.NewTicket("", "", "").Priority || len(unplanned.Dependencies) != 0 {
+		t.Errorf("unplanned = %+v", unplanned)
+	}
+	for _, tk := range tickets {
+		if err := tk.Validate(); err != nil {
+			t.Errorf("ticket %s invalid: %v", tk.ID, err)
+		}
+	}
+}
+
+func TestClient_ListOpenIssues(t *testing.T) {
+	var gotAuth, gotLabels string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/octo/widgets/issues" {
+			http.NotFound(w, r)
+			return
+		}
+		gotAuth = r.Header.Get("Authorization")
+		gotLabels = r.URL.Query().Get("labels")
+		var page []map[string]any
+		switch r.URL.Query().Get("page") {
+		case "1":
+			for i := 1; i <= perPage; i++ {
+				issue := map[string]any{"number": i, "title": fmt.Sprintf("Issue %d", i)}
+				if i%2 == 0 {
+					issue["pull_request"] = map[string]any{"url": "x"}
+				}
+				page = append(page, issue)
+			}
+		case "2":
+			page = append(page, map[string]any{"number": 1000, "title": "Last"})
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL+"/", "secret")
+	issues, err := c.ListOpenIssues(context.Background(), "octo/widgets", []string{"backend"})
+	if err != nil {
+		t.Fatalf("ListOpenIssues() err = %v", err)
+	}
+	if len(issues) != perPage/2+1 || issues[len(issues)-1].Number != 1000 {
+		t.Errorf("ListOpenIssues() = %d issues, want %d without pull requests", len(issues), perPage/2+1)
+	}
+	if gotAuth != "Bearer secret" || gotLabels != "backend" {
+		t.Errorf("request auth = %q, labels = %q", gotAuth, gotLabels)
+	}
+
+	if _, err := c.ListOpenIssues(context.Background(), "octo/missing", nil); err == nil {
+		t.Error("ListOpenIssues() should fail on a non-200 response")
+	}
+}
+
+func TestClient_CommentAndClose(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		got = append(got, fmt.Sprintf("%s %s %s%s", r.Method, r.URL.Path, payload["body"], payload["state"]))
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "secret")
+	if err := c.Comment(context.Background(), "o/r", 11, "done"); err != nil {
+		t.Fatalf("Comment() err = %v", err)
+	}
+	if err := c.CloseIssue(context.Background(), "o/r", 11); err != nil {
+		t.Fatalf("CloseIssue() err = %v", err)
+	}
+	want := []string{"POST /repos/o/r/issues/11/comments done", "PATCH /repos/o/r/issues/11 closed"}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("requests = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,124 @@
+// Package gitlab opens merge requests through the GitLab REST API (v4).
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultAPIURL is the API endpoint of gitlab.com.
+const DefaultAPIURL = "https://gitlab.com/api/v4"
+
+// maxResponseSize guards against unexpectedly large API responses.
+const maxResponseSize = 10 << 20
+
+// Client talks to the GitLab REST API.
+type Client struct {
+	BaseURL    string
+	Token      string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Client for the given API URL (DefaultAPIURL when empty).
+func NewClient(baseURL, token string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	}
+	return &Client{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// MergeRequest is a created merge request; IID is its number within the project.
+type MergeRequest struct {
+	IID    int    `json:"iid"`
+	WebURL string `json:"web_url"`
+}
+
+// projectURL returns the API URL of project ("group/subgroup/name").
+func (c *Client) projectURL(project string) string {
+	return fmt.Sprintf("%s/projects/%s", c.BaseURL, url.PathEscape(project))
+}
+
+// DefaultBranch returns the default branch of project.
+func (c *Client) DefaultBranch(ctx context.Context, project string) (string, error) {
+	data, err := c.do(ctx, http.MethodGet, c.projectURL(project), nil)
+	if err != nil {
+		return "", err
+	}
+	var p struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return "", fmt.Errorf("failed to parse project: %w", err)
+	}
+	return p.DefaultBranch, nil
+}
+
+// CreateMergeRequest opens a merge request in project merging source into target.
+func (c *Client) CreateMergeRequest(ctx context.Context, project, source, target, title, description string) (*MergeRequest, error) {
+	payload, err := json.Marshal(map[string]string{
+		"source_branch": source,
+		"target_branch": target,
+		"title":         title,
+		"description":   description,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.do(ctx, http.MethodPost, c.projectURL(project)+"/merge_requests", payload)
+	if err != nil {
+		return nil, err
+	}
+	var mr MergeRequest
+	if err := json.Unmarshal(data, &mr); err != nil {
+		return nil, fmt.Errorf("failed to parse merge request: %w", err)
+	}
+	return &mr, nil
+}
+
+// do sends a request with an optional JSON payload and returns the response body.
+// Any 2xx status is a success.
+func (c *Client) do(ctx context.Context, method, url string, payload []byte) ([]byte, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "agent-orchestrator")
+	if c.Token != "" {
+		req.Header.Set("PRIVATE-TOKEN", c.Token)
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("request %s: unexpected status %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", url, err)
+	}
+	if len(data) > maxResponseSize {
+		return nil, fmt.Errorf("response from %s exceeds %d bytes", url, maxResponseSize)
+	}
+	return data, nil
+}
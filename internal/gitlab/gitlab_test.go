@@ -0,0 +1,47 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_CreateMergeRequest(t *testing.T) {
+	var gotToken, gotPath string
+	var payload map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotToken = r.Header.Get("PRIVATE-TOKEN")
+		gotPath = r.URL.EscapedPath()
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"default_branch": "develop"}`))
+		case http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"iid": 7, "web_url": "https://gitlab.com/group/sub/app/-/merge_requests/7"}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL+"/", "secret")
+	branch, err := c.DefaultBranch(context.Background(), "group/sub/app")
+	if err != nil || branch != "develop" {
+		t.Fatalf("DefaultBranch() = %q, %v", branch, err)
+	}
+	if gotPath != "/projects/group%2Fsub%2Fapp" {
+		t.Errorf("project path = %q, want URL-encoded project", gotPath)
+	}
+
+	mr, err := c.CreateMergeRequest(context.Background(), "group/sub/app", "ticket/T-1", "develop", "T-1: Setup", "body")
+	if err != nil {
+		t.Fatalf("CreateMergeRequest() err = %v", err)
+	}
+	if mr.IID != 7 || mr.WebURL == "" {
+		t.Errorf("CreateMergeRequest() = %+v", mr)
+	}
+	if gotToken != "secret" || payload["source_branch"] != "ticket/T-1" || payload["target_branch"] != "develop" {
+		t.Errorf("request token = %q, payload = %v", gotToken, payload)
+	}
+}
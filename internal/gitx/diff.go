@@ -0,0 +1,59 @@
+package gitx
+
+import (
+	"context"
+	"strings"
+)
+
+// Diff returns the changes of the working tree, including uncommitted and untracked
+// files, since base (e.g. HEAD or main; a branch that moved on is compared from its
+// merge base with HEAD, as a pull request would be). Only files are diffed when given,
+// and the pathspecs in exclude are left out.
+func (m *Manager) Diff(ctx context.Context, base string, files, exclude []string) (string, error) {
+	from, current, err := m.diffRange(ctx, base)
+	if err != nil {
+		return "", err
+	}
+	paths := files
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	args := append([]string{"diff", from, current, "--"}, paths...)
+	out, err := m.git(ctx, m.repo, nil, nil, append(args, excludePathspecs(exclude)...)...)
+	return string(out), err
+}
+
+// DiffFiles returns the files Diff shows for base, leaving out the pathspecs in exclude.
+func (m *Manager) DiffFiles(ctx context.Context, base string, exclude []string) ([]string, error) {
+	from, current, err := m.diffRange(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+	args := append([]string{"diff", "--name-only", from, current, "--", "."}, excludePathspecs(exclude)...)
+	out, err := m.output(ctx, nil, args...)
+	if err != nil || out == "" {
+		return nil, err
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// diffRange returns the merge base of base and HEAD, and a snapshot commit of the
+// working tree to compare it with.
+func (m *Manager) diffRange(ctx context.Context, base string) (string, string, error) {
+	mu := lockFor(m.repo)
+	mu.Lock()
+	defer mu.Unlock()
+
+	if base == "" {
+		base = "HEAD"
+	}
+	from, err := m.output(ctx, nil, "merge-base", base, "HEAD")
+	if err != nil {
+		return "", "", err
+	}
+	current, err := m.snapshot(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	return from, current, nil
+}
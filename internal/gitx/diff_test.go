@@ -0,0 +1,86 @@
+package gitx
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestManager_Diff(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepo(t)
+	run(t, repo, "branch", "main-base")
+	// A commit on the branch, an uncommitted edit, an untracked file and the tickets.
+	write(t, repo, "b.txt", "b committed\n")
+	run(t, repo, "commit", "-qam", "edit b")
+	write(t, repo, "a.txt", "a edited\n")
+	write(t, repo, "new.txt", "new\n")
+	if err := os.MkdirAll(filepath.Join(repo, ".tickets"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	write(t, repo, ".tickets/t.json", "{}\n")
+	m := NewManager(repo, "")
+
+	tests := []struct {
+		name      string
+		base      string
+		files     []string
+		wantFiles string
+		want      []string
+		notWant   []string
+	}{
+		{
+			name:      "working tree since HEAD",
+			wantFiles: "a.txt new.txt",
+			want:      []string{"-a\n+a edited", "+new"},
+			notWant:   []string{"b committed", ".tickets"},
+		},
+		{
+			name:      "since a base ref",
+			base:      "main-base",
+			wantFiles: "a.txt b.txt new.txt",
+			want:      []string{"+a edited", "+b committed", "+new"},
+		},
+		{
+			name:    "only given files",
+			base:    "main-base",
+			files:   []string{"b.txt"},
+			want:    []string{"+b committed"},
+			notWant: []string{"a edited", "+new"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := m.Diff(ctx, tt.base, tt.files, []string{".tickets"})
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(diff, want) {
+					t.Errorf("Diff() should contain %q, got:\n%s", want, diff)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(diff, notWant) {
+					t.Errorf("Diff() should not contain %q, got:\n%s", notWant, diff)
+				}
+			}
+			if tt.wantFiles == "" {
+				return
+			}
+			files, err := m.DiffFiles(ctx, tt.base, []string{".tickets"})
+			if err != nil || strings.Join(files, " ") != tt.wantFiles {
+				t.Errorf("DiffFiles() = %v, %v; want %s", files, err, tt.wantFiles)
+			}
+		})
+	}
+
+	if got := run(t, repo, "status", "--porcelain"); !strings.Contains(got, "?? new.txt") {
+		t.Errorf("Diff() should leave the working tree and index alone, status:\n%s", got)
+	}
+	if _, err := m.Diff(ctx, "no-such-ref", nil, nil); err == nil {
+		t.Error("Diff() with an unknown base should fail")
+	}
+}
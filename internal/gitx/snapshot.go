@@ -0,0 +1,128 @@
+package gitx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Snapshot is the state of a repository before a run: its HEAD, the checked-out
+// branch and a commit holding the working tree including uncommitted and untracked
+// changes. A ref keeps the snapshot commit from being garbage collected.
+type Snapshot struct {
+	Ref    string `json:"ref"`
+	Commit string `json:"commit"`
+	Head   string `json:"head"`
+	// Branch is the checked-out branch, empty when HEAD was detached.
+	Branch    string    `json:"branch,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Snapshot records the repository's working tree under ref, replacing an earlier
+// snapshot with the same ref. The repository's index and working tree are untouched.
+func (m *Manager) Snapshot(ctx context.Context, ref string) (*Snapshot, error) {
+	mu := lockFor(m.repo)
+	mu.Lock()
+	defer mu.Unlock()
+
+	commit, err := m.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	head, err := m.output(ctx, nil, "rev-parse", "--verify", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	// symbolic-ref fails when HEAD is detached.
+	branch, _ := m.output(ctx, nil, "symbolic-ref", "-q", "--short", "HEAD")
+	if _, err := m.output(ctx, nil, "update-ref", ref, commit); err != nil {
+		return nil, err
+	}
+	return &Snapshot{Ref: ref, Commit: commit, Head: head, Branch: branch, CreatedAt: time.Now()}, nil
+}
+
+// SnapshotCommits returns the commits made on the snapshot's branch (or HEAD, when
+// it was detached) since the snapshot, newest first, as "<hash> <subject>".
+func (m *Manager) SnapshotCommits(ctx context.Context, s *Snapshot) ([]string, error) {
+	tip := s.Branch
+	if tip == "" {
+		tip = "HEAD"
+	}
+	out, err := m.output(ctx, nil, "log", "--oneline", s.Head+".."+tip)
+	if err != nil || out == "" {
+		return nil, err
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// SnapshotChanges returns the files whose content in the working tree differs from
+// the snapshot, leaving out the pathspecs in exclude.
+func (m *Manager) SnapshotChanges(ctx context.Context, s *Snapshot, exclude []string) ([]string, error) {
+	current, err := m.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	args := append([]string{"diff", "--name-only", s.Commit, current, "--", "."}, excludePathspecs(exclude)...)
+	out, err := m.output(ctx, nil, args...)
+	if err != nil || out == "" {
+		return nil, err
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// Restore rolls the repository back to s: the branch is checked out and reset to the
+// snapshot's HEAD, dropping commits made since, files created since are removed, and
+// the snapshot's uncommitted changes are put back as uncommitted changes. Paths
+// matching the pathspecs in exclude (e.g. the tickets directory) are not touched by
+// the cleanup; ignored files are never touched.
+func (m *Manager) Restore(ctx context.Context, s *Snapshot, exclude []string) error {
+	mu := lockFor(m.repo)
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, err := m.output(ctx, nil, "cat-file", "-e", s.Commit+"^{commit}"); err != nil {
+		return fmt.Errorf("snapshot %s no longer exists: %w", s.Commit, err)
+	}
+	if s.Branch != "" {
+		if _, err := m.output(ctx, nil, "checkout", "-q", "-f", s.Branch); err != nil {
+			return err
+		}
+	} else if _, err := m.output(ctx, nil, "checkout", "-q", "-f", "--detach", s.Head); err != nil {
+		return err
+	}
+	if _, err := m.output(ctx, nil, "reset", "-q", "--hard", s.Head); err != nil {
+		return err
+	}
+	args := append([]string{"clean", "-fdq", "--", "."}, excludePathspecs(exclude)...)
+	if _, err := m.output(ctx, nil, args...); err != nil {
+		return err
+	}
+	if s.Commit == s.Head {
+		return nil
+	}
+	args = append([]string{"diff", "--binary", s.Head, s.Commit, "--", "."}, excludePathspecs(exclude)...)
+	patch, err := m.git(ctx, m.repo, nil, nil, args...)
+	if err != nil {
+		return err
+	}
+	if len(strings.TrimSpace(string(patch))) == 0 {
+		return nil
+	}
+	_, err = m.git(ctx, m.repo, nil, patch, "apply", "--whitespace=nowarn", "-")
+	return err
+}
+
+// DeleteSnapshot removes the ref of s, letting git collect the snapshot commit.
+func (m *Manager) DeleteSnapshot(ctx context.Context, s *Snapshot) error {
+	_, err := m.output(ctx, nil, "update-ref", "-d", s.Ref)
+	return err
+}
+
+func excludePathspecs(paths []string) []string {
+	specs := make([]string, 0, len(paths))
+	for _, p := range paths {
+		specs = append(specs, ":(exclude)"+p)
+	}
+	return specs
+}
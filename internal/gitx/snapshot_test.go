@@ -0,0 +1,81 @@
+package gitx
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestManager_SnapshotRestore(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepo(t)
+	// State before the run: an uncommitted change, an untracked file and ignored tickets.
+	write(t, repo, "a.txt", "a before run\n")
+	write(t, repo, "untracked.txt", "untracked\n")
+	write(t, repo, ".gitignore", ".tickets/\n")
+	if err := os.MkdirAll(filepath.Join(repo, ".tickets"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	write(t, repo, ".tickets/t.json", "before\n")
+	statusBefore := run(t, repo, "status", "--porcelain")
+
+	m := NewManager(repo, "")
+	snap, err := m.Snapshot(ctx, "refs/agent-orchestrator/test")
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	if snap.Branch == "" || snap.Commit == snap.Head {
+		t.Fatalf("Snapshot() = %+v, want a branch and a commit holding the changes", snap)
+	}
+	if got := run(t, repo, "status", "--porcelain"); got != statusBefore {
+		t.Errorf("Snapshot() changed the working tree:\n%s", got)
+	}
+
+	// The run: commits, edits, new files, a ticket branch and updated tickets.
+	write(t, repo, "b.txt", "b by agent\n")
+	run(t, repo, "add", "b.txt")
+	run(t, repo, "commit", "-qm", "agent commit")
+	write(t, repo, "a.txt", "a by agent\n")
+	write(t, repo, "new.txt", "new\n")
+	write(t, repo, "untracked.txt", "changed by agent\n")
+	write(t, repo, ".tickets/t.json", "after\n")
+
+	commits, err := m.SnapshotCommits(ctx, snap)
+	if err != nil || len(commits) != 1 || !strings.HasSuffix(commits[0], "agent commit") {
+		t.Errorf("SnapshotCommits() = %v, %v; want the agent commit", commits, err)
+	}
+	changes, err := m.SnapshotChanges(ctx, snap, []string{".tickets"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "a.txt b.txt new.txt untracked.txt"; strings.Join(changes, " ") != want {
+		t.Errorf("SnapshotChanges() = %v, want %s", changes, want)
+	}
+
+	if err := m.Restore(ctx, snap, []string{".tickets"}); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	for name, want := range map[string]string{
+		"a.txt":           "a before run\n",
+		"b.txt":           "b\n",
+		"untracked.txt":   "untracked\n",
+		"new.txt":         "",
+		".tickets/t.json": "after\n",
+	} {
+		if got := read(t, repo, name); got != want {
+			t.Errorf("%s = %q after Restore, want %q", name, got, want)
+		}
+	}
+	if got := run(t, repo, "rev-parse", "HEAD"); got != snap.Head {
+		t.Errorf("HEAD = %s after Restore, want %s", got, snap.Head)
+	}
+
+	if err := m.DeleteSnapshot(ctx, snap); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Restore(ctx, &Snapshot{Commit: "0000000000000000000000000000000000000000"}, nil); err == nil {
+		t.Error("Restore() of a missing snapshot should fail")
+	}
+}
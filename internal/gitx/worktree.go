@@ -0,0 +1,188 @@
+// Package gitx provisions git worktrees so parallel coding agents each work in their
+// own checkout instead of colliding in one working tree.
+//
+// A worktree starts from a snapshot of the project's working tree (HEAD plus any
+// uncommitted and untracked changes), so a ticket sees the work of tickets finished
+// before it even when those changes are not committed yet. Merging applies the
+// worktree's changes relative to that snapshot back onto the project's working tree
+// as uncommitted changes, the same state a ticket coded in place leaves behind.
+//
+// The same snapshots back run --snapshot: a Snapshot kept under a ref records the
+// working tree before a run so Restore can roll the repository back to it.
+package gitx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// snapshotIdentity is the committer of snapshot commits; they are never on a branch.
+var snapshotIdentity = []string{
+	"GIT_AUTHOR_NAME=agent-orchestrator", "GIT_AUTHOR_EMAIL=agent-orchestrator@localhost",
+	"GIT_COMMITTER_NAME=agent-orchestrator", "GIT_COMMITTER_EMAIL=agent-orchestrator@localhost",
+}
+
+// repoLocks serializes operations that touch a repository's working tree or worktree
+// list (snapshot, add, apply, remove) within the process.
+var repoLocks sync.Map // repo path -> *sync.Mutex
+
+func lockFor(repo string) *sync.Mutex {
+	mu, _ := repoLocks.LoadOrStore(filepath.Clean(repo), &sync.Mutex{})
+	return mu.(*sync.Mutex)
+}
+
+// Manager creates worktrees of the repository at repo inside dir.
+type Manager struct {
+	repo string
+	dir  string
+}
+
+// Worktree is a checkout provisioned by a Manager.
+type Worktree struct {
+	Name string
+	Path string
+	// Base is the snapshot commit the worktree was created from; Merge applies the
+	// changes made since.
+	Base string
+}
+
+// NewManager returns a Manager for the repository at repo, placing worktrees in dir.
+// dir should be outside the repository (or ignored by it).
+func NewManager(repo, dir string) *Manager {
+	return &Manager{repo: repo, dir: dir}
+}
+
+// unsafeNameChars matches characters replaced in worktree directory names.
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// Create provisions a worktree named name (e.g. a ticket ID) from a snapshot of the
+// repository's working tree. A leftover worktree of the same name is replaced.
+func (m *Manager) Create(ctx context.Context, name string) (*Worktree, error) {
+	name = strings.Trim(unsafeNameChars.ReplaceAllString(name, "-"), ".-")
+	if name == "" {
+		return nil, fmt.Errorf("invalid worktree name")
+	}
+	mu := lockFor(m.repo)
+	mu.Lock()
+	defer mu.Unlock()
+
+	base, err := m.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(m.dir, name)
+	if _, err := os.Stat(path); err == nil {
+		m.remove(ctx, path)
+	}
+	if err := os.MkdirAll(m.dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create worktree directory: %w", err)
+	}
+	if _, err := m.git(ctx, m.repo, nil, nil, "worktree", "add", "--detach", path, base); err != nil {
+		return nil, err
+	}
+	return &Worktree{Name: name, Path: path, Base: base}, nil
+}
+
+// Merge applies the changes made in wt since its creation to the repository's working
+// tree. The apply is all-or-nothing: when another change touched the same lines the
+// repository is left unchanged and an error is returned.
+func (m *Manager) Merge(ctx context.Context, wt *Worktree) error {
+	if _, err := m.git(ctx, wt.Path, nil, nil, "add", "-A"); err != nil {
+		return err
+	}
+	patch, err := m.git(ctx, wt.Path, nil, nil, "diff", "--cached", "--binary", wt.Base)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(patch)) == 0 {
+		return nil
+	}
+	mu := lockFor(m.repo)
+	mu.Lock()
+	defer mu.Unlock()
+	_, err = m.git(ctx, m.repo, nil, patch, "apply", "--whitespace=nowarn", "-")
+	return err
+}
+
+// Remove deletes wt and its administrative files.
+func (m *Manager) Remove(ctx context.Context, wt *Worktree) {
+	mu := lockFor(m.repo)
+	mu.Lock()
+	defer mu.Unlock()
+	m.remove(ctx, wt.Path)
+}
+
+func (m *Manager) remove(ctx context.Context, path string) {
+	if _, err := m.git(ctx, m.repo, nil, nil, "worktree", "remove", "--force", path); err != nil {
+		os.RemoveAll(path)
+		m.git(ctx, m.repo, nil, nil, "worktree", "prune")
+	}
+}
+
+// snapshot returns a commit holding the working tree as `git add -A` would stage it,
+// built in a temporary index so the repository's own index is untouched. Returns
+// HEAD itself when there are no changes.
+func (m *Manager) snapshot(ctx context.Context) (string, error) {
+	head, err := m.output(ctx, nil, "rev-parse", "--verify", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	tmp, err := os.MkdirTemp("", "gitx-index-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+	env := []string{"GIT_INDEX_FILE=" + filepath.Join(tmp, "index")}
+
+	if _, err := m.output(ctx, env, "read-tree", head); err != nil {
+		return "", err
+	}
+	if _, err := m.output(ctx, env, "add", "-A"); err != nil {
+		return "", err
+	}
+	tree, err := m.output(ctx, env, "write-tree")
+	if err != nil {
+		return "", err
+	}
+	headTree, err := m.output(ctx, nil, "rev-parse", head+"^{tree}")
+	if err != nil {
+		return "", err
+	}
+	if tree == headTree {
+		return head, nil
+	}
+	return m.output(ctx, append(env, snapshotIdentity...), "commit-tree", tree, "-p", head, "-m", "agent-orchestrator worktree snapshot")
+}
+
+// output runs git in the repository and returns its trimmed stdout.
+func (m *Manager) output(ctx context.Context, env []string, args ...string) (string, error) {
+	out, err := m.git(ctx, m.repo, env, nil, args...)
+	return strings.TrimSpace(string(out)), err
+}
+
+// git runs a git command in dir with extra environment and optional stdin, returning
+// stdout. Errors include git's stderr.
+func (m *Manager) git(ctx context.Context, dir string, env []string, stdin []byte, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(stderr.String()+" "+err.Error()))
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,141 @@
+package gitx
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newRepo creates a repository with one commit holding a.txt and b.txt.
+func newRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	run(t, dir, "init", "-q")
+	write(t, dir, "a.txt", "a\n")
+	write(t, dir, "b.txt", "b\n")
+	run(t, dir, "add", ".")
+	run(t, dir, "commit", "-qm", "init")
+	return dir
+}
+
+func run(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func write(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func read(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func TestManager_CreateMergeRemove(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepo(t)
+	// Uncommitted and untracked work of earlier tickets is visible in the worktree.
+	write(t, repo, "a.txt", "a changed\n")
+	write(t, repo, "earlier.txt", "earlier\n")
+	indexBefore := run(t, repo, "status", "--porcelain")
+
+	m := NewManager(repo, filepath.Join(t.TempDir(), "worktrees"))
+	wt, err := m.Create(ctx, "TICKET 1")
+	if err != nil {
+		t.Fatalf("Create() err = %v", err)
+	}
+	if wt.Name != "TICKET-1" || read(t, wt.Path, "a.txt") != "a changed\n" || read(t, wt.Path, "earlier.txt") != "earlier\n" {
+		t.Fatalf("worktree %+v does not hold the working tree snapshot", wt)
+	}
+	if got := run(t, repo, "status", "--porcelain"); got != indexBefore {
+		t.Errorf("Create() changed the repository status: %q -> %q", indexBefore, got)
+	}
+
+	// The agent edits in the worktree while another ticket changes a different file.
+	write(t, wt.Path, "b.txt", "b by ticket\n")
+	write(t, wt.Path, "new.txt", "new\n")
+	if err := os.Remove(filepath.Join(wt.Path, "earlier.txt")); err != nil {
+		t.Fatal(err)
+	}
+	write(t, repo, "other.txt", "other ticket\n")
+
+	if err := m.Merge(ctx, wt); err != nil {
+		t.Fatalf("Merge() err = %v", err)
+	}
+	for name, want := range map[string]string{
+		"a.txt": "a changed\n", "b.txt": "b by ticket\n", "new.txt": "new\n", "other.txt": "other ticket\n", "earlier.txt": "",
+	} {
+		if got := read(t, repo, name); got != want {
+			t.Errorf("after Merge() %s = %q, want %q", name, got, want)
+		}
+	}
+
+	m.Remove(ctx, wt)
+	if _, err := os.Stat(wt.Path); !os.IsNotExist(err) {
+		t.Errorf("Remove() left %s behind", wt.Path)
+	}
+	if list := run(t, repo, "worktree", "list"); strings.Count(list, "\n") != 0 {
+		t.Errorf("worktree list after Remove() = %q", list)
+	}
+}
+
+func TestManager_MergeConflictLeavesRepositoryUnchanged(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepo(t)
+	m := NewManager(repo, filepath.Join(t.TempDir(), "worktrees"))
+	wt, err := m.Create(ctx, "T-1")
+	if err != nil {
+		t.Fatalf("Create() err = %v", err)
+	}
+	defer m.Remove(ctx, wt)
+
+	write(t, wt.Path, "a.txt", "from worktree\n")
+	write(t, wt.Path, "b.txt", "b from worktree\n")
+	write(t, repo, "a.txt", "from another ticket\n")
+
+	if err := m.Merge(ctx, wt); err == nil {
+		t.Fatal("Merge() should fail when the same lines changed")
+	}
+	if read(t, repo, "a.txt") != "from another ticket\n" || read(t, repo, "b.txt") != "b\n" {
+		t.Error("a failed Merge() should not change the repository")
+	}
+}
+
+func TestManager_MergeWithoutChanges(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepo(t)
+	m := NewManager(repo, filepath.Join(t.TempDir(), "worktrees"))
+	wt, err := m.Create(ctx, "T-1")
+	if err != nil {
+		t.Fatalf("Create() err = %v", err)
+	}
+	defer m.Remove(ctx, wt)
+	if wt.Base != run(t, repo, "rev-parse", "HEAD") {
+		t.Errorf("Base = %s, want HEAD for a clean working tree", wt.Base)
+	}
+	if err := m.Merge(ctx, wt); err != nil {
+		t.Errorf("Merge() without changes err = %v", err)
+	}
+
+	// Creating the same name again replaces the leftover worktree.
+	if _, err := m.Create(ctx, "T-1"); err != nil {
+		t.Errorf("Create() over a leftover worktree err = %v", err)
+	}
+}
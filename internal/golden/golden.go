@@ -0,0 +1,91 @@
+// Package golden compares test output with golden files, so that prompt and parser
+// changes show up as reviewable diffs of testdata instead of hand-edited expectations.
+//
+// Golden files live under testdata/golden of the package under test. When the output
+// changes on purpose, regenerate them and review the diff like any other change:
+//
+//	UPDATE_GOLDEN=1 go test ./internal/agent/...
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Dir is where golden files are read from, relative to the package under test.
+const Dir = "testdata/golden"
+
+// UpdateEnv is the environment variable that makes Assert rewrite golden files.
+const UpdateEnv = "UPDATE_GOLDEN"
+
+// Updating reports whether golden files are being rewritten rather than compared.
+func Updating() bool {
+	return os.Getenv(UpdateEnv) != ""
+}
+
+// Assert compares got with the golden file Dir/name, or writes got to it when
+// updating. A missing golden file fails the test with the hint to create it.
+func Assert(t testing.TB, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join(Dir, filepath.FromSlash(name))
+	if Updating() {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("golden: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("golden: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("golden: %v (run with %s=1 to create it)", err, UpdateEnv)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s (run with %s=1 to update, then review the diff):\n%s", path, UpdateEnv, Diff(string(want), string(got)))
+	}
+}
+
+// AssertJSON compares v, encoded as indented JSON, with the golden file Dir/name.
+func AssertJSON(t testing.TB, name string, v any) {
+	t.Helper()
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatalf("golden: %v", err)
+	}
+	Assert(t, name, append(data, '\n'))
+}
+
+// Diff describes how got differs from want, line by line: the first differing line
+// with a few lines of context, then the line counts when they differ.
+func Diff(want, got string) string {
+	wantLines, gotLines := strings.Split(want, "\n"), strings.Split(got, "\n")
+	first := 0
+	for first < len(wantLines) && first < len(gotLines) && wantLines[first] == gotLines[first] {
+		first++
+	}
+	var sb strings.Builder
+	from := max(first-2, 0)
+	for i := from; i < first; i++ {
+		fmt.Fprintf(&sb, "  %4d   %s\n", i+1, wantLines[i])
+	}
+	for i := first; i < first+3; i++ {
+		if i < len(wantLines) {
+			fmt.Fprintf(&sb, "- %4d   %s\n", i+1, wantLines[i])
+		}
+	}
+	for i := first; i < first+3; i++ {
+		if i < len(gotLines) {
+			fmt.Fprintf(&sb, "+ %4d   %s\n", i+1, gotLines[i])
+		}
+	}
+	if len(wantLines) != len(gotLines) {
+		fmt.Fprintf(&sb, "(want %d lines, got %d)\n", len(wantLines), len(gotLines))
+	}
+	return sb.String()
+}
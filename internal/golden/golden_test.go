@@ -0,0 +1,33 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAssert_UpdateThenCompare(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	t.Setenv(UpdateEnv, "1")
+	Assert(t, "sub/out.txt", []byte("hello\n"))
+	if data, err := os.ReadFile(filepath.Join(Dir, "sub", "out.txt")); err != nil || string(data) != "hello\n" {
+		t.Fatalf("update should write the golden file, got %q, %v", data, err)
+	}
+
+	t.Setenv(UpdateEnv, "")
+	Assert(t, "sub/out.txt", []byte("hello\n"))
+}
+
+func TestDiff(t *testing.T) {
+	got := Diff("a\nb\nc\nd\n", "a\nb\nX\nd\n")
+	for _, want := range []string{"     2   b", "-    3   c", "+    3   X"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Diff() should contain %q, got:\n%s", want, got)
+		}
+	}
+	if got := Diff("a\n", "a\nb\n"); !strings.Contains(got, "(want 2 lines, got 3)") {
+		t.Errorf("Diff() should report the line counts, got:\n%s", got)
+	}
+}
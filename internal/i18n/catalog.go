@@ -0,0 +1,186 @@
+package i18n
+
+//go:generate go run ./genkeys
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Supported languages. LangZhTW is the language of messages.go and the default; other
+// languages are read from locales/<lang>.json.
+const (
+	LangZhTW = "zh-TW"
+	LangEn   = "en"
+)
+
+//go:embed locales/*.json
+var locales embed.FS
+
+var (
+	// language is the language last set with SetLanguage.
+	language = LangZhTW
+	// defaults holds the zh-TW text of every message, as declared in messages.go.
+	defaults map[string]string
+	// translations holds the messages of each non-default language by key.
+	translations map[string]map[string]string
+	// reverse maps the text of a message in any language to its key, so strings
+	// captured before the language was set (e.g. command help) can be translated.
+	reverse map[string]string
+)
+
+func init() {
+	defaults = make(map[string]string, len(catalog))
+	reverse = make(map[string]string, 2*len(catalog))
+	for key, v := range catalog {
+		defaults[key] = *v
+		addReverse(*v, key)
+	}
+	translations = make(map[string]map[string]string)
+	entries, err := locales.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	for _, e := range entries {
+		data, err := locales.ReadFile("locales/" + e.Name())
+		if err != nil {
+			panic(err)
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: locales/%s: %v", e.Name(), err))
+		}
+		lang := strings.TrimSuffix(e.Name(), ".json")
+		translations[lang] = messages
+		for key, text := range messages {
+			addReverse(text, key)
+		}
+	}
+}
+
+// addReverse records key as the message with text. When several messages share a text
+// (e.g. CmdCommitShort and UICommitChanges), command help and flag usage win, as those
+// are what Translate is for; otherwise the first key in sort order does, so the choice
+// does not depend on map order.
+func addReverse(text, key string) {
+	if prev, ok := reverse[text]; ok {
+		if isHelpKey(prev) && !isHelpKey(key) {
+			return
+		}
+		if isHelpKey(prev) == isHelpKey(key) && prev < key {
+			return
+		}
+	}
+	reverse[text] = key
+}
+
+func isHelpKey(key string) bool {
+	return strings.HasPrefix(key, "Cmd") || strings.HasPrefix(key, "Flag")
+}
+
+// Languages returns the supported languages, LangZhTW first: the built-in ones and
+// those added by locale files (see LoadOverrides).
+func Languages() []string {
+	langs := []string{LangZhTW}
+	for _, l := range knownLanguages() {
+		if l != LangZhTW {
+			langs = append(langs, l)
+		}
+	}
+	return langs
+}
+
+// knownLanguages returns the languages with a built-in or user locale file, sorted.
+func knownLanguages() []string {
+	var langs []string
+	for lang := range translations {
+		langs = append(langs, lang)
+	}
+	for lang := range overrides {
+		if _, ok := translations[lang]; !ok {
+			langs = append(langs, lang)
+		}
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// NormalizeLanguage maps a language tag to a supported language: "en", "en-US" and
+// "en_US.UTF-8" are LangEn; "zh", "zh-TW" and "zh_Hant" are LangZhTW. A tag naming a
+// language added by a locale file matches it exactly (ignoring case) or by its base
+// language. ok is false when no supported language matches.
+func NormalizeLanguage(tag string) (lang string, ok bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+	tag = strings.ReplaceAll(tag, "_", "-")
+	base, _, _ := strings.Cut(tag, "-")
+	if base == "zh" {
+		return LangZhTW, true
+	}
+	known := knownLanguages()
+	for _, l := range known {
+		if tag == strings.ToLower(l) {
+			return l, true
+		}
+	}
+	for _, l := range known {
+		if base == strings.ToLower(l) {
+			return l, true
+		}
+	}
+	return "", false
+}
+
+// SetLanguage switches every message variable to lang; an empty lang is LangZhTW.
+// Messages come from the user locale file of lang, then its built-in locale file, and
+// keep their zh-TW text when neither has them.
+func SetLanguage(lang string) error {
+	if lang == "" {
+		lang = LangZhTW
+	}
+	normalized, ok := NormalizeLanguage(lang)
+	if !ok {
+		return fmt.Errorf(ErrUnknownLanguage, lang, strings.Join(Languages(), ", "))
+	}
+	user, builtin := overrides[normalized], translations[normalized]
+	for key, v := range catalog {
+		if text, ok := user[key]; ok {
+			*v = text
+		} else if text, ok := builtin[key]; ok {
+			*v = text
+		} else {
+			*v = defaults[key]
+		}
+	}
+	language = normalized
+	return nil
+}
+
+// Language returns the current language.
+func Language() string {
+	return language
+}
+
+// T returns the message with key (e.g. "MsgSuccess") in the current language, or key
+// itself when there is no such message.
+func T(key string) string {
+	if v, ok := catalog[key]; ok {
+		return *v
+	}
+	return key
+}
+
+// Translate returns s in the current language when s is the text of a message in any
+// language, and s unchanged otherwise. It is meant for strings copied out of the
+// message variables before SetLanguage, such as cobra help and flag usage.
+func Translate(s string) string {
+	if key, ok := reverse[s]; ok {
+		return T(key)
+	}
+	return s
+}
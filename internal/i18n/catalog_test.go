@@ -0,0 +1,130 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+// TestCatalog_MatchesMessages ensures keys.go is regenerated after messages change.
+func TestCatalog_MatchesMessages(t *testing.T) {
+	f, err := parser.ParseFile(token.NewFileSet(), "messages.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	declared := 0
+	for _, decl := range f.Decls {
+		g, ok := decl.(*ast.GenDecl)
+		if !ok || g.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range g.Specs {
+			for _, name := range spec.(*ast.ValueSpec).Names {
+				declared++
+				if _, ok := catalog[name.Name]; !ok {
+					t.Errorf("%s is missing from keys.go; run go generate ./internal/i18n", name.Name)
+				}
+			}
+		}
+	}
+	if declared != len(catalog) {
+		t.Errorf("messages.go declares %d messages, keys.go has %d; run go generate ./internal/i18n", declared, len(catalog))
+	}
+}
+
+// TestLocales_Complete checks that every locale translates every message with the same
+// format verbs, so no message falls back to zh-TW or breaks its fmt arguments.
+func TestLocales_Complete(t *testing.T) {
+	for lang, messages := range translations {
+		for key, zh := range defaults {
+			text, ok := messages[key]
+			if !ok {
+				t.Errorf("locales/%s.json: missing %s", lang, key)
+				continue
+			}
+			want := formatVerb.FindAllString(zh, -1)
+			got := formatVerb.FindAllString(text, -1)
+			if !sameVerbs(got, want) {
+				t.Errorf("locales/%s.json: %s has verbs %v, want %v", lang, key, got, want)
+			}
+		}
+		for key := range messages {
+			if _, ok := catalog[key]; !ok {
+				t.Errorf("locales/%s.json: unknown key %s", lang, key)
+			}
+		}
+	}
+	if _, ok := translations[LangEn]; !ok {
+		t.Error("the en locale is not embedded")
+	}
+}
+
+func TestNormalizeLanguage(t *testing.T) {
+	tests := []struct {
+		tag    string
+		want   string
+		wantOK bool
+	}{
+		{"zh-TW", LangZhTW, true},
+		{"zh", LangZhTW, true},
+		{"zh_Hant", LangZhTW, true},
+		{"en", LangEn, true},
+		{"EN-us", LangEn, true},
+		{"en_US.UTF-8", LangEn, true},
+		{"fr", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, ok := NormalizeLanguage(tt.tag)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("NormalizeLanguage(%q) = %q, %v; want %q, %v", tt.tag, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSetLanguage(t *testing.T) {
+	defer SetLanguage(LangZhTW)
+	zhShort := CmdRootShort
+
+	if err := SetLanguage("en-US"); err != nil {
+		t.Fatalf("SetLanguage(en-US) error = %v", err)
+	}
+	if Language() != LangEn {
+		t.Errorf("Language() = %q, want %q", Language(), LangEn)
+	}
+	if MsgSuccess != "Success" {
+		t.Errorf("MsgSuccess = %q after SetLanguage(en)", MsgSuccess)
+	}
+	if T("MsgSuccess") != "Success" {
+		t.Errorf("T(MsgSuccess) = %q", T("MsgSuccess"))
+	}
+	if got := Translate(zhShort); got != CmdRootShort || got == zhShort {
+		t.Errorf("Translate(zh CmdRootShort) = %q, want %q", got, CmdRootShort)
+	}
+	if got := Translate("not a message"); got != "not a message" {
+		t.Errorf("Translate(unknown) = %q", got)
+	}
+	if T("NoSuchKey") != "NoSuchKey" {
+		t.Errorf("T(NoSuchKey) = %q", T("NoSuchKey"))
+	}
+
+	if err := SetLanguage("fr"); err == nil {
+		t.Error("SetLanguage(fr) should fail")
+	}
+	if Language() != LangEn {
+		t.Errorf("a failed SetLanguage changed the language to %q", Language())
+	}
+
+	if err := SetLanguage(""); err != nil {
+		t.Fatalf("SetLanguage(\"\") error = %v", err)
+	}
+	if MsgSuccess != "成功" || CmdRootShort != zhShort {
+		t.Errorf("MsgSuccess = %q after switching back to zh-TW", MsgSuccess)
+	}
+	if got := Translate("Success"); got != "成功" {
+		t.Errorf("Translate(Success) = %q, want 成功", got)
+	}
+}
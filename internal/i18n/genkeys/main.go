@@ -0,0 +1,48 @@
+// Command genkeys writes keys.go, the registry of the message variables declared in
+// messages.go, so the catalog can replace their values when the language changes.
+//
+// Run it with go generate ./internal/i18n after adding or removing messages.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+)
+
+func main() {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "messages.go", nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by genkeys from messages.go; DO NOT EDIT.\n\npackage i18n\n\n")
+	b.WriteString("// catalog maps each message key to its variable.\nvar catalog = map[string]*string{\n")
+	for _, decl := range f.Decls {
+		g, ok := decl.(*ast.GenDecl)
+		if !ok || g.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range g.Specs {
+			for _, name := range spec.(*ast.ValueSpec).Names {
+				fmt.Fprintf(&b, "\t%q: &%s,\n", name.Name, name.Name)
+			}
+		}
+	}
+	b.WriteString("}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("keys.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,1078 @@
+// Code generated by genkeys from messages.go; DO NOT EDIT.
+
+package i18n
+
+// catalog maps each message key to its variable.
+var catalog = map[string]*string{
+	"MsgSuccess":                      &MsgSuccess,
+	"MsgFailed":                       &MsgFailed,
+	"MsgCompleted":                    &MsgCompleted,
+	"MsgCancelled":                    &MsgCancelled,
+	"MsgSkipped":                      &MsgSkipped,
+	"MsgPending":                      &MsgPending,
+	"MsgInProgress":                   &MsgInProgress,
+	"MsgNoData":                       &MsgNoData,
+	"MsgConfirm":                      &MsgConfirm,
+	"MsgYes":                          &MsgYes,
+	"MsgNo":                           &MsgNo,
+	"MsgInputEndHint":                 &MsgInputEndHint,
+	"MsgTextareaPlaceholder":          &MsgTextareaPlaceholder,
+	"MsgTextareaSubmitHint":           &MsgTextareaSubmitHint,
+	"MsgTextinputPlaceholder":         &MsgTextinputPlaceholder,
+	"MsgTextinputSubmitHint":          &MsgTextinputSubmitHint,
+	"MsgSelectRange":                  &MsgSelectRange,
+	"MsgInvalidSelection":             &MsgInvalidSelection,
+	"CmdRootShort":                    &CmdRootShort,
+	"CmdRootLong":                     &CmdRootLong,
+	"CmdVersionShort":                 &CmdVersionShort,
+	"CmdInitShort":                    &CmdInitShort,
+	"CmdInitLong":                     &CmdInitLong,
+	"CmdAnalyzeShort":                 &CmdAnalyzeShort,
+	"CmdAnalyzeLong":                  &CmdAnalyzeLong,
+	"CmdPlanShort":                    &CmdPlanShort,
+	"CmdPlanLong":                     &CmdPlanLong,
+	"CmdWorkShort":                    &CmdWorkShort,
+	"CmdWorkLong":                     &CmdWorkLong,
+	"CmdReviewShort":                  &CmdReviewShort,
+	"CmdReviewLong":                   &CmdReviewLong,
+	"CmdTestShort":                    &CmdTestShort,
+	"CmdTestLong":                     &CmdTestLong,
+	"CmdCommitShort":                  &CmdCommitShort,
+	"CmdCommitLong":                   &CmdCommitLong,
+	"CmdRunShort":                     &CmdRunShort,
+	"CmdRunLong":                      &CmdRunLong,
+	"CmdStatusShort":                  &CmdStatusShort,
+	"CmdStatusLong":                   &CmdStatusLong,
+	"CmdRetryShort":                   &CmdRetryShort,
+	"CmdRetryLong":                    &CmdRetryLong,
+	"CmdCleanShort":                   &CmdCleanShort,
+	"CmdCleanLong":                    &CmdCleanLong,
+	"CmdConfigShort":                  &CmdConfigShort,
+	"CmdConfigShowShort":              &CmdConfigShowShort,
+	"CmdConfigInitShort":              &CmdConfigInitShort,
+	"CmdConfigPathShort":              &CmdConfigPathShort,
+	"CmdConfigLong":                   &CmdConfigLong,
+	"CmdAddShort":                     &CmdAddShort,
+	"CmdAddLong":                      &CmdAddLong,
+	"CmdEditShort":                    &CmdEditShort,
+	"CmdEditLong":                     &CmdEditLong,
+	"CmdDropShort":                    &CmdDropShort,
+	"CmdDropLong":                     &CmdDropLong,
+	"FlagConfig":                      &FlagConfig,
+	"FlagDryRun":                      &FlagDryRun,
+	"FlagVerbose":                     &FlagVerbose,
+	"FlagDebug":                       &FlagDebug,
+	"FlagQuiet":                       &FlagQuiet,
+	"FlagOutput":                      &FlagOutput,
+	"FlagParallel":                    &FlagParallel,
+	"FlagDetach":                      &FlagDetach,
+	"FlagLogFile":                     &FlagLogFile,
+	"FlagScope":                       &FlagScope,
+	"FlagAuto":                        &FlagAuto,
+	"FlagCommitAll":                   &FlagCommitAll,
+	"FlagAnalyzeFirst":                &FlagAnalyzeFirst,
+	"FlagSkipTest":                    &FlagSkipTest,
+	"FlagSkipReview":                  &FlagSkipReview,
+	"FlagSkipCommit":                  &FlagSkipCommit,
+	"FlagDetachAfterPlan":             &FlagDetachAfterPlan,
+	"FlagForce":                       &FlagForce,
+	"FlagTitle":                       &FlagTitle,
+	"FlagType":                        &FlagType,
+	"FlagPriority":                    &FlagPriority,
+	"FlagDescription":                 &FlagDescription,
+	"FlagDeps":                        &FlagDeps,
+	"FlagEnhance":                     &FlagEnhance,
+	"FlagCriteria":                    &FlagCriteria,
+	"UIProjectInit":                   &UIProjectInit,
+	"UIProjectAnalyze":                &UIProjectAnalyze,
+	"UIPlanning":                      &UIPlanning,
+	"UIProcessTickets":                &UIProcessTickets,
+	"UIProcessTicket":                 &UIProcessTicket,
+	"UICodeReview":                    &UICodeReview,
+	"UIRunTests":                      &UIRunTests,
+	"UICommitChanges":                 &UICommitChanges,
+	"UIBatchCommit":                   &UIBatchCommit,
+	"UICommitComplete":                &UICommitComplete,
+	"UITicketStatus":                  &UITicketStatus,
+	"UIAnalysisReport":                &UIAnalysisReport,
+	"UIRetryFailed":                   &UIRetryFailed,
+	"UICleanData":                     &UICleanData,
+	"UICurrentConfig":                 &UICurrentConfig,
+	"UIFullPipeline":                  &UIFullPipeline,
+	"UIPipelineComplete":              &UIPipelineComplete,
+	"UIProcessComplete":               &UIProcessComplete,
+	"UICommonCommands":                &UICommonCommands,
+	"UIStatusStats":                   &UIStatusStats,
+	"UIAddTicket":                     &UIAddTicket,
+	"UIEditTicket":                    &UIEditTicket,
+	"UIDropTicket":                    &UIDropTicket,
+	"MsgProjectGoal":                  &MsgProjectGoal,
+	"MsgAnalyzeProject":               &MsgAnalyzeProject,
+	"MsgAnalyzeScope":                 &MsgAnalyzeScope,
+	"MsgAnalyzeMilestone":             &MsgAnalyzeMilestone,
+	"MsgProjectDir":                   &MsgProjectDir,
+	"MsgMilestone":                    &MsgMilestone,
+	"MsgDetectedExistingProject":      &MsgDetectedExistingProject,
+	"MsgProjectSummary":               &MsgProjectSummary,
+	"MsgScanComplete":                 &MsgScanComplete,
+	"MsgMaxParallel":                  &MsgMaxParallel,
+	"MsgIteration":                    &MsgIteration,
+	"MsgTicketInfo":                   &MsgTicketInfo,
+	"MsgTicketTitle":                  &MsgTicketTitle,
+	"MsgTicket":                       &MsgTicket,
+	"MsgChanges":                      &MsgChanges,
+	"MsgReviewFiles":                  &MsgReviewFiles,
+	"MsgTestResult":                   &MsgTestResult,
+	"MsgSummary":                      &MsgSummary,
+	"MsgFullOutput":                   &MsgFullOutput,
+	"MsgDependencies":                 &MsgDependencies,
+	"MsgStatsRemainingComplexity":     &MsgStatsRemainingComplexity,
+	"MsgStatsAvgDuration":             &MsgStatsAvgDuration,
+	"MsgStatsFailureRate":             &MsgStatsFailureRate,
+	"MsgErrorDetail":                  &MsgErrorDetail,
+	"MsgErrorLog":                     &MsgErrorLog,
+	"MsgConfigFilePath":               &MsgConfigFilePath,
+	"MsgEditConfigHint":               &MsgEditConfigHint,
+	"MsgFoundIssues":                  &MsgFoundIssues,
+	"MsgGeneratedTickets":             &MsgGeneratedTickets,
+	"MsgToDirectory":                  &MsgToDirectory,
+	"MsgPrepareCommit":                &MsgPrepareCommit,
+	"MsgFoundFailedTickets":           &MsgFoundFailedTickets,
+	"MsgMovedToPending":               &MsgMovedToPending,
+	"MsgCountCompleted":               &MsgCountCompleted,
+	"MsgCountFailed":                  &MsgCountFailed,
+	"MsgCountSkipped":                 &MsgCountSkipped,
+	"MsgCountSuccess":                 &MsgCountSuccess,
+	"MsgCommitCount":                  &MsgCommitCount,
+	"PromptProjectGoal":               &PromptProjectGoal,
+	"PromptGenerateTickets":           &PromptGenerateTickets,
+	"PromptContinuePlan":              &PromptContinuePlan,
+	"PromptConfirmClean":              &PromptConfirmClean,
+	"PromptOverwrite":                 &PromptOverwrite,
+	"PromptTicketTitle":               &PromptTicketTitle,
+	"PromptTicketDesc":                &PromptTicketDesc,
+	"PromptTicketType":                &PromptTicketType,
+	"PromptTicketPriority":            &PromptTicketPriority,
+	"PromptTicketDeps":                &PromptTicketDeps,
+	"PromptTicketCriteria":            &PromptTicketCriteria,
+	"PromptConfirmDrop":               &PromptConfirmDrop,
+	"PromptEditField":                 &PromptEditField,
+	"SpinnerGeneratingQuestions":      &SpinnerGeneratingQuestions,
+	"SpinnerGeneratingMilestone":      &SpinnerGeneratingMilestone,
+	"SpinnerAnalyzing":                &SpinnerAnalyzing,
+	"SpinnerPlanning":                 &SpinnerPlanning,
+	"SpinnerReviewing":                &SpinnerReviewing,
+	"SpinnerTesting":                  &SpinnerTesting,
+	"SpinnerCommitting":               &SpinnerCommitting,
+	"SpinnerProcessing":               &SpinnerProcessing,
+	"SpinnerEnhancing":                &SpinnerEnhancing,
+	"SpinnerScanningProject":          &SpinnerScanningProject,
+	"MsgQuestionsGenerated":           &MsgQuestionsGenerated,
+	"MsgMilestoneGenerated":           &MsgMilestoneGenerated,
+	"MsgMilestoneCreated":             &MsgMilestoneCreated,
+	"MsgAnalysisComplete":             &MsgAnalysisComplete,
+	"MsgPlanningComplete":             &MsgPlanningComplete,
+	"MsgReviewApproved":               &MsgReviewApproved,
+	"MsgReviewComplete":               &MsgReviewComplete,
+	"MsgTestComplete":                 &MsgTestComplete,
+	"MsgCommitSuccess":                &MsgCommitSuccess,
+	"MsgTicketCreated":                &MsgTicketCreated,
+	"MsgNoIssuesFound":                &MsgNoIssuesFound,
+	"MsgDataCleared":                  &MsgDataCleared,
+	"MsgConfigGenerated":              &MsgConfigGenerated,
+	"MsgProcessingComplete":           &MsgProcessingComplete,
+	"MsgPromptCompressed":             &MsgPromptCompressed,
+	"MsgPartialOutputSaved":           &MsgPartialOutputSaved,
+	"MsgTicketPromptCompressed":       &MsgTicketPromptCompressed,
+	"MsgTicketPartialOutput":          &MsgTicketPartialOutput,
+	"MsgTicketAdded":                  &MsgTicketAdded,
+	"MsgTicketUpdated":                &MsgTicketUpdated,
+	"MsgTicketDropped":                &MsgTicketDropped,
+	"MsgEnhanceComplete":              &MsgEnhanceComplete,
+	"MsgNoTicketsGenerated":           &MsgNoTicketsGenerated,
+	"MsgDependencyWarning":            &MsgDependencyWarning,
+	"MsgCircularDependency":           &MsgCircularDependency,
+	"MsgTicketStatusWarning":          &MsgTicketStatusWarning,
+	"MsgTicketCannotProcess":          &MsgTicketCannotProcess,
+	"MsgPendingBlocked":               &MsgPendingBlocked,
+	"MsgProcessInterrupted":           &MsgProcessInterrupted,
+	"MsgPipelineInterrupted":          &MsgPipelineInterrupted,
+	"MsgConfigExists":                 &MsgConfigExists,
+	"MsgAboutToDelete":                &MsgAboutToDelete,
+	"MsgTicketsDir":                   &MsgTicketsDir,
+	"MsgLogsDir":                      &MsgLogsDir,
+	"MsgCurrentStatus":                &MsgCurrentStatus,
+	"MsgInterruptSignal":              &MsgInterruptSignal,
+	"MsgDetached":                     &MsgDetached,
+	"MsgDetachedPid":                  &MsgDetachedPid,
+	"MsgDetachedPidLog":               &MsgDetachedPidLog,
+	"MsgRunDetachCodingDetached":      &MsgRunDetachCodingDetached,
+	"MsgRunDetachCodingDetachedNoLog": &MsgRunDetachCodingDetachedNoLog,
+	"MsgRunDetachHintNextSteps":       &MsgRunDetachHintNextSteps,
+	"MsgBackgroundWorkRunning":        &MsgBackgroundWorkRunning,
+	"MsgBackgroundWorkRunningPid":     &MsgBackgroundWorkRunningPid,
+	"MsgLogPath":                      &MsgLogPath,
+	"ErrAgentNotFound":                &ErrAgentNotFound,
+	"ErrAgentCommand":                 &ErrAgentCommand,
+	"ErrMilestoneNotFound":            &ErrMilestoneNotFound,
+	"ErrTicketNotFound":               &ErrTicketNotFound,
+	"ErrDeleteTicketFailed":           &ErrDeleteTicketFailed,
+	"ErrLoadConfigFailed":             &ErrLoadConfigFailed,
+	"ErrInitStoreFailed":              &ErrInitStoreFailed,
+	"ErrSaveTicketFailed":             &ErrSaveTicketFailed,
+	"ErrCleanTicketsFailed":           &ErrCleanTicketsFailed,
+	"ErrCleanLogsFailed":              &ErrCleanLogsFailed,
+	"ErrGenerateConfigFailed":         &ErrGenerateConfigFailed,
+	"ErrBackgroundWorkRunning":        &ErrBackgroundWorkRunning,
+	"SpinnerFailQuestions":            &SpinnerFailQuestions,
+	"SpinnerFailMilestone":            &SpinnerFailMilestone,
+	"SpinnerFailAnalysis":             &SpinnerFailAnalysis,
+	"SpinnerFailPlanning":             &SpinnerFailPlanning,
+	"SpinnerFailReview":               &SpinnerFailReview,
+	"SpinnerFailReviewNeeds":          &SpinnerFailReviewNeeds,
+	"SpinnerFailTest":                 &SpinnerFailTest,
+	"SpinnerFailTestHas":              &SpinnerFailTestHas,
+	"SpinnerFailCommit":               &SpinnerFailCommit,
+	"SpinnerFailTicket":               &SpinnerFailTicket,
+	"HintRunPlanLater":                &HintRunPlanLater,
+	"HintRunWork":                     &HintRunWork,
+	"HintRunStatus":                   &HintRunStatus,
+	"HintRunWorkCmd":                  &HintRunWorkCmd,
+	"HintRunRetryCmd":                 &HintRunRetryCmd,
+	"HintRunCommitCmd":                &HintRunCommitCmd,
+	"MsgNoTickets":                    &MsgNoTickets,
+	"MsgNoDataToClean":                &MsgNoDataToClean,
+	"MsgNoChangesToCommit":            &MsgNoChangesToCommit,
+	"MsgNoFilesToReview":              &MsgNoFilesToReview,
+	"MsgNoFailedToRetry":              &MsgNoFailedToRetry,
+	"MsgNoCompletedCommit":            &MsgNoCompletedCommit,
+	"MsgSkipNoChanges":                &MsgSkipNoChanges,
+	"MsgGettingStarted":               &MsgGettingStarted,
+	"MsgGettingStartedInit":           &MsgGettingStartedInit,
+	"MsgGettingStartedPlan":           &MsgGettingStartedPlan,
+	"MsgGettingStartedAnalyze":        &MsgGettingStartedAnalyze,
+	"MsgGettingStartedAdd":            &MsgGettingStartedAdd,
+	"CategoryPerformance":             &CategoryPerformance,
+	"CategoryRefactor":                &CategoryRefactor,
+	"CategorySecurity":                &CategorySecurity,
+	"CategoryTest":                    &CategoryTest,
+	"CategoryDocs":                    &CategoryDocs,
+	"StepAnalyze":                     &StepAnalyze,
+	"StepPlanning":                    &StepPlanning,
+	"StepCoding":                      &StepCoding,
+	"StepTesting":                     &StepTesting,
+	"StepReview":                      &StepReview,
+	"StepCommitting":                  &StepCommitting,
+	"AgentContextFilesLabel":          &AgentContextFilesLabel,
+	"AgentWriteJSONToFile":            &AgentWriteJSONToFile,
+	"AgentDryRunSkipCall":             &AgentDryRunSkipCall,
+	"AgentModelInUse":                 &AgentModelInUse,
+	"AgentWriteFile":                  &AgentWriteFile,
+	"AgentReadFile":                   &AgentReadFile,
+	"AgentRunCommand":                 &AgentRunCommand,
+	"AgentAPISystemPrompt":            &AgentAPISystemPrompt,
+	"AgentDurationMs":                 &AgentDurationMs,
+	"AgentCompressPrompt":             &AgentCompressPrompt,
+	"AgentCompressNote":               &AgentCompressNote,
+	"AgentAnalyzeIntro":               &AgentAnalyzeIntro,
+	"AgentAnalyzeProjectDir":          &AgentAnalyzeProjectDir,
+	"AgentAnalyzeFilesOnly":           &AgentAnalyzeFilesOnly,
+	"AgentAnalyzeAspects":             &AgentAnalyzeAspects,
+	"AgentAnalyzeFollowUp":            &AgentAnalyzeFollowUp,
+	"AgentAnalyzeKnownIssues":         &AgentAnalyzeKnownIssues,
+	"AgentAnalyzePerf":                &AgentAnalyzePerf,
+	"AgentAnalyzeRefactor":            &AgentAnalyzeRefactor,
+	"AgentAnalyzeSecurity":            &AgentAnalyzeSecurity,
+	"AgentAnalyzeTest":                &AgentAnalyzeTest,
+	"AgentAnalyzeDocs":                &AgentAnalyzeDocs,
+	"AgentAnalyzeJSONOutput":          &AgentAnalyzeJSONOutput,
+	"AgentEnhanceIntro":               &AgentEnhanceIntro,
+	"AgentEnhanceProjectDir":          &AgentEnhanceProjectDir,
+	"AgentEnhanceSection":             &AgentEnhanceSection,
+	"AgentEnhanceId":                  &AgentEnhanceId,
+	"AgentEnhanceTitle":               &AgentEnhanceTitle,
+	"AgentEnhanceType":                &AgentEnhanceType,
+	"AgentEnhancePriority":            &AgentEnhancePriority,
+	"AgentEnhanceDesc":                &AgentEnhanceDesc,
+	"AgentEnhanceDeps":                &AgentEnhanceDeps,
+	"AgentEnhanceCriteria":            &AgentEnhanceCriteria,
+	"AgentEnhanceJSONBlock":           &AgentEnhanceJSONBlock,
+	"AgentInitScanIntro":              &AgentInitScanIntro,
+	"AgentInitQuestionsExisting":      &AgentInitQuestionsExisting,
+	"AgentInitQuestionsNew":           &AgentInitQuestionsNew,
+	"AgentInitMilestoneExisting":      &AgentInitMilestoneExisting,
+	"AgentInitMilestoneNew":           &AgentInitMilestoneNew,
+	"ErrAgentMkdirOutput":             &ErrAgentMkdirOutput,
+	"ErrAgentMkdirDocs":               &ErrAgentMkdirDocs,
+	"ErrAgentAnalyzeFailed":           &ErrAgentAnalyzeFailed,
+	"ErrAgentAnalyzeOutput":           &ErrAgentAnalyzeOutput,
+	"ErrAgentInvalidIssues":           &ErrAgentInvalidIssues,
+	"ErrAgentReadMilestone":           &ErrAgentReadMilestone,
+	"ErrAgentPlanningFailed":          &ErrAgentPlanningFailed,
+	"ErrAgentPlanningOutput":          &ErrAgentPlanningOutput,
+	"ErrAgentInvalidTickets":          &ErrAgentInvalidTickets,
+	"ErrAgentEnhanceFailed":           &ErrAgentEnhanceFailed,
+	"ErrAgentEnhanceOutput":           &ErrAgentEnhanceOutput,
+	"ErrAgentScanFailed":              &ErrAgentScanFailed,
+	"ErrAgentWriteMilestone":          &ErrAgentWriteMilestone,
+	"ErrAgentCreateMilestone":         &ErrAgentCreateMilestone,
+	"ErrAgentAcceptanceFailed":        &ErrAgentAcceptanceFailed,
+	"ErrAgentAcceptanceOutput":        &ErrAgentAcceptanceOutput,
+	"ErrOpAgent":                      &ErrOpAgent,
+	"ErrOpFile":                       &ErrOpFile,
+	"ErrOpStore":                      &ErrOpStore,
+	"ErrOpAnalyze":                    &ErrOpAnalyze,
+	"ErrOpTest":                       &ErrOpTest,
+	"ErrOpReview":                     &ErrOpReview,
+	"ErrOpPlanning":                   &ErrOpPlanning,
+	"ErrMsgAgentNotAvailable":         &ErrMsgAgentNotAvailable,
+	"ErrMsgFileNotFound":              &ErrMsgFileNotFound,
+	"ErrMsgSaveTicket":                &ErrMsgSaveTicket,
+	"ErrMsgAnalysisFailed":            &ErrMsgAnalysisFailed,
+	"ErrMsgTestFailed":                &ErrMsgTestFailed,
+	"ErrMsgReviewFailed":              &ErrMsgReviewFailed,
+	"ErrMsgPlanningFailed":            &ErrMsgPlanningFailed,
+	"ErrMsgStoreInit":                 &ErrMsgStoreInit,
+	"CmdSelfUpdateShort":              &CmdSelfUpdateShort,
+	"CmdSelfUpdateLong":               &CmdSelfUpdateLong,
+	"FlagVersionCheck":                &FlagVersionCheck,
+	"FlagSelfUpdateForce":             &FlagSelfUpdateForce,
+	"MsgUpdateChecking":               &MsgUpdateChecking,
+	"MsgUpdateAvailable":              &MsgUpdateAvailable,
+	"MsgUpdateUpToDate":               &MsgUpdateUpToDate,
+	"MsgUpdateDevBuild":               &MsgUpdateDevBuild,
+	"MsgUpdateDownloading":            &MsgUpdateDownloading,
+	"MsgUpdateInstalled":              &MsgUpdateInstalled,
+	"HintSelfUpdate":                  &HintSelfUpdate,
+	"ErrUpdateCheckFailed":            &ErrUpdateCheckFailed,
+	"ErrUpdateFailed":                 &ErrUpdateFailed,
+	"CmdStoreShort":                   &CmdStoreShort,
+	"CmdStoreLong":                    &CmdStoreLong,
+	"CmdStoreMigrateShort":            &CmdStoreMigrateShort,
+	"CmdStoreMigrateLong":             &CmdStoreMigrateLong,
+	"CmdStoreReindexShort":            &CmdStoreReindexShort,
+	"CmdStoreReindexLong":             &CmdStoreReindexLong,
+	"FlagStoreFrom":                   &FlagStoreFrom,
+	"FlagStoreTo":                     &FlagStoreTo,
+	"FlagStoreFromPath":               &FlagStoreFromPath,
+	"FlagStoreToPath":                 &FlagStoreToPath,
+	"UIStoreMigrate":                  &UIStoreMigrate,
+	"MsgStoreMigrateFromTo":           &MsgStoreMigrateFromTo,
+	"MsgStoreMigrateDryRun":           &MsgStoreMigrateDryRun,
+	"MsgStoreMigrateDone":             &MsgStoreMigrateDone,
+	"MsgStoreMigrateMetricsCopied":    &MsgStoreMigrateMetricsCopied,
+	"MsgStoreMigrateRolledBack":       &MsgStoreMigrateRolledBack,
+	"MsgStoreMigrateSourceKept":       &MsgStoreMigrateSourceKept,
+	"MsgStoreReindexed":               &MsgStoreReindexed,
+	"MsgStoreReindexNotNeeded":        &MsgStoreReindexNotNeeded,
+	"ErrStoreUnknownBackend":          &ErrStoreUnknownBackend,
+	"ErrStoreMigrateSame":             &ErrStoreMigrateSame,
+	"ErrStoreMigrateFailed":           &ErrStoreMigrateFailed,
+	"ErrStoreMigrateFileExists":       &ErrStoreMigrateFileExists,
+	"ErrStoreMigrateVerifyFile":       &ErrStoreMigrateVerifyFile,
+	"CmdAuditShort":                   &CmdAuditShort,
+	"CmdAuditLong":                    &CmdAuditLong,
+	"FlagAuditSince":                  &FlagAuditSince,
+	"FlagAuditUntil":                  &FlagAuditUntil,
+	"FlagAuditUser":                   &FlagAuditUser,
+	"UIAudit":                         &UIAudit,
+	"TableAuditTime":                  &TableAuditTime,
+	"TableAuditOperator":              &TableAuditOperator,
+	"TableAuditResult":                &TableAuditResult,
+	"TableAuditDuration":              &TableAuditDuration,
+	"TableAuditConfig":                &TableAuditConfig,
+	"TableAuditCommand":               &TableAuditCommand,
+	"MsgAuditNoEntries":               &MsgAuditNoEntries,
+	"MsgAuditSummary":                 &MsgAuditSummary,
+	"CmdWorkStopShort":                &CmdWorkStopShort,
+	"CmdWorkStopLong":                 &CmdWorkStopLong,
+	"FlagWorkStopTimeout":             &FlagWorkStopTimeout,
+	"FlagWorkStopForce":               &FlagWorkStopForce,
+	"MsgWorkStopNotRunning":           &MsgWorkStopNotRunning,
+	"MsgWorkStopStale":                &MsgWorkStopStale,
+	"MsgWorkStopSignal":               &MsgWorkStopSignal,
+	"MsgWorkStopKill":                 &MsgWorkStopKill,
+	"MsgWorkStopped":                  &MsgWorkStopped,
+	"MsgWorkStopRequeued":             &MsgWorkStopRequeued,
+	"MsgTicketRequeued":               &MsgTicketRequeued,
+	"ErrWorkStopSignal":               &ErrWorkStopSignal,
+	"ErrWorkStopTimeout":              &ErrWorkStopTimeout,
+	"ErrWorkStopRequeue":              &ErrWorkStopRequeue,
+	"CmdHooksShort":                   &CmdHooksShort,
+	"CmdHooksLong":                    &CmdHooksLong,
+	"CmdHooksInstallShort":            &CmdHooksInstallShort,
+	"CmdHooksUninstallShort":          &CmdHooksUninstallShort,
+	"FlagHooksSelected":               &FlagHooksSelected,
+	"FlagHooksForce":                  &FlagHooksForce,
+	"FlagHooksFailOn":                 &FlagHooksFailOn,
+	"FlagAnalyzeChanged":              &FlagAnalyzeChanged,
+	"FlagAnalyzeFailOn":               &FlagAnalyzeFailOn,
+	"MsgHookInstalled":                &MsgHookInstalled,
+	"MsgHookRemoved":                  &MsgHookRemoved,
+	"MsgHookRestored":                 &MsgHookRestored,
+	"MsgHookBackedUp":                 &MsgHookBackedUp,
+	"MsgHookExists":                   &MsgHookExists,
+	"MsgHookNotOurs":                  &MsgHookNotOurs,
+	"MsgHookPendingReminder":          &MsgHookPendingReminder,
+	"MsgAnalyzeNoChangedFiles":        &MsgAnalyzeNoChangedFiles,
+	"MsgAnalyzeChangedFiles":          &MsgAnalyzeChangedFiles,
+	"ErrHooksNotGitRepo":              &ErrHooksNotGitRepo,
+	"ErrHooksUnknown":                 &ErrHooksUnknown,
+	"ErrHooksWrite":                   &ErrHooksWrite,
+	"ErrInvalidSeverity":              &ErrInvalidSeverity,
+	"ErrAnalyzeFailOn":                &ErrAnalyzeFailOn,
+	"ErrCommitMsgNoTicket":            &ErrCommitMsgNoTicket,
+	"ErrCommitMsgUnknownTicket":       &ErrCommitMsgUnknownTicket,
+	"CmdLogsShort":                    &CmdLogsShort,
+	"CmdLogsLong":                     &CmdLogsLong,
+	"FlagLogsFollow":                  &FlagLogsFollow,
+	"FlagLogsTicket":                  &FlagLogsTicket,
+	"MsgLogsFile":                     &MsgLogsFile,
+	"ErrLogsNotFound":                 &ErrLogsNotFound,
+	"ErrLogsOpen":                     &ErrLogsOpen,
+	"MsgBudgetPaused":                 &MsgBudgetPaused,
+	"CmdRecurringShort":               &CmdRecurringShort,
+	"CmdRecurringLong":                &CmdRecurringLong,
+	"CmdRecurringListShort":           &CmdRecurringListShort,
+	"CmdRecurringRemoveShort":         &CmdRecurringRemoveShort,
+	"CmdRecurringRunShort":            &CmdRecurringRunShort,
+	"CmdRecurringRunLong":             &CmdRecurringRunLong,
+	"FlagRecur":                       &FlagRecur,
+	"FlagRecurringWatch":              &FlagRecurringWatch,
+	"FlagRecurringInterval":           &FlagRecurringInterval,
+	"UIRecurringTickets":              &UIRecurringTickets,
+	"MsgRecurringAdded":               &MsgRecurringAdded,
+	"MsgRecurringRemoved":             &MsgRecurringRemoved,
+	"MsgRecurringNone":                &MsgRecurringNone,
+	"MsgRecurringNext":                &MsgRecurringNext,
+	"MsgRecurringCreated":             &MsgRecurringCreated,
+	"MsgRecurringNothingDue":          &MsgRecurringNothingDue,
+	"MsgRecurringWatching":            &MsgRecurringWatching,
+	"MsgRecurringFrom":                &MsgRecurringFrom,
+	"ErrRecurringNotFound":            &ErrRecurringNotFound,
+	"ErrRecurringInterval":            &ErrRecurringInterval,
+	"FlagLabel":                       &FlagLabel,
+	"FlagEditLabel":                   &FlagEditLabel,
+	"FlagLabelFilter":                 &FlagLabelFilter,
+	"FlagWorkLabel":                   &FlagWorkLabel,
+	"FlagDropLabel":                   &FlagDropLabel,
+	"PromptConfirmDropLabel":          &PromptConfirmDropLabel,
+	"MsgLabels":                       &MsgLabels,
+	"MsgNoTicketsWithLabels":          &MsgNoTicketsWithLabels,
+	"MsgTicketsDroppedByLabel":        &MsgTicketsDroppedByLabel,
+	"ErrDropIDAndLabel":               &ErrDropIDAndLabel,
+	"FlagAnalyzeAutoExpand":           &FlagAnalyzeAutoExpand,
+	"FlagAnalyzeExpandDepth":          &FlagAnalyzeExpandDepth,
+	"UIAnalyzeExpand":                 &UIAnalyzeExpand,
+	"PromptAnalyzeExpand":             &PromptAnalyzeExpand,
+	"SpinnerAnalyzeExpand":            &SpinnerAnalyzeExpand,
+	"MsgAnalyzeExpandSuggestion":      &MsgAnalyzeExpandSuggestion,
+	"MsgAnalyzeExpandWholeProject":    &MsgAnalyzeExpandWholeProject,
+	"MsgAnalyzeExpandFound":           &MsgAnalyzeExpandFound,
+	"HintAnalyzeAutoExpand":           &HintAnalyzeAutoExpand,
+	"ErrAnalyzeExpandDepth":           &ErrAnalyzeExpandDepth,
+	"FlagParent":                      &FlagParent,
+	"FlagEditParent":                  &FlagEditParent,
+	"FlagPlanEpics":                   &FlagPlanEpics,
+	"UIEpics":                         &UIEpics,
+	"MsgParent":                       &MsgParent,
+	"MsgEpicProgress":                 &MsgEpicProgress,
+	"MsgEpicCompleted":                &MsgEpicCompleted,
+	"MsgEpicCannotProcess":            &MsgEpicCannotProcess,
+	"CmdImportShort":                  &CmdImportShort,
+	"CmdImportLong":                   &CmdImportLong,
+	"CmdImportGithubShort":            &CmdImportGithubShort,
+	"CmdImportGithubLong":             &CmdImportGithubLong,
+	"FlagImportLabel":                 &FlagImportLabel,
+	"MsgImportFetching":               &MsgImportFetching,
+	"MsgImportNone":                   &MsgImportNone,
+	"MsgImportCreated":                &MsgImportCreated,
+	"MsgImportWouldAdd":               &MsgImportWouldAdd,
+	"MsgImportSummary":                &MsgImportSummary,
+	"ErrImportFailed":                 &ErrImportFailed,
+	"CmdTraceShort":                   &CmdTraceShort,
+	"CmdTraceLong":                    &CmdTraceLong,
+	"FlagEditPR":                      &FlagEditPR,
+	"FlagWorkResumeFromPR":            &FlagWorkResumeFromPR,
+	"MsgTraceMilestone":               &MsgTraceMilestone,
+	"MsgTraceTicket":                  &MsgTraceTicket,
+	"MsgTraceCommits":                 &MsgTraceCommits,
+	"MsgTracePR":                      &MsgTracePR,
+	"MsgWorkResumeFromPR":             &MsgWorkResumeFromPR,
+	"ErrTraceNotFound":                &ErrTraceNotFound,
+	"ErrWorkResumePRWithID":           &ErrWorkResumePRWithID,
+	"FlagWorkQueue":                   &FlagWorkQueue,
+	"UIWorkQueue":                     &UIWorkQueue,
+	"MsgWorkQueued":                   &MsgWorkQueued,
+	"MsgWorkQueueStarting":            &MsgWorkQueueStarting,
+	"HintWorkQueueStatus":             &HintWorkQueueStatus,
+	"ErrWorkQueuedFailed":             &ErrWorkQueuedFailed,
+	"GitHubCommentCompleted":          &GitHubCommentCompleted,
+	"GitHubCommentFailed":             &GitHubCommentFailed,
+	"GitHubCommentCommitted":          &GitHubCommentCommitted,
+	"MsgSyncNoToken":                  &MsgSyncNoToken,
+	"MsgSyncFailed":                   &MsgSyncFailed,
+	"FlagAssert":                      &FlagAssert,
+	"UIAssertions":                    &UIAssertions,
+	"MsgAssertionPassed":              &MsgAssertionPassed,
+	"MsgAssertionFailed":              &MsgAssertionFailed,
+	"ErrAssertionsFailed":             &ErrAssertionsFailed,
+	"CmdImportJiraShort":              &CmdImportJiraShort,
+	"CmdImportJiraLong":               &CmdImportJiraLong,
+	"FlagImportJQL":                   &FlagImportJQL,
+	"MsgImportJiraFetching":           &MsgImportJiraFetching,
+	"ErrJiraURLRequired":              &ErrJiraURLRequired,
+	"MsgTicketBranch":                 &MsgTicketBranch,
+	"MsgCommitOnBranch":               &MsgCommitOnBranch,
+	"MsgBranchPerTicketSequential":    &MsgBranchPerTicketSequential,
+	"ErrTicketBranch":                 &ErrTicketBranch,
+	"ErrWorktreeCreate":               &ErrWorktreeCreate,
+	"ErrWorktreeMerge":                &ErrWorktreeMerge,
+	"ProjectHintGoErrors":             &ProjectHintGoErrors,
+	"ProjectHintGoTests":              &ProjectHintGoTests,
+	"ProjectHintNodeLockfile":         &ProjectHintNodeLockfile,
+	"ProjectHintNodeModules":          &ProjectHintNodeModules,
+	"ProjectHintPythonStyle":          &ProjectHintPythonStyle,
+	"ProjectHintPythonTests":          &ProjectHintPythonTests,
+	"ProjectHintJavaLayout":           &ProjectHintJavaLayout,
+	"ProjectHintJavaTests":            &ProjectHintJavaTests,
+	"AgentProjectCommands":            &AgentProjectCommands,
+	"AgentProjectTest":                &AgentProjectTest,
+	"AgentProjectBuild":               &AgentProjectBuild,
+	"AgentProjectFormat":              &AgentProjectFormat,
+	"AgentTestProjectUnknown":         &AgentTestProjectUnknown,
+	"AgentTestProjectDetected":        &AgentTestProjectDetected,
+	"CmdReportShort":                  &CmdReportShort,
+	"CmdReportLong":                   &CmdReportLong,
+	"CmdReportQualityShort":           &CmdReportQualityShort,
+	"CmdReportQualityLong":            &CmdReportQualityLong,
+	"FlagReportQualityLimit":          &FlagReportQualityLimit,
+	"UIQualityReport":                 &UIQualityReport,
+	"TableQualityTime":                &TableQualityTime,
+	"TableQualityScore":               &TableQualityScore,
+	"TableQualityChange":              &TableQualityChange,
+	"TableQualityIssues":              &TableQualityIssues,
+	"MsgQualityNoHistory":             &MsgQualityNoHistory,
+	"MsgQualityScope":                 &MsgQualityScope,
+	"MsgQualityTrend":                 &MsgQualityTrend,
+	"MsgQualityImproving":             &MsgQualityImproving,
+	"MsgQualityDegrading":             &MsgQualityDegrading,
+	"MsgQualityStable":                &MsgQualityStable,
+	"MsgQualityScore":                 &MsgQualityScore,
+	"MsgQualityScoreDelta":            &MsgQualityScoreDelta,
+	"MsgStatsQuality":                 &MsgStatsQuality,
+	"CmdPRShort":                      &CmdPRShort,
+	"CmdPRLong":                       &CmdPRLong,
+	"FlagPRAll":                       &FlagPRAll,
+	"FlagPRBase":                      &FlagPRBase,
+	"UIPullRequests":                  &UIPullRequests,
+	"MsgPRCreated":                    &MsgPRCreated,
+	"MsgPRExists":                     &MsgPRExists,
+	"MsgPRNone":                       &MsgPRNone,
+	"MsgPRDryRun":                     &MsgPRDryRun,
+	"MsgPRDefaultBase":                &MsgPRDefaultBase,
+	"PRBodyAcceptance":                &PRBodyAcceptance,
+	"PRBodyTicket":                    &PRBodyTicket,
+	"ErrPRTicketRequired":             &ErrPRTicketRequired,
+	"ErrPRNotCompleted":               &ErrPRNotCompleted,
+	"ErrPRNoBranch":                   &ErrPRNoBranch,
+	"ErrPRNoToken":                    &ErrPRNoToken,
+	"ErrPRFailed":                     &ErrPRFailed,
+	"ErrPRDefaultBranch":              &ErrPRDefaultBranch,
+	"ErrPRSomeFailed":                 &ErrPRSomeFailed,
+	"FlagStatusAsOf":                  &FlagStatusAsOf,
+	"UITicketStatusAsOf":              &UITicketStatusAsOf,
+	"MsgStatusAsOfNote":               &MsgStatusAsOfNote,
+	"CmdDepsShort":                    &CmdDepsShort,
+	"CmdDepsLong":                     &CmdDepsLong,
+	"CmdDepsScanShort":                &CmdDepsScanShort,
+	"CmdDepsScanLong":                 &CmdDepsScanLong,
+	"FlagDepsEcosystem":               &FlagDepsEcosystem,
+	"UIDepsScan":                      &UIDepsScan,
+	"MsgDepsScanning":                 &MsgDepsScanning,
+	"MsgDepsNoEcosystem":              &MsgDepsNoEcosystem,
+	"MsgDepsScanFailed":               &MsgDepsScanFailed,
+	"MsgDepsNone":                     &MsgDepsNone,
+	"MsgDepsCreated":                  &MsgDepsCreated,
+	"MsgDepsWouldAdd":                 &MsgDepsWouldAdd,
+	"MsgDepsSkipped":                  &MsgDepsSkipped,
+	"MsgDepsSummary":                  &MsgDepsSummary,
+	"ErrDepsEcosystem":                &ErrDepsEcosystem,
+	"ErrDepsAllFailed":                &ErrDepsAllFailed,
+	"DepsLatestVersion":               &DepsLatestVersion,
+	"DepsTicketTitle":                 &DepsTicketTitle,
+	"DepsTicketTitleSecurity":         &DepsTicketTitleSecurity,
+	"DepsDescEcosystem":               &DepsDescEcosystem,
+	"DepsDescPackage":                 &DepsDescPackage,
+	"DepsDescCurrent":                 &DepsDescCurrent,
+	"DepsDescTarget":                  &DepsDescTarget,
+	"DepsDescSeverity":                &DepsDescSeverity,
+	"DepsDescAdvisories":              &DepsDescAdvisories,
+	"DepsDescCommand":                 &DepsDescCommand,
+	"DepsCriterionUpgraded":           &DepsCriterionUpgraded,
+	"DepsCriterionTests":              &DepsCriterionTests,
+	"RetryCommand":                    &RetryCommand,
+	"MsgRetryHint":                    &MsgRetryHint,
+	"MsgTicketNotFailed":              &MsgTicketNotFailed,
+	"AgentRetrying":                   &AgentRetrying,
+	"FlagWorkLenient":                 &FlagWorkLenient,
+	"ErrDoDUnmet":                     &ErrDoDUnmet,
+	"MsgUsage":                        &MsgUsage,
+	"MsgUsageTotal":                   &MsgUsageTotal,
+	"MsgRunUsageTotal":                &MsgRunUsageTotal,
+	"FlagWorkMaxCost":                 &FlagWorkMaxCost,
+	"MsgTicketSkipped":                &MsgTicketSkipped,
+	"MsgCostCeilingReached":           &MsgCostCeilingReached,
+	"CmdNoteShort":                    &CmdNoteShort,
+	"CmdNoteLong":                     &CmdNoteLong,
+	"MsgNoteAdded":                    &MsgNoteAdded,
+	"MsgNoNotes":                      &MsgNoNotes,
+	"MsgNotes":                        &MsgNotes,
+	"MsgLatestNote":                   &MsgLatestNote,
+	"ErrNoteClosedTicket":             &ErrNoteClosedTicket,
+	"MsgSystemicAbort":                &MsgSystemicAbort,
+	"MsgSystemicLastError":            &MsgSystemicLastError,
+	"MsgSystemicHint":                 &MsgSystemicHint,
+	"HintSystemicUnavailable":         &HintSystemicUnavailable,
+	"HintSystemicAuth":                &HintSystemicAuth,
+	"HintSystemicQuota":               &HintSystemicQuota,
+	"HintSystemicNetwork":             &HintSystemicNetwork,
+	"HintSystemicRateLimit":           &HintSystemicRateLimit,
+	"NotifySystemicAbortTitle":        &NotifySystemicAbortTitle,
+	"MsgNotifyFailed":                 &MsgNotifyFailed,
+	"ErrSystemicAbort":                &ErrSystemicAbort,
+	"CmdPromptsShort":                 &CmdPromptsShort,
+	"CmdPromptsLong":                  &CmdPromptsLong,
+	"CmdPromptsInitShort":             &CmdPromptsInitShort,
+	"FlagPromptsInitForce":            &FlagPromptsInitForce,
+	"UIPrompts":                       &UIPrompts,
+	"MsgPromptEmbedded":               &MsgPromptEmbedded,
+	"MsgPromptExists":                 &MsgPromptExists,
+	"MsgPromptWritten":                &MsgPromptWritten,
+	"FlagLang":                        &FlagLang,
+	"ErrUnknownLanguage":              &ErrUnknownLanguage,
+	"ErrLocaleFile":                   &ErrLocaleFile,
+	"ErrLocaleUnknownKey":             &ErrLocaleUnknownKey,
+	"ErrLocaleVerbs":                  &ErrLocaleVerbs,
+	"CmdCompletionShort":              &CmdCompletionShort,
+	"CmdCompletionLong":               &CmdCompletionLong,
+	"MsgTicketType":                   &MsgTicketType,
+	"MsgTicketPriority":               &MsgTicketPriority,
+	"MsgTicketStatus":                 &MsgTicketStatus,
+	"MsgTicketDescription":            &MsgTicketDescription,
+	"MsgTicketDeps":                   &MsgTicketDeps,
+	"MsgTicketCriteria":               &MsgTicketCriteria,
+	"MsgTicketFilesModify":            &MsgTicketFilesModify,
+	"MsgTicketFilesCreate":            &MsgTicketFilesCreate,
+	"MsgCurrentTicketInfo":            &MsgCurrentTicketInfo,
+	"MsgCurrentDescription":           &MsgCurrentDescription,
+	"MsgCurrentDeps":                  &MsgCurrentDeps,
+	"MsgCurrentCriteria":              &MsgCurrentCriteria,
+	"MsgDropTicketPreview":            &MsgDropTicketPreview,
+	"MsgDropTicketsPreview":           &MsgDropTicketsPreview,
+	"EditFieldTitle":                  &EditFieldTitle,
+	"EditFieldDescription":            &EditFieldDescription,
+	"EditFieldType":                   &EditFieldType,
+	"EditFieldPriority":               &EditFieldPriority,
+	"EditFieldDeps":                   &EditFieldDeps,
+	"EditFieldCriteria":               &EditFieldCriteria,
+	"EditFieldLabels":                 &EditFieldLabels,
+	"EditFieldDone":                   &EditFieldDone,
+	"TicketTypeFeature":               &TicketTypeFeature,
+	"TicketTypeBugfix":                &TicketTypeBugfix,
+	"TicketTypeRefactor":              &TicketTypeRefactor,
+	"TicketTypeTest":                  &TicketTypeTest,
+	"TicketTypeDocs":                  &TicketTypeDocs,
+	"TicketTypePerformance":           &TicketTypePerformance,
+	"TicketTypeSecurity":              &TicketTypeSecurity,
+	"PromptNewTitle":                  &PromptNewTitle,
+	"PromptNewDescription":            &PromptNewDescription,
+	"PromptSelectType":                &PromptSelectType,
+	"PromptNewPriority":               &PromptNewPriority,
+	"PromptNewDeps":                   &PromptNewDeps,
+	"PromptNewCriteria":               &PromptNewCriteria,
+	"PromptNewLabels":                 &PromptNewLabels,
+	"SpinnerFailEnhance":              &SpinnerFailEnhance,
+	"MsgEnhanceFailedKept":            &MsgEnhanceFailedKept,
+	"MsgEnhanceFailedUsed":            &MsgEnhanceFailedUsed,
+	"MsgEnhanceUnavailable":           &MsgEnhanceUnavailable,
+	"ErrTicketInvalid":                &ErrTicketInvalid,
+	"ErrTitleEmpty":                   &ErrTitleEmpty,
+	"AgentEnhanceAppendix":            &AgentEnhanceAppendix,
+	"MsgTestPassed":                   &MsgTestPassed,
+	"MsgTestFailed":                   &MsgTestFailed,
+	"MsgTestSkipped":                  &MsgTestSkipped,
+	"MsgCommitTicketStep":             &MsgCommitTicketStep,
+	"ErrCommitTicketOrAll":            &ErrCommitTicketOrAll,
+	"TableConfigKey":                  &TableConfigKey,
+	"TableConfigValue":                &TableConfigValue,
+	"MsgSeconds":                      &MsgSeconds,
+	"MsgRetriesBackoff":               &MsgRetriesBackoff,
+	"SpinnerFailScan":                 &SpinnerFailScan,
+	"AgentSummaryLanguage":            &AgentSummaryLanguage,
+	"AgentSummaryFramework":           &AgentSummaryFramework,
+	"AgentSummaryStructure":           &AgentSummaryStructure,
+	"AgentSummaryHasTests":            &AgentSummaryHasTests,
+	"AgentSummaryHasDocs":             &AgentSummaryHasDocs,
+	"AgentQuestionLanguage":           &AgentQuestionLanguage,
+	"AgentQuestionUsers":              &AgentQuestionUsers,
+	"AgentQuestionFeatures":           &AgentQuestionFeatures,
+	"AgentQuestionScale":              &AgentQuestionScale,
+	"AgentQuestionOutput":             &AgentQuestionOutput,
+	"AgentQuestionIntegrate":          &AgentQuestionIntegrate,
+	"AgentQuestionModify":             &AgentQuestionModify,
+	"AgentQuestionCompat":             &AgentQuestionCompat,
+	"AgentQuestionTests":              &AgentQuestionTests,
+	"AgentQuestionDocs":               &AgentQuestionDocs,
+	"AgentTestPrompt":                 &AgentTestPrompt,
+	"FlagProgressFormat":              &FlagProgressFormat,
+	"FlagProgressFD":                  &FlagProgressFD,
+	"ErrUnknownProgressFormat":        &ErrUnknownProgressFormat,
+	"ErrProgressFD":                   &ErrProgressFD,
+	"CmdDepsFixCyclesShort":           &CmdDepsFixCyclesShort,
+	"CmdDepsFixCyclesLong":            &CmdDepsFixCyclesLong,
+	"FlagCycleStrategy":               &FlagCycleStrategy,
+	"UIFixCycles":                     &UIFixCycles,
+	"MsgCyclePath":                    &MsgCyclePath,
+	"MsgCycleSuggest":                 &MsgCycleSuggest,
+	"PromptCycleRepair":               &PromptCycleRepair,
+	"CycleOptionSoft":                 &CycleOptionSoft,
+	"CycleOptionDrop":                 &CycleOptionDrop,
+	"CycleOptionSkip":                 &CycleOptionSkip,
+	"MsgCycleSoftened":                &MsgCycleSoftened,
+	"MsgCycleDropped":                 &MsgCycleDropped,
+	"MsgNoCycles":                     &MsgNoCycles,
+	"MsgCyclesFixed":                  &MsgCyclesFixed,
+	"MsgCyclesDryRun":                 &MsgCyclesDryRun,
+	"HintFixCycles":                   &HintFixCycles,
+	"ErrCycleStrategy":                &ErrCycleStrategy,
+	"FlagStatusOutput":                &FlagStatusOutput,
+	"ErrStatusOutput":                 &ErrStatusOutput,
+	"ErrStatusJSONAsOf":               &ErrStatusJSONAsOf,
+	"CmdWorkReapShort":                &CmdWorkReapShort,
+	"CmdWorkReapLong":                 &CmdWorkReapLong,
+	"FlagWorkReapYes":                 &FlagWorkReapYes,
+	"MsgNoOrphanAgents":               &MsgNoOrphanAgents,
+	"MsgOrphanAgentsFound":            &MsgOrphanAgentsFound,
+	"MsgOrphanAgent":                  &MsgOrphanAgent,
+	"PromptReapAgent":                 &PromptReapAgent,
+	"MsgAgentReaped":                  &MsgAgentReaped,
+	"MsgAgentsReaped":                 &MsgAgentsReaped,
+	"HintWorkReap":                    &HintWorkReap,
+	"ErrReapAgent":                    &ErrReapAgent,
+	"ErrReapPIDReused":                &ErrReapPIDReused,
+	"FlagStatusWatch":                 &FlagStatusWatch,
+	"FlagStatusInterval":              &FlagStatusInterval,
+	"ErrStatusWatchMode":              &ErrStatusWatchMode,
+	"ErrStatusInterval":               &ErrStatusInterval,
+	"MsgWatchHeader":                  &MsgWatchHeader,
+	"UIWatchInFlight":                 &UIWatchInFlight,
+	"MsgWatchBackground":              &MsgWatchBackground,
+	"AgentAcceptancePrompt":           &AgentAcceptancePrompt,
+	"AgentAcceptanceFollowUpTitle":    &AgentAcceptanceFollowUpTitle,
+	"FlagAcceptanceReview":            &FlagAcceptanceReview,
+	"StepAcceptance":                  &StepAcceptance,
+	"MsgAcceptanceSkippedIncomplete":  &MsgAcceptanceSkippedIncomplete,
+	"MsgAcceptanceNoSection":          &MsgAcceptanceNoSection,
+	"MsgAcceptanceResult":             &MsgAcceptanceResult,
+	"MsgAcceptanceReport":             &MsgAcceptanceReport,
+	"MsgAcceptanceFollowUp":           &MsgAcceptanceFollowUp,
+	"MsgAcceptanceAllMet":             &MsgAcceptanceAllMet,
+	"HintAcceptanceFollowUps":         &HintAcceptanceFollowUps,
+	"UIAcceptanceReport":              &UIAcceptanceReport,
+	"UIAcceptanceSummary":             &UIAcceptanceSummary,
+	"UIAcceptanceUnmet":               &UIAcceptanceUnmet,
+	"UIAcceptanceMet":                 &UIAcceptanceMet,
+	"MsgAcceptanceEvidence":           &MsgAcceptanceEvidence,
+	"MsgAcceptanceGap":                &MsgAcceptanceGap,
+	"MsgAcceptanceTicket":             &MsgAcceptanceTicket,
+	"FlagSoftDeps":                    &FlagSoftDeps,
+	"FlagEditSoftDeps":                &FlagEditSoftDeps,
+	"MsgTicketSoftDeps":               &MsgTicketSoftDeps,
+	"MsgSoftDependencies":             &MsgSoftDependencies,
+	"MsgSoftDeferred":                 &MsgSoftDeferred,
+	"CmdServeShort":                   &CmdServeShort,
+	"CmdServeLong":                    &CmdServeLong,
+	"FlagServeAddr":                   &FlagServeAddr,
+	"FlagServeRecurring":              &FlagServeRecurring,
+	"FlagServeRecurringInterval":      &FlagServeRecurringInterval,
+	"MsgServeListening":               &MsgServeListening,
+	"MsgServeRecurring":               &MsgServeRecurring,
+	"ErrServeListen":                  &ErrServeListen,
+	"ErrServeBusy":                    &ErrServeBusy,
+	"ErrServeStatus":                  &ErrServeStatus,
+	"ErrServeMilestoneRequired":       &ErrServeMilestoneRequired,
+	"ErrServeBadRequest":              &ErrServeBadRequest,
+	"ErrServeJobNotFound":             &ErrServeJobNotFound,
+	"ErrServeStreaming":               &ErrServeStreaming,
+	"ErrServeExposed":                 &ErrServeExposed,
+	"ErrServeContentType":             &ErrServeContentType,
+	"ErrServeCrossOrigin":             &ErrServeCrossOrigin,
+	"ErrServeHost":                    &ErrServeHost,
+	"MsgEscalated":                    &MsgEscalated,
+	"MsgUnblocks":                     &MsgUnblocks,
+	"MsgUnblocksEscalated":            &MsgUnblocksEscalated,
+	"NotifyRunFinishedTitle":          &NotifyRunFinishedTitle,
+	"NotifyWorkFinishedTitle":         &NotifyWorkFinishedTitle,
+	"NotifyRepeatedFailureTitle":      &NotifyRepeatedFailureTitle,
+	"NotifyRunSummary":                &NotifyRunSummary,
+	"MsgMetricsListening":             &MsgMetricsListening,
+	"MsgMetricsListenFailed":          &MsgMetricsListenFailed,
+	"CmdConfigGetShort":               &CmdConfigGetShort,
+	"CmdConfigGetLong":                &CmdConfigGetLong,
+	"CmdConfigSetShort":               &CmdConfigSetShort,
+	"CmdConfigSetLong":                &CmdConfigSetLong,
+	"MsgConfigSet":                    &MsgConfigSet,
+	"ErrConfigGetFailed":              &ErrConfigGetFailed,
+	"ErrConfigSetFailed":              &ErrConfigSetFailed,
+	"FlagRunSnapshot":                 &FlagRunSnapshot,
+	"FlagRunRestoreLast":              &FlagRunRestoreLast,
+	"MsgRunSnapshotTaken":             &MsgRunSnapshotTaken,
+	"UIRunRestore":                    &UIRunRestore,
+	"MsgRunRestoreFrom":               &MsgRunRestoreFrom,
+	"MsgRunRestoreCommits":            &MsgRunRestoreCommits,
+	"MsgRunRestoreFiles":              &MsgRunRestoreFiles,
+	"MsgRunRestoreTickets":            &MsgRunRestoreTickets,
+	"MsgRunRestoreNothing":            &MsgRunRestoreNothing,
+	"PromptConfirmRestore":            &PromptConfirmRestore,
+	"MsgRunRestored":                  &MsgRunRestored,
+	"ErrRunSnapshotFailed":            &ErrRunSnapshotFailed,
+	"ErrNoRunSnapshot":                &ErrNoRunSnapshot,
+	"ErrRunRestoreFailed":             &ErrRunRestoreFailed,
+	"ErrRunRestoreArgs":               &ErrRunRestoreArgs,
+	"ErrServeUnauthorized":            &ErrServeUnauthorized,
+	"ErrServeForbidden":               &ErrServeForbidden,
+	"MsgServeTokens":                  &MsgServeTokens,
+	"FlagAuditAPI":                    &FlagAuditAPI,
+	"UIAuditAPI":                      &UIAuditAPI,
+	"TableAuditToken":                 &TableAuditToken,
+	"TableAuditRequest":               &TableAuditRequest,
+	"TableAuditStatus":                &TableAuditStatus,
+	"TableAuditTarget":                &TableAuditTarget,
+	"MsgAuditAPISummary":              &MsgAuditAPISummary,
+	"UIRetryLinkTitle":                &UIRetryLinkTitle,
+	"UIRetryLinkPrompt":               &UIRetryLinkPrompt,
+	"UIRetryLinkButton":               &UIRetryLinkButton,
+	"UIRetryLinkStarted":              &UIRetryLinkStarted,
+	"ErrRetryLinkDisabled":            &ErrRetryLinkDisabled,
+	"ErrRetryLinkInvalid":             &ErrRetryLinkInvalid,
+	"ErrRetryLinkExpired":             &ErrRetryLinkExpired,
+	"FlagLogFormat":                   &FlagLogFormat,
+	"MsgTicketCodingDone":             &MsgTicketCodingDone,
+	"FlagPreviewFull":                 &FlagPreviewFull,
+	"UITicketPreview":                 &UITicketPreview,
+	"TablePreviewID":                  &TablePreviewID,
+	"TablePreviewTitle":               &TablePreviewTitle,
+	"TablePreviewType":                &TablePreviewType,
+	"TablePreviewPriority":            &TablePreviewPriority,
+	"TablePreviewDeps":                &TablePreviewDeps,
+	"PromptPreviewMore":               &PromptPreviewMore,
+	"CmdDoctorShort":                  &CmdDoctorShort,
+	"CmdDoctorLong":                   &CmdDoctorLong,
+	"FlagDoctorFix":                   &FlagDoctorFix,
+	"UIDoctor":                        &UIDoctor,
+	"ErrDoctorFix":                    &ErrDoctorFix,
+	"MsgDoctorFixed":                  &MsgDoctorFixed,
+	"MsgDoctorHealthy":                &MsgDoctorHealthy,
+	"ErrDoctorProblems":               &ErrDoctorProblems,
+	"HintDoctorFix":                   &HintDoctorFix,
+	"DoctorConfig":                    &DoctorConfig,
+	"DoctorAgent":                     &DoctorAgent,
+	"DoctorGit":                       &DoctorGit,
+	"DoctorTicketsDir":                &DoctorTicketsDir,
+	"DoctorLogsDir":                   &DoctorLogsDir,
+	"DoctorDetachLogDir":              &DoctorDetachLogDir,
+	"DoctorPIDFile":                   &DoctorPIDFile,
+	"DoctorInProgress":                &DoctorInProgress,
+	"DoctorOrphanAgents":              &DoctorOrphanAgents,
+	"MsgDoctorNoConfig":               &MsgDoctorNoConfig,
+	"HintDoctorInitConfig":            &HintDoctorInitConfig,
+	"HintDoctorFixConfig":             &HintDoctorFixConfig,
+	"HintDoctorAgentBackend":          &HintDoctorAgentBackend,
+	"MsgDoctorNoAPIKey":               &MsgDoctorNoAPIKey,
+	"HintDoctorAPIKey":                &HintDoctorAPIKey,
+	"MsgDoctorAPIBackend":             &MsgDoctorAPIBackend,
+	"MsgDoctorAgentMissing":           &MsgDoctorAgentMissing,
+	"HintDoctorAgentInstall":          &HintDoctorAgentInstall,
+	"HintDoctorAgentVersion":          &HintDoctorAgentVersion,
+	"MsgDoctorGitMissing":             &MsgDoctorGitMissing,
+	"HintDoctorGitInstall":            &HintDoctorGitInstall,
+	"MsgDoctorNotARepo":               &MsgDoctorNotARepo,
+	"HintDoctorGitInit":               &HintDoctorGitInit,
+	"HintDoctorCreateDir":             &HintDoctorCreateDir,
+	"MsgDoctorNotADir":                &MsgDoctorNotADir,
+	"HintDoctorDirPermissions":        &HintDoctorDirPermissions,
+	"MsgDoctorNoBackgroundWork":       &MsgDoctorNoBackgroundWork,
+	"MsgDoctorBackgroundWork":         &MsgDoctorBackgroundWork,
+	"MsgDoctorStalePID":               &MsgDoctorStalePID,
+	"HintDoctorStalePID":              &HintDoctorStalePID,
+	"MsgDoctorNoInProgress":           &MsgDoctorNoInProgress,
+	"MsgDoctorInProgressActive":       &MsgDoctorInProgressActive,
+	"MsgDoctorOrphanedInProgress":     &MsgDoctorOrphanedInProgress,
+	"HintDoctorRequeue":               &HintDoctorRequeue,
+	"MsgDoctorNoOrphanAgents":         &MsgDoctorNoOrphanAgents,
+	"MsgAgentProbe":                   &MsgAgentProbe,
+	"MsgAgentProbeFailed":             &MsgAgentProbeFailed,
+	"MsgAgentProbeTimeout":            &MsgAgentProbeTimeout,
+	"ErrAgentProbeFailed":             &ErrAgentProbeFailed,
+	"CmdConfigValidateShort":          &CmdConfigValidateShort,
+	"CmdConfigValidateLong":           &CmdConfigValidateLong,
+	"MsgConfigValid":                  &MsgConfigValid,
+	"MsgConfigValidateNoFile":         &MsgConfigValidateNoFile,
+	"ErrConfigInvalid":                &ErrConfigInvalid,
+	"CmdTriageShort":                  &CmdTriageShort,
+	"CmdTriageLong":                   &CmdTriageLong,
+	"FlagTriageAll":                   &FlagTriageAll,
+	"FlagTriageLimit":                 &FlagTriageLimit,
+	"UITriage":                        &UITriage,
+	"StepTriageAnalyze":               &StepTriageAnalyze,
+	"StepTriageDedupe":                &StepTriageDedupe,
+	"StepTriageQueue":                 &StepTriageQueue,
+	"MsgTriageDuplicate":              &MsgTriageDuplicate,
+	"MsgTriageWouldCreate":            &MsgTriageWouldCreate,
+	"MsgTriageCreated":                &MsgTriageCreated,
+	"MsgTriageQueueEmpty":             &MsgTriageQueueEmpty,
+	"MsgTriageQueueMore":              &MsgTriageQueueMore,
+	"TableTriageUnblocks":             &TableTriageUnblocks,
+	"FlagProfile":                     &FlagProfile,
+	"FlagConfigInitGlobal":            &FlagConfigInitGlobal,
+	"MsgConfigFilesInUse":             &MsgConfigFilesInUse,
+	"MsgConfigNoFiles":                &MsgConfigNoFiles,
+	"CmdGraphShort":                   &CmdGraphShort,
+	"CmdGraphLong":                    &CmdGraphLong,
+	"FlagGraphFormat":                 &FlagGraphFormat,
+	"ErrGraphFormat":                  &ErrGraphFormat,
+	"GraphMissing":                    &GraphMissing,
+	"GraphSoft":                       &GraphSoft,
+	"GraphCycle":                      &GraphCycle,
+	"GraphSeeAbove":                   &GraphSeeAbove,
+	"FlagPlanAuto":                    &FlagPlanAuto,
+	"PromptReviewTicket":              &PromptReviewTicket,
+	"MsgReviewTicketMeta":             &MsgReviewTicketMeta,
+	"PromptReviewTitle":               &PromptReviewTitle,
+	"PromptReviewPriority":            &PromptReviewPriority,
+	"PromptReviewDeps":                &PromptReviewDeps,
+	"MsgTicketRejected":               &MsgTicketRejected,
+	"MsgRejectedDependency":           &MsgRejectedDependency,
+	"MsgReviewSummary":                &MsgReviewSummary,
+	"FlagMilestoneFilter":             &FlagMilestoneFilter,
+	"FlagWorkMilestone":               &FlagWorkMilestone,
+	"MsgNoTicketsInMilestones":        &MsgNoTicketsInMilestones,
+	"MsgMilestoneUnassigned":          &MsgMilestoneUnassigned,
+	"FlagRunResume":                   &FlagRunResume,
+	"ErrRunResumeArgs":                &ErrRunResumeArgs,
+	"ErrNoRunState":                   &ErrNoRunState,
+	"ErrRunStateSave":                 &ErrRunStateSave,
+	"MsgRunResuming":                  &MsgRunResuming,
+	"MsgRunStepAlreadyDone":           &MsgRunStepAlreadyDone,
+	"MsgRunResumeRequeued":            &MsgRunResumeRequeued,
+	"HintRunResume":                   &HintRunResume,
+	"FlagRunPipeline":                 &FlagRunPipeline,
+	"StepShell":                       &StepShell,
+	"MsgPipelineDefinition":           &MsgPipelineDefinition,
+	"MsgPipelineStepSkipped":          &MsgPipelineStepSkipped,
+	"MsgPipelineShellPassed":          &MsgPipelineShellPassed,
+	"MsgPipelineShellFailed":          &MsgPipelineShellFailed,
+	"ErrPipelineInvalid":              &ErrPipelineInvalid,
+	"ErrPipelineNoSteps":              &ErrPipelineNoSteps,
+	"ErrPipelineStepType":             &ErrPipelineStepType,
+	"ErrPipelineStepDuplicate":        &ErrPipelineStepDuplicate,
+	"ErrPipelineStepCondition":        &ErrPipelineStepCondition,
+	"ErrPipelineStepRun":              &ErrPipelineStepRun,
+	"ErrPipelineStepOption":           &ErrPipelineStepOption,
+	"ErrPipelineStepTimeout":          &ErrPipelineStepTimeout,
+	"ErrPipelineStepFailed":           &ErrPipelineStepFailed,
+	"FlagRunFrom":                     &FlagRunFrom,
+	"FlagRunUntil":                    &FlagRunUntil,
+	"ErrRunStepUnknown":               &ErrRunStepUnknown,
+	"ErrRunStepRange":                 &ErrRunStepRange,
+	"FlagReviewFixIterations":         &FlagReviewFixIterations,
+	"MsgReviewFixIteration":           &MsgReviewFixIteration,
+	"MsgReviewFixExhausted":           &MsgReviewFixExhausted,
+	"MsgReviewFixNoTickets":           &MsgReviewFixNoTickets,
+	"MsgReviewFixFailed":              &MsgReviewFixFailed,
+	"MsgReviewBlocksCommit":           &MsgReviewBlocksCommit,
+	"MsgCommitBlockedByReview":        &MsgCommitBlockedByReview,
+	"AgentReviewDiffTruncated":        &AgentReviewDiffTruncated,
+	"FlagReviewBase":                  &FlagReviewBase,
+	"ErrReviewDiff":                   &ErrReviewDiff,
+	"MsgTestCoverage":                 &MsgTestCoverage,
+	"ErrCoverageBelow":                &ErrCoverageBelow,
+	"ErrCoverageUnknown":              &ErrCoverageUnknown,
+	"AgentTestFailurePrompt":          &AgentTestFailurePrompt,
+	"AgentTestRetry":                  &AgentTestRetry,
+	"MsgTestFlaky":                    &MsgTestFlaky,
+	"AgentVerifyPrompt":               &AgentVerifyPrompt,
+	"AgentVerifyNoAssertions":         &AgentVerifyNoAssertions,
+	"AgentVerifyNoVerdict":            &AgentVerifyNoVerdict,
+	"ErrAgentVerifyFailed":            &ErrAgentVerifyFailed,
+	"CmdVerifyShort":                  &CmdVerifyShort,
+	"CmdVerifyLong":                   &CmdVerifyLong,
+	"FlagVerify":                      &FlagVerify,
+	"StepVerify":                      &StepVerify,
+	"ErrVerifyNotComplete":            &ErrVerifyNotComplete,
+	"ErrVerifyFailed":                 &ErrVerifyFailed,
+	"MsgVerifyNothing":                &MsgVerifyNothing,
+	"MsgVerifyNoCriteria":             &MsgVerifyNoCriteria,
+	"MsgVerifyTicket":                 &MsgVerifyTicket,
+	"MsgVerifyCriterion":              &MsgVerifyCriterion,
+	"MsgVerifyEvidence":               &MsgVerifyEvidence,
+	"UIVerification":                  &UIVerification,
+	"MsgRetryTimeoutBumped":           &MsgRetryTimeoutBumped,
+	"NoteTicketReclaimed":             &NoteTicketReclaimed,
+	"MsgTicketReclaimed":              &MsgTicketReclaimed,
+	"MsgNoOrphanedTickets":            &MsgNoOrphanedTickets,
+	"FlagWorkReclaim":                 &FlagWorkReclaim,
+	"FlagNoLock":                      &FlagNoLock,
+	"DoctorCorruptTickets":            &DoctorCorruptTickets,
+	"MsgDoctorNoCorruptTickets":       &MsgDoctorNoCorruptTickets,
+	"MsgCorruptTicketFiles":           &MsgCorruptTicketFiles,
+	"HintCorruptTickets":              &HintCorruptTickets,
+	"CmdArchiveShort":                 &CmdArchiveShort,
+	"CmdArchiveLong":                  &CmdArchiveLong,
+	"FlagArchiveOlderThan":            &FlagArchiveOlderThan,
+	"FlagStatusArchived":              &FlagStatusArchived,
+	"UIArchive":                       &UIArchive,
+	"MsgArchiveNone":                  &MsgArchiveNone,
+	"MsgArchiveDryRun":                &MsgArchiveDryRun,
+	"MsgArchived":                     &MsgArchived,
+	"UIArchivedTickets":               &UIArchivedTickets,
+	"MsgNoArchivedTickets":            &MsgNoArchivedTickets,
+	"MsgArchivedTicketState":          &MsgArchivedTicketState,
+	"MsgArchivedCount":                &MsgArchivedCount,
+	"CmdHistoryShort":                 &CmdHistoryShort,
+	"CmdHistoryLong":                  &CmdHistoryLong,
+	"UIHistory":                       &UIHistory,
+	"MsgHistoryEmpty":                 &MsgHistoryEmpty,
+	"HistoryCreated":                  &HistoryCreated,
+	"HistoryStatus":                   &HistoryStatus,
+	"HistoryAgentStarted":             &HistoryAgentStarted,
+	"HistoryAgentFinished":            &HistoryAgentFinished,
+	"HistoryCommit":                   &HistoryCommit,
+	"MsgHistorySummary":               &MsgHistorySummary,
+	"CmdSearchShort":                  &CmdSearchShort,
+	"CmdSearchLong":                   &CmdSearchLong,
+	"FlagSearchStatus":                &FlagSearchStatus,
+	"FlagSearchType":                  &FlagSearchType,
+	"FlagSearchArchived":              &FlagSearchArchived,
+	"ErrSearchStatus":                 &ErrSearchStatus,
+	"ErrSearchType":                   &ErrSearchType,
+	"UISearch":                        &UISearch,
+	"MsgSearchNoMatch":                &MsgSearchNoMatch,
+	"MsgSearchFound":                  &MsgSearchFound,
+	"MsgSearchHit":                    &MsgSearchHit,
+	"FlagStatusFilter":                &FlagStatusFilter,
+	"FlagWorkFilter":                  &FlagWorkFilter,
+	"FlagDropFilter":                  &FlagDropFilter,
+	"MsgNoTicketsMatchFilter":         &MsgNoTicketsMatchFilter,
+	"PromptConfirmDropFilter":         &PromptConfirmDropFilter,
+	"FlagAddFromFile":                 &FlagAddFromFile,
+	"UIAddFromFile":                   &UIAddFromFile,
+	"MsgAddFileEmpty":                 &MsgAddFileEmpty,
+	"MsgAddFileRowError":              &MsgAddFileRowError,
+	"MsgAddFileDryRun":                &MsgAddFileDryRun,
+	"MsgAddFileAdded":                 &MsgAddFileAdded,
+	"ErrAddFileRead":                  &ErrAddFileRead,
+	"ErrAddFileInvalid":               &ErrAddFileInvalid,
+	"ErrAddFileWithFlags":             &ErrAddFileWithFlags,
+}
@@ -1,13 +1,16 @@
 // Package i18n provides internationalization support for the agent orchestrator.
-// All user-facing strings are centralized here for future localization.
+// All user-facing strings are centralized here and localized by the catalog.
 package i18n
 
-// Message keys organized by functional area.
-// The current implementation uses Traditional Chinese (zh-TW) as the default.
-// Future implementations can load these from locale-specific message files.
+// Message keys organized by functional area; the variable name is the message key.
+// The values here are Traditional Chinese (zh-TW), the default language. SetLanguage
+// replaces them with the translations in locales/<lang>.json.
+//
+// After adding or removing a message, run go generate ./internal/i18n to update
+// keys.go and add its translation to every locale file.
 
 // Common messages
-const (
+var (
 	// General
 	MsgSuccess    = "成功"
 	MsgFailed     = "失敗"
@@ -32,7 +35,7 @@ const (
 )
 
 // Command descriptions
-const (
+var (
 	// Root command
 	CmdRootShort = "協調多個 Cursor Agent 的 CLI 工具"
 	CmdRootLong  = `Agent Orchestrator - 使用 Cursor Agent (Headless Mode) 作為 Subagents
@@ -63,14 +66,22 @@ const (
 範例:
   agent-orchestrator analyze
   agent-orchestrator analyze --scope performance,refactor
-  agent-orchestrator analyze --scope security --auto`
+  agent-orchestrator analyze --scope security --auto
+  agent-orchestrator analyze --changed --fail-on HIGH`
 
 	// Plan command
 	CmdPlanShort = "分析 milestone 並產生 tickets"
 	CmdPlanLong  = `分析 milestone 文件，將其分解為可執行的 tickets。
+可一次規劃多個 milestone 檔案（或以引號括住的 glob），產生一份合併的 tickets，
+跨 milestone 的相依也會標出，每個 ticket 記錄所屬的 milestone，供 status、work 的 --milestone 篩選。
+在終端機中會逐一顯示規劃出的 tickets，可接受、編輯（標題、優先級、相依）或拒絕後才儲存；
+加上 --auto 則直接儲存全部。
 
 範例:
   agent-orchestrator plan docs/milestone-001.md
+  agent-orchestrator plan docs/milestone.md --auto
+  agent-orchestrator plan docs/milestone-1.md docs/milestone-2.md
+  agent-orchestrator plan 'docs/milestone-*.md'
   agent-orchestrator plan docs/milestone.md --dry-run`
 
 	// Work command
@@ -80,15 +91,18 @@ const (
 範例:
   agent-orchestrator work              # 處理所有 pending tickets
   agent-orchestrator work TICKET-001   # 處理指定 ticket
-  agent-orchestrator work -p 5         # 使用 5 個並行 agents`
+  agent-orchestrator work -p 5         # 使用 5 個並行 agents
+  agent-orchestrator work --filter "label=backend and priority<=2"`
 
 	// Review command
 	CmdReviewShort = "執行程式碼審查"
 	CmdReviewLong  = `對變更的檔案執行程式碼審查。如果沒有指定檔案，會自動取得 git 變更的檔案。
+變更的 git diff 會附在審查 prompt 中；--base 改為審查相對於該 ref 的全部變更（如整個分支）。
 
 範例:
   agent-orchestrator review
-  agent-orchestrator review src/main.go src/util.go`
+  agent-orchestrator review src/main.go src/util.go
+  agent-orchestrator review --base main`
 
 	// Test command
 	CmdTestShort = "執行專案測試"
@@ -108,26 +122,38 @@ const (
 	// Run command
 	CmdRunShort = "執行完整 pipeline"
 	CmdRunLong  = `執行完整的開發 pipeline: plan -> work -> test -> review -> commit
+步驟可在 pipeline 定義檔中自訂（--pipeline，預設 .agent-orchestrator/pipeline.yaml）。
 
 範例:
   agent-orchestrator run docs/milestone.md
   agent-orchestrator run docs/milestone.md --analyze-first
-  agent-orchestrator run docs/milestone.md --skip-test --skip-review`
+  agent-orchestrator run docs/milestone.md --skip-test --skip-review
+  agent-orchestrator run docs/milestone.md --pipeline ci/pipeline.yaml
+  agent-orchestrator run docs/milestone.md --until work   # plan 與 work 後停止
+  agent-orchestrator run docs/milestone.md --from test    # tickets 已完成，從 test 開始
+  agent-orchestrator run docs/milestone.md --snapshot   # 執行前保存工作區快照
+  agent-orchestrator run --restore-last                 # 還原到最近一次快照
+  agent-orchestrator run --resume                       # 從中斷的 run 最後完成的步驟之後繼續`
 
 	// Status command
 	CmdStatusShort = "顯示 tickets 狀態"
 	CmdStatusLong  = `顯示所有 tickets 的狀態統計和列表。
+--as-of 依 metrics 歷史重建指定時間點的狀態。
 
 範例:
-  agent-orchestrator status`
+  agent-orchestrator status
+  agent-orchestrator status --as-of "2024-06-01 12:00"
+  agent-orchestrator status --filter "type=feature and priority<=2"`
 
 	// Retry command
 	CmdRetryShort = "重試失敗的 tickets"
 	CmdRetryLong  = `將所有失敗的 tickets 移回 pending 狀態，以便重新處理。
+指定 ticket ID 時只重試這些 tickets。
 
 範例:
   agent-orchestrator retry
-  agent-orchestrator retry && agent-orchestrator work`
+  agent-orchestrator retry && agent-orchestrator work
+  agent-orchestrator retry TICKET-007 && agent-orchestrator work TICKET-007`
 
 	// Clean command
 	CmdCleanShort = "清除所有 tickets 和 logs"
@@ -147,17 +173,23 @@ const (
 範例:
   agent-orchestrator config           # 顯示目前設定
   agent-orchestrator config init      # 產生預設設定檔
-  agent-orchestrator config path      # 顯示設定檔路徑`
+  agent-orchestrator config path      # 顯示設定檔路徑
+  agent-orchestrator config get agent_command      # 顯示單一設定值
+  agent-orchestrator config set max_parallel 6     # 修改設定檔中的單一設定值
+  agent-orchestrator config validate  # 檢查設定檔的未知設定鍵與錯誤的值`
 
 	// Add command
 	CmdAddShort = "新增 ticket"
-	CmdAddLong  = `透過互動式問答或直接參數新增 ticket。
+	CmdAddLong  = `透過互動式問答或直接參數新增 ticket，或以 --from-file 從 JSON/CSV 檔案一次新增多個。
+從檔案新增時會先檢查每一列與依賴關係（含循環依賴），有任何錯誤就列出並不新增任何 ticket。
 
 範例:
   agent-orchestrator add                              # 互動模式
   agent-orchestrator add --title "實作登入功能"        # 直接模式
   agent-orchestrator add --title "新增快取" --enhance  # AI 預處理
-  agent-orchestrator add --title "重構" --type refactor --priority 2`
+  agent-orchestrator add --title "重構" --type refactor --priority 2
+  agent-orchestrator add --from-file backlog.json     # 格式同 generated-tickets.json
+  agent-orchestrator add --from-file backlog.csv --dry-run`
 
 	// Edit command
 	CmdEditShort = "修改 ticket"
@@ -175,11 +207,12 @@ const (
 
 範例:
   agent-orchestrator drop TICKET-001
-  agent-orchestrator drop TICKET-001 --force  # 不詢問直接刪除`
+  agent-orchestrator drop TICKET-001 --force  # 不詢問直接刪除
+  agent-orchestrator drop --filter "status=failed and created<7d"`
 )
 
 // Flag descriptions
-const (
+var (
 	FlagConfig       = "設定檔路徑 (預設: .agent-orchestrator.yaml)"
 	FlagDryRun       = "不實際執行 agent，只顯示會做什麼"
 	FlagVerbose      = "詳細輸出"
@@ -201,7 +234,7 @@ const (
 
 	// Add/Edit ticket flags
 	FlagTitle       = "Ticket 標題"
-	FlagType        = "Ticket 類型: feature, bugfix, refactor, test, docs, performance, security"
+	FlagType        = "Ticket 類型: feature, bugfix, refactor, test, docs, performance, security, epic"
 	FlagPriority    = "優先級 (1-5，1 最高)"
 	FlagDescription = "詳細描述"
 	FlagDeps        = "依賴的 ticket IDs (逗號分隔)"
@@ -210,7 +243,7 @@ const (
 )
 
 // UI messages
-const (
+var (
 	// Headers
 	UIProjectInit      = "專案初始化"
 	UIProjectAnalyze   = "專案分析"
@@ -231,6 +264,7 @@ const (
 	UIPipelineComplete = "Pipeline 完成!"
 	UIProcessComplete  = "處理完成"
 	UICommonCommands   = "常用指令:"
+	UIStatusStats      = "統計:"
 	UIAddTicket        = "新增 Ticket"
 	UIEditTicket       = "修改 Ticket"
 	UIDropTicket       = "刪除 Ticket"
@@ -256,6 +290,9 @@ const (
 	MsgSummary                 = "摘要: %s"
 	MsgFullOutput              = "完整輸出:"
 	MsgDependencies            = "依賴: %v"
+	MsgStatsRemainingComplexity = "  剩餘預估複雜度: %d 點 (low=1, medium=2, high=3)"
+	MsgStatsAvgDuration         = "  平均完成時間: %s"
+	MsgStatsFailureRate         = "  最近 %d 次執行失敗率: %.0f%% (%d/%d)"
 	MsgErrorDetail             = "錯誤: %s"
 	MsgErrorLog                 = "詳細日誌: %s"
 	MsgConfigFilePath          = "設定檔路徑: %s"
@@ -318,6 +355,10 @@ const (
 	MsgDataCleared        = "已清除所有資料"
 	MsgConfigGenerated    = "已產生設定檔: %s"
 	MsgProcessingComplete = "%s 完成"
+	MsgPromptCompressed   = "Prompt 已自動摘要（%s）：%d → %d 字元（預算 %d）"
+	MsgPartialOutputSaved = "上次執行逾時，已保存 %d 字元的部分輸出，重試時會從此繼續"
+	MsgTicketPromptCompressed = "%s prompt 超出預算，已自動摘要（%s）：%d → %d 字元"
+	MsgTicketPartialOutput    = "%s 逾時，已保存 %d 字元的部分輸出供重試使用"
 	MsgTicketAdded        = "已新增 ticket: %s"
 	MsgTicketUpdated      = "已更新 ticket: %s"
 	MsgTicketDropped      = "已刪除 ticket: %s"
@@ -364,7 +405,7 @@ const (
 	ErrCleanLogsFailed      = "清除 logs 失敗: %s"
 	ErrGenerateConfigFailed = "產生設定檔失敗: %s"
 	// ErrBackgroundWorkRunning 當背景 work (detach) 執行中時，禁止會寫入 store 的指令
-	ErrBackgroundWorkRunning = "背景 work 執行中 (PID %d)，無法執行會寫入 store 的指令。請稍後再試或先停止背景 work。"
+	ErrBackgroundWorkRunning = "背景 work 執行中 (PID %d)，無法執行會寫入 store 的指令。請稍後再試或先以 'agent-orchestrator work stop' 停止背景 work。"
 
 	// Spinner fail messages
 	SpinnerFailQuestions   = "產生問題失敗"
@@ -419,7 +460,7 @@ const (
 )
 
 // Agent prompts and messages (caller, coding, planning, enhance)
-const (
+var (
 	// Caller
 	AgentContextFilesLabel = "相關檔案: %s"
 	AgentWriteJSONToFile    = "請將結果以 JSON 格式寫入檔案: %s"
@@ -427,34 +468,29 @@ const (
 	AgentModelInUse         = "使用模型: %s"
 	AgentWriteFile          = "寫入檔案: %s"
 	AgentReadFile           = "讀取檔案: %s"
+	AgentRunCommand         = "執行指令: %s"
+	AgentAPISystemPrompt    = "你是在目錄 %s 中工作的開發 Agent。請使用提供的工具讀取與修改檔案、執行指令來完成任務，檔案路徑一律相對於該目錄。完成後以簡短文字說明所做的變更。"
 	AgentDurationMs = "完成，耗時 %.0fms"
 
-	// Coding agent prompt
-	AgentCodingIntro           = "你是一個專業的開發 Agent。請根據以下 ticket 實作程式碼。\n\n"
-	AgentCodingProjectRoot     = "專案根目錄: %s\n\n"
-	AgentCodingSectionTicket   = "## Ticket 資訊\n"
-	AgentCodingTicketId        = "- ID: %s\n"
-	AgentCodingTicketTitle     = "- 標題: %s\n"
-	AgentCodingTicketDesc      = "- 描述: %s\n"
-	AgentCodingTicketType      = "- 類型: %s\n"
-	AgentCodingTicketComplexity = "- 複雜度: %s\n\n"
-	AgentCodingSectionFilesCreate = "## 需要建立的檔案\n"
-	AgentCodingSectionFilesModify = "## 需要修改的檔案\n"
-	AgentCodingSectionAcceptance  = "## 驗收標準\n"
-	AgentCodingSteps = `## 請執行以下步驟:
-1. 閱讀相關的現有程式碼 (如果有)
-2. 實作 ticket 所描述的功能
-3. 確保程式碼符合最佳實踐
-4. 新增必要的 import 語句
-5. 確保程式碼可以編譯
-6. 如果適當，新增對應的單元測試
-
-完成後，說明你所做的變更。`
+	// Prompt compression (oversized tickets)
+	AgentCompressPrompt = `以下是一個開發 ticket 的 prompt 中的一段內容（描述、操作者備註、上次執行進度或專案慣例），內容過長，超出 prompt 預算。
+請將它濃縮為不超過 %d 個字元的摘要：
+- 保留最新、優先級最高的需求與限制條件（檔名、API、錯誤訊息等具體細節要原樣保留）
+- 較舊的背景說明、次要段落與重複內容可以摘要或省略
+- 不要加入原文沒有的需求
+- 只輸出摘要本身，不要任何前言或說明
+
+內容：
+%s`
+	AgentCompressNote = "\n\n(註：此段內容因超出 prompt 預算已自動摘要；驗收標準為原文)"
 
 	// Analyze agent prompt
 	AgentAnalyzeIntro       = "你是一個程式碼分析專家。請分析當前專案的程式碼，找出可改進的地方。\n\n"
 	AgentAnalyzeProjectDir  = "專案目錄: %s\n\n"
+	AgentAnalyzeFilesOnly   = "只分析以下變更的檔案（其他檔案僅在理解這些變更所需時參考，不要回報其問題）：\n"
 	AgentAnalyzeAspects     = "請分析以下方面：\n"
+	AgentAnalyzeFollowUp    = "這是針對先前分析結果的深入分析，請比一般分析更徹底地檢查以下目錄（未列出目錄時為整個專案）：\n"
+	AgentAnalyzeKnownIssues = "以下問題已經回報過，不要重複回報：\n"
 	AgentAnalyzePerf        = "- **效能問題**: N+1 查詢、不必要的迴圈、記憶體浪費等\n"
 	AgentAnalyzeRefactor    = "- **重構建議**: 過長的方法、重複程式碼、缺少抽象等\n"
 	AgentAnalyzeSecurity    = "- **安全性問題**: 硬編碼密碼、SQL 注入、XSS 等\n"
@@ -478,32 +514,6 @@ const (
 
 請將結果寫入 .tickets/analysis-result.json`
 
-	// Planning agent prompt
-	AgentPlanningPromptTemplate = `你是一個專案規劃 Agent。請分析 milestone 文件並產生 tickets。
-
-請讀取檔案 %s 的內容，然後產生 JSON 格式的 tickets 列表。
-
-每個 ticket 包含:
-- id: 唯一識別碼 (格式: TICKET-xxx-描述)
-- title: 簡短標題
-- description: 詳細描述
-- type: 類型 (feature/test/refactor/docs/bugfix/performance/security)
-- priority: 優先級 (1-5, 1最高)
-- estimated_complexity: 複雜度 (low/medium/high)
-- dependencies: 依賴的其他 ticket ID 列表
-- acceptance_criteria: 驗收標準列表
-- files_to_create: 需要建立的檔案
-- files_to_modify: 需要修改的檔案
-
-請確保：
-1. Tickets 之間的依賴關係正確
-2. 每個 ticket 都是獨立可完成的工作單元
-3. 複雜的任務要拆分成多個小 tickets
-4. 按照優先級排序
-
-請將結果以 JSON 格式寫入檔案: %s
-格式為: {"tickets": [...]}`
-
 	// Enhance agent prompt
 	AgentEnhanceIntro     = "你是一個專案分析專家。請根據以下 ticket 資訊和專案結構，補充更詳細的實作細節。\n\n"
 	AgentEnhanceProjectDir = "專案目錄: %s\n\n"
@@ -544,25 +554,27 @@ const (
 )
 
 // Agent error messages (coding, planning, enhance, init)
-const (
-	ErrAgentMkdirOutput   = "無法建立輸出目錄: %w"
-	ErrAgentMkdirDocs     = "無法建立文件目錄: %w"
-	ErrAgentAnalyzeFailed = "分析失敗: %w"
-	ErrAgentAnalyzeOutput = "分析失敗: %s"
-	ErrAgentInvalidIssues = "無效的 issues 格式"
-	ErrAgentReadMilestone = "無法讀取 milestone 檔案: %w"
-	ErrAgentPlanningFailed = "規劃失敗: %w"
-	ErrAgentPlanningOutput = "規劃失敗: %s"
-	ErrAgentInvalidTickets = "無效的 tickets 格式"
-	ErrAgentEnhanceFailed  = "AI 預處理失敗: %w"
-	ErrAgentEnhanceOutput  = "AI 預處理失敗: %s"
-	ErrAgentScanFailed     = "掃描專案失敗: %w"
-	ErrAgentWriteMilestone = "無法寫入 milestone 檔案: %w"
-	ErrAgentCreateMilestone = "產生 milestone 失敗: %s"
+var (
+	ErrAgentMkdirOutput      = "無法建立輸出目錄: %w"
+	ErrAgentMkdirDocs        = "無法建立文件目錄: %w"
+	ErrAgentAnalyzeFailed    = "分析失敗: %w"
+	ErrAgentAnalyzeOutput    = "分析失敗: %s"
+	ErrAgentInvalidIssues    = "無效的 issues 格式"
+	ErrAgentReadMilestone    = "無法讀取 milestone 檔案: %w"
+	ErrAgentPlanningFailed   = "規劃失敗: %w"
+	ErrAgentPlanningOutput   = "規劃失敗: %s"
+	ErrAgentInvalidTickets   = "無效的 tickets 格式"
+	ErrAgentEnhanceFailed    = "AI 預處理失敗: %w"
+	ErrAgentEnhanceOutput    = "AI 預處理失敗: %s"
+	ErrAgentScanFailed       = "掃描專案失敗: %w"
+	ErrAgentWriteMilestone   = "無法寫入 milestone 檔案: %w"
+	ErrAgentCreateMilestone  = "產生 milestone 失敗: %s"
+	ErrAgentAcceptanceFailed = "驗收審查失敗: %w"
+	ErrAgentAcceptanceOutput = "驗收審查失敗: %s"
 )
 
 // Error messages for the errors package
-const (
+var (
 	// Error operation names
 	ErrOpAgent    = "agent"
 	ErrOpFile     = "file"
@@ -582,3 +594,1529 @@ const (
 	ErrMsgPlanningFailed    = "planning failed"
 	ErrMsgStoreInit         = "failed to initialize store"
 )
+
+// Self-update (version --check, self-update)
+var (
+	CmdSelfUpdateShort = "更新至最新版本"
+	CmdSelfUpdateLong  = `從 release 端點下載最新版本，驗證簽章與 SHA-256 checksum 後取代目前執行檔。
+
+Release 端點由設定 update_release_url 決定（預設為 GitHub Releases），只能以環境變數或全域設定檔設定。
+每個 release 必須附上 checksums.txt 與其簽章 checksums.txt.sig，簽章無法以內建公鑰驗證或 checksum 不符時不會更新。
+
+範例:
+  agent-orchestrator version --check   # 只檢查是否有新版本
+  agent-orchestrator self-update       # 下載並安裝最新版本
+  agent-orchestrator self-update --force  # 即使版本相同或為 dev 版也重新安裝`
+
+	FlagVersionCheck    = "檢查是否有更新的 release"
+	FlagSelfUpdateForce = "即使已是最新版本或為開發版本也強制更新"
+
+	MsgUpdateChecking    = "檢查最新版本..."
+	MsgUpdateAvailable   = "有新版本可用: %s（目前 %s）"
+	MsgUpdateUpToDate    = "已是最新版本 (%s)"
+	MsgUpdateDevBuild    = "目前為開發版本 (%s)，最新 release 為 %s；如需安裝請使用 self-update --force"
+	MsgUpdateDownloading = "下載 %s..."
+	MsgUpdateInstalled   = "已更新至 %s: %s"
+	HintSelfUpdate       = "執行 'agent-orchestrator self-update' 更新"
+
+	ErrUpdateCheckFailed = "檢查更新失敗: %w"
+	ErrUpdateFailed      = "更新失敗: %w"
+)
+
+// Store management (store migrate, store reindex)
+var (
+	CmdStoreShort        = "Ticket store 管理"
+	CmdStoreLong         = `管理 ticket store（儲存後端）。`
+	CmdStoreMigrateShort = "在 store 後端之間遷移 tickets"
+	CmdStoreMigrateLong  = `將所有 tickets 與 metrics 歷史從一個 store 複製到另一個，並驗證數量與 checksum。
+
+來源 store 不會被修改；驗證失敗時會自動刪除已寫入目的地的資料（rollback）。
+遷移完成後，請將設定中的 store_backend、tickets_dir 等改為新位置。
+
+範例:
+  agent-orchestrator store migrate --from file --to sqlite
+  agent-orchestrator store migrate --from file --to file --to-path .tickets-new`
+	CmdStoreReindexShort = "重建 ticket 索引"
+	CmdStoreReindexLong  = `從 ticket 檔案重建 file store 的索引（tickets_dir/index.json）。
+
+索引記錄每個 ticket 的狀態、路徑與優先順序，讓讀取單一 ticket 與統計數量不必掃描各狀態目錄；
+Save 與 Delete 會同步更新。手動搬移、編輯或刪除 ticket 檔案後索引會視為過期而改回掃描目錄，
+下次 status 等讀取全部 tickets 的指令會自動重建；也可執行此指令立即重建。
+
+範例:
+  agent-orchestrator store reindex`
+
+	FlagStoreFrom     = "來源 store 後端 (file, sqlite)"
+	FlagStoreTo       = "目的 store 後端 (file, sqlite)"
+	FlagStoreFromPath = "來源位置：file 為目錄、sqlite 為資料庫檔 (預設: tickets_dir 或 tickets_dir/tickets.db)"
+	FlagStoreToPath   = "目的位置：file 為目錄、sqlite 為資料庫檔 (預設: tickets_dir 或 tickets_dir/tickets.db)"
+
+	UIStoreMigrate               = "Store 遷移"
+	MsgStoreMigrateFromTo        = "來源: %s (%s) → 目的: %s (%s)"
+	MsgStoreMigrateDryRun        = "[DRY RUN] 將遷移 %d 個 tickets，未寫入任何資料"
+	MsgStoreMigrateDone          = "已遷移並驗證 %d 個 tickets (checksum %s)"
+	MsgStoreMigrateMetricsCopied = "已複製 metrics 歷史"
+	MsgStoreMigrateRolledBack    = "驗證失敗，已刪除寫入目的地的資料 (rollback)"
+	MsgStoreMigrateSourceKept    = "來源 store 未被修改；確認無誤後再更新設定並自行刪除舊資料"
+	MsgStoreReindexed            = "已重建索引: %d 個 tickets"
+	MsgStoreReindexNotNeeded     = "%s 後端不使用索引檔，不需要重建"
+
+	ErrStoreUnknownBackend    = "不支援的 store 後端 %q (支援: %v)"
+	ErrStoreMigrateSame       = "來源與目的 store 相同"
+	ErrStoreMigrateFailed     = "store 遷移失敗: %w"
+	ErrStoreMigrateFileExists = "目的檔案已存在且內容不同: %s"
+	ErrStoreMigrateVerifyFile = "複製後驗證失敗: %s"
+)
+
+// Agent call auditing (audit)
+var (
+	CmdAuditShort = "列出 agent 呼叫稽核紀錄"
+	CmdAuditLong  = `列出指定期間內的 agent 呼叫：操作者（設定的 audit_identity 或 OS 使用者）、結果、耗時、
+設定快照雜湊與觸發的指令列。
+
+每次 agent 呼叫都會追加一筆紀錄至 .tickets/audit.jsonl。
+
+範例:
+  agent-orchestrator audit                       # 最近 7 天
+  agent-orchestrator audit --since 24h
+  agent-orchestrator audit --since 2026-01-01 --until 2026-02-01 --user alice`
+
+	FlagAuditSince = "起始時間：時間長度 (24h、7d)、日期 (2006-01-02) 或 RFC 3339"
+	FlagAuditUntil = "結束時間（不含），格式同 --since；未設則至今"
+	FlagAuditUser  = "只列出此操作者（OS 使用者或 audit_identity）"
+
+	UIAudit            = "Agent 呼叫稽核"
+	TableAuditTime     = "時間"
+	TableAuditOperator = "操作者"
+	TableAuditResult   = "結果"
+	TableAuditDuration = "耗時"
+	TableAuditConfig   = "設定"
+	TableAuditCommand  = "指令"
+	MsgAuditNoEntries  = "此期間沒有 agent 呼叫紀錄"
+	MsgAuditSummary    = "共 %d 次 agent 呼叫，%d 位操作者，%d 次失敗"
+)
+
+// Stopping background work (work stop)
+var (
+	CmdWorkStopShort = "停止背景執行中的 work"
+	CmdWorkStopLong  = `停止以 work --detach 啟動的背景 work。
+
+讀取 work PID 檔並送出 SIGTERM，等待程序優雅結束；處理中被中斷的 tickets 會回到 pending，
+最後移除 PID 檔。若逾時仍未結束，可加 --force 強制終止。
+
+範例:
+  agent-orchestrator work stop
+  agent-orchestrator work stop --timeout 60 --force`
+
+	FlagWorkStopTimeout = "等待背景程序結束的秒數"
+	FlagWorkStopForce   = "逾時後強制終止 (SIGKILL)"
+
+	MsgWorkStopNotRunning = "沒有背景執行中的 work"
+	MsgWorkStopStale      = "PID 檔記錄的程序 (PID %d) 已不存在，清除 PID 檔"
+	MsgWorkStopSignal     = "送出停止信號至背景 work (PID %d)，等待結束..."
+	MsgWorkStopKill       = "背景 work (PID %d) 未在時限內結束，強制終止"
+	MsgWorkStopped        = "背景 work (PID %d) 已停止"
+	MsgWorkStopRequeued   = "已將 %d 個處理中的 tickets 移回 pending"
+	MsgTicketRequeued     = "%s 已中斷，移回 pending"
+
+	ErrWorkStopSignal  = "無法停止背景 work (PID %d): %w"
+	ErrWorkStopTimeout = "背景 work (PID %d) 未在 %d 秒內結束；可使用 --force 強制終止"
+	ErrWorkStopRequeue = "將處理中 tickets 移回 pending 失敗: %w"
+)
+
+// Git hooks (hooks install/uninstall) and analyze --changed/--fail-on
+var (
+	CmdHooksShort = "安裝或移除 git hooks"
+	CmdHooksLong  = `安裝選用的 git hooks，將 orchestrator 整合進日常 git 流程：
+
+  pre-push    執行 analyze --changed --fail-on HIGH，有高嚴重度問題時阻擋 push
+  commit-msg  檢查 commit 訊息是否引用既有的 ticket ID（如 TICKET-001）
+  post-merge  merge 後提醒尚未處理的 tickets
+
+已存在且非本工具安裝的 hook 會被略過；加 --force 時會先備份為 <hook>.pre-agent-orchestrator，
+uninstall 時還原。臨時略過檢查可使用 git push --no-verify / git commit --no-verify。
+
+範例:
+  agent-orchestrator hooks install
+  agent-orchestrator hooks install --hooks pre-push --fail-on MED
+  agent-orchestrator hooks uninstall`
+	CmdHooksInstallShort   = "安裝 git hooks"
+	CmdHooksUninstallShort = "移除本工具安裝的 git hooks 並還原備份"
+
+	FlagHooksSelected  = "要處理的 hooks (逗號分隔): pre-push, commit-msg, post-merge"
+	FlagHooksForce     = "覆蓋既有 hook（原檔備份為 <hook>.pre-agent-orchestrator）"
+	FlagHooksFailOn    = "pre-push 阻擋的最低嚴重度: HIGH, MED, LOW"
+	FlagAnalyzeChanged = "只分析工作目錄與尚未 push 的 commits 中變更的檔案"
+	FlagAnalyzeFailOn  = "發現此嚴重度以上的問題時以錯誤結束: HIGH, MED, LOW"
+
+	MsgHookInstalled         = "已安裝 %s hook"
+	MsgHookRemoved           = "已移除 %s hook"
+	MsgHookRestored          = "已還原原本的 %s hook"
+	MsgHookBackedUp          = "既有的 %s hook 已備份為 %s"
+	MsgHookExists            = "%s hook 已存在且非本工具安裝，略過（可加 --force 覆蓋並備份）"
+	MsgHookNotOurs           = "%s hook 非本工具安裝，保留不動"
+	MsgHookPendingReminder   = "尚有 %d 個 pending、%d 個 in_progress、%d 個 failed tickets"
+	MsgAnalyzeNoChangedFiles = "沒有變更的檔案，略過分析"
+	MsgAnalyzeChangedFiles   = "只分析 %d 個變更的檔案"
+
+	ErrHooksNotGitRepo        = "%s 不是 git repository"
+	ErrHooksUnknown           = "未知的 hook: %s (可用: %s)"
+	ErrHooksWrite             = "寫入 hook %s 失敗: %w"
+	ErrInvalidSeverity        = "無效的嚴重度: %s (可用: HIGH, MED, LOW)"
+	ErrAnalyzeFailOn          = "發現 %d 個 %s 以上的問題"
+	ErrCommitMsgNoTicket      = "commit 訊息未引用 ticket ID（如 TICKET-001）；略過檢查請使用 git commit --no-verify"
+	ErrCommitMsgUnknownTicket = "commit 訊息引用的 ticket 不存在: %s"
+)
+
+// Viewing work logs (logs)
+var (
+	CmdLogsShort = "顯示背景 work 的日誌"
+	CmdLogsLong  = `顯示最近一次 work --detach 的日誌；加 --follow 持續輸出新內容，直到背景 work 結束或按 Ctrl+C。
+
+指定 --ticket 時，若該 ticket 失敗時記錄了 agent 日誌則顯示該檔，
+否則只顯示 detach 日誌中提到該 ticket 的行。
+
+範例:
+  agent-orchestrator logs
+  agent-orchestrator logs --follow
+  agent-orchestrator logs --ticket TICKET-001`
+
+	FlagLogsFollow = "持續輸出新寫入的內容（類似 tail -f）"
+	FlagLogsTicket = "只顯示指定 ticket 的日誌"
+
+	MsgLogsFile = "日誌: %s"
+
+	ErrLogsNotFound = "在 %s 找不到 work 日誌；請先執行 work --detach"
+	ErrLogsOpen     = "無法開啟日誌 %s: %w"
+)
+
+// Hourly token/cost budget (budget_tokens_per_hour, budget_cost_per_hour)
+var (
+	MsgBudgetPaused = "已達每小時 token/費用預算，%s 暫停派發，約 %s 後繼續"
+)
+
+// Recurring tickets (add --recur, recurring)
+var (
+	CmdRecurringShort = "管理週期性 tickets"
+	CmdRecurringLong  = `管理週期性 ticket 範本（如每週更新依賴）。
+
+以 add --recur "<cron>" 建立範本；範本不會被 work 執行，而是由 recurring run
+依排程建立新的 pending ticket（ID 為 <範本 ID>-<排程時間>，recurring_from 指回範本）。
+前一個實例仍為 pending 或 in_progress 時，該次排程會略過，不會堆積。
+
+排程格式為五欄 cron（分 時 日 月 星期，星期 0 為週日）或 @hourly、@daily、@weekly、@monthly。
+
+範例:
+  agent-orchestrator add --title "更新依賴套件" --type refactor --recur "0 9 * * 1"
+  agent-orchestrator recurring list
+  agent-orchestrator recurring run --watch
+  agent-orchestrator recurring remove RECUR-1700000000000`
+	CmdRecurringListShort   = "列出週期性 ticket 範本與已建立的實例"
+	CmdRecurringRemoveShort = "移除週期性 ticket 範本（已建立的實例保留）"
+	CmdRecurringRunShort    = "依排程建立到期的週期性 tickets"
+	CmdRecurringRunLong     = `為到期的週期性範本建立 pending tickets。錯過多次排程時只建立一張（最近一次）。
+
+加 --watch 時常駐執行，每 --interval 檢查一次，直到按 Ctrl+C；
+背景 work 執行中時該次檢查會略過，待下次再建立。`
+
+	FlagRecur             = "建立週期性範本而非一般 ticket，值為 cron 排程（如 \"0 9 * * 1\" 或 @weekly）"
+	FlagRecurringWatch    = "常駐執行並定期檢查排程"
+	FlagRecurringInterval = "--watch 時的檢查間隔"
+
+	UIRecurringTickets = "週期性 Tickets"
+
+	MsgRecurringAdded      = "已新增週期性 ticket: %s（排程 %s）"
+	MsgRecurringRemoved    = "已移除週期性 ticket: %s"
+	MsgRecurringNone       = "沒有週期性 tickets；可用 add --recur 建立"
+	MsgRecurringNext       = "下次排程: %s"
+	MsgRecurringCreated    = "已建立 ticket %s（來自週期性 ticket %s）"
+	MsgRecurringNothingDue = "沒有到期的週期性 tickets"
+	MsgRecurringWatching   = "每 %s 檢查週期性 tickets 排程，按 Ctrl+C 結束"
+	MsgRecurringFrom       = "週期性來源: %s"
+
+	ErrRecurringNotFound = "找不到週期性 ticket: %s"
+	ErrRecurringInterval = "無效的檢查間隔: %s"
+)
+
+// Ticket labels (add/edit --label, status/work/drop --label)
+var (
+	FlagLabel       = "標籤 (逗號分隔或重複指定，如 --label backend,api)"
+	FlagEditLabel   = "以指定標籤取代原有標籤 (逗號分隔或重複指定)"
+	FlagLabelFilter = "只顯示帶有指定標籤的 tickets (多個標籤須同時具備)"
+	FlagWorkLabel   = "只處理帶有指定標籤的 tickets (多個標籤須同時具備)"
+	FlagDropLabel   = "刪除所有帶有指定標籤的 tickets (取代 ticket-id)"
+
+	PromptConfirmDropLabel = "確定要刪除這 %d 個帶有標籤 %s 的 tickets 嗎？"
+
+	MsgLabels                = "標籤: %s"
+	MsgNoTicketsWithLabels   = "沒有帶有標籤 %s 的 tickets"
+	MsgTicketsDroppedByLabel = "已刪除 %d 個 tickets"
+
+	ErrDropIDAndLabel = "ticket-id、--label 與 --filter 只能擇一指定"
+)
+
+// Analyze scope expansion (analyze --auto-expand/--expand-depth)
+var (
+	FlagAnalyzeAutoExpand  = "不詢問，直接執行依分析結果建議的深入分析"
+	FlagAnalyzeExpandDepth = "深入分析的最大輪數（0 停用建議）"
+
+	UIAnalyzeExpand = "建議的深入分析"
+
+	PromptAnalyzeExpand = "執行深入分析 %s？"
+
+	SpinnerAnalyzeExpand = "深入分析 %s 中..."
+
+	MsgAnalyzeExpandSuggestion   = "%s（%d 個問題，%d 個 HIGH）: %s"
+	MsgAnalyzeExpandWholeProject = "整個專案"
+	MsgAnalyzeExpandFound        = "深入分析完成，新增 %d 個問題"
+
+	HintAnalyzeAutoExpand = "使用 analyze --auto-expand 執行以上深入分析"
+
+	ErrAnalyzeExpandDepth = "無效的 --expand-depth: %d（須 >= 0）"
+)
+
+// Epics and sub-tickets (ParentID, plan --epics)
+var (
+	FlagParent     = "所屬 epic 的 ticket ID"
+	FlagEditParent = "變更所屬 epic 的 ticket ID（none 表示移除）"
+	FlagPlanEpics  = "為 milestone 的每個階段產生一個 epic，其餘 tickets 歸屬其下"
+
+	UIEpics = "Epics"
+
+	MsgParent            = "所屬 epic: %s"
+	MsgEpicProgress      = "%s: %s (%d/%d 完成)"
+	MsgEpicCompleted     = "Epic %s 的子 tickets 皆已完成，標記為 completed"
+	MsgEpicCannotProcess = "%s 是 epic，不會直接處理；epic 會在所有子 tickets 完成後自動完成"
+)
+
+// Issue tracker import (import github)
+var (
+	CmdImportShort       = "從外部 issue tracker 匯入 tickets"
+	CmdImportLong        = `從外部 issue tracker 匯入 open issues 作為 pending tickets。已存在的 tickets（相同 ID）會略過，可重複執行以同步新 issues。`
+	CmdImportGithubShort = "從 GitHub Issues 匯入 tickets"
+	CmdImportGithubLong  = `從 GitHub repo 匯入 open issues（不含 pull requests）作為 pending tickets，ID 為 GH-<issue 編號>。
+
+對應方式：
+  - bug、documentation、security 等標籤（含 "type: bug"、"kind/bug" 形式）決定 ticket 類型，其餘標籤保留為 ticket 標籤
+  - milestone 依到期日排序，第一個為優先級 1、其次為 2...；每個 milestone 的 tickets 依賴前一個 milestone 的 tickets
+  - issue 內容中未勾選的 task list（- [ ] ...）作為驗收條件
+
+Token 來自設定 github_token 或環境變數 GITHUB_TOKEN。
+
+範例:
+  agent-orchestrator import github octo/widgets
+  agent-orchestrator import github https://github.com/octo/widgets --label backend`
+
+	FlagImportLabel = "只匯入帶有這些標籤的 issues（可用逗號分隔多個，須同時具備）"
+
+	MsgImportFetching = "正在取得 %s 的 open issues..."
+	MsgImportNone     = "沒有可匯入的 issues"
+	MsgImportCreated  = "已匯入: %s - %s"
+	MsgImportWouldAdd = "[dry-run] 將匯入: %s - %s"
+	MsgImportSummary  = "匯入完成：新增 %d 個，略過已存在 %d 個"
+
+	ErrImportFailed = "匯入失敗: %w"
+)
+
+// Ticket traceability (milestone → ticket → commit → PR)
+var (
+	CmdTraceShort = "查詢 milestone → ticket → commit → PR 的對應"
+	CmdTraceLong  = `顯示 ticket 的來源 milestone、為其建立的 commits 與所屬 pull request。
+
+參數可以是 ticket ID、PR 編號或 URL、或 commit SHA（至少 7 碼），會找出對應的 tickets。
+plan 會記錄 tickets 的來源 milestone，commit 會記錄每次提交的 SHA，PR 可用 edit --pr 連結。
+
+範例:
+  agent-orchestrator trace TICKET-001
+  agent-orchestrator trace 42
+  agent-orchestrator trace https://github.com/octo/widgets/pull/42
+  agent-orchestrator trace 3f2c1ab`
+
+	FlagEditPR           = "連結 pull request（編號或 URL；none 表示移除）"
+	FlagWorkResumeFromPR = "重新開啟並處理連結到此 pull request（編號或 URL）的 tickets"
+
+	MsgTraceMilestone   = "Milestone: %s"
+	MsgTraceTicket      = "Ticket: %s - %s (%s)"
+	MsgTraceCommits     = "Commits: %s"
+	MsgTracePR          = "PR: %s"
+	MsgWorkResumeFromPR = "依 PR %[2]s 重新開啟 %[1]s"
+
+	ErrTraceNotFound      = "找不到與 %s 對應的 ticket"
+	ErrWorkResumePRWithID = "--resume-from-pr 不可與 ticket ID 同時使用"
+)
+
+// Work queueing (work --queue)
+var (
+	FlagWorkQueue = "背景 work 執行中時，將此次請求排入佇列，由背景 work 在目前批次完成後接著執行"
+
+	UIWorkQueue = "排隊中的 work 請求 (%d)"
+
+	MsgWorkQueued        = "背景 work 執行中，已排入佇列: %s (%s)"
+	MsgWorkQueueStarting = "執行排隊中的請求 %s: %s"
+
+	HintWorkQueueStatus = "使用 'agent-orchestrator status' 查看佇列"
+
+	ErrWorkQueuedFailed = "排隊中的請求 %s 執行失敗: %v"
+)
+
+// Issue tracker sync (RemoteRef, github_sync)
+var (
+	GitHubCommentCompleted = "✅ Ticket %s 已由 agent-orchestrator 完成。\n\nAgent 輸出：\n%s"
+	GitHubCommentFailed    = "❌ Ticket %s 處理失敗。\n\n錯誤：\n%s\n\n重試：\n```\n%s\n```"
+	GitHubCommentCommitted = "📝 Commit %s（ticket %s）"
+
+	MsgSyncNoToken = "%s 未同步到 %s：未設定 github_token 或 GITHUB_TOKEN"
+	MsgSyncFailed  = "%s 同步到 %s 失敗: %v"
+)
+
+// Acceptance assertions (executable acceptance criteria)
+var (
+	FlagAssert = "可執行的驗收檢查指令（可重複；完成 coding 後於專案根目錄執行，需以 exit code 0 結束）"
+
+	UIAssertions = "驗收檢查:"
+
+	MsgAssertionPassed = "驗收檢查通過: %s"
+	MsgAssertionFailed = "驗收檢查失敗: %s (%s)"
+
+	ErrAssertionsFailed = "驗收檢查未通過 (%d/%d): %s"
+)
+
+// Jira import (import jira)
+var (
+	CmdImportJiraShort = "以 JQL 從 Jira 匯入 tickets"
+	CmdImportJiraLong  = `以 JQL 查詢 Jira issues 並匯入為 pending tickets，ID 為 issue key（例如 PROJ-123）。
+
+對應方式：
+  - summary、description 作為標題與描述，描述末尾附上 issue 連結
+  - 優先級與 issue 類型依 jira.priority_map、jira.type_map 對應（未設定者使用內建對應）
+  - labels 保留為 ticket 標籤
+  - 「is blocked by」等 jira.dependency_links 類型的 issue links 作為依賴（已完成且未一併匯入的 issue 除外）
+
+連線設定來自 .agent-orchestrator.yaml 的 jira: 區段；token 未設定時使用環境變數 JIRA_API_TOKEN。
+
+範例:
+  agent-orchestrator import jira --jql "project = PROJ AND sprint in openSprints()"
+  agent-orchestrator import jira --jql "labels = agent AND statusCategory != Done"`
+
+	FlagImportJQL = "要匯入之 issues 的 JQL 查詢（必填）"
+
+	MsgImportJiraFetching = "正在以 JQL 取得 Jira issues: %s"
+
+	ErrJiraURLRequired = "未設定 jira.url，請在 .agent-orchestrator.yaml 的 jira: 區段設定 Jira 網址"
+)
+
+// Branch per ticket (git_branch_per_ticket)
+var (
+	MsgTicketBranch              = "分支: %s"
+	MsgCommitOnBranch            = "於分支 %s 提交"
+	MsgBranchPerTicketSequential = "git_branch_per_ticket 啟用時各 ticket 共用同一個工作目錄，改為逐一處理（原並行數 %d）"
+
+	ErrTicketBranch = "無法切換 %s 的分支: %v"
+)
+
+// Git worktrees (git_worktrees)
+var (
+	ErrWorktreeCreate = "無法為 %s 建立 git worktree: %v"
+	ErrWorktreeMerge  = "無法將 worktree %s 的變更套用回專案（已保留 worktree 供手動處理）: %v"
+)
+
+// Project-type plugins (internal/project)
+var (
+	ProjectHintGoErrors      = "錯誤以 error 回傳並用 fmt.Errorf(\"...: %w\", err) 包裝，不要 panic"
+	ProjectHintGoTests       = "測試放在同目錄的 _test.go，優先使用 table-driven tests"
+	ProjectHintNodeLockfile  = "依賴變更需同步更新 package.json 與 lock 檔"
+	ProjectHintNodeModules   = "沿用專案既有的模組格式（ESM 或 CommonJS）與 TypeScript 設定"
+	ProjectHintPythonStyle   = "遵循 PEP 8，公開函式加上 type hints"
+	ProjectHintPythonTests   = "測試使用 pytest，放在 tests/ 或 test_*.py"
+	ProjectHintJavaLayout    = "遵循既有的套件結構（src/main/java、src/test/java）"
+	ProjectHintJavaTests     = "測試使用專案既有的框架（JUnit 等）"
+
+	AgentProjectCommands      = "%s（%s）"
+	AgentProjectTest          = "測試: %s"
+	AgentProjectBuild         = "建置: %s"
+	AgentProjectFormat        = "格式化: %s"
+	AgentTestProjectUnknown   = "無法自動判斷專案類型，常見的測試指令:\n"
+	AgentTestProjectDetected  = "偵測到的專案類型與測試指令:\n"
+)
+
+// Analysis score trend (report quality)
+var (
+	CmdReportShort = "產生專案報告"
+	CmdReportLong  = `產生由歷史紀錄彙整的專案報告。`
+
+	CmdReportQualityShort = "顯示分析分數（技術債）趨勢"
+	CmdReportQualityLong  = `列出每次 analyze 的技術債分數與變化趨勢。
+
+分數依問題嚴重度加權：HIGH 10 分、MED 3 分、LOW 1 分，越低越好。
+每次完整的 analyze（不含 --changed 與 dry-run）都會記錄一筆；只有相同 --scope 的分析可互相比較，
+因此報告只列出與最近一次分析相同範圍的紀錄。
+
+範例:
+  agent-orchestrator report quality
+  agent-orchestrator report quality --limit 30`
+
+	FlagReportQualityLimit = "最多顯示最近幾次分析（0 表示全部）"
+
+	UIQualityReport     = "程式碼品質趨勢"
+	TableQualityTime    = "時間"
+	TableQualityScore   = "分數"
+	TableQualityChange  = "變化"
+	TableQualityIssues  = "問題數"
+	MsgQualityNoHistory = "尚無分析紀錄，請先執行 analyze"
+	MsgQualityScope     = "範圍: %s"
+	MsgQualityTrend     = "趨勢: %s  %d → %d（%s）"
+	MsgQualityImproving = "改善中"
+	MsgQualityDegrading = "惡化中"
+	MsgQualityStable    = "持平"
+
+	MsgQualityScore      = "技術債分數: %d（HIGH %d、MED %d、LOW %d）"
+	MsgQualityScoreDelta = "（上次 %d，%s）"
+	MsgStatsQuality      = "  技術債趨勢: %s %d"
+)
+
+// Pull requests (pr)
+var (
+	CmdPRShort = "為已完成的 tickets 推送分支並建立 pull request"
+	CmdPRLong  = `推送 ticket 的分支（git_branch_per_ticket 建立的 ticket/<id>-<標題>）並建立 pull request，
+標題與內容由 ticket 的描述與驗收標準產生，PR 連結會記錄在 ticket 上（status、trace 可查看）。
+
+origin 為 GitHub 時建立 pull request（token: github_token 或 GITHUB_TOKEN），
+為 GitLab 時建立 merge request（token: gitlab_token 或 GITLAB_TOKEN）。
+目標分支依序為 --base、pr_base_branch、repo 的預設分支。
+
+範例:
+  agent-orchestrator pr TICKET-001
+  agent-orchestrator pr --all
+  agent-orchestrator pr --all --base develop`
+
+	FlagPRAll  = "為所有已完成、有分支且尚未建立 PR 的 tickets 建立 PR"
+	FlagPRBase = "PR 的目標分支（預設為 pr_base_branch 或 repo 預設分支）"
+
+	UIPullRequests   = "建立 Pull Requests"
+	MsgPRCreated     = "%s: 已建立 PR %s"
+	MsgPRExists      = "%s 已有 PR %s"
+	MsgPRNone        = "沒有需要建立 PR 的 tickets（需已完成、有分支且尚未建立 PR）"
+	MsgPRDryRun      = "[dry-run] 將推送 %s 並建立 PR 至 %s: %s"
+	MsgPRDefaultBase = "預設分支"
+
+	PRBodyAcceptance = "## 驗收標準\n"
+	PRBodyTicket     = "Ticket: %s\n"
+
+	ErrPRTicketRequired = "請指定 ticket ID，或使用 --all"
+	ErrPRNotCompleted   = "%s 尚未完成（狀態: %s），無法建立 PR"
+	ErrPRNoBranch       = "%s 沒有專屬分支，無法建立 PR；請啟用 git_branch_per_ticket 後處理，或以 edit --pr 手動連結"
+	ErrPRNoToken        = "未設定 %s token，請設定 %s 或環境變數 %s"
+	ErrPRFailed         = "無法為 %s 建立 PR: %v"
+	ErrPRDefaultBranch  = "無法取得 repo 的預設分支（可用 --base 或 pr_base_branch 指定）: %v"
+	ErrPRSomeFailed     = "%d/%d 個 PR 建立失敗"
+)
+
+// Status time travel (status --as-of)
+var (
+	FlagStatusAsOf     = "顯示指定時間點的 ticket 狀態（如 \"2024-06-01 12:00\"、2024-06-01、24h、7d）"
+	UITicketStatusAsOf = "Tickets 狀態（截至 %s）"
+	MsgStatusAsOfNote  = "依 metrics 歷史與 ticket 時間戳重建；之後建立的 tickets 不列入，已刪除的 tickets 無法顯示"
+)
+
+// Dependency scan (deps scan)
+var (
+	CmdDepsShort     = "依賴相關指令（套件掃描、ticket 相依循環）"
+	CmdDepsLong      = `掃描專案的依賴套件並為需要升級的套件建立 tickets（scan），或修復 tickets 之間的相依循環（fix-cycles）。`
+	CmdDepsScanShort = "掃描過期或有漏洞的依賴並建立 tickets"
+	CmdDepsScanLong  = `依專案根目錄的標記檔執行對應的依賴掃描工具，為過期或有漏洞的依賴建立 pending tickets：
+  - Go（go.mod）: go list -m -u -json all（僅直接依賴）
+  - npm（package.json）: npm outdated --json 與 npm audit --json
+  - pip（pyproject.toml、setup.py、requirements.txt）: pip list --outdated --format=json
+
+有漏洞的依賴建立 security ticket（依嚴重度決定優先級），僅過期的依賴建立 refactor ticket（大版本升級優先級較高）。
+描述包含目前與目標版本、漏洞公告與升級指令。ticket ID 為 DEPS-<生態系>-<套件>@<目標版本>，
+已存在相同 ID 或同一套件尚未完成的 ticket 時會略過，可定期重複執行。
+
+範例:
+  agent-orchestrator deps scan
+  agent-orchestrator deps scan --ecosystem go
+  agent-orchestrator deps scan --dry-run`
+
+	FlagDepsEcosystem = "只掃描指定的生態系（go、npm、pip，可重複）"
+
+	UIDepsScan         = "依賴掃描"
+	MsgDepsScanning    = "執行 %s 依賴掃描..."
+	MsgDepsNoEcosystem = "未偵測到支援的依賴管理檔（go.mod、package.json、pyproject.toml、requirements.txt 等）"
+	MsgDepsScanFailed  = "%s 依賴掃描失敗: %v"
+	MsgDepsNone        = "所有依賴皆為最新，沒有需要建立的 tickets"
+	MsgDepsCreated     = "已建立 %s: %s"
+	MsgDepsWouldAdd    = "[dry-run] 將建立 %s: %s"
+	MsgDepsSkipped     = "%s 已有 ticket %s，略過"
+	MsgDepsSummary     = "建立 %d 張 tickets，略過 %d 個已追蹤的依賴"
+	ErrDepsEcosystem   = "不支援的生態系 %q（可用: %s）"
+	ErrDepsAllFailed   = "所有依賴掃描皆失敗"
+
+	DepsLatestVersion       = "最新版本"
+	DepsTicketTitle         = "升級 %s 至 %s"
+	DepsTicketTitleSecurity = "修補 %s 的安全漏洞（升級至 %s）"
+	DepsDescEcosystem       = "生態系: %s\n"
+	DepsDescPackage         = "套件: %s\n"
+	DepsDescCurrent         = "目前版本: %s\n"
+	DepsDescTarget          = "目標版本: %s\n"
+	DepsDescSeverity        = "漏洞嚴重度: %s\n"
+	DepsDescAdvisories      = "漏洞公告:\n"
+	DepsDescCommand         = "\n升級指令: %s\n"
+	DepsCriterionUpgraded   = "%s 已升級至 %s，並更新相關的 lock 檔"
+	DepsCriterionTests      = "升級後建置與既有測試皆通過，必要時調整受 API 變更影響的程式碼"
+)
+
+// Failure retry commands
+var (
+	RetryCommand       = "agent-orchestrator retry %s && agent-orchestrator work %s"
+	MsgRetryHint       = "重試: %s"
+	MsgTicketNotFailed = "Ticket %s 狀態為 %s，不是 failed，略過"
+)
+
+// Agent call retries
+var (
+	AgentRetrying = "agent 以 exit code %d 結束，進行第 %d/%d 次重試（等待 %s）"
+)
+
+// Definition of done
+var (
+	FlagWorkLenient = "definition_of_done 未滿足時僅警告，仍將 ticket 標記為完成"
+	ErrDoDUnmet     = "Ticket %s 未滿足 %s 的完成條件（definition_of_done）: %s"
+)
+
+// Token usage and cost
+var (
+	MsgUsage         = "%s tokens · $%.2f"
+	MsgUsageTotal    = "  Token 用量: %s"
+	MsgRunUsageTotal = "本次執行用量: %s"
+)
+
+// Work cost ceiling
+var (
+	FlagWorkMaxCost       = "本次 work 的費用上限（USD），達到後停止派發新 ticket（覆寫 budget_usd）"
+	MsgTicketSkipped      = "%s: 已停止派發新 ticket，略過"
+	MsgCostCeilingReached = "已達費用上限 $%.2f（本次已使用 $%.2f），停止派發新 ticket；%d 張 tickets 略過，留在 pending"
+)
+
+// Operator notes
+var (
+	CmdNoteShort = "為 ticket 新增操作者備註"
+	CmdNoteLong  = `為 pending 或處理中的 ticket 新增備註，於該 ticket 下一次的 coding 與 review prompt 中帶入，
+用來在不改寫描述的情況下調整進行中的工作方向。只給 ticket ID 時列出既有備註。
+別名 comment；status 會在 ticket 下方顯示其最新一則備註。
+
+範例:
+  agent-orchestrator note TICKET-003 "改用既有的 retry 套件，不要新增依賴"
+  agent-orchestrator comment TICKET-001 "等 API 上線後再處理"
+  agent-orchestrator note TICKET-003`
+	MsgNoteAdded            = "已為 %s 新增備註，將於下次 coding/review prompt 帶入"
+	MsgNoNotes              = "%s 沒有備註"
+	MsgNotes                = "備註:"
+	MsgLatestNote           = "最新備註 (共 %d 則):"
+	ErrNoteClosedTicket     = "Ticket %s 狀態為 %s，只能為 pending、in_progress 或 failed 的 ticket 新增備註"
+)
+
+// Systemic failure abort
+var (
+	MsgSystemicAbort         = "連續 %d 張 tickets 因同一類系統性錯誤（%s）失敗，提早中止，不再派發新 ticket"
+	MsgSystemicLastError     = "  最後一次錯誤: %s"
+	MsgSystemicHint          = "  建議: %s"
+	HintSystemicUnavailable  = "找不到或無法啟動 agent 指令，請確認已安裝並設定 agent_command"
+	HintSystemicAuth         = "agent 認證失效或未登入，請重新登入 agent CLI 或更新 API key 後再執行 retry"
+	HintSystemicQuota        = "帳號額度或點數已用盡，請確認帳單狀態後再執行 retry"
+	HintSystemicNetwork      = "無法連線至模型 API，請檢查網路或 proxy 設定後再執行 retry"
+	HintSystemicRateLimit    = "模型 API 持續限流或過載，請降低 max_parallel 或 max_agent_calls_per_minute 後再執行 retry"
+	NotifySystemicAbortTitle = "agent-orchestrator %s 因系統性錯誤中止"
+	MsgNotifyFailed          = "通知發送失敗: %v"
+	ErrSystemicAbort         = "%s 因連續的系統性錯誤（%s）中止"
+)
+
+// Prompt templates (prompts)
+var (
+	CmdPromptsShort = "列出 agent prompt 範本與其來源"
+	CmdPromptsLong  = `列出 coding、review、planning、commit 的 prompt 範本，以及各自使用內建範本或專案的覆寫檔。
+
+Prompt 為 Go text/template 範本。在專案的 .agent-orchestrator/prompts/ 放置同名檔案（如 coding.tmpl）即可覆寫內建範本，不需重新建置；
+只套用於特定語言的範本放在以語言命名的子目錄（如 en/coding.tmpl），優先於共用的同名檔案。
+可用變數：
+  coding    .Ticket（完整 ticket，如 .Ticket.ID、.Ticket.Title、.Ticket.Description）、.ProjectRoot、
+            .AcceptanceCriteria、.Notes、.Projects、.ProjectHints、.Conventions、.PartialOutput
+  review    .ProjectRoot、.Files、.Notes
+  planning  .ProjectRoot、.MilestoneFile、.MilestoneFiles、.OutputFile、.Epics
+  commit    .ProjectRoot、.TicketID、.TicketTitle、.Changes、.FilesToStage
+除 text/template 內建函式外，另有 join（如 {{join .Files ", "}}）與 trim。
+
+範例:
+  agent-orchestrator prompts init coding              # 匯出內建的 coding 範本後再修改
+  agent-orchestrator --lang en prompts init coding    # 匯出英文範本至 en/ 子目錄`
+	CmdPromptsInitShort  = "將內建 prompt 範本匯出至 .agent-orchestrator/prompts/ 以便修改"
+	FlagPromptsInitForce = "覆寫已存在的範本檔"
+	UIPrompts            = "Prompt 範本"
+	MsgPromptEmbedded    = "(內建)"
+	MsgPromptExists      = "%s 已存在，略過（使用 --force 覆寫）"
+	MsgPromptWritten     = "已寫入 %s"
+)
+
+
+// Output language (language)
+var (
+	FlagLang            = "輸出語言: zh-TW, en 或 .agent-orchestrator/locales/ 中語系檔的語言（覆寫設定的 language）"
+	ErrUnknownLanguage  = "不支援的語言 %q（可用: %s）"
+	ErrLocaleFile       = "語系檔 %s: %v"
+	ErrLocaleUnknownKey = "未知的訊息 key %q"
+	ErrLocaleVerbs      = "訊息 %s 的格式符號為 %v，應與內建訊息相同: %v"
+)
+
+// Shell completion (completion)
+var (
+	CmdCompletionShort = "產生 shell 自動補全腳本"
+	CmdCompletionLong  = `產生指定 shell 的自動補全腳本。
+
+Bash:
+  # Linux
+  agent-orchestrator completion bash > /etc/bash_completion.d/agent-orchestrator
+  
+  # macOS
+  agent-orchestrator completion bash > $(brew --prefix)/etc/bash_completion.d/agent-orchestrator
+
+Zsh:
+  # 如果 shell completion 尚未啟用，需要先執行:
+  echo "autoload -U compinit; compinit" >> ~/.zshrc
+  
+  # 產生補全腳本
+  agent-orchestrator completion zsh > "${fpath[1]}/_agent-orchestrator"
+  
+  # 或者放到自訂目錄
+  agent-orchestrator completion zsh > ~/.zsh/completions/_agent-orchestrator
+
+Fish:
+  agent-orchestrator completion fish > ~/.config/fish/completions/agent-orchestrator.fish
+
+PowerShell:
+  agent-orchestrator completion powershell > agent-orchestrator.ps1
+  # 然後在 PowerShell profile 中 source 這個檔案`
+)
+
+// Ticket details and interactive editing (add, edit, drop)
+var (
+	MsgTicketType         = "類型: %s"
+	MsgTicketPriority     = "優先級: P%d"
+	MsgTicketStatus       = "狀態: %s"
+	MsgTicketDescription  = "描述: %s"
+	MsgTicketDeps         = "依賴: %s"
+	MsgTicketCriteria     = "驗收條件:"
+	MsgTicketFilesModify  = "要修改的檔案: %s"
+	MsgTicketFilesCreate  = "要建立的檔案: %s"
+	MsgCurrentTicketInfo  = "目前 Ticket 資訊:"
+	MsgCurrentDescription = "目前描述: %s"
+	MsgCurrentDeps        = "目前依賴: %s"
+	MsgCurrentCriteria    = "目前驗收條件:"
+	MsgDropTicketPreview  = "即將刪除的 Ticket:"
+	MsgDropTicketsPreview = "即將刪除的 Tickets:"
+	EditFieldTitle        = "標題"
+	EditFieldDescription  = "描述"
+	EditFieldType         = "類型"
+	EditFieldPriority     = "優先級"
+	EditFieldDeps         = "依賴"
+	EditFieldCriteria     = "驗收條件"
+	EditFieldLabels       = "標籤"
+	EditFieldDone         = "完成編輯"
+	TicketTypeFeature     = "feature - 新功能"
+	TicketTypeBugfix      = "bugfix - 錯誤修復"
+	TicketTypeRefactor    = "refactor - 重構"
+	TicketTypeTest        = "test - 測試"
+	TicketTypeDocs        = "docs - 文件"
+	TicketTypePerformance = "performance - 效能優化"
+	TicketTypeSecurity    = "security - 安全性"
+	PromptNewTitle        = "新標題 (目前: %s)"
+	PromptNewDescription  = "新描述"
+	PromptSelectType      = "選擇類型 (目前: %s)"
+	PromptNewPriority     = "新優先級 1-5 (目前: %d)"
+	PromptNewDeps         = "新依賴 (逗號分隔，留空清除)"
+	PromptNewCriteria     = "新驗收條件 (每行一條)"
+	PromptNewLabels       = "新標籤 (逗號分隔，留空清除)"
+	SpinnerFailEnhance    = "AI 預處理失敗"
+	MsgEnhanceFailedKept  = "AI 預處理失敗: %s，保留原始內容"
+	MsgEnhanceFailedUsed  = "AI 預處理失敗: %s，使用原始內容"
+	MsgEnhanceUnavailable = "無法使用 AI 預處理: agent 不可用"
+	ErrTicketInvalid      = "Ticket 驗證失敗: %s"
+	ErrTitleEmpty         = "標題不能為空"
+	AgentEnhanceAppendix  = "\n\n## AI 補充說明\n"
+)
+
+// Other command output (test, commit, config, init)
+var (
+	MsgTestPassed        = "  通過: %d"
+	MsgTestFailed        = "  失敗: %d"
+	MsgTestSkipped       = "  跳過: %d"
+	MsgCommitTicketStep  = "提交 %s: %s"
+	ErrCommitTicketOrAll = "請提供 ticket ID 或使用 --all"
+	TableConfigKey       = "設定項"
+	TableConfigValue     = "值"
+	MsgSeconds           = "%d 秒"
+	MsgRetriesBackoff    = "%d（backoff %d 秒）"
+	SpinnerFailScan      = "掃描專案失敗"
+)
+
+// Project summary and default init questions (agent)
+var (
+	AgentSummaryLanguage   = "  - 語言: %s\n"
+	AgentSummaryFramework  = "  - 框架: %s\n"
+	AgentSummaryStructure  = "  - 結構: %s\n"
+	AgentSummaryHasTests   = "  - 已有測試: 是\n"
+	AgentSummaryHasDocs    = "  - 已有文件: 是\n"
+	AgentQuestionLanguage  = "這個專案使用什麼程式語言？"
+	AgentQuestionUsers     = "主要的目標使用者是誰？"
+	AgentQuestionFeatures  = "有什麼關鍵功能需求？"
+	AgentQuestionScale     = "有沒有效能或規模上的需求？"
+	AgentQuestionOutput    = "需要什麼輸出格式或介面？"
+	AgentQuestionIntegrate = "這個新功能如何與現有架構整合？"
+	AgentQuestionModify    = "是否需要修改現有的模組或 API？"
+	AgentQuestionCompat    = "有沒有相容性的考量？"
+	AgentQuestionTests     = "需要新增哪些測試？"
+	AgentQuestionDocs      = "是否需要更新文件？"
+	AgentTestPrompt        = `你是一個測試 Agent。請在專案目錄 %s 執行以下任務:
+
+1. 檢查專案類型並找到適合的測試指令
+   %s
+2. 執行測試，可行時一併量測 coverage（例如 Go 加上 -cover、pytest 加上 --cov）
+
+3. 分析測試結果
+
+4. 如果有測試失敗，分析失敗原因
+
+請在輸出中包含:
+- 測試摘要
+- 通過/失敗的測試數量
+- 有量測 coverage 時，總 coverage（格式為 總 coverage: 82.5%）
+- 失敗測試的詳細資訊 (如果有)
+- 修復建議`
+)
+
+// Machine-readable progress (--progress-format)
+var (
+	FlagProgressFormat       = "進度輸出格式: text, jsonl（jsonl 時事件寫到 stdout 或 --progress-fd，一般輸出改到 stderr）"
+	FlagProgressFD           = "jsonl 進度事件寫入的檔案描述子（1 為 stdout，也可用如 3 另開）"
+	ErrUnknownProgressFormat = "不支援的進度格式 %q（可用: %s, %s）"
+	ErrProgressFD            = "無法寫入進度事件到檔案描述子 %d（是否已開啟？）"
+)
+
+// Dependency cycle repair (deps fix-cycles)
+var (
+	CmdDepsFixCyclesShort = "找出 tickets 的相依循環並修復"
+	CmdDepsFixCyclesLong  = `找出未完成 tickets 之間的相依循環（循環中的 tickets 永遠不會變成可處理），列出完整路徑，
+並為每個循環建議最適合解除的一條相依：依賴方若修改依賴方建立的檔案、兩者修改相同檔案、或描述提到對方，
+該相依視為較強；低優先級 ticket 擋住高優先級 ticket 則視為較弱。
+
+每個循環可選擇:
+  - 改為軟相依（soft）: 移到 soft_dependencies，保留順序提示但不再阻擋
+  - 移除相依（drop）
+  - 略過
+
+範例:
+  agent-orchestrator deps fix-cycles                   # 逐一詢問
+  agent-orchestrator deps fix-cycles --strategy soft   # 不詢問，全部改為軟相依
+  agent-orchestrator deps fix-cycles --dry-run         # 只顯示會做的修改`
+
+	FlagCycleStrategy = "不詢問，直接以指定方式解除每個循環的建議相依: soft（改為軟相依）、drop（移除）"
+
+	UIFixCycles       = "修復相依循環"
+	MsgCyclePath      = "相依循環: %s"
+	MsgCycleSuggest   = "建議解除 %s 對 %s 的相依"
+	PromptCycleRepair = "如何處理這個循環？"
+	CycleOptionSoft   = "將 %s 對 %s 的相依改為軟相依（保留順序提示，不再阻擋）"
+	CycleOptionDrop   = "移除 %s 對 %s 的相依"
+	CycleOptionSkip   = "略過，稍後手動處理"
+	MsgCycleSoftened  = "%s 對 %s 的相依已改為軟相依"
+	MsgCycleDropped   = "已移除 %s 對 %s 的相依"
+	MsgNoCycles       = "沒有相依循環"
+	MsgCyclesFixed    = "已解除 %d 條相依，更新 %d 張 tickets"
+	MsgCyclesDryRun   = "[dry-run] 未儲存變更"
+	HintFixCycles     = "可執行 agent-orchestrator deps fix-cycles 修復相依循環"
+	ErrCycleStrategy  = "不支援的修復方式 %q（可用: soft, drop）"
+)
+
+// Machine-readable status (status --output json)
+var (
+	FlagStatusOutput  = "輸出格式: text（表格）、json（供 CI 與儀表板讀取）"
+	ErrStatusOutput   = "不支援的輸出格式 %q（可用: text, json）"
+	ErrStatusJSONAsOf = "--as-of 目前不支援 --output json"
+)
+
+// Orphaned agent processes (work reap)
+var (
+	CmdWorkReapShort = "終止崩潰執行遺留的 agent 行程"
+	CmdWorkReapLong  = `終止 orchestrator 崩潰後遺留、仍在執行的 agent 行程。
+
+每次啟動 agent 時會將其 PID 與所屬 orchestrator 的 PID 記錄在 .tickets/agents.json，
+agent 結束後移除。所屬 orchestrator 已結束但 agent 仍在執行者即為遺留行程；
+確認後送出 SIGTERM，數秒內未結束則強制終止，並記錄於 logs 目錄下的 reaper.log。
+
+work 啟動時也會檢查遺留行程：在終端機中逐一詢問，否則只提示執行本指令。
+
+範例:
+  agent-orchestrator work reap         # 逐一確認
+  agent-orchestrator work reap --yes   # 不詢問，全部終止`
+
+	FlagWorkReapYes = "不詢問，直接終止所有遺留的 agent 行程"
+
+	MsgNoOrphanAgents    = "沒有遺留的 agent 行程"
+	MsgOrphanAgentsFound = "發現 %d 個崩潰執行遺留的 agent 行程"
+	MsgOrphanAgent       = "PID %d: %s（啟動於 %s，所屬 orchestrator PID %d 已結束）"
+	PromptReapAgent      = "終止 PID %d？"
+	MsgAgentReaped       = "已終止遺留的 agent 行程 PID %d"
+	MsgAgentsReaped      = "已終止 %d 個遺留的 agent 行程"
+	HintWorkReap         = "可執行 agent-orchestrator work reap 終止遺留的 agent 行程"
+	ErrReapAgent         = "無法終止 agent 行程 PID %d: %v"
+	ErrReapPIDReused     = "此 PID 已屬於另一個行程，原 agent 已結束，不送出訊號"
+)
+
+// Live status (status --watch)
+var (
+	FlagStatusWatch    = "持續更新狀態畫面，tickets 目錄有變動或每隔 --interval 重新顯示（Ctrl+C 離開）"
+	FlagStatusInterval = "--watch 的更新間隔"
+	ErrStatusWatchMode = "--watch 不支援 --output json 或 --as-of"
+	ErrStatusInterval  = "更新間隔須大於 0，目前為 %s"
+	MsgWatchHeader     = "每 %s 或 tickets 變動時更新 · 最後更新 %s · Ctrl+C 離開"
+	UIWatchInFlight    = "處理中 (%d):"
+	MsgWatchBackground = "背景工作 PID %d 已執行 %s: 完成 %d、失敗 %d，尚有 %d 張 pending"
+)
+
+// Milestone acceptance review (run --acceptance-review)
+var (
+	AgentAcceptancePrompt = `你是一位驗收審查者。以下 milestone 的 tickets 都已完成，請檢查專案目前的實作是否滿足 milestone 的驗收標準。
+
+專案目錄: %s
+Milestone: %s
+
+## 驗收標準
+%s
+
+## 已完成的 tickets
+%s
+請逐條檢查驗收標準：閱讀相關程式碼、文件與測試（必要時執行測試），判斷是否達成並附上依據。
+未達成的條目請說明缺口，並建議一張補齊缺口的 follow-up ticket。
+
+請以 JSON 格式輸出：
+{
+  "summary": "整體結論",
+  "criteria": [
+    {
+      "criterion": "驗收標準原文",
+      "met": true,
+      "evidence": "判斷依據（檔案、測試、指令輸出）",
+      "gap": "未達成時的缺口說明",
+      "follow_up": {
+        "title": "補齊缺口的 ticket 標題",
+        "description": "需要完成的工作",
+        "type": "feature|test|docs|bugfix|refactor",
+        "priority": 2
+      }
+    }
+  ]
+}`
+	AgentAcceptanceFollowUpTitle = "補齊驗收標準: %s"
+
+	FlagAcceptanceReview           = "所有 tickets 完成後，依 milestone 的驗收標準章節做最終驗收審查，產生缺口報告並為未達成的標準建立 follow-up tickets"
+	StepAcceptance                 = "驗收審查"
+	MsgAcceptanceSkippedIncomplete = "  尚有 %d 張 tickets 未完成，略過驗收審查"
+	MsgAcceptanceNoSection         = "  milestone 沒有驗收標準章節（如「## 驗收標準」或「## Acceptance Criteria」），略過驗收審查"
+	MsgAcceptanceResult            = "  驗收標準: 達成 %d / %d"
+	MsgAcceptanceReport            = "  缺口報告: %s"
+	MsgAcceptanceFollowUp          = "  已建立 follow-up ticket %s: %s"
+	MsgAcceptanceAllMet            = "  所有驗收標準皆已達成"
+	HintAcceptanceFollowUps        = "  可執行 agent-orchestrator work 處理 follow-up tickets"
+
+	UIAcceptanceReport    = "# 驗收審查: %s"
+	UIAcceptanceSummary   = "## 結論"
+	UIAcceptanceUnmet     = "## 未達成 (%d)"
+	UIAcceptanceMet       = "## 已達成 (%d)"
+	MsgAcceptanceEvidence = "依據: %s"
+	MsgAcceptanceGap      = "缺口: %s"
+	MsgAcceptanceTicket   = "Follow-up ticket: %s"
+)
+
+// Soft dependencies (ordering hints)
+var (
+	FlagSoftDeps        = "軟相依的 ticket IDs (逗號分隔)：最好排在它們之後，但不會因它們未完成或失敗而被擋住"
+	FlagEditSoftDeps    = "軟相依的 ticket IDs (逗號分隔，取代原有的；none 表示清除)"
+	MsgTicketSoftDeps   = "軟相依: %s"
+	MsgSoftDependencies = "軟相依: %v"
+	MsgSoftDeferred     = "%d 個 tickets 的軟相依在本輪處理，延到下一輪"
+)
+
+// Local REST API and web dashboard (serve)
+var (
+	CmdServeShort = "啟動本機 REST API 與 web dashboard"
+	CmdServeLong  = `啟動常駐的 HTTP 伺服器，提供 REST API 與內嵌的簡易 web dashboard，方便團隊查看與操作 tickets。
+
+API:
+  GET  /api/status              狀態摘要（同 status --output json，可加 ?label=）
+  GET  /api/tickets             列出 tickets（可加 ?status=pending&label=backend）
+  GET  /api/tickets/{id}        單一 ticket
+  POST /api/tickets             建立 ticket（欄位同 add：title、description、type、priority、dependencies、labels...）
+  POST /api/tickets/{id}/retry  將 failed 的 ticket 放回 pending 並背景執行 work（同 retry 後 work）
+  POST /api/work                背景執行 work（{"ticket_id": "..."} 只處理一張；背景 work 執行中時改為排入佇列）
+  POST /api/plan                背景執行 plan（{"milestone": "docs/milestone-001.md"}）
+  GET  /api/jobs                由 API 啟動的 work/plan
+  GET  /api/jobs/{id}/logs      以 server-sent events 串流該 job 的日誌
+  GET  /retry/{id}              失敗通知中重試連結的確認頁（需設定 notifications.serve_url；以連結簽章驗證）
+
+預設只接受本機連線。未設定 serve_tokens 時只能監聽本機位址，且只接受 Host 為本機、
+來自同一來源的請求；變更類請求必須是 application/json。
+
+加上 --recurring 時同時在伺服器內執行週期性 tickets 的排程（同 recurring run --watch）。
+
+Examples:
+  agent-orchestrator serve
+  agent-orchestrator serve --addr 127.0.0.1:9090
+  agent-orchestrator serve --recurring --recurring-interval 5m`
+	FlagServeAddr              = "監聽位址（host:port）"
+	FlagServeRecurring         = "同時執行週期性 tickets 的排程"
+	FlagServeRecurringInterval = "--recurring 時的檢查間隔"
+
+	MsgServeListening = "API 與 dashboard 已啟動: %s（Ctrl+C 停止）"
+	MsgServeRecurring = "週期性 tickets 排程已啟動，每 %s 檢查一次"
+
+	ErrServeListen            = "無法監聽 %s: %w"
+	ErrServeBusy              = "已有由 API 啟動的 work 或 plan 正在執行"
+	ErrServeStatus            = "無效的 status: %s（可用 pending、in_progress、completed、failed）"
+	ErrServeMilestoneRequired = "請指定 milestone"
+	ErrServeBadRequest        = "無效的請求內容: %v"
+	ErrServeJobNotFound       = "找不到 job: %s"
+	ErrServeStreaming         = "此連線不支援串流"
+	ErrServeExposed           = "監聽位址 %s 不限於本機，請先設定 serve_tokens，否則任何能連到此位址的人都能建立 tickets 並觸發 work/plan"
+	ErrServeContentType       = "請求內容必須是 application/json（收到 %q）"
+	ErrServeCrossOrigin       = "拒絕來自其他來源的請求（Origin: %s）"
+	ErrServeHost              = "拒絕 Host 為 %s 的請求：未設定 serve_tokens 時只接受本機位址"
+)
+
+// Priority escalation of bottleneck tickets
+var (
+	MsgEscalated         = "%s 解除 %d 張 tickets 的阻塞，優先級由 P%d 提升為 P%d"
+	MsgUnblocks          = "解除 %d 張 tickets 的阻塞"
+	MsgUnblocksEscalated = "解除 %d 張 tickets 的阻塞（有效優先級 P%d）"
+)
+
+// Run and background work summaries, repeated failures (notifications)
+var (
+	NotifyRunFinishedTitle     = "agent-orchestrator run 完成: %s"
+	NotifyWorkFinishedTitle    = "agent-orchestrator 背景 work 結束"
+	NotifyRepeatedFailureTitle = "Ticket %s 已連續失敗 %d 次"
+	NotifyRunSummary           = "完成 %d、失敗 %d、待處理 %d"
+)
+
+// Prometheus metrics of long-running modes
+var (
+	MsgMetricsListening    = "Prometheus metrics: %s"
+	MsgMetricsListenFailed = "無法在 %s 提供 metrics: %v"
+)
+
+// Config get/set
+var (
+	CmdConfigGetShort = "顯示單一設定值"
+	CmdConfigGetLong  = `顯示設定鍵的有效值（設定檔、環境變數與預設值合併後的結果）。巢狀設定以點分隔，例如 escalation.threshold；清單與對照表以單行 YAML 輸出。`
+	CmdConfigSetShort = "修改設定檔中的單一設定值"
+	CmdConfigSetLong  = `修改設定檔中的單一設定值，保留其他內容、註解與排版。設定檔不存在時會建立；只以註解形式存在的頂層設定（如 config init 產生的選填項目）會被取消註解。
+
+值依設定鍵的型別解析：布林值、數字、清單（a,b 或 [a, b]）與對照表（{key: value}）。修改後的設定未通過驗證時不會寫入。`
+	MsgConfigSet       = "已將 %s 設為 %s（%s）"
+	ErrConfigGetFailed = "讀取設定值失敗: %s"
+	ErrConfigSetFailed = "修改設定失敗: %s"
+)
+
+// Run snapshot and restore
+var (
+	FlagRunSnapshot      = "執行前以 git 保存工作區快照（HEAD、未提交與未追蹤的變更），之後可用 --restore-last 還原"
+	FlagRunRestoreLast   = "將工作區還原到最近一次 run --snapshot 前的狀態，並刪除該次 run 建立的 tickets"
+	MsgRunSnapshotTaken  = "已保存工作區快照 %s（分支 %s）；結果不理想時可執行 run --restore-last 還原"
+	UIRunRestore         = "還原 run 前的工作區"
+	MsgRunRestoreFrom    = "快照: %s，milestone %s（%s）"
+	MsgRunRestoreCommits = "將移除 %d 個 commit:"
+	MsgRunRestoreFiles   = "將還原 %d 個檔案:"
+	MsgRunRestoreTickets = "將刪除 %d 張 tickets:"
+	MsgRunRestoreNothing = "工作區與快照相同，沒有需要還原的變更"
+	PromptConfirmRestore = "確定要還原工作區嗎？未保存於快照的變更將會遺失"
+	MsgRunRestored       = "已還原到 %s 的工作區快照"
+	ErrRunSnapshotFailed = "保存工作區快照失敗（需要 git 儲存庫）: %v"
+	ErrNoRunSnapshot     = "沒有可還原的快照，請先以 run --snapshot 執行"
+	ErrRunRestoreFailed  = "還原工作區失敗: %v"
+	ErrRunRestoreArgs    = "--restore-last 不需要 milestone 檔案，也不能與 --snapshot 同時使用"
+)
+
+// Serve API tokens and API audit log
+var (
+	ErrServeUnauthorized = "需要有效的 API token（Authorization: Bearer <token>）"
+	ErrServeForbidden    = "token %s 的權限（%s）不足，此操作需要 %s"
+	MsgServeTokens       = "API 需要 token 驗證（已設定 %d 個 token）"
+	FlagAuditAPI         = "列出 serve API 觸發的操作與被拒絕的請求，而非 agent 呼叫（--user 依 token 名稱篩選）"
+	UIAuditAPI           = "API 操作稽核紀錄"
+	TableAuditToken      = "Token"
+	TableAuditRequest    = "請求"
+	TableAuditStatus     = "狀態"
+	TableAuditTarget     = "對象"
+	MsgAuditAPISummary   = "共 %d 筆請求，%d 個 token，%d 筆被拒絕"
+)
+
+// Signed retry links in failure notifications
+var (
+	UIRetryLinkTitle     = "重試 ticket %s"
+	UIRetryLinkPrompt    = "將 failed 的 ticket %s 放回 pending 並在背景處理。"
+	UIRetryLinkButton    = "重試"
+	UIRetryLinkStarted   = "已開始重試"
+	ErrRetryLinkDisabled = "此伺服器不接受重試連結（未設定 notifications.serve_url）"
+	ErrRetryLinkInvalid  = "重試連結無效或不是此伺服器簽發的"
+	ErrRetryLinkExpired  = "重試連結已於 %s 過期"
+)
+
+// Structured logging
+var (
+	FlagLogFormat       = "診斷日誌格式：text 或 json（覆寫設定 log_format；work --detach 一律為 json）"
+	MsgTicketCodingDone = "%s 的 agent 執行完成"
+)
+
+// Ticket preview before creating tickets
+var (
+	FlagPreviewFull      = "先以分頁程式（$PAGER）顯示將建立的 tickets 完整 JSON"
+	UITicketPreview      = "將建立的 Tickets"
+	TablePreviewID       = "ID"
+	TablePreviewTitle    = "標題"
+	TablePreviewType     = "類型"
+	TablePreviewPriority = "優先級"
+	TablePreviewDeps     = "依賴"
+	PromptPreviewMore    = "-- 已顯示 %d / %d 個，Enter 顯示更多，q 略過 --"
+)
+
+// Doctor command
+var (
+	CmdDoctorShort = "診斷執行環境"
+	CmdDoctorLong  = `檢查 agent-orchestrator 的執行環境並列出問題與修正方式：
+
+  - 設定檔是否存在且有效
+  - agent CLI 是否在 PATH 上及其版本（api 後端則檢查 API key）
+  - 專案是否為 git repository
+  - tickets、logs 目錄是否存在且可寫入
+  - 背景 work 的 PID 檔是否過期
+  - 沒有任何 orchestrator 在處理的 in_progress tickets
+  - 崩潰執行遺留的 agent 行程
+
+使用 --fix 自動修正可修正的問題（建立目錄、移除過期 PID 檔、
+將孤立的 in_progress tickets 放回 pending、終止遺留的 agent 行程）。
+有檢查失敗時以非零狀態結束。`
+	FlagDoctorFix = "自動修正可修正的問題"
+
+	UIDoctor          = "環境診斷"
+	ErrDoctorFix      = "無法修正 %s: %v"
+	MsgDoctorFixed    = "已修正: %s"
+	MsgDoctorHealthy  = "環境正常"
+	ErrDoctorProblems = "發現 %d 個問題"
+	HintDoctorFix     = "可執行 agent-orchestrator doctor --fix 自動修正 %d 個問題"
+
+	DoctorConfig       = "設定檔"
+	DoctorAgent        = "Agent CLI"
+	DoctorGit          = "Git repository"
+	DoctorTicketsDir   = "Tickets 目錄"
+	DoctorLogsDir      = "Logs 目錄"
+	DoctorDetachLogDir = "背景 work 日誌目錄"
+	DoctorPIDFile      = "背景 work PID 檔"
+	DoctorInProgress   = "In-progress tickets"
+	DoctorOrphanAgents = "遺留的 agent 行程"
+
+	MsgDoctorNoConfig    = "找不到設定檔，使用預設值"
+	HintDoctorInitConfig = "可執行 agent-orchestrator init 建立設定檔"
+	HintDoctorFixConfig  = "請修正設定檔 %s；agent-orchestrator config validate 會列出問題所在行"
+
+	HintDoctorAgentBackend = "請在設定檔設定有效的 agent_backend"
+	MsgDoctorNoAPIKey      = "api 後端需要 ANTHROPIC_API_KEY"
+	HintDoctorAPIKey       = "請設定 ANTHROPIC_API_KEY 環境變數"
+	MsgDoctorAPIBackend    = "api 後端，模型 %s"
+	MsgDoctorAgentMissing  = "PATH 上找不到 %s"
+	HintDoctorAgentInstall = "請安裝 %s 的 CLI，或以 agent_command 指定其路徑"
+	HintDoctorAgentVersion = "無法取得版本，請確認 %s --version 可以執行"
+
+	MsgDoctorGitMissing  = "PATH 上找不到 git"
+	HintDoctorGitInstall = "請安裝 git"
+	MsgDoctorNotARepo    = "%s 不是 git repository"
+	HintDoctorGitInit    = "可執行 git init；coding 與 review 需要 git"
+
+	HintDoctorCreateDir      = "目錄不存在，會在需要時建立"
+	MsgDoctorNotADir         = "%s 不是目錄"
+	HintDoctorDirPermissions = "請確認 %s 是可寫入的目錄"
+
+	MsgDoctorNoBackgroundWork   = "沒有執行中的背景 work"
+	MsgDoctorBackgroundWork     = "背景 work 執行中 (PID %d)"
+	MsgDoctorStalePID           = "%s 記錄的 PID %d 已不存在"
+	HintDoctorStalePID          = "可移除過期的 PID 檔"
+	MsgDoctorNoInProgress       = "沒有 in_progress tickets"
+	MsgDoctorInProgressActive   = "%d 個 in_progress tickets 正在處理中"
+	MsgDoctorOrphanedInProgress = "沒有 orchestrator 在處理: %s"
+	HintDoctorRequeue           = "可執行 agent-orchestrator doctor --fix 將其放回 pending"
+	MsgDoctorNoOrphanAgents     = "沒有遺留的 agent 行程"
+)
+
+// Agent probe before a parallel batch
+var (
+	MsgAgentProbe        = "檢查 agent 是否可用..."
+	MsgAgentProbeFailed  = "Agent 預檢失敗: %s"
+	MsgAgentProbeTimeout = "agent 在 %s 內沒有回應"
+	ErrAgentProbeFailed  = "agent 預檢失敗，未處理任何 ticket: %s"
+)
+
+// Config validate
+var (
+	CmdConfigValidateShort = "檢查設定檔"
+	CmdConfigValidateLong  = `檢查設定檔（預設為目前使用的設定檔，或指定的檔案）：YAML 語法、未知的設定鍵（附上最接近的設定鍵）、型別錯誤的值，以及載入時的驗證規則，並列出每個問題所在的行號。
+
+設定檔有問題時以非零狀態結束，可用於 CI 或 pre-commit hook。`
+	MsgConfigValid          = "%s 設定有效"
+	MsgConfigValidateNoFile = "找不到設定檔 %s，使用預設值"
+	ErrConfigInvalid        = "%s 有 %d 個問題"
+)
+
+// Triage command
+var (
+	CmdTriageShort = "分析變更、去除重複並列出待處理佇列"
+	CmdTriageLong  = `每日一次的整理流程，適合逐步導入本工具的維護者：
+
+  1. 分析相對於 upstream 分支變更的檔案（--all 分析整個專案）
+  2. 比對既有 tickets 去除重複的問題（標題相同，或同一檔案且標題相近），只為新問題建立 tickets
+  3. 依優先級（含 escalation 對瓶頸 tickets 的提升）列出 work 接下來會處理的 tickets
+
+範例:
+  agent-orchestrator triage
+  agent-orchestrator triage --scope security,test --limit 5`
+	FlagTriageAll   = "分析整個專案，而非只分析變更的檔案"
+	FlagTriageLimit = "待處理佇列最多顯示幾個 tickets（0 為全部）"
+
+	UITriage             = "Triage"
+	StepTriageAnalyze    = "分析變更的程式碼"
+	StepTriageDedupe     = "去除重複並建立 tickets"
+	StepTriageQueue      = "待處理佇列"
+	MsgTriageDuplicate   = "  已有 ticket: %s → %s (%s)"
+	MsgTriageWouldCreate = "[DRY RUN] 將建立 ticket: %s - %s"
+	MsgTriageCreated     = "新建 %d 個 tickets，略過 %d 個重複問題"
+	MsgTriageQueueEmpty  = "沒有可處理的 tickets"
+	MsgTriageQueueMore   = "另有 %d 個可處理的 tickets（--limit 0 顯示全部）"
+	TableTriageUnblocks  = "解除阻擋"
+)
+
+// Config profiles
+var (
+	FlagProfile = "套用設定檔中的具名 profile（見 profiles 設定）"
+)
+
+// Global config
+var (
+	FlagConfigInitGlobal = "產生使用者的全域設定檔（各專案的 .agent-orchestrator.yaml 會覆寫其中的設定）"
+	MsgConfigFilesInUse  = "套用的設定檔（後者優先）: %s"
+	MsgConfigNoFiles     = "未找到設定檔，使用預設值與環境變數"
+)
+
+// Graph command
+var (
+	CmdGraphShort = "匯出 tickets 的相依關係圖"
+	CmdGraphLong  = `匯出所有 tickets 的相依關係圖，節點依狀態上色。
+
+格式：
+  ascii    在終端機顯示的樹狀圖：每個沒有其他 ticket 依賴的 ticket 為根，其下列出它的相依（預設）
+  dot      Graphviz DOT，可用 dot -Tsvg 產生圖片
+  mermaid  Mermaid flowchart，可直接貼進 Markdown 文件
+
+DOT 與 Mermaid 的箭頭由相依指向等待它的 ticket，即 work 處理的順序；軟相依以虛線表示，
+不存在的相依以虛線框標示。
+
+範例：
+  agent-orchestrator graph
+  agent-orchestrator graph --format dot | dot -Tsvg > tickets.svg
+  agent-orchestrator graph --format mermaid > docs/tickets.mmd`
+	FlagGraphFormat = "輸出格式: ascii, dot, mermaid"
+	ErrGraphFormat  = "不支援的圖格式: %s（可用 ascii、dot、mermaid）"
+	GraphMissing    = "%s（不存在）"
+	GraphSoft       = "軟相依"
+	GraphCycle      = "循環"
+	GraphSeeAbove   = "見上方"
+)
+
+// Plan review
+var (
+	FlagPlanAuto          = "不逐一確認，直接建立所有規劃出的 tickets"
+	PromptReviewTicket    = "[a] 接受 [e] 編輯 [r] 拒絕 [A] 接受其餘全部 [q] 取消規劃 (預設 a):"
+	MsgReviewTicketMeta   = "類型 %s，優先級 %s，相依: %s"
+	PromptReviewTitle     = "標題 (Enter 保留「%s」):"
+	PromptReviewPriority  = "優先級 1-5 (Enter 保留 P%d):"
+	PromptReviewDeps      = "相依，以逗號分隔 (Enter 保留 %s，- 清除):"
+	MsgTicketRejected     = "已拒絕 %s"
+	MsgRejectedDependency = "%s 依賴已拒絕的 %s，已移除此相依"
+	MsgReviewSummary      = "接受 %d 個、拒絕 %d 個 tickets"
+)
+
+// Milestone filters
+var (
+	FlagMilestoneFilter      = "只顯示從指定 milestone 檔案規劃出的 tickets (可指定多個)"
+	FlagWorkMilestone        = "只處理從指定 milestone 檔案規劃出的 tickets (可指定多個)"
+	MsgNoTicketsInMilestones = "沒有從 milestone %s 規劃出的 tickets"
+	MsgMilestoneUnassigned   = "%s 未標出所屬的 milestone，記為 %s"
+)
+
+// Run resume
+var (
+	FlagRunResume         = "從上次中斷的 run 最後完成的步驟之後繼續（沿用當時的 milestone 與步驟選項）"
+	ErrRunResumeArgs      = "--resume 不需指定 milestone 檔案，也不可與 --snapshot、--pipeline、--from 或 --until 併用"
+	ErrNoRunState         = "沒有可繼續的 run：上次的 run 已完成或尚未執行過"
+	ErrRunStateSave       = "無法更新 run 的進度記錄: %v"
+	MsgRunResuming        = "繼續 %s 開始的 run，已完成的步驟: %s"
+	MsgRunStepAlreadyDone = "已在上次執行時完成，略過"
+	MsgRunResumeRequeued  = "%s 在中斷時仍在處理中，已重設為 pending"
+	HintRunResume         = "可用 'agent-orchestrator run --resume' 從最後完成的步驟之後繼續"
+)
+
+// Pipeline definitions (run --pipeline)
+var (
+	FlagRunPipeline          = "依 pipeline 定義檔執行步驟（預設使用 .agent-orchestrator/pipeline.yaml，不存在時為內建順序）"
+	StepShell                = "Shell - 執行自訂指令..."
+	MsgPipelineDefinition    = "Pipeline 定義: %s"
+	MsgPipelineStepSkipped   = "條件 %s 不成立，略過此步驟"
+	MsgPipelineShellPassed   = "步驟 %s 指令成功"
+	MsgPipelineShellFailed   = "步驟 %s 指令失敗: %s"
+	ErrPipelineInvalid       = "pipeline 定義 %s 無效: %w"
+	ErrPipelineNoSteps       = "未定義任何步驟 (steps)"
+	ErrPipelineStepType      = "第 %d 個步驟的類型 %q 不支援（可用: analyze, plan, work, shell, test, review, commit, acceptance）"
+	ErrPipelineStepDuplicate = "第 %d 個步驟的名稱 %q 重複，請以 name 區分"
+	ErrPipelineStepCondition = "步驟 %q 的條件 %q 不支援（可用: always, success, failure, tickets_completed, review_passed）"
+	ErrPipelineStepRun       = "shell 步驟 %q 需要 run 指令"
+	ErrPipelineStepOption    = "步驟 %q 不可設定 %s（僅限 %s 步驟）"
+	ErrPipelineStepTimeout   = "步驟 %q 的 timeout 不可為負數"
+	ErrPipelineStepFailed    = "步驟 %q 失敗，pipeline 已停止"
+)
+
+// Run step range (run --from/--until)
+var (
+	FlagRunFrom       = "從指定的步驟開始（步驟名稱或類型，如 work、test）"
+	FlagRunUntil      = "執行到指定的步驟為止（步驟名稱或類型，如 work、review）"
+	ErrRunStepUnknown = "%q 不是 pipeline 的步驟（可用: %s）"
+	ErrRunStepRange   = "--from %q 位於 --until %q 之後"
+)
+
+// Review auto-fix (run --review-fix-iterations)
+var (
+	FlagReviewFixIterations = "review 要求修改時，交回 coding agent 修正並重新 review 的最多次數（0 為只回報）"
+	MsgReviewFixIteration   = "  審查要求修改，第 %d/%d 次修正（%d 個問題）..."
+	MsgReviewFixExhausted   = "已修正 %d 次仍未通過審查，繼續後續步驟"
+	MsgReviewFixNoTickets   = "沒有這次 run 完成的 tickets 可供修正"
+	MsgReviewFixFailed      = "修正 %s 失敗: %s"
+)
+
+// Review gating (review_block_on)
+var (
+	MsgReviewBlocksCommit    = "審查發現 %s 嚴重度的問題，run 不會 commit"
+	MsgCommitBlockedByReview = "審查發現阻擋 commit 的問題（review_block_on），略過 commit"
+)
+
+// Review diff (review --base)
+var (
+	AgentReviewDiffTruncated = "...（diff 過長，已省略其餘 %d 個字元；其餘變更請直接閱讀檔案）"
+	FlagReviewBase           = "審查相對於此 ref（如 main）的變更，含未提交與未追蹤的檔案；未指定時為相對於 HEAD"
+	ErrReviewDiff            = "無法取得相對於 %s 的 diff: %v"
+)
+
+// Coverage gating (min_coverage)
+var (
+	MsgTestCoverage    = "  覆蓋率: %.1f%%"
+	ErrCoverageBelow   = "測試覆蓋率 %.1f%% 低於 min_coverage %.1f%%"
+	ErrCoverageUnknown = "測試輸出沒有 coverage，無法確認是否達到 min_coverage %.1f%%"
+)
+
+// Native test execution (test_mode: native)
+var (
+	AgentTestFailurePrompt = `你是一個測試 Agent。專案目錄 %s 的測試已直接執行並失敗，以下為測試指令與輸出:
+
+~~~
+%s
+~~~
+
+請分析失敗原因。不需要重新執行全部測試；必要時可閱讀相關程式碼，或只重新執行失敗的測試。
+
+請在輸出中包含:
+- 失敗測試的詳細資訊
+- 失敗原因
+- 修復建議`
+)
+
+// Flaky tests (test_retries)
+var (
+	AgentTestRetry = "[重新執行失敗的測試，第 %d/%d 次]"
+	MsgTestFlaky   = "  不穩定的測試（重新執行後通過）: %s"
+)
+
+// Ticket verification (verify, run --verify)
+var (
+	AgentVerifyPrompt = `你是一位驗收檢查者。以下 ticket 已完成，請檢查專案目前的實作是否滿足它的每一條驗收標準。
+
+專案目錄: %s
+Ticket: %s %s
+
+## 描述
+%s
+
+## 驗收標準
+%s
+## 驗收 assertions 的執行結果
+%s
+請逐條檢查驗收標準：閱讀相關程式碼、文件與測試，必要時執行測試，判斷是否通過並附上依據。不要修改任何檔案。
+
+請以 JSON 格式輸出：
+{
+  "summary": "整體結論",
+  "criteria": [
+    {
+      "criterion": "驗收標準原文",
+      "passed": true,
+      "evidence": "判斷依據（檔案、測試、指令輸出）"
+    }
+  ]
+}`
+	AgentVerifyNoAssertions = "（沒有執行過 assertions）"
+	AgentVerifyNoVerdict    = "agent 沒有對此條目做出判斷"
+	ErrAgentVerifyFailed    = "驗收檢查失敗: %v"
+
+	CmdVerifyShort = "檢查已完成的 tickets 是否滿足驗收標準"
+	CmdVerifyLong  = `由 agent 逐條檢查已完成 ticket 的驗收標準（閱讀程式碼、assertions 結果，必要時執行測試），
+並將每條標準的通過與否及依據記錄在 ticket 的 verification 欄位。
+未指定 ticket 時，檢查所有尚未驗收的已完成 tickets。任一標準未通過時以錯誤結束。
+
+範例:
+  agent-orchestrator verify TICKET-001
+  agent-orchestrator verify`
+	FlagVerify           = "work 之後由 agent 逐條檢查這次完成的 tickets 是否滿足驗收標準，結果記錄在 ticket 上"
+	StepVerify           = "驗收檢查"
+	ErrVerifyNotComplete = "ticket %s 尚未完成（狀態: %s），無法驗收"
+	ErrVerifyFailed      = "%d 張 tickets 未通過驗收檢查: %s"
+	MsgVerifyNothing     = "沒有需要驗收的已完成 tickets"
+	MsgVerifyNoCriteria  = "  %s 沒有驗收標準，略過"
+	MsgVerifyTicket      = "%s %s: 通過 %d / %d"
+	MsgVerifyCriterion   = "    %s"
+	MsgVerifyEvidence    = "      依據: %s"
+	UIVerification       = "驗收檢查 (%s):"
+)
+
+// Per-complexity ticket timeouts (ticket_timeouts)
+var (
+	MsgRetryTimeoutBumped = "  %s 上次在 %s 後逾時，重試時超時提高為 %s"
+)
+
+// Reclaiming orphaned in_progress tickets (work, work --reclaim)
+var (
+	NoteTicketReclaimed  = "上次處理此 ticket 的 process 在完成前中止，ticket 已由 in_progress 放回 pending；專案中可能留有上次未完成的變更，請先檢查再繼續。"
+	MsgTicketReclaimed   = "%s 停留在 in_progress 但沒有 worker 在處理，已放回 pending"
+	MsgNoOrphanedTickets = "沒有需要收回的 in_progress tickets"
+	FlagWorkReclaim      = "將沒有 worker 在處理的 in_progress tickets 放回 pending 後結束，不處理 tickets（work 啟動時也會自動進行）"
+)
+
+// Ticket store locking (--no-lock)
+var (
+	FlagNoLock = "不對 ticket 檔案加鎖（僅在檔案系統不支援 flock/LockFileEx 時使用；多個指令同時寫入可能損毀 tickets）"
+)
+
+// Corrupt ticket files
+var (
+	DoctorCorruptTickets      = "損壞的 ticket 檔案"
+	MsgDoctorNoCorruptTickets = "沒有損壞的 ticket 檔案"
+	MsgCorruptTicketFiles     = "%d 個 ticket 檔案無法解析，已略過: %s"
+	HintCorruptTickets        = "請修正或刪除這些檔案；agent-orchestrator 不會讀取它們"
+)
+
+// Archive (archive, status --archived)
+var (
+	CmdArchiveShort = "封存已完成或失敗的舊 tickets"
+	CmdArchiveLong  = `將完成或失敗超過一段時間的 tickets 移到壓縮的封存目錄（tickets_dir/archive，每張 ticket 一個 .json.gz）。
+
+封存的 tickets 不再出現在 status、work 等一般查詢中，也不再拖慢讀取全部 tickets 的指令。
+仍有未封存的 ticket 依賴、或為其 epic 的 tickets 會保留，以免依賴被視為缺少。
+以 status --archived 查看已封存的 tickets。
+
+範例:
+  agent-orchestrator archive                  # 封存 30 天前完成或失敗的 tickets
+  agent-orchestrator archive --older-than 7d
+  agent-orchestrator archive --older-than 0   # 封存所有已完成或失敗的 tickets
+  agent-orchestrator status --archived`
+
+	FlagArchiveOlderThan = "只封存在此之前完成或失敗的 tickets (如 30d、24h、2006-01-02；0 為全部)"
+	FlagStatusArchived   = "列出已封存的 tickets (可搭配 --label、--milestone、--output json)"
+
+	UIArchive              = "封存 Tickets"
+	MsgArchiveNone         = "沒有可封存的 tickets"
+	MsgArchiveDryRun       = "[DRY RUN] 將封存 %d 個 tickets，未移動任何檔案"
+	MsgArchived            = "已封存 %d 個 tickets 到 %s"
+	UIArchivedTickets      = "已封存的 Tickets"
+	MsgNoArchivedTickets   = "沒有已封存的 tickets"
+	MsgArchivedTicketState = "%s，%s 結束"
+	MsgArchivedCount       = "另有 %d 個已封存的 tickets (status --archived 查看)"
+)
+
+// Ticket event history (history)
+var (
+	CmdHistoryShort = "顯示 ticket 的事件歷史"
+	CmdHistoryLong  = `依時間列出 ticket 的事件：建立、每次狀態變更（含原因，如 retry 或失敗的錯誤）、
+每次 agent 呼叫的開始與結束（結果與耗時），以及為其建立的 commits。
+已封存的 tickets 也可查詢。用於追查「為什麼失敗了兩次」。
+
+範例:
+  agent-orchestrator history TICKET-001`
+
+	UIHistory            = "Ticket 歷史: %s %s"
+	MsgHistoryEmpty      = "%s 沒有事件紀錄"
+	HistoryCreated       = "建立"
+	HistoryStatus        = "狀態 %s → %s"
+	HistoryAgentStarted  = "agent 開始 (%s)"
+	HistoryAgentFinished = "agent 結束: %s，耗時 %s"
+	HistoryCommit        = "commit %s"
+	MsgHistorySummary    = "%d 次 agent 呼叫，共耗時 %s；失敗 %d 次"
+)
+
+// Full-text search (search)
+var (
+	CmdSearchShort = "全文搜尋 tickets"
+	CmdSearchLong  = `在所有狀態的 tickets 中搜尋標題、描述、驗收條件與 agent 輸出（不分大小寫）。
+查詢的每個字都必須出現，可分散在不同欄位；結果依第一個字出現的欄位排序（標題優先），
+並顯示其前後文。用於找出造成某項變更的 ticket。
+
+範例:
+  agent-orchestrator search "cache invalidation"
+  agent-orchestrator search timeout --status failed
+  agent-orchestrator search login --type bugfix --archived`
+
+	FlagSearchStatus   = "只搜尋這些狀態的 tickets (pending、in_progress、completed、failed，可重複)"
+	FlagSearchType     = "只搜尋這些類型的 tickets (如 feature、bugfix，可重複)"
+	FlagSearchArchived = "一併搜尋已封存的 tickets"
+
+	ErrSearchStatus = "無效的狀態: %s (可用: pending、in_progress、completed、failed)"
+	ErrSearchType   = "無效的類型: %s (可用: feature、test、refactor、docs、bugfix、performance、security、epic)"
+
+	UISearch         = "搜尋: %s"
+	MsgSearchNoMatch = "沒有符合的 tickets"
+	MsgSearchFound   = "找到 %d 個 tickets"
+	MsgSearchHit     = "%s · %s: %s"
+)
+
+// Filter expressions (--filter)
+var (
+	FlagStatusFilter = "只顯示符合篩選運算式的 tickets (如 \"type=feature and priority<=2\")"
+	FlagWorkFilter   = "只處理符合篩選運算式的 tickets (如 \"label=backend\")"
+	FlagDropFilter   = "刪除所有符合篩選運算式的 tickets (取代 ticket-id，如 \"status=failed and created<7d\")"
+
+	MsgNoTicketsMatchFilter = "沒有符合 %s 的 tickets"
+	PromptConfirmDropFilter = "確定要刪除這 %d 個符合 %s 的 tickets 嗎？"
+)
+
+// Bulk ticket creation (add --from-file)
+var (
+	FlagAddFromFile = "從 JSON (格式同 generated-tickets.json) 或 CSV 檔案一次新增多個 tickets"
+
+	UIAddFromFile       = "從檔案新增 Tickets: %s"
+	MsgAddFileEmpty     = "%s 中沒有 tickets"
+	MsgAddFileRowError  = "第 %d 列 (%s): %s"
+	MsgAddFileDryRun    = "[DRY RUN] 將新增 %d 個 tickets，未寫入任何檔案"
+	MsgAddFileAdded     = "已新增 %d 個 tickets"
+	ErrAddFileRead      = "讀取 %s 失敗: %w"
+	ErrAddFileInvalid   = "%d/%d 個 tickets 有錯誤，未新增任何 ticket"
+	ErrAddFileWithFlags = "--from-file 不能與 --title、--enhance 或 --recur 併用"
+)
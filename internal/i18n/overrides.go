@@ -0,0 +1,106 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// OverridesDir is where user locale files live, relative to the project root. Each
+// <lang>.yaml maps message keys to text; a file for a built-in language replaces just
+// the keys it lists, and a file for any other language adds that language.
+const OverridesDir = ".agent-orchestrator/locales"
+
+// overrides holds the messages of each user locale file by language and key.
+var overrides = map[string]map[string]string{}
+
+var formatVerb = regexp.MustCompile(`%(\[\d+\])?[-+# 0]*\d*(\.\d+)?[a-zA-Z%]`)
+
+// LoadOverrides reads the *.yaml and *.yml locale files in dir, replacing any loaded
+// before; a missing dir means no overrides. A file is rejected as a whole when it has
+// an unknown key or changes the format verbs of a message, as either would garble the
+// output. Call SetLanguage afterwards to apply the overrides.
+func LoadOverrides(dir string) error {
+	overrides = map[string]map[string]string{}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		messages, err := readLocaleFile(path)
+		if err != nil {
+			return fmt.Errorf(ErrLocaleFile, path, err)
+		}
+		lang := strings.TrimSuffix(e.Name(), ext)
+		if normalized, ok := NormalizeLanguage(lang); ok {
+			lang = normalized
+		}
+		if overrides[lang] == nil {
+			overrides[lang] = map[string]string{}
+		}
+		for key, text := range messages {
+			overrides[lang][key] = text
+			addReverse(text, key)
+		}
+	}
+	return nil
+}
+
+// readLocaleFile parses and checks one locale file.
+func readLocaleFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var messages map[string]string
+	if err := yaml.Unmarshal(data, &messages); err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(messages))
+	for key := range messages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		zh, ok := defaults[key]
+		if !ok {
+			return nil, fmt.Errorf(ErrLocaleUnknownKey, key)
+		}
+		want := formatVerb.FindAllString(zh, -1)
+		if got := formatVerb.FindAllString(messages[key], -1); !sameVerbs(got, want) {
+			return nil, fmt.Errorf(ErrLocaleVerbs, key, got, want)
+		}
+	}
+	return messages, nil
+}
+
+// sameVerbs reports whether got has the verbs of want in the same order; explicitly
+// indexed verbs (%[2]s) may be reordered.
+func sameVerbs(got, want []string) bool {
+	if slices.Equal(got, want) {
+		return true
+	}
+	for _, v := range want {
+		if v[1] != '[' {
+			return false
+		}
+	}
+	got, want = slices.Clone(got), slices.Clone(want)
+	slices.Sort(got)
+	slices.Sort(want)
+	return slices.Equal(got, want)
+}
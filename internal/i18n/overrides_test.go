@@ -0,0 +1,88 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func writeLocale(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadOverrides(t *testing.T) {
+	defer func() {
+		LoadOverrides(t.TempDir())
+		SetLanguage(LangZhTW)
+	}()
+	dir := t.TempDir()
+	writeLocale(t, dir, "zh-TW.yaml", "MsgSuccess: 完成囉\n")
+	writeLocale(t, dir, "en.yml", "MsgSuccess: Done\n")
+	writeLocale(t, dir, "fr.yaml", "MsgSuccess: Succès\nMsgCountCompleted: \"Terminés : %d\"\n")
+	writeLocale(t, dir, "README.md", "not a locale")
+
+	if err := LoadOverrides(dir); err != nil {
+		t.Fatalf("LoadOverrides() error = %v", err)
+	}
+	if got := Languages(); !slices.Equal(got, []string{LangZhTW, LangEn, "fr"}) {
+		t.Errorf("Languages() = %v", got)
+	}
+	if lang, ok := NormalizeLanguage("fr_FR.UTF-8"); !ok || lang != "fr" {
+		t.Errorf("NormalizeLanguage(fr_FR.UTF-8) = %q, %v", lang, ok)
+	}
+
+	tests := []struct {
+		lang string
+		key  string
+		want string
+	}{
+		{LangZhTW, "MsgSuccess", "完成囉"},
+		{LangEn, "MsgSuccess", "Done"},
+		{LangEn, "MsgFailed", "Failed"}, // not overridden: built-in en
+		{"fr", "MsgCountCompleted", "Terminés : %d"},
+		{"fr", "MsgFailed", "失敗"}, // missing: zh-TW
+	}
+	for _, tt := range tests {
+		if err := SetLanguage(tt.lang); err != nil {
+			t.Fatalf("SetLanguage(%s) error = %v", tt.lang, err)
+		}
+		if got := T(tt.key); got != tt.want {
+			t.Errorf("%s: %s = %q, want %q", tt.lang, tt.key, got, tt.want)
+		}
+	}
+	if got := Translate(defaults["MsgSuccess"]); got != "Succès" {
+		t.Errorf("Translate(zh MsgSuccess) in fr = %q", got)
+	}
+}
+
+func TestLoadOverrides_Errors(t *testing.T) {
+	defer LoadOverrides(t.TempDir())
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"unknown key", "MsgNoSuchThing: x\n", "MsgNoSuchThing"},
+		{"dropped verb", "MsgCountCompleted: Completed\n", "MsgCountCompleted"},
+		{"not a map", "- a\n- b\n", "en.yaml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeLocale(t, dir, "en.yaml", tt.content)
+			err := LoadOverrides(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadOverrides() error = %v, want it to mention %s", err, tt.want)
+			}
+		})
+	}
+
+	if err := LoadOverrides(filepath.Join(t.TempDir(), "missing")); err != nil {
+		t.Errorf("LoadOverrides(missing dir) error = %v", err)
+	}
+}
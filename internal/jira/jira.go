@@ -0,0 +1,317 @@
+// Package jira reads issues from the Jira REST API with a JQL query and maps them to
+// tickets, so organisations that plan in Jira can hand that backlog to the orchestrator.
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// System is the RemoteRef.System of tickets imported from Jira.
+const System = "jira"
+
+// pageSize is the number of issues requested per search page.
+const pageSize = 100
+
+// maxResponseSize guards against unexpectedly large API responses.
+const maxResponseSize = 10 << 20
+
+// searchFields are the issue fields requested from the search endpoint.
+const searchFields = "summary,description,priority,issuetype,labels,issuelinks,status"
+
+// Issue is the subset of a Jira issue used for import.
+type Issue struct {
+	Key    string `json:"key"`
+	Fields Fields `json:"fields"`
+}
+
+// Fields are the issue fields requested by Search.
+type Fields struct {
+	Summary     string      `json:"summary"`
+	Description string      `json:"description"`
+	Priority    *Named      `json:"priority"`
+	IssueType   *Named      `json:"issuetype"`
+	Labels      []string    `json:"labels"`
+	IssueLinks  []IssueLink `json:"issuelinks"`
+	Status      *Status     `json:"status"`
+}
+
+// Named is a Jira object identified by its display name (priority, issue type).
+type Named struct {
+	Name string `json:"name"`
+}
+
+// Status is an issue status; its category key is "new", "indeterminate" or "done".
+type Status struct {
+	Name           string `json:"name"`
+	StatusCategory struct {
+		Key string `json:"key"`
+	} `json:"statusCategory"`
+}
+
+// IssueLink links the issue to another. Exactly one of InwardIssue and OutwardIssue is
+// set: InwardIssue means "this issue <Type.Inward> InwardIssue" (e.g. "is blocked by").
+type IssueLink struct {
+	Type         LinkType     `json:"type"`
+	InwardIssue  *LinkedIssue `json:"inwardIssue,omitempty"`
+	OutwardIssue *LinkedIssue `json:"outwardIssue,omitempty"`
+}
+
+// LinkType names a link type and its two directions.
+type LinkType struct {
+	Name    string `json:"name"`
+	Inward  string `json:"inward"`
+	Outward string `json:"outward"`
+}
+
+// LinkedIssue is the other end of an issue link.
+type LinkedIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Status *Status `json:"status"`
+	} `json:"fields"`
+}
+
+// Client talks to the Jira REST API (v2).
+type Client struct {
+	BaseURL string
+	// Email selects basic auth (Jira Cloud: account email + API token); when empty the
+	// token is sent as a bearer token (Jira Server/Data Center personal access token).
+	Email      string
+	Token      string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Client for a Jira site such as https://example.atlassian.net.
+func NewClient(baseURL, email, token string) *Client {
+	return &Client{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		Email:      email,
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// Search returns all issues matching jql, following pagination.
+func (c *Client) Search(ctx context.Context, jql string) ([]Issue, error) {
+	var out []Issue
+	for startAt := 0; ; {
+		q := url.Values{}
+		q.Set("jql", jql)
+		q.Set("startAt", strconv.Itoa(startAt))
+		q.Set("maxResults", strconv.Itoa(pageSize))
+		q.Set("fields", searchFields)
+		data, err := c.get(ctx, fmt.Sprintf("%s/rest/api/2/search?%s", c.BaseURL, q.Encode()))
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			StartAt int     `json:"startAt"`
+			Total   int     `json:"total"`
+			Issues  []Issue `json:"issues"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse search results: %w", err)
+		}
+		out = append(out, page.Issues...)
+		startAt += len(page.Issues)
+		if len(page.Issues) == 0 || startAt >= page.Total {
+			return out, nil
+		}
+	}
+}
+
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "agent-orchestrator")
+	switch {
+	case c.Email != "":
+		req.SetBasicAuth(c.Email, c.Token)
+	case c.Token != "":
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request %s: unexpected status %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", url, err)
+	}
+	if len(data) > maxResponseSize {
+		return nil, fmt.Errorf("response from %s exceeds %d bytes", url, maxResponseSize)
+	}
+	return data, nil
+}
+
+// Mapping controls how Jira fields become ticket fields. Keys of Priorities and Types
+// are matched case-insensitively.
+type Mapping struct {
+	// Priorities maps priority names to ticket priorities (1-5).
+	Priorities map[string]int
+	// Types maps issue type names to ticket types.
+	Types map[string]ticket.Type
+	// DependencyLinks names the link types whose inward direction ("is blocked by")
+	// makes the linked issue a dependency.
+	DependencyLinks []string
+}
+
+// DefaultMapping returns the mapping for Jira's default priorities, issue types and
+// the "Blocks" link type.
+func DefaultMapping() Mapping {
+	return Mapping{
+		Priorities: map[string]int{
+			"highest": 1, "blocker": 1, "critical": 1,
+			"high": 2, "major": 2,
+			"medium": 3,
+			"low":    4, "minor": 4,
+			"lowest": 5, "trivial": 5,
+		},
+		Types: map[string]ticket.Type{
+			"bug":         ticket.TypeBugfix,
+			"story":       ticket.TypeFeature,
+			"task":        ticket.TypeFeature,
+			"new feature": ticket.TypeFeature,
+			"improvement": ticket.TypeRefactor,
+			"sub-task":    ticket.TypeFeature,
+		},
+		DependencyLinks: []string{"blocks"},
+	}
+}
+
+// WithOverrides returns m with the given entries added or replaced; empty link types
+// keep m's. Type names must be valid ticket types.
+func (m Mapping) WithOverrides(priorities map[string]int, types map[string]string, links []string) (Mapping, error) {
+	out := Mapping{
+		Priorities:      make(map[string]int, len(m.Priorities)+len(priorities)),
+		Types:           make(map[string]ticket.Type, len(m.Types)+len(types)),
+		DependencyLinks: m.DependencyLinks,
+	}
+	for k, v := range m.Priorities {
+		out.Priorities[strings.ToLower(k)] = v
+	}
+	for k, v := range priorities {
+		if v < 1 || v > 5 {
+			return m, fmt.Errorf("priority for %q must be between 1 and 5, got %d", k, v)
+		}
+		out.Priorities[strings.ToLower(k)] = v
+	}
+	for k, v := range m.Types {
+		out.Types[strings.ToLower(k)] = v
+	}
+	for k, v := range types {
+		t := ticket.Type(v)
+		switch t {
+		case ticket.TypeFeature, ticket.TypeTest, ticket.TypeRefactor, ticket.TypeDocs,
+			ticket.TypeBugfix, ticket.TypePerf, ticket.TypeSecurity, ticket.TypeEpic:
+		default:
+			return m, fmt.Errorf("invalid ticket type %q for issue type %q", v, k)
+		}
+		out.Types[strings.ToLower(k)] = t
+	}
+	if len(links) > 0 {
+		out.DependencyLinks = links
+	}
+	return out, nil
+}
+
+// ToTickets maps issues to pending tickets with the issue key as ticket ID:
+//   - summary and description become title and description (with a link to the issue);
+//   - priority and issue type are mapped through m; unmapped values keep the defaults;
+//   - labels are kept as ticket labels;
+//   - issues this one "is blocked by" through a DependencyLinks link type become
+//     dependencies, unless the blocker is already done and not part of the import;
+//   - RemoteRef points back to the issue.
+func ToTickets(baseURL string, issues []Issue, m Mapping) []*ticket.Ticket {
+	baseURL = strings.TrimRight(baseURL, "/")
+	imported := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		imported[issue.Key] = true
+	}
+	depLinks := make(map[string]bool, len(m.DependencyLinks))
+	for _, name := range m.DependencyLinks {
+		depLinks[strings.ToLower(name)] = true
+	}
+
+	tickets := make([]*ticket.Ticket, 0, len(issues))
+	for _, issue := range issues {
+		f := issue.Fields
+		browseURL := ""
+		if baseURL != "" {
+			browseURL = baseURL + "/browse/" + issue.Key
+		}
+		t := ticket.NewTicket(issue.Key, f.Summary, description(f.Description, browseURL))
+		if f.Priority != nil {
+			if p, ok := m.Priorities[strings.ToLower(f.Priority.Name)]; ok {
+				t.Priority = p
+			}
+		}
+		if f.IssueType != nil {
+			if typ, ok := m.Types[strings.ToLower(f.IssueType.Name)]; ok {
+				t.Type = typ
+			}
+		}
+		t.Labels = ticket.NormalizeLabels(f.Labels)
+		for _, link := range f.IssueLinks {
+			blocker := link.InwardIssue
+			if blocker == nil || !depLinks[strings.ToLower(link.Type.Name)] {
+				continue
+			}
+			if !imported[blocker.Key] && isDone(blocker.Fields.Status) {
+				continue
+			}
+			t.Dependencies = append(t.Dependencies, blocker.Key)
+		}
+		if project, number, ok := splitKey(issue.Key); ok {
+			t.RemoteRef = &ticket.RemoteRef{System: System, Repo: project, Number: number, URL: browseURL}
+		}
+		tickets = append(tickets, t)
+	}
+	return tickets
+}
+
+func isDone(s *Status) bool {
+	return s != nil && s.StatusCategory.Key == "done"
+}
+
+// splitKey splits an issue key such as "PROJ-123" into project and number.
+func splitKey(key string) (string, int, bool) {
+	i := strings.LastIndex(key, "-")
+	if i <= 0 {
+		return "", 0, false
+	}
+	n, err := strconv.Atoi(key[i+1:])
+	if err != nil {
+		return "", 0, false
+	}
+	return key[:i], n, true
+}
+
+// description returns the issue description followed by a link back to the issue.
+func description(body, browseURL string) string {
+	body = strings.TrimSpace(body)
+	if browseURL == "" {
+		return body
+	}
+	if body == "" {
+		return browseURL
+	}
+	return body + "\n\n" + browseURL
+}
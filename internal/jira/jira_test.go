@@ -0,0 +1,147 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func link(name string, inward, outward *LinkedIssue) IssueLink {
+	return IssueLink{Type: LinkType{Name: name, Inward: "is blocked by", Outward: "blocks"}, InwardIssue: inward, OutwardIssue: outward}
+}
+
+func linked(key, category string) *LinkedIssue {
+	l := &LinkedIssue{Key: key}
+	l.Fields.Status = &Status{}
+	l.Fields.Status.StatusCategory.Key = category
+	return l
+}
+
+func TestToTickets(t *testing.T) {
+	issues := []Issue{
+		{Key: "PROJ-1", Fields: Fields{Summary: "Schema", Priority: &Named{Name: "Highest"}, IssueType: &Named{Name: "Task"}}},
+		{Key: "PROJ-2", Fields: Fields{
+			Summary:     "API",
+			Description: "Build the API",
+			Priority:    &Named{Name: "P0"},
+			IssueType:   &Named{Name: "Bug"},
+			Labels:      []string{"Backend"},
+			IssueLinks: []IssueLink{
+				link("Blocks", linked("PROJ-1", "indeterminate"), nil),
+				link("Blocks", linked("OLD-9", "done"), nil),
+				link("Blocks", linked("OTHER-3", "new"), nil),
+				link("Blocks", nil, linked("PROJ-3", "new")),
+				link("Relates", linked("PROJ-4", "new"), nil),
+			},
+		}},
+		{Key: "PROJ-3", Fields: Fields{Summary: "Docs", Priority: &Named{Name: "Unknown"}, IssueType: &Named{Name: "Spike"}}},
+	}
+	m, err := DefaultMapping().WithOverrides(map[string]int{"p0": 1}, map[string]string{"Spike": "docs"}, nil)
+	if err != nil {
+		t.Fatalf("WithOverrides() err = %v", err)
+	}
+
+	tickets := ToTickets("https://jira.example.com/", issues, m)
+	if len(tickets) != 3 {
+		t.Fatalf("ToTickets() len = %d, want 3", len(tickets))
+	}
+	schema, api, docs := tickets[0], tickets[1], tickets[2]
+
+	if schema.ID != "PROJ-1" || schema.Priority != 1 || schema.Type != ticket.TypeFeature {
+		t.Errorf("schema = %+v", schema)
+	}
+	if schema.Description != "https://jira.example.com/browse/PROJ-1" {
+		t.Errorf("schema.Description = %q", schema.Description)
+	}
+	if api.Priority != 1 || api.Type != ticket.TypeBugfix || len(api.Labels) != 1 || api.Labels[0] != "backend" {
+		t.Errorf("api priority/type/labels = %d %s %v", api.Priority, api.Type, api.Labels)
+	}
+	if len(api.Dependencies) != 2 || api.Dependencies[0] != "PROJ-1" || api.Dependencies[1] != "OTHER-3" {
+		t.Errorf("api.Dependencies = %v, want [PROJ-1 OTHER-3]", api.Dependencies)
+	}
+	if docs.Type != ticket.TypeDocs || docs.Priority != ticket.NewTicket("", "", "").Priority {
+		t.Errorf("docs type/priority = %s %d", docs.Type, docs.Priority)
+	}
+	if ref := api.RemoteRef; ref == nil || ref.System != System || ref.String() != "PROJ-2" || ref.URL != "https://jira.example.com/browse/PROJ-2" {
+		t.Errorf("api.RemoteRef = %+v", ref)
+	}
+	for _, tk := range tickets {
+		if err := tk.Validate(); err != nil {
+			t.Errorf("ticket %s invalid: %v", tk.ID, err)
+		}
+	}
+}
+
+func TestMapping_WithOverrides(t *testing.T) {
+	tests := []struct {
+		name       string
+		priorities map[string]int
+		types      map[string]string
+		links      []string
+		wantErr    bool
+	}{
+		{"no overrides", nil, nil, nil, false},
+		{"valid overrides", map[string]int{"P1": 2}, map[string]string{"Spike": "docs"}, []string{"Depends"}, false},
+		{"priority out of range", map[string]int{"P9": 9}, nil, nil, true},
+		{"unknown ticket type", nil, map[string]string{"Spike": "research"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := DefaultMapping().WithOverrides(tt.priorities, tt.types, tt.links)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WithOverrides() err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if m.Priorities["highest"] != 1 || m.Types["bug"] != ticket.TypeBugfix {
+				t.Error("WithOverrides() should keep the default entries")
+			}
+			if len(tt.links) > 0 && m.DependencyLinks[0] != tt.links[0] {
+				t.Errorf("DependencyLinks = %v, want %v", m.DependencyLinks, tt.links)
+			}
+		})
+	}
+}
+
+func TestClient_Search(t *testing.T) {
+	const total = pageSize + 1
+	var gotUser, gotPass, gotJQL string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/search" {
+			http.NotFound(w, r)
+			return
+		}
+		gotUser, gotPass, _ = r.BasicAuth()
+		gotJQL = r.URL.Query().Get("jql")
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		var issues []map[string]any
+		for i := startAt; i < total && i < startAt+pageSize; i++ {
+			issues = append(issues, map[string]any{"key": fmt.Sprintf("PROJ-%d", i+1), "fields": map[string]any{"summary": "s"}})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"startAt": startAt, "total": total, "issues": issues})
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL+"/", "dev@example.com", "secret")
+	issues, err := c.Search(context.Background(), "project = PROJ")
+	if err != nil {
+		t.Fatalf("Search() err = %v", err)
+	}
+	if len(issues) != total || issues[total-1].Key != fmt.Sprintf("PROJ-%d", total) {
+		t.Errorf("Search() = %d issues, want %d", len(issues), total)
+	}
+	if gotUser != "dev@example.com" || gotPass != "secret" || gotJQL != "project = PROJ" {
+		t.Errorf("request auth = %q/%q, jql = %q", gotUser, gotPass, gotJQL)
+	}
+
+	if _, err := NewClient(srv.URL+"/missing", "", "tok").Search(context.Background(), "x"); err == nil {
+		t.Error("Search() should fail on a non-200 response")
+	}
+}
@@ -0,0 +1,137 @@
+// Package logging sets up the orchestrator's structured logger (log/slog).
+//
+// Diagnostics (agent calls, retries, ticket steps) go through one logger with a text
+// or JSON handler; its level follows --verbose, --debug and --quiet. Records about a
+// ticket carry the same fields everywhere: ticket_id, step and duration (in seconds),
+// so a JSON log can be filtered with e.g. jq 'select(.ticket_id == "TICKET-001")'.
+package logging
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Log formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Formats lists the supported log formats.
+var Formats = []string{FormatText, FormatJSON}
+
+// Field names shared by all records about a ticket.
+const (
+	KeyTicketID = "ticket_id"
+	KeyStep     = "step"
+	KeyDuration = "duration"
+)
+
+// Steps of processing a ticket, the values of the step field.
+const (
+	StepStart    = "start"
+	StepCoding   = "coding"
+	StepVerify   = "verify"
+	StepMerge    = "merge"
+	StepRequeue  = "requeue"
+	StepComplete = "complete"
+	StepFail     = "fail"
+)
+
+// Level returns the minimum level for the global flags: debug logs everything,
+// verbose logs Info and up, quiet only errors, and the default is warnings and errors.
+func Level(verbose, debug, quiet bool) slog.Level {
+	switch {
+	case debug:
+		return slog.LevelDebug
+	case quiet:
+		return slog.LevelError
+	case verbose:
+		return slog.LevelInfo
+	}
+	return slog.LevelWarn
+}
+
+// New returns a logger writing records of at least level to w in format (text when
+// format is empty or unknown).
+func New(w io.Writer, format string, level slog.Leveler) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: level, ReplaceAttr: replaceAttr}
+	if format == FormatJSON {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// replaceAttr writes durations as seconds, which both handlers print as plain numbers.
+func replaceAttr(_ []string, a slog.Attr) slog.Attr {
+	if a.Value.Kind() == slog.KindDuration {
+		return slog.Float64(a.Key, a.Value.Duration().Round(time.Millisecond).Seconds())
+	}
+	return a
+}
+
+// Ticket returns l with the ticket_id field set to id.
+func Ticket(l *slog.Logger, id string) *slog.Logger {
+	return l.With(KeyTicketID, id)
+}
+
+// Step returns the step field.
+func Step(step string) slog.Attr {
+	return slog.String(KeyStep, step)
+}
+
+// Since returns the duration field for the time elapsed since start.
+func Since(start time.Time) slog.Attr {
+	return slog.Duration(KeyDuration, time.Since(start))
+}
+
+// Writer adapts l to an io.Writer: every line written becomes the message of a record
+// at level, so plain-text output (e.g. of a definition-of-done check) ends up in the
+// same log as the structured records. Partial lines are kept until their newline or Flush.
+type Writer struct {
+	mu    sync.Mutex
+	l     *slog.Logger
+	level slog.Level
+	buf   []byte
+}
+
+// NewWriter returns a Writer logging lines to l at level.
+func NewWriter(l *slog.Logger, level slog.Level) *Writer {
+	return &Writer{l: l, level: level}
+}
+
+// Write logs every complete line of p.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		w.log(w.buf[:i])
+		w.buf = w.buf[i+1:]
+	}
+}
+
+// Flush logs a buffered partial line.
+func (w *Writer) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buf) > 0 {
+		w.log(w.buf)
+		w.buf = nil
+	}
+	return nil
+}
+
+func (w *Writer) log(line []byte) {
+	if len(bytes.TrimSpace(line)) > 0 {
+		w.l.Log(context.Background(), w.level, string(bytes.TrimRight(line, "\r")))
+	}
+}
@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLevel(t *testing.T) {
+	tests := []struct {
+		name                  string
+		verbose, debug, quiet bool
+		want                  slog.Level
+	}{
+		{"default", false, false, false, slog.LevelWarn},
+		{"verbose", true, false, false, slog.LevelInfo},
+		{"debug", true, true, false, slog.LevelDebug},
+		{"quiet", false, false, true, slog.LevelError},
+		{"debug wins over quiet", false, true, true, slog.LevelDebug},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Level(tt.verbose, tt.debug, tt.quiet); got != tt.want {
+				t.Errorf("Level() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew_JSONTicketFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := Ticket(New(&buf, FormatJSON, slog.LevelInfo), "TICKET-001")
+	l.Info("done", Step(StepComplete), slog.Duration(KeyDuration, 1500*time.Millisecond))
+	l.Debug("hidden")
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("want one JSON record, got %q: %v", buf.String(), err)
+	}
+	if rec[KeyTicketID] != "TICKET-001" || rec[KeyStep] != StepComplete || rec[KeyDuration] != 1.5 {
+		t.Errorf("record = %v, want ticket_id, step and duration in seconds", rec)
+	}
+}
+
+func TestNew_Text(t *testing.T) {
+	var buf bytes.Buffer
+	New(&buf, "", slog.LevelWarn).Warn("retrying", KeyDuration, 2*time.Second)
+	if out := buf.String(); !strings.Contains(out, "level=WARN") || !strings.Contains(out, "duration=2") {
+		t.Errorf("text record = %q", out)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(New(&buf, FormatJSON, slog.LevelInfo), slog.LevelInfo)
+	w.Write([]byte("first line\nsecond "))
+	w.Write([]byte("line\n\n"))
+	w.Write([]byte("partial"))
+	w.Flush()
+
+	var msgs []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var rec struct{ Msg string }
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("line %q is not a JSON record: %v", line, err)
+		}
+		msgs = append(msgs, rec.Msg)
+	}
+	if want := []string{"first line", "second line", "partial"}; strings.Join(msgs, "|") != strings.Join(want, "|") {
+		t.Errorf("messages = %q, want %q", msgs, want)
+	}
+}
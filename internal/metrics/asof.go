@@ -0,0 +1,32 @@
+package metrics
+
+import "time"
+
+// StateRunning is returned by StatesAt for a ticket whose run was under way at the
+// requested time.
+const StateRunning = "running"
+
+// StatesAt reconstructs from the history what each ticket's runs had produced at
+// time at: StateRunning when a run spanned at, otherwise the outcome of the last run
+// finished by then. Tickets without a run started by at are absent.
+func StatesAt(records []Record, at time.Time) map[string]string {
+	states := make(map[string]string)
+	finished := make(map[string]time.Time)
+	for _, r := range records {
+		if r.StartedAt.After(at) {
+			continue
+		}
+		end := r.StartedAt.Add(r.Duration)
+		if end.After(at) {
+			states[r.TicketID] = StateRunning
+			finished[r.TicketID] = at
+			continue
+		}
+		if prev, ok := finished[r.TicketID]; ok && prev.After(end) {
+			continue
+		}
+		states[r.TicketID] = r.Outcome
+		finished[r.TicketID] = end
+	}
+	return states
+}
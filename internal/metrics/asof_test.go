@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatesAt(t *testing.T) {
+	base := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	records := []Record{
+		{TicketID: "T-1", Outcome: OutcomeFailed, StartedAt: base, Duration: time.Minute},
+		{TicketID: "T-1", Outcome: OutcomeCompleted, StartedAt: base.Add(time.Hour), Duration: time.Minute},
+		{TicketID: "T-2", Outcome: OutcomeCompleted, StartedAt: base.Add(10 * time.Minute), Duration: 20 * time.Minute},
+		// Parallel runs may be appended out of start order.
+		{TicketID: "T-3", Outcome: OutcomeCompleted, StartedAt: base.Add(5 * time.Minute), Duration: 30 * time.Minute},
+		{TicketID: "T-3", Outcome: OutcomeFailed, StartedAt: base, Duration: time.Minute},
+	}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want map[string]string
+	}{
+		{"before any run", base.Add(-time.Minute), map[string]string{}},
+		{"during first run", base.Add(30 * time.Second), map[string]string{"T-1": StateRunning, "T-3": StateRunning}},
+		{"after failure, during other runs", base.Add(15 * time.Minute), map[string]string{"T-1": OutcomeFailed, "T-2": StateRunning, "T-3": StateRunning}},
+		{"all finished", base.Add(2 * time.Hour), map[string]string{"T-1": OutcomeCompleted, "T-2": OutcomeCompleted, "T-3": OutcomeCompleted}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := StatesAt(records, tt.at)
+			if len(got) != len(tt.want) {
+				t.Fatalf("StatesAt() = %v, want %v", got, tt.want)
+			}
+			for id, state := range tt.want {
+				if got[id] != state {
+					t.Errorf("StatesAt()[%s] = %q, want %q", id, got[id], state)
+				}
+			}
+		})
+	}
+}
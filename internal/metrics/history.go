@@ -0,0 +1,105 @@
+// Package metrics records per-ticket run history and derives aggregate statistics
+// (average completion time, failure rate, remaining complexity) from it.
+//
+// The history is an append-only JSON Lines file, one Record per processed ticket,
+// so concurrent workers and later runs only ever append.
+package metrics
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Outcome values recorded for a ticket run.
+const (
+	OutcomeCompleted = "completed"
+	OutcomeFailed    = "failed"
+)
+
+// Record is a single ticket run in the metrics history.
+type Record struct {
+	TicketID   string        `json:"ticket_id"`
+	Type       string        `json:"type"`
+	Complexity string        `json:"complexity,omitempty"`
+	Outcome    string        `json:"outcome"`
+	StartedAt  time.Time     `json:"started_at"`
+	Duration   time.Duration `json:"duration_ns"`
+	// Operator is who triggered the run (configured audit identity or OS user).
+	Operator string `json:"operator,omitempty"`
+}
+
+// appendMu serializes appends from concurrent workers in the same process.
+var appendMu sync.Mutex
+
+// Append writes a record to the history file at path, creating the file (0600) and
+// its directory (0700) if needed.
+func Append(path string, r Record) error {
+	return appendJSONL(path, "metrics history", r)
+}
+
+// Load reads all records from the history file in append order.
+// A missing file yields an empty history; malformed lines are skipped.
+func Load(path string) ([]Record, error) {
+	return loadJSONL[Record](path, "metrics history")
+}
+
+// appendJSONL appends v as one JSON line to the file at path; what names the file in errors.
+func appendJSONL(path, what string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s record: %w", what, err)
+	}
+
+	appendMu.Lock()
+	defer appendMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", what, err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", what, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write %s: %w", what, err)
+	}
+	return nil
+}
+
+// loadJSONL reads the JSON Lines file at path in append order. A missing file yields
+// no records; malformed lines are skipped.
+func loadJSONL[T any](path, what string) ([]T, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open %s: %w", what, err)
+	}
+	defer f.Close()
+
+	records := make([]T, 0)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var r T
+		if err := json.Unmarshal(line, &r); err != nil {
+			continue
+		}
+		records = append(records, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return records, fmt.Errorf("failed to read %s: %w", what, err)
+	}
+	return records, nil
+}
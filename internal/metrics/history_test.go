@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAppendAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "metrics.jsonl")
+	start := time.Date(2026, 1, 30, 10, 0, 0, 0, time.UTC)
+
+	records := []Record{
+		{TicketID: "T-1", Type: "feature", Complexity: "low", Outcome: OutcomeCompleted, StartedAt: start, Duration: time.Minute},
+		{TicketID: "T-2", Type: "bugfix", Outcome: OutcomeFailed, StartedAt: start, Duration: 2 * time.Minute},
+	}
+	for _, r := range records {
+		if err := Append(path, r); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat history: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm&0077 != 0 {
+		t.Errorf("history file permissions = %o, want no group/other access", perm)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Load() returned %d records, want 2", len(got))
+	}
+	if got[0].TicketID != "T-1" || got[1].Outcome != OutcomeFailed || got[1].Duration != 2*time.Minute {
+		t.Errorf("Load() = %+v, want records in append order", got)
+	}
+}
+
+func TestLoad_MissingFileAndMalformedLines(t *testing.T) {
+	dir := t.TempDir()
+
+	got, err := Load(filepath.Join(dir, "missing.jsonl"))
+	if err != nil || len(got) != 0 {
+		t.Errorf("Load(missing) = %v, %v; want empty, nil", got, err)
+	}
+
+	path := filepath.Join(dir, "metrics.jsonl")
+	content := `{"ticket_id":"T-1","type":"feature","outcome":"completed"}
+not json
+
+{"ticket_id":"T-2","type":"docs","outcome":"failed"}
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("Load() returned %d records, want 2 (malformed line skipped)", len(got))
+	}
+}
+
+func TestAppend_Concurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.jsonl")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = Append(path, Record{TicketID: "T", Type: "feature", Outcome: OutcomeCompleted})
+		}()
+	}
+	wg.Wait()
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(got) != 20 {
+		t.Errorf("Load() returned %d records, want 20", len(got))
+	}
+}
@@ -0,0 +1,43 @@
+package metrics
+
+import "time"
+
+// OutcomesSince returns the tickets whose last run started at or after since, split by
+// the outcome of that run, in the order the runs were recorded.
+func OutcomesSince(records []Record, since time.Time) (completed, failed []string) {
+	last := make(map[string]string)
+	var order []string
+	for _, r := range records {
+		if r.StartedAt.Before(since) {
+			continue
+		}
+		if _, ok := last[r.TicketID]; !ok {
+			order = append(order, r.TicketID)
+		}
+		last[r.TicketID] = r.Outcome
+	}
+	for _, id := range order {
+		switch last[id] {
+		case OutcomeCompleted:
+			completed = append(completed, id)
+		case OutcomeFailed:
+			failed = append(failed, id)
+		}
+	}
+	return completed, failed
+}
+
+// ConsecutiveFailures returns how many of the latest runs of ticketID failed in a row.
+func ConsecutiveFailures(records []Record, ticketID string) int {
+	n := 0
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].TicketID != ticketID {
+			continue
+		}
+		if records[i].Outcome != OutcomeFailed {
+			break
+		}
+		n++
+	}
+	return n
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOutcomesSince(t *testing.T) {
+	base := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	records := []Record{
+		{TicketID: "T-0", Outcome: OutcomeFailed, StartedAt: base.Add(-time.Hour)},
+		{TicketID: "T-1", Outcome: OutcomeFailed, StartedAt: base},
+		{TicketID: "T-2", Outcome: OutcomeFailed, StartedAt: base.Add(time.Minute)},
+		{TicketID: "T-1", Outcome: OutcomeCompleted, StartedAt: base.Add(2 * time.Minute)},
+		{TicketID: "T-3", Outcome: OutcomeCompleted, StartedAt: base.Add(3 * time.Minute)},
+	}
+	completed, failed := OutcomesSince(records, base)
+	if !reflect.DeepEqual(completed, []string{"T-1", "T-3"}) || !reflect.DeepEqual(failed, []string{"T-2"}) {
+		t.Errorf("OutcomesSince() = %v, %v; want [T-1 T-3], [T-2]", completed, failed)
+	}
+}
+
+func TestConsecutiveFailures(t *testing.T) {
+	records := []Record{
+		{TicketID: "T-1", Outcome: OutcomeFailed},
+		{TicketID: "T-1", Outcome: OutcomeCompleted},
+		{TicketID: "T-1", Outcome: OutcomeFailed},
+		{TicketID: "T-2", Outcome: OutcomeFailed},
+		{TicketID: "T-1", Outcome: OutcomeFailed},
+	}
+	tests := []struct {
+		id   string
+		want int
+	}{
+		{"T-1", 2},
+		{"T-2", 1},
+		{"T-3", 0},
+	}
+	for _, tt := range tests {
+		if got := ConsecutiveFailures(records, tt.id); got != tt.want {
+			t.Errorf("ConsecutiveFailures(%s) = %d, want %d", tt.id, got, tt.want)
+		}
+	}
+}
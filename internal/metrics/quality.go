@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"strings"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// Severity weights of the technical-debt score: one HIGH issue outweighs several
+// lower ones, so fixing it moves the score visibly.
+const (
+	WeightHigh = 10
+	WeightMed  = 3
+	WeightLow  = 1
+)
+
+// QualityRecord is the result of one analyze run in the score history.
+type QualityRecord struct {
+	At time.Time `json:"at"`
+	// Scope is the analyzed scope (e.g. "all" or "security,test"); only runs with the
+	// same scope are comparable.
+	Scope string `json:"scope"`
+	// Score is the severity-weighted technical-debt score; lower is better.
+	Score  int `json:"score"`
+	Issues int `json:"issues"`
+	High   int `json:"high"`
+	Med    int `json:"med"`
+	Low    int `json:"low"`
+}
+
+// NewQualityRecord summarizes an analyze run over scope that found issues, scoring
+// them by severity. Issues with an unknown severity count as LOW.
+func NewQualityRecord(at time.Time, scope []string, issues []*ticket.Issue) QualityRecord {
+	r := QualityRecord{At: at, Scope: strings.Join(scope, ","), Issues: len(issues)}
+	for _, issue := range issues {
+		switch ticket.SeverityRank(issue.Severity) {
+		case 3:
+			r.High++
+		case 2:
+			r.Med++
+		default:
+			r.Low++
+		}
+	}
+	r.Score = r.High*WeightHigh + r.Med*WeightMed + r.Low*WeightLow
+	return r
+}
+
+// AppendQuality writes a record to the score history at path, creating the file
+// (0600) and its directory (0700) if needed.
+func AppendQuality(path string, r QualityRecord) error {
+	return appendJSONL(path, "quality history", r)
+}
+
+// LoadQuality reads all records from the score history in append order.
+// A missing file yields an empty history; malformed lines are skipped.
+func LoadQuality(path string) ([]QualityRecord, error) {
+	return loadJSONL[QualityRecord](path, "quality history")
+}
+
+// QualityTrend returns the records with the same scope as the latest one, oldest
+// first, limited to the last n (all when n <= 0).
+func QualityTrend(records []QualityRecord, n int) []QualityRecord {
+	if len(records) == 0 {
+		return nil
+	}
+	scope := records[len(records)-1].Scope
+	trend := make([]QualityRecord, 0, len(records))
+	for _, r := range records {
+		if r.Scope == scope {
+			trend = append(trend, r)
+		}
+	}
+	if n > 0 && len(trend) > n {
+		trend = trend[len(trend)-n:]
+	}
+	return trend
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func TestNewQualityRecord(t *testing.T) {
+	issues := []*ticket.Issue{
+		{Severity: "HIGH"}, {Severity: "high"},
+		{Severity: "MED"}, {Severity: "MEDIUM"},
+		{Severity: "LOW"}, {Severity: "unknown"},
+	}
+	r := NewQualityRecord(time.Now(), []string{"security", "test"}, issues)
+	if r.High != 2 || r.Med != 2 || r.Low != 2 || r.Issues != 6 {
+		t.Errorf("counts = %d/%d/%d of %d, want 2/2/2 of 6", r.High, r.Med, r.Low, r.Issues)
+	}
+	if want := 2*WeightHigh + 2*WeightMed + 2*WeightLow; r.Score != want {
+		t.Errorf("Score = %d, want %d", r.Score, want)
+	}
+	if r.Scope != "security,test" {
+		t.Errorf("Scope = %q", r.Scope)
+	}
+	if empty := NewQualityRecord(time.Now(), nil, nil); empty.Score != 0 {
+		t.Errorf("Score of no issues = %d, want 0", empty.Score)
+	}
+}
+
+func TestQualityHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quality.jsonl")
+	if got, err := LoadQuality(path); err != nil || len(got) != 0 {
+		t.Fatalf("LoadQuality(missing) = %v, %v", got, err)
+	}
+	for _, r := range []QualityRecord{
+		{Scope: "all", Score: 30},
+		{Scope: "security", Score: 5},
+		{Scope: "all", Score: 25},
+		{Scope: "all", Score: 12},
+	} {
+		if err := AppendQuality(path, r); err != nil {
+			t.Fatalf("AppendQuality() error = %v", err)
+		}
+	}
+	records, err := LoadQuality(path)
+	if err != nil || len(records) != 4 {
+		t.Fatalf("LoadQuality() = %d records, %v", len(records), err)
+	}
+
+	tests := []struct {
+		records []QualityRecord
+		n       int
+		want    []int
+	}{
+		{records, 0, []int{30, 25, 12}},
+		{records, 2, []int{25, 12}},
+		{records[:2], 0, []int{5}},
+		{nil, 0, nil},
+	}
+	for _, tt := range tests {
+		trend := QualityTrend(tt.records, tt.n)
+		var got []int
+		for _, r := range trend {
+			got = append(got, r.Score)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("QualityTrend(n=%d) = %v, want %v", tt.n, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("QualityTrend(n=%d) = %v, want %v", tt.n, got, tt.want)
+				break
+			}
+		}
+	}
+}
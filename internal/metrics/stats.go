@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultRecentRuns is the number of most recent runs used for the failure rate.
+const DefaultRecentRuns = 20
+
+// Stats is an aggregate snapshot derived from the metrics history.
+type Stats struct {
+	// AvgDurationByType is the average duration of completed runs per ticket type.
+	AvgDurationByType map[string]time.Duration
+	// RecentRuns is the number of runs considered for FailureRate (at most the requested N).
+	RecentRuns int
+	// RecentFailed is the number of failed runs among RecentRuns.
+	RecentFailed int
+}
+
+// FailureRate returns RecentFailed/RecentRuns, or 0 when there are no runs.
+func (s Stats) FailureRate() float64 {
+	if s.RecentRuns == 0 {
+		return 0
+	}
+	return float64(s.RecentFailed) / float64(s.RecentRuns)
+}
+
+// Types returns the ticket types present in AvgDurationByType, sorted by name.
+func (s Stats) Types() []string {
+	types := make([]string, 0, len(s.AvgDurationByType))
+	for t := range s.AvgDurationByType {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Summarize computes Stats from records in append order. The failure rate is taken over
+// the last recentRuns records (all records when recentRuns <= 0).
+func Summarize(records []Record, recentRuns int) Stats {
+	stats := Stats{AvgDurationByType: make(map[string]time.Duration)}
+
+	totals := make(map[string]time.Duration)
+	counts := make(map[string]int)
+	for _, r := range records {
+		if r.Outcome != OutcomeCompleted {
+			continue
+		}
+		totals[r.Type] += r.Duration
+		counts[r.Type]++
+	}
+	for t, total := range totals {
+		stats.AvgDurationByType[t] = total / time.Duration(counts[t])
+	}
+
+	recent := records
+	if recentRuns > 0 && len(recent) > recentRuns {
+		recent = recent[len(recent)-recentRuns:]
+	}
+	stats.RecentRuns = len(recent)
+	for _, r := range recent {
+		if r.Outcome == OutcomeFailed {
+			stats.RecentFailed++
+		}
+	}
+	return stats
+}
+
+// ComplexityPoints maps an estimated complexity (low/medium/high) to points: 1, 2, 3.
+// Unknown or empty values count as medium.
+func ComplexityPoints(complexity string) int {
+	switch strings.ToLower(strings.TrimSpace(complexity)) {
+	case "low":
+		return 1
+	case "high":
+		return 3
+	default:
+		return 2
+	}
+}
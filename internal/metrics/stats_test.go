@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	records := []Record{
+		{Type: "feature", Outcome: OutcomeCompleted, Duration: 1 * time.Minute},
+		{Type: "feature", Outcome: OutcomeCompleted, Duration: 3 * time.Minute},
+		{Type: "bugfix", Outcome: OutcomeFailed, Duration: 10 * time.Minute},
+		{Type: "bugfix", Outcome: OutcomeCompleted, Duration: 30 * time.Second},
+		{Type: "docs", Outcome: OutcomeFailed, Duration: time.Minute},
+	}
+
+	stats := Summarize(records, 0)
+	if got := stats.AvgDurationByType["feature"]; got != 2*time.Minute {
+		t.Errorf("avg feature = %v, want 2m", got)
+	}
+	if got := stats.AvgDurationByType["bugfix"]; got != 30*time.Second {
+		t.Errorf("avg bugfix = %v, want 30s (failed runs excluded)", got)
+	}
+	if _, ok := stats.AvgDurationByType["docs"]; ok {
+		t.Error("types with no completed runs should have no average")
+	}
+	if stats.RecentRuns != 5 || stats.RecentFailed != 2 {
+		t.Errorf("recent = %d/%d, want 2/5", stats.RecentFailed, stats.RecentRuns)
+	}
+	if got := stats.FailureRate(); got != 0.4 {
+		t.Errorf("FailureRate() = %v, want 0.4", got)
+	}
+	if types := stats.Types(); len(types) != 2 || types[0] != "bugfix" || types[1] != "feature" {
+		t.Errorf("Types() = %v, want [bugfix feature]", types)
+	}
+
+	recent := Summarize(records, 2)
+	if recent.RecentRuns != 2 || recent.RecentFailed != 1 {
+		t.Errorf("last 2 runs = %d/%d, want 1/2", recent.RecentFailed, recent.RecentRuns)
+	}
+}
+
+func TestSummarize_Empty(t *testing.T) {
+	stats := Summarize(nil, DefaultRecentRuns)
+	if stats.RecentRuns != 0 || stats.FailureRate() != 0 || len(stats.Types()) != 0 {
+		t.Errorf("Summarize(nil) = %+v, want zero stats", stats)
+	}
+}
+
+func TestComplexityPoints(t *testing.T) {
+	tests := map[string]int{"low": 1, "medium": 2, "High": 3, "": 2, "unknown": 2}
+	for in, want := range tests {
+		if got := ComplexityPoints(in); got != want {
+			t.Errorf("ComplexityPoints(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Package notify delivers events that need an operator's attention (e.g. work aborted
+// after repeated systemic failures) to the sinks configured in the notifications:
+// section. Delivery is best-effort: a failing sink is reported but never fails the
+// operation that raised the event.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Event kinds.
+const (
+	// KindAborted is sent when work or run stops early, e.g. on repeated systemic failures.
+	KindAborted = "aborted"
+	// KindRunFinished is sent when run has finished its pipeline.
+	KindRunFinished = "run_finished"
+	// KindWorkFinished is sent when background work (work --detach) exits.
+	KindWorkFinished = "work_finished"
+	// KindRepeatedFailure is sent when a ticket keeps failing run after run.
+	KindRepeatedFailure = "repeated_failure"
+)
+
+// Event is one notification.
+type Event struct {
+	Kind    string    `json:"kind"`
+	Title   string    `json:"title"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+	// Fields holds event details such as the failure class or the log path.
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// Sink delivers events to one destination.
+type Sink interface {
+	Notify(ctx context.Context, e Event) error
+}
+
+// CommandSink runs a shell command per event, with the event as JSON on stdin and
+// AO_EVENT, AO_TITLE and AO_MESSAGE in the environment.
+type CommandSink struct {
+	Command string
+	Timeout time.Duration
+}
+
+// DefaultCommandTimeout bounds a notification command when CommandSink.Timeout is unset.
+const DefaultCommandTimeout = 30 * time.Second
+
+func (s CommandSink) Notify(ctx context.Context, e Event) error {
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = DefaultCommandTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", s.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", s.Command)
+	}
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(), "AO_EVENT="+e.Kind, "AO_TITLE="+e.Title, "AO_MESSAGE="+e.Message)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("notification command: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Notifier fans events out to sinks.
+type Notifier struct {
+	Sinks []Sink
+}
+
+// Enabled reports whether any sink is configured.
+func (n *Notifier) Enabled() bool {
+	return n != nil && len(n.Sinks) > 0
+}
+
+// Send delivers e to every sink and returns their errors joined. Time defaults to now.
+func (n *Notifier) Send(ctx context.Context, e Event) error {
+	if !n.Enabled() {
+		return nil
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	var errs []error
+	for _, s := range n.Sinks {
+		if err := s.Notify(ctx, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,71 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCommandSink_Notify(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test command requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	sink := CommandSink{Command: "cat > " + filepath.Join(dir, "event.json") + ` && printf '%s' "$AO_EVENT" > ` + filepath.Join(dir, "kind")}
+	e := Event{Kind: KindAborted, Title: "work aborted", Message: "3 failures", Fields: map[string]string{"class": "auth"}}
+	if err := sink.Notify(context.Background(), e); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "event.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Event
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("stdin is not an event: %v", err)
+	}
+	if got.Title != e.Title || got.Fields["class"] != "auth" {
+		t.Errorf("event = %+v, want %+v", got, e)
+	}
+	if kind, _ := os.ReadFile(filepath.Join(dir, "kind")); string(kind) != KindAborted {
+		t.Errorf("AO_EVENT = %q, want %q", kind, KindAborted)
+	}
+
+	err = CommandSink{Command: "echo boom >&2; exit 3"}.Notify(context.Background(), e)
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Notify() error = %v, want the command's output", err)
+	}
+}
+
+type fakeSink struct {
+	events []Event
+	err    error
+}
+
+func (s *fakeSink) Notify(_ context.Context, e Event) error {
+	s.events = append(s.events, e)
+	return s.err
+}
+
+func TestNotifier_Send(t *testing.T) {
+	if err := (&Notifier{}).Send(context.Background(), Event{}); err != nil {
+		t.Errorf("Send() without sinks = %v, want nil", err)
+	}
+
+	failing := &fakeSink{err: errors.New("unreachable")}
+	ok := &fakeSink{}
+	n := &Notifier{Sinks: []Sink{failing, ok}}
+	err := n.Send(context.Background(), Event{Kind: KindAborted})
+	if err == nil || !strings.Contains(err.Error(), "unreachable") {
+		t.Errorf("Send() error = %v, want the failing sink's error", err)
+	}
+	if len(ok.events) != 1 || ok.events[0].Time.IsZero() {
+		t.Errorf("every sink should receive the event with a time, got %+v", ok.events)
+	}
+}
@@ -0,0 +1,95 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SlackSink posts events to a Slack incoming webhook as a message with the title as
+// header, the message as text and the fields as a two-column list.
+type SlackSink struct {
+	WebhookURL string
+	// Client defaults to http.DefaultClient; requests time out after DefaultSlackTimeout.
+	Client *http.Client
+}
+
+// DefaultSlackTimeout bounds a webhook request.
+const DefaultSlackTimeout = 10 * time.Second
+
+// slackFieldsPerSection is the most fields Slack accepts in one section block.
+const slackFieldsPerSection = 10
+
+func (s SlackSink) Notify(ctx context.Context, e Event) error {
+	ctx, cancel := context.WithTimeout(ctx, DefaultSlackTimeout)
+	defer cancel()
+
+	payload, err := json.Marshal(SlackMessage(e))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("slack webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("slack webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("slack webhook: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// SlackMessage returns the webhook payload for e: Block Kit blocks plus a plain text
+// fallback used in notifications. Fields are listed in key order.
+func SlackMessage(e Event) map[string]any {
+	blocks := []map[string]any{
+		{"type": "header", "text": map[string]any{"type": "plain_text", "text": e.Title}},
+	}
+	if e.Message != "" {
+		blocks = append(blocks, map[string]any{"type": "section", "text": mrkdwn(e.Message)})
+	}
+
+	keys := make([]string, 0, len(e.Fields))
+	for k := range e.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var fields []map[string]any
+	for _, k := range keys {
+		label := strings.ReplaceAll(k, "_", " ")
+		fields = append(fields, mrkdwn("*"+label+"*\n"+e.Fields[k]))
+		if len(fields) == slackFieldsPerSection {
+			blocks = append(blocks, map[string]any{"type": "section", "fields": fields})
+			fields = nil
+		}
+	}
+	if len(fields) > 0 {
+		blocks = append(blocks, map[string]any{"type": "section", "fields": fields})
+	}
+
+	text := e.Title
+	if e.Message != "" {
+		text += "\n" + e.Message
+	}
+	return map[string]any{"text": text, "blocks": blocks}
+}
+
+func mrkdwn(text string) map[string]any {
+	return map[string]any{"type": "mrkdwn", "text": text}
+}
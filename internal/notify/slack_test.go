@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSlackMessage(t *testing.T) {
+	e := Event{Kind: KindRunFinished, Title: "run finished", Message: "3 completed, 1 failed",
+		Fields: map[string]string{"failed_tickets": "T-2", "completed": "3"}}
+	msg := SlackMessage(e)
+	if msg["text"] != "run finished\n3 completed, 1 failed" {
+		t.Errorf("text = %q", msg["text"])
+	}
+	blocks := msg["blocks"].([]map[string]any)
+	if len(blocks) != 3 {
+		t.Fatalf("blocks = %v, want header, message and fields", blocks)
+	}
+	fields := blocks[2]["fields"].([]map[string]any)
+	if len(fields) != 2 || fields[0]["text"] != "*completed*\n3" || fields[1]["text"] != "*failed tickets*\nT-2" {
+		t.Errorf("fields = %v, want completed then failed tickets", fields)
+	}
+
+	// Slack accepts at most 10 fields per section.
+	many := Event{Title: "t", Fields: map[string]string{}}
+	for i := 0; i < 12; i++ {
+		many.Fields[fmt.Sprintf("f%02d", i)] = "v"
+	}
+	blocks = SlackMessage(many)["blocks"].([]map[string]any)
+	if len(blocks) != 3 || len(blocks[1]["fields"].([]map[string]any)) != 10 {
+		t.Errorf("blocks = %v, want header and fields split 10 + 2", blocks)
+	}
+}
+
+func TestSlackSink_Notify(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Content-Type = %q", r.Header.Get("Content-Type"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Errorf("body is not JSON: %v", err)
+		}
+		if strings.HasSuffix(r.URL.Path, "/revoked") {
+			http.Error(w, "invalid_token", http.StatusForbidden)
+		}
+	}))
+	defer srv.Close()
+
+	e := Event{Kind: KindWorkFinished, Title: "work finished"}
+	if err := (SlackSink{WebhookURL: srv.URL + "/hook"}).Notify(context.Background(), e); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if got["text"] != "work finished" {
+		t.Errorf("posted text = %v", got["text"])
+	}
+
+	err := SlackSink{WebhookURL: srv.URL + "/revoked"}.Notify(context.Background(), e)
+	if err == nil || !strings.Contains(err.Error(), "invalid_token") {
+		t.Errorf("Notify() error = %v, want the webhook's response", err)
+	}
+}
@@ -0,0 +1,91 @@
+// Package progress writes machine-readable progress events as JSON Lines, one event per
+// line, so wrappers and CI jobs can follow a work or run invocation while it runs.
+package progress
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Formats accepted by --progress-format.
+const (
+	// FormatText is the default: progress is only shown as human output.
+	FormatText = "text"
+	// FormatJSONL additionally writes one JSON event per line.
+	FormatJSONL = "jsonl"
+)
+
+// Event types.
+const (
+	TypeTicketStarted   = "ticket_started"
+	TypeTicketCompleted = "ticket_completed"
+	TypeStepChanged     = "step_changed"
+	TypeRunSummary      = "run_summary"
+)
+
+// Event is one progress event. Fields that do not apply to Type are omitted.
+type Event struct {
+	Type    string    `json:"type"`
+	Time    time.Time `json:"time"`
+	Command string    `json:"command,omitempty"`
+
+	// ticket_started, ticket_completed
+	TicketID string `json:"ticket_id,omitempty"`
+	Title    string `json:"title,omitempty"`
+	// Status is the ticket status after processing, e.g. "completed" or "failed".
+	Status string `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+
+	// step_changed: Step is a stable name such as "planning" or "coding"; Index is 1-based.
+	Step  string `json:"step,omitempty"`
+	Index int    `json:"index,omitempty"`
+	Total int    `json:"total,omitempty"`
+
+	// run_summary
+	Counts *Counts `json:"counts,omitempty"`
+
+	// ticket_completed, run_summary
+	Tokens     int     `json:"tokens,omitempty"`
+	CostUSD    float64 `json:"cost_usd,omitempty"`
+	DurationMS int64   `json:"duration_ms,omitempty"`
+}
+
+// Counts are the ticket outcomes of a run; zero counts are kept in the JSON.
+type Counts struct {
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+	Skipped   int `json:"skipped"`
+}
+
+// Emitter writes events to w. It is safe for concurrent use, and a nil *Emitter
+// discards events, so callers need not check whether progress output is enabled.
+type Emitter struct {
+	mu  sync.Mutex
+	w   io.Writer
+	now func() time.Time
+}
+
+// NewEmitter returns an Emitter writing JSON Lines to w.
+func NewEmitter(w io.Writer) *Emitter {
+	return &Emitter{w: w, now: time.Now}
+}
+
+// Emit writes e as one line, setting e.Time when it is zero. Write errors are ignored:
+// a reader that went away must not stop the run.
+func (e *Emitter) Emit(ev Event) {
+	if e == nil {
+		return
+	}
+	if ev.Time.IsZero() {
+		ev.Time = e.now()
+	}
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, _ = e.w.Write(append(data, '\n'))
+}
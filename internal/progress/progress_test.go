@@ -0,0 +1,78 @@
+package progress
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEmitter_Emit(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewEmitter(&buf)
+	fixed := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	e.now = func() time.Time { return fixed }
+
+	e.Emit(Event{Type: TypeTicketStarted, TicketID: "TICKET-001", Title: "Add login"})
+	e.Emit(Event{Type: TypeRunSummary, Command: "work", Counts: &Counts{Completed: 2, Failed: 1}})
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), buf.String())
+	}
+	want := `{"type":"ticket_started","time":"2026-01-02T03:04:05Z","ticket_id":"TICKET-001","title":"Add login"}`
+	if lines[0] != want {
+		t.Errorf("line 1 = %s\nwant     %s", lines[0], want)
+	}
+	var summary Event
+	if err := json.Unmarshal([]byte(lines[1]), &summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary.Type != TypeRunSummary || summary.Counts == nil || *summary.Counts != (Counts{Completed: 2, Failed: 1}) || summary.TicketID != "" {
+		t.Errorf("summary = %+v", summary)
+	}
+}
+
+func TestEmitter_KeepsTime(t *testing.T) {
+	var buf bytes.Buffer
+	at := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	NewEmitter(&buf).Emit(Event{Type: TypeStepChanged, Time: at, Step: "coding", Index: 2, Total: 5})
+	if !strings.Contains(buf.String(), `"time":"2025-06-01T00:00:00Z"`) {
+		t.Errorf("output = %s, want the given time", buf.String())
+	}
+}
+
+func TestEmitter_Nil(t *testing.T) {
+	var e *Emitter
+	e.Emit(Event{Type: TypeTicketStarted}) // must not panic
+}
+
+func TestEmitter_Concurrent(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewEmitter(&buf)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e.Emit(Event{Type: TypeTicketCompleted, TicketID: "TICKET-001", Status: "completed"})
+		}()
+	}
+	wg.Wait()
+
+	n := 0
+	sc := bufio.NewScanner(&buf)
+	for sc.Scan() {
+		var ev Event
+		if err := json.Unmarshal(sc.Bytes(), &ev); err != nil {
+			t.Fatalf("line %d is not JSON: %v: %s", n+1, err, sc.Text())
+		}
+		n++
+	}
+	if n != 50 {
+		t.Errorf("got %d events, want 50", n)
+	}
+}
@@ -0,0 +1,180 @@
+// Package project recognizes the ecosystem a project is written for (Go, Node,
+// Python, Java, ...) through a registry of project-type plugins. Each plugin
+// contributes detection, the project's default test, build and format commands, and
+// hints added to agent prompts, so supporting a new ecosystem means registering one
+// more plugin instead of editing every prompt.
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+)
+
+// Commands are the default commands for a project, run from its root. An empty
+// command means the ecosystem has no conventional one.
+type Commands struct {
+	Test   string
+	Build  string
+	Format string
+}
+
+// Plugin describes one project type.
+type Plugin interface {
+	// Name is the display name, e.g. "Go" or "Node.js".
+	Name() string
+	// Detect reports whether the project at root is of this type.
+	Detect(root string) bool
+	// Commands returns the default commands for the project at root. It may look at
+	// root to refine them (e.g. a wrapper script or lock file) and must fall back to the
+	// ecosystem's defaults, as it is also used to list defaults for undetected projects.
+	Commands(root string) Commands
+	// PromptHints returns conventions of the ecosystem worth telling a coding agent.
+	PromptHints() []string
+}
+
+var (
+	mu      sync.RWMutex
+	plugins []Plugin
+)
+
+// Register adds p to the registry. Plugins are tried in registration order; a plugin
+// registered with the name of an existing one replaces it.
+func Register(p Plugin) {
+	mu.Lock()
+	defer mu.Unlock()
+	for i, existing := range plugins {
+		if existing.Name() == p.Name() {
+			plugins[i] = p
+			return
+		}
+	}
+	plugins = append(plugins, p)
+}
+
+// Plugins returns the registered plugins in registration order.
+func Plugins() []Plugin {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]Plugin(nil), plugins...)
+}
+
+// Detected is a plugin that matched a project, with the project's commands.
+type Detected struct {
+	Plugin   Plugin
+	Commands Commands
+}
+
+// Detect returns the plugins matching the project at root, in registration order.
+// A polyglot project (e.g. a Go backend with a Node frontend) matches several.
+func Detect(root string) []Detected {
+	var out []Detected
+	for _, p := range Plugins() {
+		if p.Detect(root) {
+			out = append(out, Detected{Plugin: p, Commands: p.Commands(root)})
+		}
+	}
+	return out
+}
+
+// exists reports whether any of names exists directly under root.
+func exists(root string, names ...string) bool {
+	for _, name := range names {
+		if _, err := os.Stat(filepath.Join(root, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// markerPlugin is a plugin detected by the presence of marker files.
+type markerPlugin struct {
+	name     string
+	markers  []string
+	commands func(root string) Commands
+	hints    []string
+}
+
+func (p markerPlugin) Name() string                  { return p.name }
+func (p markerPlugin) Detect(root string) bool       { return exists(root, p.markers...) }
+func (p markerPlugin) Commands(root string) Commands { return p.commands(root) }
+func (p markerPlugin) PromptHints() []string         { return p.hints }
+
+func init() {
+	Register(markerPlugin{
+		name:    "Go",
+		markers: []string{"go.mod"},
+		commands: func(string) Commands {
+			return Commands{Test: "go test ./...", Build: "go build ./...", Format: "gofmt -w ."}
+		},
+		hints: []string{
+			i18n.ProjectHintGoErrors,
+			i18n.ProjectHintGoTests,
+		},
+	})
+	Register(markerPlugin{
+		name:    "Node.js",
+		markers: []string{"package.json"},
+		commands: func(root string) Commands {
+			pm := "npm"
+			switch {
+			case exists(root, "pnpm-lock.yaml"):
+				pm = "pnpm"
+			case exists(root, "yarn.lock"):
+				pm = "yarn"
+			}
+			run := pm + " run"
+			if pm == "yarn" {
+				run = "yarn"
+			}
+			return Commands{Test: pm + " test", Build: run + " build", Format: "npx prettier --write ."}
+		},
+		hints: []string{
+			i18n.ProjectHintNodeLockfile,
+			i18n.ProjectHintNodeModules,
+		},
+	})
+	Register(markerPlugin{
+		name:    "Python",
+		markers: []string{"pyproject.toml", "setup.py", "setup.cfg", "requirements.txt"},
+		commands: func(string) Commands {
+			return Commands{Test: "pytest", Format: "black ."}
+		},
+		hints: []string{
+			i18n.ProjectHintPythonStyle,
+			i18n.ProjectHintPythonTests,
+		},
+	})
+	Register(markerPlugin{
+		name:    "Java (Maven)",
+		markers: []string{"pom.xml"},
+		commands: func(root string) Commands {
+			mvn := "mvn"
+			if exists(root, "mvnw") {
+				mvn = "./mvnw"
+			}
+			return Commands{Test: mvn + " test", Build: mvn + " package -DskipTests"}
+		},
+		hints: []string{
+			i18n.ProjectHintJavaLayout,
+			i18n.ProjectHintJavaTests,
+		},
+	})
+	Register(markerPlugin{
+		name:    "Java (Gradle)",
+		markers: []string{"build.gradle", "build.gradle.kts"},
+		commands: func(root string) Commands {
+			gradle := "gradle"
+			if exists(root, "gradlew") {
+				gradle = "./gradlew"
+			}
+			return Commands{Test: gradle + " test", Build: gradle + " build -x test"}
+		},
+		hints: []string{
+			i18n.ProjectHintJavaLayout,
+			i18n.ProjectHintJavaTests,
+		},
+	})
+}
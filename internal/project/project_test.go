@@ -0,0 +1,78 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     []string
+		wantNames []string
+		wantTests []string
+	}{
+		{"empty", nil, nil, nil},
+		{"go", []string{"go.mod"}, []string{"Go"}, []string{"go test ./..."}},
+		{"node with pnpm", []string{"package.json", "pnpm-lock.yaml"}, []string{"Node.js"}, []string{"pnpm test"}},
+		{"python", []string{"pyproject.toml"}, []string{"Python"}, []string{"pytest"}},
+		{"maven wrapper", []string{"pom.xml", "mvnw"}, []string{"Java (Maven)"}, []string{"./mvnw test"}},
+		{"gradle kts", []string{"build.gradle.kts"}, []string{"Java (Gradle)"}, []string{"gradle test"}},
+		{"polyglot", []string{"go.mod", "package.json"}, []string{"Go", "Node.js"}, []string{"go test ./...", "npm test"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for _, f := range tt.files {
+				if err := os.WriteFile(filepath.Join(root, f), nil, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got := Detect(root)
+			if len(got) != len(tt.wantNames) {
+				t.Fatalf("Detect() = %d plugins, want %v", len(got), tt.wantNames)
+			}
+			for i, d := range got {
+				if d.Plugin.Name() != tt.wantNames[i] || d.Commands.Test != tt.wantTests[i] {
+					t.Errorf("Detect()[%d] = %s %q, want %s %q", i, d.Plugin.Name(), d.Commands.Test, tt.wantNames[i], tt.wantTests[i])
+				}
+				if len(d.Plugin.PromptHints()) == 0 {
+					t.Errorf("%s has no prompt hints", d.Plugin.Name())
+				}
+			}
+		})
+	}
+}
+
+type fakePlugin struct{ name, marker string }
+
+func (p fakePlugin) Name() string             { return p.name }
+func (p fakePlugin) Detect(root string) bool  { return exists(root, p.marker) }
+func (p fakePlugin) Commands(string) Commands { return Commands{Test: "cargo test"} }
+func (p fakePlugin) PromptHints() []string    { return nil }
+
+func TestRegister(t *testing.T) {
+	saved := Plugins()
+	defer func() {
+		mu.Lock()
+		plugins = saved
+		mu.Unlock()
+	}()
+
+	Register(fakePlugin{name: "Rust", marker: "Cargo.toml"})
+	Register(fakePlugin{name: "Rust", marker: "Cargo.lock"})
+	all := Plugins()
+	if len(all) != len(saved)+1 {
+		t.Fatalf("Plugins() = %d, want %d (re-registering should replace)", len(all), len(saved)+1)
+	}
+
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "Cargo.lock"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	got := Detect(root)
+	if len(got) != 1 || got[0].Plugin.Name() != "Rust" || got[0].Commands.Test != "cargo test" {
+		t.Errorf("Detect() = %+v, want the replaced Rust plugin", got)
+	}
+}
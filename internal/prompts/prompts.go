@@ -0,0 +1,244 @@
+// Package prompts renders the agent prompts from text/template files. Every prompt has
+// an embedded default per language; a file of the same name under the project's
+// .agent-orchestrator/prompts directory (e.g. coding.tmpl, or en/coding.tmpl for English
+// only) replaces it, so prompts can be customized without rebuilding.
+package prompts
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// Prompt names; each is rendered from <name>.tmpl.
+const (
+	Coding   = "coding"
+	Review   = "review"
+	Planning = "planning"
+	Commit   = "commit"
+)
+
+// Dir is where override templates live, relative to the project root. Templates in a
+// subdirectory named after a language (e.g. en/) apply to that language only.
+const Dir = ".agent-orchestrator/prompts"
+
+// The zh-TW templates are in templates/, those of other languages in templates/<lang>/.
+//
+//go:embed templates/*.tmpl templates/en/*.tmpl
+var defaults embed.FS
+
+// CodingData is the data of the coding prompt.
+type CodingData struct {
+	Ticket      *ticket.Ticket
+	ProjectRoot string
+	// AcceptanceCriteria is Ticket.AcceptanceCriteria, for brevity in templates.
+	AcceptanceCriteria []string
+	// Notes are the ticket's operator notes, e.g. "[2024-06-01 12:00 alice] 改用既有的 retry 套件".
+	Notes []string
+	// Projects are the detected project types with their commands, e.g. "Go（測試: go test ./...）".
+	Projects []string
+	// ProjectHints are the detected project types' instructions for the agent.
+	ProjectHints []string
+	// Conventions are recurring review findings stated as project conventions.
+	Conventions []string
+	// PartialOutput is what a previous, timed-out run had done.
+	PartialOutput string
+	// ReviewSummary, ReviewIssues and ReviewSuggestions are the feedback of a review that
+	// requested changes, when the agent is asked to fix them.
+	ReviewSummary     string
+	ReviewIssues      []string
+	ReviewSuggestions []string
+}
+
+// ReviewData is the data of the review prompt.
+type ReviewData struct {
+	ProjectRoot string
+	Files       []string
+	// Notes are the operator notes of the tickets under review, e.g. "T-1: keep the public API unchanged".
+	Notes []string
+	// Diff is the git diff of the files under review, possibly truncated; empty when unknown.
+	Diff string
+}
+
+// PlanningData is the data of the planning prompt.
+type PlanningData struct {
+	ProjectRoot string
+	// MilestoneFile is the first of MilestoneFiles.
+	MilestoneFile string
+	// MilestoneFiles are all the milestones planned together, in order; with more
+	// than one, each ticket names its milestone and may depend on the others'.
+	MilestoneFiles []string
+	OutputFile     string
+	// Epics asks for one epic per milestone phase.
+	Epics bool
+}
+
+// CommitData is the data of the commit prompt.
+type CommitData struct {
+	ProjectRoot string
+	TicketID    string
+	TicketTitle string
+	Changes     string
+	// FilesToStage limits git add to these paths; empty lets the agent choose.
+	FilesToStage []string
+}
+
+// samples are the data used to check override templates when loading them.
+var samples = map[string]any{
+	Coding:   CodingData{Ticket: &ticket.Ticket{}},
+	Review:   ReviewData{},
+	Planning: PlanningData{},
+	Commit:   CommitData{},
+}
+
+// Names returns the prompt names.
+func Names() []string {
+	return []string{Coding, Review, Planning, Commit}
+}
+
+// funcs are the functions available to templates besides the text/template builtins.
+var funcs = template.FuncMap{
+	"join": strings.Join,
+	"trim": strings.TrimSpace,
+}
+
+// LangDir returns the subdirectory of the templates of lang: empty for the default
+// language (zh-TW), lang itself otherwise.
+func LangDir(lang string) string {
+	if lang == "" || lang == i18n.LangZhTW {
+		return ""
+	}
+	return lang
+}
+
+// Default returns the source of the embedded template of the named prompt in the
+// current language, falling back to zh-TW when the language has none.
+func Default(name string) (string, error) {
+	if dir := LangDir(i18n.Language()); dir != "" {
+		if data, err := defaults.ReadFile("templates/" + dir + "/" + name + ".tmpl"); err == nil {
+			return string(data), nil
+		}
+	}
+	data, err := defaults.ReadFile("templates/" + name + ".tmpl")
+	if err != nil {
+		return "", fmt.Errorf("unknown prompt %q (expected one of %s)", name, strings.Join(Names(), ", "))
+	}
+	return string(data), nil
+}
+
+// Set holds the prompt templates of a project. A nil *Set renders the embedded defaults.
+type Set struct {
+	dir       string
+	templates map[string]*template.Template
+	// overrides maps the overridden prompts to their files.
+	overrides map[string]string
+}
+
+// Load parses the embedded templates of the current language and the overrides found
+// in projectRoot's Dir, preferring Dir/<lang>/<name>.tmpl over Dir/<name>.tmpl. An
+// override that does not parse, or fails on sample data (e.g. a misspelled field), is
+// an error naming the file.
+func Load(projectRoot string) (*Set, error) {
+	s := &Set{
+		dir:       filepath.Join(projectRoot, Dir),
+		templates: make(map[string]*template.Template),
+		overrides: make(map[string]string),
+	}
+	for _, name := range Names() {
+		text, err := Default(name)
+		if err != nil {
+			return nil, err
+		}
+		path, data, err := s.readOverride(name)
+		switch {
+		case err != nil:
+			return nil, err
+		case data != nil:
+			text = string(data)
+			s.overrides[name] = path
+		default:
+			path = "embedded " + name + ".tmpl"
+		}
+		tmpl, err := template.New(name).Funcs(funcs).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("parse prompt template %s: %w", path, err)
+		}
+		if err := tmpl.Execute(new(bytes.Buffer), samples[name]); err != nil {
+			return nil, fmt.Errorf("prompt template %s: %w", path, err)
+		}
+		s.templates[name] = tmpl
+	}
+	return s, nil
+}
+
+// readOverride returns the project's template of the named prompt, nil when there is
+// none.
+func (s *Set) readOverride(name string) (string, []byte, error) {
+	var paths []string
+	if dir := LangDir(i18n.Language()); dir != "" {
+		paths = append(paths, filepath.Join(s.dir, dir, name+".tmpl"))
+	}
+	for _, path := range append(paths, filepath.Join(s.dir, name+".tmpl")) {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			return path, data, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", nil, fmt.Errorf("read prompt template %s: %w", path, err)
+		}
+	}
+	return "", nil, nil
+}
+
+// Dir returns the directory the overrides are read from.
+func (s *Set) Dir() string {
+	if s == nil {
+		return Dir
+	}
+	return s.dir
+}
+
+// Overridden reports whether the named prompt comes from a project file.
+func (s *Set) Overridden(name string) bool {
+	return s.Source(name) != ""
+}
+
+// Source returns the project file the named prompt comes from, empty for the embedded
+// template.
+func (s *Set) Source(name string) string {
+	if s == nil {
+		return ""
+	}
+	return s.overrides[name]
+}
+
+// Render executes the named prompt's template with data, which must be the prompt's
+// data type (e.g. CodingData for Coding).
+func (s *Set) Render(name string, data any) (string, error) {
+	var tmpl *template.Template
+	if s != nil {
+		tmpl = s.templates[name]
+	}
+	if tmpl == nil {
+		text, err := Default(name)
+		if err != nil {
+			return "", err
+		}
+		if tmpl, err = template.New(name).Funcs(funcs).Parse(text); err != nil {
+			return "", err
+		}
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,151 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+func writeTemplate(t *testing.T, root, name, text string) {
+	t.Helper()
+	path := filepath.Join(root, Dir, name+".tmpl")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDefault(t *testing.T) {
+	for _, name := range Names() {
+		if text, err := Default(name); err != nil || text == "" {
+			t.Errorf("Default(%s) = %q, %v", name, text, err)
+		}
+	}
+	if _, err := Default("deploy"); err == nil {
+		t.Error("Default(deploy) should fail")
+	}
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	set, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	for _, name := range Names() {
+		if set.Overridden(name) {
+			t.Errorf("Overridden(%s) = true without a project file", name)
+		}
+	}
+
+	got, err := set.Render(Coding, CodingData{
+		Ticket:             &ticket.Ticket{ID: "T-1", Title: "Add parser"},
+		ProjectRoot:        "/p",
+		AcceptanceCriteria: []string{"parses JSON"},
+	})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	for _, want := range []string{"專案根目錄: /p", "- ID: T-1", "## 驗收標準\n- parses JSON\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render(coding) missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "## 需要建立的檔案") {
+		t.Error("Render(coding) should omit empty sections")
+	}
+}
+
+func TestLoad_Override(t *testing.T) {
+	root := t.TempDir()
+	writeTemplate(t, root, Commit, `Commit {{.TicketID}}: {{join .FilesToStage ", "}}`)
+
+	set, err := Load(root)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !set.Overridden(Commit) || set.Overridden(Coding) {
+		t.Errorf("Overridden() commit, coding = %v, %v", set.Overridden(Commit), set.Overridden(Coding))
+	}
+	got, err := set.Render(Commit, CommitData{TicketID: "T-1", FilesToStage: []string{"a.go", "b.go"}})
+	if err != nil || got != "Commit T-1: a.go, b.go" {
+		t.Errorf("Render(commit) = %q, %v", got, err)
+	}
+}
+
+func TestLoad_Language(t *testing.T) {
+	if err := i18n.SetLanguage(i18n.LangEn); err != nil {
+		t.Fatal(err)
+	}
+	defer i18n.SetLanguage(i18n.LangZhTW)
+
+	for _, name := range Names() {
+		en, _ := Default(name)
+		if zh, _ := defaults.ReadFile("templates/" + name + ".tmpl"); en == "" || en == string(zh) {
+			t.Errorf("Default(%s) in en should be the English template", name)
+		}
+	}
+
+	root := t.TempDir()
+	writeTemplate(t, root, Commit, "shared {{.TicketID}}")
+	writeTemplate(t, root, Review, "shared review")
+	writeTemplate(t, root, filepath.Join("en", Commit), "english {{.TicketID}}")
+	set, err := Load(root)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got, _ := set.Render(Commit, CommitData{TicketID: "T-1"}); got != "english T-1" {
+		t.Errorf("Render(commit) = %q, want the en/ override", got)
+	}
+	if got, _ := set.Render(Review, ReviewData{}); got != "shared review" {
+		t.Errorf("Render(review) = %q, want the shared override", got)
+	}
+	if want := filepath.Join(root, Dir, "en", "commit.tmpl"); set.Source(Commit) != want {
+		t.Errorf("Source(commit) = %q, want %q", set.Source(Commit), want)
+	}
+	got, err := set.Render(Coding, CodingData{Ticket: &ticket.Ticket{ID: "T-1"}, ProjectRoot: "/p"})
+	if err != nil || !strings.Contains(got, "Project root: /p") {
+		t.Errorf("Render(coding) = %q, %v; want the English template", got, err)
+	}
+}
+
+func TestLoad_InvalidOverride(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"syntax error", "{{if .Files}}unterminated"},
+		{"unknown field", "{{.Ticket.Titel}}"},
+		{"unknown function", "{{upper .ProjectRoot}}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			name := Coding
+			if tt.name == "syntax error" {
+				name = Review
+			}
+			writeTemplate(t, root, name, tt.text)
+			_, err := Load(root)
+			if err == nil || !strings.Contains(err.Error(), name+".tmpl") {
+				t.Errorf("Load() error = %v, want one naming %s.tmpl", err, name)
+			}
+		})
+	}
+}
+
+func TestSet_Render_Nil(t *testing.T) {
+	var set *Set
+	got, err := set.Render(Planning, PlanningData{MilestoneFile: "m.md", OutputFile: "out.json", Epics: true})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(got, "m.md") || !strings.Contains(got, "out.json") || !strings.Contains(got, `"epic"`) {
+		t.Errorf("Render(planning) = %q", got)
+	}
+}
@@ -0,0 +1,131 @@
+// Package redact masks sensitive data (API keys, tokens, passwords) in text written to
+// agent logs, the detach work log and ticket files.
+package redact
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Mask replaces each match of a pattern.
+const Mask = "[REDACTED]"
+
+// defaults are the built-in patterns by name; an entry "-<name>" in Configure
+// disables one and "-defaults" disables all of them.
+var defaults = []struct {
+	name    string
+	pattern string
+}{
+	// API keys and tokens
+	{"api_key", `(?i)(api[_-]?key|apikey|api_secret|secret[_-]?key)\s*[:=]\s*['"]?[a-zA-Z0-9_\-]{16,}['"]?`},
+	{"access_token", `(?i)(access[_-]?token|auth[_-]?token|bearer)\s*[:=]\s*['"]?[a-zA-Z0-9_\-\.]{20,}['"]?`},
+	// AWS credentials
+	{"aws_credentials", `(?i)(aws[_-]?access[_-]?key[_-]?id|aws[_-]?secret)\s*[:=]\s*['"]?[A-Z0-9]{16,}['"]?`},
+	{"aws_access_key_id", `AKIA[0-9A-Z]{16}`},
+	// Password patterns
+	{"password", `(?i)(password|passwd|pwd)\s*[:=]\s*['"]?[^\s'"]{4,}['"]?`},
+	// Private keys
+	{"private_key", `-----BEGIN\s+(RSA\s+)?PRIVATE\s+KEY-----`},
+	// GitHub tokens
+	{"github_token", `gh[pousr]_[A-Za-z0-9_]{36,}`},
+	// Generic secrets
+	{"secret", `(?i)(client[_-]?secret|secret)\s*[:=]\s*['"]?[a-zA-Z0-9_\-]{16,}['"]?`},
+}
+
+// disableAll is the entry that disables every built-in pattern.
+const disableAll = "defaults"
+
+// disableEntry matches "-<name>" entries; other entries are regular expressions.
+var disableEntry = regexp.MustCompile(`^-[a-z_]+$`)
+
+var (
+	mu        sync.RWMutex
+	active, _ = Compile(nil)
+)
+
+// Compile returns the patterns in effect for the log_redaction_patterns entries: the
+// built-in ones, less those disabled by "-<name>" entries, plus every other entry as a
+// regular expression.
+func Compile(entries []string) ([]*regexp.Regexp, error) {
+	disabled := make(map[string]bool)
+	var extra []*regexp.Regexp
+	for _, entry := range entries {
+		if disableEntry.MatchString(entry) {
+			name := entry[1:]
+			if name != disableAll && !slices.Contains(defaultNames(), name) {
+				return nil, fmt.Errorf("unknown built-in redaction pattern %q (available: %s, %s)", name, strings.Join(defaultNames(), ", "), disableAll)
+			}
+			disabled[name] = true
+			continue
+		}
+		re, err := regexp.Compile(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", entry, err)
+		}
+		extra = append(extra, re)
+	}
+
+	var out []*regexp.Regexp
+	if !disabled[disableAll] {
+		for _, d := range defaults {
+			if !disabled[d.name] {
+				out = append(out, regexp.MustCompile(d.pattern))
+			}
+		}
+	}
+	return append(out, extra...), nil
+}
+
+// Configure sets the patterns String applies in the process (see Compile); until it
+// is called, the built-in patterns apply.
+func Configure(entries []string) error {
+	patterns, err := Compile(entries)
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	active = patterns
+	mu.Unlock()
+	return nil
+}
+
+// String returns text with every match of the configured patterns masked.
+func String(text string) string {
+	mu.RLock()
+	patterns := active
+	mu.RUnlock()
+	for _, re := range patterns {
+		text = re.ReplaceAllString(text, Mask)
+	}
+	return text
+}
+
+// NewWriter returns a writer that masks sensitive data in each write before passing it
+// to w. Secrets split across two writes are not recognized, so it suits writers that
+// receive whole lines or records, such as a log handler's.
+func NewWriter(w io.Writer) io.Writer {
+	return &writer{w: w}
+}
+
+type writer struct {
+	w io.Writer
+}
+
+func (rw *writer) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(rw.w, String(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func defaultNames() []string {
+	names := make([]string, len(defaults))
+	for i, d := range defaults {
+		names[i] = d.name
+	}
+	return names
+}
@@ -0,0 +1,192 @@
+// Package runstate records the agent subprocesses spawned by orchestrator runs, so
+// processes left behind by a run that crashed can be found and terminated.
+//
+// The run state is a small JSON file (TicketsDir/agents.json). Every orchestrator
+// process adds an entry when it starts an agent and removes it when the agent exits;
+// an entry whose owner is gone while its agent still runs is an orphan. Writes go
+// through a temp file and rename, and every read-modify-write holds a file lock
+// (agents.json.lock) shared by all processes.
+package runstate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/filelock"
+)
+
+// Entry is one running agent subprocess.
+type Entry struct {
+	PID int `json:"pid"`
+	// OwnerPID is the orchestrator process that spawned the agent.
+	OwnerPID  int       `json:"owner_pid"`
+	Command   string    `json:"command"`
+	StartedAt time.Time `json:"started_at"`
+	// ProcessStart identifies when the operating system started PID (see
+	// SameProcess), so a PID reused by another process is not taken for the agent.
+	ProcessStart string `json:"process_start,omitempty"`
+}
+
+// SameProcess reports whether PID still is the recorded agent, given start, what
+// ProcessStart would be for PID now. Entries recorded without a ProcessStart match
+// any process.
+func (e Entry) SameProcess(start string) bool {
+	return e.ProcessStart == "" || start == e.ProcessStart
+}
+
+// File is the run state stored at a path.
+type File struct {
+	path string
+}
+
+// New returns the run state stored at path. The file is created on the first Add.
+func New(path string) *File {
+	return &File{path: path}
+}
+
+// List returns the recorded agent processes, oldest first.
+func (f *File) List() ([]Entry, error) {
+	unlock, err := f.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return f.load()
+}
+
+// Add records a started agent process, setting StartedAt when unset. An earlier entry
+// with the same PID (a reused PID) is replaced.
+func (f *File) Add(e Entry) error {
+	unlock, err := f.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	entries, err := f.load()
+	if err != nil {
+		return err
+	}
+	if e.StartedAt.IsZero() {
+		e.StartedAt = time.Now()
+	}
+	return f.save(append(without(entries, e.PID), e))
+}
+
+// Remove forgets the agent process with the given PID. Safe to call when it is not recorded.
+func (f *File) Remove(pid int) error {
+	unlock, err := f.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	entries, err := f.load()
+	if err != nil {
+		return err
+	}
+	kept := without(entries, pid)
+	if len(kept) == len(entries) {
+		return nil
+	}
+	return f.save(kept)
+}
+
+// Orphans returns the agent processes that are still alive while the orchestrator that
+// spawned them is not. Entries whose agent has exited, or whose PID now belongs to
+// another process, are stale and dropped from the file. alive reports whether a PID
+// is a running process and started returns its ProcessStart.
+func (f *File) Orphans(alive func(pid int) bool, started func(pid int) string) ([]Entry, error) {
+	unlock, err := f.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	entries, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+	var kept, orphans []Entry
+	for _, e := range entries {
+		if !alive(e.PID) || !e.SameProcess(started(e.PID)) {
+			continue
+		}
+		kept = append(kept, e)
+		if !alive(e.OwnerPID) {
+			orphans = append(orphans, e)
+		}
+	}
+	if len(kept) != len(entries) {
+		if err := f.save(kept); err != nil {
+			return nil, err
+		}
+	}
+	return orphans, nil
+}
+
+// lock takes the file lock serializing access to the run state across processes.
+func (f *File) lock() (unlock func(), err error) {
+	return filelock.Lock(f.path+".lock", 0)
+}
+
+func without(entries []Entry, pid int) []Entry {
+	out := make([]Entry, 0, len(entries))
+	for _, e := range entries {
+		if e.PID != pid {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func (f *File) load() ([]Entry, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read run state: %w", err)
+	}
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse run state: %w", err)
+	}
+	return entries, nil
+}
+
+// save writes the run state atomically; no entries removes the file.
+func (f *File) save(entries []Entry) error {
+	if len(entries) == 0 {
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to write run state: %w", err)
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(f.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create run state directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".agents-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write run state: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write run state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write run state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write run state: %w", err)
+	}
+	return nil
+}
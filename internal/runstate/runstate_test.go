@@ -0,0 +1,94 @@
+package runstate
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestFile_AddRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agents.json")
+	f := New(path)
+
+	if entries, err := f.List(); err != nil || len(entries) != 0 {
+		t.Fatalf("List() on missing file = %v, %v; want empty", entries, err)
+	}
+	if err := f.Add(Entry{PID: 100, OwnerPID: 1, Command: "agent"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Add(Entry{PID: 200, OwnerPID: 1, Command: "agent"}); err != nil {
+		t.Fatal(err)
+	}
+	// A reused PID replaces the earlier entry.
+	if err := f.Add(Entry{PID: 100, OwnerPID: 2, Command: "agent"}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := New(path).List()
+	if err != nil || len(entries) != 2 || entries[0].PID != 200 || entries[1].OwnerPID != 2 {
+		t.Fatalf("List() = %+v, %v", entries, err)
+	}
+	if entries[0].StartedAt.IsZero() {
+		t.Errorf("Add() should set StartedAt, got %+v", entries[0])
+	}
+
+	if err := f.Remove(300); err != nil {
+		t.Errorf("Remove() of an unknown PID error = %v", err)
+	}
+	for _, pid := range []int{100, 200} {
+		if err := f.Remove(pid); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("run state file should be removed once empty, stat err = %v", err)
+	}
+}
+
+func TestFile_Orphans(t *testing.T) {
+	f := New(filepath.Join(t.TempDir(), "agents.json"))
+	for _, e := range []Entry{
+		{PID: 11, OwnerPID: 1},                      // owner alive: not an orphan
+		{PID: 12, OwnerPID: 2},                      // owner dead, agent alive: orphan
+		{PID: 13, OwnerPID: 2},                      // agent exited: stale
+		{PID: 14, OwnerPID: 2, ProcessStart: "100"}, // PID reused by another process: stale
+	} {
+		if err := f.Add(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	alive := map[int]bool{1: true, 11: true, 12: true, 14: true}
+	started := func(pid int) string { return "200" }
+
+	orphans, err := f.Orphans(func(pid int) bool { return alive[pid] }, started)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orphans) != 1 || orphans[0].PID != 12 {
+		t.Errorf("Orphans() = %+v, want only PID 12", orphans)
+	}
+	entries, _ := f.List()
+	if len(entries) != 2 {
+		t.Errorf("Orphans() should drop the stale entries, left %+v", entries)
+	}
+}
+
+func TestFile_ConcurrentAdds(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agents.json")
+	var wg sync.WaitGroup
+	for pid := 1; pid <= 20; pid++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each writer opens the file on its own, as separate processes do.
+			if err := New(path).Add(Entry{PID: pid, OwnerPID: 1}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if entries, err := New(path).List(); err != nil || len(entries) != 20 {
+		t.Errorf("List() after concurrent adds = %d entries, %v; want 20", len(entries), err)
+	}
+}
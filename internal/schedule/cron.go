@@ -0,0 +1,164 @@
+// Package schedule parses cron-style recurrence rules.
+//
+// A rule is either a standard five-field cron expression
+// ("minute hour day-of-month month day-of-week") or one of the shorthands
+// @hourly, @daily, @weekly and @monthly. Fields support "*", single values,
+// ranges ("1-5"), lists ("1,15") and steps ("*/15", "0-30/10"). Day of week
+// is 0-6 with 0 = Sunday (7 is accepted as Sunday too). As in cron, when both
+// day-of-month and day-of-week are restricted, a day matching either one fires.
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var shorthands = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// Schedule is a parsed recurrence rule.
+type Schedule struct {
+	expr    string
+	minute  map[int]bool
+	hour    map[int]bool
+	dom     map[int]bool
+	month   map[int]bool
+	dow     map[int]bool
+	domStar bool
+	dowStar bool
+}
+
+// Parse parses a cron expression or shorthand.
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	spec := expr
+	if s, ok := shorthands[strings.ToLower(expr)]; ok {
+		spec = s
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q: want 5 fields (minute hour day month weekday) or @hourly/@daily/@weekly/@monthly", expr)
+	}
+
+	s := &Schedule{expr: expr}
+	var err error
+	if s.minute, err = parseField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: minute: %w", expr, err)
+	}
+	if s.hour, err = parseField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: hour: %w", expr, err)
+	}
+	if s.dom, err = parseField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: day of month: %w", expr, err)
+	}
+	if s.month, err = parseField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: month: %w", expr, err)
+	}
+	if s.dow, err = parseField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: day of week: %w", expr, err)
+	}
+	if s.dow[7] {
+		s.dow[0] = true
+	}
+	s.domStar = fields[2] == "*"
+	s.dowStar = fields[4] == "*"
+	return s, nil
+}
+
+// String returns the rule as written.
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// parseField expands one cron field into the set of values it matches.
+func parseField(field string, min, max int) (map[int]bool, error) {
+	set := make(map[int]bool)
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid step in %q", part)
+			}
+			rng, step = part[:i], n
+		}
+
+		lo, hi := min, max
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			bounds := strings.SplitN(rng, "-", 2)
+			var err1, err2 error
+			lo, err1 = strconv.Atoi(bounds[0])
+			hi, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil {
+				return nil, fmt.Errorf("invalid range %q", rng)
+			}
+		default:
+			n, err := strconv.Atoi(rng)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q", rng)
+			}
+			lo, hi = n, n
+			if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("%q out of range %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+// Next returns the first time strictly after t that matches the schedule, at minute
+// resolution in t's location. Returns the zero time if nothing matches within five
+// years (e.g. "0 0 31 2 *").
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if !s.month[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.hour[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.minute[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.dom[t.Day()]
+	dow := s.dow[int(t.Weekday())]
+	switch {
+	case s.domStar && s.dowStar:
+		return true
+	case s.domStar:
+		return dow
+	case s.dowStar:
+		return dom
+	default:
+		return dom || dow
+	}
+}
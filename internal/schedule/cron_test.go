@@ -0,0 +1,60 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"a * * * *",
+		"5-1 * * * *",
+	} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) should fail", expr)
+		}
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	// Wednesday 2025-01-15 10:30
+	base := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2025, 1, 15, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 1, 15, 10, 45, 0, 0, time.UTC)},
+		{"0 9 * * *", time.Date(2025, 1, 16, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 1", time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2025, 1, 16, 9, 0, 0, 0, time.UTC)},
+		{"30 10 15 * *", time.Date(2025, 2, 15, 10, 30, 0, 0, time.UTC)},
+		{"0 0 1 */3 *", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		// Day of month OR day of week when both are restricted: the 20th or any Friday.
+		{"0 0 20 * 5", time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.expr, err)
+		}
+		if got := s.Next(base); !got.Equal(tt.want) {
+			t.Errorf("Parse(%q).Next() = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
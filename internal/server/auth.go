@@ -0,0 +1,156 @@
+package server
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/audit"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+)
+
+// Scope is what a token may do. Each scope includes the ones before it.
+type Scope string
+
+const (
+	// ScopeNone marks routes served without a token (the dashboard page itself).
+	ScopeNone Scope = ""
+	// ScopeRead may read the status, tickets, jobs and job logs.
+	ScopeRead Scope = "read"
+	// ScopeWork may also start work and retry failed tickets.
+	ScopeWork Scope = "work"
+	// ScopeAdmin may also create tickets and start plans.
+	ScopeAdmin Scope = "admin"
+)
+
+var scopeRank = map[Scope]int{ScopeNone: 0, ScopeRead: 1, ScopeWork: 2, ScopeAdmin: 3}
+
+// Allows reports whether a token with scope s may use a route requiring required.
+func (s Scope) Allows(required Scope) bool {
+	rank, ok := scopeRank[s]
+	return ok && rank >= scopeRank[required]
+}
+
+// Token is an API token: clients send Secret as "Authorization: Bearer <secret>" (or
+// as the token query parameter, for EventSource); Name identifies it in the audit log.
+type Token struct {
+	Name   string
+	Secret string
+	Scope  Scope
+}
+
+// SetTokens makes the API require one of tokens. Without tokens no token is checked,
+// so nothing but the origin checks of authorize stands between the API and anyone who
+// can reach it; serve refuses to listen on other than a loopback address then.
+func (s *Server) SetTokens(tokens []Token) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = tokens
+}
+
+// SetAudit makes the server report every request that changes something (any method
+// but GET) or is refused for lack of a valid token or scope.
+func (s *Server) SetAudit(fn func(audit.APIEntry)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.audit = fn
+}
+
+// route registers handler for pattern, requiring a token with scope when tokens are set.
+func (s *Server) route(pattern string, scope Scope, handler http.HandlerFunc) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+		token, err := s.authorize(r, scope)
+		if err != nil {
+			writeError(rec, err)
+		} else {
+			handler(rec, r)
+		}
+
+		s.mu.Lock()
+		report := s.audit
+		s.mu.Unlock()
+		if report == nil || (r.Method == http.MethodGet && rec.status != http.StatusUnauthorized && rec.status != http.StatusForbidden) {
+			return
+		}
+		report(audit.APIEntry{
+			Time:   time.Now(),
+			Token:  token.Name,
+			Scope:  string(token.Scope),
+			Remote: r.RemoteAddr,
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Status: rec.status,
+			Target: rec.target,
+			Error:  rec.err,
+		})
+	})
+}
+
+// authorize returns the token of r when it may use a route requiring scope. Every
+// request must pass checkRequest first. Without configured tokens the request must
+// also be addressed to a loopback Host, and is then allowed with the zero Token: a
+// browser on this machine is the only client left, so these checks are what keeps
+// other sites from creating tickets or starting work through it.
+func (s *Server) authorize(r *http.Request, scope Scope) (Token, error) {
+	if err := checkRequest(r); err != nil {
+		return Token{}, err
+	}
+	if scope == scopeRetryLink {
+		// The handler checks the link's signature.
+		return Token{Name: string(scopeRetryLink)}, nil
+	}
+	s.mu.Lock()
+	tokens := s.tokens
+	s.mu.Unlock()
+	if len(tokens) == 0 {
+		return Token{}, checkLocalHost(r)
+	}
+	if scope == ScopeNone {
+		return Token{}, nil
+	}
+
+	secret := r.URL.Query().Get("token")
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		secret = strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	}
+	for _, t := range tokens {
+		if secret != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(t.Secret)) == 1 {
+			if !t.Scope.Allows(scope) {
+				return t, NewError(http.StatusForbidden, fmt.Errorf(i18n.ErrServeForbidden, t.Name, t.Scope, scope))
+			}
+			return t, nil
+		}
+	}
+	return Token{}, NewError(http.StatusUnauthorized, errors.New(i18n.ErrServeUnauthorized))
+}
+
+// recorder captures what the audit log needs from a response.
+type recorder struct {
+	http.ResponseWriter
+	status int
+	target string
+	err    string
+}
+
+func (r *recorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Flush lets job log streams flush through the recorder.
+func (r *recorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// setTarget records what a request created for the audit log.
+func setTarget(w http.ResponseWriter, target string) {
+	if rec, ok := w.(*recorder); ok {
+		rec.target = target
+	}
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anthropic/agent-orchestrator/internal/audit"
+)
+
+func TestServer_TokenScopes(t *testing.T) {
+	tokens := []Token{
+		{Name: "dashboard", Secret: "read-secret", Scope: ScopeRead},
+		{Name: "ci", Secret: "work-secret", Scope: ScopeWork},
+		{Name: "ops", Secret: "admin-secret", Scope: ScopeAdmin},
+	}
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		secret   string
+		query    bool
+		wantCode int
+	}{
+		{"dashboard page needs no token", http.MethodGet, "/", "", "", false, http.StatusOK},
+		{"missing token", http.MethodGet, "/api/status", "", "", false, http.StatusUnauthorized},
+		{"wrong token", http.MethodGet, "/api/status", "", "nope", false, http.StatusUnauthorized},
+		{"read token reads", http.MethodGet, "/api/tickets", "", "read-secret", false, http.StatusOK},
+		{"token as query parameter", http.MethodGet, "/api/jobs", "", "read-secret", true, http.StatusOK},
+		{"read token cannot start work", http.MethodPost, "/api/work", `{}`, "read-secret", false, http.StatusForbidden},
+		{"work token starts work", http.MethodPost, "/api/work", `{}`, "work-secret", false, http.StatusAccepted},
+		{"read token cannot retry", http.MethodPost, "/api/tickets/T-1/retry", "", "read-secret", false, http.StatusForbidden},
+		{"work token retries", http.MethodPost, "/api/tickets/T-1/retry", "", "work-secret", false, http.StatusAccepted},
+		{"work token cannot add tickets", http.MethodPost, "/api/tickets", `{"title": "x"}`, "work-secret", false, http.StatusForbidden},
+		{"work token cannot plan", http.MethodPost, "/api/plan", `{"milestone": "m.md"}`, "work-secret", false, http.StatusForbidden},
+		{"admin token adds tickets", http.MethodPost, "/api/tickets", `{"title": "x"}`, "admin-secret", false, http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := newTestBackend()
+			backend.job = &Job{Queued: true, QueueID: "Q-1"}
+			s := New(backend)
+			s.SetTokens(tokens)
+
+			path := tt.path
+			if tt.query {
+				path += "?token=" + tt.secret
+			}
+			req := newRequest(tt.method, path, tt.body)
+			if tt.secret != "" && !tt.query {
+				req.Header.Set("Authorization", "Bearer "+tt.secret)
+			}
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, req)
+			if rec.Code != tt.wantCode {
+				t.Errorf("%s %s = %d, want %d; body %s", tt.method, tt.path, rec.Code, tt.wantCode, rec.Body)
+			}
+		})
+	}
+}
+
+func TestServer_AuthorizeWithoutTokens(t *testing.T) {
+	s := New(newTestBackend())
+	tests := []struct {
+		name     string
+		host     string
+		origin   string
+		wantCode int
+	}{
+		{"local same-origin request", "localhost:8080", "http://localhost:8080", 0},
+		{"rebound host", "evil.example:8080", "", http.StatusForbidden},
+		{"cross-site origin", "127.0.0.1:8080", "https://evil.example", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newRequest(http.MethodPost, "/api/tickets", `{"title": "x"}`)
+			req.Host = tt.host
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			_, err := s.authorize(req, ScopeAdmin)
+			if code := errorCode(err); code != tt.wantCode {
+				t.Errorf("authorize() = %v (code %d), want code %d", err, code, tt.wantCode)
+			}
+		})
+	}
+}
+
+// errorCode returns the HTTP status of an Error, or 0 for nil.
+func errorCode(err error) int {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	if err != nil {
+		return http.StatusInternalServerError
+	}
+	return 0
+}
+
+func TestServer_AuditLog(t *testing.T) {
+	backend := newTestBackend()
+	backend.job = &Job{Queued: true, QueueID: "Q-1"}
+	s := New(backend)
+	s.SetTokens([]Token{{Name: "ci", Secret: "work-secret", Scope: ScopeWork}})
+	var entries []audit.APIEntry
+	s.SetAudit(func(e audit.APIEntry) { entries = append(entries, e) })
+
+	do := func(method, path, body, secret string) {
+		req := newRequest(method, path, body)
+		if secret != "" {
+			req.Header.Set("Authorization", "Bearer "+secret)
+		}
+		s.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	do(http.MethodGet, "/api/status", "", "work-secret")                     // allowed read: not audited
+	do(http.MethodPost, "/api/work", `{"ticket_id": "T-1"}`, "work-secret")  // action
+	do(http.MethodPost, "/api/plan", `{"milestone": "m.md"}`, "work-secret") // forbidden
+	do(http.MethodGet, "/api/tickets", "", "")                               // unauthorized
+
+	if len(entries) != 3 {
+		t.Fatalf("audited %d requests, want 3: %+v", len(entries), entries)
+	}
+	if e := entries[0]; e.Token != "ci" || e.Scope != "work" || e.Path != "/api/work" || e.Status != http.StatusAccepted || e.Target != "Q-1" {
+		t.Errorf("work entry = %+v", e)
+	}
+	if e := entries[1]; e.Token != "ci" || e.Status != http.StatusForbidden || e.Error == "" {
+		t.Errorf("forbidden entry = %+v", e)
+	}
+	if e := entries[2]; e.Token != "" || e.Method != http.MethodGet || e.Status != http.StatusUnauthorized {
+		t.Errorf("unauthorized entry = %+v", e)
+	}
+}
+
+func TestScope_Allows(t *testing.T) {
+	tests := []struct {
+		scope, required Scope
+		want            bool
+	}{
+		{ScopeRead, ScopeRead, true},
+		{ScopeRead, ScopeWork, false},
+		{ScopeWork, ScopeRead, true},
+		{ScopeWork, ScopeAdmin, false},
+		{ScopeAdmin, ScopeWork, true},
+		{Scope("root"), ScopeRead, false},
+	}
+	for _, tt := range tests {
+		if got := tt.scope.Allows(tt.required); got != tt.want {
+			t.Errorf("%q.Allows(%q) = %v, want %v", tt.scope, tt.required, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+)
+
+// handleJobLogs streams the log of a job as server-sent events: one "data" event per
+// log line, from the start of the file, following new output until the job exits.
+// A final "end" event tells the client the log is complete.
+func (s *Server) handleJobLogs(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.job(r.PathValue("id"))
+	if !ok {
+		writeError(w, NewError(http.StatusNotFound, fmt.Errorf(i18n.ErrServeJobNotFound, r.PathValue("id"))))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, errors.New(i18n.ErrServeStreaming))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ctx := r.Context()
+	var reader *bufio.Reader
+	var partial string
+	for {
+		// The child creates its log file shortly after it starts.
+		if reader == nil {
+			if f, err := os.Open(job.LogPath); err == nil {
+				defer f.Close()
+				reader = bufio.NewReader(f)
+			}
+		}
+		done := job.Exited()
+		if reader != nil {
+			for {
+				line, err := reader.ReadString('\n')
+				partial += line
+				if err != nil {
+					break
+				}
+				writeEvent(w, "", strings.TrimRight(partial, "\r\n"))
+				partial = ""
+			}
+			flusher.Flush()
+		}
+		// Output written before the exit was seen has been read above.
+		if done {
+			if partial != "" {
+				writeEvent(w, "", partial)
+			}
+			writeEvent(w, "end", "")
+			flusher.Flush()
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-job.Done:
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
+
+// writeEvent writes one server-sent event; event "" is the default "message" event.
+func writeEvent(w io.Writer, event, data string) {
+	if event != "" {
+		fmt.Fprintf(w, "event: %s\n", event)
+	}
+	fmt.Fprintf(w, "data: %s\n\n", data)
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+)
+
+// Retry links let a failure notification retry one ticket without an API token: the
+// link carries an HMAC over the ticket ID and an expiry time, signed with a key only
+// the orchestrator processes of the project can read (see LoadRetryKey). Opening the
+// link shows a confirmation page, because chat apps fetch links to preview them; its
+// button posts the same signature to retry the ticket.
+
+// RetryLinkTTL is how long a retry link stays valid.
+const RetryLinkTTL = 24 * time.Hour
+
+// retryKeySize is the length of a generated signing key in bytes.
+const retryKeySize = 32
+
+// scopeRetryLink marks the retry link routes: served without a token or a loopback
+// Host, as the signature authorizes them.
+const scopeRetryLink Scope = "retry-link"
+
+// RetryLink returns the link that retries ticket id through the server at baseURL,
+// signed with key and valid until expires.
+func RetryLink(baseURL string, key []byte, id string, expires time.Time) string {
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	q := url.Values{"expires": {exp}, "sig": {retrySignature(key, id, exp)}}
+	return strings.TrimSuffix(baseURL, "/") + "/retry/" + url.PathEscape(id) + "?" + q.Encode()
+}
+
+func retrySignature(key []byte, id, expires string) string {
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "retry\n%s\n%s", id, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// LoadRetryKey returns the retry link signing key stored at path, creating a random
+// one readable only by the owner when there is none yet.
+func LoadRetryKey(path string) ([]byte, error) {
+	if data, err := os.ReadFile(path); err == nil {
+		return hex.DecodeString(strings.TrimSpace(string(data)))
+	}
+	key := make([]byte, retryKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if errors.Is(err, os.ErrExist) {
+		// Another process created it first; use theirs.
+		return LoadRetryKey(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	_, err = f.WriteString(hex.EncodeToString(key) + "\n")
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// SetRetryKey makes the server accept retry links signed with key (see RetryLink).
+// Without a key every retry link is refused.
+func (s *Server) SetRetryKey(key []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.retryKey = key
+}
+
+// checkRetryLink returns nil when sig is the signature of ticket id valid until expires.
+func (s *Server) checkRetryLink(id, expires, sig string) error {
+	s.mu.Lock()
+	key := s.retryKey
+	s.mu.Unlock()
+	if len(key) == 0 {
+		return NewError(http.StatusNotFound, errors.New(i18n.ErrRetryLinkDisabled))
+	}
+	want := retrySignature(key, id, expires)
+	if !hmac.Equal([]byte(sig), []byte(want)) {
+		return NewError(http.StatusForbidden, errors.New(i18n.ErrRetryLinkInvalid))
+	}
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return NewError(http.StatusForbidden, errors.New(i18n.ErrRetryLinkInvalid))
+	}
+	if at := time.Unix(exp, 0); time.Now().After(at) {
+		return NewError(http.StatusForbidden, fmt.Errorf(i18n.ErrRetryLinkExpired, at.Format(time.RFC3339)))
+	}
+	return nil
+}
+
+var retryPage = template.Must(template.New("retry").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>body{font-family:system-ui,sans-serif;max-width:32rem;margin:3rem auto;padding:0 1rem}button{font-size:1rem;padding:.4rem 1.2rem}</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{.Prompt}}</p>
+<button id="retry">{{.Button}}</button>
+<p id="result"></p>
+<script>
+document.getElementById("retry").onclick = async (e) => {
+  e.target.disabled = true;
+  const res = await fetch(location.pathname, {
+    method: "POST",
+    headers: {"Content-Type": "application/json"},
+    body: JSON.stringify({expires: {{.Expires}}, sig: {{.Sig}}}),
+  });
+  const body = await res.json();
+  document.getElementById("result").textContent = res.ok ? {{.Started}} : body.error;
+};
+</script>
+</body>
+</html>
+`))
+
+// handleRetryLinkPage shows the confirmation page of a valid retry link.
+func (s *Server) handleRetryLinkPage(w http.ResponseWriter, r *http.Request) {
+	id, q := r.PathValue("id"), r.URL.Query()
+	if err := s.checkRetryLink(id, q.Get("expires"), q.Get("sig")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = retryPage.Execute(w, map[string]string{
+		"Title":   fmt.Sprintf(i18n.UIRetryLinkTitle, id),
+		"Prompt":  fmt.Sprintf(i18n.UIRetryLinkPrompt, id),
+		"Button":  i18n.UIRetryLinkButton,
+		"Started": i18n.UIRetryLinkStarted,
+		"Expires": q.Get("expires"),
+		"Sig":     q.Get("sig"),
+	})
+}
+
+// handleRetryLink retries the ticket of a valid retry link, as POST
+// /api/tickets/{id}/retry does.
+func (s *Server) handleRetryLink(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Expires string `json:"expires"`
+		Sig     string `json:"sig"`
+	}
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	id := r.PathValue("id")
+	if err := s.checkRetryLink(id, req.Expires, req.Sig); err != nil {
+		writeError(w, err)
+		return
+	}
+	s.handleRetry(w, r)
+}
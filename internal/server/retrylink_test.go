@@ -0,0 +1,90 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestServer_RetryLink(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	valid := RetryLink("https://ao.example.com/", key, "T-1", time.Now().Add(time.Hour))
+	tests := []struct {
+		name     string
+		link     string
+		path     string // request path instead of the link's, e.g. another ticket
+		noKey    bool
+		wantCode int
+	}{
+		{"valid link", valid, "", false, http.StatusAccepted},
+		{"another ticket", valid, "/retry/T-2", false, http.StatusForbidden},
+		{"other key", RetryLink("https://ao.example.com", []byte("other"), "T-1", time.Now().Add(time.Hour)), "", false, http.StatusForbidden},
+		{"expired", RetryLink("https://ao.example.com", key, "T-1", time.Now().Add(-time.Minute)), "", false, http.StatusForbidden},
+		{"retry links disabled", valid, "", true, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := newTestBackend()
+			backend.job = &Job{Queued: true, QueueID: "Q-1"}
+			s := New(backend)
+			// Neither a token nor a loopback Host is needed.
+			s.SetTokens([]Token{{Name: "ci", Secret: "work-secret", Scope: ScopeWork}})
+			if !tt.noKey {
+				s.SetRetryKey(key)
+			}
+			u, err := url.Parse(tt.link)
+			if err != nil {
+				t.Fatal(err)
+			}
+			path := u.Path
+			if tt.path != "" {
+				path = tt.path
+			}
+
+			page := newRequest(http.MethodGet, path+"?"+u.RawQuery, "")
+			page.Host = u.Host
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, page)
+			wantPage := tt.wantCode
+			if wantPage == http.StatusAccepted {
+				wantPage = http.StatusOK
+			}
+			if rec.Code != wantPage {
+				t.Errorf("GET %s = %d, want %d; body %s", path, rec.Code, wantPage, rec.Body)
+			}
+
+			body, _ := json.Marshal(map[string]string{"expires": u.Query().Get("expires"), "sig": u.Query().Get("sig")})
+			post := newRequest(http.MethodPost, path, string(body))
+			post.Host = u.Host
+			rec = httptest.NewRecorder()
+			s.ServeHTTP(rec, post)
+			if rec.Code != tt.wantCode {
+				t.Errorf("POST %s = %d, want %d; body %s", path, rec.Code, tt.wantCode, rec.Body)
+			}
+			if retried := len(backend.retried) == 1; retried != (tt.wantCode == http.StatusAccepted) {
+				t.Errorf("retried %v", backend.retried)
+			}
+		})
+	}
+}
+
+func TestLoadRetryKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tickets", ".retry-key")
+	key, err := LoadRetryKey(path)
+	if err != nil || len(key) != retryKeySize {
+		t.Fatalf("LoadRetryKey() = %x, %v; want a new %d-byte key", key, err, retryKeySize)
+	}
+	again, err := LoadRetryKey(path)
+	if err != nil || string(again) != string(key) {
+		t.Errorf("second LoadRetryKey() = %x, %v; want the stored key %x", again, err, key)
+	}
+	if info, err := os.Stat(path); err == nil && runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		t.Errorf("key file mode = %v, want readable only by the owner", info.Mode())
+	}
+}
@@ -0,0 +1,410 @@
+// Package server implements the HTTP API and web dashboard of the serve command.
+//
+// The server only translates HTTP to calls on a Backend; the cli package implements
+// Backend with the same code paths as the corresponding commands (add, status, work,
+// plan), so tickets created or work triggered over HTTP behave exactly as on the CLI.
+// Work and plan run as child processes whose output goes to a log file; the server
+// keeps track of the jobs it started and streams their logs with server-sent events.
+//
+// With tokens set (SetTokens) every API route requires a token whose Scope covers
+// it: read for GET routes, work to start or retry work, admin to create tickets and
+// plan.
+// Without tokens only same-origin JSON requests to a loopback Host are served.
+// Retry links (SetRetryKey) are authorized by their signature instead.
+package server
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/audit"
+	"github.com/anthropic/agent-orchestrator/internal/i18n"
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+//go:embed web
+var webFS embed.FS
+
+// Job kinds.
+const (
+	JobWork = "work"
+	JobPlan = "plan"
+)
+
+// Job is a work or plan run started through the API.
+type Job struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+	// Target is the ticket ID of a work job (empty for all pending tickets) or the
+	// milestone file of a plan job.
+	Target    string    `json:"target,omitempty"`
+	PID       int       `json:"pid,omitempty"`
+	LogPath   string    `json:"log_path,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+	// Queued is set when a work request was handed to the already running background
+	// worker instead of starting a process; QueueID is its work queue entry.
+	Queued  bool   `json:"queued,omitempty"`
+	QueueID string `json:"queue_id,omitempty"`
+	Running bool   `json:"running"`
+	// Done is closed once the process has exited.
+	Done <-chan struct{} `json:"-"`
+}
+
+// TicketRequest is the body of POST /api/tickets. Fields mirror the add flags.
+type TicketRequest struct {
+	Title              string   `json:"title"`
+	Description        string   `json:"description"`
+	Type               string   `json:"type"`
+	Priority           int      `json:"priority"`
+	Dependencies       []string `json:"dependencies"`
+	SoftDependencies   []string `json:"soft_dependencies"`
+	AcceptanceCriteria []string `json:"acceptance_criteria"`
+	Labels             []string `json:"labels"`
+	ParentID           string   `json:"parent_id"`
+	Assertions         []string `json:"assertions"`
+}
+
+// Backend is what the API exposes. Errors that are the client's fault should be
+// wrapped with NewError to pick the HTTP status; other errors are reported as 500.
+type Backend interface {
+	// Status returns the status document of the tickets carrying all labels.
+	Status(labels []string) (any, error)
+	// Tickets returns the tickets with status (all when empty) carrying all labels.
+	Tickets(status ticket.Status, labels []string) ([]*ticket.Ticket, error)
+	// Ticket returns one ticket.
+	Ticket(id string) (*ticket.Ticket, error)
+	// AddTicket creates a pending ticket.
+	AddTicket(req TicketRequest) (*ticket.Ticket, error)
+	// StartWork starts background work on ticketID (all pending tickets when empty),
+	// or queues it when background work is already running.
+	StartWork(ticketID string) (*Job, error)
+	// RetryTicket moves failed ticket id back to pending and starts work on it as
+	// StartWork does.
+	RetryTicket(id string) (*Job, error)
+	// StartPlan plans milestone into tickets in a child process.
+	StartPlan(milestone string) (*Job, error)
+}
+
+// Error is an error with the HTTP status it should be reported with.
+type Error struct {
+	Code int
+	Err  error
+}
+
+func (e *Error) Error() string { return e.Err.Error() }
+func (e *Error) Unwrap() error { return e.Err }
+
+// NewError wraps err to be reported with the HTTP status code.
+func NewError(code int, err error) error {
+	return &Error{Code: code, Err: err}
+}
+
+// Server serves the API and the dashboard. Create with New and use as an http.Handler.
+type Server struct {
+	backend Backend
+	mux     *http.ServeMux
+	// pollInterval is how often log streams check for new output.
+	pollInterval time.Duration
+
+	mu     sync.Mutex
+	jobs   map[string]*Job
+	seq    int
+	tokens []Token
+	audit  func(audit.APIEntry)
+	// retryKey signs the retry links of failure notifications (see SetRetryKey).
+	retryKey []byte
+}
+
+// New returns a Server for backend.
+func New(backend Backend) *Server {
+	s := &Server{
+		backend:      backend,
+		mux:          http.NewServeMux(),
+		pollInterval: 500 * time.Millisecond,
+		jobs:         make(map[string]*Job),
+	}
+	web, _ := fs.Sub(webFS, "web")
+	s.route("GET /", ScopeNone, http.FileServerFS(web).ServeHTTP)
+	s.route("GET /api/status", ScopeRead, s.handleStatus)
+	s.route("GET /api/tickets", ScopeRead, s.handleTickets)
+	s.route("POST /api/tickets", ScopeAdmin, s.handleAddTicket)
+	s.route("GET /api/tickets/{id}", ScopeRead, s.handleTicket)
+	s.route("POST /api/tickets/{id}/retry", ScopeWork, s.handleRetry)
+	s.route("POST /api/work", ScopeWork, s.handleWork)
+	s.route("POST /api/plan", ScopeAdmin, s.handlePlan)
+	s.route("GET /api/jobs", ScopeRead, s.handleJobs)
+	s.route("GET /api/jobs/{id}/logs", ScopeRead, s.handleJobLogs)
+	s.route("GET /retry/{id}", scopeRetryLink, s.handleRetryLinkPage)
+	s.route("POST /retry/{id}", scopeRetryLink, s.handleRetryLink)
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	report, err := s.backend.Status(queryLabels(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+func (s *Server) handleTickets(w http.ResponseWriter, r *http.Request) {
+	status := ticket.Status(r.URL.Query().Get("status"))
+	switch status {
+	case "", ticket.StatusPending, ticket.StatusInProgress, ticket.StatusCompleted, ticket.StatusFailed:
+	default:
+		writeError(w, NewError(http.StatusBadRequest, fmt.Errorf(i18n.ErrServeStatus, status)))
+		return
+	}
+	tickets, err := s.backend.Tickets(status, queryLabels(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, tickets)
+}
+
+func (s *Server) handleTicket(w http.ResponseWriter, r *http.Request) {
+	t, err := s.backend.Ticket(r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (s *Server) handleAddTicket(w http.ResponseWriter, r *http.Request) {
+	var req TicketRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	t, err := s.backend.AddTicket(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	setTarget(w, t.ID)
+	writeJSON(w, http.StatusCreated, t)
+}
+
+func (s *Server) handleWork(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TicketID string `json:"ticket_id"`
+	}
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	job, err := s.backend.StartWork(req.TicketID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	job.Kind, job.Target = JobWork, req.TicketID
+	s.writeJob(w, job)
+}
+
+func (s *Server) handleRetry(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	job, err := s.backend.RetryTicket(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	job.Kind, job.Target = JobWork, id
+	s.writeJob(w, job)
+}
+
+func (s *Server) handlePlan(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Milestone string `json:"milestone"`
+	}
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	if req.Milestone == "" {
+		writeError(w, NewError(http.StatusBadRequest, errors.New(i18n.ErrServeMilestoneRequired)))
+		return
+	}
+	job, err := s.backend.StartPlan(req.Milestone)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	job.Kind, job.Target = JobPlan, req.Milestone
+	s.writeJob(w, job)
+}
+
+func (s *Server) handleJobs(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.Jobs())
+}
+
+// writeJob records job and responds with it.
+func (s *Server) writeJob(w http.ResponseWriter, job *Job) {
+	j := s.addJob(job)
+	if j.Queued {
+		setTarget(w, j.QueueID)
+	} else {
+		setTarget(w, j.ID)
+	}
+	writeJSON(w, http.StatusAccepted, j)
+}
+
+// addJob records job under a new ID and returns a snapshot of it. Queued work
+// requests have no process or log and are not recorded.
+func (s *Server) addJob(job *Job) Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if job.StartedAt.IsZero() {
+		job.StartedAt = time.Now()
+	}
+	if job.Queued {
+		return *job
+	}
+	s.seq++
+	job.ID = job.Kind + "-" + strconv.Itoa(s.seq)
+	s.jobs[job.ID] = job
+	return snapshot(job)
+}
+
+// Jobs returns the jobs started through the API, newest first.
+func (s *Server) Jobs() []Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jobs := make([]Job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		jobs = append(jobs, snapshot(j))
+	}
+	sort.Slice(jobs, func(i, k int) bool { return jobs[i].StartedAt.After(jobs[k].StartedAt) })
+	return jobs
+}
+
+func (s *Server) job(id string) (*Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, ok := s.jobs[id]
+	return j, ok
+}
+
+// snapshot copies job with Running reflecting whether its process is still running.
+func snapshot(job *Job) Job {
+	j := *job
+	j.Running = !job.Exited()
+	return j
+}
+
+// Exited reports whether the job's process has exited; queued jobs have none.
+func (j *Job) Exited() bool {
+	if j.Done == nil {
+		return true
+	}
+	select {
+	case <-j.Done:
+		return true
+	default:
+		return false
+	}
+}
+
+// queryLabels returns the label query parameters; both ?label=a&label=b and
+// ?label=a,b are accepted.
+func queryLabels(r *http.Request) []string {
+	var labels []string
+	for _, v := range r.URL.Query()["label"] {
+		for _, l := range strings.Split(v, ",") {
+			if l = strings.TrimSpace(l); l != "" {
+				labels = append(labels, l)
+			}
+		}
+	}
+	return ticket.NormalizeLabels(labels)
+}
+
+// checkRequest refuses requests a browser could send on behalf of another site: a
+// request that changes something must be application/json, which a cross-site form
+// cannot send without a CORS preflight, and an Origin header must name the server
+// itself.
+func checkRequest(r *http.Request) error {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		ct := r.Header.Get("Content-Type")
+		if mt, _, err := mime.ParseMediaType(ct); err != nil || mt != "application/json" {
+			return NewError(http.StatusUnsupportedMediaType, fmt.Errorf(i18n.ErrServeContentType, ct))
+		}
+	}
+	if origin := r.Header.Get("Origin"); origin != "" {
+		u, err := url.Parse(origin)
+		if err != nil || !strings.EqualFold(u.Host, r.Host) {
+			return NewError(http.StatusForbidden, fmt.Errorf(i18n.ErrServeCrossOrigin, origin))
+		}
+	}
+	return nil
+}
+
+// checkLocalHost refuses a request whose Host header is not a loopback name or
+// address, so that a site cannot reach the server by rebinding its own DNS name to
+// 127.0.0.1.
+func checkLocalHost(r *http.Request) error {
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		host = r.Host
+	}
+	host = strings.Trim(host, "[]")
+	if strings.EqualFold(host, "localhost") {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return NewError(http.StatusForbidden, fmt.Errorf(i18n.ErrServeHost, r.Host))
+}
+
+// decodeBody decodes the JSON request body into v. An empty body leaves v unchanged.
+func decodeBody(r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, 1<<20))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return NewError(http.StatusBadRequest, fmt.Errorf(i18n.ErrServeBadRequest, err))
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+// writeError reports err as {"error": "..."} with the status of an Error, else 500.
+func writeError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	var e *Error
+	if errors.As(err, &e) {
+		code = e.Code
+	}
+	if rec, ok := w.(*recorder); ok {
+		rec.err = err.Error()
+	}
+	writeJSON(w, code, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,271 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/ticket"
+)
+
+// fakeBackend serves a fixed ticket list and records the requests it receives.
+type fakeBackend struct {
+	tickets []*ticket.Ticket
+	added   []TicketRequest
+	job     *Job
+	labels  []string
+	retried []string
+}
+
+func (b *fakeBackend) Status(labels []string) (any, error) {
+	b.labels = labels
+	return map[string]int{"total": len(b.tickets)}, nil
+}
+
+func (b *fakeBackend) Tickets(status ticket.Status, labels []string) ([]*ticket.Ticket, error) {
+	var out []*ticket.Ticket
+	for _, t := range b.tickets {
+		if status == "" || t.Status == status {
+			out = append(out, t)
+		}
+	}
+	return ticket.FilterByLabels(out, labels), nil
+}
+
+func (b *fakeBackend) Ticket(id string) (*ticket.Ticket, error) {
+	for _, t := range b.tickets {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+	return nil, NewError(http.StatusNotFound, errors.New("not found"))
+}
+
+func (b *fakeBackend) AddTicket(req TicketRequest) (*ticket.Ticket, error) {
+	b.added = append(b.added, req)
+	return ticket.NewTicket("T-NEW", req.Title, req.Description), nil
+}
+
+func (b *fakeBackend) StartWork(ticketID string) (*Job, error) { return b.job, nil }
+
+func (b *fakeBackend) RetryTicket(id string) (*Job, error) {
+	if _, err := b.Ticket(id); err != nil {
+		return nil, err
+	}
+	b.retried = append(b.retried, id)
+	return b.job, nil
+}
+
+func (b *fakeBackend) StartPlan(milestone string) (*Job, error) {
+	return nil, NewError(http.StatusConflict, errors.New("busy"))
+}
+
+func newTestBackend() *fakeBackend {
+	pending := ticket.NewTicket("T-1", "first", "")
+	pending.Labels = []string{"backend"}
+	done := ticket.NewTicket("T-2", "second", "")
+	done.Status = ticket.StatusCompleted
+	return &fakeBackend{tickets: []*ticket.Ticket{pending, done}}
+}
+
+// newRequest returns a request as the dashboard sends it: to a local Host, with a JSON
+// body for anything but GET.
+func newRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Host = "127.0.0.1:8080"
+	if method != http.MethodGet {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req
+}
+
+func TestServer_Routes(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantCode int
+		wantBody string
+	}{
+		{"dashboard", http.MethodGet, "/", "", http.StatusOK, "<title>agent-orchestrator</title>"},
+		{"status", http.MethodGet, "/api/status?label=backend", "", http.StatusOK, `"total": 2`},
+		{"tickets by status", http.MethodGet, "/api/tickets?status=completed", "", http.StatusOK, `"T-2"`},
+		{"tickets by label", http.MethodGet, "/api/tickets?label=backend", "", http.StatusOK, `"T-1"`},
+		{"invalid status", http.MethodGet, "/api/tickets?status=done", "", http.StatusBadRequest, `"error"`},
+		{"one ticket", http.MethodGet, "/api/tickets/T-1", "", http.StatusOK, `"first"`},
+		{"unknown ticket", http.MethodGet, "/api/tickets/T-9", "", http.StatusNotFound, `"not found"`},
+		{"add ticket", http.MethodPost, "/api/tickets", `{"title": "new", "labels": ["api"]}`, http.StatusCreated, `"T-NEW"`},
+		{"unknown field", http.MethodPost, "/api/tickets", `{"titel": "new"}`, http.StatusBadRequest, `"error"`},
+		{"plan requires milestone", http.MethodPost, "/api/plan", `{}`, http.StatusBadRequest, `"error"`},
+		{"backend error status", http.MethodPost, "/api/plan", `{"milestone": "m.md"}`, http.StatusConflict, `"busy"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(newTestBackend())
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, newRequest(tt.method, tt.path, tt.body))
+			if rec.Code != tt.wantCode {
+				t.Errorf("%s %s = %d, want %d; body %s", tt.method, tt.path, rec.Code, tt.wantCode, rec.Body)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("%s %s body should contain %q, got %s", tt.method, tt.path, tt.wantBody, rec.Body)
+			}
+		})
+	}
+}
+
+func TestServer_TicketsFilteredByLabel(t *testing.T) {
+	s := New(newTestBackend())
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, newRequest(http.MethodGet, "/api/tickets?label=backend", ""))
+	var tickets []*ticket.Ticket
+	if err := json.Unmarshal(rec.Body.Bytes(), &tickets); err != nil {
+		t.Fatal(err)
+	}
+	if len(tickets) != 1 || tickets[0].ID != "T-1" {
+		t.Errorf("GET /api/tickets?label=backend = %v, want only T-1", tickets)
+	}
+}
+
+func TestServer_RetryTicket(t *testing.T) {
+	backend := newTestBackend()
+	backend.job = &Job{Queued: true, QueueID: "Q-1"}
+	s := New(backend)
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, newRequest(http.MethodPost, "/api/tickets/T-1/retry", ""))
+	var job Job
+	if err := json.Unmarshal(rec.Body.Bytes(), &job); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusAccepted || job.Kind != JobWork || job.Target != "T-1" || len(backend.retried) != 1 {
+		t.Errorf("POST /api/tickets/T-1/retry = %d %+v, retried %v", rec.Code, job, backend.retried)
+	}
+
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, newRequest(http.MethodPost, "/api/tickets/T-9/retry", ""))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("POST /api/tickets/T-9/retry = %d, want 404", rec.Code)
+	}
+}
+
+func TestServer_WorkJobAndLogStream(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "work.log")
+	if err := os.WriteFile(logPath, []byte("line 1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	backend := newTestBackend()
+	backend.job = &Job{PID: 42, LogPath: logPath, Done: done}
+	s := New(backend)
+	s.pollInterval = 10 * time.Millisecond
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	res, err := http.Post(srv.URL+"/api/work", "application/json", strings.NewReader(`{"ticket_id": "T-1"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var job Job
+	json.NewDecoder(res.Body).Decode(&job)
+	res.Body.Close()
+	if res.StatusCode != http.StatusAccepted || job.ID != "work-1" || job.Kind != JobWork || job.Target != "T-1" || !job.Running {
+		t.Fatalf("POST /api/work = %d %+v", res.StatusCode, job)
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		f, _ := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, 0600)
+		f.WriteString("line 2\n")
+		f.Close()
+		close(done)
+	}()
+	res, err = http.Get(srv.URL + "/api/jobs/work-1/logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	want := "data: line 1\n\ndata: line 2\n\nevent: end\ndata: \n\n"
+	if string(body) != want {
+		t.Errorf("log stream = %q, want %q", body, want)
+	}
+
+	if jobs := s.Jobs(); len(jobs) != 1 || jobs[0].Running {
+		t.Errorf("Jobs() = %+v, want the exited work job", jobs)
+	}
+}
+
+func TestServer_QueuedWorkNotRecorded(t *testing.T) {
+	backend := newTestBackend()
+	backend.job = &Job{Queued: true, QueueID: "Q-1"}
+	s := New(backend)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, newRequest(http.MethodPost, "/api/work", `{}`))
+	if rec.Code != http.StatusAccepted || !strings.Contains(rec.Body.String(), `"queue_id": "Q-1"`) {
+		t.Errorf("POST /api/work = %d %s", rec.Code, rec.Body)
+	}
+	if jobs := s.Jobs(); len(jobs) != 0 {
+		t.Errorf("queued work should not be a job, got %+v", jobs)
+	}
+}
+
+func TestServer_RejectsCrossSiteRequests(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		host        string
+		origin      string
+		contentType string
+		tokens      bool
+		wantCode    int
+	}{
+		{"same-origin JSON post", http.MethodPost, "127.0.0.1:8080", "http://127.0.0.1:8080", "application/json; charset=utf-8", false, http.StatusAccepted},
+		{"localhost host", http.MethodGet, "localhost:8080", "", "", false, http.StatusOK},
+		{"text/plain post", http.MethodPost, "127.0.0.1:8080", "", "text/plain", false, http.StatusUnsupportedMediaType},
+		{"form post", http.MethodPost, "127.0.0.1:8080", "", "application/x-www-form-urlencoded", false, http.StatusUnsupportedMediaType},
+		{"post without content type", http.MethodPost, "127.0.0.1:8080", "", "", false, http.StatusUnsupportedMediaType},
+		{"other origin", http.MethodPost, "127.0.0.1:8080", "https://evil.example", "application/json", false, http.StatusForbidden},
+		{"other origin reading", http.MethodGet, "127.0.0.1:8080", "https://evil.example", "", false, http.StatusForbidden},
+		{"rebound host", http.MethodGet, "evil.example:8080", "", "", false, http.StatusForbidden},
+		{"any host with tokens", http.MethodGet, "orchestrator.internal:8080", "", "", true, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := newTestBackend()
+			backend.job = &Job{Queued: true, QueueID: "Q-1"}
+			s := New(backend)
+			if tt.tokens {
+				s.SetTokens([]Token{{Name: "ci", Secret: "secret", Scope: ScopeAdmin}})
+			}
+			path := "/api/status"
+			if tt.method == http.MethodPost {
+				path = "/api/work"
+			}
+			req := httptest.NewRequest(tt.method, path, strings.NewReader(`{}`))
+			req.Host = tt.host
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.tokens {
+				req.Header.Set("Authorization", "Bearer secret")
+			}
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, req)
+			if rec.Code != tt.wantCode {
+				t.Errorf("%s %s (Host %s) = %d, want %d; body %s", tt.method, path, tt.host, rec.Code, tt.wantCode, rec.Body)
+			}
+		})
+	}
+}
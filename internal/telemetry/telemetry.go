@@ -0,0 +1,262 @@
+// Package telemetry keeps in-process counters, histograms and gauges and serves them
+// in the Prometheus text exposition format, so long-running modes (serve, work
+// --detach) can be scraped and alerted on, e.g. when no ticket finished for an hour.
+//
+// Only what the orchestrator needs is implemented: metrics are registered once at
+// startup, label values are given in registration order, and there is no push support.
+package telemetry
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the media type of the text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Registry holds metrics in registration order.
+type Registry struct {
+	mu      sync.Mutex
+	metrics []metric
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+type metric interface {
+	write(w io.Writer)
+}
+
+func (r *Registry) register(m metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = append(r.metrics, m)
+}
+
+// WriteText writes every metric in the text exposition format.
+func (r *Registry) WriteText(w io.Writer) {
+	r.mu.Lock()
+	metrics := append([]metric(nil), r.metrics...)
+	r.mu.Unlock()
+	for _, m := range metrics {
+		m.write(w)
+	}
+}
+
+// Handler serves the registry for Prometheus scrapes.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		r.WriteText(w)
+	})
+}
+
+// desc is what every metric has: name, help and label names.
+type desc struct {
+	name   string
+	help   string
+	labels []string
+}
+
+func (d desc) header(w io.Writer, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", d.name, escapeHelp(d.help), d.name, kind)
+}
+
+// key joins label values into a map key; labelPairs formats them back.
+func (d desc) key(values []string) string {
+	if len(values) != len(d.labels) {
+		panic(fmt.Sprintf("telemetry: %s takes %d label values, got %d", d.name, len(d.labels), len(values)))
+	}
+	return strings.Join(values, "\xff")
+}
+
+func (d desc) labelPairs(key string, extra ...string) string {
+	var pairs []string
+	if len(d.labels) > 0 {
+		for i, v := range strings.Split(key, "\xff") {
+			pairs = append(pairs, d.labels[i]+`="`+escapeLabel(v)+`"`)
+		}
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, extra[i]+`="`+escapeLabel(extra[i+1])+`"`)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// Counter is a monotonically increasing value per combination of label values.
+type Counter struct {
+	desc
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+// NewCounter registers a counter with the given label names.
+func (r *Registry) NewCounter(name, help string, labels ...string) *Counter {
+	c := &Counter{desc: desc{name, help, labels}, values: make(map[string]float64)}
+	r.register(c)
+	return c
+}
+
+// Add adds v (which must not be negative) to the counter for labelValues.
+func (c *Counter) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		return
+	}
+	k := c.key(labelValues)
+	c.mu.Lock()
+	c.values[k] += v
+	c.mu.Unlock()
+}
+
+// Inc adds 1 to the counter for labelValues.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Value returns the counter for labelValues.
+func (c *Counter) Value(labelValues ...string) float64 {
+	k := c.key(labelValues)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.values[k]
+}
+
+func (c *Counter) write(w io.Writer) {
+	c.header(w, "counter")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, k := range sortedKeys(c.values) {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, c.labelPairs(k), formatFloat(c.values[k]))
+	}
+}
+
+// Histogram counts observations into cumulative buckets per combination of label values.
+type Histogram struct {
+	desc
+	buckets []float64 // upper bounds, ascending, without +Inf
+	mu      sync.Mutex
+	series  map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	counts []uint64 // per bucket, not cumulative; last is +Inf
+	sum    float64
+	count  uint64
+}
+
+// NewHistogram registers a histogram with the given bucket upper bounds (sorted
+// ascending; +Inf is implied) and label names.
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	h := &Histogram{desc: desc{name, help, labels}, buckets: buckets, series: make(map[string]*histogramSeries)}
+	r.register(h)
+	return h
+}
+
+// Observe records v for labelValues.
+func (h *Histogram) Observe(v float64, labelValues ...string) {
+	k := h.key(labelValues)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s := h.series[k]
+	if s == nil {
+		s = &histogramSeries{counts: make([]uint64, len(h.buckets)+1)}
+		h.series[k] = s
+	}
+	s.counts[sort.SearchFloat64s(h.buckets, v)]++
+	s.sum += v
+	s.count++
+}
+
+// Count returns the number of observations for labelValues.
+func (h *Histogram) Count(labelValues ...string) uint64 {
+	k := h.key(labelValues)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if s := h.series[k]; s != nil {
+		return s.count
+	}
+	return 0
+}
+
+func (h *Histogram) write(w io.Writer) {
+	h.header(w, "histogram")
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, k := range sortedKeys(h.series) {
+		s := h.series[k]
+		var cumulative uint64
+		for i, upper := range h.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairs(k, "le", formatFloat(upper)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairs(k, "le", "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, h.labelPairs(k), formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, h.labelPairs(k), s.count)
+	}
+}
+
+// GaugeFunc is a gauge whose values are read at scrape time, keyed by the value of its
+// single label (or "" when it has none).
+type GaugeFunc struct {
+	desc
+	fn func() map[string]float64
+}
+
+// NewGaugeFunc registers a gauge computed by fn on every scrape. label is the name of
+// the label fn's keys are values of; "" for an unlabelled gauge read from key "".
+// A nil map (e.g. on error) omits the gauge's samples from that scrape.
+func (r *Registry) NewGaugeFunc(name, help, label string, fn func() map[string]float64) *GaugeFunc {
+	var labels []string
+	if label != "" {
+		labels = []string{label}
+	}
+	g := &GaugeFunc{desc: desc{name, help, labels}, fn: fn}
+	r.register(g)
+	return g
+}
+
+func (g *GaugeFunc) write(w io.Writer) {
+	g.header(w, "gauge")
+	values := g.fn()
+	for _, k := range sortedKeys(values) {
+		fmt.Fprintf(w, "%s%s %s\n", g.name, g.labelPairs(k), formatFloat(values[k]))
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string  { return helpEscaper.Replace(s) }
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
@@ -0,0 +1,72 @@
+package telemetry
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegistry_WriteText(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounter("ao_processed_total", "Tickets processed.", "status")
+	c.Inc("failed")
+	c.Add(2, "completed")
+	c.Add(-1, "completed") // counters never go down
+	h := r.NewHistogram("ao_call_seconds", "Call duration.", []float64{1, 10}, "outcome")
+	h.Observe(0.5, "success")
+	h.Observe(1, "success")
+	h.Observe(30, "success")
+	r.NewGaugeFunc("ao_tickets", "Tickets by status.", "status", func() map[string]float64 {
+		return map[string]float64{"pending": 3, `we"ird`: 1}
+	})
+	r.NewGaugeFunc("ao_broken", "Unavailable gauge.", "", func() map[string]float64 { return nil })
+
+	var sb strings.Builder
+	r.WriteText(&sb)
+	want := `# HELP ao_processed_total Tickets processed.
+# TYPE ao_processed_total counter
+ao_processed_total{status="completed"} 2
+ao_processed_total{status="failed"} 1
+# HELP ao_call_seconds Call duration.
+# TYPE ao_call_seconds histogram
+ao_call_seconds_bucket{outcome="success",le="1"} 2
+ao_call_seconds_bucket{outcome="success",le="10"} 2
+ao_call_seconds_bucket{outcome="success",le="+Inf"} 3
+ao_call_seconds_sum{outcome="success"} 31.5
+ao_call_seconds_count{outcome="success"} 3
+# HELP ao_tickets Tickets by status.
+# TYPE ao_tickets gauge
+ao_tickets{status="pending"} 3
+ao_tickets{status="we\"ird"} 1
+# HELP ao_broken Unavailable gauge.
+# TYPE ao_broken gauge
+`
+	if sb.String() != want {
+		t.Errorf("WriteText() =\n%s\nwant\n%s", sb.String(), want)
+	}
+	if c.Value("completed") != 2 || h.Count("success") != 3 || h.Count("failure") != 0 {
+		t.Errorf("Value() = %v, Count() = %d", c.Value("completed"), h.Count("success"))
+	}
+}
+
+func TestRegistry_Handler(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounter("ao_up_total", "Scrapes.").Inc()
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Header().Get("Content-Type") != ContentType {
+		t.Errorf("Content-Type = %q", rec.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(rec.Body.String(), "ao_up_total 1\n") {
+		t.Errorf("body = %q, want the unlabelled counter", rec.Body.String())
+	}
+}
+
+func TestCounter_WrongLabelCountPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Inc() with the wrong number of label values should panic")
+		}
+	}()
+	NewRegistry().NewCounter("ao_x_total", "x", "a", "b").Inc("only-one")
+}
@@ -0,0 +1,192 @@
+package ticket
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// archiveExt is the file extension of an archived ticket: gzip-compressed JSON.
+const archiveExt = ".json.gz"
+
+// Archive holds tickets moved out of the store, one gzip-compressed JSON file per
+// ticket. Archived tickets are not seen by Storer queries (LoadAll, Count, the
+// dependency resolver); read them with Load and LoadAll on the Archive.
+type Archive struct {
+	dir string
+}
+
+// NewArchive returns the archive in dir (e.g. .tickets/archive); the directory is
+// created on the first Add.
+func NewArchive(dir string) *Archive {
+	return &Archive{dir: dir}
+}
+
+func (a *Archive) path(id string) string {
+	return filepath.Join(a.dir, id+archiveExt)
+}
+
+// Add writes t to the archive, replacing an archived ticket with the same ID.
+func (a *Archive) Add(t *Ticket) error {
+	data, err := t.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal ticket: %w", err)
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	// Use 0700 for ticket directories to protect sensitive data
+	if err := os.MkdirAll(a.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	if err := writeFileAtomic(a.path(t.ID), buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write archived ticket: %w", err)
+	}
+	return nil
+}
+
+// Load reads an archived ticket by ID.
+func (a *Archive) Load(id string) (*Ticket, error) {
+	return a.read(a.path(id))
+}
+
+func (a *Archive) read(path string) (*Ticket, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("archived ticket not found: %s", strings.TrimSuffix(filepath.Base(path), archiveExt))
+		}
+		return nil, err
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, &CorruptFileError{Path: path, Err: err}
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, &CorruptFileError{Path: path, Err: err}
+	}
+	t, err := FromJSON(data)
+	if err != nil {
+		return nil, &CorruptFileError{Path: path, Err: err}
+	}
+	t.recordCreated()
+	return t, nil
+}
+
+// LoadAll reads every archived ticket, most recently finished first. Corrupt files are
+// skipped. A missing archive directory yields no tickets.
+func (a *Archive) LoadAll() ([]*Ticket, error) {
+	entries, err := os.ReadDir(a.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read archive directory: %w", err)
+	}
+	var tickets []*Ticket
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), archiveExt) {
+			continue
+		}
+		if t, err := a.read(filepath.Join(a.dir, e.Name())); err == nil {
+			tickets = append(tickets, t)
+		}
+	}
+	sort.SliceStable(tickets, func(i, j int) bool {
+		return finishedAt(tickets[i]).After(finishedAt(tickets[j]))
+	})
+	return tickets, nil
+}
+
+// Count returns the number of archived tickets without reading them; 0 when there is
+// no archive.
+func (a *Archive) Count() int {
+	entries, err := os.ReadDir(a.dir)
+	if err != nil {
+		return 0
+	}
+	n := 0
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), archiveExt) {
+			n++
+		}
+	}
+	return n
+}
+
+// finishedAt returns when t was completed or failed, or its creation time if unknown.
+func finishedAt(t *Ticket) time.Time {
+	if t.CompletedAt != nil {
+		return *t.CompletedAt
+	}
+	return t.CreatedAt
+}
+
+// ArchivableTickets returns the completed and failed tickets among tickets that
+// finished before cutoff. Tickets that a ticket staying in the store depends on, or
+// that are the parent of one, are kept out: the dependency resolver only sees the
+// store, and would otherwise consider such dependencies missing.
+func ArchivableTickets(tickets []*Ticket, cutoff time.Time) []*Ticket {
+	archive := make(map[string]bool)
+	for _, t := range tickets {
+		if (t.Status == StatusCompleted || t.Status == StatusFailed) && finishedAt(t).Before(cutoff) {
+			archive[t.ID] = true
+		}
+	}
+	// Keeping a ticket can keep its own dependencies, so repeat until nothing changes.
+	for changed := true; changed; {
+		changed = false
+		for _, t := range tickets {
+			if archive[t.ID] {
+				continue
+			}
+			for _, id := range append(append([]string{}, t.Dependencies...), t.ParentID) {
+				if archive[id] {
+					delete(archive, id)
+					changed = true
+				}
+			}
+		}
+	}
+	var out []*Ticket
+	for _, t := range tickets {
+		if archive[t.ID] {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// ArchiveTickets moves the tickets of store that ArchivableTickets selects for cutoff
+// into archive: each ticket is written to the archive before it is deleted from the
+// store. It returns the archived tickets, also those archived before an error.
+func ArchiveTickets(store Storer, archive *Archive, cutoff time.Time) ([]*Ticket, error) {
+	all, err := store.LoadAll()
+	if err != nil {
+		return nil, err
+	}
+	var archived []*Ticket
+	for _, t := range ArchivableTickets(all.Tickets, cutoff) {
+		if err := archive.Add(t); err != nil {
+			return archived, err
+		}
+		if err := store.Delete(t.ID); err != nil {
+			return archived, err
+		}
+		archived = append(archived, t)
+	}
+	return archived, nil
+}
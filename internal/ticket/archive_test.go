@@ -0,0 +1,91 @@
+package ticket
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestArchivableTickets(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-48 * time.Hour)
+	recent := now.Add(-time.Hour)
+	finished := func(id string, status Status, at time.Time, deps ...string) *Ticket {
+		tk := NewTicket(id, id, "")
+		tk.Status = status
+		tk.CompletedAt = &at
+		tk.Dependencies = deps
+		return tk
+	}
+	pending := func(id string, deps ...string) *Ticket {
+		tk := NewTicket(id, id, "")
+		tk.Dependencies = deps
+		return tk
+	}
+	child := func(id, parent string) *Ticket {
+		tk := NewTicket(id, id, "")
+		tk.ParentID = parent
+		return tk
+	}
+
+	tests := []struct {
+		name    string
+		tickets []*Ticket
+		want    []string
+	}{
+		{"old completed and failed", []*Ticket{finished("A", StatusCompleted, old), finished("B", StatusFailed, old)}, []string{"A", "B"}},
+		{"recent ones are kept", []*Ticket{finished("A", StatusCompleted, old), finished("B", StatusCompleted, recent)}, []string{"A"}},
+		{"open tickets are kept", []*Ticket{pending("A"), finished("B", StatusCompleted, old)}, []string{"B"}},
+		{"dependency of an open ticket is kept", []*Ticket{finished("A", StatusCompleted, old), pending("B", "A")}, nil},
+		{"kept transitively", []*Ticket{finished("A", StatusCompleted, old), finished("B", StatusCompleted, recent, "A"), pending("C", "B")}, nil},
+		{"archived together with its dependent", []*Ticket{finished("A", StatusCompleted, old), finished("B", StatusCompleted, old, "A")}, []string{"A", "B"}},
+		{"epic of an open child is kept", []*Ticket{finished("E", StatusCompleted, old), child("C", "E")}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, tk := range ArchivableTickets(tt.tickets, now.Add(-24*time.Hour)) {
+				got = append(got, tk.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ArchivableTickets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArchiveTickets(t *testing.T) {
+	store, tempDir := setupTestStoreForStore(t)
+	defer cleanupTestStoreForStore(t, tempDir)
+
+	done := NewTicket("T-1", "done", "")
+	done.MarkCompleted("ok")
+	for _, tk := range []*Ticket{done, NewTicket("T-2", "open", "")} {
+		if err := store.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	archive := NewArchive(t.TempDir())
+	archived, err := ArchiveTickets(store, archive, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("ArchiveTickets() error = %v", err)
+	}
+	if len(archived) != 1 || archived[0].ID != "T-1" {
+		t.Fatalf("ArchiveTickets() = %v, want T-1", archived)
+	}
+	if _, err := store.Load("T-1"); err == nil {
+		t.Error("an archived ticket should be removed from the store")
+	}
+	if _, err := store.Load("T-2"); err != nil {
+		t.Errorf("an open ticket should stay in the store: %v", err)
+	}
+
+	got, err := archive.Load("T-1")
+	if err != nil || got.Title != "done" || got.Status != StatusCompleted || got.AgentOutput != "ok" {
+		t.Errorf("archive.Load() = %+v, %v", got, err)
+	}
+	if all, err := archive.LoadAll(); err != nil || len(all) != 1 || archive.Count() != 1 {
+		t.Errorf("archive.LoadAll() = %d tickets, %v; Count() = %d; want 1", len(all), err, archive.Count())
+	}
+}
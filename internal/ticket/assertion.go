@@ -0,0 +1,67 @@
+package ticket
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Assertion is an executable acceptance criterion: a shell command run in the project
+// root after coding, with its expected exit code and optionally a pattern its output
+// must match.
+type Assertion struct {
+	Command string `json:"command"`
+	// ExitCode is the expected exit code (default 0).
+	ExitCode int `json:"exit_code,omitempty"`
+	// OutputPattern is a regular expression the combined stdout/stderr must match.
+	OutputPattern string `json:"output_pattern,omitempty"`
+	// TimeoutSec bounds the command's run time; 0 uses the runner's default.
+	TimeoutSec int `json:"timeout_sec,omitempty"`
+}
+
+// Validate checks that the assertion has a command and a valid output pattern.
+func (a Assertion) Validate() error {
+	if strings.TrimSpace(a.Command) == "" {
+		return fmt.Errorf("command is required")
+	}
+	if a.TimeoutSec < 0 {
+		return fmt.Errorf("timeout_sec must not be negative")
+	}
+	if a.OutputPattern != "" {
+		if _, err := regexp.Compile(a.OutputPattern); err != nil {
+			return fmt.Errorf("invalid output_pattern: %w", err)
+		}
+	}
+	return nil
+}
+
+// String describes the assertion, e.g. "go test ./... (exit 0)".
+func (a Assertion) String() string {
+	s := fmt.Sprintf("%s (exit %d", a.Command, a.ExitCode)
+	if a.OutputPattern != "" {
+		s += fmt.Sprintf(", output ~ /%s/", a.OutputPattern)
+	}
+	return s + ")"
+}
+
+// AssertionResult records one run of an Assertion.
+type AssertionResult struct {
+	Command  string    `json:"command"`
+	Passed   bool      `json:"passed"`
+	ExitCode int       `json:"exit_code"`
+	Output   string    `json:"output,omitempty"`
+	Error    string    `json:"error,omitempty"`
+	RanAt    time.Time `json:"ran_at"`
+}
+
+// FailedAssertions returns the results that did not pass.
+func FailedAssertions(results []AssertionResult) []AssertionResult {
+	var out []AssertionResult
+	for _, r := range results {
+		if !r.Passed {
+			out = append(out, r)
+		}
+	}
+	return out
+}
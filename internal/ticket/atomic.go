@@ -0,0 +1,93 @@
+package ticket
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// writeFileAtomic writes data to path through a temporary file in the same directory,
+// synced and then renamed over path, so a crash mid-write leaves either the old file
+// or the new one, never a truncated one. The temporary name does not end in .json, so
+// a leftover is never read as a ticket.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Chmod(tmp, perm); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// CorruptFileError reports a ticket file that exists but cannot be parsed, e.g. one
+// truncated by a crash before writes were atomic or edited by hand.
+type CorruptFileError struct {
+	Path string
+	Err  error
+}
+
+func (e *CorruptFileError) Error() string {
+	return fmt.Sprintf("corrupt ticket file %s: %v", e.Path, e.Err)
+}
+
+func (e *CorruptFileError) Unwrap() error {
+	return e.Err
+}
+
+// corruptFiles tracks the corrupt ticket files the store came across while loading.
+type corruptFiles struct {
+	mu    sync.Mutex
+	files map[string]*CorruptFileError
+}
+
+// record notes that path is corrupt (err non-nil) or readable again (err nil).
+func (c *corruptFiles) record(path string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err == nil {
+		delete(c.files, path)
+		return
+	}
+	if c.files == nil {
+		c.files = make(map[string]*CorruptFileError)
+	}
+	c.files[path] = &CorruptFileError{Path: path, Err: err}
+}
+
+// CorruptFiles returns the corrupt ticket files the store skipped while loading tickets
+// (LoadByStatus, LoadAll), sorted by path. Load reports a corrupt file as its error
+// instead. A file is dropped from the list once it loads or is saved again.
+func (s *Store) CorruptFiles() []*CorruptFileError {
+	s.corrupt.mu.Lock()
+	defer s.corrupt.mu.Unlock()
+	out := make([]*CorruptFileError, 0, len(s.corrupt.files))
+	for _, e := range s.corrupt.files {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out
+}
@@ -0,0 +1,215 @@
+package ticket
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BulkRow is a ticket read from a bulk import file (add --from-file), with its
+// position in the file and what is wrong with it.
+type BulkRow struct {
+	// Row is the 1-based position of the ticket: its index in a JSON file, its line in
+	// a CSV file.
+	Row    int
+	Ticket *Ticket
+	Errors []string
+}
+
+func (r *BulkRow) addError(format string, args ...any) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+}
+
+// bulkColumns are the columns of a CSV bulk file. List columns separate their values
+// with ";".
+var bulkColumns = []string{"id", "title", "description", "type", "priority", "dependencies", "soft_dependencies", "acceptance_criteria", "labels", "parent", "milestone"}
+
+// ReadBulkFile reads the tickets of a bulk import file: CSV when path ends in .csv,
+// otherwise JSON in the generated-tickets format ({"tickets": [...]}, or a bare array).
+// Every ticket starts pending. Values that cannot be read (e.g. a priority that is not
+// a number) are reported in the row's Errors; an unreadable file is an error.
+func ReadBulkFile(path string) ([]*BulkRow, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return readBulkCSV(data)
+	}
+	return readBulkJSON(data)
+}
+
+func readBulkJSON(data []byte) ([]*BulkRow, error) {
+	var raws []json.RawMessage
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(data, &raws); err != nil {
+			return nil, fmt.Errorf("failed to parse ticket list JSON: %w", err)
+		}
+	} else {
+		var list struct {
+			Tickets []json.RawMessage `json:"tickets"`
+		}
+		if err := json.Unmarshal(data, &list); err != nil {
+			return nil, fmt.Errorf("failed to parse ticket list JSON: %w", err)
+		}
+		raws = list.Tickets
+	}
+
+	rows := make([]*BulkRow, len(raws))
+	for i, raw := range raws {
+		row := &BulkRow{Row: i + 1, Ticket: NewTicket("", "", "")}
+		if err := json.Unmarshal(raw, row.Ticket); err != nil {
+			row.addError("invalid ticket JSON: %v", err)
+		}
+		row.Ticket.Status, row.Ticket.CreatedAt, row.Ticket.CompletedAt = StatusPending, time.Now(), nil
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+func readBulkCSV(data []byte) ([]*BulkRow, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !slices.Contains(bulkColumns, name) {
+			return nil, fmt.Errorf("unknown CSV column %q (use %s)", name, strings.Join(bulkColumns, ", "))
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, fmt.Errorf("CSV header has no title column")
+	}
+
+	var rows []*BulkRow
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		line, _ := r.FieldPos(0)
+		row := &BulkRow{Row: line, Ticket: NewTicket("", "", "")}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		list := func(name string) []string {
+			var values []string
+			for _, v := range strings.Split(field(name), ";") {
+				if v = strings.TrimSpace(v); v != "" {
+					values = append(values, v)
+				}
+			}
+			return values
+		}
+		t := row.Ticket
+		t.ID, t.Title, t.Description = field("id"), field("title"), field("description")
+		if v := field("type"); v != "" {
+			t.Type = Type(strings.ToLower(v))
+		}
+		if v := field("priority"); v != "" {
+			if p, err := strconv.Atoi(v); err != nil {
+				row.addError("priority must be a number, got %q", v)
+			} else {
+				t.Priority = p
+			}
+		}
+		if deps := list("dependencies"); deps != nil {
+			t.Dependencies = deps
+		}
+		t.SoftDependencies = list("soft_dependencies")
+		if criteria := list("acceptance_criteria"); criteria != nil {
+			t.AcceptanceCriteria = criteria
+		}
+		t.Labels = NormalizeLabels(list("labels"))
+		t.ParentID, t.Milestone = field("parent"), field("milestone")
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// ValidateBulk checks the tickets of rows before they are saved to the resolver's
+// store and records the problems in each row's Errors: invalid fields, IDs used twice
+// or already taken, dependencies and parents that are neither in the file nor in the
+// store, and dependency cycles through the new tickets.
+func (dr *DependencyResolver) ValidateBulk(rows []*BulkRow) error {
+	stored, err := dr.store.LoadAll()
+	if err != nil {
+		return err
+	}
+	existing := stored.Tickets
+	known := make(map[string]bool, len(existing)+len(rows))
+	for _, t := range existing {
+		known[t.ID] = true
+	}
+	byID := make(map[string]*BulkRow, len(rows))
+	for _, row := range rows {
+		t := row.Ticket
+		if err := t.Validate(); err != nil {
+			row.addError("%v", err)
+		}
+		if !t.Type.IsValid() {
+			row.addError("unknown type %q", t.Type)
+		}
+		if t.Priority < 1 || t.Priority > 5 {
+			row.addError("priority must be between 1 and 5, got %d", t.Priority)
+		}
+		if t.ID == "" {
+			continue
+		}
+		if known[t.ID] && byID[t.ID] == nil {
+			row.addError("ticket %s already exists", t.ID)
+		} else if first := byID[t.ID]; first != nil {
+			row.addError("duplicate ID %s (also row %d)", t.ID, first.Row)
+			continue
+		}
+		byID[t.ID] = row
+		known[t.ID] = true
+	}
+
+	for _, row := range rows {
+		t := row.Ticket
+		for _, dep := range slices.Concat(t.Dependencies, t.SoftDependencies) {
+			if !known[dep] {
+				row.addError("unknown dependency: %s", dep)
+			}
+		}
+		if t.ParentID != "" && !known[t.ParentID] {
+			row.addError("unknown parent: %s", t.ParentID)
+		}
+	}
+
+	all := slices.Clip(existing)
+	for _, row := range rows {
+		if byID[row.Ticket.ID] == row {
+			all = append(all, row.Ticket)
+		}
+	}
+	for _, cycle := range dr.FindCycles(all) {
+		path := strings.Join(append(slices.Clone(cycle), cycle[0]), " → ")
+		for _, id := range cycle {
+			if row := byID[id]; row != nil {
+				row.addError("dependency cycle: %s", path)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package ticket
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeBulkFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadBulkFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantIDs []string
+		wantErr bool
+	}{
+		{"generated-tickets format", "b.json", `{"tickets": [{"id": "A", "title": "a", "status": "completed"}, {"id": "B", "title": "b"}]}`, []string{"A", "B"}, false},
+		{"bare array", "b.json", `[{"id": "A", "title": "a"}]`, []string{"A"}, false},
+		{"csv", "b.csv", "id,title,dependencies,labels\nA,a,,\nB,b,A;C,Backend; api\n", []string{"A", "B"}, false},
+		{"invalid json", "b.json", `{"tickets": [`, nil, true},
+		{"unknown csv column", "b.csv", "id,title,owner\nA,a,me\n", nil, true},
+		{"csv without title", "b.csv", "id\nA\n", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := ReadBulkFile(writeBulkFile(t, tt.file, tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadBulkFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var ids []string
+			for _, row := range rows {
+				ids = append(ids, row.Ticket.ID)
+				if row.Ticket.Status != StatusPending {
+					t.Errorf("row %d status = %s, want pending", row.Row, row.Ticket.Status)
+				}
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("ReadBulkFile() IDs = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+
+	rows, err := ReadBulkFile(writeBulkFile(t, "b.csv", "id,title,dependencies,labels,priority\nA,a,,,\nB,b,A;C,Backend; api,high\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := rows[1]
+	if b.Row != 3 || !reflect.DeepEqual(b.Ticket.Dependencies, []string{"A", "C"}) || !reflect.DeepEqual(b.Ticket.Labels, []string{"backend", "api"}) {
+		t.Errorf("csv row = %d %+v", b.Row, b.Ticket)
+	}
+	if len(b.Errors) != 1 || !strings.Contains(b.Errors[0], "priority") {
+		t.Errorf("csv row errors = %v, want a priority error", b.Errors)
+	}
+}
+
+func TestValidateBulk(t *testing.T) {
+	store, tempDir := setupTestStoreForStore(t)
+	defer cleanupTestStoreForStore(t, tempDir)
+	if err := store.Save(NewTicket("OLD", "stored", "")); err != nil {
+		t.Fatal(err)
+	}
+
+	row := func(id string, deps ...string) *BulkRow {
+		tk := NewTicket(id, "title "+id, "")
+		tk.Dependencies = deps
+		return &BulkRow{Ticket: tk}
+	}
+	untitled := row("U")
+	untitled.Ticket.Title = ""
+	badType := row("T")
+	badType.Ticket.Type = "chore"
+
+	tests := []struct {
+		name string
+		rows []*BulkRow
+		want map[int]string // row -> part of its first error; other rows have none
+	}{
+		{"valid, depending on stored and new tickets", []*BulkRow{row("A", "OLD"), row("B", "A")}, nil},
+		{"unknown dependency", []*BulkRow{row("A", "NOPE")}, map[int]string{1: "unknown dependency: NOPE"}},
+		{"id already stored", []*BulkRow{row("OLD")}, map[int]string{1: "already exists"}},
+		{"duplicate id", []*BulkRow{row("A"), row("A")}, map[int]string{2: "duplicate ID A (also row 1)"}},
+		{"cycle", []*BulkRow{row("A", "B"), row("B", "A"), row("C", "A")}, map[int]string{1: "dependency cycle: A → B → A", 2: "dependency cycle"}},
+		{"invalid fields", []*BulkRow{untitled, badType}, map[int]string{1: "title is required", 2: "unknown type"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, r := range tt.rows {
+				r.Row, r.Errors = i+1, nil
+			}
+			if err := NewDependencyResolver(store).ValidateBulk(tt.rows); err != nil {
+				t.Fatal(err)
+			}
+			for _, r := range tt.rows {
+				want, ok := tt.want[r.Row]
+				if !ok {
+					if len(r.Errors) > 0 {
+						t.Errorf("row %d (%s) errors = %v, want none", r.Row, r.Ticket.ID, r.Errors)
+					}
+					continue
+				}
+				if len(r.Errors) == 0 || !strings.Contains(r.Errors[0], want) {
+					t.Errorf("row %d (%s) errors = %v, want %q", r.Row, r.Ticket.ID, r.Errors, want)
+				}
+			}
+		})
+	}
+}
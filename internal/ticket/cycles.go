@@ -0,0 +1,202 @@
+package ticket
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Edge is a dependency between two tickets: From depends on To.
+type Edge struct {
+	From string
+	To   string
+}
+
+// FindCycles returns the dependency cycles among tickets, at most one per group of
+// mutually dependent tickets. Each cycle lists ticket IDs in dependency order starting
+// at the smallest ID: [A, B, C] means A depends on B, B on C and C on A. Dependencies on
+// tickets outside the slice and soft dependencies are ignored, as in SortByDependency.
+//
+// Groups are found with Tarjan's strongly connected components algorithm; within a
+// group the shortest cycle through its smallest ID is reported, so the result does not
+// depend on map order. Breaking that cycle may leave another in the same group, so
+// callers repairing cycles should call FindCycles again until it returns none.
+func (dr *DependencyResolver) FindCycles(tickets []*Ticket) [][]string {
+	graph := make(map[string][]string, len(tickets))
+	for _, t := range tickets {
+		graph[t.ID] = nil
+	}
+	for _, t := range tickets {
+		for _, dep := range t.Dependencies {
+			if _, ok := graph[dep]; ok {
+				graph[t.ID] = append(graph[t.ID], dep)
+			}
+		}
+	}
+	ids := make([]string, 0, len(graph))
+	for id := range graph {
+		ids = append(ids, id)
+		sort.Strings(graph[id])
+	}
+	sort.Strings(ids)
+
+	var cycles [][]string
+	for _, scc := range stronglyConnected(ids, graph) {
+		if len(scc) == 1 && !slices.Contains(graph[scc[0]], scc[0]) {
+			continue
+		}
+		cycles = append(cycles, shortestCycle(scc, graph))
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// stronglyConnected returns the strongly connected components of graph (Tarjan).
+func stronglyConnected(ids []string, graph map[string][]string) [][]string {
+	index := make(map[string]int, len(ids))
+	low := make(map[string]int, len(ids))
+	onStack := make(map[string]bool)
+	var stack []string
+	var components [][]string
+
+	var visit func(id string)
+	visit = func(id string) {
+		index[id] = len(index)
+		low[id] = index[id]
+		stack = append(stack, id)
+		onStack[id] = true
+		for _, next := range graph[id] {
+			if _, seen := index[next]; !seen {
+				visit(next)
+				low[id] = min(low[id], low[next])
+			} else if onStack[next] {
+				low[id] = min(low[id], index[next])
+			}
+		}
+		if low[id] != index[id] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		sort.Strings(component)
+		components = append(components, component)
+	}
+	for _, id := range ids {
+		if _, seen := index[id]; !seen {
+			visit(id)
+		}
+	}
+	return components
+}
+
+// shortestCycle returns the shortest cycle through the smallest ID of scc, found by a
+// breadth-first search that stays inside scc.
+func shortestCycle(scc []string, graph map[string][]string) []string {
+	start := scc[0]
+	inSCC := make(map[string]bool, len(scc))
+	for _, id := range scc {
+		inSCC[id] = true
+	}
+	parent := map[string]string{start: ""}
+	queue := []string{start}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, next := range graph[id] {
+			if next == start {
+				cycle := []string{id}
+				for p := parent[id]; p != ""; p = parent[p] {
+					cycle = append(cycle, p)
+				}
+				slices.Reverse(cycle)
+				return cycle
+			}
+			if _, seen := parent[next]; !seen && inSCC[next] {
+				parent[next] = id
+				queue = append(queue, next)
+			}
+		}
+	}
+	return []string{start} // unreachable for a strongly connected scc
+}
+
+// WeakestEdge returns the edge of cycle (as returned by FindCycles) that is the best
+// candidate to break. An edge is considered stronger when the dependent ticket touches
+// a file the dependency creates, when both touch the same files, or when the dependent's
+// description mentions the dependency; it is considered weaker when a lower-priority
+// ticket blocks a higher-priority one. Ties go to the edge that closes the cycle.
+func (dr *DependencyResolver) WeakestEdge(tickets []*Ticket, cycle []string) Edge {
+	byID := make(map[string]*Ticket, len(tickets))
+	for _, t := range tickets {
+		byID[t.ID] = t
+	}
+	var weakest Edge
+	best := 0
+	for i := len(cycle) - 1; i >= 0; i-- {
+		e := Edge{From: cycle[i], To: cycle[(i+1)%len(cycle)]}
+		score := edgeStrength(byID[e.From], byID[e.To])
+		if weakest.From == "" || score < best {
+			weakest, best = e, score
+		}
+	}
+	return weakest
+}
+
+func edgeStrength(from, to *Ticket) int {
+	if from == nil || to == nil {
+		return 0
+	}
+	touched := make(map[string]bool)
+	for _, f := range append(slices.Clone(from.FilesToCreate), from.FilesToModify...) {
+		touched[f] = true
+	}
+	score := 0
+	for _, f := range to.FilesToCreate {
+		if touched[f] {
+			score += 2
+		}
+	}
+	for _, f := range to.FilesToModify {
+		if touched[f] {
+			score++
+		}
+	}
+	if strings.Contains(from.Description, to.ID) {
+		score++
+	}
+	if to.Priority > from.Priority {
+		score--
+	}
+	return score
+}
+
+// RemoveDependency removes id from the ticket's dependencies and reports whether it was
+// there.
+func (t *Ticket) RemoveDependency(id string) bool {
+	i := slices.Index(t.Dependencies, id)
+	if i < 0 {
+		return false
+	}
+	t.Dependencies = slices.Delete(t.Dependencies, i, i+1)
+	return true
+}
+
+// SoftenDependency turns the dependency on id into a soft dependency and reports whether
+// the ticket depended on id.
+func (t *Ticket) SoftenDependency(id string) bool {
+	if !t.RemoveDependency(id) {
+		return false
+	}
+	if !slices.Contains(t.SoftDependencies, id) {
+		t.SoftDependencies = append(t.SoftDependencies, id)
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+package ticket
+
+import (
+	"reflect"
+	"testing"
+)
+
+// depTicket returns a ticket with the given dependencies.
+func depTicket(id string, deps ...string) *Ticket {
+	t := NewTicket(id, id, "")
+	t.Dependencies = deps
+	return t
+}
+
+func TestFindCycles(t *testing.T) {
+	tests := []struct {
+		name    string
+		tickets []*Ticket
+		want    [][]string
+	}{
+		{"no cycle", []*Ticket{depTicket("A"), depTicket("B", "A"), depTicket("C", "B")}, nil},
+		{"self loop", []*Ticket{depTicket("A", "A")}, [][]string{{"A"}}},
+		{"three-cycle", []*Ticket{depTicket("C", "A"), depTicket("A", "B"), depTicket("B", "C")}, [][]string{{"A", "B", "C"}}},
+		{
+			"two groups",
+			[]*Ticket{depTicket("A", "B"), depTicket("B", "A"), depTicket("X", "Y"), depTicket("Y", "Z"), depTicket("Z", "X"), depTicket("Q", "A")},
+			[][]string{{"A", "B"}, {"X", "Y", "Z"}},
+		},
+		{
+			"shortest cycle through smallest ID",
+			[]*Ticket{depTicket("A", "D", "B"), depTicket("B", "C"), depTicket("C", "A"), depTicket("D", "A")},
+			[][]string{{"A", "D"}},
+		},
+		{"external dependency ignored", []*Ticket{depTicket("A", "OUTSIDE")}, nil},
+	}
+	dr := NewDependencyResolver(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := dr.FindCycles(tt.tickets)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindCycles() = %v, want %v", got, tt.want)
+			}
+			if has := dr.HasCircularDependency(tt.tickets); has != (len(tt.want) > 0) {
+				t.Errorf("HasCircularDependency() = %v, but FindCycles found %v", has, got)
+			}
+		})
+	}
+}
+
+func TestWeakestEdge(t *testing.T) {
+	dr := NewDependencyResolver(nil)
+
+	// B creates the file A modifies, so A → B is strong; C → A is the weakest.
+	a, b, c := depTicket("A", "B"), depTicket("B", "C"), depTicket("C", "A")
+	b.FilesToCreate = []string{"auth.go"}
+	a.FilesToModify = []string{"auth.go"}
+	c.FilesToModify = []string{"docs.md"}
+	b.Description = "Needs C first"
+	if got := dr.WeakestEdge([]*Ticket{a, b, c}, []string{"A", "B", "C"}); got != (Edge{From: "C", To: "A"}) {
+		t.Errorf("WeakestEdge() = %+v, want C → A", got)
+	}
+
+	// A low-priority dependency blocking an urgent ticket is weaker than the closing edge.
+	a, b = depTicket("A", "B"), depTicket("B", "A")
+	a.Priority, b.Priority = 1, 4
+	if got := dr.WeakestEdge([]*Ticket{a, b}, []string{"A", "B"}); got != (Edge{From: "A", To: "B"}) {
+		t.Errorf("WeakestEdge() = %+v, want A → B", got)
+	}
+
+	// Ties go to the edge closing the cycle.
+	a, b = depTicket("A", "B"), depTicket("B", "A")
+	if got := dr.WeakestEdge([]*Ticket{a, b}, []string{"A", "B"}); got != (Edge{From: "B", To: "A"}) {
+		t.Errorf("WeakestEdge() = %+v, want B → A", got)
+	}
+}
+
+func TestSoftenDependency(t *testing.T) {
+	tk := depTicket("A", "B", "C")
+	if !tk.SoftenDependency("B") {
+		t.Fatal("SoftenDependency(B) = false")
+	}
+	if !reflect.DeepEqual(tk.Dependencies, []string{"C"}) || !reflect.DeepEqual(tk.SoftDependencies, []string{"B"}) {
+		t.Errorf("Dependencies = %v, SoftDependencies = %v", tk.Dependencies, tk.SoftDependencies)
+	}
+	if tk.SoftenDependency("B") || tk.RemoveDependency("X") {
+		t.Error("softening or removing a missing dependency should report false")
+	}
+	if !tk.RemoveDependency("C") || len(tk.Dependencies) != 0 {
+		t.Errorf("RemoveDependency(C) left %v", tk.Dependencies)
+	}
+}
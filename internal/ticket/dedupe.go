@@ -0,0 +1,123 @@
+package ticket
+
+import (
+	"strings"
+	"unicode"
+)
+
+// duplicateSimilarity is the share of title words an issue and a ticket about the same
+// file must have in common to be the same finding.
+const duplicateSimilarity = 0.5
+
+// Duplicate is an issue that repeats an existing ticket.
+type Duplicate struct {
+	Issue  *Issue
+	Ticket *Ticket
+}
+
+// Dedupe splits issues into the ones no existing ticket covers yet and the duplicates.
+// An issue duplicates a ticket with the same title (ignoring case and punctuation) or
+// a ticket about the same file whose title shares at least half of its words (see
+// stems), so a finding reworded by a later analysis is still recognized.
+func Dedupe(issues []*Issue, existing []*Ticket) (fresh []*Issue, duplicates []Duplicate) {
+	for _, issue := range issues {
+		if t := findDuplicate(issue, existing); t != nil {
+			duplicates = append(duplicates, Duplicate{Issue: issue, Ticket: t})
+			continue
+		}
+		fresh = append(fresh, issue)
+	}
+	return fresh, duplicates
+}
+
+func findDuplicate(issue *Issue, existing []*Ticket) *Ticket {
+	words := titleWords(issue.Title)
+	file := locationFile(issue.Location)
+	for _, t := range existing {
+		tw := titleWords(t.Title)
+		if len(words) > 0 && strings.Join(words, " ") == strings.Join(tw, " ") {
+			return t
+		}
+		if file == "" || !touchesFile(t, file) {
+			continue
+		}
+		if similarity(stems(words), stems(tw)) >= duplicateSimilarity {
+			return t
+		}
+	}
+	return nil
+}
+
+// titleWords returns the lower-case words of a title.
+func titleWords(title string) []string {
+	return strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// stopWords are left out when comparing titles.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "is": true, "are": true, "not": true, "no": true,
+	"to": true, "of": true, "in": true, "on": true, "for": true, "and": true, "or": true, "with": true,
+}
+
+// stems returns the words of a title that carry meaning, with common English endings
+// removed so that e.g. "validate", "validated" and "validation" compare equal.
+func stems(words []string) []string {
+	out := make([]string, 0, len(words))
+	for _, w := range words {
+		if stopWords[w] {
+			continue
+		}
+		for _, suffix := range []string{"ion", "ing", "ed", "es", "s", "e"} {
+			if len(w)-len(suffix) >= 3 && strings.HasSuffix(w, suffix) {
+				w = strings.TrimSuffix(w, suffix)
+				break
+			}
+		}
+		out = append(out, w)
+	}
+	return out
+}
+
+// locationFile returns the file of an issue location such as "internal/cli/work.go:42".
+func locationFile(location string) string {
+	file, _, _ := strings.Cut(strings.TrimSpace(location), ":")
+	return file
+}
+
+func touchesFile(t *Ticket, file string) bool {
+	for _, files := range [][]string{t.FilesToModify, t.FilesToCreate} {
+		for _, f := range files {
+			if locationFile(f) == file {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// similarity returns the Jaccard index of two word lists.
+func similarity(a, b []string) float64 {
+	set := make(map[string]bool, len(a))
+	for _, w := range a {
+		set[w] = true
+	}
+	common, union := 0, len(set)
+	seen := make(map[string]bool, len(b))
+	for _, w := range b {
+		if seen[w] {
+			continue
+		}
+		seen[w] = true
+		if set[w] {
+			common++
+		} else {
+			union++
+		}
+	}
+	if union == 0 {
+		return 0
+	}
+	return float64(common) / float64(union)
+}
@@ -0,0 +1,46 @@
+package ticket
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDedupe(t *testing.T) {
+	existing := NewTicket("TICKET-001", "Add input validation to the login handler", "")
+	existing.FilesToModify = []string{"internal/auth/login.go:40"}
+	other := NewTicket("TICKET-002", "Document the config file", "")
+
+	tests := []struct {
+		name  string
+		issue *Issue
+		want  *Ticket
+	}{
+		{"same title", &Issue{Title: "document the config file."}, other},
+		{"reworded about the same file", &Issue{Title: "Login handler lacks input validation", Location: "internal/auth/login.go:52"}, existing},
+		{"similar title in another file", &Issue{Title: "Login handler lacks input validation", Location: "internal/auth/logout.go:3"}, nil},
+		{"different finding in the same file", &Issue{Title: "Cache the session lookup", Location: "internal/auth/login.go"}, nil},
+		{"no location", &Issue{Title: "Login handler lacks input validation"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fresh, dups := Dedupe([]*Issue{tt.issue}, []*Ticket{existing, other})
+			if tt.want == nil {
+				if len(fresh) != 1 || len(dups) != 0 {
+					t.Errorf("Dedupe() = %v, %v, want the issue as fresh", fresh, dups)
+				}
+				return
+			}
+			if len(dups) != 1 || dups[0].Ticket != tt.want || dups[0].Issue != tt.issue || len(fresh) != 0 {
+				t.Errorf("Dedupe() = %v, %v, want a duplicate of %s", fresh, dups, tt.want.ID)
+			}
+		})
+	}
+}
+
+func TestStems(t *testing.T) {
+	got := stems(titleWords("Validation of the redirect URL; validated URLs"))
+	want := "validat redirect url validat url"
+	if s := strings.Join(got, " "); s != want {
+		t.Errorf("stems() = %q, want %q", s, want)
+	}
+}
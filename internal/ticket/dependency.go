@@ -2,48 +2,96 @@ package ticket
 
 import (
 	"fmt"
+	"slices"
 )
 
-// ResolverContext holds a cached set of completed ticket IDs for dependency resolution.
-// Create once with NewResolverContext(store), then pass to CanProcessWithContext,
-// GetProcessableWithContext, GetBlockedTicketsWithContext, and GetMissingDependenciesWithContext
-// to avoid repeated LoadByStatus(StatusCompleted) when checking many tickets.
+// ResolverContext holds a cached set of completed ticket IDs and the epic hierarchy for
+// dependency resolution. Create once with NewResolverContext(store), then pass to
+// CanProcessWithContext, GetProcessableWithContext, GetBlockedTicketsWithContext, and
+// GetMissingDependenciesWithContext to avoid reloading the store when checking many tickets.
 type ResolverContext struct {
 	completedIDs map[string]bool
+	epicIDs      map[string]bool
+	children     map[string][]string // parent ID -> child IDs
+	dependents   map[string][]string // dependency ID -> pending tickets depending on it
 }
 
-// NewResolverContext loads all completed tickets from the store and builds a context
-// mapping their IDs to true. Returns an error if LoadByStatus fails.
-func NewResolverContext(store *Store) (*ResolverContext, error) {
-	completed, err := store.LoadByStatus(StatusCompleted)
+// NewResolverContext loads all tickets from the store and builds the completed set and
+// the parent/child index. Returns an error if loading fails.
+func NewResolverContext(store Storer) (*ResolverContext, error) {
+	all, err := store.LoadAll()
 	if err != nil {
 		return nil, err
 	}
 
-	completedIDs := make(map[string]bool)
-	for _, t := range completed {
-		completedIDs[t.ID] = true
+	rc := &ResolverContext{
+		completedIDs: make(map[string]bool),
+		epicIDs:      make(map[string]bool),
+		children:     make(map[string][]string),
+		dependents:   make(map[string][]string),
 	}
-
-	return &ResolverContext{
-		completedIDs: completedIDs,
-	}, nil
+	for _, t := range all.Tickets {
+		if t.Status == StatusCompleted {
+			rc.completedIDs[t.ID] = true
+		}
+		if t.IsEpic() {
+			rc.epicIDs[t.ID] = true
+		}
+		if t.ParentID != "" {
+			rc.children[t.ParentID] = append(rc.children[t.ParentID], t.ID)
+		}
+		if t.Status == StatusPending {
+			for _, dep := range t.Dependencies {
+				rc.dependents[dep] = append(rc.dependents[dep], t.ID)
+			}
+		}
+	}
+	return rc, nil
 }
 
-// IsCompleted reports whether the given ticket ID is in the completed set.
+// IsCompleted reports whether the given ticket ID counts as completed for dependencies.
+// A ticket with children is completed only when all its children are (recursively); an
+// epic's own status is ignored, other parents must also be completed themselves.
 func (rc *ResolverContext) IsCompleted(id string) bool {
-	return rc.completedIDs[id]
+	return rc.isCompleted(id, make(map[string]bool))
+}
+
+func (rc *ResolverContext) isCompleted(id string, visiting map[string]bool) bool {
+	kids := rc.children[id]
+	if len(kids) == 0 {
+		return rc.completedIDs[id]
+	}
+	if visiting[id] {
+		return false // parent cycle: never complete
+	}
+	visiting[id] = true
+	defer delete(visiting, id)
+
+	if !rc.epicIDs[id] && !rc.completedIDs[id] {
+		return false
+	}
+	for _, kid := range kids {
+		if !rc.isCompleted(kid, visiting) {
+			return false
+		}
+	}
+	return true
+}
+
+// Children returns the IDs of the tickets whose parent is id.
+func (rc *ResolverContext) Children(id string) []string {
+	return rc.children[id]
 }
 
 // DependencyResolver answers dependency questions for tickets (can process, processable list,
 // blocked list, missing dependencies, topological sort). It uses the Store to load completed
 // tickets; for batch checks use ResolverContext and the WithContext methods to avoid repeated I/O.
 type DependencyResolver struct {
-	store *Store
+	store Storer
 }
 
-// NewDependencyResolver creates a DependencyResolver that uses the given Store.
-func NewDependencyResolver(store *Store) *DependencyResolver {
+// NewDependencyResolver creates a DependencyResolver that uses the given store.
+func NewDependencyResolver(store Storer) *DependencyResolver {
 	return &DependencyResolver{
 		store: store,
 	}
@@ -99,6 +147,9 @@ func (dr *DependencyResolver) GetProcessableWithContext(ctx *ResolverContext) ([
 
 	processable := make([]*Ticket, 0)
 	for _, t := range pending {
+		if t.IsEpic() {
+			continue // epics are completed by CompleteEpics, not processed
+		}
 		if dr.CanProcessWithContext(t, ctx) {
 			processable = append(processable, t)
 		}
@@ -107,6 +158,65 @@ func (dr *DependencyResolver) GetProcessableWithContext(ctx *ResolverContext) ([
 	return processable, nil
 }
 
+// CompleteEpics marks every pending epic whose children are all completed as completed
+// and returns them. Epics without children are left alone. Call before GetProcessable so
+// tickets depending on an epic are unblocked in the same iteration.
+func (dr *DependencyResolver) CompleteEpics() ([]*Ticket, error) {
+	pending, err := dr.store.LoadByStatus(StatusPending)
+	if err != nil {
+		return nil, err
+	}
+	var completed []*Ticket
+	for {
+		ctx, err := NewResolverContext(dr.store)
+		if err != nil {
+			return completed, err
+		}
+		progressed := false
+		remaining := pending[:0]
+		for _, t := range pending {
+			if !t.IsEpic() || len(ctx.Children(t.ID)) == 0 || !ctx.IsCompleted(t.ID) {
+				remaining = append(remaining, t)
+				continue
+			}
+			t.MarkCompleted("")
+			if err := dr.store.Save(t); err != nil {
+				return completed, err
+			}
+			completed = append(completed, t)
+			progressed = true
+		}
+		pending = remaining
+		// Completing an epic can complete the epic it belongs to.
+		if !progressed {
+			return completed, nil
+		}
+	}
+}
+
+// DeferSoftDependents splits processable tickets into those to start now and those
+// to hold back for a later iteration because a soft dependency is also among tickets.
+// Holding them back lets the soft dependency run first without ever blocking: a soft
+// dependency that is not processable (blocked, failed or already done) is ignored.
+// When soft dependencies form a cycle within tickets, the cycle is started as is.
+func (dr *DependencyResolver) DeferSoftDependents(tickets []*Ticket) (ready, deferred []*Ticket) {
+	inBatch := make(map[string]bool, len(tickets))
+	for _, t := range tickets {
+		inBatch[t.ID] = true
+	}
+	for _, t := range tickets {
+		if slices.ContainsFunc(t.SoftDependencies, func(id string) bool { return id != t.ID && inBatch[id] }) {
+			deferred = append(deferred, t)
+		} else {
+			ready = append(ready, t)
+		}
+	}
+	if len(ready) == 0 {
+		return deferred, nil
+	}
+	return ready, deferred
+}
+
 // GetBlockedTickets returns all pending tickets that are blocked (at least one dependency not completed).
 func (dr *DependencyResolver) GetBlockedTickets() ([]*Ticket, error) {
 	ctx, err := NewResolverContext(dr.store)
@@ -161,8 +271,9 @@ func (dr *DependencyResolver) GetMissingDependenciesWithContext(ticket *Ticket,
 	return missing
 }
 
-// ValidateDependencies checks that every dependency ID referenced by any ticket in tickets
-// is present in the same slice. Returns an error if a ticket references an unknown dependency.
+// ValidateDependencies checks that every dependency and parent ID referenced by any ticket
+// in tickets is present in the same slice. Returns an error if a ticket references an
+// unknown dependency or parent.
 func (dr *DependencyResolver) ValidateDependencies(tickets []*Ticket) error {
 	ticketIDs := make(map[string]bool)
 	for _, t := range tickets {
@@ -175,6 +286,9 @@ func (dr *DependencyResolver) ValidateDependencies(tickets []*Ticket) error {
 				return fmt.Errorf("ticket %s has unknown dependency: %s", t.ID, depID)
 			}
 		}
+		if t.ParentID != "" && !ticketIDs[t.ParentID] {
+			return fmt.Errorf("ticket %s has unknown parent: %s", t.ID, t.ParentID)
+		}
 	}
 
 	return nil
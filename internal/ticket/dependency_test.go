@@ -3,6 +3,7 @@ package ticket
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -315,3 +316,165 @@ func TestContextReuse(t *testing.T) {
 		}
 	}
 }
+
+func TestEpic_CompletesOnlyWhenAllChildrenComplete(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	epic := NewTicket("EPIC-1", "Phase 1", "")
+	epic.Type = TypeEpic
+	c1 := NewTicket("T1", "Child 1", "")
+	c1.ParentID = "EPIC-1"
+	c1.Status = StatusCompleted
+	c2 := NewTicket("T2", "Child 2", "")
+	c2.ParentID = "EPIC-1"
+	next := NewTicket("T3", "After phase 1", "")
+	next.Dependencies = []string{"EPIC-1"}
+	for _, tk := range []*Ticket{epic, c1, c2, next} {
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("failed to save ticket: %v", err)
+		}
+	}
+
+	dr := NewDependencyResolver(store)
+	processable, err := dr.GetProcessable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The epic itself is never processable, and T3 waits on the epic's children.
+	if len(processable) != 1 || processable[0].ID != "T2" {
+		t.Fatalf("GetProcessable() = %v, want only T2", processable)
+	}
+	if done, _ := dr.CompleteEpics(); len(done) != 0 {
+		t.Errorf("CompleteEpics() with an open child = %v, want none", done)
+	}
+
+	c2.Status = StatusCompleted
+	if err := store.Save(c2); err != nil {
+		t.Fatal(err)
+	}
+	done, err := dr.CompleteEpics()
+	if err != nil || len(done) != 1 || done[0].ID != "EPIC-1" {
+		t.Fatalf("CompleteEpics() = %v, %v; want EPIC-1", done, err)
+	}
+	if loaded, _ := store.Load("EPIC-1"); loaded.Status != StatusCompleted {
+		t.Errorf("epic status = %s, want completed", loaded.Status)
+	}
+	processable, _ = dr.GetProcessable()
+	if len(processable) != 1 || processable[0].ID != "T3" {
+		t.Errorf("GetProcessable() after epic = %v, want T3", processable)
+	}
+}
+
+func TestResolverContext_NestedEpicsAndParentCycle(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	outer := NewTicket("E1", "Outer", "")
+	outer.Type = TypeEpic
+	inner := NewTicket("E2", "Inner", "")
+	inner.Type = TypeEpic
+	inner.ParentID = "E1"
+	leaf := NewTicket("T1", "Leaf", "")
+	leaf.ParentID = "E2"
+	leaf.Status = StatusCompleted
+	// A and B name each other as parent.
+	a := NewTicket("A", "A", "")
+	a.Type = TypeEpic
+	a.ParentID = "B"
+	b := NewTicket("B", "B", "")
+	b.Type = TypeEpic
+	b.ParentID = "A"
+	for _, tk := range []*Ticket{outer, inner, leaf, a, b} {
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("failed to save ticket: %v", err)
+		}
+	}
+
+	ctx, err := NewResolverContext(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ctx.IsCompleted("E1") || !ctx.IsCompleted("E2") {
+		t.Error("nested epics should be completed when the leaf is completed")
+	}
+	if ctx.IsCompleted("A") || ctx.IsCompleted("B") {
+		t.Error("epics in a parent cycle must never count as completed")
+	}
+
+	done, err := NewDependencyResolver(store).CompleteEpics()
+	if err != nil || len(done) != 2 {
+		t.Errorf("CompleteEpics() = %v, %v; want E1 and E2", done, err)
+	}
+}
+
+func TestValidateDependencies_UnknownParent(t *testing.T) {
+	dr := NewDependencyResolver(nil)
+	child := NewTicket("T1", "Child", "")
+	child.ParentID = "EPIC-404"
+	if err := dr.ValidateDependencies([]*Ticket{child}); err == nil {
+		t.Error("ValidateDependencies() should report an unknown parent")
+	}
+}
+
+func TestDeferSoftDependents(t *testing.T) {
+	soft := func(id string, deps ...string) *Ticket {
+		tk := NewTicket(id, id, "")
+		tk.SoftDependencies = deps
+		return tk
+	}
+	ids := func(tickets []*Ticket) []string {
+		var out []string
+		for _, tk := range tickets {
+			out = append(out, tk.ID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name         string
+		tickets      []*Ticket
+		wantReady    []string
+		wantDeferred []string
+	}{
+		{
+			name:      "no soft dependencies",
+			tickets:   []*Ticket{soft("A"), soft("B")},
+			wantReady: []string{"A", "B"},
+		},
+		{
+			name:         "soft dependency in the batch runs first",
+			tickets:      []*Ticket{soft("A"), soft("B", "A")},
+			wantReady:    []string{"A"},
+			wantDeferred: []string{"B"},
+		},
+		{
+			name:      "soft dependency outside the batch does not block",
+			tickets:   []*Ticket{soft("B", "FAILED-OR-BLOCKED")},
+			wantReady: []string{"B"},
+		},
+		{
+			name:      "soft cycle starts as is",
+			tickets:   []*Ticket{soft("A", "B"), soft("B", "A")},
+			wantReady: []string{"A", "B"},
+		},
+		{
+			name:      "self reference ignored",
+			tickets:   []*Ticket{soft("A", "A")},
+			wantReady: []string{"A"},
+		},
+	}
+
+	dr := NewDependencyResolver(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ready, deferred := dr.DeferSoftDependents(tt.tickets)
+			if got := ids(ready); !reflect.DeepEqual(got, tt.wantReady) {
+				t.Errorf("ready = %v, want %v", got, tt.wantReady)
+			}
+			if got := ids(deferred); !reflect.DeepEqual(got, tt.wantDeferred) {
+				t.Errorf("deferred = %v, want %v", got, tt.wantDeferred)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package ticket
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEscalationRule_EffectivePriority(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     EscalationRule
+		priority int
+		unblocks int
+		want     int
+	}{
+		{"disabled", EscalationRule{Threshold: 0, Step: 1}, 4, 10, 4},
+		{"below threshold", EscalationRule{Threshold: 3, Step: 1}, 4, 2, 4},
+		{"at threshold", EscalationRule{Threshold: 3, Step: 1}, 4, 3, 3},
+		{"per multiple of threshold", EscalationRule{Threshold: 3, Step: 1}, 4, 7, 2},
+		{"larger step", EscalationRule{Threshold: 2, Step: 2}, 5, 2, 3},
+		{"capped at 1", EscalationRule{Threshold: 1, Step: 1}, 3, 10, 1},
+		{"already 1", EscalationRule{Threshold: 1, Step: 1}, 1, 5, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.EffectivePriority(tt.priority, tt.unblocks); got != tt.want {
+				t.Errorf("EffectivePriority(%d, %d) = %d, want %d", tt.priority, tt.unblocks, got, tt.want)
+			}
+		})
+	}
+}
+
+// saveChain saves tickets as a store where BASE blocks A and B, C waits on A, and
+// OTHER blocks nothing; DONE is completed.
+func saveChain(t *testing.T, store *Store) map[string]*Ticket {
+	t.Helper()
+	tickets := map[string]*Ticket{}
+	add := func(id string, priority int, status Status, deps ...string) {
+		tk := NewTicket(id, id, "")
+		tk.Priority = priority
+		tk.Status = status
+		tk.Dependencies = deps
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("failed to save ticket: %v", err)
+		}
+		tickets[id] = tk
+	}
+	add("DONE", 1, StatusCompleted)
+	add("BASE", 5, StatusPending)
+	add("OTHER", 3, StatusPending)
+	add("A", 2, StatusPending, "BASE", "DONE")
+	add("B", 2, StatusPending, "BASE")
+	add("C", 2, StatusPending, "A")
+	return tickets
+}
+
+func TestResolverContext_Unblocks(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	saveChain(t, store)
+	ctx, err := NewResolverContext(store)
+	if err != nil {
+		t.Fatalf("failed to create resolver context: %v", err)
+	}
+
+	tests := []struct {
+		id         string
+		transitive bool
+		want       int
+	}{
+		{"BASE", false, 2},
+		{"BASE", true, 3},
+		{"A", true, 1},
+		{"OTHER", true, 0},
+		{"DONE", true, 0},
+	}
+	for _, tt := range tests {
+		if got := ctx.Unblocks(tt.id, tt.transitive); got != tt.want {
+			t.Errorf("Unblocks(%s, %v) = %d, want %d", tt.id, tt.transitive, got, tt.want)
+		}
+	}
+}
+
+func TestPrioritize(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	tickets := saveChain(t, store)
+	resolver := NewDependencyResolver(store)
+
+	batch := []*Ticket{tickets["OTHER"], tickets["BASE"]}
+	escalated, err := resolver.Prioritize(batch, EscalationRule{Threshold: 3, Step: 3, Transitive: true})
+	if err != nil {
+		t.Fatalf("Prioritize() error = %v", err)
+	}
+	if got := []string{batch[0].ID, batch[1].ID}; !reflect.DeepEqual(got, []string{"BASE", "OTHER"}) {
+		t.Errorf("order = %v, want [BASE OTHER]", got)
+	}
+	if len(escalated) != 1 || escalated[0].Ticket.ID != "BASE" || escalated[0].Unblocks != 3 || escalated[0].Priority != 2 {
+		t.Errorf("escalated = %+v, want BASE unblocking 3 at P2", escalated)
+	}
+	if tickets["BASE"].Priority != 5 {
+		t.Errorf("stored priority changed to %d", tickets["BASE"].Priority)
+	}
+
+	// Direct dependents only: BASE unblocks 2, below the threshold.
+	batch = []*Ticket{tickets["OTHER"], tickets["BASE"]}
+	escalated, err = resolver.Prioritize(batch, EscalationRule{Threshold: 3, Step: 3})
+	if err != nil {
+		t.Fatalf("Prioritize() error = %v", err)
+	}
+	if len(escalated) != 0 || batch[0].ID != "OTHER" {
+		t.Errorf("escalated = %+v, order starts with %s; want none, OTHER", escalated, batch[0].ID)
+	}
+}
@@ -0,0 +1,90 @@
+package ticket
+
+import (
+	"strings"
+	"time"
+)
+
+// EventKind is what happened to a ticket in its event history.
+type EventKind string
+
+const (
+	EventCreated       EventKind = "created"
+	EventStatus        EventKind = "status"         // status change, From → To
+	EventAgentStarted  EventKind = "agent_started"  // Detail is the agent role, e.g. coding
+	EventAgentFinished EventKind = "agent_finished" // Duration of the call; Detail is its outcome
+	EventCommit        EventKind = "commit"         // Detail is the commit SHA
+)
+
+// Reasons recorded with status changes that are not the outcome of processing.
+const (
+	ReasonRetry       = "retry"       // a failed ticket is tried again
+	ReasonReset       = "reset"       // the worker processing it was stopped
+	ReasonReclaimed   = "reclaimed"   // left in progress by a crashed worker
+	ReasonInterrupted = "interrupted" // processing was cancelled (Ctrl+C or work stop)
+	ReasonResumed     = "resumed"     // run --resume picks it up again
+)
+
+// Event is an entry of a ticket's append-only history (see the history command).
+type Event struct {
+	Kind     EventKind     `json:"kind"`
+	At       time.Time     `json:"at"`
+	From     Status        `json:"from,omitempty"`
+	To       Status        `json:"to,omitempty"`
+	Duration time.Duration `json:"duration_ns,omitempty"`
+	// Detail depends on Kind: why the status changed (e.g. retry), the agent role or
+	// outcome, or the commit SHA.
+	Detail string `json:"detail,omitempty"`
+}
+
+// AddEvent appends e to the ticket's history, stamped with the current time unless
+// e.At is set, and advances UpdatedAt to it. Only the first line of e.Detail is kept,
+// up to maxEventDetail characters.
+func (t *Ticket) AddEvent(e Event) {
+	if e.At.IsZero() {
+		e.At = time.Now()
+	}
+	e.Detail = eventDetail(e.Detail)
+	t.Events = append(t.Events, e)
+	if t.UpdatedAt == nil || e.At.After(*t.UpdatedAt) {
+		at := e.At
+		t.UpdatedAt = &at
+	}
+}
+
+// SetStatus changes the ticket's status, recording the change with reason (e.g.
+// ReasonRetry) in its history. Setting the current status records nothing. Leaving
+// in_progress forgets the Worker.
+func (t *Ticket) SetStatus(status Status, reason string) {
+	if t.Status == status {
+		return
+	}
+	t.AddEvent(Event{Kind: EventStatus, From: t.Status, To: status, Detail: reason})
+	t.Status = status
+	if status != StatusInProgress {
+		t.Worker = nil
+	}
+}
+
+// maxEventDetail caps the detail of an event, e.g. the error of a failure; the full
+// error stays in the ticket's Error.
+const maxEventDetail = 200
+
+// eventDetail returns the first line of s, shortened to maxEventDetail characters.
+func eventDetail(s string) string {
+	s, _, _ = strings.Cut(strings.TrimSpace(s), "\n")
+	if r := []rune(s); len(r) > maxEventDetail {
+		s = string(r[:maxEventDetail]) + "..."
+	}
+	return s
+}
+
+// recordCreated starts the ticket's history with its creation unless it already does
+// or the creation time is unknown. Stores call it on load, so tickets saved before
+// histories existed get one too, while Save still writes exactly the ticket it is given.
+func (t *Ticket) recordCreated() {
+	if t.CreatedAt.IsZero() || len(t.Events) > 0 && t.Events[0].Kind == EventCreated {
+		return
+	}
+	t.Events = append([]Event{{Kind: EventCreated, At: t.CreatedAt}}, t.Events...)
+}
@@ -0,0 +1,72 @@
+package ticket
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTicket_SetStatus(t *testing.T) {
+	tk := NewTicket("T-1", "events", "")
+	tk.MarkInProgress()
+	tk.MarkFailed(errors.New("exit status 1\nstack trace"))
+	tk.SetStatus(StatusPending, ReasonRetry)
+	tk.SetStatus(StatusPending, ReasonRetry) // no change, not recorded
+
+	want := []struct {
+		from, to Status
+		detail   string
+	}{
+		{StatusPending, StatusInProgress, ""},
+		{StatusInProgress, StatusFailed, "exit status 1"},
+		{StatusFailed, StatusPending, ReasonRetry},
+	}
+	if len(tk.Events) != len(want) {
+		t.Fatalf("Events = %+v, want %d status changes", tk.Events, len(want))
+	}
+	for i, w := range want {
+		e := tk.Events[i]
+		if e.Kind != EventStatus || e.From != w.from || e.To != w.to || e.Detail != w.detail || e.At.IsZero() {
+			t.Errorf("Events[%d] = %+v, want %s → %s (%q)", i, e, w.from, w.to, w.detail)
+		}
+	}
+
+	tk.AddEvent(Event{Kind: EventCommit, Detail: strings.Repeat("x", maxEventDetail+10)})
+	if got := tk.Events[len(tk.Events)-1].Detail; len([]rune(got)) != maxEventDetail+3 {
+		t.Errorf("long detail should be shortened to %d characters, got %d", maxEventDetail, len([]rune(got)))
+	}
+}
+
+func TestStorer_Load_RecordsCreated(t *testing.T) {
+	for name, store := range backendsForTest(t) {
+		t.Run(name, func(t *testing.T) {
+			// A ticket saved before histories existed already changed status.
+			tk := NewTicket("T-1", "old", "")
+			tk.MarkInProgress()
+			if err := store.Save(tk); err != nil {
+				t.Fatal(err)
+			}
+			if len(tk.Events) != 1 {
+				t.Fatalf("Save changed the ticket's Events to %+v", tk.Events)
+			}
+			if err := store.MoveToStatus("T-1", StatusCompleted); err != nil {
+				t.Fatal(err)
+			}
+
+			loaded, err := store.Load("T-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			kinds := make([]string, len(loaded.Events))
+			for i, e := range loaded.Events {
+				kinds[i] = string(e.Kind) + ":" + string(e.To)
+			}
+			if got, want := strings.Join(kinds, ","), "created:,status:in_progress,status:completed"; got != want {
+				t.Errorf("Events = %s, want %s", got, want)
+			}
+			if !loaded.Events[0].At.Equal(tk.CreatedAt) {
+				t.Errorf("created event at %v, want the ticket's CreatedAt %v", loaded.Events[0].At, tk.CreatedAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package ticket
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/audit"
+)
+
+// Filter selects tickets by a filter expression shared by the --filter flags of
+// status, work and drop, e.g. "type=feature and priority<=2". Conditions are joined
+// with "and"; each compares a field with a value:
+//
+//	id, status, type, milestone, parent  = or !=
+//	label                                 = (carries the label) or != (does not)
+//	priority                              =, !=, <, <=, >, >=
+//	created, completed                    <, <=, >, >= against an age (7d, 24h) or a date
+//
+// An age reads as "less than 7 days old" for created<7d; a date as "before it" for
+// created<2006-01-02. Tickets that have not finished never match a completed condition.
+type Filter struct {
+	expr  string
+	conds []filterCond
+}
+
+type filterCond func(t *Ticket, now time.Time) bool
+
+var (
+	filterAnd  = regexp.MustCompile(`(?i)\s+and\s+`)
+	filterCmp  = regexp.MustCompile(`^([A-Za-z_]+)\s*(<=|>=|!=|=|<|>)\s*(.*)$`)
+	filterNums = map[string]func(a, b int) bool{
+		"=":  func(a, b int) bool { return a == b },
+		"!=": func(a, b int) bool { return a != b },
+		"<":  func(a, b int) bool { return a < b },
+		"<=": func(a, b int) bool { return a <= b },
+		">":  func(a, b int) bool { return a > b },
+		">=": func(a, b int) bool { return a >= b },
+	}
+)
+
+// ParseFilter parses a filter expression (see Filter). An empty expression yields a
+// nil filter, which matches every ticket.
+func ParseFilter(expr string) (*Filter, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, nil
+	}
+	f := &Filter{expr: expr}
+	for _, part := range filterAnd.Split(expr, -1) {
+		cond, err := parseFilterCond(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter %q: %w", expr, err)
+		}
+		f.conds = append(f.conds, cond)
+	}
+	return f, nil
+}
+
+func parseFilterCond(s string) (filterCond, error) {
+	m := filterCmp.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("%q is not a condition such as type=feature", s)
+	}
+	field, op, value := strings.ToLower(m[1]), m[2], strings.Trim(strings.TrimSpace(m[3]), `"'`)
+	if value == "" {
+		return nil, fmt.Errorf("%q has no value", s)
+	}
+	equality := op == "=" || op == "!="
+	switch field {
+	case "id", "status", "type", "milestone", "parent", "label":
+		if !equality {
+			return nil, fmt.Errorf("%s only supports = and !=", field)
+		}
+		if field == "status" && !Status(value).IsValid() {
+			return nil, fmt.Errorf("unknown status %q", value)
+		}
+		if field == "type" && !Type(value).IsValid() {
+			return nil, fmt.Errorf("unknown type %q", value)
+		}
+		want := op == "="
+		return func(t *Ticket, _ time.Time) bool { return t.filterField(field, value) == want }, nil
+	case "priority":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("priority must be a number, got %q", value)
+		}
+		cmp := filterNums[op]
+		return func(t *Ticket, _ time.Time) bool { return cmp(t.Priority, n) }, nil
+	case "created", "completed":
+		if equality {
+			return nil, fmt.Errorf("%s only supports <, <=, > and >=", field)
+		}
+		return parseTimeCond(field, op, value)
+	default:
+		return nil, fmt.Errorf("unknown field %q (use id, status, type, label, milestone, parent, priority, created or completed)", field)
+	}
+}
+
+// filterField reports whether the string field of t equals value; for label, whether
+// t carries it.
+func (t *Ticket) filterField(field, value string) bool {
+	switch field {
+	case "id":
+		return strings.EqualFold(t.ID, value)
+	case "status":
+		return string(t.Status) == value
+	case "type":
+		return string(t.Type) == value
+	case "milestone":
+		return t.Milestone == value
+	case "parent":
+		return strings.EqualFold(t.ParentID, value)
+	default:
+		return t.HasLabels([]string{value})
+	}
+}
+
+// parseTimeCond compares created or completed with value, an age or a point in time
+// as accepted by audit.ParseTime. Ages compare the other way round: created<7d means
+// created after 7 days ago.
+func parseTimeCond(field, op, value string) (filterCond, error) {
+	if _, err := audit.ParseTime(value, time.Now()); err != nil {
+		return nil, err
+	}
+	if isAge(value) {
+		op = map[string]string{"<": ">", "<=": ">=", ">": "<", ">=": "<="}[op]
+	}
+	return func(t *Ticket, now time.Time) bool {
+		at := t.CreatedAt
+		if field == "completed" {
+			if t.CompletedAt == nil {
+				return false
+			}
+			at = *t.CompletedAt
+		}
+		cutoff, _ := audit.ParseTime(value, now)
+		switch op {
+		case "<":
+			return at.Before(cutoff)
+		case "<=":
+			return !at.After(cutoff)
+		case ">":
+			return at.After(cutoff)
+		default:
+			return !at.Before(cutoff)
+		}
+	}, nil
+}
+
+// isAge reports whether value is a duration such as 7d or 24h rather than a date.
+func isAge(value string) bool {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if _, err := strconv.Atoi(days); err == nil {
+			return true
+		}
+	}
+	_, err := time.ParseDuration(value)
+	return err == nil
+}
+
+// Match reports whether t satisfies every condition of the filter. A nil filter
+// matches every ticket.
+func (f *Filter) Match(t *Ticket) bool {
+	if f == nil {
+		return true
+	}
+	now := time.Now()
+	for _, cond := range f.conds {
+		if !cond(t, now) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the expression the filter was parsed from.
+func (f *Filter) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.expr
+}
+
+// FilterByExpr returns the tickets matching f.
+// Returns tickets unchanged when f is nil.
+func FilterByExpr(tickets []*Ticket, f *Filter) []*Ticket {
+	if f == nil {
+		return tickets
+	}
+	out := make([]*Ticket, 0, len(tickets))
+	for _, t := range tickets {
+		if f.Match(t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,76 @@
+package ticket
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseFilter(t *testing.T) {
+	now := time.Now()
+	lastWeek, lastMonth := now.AddDate(0, 0, -7), now.AddDate(0, -1, 0)
+	newTicket := func(id string, tt Type, priority int, status Status, created time.Time, labels ...string) *Ticket {
+		tk := NewTicket(id, id, "")
+		tk.Type, tk.Priority, tk.Status, tk.CreatedAt, tk.Labels = tt, priority, status, created, labels
+		return tk
+	}
+	done := newTicket("D", TypeDocs, 3, StatusCompleted, lastMonth)
+	done.CompletedAt = &lastWeek
+	tickets := []*Ticket{
+		newTicket("A", TypeFeature, 1, StatusPending, now, "backend"),
+		newTicket("B", TypeFeature, 4, StatusFailed, now.Add(-time.Hour), "frontend"),
+		newTicket("C", TypeBugfix, 2, StatusFailed, lastMonth, "Backend"),
+		done,
+	}
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"", []string{"A", "B", "C", "D"}},
+		{"type=feature and priority<=2", []string{"A"}},
+		{"TYPE = feature AND priority > 1", []string{"B"}},
+		{"label=backend", []string{"A", "C"}},
+		{"label!=backend", []string{"B", "D"}},
+		{"status=failed and created<7d", []string{"B"}},
+		{"created>=7d", []string{"C", "D"}},
+		{"created<" + now.AddDate(0, 0, -1).Format("2006-01-02"), []string{"C", "D"}},
+		{"completed<30d", []string{"D"}},
+		{`id="c"`, []string{"C"}},
+		{"priority!=4 and type!=docs", []string{"A", "C"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			f, err := ParseFilter(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseFilter(%q) error = %v", tt.expr, err)
+			}
+			var got []string
+			for _, tk := range FilterByExpr(tickets, f) {
+				got = append(got, tk.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterByExpr(%q) = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilter_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"type",
+		"colour=red",
+		"status=done",
+		"type=chore",
+		"priority<=high",
+		"label<backend",
+		"created=7d",
+		"created<soon",
+		"type=feature and",
+		"priority=",
+	} {
+		if _, err := ParseFilter(expr); err == nil {
+			t.Errorf("ParseFilter(%q) should fail", expr)
+		}
+	}
+}
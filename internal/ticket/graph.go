@@ -0,0 +1,67 @@
+package ticket
+
+import (
+	"slices"
+	"sort"
+)
+
+// Graph is the dependency graph of a set of tickets, for export (see the graph command).
+type Graph struct {
+	// Nodes are the tickets, sorted by ID.
+	Nodes []*Ticket
+	// Edges are the dependencies between them, sorted: From depends on To.
+	Edges []GraphEdge
+	// Missing are the IDs depended on that are not among the tickets, sorted.
+	Missing []string
+}
+
+// GraphEdge is a dependency edge of a Graph.
+type GraphEdge struct {
+	Edge
+	// Soft is set for a soft dependency, which never blocks From.
+	Soft bool
+}
+
+// Graph returns the dependency graph of tickets. Dependencies on tickets outside the
+// slice become edges to Missing IDs, so a graph of a partial set still shows them.
+func (dr *DependencyResolver) Graph(tickets []*Ticket) *Graph {
+	g := &Graph{Nodes: slices.Clone(tickets)}
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].ID < g.Nodes[j].ID })
+
+	known := make(map[string]bool, len(tickets))
+	for _, t := range tickets {
+		known[t.ID] = true
+	}
+	missing := make(map[string]bool)
+	for _, t := range g.Nodes {
+		for _, deps := range []struct {
+			ids  []string
+			soft bool
+		}{{t.Dependencies, false}, {t.SoftDependencies, true}} {
+			for _, dep := range deps.ids {
+				g.Edges = append(g.Edges, GraphEdge{Edge: Edge{From: t.ID, To: dep}, Soft: deps.soft})
+				if !known[dep] && !missing[dep] {
+					missing[dep] = true
+					g.Missing = append(g.Missing, dep)
+				}
+			}
+		}
+	}
+	sort.SliceStable(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+	sort.Strings(g.Missing)
+	return g
+}
+
+// Dependents returns, for each ID, the tickets that depend on it (hard or soft), sorted.
+func (g *Graph) Dependents() map[string][]string {
+	dependents := make(map[string][]string)
+	for _, e := range g.Edges {
+		dependents[e.To] = append(dependents[e.To], e.From)
+	}
+	return dependents
+}
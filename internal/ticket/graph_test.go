@@ -0,0 +1,41 @@
+package ticket
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDependencyResolver_Graph(t *testing.T) {
+	b := NewTicket("B", "Second", "")
+	b.Dependencies = []string{"C", "A"}
+	b.SoftDependencies = []string{"X"}
+	a := NewTicket("A", "First", "")
+	c := NewTicket("C", "Third", "")
+	c.Dependencies = []string{"A", "Y"}
+
+	g := NewDependencyResolver(nil).Graph([]*Ticket{b, a, c})
+
+	var ids []string
+	for _, n := range g.Nodes {
+		ids = append(ids, n.ID)
+	}
+	if want := []string{"A", "B", "C"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Nodes = %v, want %v", ids, want)
+	}
+	wantEdges := []GraphEdge{
+		{Edge: Edge{From: "B", To: "A"}},
+		{Edge: Edge{From: "B", To: "C"}},
+		{Edge: Edge{From: "B", To: "X"}, Soft: true},
+		{Edge: Edge{From: "C", To: "A"}},
+		{Edge: Edge{From: "C", To: "Y"}},
+	}
+	if !reflect.DeepEqual(g.Edges, wantEdges) {
+		t.Errorf("Edges = %v, want %v", g.Edges, wantEdges)
+	}
+	if want := []string{"X", "Y"}; !reflect.DeepEqual(g.Missing, want) {
+		t.Errorf("Missing = %v, want %v", g.Missing, want)
+	}
+	if got, want := g.Dependents(), map[string][]string{"A": {"B", "C"}, "C": {"B"}, "X": {"B"}, "Y": {"C"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Dependents() = %v, want %v", got, want)
+	}
+}
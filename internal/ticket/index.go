@@ -0,0 +1,204 @@
+package ticket
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// IndexFileName is the file under baseDir holding the ticket index of the file store.
+const IndexFileName = "index.json"
+
+// indexLockName names the lock of the index among the ticket locks in .locks, which
+// are named by ticket ID.
+const indexLockName = ".index"
+
+// statuses lists the status directories of the file store.
+var statuses = []Status{StatusPending, StatusInProgress, StatusCompleted, StatusFailed}
+
+// indexEntry is where the index records a ticket.
+type indexEntry struct {
+	Status   Status `json:"status"`
+	Path     string `json:"path"` // relative to baseDir
+	Priority int    `json:"priority"`
+}
+
+// storeIndex maps ticket IDs to their status, path and priority, so Load, Delete and
+// Count need not scan the status directories. Dirs holds the modification time of
+// each status directory when the index was last in sync: a directory changed since
+// (e.g. a ticket file edited or removed by hand) makes the index stale, and the store
+// falls back to scanning until the index is rebuilt.
+type storeIndex struct {
+	Dirs    map[Status]int64      `json:"dirs"`
+	Tickets map[string]indexEntry `json:"tickets"`
+}
+
+func (s *Store) indexPath() string {
+	return filepath.Join(s.baseDir, IndexFileName)
+}
+
+// dirTimes returns the modification time of each status directory; a missing one is 0.
+func (s *Store) dirTimes() map[Status]int64 {
+	times := make(map[Status]int64, len(statuses))
+	for _, status := range statuses {
+		if info, err := os.Stat(filepath.Join(s.baseDir, string(status))); err == nil {
+			times[status] = info.ModTime().UnixNano()
+		} else {
+			times[status] = 0
+		}
+	}
+	return times
+}
+
+// readIndex reads the index file; nil when it is missing or cannot be parsed.
+func (s *Store) readIndex() *storeIndex {
+	data, err := os.ReadFile(s.indexPath())
+	if err != nil {
+		return nil
+	}
+	var idx storeIndex
+	if err := json.Unmarshal(data, &idx); err != nil || idx.Tickets == nil {
+		return nil
+	}
+	return &idx
+}
+
+// fresh reports whether idx is in sync with the status directories.
+func (idx *storeIndex) fresh(times map[Status]int64) bool {
+	if idx == nil {
+		return false
+	}
+	for _, status := range statuses {
+		if idx.Dirs[status] != times[status] {
+			return false
+		}
+	}
+	return true
+}
+
+// freshIndex returns the index if it is in sync with the status directories, else nil.
+func (s *Store) freshIndex() *storeIndex {
+	idx := s.readIndex()
+	if !idx.fresh(s.dirTimes()) {
+		return nil
+	}
+	return idx
+}
+
+// count returns the number of indexed tickets per status.
+func (idx *storeIndex) count() map[Status]int {
+	counts := make(map[Status]int, len(statuses))
+	for _, status := range statuses {
+		counts[status] = 0
+	}
+	for _, e := range idx.Tickets {
+		counts[e.Status]++
+	}
+	return counts
+}
+
+// writeIndex writes idx, recording times as the directory times it is in sync with.
+func (s *Store) writeIndex(idx *storeIndex, times map[Status]int64) error {
+	idx.Dirs = times
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.indexPath(), data, 0644)
+}
+
+// indexPathOf returns the path the index records for ticket id, or "" if none or the
+// index is out of sync, e.g. after a status change interrupted between writing the new
+// file and removing the old one. The path is only a hint: callers check that the file
+// is still there.
+func (s *Store) indexPathOf(id string) string {
+	idx := s.freshIndex()
+	if idx == nil {
+		return ""
+	}
+	e, ok := idx.Tickets[id]
+	if !ok {
+		return ""
+	}
+	return filepath.Join(s.baseDir, e.Path)
+}
+
+// updateIndex runs change, which writes or removes ticket files, under the index lock
+// and then records entry for id in the index (or drops id when entry is nil). The index
+// is only updated when it was in sync before the change; a stale or missing index is
+// left for LoadAll or Reindex to rebuild, so a ticket file changed by hand is never
+// hidden by the update.
+func (s *Store) updateIndex(id string, entry *indexEntry, change func() error) error {
+	unlock, err := s.lock(indexLockName, "the ticket index", true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	idx := s.readIndex()
+	inSync := idx.fresh(s.dirTimes())
+	if err := change(); err != nil {
+		return err
+	}
+	if !inSync {
+		return nil
+	}
+	if entry == nil {
+		delete(idx.Tickets, id)
+	} else {
+		idx.Tickets[id] = *entry
+	}
+	return s.writeIndex(idx, s.dirTimes())
+}
+
+// rebuildIndex writes an index of tickets, read from the status directories when they
+// had the given times, unless the index on disk is already in sync.
+func (s *Store) rebuildIndex(tickets []*Ticket, times map[Status]int64) error {
+	unlock, err := s.lock(indexLockName, "the ticket index", true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if s.readIndex().fresh(s.dirTimes()) {
+		return nil
+	}
+	return s.writeIndex(newStoreIndex(tickets), times)
+}
+
+func newStoreIndex(tickets []*Ticket) *storeIndex {
+	idx := &storeIndex{Tickets: make(map[string]indexEntry, len(tickets))}
+	for _, t := range tickets {
+		idx.Tickets[t.ID] = indexEntryOf(t)
+	}
+	return idx
+}
+
+func indexEntryOf(t *Ticket) indexEntry {
+	return indexEntry{
+		Status:   t.Status,
+		Path:     filepath.Join(string(t.Status), t.ID+".json"),
+		Priority: t.Priority,
+	}
+}
+
+// Reindex rebuilds the ticket index from the ticket files, e.g. after editing or
+// removing them by hand, and returns the number of tickets indexed. LoadAll also
+// rebuilds an index that is out of sync, so this is rarely needed.
+func (s *Store) Reindex() (int, error) {
+	times := s.dirTimes()
+	tl, err := s.loadAll()
+	if err != nil {
+		return 0, err
+	}
+	unlock, err := s.lock(indexLockName, "the ticket index", true)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+	if err := s.writeIndex(newStoreIndex(tl.Tickets), times); err != nil {
+		return 0, fmt.Errorf("failed to write ticket index: %w", err)
+	}
+	return len(tl.Tickets), nil
+}
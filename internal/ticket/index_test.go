@@ -0,0 +1,100 @@
+package ticket
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore_Index(t *testing.T) {
+	store, tempDir := setupTestStoreForStore(t)
+	defer cleanupTestStoreForStore(t, tempDir)
+
+	for _, tk := range []*Ticket{NewTicket("T-1", "one", ""), NewTicket("T-2", "two", "")} {
+		if err := store.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if store.readIndex() != nil {
+		t.Fatal("Save() should not create an index that was missing")
+	}
+
+	// LoadAll builds the missing index.
+	if _, err := store.LoadAll(); err != nil {
+		t.Fatal(err)
+	}
+	idx := store.freshIndex()
+	if idx == nil || len(idx.Tickets) != 2 {
+		t.Fatalf("index after LoadAll() = %+v, want 2 tickets in sync", idx)
+	}
+
+	// Save and Delete keep it in sync.
+	if err := store.MoveToStatus("T-1", StatusCompleted); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete("T-2"); err != nil {
+		t.Fatal(err)
+	}
+	idx = store.freshIndex()
+	if idx == nil {
+		t.Fatal("index should stay in sync after Save and Delete")
+	}
+	want := indexEntry{Status: StatusCompleted, Path: filepath.Join("completed", "T-1.json"), Priority: 5}
+	if len(idx.Tickets) != 1 || idx.Tickets["T-1"] != want {
+		t.Errorf("index tickets = %+v, want only T-1 = %+v", idx.Tickets, want)
+	}
+
+	// A fresh store finds T-1 through the index.
+	other := NewStore(tempDir)
+	if tk, err := other.Load("T-1"); err != nil || tk.Status != StatusCompleted {
+		t.Errorf("Load() through the index = %+v, %v", tk, err)
+	}
+	if counts, err := other.Count(); err != nil || counts[StatusCompleted] != 1 || counts[StatusPending] != 0 {
+		t.Errorf("Count() from the index = %v, %v", counts, err)
+	}
+}
+
+func TestStore_Index_StaleAfterManualEdit(t *testing.T) {
+	store, tempDir := setupTestStoreForStore(t)
+	defer cleanupTestStoreForStore(t, tempDir)
+
+	if err := store.Save(NewTicket("T-1", "one", "")); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := store.Reindex(); err != nil || n != 1 {
+		t.Fatalf("Reindex() = %d, %v; want 1", n, err)
+	}
+
+	// A ticket file added by hand makes the index stale.
+	dir := filepath.Join(tempDir, string(StatusPending))
+	data, _ := NewTicket("T-2", "by hand", "").ToJSON()
+	if err := os.WriteFile(filepath.Join(dir, "T-2.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(dir, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if store.freshIndex() != nil {
+		t.Fatal("index should be stale after a ticket file is added by hand")
+	}
+	if counts, err := store.Count(); err != nil || counts[StatusPending] != 2 {
+		t.Errorf("Count() with a stale index = %v, %v; want 2 pending from the directory", counts, err)
+	}
+
+	// Saving another ticket does not hide the change behind an updated index.
+	if err := store.Save(NewTicket("T-3", "three", "")); err != nil {
+		t.Fatal(err)
+	}
+	if store.freshIndex() != nil {
+		t.Error("Save() should not bring a stale index back in sync")
+	}
+
+	if n, err := store.Reindex(); err != nil || n != 3 {
+		t.Fatalf("Reindex() = %d, %v; want 3", n, err)
+	}
+	if idx := store.freshIndex(); idx == nil || len(idx.Tickets) != 3 {
+		t.Errorf("index after Reindex() = %+v, want 3 tickets in sync", idx)
+	}
+}
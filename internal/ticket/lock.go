@@ -0,0 +1,74 @@
+package ticket
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/filelock"
+)
+
+// DefaultLockTimeout is how long Store waits for another process to release a ticket
+// before giving up, unless set with SetLocking.
+const DefaultLockTimeout = 10 * time.Second
+
+// lockRetryInterval is how often a held ticket lock is tried again.
+const lockRetryInterval = 20 * time.Millisecond
+
+// locksDir is the directory under baseDir holding the lock file of each ticket.
+const locksDir = ".locks"
+
+// SetLocking enables or disables the advisory file locks Store takes around each
+// ticket's reads and writes, and sets how long to wait for a ticket another process
+// holds (timeout <= 0 keeps the current one). Disable it only when the file system
+// does not support locks; concurrent processes can then corrupt tickets.
+func (s *Store) SetLocking(enabled bool, timeout time.Duration) {
+	s.noLock = !enabled
+	if timeout > 0 {
+		s.lockTimeout = timeout
+	}
+}
+
+// lockTicket takes the lock of ticket id: exclusive for writes and status moves,
+// shared for reads. The lock is advisory (flock on Unix, LockFileEx on Windows) and
+// keyed by ID, not path, so it also covers the move between status directories. It
+// returns the function releasing the lock.
+func (s *Store) lockTicket(id string, exclusive bool) (unlock func(), err error) {
+	return s.lock(id, "ticket "+id, exclusive)
+}
+
+// lock takes the lock file <baseDir>/.locks/<name>.lock; what names the locked
+// resource in errors.
+func (s *Store) lock(name, what string, exclusive bool) (unlock func(), err error) {
+	if s.noLock {
+		return func() {}, nil
+	}
+	dir := filepath.Join(s.baseDir, locksDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+	f, err := os.OpenFile(filepath.Join(dir, name+".lock"), os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+	deadline := time.Now().Add(s.lockTimeout)
+	for {
+		locked, err := filelock.TryLockFile(f, exclusive)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", what, err)
+		}
+		if locked {
+			return func() {
+				_ = filelock.UnlockFile(f)
+				f.Close()
+			}, nil
+		}
+		if time.Now().After(deadline) {
+			f.Close()
+			return nil, fmt.Errorf("%s is locked by another process (waited %s); retry, or use --no-lock if the file system does not support locks", what, s.lockTimeout)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
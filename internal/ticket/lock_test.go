@@ -0,0 +1,62 @@
+package ticket
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStore_lockTicket(t *testing.T) {
+	store := NewStore(t.TempDir())
+	store.SetLocking(true, 100*time.Millisecond)
+	if err := store.Init(); err != nil {
+		t.Fatal(err)
+	}
+	tk := NewTicket("T-1", "locked", "")
+	if err := store.Save(tk); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another process holding T-1 exclusively: writes and reads time out.
+	unlock, err := store.lockTicket("T-1", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := NewStore(store.baseDir)
+	other.SetLocking(true, 100*time.Millisecond)
+	if err := other.Save(tk); err == nil || !strings.Contains(err.Error(), "locked by another process") {
+		t.Errorf("Save() of a locked ticket error = %v", err)
+	}
+	if _, err := other.Load("T-1"); err == nil {
+		t.Error("Load() of an exclusively locked ticket should time out")
+	}
+	if tickets, err := other.LoadByStatus(StatusPending); err != nil || len(tickets) != 0 {
+		t.Errorf("LoadByStatus() = %v, %v; want the locked ticket skipped", tickets, err)
+	}
+
+	// --no-lock ignores the lock.
+	other.SetLocking(false, 0)
+	if _, err := other.Load("T-1"); err != nil {
+		t.Errorf("Load() without locking error = %v", err)
+	}
+	unlock()
+
+	// Readers share the lock; a status move still gets it once they are done.
+	other.SetLocking(true, 0)
+	unlockRead, err := store.lockTicket("T-1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.Load("T-1"); err != nil {
+		t.Errorf("Load() under a shared lock error = %v", err)
+	}
+	unlockRead()
+	if err := other.MoveToStatus("T-1", StatusInProgress); err != nil {
+		t.Fatalf("MoveToStatus() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(store.baseDir, string(StatusInProgress), "T-1.json")); err != nil {
+		t.Errorf("ticket not moved: %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package ticket
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newMigrateStore(t *testing.T, dir string, tickets ...*Ticket) *Store {
+	t.Helper()
+	s := NewStore(dir)
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	for _, tk := range tickets {
+		if err := s.Save(tk); err != nil {
+			t.Fatalf("Save(%s) error = %v", tk.ID, err)
+		}
+	}
+	return s
+}
+
+func TestMigrate_CopiesAndVerifies(t *testing.T) {
+	root := t.TempDir()
+	done := NewTicket("T-2", "done", "d")
+	done.MarkCompleted("ok")
+	src := newMigrateStore(t, filepath.Join(root, "src"),
+		NewTicket("T-1", "pending", "p"),
+		done,
+	)
+	dst := NewStore(filepath.Join(root, "dst"))
+
+	report, err := Migrate(src, dst)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if report.Tickets != 2 || report.RolledBack {
+		t.Errorf("report = %+v, want 2 tickets, not rolled back", report)
+	}
+	if len(report.Checksum) != 64 {
+		t.Errorf("Checksum = %q, want sha256 hex", report.Checksum)
+	}
+
+	got, err := dst.Load("T-2")
+	if err != nil {
+		t.Fatalf("dst.Load() error = %v", err)
+	}
+	if got.Status != StatusCompleted || got.AgentOutput != "ok" {
+		t.Errorf("migrated ticket = %+v, want completed with output", got)
+	}
+
+	// Source is untouched.
+	if _, err := src.Load("T-1"); err != nil {
+		t.Errorf("source ticket should remain: %v", err)
+	}
+}
+
+func TestMigrate_LegacyTicketBetweenBackends(t *testing.T) {
+	root := t.TempDir()
+	src := newMigrateStore(t, filepath.Join(root, "src"))
+	// Written before tickets had an event history.
+	legacy := `{"id":"T-1","title":"legacy","type":"feature","priority":5,"status":"pending","created_at":"2024-06-01T12:00:00Z"}`
+	if err := os.WriteFile(filepath.Join(root, "src", "pending", "T-1.json"), []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sqlite := NewSQLiteStore(filepath.Join(root, "db", SQLiteFileName))
+	t.Cleanup(func() { sqlite.Close() })
+	files := NewStore(filepath.Join(root, "dst"))
+
+	for _, step := range []struct {
+		name     string
+		src, dst Storer
+	}{
+		{"file to sqlite", src, sqlite},
+		{"sqlite to file", sqlite, files},
+	} {
+		report, err := Migrate(step.src, step.dst)
+		if err != nil {
+			t.Fatalf("%s: Migrate() error = %v", step.name, err)
+		}
+		if report.Tickets != 1 || report.RolledBack {
+			t.Errorf("%s: report = %+v, want 1 ticket, not rolled back", step.name, report)
+		}
+	}
+	got, err := files.Load("T-1")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got.Title != "legacy" || len(got.Events) != 1 || got.Events[0].Kind != EventCreated {
+		t.Errorf("migrated ticket = %+v, want the legacy ticket with its created event", got)
+	}
+}
+
+func TestMigrate_RefusesConflictingDestination(t *testing.T) {
+	root := t.TempDir()
+	src := newMigrateStore(t, filepath.Join(root, "src"), NewTicket("T-1", "a", ""))
+	dst := newMigrateStore(t, filepath.Join(root, "dst"), NewTicket("T-1", "other", ""))
+
+	if _, err := Migrate(src, dst); err == nil {
+		t.Fatal("Migrate() should refuse when destination already has the ticket")
+	}
+	got, _ := dst.Load("T-1")
+	if got == nil || got.Title != "other" {
+		t.Error("existing destination ticket must not be overwritten")
+	}
+}
+
+func TestMigrate_RollsBackOnWriteFailure(t *testing.T) {
+	if os.Getuid() == 0 {
+		t.Skip("permission-based failure injection does not apply to root")
+	}
+	root := t.TempDir()
+	src := newMigrateStore(t, filepath.Join(root, "src"),
+		NewTicket("T-1", "pending", ""),
+		&Ticket{ID: "T-2", Title: "failed", Status: StatusFailed},
+	)
+	dstDir := filepath.Join(root, "dst")
+	dst := newMigrateStore(t, dstDir)
+	// Make the failed directory read-only so the second write fails.
+	if err := os.Chmod(filepath.Join(dstDir, string(StatusFailed)), 0500); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chmod(filepath.Join(dstDir, string(StatusFailed)), 0700)
+
+	report, err := Migrate(src, dst)
+	if err == nil {
+		t.Fatal("Migrate() should fail when a write fails")
+	}
+	if report == nil || !report.RolledBack {
+		t.Errorf("report = %+v, want RolledBack", report)
+	}
+	if _, err := dst.Load("T-1"); err == nil {
+		t.Error("ticket written before the failure should be rolled back")
+	}
+}
+
+func TestChecksum_Stable(t *testing.T) {
+	tk := NewTicket("T-1", "a", "b")
+	a, err := Checksum(tk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := Checksum(tk)
+	if a != b {
+		t.Error("Checksum should be deterministic")
+	}
+	tk.Title = "changed"
+	c, _ := Checksum(tk)
+	if a == c {
+		t.Error("Checksum should change when the ticket changes")
+	}
+}
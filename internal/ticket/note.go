@@ -0,0 +1,43 @@
+package ticket
+
+import (
+	"strings"
+	"time"
+)
+
+// Note is an operator note added with the note command. Notes steer a pending or
+// running ticket without rewriting its description: they are included in the next
+// coding and review prompts for the ticket.
+type Note struct {
+	Author    string    `json:"author,omitempty"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AddNote appends a note by author; blank text is ignored.
+func (t *Ticket) AddNote(author, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	t.Notes = append(t.Notes, Note{Author: author, Text: text, CreatedAt: time.Now()})
+}
+
+// MergeNotes appends the notes in notes that t does not have yet, e.g. those added to
+// the stored ticket while t was being processed, so saving t does not drop them.
+func (t *Ticket) MergeNotes(notes []Note) {
+	for _, n := range notes {
+		if !t.hasNote(n) {
+			t.Notes = append(t.Notes, n)
+		}
+	}
+}
+
+func (t *Ticket) hasNote(n Note) bool {
+	for _, existing := range t.Notes {
+		if existing.Text == n.Text && existing.CreatedAt.Equal(n.CreatedAt) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package ticket
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTicket_AddNote(t *testing.T) {
+	tk := NewTicket("T-1", "title", "")
+	tk.AddNote("alice", "  use the retry package  ")
+	tk.AddNote("alice", "   ")
+	if len(tk.Notes) != 1 {
+		t.Fatalf("Notes = %v, want one note (blank text ignored)", tk.Notes)
+	}
+	if n := tk.Notes[0]; n.Author != "alice" || n.Text != "use the retry package" || n.CreatedAt.IsZero() {
+		t.Errorf("Notes[0] = %+v", n)
+	}
+}
+
+func TestTicket_MergeNotes(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	first := Note{Text: "first", CreatedAt: at}
+	second := Note{Text: "second", CreatedAt: at.Add(time.Minute)}
+
+	tk := &Ticket{Notes: []Note{first}}
+	tk.MergeNotes([]Note{first, second})
+	tk.MergeNotes(nil)
+	if len(tk.Notes) != 2 || tk.Notes[1].Text != "second" {
+		t.Errorf("Notes = %+v, want [first second]", tk.Notes)
+	}
+}
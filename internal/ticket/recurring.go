@@ -0,0 +1,227 @@
+package ticket
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/schedule"
+)
+
+// RecurringFileName is the default file name of the recurring registry inside the tickets directory.
+const RecurringFileName = "recurring.json"
+
+// RecurrenceRun records one scheduled occurrence of a recurring template.
+type RecurrenceRun struct {
+	// ScheduledAt is the schedule slot this run belongs to.
+	ScheduledAt time.Time `json:"scheduled_at"`
+	// TicketID is the instance created for the slot; empty when the slot was skipped.
+	TicketID string `json:"ticket_id,omitempty"`
+	// SkippedFor is the still-open previous instance that caused this slot to be skipped.
+	SkippedFor string `json:"skipped_for,omitempty"`
+}
+
+// Recurring is the registry of recurring ticket templates and the instances created
+// from them. Templates are ordinary tickets with RecurrenceRule set; they never enter
+// the ticket store themselves, so work never picks them up.
+type Recurring struct {
+	Templates []*Ticket                  `json:"templates"`
+	History   map[string][]RecurrenceRun `json:"history,omitempty"`
+}
+
+// LoadRecurring reads the registry at path. A missing file yields an empty registry.
+func LoadRecurring(path string) (*Recurring, error) {
+	r := &Recurring{History: make(map[string][]RecurrenceRun)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return r, nil
+		}
+		return nil, fmt.Errorf("failed to read recurring tickets: %w", err)
+	}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("failed to parse recurring tickets: %w", err)
+	}
+	if r.History == nil {
+		r.History = make(map[string][]RecurrenceRun)
+	}
+	return r, nil
+}
+
+// Save writes the registry to path atomically (temp file + rename).
+func (r *Recurring) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create recurring directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".recurring-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write recurring tickets: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write recurring tickets: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write recurring tickets: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write recurring tickets: %w", err)
+	}
+	return nil
+}
+
+// Add registers a template after validating it and its schedule.
+func (r *Recurring) Add(t *Ticket) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+	if _, err := schedule.Parse(t.RecurrenceRule); err != nil {
+		return err
+	}
+	if r.Get(t.ID) != nil {
+		return fmt.Errorf("recurring ticket already exists: %s", t.ID)
+	}
+	r.Templates = append(r.Templates, t)
+	return nil
+}
+
+// Get returns the template with the given ID, or nil.
+func (r *Recurring) Get(id string) *Ticket {
+	for _, t := range r.Templates {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+// Remove deletes a template and its history. Instances already created are kept.
+// Reports whether the template existed.
+func (r *Recurring) Remove(id string) bool {
+	for i, t := range r.Templates {
+		if t.ID == id {
+			r.Templates = append(r.Templates[:i], r.Templates[i+1:]...)
+			delete(r.History, id)
+			return true
+		}
+	}
+	return false
+}
+
+// LastRun returns the most recent run of a template, or nil if it has never fired.
+func (r *Recurring) LastRun(id string) *RecurrenceRun {
+	runs := r.History[id]
+	if len(runs) == 0 {
+		return nil
+	}
+	return &runs[len(runs)-1]
+}
+
+// NextRun returns when the template fires next: the first schedule slot after its last
+// run, or after its creation if it has never fired. Zero if the schedule never matches.
+func (r *Recurring) NextRun(t *Ticket) (time.Time, error) {
+	s, err := schedule.Parse(t.RecurrenceRule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	base := t.CreatedAt
+	if last := r.LastRun(t.ID); last != nil {
+		base = last.ScheduledAt
+	}
+	return s.Next(base), nil
+}
+
+// Instantiate creates a pending ticket in store for every template whose next slot is
+// at or before now, and records the run. Missed slots collapse into one run for the
+// latest slot. A slot is skipped (recorded, no ticket) while the previous instance is
+// still pending or in progress, so instances never pile up. Returns the created tickets.
+// The caller saves the registry afterwards.
+func (r *Recurring) Instantiate(store Storer, now time.Time) ([]*Ticket, error) {
+	var created []*Ticket
+	for _, tmpl := range r.Templates {
+		s, err := schedule.Parse(tmpl.RecurrenceRule)
+		if err != nil {
+			return created, fmt.Errorf("recurring ticket %s: %w", tmpl.ID, err)
+		}
+		next, _ := r.NextRun(tmpl)
+		if next.IsZero() || next.After(now) {
+			continue
+		}
+		slot := next
+		for n := s.Next(slot); !n.IsZero() && !n.After(now); n = s.Next(n) {
+			slot = n
+		}
+
+		run := RecurrenceRun{ScheduledAt: slot}
+		if open := r.openInstance(store, tmpl.ID); open != "" {
+			run.SkippedFor = open
+		} else {
+			t := tmpl.instance(slot, now)
+			if err := store.Save(t); err != nil {
+				return created, err
+			}
+			run.TicketID = t.ID
+			created = append(created, t)
+		}
+		r.History[tmpl.ID] = append(r.History[tmpl.ID], run)
+	}
+	return created, nil
+}
+
+// openInstance returns the ID of the template's latest instance if it is still pending
+// or in progress; empty otherwise (including when it has been dropped).
+func (r *Recurring) openInstance(store Storer, id string) string {
+	runs := r.History[id]
+	for i := len(runs) - 1; i >= 0; i-- {
+		if runs[i].TicketID == "" {
+			continue
+		}
+		t, err := store.Load(runs[i].TicketID)
+		if err != nil {
+			return ""
+		}
+		if t.Status == StatusPending || t.Status == StatusInProgress {
+			return t.ID
+		}
+		return ""
+	}
+	return ""
+}
+
+// Instances returns the IDs of tickets created from a template, oldest first.
+func (r *Recurring) Instances(id string) []string {
+	var ids []string
+	for _, run := range r.History[id] {
+		if run.TicketID != "" {
+			ids = append(ids, run.TicketID)
+		}
+	}
+	return ids
+}
+
+// instance builds the fresh pending ticket for one schedule slot.
+func (t *Ticket) instance(slot, now time.Time) *Ticket {
+	inst := NewTicket(t.ID+"-"+slot.Format("20060102-1504"), t.Title, t.Description)
+	inst.Type = t.Type
+	inst.Priority = t.Priority
+	inst.EstimatedComplexity = t.EstimatedComplexity
+	inst.Dependencies = append(inst.Dependencies, t.Dependencies...)
+	inst.AcceptanceCriteria = append(inst.AcceptanceCriteria, t.AcceptanceCriteria...)
+	inst.FilesToCreate = append(inst.FilesToCreate, t.FilesToCreate...)
+	inst.FilesToModify = append(inst.FilesToModify, t.FilesToModify...)
+	inst.Labels = append(inst.Labels, t.Labels...)
+	inst.ParentID = t.ParentID
+	inst.CreatedAt = now
+	inst.RecurringFrom = t.ID
+	return inst
+}
@@ -0,0 +1,117 @@
+package ticket
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newRecurringTemplate(id, rule string, created time.Time) *Ticket {
+	t := NewTicket(id, "Update dependencies", "Bump module versions")
+	t.RecurrenceRule = rule
+	t.Priority = 2
+	t.AcceptanceCriteria = []string{"go test passes"}
+	t.CreatedAt = created
+	return t
+}
+
+func TestRecurring_Instantiate(t *testing.T) {
+	store := NewStore(t.TempDir())
+	if err := store.Init(); err != nil {
+		t.Fatal(err)
+	}
+	// Monday 2025-01-06 09:00 weekly.
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := &Recurring{History: make(map[string][]RecurrenceRun)}
+	if err := r.Add(newRecurringTemplate("RECUR-1", "0 9 * * 1", created)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Not due yet.
+	got, err := r.Instantiate(store, time.Date(2025, 1, 6, 8, 59, 0, 0, time.UTC))
+	if err != nil || len(got) != 0 {
+		t.Fatalf("Instantiate() before first slot = %v, %v; want none", got, err)
+	}
+
+	got, err = r.Instantiate(store, time.Date(2025, 1, 6, 9, 0, 30, 0, time.UTC))
+	if err != nil || len(got) != 1 {
+		t.Fatalf("Instantiate() at first slot = %v, %v; want one ticket", got, err)
+	}
+	inst := got[0]
+	if inst.ID != "RECUR-1-20250106-0900" || inst.RecurringFrom != "RECUR-1" || inst.Status != StatusPending {
+		t.Errorf("instance = %s from %q (%s)", inst.ID, inst.RecurringFrom, inst.Status)
+	}
+	if inst.RecurrenceRule != "" || inst.Priority != 2 || len(inst.AcceptanceCriteria) != 1 {
+		t.Errorf("instance should copy the template without its rule: %+v", inst)
+	}
+	if _, err := store.Load(inst.ID); err != nil {
+		t.Errorf("instance not saved: %v", err)
+	}
+
+	// Same slot again: nothing new.
+	if got, _ := r.Instantiate(store, time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)); len(got) != 0 {
+		t.Errorf("Instantiate() within the same slot created %d tickets", len(got))
+	}
+
+	// Next week while the first instance is still pending: skipped, not piled up.
+	if got, _ := r.Instantiate(store, time.Date(2025, 1, 13, 9, 0, 0, 0, time.UTC)); len(got) != 0 {
+		t.Errorf("Instantiate() with open instance created %d tickets", len(got))
+	}
+	if last := r.LastRun("RECUR-1"); last == nil || last.SkippedFor != inst.ID {
+		t.Errorf("LastRun() = %+v, want skipped for %s", last, inst.ID)
+	}
+
+	// Completed, then several weeks missed: one fresh instance for the latest slot.
+	if err := store.MoveToStatus(inst.ID, StatusCompleted); err != nil {
+		t.Fatal(err)
+	}
+	got, err = r.Instantiate(store, time.Date(2025, 2, 5, 12, 0, 0, 0, time.UTC))
+	if err != nil || len(got) != 1 || got[0].ID != "RECUR-1-20250203-0900" {
+		t.Fatalf("Instantiate() after missed slots = %v, %v; want RECUR-1-20250203-0900", got, err)
+	}
+	if ids := r.Instances("RECUR-1"); len(ids) != 2 || ids[0] != inst.ID {
+		t.Errorf("Instances() = %v", ids)
+	}
+	next, _ := r.NextRun(r.Get("RECUR-1"))
+	if want := time.Date(2025, 2, 10, 9, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Errorf("NextRun() = %v, want %v", next, want)
+	}
+}
+
+func TestRecurring_AddRemoveAndPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), RecurringFileName)
+	r, err := LoadRecurring(path)
+	if err != nil || len(r.Templates) != 0 {
+		t.Fatalf("LoadRecurring() on missing file = %v, %v", r, err)
+	}
+	if err := r.Add(newRecurringTemplate("RECUR-1", "not a cron", time.Now())); err == nil {
+		t.Error("Add() should reject an invalid schedule")
+	}
+	if err := r.Add(newRecurringTemplate("RECUR-1", "@weekly", time.Now())); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Add(newRecurringTemplate("RECUR-1", "@daily", time.Now())); err == nil {
+		t.Error("Add() should reject a duplicate ID")
+	}
+	r.History["RECUR-1"] = []RecurrenceRun{{ScheduledAt: time.Now(), TicketID: "RECUR-1-x"}}
+	if err := r.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadRecurring(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tmpl := loaded.Get("RECUR-1"); tmpl == nil || tmpl.RecurrenceRule != "@weekly" {
+		t.Fatalf("Get() after reload = %+v", tmpl)
+	}
+	if ids := loaded.Instances("RECUR-1"); len(ids) != 1 {
+		t.Errorf("Instances() after reload = %v", ids)
+	}
+	if !loaded.Remove("RECUR-1") || loaded.Remove("RECUR-1") {
+		t.Error("Remove() should succeed once")
+	}
+	if len(loaded.History) != 0 {
+		t.Errorf("Remove() should drop history, got %v", loaded.History)
+	}
+}
@@ -0,0 +1,108 @@
+package ticket
+
+import (
+	"sort"
+	"strings"
+)
+
+// SearchField is a ticket field the search command looks in.
+type SearchField string
+
+// Searched fields, in the order hits are ranked: a title hit comes before a hit in
+// the agent output.
+const (
+	SearchTitle       SearchField = "title"
+	SearchDescription SearchField = "description"
+	SearchCriteria    SearchField = "acceptance_criteria"
+	SearchAgentOutput SearchField = "agent_output"
+)
+
+// SearchHit is a ticket matching a search query, with the first field the query was
+// found in and a one-line excerpt around it.
+type SearchHit struct {
+	Ticket  *Ticket
+	Field   SearchField
+	Snippet string
+}
+
+// snippetContext is how many characters of context a snippet keeps on each side of
+// the match.
+const snippetContext = 40
+
+// Search returns the tickets whose title, description, acceptance criteria or agent
+// output contain every word of query, case-insensitively; the words may be in
+// different fields. Hits are ranked by the field the first word is found in, then
+// keep the order of tickets. An empty query matches nothing.
+func Search(tickets []*Ticket, query string) []SearchHit {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return nil
+	}
+	var hits []SearchHit
+	rank := make(map[string]int)
+	for _, t := range tickets {
+		fields := t.searchFields()
+		all := strings.ToLower(strings.Join(fieldTexts(fields), "\n"))
+		matched := true
+		for _, term := range terms {
+			if !strings.Contains(all, term) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		for i, f := range fields {
+			if idx := strings.Index(strings.ToLower(f.text), terms[0]); idx >= 0 {
+				hits = append(hits, SearchHit{Ticket: t, Field: f.field, Snippet: snippet(f.text, idx, len(terms[0]))})
+				rank[t.ID] = i
+				break
+			}
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		return rank[hits[i].Ticket.ID] < rank[hits[j].Ticket.ID]
+	})
+	return hits
+}
+
+type searchField struct {
+	field SearchField
+	text  string
+}
+
+func (t *Ticket) searchFields() []searchField {
+	return []searchField{
+		{SearchTitle, t.Title},
+		{SearchDescription, t.Description},
+		{SearchCriteria, strings.Join(t.AcceptanceCriteria, "\n")},
+		{SearchAgentOutput, t.AgentOutput},
+	}
+}
+
+func fieldTexts(fields []searchField) []string {
+	texts := make([]string, len(fields))
+	for i, f := range fields {
+		texts[i] = f.text
+	}
+	return texts
+}
+
+// snippet returns the text around the n bytes at idx of s on one line, with "..."
+// where it was cut. strings.ToLower keeps the byte offsets of ASCII text; for other
+// text the excerpt may shift slightly but stays valid UTF-8.
+func snippet(s string, idx, n int) string {
+	runes := []rune(s)
+	start := len([]rune(s[:min(idx, len(s))]))
+	end := min(start+len([]rune(s[min(idx, len(s)):min(idx+n, len(s))])), len(runes))
+	from, to := max(start-snippetContext, 0), min(end+snippetContext, len(runes))
+	out := strings.Join(strings.Fields(string(runes[from:to])), " ")
+	if from > 0 {
+		out = "..." + out
+	}
+	if to < len(runes) {
+		out += "..."
+	}
+	return out
+}
@@ -0,0 +1,56 @@
+package ticket
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	a := NewTicket("A", "Fix cache invalidation", "")
+	b := NewTicket("B", "Refactor store", "The cache is rebuilt on every load.")
+	b.AcceptanceCriteria = []string{"Invalidation happens on save"}
+	c := NewTicket("C", "Add logging", "")
+	c.AgentOutput = "Changed internal/cache/invalidate.go to drop stale entries"
+	tickets := []*Ticket{c, b, a}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"title hits rank first", "cache", []string{"A", "B", "C"}},
+		{"words may be in different fields", "cache invalidation", []string{"A", "B"}},
+		{"case-insensitive", "LOGGING", []string{"C"}},
+		{"every word must match", "cache logging", []string{"C"}},
+		{"no match", "webhook", nil},
+		{"empty query", "  ", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, h := range Search(tickets, tt.query) {
+				got = append(got, h.Ticket.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+
+	hits := Search([]*Ticket{c}, "stale")
+	if len(hits) != 1 || hits[0].Field != SearchAgentOutput || !strings.Contains(hits[0].Snippet, "drop stale entries") {
+		t.Errorf("Search(stale) = %+v, want an agent output hit", hits)
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	long := strings.Repeat("x", 60) + " needle\nin a haystack " + strings.Repeat("y", 60)
+	got := snippet(long, strings.Index(long, "needle"), len("needle"))
+	if !strings.HasPrefix(got, "...") || !strings.HasSuffix(got, "...") || !strings.Contains(got, "needle in a haystack") {
+		t.Errorf("snippet() = %q, want a cut single-line excerpt around needle", got)
+	}
+	if got := snippet("short text", 0, 5); got != "short text" {
+		t.Errorf("snippet() = %q, want the whole short text", got)
+	}
+}
@@ -0,0 +1,329 @@
+package ticket
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" database/sql driver
+)
+
+// SQLiteFileName is the default database file name inside the tickets directory.
+const SQLiteFileName = "tickets.db"
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS tickets (
+	id       TEXT PRIMARY KEY,
+	status   TEXT NOT NULL,
+	priority INTEGER NOT NULL,
+	data     TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS tickets_status_priority ON tickets (status, priority);
+`
+
+// SQLiteStore persists tickets in a single SQLite database. Each row keeps the ticket's
+// JSON encoding plus indexed status and priority columns, so listing and counting by
+// status does not read every ticket, and each write is a single atomic statement.
+// The database uses WAL mode and a busy timeout so parallel workers and concurrent
+// readers (status, logs) do not fail on a locked database.
+//
+// The database is opened lazily on first use. Read methods on a database that does
+// not exist yet behave like an empty store and do not create the file.
+type SQLiteStore struct {
+	path string
+
+	mu sync.Mutex
+	db *sql.DB
+}
+
+// NewSQLiteStore creates a SQLiteStore backed by the database file at path.
+func NewSQLiteStore(path string) *SQLiteStore {
+	return &SQLiteStore{path: path}
+}
+
+// Path returns the database file path.
+func (s *SQLiteStore) Path() string {
+	return s.path
+}
+
+// conn returns the open database. When create is false and the database file does not
+// exist, it returns (nil, nil) so callers can report an empty store.
+func (s *SQLiteStore) conn(create bool) (*sql.DB, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db != nil {
+		return s.db, nil
+	}
+	if _, err := os.Stat(s.path); errors.Is(err, os.ErrNotExist) {
+		if !create {
+			return nil, nil
+		}
+		// Use 0700 for the tickets directory to protect sensitive data
+		if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+			return nil, fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+
+	dsn := "file:" + s.path + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ticket database: %w", err)
+	}
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create ticket schema: %w", err)
+	}
+	s.db = db
+	return db, nil
+}
+
+// Close closes the database. The store reopens it on next use.
+func (s *SQLiteStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db == nil {
+		return nil
+	}
+	err := s.db.Close()
+	s.db = nil
+	return err
+}
+
+// Init creates the database file and schema.
+func (s *SQLiteStore) Init() error {
+	_, err := s.conn(true)
+	return err
+}
+
+// Save inserts or replaces the ticket. Validates the ticket before saving.
+func (s *SQLiteStore) Save(t *Ticket) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+	db, err := s.conn(true)
+	if err != nil {
+		return err
+	}
+	return saveRow(db, t)
+}
+
+// execer is the subset of *sql.DB and *sql.Tx used for writes.
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+func saveRow(db execer, t *Ticket) error {
+	data, err := t.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal ticket: %w", err)
+	}
+	_, err = db.Exec(`INSERT INTO tickets (id, status, priority, data) VALUES (?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET status = excluded.status, priority = excluded.priority, data = excluded.data`,
+		t.ID, string(t.Status), t.Priority, string(data))
+	if err != nil {
+		return fmt.Errorf("failed to write ticket %s: %w", t.ID, err)
+	}
+	return nil
+}
+
+// Load reads a ticket by ID. Returns an error if the ticket is not found.
+func (s *SQLiteStore) Load(id string) (*Ticket, error) {
+	db, err := s.conn(false)
+	if err != nil {
+		return nil, err
+	}
+	if db == nil {
+		return nil, fmt.Errorf("ticket not found: %s", id)
+	}
+	var data string
+	err = db.QueryRow(`SELECT data FROM tickets WHERE id = ?`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("ticket not found: %s", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ticket %s: %w", id, err)
+	}
+	t, err := FromJSON([]byte(data))
+	if err != nil {
+		return nil, err
+	}
+	t.recordCreated()
+	return t, nil
+}
+
+// LoadByStatus returns all tickets with the given status, sorted by priority.
+// Rows that fail to parse are skipped, like unreadable files in Store.
+func (s *SQLiteStore) LoadByStatus(status Status) ([]*Ticket, error) {
+	return s.query(`SELECT data FROM tickets WHERE status = ? ORDER BY priority, id`, string(status))
+}
+
+// LoadAll returns every ticket, grouped by status in the same order as Store.LoadAll.
+func (s *SQLiteStore) LoadAll() (*TicketList, error) {
+	tl := NewTicketList()
+	for _, status := range []Status{StatusPending, StatusInProgress, StatusCompleted, StatusFailed} {
+		tickets, err := s.LoadByStatus(status)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tickets {
+			tl.Add(t)
+		}
+	}
+	return tl, nil
+}
+
+func (s *SQLiteStore) query(q string, args ...any) ([]*Ticket, error) {
+	db, err := s.conn(false)
+	if err != nil {
+		return nil, err
+	}
+	tickets := make([]*Ticket, 0)
+	if db == nil {
+		return tickets, nil
+	}
+	rows, err := db.Query(q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tickets: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to read ticket row: %w", err)
+		}
+		t, err := FromJSON([]byte(data))
+		if err != nil {
+			continue
+		}
+		t.recordCreated()
+		tickets = append(tickets, t)
+	}
+	return tickets, rows.Err()
+}
+
+// Delete removes a ticket by ID. Returns an error if the ticket is not found.
+func (s *SQLiteStore) Delete(id string) error {
+	db, err := s.conn(false)
+	if err != nil {
+		return err
+	}
+	if db == nil {
+		return fmt.Errorf("ticket not found: %s", id)
+	}
+	res, err := db.Exec(`DELETE FROM tickets WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete ticket %s: %w", id, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("ticket not found: %s", id)
+	}
+	return nil
+}
+
+// CountByStatus returns the number of tickets with the given status.
+func (s *SQLiteStore) CountByStatus(status Status) (int, error) {
+	db, err := s.conn(false)
+	if err != nil || db == nil {
+		return 0, err
+	}
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM tickets WHERE status = ?`, string(status)).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to count tickets: %w", err)
+	}
+	return n, nil
+}
+
+// Count returns the number of tickets per status in a single query.
+func (s *SQLiteStore) Count() (map[Status]int, error) {
+	counts := map[Status]int{StatusPending: 0, StatusInProgress: 0, StatusCompleted: 0, StatusFailed: 0}
+	db, err := s.conn(false)
+	if err != nil || db == nil {
+		return counts, err
+	}
+	rows, err := db.Query(`SELECT status, COUNT(*) FROM tickets GROUP BY status`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tickets: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var status string
+		var n int
+		if err := rows.Scan(&status, &n); err != nil {
+			return nil, fmt.Errorf("failed to count tickets: %w", err)
+		}
+		counts[Status(status)] = n
+	}
+	return counts, rows.Err()
+}
+
+// MoveToStatus loads the ticket by ID, sets its status to newStatus, and saves it.
+func (s *SQLiteStore) MoveToStatus(id string, newStatus Status) error {
+	t, err := s.Load(id)
+	if err != nil {
+		return err
+	}
+	t.SetStatus(newStatus, "")
+	return s.Save(t)
+}
+
+// MoveFailed moves all failed tickets to pending, clearing Error/CompletedAt, in one
+// transaction. Returns the number of tickets moved.
+func (s *SQLiteStore) MoveFailed() (int, error) {
+	return s.updateStatus(StatusFailed, func(t *Ticket) {
+		t.SetStatus(StatusPending, ReasonRetry)
+		t.Error = ""
+		t.CompletedAt = nil
+	})
+}
+
+// ResetInProgress moves all in_progress tickets back to pending in one transaction.
+// Returns the number of tickets moved.
+func (s *SQLiteStore) ResetInProgress() (int, error) {
+	return s.updateStatus(StatusInProgress, func(t *Ticket) {
+		t.SetStatus(StatusPending, ReasonReset)
+	})
+}
+
+// updateStatus applies fn to every ticket with the given status and saves them
+// atomically: either all updates are written or none are.
+func (s *SQLiteStore) updateStatus(status Status, fn func(*Ticket)) (int, error) {
+	tickets, err := s.LoadByStatus(status)
+	if err != nil || len(tickets) == 0 {
+		return 0, err
+	}
+	db, err := s.conn(true)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	for _, t := range tickets {
+		fn(t)
+		if err := saveRow(tx, t); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return len(tickets), nil
+}
+
+// Clean closes and removes the database file (including WAL files).
+func (s *SQLiteStore) Clean() error {
+	if err := s.Close(); err != nil {
+		return err
+	}
+	for _, p := range []string{s.path, s.path + "-wal", s.path + "-shm"} {
+		if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
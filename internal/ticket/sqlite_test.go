@@ -0,0 +1,204 @@
+package ticket
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// backendsForTest returns a freshly initialized store for every backend, so the
+// Storer contract is exercised identically on each.
+func backendsForTest(t *testing.T) map[string]Storer {
+	t.Helper()
+	dir := t.TempDir()
+	sqlite := NewSQLiteStore(filepath.Join(dir, "db", SQLiteFileName))
+	t.Cleanup(func() { sqlite.Close() })
+	stores := map[string]Storer{
+		BackendFile:   NewStore(filepath.Join(dir, "files")),
+		BackendSQLite: sqlite,
+	}
+	for name, s := range stores {
+		if err := s.Init(); err != nil {
+			t.Fatalf("%s Init() error = %v", name, err)
+		}
+	}
+	return stores
+}
+
+func TestStorer_Contract(t *testing.T) {
+	for name, s := range backendsForTest(t) {
+		t.Run(name, func(t *testing.T) {
+			low := NewTicket("T-1", "low priority", "")
+			low.Priority = 5
+			high := NewTicket("T-2", "high priority", "")
+			high.Priority = 1
+			failed := NewTicket("T-3", "failed", "")
+			failed.MarkFailed(fmt.Errorf("boom"))
+			running := NewTicket("T-4", "running", "")
+			running.MarkInProgress()
+			for _, tk := range []*Ticket{low, high, failed, running} {
+				if err := s.Save(tk); err != nil {
+					t.Fatalf("Save(%s) error = %v", tk.ID, err)
+				}
+			}
+
+			pending, err := s.LoadByStatus(StatusPending)
+			if err != nil || len(pending) != 2 || pending[0].ID != "T-2" {
+				t.Fatalf("LoadByStatus(pending) = %v, %v; want [T-2 T-1]", pending, err)
+			}
+
+			got, err := s.Load("T-3")
+			if err != nil || got.Error != "boom" {
+				t.Fatalf("Load(T-3) = %+v, %v", got, err)
+			}
+			if _, err := s.Load("missing"); err == nil {
+				t.Error("Load(missing) should fail")
+			}
+
+			if err := s.MoveToStatus("T-1", StatusCompleted); err != nil {
+				t.Fatalf("MoveToStatus() error = %v", err)
+			}
+			counts, err := s.Count()
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := map[Status]int{StatusPending: 1, StatusInProgress: 1, StatusCompleted: 1, StatusFailed: 1}
+			for status, n := range want {
+				if counts[status] != n {
+					t.Errorf("Count()[%s] = %d, want %d", status, counts[status], n)
+				}
+			}
+
+			if n, err := s.MoveFailed(); err != nil || n != 1 {
+				t.Errorf("MoveFailed() = %d, %v; want 1", n, err)
+			}
+			if got, _ := s.Load("T-3"); got == nil || got.Status != StatusPending || got.Error != "" {
+				t.Errorf("after MoveFailed T-3 = %+v", got)
+			}
+			if n, err := s.ResetInProgress(); err != nil || n != 1 {
+				t.Errorf("ResetInProgress() = %d, %v; want 1", n, err)
+			}
+			if n, _ := s.CountByStatus(StatusPending); n != 3 {
+				t.Errorf("CountByStatus(pending) = %d, want 3", n)
+			}
+
+			if err := s.Delete("T-2"); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if err := s.Delete("T-2"); err == nil {
+				t.Error("Delete() of a deleted ticket should fail")
+			}
+			all, err := s.LoadAll()
+			if err != nil || all.Count() != 3 {
+				t.Errorf("LoadAll() = %d tickets, %v; want 3", all.Count(), err)
+			}
+
+			if err := s.Clean(); err != nil {
+				t.Fatalf("Clean() error = %v", err)
+			}
+			if all, err := s.LoadAll(); err != nil || all.Count() != 0 {
+				t.Errorf("LoadAll() after Clean = %v, %v; want empty", all, err)
+			}
+		})
+	}
+}
+
+func TestSQLiteStore_ReadsDoNotCreateDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), SQLiteFileName)
+	s := NewSQLiteStore(path)
+
+	if tickets, err := s.LoadByStatus(StatusPending); err != nil || len(tickets) != 0 {
+		t.Errorf("LoadByStatus() on missing db = %v, %v; want empty", tickets, err)
+	}
+	if counts, err := s.Count(); err != nil || counts[StatusPending] != 0 {
+		t.Errorf("Count() on missing db = %v, %v", counts, err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("read-only calls should not create the database file")
+	}
+}
+
+func TestSQLiteStore_ConcurrentSaves(t *testing.T) {
+	path := filepath.Join(t.TempDir(), SQLiteFileName)
+	s := NewSQLiteStore(path)
+	defer s.Close()
+	if err := s.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- s.Save(NewTicket(fmt.Sprintf("T-%03d", i), "concurrent", ""))
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent Save() error = %v", err)
+		}
+	}
+
+	// A second store on the same file (e.g. status running next to work) sees every ticket.
+	other := NewSQLiteStore(path)
+	defer other.Close()
+	if got, err := other.CountByStatus(StatusPending); err != nil || got != n {
+		t.Errorf("CountByStatus() from second connection = %d, %v; want %d", got, err, n)
+	}
+}
+
+func TestMigrate_FileToSQLite(t *testing.T) {
+	dir := t.TempDir()
+	src := NewStore(filepath.Join(dir, ".tickets"))
+	if err := src.Init(); err != nil {
+		t.Fatal(err)
+	}
+	for _, tk := range []*Ticket{NewTicket("T-1", "a", ""), NewTicket("T-2", "b", "")} {
+		if err := src.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dst := NewSQLiteStore(filepath.Join(dir, ".tickets", SQLiteFileName))
+	defer dst.Close()
+
+	report, err := Migrate(src, dst)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if report.Tickets != 2 {
+		t.Errorf("report.Tickets = %d, want 2", report.Tickets)
+	}
+	if n, _ := dst.CountByStatus(StatusPending); n != 2 {
+		t.Errorf("sqlite pending count = %d, want 2", n)
+	}
+}
+
+func TestOpenStore(t *testing.T) {
+	tests := []struct {
+		backend string
+		want    string
+		wantErr bool
+	}{
+		{"", "*ticket.Store", false},
+		{BackendFile, "*ticket.Store", false},
+		{BackendSQLite, "*ticket.SQLiteStore", false},
+		{"s3", "", true},
+	}
+	for _, tt := range tests {
+		s, err := OpenStore(tt.backend, t.TempDir())
+		if (err != nil) != tt.wantErr {
+			t.Errorf("OpenStore(%q) error = %v, wantErr %v", tt.backend, err, tt.wantErr)
+			continue
+		}
+		if err == nil && fmt.Sprintf("%T", s) != tt.want {
+			t.Errorf("OpenStore(%q) = %T, want %s", tt.backend, s, tt.want)
+		}
+	}
+}
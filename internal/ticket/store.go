@@ -1,48 +1,73 @@
-// Package ticket provides ticket data structures and file-based persistence (Store).
+// Package ticket provides ticket data structures and persistence: the Storer interface
+// with file-based (Store) and SQLite (SQLiteStore) backends.
 //
 // Concurrency (TICKET-017, TICKET-018)
 // ------------------------------------
-// We use a fallback strategy: we do not add version/ETag to Ticket nor implement
-// read-compare-write or file locks in Store. Instead, callers that modify the store
-// (e.g. CLI commands that call Save, Delete, MoveToStatus, MoveFailed, SaveGeneratedTickets)
-// must ensure no other process is writing at the same time. In practice, when background
-// work (detached work) is running, its PID file exists and the process is alive; CLI
-// write commands should check for this and refuse to run with a clear message.
-// Read-only operations (Load, LoadByStatus, Count, etc.) may run concurrently with
-// background work. See docs/ticket-store-concurrency.md for the full design and
-// scope evaluation.
+// Store takes an advisory file lock per ticket around each read and write (see
+// lockTicket), so two processes never interleave writes to the same ticket or read one
+// half written. We do not add version/ETag to Ticket nor implement read-compare-write,
+// so callers that modify the store (e.g. CLI commands that call Save, Delete,
+// MoveToStatus, MoveFailed, SaveGeneratedTickets) must still ensure no other process is
+// changing the same tickets. In practice, when background work (detached work) is
+// running, its PID file exists and the process is alive; CLI write commands should
+// check for this and refuse to run with a clear message. Read-only operations (Load,
+// LoadByStatus, Count, etc.) may run concurrently with background work. See
+// docs/ticket-store-concurrency.md for the full design and scope evaluation.
 package ticket
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 )
 
 // Store handles ticket persistence. Tickets are stored as JSON files under baseDir,
-// organized by status (pending, in_progress, completed, failed). A path cache
-// speeds up Load/Save/Delete by avoiding directory scans.
+// organized by status (pending, in_progress, completed, failed). A path cache and an
+// index file (see storeIndex) speed up Load/Save/Delete and Count by avoiding
+// directory scans.
 //
-// Concurrency contract: Save/Delete/MoveToStatus/MoveFailed do not perform any
-// locking or version check. Callers that write to the store must ensure no
-// concurrent writers (e.g. by checking the work PID file before proceeding).
+// Concurrency contract: each ticket's reads and writes (including status moves) hold
+// an advisory file lock on the ticket, across processes (see lockTicket), so a write
+// never interleaves with another. There is no version check: callers that load, change
+// and save a ticket must still ensure no concurrent writers (e.g. by checking the work
+// PID file before proceeding), or the last save wins.
 type Store struct {
-	baseDir   string
-	pathCache map[string]string // ticket ID -> file path cache
-	cacheMu   sync.RWMutex      // protects pathCache
+	baseDir       string
+	pathCache     map[string]string // ticket ID -> file path cache
+	cacheMu       sync.RWMutex      // protects pathCache
+	ioParallelism int               // concurrent file reads in LoadByStatus
+	noLock        bool              // skip the ticket file locks (--no-lock)
+	lockTimeout   time.Duration     // how long to wait for a locked ticket
+	corrupt       corruptFiles      // unparseable files skipped by LoadByStatus
 }
 
+// DefaultIOParallelism is the number of ticket files LoadByStatus reads concurrently
+// unless set with SetIOParallelism: serial reads, as parallel ones only pay off with
+// several cores and storage that serves concurrent reads well.
+const DefaultIOParallelism = 1
+
 // NewStore creates a Store with the given base directory (e.g. .tickets).
 func NewStore(baseDir string) *Store {
 	return &Store{
-		baseDir:   baseDir,
-		pathCache: make(map[string]string),
+		baseDir:       baseDir,
+		pathCache:     make(map[string]string),
+		ioParallelism: DefaultIOParallelism,
+		lockTimeout:   DefaultLockTimeout,
 	}
 }
 
+// SetIOParallelism sets how many ticket files LoadByStatus (and so LoadAll) reads
+// concurrently; values below 1 read serially.
+func (s *Store) SetIOParallelism(n int) {
+	s.ioParallelism = max(n, 1)
+}
+
 // Init creates the status subdirectories under baseDir (pending, in_progress, completed, failed).
 // Call before Save or LoadByStatus. Directory permissions are 0700 to protect sensitive data.
 func (s *Store) Init() error {
@@ -69,9 +94,43 @@ func (s *Store) Save(t *Ticket) error {
 	if err := t.Validate(); err != nil {
 		return err
 	}
+	unlock, err := s.lockTicket(t.ID, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return s.save(t)
+}
+
+// save writes t and records it in the index; the caller holds its lock.
+func (s *Store) save(t *Ticket) error {
+	entry := indexEntryOf(t)
+	return s.updateIndex(t.ID, &entry, func() error { return s.writeTicket(t) })
+}
 
+// writeTicket writes the file of t, then removes the one in its previous status
+// directory. A crash in between leaves the ticket in both directories rather than in
+// neither; Load and LoadAll pick the newer copy.
+func (s *Store) writeTicket(t *Ticket) error {
 	newPath := filepath.Join(s.baseDir, string(t.Status), t.ID+".json")
 
+	// Save to new location
+	dir := filepath.Join(s.baseDir, string(t.Status))
+	// Use 0700 for ticket directories to protect sensitive data
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create status directory: %w", err)
+	}
+
+	data, err := t.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal ticket: %w", err)
+	}
+
+	if err := writeFileAtomic(newPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write ticket file: %w", err)
+	}
+	s.corrupt.record(newPath, nil)
+
 	// Check if we have a cached path for this ticket
 	s.cacheMu.RLock()
 	cachedPath, hasCached := s.pathCache[t.ID]
@@ -79,43 +138,22 @@ func (s *Store) Save(t *Ticket) error {
 
 	// Only remove old file if status changed (path is different)
 	if hasCached && cachedPath != newPath {
-		if _, err := os.Stat(cachedPath); err == nil {
-			if err := os.Remove(cachedPath); err != nil {
-				return fmt.Errorf("failed to remove old ticket file: %w", err)
-			}
+		if err := os.Remove(cachedPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove old ticket file: %w", err)
 		}
 	} else if !hasCached {
-		// No cache entry - this might be a new ticket or cache was cleared
-		// Search other directories only if ticket might exist elsewhere
+		// No cache entry - this might be a new ticket or cache was cleared. Remove
+		// every other copy, including one left behind by an interrupted move.
 		for _, status := range []Status{StatusPending, StatusInProgress, StatusCompleted, StatusFailed} {
 			if status != t.Status {
 				oldPath := filepath.Join(s.baseDir, string(status), t.ID+".json")
-				if _, err := os.Stat(oldPath); err == nil {
-					if err := os.Remove(oldPath); err != nil {
-						return fmt.Errorf("failed to remove old ticket file: %w", err)
-					}
-					break // Found and removed, no need to check other directories
+				if err := os.Remove(oldPath); err != nil && !os.IsNotExist(err) {
+					return fmt.Errorf("failed to remove old ticket file: %w", err)
 				}
 			}
 		}
 	}
 
-	// Save to new location
-	dir := filepath.Join(s.baseDir, string(t.Status))
-	// Use 0700 for ticket directories to protect sensitive data
-	if err := os.MkdirAll(dir, 0700); err != nil {
-		return fmt.Errorf("failed to create status directory: %w", err)
-	}
-
-	data, err := t.ToJSON()
-	if err != nil {
-		return fmt.Errorf("failed to marshal ticket: %w", err)
-	}
-
-	if err := os.WriteFile(newPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write ticket file: %w", err)
-	}
-
 	// Update cache with new path
 	s.cacheMu.Lock()
 	s.pathCache[t.ID] = newPath
@@ -124,9 +162,19 @@ func (s *Store) Save(t *Ticket) error {
 	return nil
 }
 
-// Load reads a ticket by ID. Uses the path cache when available; otherwise searches
-// all status directories. Returns an error if the ticket is not found.
+// Load reads a ticket by ID. Uses the path cache or the index when available; otherwise
+// searches all status directories. Returns an error if the ticket is not found.
 func (s *Store) Load(id string) (*Ticket, error) {
+	unlock, err := s.lockTicket(id, false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return s.load(id)
+}
+
+// load reads ticket id; the caller holds its lock.
+func (s *Store) load(id string) (*Ticket, error) {
 	// First check cache for known path
 	s.cacheMu.RLock()
 	cachedPath, hasCached := s.pathCache[id]
@@ -134,11 +182,7 @@ func (s *Store) Load(id string) (*Ticket, error) {
 
 	if hasCached {
 		if _, err := os.Stat(cachedPath); err == nil {
-			data, err := os.ReadFile(cachedPath)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read ticket file: %w", err)
-			}
-			return FromJSON(data)
+			return readTicketFile(cachedPath)
 		}
 		// Cache entry is stale, remove it and search
 		s.cacheMu.Lock()
@@ -146,30 +190,53 @@ func (s *Store) Load(id string) (*Ticket, error) {
 		s.cacheMu.Unlock()
 	}
 
-	// Search in all status directories
-	for _, status := range []Status{StatusPending, StatusInProgress, StatusCompleted, StatusFailed} {
-		path := filepath.Join(s.baseDir, string(status), id+".json")
+	// Then the path the index records
+	if path := s.indexPathOf(id); path != "" {
 		if _, err := os.Stat(path); err == nil {
-			data, err := os.ReadFile(path)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read ticket file: %w", err)
-			}
-			ticket, err := FromJSON(data)
+			ticket, err := readTicketFile(path)
 			if err != nil {
 				return nil, err
 			}
-			// Update cache
 			s.cacheMu.Lock()
 			s.pathCache[id] = path
 			s.cacheMu.Unlock()
 			return ticket, nil
 		}
 	}
-	return nil, fmt.Errorf("ticket not found: %s", id)
+
+	// Search in all status directories; an interrupted move may have left two copies
+	var found *Ticket
+	var foundPath string
+	for _, status := range []Status{StatusPending, StatusInProgress, StatusCompleted, StatusFailed} {
+		path := filepath.Join(s.baseDir, string(status), id+".json")
+		if _, err := os.Stat(path); err == nil {
+			ticket, err := readTicketFile(path)
+			if err != nil {
+				return nil, err
+			}
+			if found == nil || newer(ticket, found) {
+				found, foundPath = ticket, path
+			}
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("ticket not found: %s", id)
+	}
+	// Update cache
+	s.cacheMu.Lock()
+	s.pathCache[id] = foundPath
+	s.cacheMu.Unlock()
+	return found, nil
+}
+
+// newer reports whether a was updated after b. A copy without UpdatedAt is never newer.
+func newer(a, b *Ticket) bool {
+	return a.UpdatedAt != nil && (b.UpdatedAt == nil || a.UpdatedAt.After(*b.UpdatedAt))
 }
 
 // LoadByStatus loads all tickets in the given status directory, sorted by priority.
-// Returns an empty slice if the directory does not exist.
+// Files are read concurrently (see SetIOParallelism). Returns an empty slice if the
+// directory does not exist.
 func (s *Store) LoadByStatus(status Status) ([]*Ticket, error) {
 	dir := filepath.Join(s.baseDir, string(status))
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
@@ -181,24 +248,14 @@ func (s *Store) LoadByStatus(status Status) ([]*Ticket, error) {
 		return nil, fmt.Errorf("failed to read directory: %w", err)
 	}
 
-	tickets := make([]*Ticket, 0)
+	paths := make([]string, 0, len(entries))
 	for _, entry := range entries {
 		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
 			continue
 		}
-
-		path := filepath.Join(dir, entry.Name())
-		data, err := os.ReadFile(path)
-		if err != nil {
-			continue
-		}
-
-		ticket, err := FromJSON(data)
-		if err != nil {
-			continue
-		}
-		tickets = append(tickets, ticket)
+		paths = append(paths, filepath.Join(dir, entry.Name()))
 	}
+	tickets := s.readTickets(paths)
 
 	// Sort by priority
 	sort.Slice(tickets, func(i, j int) bool {
@@ -208,26 +265,131 @@ func (s *Store) LoadByStatus(status Status) ([]*Ticket, error) {
 	return tickets, nil
 }
 
-// LoadAll loads tickets from all status directories and returns a TicketList.
+// readTickets reads and parses the ticket files at paths with up to ioParallelism
+// concurrent reads, keeping the order of paths. Unreadable files and those moved or
+// locked by another process for longer than the lock timeout are skipped; so are
+// corrupt ones, which are reported by CorruptFiles.
+func (s *Store) readTickets(paths []string) []*Ticket {
+	read := func(path string) *Ticket {
+		unlock, err := s.lockTicket(strings.TrimSuffix(filepath.Base(path), ".json"), false)
+		if err != nil {
+			return nil
+		}
+		defer unlock()
+		t, err := readTicketFile(path)
+		var corrupt *CorruptFileError
+		if errors.As(err, &corrupt) {
+			s.corrupt.record(path, err)
+		} else if err == nil {
+			s.corrupt.record(path, nil)
+		}
+		return t
+	}
+
+	results := make([]*Ticket, len(paths))
+	workers := min(s.ioParallelism, len(paths))
+	if workers <= 1 {
+		for i, path := range paths {
+			results[i] = read(path)
+		}
+	} else {
+		next := make(chan int)
+		var wg sync.WaitGroup
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range next {
+					results[i] = read(paths[i])
+				}
+			}()
+		}
+		for i := range paths {
+			next <- i
+		}
+		close(next)
+		wg.Wait()
+	}
+
+	tickets := make([]*Ticket, 0, len(results))
+	for _, t := range results {
+		if t != nil {
+			tickets = append(tickets, t)
+		}
+	}
+	return tickets
+}
+
+// readTicketFile reads and parses the ticket file at path, starting its history with
+// its creation if it has none. A file that cannot be parsed is reported as a
+// *CorruptFileError.
+func readTicketFile(path string) (*Ticket, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ticket file: %w", err)
+	}
+	t, err := FromJSON(data)
+	if err != nil {
+		return nil, &CorruptFileError{Path: path, Err: err}
+	}
+	t.recordCreated()
+	return t, nil
+}
+
+// LoadAll loads tickets from all status directories and returns a TicketList. An
+// index that is out of sync with the directories is rebuilt from the tickets read.
 func (s *Store) LoadAll() (*TicketList, error) {
-	tl := NewTicketList()
+	times := s.dirTimes()
+	tl, err := s.loadAll()
+	if err != nil {
+		return nil, err
+	}
+	if !s.readIndex().fresh(times) {
+		// The index only saves scans; the tickets were read either way.
+		_ = s.rebuildIndex(tl.Tickets, times)
+	}
+	return tl, nil
+}
 
+func (s *Store) loadAll() (*TicketList, error) {
+	var all []*Ticket
+	latest := make(map[string]*Ticket)
 	for _, status := range []Status{StatusPending, StatusInProgress, StatusCompleted, StatusFailed} {
 		tickets, err := s.LoadByStatus(status)
 		if err != nil {
 			return nil, err
 		}
 		for _, t := range tickets {
-			tl.Add(t)
+			// Keep the newer copy of a ticket an interrupted move left in two directories
+			if prev, ok := latest[t.ID]; !ok || newer(t, prev) {
+				latest[t.ID] = t
+			}
+			all = append(all, t)
 		}
 	}
 
+	tl := NewTicketList()
+	for _, t := range all {
+		if latest[t.ID] == t {
+			tl.Add(t)
+		}
+	}
 	return tl, nil
 }
 
-// Delete removes the ticket file for the given ID. Uses the path cache when available.
-// Returns an error if the ticket is not found.
+// Delete removes the ticket file for the given ID. Uses the path cache or the index
+// when available. Returns an error if the ticket is not found.
 func (s *Store) Delete(id string) error {
+	unlock, err := s.lockTicket(id, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return s.updateIndex(id, nil, func() error { return s.removeTicket(id) })
+}
+
+// removeTicket removes the file of ticket id.
+func (s *Store) removeTicket(id string) error {
 	// First check cache for known path
 	s.cacheMu.RLock()
 	cachedPath, hasCached := s.pathCache[id]
@@ -249,6 +411,12 @@ func (s *Store) Delete(id string) error {
 		s.cacheMu.Unlock()
 	}
 
+	if path := s.indexPathOf(id); path != "" {
+		if _, err := os.Stat(path); err == nil {
+			return os.Remove(path)
+		}
+	}
+
 	for _, status := range []Status{StatusPending, StatusInProgress, StatusCompleted, StatusFailed} {
 		path := filepath.Join(s.baseDir, string(status), id+".json")
 		if _, err := os.Stat(path); err == nil {
@@ -258,9 +426,13 @@ func (s *Store) Delete(id string) error {
 	return fmt.Errorf("ticket not found: %s", id)
 }
 
-// CountByStatus returns the number of tickets with the given status by counting
-// .json files in the status directory. It does not read or parse ticket JSON.
+// CountByStatus returns the number of tickets with the given status from the index, or
+// by counting .json files in the status directory when the index is out of sync. It
+// does not read or parse ticket JSON.
 func (s *Store) CountByStatus(status Status) (int, error) {
+	if idx := s.freshIndex(); idx != nil {
+		return idx.count()[status], nil
+	}
 	dir := filepath.Join(s.baseDir, string(status))
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
 		return 0, nil
@@ -281,8 +453,12 @@ func (s *Store) CountByStatus(status Status) (int, error) {
 	return count, nil
 }
 
-// Count returns the count of tickets per status using ReadDir only (no JSON parsing).
+// Count returns the count of tickets per status from the index, or using ReadDir when
+// the index is out of sync (no JSON parsing either way).
 func (s *Store) Count() (map[Status]int, error) {
+	if idx := s.freshIndex(); idx != nil {
+		return idx.count(), nil
+	}
 	counts := make(map[Status]int)
 
 	for _, status := range []Status{StatusPending, StatusInProgress, StatusCompleted, StatusFailed} {
@@ -299,13 +475,22 @@ func (s *Store) Count() (map[Status]int, error) {
 // MoveToStatus loads the ticket by ID, sets its status to newStatus, and saves it.
 // The file is moved from the old status directory to the new one.
 func (s *Store) MoveToStatus(id string, newStatus Status) error {
-	ticket, err := s.Load(id)
+	unlock, err := s.lockTicket(id, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	ticket, err := s.load(id)
 	if err != nil {
 		return err
 	}
 
-	ticket.Status = newStatus
-	return s.Save(ticket)
+	ticket.SetStatus(newStatus, "")
+	if err := ticket.Validate(); err != nil {
+		return err
+	}
+	return s.save(ticket)
 }
 
 // MoveFailed loads all failed tickets, sets their status to pending and clears Error/CompletedAt, then saves.
@@ -318,7 +503,7 @@ func (s *Store) MoveFailed() (int, error) {
 
 	count := 0
 	for _, t := range failed {
-		t.Status = StatusPending
+		t.SetStatus(StatusPending, ReasonRetry)
 		t.Error = ""
 		t.CompletedAt = nil
 		if err := s.Save(t); err != nil {
@@ -330,6 +515,26 @@ func (s *Store) MoveFailed() (int, error) {
 	return count, nil
 }
 
+// ResetInProgress moves all in_progress tickets back to pending, e.g. after the worker
+// that was processing them has been stopped. Returns the number of tickets moved.
+func (s *Store) ResetInProgress() (int, error) {
+	inProgress, err := s.LoadByStatus(StatusInProgress)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, t := range inProgress {
+		t.SetStatus(StatusPending, ReasonReset)
+		if err := s.Save(t); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
+
 // Clean removes the base directory and all ticket files.
 func (s *Store) Clean() error {
 	return os.RemoveAll(s.baseDir)
@@ -350,7 +555,7 @@ func (s *Store) SaveGeneratedTickets(path string, tickets []*Ticket) error {
 		return fmt.Errorf("failed to marshal tickets: %w", err)
 	}
 
-	return os.WriteFile(path, data, 0644)
+	return writeFileAtomic(path, data, 0644)
 }
 
 // LoadGeneratedTickets reads a JSON file at path (e.g. generated-tickets.json) and returns the ticket list.
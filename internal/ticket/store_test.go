@@ -1,11 +1,13 @@
 package ticket
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 )
 
 // setupTestStoreForStore creates a temporary store for testing
@@ -783,3 +785,219 @@ func TestStore_Save_ConcurrentDifferentTickets(t *testing.T) {
 		}
 	}
 }
+
+func TestStore_ResetInProgress(t *testing.T) {
+	store, tempDir := setupTestStoreForStore(t)
+	defer cleanupTestStoreForStore(t, tempDir)
+
+	store.Save(&Ticket{ID: "P1", Title: "In progress 1", Status: StatusInProgress})
+	store.Save(&Ticket{ID: "P2", Title: "In progress 2", Status: StatusInProgress})
+	store.Save(&Ticket{ID: "C1", Title: "Completed", Status: StatusCompleted})
+
+	count, err := store.ResetInProgress()
+	if err != nil {
+		t.Fatalf("ResetInProgress() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("ResetInProgress() count = %d, want 2", count)
+	}
+
+	counts, _ := store.Count()
+	if counts[StatusInProgress] != 0 || counts[StatusPending] != 2 || counts[StatusCompleted] != 1 {
+		t.Errorf("counts after ResetInProgress() = %v", counts)
+	}
+}
+
+func TestStore_LoadByStatus_Parallel(t *testing.T) {
+	store, tempDir := setupTestStoreForStore(t)
+	defer cleanupTestStoreForStore(t, tempDir)
+
+	for i := 0; i < 50; i++ {
+		store.Save(&Ticket{ID: fmt.Sprintf("T-%03d", i), Title: "t", Status: StatusPending, Priority: i%5 + 1})
+	}
+	// An invalid file is skipped, as with serial reads.
+	os.WriteFile(filepath.Join(tempDir, string(StatusPending), "broken.json"), []byte("{"), 0644)
+
+	for _, n := range []int{0, 1, 4, 100} {
+		store.SetIOParallelism(n)
+		tickets, err := store.LoadByStatus(StatusPending)
+		if err != nil {
+			t.Fatalf("parallelism %d: LoadByStatus() error = %v", n, err)
+		}
+		if len(tickets) != 50 {
+			t.Fatalf("parallelism %d: got %d tickets, want 50", n, len(tickets))
+		}
+		for i := 1; i < len(tickets); i++ {
+			if tickets[i-1].Priority > tickets[i].Priority {
+				t.Fatalf("parallelism %d: tickets not sorted by priority", n)
+			}
+		}
+	}
+}
+
+// benchmarkStore creates a store with n pending tickets, a tenth of them depending on
+// a completed ticket.
+func benchmarkStore(b *testing.B, n int) *Store {
+	b.Helper()
+	store := NewStore(b.TempDir())
+	if err := store.Init(); err != nil {
+		b.Fatal(err)
+	}
+	if err := store.Save(&Ticket{ID: "DONE", Title: "done", Status: StatusCompleted, Priority: 1}); err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		t := &Ticket{ID: fmt.Sprintf("T-%05d", i), Title: "Ticket", Description: "Benchmark ticket description", Status: StatusPending, Priority: i%5 + 1}
+		if i%10 == 0 {
+			t.Dependencies = []string{"DONE"}
+		}
+		if err := store.Save(t); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return store
+}
+
+// BenchmarkStore_LoadByStatus compares serial and parallel reads of a large store:
+//
+//	go test ./internal/ticket -run '^$' -bench LoadByStatus
+func BenchmarkStore_LoadByStatus(b *testing.B) {
+	store := benchmarkStore(b, 3000)
+	for _, n := range []int{1, 8} {
+		b.Run(fmt.Sprintf("parallelism=%d", n), func(b *testing.B) {
+			store.SetIOParallelism(n)
+			for b.Loop() {
+				if _, err := store.LoadByStatus(StatusPending); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDependencyResolver_GetProcessable(b *testing.B) {
+	store := benchmarkStore(b, 3000)
+	resolver := NewDependencyResolver(store)
+	for _, n := range []int{1, 8} {
+		b.Run(fmt.Sprintf("parallelism=%d", n), func(b *testing.B) {
+			store.SetIOParallelism(n)
+			for b.Loop() {
+				if _, err := resolver.GetProcessable(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestStore_Save_Atomic(t *testing.T) {
+	store, tempDir := setupTestStoreForStore(t)
+	defer cleanupTestStoreForStore(t, tempDir)
+
+	tk := NewTicket("T-1", "Atomic", "")
+	for i := 0; i < 3; i++ {
+		if err := store.Save(tk); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	entries, err := os.ReadDir(filepath.Join(tempDir, string(StatusPending)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "T-1.json" {
+		names := make([]string, len(entries))
+		for i, e := range entries {
+			names[i] = e.Name()
+		}
+		t.Errorf("pending dir = %v, want only T-1.json (no leftover temp files)", names)
+	}
+}
+
+func TestStore_CorruptFile(t *testing.T) {
+	store, tempDir := setupTestStoreForStore(t)
+	defer cleanupTestStoreForStore(t, tempDir)
+
+	if err := store.Save(NewTicket("T-1", "Good", "")); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(tempDir, string(StatusPending), "T-2.json")
+	if err := os.WriteFile(path, []byte(`{"id": "T-2", "tit`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := store.Load("T-2")
+	var corrupt *CorruptFileError
+	if !errors.As(err, &corrupt) || corrupt.Path != path {
+		t.Fatalf("Load() of a truncated file error = %v, want a *CorruptFileError for %s", err, path)
+	}
+
+	tickets, err := store.LoadByStatus(StatusPending)
+	if err != nil {
+		t.Fatalf("LoadByStatus() error = %v", err)
+	}
+	if len(tickets) != 1 || tickets[0].ID != "T-1" {
+		t.Errorf("LoadByStatus() = %d tickets, want only T-1", len(tickets))
+	}
+	if files := store.CorruptFiles(); len(files) != 1 || files[0].Path != path {
+		t.Errorf("CorruptFiles() = %v, want %s", files, path)
+	}
+
+	if err := store.Save(NewTicket("T-2", "Rewritten", "")); err != nil {
+		t.Fatal(err)
+	}
+	if files := store.CorruptFiles(); len(files) != 0 {
+		t.Errorf("CorruptFiles() after rewriting the ticket = %v, want none", files)
+	}
+}
+
+func TestStore_InterruptedMove(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to Status
+	}{
+		{"newer copy in a later directory", StatusPending, StatusInProgress},
+		{"newer copy in an earlier directory", StatusFailed, StatusPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := NewStore(dir).Init(); err != nil {
+				t.Fatal(err)
+			}
+			// The old copy, then the new one written before the old was removed.
+			tk := NewTicket("T-1", "moved", "")
+			tk.SetStatus(tt.from, "")
+			old, _ := tk.ToJSON()
+			tk.SetStatus(tt.to, "")
+			later := tk.UpdatedAt.Add(time.Second)
+			tk.UpdatedAt = &later
+			moved, _ := tk.ToJSON()
+			for status, data := range map[Status][]byte{tt.from: old, tt.to: moved} {
+				if err := os.WriteFile(filepath.Join(dir, string(status), "T-1.json"), data, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			store := NewStore(dir)
+			got, err := store.Load("T-1")
+			if err != nil || got.Status != tt.to {
+				t.Fatalf("Load() = %v, %v; want the %s copy", got, err, tt.to)
+			}
+			all, err := NewStore(dir).LoadAll()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if all.Count() != 1 || all.Tickets[0].Status != tt.to {
+				t.Errorf("LoadAll() = %d tickets, want only the %s copy", all.Count(), tt.to)
+			}
+
+			// The next save removes the stale copy.
+			if err := NewStore(dir).Save(got); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := os.Stat(filepath.Join(dir, string(tt.from), "T-1.json")); !os.IsNotExist(err) {
+				t.Errorf("stale %s copy still there after Save (err = %v)", tt.from, err)
+			}
+		})
+	}
+}
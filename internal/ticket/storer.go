@@ -0,0 +1,64 @@
+package ticket
+
+import "fmt"
+
+// Storer is the ticket persistence interface implemented by every store backend.
+// The CLI and the dependency resolver only depend on Storer, so backends can be
+// swapped via the store_backend config key. Store (file-per-ticket JSON) is the
+// default; SQLiteStore keeps all tickets in a single SQLite database.
+//
+// All backends follow the same concurrency contract as Store: callers that write
+// must ensure no other process is writing (see docs/ticket-store-concurrency.md).
+type Storer interface {
+	// Init prepares the backend (directories, schema). Call before Save or LoadByStatus.
+	Init() error
+	// Save validates and writes the ticket, replacing any previous version with the same ID.
+	Save(t *Ticket) error
+	// Load reads a ticket by ID. Returns an error if it is not found.
+	Load(id string) (*Ticket, error)
+	// LoadByStatus returns all tickets with the given status, sorted by priority.
+	LoadByStatus(status Status) ([]*Ticket, error)
+	// LoadAll returns every ticket.
+	LoadAll() (*TicketList, error)
+	// Delete removes a ticket by ID. Returns an error if it is not found.
+	Delete(id string) error
+	// CountByStatus returns the number of tickets with the given status.
+	CountByStatus(status Status) (int, error)
+	// Count returns the number of tickets per status.
+	Count() (map[Status]int, error)
+	// MoveToStatus changes the status of a ticket.
+	MoveToStatus(id string, newStatus Status) error
+	// MoveFailed moves failed tickets back to pending and clears their error.
+	MoveFailed() (int, error)
+	// ResetInProgress moves in_progress tickets back to pending.
+	ResetInProgress() (int, error)
+	// Clean removes all stored tickets.
+	Clean() error
+}
+
+// Store backend names accepted by OpenStore and the store_backend config key.
+const (
+	BackendFile   = "file"
+	BackendSQLite = "sqlite"
+)
+
+// Backends lists the available store backends.
+var Backends = []string{BackendFile, BackendSQLite}
+
+// OpenStore returns the store for a backend name. path is the tickets directory for
+// the file backend and the database file for the sqlite backend.
+func OpenStore(backend, path string) (Storer, error) {
+	switch backend {
+	case BackendFile, "":
+		return NewStore(path), nil
+	case BackendSQLite:
+		return NewSQLiteStore(path), nil
+	default:
+		return nil, fmt.Errorf("unknown store backend %q (available: %v)", backend, Backends)
+	}
+}
+
+var (
+	_ Storer = (*Store)(nil)
+	_ Storer = (*SQLiteStore)(nil)
+)
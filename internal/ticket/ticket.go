@@ -9,6 +9,8 @@ package ticket
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -48,6 +50,9 @@ const (
 	TypeBugfix   Type = "bugfix"
 	TypePerf     Type = "performance"
 	TypeSecurity Type = "security"
+	// TypeEpic groups child tickets (see ParentID). Epics are never sent to the coding
+	// agent; they complete when all their children complete.
+	TypeEpic Type = "epic"
 )
 
 // String returns the string representation of the type
@@ -55,6 +60,16 @@ func (t Type) String() string {
 	return string(t)
 }
 
+// IsValid checks if the type is valid
+func (t Type) IsValid() bool {
+	switch t {
+	case TypeFeature, TypeTest, TypeRefactor, TypeDocs, TypeBugfix, TypePerf, TypeSecurity, TypeEpic:
+		return true
+	default:
+		return false
+	}
+}
+
 // Ticket represents a work ticket.
 // No version/ETag field is used; concurrent-write avoidance is the caller's
 // responsibility (e.g. CLI checks work PID file before any write).
@@ -75,6 +90,117 @@ type Ticket struct {
 	AgentOutput         string     `json:"agent_output,omitempty"`
 	Error               string     `json:"error,omitempty"`
 	ErrorLog            string     `json:"error_log,omitempty"` // Path to agent log file when failed
+
+	// UpdatedAt is when the ticket last changed status or recorded another event in its
+	// history. The file store prefers the newer copy when a crash during a status change
+	// leaves the ticket in two status directories.
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+
+	// PromptCompression records that the coding prompt of the latest attempt exceeded the
+	// prompt budget and sections of it were summarized before the agent call. Nil when
+	// no compression occurred.
+	PromptCompression *PromptCompression `json:"prompt_compression,omitempty"`
+
+	// PartialOutput is what the agent produced before its last attempt timed out. The
+	// next attempt's prompt includes it so the agent can continue instead of starting over.
+	// Cleared when the ticket completes.
+	PartialOutput string `json:"partial_output,omitempty"`
+
+	// TimedOutAfter is the timeout in seconds the last coding attempt hit (ticket_timeouts);
+	// the next attempt gets twice as long. Cleared when the ticket completes.
+	TimedOutAfter int `json:"timed_out_after,omitempty"`
+
+	// RecurrenceRule is the cron schedule of a recurring template (see recurring.go).
+	// Templates live in the recurring registry, not in the ticket store.
+	RecurrenceRule string `json:"recurrence_rule,omitempty"`
+
+	// RecurringFrom is the ID of the recurring template this ticket was instantiated from.
+	RecurringFrom string `json:"recurring_from,omitempty"`
+
+	// Labels are free-form tags (e.g. backend, stale) used to filter status, work and drop.
+	// Stored normalized: trimmed, lower-case, unique.
+	Labels []string `json:"labels,omitempty"`
+
+	// ParentID is the epic this ticket belongs to. Empty for top-level tickets.
+	ParentID string `json:"parent_id,omitempty"`
+
+	// Milestone is the milestone file the ticket was planned from, relative to the project root.
+	Milestone string `json:"milestone,omitempty"`
+
+	// Commits are the SHAs of the commits made for this ticket, oldest first.
+	Commits []string `json:"commits,omitempty"`
+
+	// Branch is the git branch the ticket was worked on (git_branch_per_ticket); commit
+	// commits the ticket's changes on it.
+	Branch string `json:"branch,omitempty"`
+
+	// PullRequest is the pull request carrying this ticket's commits, if any.
+	PullRequest *PullRequestRef `json:"pull_request,omitempty"`
+
+	// RemoteRef is the issue in an external tracker this ticket was imported from.
+	// Status changes and commits are synced back to it.
+	RemoteRef *RemoteRef `json:"remote_ref,omitempty"`
+
+	// Assertions are executable acceptance checks run after coding; the ticket only
+	// completes when all of them pass. AssertionResults holds the latest run.
+	Assertions       []Assertion       `json:"assertions,omitempty"`
+	AssertionResults []AssertionResult `json:"assertion_results,omitempty"`
+
+	// Verification is the latest verdict of the verify agent on AcceptanceCriteria.
+	Verification *Verification `json:"verification,omitempty"`
+
+	// TokensUsed and CostUSD accumulate the model usage of the agent calls made for the
+	// ticket over all its runs, as reported by the agent (see AddUsage).
+	TokensUsed int     `json:"tokens_used,omitempty"`
+	CostUSD    float64 `json:"cost_usd,omitempty"`
+
+	// Notes are operator notes (note command) for the next agent runs, oldest first.
+	Notes []Note `json:"notes,omitempty"`
+
+	// Events is the ticket's append-only history, oldest first: its creation, status
+	// changes, agent calls and commits (see the history command).
+	Events []Event `json:"events,omitempty"`
+
+	// SoftDependencies are tickets this one would rather follow but does not wait for;
+	// unlike Dependencies they never block it. deps fix-cycles moves dependencies here
+	// to break a cycle while keeping the relationship on record.
+	SoftDependencies []string `json:"soft_dependencies,omitempty"`
+
+	// Worker is the process that moved the ticket to in_progress and is working on it;
+	// cleared on any other status. A ticket whose worker is gone was cut off.
+	Worker *Worker `json:"worker,omitempty"`
+}
+
+// Worker identifies an orchestrator process working on a ticket.
+type Worker struct {
+	PID int `json:"pid"`
+	// ProcessStart tells the process apart from a later one reusing PID (see
+	// runstate.Entry.ProcessStart).
+	ProcessStart string `json:"process_start,omitempty"`
+}
+
+// MaxPartialOutputChars caps PartialOutput; only the most recent output is kept.
+const MaxPartialOutputChars = 4000
+
+// PromptCompression describes a summarization applied to a ticket's prompt.
+// Acceptance criteria and review feedback are never summarized.
+type PromptCompression struct {
+	OriginalChars   int `json:"original_chars"`
+	CompressedChars int `json:"compressed_chars"`
+	Budget          int `json:"budget"`
+	// Sections are the summarized sections in the order they were summarized:
+	// conventions, partial_output, notes (all but the latest) and description.
+	Sections     []string  `json:"sections,omitempty"`
+	CompressedAt time.Time `json:"compressed_at"`
+}
+
+// SectionList returns the summarized sections comma-separated, e.g. "notes, description".
+// Compressions recorded before sections were tracked only summarized the description.
+func (pc *PromptCompression) SectionList() string {
+	if len(pc.Sections) == 0 {
+		return "description"
+	}
+	return strings.Join(pc.Sections, ", ")
 }
 
 // NewTicket creates a new ticket with default values
@@ -97,20 +223,42 @@ func NewTicket(id, title, description string) *Ticket {
 
 // MarkInProgress marks the ticket as in progress
 func (t *Ticket) MarkInProgress() {
-	t.Status = StatusInProgress
+	t.SetStatus(StatusInProgress, "")
 }
 
 // MarkCompleted marks the ticket as completed
 func (t *Ticket) MarkCompleted(output string) {
-	t.Status = StatusCompleted
+	t.SetStatus(StatusCompleted, "")
 	now := time.Now()
 	t.CompletedAt = &now
 	t.AgentOutput = output
+	t.PartialOutput = ""
+	t.TimedOutAfter = 0
+}
+
+// AddUsage adds the tokens and cost of agent calls made for the ticket.
+func (t *Ticket) AddUsage(tokens int, costUSD float64) {
+	t.TokensUsed += tokens
+	t.CostUSD += costUSD
+}
+
+// SetPartialOutput stores output salvaged from a timed-out attempt, keeping only the
+// last MaxPartialOutputChars characters (the most recent progress).
+func (t *Ticket) SetPartialOutput(output string) {
+	output = strings.TrimSpace(output)
+	if r := []rune(output); len(r) > MaxPartialOutputChars {
+		output = "..." + string(r[len(r)-MaxPartialOutputChars:])
+	}
+	t.PartialOutput = output
 }
 
 // MarkFailed marks the ticket as failed
 func (t *Ticket) MarkFailed(err error) {
-	t.Status = StatusFailed
+	reason := ""
+	if err != nil {
+		reason = err.Error()
+	}
+	t.SetStatus(StatusFailed, reason)
 	now := time.Now()
 	t.CompletedAt = &now
 	if err != nil {
@@ -118,6 +266,75 @@ func (t *Ticket) MarkFailed(err error) {
 	}
 }
 
+// NormalizeLabels trims, lower-cases and de-duplicates labels, dropping empty ones.
+func NormalizeLabels(labels []string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, l := range labels {
+		l = strings.ToLower(strings.TrimSpace(l))
+		if l == "" || seen[l] {
+			continue
+		}
+		seen[l] = true
+		out = append(out, l)
+	}
+	return out
+}
+
+// HasLabels reports whether the ticket carries every one of the given labels
+// (case-insensitive). An empty list matches every ticket.
+func (t *Ticket) HasLabels(labels []string) bool {
+	for _, want := range NormalizeLabels(labels) {
+		found := false
+		for _, l := range t.Labels {
+			if strings.EqualFold(l, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterByLabels returns the tickets carrying every one of the given labels.
+// Returns tickets unchanged when labels is empty.
+func FilterByLabels(tickets []*Ticket, labels []string) []*Ticket {
+	if len(labels) == 0 {
+		return tickets
+	}
+	out := make([]*Ticket, 0, len(tickets))
+	for _, t := range tickets {
+		if t.HasLabels(labels) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// InMilestones reports whether the ticket was planned from one of the given milestone
+// files, compared as recorded in Milestone. An empty list matches every ticket.
+func (t *Ticket) InMilestones(milestones []string) bool {
+	return len(milestones) == 0 || slices.Contains(milestones, t.Milestone)
+}
+
+// FilterByMilestones returns the tickets planned from one of the given milestone files.
+// Returns tickets unchanged when milestones is empty.
+func FilterByMilestones(tickets []*Ticket, milestones []string) []*Ticket {
+	if len(milestones) == 0 {
+		return tickets
+	}
+	out := make([]*Ticket, 0, len(tickets))
+	for _, t := range tickets {
+		if t.InMilestones(milestones) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
 // ToJSON converts the ticket to JSON
 func (t *Ticket) ToJSON() ([]byte, error) {
 	return json.MarshalIndent(t, "", "  ")
@@ -143,9 +360,22 @@ func (t *Ticket) Validate() error {
 	if !t.Status.IsValid() {
 		return fmt.Errorf("invalid ticket status: %s", t.Status)
 	}
+	if t.ParentID != "" && t.ParentID == t.ID {
+		return fmt.Errorf("ticket %s cannot be its own parent", t.ID)
+	}
+	for i, a := range t.Assertions {
+		if err := a.Validate(); err != nil {
+			return fmt.Errorf("assertion %d: %w", i+1, err)
+		}
+	}
 	return nil
 }
 
+// IsEpic reports whether the ticket is an epic.
+func (t *Ticket) IsEpic() bool {
+	return t.Type == TypeEpic
+}
+
 // Summary returns a short summary of the ticket
 func (t *Ticket) Summary() string {
 	return fmt.Sprintf("[%s] %s - %s", t.ID, t.Title, t.Status)
@@ -242,6 +472,60 @@ func (il *IssueList) FilterByCategory(category string) []*Issue {
 	return result
 }
 
+// Merge adds the issues of other that are not already in the list (same title and
+// location) and returns the ones added. An added issue whose ID is already taken gets
+// idSuffix appended, since issue IDs become ticket IDs.
+func (il *IssueList) Merge(other *IssueList, idSuffix string) []*Issue {
+	seen := make(map[string]bool)
+	ids := make(map[string]bool)
+	for _, i := range il.Issues {
+		seen[i.Title+"\x00"+i.Location] = true
+		ids[i.ID] = true
+	}
+	added := make([]*Issue, 0)
+	for _, i := range other.Issues {
+		key := i.Title + "\x00" + i.Location
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if ids[i.ID] {
+			i.ID = i.ID + "-" + idSuffix
+		}
+		ids[i.ID] = true
+		il.Add(i)
+		added = append(added, i)
+	}
+	return added
+}
+
+// SeverityRank orders issue severities: LOW=1, MED/MEDIUM=2, HIGH=3 (case-insensitive).
+// Unknown severities rank 0.
+func SeverityRank(severity string) int {
+	switch strings.ToUpper(strings.TrimSpace(severity)) {
+	case "HIGH":
+		return 3
+	case "MED", "MEDIUM":
+		return 2
+	case "LOW":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// AtLeast returns issues whose severity is at or above the given severity.
+func (il *IssueList) AtLeast(severity string) []*Issue {
+	min := SeverityRank(severity)
+	result := make([]*Issue, 0)
+	for _, i := range il.Issues {
+		if SeverityRank(i.Severity) >= min {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
 // ToTickets converts issues to tickets
 func (il *IssueList) ToTickets() *TicketList {
 	tl := NewTicketList()
@@ -2,6 +2,7 @@ package ticket
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 )
@@ -179,6 +180,29 @@ func (e *testError) Error() string {
 	return e.msg
 }
 
+func TestTicket_SetPartialOutput(t *testing.T) {
+	tk := NewTicket("T-1", "t", "")
+
+	tk.SetPartialOutput("  step 1 done\n")
+	if tk.PartialOutput != "step 1 done" {
+		t.Errorf("PartialOutput = %q, want trimmed output", tk.PartialOutput)
+	}
+
+	long := strings.Repeat("a", MaxPartialOutputChars) + "TAIL"
+	tk.SetPartialOutput(long)
+	if got := []rune(tk.PartialOutput); len(got) != MaxPartialOutputChars+3 {
+		t.Errorf("PartialOutput length = %d, want %d", len(got), MaxPartialOutputChars+3)
+	}
+	if !strings.HasPrefix(tk.PartialOutput, "...") || !strings.HasSuffix(tk.PartialOutput, "TAIL") {
+		t.Error("PartialOutput should keep the most recent output")
+	}
+
+	tk.MarkCompleted("done")
+	if tk.PartialOutput != "" {
+		t.Error("MarkCompleted() should clear PartialOutput")
+	}
+}
+
 func TestTicket_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -227,6 +251,36 @@ func TestTicket_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid assertion",
+			ticket: &Ticket{
+				ID:         "T1",
+				Title:      "Test",
+				Status:     StatusPending,
+				Assertions: []Assertion{{Command: "go test ./...", OutputPattern: "^ok"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "assertion without command",
+			ticket: &Ticket{
+				ID:         "T1",
+				Title:      "Test",
+				Status:     StatusPending,
+				Assertions: []Assertion{{Command: " "}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "assertion with invalid pattern",
+			ticket: &Ticket{
+				ID:         "T1",
+				Title:      "Test",
+				Status:     StatusPending,
+				Assertions: []Assertion{{Command: "true", OutputPattern: "("}},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -517,3 +571,106 @@ func TestTicket_CompletedAt_JSON(t *testing.T) {
 		t.Error("completed_at should be omitted when nil")
 	}
 }
+
+func TestIssueList_AtLeast(t *testing.T) {
+	il := NewIssueList()
+	il.Add(&Issue{ID: "I-1", Severity: "HIGH"})
+	il.Add(&Issue{ID: "I-2", Severity: "MED"})
+	il.Add(&Issue{ID: "I-3", Severity: "low"})
+	il.Add(&Issue{ID: "I-4", Severity: "MEDIUM"})
+
+	tests := []struct {
+		severity string
+		want     int
+	}{
+		{"HIGH", 1},
+		{"med", 3},
+		{"LOW", 4},
+	}
+	for _, tt := range tests {
+		if got := il.AtLeast(tt.severity); len(got) != tt.want {
+			t.Errorf("AtLeast(%q) returned %d issues, want %d", tt.severity, len(got), tt.want)
+		}
+	}
+	if SeverityRank("unknown") != 0 {
+		t.Error("unknown severity should rank 0")
+	}
+}
+
+func TestNormalizeLabels(t *testing.T) {
+	got := NormalizeLabels([]string{" Backend", "stale", "", "backend", "UI "})
+	want := []string{"backend", "stale", "ui"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("NormalizeLabels() = %v, want %v", got, want)
+	}
+}
+
+func TestFilterByLabels(t *testing.T) {
+	a := &Ticket{ID: "A", Labels: []string{"backend", "stale"}}
+	b := &Ticket{ID: "B", Labels: []string{"backend"}}
+	c := &Ticket{ID: "C"}
+	all := []*Ticket{a, b, c}
+
+	tests := []struct {
+		labels []string
+		want   string
+	}{
+		{nil, "A,B,C"},
+		{[]string{"backend"}, "A,B"},
+		{[]string{"Backend", "STALE"}, "A"},
+		{[]string{"frontend"}, ""},
+	}
+	for _, tt := range tests {
+		var ids []string
+		for _, tk := range FilterByLabels(all, tt.labels) {
+			ids = append(ids, tk.ID)
+		}
+		if got := strings.Join(ids, ","); got != tt.want {
+			t.Errorf("FilterByLabels(%v) = %q, want %q", tt.labels, got, tt.want)
+		}
+	}
+}
+
+func TestFilterByMilestones(t *testing.T) {
+	a := &Ticket{ID: "A", Milestone: "docs/m1.md"}
+	b := &Ticket{ID: "B", Milestone: "docs/m2.md"}
+	c := &Ticket{ID: "C"}
+	all := []*Ticket{a, b, c}
+
+	tests := []struct {
+		milestones []string
+		want       string
+	}{
+		{nil, "A,B,C"},
+		{[]string{"docs/m1.md"}, "A"},
+		{[]string{"docs/m1.md", "docs/m2.md"}, "A,B"},
+		{[]string{"docs/m3.md"}, ""},
+	}
+	for _, tt := range tests {
+		var ids []string
+		for _, tk := range FilterByMilestones(all, tt.milestones) {
+			ids = append(ids, tk.ID)
+		}
+		if got := strings.Join(ids, ","); got != tt.want {
+			t.Errorf("FilterByMilestones(%v) = %q, want %q", tt.milestones, got, tt.want)
+		}
+	}
+}
+
+func TestIssueList_Merge(t *testing.T) {
+	il := NewIssueList()
+	il.Add(&Issue{ID: "ISSUE-001", Title: "SQL injection", Location: "api/user.go:10"})
+
+	other := NewIssueList()
+	other.Add(&Issue{ID: "ISSUE-009", Title: "SQL injection", Location: "api/user.go:10"})
+	other.Add(&Issue{ID: "ISSUE-001", Title: "Weak hash", Location: "auth/pw.go:3"})
+	other.Add(&Issue{ID: "ISSUE-002", Title: "Open redirect", Location: "api/login.go:7"})
+
+	added := il.Merge(other, "X1")
+	if len(added) != 2 || il.Count() != 3 {
+		t.Fatalf("Merge() added %d, list has %d; want 2 and 3", len(added), il.Count())
+	}
+	if added[0].ID != "ISSUE-001-X1" || added[1].ID != "ISSUE-002" {
+		t.Errorf("Merge() IDs = %s, %s; want ISSUE-001-X1, ISSUE-002", added[0].ID, added[1].ID)
+	}
+}
@@ -0,0 +1,122 @@
+package ticket
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PullRequestRef links a ticket to the pull request that carries its commits.
+type PullRequestRef struct {
+	Number int    `json:"number"`
+	URL    string `json:"url,omitempty"`
+}
+
+// String returns "#<number>", followed by the URL when known.
+func (p *PullRequestRef) String() string {
+	if p.URL == "" {
+		return fmt.Sprintf("#%d", p.Number)
+	}
+	return fmt.Sprintf("#%d %s", p.Number, p.URL)
+}
+
+// RemoteRef identifies an issue in an external tracker.
+type RemoteRef struct {
+	// System names the tracker, e.g. "github".
+	System string `json:"system"`
+	// Repo is the tracker's project, e.g. "owner/repo" on GitHub.
+	Repo   string `json:"repo"`
+	Number int    `json:"number"`
+	URL    string `json:"url,omitempty"`
+}
+
+// String returns "<repo>#<number>", or the issue key ("<project>-<number>") for Jira.
+func (r *RemoteRef) String() string {
+	if r.System == "jira" {
+		return fmt.Sprintf("%s-%d", r.Repo, r.Number)
+	}
+	return fmt.Sprintf("%s#%d", r.Repo, r.Number)
+}
+
+// pullURLPattern matches pull request URLs such as https://github.com/o/r/pull/12
+// (GitHub) or .../merge_requests/12 (GitLab).
+var pullURLPattern = regexp.MustCompile(`/(?:pull|pulls|merge_requests)/(\d+)(?:[/?#]|$)`)
+
+// ParsePullRequestRef parses a pull request reference: a number ("12" or "#12") or a
+// pull request URL.
+func ParsePullRequestRef(s string) (*PullRequestRef, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.Atoi(strings.TrimPrefix(s, "#")); err == nil && n > 0 {
+		return &PullRequestRef{Number: n}, nil
+	}
+	if m := pullURLPattern.FindStringSubmatch(s); m != nil && strings.Contains(s, "://") {
+		n, _ := strconv.Atoi(m[1])
+		return &PullRequestRef{Number: n, URL: s}, nil
+	}
+	return nil, fmt.Errorf("invalid pull request reference %q (expected a number or a pull request URL)", s)
+}
+
+// Matches reports whether ref refers to the same pull request: equal URLs, or equal
+// numbers when either side has no URL.
+func (p *PullRequestRef) Matches(ref *PullRequestRef) bool {
+	if p == nil || ref == nil {
+		return false
+	}
+	if p.URL != "" && ref.URL != "" {
+		return strings.TrimRight(p.URL, "/") == strings.TrimRight(ref.URL, "/")
+	}
+	return p.Number == ref.Number
+}
+
+// AddCommit records a commit SHA made for the ticket; duplicates are ignored.
+func (t *Ticket) AddCommit(sha string) {
+	sha = strings.TrimSpace(sha)
+	if sha == "" {
+		return
+	}
+	for _, c := range t.Commits {
+		if c == sha {
+			return
+		}
+	}
+	t.Commits = append(t.Commits, sha)
+	t.AddEvent(Event{Kind: EventCommit, Detail: sha})
+}
+
+// HasCommit reports whether the ticket recorded a commit starting with the given SHA
+// prefix (at least 7 characters, like git's short SHAs).
+func (t *Ticket) HasCommit(prefix string) bool {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	if len(prefix) < 7 {
+		return false
+	}
+	for _, c := range t.Commits {
+		if strings.HasPrefix(strings.ToLower(c), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindByPullRequest returns the tickets linked to the given pull request.
+func FindByPullRequest(tickets []*Ticket, ref *PullRequestRef) []*Ticket {
+	var out []*Ticket
+	for _, t := range tickets {
+		if t.PullRequest.Matches(ref) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// FindByCommit returns the tickets that recorded a commit with the given SHA prefix.
+func FindByCommit(tickets []*Ticket, sha string) []*Ticket {
+	var out []*Ticket
+	for _, t := range tickets {
+		if t.HasCommit(sha) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,58 @@
+package ticket
+
+import "testing"
+
+func TestParsePullRequestRef(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		wantURL bool
+		wantErr bool
+	}{
+		{"42", 42, false, false},
+		{"#42", 42, false, false},
+		{"https://github.com/octo/widgets/pull/42", 42, true, false},
+		{"https://github.com/octo/widgets/pull/42/files", 42, true, false},
+		{"https://gitlab.com/octo/widgets/-/merge_requests/7", 7, true, false},
+		{"0", 0, false, true},
+		{"pull/42", 0, false, true},
+		{"https://github.com/octo/widgets/issues/42", 0, false, true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePullRequestRef(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePullRequestRef(%q) err = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err == nil && (got.Number != tt.want || (got.URL != "") != tt.wantURL) {
+			t.Errorf("ParsePullRequestRef(%q) = %+v", tt.in, got)
+		}
+	}
+}
+
+func TestTraceLookups(t *testing.T) {
+	a := NewTicket("T-1", "a", "")
+	a.AddCommit("3f2c1ab9d0e4")
+	a.AddCommit("3f2c1ab9d0e4")
+	a.PullRequest = &PullRequestRef{Number: 42, URL: "https://github.com/o/r/pull/42"}
+	b := NewTicket("T-2", "b", "")
+	b.PullRequest = &PullRequestRef{Number: 42}
+	c := NewTicket("T-3", "c", "")
+	tickets := []*Ticket{a, b, c}
+
+	if len(a.Commits) != 1 {
+		t.Errorf("AddCommit() should ignore duplicates, got %v", a.Commits)
+	}
+	if got := FindByCommit(tickets, "3F2C1AB"); len(got) != 1 || got[0] != a {
+		t.Errorf("FindByCommit() = %v, want [T-1]", got)
+	}
+	if got := FindByCommit(tickets, "3f2c"); len(got) != 0 {
+		t.Errorf("FindByCommit() with a too short prefix = %v, want none", got)
+	}
+	if got := FindByPullRequest(tickets, &PullRequestRef{Number: 42}); len(got) != 2 {
+		t.Errorf("FindByPullRequest(#42) = %d tickets, want 2", len(got))
+	}
+	if got := FindByPullRequest(tickets, &PullRequestRef{Number: 42, URL: "https://github.com/o/other/pull/42"}); len(got) != 1 || got[0] != b {
+		t.Errorf("FindByPullRequest(other repo URL) = %v, want only the URL-less T-2", got)
+	}
+}
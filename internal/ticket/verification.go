@@ -0,0 +1,36 @@
+package ticket
+
+import "time"
+
+// Verification is the verify agent's verdict on a ticket's acceptance criteria (verify
+// command, run --verify): one verdict per criterion, judged against the repository and
+// the ticket's assertion results. The latest verification replaces earlier ones.
+type Verification struct {
+	Summary    string             `json:"summary,omitempty"`
+	Criteria   []CriterionVerdict `json:"criteria"`
+	VerifiedAt time.Time          `json:"verified_at"`
+}
+
+// CriterionVerdict is the verdict on one acceptance criterion.
+type CriterionVerdict struct {
+	Criterion string `json:"criterion"`
+	Passed    bool   `json:"passed"`
+	// Evidence is what the verdict is based on, e.g. files, tests or command output.
+	Evidence string `json:"evidence,omitempty"`
+}
+
+// Passed reports whether every criterion passed.
+func (v *Verification) Passed() bool {
+	return len(v.Failed()) == 0
+}
+
+// Failed returns the verdicts that did not pass.
+func (v *Verification) Failed() []CriterionVerdict {
+	var out []CriterionVerdict
+	for _, c := range v.Criteria {
+		if !c.Passed {
+			out = append(out, c)
+		}
+	}
+	return out
+}
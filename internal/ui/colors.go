@@ -5,60 +5,80 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-// Color palette
+// Color palette, set from the active theme (see ApplyTheme)
 var (
-	ColorPrimary   = lipgloss.Color("39")  // Blue
-	ColorSuccess   = lipgloss.Color("82")  // Green
-	ColorWarning   = lipgloss.Color("214") // Orange
-	ColorError     = lipgloss.Color("196") // Red
-	ColorInfo      = lipgloss.Color("87")  // Cyan
-	ColorMuted     = lipgloss.Color("245") // Gray
-	ColorHighlight = lipgloss.Color("212") // Pink
+	ColorPrimary   lipgloss.TerminalColor
+	ColorSuccess   lipgloss.TerminalColor
+	ColorWarning   lipgloss.TerminalColor
+	ColorError     lipgloss.TerminalColor
+	ColorInfo      lipgloss.TerminalColor
+	ColorMuted     lipgloss.TerminalColor
+	ColorHighlight lipgloss.TerminalColor
 )
 
 // Text styles
 var (
 	StyleBold = lipgloss.NewStyle().Bold(true)
 
+	StylePrimary  lipgloss.Style
+	StyleSuccess  lipgloss.Style
+	StyleWarning  lipgloss.Style
+	StyleError    lipgloss.Style
+	StyleInfo     lipgloss.Style
+	StyleMuted    lipgloss.Style
+	StyleTitle    lipgloss.Style
+	StyleSubtitle lipgloss.Style
+)
+
+// Status indicators
+var (
+	StatusPending    string
+	StatusInProgress string
+	StatusCompleted  string
+	StatusFailed     string
+)
+
+// Box styles
+var (
+	BoxStyle    lipgloss.Style
+	HeaderStyle lipgloss.Style
+)
+
+// buildStyles derives the styles and status indicators from the color palette.
+func buildStyles(background lipgloss.TerminalColor) {
 	StylePrimary = lipgloss.NewStyle().Foreground(ColorPrimary)
 	StyleSuccess = lipgloss.NewStyle().Foreground(ColorSuccess)
 	StyleWarning = lipgloss.NewStyle().Foreground(ColorWarning)
-	StyleError   = lipgloss.NewStyle().Foreground(ColorError)
-	StyleInfo    = lipgloss.NewStyle().Foreground(ColorInfo)
-	StyleMuted   = lipgloss.NewStyle().Foreground(ColorMuted)
+	StyleError = lipgloss.NewStyle().Foreground(ColorError)
+	StyleInfo = lipgloss.NewStyle().Foreground(ColorInfo)
+	StyleMuted = lipgloss.NewStyle().Foreground(ColorMuted)
 
 	StyleTitle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorPrimary).
-			MarginBottom(1)
+		Bold(true).
+		Foreground(ColorPrimary).
+		MarginBottom(1)
 
 	StyleSubtitle = lipgloss.NewStyle().
-			Foreground(ColorMuted).
-			Italic(true)
-)
+		Foreground(ColorMuted).
+		Italic(true)
 
-// Status indicators
-var (
-	StatusPending    = StyleWarning.Render("○")
+	StatusPending = StyleWarning.Render("○")
 	StatusInProgress = StyleInfo.Render("◐")
-	StatusCompleted  = StyleSuccess.Render("●")
-	StatusFailed     = StyleError.Render("✗")
-)
+	StatusCompleted = StyleSuccess.Render("●")
+	StatusFailed = StyleError.Render("✗")
 
-// Box styles
-var (
 	BoxStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorMuted).
-			Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorMuted).
+		Padding(0, 1)
 
 	HeaderStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorPrimary).
-			Background(lipgloss.Color("236")).
-			Padding(0, 1).
-			Width(60)
-)
+		Bold(true).
+		Foreground(ColorPrimary).
+		Background(background).
+		Padding(0, 1).
+		Width(60)
+}
 
 // Priority styles
 func PriorityStyle(priority int) lipgloss.Style {
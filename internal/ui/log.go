@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+var (
+	loggerMu sync.RWMutex
+	logger   *slog.Logger
+)
+
+// SetLogger routes the Print helpers to l instead of their writer: headers, info and
+// success messages become Info records, warnings Warn and errors Error records. A
+// background process whose output is a log file uses it so the whole file is written
+// by one handler; nil restores printing.
+func SetLogger(l *slog.Logger) {
+	loggerMu.Lock()
+	defer loggerMu.Unlock()
+	logger = l
+}
+
+// logPrint logs message at level when a logger is set and reports whether it did.
+// Blank messages, used as spacing on a terminal, are dropped.
+func logPrint(level slog.Level, message string) bool {
+	loggerMu.RLock()
+	l := logger
+	loggerMu.RUnlock()
+	if l == nil {
+		return false
+	}
+	if message = strings.TrimSpace(message); message != "" {
+		l.Log(context.Background(), level, message)
+	}
+	return true
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"log/slog"
 	"strings"
 
 	"github.com/anthropic/agent-orchestrator/internal/i18n"
@@ -148,37 +149,58 @@ func (p *Prompt) askSelectScanner(question string, options []string) (int, error
 
 // PrintHeader prints a styled header
 func PrintHeader(w io.Writer, title string) {
+	if logPrint(slog.LevelInfo, title) {
+		return
+	}
 	fmt.Fprintln(w)
 	fmt.Fprintln(w, StyleTitle.Render(title))
 }
 
 // PrintSubheader prints a styled subheader
 func PrintSubheader(w io.Writer, title string) {
+	if logPrint(slog.LevelInfo, title) {
+		return
+	}
 	fmt.Fprintln(w, StyleSubtitle.Render(title))
 }
 
 // PrintSuccess prints a success message
 func PrintSuccess(w io.Writer, message string) {
+	if logPrint(slog.LevelInfo, message) {
+		return
+	}
 	fmt.Fprintf(w, "%s %s\n", StyleSuccess.Render("✓"), message)
 }
 
 // PrintError prints an error message
 func PrintError(w io.Writer, message string) {
+	if logPrint(slog.LevelError, message) {
+		return
+	}
 	fmt.Fprintf(w, "%s %s\n", StyleError.Render("✗"), message)
 }
 
 // PrintWarning prints a warning message
 func PrintWarning(w io.Writer, message string) {
+	if logPrint(slog.LevelWarn, message) {
+		return
+	}
 	fmt.Fprintf(w, "%s %s\n", StyleWarning.Render("!"), message)
 }
 
 // PrintInfo prints an info message
 func PrintInfo(w io.Writer, message string) {
+	if logPrint(slog.LevelInfo, message) {
+		return
+	}
 	fmt.Fprintf(w, "%s %s\n", StyleInfo.Render("ℹ"), message)
 }
 
 // PrintStep prints a step indicator
 func PrintStep(w io.Writer, current, total int, message string) {
+	if logPrint(slog.LevelInfo, fmt.Sprintf("[%d/%d] %s", current, total, message)) {
+		return
+	}
 	step := StylePrimary.Render(fmt.Sprintf("[%d/%d]", current, total))
 	fmt.Fprintf(w, "%s %s\n", step, message)
 }
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"bytes"
+	"log/slog"
 	"reflect"
 	"strings"
 	"testing"
@@ -37,3 +38,33 @@ func TestPromptAskMultilineScannerEmpty(t *testing.T) {
 		t.Fatalf("AskMultiline = %#v, want empty", lines)
 	}
 }
+
+func TestPrintHelpers_SetLogger(t *testing.T) {
+	var logged, printed bytes.Buffer
+	SetLogger(slog.New(slog.NewJSONHandler(&logged, nil)))
+	defer SetLogger(nil)
+
+	PrintInfo(&printed, "")
+	PrintSuccess(&printed, "done")
+	PrintWarning(&printed, "careful")
+	PrintError(&printed, "broken")
+	if printed.Len() != 0 {
+		t.Errorf("Print helpers wrote %q, want everything logged", printed.String())
+	}
+	lines := strings.Split(strings.TrimSpace(logged.String()), "\n")
+	want := []string{`"level":"INFO","msg":"done"`, `"level":"WARN","msg":"careful"`, `"level":"ERROR","msg":"broken"`}
+	if len(lines) != len(want) {
+		t.Fatalf("logged %d records, want %d (blank messages dropped):\n%s", len(lines), len(want), logged.String())
+	}
+	for i, w := range want {
+		if !strings.Contains(lines[i], w) {
+			t.Errorf("record %d = %s, want %s", i, lines[i], w)
+		}
+	}
+
+	SetLogger(nil)
+	PrintInfo(&printed, "back")
+	if !strings.Contains(printed.String(), "back") {
+		t.Error("SetLogger(nil) should restore printing")
+	}
+}
@@ -184,3 +184,27 @@ func (it *IssueTable) Render(w io.Writer) {
 	}
 	fmt.Fprintln(w)
 }
+
+// sparkBlocks are the bar glyphs of a sparkline, lowest to highest.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a one-line bar chart scaled between their minimum and
+// maximum, e.g. "▁▃▅█".
+func Sparkline(values []int) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	var sb strings.Builder
+	for _, v := range values {
+		i := len(sparkBlocks) / 2
+		if hi > lo {
+			i = (v - lo) * (len(sparkBlocks) - 1) / (hi - lo)
+		}
+		sb.WriteRune(sparkBlocks[i])
+	}
+	return sb.String()
+}
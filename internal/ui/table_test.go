@@ -0,0 +1,21 @@
+package ui
+
+import "testing"
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values []int
+		want   string
+	}{
+		{nil, ""},
+		{[]int{5}, "▅"},
+		{[]int{3, 3}, "▅▅"},
+		{[]int{0, 7}, "▁█"},
+		{[]int{10, 40, 20, 70}, "▁▄▂█"},
+	}
+	for _, tt := range tests {
+		if got := Sparkline(tt.values); got != tt.want {
+			t.Errorf("Sparkline(%v) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Built-in theme names, as accepted by the theme setting.
+const (
+	ThemeDefault    = "default"
+	ThemeColorblind = "colorblind"
+	ThemeMonochrome = "monochrome"
+)
+
+// Theme is the color palette behind every style in this package: message prefixes,
+// spinners, headers, status tables and prompts.
+type Theme struct {
+	Name       string
+	Primary    lipgloss.TerminalColor
+	Success    lipgloss.TerminalColor
+	Warning    lipgloss.TerminalColor
+	Error      lipgloss.TerminalColor
+	Info       lipgloss.TerminalColor
+	Muted      lipgloss.TerminalColor
+	Highlight  lipgloss.TerminalColor
+	Background lipgloss.TerminalColor
+}
+
+var themes = map[string]Theme{
+	ThemeDefault: {
+		Name:       ThemeDefault,
+		Primary:    lipgloss.Color("39"),  // Blue
+		Success:    lipgloss.Color("82"),  // Green
+		Warning:    lipgloss.Color("214"), // Orange
+		Error:      lipgloss.Color("196"), // Red
+		Info:       lipgloss.Color("87"),  // Cyan
+		Muted:      lipgloss.Color("245"), // Gray
+		Highlight:  lipgloss.Color("212"), // Pink
+		Background: lipgloss.Color("236"),
+	},
+	// The Okabe–Ito palette: success and error differ in hue and brightness for every
+	// common form of color blindness, unlike green and red.
+	ThemeColorblind: {
+		Name:       ThemeColorblind,
+		Primary:    lipgloss.Color("#0072B2"), // Blue
+		Success:    lipgloss.Color("#009E73"), // Bluish green
+		Warning:    lipgloss.Color("#E69F00"), // Orange
+		Error:      lipgloss.Color("#D55E00"), // Vermillion
+		Info:       lipgloss.Color("#56B4E9"), // Sky blue
+		Muted:      lipgloss.Color("245"),     // Gray
+		Highlight:  lipgloss.Color("#CC79A7"), // Reddish purple
+		Background: lipgloss.Color("236"),
+	},
+	ThemeMonochrome: {
+		Name:       ThemeMonochrome,
+		Primary:    lipgloss.NoColor{},
+		Success:    lipgloss.NoColor{},
+		Warning:    lipgloss.NoColor{},
+		Error:      lipgloss.NoColor{},
+		Info:       lipgloss.NoColor{},
+		Muted:      lipgloss.NoColor{},
+		Highlight:  lipgloss.NoColor{},
+		Background: lipgloss.NoColor{},
+	},
+}
+
+// activeTheme is the theme last applied.
+var activeTheme Theme
+
+func init() {
+	if NoColorRequested() {
+		ApplyTheme(themes[ThemeMonochrome])
+		return
+	}
+	ApplyTheme(themes[ThemeDefault])
+}
+
+// ThemeNames returns the built-in theme names, ThemeDefault first.
+func ThemeNames() []string {
+	return []string{ThemeDefault, ThemeColorblind, ThemeMonochrome}
+}
+
+// LookupTheme returns the built-in theme named name; an empty name is ThemeDefault.
+func LookupTheme(name string) (Theme, error) {
+	if name == "" {
+		name = ThemeDefault
+	}
+	t, ok := themes[strings.ToLower(name)]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown theme %q (expected one of %s)", name, strings.Join(ThemeNames(), ", "))
+	}
+	return t, nil
+}
+
+// ThemeColorNames returns the color names WithColors accepts, sorted.
+func ThemeColorNames() []string {
+	return []string{"background", "error", "highlight", "info", "muted", "primary", "success", "warning"}
+}
+
+// WithColors returns t with the named colors replaced, e.g. {"success": "#00A0FF"}.
+// Values are hex colors (#rgb or #rrggbb) or ANSI 256-color codes (0-255).
+func (t Theme) WithColors(colors map[string]string) (Theme, error) {
+	for name, value := range colors {
+		c, err := ParseColor(value)
+		if err != nil {
+			return Theme{}, fmt.Errorf("theme color %s: %w", name, err)
+		}
+		switch strings.ToLower(name) {
+		case "primary":
+			t.Primary = c
+		case "success":
+			t.Success = c
+		case "warning":
+			t.Warning = c
+		case "error":
+			t.Error = c
+		case "info":
+			t.Info = c
+		case "muted":
+			t.Muted = c
+		case "highlight":
+			t.Highlight = c
+		case "background":
+			t.Background = c
+		default:
+			return Theme{}, fmt.Errorf("unknown theme color %q (expected one of %s)", name, strings.Join(ThemeColorNames(), ", "))
+		}
+	}
+	return t, nil
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// ParseColor parses a hex color (#rgb or #rrggbb) or an ANSI 256-color code (0-255).
+func ParseColor(value string) (lipgloss.TerminalColor, error) {
+	value = strings.TrimSpace(value)
+	if hexColor.MatchString(value) {
+		return lipgloss.Color(value), nil
+	}
+	if n, err := strconv.Atoi(value); err == nil && n >= 0 && n <= 255 {
+		return lipgloss.Color(value), nil
+	}
+	return nil, fmt.Errorf("invalid color %q (expected #rgb, #rrggbb or 0-255)", value)
+}
+
+// ApplyTheme makes t the palette of all styles in this package.
+func ApplyTheme(t Theme) {
+	activeTheme = t
+	ColorPrimary = t.Primary
+	ColorSuccess = t.Success
+	ColorWarning = t.Warning
+	ColorError = t.Error
+	ColorInfo = t.Info
+	ColorMuted = t.Muted
+	ColorHighlight = t.Highlight
+	buildStyles(t.Background)
+}
+
+// ActiveTheme returns the theme last applied.
+func ActiveTheme() Theme {
+	return activeTheme
+}
+
+// NoColorRequested reports whether the NO_COLOR convention (https://no-color.org)
+// asks for output without color.
+func NoColorRequested() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// ConfigureTheme applies the named theme with colors overriding its palette. When
+// NO_COLOR is set, the monochrome theme is applied instead, whatever the settings.
+func ConfigureTheme(name string, colors map[string]string) error {
+	t, err := LookupTheme(name)
+	if err != nil {
+		return err
+	}
+	if t, err = t.WithColors(colors); err != nil {
+		return err
+	}
+	if NoColorRequested() {
+		t = themes[ThemeMonochrome]
+	}
+	ApplyTheme(t)
+	return nil
+}
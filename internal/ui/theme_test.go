@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestLookupTheme(t *testing.T) {
+	for _, name := range append(ThemeNames(), "", "Colorblind") {
+		if _, err := LookupTheme(name); err != nil {
+			t.Errorf("LookupTheme(%q) error = %v", name, err)
+		}
+	}
+	if _, err := LookupTheme("neon"); err == nil {
+		t.Error("LookupTheme(neon) should fail")
+	}
+}
+
+func TestTheme_WithColors(t *testing.T) {
+	base, _ := LookupTheme(ThemeDefault)
+	got, err := base.WithColors(map[string]string{"Success": "#00A0FF", "error": "160"})
+	if err != nil {
+		t.Fatalf("WithColors() error = %v", err)
+	}
+	if got.Success != lipgloss.Color("#00A0FF") || got.Error != lipgloss.Color("160") {
+		t.Errorf("WithColors() success, error = %v, %v", got.Success, got.Error)
+	}
+	if got.Warning != base.Warning {
+		t.Errorf("WithColors() changed warning to %v", got.Warning)
+	}
+
+	for _, colors := range []map[string]string{
+		{"accent": "#fff"},
+		{"success": "green"},
+		{"success": "#12345"},
+		{"success": "256"},
+	} {
+		if _, err := base.WithColors(colors); err == nil {
+			t.Errorf("WithColors(%v) should fail", colors)
+		}
+	}
+}
+
+func TestConfigureTheme(t *testing.T) {
+	defer ApplyTheme(ActiveTheme())
+
+	t.Setenv("NO_COLOR", "")
+	if err := ConfigureTheme(ThemeColorblind, map[string]string{"info": "#fff"}); err != nil {
+		t.Fatalf("ConfigureTheme() error = %v", err)
+	}
+	if ActiveTheme().Name != ThemeColorblind || ColorInfo != lipgloss.Color("#fff") {
+		t.Errorf("ConfigureTheme() applied %s with info %v", ActiveTheme().Name, ColorInfo)
+	}
+	if err := ConfigureTheme("neon", nil); err == nil {
+		t.Error("ConfigureTheme(neon) should fail")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if err := ConfigureTheme(ThemeColorblind, nil); err != nil {
+		t.Fatalf("ConfigureTheme() error = %v", err)
+	}
+	if ActiveTheme().Name != ThemeMonochrome || ColorSuccess != (lipgloss.NoColor{}) {
+		t.Errorf("ConfigureTheme() with NO_COLOR applied %s", ActiveTheme().Name)
+	}
+}
@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// SyncWriter serializes writes to an underlying writer, so output from concurrent
+// workers is never interleaved within a single Write.
+type SyncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewSyncWriter wraps w for concurrent use.
+func NewSyncWriter(w io.Writer) *SyncWriter {
+	return &SyncWriter{w: w}
+}
+
+// Write writes p to the underlying writer while holding the lock.
+func (s *SyncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+// PrefixWriter prefixes every line written through it, e.g. with "[TICKET-001] ".
+// Partial lines are buffered until their newline (or Flush), and complete lines are
+// passed to the underlying writer in one Write; with a SyncWriter underneath, lines
+// from concurrent PrefixWriters therefore never mix.
+type PrefixWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	prefix string
+	buf    []byte
+}
+
+// NewPrefixWriter returns a writer that prefixes each line with prefix before
+// writing it to w.
+func NewPrefixWriter(w io.Writer, prefix string) *PrefixWriter {
+	return &PrefixWriter{w: w, prefix: prefix}
+}
+
+// Write buffers p and writes out every complete line.
+func (p *PrefixWriter) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.buf = append(p.buf, b...)
+	i := bytes.LastIndexByte(p.buf, '\n')
+	if i < 0 {
+		return len(b), nil
+	}
+	err := p.writeLines(p.buf[:i+1])
+	p.buf = append(p.buf[:0], p.buf[i+1:]...)
+	return len(b), err
+}
+
+// Flush writes a buffered partial line, terminated with a newline.
+func (p *PrefixWriter) Flush() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.buf) == 0 {
+		return nil
+	}
+	err := p.writeLines(append(p.buf, '\n'))
+	p.buf = p.buf[:0]
+	return err
+}
+
+func (p *PrefixWriter) writeLines(lines []byte) error {
+	var out bytes.Buffer
+	for _, line := range bytes.SplitAfter(lines, []byte("\n")) {
+		if len(line) > 0 {
+			out.WriteString(p.prefix)
+			out.Write(line)
+		}
+	}
+	_, err := p.w.Write(out.Bytes())
+	return err
+}
+
+// SectionBuffer collects output per task while a MultiSpinner owns the terminal and
+// prints each task's output as one titled section when flushed, so output of parallel
+// tasks stays grouped and attributable.
+type SectionBuffer struct {
+	mu       sync.Mutex
+	w        io.Writer
+	sections map[string]*bytes.Buffer
+	order    []string
+}
+
+// NewSectionBuffer creates a SectionBuffer that prints to w.
+func NewSectionBuffer(w io.Writer) *SectionBuffer {
+	return &SectionBuffer{w: w, sections: make(map[string]*bytes.Buffer)}
+}
+
+// Writer returns the writer for the section of task id.
+func (s *SectionBuffer) Writer(id string) io.Writer {
+	return sectionWriter{s: s, id: id}
+}
+
+// Flush prints the non-empty sections in the order they were first written to, each
+// under a header naming its task, and clears them.
+func (s *SectionBuffer) Flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range s.order {
+		buf := s.sections[id]
+		fmt.Fprintln(s.w, StyleMuted.Render("── "+id+" ──"))
+		s.w.Write(buf.Bytes())
+		if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			fmt.Fprintln(s.w)
+		}
+	}
+	s.sections = make(map[string]*bytes.Buffer)
+	s.order = nil
+}
+
+type sectionWriter struct {
+	s  *SectionBuffer
+	id string
+}
+
+func (sw sectionWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	sw.s.mu.Lock()
+	defer sw.s.mu.Unlock()
+	buf, ok := sw.s.sections[sw.id]
+	if !ok {
+		buf = &bytes.Buffer{}
+		sw.s.sections[sw.id] = buf
+		sw.s.order = append(sw.s.order, sw.id)
+	}
+	return buf.Write(p)
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestPrefixWriter_ConcurrentLinesStayIntact(t *testing.T) {
+	var out bytes.Buffer
+	sw := NewSyncWriter(&out)
+
+	const workers, lines = 8, 50
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pw := NewPrefixWriter(sw, fmt.Sprintf("[T-%d] ", i))
+			for j := 0; j < lines; j++ {
+				// Each line arrives in two writes.
+				fmt.Fprintf(pw, "worker %d line %d", i, j)
+				fmt.Fprint(pw, "\n")
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	got := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(got) != workers*lines {
+		t.Fatalf("got %d lines, want %d", len(got), workers*lines)
+	}
+	for _, line := range got {
+		var a, b, n int
+		if _, err := fmt.Sscanf(line, "[T-%d] worker %d line %d", &a, &b, &n); err != nil || a != b {
+			t.Errorf("garbled line %q", line)
+		}
+	}
+}
+
+func TestPrefixWriter_BuffersPartialLines(t *testing.T) {
+	var out bytes.Buffer
+	pw := NewPrefixWriter(&out, "> ")
+
+	fmt.Fprint(pw, "one\ntw")
+	if out.String() != "> one\n" {
+		t.Errorf("after partial write = %q, want only the complete line", out.String())
+	}
+	fmt.Fprint(pw, "o\n\nthree")
+	if err := pw.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if want := "> one\n> two\n> \n> three\n"; out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+	if err := pw.Flush(); err != nil || strings.Count(out.String(), "three") != 1 {
+		t.Errorf("second Flush() should write nothing, got %q", out.String())
+	}
+}
+
+func TestSectionBuffer_GroupsOutputPerTask(t *testing.T) {
+	var out bytes.Buffer
+	sb := NewSectionBuffer(&out)
+	a, b := sb.Writer("T-2"), sb.Writer("T-1")
+	fmt.Fprintln(a, "a1")
+	fmt.Fprintln(b, "b1")
+	fmt.Fprint(a, "a2")
+	sb.Writer("T-3") // never written: no section
+	if out.Len() != 0 {
+		t.Fatalf("output before Flush = %q", out.String())
+	}
+
+	sb.Flush()
+	got := out.String()
+	iA, iB := strings.Index(got, "T-2"), strings.Index(got, "T-1")
+	if iA < 0 || iB < 0 || iA > iB || strings.Contains(got, "T-3") {
+		t.Errorf("sections out of order or unexpected:\n%s", got)
+	}
+	if !strings.Contains(got, "a1\na2\n") || !strings.Contains(got, "b1\n") {
+		t.Errorf("section content missing:\n%s", got)
+	}
+
+	out.Reset()
+	sb.Flush()
+	if out.Len() != 0 {
+		t.Errorf("Flush() after Flush() = %q, want empty", out.String())
+	}
+}
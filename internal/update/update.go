@@ -0,0 +1,344 @@
+// Package update checks a release endpoint for newer versions of the orchestrator
+// and replaces the running binary with a verified release asset.
+//
+// The endpoint is expected to return a GitHub-style release document
+// (tag_name + assets). Every release must ship a checksums.txt asset in
+// sha256sum format and its Ed25519 signature, checksums.txt.sig, made with the key
+// whose public half is pinned in the binary (PublicKey). Checksums without a valid
+// signature and downloads whose SHA-256 does not match are rejected, so a release
+// endpoint can only serve binaries the holder of the signing key published.
+package update
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BinaryName is the base name of release assets (agent-orchestrator-<os>-<arch>[.exe]).
+const BinaryName = "agent-orchestrator"
+
+// ChecksumsAssetName is the release asset holding SHA-256 checksums of all binaries.
+const ChecksumsAssetName = "checksums.txt"
+
+// SignatureAssetName is the release asset holding the Ed25519 signature of checksums.txt,
+// raw (as openssl pkeyutl -sign -rawin writes it) or base64-encoded.
+const SignatureAssetName = ChecksumsAssetName + ".sig"
+
+// PublicKey is the release signing public key, base64 DER (PKIX) as printed by
+// openssl pkey -pubout -outform DER | base64. Release builds set it with
+//
+//	-ldflags "-X github.com/anthropic/agent-orchestrator/internal/update.PublicKey=..."
+//
+// Builds without it refuse to install updates.
+var PublicKey string
+
+// ErrNoPublicKey is returned by Download when the binary has no release signing key.
+var ErrNoPublicKey = errors.New("this build has no release signing key; refusing unverified update")
+
+// maxAssetSize guards against unexpectedly large downloads (binaries are ~20MB).
+const maxAssetSize = 200 << 20
+
+// Release is the subset of a release document used for updates.
+type Release struct {
+	TagName string  `json:"tag_name"`
+	HTMLURL string  `json:"html_url"`
+	Assets  []Asset `json:"assets"`
+}
+
+// Asset is a downloadable file attached to a release.
+type Asset struct {
+	Name        string `json:"name"`
+	DownloadURL string `json:"browser_download_url"`
+}
+
+// FindAsset returns the asset with the given name, or nil.
+func (r *Release) FindAsset(name string) *Asset {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// Client talks to the release endpoint.
+type Client struct {
+	ReleaseURL string
+	HTTPClient *http.Client
+	// PublicKey verifies the signature of checksums.txt; Download refuses releases
+	// when it is empty.
+	PublicKey ed25519.PublicKey
+}
+
+// NewClient creates a Client for the given "latest release" URL, verifying releases
+// with the pinned PublicKey.
+func NewClient(releaseURL string) *Client {
+	key, _ := ParsePublicKey(PublicKey)
+	return &Client{
+		ReleaseURL: releaseURL,
+		HTTPClient: &http.Client{Timeout: 60 * time.Second},
+		PublicKey:  key,
+	}
+}
+
+// ParsePublicKey decodes an Ed25519 public key in the format of PublicKey. An empty
+// string yields a nil key.
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	if s == "" {
+		return nil, nil
+	}
+	der, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid release public key: %w", err)
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("invalid release public key: %w", err)
+	}
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("invalid release public key: %T is not Ed25519", key)
+	}
+	return pub, nil
+}
+
+// Latest fetches the latest release document.
+func (c *Client) Latest(ctx context.Context) (*Release, error) {
+	data, err := c.get(ctx, c.ReleaseURL, 1<<20)
+	if err != nil {
+		return nil, err
+	}
+	var rel Release
+	if err := json.Unmarshal(data, &rel); err != nil {
+		return nil, fmt.Errorf("failed to parse release: %w", err)
+	}
+	if rel.TagName == "" {
+		return nil, fmt.Errorf("release has no tag_name")
+	}
+	return &rel, nil
+}
+
+// Download fetches the release asset for goos/goarch and verifies it against the
+// release's checksums.txt, whose signature must verify with c.PublicKey. It returns
+// the verified binary contents.
+func (c *Client) Download(ctx context.Context, rel *Release, goos, goarch string) ([]byte, error) {
+	if len(c.PublicKey) == 0 {
+		return nil, ErrNoPublicKey
+	}
+	name := AssetName(goos, goarch)
+	asset := rel.FindAsset(name)
+	if asset == nil {
+		return nil, fmt.Errorf("release %s has no asset %s", rel.TagName, name)
+	}
+	sumsAsset := rel.FindAsset(ChecksumsAssetName)
+	if sumsAsset == nil {
+		return nil, fmt.Errorf("release %s has no %s; refusing unverified update", rel.TagName, ChecksumsAssetName)
+	}
+	sigAsset := rel.FindAsset(SignatureAssetName)
+	if sigAsset == nil {
+		return nil, fmt.Errorf("release %s has no %s; refusing unverified update", rel.TagName, SignatureAssetName)
+	}
+
+	sums, err := c.get(ctx, sumsAsset.DownloadURL, 1<<20)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := c.get(ctx, sigAsset.DownloadURL, 1<<10)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifySignature(c.PublicKey, sums, sig); err != nil {
+		return nil, fmt.Errorf("%s: %w", ChecksumsAssetName, err)
+	}
+	want, ok := ParseChecksums(sums)[name]
+	if !ok {
+		return nil, fmt.Errorf("%s does not list %s", ChecksumsAssetName, name)
+	}
+
+	data, err := c.get(ctx, asset.DownloadURL, maxAssetSize)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyChecksum(data, want); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return data, nil
+}
+
+func (c *Client) get(ctx context.Context, url string, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", BinaryName)
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request %s: unexpected status %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", url, err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("response from %s exceeds %d bytes", url, limit)
+	}
+	return data, nil
+}
+
+// AssetName returns the release asset name for a platform, matching `make build-all`.
+func AssetName(goos, goarch string) string {
+	name := fmt.Sprintf("%s-%s-%s", BinaryName, goos, goarch)
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// CurrentAssetName returns the asset name for the running platform.
+func CurrentAssetName() string {
+	return AssetName(runtime.GOOS, runtime.GOARCH)
+}
+
+// ParseChecksums parses sha256sum output ("<hex>  <name>" per line) into name → hex.
+func ParseChecksums(data []byte) map[string]string {
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		// sha256sum marks binary mode with a leading '*'.
+		sums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+	}
+	return sums
+}
+
+// VerifyChecksum checks that the SHA-256 of data equals wantHex.
+func VerifyChecksum(data []byte, wantHex string) error {
+	sum := sha256.Sum256(data)
+	got := hex.EncodeToString(sum[:])
+	if !strings.EqualFold(got, wantHex) {
+		return fmt.Errorf("checksum mismatch: got %s, want %s", got, wantHex)
+	}
+	return nil
+}
+
+// VerifySignature checks that sig, raw or base64-encoded, is the Ed25519 signature of
+// data by key.
+func VerifySignature(key ed25519.PublicKey, data, sig []byte) error {
+	if len(key) != ed25519.PublicKeySize {
+		return errors.New("invalid release signing key")
+	}
+	if len(sig) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+		if err != nil {
+			return errors.New("malformed signature")
+		}
+		sig = decoded
+	}
+	if !ed25519.Verify(key, data, sig) {
+		return errors.New("signature does not match the release signing key")
+	}
+	return nil
+}
+
+// CompareVersions compares two versions like "v1.2.3" (a leading "v" and any
+// "-suffix" such as git describe output are ignored). It returns -1, 0 or 1.
+// Non-numeric components compare as 0, so "dev" is older than any release.
+func CompareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := 0; i < 3; i++ {
+		switch {
+		case pa[i] < pb[i]:
+			return -1
+		case pa[i] > pb[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(v string) [3]int {
+	var parts [3]int
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	for i, s := range strings.SplitN(v, ".", 3) {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			continue
+		}
+		parts[i] = n
+	}
+	return parts
+}
+
+// ReplaceExecutable atomically replaces the binary at path with data.
+// The new file is written next to the target and renamed over it, so a failed
+// update never leaves a partially written binary. On Windows the running binary
+// cannot be overwritten, so it is first moved aside to path+".old".
+func ReplaceExecutable(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("stat executable: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".new-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op after a successful rename
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, info.Mode().Perm()|0111); err != nil {
+		return fmt.Errorf("chmod temp file: %w", err)
+	}
+
+	if runtime.GOOS == "windows" {
+		old := path + ".old"
+		_ = os.Remove(old)
+		if err := os.Rename(path, old); err != nil {
+			return fmt.Errorf("move current executable aside: %w", err)
+		}
+		if err := os.Rename(tmpPath, path); err != nil {
+			_ = os.Rename(old, path)
+			return fmt.Errorf("install new executable: %w", err)
+		}
+		return nil
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("install new executable: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package update
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.2.3", "v1.2.3", 0},
+		{"1.2.3", "v1.2.3", 0},
+		{"v1.2.3", "v1.3.0", -1},
+		{"v2.0.0", "v1.9.9", 1},
+		{"v1.10.0", "v1.9.0", 1},
+		{"v1.2.3-4-gabcdef", "v1.2.3", 0},
+		{"dev", "v0.0.1", -1},
+		{"v1.2", "v1.2.0", 0},
+	}
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestAssetName(t *testing.T) {
+	if got := AssetName("linux", "amd64"); got != "agent-orchestrator-linux-amd64" {
+		t.Errorf("AssetName(linux, amd64) = %s", got)
+	}
+	if got := AssetName("windows", "amd64"); got != "agent-orchestrator-windows-amd64.exe" {
+		t.Errorf("AssetName(windows, amd64) = %s", got)
+	}
+}
+
+func TestParseChecksumsAndVerify(t *testing.T) {
+	data := []byte("binary")
+	sum := sha256.Sum256(data)
+	hexSum := hex.EncodeToString(sum[:])
+
+	sums := ParseChecksums([]byte(hexSum + "  agent-orchestrator-linux-amd64\n" +
+		strings.ToUpper(hexSum) + " *agent-orchestrator-darwin-arm64\n" +
+		"garbage line with too many fields\n"))
+	if sums["agent-orchestrator-linux-amd64"] != hexSum {
+		t.Errorf("linux checksum = %q", sums["agent-orchestrator-linux-amd64"])
+	}
+	if sums["agent-orchestrator-darwin-arm64"] != hexSum {
+		t.Errorf("binary-mode entry should be parsed and lowercased, got %q", sums["agent-orchestrator-darwin-arm64"])
+	}
+	if len(sums) != 2 {
+		t.Errorf("ParseChecksums() returned %d entries, want 2", len(sums))
+	}
+
+	if err := VerifyChecksum(data, hexSum); err != nil {
+		t.Errorf("VerifyChecksum() error = %v", err)
+	}
+	if err := VerifyChecksum([]byte("tampered"), hexSum); err == nil {
+		t.Error("VerifyChecksum() should fail for tampered data")
+	}
+}
+
+// newReleaseServer serves a release with the current platform's binary, a checksums
+// file and its signature by key (no signature asset when key is nil).
+func newReleaseServer(t *testing.T, tag string, binary []byte, checksum string, key ed25519.PrivateKey) *httptest.Server {
+	t.Helper()
+	sums := []byte(checksum + "  " + CurrentAssetName() + "\n")
+	var srv *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/latest", func(w http.ResponseWriter, r *http.Request) {
+		rel := Release{
+			TagName: tag,
+			Assets: []Asset{
+				{Name: CurrentAssetName(), DownloadURL: srv.URL + "/bin"},
+				{Name: ChecksumsAssetName, DownloadURL: srv.URL + "/sums"},
+			},
+		}
+		if key != nil {
+			rel.Assets = append(rel.Assets, Asset{Name: SignatureAssetName, DownloadURL: srv.URL + "/sig"})
+		}
+		_ = json.NewEncoder(w).Encode(rel)
+	})
+	mux.HandleFunc("/bin", func(w http.ResponseWriter, r *http.Request) { w.Write(binary) })
+	mux.HandleFunc("/sums", func(w http.ResponseWriter, r *http.Request) { w.Write(sums) })
+	mux.HandleFunc("/sig", func(w http.ResponseWriter, r *http.Request) { w.Write(ed25519.Sign(key, sums)) })
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newSigningKey(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pub, priv
+}
+
+func TestClient_LatestAndDownload(t *testing.T) {
+	pub, priv := newSigningKey(t)
+	binary := []byte("new binary contents")
+	sum := sha256.Sum256(binary)
+	srv := newReleaseServer(t, "v9.9.9", binary, hex.EncodeToString(sum[:]), priv)
+
+	client := NewClient(srv.URL + "/latest")
+	client.PublicKey = pub
+	rel, err := client.Latest(context.Background())
+	if err != nil {
+		t.Fatalf("Latest() error = %v", err)
+	}
+	if rel.TagName != "v9.9.9" {
+		t.Errorf("TagName = %s, want v9.9.9", rel.TagName)
+	}
+
+	data, err := client.Download(context.Background(), rel, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+	if string(data) != string(binary) {
+		t.Errorf("Download() = %q, want %q", data, binary)
+	}
+}
+
+func TestClient_Download_Refused(t *testing.T) {
+	pub, priv := newSigningKey(t)
+	_, other := newSigningKey(t)
+	binary := []byte("new binary contents")
+	sum := sha256.Sum256(binary)
+	tests := []struct {
+		name     string
+		binary   []byte
+		key      ed25519.PrivateKey // signs checksums.txt
+		clientPK ed25519.PublicKey
+		wantErr  string
+	}{
+		{"checksum mismatch", []byte("tampered"), priv, pub, "checksum mismatch"},
+		{"signed by another key", binary, other, pub, "signature does not match"},
+		{"no signature", binary, nil, pub, SignatureAssetName},
+		{"no pinned key", binary, priv, nil, ErrNoPublicKey.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newReleaseServer(t, "v9.9.9", tt.binary, hex.EncodeToString(sum[:]), tt.key)
+			client := NewClient(srv.URL + "/latest")
+			client.PublicKey = tt.clientPK
+			rel, err := client.Latest(context.Background())
+			if err != nil {
+				t.Fatalf("Latest() error = %v", err)
+			}
+			_, err = client.Download(context.Background(), rel, runtime.GOOS, runtime.GOARCH)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Download() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_Download_MissingChecksums(t *testing.T) {
+	pub, _ := newSigningKey(t)
+	rel := &Release{TagName: "v1.0.0", Assets: []Asset{{Name: CurrentAssetName(), DownloadURL: "http://invalid"}}}
+	client := NewClient("")
+	client.PublicKey = pub
+	if _, err := client.Download(context.Background(), rel, runtime.GOOS, runtime.GOARCH); err == nil {
+		t.Error("Download() should refuse a release without checksums.txt")
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	pub, priv := newSigningKey(t)
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParsePublicKey(base64.StdEncoding.EncodeToString(der))
+	if err != nil || !got.Equal(pub) {
+		t.Fatalf("ParsePublicKey() = %x, %v; want %x", got, err, pub)
+	}
+	if _, err := ParsePublicKey("not base64!"); err == nil {
+		t.Error("ParsePublicKey() should reject a malformed key")
+	}
+
+	data := []byte("checksums")
+	sig := ed25519.Sign(priv, data)
+	if err := VerifySignature(got, data, []byte(base64.StdEncoding.EncodeToString(sig)+"\n")); err != nil {
+		t.Errorf("VerifySignature() of a base64 signature error = %v", err)
+	}
+}
+
+func TestClient_Latest_HTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	if _, err := NewClient(srv.URL).Latest(context.Background()); err == nil {
+		t.Error("Latest() should fail on 404")
+	}
+}
+
+func TestReplaceExecutable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent-orchestrator")
+	if err := os.WriteFile(path, []byte("old"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ReplaceExecutable(path, []byte("new")); err != nil {
+		t.Fatalf("ReplaceExecutable() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "new" {
+		t.Errorf("executable content = %q, want new", got)
+	}
+	info, _ := os.Stat(path)
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0100 == 0 {
+		t.Errorf("replaced executable mode = %o, want executable", info.Mode().Perm())
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	for _, e := range entries {
+		if strings.Contains(e.Name(), ".new-") {
+			t.Errorf("temp file %s should not be left behind", e.Name())
+		}
+	}
+}
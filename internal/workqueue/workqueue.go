@@ -0,0 +1,182 @@
+// Package workqueue records work requests made while a background (detach) worker is
+// running, so they run after the current batch instead of being refused.
+//
+// The queue is a small JSON file (TicketsDir/work-queue.json). The foreground CLI
+// appends requests; the detach worker takes them in FIFO order once its batch is done.
+// Writes go through a temp file and rename, and every read-modify-write holds a file
+// lock (work-queue.json.lock) shared by all processes.
+package workqueue
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/anthropic/agent-orchestrator/internal/filelock"
+)
+
+// Request is one queued work invocation.
+type Request struct {
+	ID string `json:"id"`
+	// TicketID is the single ticket to process; empty processes all pending tickets.
+	TicketID string `json:"ticket_id,omitempty"`
+	// Labels restrict processing to tickets carrying all of them (work --label).
+	Labels []string `json:"labels,omitempty"`
+	// Milestones restrict processing to tickets planned from one of them (work --milestone).
+	Milestones []string `json:"milestones,omitempty"`
+	// Filter restricts processing to tickets matching a filter expression (work --filter).
+	Filter string `json:"filter,omitempty"`
+	// ResumeFromPR reopens and processes the tickets linked to a pull request (work --resume-from-pr).
+	ResumeFromPR string `json:"resume_from_pr,omitempty"`
+	// Parallel overrides max_parallel when positive (work --parallel).
+	Parallel int `json:"parallel,omitempty"`
+	// Lenient only warns about an unmet definition of done (work --lenient).
+	Lenient bool `json:"lenient,omitempty"`
+	// MaxCost is the cost ceiling of the run in USD (work --max-cost).
+	MaxCost  float64   `json:"max_cost,omitempty"`
+	QueuedAt time.Time `json:"queued_at"`
+}
+
+// Queue is the work queue stored at a file.
+type Queue struct {
+	path string
+}
+
+// New returns the queue stored at path. The file is created on the first Push.
+func New(path string) *Queue {
+	return &Queue{path: path}
+}
+
+// List returns the queued requests, oldest first.
+func (q *Queue) List() ([]Request, error) {
+	unlock, err := q.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return q.load()
+}
+
+// Push appends a request, assigning its ID and QueuedAt when unset, and returns it.
+func (q *Queue) Push(r Request) (Request, error) {
+	unlock, err := q.lock()
+	if err != nil {
+		return r, err
+	}
+	defer unlock()
+	reqs, err := q.load()
+	if err != nil {
+		return r, err
+	}
+	if r.QueuedAt.IsZero() {
+		r.QueuedAt = time.Now()
+	}
+	if r.ID == "" {
+		r.ID = newID(r.QueuedAt)
+	}
+	return r, q.save(append(reqs, r))
+}
+
+// Pop removes and returns the oldest request, or nil when the queue is empty.
+func (q *Queue) Pop() (*Request, error) {
+	unlock, err := q.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	reqs, err := q.load()
+	if err != nil || len(reqs) == 0 {
+		return nil, err
+	}
+	if err := q.save(reqs[1:]); err != nil {
+		return nil, err
+	}
+	return &reqs[0], nil
+}
+
+// Remove deletes the request with the given ID. Reports whether it was queued.
+func (q *Queue) Remove(id string) (bool, error) {
+	unlock, err := q.lock()
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+	reqs, err := q.load()
+	if err != nil {
+		return false, err
+	}
+	for i, r := range reqs {
+		if r.ID == id {
+			return true, q.save(append(reqs[:i], reqs[i+1:]...))
+		}
+	}
+	return false, nil
+}
+
+// lock takes the file lock serializing access to the queue across processes.
+func (q *Queue) lock() (unlock func(), err error) {
+	return filelock.Lock(q.path+".lock", 0)
+}
+
+// newID returns a request ID: the queue time in milliseconds and a random suffix, so
+// requests queued in the same millisecond by different processes do not collide.
+func newID(at time.Time) string {
+	suffix := make([]byte, 3)
+	_, _ = rand.Read(suffix)
+	return fmt.Sprintf("Q-%d-%s", at.UnixMilli(), hex.EncodeToString(suffix))
+}
+
+func (q *Queue) load() ([]Request, error) {
+	data, err := os.ReadFile(q.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read work queue: %w", err)
+	}
+	var reqs []Request
+	if err := json.Unmarshal(data, &reqs); err != nil {
+		return nil, fmt.Errorf("failed to parse work queue: %w", err)
+	}
+	return reqs, nil
+}
+
+// save writes the queue atomically; an empty queue removes the file.
+func (q *Queue) save(reqs []Request) error {
+	if len(reqs) == 0 {
+		if err := os.Remove(q.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to write work queue: %w", err)
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(reqs, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(q.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create work queue directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".work-queue-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write work queue: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write work queue: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write work queue: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), q.path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write work queue: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package workqueue
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestQueue_PushPopFIFO(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "work-queue.json")
+	q := New(path)
+
+	if r, err := q.Pop(); err != nil || r != nil {
+		t.Fatalf("Pop() on empty queue = %v, %v; want nil, nil", r, err)
+	}
+	first, err := q.Push(Request{TicketID: "T-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.ID == "" || first.QueuedAt.IsZero() {
+		t.Errorf("Push() should assign ID and QueuedAt, got %+v", first)
+	}
+	if _, err := q.Push(Request{ID: "Q-2", Labels: []string{"backend"}, Parallel: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A second Queue on the same file sees the same requests.
+	reqs, err := New(path).List()
+	if err != nil || len(reqs) != 2 || reqs[0].TicketID != "T-1" || reqs[1].ID != "Q-2" {
+		t.Fatalf("List() = %+v, %v", reqs, err)
+	}
+
+	r, err := q.Pop()
+	if err != nil || r == nil || r.TicketID != "T-1" {
+		t.Fatalf("Pop() = %+v, %v; want T-1 first", r, err)
+	}
+	r, _ = q.Pop()
+	if r == nil || r.ID != "Q-2" || r.Parallel != 2 || len(r.Labels) != 1 {
+		t.Fatalf("Pop() = %+v; want Q-2", r)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("queue file should be removed once empty, stat err = %v", err)
+	}
+}
+
+func TestQueue_Remove(t *testing.T) {
+	q := New(filepath.Join(t.TempDir(), "work-queue.json"))
+	a, _ := q.Push(Request{ID: "Q-1"})
+	if _, err := q.Push(Request{ID: "Q-2"}); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := q.Remove(a.ID); !ok || err != nil {
+		t.Fatalf("Remove(%s) = %v, %v", a.ID, ok, err)
+	}
+	if ok, _ := q.Remove("Q-missing"); ok {
+		t.Error("Remove() of an unknown ID should report false")
+	}
+	if reqs, _ := q.List(); len(reqs) != 1 || reqs[0].ID != "Q-2" {
+		t.Errorf("List() after Remove = %+v", reqs)
+	}
+}
+
+func TestQueue_CorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "work-queue.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(path).List(); err == nil {
+		t.Error("List() should fail on a corrupt queue file")
+	}
+}
+
+func TestQueue_ConcurrentPushes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "work-queue.json")
+	at := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Separate Queues, as separate processes have, queuing in the same millisecond.
+			if _, err := New(path).Push(Request{QueuedAt: at}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	reqs, err := New(path).List()
+	if err != nil || len(reqs) != 20 {
+		t.Fatalf("List() after concurrent pushes = %d requests, %v; want 20", len(reqs), err)
+	}
+	seen := make(map[string]bool)
+	for _, r := range reqs {
+		if seen[r.ID] {
+			t.Errorf("duplicate request ID %s", r.ID)
+		}
+		seen[r.ID] = true
+	}
+}